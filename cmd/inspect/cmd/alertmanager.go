@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/alertmanager"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// collectAlertmanagerAlerts fetches the alerts firing in Alertmanager for the combined reports.
+// Alertmanager is queried once by the coordinating instance, as its alerts are not tied to the
+// inspected hosts. A failed request only warns: the reports are written without the section.
+func collectAlertmanagerAlerts(ctx context.Context, cfg *config.Config, logger zerolog.Logger) []*model.AlertmanagerAlert {
+	client := alertmanager.NewClient(&cfg.Datasources.Alertmanager, &cfg.HTTP.Retry, logger)
+	alerts, err := client.GetActiveAlerts(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to fetch alerts from Alertmanager")
+		fmt.Printf("⚠️  查询 Alertmanager 告警失败，报告不含该章节: %v\n", err)
+		return nil
+	}
+
+	silenced := 0
+	for _, a := range alerts {
+		if a.Silenced() {
+			silenced++
+		}
+	}
+	fmt.Printf("🔔 Alertmanager: %d 条告警正在触发（已静默 %d 条）\n", len(alerts), silenced)
+	return alerts
+}
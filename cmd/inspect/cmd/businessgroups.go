@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// syncBusinessGroups fetches the business group tree from N9E, checks that every value of
+// inspection.host_filter.business_groups is a group, a level of the tree or a busigroup label
+// value, and expands the filter to the label values of the subtrees, so filtering on a level
+// (e.g. "电商") inspects all of its groups. A failed request only warns and returns a nil tree:
+// the filter is then used as configured and hosts are grouped by the busigroup label.
+func syncBusinessGroups(ctx context.Context, cfg *config.Config, logger zerolog.Logger) (*model.BusinessGroupTree, error) {
+	client := n9e.NewClient(&cfg.Datasources.N9E, &cfg.HTTP.Retry, logger)
+	groups, err := client.GetBusinessGroups(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to sync business groups from N9E")
+		fmt.Printf("⚠️  同步业务组失败，按指标 busigroup 标签分组: %v\n", err)
+		return nil, nil
+	}
+	tree := model.NewBusinessGroupTree(groups)
+
+	filter := cfg.Inspection.HostFilter.BusinessGroups
+	if len(filter) == 0 {
+		fmt.Printf("🌳 业务组: 已同步 %d 个\n", tree.Len())
+		return tree, nil
+	}
+	if unknown := tree.Unknown(filter); len(unknown) > 0 {
+		return nil, fmt.Errorf("业务组不存在: %s（夜莺共 %d 个业务组，请检查 inspection.host_filter.business_groups 或 --business-groups）",
+			strings.Join(unknown, ", "), tree.Len())
+	}
+
+	expanded := tree.Expand(filter)
+	cfg.Inspection.HostFilter.BusinessGroups = expanded
+	fmt.Printf("🌳 业务组: 已同步 %d 个，过滤 %s（含下级共 %d 个）\n", tree.Len(), strings.Join(filter, ", "), len(expanded))
+	logger.Debug().Strs("business_groups", filter).Strs("expanded", expanded).Msg("business group filter expanded")
+	return tree, nil
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// checkpointMaxAge is the age beyond which a checkpoint is not resumed: its results are too
+// old to be reported as the current state.
+const checkpointMaxAge = 24 * time.Hour
+
+// moduleRun is an inspection module and whether this run executes it.
+type moduleRun struct {
+	key string
+	run *bool
+}
+
+// inspectionCheckpoint saves the result of each module as it completes into the output
+// directory, and the query results of each host metric as it is collected, so that --resume
+// can skip the modules and host metrics an interrupted run already collected.
+type inspectionCheckpoint struct {
+	path       string
+	mu         sync.Mutex // Guards checkpoint: the host metrics are saved concurrently
+	checkpoint *json.Checkpoint
+	planned    []string       // Modules this run has to complete, resumed ones included
+	timezone   *time.Location // Time zone of the start time printed on resume
+	logger     zerolog.Logger
+}
+
+// newInspectionCheckpoint creates the checkpoint of an inspection of configPath over scope,
+// running the modules whose run flag is set.
+func newInspectionCheckpoint(outputPath, configPath string, scope json.CheckpointScope, modules []moduleRun, timezone string, logger zerolog.Logger) *inspectionCheckpoint {
+	if abs, err := filepath.Abs(configPath); err == nil {
+		configPath = abs
+	}
+	c := &inspectionCheckpoint{
+		path:   filepath.Join(outputPath, json.CheckpointFilename),
+		logger: logger,
+	}
+	c.timezone, _ = time.LoadLocation("Asia/Shanghai")
+	if tz, err := time.LoadLocation(timezone); err == nil {
+		c.timezone = tz
+	}
+	for _, m := range modules {
+		if *m.run {
+			c.planned = append(c.planned, m.key)
+		}
+	}
+	scope.Modules = c.planned
+	c.checkpoint = json.NewCheckpoint(configPath, scope, time.Now())
+	return c
+}
+
+// resume loads the checkpoint of an interrupted inspection and clears the run flag of the
+// modules it completed. Returns their results, or nil when nothing can be resumed.
+func (c *inspectionCheckpoint) resume(modules []moduleRun) *json.Report {
+	saved, err := json.ReadCheckpoint(c.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		fmt.Printf("♻️  未找到断点 %s，执行完整巡检\n", c.path)
+		return nil
+	case err != nil:
+		c.logger.Warn().Err(err).Str("path", c.path).Msg("invalid checkpoint, running the full inspection")
+		fmt.Printf("⚠️  断点文件无效，执行完整巡检: %v\n", err)
+		return nil
+	case saved.ConfigPath != c.checkpoint.ConfigPath:
+		fmt.Printf("⚠️  断点来自其他配置文件 %s，执行完整巡检\n", saved.ConfigPath)
+		return nil
+	case time.Since(saved.StartedAt) > checkpointMaxAge:
+		fmt.Printf("⚠️  断点已超过 %s（%s 开始的巡检），执行完整巡检\n", checkpointMaxAge, saved.StartedAt.In(c.timezone).Format("2006-01-02 15:04"))
+		return nil
+	}
+	if changes := scopeChanges(saved.Scope, c.checkpoint.Scope); len(changes) > 0 {
+		fmt.Printf("⚠️  断点的巡检范围与本次不同（%s），执行完整巡检\n", strings.Join(changes, "、"))
+		return nil
+	}
+
+	report, restored, err := saved.Restore(c.planned)
+	if err != nil {
+		c.logger.Warn().Err(err).Str("path", c.path).Msg("invalid checkpoint, running the full inspection")
+		fmt.Printf("⚠️  断点文件无效，执行完整巡检: %v\n", err)
+		return nil
+	}
+	hostMetrics := saved.PartialCount(json.ModuleHost)
+	if len(restored) == 0 && hostMetrics == 0 {
+		fmt.Println("♻️  断点中没有本次巡检的已完成模块或已采集指标，执行完整巡检")
+		return nil
+	}
+
+	names := make([]string, 0, len(restored))
+	for _, m := range modules {
+		if slices.Contains(restored, m.key) {
+			*m.run = false
+			names = append(names, json.ModuleName(m.key))
+		}
+	}
+	c.checkpoint = saved // Modules completed from now on are added to the interrupted run
+	c.logger.Info().Strs("modules", restored).Int("host_metrics", hostMetrics).Time("started_at", saved.StartedAt).Msg("resuming inspection from checkpoint")
+	fmt.Printf("♻️  从断点恢复 %s 开始的巡检", saved.StartedAt.In(c.timezone).Format("2006-01-02 15:04"))
+	if len(names) > 0 {
+		fmt.Printf("，跳过已完成的模块: %s", strings.Join(names, "、"))
+	}
+	if hostMetrics > 0 {
+		fmt.Printf("，%s", hostMetricsResumed(hostMetrics))
+	}
+	fmt.Println()
+	return report
+}
+
+// hostMetricsResumed tells that the host metrics saved in the checkpoint are not queried again.
+func hostMetricsResumed(count int) string {
+	return fmt.Sprintf("主机巡检已采集的 %d 个指标不再查询", count)
+}
+
+// scopeChanges returns what differs between the scope of a checkpoint and the scope of this
+// run. The business groups and host exclusions are compared regardless of their order.
+func scopeChanges(saved, current json.CheckpointScope) []string {
+	var changes []string
+	if !slices.Equal(saved.Modules, current.Modules) {
+		changes = append(changes, "巡检模块")
+	}
+	if saved.Preset != current.Preset {
+		changes = append(changes, "预设")
+	}
+	if !sameElements(saved.BusinessGroups, current.BusinessGroups) {
+		changes = append(changes, "业务组")
+	}
+	if !sameElements(saved.ExcludeHosts, current.ExcludeHosts) {
+		changes = append(changes, "排除主机")
+	}
+	if saved.RangeWindow != current.RangeWindow || saved.RangeStart != current.RangeStart || saved.RangeEnd != current.RangeEnd {
+		changes = append(changes, "聚合窗口")
+	}
+	return changes
+}
+
+// sameElements reports whether a and b hold the same values, in any order.
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// discard removes the checkpoint of a previous inspection, which a new one replaces.
+func (c *inspectionCheckpoint) discard() {
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		c.logger.Warn().Err(err).Str("path", c.path).Msg("failed to remove previous checkpoint")
+	}
+}
+
+// complete saves the result of a completed module. A failure is reported but does not stop
+// the inspection.
+func (c *inspectionCheckpoint) complete(module string, result any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.checkpoint.Complete(module, result)
+	if err == nil {
+		err = c.checkpoint.Save(c.path)
+	}
+	if err != nil {
+		c.logger.Warn().Err(err).Str("module", module).Str("path", c.path).Msg("failed to save checkpoint")
+		fmt.Printf("⚠️  保存断点失败: %v\n", err)
+	}
+}
+
+// finish removes the checkpoint once every planned module completed, or tells how to resume
+// the modules that failed.
+func (c *inspectionCheckpoint) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.checkpoint.Modules) == 0 && len(c.checkpoint.Partial) == 0 {
+		return // Nothing was saved to resume from
+	}
+	var pending []string
+	for _, module := range c.planned {
+		if !c.checkpoint.Done(module) {
+			pending = append(pending, json.ModuleName(module))
+		}
+	}
+	if len(pending) > 0 {
+		fmt.Printf("\n♻️  未完成的模块: %s，断点已保存，使用 --resume 重新执行时只巡检这些模块", strings.Join(pending, "、"))
+		if count := c.checkpoint.PartialCount(json.ModuleHost); count > 0 {
+			fmt.Printf("（%s）", hostMetricsResumed(count))
+		}
+		fmt.Println()
+		return
+	}
+	c.discard()
+}
+
+// hostMetrics returns the checkpoint of the host metrics collection: the query results of
+// each metric are saved as soon as it is collected, and those of an interrupted run are
+// taken instead of querying the metrics again.
+func (c *inspectionCheckpoint) hostMetrics() service.MetricCheckpoint {
+	return hostMetricCheckpoint{c}
+}
+
+// hostMetricCheckpoint keeps the query results of the host metrics in the partial results of
+// the host module.
+type hostMetricCheckpoint struct {
+	*inspectionCheckpoint
+}
+
+// checkpointMetric is the saved query results of a host metric.
+type checkpointMetric struct {
+	Query   string           `json:"query"` // Results of another query are not resumed
+	Results []vm.QueryResult `json:"results"`
+}
+
+// Results returns the saved query results of a metric collected with the same query.
+func (c hostMetricCheckpoint) Results(metric *model.MetricDefinition) ([]vm.QueryResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var saved checkpointMetric
+	ok, err := c.checkpoint.LoadPartial(json.ModuleHost, metric.Name, &saved)
+	if err != nil {
+		c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("invalid checkpoint metric, querying it again")
+		return nil, false
+	}
+	if !ok || saved.Query != metric.Query {
+		return nil, false
+	}
+	return saved.Results, true
+}
+
+// Save saves the query results of a collected metric. A failure is logged but does not stop
+// the collection; the metric is queried again on resume.
+func (c hostMetricCheckpoint) Save(metric *model.MetricDefinition, results []vm.QueryResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.checkpoint.SavePartial(json.ModuleHost, metric.Name, checkpointMetric{Query: metric.Query, Results: results})
+	if err == nil {
+		err = c.checkpoint.Save(c.path)
+	}
+	if err != nil {
+		c.logger.Warn().Err(err).Str("metric", metric.Name).Str("path", c.path).Msg("failed to save checkpoint")
+	}
+}
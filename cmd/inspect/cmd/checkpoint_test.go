@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// testModuleRuns returns the host, MySQL and Redis modules, all to run.
+func testModuleRuns() (host, mysql, redis *bool, modules []moduleRun) {
+	host, mysql, redis = new(bool), new(bool), new(bool)
+	*host, *mysql, *redis = true, true, true
+	return host, mysql, redis, []moduleRun{{json.ModuleHost, host}, {json.ModuleMySQL, mysql}, {json.ModuleRedis, redis}}
+}
+
+func TestInspectionCheckpoint_Resume(t *testing.T) {
+	dir := t.TempDir()
+	scope := json.CheckpointScope{BusinessGroups: []string{"业务组A", "业务组B"}, RangeWindow: 24 * time.Hour}
+
+	// An interrupted run completing host only
+	_, _, _, modules := testModuleRuns()
+	interrupted := newInspectionCheckpoint(dir, "config.yaml", scope, modules, "Asia/Shanghai", zerolog.Nop())
+	interrupted.complete(json.ModuleHost, &model.InspectionResult{Summary: &model.InspectionSummary{TotalHosts: 3}})
+	interrupted.finish()
+	if _, err := os.Stat(filepath.Join(dir, json.CheckpointFilename)); err != nil {
+		t.Fatalf("checkpoint should be kept while modules are pending: %v", err)
+	}
+
+	// The same run resumed, with the business groups in another order
+	host, mysql, redis, modules := testModuleRuns()
+	scope.BusinessGroups = []string{"业务组B", "业务组A"}
+	resumed := newInspectionCheckpoint(dir, "config.yaml", scope, modules, "Asia/Shanghai", zerolog.Nop())
+	report := resumed.resume(modules)
+	if report == nil || report.Host == nil || report.Host.Summary.TotalHosts != 3 {
+		t.Fatalf("resume() = %+v, want the saved host result", report)
+	}
+	if *host || !*mysql || !*redis {
+		t.Errorf("run flags after resume = host %v, mysql %v, redis %v, want false, true, true", *host, *mysql, *redis)
+	}
+
+	// The checkpoint is removed once every module completed, resumed ones included
+	resumed.complete(json.ModuleMySQL, &model.MySQLInspectionResults{})
+	resumed.complete(json.ModuleRedis, &model.RedisInspectionResults{})
+	resumed.finish()
+	if _, err := os.Stat(filepath.Join(dir, json.CheckpointFilename)); !os.IsNotExist(err) {
+		t.Errorf("checkpoint should be removed once every module completed, stat error = %v", err)
+	}
+}
+
+func TestInspectionCheckpoint_ResumeHostMetrics(t *testing.T) {
+	dir := t.TempDir()
+	cpu := &model.MetricDefinition{Name: "cpu_usage", Query: "cpu_usage_active"}
+	memory := &model.MetricDefinition{Name: "memory_usage", Query: "100 - mem_available_percent"}
+	results := []vm.QueryResult{{Ident: "host1", Value: 45.5, Labels: map[string]string{"ident": "host1"}}}
+
+	// An interrupted run collecting one host metric
+	_, _, _, modules := testModuleRuns()
+	interrupted := newInspectionCheckpoint(dir, "config.yaml", json.CheckpointScope{}, modules, "Asia/Shanghai", zerolog.Nop())
+	interrupted.hostMetrics().Save(cpu, results)
+
+	// The host module still runs, taking the saved metric
+	host, _, _, modules := testModuleRuns()
+	resumed := newInspectionCheckpoint(dir, "config.yaml", json.CheckpointScope{}, modules, "Asia/Shanghai", zerolog.Nop())
+	if report := resumed.resume(modules); report == nil || report.Host != nil {
+		t.Fatalf("resume() = %+v, want an empty report", report)
+	}
+	if !*host {
+		t.Error("resume() should keep the host run flag while host metrics are pending")
+	}
+	checkpoint := resumed.hostMetrics()
+	if got, ok := checkpoint.Results(cpu); !ok || !reflect.DeepEqual(got, results) {
+		t.Errorf("Results(cpu_usage) = %v, %v, want the saved results", got, ok)
+	}
+	if _, ok := checkpoint.Results(memory); ok {
+		t.Error("Results(memory_usage) should report a metric that was not collected")
+	}
+	changed := *cpu
+	changed.Query = "avg(cpu_usage_active)"
+	if _, ok := checkpoint.Results(&changed); ok {
+		t.Error("Results() should not resume the results of another query")
+	}
+
+	// The host metrics are dropped once the host module completes
+	resumed.complete(json.ModuleHost, &model.InspectionResult{})
+	if _, ok := checkpoint.Results(cpu); ok {
+		t.Error("Results() should be empty once the host module completed")
+	}
+}
+
+func TestInspectionCheckpoint_ResumeRefused(t *testing.T) {
+	scope := json.CheckpointScope{BusinessGroups: []string{"业务组A"}, RangeWindow: 24 * time.Hour}
+
+	tests := []struct {
+		name       string
+		configPath string
+		scope      func(s *json.CheckpointScope)
+		modules    func(mysql *bool)
+		age        time.Duration
+	}{
+		{"other config file", "other.yaml", nil, nil, 0},
+		{"expired", "config.yaml", nil, nil, checkpointMaxAge + time.Minute},
+		{"other modules", "config.yaml", nil, func(mysql *bool) { *mysql = false }, 0},
+		{"other preset", "config.yaml", func(s *json.CheckpointScope) { s.Preset = "quick" }, nil, 0},
+		{"other business groups", "config.yaml", func(s *json.CheckpointScope) { s.BusinessGroups = []string{"业务组B"} }, nil, 0},
+		{"other excluded hosts", "config.yaml", func(s *json.CheckpointScope) { s.ExcludeHosts = []string{"test-*"} }, nil, 0},
+		{"other time window", "config.yaml", func(s *json.CheckpointScope) { s.RangeStart = "2026-10-01 00:00" }, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_, _, _, modules := testModuleRuns()
+			interrupted := newInspectionCheckpoint(dir, "config.yaml", scope, modules, "Asia/Shanghai", zerolog.Nop())
+			interrupted.checkpoint.StartedAt = time.Now().Add(-tt.age)
+			interrupted.complete(json.ModuleHost, &model.InspectionResult{})
+
+			current := scope
+			if tt.scope != nil {
+				tt.scope(&current)
+			}
+			host, mysql, _, modules := testModuleRuns()
+			if tt.modules != nil {
+				tt.modules(mysql)
+			}
+			c := newInspectionCheckpoint(dir, tt.configPath, current, modules, "Asia/Shanghai", zerolog.Nop())
+			if report := c.resume(modules); report != nil {
+				t.Errorf("resume() = %+v, want nil", report)
+			}
+			if !*host {
+				t.Error("resume() should keep the host run flag when the checkpoint is refused")
+			}
+		})
+	}
+}
+
+func TestInspectionCheckpoint_ResumeWithoutCheckpoint(t *testing.T) {
+	host, _, _, modules := testModuleRuns()
+	c := newInspectionCheckpoint(t.TempDir(), "config.yaml", json.CheckpointScope{}, modules, "Asia/Shanghai", zerolog.Nop())
+	if report := c.resume(modules); report != nil || !*host {
+		t.Errorf("resume() without checkpoint = %+v, host run %v, want nil and true", report, *host)
+	}
+}
+
+func TestInspectionCheckpoint_Discard(t *testing.T) {
+	dir := t.TempDir()
+	_, _, _, modules := testModuleRuns()
+	c := newInspectionCheckpoint(dir, "config.yaml", json.CheckpointScope{}, modules, "Asia/Shanghai", zerolog.Nop())
+	c.complete(json.ModuleHost, &model.InspectionResult{})
+	c.discard()
+	if _, err := os.Stat(filepath.Join(dir, json.CheckpointFilename)); !os.IsNotExist(err) {
+		t.Errorf("discard() should remove the checkpoint, stat error = %v", err)
+	}
+	c.discard() // No checkpoint left: nothing to do
+}
+
+func TestScopeChanges(t *testing.T) {
+	saved := json.CheckpointScope{
+		Modules:        []string{json.ModuleHost, json.ModuleMySQL},
+		BusinessGroups: []string{"业务组A", "业务组B"},
+		ExcludeHosts:   []string{"test-*"},
+		RangeWindow:    24 * time.Hour,
+	}
+
+	same := saved
+	same.BusinessGroups = []string{"业务组B", "业务组A"}
+	if changes := scopeChanges(saved, same); len(changes) != 0 {
+		t.Errorf("scopeChanges() = %v, want none for reordered business groups", changes)
+	}
+
+	changed := saved
+	changed.Modules = []string{json.ModuleHost}
+	changed.ExcludeHosts = nil
+	changed.RangeEnd = "2026-10-15 00:00"
+	want := []string{"巡检模块", "排除主机", "聚合窗口"}
+	if changes := scopeChanges(saved, changed); !slices.Equal(changes, want) {
+		t.Errorf("scopeChanges() = %v, want %v", changes, want)
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// CI providers supported by --ci.
+const (
+	ciProviderAuto   = "auto"
+	ciProviderGitHub = "github"
+	ciProviderGitLab = "gitlab"
+)
+
+// ciReporter writes CI job log markup: collapsible log groups around each inspection step
+// and one annotation per warning / critical alert. A nil ciReporter writes nothing, so
+// callers do not need to check whether --ci is set.
+type ciReporter struct {
+	out      io.Writer
+	provider string // ciProviderGitHub or ciProviderGitLab
+	section  string // Name of the open GitLab section, "" when no group is open
+	sections int    // Number of GitLab sections opened so far, used for unique section names
+	open     bool   // Whether a group is open
+}
+
+// newCIReporter returns a ciReporter for the --ci flag value, or nil when CI mode is off.
+// "auto" picks the provider from the GITHUB_ACTIONS / GITLAB_CI environment variables and
+// falls back to GitHub workflow commands, which other CI systems print as plain lines.
+func newCIReporter(out io.Writer, provider string) (*ciReporter, error) {
+	switch provider {
+	case "":
+		return nil, nil
+	case ciProviderAuto:
+		provider = ciProviderGitHub
+		if os.Getenv("GITHUB_ACTIONS") != "true" && os.Getenv("GITLAB_CI") == "true" {
+			provider = ciProviderGitLab
+		}
+	case ciProviderGitHub, ciProviderGitLab:
+	default:
+		return nil, fmt.Errorf("不支持的 CI 类型: %s（可选 auto、github、gitlab）", provider)
+	}
+	return &ciReporter{out: out, provider: provider}, nil
+}
+
+// startGroup opens a collapsible log group titled title, closing the previous one.
+func (c *ciReporter) startGroup(title string) {
+	if c == nil {
+		return
+	}
+	c.endGroup()
+	c.open = true
+
+	switch c.provider {
+	case ciProviderGitLab:
+		c.sections++
+		c.section = fmt.Sprintf("inspect_%d", c.sections)
+		fmt.Fprintf(c.out, "\x1b[0Ksection_start:%d:%s[collapsed=true]\r\x1b[0K%s\n", time.Now().Unix(), c.section, title)
+	default:
+		fmt.Fprintf(c.out, "::group::%s\n", escapeCIData(title))
+	}
+}
+
+// endGroup closes the open log group, if any.
+func (c *ciReporter) endGroup() {
+	if c == nil || !c.open {
+		return
+	}
+	c.open = false
+
+	switch c.provider {
+	case ciProviderGitLab:
+		fmt.Fprintf(c.out, "\x1b[0Ksection_end:%d:%s\r\x1b[0K\n", time.Now().Unix(), c.section)
+	default:
+		fmt.Fprintln(c.out, "::endgroup::")
+	}
+}
+
+// error reports a failed step, e.g. a module inspection or report that could not be generated.
+func (c *ciReporter) error(title string, err error) {
+	if c == nil {
+		return
+	}
+	c.annotate(model.AlertLevelCritical, title, err.Error())
+}
+
+// annotateAlerts writes one annotation per warning or critical alert, outside of any group so
+// GitLab shows them expanded. Critical alerts are errors, warnings are warnings.
+func (c *ciReporter) annotateAlerts(alerts []*json.Alert) {
+	if c == nil {
+		return
+	}
+	c.endGroup()
+
+	for _, a := range alerts {
+		if a.Level != model.AlertLevelWarning && a.Level != model.AlertLevelCritical {
+			continue
+		}
+		module := json.ModuleName(a.Module)
+		title := fmt.Sprintf("%s %s", module, a.Identifier)
+		message := a.Message
+		if message == "" {
+			message = fmt.Sprintf("%s: %s", a.MetricDisplayName, a.FormattedValue)
+		}
+		c.annotate(a.Level, title, message)
+	}
+}
+
+// annotate writes one annotation line.
+func (c *ciReporter) annotate(level model.AlertLevel, title, message string) {
+	switch c.provider {
+	case ciProviderGitLab:
+		// GitLab has no log annotations; colored lines stand out in the job log
+		color, label := "\x1b[33m", "WARNING"
+		if level == model.AlertLevelCritical {
+			color, label = "\x1b[31m", "ERROR"
+		}
+		fmt.Fprintf(c.out, "%s%s\x1b[0m [%s] %s\n", color, label, title, strings.ReplaceAll(message, "\n", " "))
+	default:
+		command := "warning"
+		if level == model.AlertLevelCritical {
+			command = "error"
+		}
+		fmt.Fprintf(c.out, "::%s title=%s::%s\n", command, escapeCIProperty(title), escapeCIData(message))
+	}
+}
+
+// escapeCIData escapes the message of a GitHub workflow command.
+func escapeCIData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeCIProperty escapes a property value of a GitHub workflow command.
+func escapeCIProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
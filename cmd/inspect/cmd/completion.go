@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/report/json"
+)
+
+// completionShells are the arguments of the completion command.
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// completionCmd represents the completion command. It replaces the default completion
+// command of cobra, adding the Chinese help and the loading instructions.
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish|powershell>",
+	Short: "生成 Shell 自动补全脚本",
+	Long: `生成 bash、zsh、fish 或 PowerShell 的自动补全脚本，补全所有子命令与参数，
+以及输出格式、巡检模块、预设、告警级别等参数的取值和配置、指标定义、JSON 报告文件。
+
+加载方式:
+  # bash（需安装 bash-completion）
+  source <(inspect completion bash)
+  inspect completion bash > /etc/bash_completion.d/inspect
+
+  # zsh（未启用补全时先执行 autoload -U compinit; compinit）
+  inspect completion zsh > "${fpath[1]}/_inspect"
+
+  # fish
+  inspect completion fish > ~/.config/fish/completions/inspect.fish
+
+  # PowerShell
+  inspect completion powershell | Out-String | Invoke-Expression
+
+升级工具后重新生成脚本，新增的子命令与参数才会出现在补全中。`,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:             completionShells,
+	DisableFlagsInUseLine: true,
+	Run:                   runCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+// runCompletion writes the completion script of the shell to stdout.
+func runCompletion(cmd *cobra.Command, args []string) {
+	var err error
+	switch args[0] {
+	case "bash":
+		err = rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		err = rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		err = rootCmd.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		err = rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 生成补全脚本失败: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// registerCompletions registers the completion of the flag values and file arguments. It
+// runs from Execute, once the flags of every command are defined by the init functions.
+func registerCompletions() {
+	_ = rootCmd.RegisterFlagCompletionFunc("log-level", cobra.FixedCompletions(
+		[]cobra.Completion{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml")
+
+	formats := make([]cobra.Completion, 0, len(reportFormats))
+	for _, f := range reportFormats {
+		formats = append(formats, cobra.CompletionWithDesc(f.name, f.description))
+	}
+	modules := make([]cobra.Completion, 0, len(config.ModuleNames))
+	for _, m := range config.ModuleNames {
+		modules = append(modules, cobra.CompletionWithDesc(m, json.ModuleName(m)))
+	}
+	presets := make([]cobra.Completion, 0, len(config.PresetNames()))
+	for _, name := range config.PresetNames() {
+		preset, _ := config.LookupPreset(name)
+		presets = append(presets, cobra.CompletionWithDesc(name, preset.Description))
+	}
+
+	_ = inspectCmd.RegisterFlagCompletionFunc("format", completeList(formats))
+	_ = reportCmd.RegisterFlagCompletionFunc("format", completeList(slices.DeleteFunc(slices.Clone(formats), func(c cobra.Completion) bool {
+		return strings.HasPrefix(c, "json\t") // report regenerates the other formats from JSON
+	})))
+	_ = inspectCmd.RegisterFlagCompletionFunc("only", completeList(modules))
+	_ = inspectCmd.RegisterFlagCompletionFunc("skip", completeList(modules))
+	_ = inspectCmd.RegisterFlagCompletionFunc("fail-on", cobra.FixedCompletions([]cobra.Completion{
+		cobra.CompletionWithDesc(failOnWarning, "有警告或严重告警时退出码非零"),
+		cobra.CompletionWithDesc(failOnCritical, "仅有严重告警时退出码非零"),
+		cobra.CompletionWithDesc(failOnNever, "巡检完成即返回 0"),
+	}, cobra.ShellCompDirectiveNoFileComp))
+	_ = inspectCmd.RegisterFlagCompletionFunc("ci", cobra.FixedCompletions(
+		[]cobra.Completion{ciProviderAuto, ciProviderGitHub, ciProviderGitLab}, cobra.ShellCompDirectiveNoFileComp))
+	for _, cmd := range []*cobra.Command{inspectCmd, listCmd} {
+		_ = cmd.RegisterFlagCompletionFunc("preset", cobra.FixedCompletions(presets, cobra.ShellCompDirectiveNoFileComp))
+	}
+
+	// Metric definition files, report directories and report files
+	definitionFlags := []string{
+		"metrics", "mysql-metrics", "redis-metrics", "nginx-metrics", "tomcat-metrics", "cassandra-metrics", "monitoring-metrics",
+		"storage-metrics", "log-checks", "lvs-metrics", "windows-metrics", "ad-metrics", "cloud-metrics", "firewall-metrics",
+	}
+	for _, cmd := range []*cobra.Command{inspectCmd, validateCmd, configValidateCmd, listCmd, reportCmd} {
+		for _, name := range definitionFlags {
+			if cmd.Flags().Lookup(name) != nil {
+				_ = cmd.MarkFlagFilename(name, "yaml", "yml")
+			}
+		}
+	}
+	for _, cmd := range []*cobra.Command{inspectCmd, listCmd, reportCmd, serveCmd} {
+		_ = cmd.MarkFlagDirname("output")
+	}
+	for _, cmd := range []*cobra.Command{diffCmd, rollupCmd} {
+		_ = cmd.MarkFlagFilename("output", "xlsx")
+		cmd.ValidArgsFunction = completeJSONFiles
+	}
+	reportCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeJSONFiles(cmd, args, toComplete)
+	}
+	_ = reportCmd.MarkFlagFilename("from", "json")
+}
+
+// completeList completes a comma-separated list flag: the choices not listed yet, after the
+// values already typed, without a trailing space so the list can go on.
+func completeList(choices []cobra.Completion) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		typed := ""
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			typed = toComplete[:i+1]
+		}
+		listed := strings.Split(typed, ",")
+		completions := make([]cobra.Completion, 0, len(choices))
+		for _, choice := range choices {
+			value, _, _ := strings.Cut(choice, "\t")
+			if !slices.Contains(listed, value) {
+				completions = append(completions, typed+choice)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// completeJSONFiles completes the JSON inspection results (and the directories holding them).
+func completeJSONFiles(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return []cobra.Completion{"json"}, cobra.ShellCompDirectiveFilterFileExt
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/excel"
+	"inspection-tool/internal/report/json"
+)
+
+// diffOutput is the --output flag of the diff command.
+var diffOutput string
+
+// diffCmd represents the diff command.
+var diffCmd = &cobra.Command{
+	Use:   "diff <旧 JSON 报告> <新 JSON 报告> | diff <报告目录>",
+	Short: "对比两次巡检结果",
+	Long: `对比两次巡检的 JSON 报告（report.formats 包含 json 时生成），生成 Excel 对比报告：
+新增告警、已恢复告警、告警级别变化（升级 / 降级），以及各主机指标值的变化。
+
+只传入一个目录时，对比目录中最新的两份 JSON 报告。
+
+示例:
+  inspect diff reports/inspection_report_2025-12-12.json reports/inspection_report_2025-12-13.json
+  inspect diff reports/ -o diff.xlsx`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "", "对比报告输出路径（默认 diff_<时间>.xlsx）")
+	rootCmd.AddCommand(diffCmd)
+}
+
+// runDiff executes the diff command logic.
+func runDiff(cmd *cobra.Command, args []string) {
+	oldPath, newPath, err := resolveDiffReports(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	older, err := json.ReadReport(oldPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 读取巡检结果失败: %v\n", err)
+		os.Exit(1)
+	}
+	newer, err := json.ReadReport(newPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 读取巡检结果失败: %v\n", err)
+		os.Exit(1)
+	}
+	// Compare in chronological order, whatever order the reports were given in
+	if newer.GeneratedAt.Before(older.GeneratedAt) {
+		older, newer = newer, older
+		oldPath, newPath = newPath, oldPath
+	}
+
+	diff := json.Diff(older, newer)
+
+	outputPath := diffOutput
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("diff_%s.xlsx", time.Now().Format("20060102_150405"))
+	}
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath += ".xlsx"
+	}
+
+	if err := excel.NewWriter(nil).WriteDiff(diff, outputPath); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 生成对比报告失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🔍 对比: %s → %s\n", oldPath, newPath)
+	fmt.Printf("   新增告警: %d | 已恢复: %d | 升级: %d | 降级: %d | 指标变化: %d\n",
+		diff.CountAlerts(model.AlertChangeNew),
+		diff.CountAlerts(model.AlertChangeResolved),
+		diff.CountAlerts(model.AlertChangeEscalated),
+		diff.CountAlerts(model.AlertChangeDowngraded),
+		len(diff.MetricDeltas))
+	if len(diff.AddedHosts) > 0 || len(diff.RemovedHosts) > 0 {
+		fmt.Printf("   新增主机: %d | 移除主机: %d\n", len(diff.AddedHosts), len(diff.RemovedHosts))
+	}
+	fmt.Printf("\n✅ 对比报告已生成: %s\n", outputPath)
+}
+
+// resolveDiffReports returns the older and newer report paths of the diff arguments: two
+// report paths, or a directory whose two most recent JSON reports are compared.
+func resolveDiffReports(args []string) (string, string, error) {
+	if len(args) == 2 {
+		return args[0], args[1], nil
+	}
+
+	reports, err := listJSONReports(args[0])
+	if err != nil {
+		return "", "", err
+	}
+	if len(reports) < 2 {
+		return "", "", fmt.Errorf("目录中的 JSON 报告少于两份: %s", args[0])
+	}
+	return reports[1], reports[0], nil
+}
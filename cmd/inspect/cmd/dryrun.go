@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/report/bundle"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// dryRunModule is an inspection module whose queries are printed by a dry run.
+type dryRunModule struct {
+	key     string
+	run     bool
+	planned func() []service.PlannedQuery // Builds the collector without clients
+}
+
+// printDryRun prints the queries of the modules to run, with the host and instance filters
+// injected, and the reports that would be generated. Nothing is queried or written.
+func printDryRun(out io.Writer, cfg *config.Config, modules []dryRunModule, outputFormats []string, outputPath string) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "🧪 预演模式（--dry-run）: 不查询数据源，不生成报告")
+
+	queries := 0
+	for _, m := range modules {
+		if !m.run {
+			continue
+		}
+		planned := m.planned()
+		fmt.Fprintf(out, "\n📋 %s（%d 条查询）\n", json.ModuleName(m.key), len(planned))
+		for _, q := range planned {
+			fmt.Fprintf(out, "   - %s: %s\n", q.Metric, q.Query)
+		}
+		queries += len(planned)
+	}
+	if queries == 0 {
+		fmt.Fprintln(out, "\n📋 本机不执行任何查询")
+	}
+
+	timezone, _ := time.LoadLocation("Asia/Shanghai")
+	if tz, err := time.LoadLocation(cfg.Report.Timezone); err == nil {
+		timezone = tz
+	}
+	filenameBase := generateFilename(cfg.Report.FilenameTemplate, time.Now().In(timezone))
+
+	fmt.Fprintf(out, "\n📄 计划生成的报告:\n")
+	for _, format := range outputFormats {
+		fmt.Fprintf(out, "   - %s: %s\n", format, reportOutputPath(format, outputPath, filenameBase, cfg.Report.HTMLSplit))
+	}
+	if cfg.Report.Bundle == bundle.ModeZip && len(outputFormats) > 0 {
+		fmt.Fprintf(out, "   - 打包: %s\n", filepath.Join(outputPath, bundle.Filename(filenameBase, time.Now().In(timezone))))
+	}
+	if confluence := cfg.Report.Publish.Confluence; confluence.Enabled && len(outputFormats) > 0 {
+		fmt.Fprintf(out, "   - 发布到 Confluence: %s（空间 %s）\n", confluence.URL, confluence.SpaceKey)
+	}
+	fmt.Fprintf(out, "\n✅ 预演完成: %d 条查询，%d 个报告格式\n", queries, len(outputFormats))
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// listHostsTimeout bounds the discovery requests of list hosts.
+const listHostsTimeout = 2 * time.Minute
+
+// scopeEntry is a host or instance in the inspection scope, as printed by list hosts.
+type scopeEntry struct {
+	Module   string `json:"module"`
+	Instance string `json:"instance"`
+	Hostname string `json:"hostname,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	Detail   string `json:"detail,omitempty"` // Business group, component, cloud provider...
+}
+
+// scopeModule discovers the hosts or instances of one module.
+type scopeModule struct {
+	key      string
+	enabled  bool
+	discover func(ctx context.Context) ([]scopeEntry, error)
+}
+
+// discoverEntries adapts the instance discovery of a collector to scopeModule.discover.
+func discoverEntries[T any](discover func(ctx context.Context) ([]T, error), entry func(T) scopeEntry) func(ctx context.Context) ([]scopeEntry, error) {
+	return func(ctx context.Context) ([]scopeEntry, error) {
+		instances, err := discover(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]scopeEntry, 0, len(instances))
+		for _, instance := range instances {
+			entries = append(entries, entry(instance))
+		}
+		return entries, nil
+	}
+}
+
+// listHosts discovers the hosts from N9E and the instances of the enabled modules with the
+// configured filters applied, the way an inspection would, and prints them as a table or JSON.
+func listHosts() error {
+	cfg, err := config.Load(GetConfigFile())
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	logger := zerolog.Nop() // Failures are reported per module
+
+	n9eClient := n9e.NewClient(&cfg.Datasources.N9E, &cfg.HTTP.Retry, logger)
+	vmClient := vm.NewMetricsSource(&cfg.Datasources.VictoriaMetrics, &cfg.HTTP.Retry, logger)
+	ctx, cancel := context.WithTimeout(context.Background(), listHostsTimeout)
+	defer cancel()
+
+	var hostOpts []service.CollectorOption
+	if cfg.Datasources.N9E.BusinessGroupSync {
+		if groups, err := n9eClient.GetBusinessGroups(ctx); err == nil {
+			hostOpts = append(hostOpts, service.WithBusinessGroupTree(model.NewBusinessGroupTree(groups)))
+		}
+	}
+
+	modules := []scopeModule{
+		{json.ModuleHost, true, discoverEntries(service.NewCollector(cfg, n9eClient, vmClient, nil, logger, hostOpts...).CollectHostMetas,
+			func(h *model.HostMeta) scopeEntry {
+				return scopeEntry{Instance: h.Ident, Hostname: h.Hostname, IP: h.IP, Detail: h.BusinessGroup}
+			})},
+		{json.ModuleMySQL, cfg.MySQL.Enabled, discoverEntries(service.NewMySQLCollector(&cfg.MySQL, vmClient, nil, logger).DiscoverInstances,
+			func(i *model.MySQLInstance) scopeEntry { return scopeEntry{Instance: i.Address, IP: i.IP} })},
+		{json.ModuleRedis, cfg.Redis.Enabled, discoverEntries(service.NewRedisCollector(&cfg.Redis, vmClient, nil, logger).DiscoverInstances,
+			func(i *model.RedisInstance) scopeEntry { return scopeEntry{Instance: i.Address, IP: i.IP} })},
+		{json.ModuleNginx, cfg.Nginx.Enabled, discoverEntries(service.NewNginxCollector(&cfg.Nginx, vmClient, n9eClient, nil, logger).DiscoverInstances,
+			func(i *model.NginxInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, IP: i.IP, Detail: i.Container}
+			})},
+		{json.ModuleTomcat, cfg.Tomcat.Enabled, discoverEntries(service.NewTomcatCollector(&cfg.Tomcat, vmClient, n9eClient, nil, logger).DiscoverInstances,
+			func(i *model.TomcatInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, IP: i.IP, Detail: i.Container}
+			})},
+		{json.ModuleCassandra, cfg.Cassandra.Enabled, discoverEntries(service.NewCassandraCollector(&cfg.Cassandra, vmClient, n9eClient, nil, logger).DiscoverInstances,
+			func(i *model.CassandraInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, IP: i.IP, Detail: i.ClusterName}
+			})},
+		{json.ModuleMonitoring, cfg.Monitoring.Enabled, discoverEntries(service.NewMonitoringCollector(&cfg.Monitoring, vmClient, nil, logger).DiscoverInstances,
+			func(i *model.MonitoringInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, Detail: i.Component}
+			})},
+		{json.ModuleStorage, cfg.Storage.Enabled, discoverEntries(service.NewStorageCollector(&cfg.Storage, vmClient, n9eClient, nil, logger).DiscoverInstances,
+			func(i *model.StorageInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, IP: i.IP, Detail: i.RoleText()}
+			})},
+		{json.ModuleLVS, cfg.LVS.Enabled, discoverEntries(service.NewLVSCollector(&cfg.LVS, vmClient, n9eClient, nil, logger).DiscoverInstances,
+			func(i *model.LVSInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, IP: i.IP}
+			})},
+		{json.ModuleWindows, cfg.Windows.Enabled, discoverEntries(service.NewWindowsCollector(&cfg.Windows, vmClient, n9eClient, nil, logger).DiscoverInstances,
+			func(i *model.WindowsInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, IP: i.IP}
+			})},
+		{json.ModuleAD, cfg.AD.Enabled, discoverEntries(service.NewADCollector(&cfg.AD, vmClient, n9eClient, nil, logger).DiscoverInstances,
+			func(i *model.ADInstance) scopeEntry {
+				return scopeEntry{Instance: i.Identifier, Hostname: i.Hostname, IP: i.IP}
+			})},
+		{json.ModuleCloud, cfg.Cloud.Enabled, discoverEntries(service.NewCloudCollector(&cfg.Cloud, vmClient, nil, logger).DiscoverInstances,
+			func(r *model.CloudResource) scopeEntry {
+				detail := strings.Join([]string{r.Provider, r.ResourceType, r.Region}, "/")
+				return scopeEntry{Instance: r.ResourceID, Hostname: r.Name, Detail: strings.TrimRight(detail, "/")}
+			})},
+		{json.ModuleFirewall, cfg.Firewall.Enabled, discoverEntries(service.NewFirewallCollector(&cfg.Firewall, vmClient, nil, logger).DiscoverInstances,
+			func(d *model.FirewallDevice) scopeEntry {
+				return scopeEntry{Instance: d.Address, Hostname: d.Name, Detail: model.FirewallProfileText(d.Profile)}
+			})},
+	}
+
+	entries := []scopeEntry{}
+	var failed []string
+	for _, m := range modules {
+		if !m.enabled {
+			continue
+		}
+		found, err := m.discover(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %s\n", json.ModuleName(m.key), shortError(err))
+			failed = append(failed, json.ModuleName(m.key))
+			continue
+		}
+		for _, e := range found {
+			e.Module = m.key
+			entries = append(entries, e)
+		}
+	}
+
+	if listJSON {
+		encoder := stdjson.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entries); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, 0, len(entries))
+		for _, e := range entries {
+			rows = append(rows, []string{json.ModuleName(e.Module), e.Instance, e.Hostname, e.IP, e.Detail})
+		}
+		writeAlignedTable(os.Stdout, []string{"模块", "主机/实例", "主机名", "IP", "说明"}, rows, 5)
+		fmt.Printf("   共 %d 个主机与实例\n", len(entries))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("发现失败: %s", strings.Join(failed, "、"))
+	}
+	return nil
+}
@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/report/json"
+)
+
+// initForce is the --force flag of the init command.
+var initForce bool
+
+// initCmd represents the init command.
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "交互式生成配置文件",
+	Long: `依次询问夜莺（N9E）与指标数据源地址、启用的巡检模块、主机告警阈值和报告输出设置，
+生成带注释的 YAML 配置文件（-c 指定路径，默认 config.yaml），并验证生成的配置。
+每个问题的默认值显示在方括号中，直接回车即采用默认值。
+
+生成的文件只包含常用配置；完整的配置项与说明见 configs/config.example.yaml。
+Token 留空时请通过环境变量 INSPECT_DATASOURCES_N9E_TOKEN 设置。
+
+示例:
+  inspect init
+  inspect init -c /etc/inspection-tool/config.yaml
+  inspect init -c config.yaml --force`,
+	Args: cobra.NoArgs,
+	Run:  runInit,
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initForce, "force", false, "覆盖已存在的配置文件")
+	rootCmd.AddCommand(initCmd)
+}
+
+// runInit executes the init command logic.
+func runInit(cmd *cobra.Command, args []string) {
+	path := GetConfigFile()
+	if _, err := os.Stat(path); err == nil && !initForce {
+		fmt.Fprintf(os.Stderr, "❌ 配置文件已存在: %s（使用 --force 覆盖）\n", path)
+		os.Exit(1)
+	}
+
+	fmt.Println("🧭 生成巡检配置文件，直接回车采用方括号中的默认值")
+	answers, err := askInitAnswers(&prompter{in: bufio.NewReader(os.Stdin), out: os.Stdout})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	var content bytes.Buffer
+	if err := renderInitConfig(&content, answers, path, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 生成配置失败: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(path, content.Bytes(), 0o600); err != nil { // The file may hold the N9E token
+		fmt.Fprintf(os.Stderr, "❌ 写入配置文件失败: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n✅ 已生成配置文件: %s\n", path)
+
+	if answers.N9EToken == "" {
+		fmt.Println("⚠️  未填写夜莺 Token，请设置环境变量 INSPECT_DATASOURCES_N9E_TOKEN 或编辑 datasources.n9e.token")
+	}
+	if _, err := config.Load(path); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  配置验证未通过: %v\n", err)
+		return
+	}
+	fmt.Println("✅ 配置验证通过")
+	fmt.Println("\n下一步:")
+	fmt.Printf("  inspect config validate -c %s --connectivity   # 测试数据源连通性\n", path)
+	fmt.Printf("  inspect list hosts -c %s                       # 确认巡检范围\n", path)
+	fmt.Printf("  inspect inspect -c %s                          # 执行巡检\n", path)
+}
+
+// initAnswers are the settings asked by the init wizard.
+type initAnswers struct {
+	N9EEndpoint     string
+	N9EToken        string
+	MetricsType     string // config.MetricsTypeVictoriaMetrics or config.MetricsTypePrometheus
+	MetricsEndpoint string
+	Modules         []string // Enabled service modules, as named in config.ModuleNames
+	MySQLMode       string   // mysql.cluster_mode, when MySQL is enabled
+	RedisMode       string   // redis.cluster_mode, when Redis is enabled
+	LogsType        string   // datasources.logs.type, when log checks are enabled
+	LogsEndpoint    string
+	CPU             config.ThresholdPair
+	Memory          config.ThresholdPair
+	Disk            config.ThresholdPair
+	OutputDir       string
+	Formats         []string
+}
+
+// prompter asks questions on a terminal, one answer per line.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask prints the question with its default value and returns the answer, the default for an
+// empty line. Invalid answers print the error of check and ask again.
+func (p *prompter) ask(question, def string, check func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+		line, err := p.in.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			return "", fmt.Errorf("输入已结束，未生成配置文件")
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+		if check == nil {
+			return answer, nil
+		}
+		if err := check(answer); err != nil {
+			fmt.Fprintf(p.out, "   ❌ %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// askInitAnswers asks the init wizard questions in order.
+func askInitAnswers(p *prompter) (*initAnswers, error) {
+	a := &initAnswers{}
+	var err error
+
+	fmt.Fprintln(p.out, "\n📡 数据源")
+	if a.N9EEndpoint, err = p.ask("夜莺 N9E API 地址（如 http://10.0.0.1:17000）", "", checkEndpoint); err != nil {
+		return nil, err
+	}
+	if a.N9EToken, err = p.ask("夜莺 Token（留空则通过环境变量 INSPECT_DATASOURCES_N9E_TOKEN 设置）", "", nil); err != nil {
+		return nil, err
+	}
+	metricsTypes := []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus}
+	if a.MetricsType, err = p.ask("指标数据源类型（victoriametrics / prometheus）", config.MetricsTypeVictoriaMetrics, checkChoice(metricsTypes)); err != nil {
+		return nil, err
+	}
+	metricsDefault := "http://127.0.0.1:8428"
+	if a.MetricsType == config.MetricsTypePrometheus {
+		metricsDefault = "http://127.0.0.1:9090"
+	}
+	if a.MetricsEndpoint, err = p.ask("指标数据源地址", metricsDefault, checkEndpoint); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(p.out, "\n🧩 巡检模块（主机巡检始终执行）")
+	services := slices.DeleteFunc(slices.Clone(config.ModuleNames), func(m string) bool { return m == json.ModuleHost })
+	modules, err := p.ask("启用的服务模块，逗号分隔（可选 "+strings.Join(services, ", ")+"；留空不启用）", "", checkList(services, true))
+	if err != nil {
+		return nil, err
+	}
+	a.Modules = splitList(modules)
+	if slices.Contains(a.Modules, json.ModuleMySQL) {
+		if a.MySQLMode, err = p.ask("MySQL 集群模式（mgr / dual-master / master-slave）", "mgr", checkChoice([]string{"mgr", "dual-master", "master-slave"})); err != nil {
+			return nil, err
+		}
+	}
+	if slices.Contains(a.Modules, json.ModuleRedis) {
+		if a.RedisMode, err = p.ask("Redis 集群模式（3m3s: 3 主 3 从 / 3m6s: 3 主 6 从）", "3m3s", checkChoice([]string{"3m3s", "3m6s"})); err != nil {
+			return nil, err
+		}
+	}
+	if slices.Contains(a.Modules, json.ModuleLogChecks) {
+		if a.LogsType, err = p.ask("日志数据源类型（loki / victorialogs）", "loki", checkChoice([]string{"loki", "victorialogs"})); err != nil {
+			return nil, err
+		}
+		logsDefault := "http://127.0.0.1:3100"
+		if a.LogsType == "victorialogs" {
+			logsDefault = "http://127.0.0.1:9428"
+		}
+		if a.LogsEndpoint, err = p.ask("日志数据源地址", logsDefault, checkEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Fprintln(p.out, "\n🚨 主机告警阈值（警告/严重，单位 %）")
+	for _, t := range []struct {
+		name string
+		pair *config.ThresholdPair
+	}{
+		{"CPU 利用率", &a.CPU},
+		{"内存利用率", &a.Memory},
+		{"磁盘利用率", &a.Disk},
+	} {
+		answer, err := p.ask(t.name, "70/90", checkThresholdPair)
+		if err != nil {
+			return nil, err
+		}
+		*t.pair, _ = parseThresholdPair(answer)
+	}
+
+	fmt.Fprintln(p.out, "\n📄 报告")
+	if a.OutputDir, err = p.ask("报告输出目录", "./reports", nil); err != nil {
+		return nil, err
+	}
+	formatNames := make([]string, 0, len(reportFormats))
+	for _, f := range reportFormats {
+		formatNames = append(formatNames, f.name)
+	}
+	formats, err := p.ask("输出格式，逗号分隔（可选 "+strings.Join(formatNames, ", ")+"）", "excel,html", checkList(formatNames, false))
+	if err != nil {
+		return nil, err
+	}
+	a.Formats = splitList(formats)
+	return a, nil
+}
+
+// checkEndpoint accepts http and https URLs with a host.
+func checkEndpoint(answer string) error {
+	u, err := url.Parse(answer)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("请输入 http:// 或 https:// 开头的地址")
+	}
+	return nil
+}
+
+// checkChoice accepts one of the choices.
+func checkChoice(choices []string) func(string) error {
+	return func(answer string) error {
+		if !slices.Contains(choices, answer) {
+			return fmt.Errorf("可选值: %s", strings.Join(choices, ", "))
+		}
+		return nil
+	}
+}
+
+// checkList accepts a comma-separated list of choices, empty when allowEmpty is set.
+func checkList(choices []string, allowEmpty bool) func(string) error {
+	return func(answer string) error {
+		values := splitList(answer)
+		if len(values) == 0 && !allowEmpty {
+			return fmt.Errorf("至少选择一项: %s", strings.Join(choices, ", "))
+		}
+		for _, v := range values {
+			if !slices.Contains(choices, v) {
+				return fmt.Errorf("不支持 %q，可选值: %s", v, strings.Join(choices, ", "))
+			}
+		}
+		return nil
+	}
+}
+
+// splitList splits a comma-separated answer, dropping blanks and duplicates.
+func splitList(answer string) []string {
+	var values []string
+	for _, v := range strings.Split(answer, ",") {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseThresholdPair parses a "warning/critical" percentage pair.
+func parseThresholdPair(answer string) (config.ThresholdPair, error) {
+	warningText, criticalText, ok := strings.Cut(answer, "/")
+	if !ok {
+		return config.ThresholdPair{}, fmt.Errorf("格式为 警告/严重，如 70/90")
+	}
+	warning, err1 := strconv.ParseFloat(strings.TrimSpace(warningText), 64)
+	critical, err2 := strconv.ParseFloat(strings.TrimSpace(criticalText), 64)
+	if err1 != nil || err2 != nil {
+		return config.ThresholdPair{}, fmt.Errorf("格式为 警告/严重，如 70/90")
+	}
+	if warning <= 0 || critical > 100 || warning >= critical {
+		return config.ThresholdPair{}, fmt.Errorf("需满足 0 < 警告 < 严重 <= 100")
+	}
+	return config.ThresholdPair{Warning: warning, Critical: critical}, nil
+}
+
+// checkThresholdPair accepts a valid "warning/critical" pair.
+func checkThresholdPair(answer string) error {
+	_, err := parseThresholdPair(answer)
+	return err
+}
+
+// initModule is a service module section of the generated configuration.
+type initModule struct {
+	Key      string
+	Name     string
+	Enabled  bool
+	Settings []string // Extra lines of the section (e.g. cluster_mode), indented by the template
+}
+
+// initConfigTemplate is the configuration written by the init command.
+var initConfigTemplate = template.Must(template.New("config").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+	"num":   func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) },
+}).Parse(`# =============================================================================
+# 系统巡检工具配置文件（由 inspect init 生成于 {{.GeneratedAt}}）
+# =============================================================================
+#
+# 完整的配置项与说明见 configs/config.example.yaml
+# 验证配置并测试数据源连通性: inspect config validate -c {{.Path}} --connectivity
+#
+# 所有配置项都可以通过环境变量覆盖，格式为 INSPECT_<节>_<键>
+# 例如: INSPECT_DATASOURCES_N9E_TOKEN 覆盖 datasources.n9e.token
+#
+# =============================================================================
+
+# -----------------------------------------------------------------------------
+# 数据源配置
+# -----------------------------------------------------------------------------
+datasources:
+  # 夜莺 (N9E) 监控平台: 获取主机元信息
+  n9e:
+    endpoint: {{quote .N9EEndpoint}}
+    # 认证 Token (必填)，建议使用环境变量: export INSPECT_DATASOURCES_N9E_TOKEN="your-token"
+    token: {{quote .N9EToken}}
+    timeout: 30s
+    # 主机过滤查询 (可选)，如 "items=短剧项目"
+    # query: ""
+
+  # 指标数据源: 查询监控指标
+  victoriametrics:
+    # 后端类型: victoriametrics 或 prometheus
+    type: {{.MetricsType}}
+    endpoint: {{quote .MetricsEndpoint}}
+    timeout: 30s
+{{- if .LogsEndpoint}}
+
+  # 日志数据源: 日志巡检查询
+  logs:
+    # 后端类型: loki 或 victorialogs
+    type: {{.LogsType}}
+    endpoint: {{quote .LogsEndpoint}}
+    timeout: 30s
+{{- end}}
+
+# -----------------------------------------------------------------------------
+# 巡检配置
+# -----------------------------------------------------------------------------
+inspection:
+  # 并发数: 同时采集的主机数量 (范围: 1-100)
+  concurrency: 20
+  # 单主机超时时间
+  host_timeout: 10s
+  # 主机筛选条件 (可选)，不配置则巡检夜莺返回的所有主机
+  # host_filter:
+  #   business_groups: ["生产环境"]
+  #   exclude: ["test-*"]
+
+# -----------------------------------------------------------------------------
+# 告警阈值配置 (单位: %，warning 必须小于 critical)
+# -----------------------------------------------------------------------------
+thresholds:
+  cpu_usage:
+    warning: {{num .CPU.Warning}}
+    critical: {{num .CPU.Critical}}
+  memory_usage:
+    warning: {{num .Memory.Warning}}
+    critical: {{num .Memory.Critical}}
+  disk_usage:
+    warning: {{num .Disk.Warning}}
+    critical: {{num .Disk.Critical}}
+
+# -----------------------------------------------------------------------------
+# 报告配置
+# -----------------------------------------------------------------------------
+report:
+  output_dir: {{quote .OutputDir}}
+  # 可选值: excel, html, html-email, csv, json
+  formats:
+{{- range .Formats}}
+    - {{.}}
+{{- end}}
+  timezone: "Asia/Shanghai"
+
+# -----------------------------------------------------------------------------
+# 日志配置
+# -----------------------------------------------------------------------------
+logging:
+  level: info
+  format: json
+
+# -----------------------------------------------------------------------------
+# 服务巡检模块
+# -----------------------------------------------------------------------------
+# 实例筛选、阈值与模块专属配置见 configs/config.example.yaml 中的同名配置段
+{{- range .ServiceModules}}
+
+# {{.Name}} 巡检
+{{.Key}}:
+  enabled: {{.Enabled}}
+{{- range .Settings}}
+  {{.}}
+{{- end}}
+{{- end}}
+`))
+
+// renderInitConfig writes the configuration of the wizard answers.
+func renderInitConfig(w io.Writer, a *initAnswers, path string, now time.Time) error {
+	var modules []initModule
+	for _, key := range config.ModuleNames {
+		if key == json.ModuleHost {
+			continue
+		}
+		m := initModule{Key: key, Name: json.ModuleName(key), Enabled: slices.Contains(a.Modules, key)}
+		switch {
+		case key == json.ModuleMySQL && a.MySQLMode != "":
+			m.Settings = []string{"# 集群模式: mgr (MGR 1主N从)、dual-master (双主)、master-slave (主从)", "cluster_mode: " + strconv.Quote(a.MySQLMode)}
+		case key == json.ModuleRedis && a.RedisMode != "":
+			m.Settings = []string{"# 集群模式: 3m3s (3主3从)、3m6s (3主6从)", "cluster_mode: " + strconv.Quote(a.RedisMode)}
+		}
+		modules = append(modules, m)
+	}
+	return initConfigTemplate.Execute(w, struct {
+		*initAnswers
+		GeneratedAt    string
+		Path           string
+		ServiceModules []initModule
+	}{a, now.Format("2006-01-02 15:04"), path, modules})
+}
@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// listTargets are the arguments of the list command, in help order.
+var listTargets = []string{"modules", "metrics", "presets", "formats", "reports", "hosts"}
+
+// listJSON is the --json flag of the list command.
+var listJSON bool
+
+// reportFormats describes the output formats of the -f flag.
+var reportFormats = []struct {
+	name        string
+	description string
+}{
+	{"excel", "Excel 报告（.xlsx），每个模块一个 sheet"},
+	{"html", "HTML 报告，可交互的单文件页面（report.html_split 时按模块拆分）"},
+	{"html-email", "邮件正文 HTML（内联样式、无脚本）"},
+	{"csv", "CSV 目录，每个 sheet 一个文件"},
+	{"json", "JSON 巡检结果，可用于 report、diff、rollup 与趋势 sheet"},
+}
+
+// listCmd represents the list command.
+var listCmd = &cobra.Command{
+	Use:   "list <modules|metrics|presets|formats|reports|hosts>",
+	Short: "列出巡检模块、指标、预设、输出格式、历史结果或巡检范围",
+	Long: `列出工具的可选项与历史巡检结果：
+
+  modules   巡检模块及其在配置文件中的启用状态
+  metrics   巡检将采集的指标定义：已启用模块按 --only / --skip 与 --preset 筛选后，
+            每个模块一节（-m、--mysql-metrics 等指定的文件），含实际执行的查询、
+            配置文件中的告警阈值与状态（已实现 / 待实现）
+  presets   巡检预设（--preset）
+  formats   报告输出格式（-f）
+  reports   输出目录中的 JSON 巡检结果，新的在前（可用 inspect report 重新生成报告）
+  hosts     按配置的过滤条件发现的主机（夜莺）与已启用模块的实例，用于巡检前确认范围
+
+示例:
+  inspect list modules -c config.yaml
+  inspect list metrics -c config.yaml -m configs/metrics.yaml
+  inspect list metrics -c config.yaml --only mysql,redis
+  inspect list metrics -c config.yaml --preset quick --json
+  inspect list reports -o ./reports
+  inspect list hosts -c config.yaml --json`,
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: listTargets,
+	Run:       runList,
+}
+
+func init() {
+	addMetricsFlags(listCmd)
+	listCmd.Flags().StringVarP(&outputDir, "output", "o", "", "输出目录（list reports，默认使用配置文件中的 report.output_dir）")
+	listCmd.Flags().StringVar(&presetName, "preset", "", "巡检预设，仅列出预设选中的模块与指标（list metrics）")
+	listCmd.Flags().StringSliceVar(&onlyModules, "only", nil, "仅列出指定模块的指标（覆盖 inspection.modules.only，list metrics）")
+	listCmd.Flags().StringSliceVar(&skipModules, "skip", nil, "不列出指定模块的指标（覆盖 inspection.modules.skip，list metrics）")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "以 JSON 输出（list metrics、list hosts）")
+	rootCmd.AddCommand(listCmd)
+}
+
+// runList executes the list command logic.
+func runList(cmd *cobra.Command, args []string) {
+	var err error
+	switch args[0] {
+	case "modules":
+		err = listModules()
+	case "metrics":
+		err = listMetrics()
+	case "presets":
+		listPresets()
+	case "formats":
+		listFormats()
+	case "reports":
+		err = listReports()
+	case "hosts":
+		err = listHosts()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// listModules prints the inspection modules in report order with their enabled state.
+func listModules() error {
+	cfg, err := config.Load(GetConfigFile())
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	modules := []struct {
+		key     string
+		enabled bool
+	}{
+		{json.ModuleHost, true},
+		{json.ModuleMySQL, cfg.MySQL.Enabled},
+		{json.ModuleRedis, cfg.Redis.Enabled},
+		{json.ModuleNginx, cfg.Nginx.Enabled},
+		{json.ModuleTomcat, cfg.Tomcat.Enabled},
+		{json.ModuleCassandra, cfg.Cassandra.Enabled},
+		{json.ModuleMonitoring, cfg.Monitoring.Enabled},
+		{json.ModuleStorage, cfg.Storage.Enabled},
+		{json.ModuleLogChecks, cfg.LogChecks.Enabled},
+		{json.ModuleLVS, cfg.LVS.Enabled},
+		{json.ModuleWindows, cfg.Windows.Enabled},
+		{json.ModuleAD, cfg.AD.Enabled},
+		{json.ModuleCloud, cfg.Cloud.Enabled},
+		{json.ModuleFirewall, cfg.Firewall.Enabled},
+	}
+
+	rows := make([][]string, 0, len(modules))
+	for _, m := range modules {
+		status := "未启用"
+		if m.enabled {
+			status = "已启用"
+		}
+		rows = append(rows, []string{m.key, json.ModuleName(m.key), status})
+	}
+	writeAlignedTable(os.Stdout, []string{"模块", "名称", "状态"}, rows, 3)
+	return nil
+}
+
+// metricEntry is a metric definition as printed by list metrics.
+type metricEntry struct {
+	Name          string              `json:"name"`
+	DisplayName   string              `json:"display_name"`
+	Category      string              `json:"category"`
+	Unit          string              `json:"unit,omitempty"`
+	Status        string              `json:"status"` // active, pending
+	Query         string              `json:"query,omitempty"`
+	RangeFunction model.RangeFunction `json:"range_function,omitempty"`
+	Threshold     *metricThreshold    `json:"threshold,omitempty"`
+}
+
+// metricThreshold is the threshold pair of a metric entry.
+type metricThreshold struct {
+	Warning   float64 `json:"warning"`
+	Critical  float64 `json:"critical"`
+	Direction string  `json:"direction"`
+}
+
+// derivedThreshold is a threshold of a module that alerts on a value derived from the
+// collected metrics, as a usage ratio, rather than on one metric definition.
+type derivedThreshold struct {
+	Metric string `json:"metric"`
+	metricThreshold
+}
+
+// metricSection is the metric definitions of one module, as printed by list metrics.
+type metricSection struct {
+	Module     string             `json:"module"`
+	Path       string             `json:"path"`
+	Metrics    []metricEntry      `json:"metrics"`
+	Thresholds []derivedThreshold `json:"thresholds,omitempty"`
+}
+
+// newMetricThreshold returns the metric threshold of a pair, "above" when it has no direction.
+func newMetricThreshold(t config.ThresholdPair) *metricThreshold {
+	direction := t.Direction
+	if direction == "" {
+		direction = config.ThresholdDirectionAbove
+	}
+	return &metricThreshold{Warning: t.Warning, Critical: t.Critical, Direction: direction}
+}
+
+// definitionEntry returns the metric entry of a module metric definition.
+func definitionEntry(name, displayName, category, query string, pending bool) metricEntry {
+	entry := metricEntry{Name: name, DisplayName: displayName, Category: category, Status: "active", Query: query}
+	if pending {
+		entry.Status = "pending"
+	}
+	return entry
+}
+
+// newMetricSection returns the section of a module. entry describes a definition and returns
+// the evaluator metric its threshold is configured under; thresholds that match no definition
+// are listed as derived thresholds of the module.
+func newMetricSection[D any](module, path string, defs []*D, entry func(*D) (metricEntry, string), thresholds []service.ModuleThreshold) metricSection {
+	section := metricSection{Module: module, Path: path, Metrics: make([]metricEntry, 0, len(defs))}
+	matched := make(map[string]bool, len(thresholds))
+	for _, def := range defs {
+		e, metric := entry(def)
+		if e.Threshold == nil {
+			for _, t := range thresholds {
+				if t.Metric == metric {
+					e.Threshold = newMetricThreshold(t.ThresholdPair)
+					matched[metric] = true
+				}
+			}
+		}
+		section.Metrics = append(section.Metrics, e)
+	}
+	for _, t := range thresholds {
+		if !matched[t.Metric] {
+			section.Thresholds = append(section.Thresholds, derivedThreshold{t.Metric, *newMetricThreshold(t.ThresholdPair)})
+		}
+	}
+	return section
+}
+
+// hostMetricSection returns the section of the host metrics, with the thresholds of the
+// configuration when one is given.
+func hostMetricSection(metrics []*model.MetricDefinition, thresholds *config.ThresholdsConfig) metricSection {
+	evaluator := service.NewEvaluator(thresholds, metrics, zerolog.Nop())
+	return newMetricSection(json.ModuleHost, metricsPath, metrics, func(m *model.MetricDefinition) (metricEntry, string) {
+		entry := definitionEntry(m.Name, m.DisplayName, string(m.Category), m.Query, m.IsPending())
+		entry.Unit = m.Unit
+		entry.RangeFunction = m.RangeFunction
+		if t := evaluator.MetricThreshold(m); t != nil {
+			entry.Threshold = newMetricThreshold(*t)
+		}
+		return entry, m.Name
+	}, nil)
+}
+
+// listMetrics prints the metric definitions an inspection would collect: those of every
+// module the run would inspect, after the module selection (--only / --skip) and --preset,
+// with the queries actually run (range functions applied) and the thresholds of the
+// configuration. Without a readable configuration the host metrics are printed as defined
+// in the file.
+func listMetrics() error {
+	var sections []metricSection
+	cfg, err := config.Load(GetConfigFile())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  未加载配置文件，仅列出主机指标，不显示阈值与范围聚合: %v\n", err)
+		metrics, err := config.LoadMetrics(metricsPath)
+		if err != nil {
+			return fmt.Errorf("加载指标定义失败: %w", err)
+		}
+		if presetName != "" {
+			preset, err := config.LookupPreset(presetName)
+			if err != nil {
+				return err
+			}
+			metrics = preset.FilterMetrics(metrics)
+		}
+		sections = []metricSection{hostMetricSection(metrics, nil)}
+	} else {
+		var preset *config.Preset
+		if presetName != "" {
+			if preset, err = config.LookupPreset(presetName); err != nil {
+				return err
+			}
+		}
+		plan, err := selectModules(cfg, preset)
+		if err != nil {
+			return err
+		}
+		defs, err := loadModuleDefinitions(cfg, plan, preset, io.Discard, zerolog.Nop())
+		if err != nil {
+			return err
+		}
+		sections = metricSections(cfg, plan, defs)
+	}
+
+	if listJSON {
+		encoder := stdjson.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sections)
+	}
+	for i, section := range sections {
+		if i > 0 {
+			fmt.Println()
+		}
+		printMetricSection(os.Stdout, section)
+	}
+	return nil
+}
+
+// metricSections returns the sections of the modules of the plan, in report order.
+func metricSections(cfg *config.Config, plan *modulePlan, defs *moduleDefinitions) []metricSection {
+	logger := zerolog.Nop()
+	modules := []struct {
+		run     bool
+		section func() metricSection
+	}{
+		{plan.host, func() metricSection { return hostMetricSection(defs.host, &cfg.Thresholds) }},
+		{plan.mysql, func() metricSection {
+			return newMetricSection(json.ModuleMySQL, mysqlMetricsPath, defs.mysql, func(d *model.MySQLMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewMySQLEvaluator(&cfg.MySQL.Thresholds, defs.mysql, logger).Thresholds())
+		}},
+		{plan.redis, func() metricSection {
+			return newMetricSection(json.ModuleRedis, redisMetricsPath, defs.redis, func(d *model.RedisMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewRedisEvaluator(&cfg.Redis.Thresholds, defs.redis, logger).Thresholds())
+		}},
+		{plan.nginx, func() metricSection {
+			return newMetricSection(json.ModuleNginx, nginxMetricsPath, defs.nginx, func(d *model.NginxMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewNginxEvaluator(&cfg.Nginx.Thresholds, defs.nginx, time.Local, logger).Thresholds())
+		}},
+		{plan.tomcat, func() metricSection {
+			return newMetricSection(json.ModuleTomcat, tomcatMetricsPath, defs.tomcat, func(d *model.TomcatMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewTomcatEvaluator(&cfg.Tomcat.Thresholds, defs.tomcat, time.Local, logger).Thresholds())
+		}},
+		{plan.cassandra, func() metricSection {
+			return newMetricSection(json.ModuleCassandra, cassandraMetricsPath, defs.cassandra, func(d *model.CassandraMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewCassandraEvaluator(&cfg.Cassandra.Thresholds, defs.cassandra, time.Local, logger).Thresholds())
+		}},
+		{plan.monitoring, func() metricSection {
+			return newMetricSection(json.ModuleMonitoring, monitoringMetricsPath, defs.monitoring, func(d *model.MonitoringMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewMonitoringEvaluator(&cfg.Monitoring.Thresholds, defs.monitoring, time.Local, logger).Thresholds())
+		}},
+		{plan.storage, func() metricSection {
+			return newMetricSection(json.ModuleStorage, storageMetricsPath, defs.storage, func(d *model.StorageMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewStorageEvaluator(&cfg.Storage.Thresholds, defs.storage, time.Local, logger).Thresholds())
+		}},
+		{plan.logChecks, func() metricSection {
+			// Log checks carry their thresholds in the definitions
+			return newMetricSection(json.ModuleLogChecks, logChecksPath, defs.logChecks, func(c *model.LogCheckDefinition) (metricEntry, string) {
+				entry := definitionEntry(c.Name, c.DisplayName, "", c.Query, c.IsPending())
+				if pair := (config.ThresholdPair{Warning: c.Warning, Critical: c.Critical}); !pair.IsDisabled() {
+					entry.Threshold = newMetricThreshold(pair)
+				}
+				return entry, c.Name
+			}, nil)
+		}},
+		{plan.lvs, func() metricSection {
+			return newMetricSection(json.ModuleLVS, lvsMetricsPath, defs.lvs, func(d *model.LVSMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewLVSEvaluator(&cfg.LVS.Thresholds, defs.lvs, time.Local, logger).Thresholds())
+		}},
+		{plan.windows, func() metricSection {
+			return newMetricSection(json.ModuleWindows, windowsMetricsPath, defs.windows, func(d *model.WindowsMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewWindowsEvaluator(&cfg.Windows.Thresholds, defs.windows, time.Local, logger).Thresholds())
+		}},
+		{plan.ad, func() metricSection {
+			return newMetricSection(json.ModuleAD, adMetricsPath, defs.ad, func(d *model.ADMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Name
+			}, service.NewADEvaluator(&cfg.AD.Thresholds, defs.ad, time.Local, logger).Thresholds())
+		}},
+		{plan.cloud, func() metricSection {
+			// Cloud and firewall thresholds apply to the normalized field of every profile
+			return newMetricSection(json.ModuleCloud, cloudMetricsPath, defs.cloud, func(d *model.CloudMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Field
+			}, service.NewCloudEvaluator(&cfg.Cloud.Thresholds, defs.cloud, time.Local, logger).Thresholds())
+		}},
+		{plan.firewall, func() metricSection {
+			return newMetricSection(json.ModuleFirewall, firewallMetricsPath, defs.firewall, func(d *model.FirewallMetricDefinition) (metricEntry, string) {
+				return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Field
+			}, service.NewFirewallEvaluator(&cfg.Firewall.Thresholds, defs.firewall, time.Local, logger).Thresholds())
+		}},
+	}
+
+	var sections []metricSection
+	for _, m := range modules {
+		if m.run {
+			sections = append(sections, m.section())
+		}
+	}
+	return sections
+}
+
+// thresholdText formats a threshold as "≥80 / ≥90", "-" without one. A level set to 0 is
+// disabled and shown as "-".
+func thresholdText(t *metricThreshold) string {
+	if t == nil {
+		return "-"
+	}
+	op := "≥"
+	if t.Direction == config.ThresholdDirectionBelow {
+		op = "≤"
+	}
+	level := func(limit float64) string {
+		if limit == 0 {
+			return "-"
+		}
+		return op + strconv.FormatFloat(limit, 'f', -1, 64)
+	}
+	return level(t.Warning) + " / " + level(t.Critical)
+}
+
+// printMetricSection prints the definitions of a module as a table, followed by the
+// thresholds of its derived values.
+func printMetricSection(out io.Writer, section metricSection) {
+	fmt.Fprintf(out, "📊 %s: %s\n", json.ModuleName(section.Module), section.Path)
+	rows := make([][]string, 0, len(section.Metrics))
+	active := 0
+	for _, e := range section.Metrics {
+		status := "已实现"
+		if e.Status == "pending" {
+			status = "待实现"
+		} else {
+			active++
+		}
+		rows = append(rows, []string{e.Name, e.DisplayName, e.Category, e.Unit, status, thresholdText(e.Threshold), e.Query})
+	}
+	writeAlignedTable(out, []string{"指标", "名称", "分类", "单位", "状态", "阈值（警告 / 严重）", "查询"}, rows, 7)
+	fmt.Fprintf(out, "   共 %d 个指标，%d 个已实现\n", len(section.Metrics), active)
+	for _, t := range section.Thresholds {
+		fmt.Fprintf(out, "   派生指标阈值 %s: %s\n", t.Metric, thresholdText(&t.metricThreshold))
+	}
+}
+
+// listPresets prints the built-in inspection presets.
+func listPresets() {
+	names := config.PresetNames()
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		preset, _ := config.LookupPreset(name)
+		rows = append(rows, []string{preset.Name, strings.Join(preset.Modules, ","), preset.Description})
+	}
+	writeAlignedTable(os.Stdout, []string{"预设", "模块", "说明"}, rows, 3)
+}
+
+// listFormats prints the report output formats.
+func listFormats() {
+	rows := make([][]string, 0, len(reportFormats))
+	for _, f := range reportFormats {
+		rows = append(rows, []string{f.name, f.description})
+	}
+	writeAlignedTable(os.Stdout, []string{"格式", "说明"}, rows, 2)
+}
+
+// listReports prints the JSON results in the output directory, newest first, with their
+// inspection time and alert counts.
+func listReports() error {
+	dir := outputDir
+	if dir == "" {
+		cfg, err := config.Load(GetConfigFile())
+		if err != nil {
+			return fmt.Errorf("加载配置失败: %w", err)
+		}
+		dir = resolveOutputDir(cfg)
+	}
+	paths, err := listJSONReports(dir)
+	if err != nil {
+		return fmt.Errorf("读取目录 %s 失败: %w", dir, err)
+	}
+	if len(paths) == 0 {
+		fmt.Printf("   目录 %s 中没有 JSON 巡检结果\n", dir)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		report, err := json.ReadReport(path)
+		if err != nil {
+			continue // Other JSON files in the directory
+		}
+		warning, critical := 0, 0
+		for _, alert := range report.Alerts {
+			switch alert.Level {
+			case model.AlertLevelWarning:
+				warning++
+			case model.AlertLevelCritical:
+				critical++
+			}
+		}
+		rows = append(rows, []string{
+			filepath.Base(path),
+			report.GeneratedAt.Format("2006-01-02 15:04:05"),
+			fmt.Sprint(warning),
+			fmt.Sprint(critical),
+		})
+	}
+	writeAlignedTable(os.Stdout, []string{"文件", "巡检时间", "警告", "严重"}, rows, 2)
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+func TestNewMetricSection(t *testing.T) {
+	defs := []*model.CloudMetricDefinition{
+		{Name: "rds_cpu_usage", DisplayName: "CPU 使用率", Query: "rds_cpu", Field: "cpu_usage"},
+		{Name: "rds_disk_usage", DisplayName: "存储空间使用率", Field: "disk_usage", Status: "pending"},
+	}
+	thresholds := []service.ModuleThreshold{
+		{Metric: "cpu_usage", ThresholdPair: config.ThresholdPair{Warning: 70, Critical: 90}},
+		{Metric: "connection_usage", ThresholdPair: config.ThresholdPair{Warning: 80, Critical: 95}},
+	}
+	section := newMetricSection(json.ModuleCloud, "cloud-metrics.yaml", defs, func(d *model.CloudMetricDefinition) (metricEntry, string) {
+		return definitionEntry(d.Name, d.DisplayName, d.Category, d.Query, d.IsPending()), d.Field
+	}, thresholds)
+
+	if len(section.Metrics) != 2 {
+		t.Fatalf("section has %d metrics, want 2", len(section.Metrics))
+	}
+	if got := section.Metrics[0].Threshold; got == nil || got.Warning != 70 || got.Direction != config.ThresholdDirectionAbove {
+		t.Errorf("CPU threshold = %+v, want ≥70 / ≥90", got)
+	}
+	if got := section.Metrics[1]; got.Status != "pending" || got.Threshold != nil {
+		t.Errorf("disk entry = %+v, want pending without threshold", got)
+	}
+	// Thresholds matching no definition are listed as derived thresholds
+	if len(section.Thresholds) != 1 || section.Thresholds[0].Metric != "connection_usage" {
+		t.Errorf("derived thresholds = %+v, want connection_usage", section.Thresholds)
+	}
+}
+
+func TestThresholdText(t *testing.T) {
+	tests := []struct {
+		threshold *metricThreshold
+		want      string
+	}{
+		{nil, "-"},
+		{&metricThreshold{Warning: 70, Critical: 90, Direction: config.ThresholdDirectionAbove}, "≥70 / ≥90"},
+		{&metricThreshold{Warning: 99, Critical: 95, Direction: config.ThresholdDirectionBelow}, "≤99 / ≤95"},
+		{&metricThreshold{Critical: 1, Direction: config.ThresholdDirectionAbove}, "- / ≥1"},
+		{&metricThreshold{Warning: 1048576, Critical: 1.5, Direction: config.ThresholdDirectionAbove}, "≥1048576 / ≥1.5"},
+	}
+	for _, tt := range tests {
+		if got := thresholdText(tt.threshold); got != tt.want {
+			t.Errorf("thresholdText(%+v) = %q, want %q", tt.threshold, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/cmdb"
+	"inspection-tool/internal/client/logs"
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/ssh"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/distributed"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// printDatasources prints the datasources the modules of the plan connect to.
+func printDatasources(cfg *config.Config, plan *modulePlan, logger zerolog.Logger) {
+	fmt.Println("🔗 连接数据源...")
+	if plan.host {
+		fmt.Printf("   - 夜莺 N9E: %s\n", cfg.Datasources.N9E.Endpoint)
+	}
+	fmt.Printf("   - %s: %s\n", metricsSourceName(cfg.Datasources.VictoriaMetrics.Type), cfg.Datasources.VictoriaMetrics.QueryURL())
+	if cache := cfg.Datasources.VictoriaMetrics.Cache; cache.Enabled {
+		ttl := cache.TTL
+		if ttl <= 0 {
+			ttl = vm.DefaultCacheTTL
+		}
+		fmt.Printf("   - 查询缓存: 已启用（有效期 %s，--no-cache 跳过）\n", ttl)
+	}
+	if limit := cfg.Datasources.VictoriaMetrics.RateLimit; limit.RequestsPerSecond > 0 {
+		fmt.Printf("   - 查询限速: %g 次/秒\n", limit.RequestsPerSecond)
+	}
+	if plan.host && cfg.Datasources.CMDB.Endpoint != "" {
+		fmt.Printf("   - CMDB: %s\n", cfg.Datasources.CMDB.Endpoint)
+	}
+	if plan.host && cfg.Inspection.SSHFallback.Enabled {
+		fmt.Printf("   - SSH 补采: 已启用（用户 %s）\n", cfg.Inspection.SSHFallback.User)
+	}
+	fmt.Println()
+	logger.Info().
+		Str("n9e_endpoint", cfg.Datasources.N9E.Endpoint).
+		Str("vm_endpoint", cfg.Datasources.VictoriaMetrics.QueryURL()).
+		Str("metrics_type", cfg.Datasources.VictoriaMetrics.Type).
+		Msg("connecting to data sources")
+}
+
+// moduleInspectors are the inspectors of the modules of a run. The inspectors of the
+// modules that are not inspected are nil.
+type moduleInspectors struct {
+	host       *service.Inspector
+	mysql      *service.MySQLInspector
+	redis      *service.RedisInspector
+	nginx      *service.NginxInspector
+	tomcat     *service.TomcatInspector
+	cassandra  *service.CassandraInspector
+	monitoring *service.MonitoringInspector
+	storage    *service.StorageInspector
+	logChecks  *service.LogCheckInspector
+	lvs        *service.LVSInspector
+	windows    *service.WindowsInspector
+	ad         *service.ADInspector
+	cloud      *service.CloudInspector
+	firewall   *service.FirewallInspector
+}
+
+// exitOnInspectorError exits when the inspector of a module could not be created. name is
+// the console name of the module, as "MySQL " or "" for the host inspector.
+func exitOnInspectorError(err error, name, logName string, logger zerolog.Logger) {
+	if err == nil {
+		return
+	}
+	logger.Error().Err(err).Msg("failed to create " + logName + " inspector")
+	fmt.Fprintf(os.Stderr, "❌ %s失败: %v\n", cjkJoin("创建", name+"巡检器"), err)
+	os.Exit(1)
+}
+
+// newModuleInspectors creates the datasource clients and the inspectors of the modules of
+// the plan. The business group tree, when set, expands the business groups of the host
+// queries; the host metrics are saved to and resumed from checkpoint. Exits when an
+// inspector cannot be created.
+func newModuleInspectors(cfg *config.Config, plan *modulePlan, defs *moduleDefinitions, groupTree *model.BusinessGroupTree, checkpoint service.MetricCheckpoint, logger zerolog.Logger) *moduleInspectors {
+	var err error
+
+	var n9eClient *n9e.Client
+	if plan.host {
+		n9eClient = n9e.NewClient(&cfg.Datasources.N9E, &cfg.HTTP.Retry, logger)
+	}
+	vmClient := vm.NewMetricsSource(&cfg.Datasources.VictoriaMetrics, &cfg.HTTP.Retry, logger)
+
+	// CMDB enriching the host metadata (optional)
+	var cmdbSource cmdb.Source
+	if plan.host {
+		if cmdbSource, err = cmdb.New(&cfg.Datasources.CMDB, &cfg.HTTP.Retry, logger); err != nil {
+			logger.Warn().Err(err).Msg("invalid CMDB configuration, host metadata not enriched")
+			fmt.Printf("⚠️  CMDB 配置无效，主机信息不做补充: %v\n", err)
+		}
+	}
+
+	// SSH fallback filling the host metrics missing from the backend (optional)
+	var sshRunner ssh.Runner
+	if plan.host && cfg.Inspection.SSHFallback.Enabled {
+		sshClient, err := ssh.NewClient(&cfg.Inspection.SSHFallback, logger)
+		if err != nil {
+			logger.Warn().Err(err).Msg("invalid SSH fallback configuration, missing metrics left as N/A")
+			fmt.Printf("⚠️  SSH 补采配置无效，缺失指标保持 N/A: %v\n", err)
+		} else {
+			sshRunner = sshClient
+		}
+	}
+
+	// Log excerpts for critical Nginx/Tomcat alerts (optional)
+	var logFetcher *service.LogExcerptFetcher
+	if (plan.nginx && cfg.Nginx.LogExcerpt.Enabled) || (plan.tomcat && cfg.Tomcat.LogExcerpt.Enabled) {
+		logsClient := logs.NewClient(&cfg.Datasources.Logs, &cfg.HTTP.Retry, logger)
+		logFetcher = service.NewLogExcerptFetcher(logsClient, logger)
+		logger.Debug().
+			Str("logs_type", cfg.Datasources.Logs.Type).
+			Str("logs_endpoint", cfg.Datasources.Logs.Endpoint).
+			Msg("log excerpt collection enabled")
+	}
+	logger.Debug().Msg("API clients created")
+
+	// Timezone of the evaluators that need one
+	timezone, _ := time.LoadLocation("Asia/Shanghai")
+
+	in := &moduleInspectors{}
+	if plan.host {
+		collector := service.NewCollector(cfg, n9eClient, vmClient, defs.host, logger, service.WithBusinessGroupTree(groupTree), service.WithCMDB(cmdbSource), service.WithSSHFallback(sshRunner), service.WithMetricCheckpoint(checkpoint))
+		evaluator := service.NewEvaluator(&cfg.Thresholds, defs.host, logger)
+		in.host, err = service.NewInspector(cfg, collector, evaluator, logger, service.WithVersion(Version))
+		exitOnInspectorError(err, "", "host", logger)
+		logger.Debug().Msg("host services initialized")
+	}
+	if plan.mysql {
+		collector := service.NewMySQLCollector(&cfg.MySQL, vmClient, defs.mysql, logger)
+		evaluator := service.NewMySQLEvaluator(&cfg.MySQL.Thresholds, defs.mysql, logger)
+		in.mysql, err = service.NewMySQLInspector(cfg, collector, evaluator, logger,
+			service.WithMySQLVersion(Version))
+		exitOnInspectorError(err, "MySQL ", "MySQL", logger)
+		logger.Debug().Msg("MySQL services initialized")
+	}
+	if plan.redis {
+		collector := service.NewRedisCollector(&cfg.Redis, vmClient, defs.redis, logger)
+		evaluator := service.NewRedisEvaluator(&cfg.Redis.Thresholds, defs.redis, logger)
+		in.redis, err = service.NewRedisInspector(cfg, collector, evaluator, logger,
+			service.WithRedisVersion(Version))
+		exitOnInspectorError(err, "Redis ", "Redis", logger)
+		logger.Debug().Msg("Redis services initialized")
+	}
+	if plan.nginx {
+		collector := service.NewNginxCollector(&cfg.Nginx, vmClient, n9eClient, defs.nginx, logger)
+		evaluator := service.NewNginxEvaluator(&cfg.Nginx.Thresholds, defs.nginx, timezone, logger)
+		in.nginx, err = service.NewNginxInspector(cfg, collector, evaluator, logger,
+			service.WithNginxVersion(Version), service.WithNginxLogExcerptFetcher(logFetcher))
+		exitOnInspectorError(err, "Nginx ", "Nginx", logger)
+		logger.Debug().Msg("Nginx services initialized")
+	}
+	if plan.tomcat {
+		collector := service.NewTomcatCollector(&cfg.Tomcat, vmClient, n9eClient, defs.tomcat, logger)
+		evaluator := service.NewTomcatEvaluator(&cfg.Tomcat.Thresholds, defs.tomcat, timezone, logger)
+		in.tomcat, err = service.NewTomcatInspector(cfg, collector, evaluator, logger,
+			service.WithTomcatVersion(Version), service.WithTomcatLogExcerptFetcher(logFetcher))
+		exitOnInspectorError(err, "Tomcat ", "Tomcat", logger)
+		logger.Debug().Msg("Tomcat services initialized")
+	}
+	if plan.cassandra {
+		collector := service.NewCassandraCollector(&cfg.Cassandra, vmClient, n9eClient, defs.cassandra, logger)
+		evaluator := service.NewCassandraEvaluator(&cfg.Cassandra.Thresholds, defs.cassandra, timezone, logger)
+		in.cassandra, err = service.NewCassandraInspector(cfg, collector, evaluator, logger,
+			service.WithCassandraVersion(Version))
+		exitOnInspectorError(err, "Cassandra ", "Cassandra", logger)
+		logger.Debug().Msg("Cassandra services initialized")
+	}
+	if plan.monitoring {
+		collector := service.NewMonitoringCollector(&cfg.Monitoring, vmClient, defs.monitoring, logger)
+		evaluator := service.NewMonitoringEvaluator(&cfg.Monitoring.Thresholds, defs.monitoring, timezone, logger)
+		in.monitoring, err = service.NewMonitoringInspector(cfg, collector, evaluator, logger,
+			service.WithMonitoringVersion(Version))
+		exitOnInspectorError(err, "监控系统", "monitoring", logger)
+		logger.Debug().Msg("monitoring services initialized")
+	}
+	if plan.storage {
+		collector := service.NewStorageCollector(&cfg.Storage, vmClient, n9eClient, defs.storage, logger)
+		evaluator := service.NewStorageEvaluator(&cfg.Storage.Thresholds, defs.storage, timezone, logger)
+		in.storage, err = service.NewStorageInspector(cfg, collector, evaluator, logger,
+			service.WithStorageVersion(Version))
+		exitOnInspectorError(err, "共享存储", "storage", logger)
+		logger.Debug().Msg("storage services initialized")
+	}
+	if plan.logChecks {
+		logsClient := logs.NewClient(&cfg.Datasources.Logs, &cfg.HTTP.Retry, logger)
+		collector := service.NewLogCheckCollector(&cfg.LogChecks, logsClient, defs.logChecks, logger)
+		evaluator := service.NewLogCheckEvaluator(collector.GetWindow(), timezone, logger)
+		in.logChecks, err = service.NewLogCheckInspector(cfg, collector, evaluator, logger,
+			service.WithLogCheckVersion(Version))
+		exitOnInspectorError(err, "日志", "log check", logger)
+		logger.Debug().
+			Str("logs_type", cfg.Datasources.Logs.Type).
+			Str("logs_endpoint", cfg.Datasources.Logs.Endpoint).
+			Msg("log checks services initialized")
+	}
+	if plan.lvs {
+		collector := service.NewLVSCollector(&cfg.LVS, vmClient, n9eClient, defs.lvs, logger)
+		evaluator := service.NewLVSEvaluator(&cfg.LVS.Thresholds, defs.lvs, timezone, logger)
+		in.lvs, err = service.NewLVSInspector(cfg, collector, evaluator, logger,
+			service.WithLVSVersion(Version))
+		exitOnInspectorError(err, "LVS ", "LVS", logger)
+		logger.Debug().Msg("LVS services initialized")
+	}
+	if plan.windows {
+		collector := service.NewWindowsCollector(&cfg.Windows, vmClient, n9eClient, defs.windows, logger)
+		evaluator := service.NewWindowsEvaluator(&cfg.Windows.Thresholds, defs.windows, timezone, logger)
+		in.windows, err = service.NewWindowsInspector(cfg, collector, evaluator, logger,
+			service.WithWindowsVersion(Version))
+		exitOnInspectorError(err, "Windows ", "Windows", logger)
+		logger.Debug().Strs("services", cfg.Windows.Services).Msg("Windows services initialized")
+	}
+	if plan.ad {
+		collector := service.NewADCollector(&cfg.AD, vmClient, n9eClient, defs.ad, logger)
+		evaluator := service.NewADEvaluator(&cfg.AD.Thresholds, defs.ad, timezone, logger)
+		in.ad, err = service.NewADInspector(cfg, collector, evaluator, logger,
+			service.WithADVersion(Version))
+		exitOnInspectorError(err, "AD ", "AD", logger)
+		logger.Debug().Str("sysvol_volume", cfg.AD.SysvolVolume).Msg("AD services initialized")
+	}
+	if plan.cloud {
+		collector := service.NewCloudCollector(&cfg.Cloud, vmClient, defs.cloud, logger)
+		evaluator := service.NewCloudEvaluator(&cfg.Cloud.Thresholds, defs.cloud, timezone, logger)
+		in.cloud, err = service.NewCloudInspector(cfg, collector, evaluator, logger,
+			service.WithCloudVersion(Version))
+		exitOnInspectorError(err, "云资源", "cloud", logger)
+		logger.Debug().Strs("providers", cfg.Cloud.InstanceFilter.Providers).Msg("cloud services initialized")
+	}
+	if plan.firewall {
+		collector := service.NewFirewallCollector(&cfg.Firewall, vmClient, defs.firewall, logger)
+		evaluator := service.NewFirewallEvaluator(&cfg.Firewall.Thresholds, defs.firewall, timezone, logger)
+		in.firewall, err = service.NewFirewallInspector(cfg, collector, evaluator, logger,
+			service.WithFirewallVersion(Version))
+		exitOnInspectorError(err, "防火墙", "firewall", logger)
+		logger.Debug().Strs("profiles", cfg.Firewall.InstanceFilter.Profiles).Msg("firewall services initialized")
+	}
+	return in
+}
+
+// timezone returns the timezone of the first inspector of the run, nil when no module is
+// inspected by this instance.
+func (in *moduleInspectors) timezone() *time.Location {
+	switch {
+	case in.host != nil:
+		return in.host.GetTimezone()
+	case in.mysql != nil:
+		return in.mysql.GetTimezone()
+	case in.redis != nil:
+		return in.redis.GetTimezone()
+	case in.nginx != nil:
+		return in.nginx.GetTimezone()
+	case in.tomcat != nil:
+		return in.tomcat.GetTimezone()
+	case in.cassandra != nil:
+		return in.cassandra.GetTimezone()
+	case in.monitoring != nil:
+		return in.monitoring.GetTimezone()
+	case in.storage != nil:
+		return in.storage.GetTimezone()
+	case in.logChecks != nil:
+		return in.logChecks.GetTimezone()
+	case in.lvs != nil:
+		return in.lvs.GetTimezone()
+	case in.windows != nil:
+		return in.windows.GetTimezone()
+	case in.ad != nil:
+		return in.ad.GetTimezone()
+	case in.cloud != nil:
+		return in.cloud.GetTimezone()
+	case in.firewall != nil:
+		return in.firewall.GetTimezone()
+	}
+	return nil
+}
+
+// moduleRunner runs the inspections of the modules one after another, collecting their
+// results into report.
+type moduleRunner struct {
+	report     *json.Report
+	progress   *moduleProgress
+	checkpoint *inspectionCheckpoint
+	ci         *ciReporter
+	logger     zerolog.Logger
+}
+
+// run runs the inspections of the modules of the plan.
+func (r *moduleRunner) run(ctx context.Context, plan *modulePlan, in *moduleInspectors) {
+	if plan.host {
+		r.ci.startGroup("主机巡检")
+		fmt.Printf("⏳ %s开始主机巡检...\n", r.progress.start())
+		result, err := in.host.Run(r.progress.track(ctx, json.ModuleHost))
+		r.progress.stop(err == nil)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("host inspection failed")
+			fmt.Fprintf(os.Stderr, "❌ 主机巡检执行失败: %v\n", err)
+			r.ci.error("主机巡检执行失败", err)
+			r.checkpoint.finish() // Host metrics collected before the failure are resumed
+			os.Exit(1)
+		}
+		r.report.Host = result
+		fmt.Printf("\n📊 主机巡检完成！%s\n", r.progress.elapsed())
+		printSummary(result)
+		r.checkpoint.complete(json.ModuleHost, result)
+	}
+	runModule(ctx, r, plan.mysql, json.ModuleMySQL, "MySQL", in.mysql.Inspect, &r.report.MySQL, printMySQLSummary)
+	runModule(ctx, r, plan.redis, json.ModuleRedis, "Redis", in.redis.Inspect, &r.report.Redis, printRedisSummary)
+	runModule(ctx, r, plan.nginx, json.ModuleNginx, "Nginx", in.nginx.Inspect, &r.report.Nginx, printNginxSummary)
+	runModule(ctx, r, plan.tomcat, json.ModuleTomcat, "Tomcat", in.tomcat.Inspect, &r.report.Tomcat, printTomcatSummary)
+	runModule(ctx, r, plan.cassandra, json.ModuleCassandra, "Cassandra", in.cassandra.Inspect, &r.report.Cassandra, printCassandraSummary)
+	runModule(ctx, r, plan.monitoring, json.ModuleMonitoring, "monitoring", in.monitoring.Inspect, &r.report.Monitoring, printMonitoringSummary)
+	runModule(ctx, r, plan.storage, json.ModuleStorage, "storage", in.storage.Inspect, &r.report.Storage, printStorageSummary)
+	runModule(ctx, r, plan.logChecks, json.ModuleLogChecks, "log checks", in.logChecks.Inspect, &r.report.LogChecks, printLogCheckSummary)
+	runModule(ctx, r, plan.lvs, json.ModuleLVS, "LVS", in.lvs.Inspect, &r.report.LVS, printLVSSummary)
+	runModule(ctx, r, plan.windows, json.ModuleWindows, "Windows", in.windows.Inspect, &r.report.Windows, printWindowsSummary)
+	runModule(ctx, r, plan.ad, json.ModuleAD, "AD", in.ad.Inspect, &r.report.AD, printADSummary)
+	runModule(ctx, r, plan.cloud, json.ModuleCloud, "cloud", in.cloud.Inspect, &r.report.Cloud, printCloudSummary)
+	runModule(ctx, r, plan.firewall, json.ModuleFirewall, "firewall", in.firewall.Inspect, &r.report.Firewall, printFirewallSummary)
+}
+
+// runModule runs the inspection of one module into result when run is set. A failed
+// inspection is reported and skipped so that the reports of the other modules are still
+// generated; the run exits when no module has a result.
+func runModule[R any](ctx context.Context, r *moduleRunner, run bool, key, logName string, inspect func(context.Context) (*R, error), result **R, printSummary func(*R)) {
+	if !run {
+		return
+	}
+	title := moduleTitles[key]
+	r.ci.startGroup(title)
+	fmt.Printf("\n⏳ %s...\n", cjkJoin(r.progress.start()+"开始", title))
+	res, err := inspect(r.progress.track(ctx, key))
+	r.progress.stop(err == nil)
+	if err != nil {
+		r.logger.Error().Err(err).Msg(logName + " inspection failed")
+		fmt.Fprintf(os.Stderr, "❌ %s执行失败: %v\n", title, err)
+		r.ci.error(title+"执行失败", err)
+		if r.report.Empty() {
+			os.Exit(1)
+		}
+		return
+	}
+	*result = res
+	fmt.Printf("\n📊 %s完成！%s\n", title, r.progress.elapsed())
+	printSummary(res)
+	r.checkpoint.complete(key, res)
+}
+
+// runDistributed has the workers inspect their assignments and returns their merged results.
+// Exits when the distributed inspection fails.
+func runDistributed(cfg *config.Config, assignments []*distributed.Assignment, ci *ciReporter, logger zerolog.Logger) *json.Report {
+	ci.startGroup("分布式巡检")
+	fmt.Println("⏳ 分发巡检任务...")
+	tlsConfig, err := cfg.Distributed.TLS.ClientConfig()
+	if err != nil {
+		logger.Error().Err(err).Msg("invalid distributed TLS configuration")
+		fmt.Fprintf(os.Stderr, "❌ 分布式巡检 TLS 配置无效: %v\n", err)
+		ci.error("分布式巡检 TLS 配置无效", err)
+		os.Exit(1)
+	}
+	coordinator := distributed.NewCoordinator(cfg.Distributed.Token, cfg.Distributed.Timeout, tlsConfig, logger)
+	report, err := coordinator.Run(context.Background(), assignments)
+	if err != nil {
+		logger.Error().Err(err).Msg("distributed inspection failed")
+		fmt.Fprintf(os.Stderr, "❌ 分布式巡检执行失败: %v\n", err)
+		ci.error("分布式巡检执行失败", err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n📊 分布式巡检完成！\n")
+	if report.Host != nil {
+		printSummary(report.Host)
+	}
+	return report
+}
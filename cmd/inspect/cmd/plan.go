@@ -0,0 +1,532 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/distributed"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// moduleTitles are the console names of the inspection of each module.
+var moduleTitles = map[string]string{
+	json.ModuleHost:       "主机巡检",
+	json.ModuleMySQL:      "MySQL 巡检",
+	json.ModuleRedis:      "Redis 巡检",
+	json.ModuleNginx:      "Nginx 巡检",
+	json.ModuleTomcat:     "Tomcat 巡检",
+	json.ModuleCassandra:  "Cassandra 巡检",
+	json.ModuleMonitoring: "监控系统巡检",
+	json.ModuleStorage:    "共享存储巡检",
+	json.ModuleLogChecks:  "日志巡检",
+	json.ModuleLVS:        "LVS 巡检",
+	json.ModuleWindows:    "Windows 服务巡检",
+	json.ModuleAD:         "AD 域控制器巡检",
+	json.ModuleCloud:      "云资源巡检",
+	json.ModuleFirewall:   "防火墙巡检",
+}
+
+// cjkJoin appends phrase to Chinese text, with a space before a phrase that starts with a
+// Latin name, as in "开始 MySQL 巡检" and "开始监控系统巡检".
+func cjkJoin(text, phrase string) string {
+	if phrase != "" && phrase[0] < utf8.RuneSelf {
+		return text + " " + phrase
+	}
+	return text + phrase
+}
+
+// modulePlan is whether this run inspects each module.
+type modulePlan struct {
+	host       bool
+	mysql      bool
+	redis      bool
+	nginx      bool
+	tomcat     bool
+	cassandra  bool
+	monitoring bool
+	storage    bool
+	logChecks  bool
+	lvs        bool
+	windows    bool
+	ad         bool
+	cloud      bool
+	firewall   bool
+}
+
+// runs returns the modules of the plan with their run flags, in report order.
+func (p *modulePlan) runs() []moduleRun {
+	return []moduleRun{
+		{json.ModuleHost, &p.host}, {json.ModuleMySQL, &p.mysql},
+		{json.ModuleRedis, &p.redis}, {json.ModuleNginx, &p.nginx},
+		{json.ModuleTomcat, &p.tomcat}, {json.ModuleCassandra, &p.cassandra},
+		{json.ModuleMonitoring, &p.monitoring}, {json.ModuleStorage, &p.storage},
+		{json.ModuleLogChecks, &p.logChecks}, {json.ModuleLVS, &p.lvs},
+		{json.ModuleWindows, &p.windows}, {json.ModuleAD, &p.ad},
+		{json.ModuleCloud, &p.cloud}, {json.ModuleFirewall, &p.firewall},
+	}
+}
+
+// onlyFlag is a --*-only flag and the module it selects.
+type onlyFlag struct {
+	module  string
+	flag    string
+	set     bool
+	enabled bool // The module is enabled in the configuration
+}
+
+// onlyFlags returns the --*-only flags, in report order.
+func onlyFlags(cfg *config.Config) []onlyFlag {
+	return []onlyFlag{
+		{json.ModuleMySQL, "--mysql-only", mysqlOnly, cfg.MySQL.Enabled},
+		{json.ModuleRedis, "--redis-only", redisOnly, cfg.Redis.Enabled},
+		{json.ModuleNginx, "--nginx-only", nginxOnly, cfg.Nginx.Enabled},
+		{json.ModuleTomcat, "--tomcat-only", tomcatOnly, cfg.Tomcat.Enabled},
+		{json.ModuleCassandra, "--cassandra-only", cassandraOnly, cfg.Cassandra.Enabled},
+		{json.ModuleMonitoring, "--monitoring-only", monitoringOnly, cfg.Monitoring.Enabled},
+		{json.ModuleStorage, "--storage-only", storageOnly, cfg.Storage.Enabled},
+		{json.ModuleLogChecks, "--log-checks-only", logChecksOnly, cfg.LogChecks.Enabled},
+		{json.ModuleLVS, "--lvs-only", lvsOnly, cfg.LVS.Enabled},
+		{json.ModuleWindows, "--windows-only", windowsOnly, cfg.Windows.Enabled},
+		{json.ModuleAD, "--ad-only", adOnly, cfg.AD.Enabled},
+		{json.ModuleCloud, "--cloud-only", cloudOnly, cfg.Cloud.Enabled},
+		{json.ModuleFirewall, "--firewall-only", firewallOnly, cfg.Firewall.Enabled},
+	}
+}
+
+// validateOnlyFlags checks that a --*-only flag is neither combined with the --skip-* flag
+// of its module nor with another --*-only flag.
+func validateOnlyFlags() error {
+	if mysqlOnly && skipMySQL {
+		return errors.New("--mysql-only 和 --skip-mysql 不能同时使用")
+	}
+	if redisOnly && skipRedis {
+		return errors.New("--redis-only 和 --skip-redis 不能同时使用")
+	}
+	if redisOnly && mysqlOnly {
+		return errors.New("--redis-only 和 --mysql-only 不能同时使用")
+	}
+	if nginxOnly && skipNginx {
+		return errors.New("--nginx-only 和 --skip-nginx 不能同时使用")
+	}
+	if nginxOnly && mysqlOnly {
+		return errors.New("--nginx-only 和 --mysql-only 不能同时使用")
+	}
+	if nginxOnly && redisOnly {
+		return errors.New("--nginx-only 和 --redis-only 不能同时使用")
+	}
+
+	// Tomcat flag validation
+	if tomcatOnly && skipTomcat {
+		return errors.New("--tomcat-only 和 --skip-tomcat 不能同时使用")
+	}
+	if tomcatOnly && mysqlOnly {
+		return errors.New("--tomcat-only 和 --mysql-only 不能同时使用")
+	}
+	if tomcatOnly && redisOnly {
+		return errors.New("--tomcat-only 和 --redis-only 不能同时使用")
+	}
+	if tomcatOnly && nginxOnly {
+		return errors.New("--tomcat-only 和 --nginx-only 不能同时使用")
+	}
+
+	// Later modules share one message for every other --*-only flag
+	skipped := []struct {
+		only, skip bool
+		name       string
+	}{
+		{cassandraOnly, skipCassandra, "cassandra"},
+		{monitoringOnly, skipMonitoring, "monitoring"},
+		{storageOnly, skipStorage, "storage"},
+		{logChecksOnly, skipLogChecks, "log-checks"},
+		{lvsOnly, skipLVS, "lvs"},
+		{windowsOnly, skipWindows, "windows"},
+		{adOnly, skipAD, "ad"},
+		{cloudOnly, skipCloud, "cloud"},
+		{firewallOnly, skipFirewall, "firewall"},
+	}
+	earlierOnly := mysqlOnly || redisOnly || nginxOnly || tomcatOnly
+	for _, m := range skipped {
+		if m.only && m.skip {
+			return fmt.Errorf("--%s-only 和 --skip-%s 不能同时使用", m.name, m.name)
+		}
+		if m.only && earlierOnly {
+			return fmt.Errorf("--%s-only 不能与其他 --*-only 参数同时使用", m.name)
+		}
+		earlierOnly = earlierOnly || m.only
+	}
+	return nil
+}
+
+// planModules determines the modules to inspect with selectModules and prints the modules
+// left by a module selection. Exits when the selection cannot be run.
+func planModules(cfg *config.Config, preset *config.Preset) *modulePlan {
+	plan, err := selectModules(cfg, preset)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+	if selection := moduleSelection(cfg); len(selection.Only) > 0 || len(selection.Skip) > 0 {
+		var names []string
+		for _, m := range plan.runs() {
+			if *m.run {
+				names = append(names, json.ModuleName(m.key))
+			}
+		}
+		fmt.Printf("🧩 巡检模块: %s\n", strings.Join(names, "、"))
+	}
+	return plan
+}
+
+// moduleSelection returns the module selection of the run: --only / --skip override
+// inspection.modules.
+func moduleSelection(cfg *config.Config) config.ModuleSelection {
+	selection := cfg.Inspection.Modules
+	if len(onlyModules) > 0 {
+		selection.Only = onlyModules
+	}
+	if len(skipModules) > 0 {
+		selection.Skip = skipModules
+	}
+	return selection
+}
+
+// selectModules determines the modules to inspect from the --*-only and --skip-* flags, the
+// enabled modules of the configuration, the preset and the module selection. Returns an
+// error when the selection cannot be run.
+func selectModules(cfg *config.Config, preset *config.Preset) (*modulePlan, error) {
+	flags := onlyFlags(cfg)
+	anyOnly := slices.ContainsFunc(flags, func(o onlyFlag) bool { return o.set })
+	runs := func(skip, only, enabled bool) bool {
+		return !skip && (!anyOnly || only) && enabled
+	}
+	plan := &modulePlan{
+		host:       !skipHost && !anyOnly,
+		mysql:      runs(skipMySQL, mysqlOnly, cfg.MySQL.Enabled),
+		redis:      runs(skipRedis, redisOnly, cfg.Redis.Enabled),
+		nginx:      runs(skipNginx, nginxOnly, cfg.Nginx.Enabled),
+		tomcat:     runs(skipTomcat, tomcatOnly, cfg.Tomcat.Enabled),
+		cassandra:  runs(skipCassandra, cassandraOnly, cfg.Cassandra.Enabled),
+		monitoring: runs(skipMonitoring, monitoringOnly, cfg.Monitoring.Enabled),
+		storage:    runs(skipStorage, storageOnly, cfg.Storage.Enabled),
+		logChecks:  runs(skipLogChecks, logChecksOnly, cfg.LogChecks.Enabled),
+		lvs:        runs(skipLVS, lvsOnly, cfg.LVS.Enabled),
+		windows:    runs(skipWindows, windowsOnly, cfg.Windows.Enabled),
+		ad:         runs(skipAD, adOnly, cfg.AD.Enabled),
+		cloud:      runs(skipCloud, cloudOnly, cfg.Cloud.Enabled),
+		firewall:   runs(skipFirewall, firewallOnly, cfg.Firewall.Enabled),
+	}
+
+	// Preset: only the modules of the preset are inspected
+	if preset != nil {
+		for _, o := range flags {
+			if o.set && !preset.RunsModule(o.module) {
+				return nil, fmt.Errorf("巡检预设 %s 不包含 %s 对应的模块", preset.Name, o.flag)
+			}
+		}
+		for _, m := range plan.runs() {
+			*m.run = *m.run && preset.RunsModule(m.key)
+		}
+	}
+
+	// Module selection: --only / --skip override inspection.modules
+	selection := moduleSelection(cfg)
+	if err := selection.CheckNames(); err != nil {
+		return nil, fmt.Errorf("模块选择无效: %w", err)
+	}
+	enabled := map[string]bool{json.ModuleHost: true}
+	for _, o := range flags {
+		enabled[o.module] = o.enabled
+	}
+	for _, m := range plan.runs() {
+		if slices.Contains(selection.Only, m.key) && !slices.Contains(selection.Skip, m.key) && !enabled[m.key] {
+			return nil, fmt.Errorf("模块 %s 未启用，请在配置文件中设置 %s.enabled: true", json.ModuleName(m.key), m.key)
+		}
+		*m.run = *m.run && selection.Runs(m.key)
+	}
+	if len(selection.Only) > 0 || len(selection.Skip) > 0 {
+		if !slices.ContainsFunc(plan.runs(), func(m moduleRun) bool { return *m.run }) {
+			return nil, errors.New("模块选择后没有可执行的巡检模块")
+		}
+	}
+
+	// A --*-only flag of a module that is not enabled
+	for _, o := range flags {
+		if o.set && !o.enabled {
+			return nil, fmt.Errorf("%s未启用，请在配置文件中设置 %s.enabled: true", moduleTitles[o.module], o.module)
+		}
+	}
+
+	return plan, nil
+}
+
+// log logs the plan with the enabled modules of the configuration.
+func (p *modulePlan) log(cfg *config.Config, logger zerolog.Logger) {
+	logger.Debug().
+		Bool("run_host", p.host).
+		Bool("run_mysql", p.mysql).
+		Bool("run_redis", p.redis).
+		Bool("run_nginx", p.nginx).
+		Bool("run_tomcat", p.tomcat).
+		Bool("run_cassandra", p.cassandra).
+		Bool("run_monitoring", p.monitoring).
+		Bool("run_storage", p.storage).
+		Bool("run_log_checks", p.logChecks).
+		Bool("run_lvs", p.lvs).
+		Bool("run_windows", p.windows).
+		Bool("run_ad", p.ad).
+		Bool("run_cloud", p.cloud).
+		Bool("run_firewall", p.firewall).
+		Bool("mysql_enabled", cfg.MySQL.Enabled).
+		Bool("redis_enabled", cfg.Redis.Enabled).
+		Bool("nginx_enabled", cfg.Nginx.Enabled).
+		Bool("tomcat_enabled", cfg.Tomcat.Enabled).
+		Bool("cassandra_enabled", cfg.Cassandra.Enabled).
+		Bool("monitoring_enabled", cfg.Monitoring.Enabled).
+		Bool("storage_enabled", cfg.Storage.Enabled).
+		Bool("log_checks_enabled", cfg.LogChecks.Enabled).
+		Bool("lvs_enabled", cfg.LVS.Enabled).
+		Bool("windows_enabled", cfg.Windows.Enabled).
+		Bool("ad_enabled", cfg.AD.Enabled).
+		Bool("cloud_enabled", cfg.Cloud.Enabled).
+		Bool("firewall_enabled", cfg.Firewall.Enabled).
+		Msg("execution mode determined")
+}
+
+// planWorkers assigns the modules of the plan to the distributed workers. The modules are
+// then inspected by the workers only, so they are cleared from the plan: this instance
+// merges the results of the workers and renders the reports.
+func planWorkers(cfg *config.Config, plan *modulePlan) []*distributed.Assignment {
+	var modules []string
+	for _, m := range plan.runs() {
+		if *m.run {
+			modules = append(modules, m.key)
+			*m.run = false
+		}
+	}
+	assignments := distributed.Plan(cfg.Distributed.Workers, cfg.Inspection.HostFilter.BusinessGroups, modules)
+	// Workers drop the hosts excluded by the coordinator on top of their own exclusions
+	if len(excludeHosts) > 0 {
+		for _, a := range assignments {
+			a.Job.ExcludeHosts = excludeHosts
+		}
+	}
+	// Workers aggregate over the window of the coordinator
+	if rangeStart != "" || rangeEnd != "" {
+		for _, a := range assignments {
+			a.Job.RangeStart = cfg.Inspection.RangeEnd.Add(-cfg.Inspection.RangeWindow)
+			a.Job.RangeEnd = cfg.Inspection.RangeEnd
+		}
+	}
+	fmt.Printf("🌐 分布式巡检: %d 个 worker\n", len(assignments))
+	for _, a := range assignments {
+		fmt.Printf("   - %s: %s\n", a.Worker, a.Job)
+	}
+	return assignments
+}
+
+// moduleDefinitions are the metric definitions of the modules of a run. The definitions of
+// the modules that are not inspected are nil.
+type moduleDefinitions struct {
+	host       []*model.MetricDefinition
+	mysql      []*model.MySQLMetricDefinition
+	redis      []*model.RedisMetricDefinition
+	nginx      []*model.NginxMetricDefinition
+	tomcat     []*model.TomcatMetricDefinition
+	cassandra  []*model.CassandraMetricDefinition
+	monitoring []*model.MonitoringMetricDefinition
+	storage    []*model.StorageMetricDefinition
+	logChecks  []*model.LogCheckDefinition
+	lvs        []*model.LVSMetricDefinition
+	windows    []*model.WindowsMetricDefinition
+	ad         []*model.ADMetricDefinition
+	cloud      []*model.CloudMetricDefinition
+	firewall   []*model.FirewallMetricDefinition
+}
+
+// definitionFile describes the definition file of a module for loadDefinitions.
+type definitionFile struct {
+	title   string // Console name, as "MySQL 指标定义"
+	unit    string // Console name of one definition, as "指标"
+	logName string // Log name, as "MySQL metrics"
+	logUnit string // Log field suffix of the counts, as "metrics"
+	path    string
+}
+
+// definitionLoader loads the definition files of a run and prints its progress to out. Once
+// a file fails to load, the later files are skipped and err holds the failure.
+type definitionLoader struct {
+	out    io.Writer
+	logger zerolog.Logger
+	err    error
+}
+
+// loadDefinitions loads the definitions of file and prints how many are active. filter, when
+// set, drops definitions before they are counted. Returns nil when the file or an earlier
+// one cannot be loaded.
+func loadDefinitions[D any](l *definitionLoader, file definitionFile, load func(string) ([]*D, error), filter func([]*D) []*D, countActive func([]*D) int) []*D {
+	if l.err != nil {
+		return nil
+	}
+	fmt.Fprintf(l.out, "📊 %s: %s", cjkJoin("加载", file.title), file.path)
+	defs, err := load(file.path)
+	if err != nil {
+		l.logger.Error().Err(err).Str("path", file.path).Msg("failed to load " + file.logName)
+		l.err = fmt.Errorf("%s失败: %w", cjkJoin("加载", file.title), err)
+		return nil
+	}
+	if filter != nil {
+		defs = filter(defs)
+	}
+	activeCount := countActive(defs)
+	fmt.Fprintf(l.out, " (%d 个活跃%s)\n", activeCount, file.unit)
+	l.logger.Debug().Int("active_"+file.logUnit, activeCount).Int("total_"+file.logUnit, len(defs)).Msg(file.logName + " loaded")
+	return defs
+}
+
+// loadModuleDefinitions loads the metric definitions of the modules of the plan, printing
+// the progress to out. The preset drops the host, MySQL and Redis metrics it leaves out, and
+// the host metrics are aggregated over the range window when one is set.
+func loadModuleDefinitions(cfg *config.Config, plan *modulePlan, preset *config.Preset, out io.Writer, logger zerolog.Logger) (*moduleDefinitions, error) {
+	l := &definitionLoader{out: out, logger: logger}
+	defs := &moduleDefinitions{}
+	if plan.host {
+		defs.host = loadDefinitions(l, definitionFile{"主机指标定义", "指标", "host metrics", "metrics", metricsPath}, config.LoadMetrics,
+			func(metrics []*model.MetricDefinition) []*model.MetricDefinition {
+				if preset != nil {
+					metrics = preset.FilterMetrics(metrics)
+				}
+				return service.ApplyRangeFunctions(metrics, &cfg.Inspection)
+			}, config.CountActiveMetrics)
+		if ranged := countRangeMetrics(defs.host); ranged > 0 {
+			fmt.Fprintf(out, "📈 范围聚合: %d 个指标按 %s 窗口聚合%s\n", ranged, cfg.Inspection.RangeWindow, rangeEndText(cfg.Inspection.RangeEnd, cfg.Report.Timezone))
+		}
+	}
+	if plan.mysql {
+		var filter func([]*model.MySQLMetricDefinition) []*model.MySQLMetricDefinition
+		if preset != nil {
+			filter = preset.FilterMySQLMetrics
+		}
+		defs.mysql = loadDefinitions(l, definitionFile{"MySQL 指标定义", "指标", "MySQL metrics", "metrics", mysqlMetricsPath}, config.LoadMySQLMetrics, filter, config.CountActiveMySQLMetrics)
+	}
+	if plan.redis {
+		var filter func([]*model.RedisMetricDefinition) []*model.RedisMetricDefinition
+		if preset != nil {
+			filter = preset.FilterRedisMetrics
+		}
+		defs.redis = loadDefinitions(l, definitionFile{"Redis 指标定义", "指标", "Redis metrics", "metrics", redisMetricsPath}, config.LoadRedisMetrics, filter, config.CountActiveRedisMetrics)
+	}
+	if plan.nginx {
+		defs.nginx = loadDefinitions(l, definitionFile{"Nginx 指标定义", "指标", "Nginx metrics", "metrics", nginxMetricsPath}, config.LoadNginxMetrics, nil, config.CountActiveNginxMetrics)
+	}
+	if plan.tomcat {
+		defs.tomcat = loadDefinitions(l, definitionFile{"Tomcat 指标定义", "指标", "Tomcat metrics", "metrics", tomcatMetricsPath}, config.LoadTomcatMetrics, nil, config.CountActiveTomcatMetrics)
+	}
+	if plan.cassandra {
+		defs.cassandra = loadDefinitions(l, definitionFile{"Cassandra 指标定义", "指标", "Cassandra metrics", "metrics", cassandraMetricsPath}, config.LoadCassandraMetrics, nil, config.CountActiveCassandraMetrics)
+	}
+	if plan.monitoring {
+		defs.monitoring = loadDefinitions(l, definitionFile{"监控系统指标定义", "指标", "monitoring metrics", "metrics", monitoringMetricsPath}, config.LoadMonitoringMetrics, nil, config.CountActiveMonitoringMetrics)
+	}
+	if plan.storage {
+		defs.storage = loadDefinitions(l, definitionFile{"共享存储指标定义", "指标", "storage metrics", "metrics", storageMetricsPath}, config.LoadStorageMetrics, nil, config.CountActiveStorageMetrics)
+	}
+	if plan.logChecks {
+		defs.logChecks = loadDefinitions(l, definitionFile{"日志检查项定义", "检查项", "log checks", "checks", logChecksPath}, config.LoadLogChecks, nil, config.CountActiveLogChecks)
+	}
+	if plan.lvs {
+		defs.lvs = loadDefinitions(l, definitionFile{"LVS 指标定义", "指标", "LVS metrics", "metrics", lvsMetricsPath}, config.LoadLVSMetrics, nil, config.CountActiveLVSMetrics)
+	}
+	if plan.windows {
+		defs.windows = loadDefinitions(l, definitionFile{"Windows 指标定义", "指标", "Windows metrics", "metrics", windowsMetricsPath}, config.LoadWindowsMetrics, nil, config.CountActiveWindowsMetrics)
+	}
+	if plan.ad {
+		defs.ad = loadDefinitions(l, definitionFile{"AD 指标定义", "指标", "AD metrics", "metrics", adMetricsPath}, config.LoadADMetrics, nil, config.CountActiveADMetrics)
+	}
+	if plan.cloud {
+		defs.cloud = loadDefinitions(l, definitionFile{"云资源指标定义", "指标", "cloud metrics", "metrics", cloudMetricsPath}, config.LoadCloudMetrics, nil, config.CountActiveCloudMetrics)
+	}
+	if plan.firewall {
+		defs.firewall = loadDefinitions(l, definitionFile{"防火墙指标定义", "指标", "firewall metrics", "metrics", firewallMetricsPath}, config.LoadFirewallMetrics, nil, config.CountActiveFirewallMetrics)
+	}
+	if l.err != nil {
+		return nil, l.err
+	}
+	return defs, nil
+}
+
+// rawDataQueries maps each raw data module to the queries of its metric definitions.
+func (d *moduleDefinitions) rawDataQueries() map[string]map[string]string {
+	return map[string]map[string]string{
+		model.RawDataModuleMySQL:      metricQueries(d.mysql, func(d *model.MySQLMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleRedis:      metricQueries(d.redis, func(d *model.RedisMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleNginx:      metricQueries(d.nginx, func(d *model.NginxMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleTomcat:     metricQueries(d.tomcat, func(d *model.TomcatMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleCassandra:  metricQueries(d.cassandra, func(d *model.CassandraMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleMonitoring: metricQueries(d.monitoring, func(d *model.MonitoringMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleStorage:    metricQueries(d.storage, func(d *model.StorageMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleLogChecks:  metricQueries(d.logChecks, func(d *model.LogCheckDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleLVS:        metricQueries(d.lvs, func(d *model.LVSMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleWindows:    metricQueries(d.windows, func(d *model.WindowsMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleAD:         metricQueries(d.ad, func(d *model.ADMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleCloud:      metricQueries(d.cloud, func(d *model.CloudMetricDefinition) (string, string) { return d.Name, d.Query }),
+		model.RawDataModuleFirewall:   metricQueries(d.firewall, func(d *model.FirewallMetricDefinition) (string, string) { return d.Name, d.Query }),
+	}
+}
+
+// dryRunModules returns the modules of the plan with the collectors printing their queries.
+func dryRunModules(cfg *config.Config, plan *modulePlan, defs *moduleDefinitions, logger zerolog.Logger) []dryRunModule {
+	return []dryRunModule{
+		{json.ModuleHost, plan.host, func() []service.PlannedQuery {
+			return service.NewCollector(cfg, nil, nil, defs.host, logger).PlannedQueries()
+		}},
+		{json.ModuleMySQL, plan.mysql, func() []service.PlannedQuery {
+			return service.NewMySQLCollector(&cfg.MySQL, nil, defs.mysql, logger).PlannedQueries()
+		}},
+		{json.ModuleRedis, plan.redis, func() []service.PlannedQuery {
+			return service.NewRedisCollector(&cfg.Redis, nil, defs.redis, logger).PlannedQueries()
+		}},
+		{json.ModuleNginx, plan.nginx, func() []service.PlannedQuery {
+			return service.NewNginxCollector(&cfg.Nginx, nil, nil, defs.nginx, logger).PlannedQueries()
+		}},
+		{json.ModuleTomcat, plan.tomcat, func() []service.PlannedQuery {
+			return service.NewTomcatCollector(&cfg.Tomcat, nil, nil, defs.tomcat, logger).PlannedQueries()
+		}},
+		{json.ModuleCassandra, plan.cassandra, func() []service.PlannedQuery {
+			return service.NewCassandraCollector(&cfg.Cassandra, nil, nil, defs.cassandra, logger).PlannedQueries()
+		}},
+		{json.ModuleMonitoring, plan.monitoring, func() []service.PlannedQuery {
+			return service.NewMonitoringCollector(&cfg.Monitoring, nil, defs.monitoring, logger).PlannedQueries()
+		}},
+		{json.ModuleStorage, plan.storage, func() []service.PlannedQuery {
+			return service.NewStorageCollector(&cfg.Storage, nil, nil, defs.storage, logger).PlannedQueries()
+		}},
+		{json.ModuleLogChecks, plan.logChecks, func() []service.PlannedQuery {
+			return service.NewLogCheckCollector(&cfg.LogChecks, nil, defs.logChecks, logger).PlannedQueries()
+		}},
+		{json.ModuleLVS, plan.lvs, func() []service.PlannedQuery {
+			return service.NewLVSCollector(&cfg.LVS, nil, nil, defs.lvs, logger).PlannedQueries()
+		}},
+		{json.ModuleWindows, plan.windows, func() []service.PlannedQuery {
+			return service.NewWindowsCollector(&cfg.Windows, nil, nil, defs.windows, logger).PlannedQueries()
+		}},
+		{json.ModuleAD, plan.ad, func() []service.PlannedQuery {
+			return service.NewADCollector(&cfg.AD, nil, nil, defs.ad, logger).PlannedQueries()
+		}},
+		{json.ModuleCloud, plan.cloud, func() []service.PlannedQuery {
+			return service.NewCloudCollector(&cfg.Cloud, nil, defs.cloud, logger).PlannedQueries()
+		}},
+		{json.ModuleFirewall, plan.firewall, func() []service.PlannedQuery {
+			return service.NewFirewallCollector(&cfg.Firewall, nil, defs.firewall, logger).PlannedQueries()
+		}},
+	}
+}
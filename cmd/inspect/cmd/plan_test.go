@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"testing"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+func TestCJKJoin(t *testing.T) {
+	tests := []struct {
+		text, phrase, want string
+	}{
+		{"开始", "MySQL 巡检", "开始 MySQL 巡检"},
+		{"开始", "监控系统巡检", "开始监控系统巡检"},
+		{"[1/2] 开始", "AD 域控制器巡检", "[1/2] 开始 AD 域控制器巡检"},
+		{"创建", "巡检器", "创建巡检器"},
+		{"加载", "", "加载"},
+	}
+	for _, tt := range tests {
+		if got := cjkJoin(tt.text, tt.phrase); got != tt.want {
+			t.Errorf("cjkJoin(%q, %q) = %q, want %q", tt.text, tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestValidateOnlyFlags(t *testing.T) {
+	defer func() {
+		mysqlOnly, skipMySQL, redisOnly, lvsOnly, skipLVS, cloudOnly = false, false, false, false, false, false
+	}()
+
+	tests := []struct {
+		name string
+		set  func()
+		want string
+	}{
+		{"no flags", func() {}, ""},
+		{"only with skip", func() { mysqlOnly, skipMySQL = true, true }, "--mysql-only 和 --skip-mysql 不能同时使用"},
+		{"two early only flags", func() { mysqlOnly, redisOnly = true, true }, "--redis-only 和 --mysql-only 不能同时使用"},
+		{"later only with skip", func() { lvsOnly, skipLVS = true, true }, "--lvs-only 和 --skip-lvs 不能同时使用"},
+		{"later only with earlier only", func() { lvsOnly, cloudOnly = true, true }, "--cloud-only 不能与其他 --*-only 参数同时使用"},
+		{"single only", func() { cloudOnly = true }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mysqlOnly, skipMySQL, redisOnly, lvsOnly, skipLVS, cloudOnly = false, false, false, false, false, false
+			tt.set()
+			err := validateOnlyFlags()
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("validateOnlyFlags() = %v, want nil", err)
+			case tt.want != "" && (err == nil || err.Error() != tt.want):
+				t.Errorf("validateOnlyFlags() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestModulePlanRuns(t *testing.T) {
+	plan := &modulePlan{host: true, logChecks: true}
+	runs := plan.runs()
+	if len(runs) != len(moduleTitles) {
+		t.Fatalf("runs() has %d modules, want %d", len(runs), len(moduleTitles))
+	}
+	var planned []string
+	for _, m := range runs {
+		if moduleTitles[m.key] == "" {
+			t.Errorf("module %q has no title", m.key)
+		}
+		if *m.run {
+			planned = append(planned, m.key)
+		}
+	}
+	if len(planned) != 2 || planned[0] != json.ModuleHost || planned[1] != json.ModuleLogChecks {
+		t.Errorf("planned modules = %v, want [%s %s]", planned, json.ModuleHost, json.ModuleLogChecks)
+	}
+
+	// Clearing a run flag clears the plan, as the checkpoint does for resumed modules
+	*runs[0].run = false
+	if plan.host {
+		t.Error("plan.host still set after clearing its run flag")
+	}
+}
+
+func TestModuleDefinitionsRawDataQueries(t *testing.T) {
+	defs := &moduleDefinitions{
+		mysql:     []*model.MySQLMetricDefinition{{Name: "mysql_up", Query: "mysql_up"}},
+		logChecks: []*model.LogCheckDefinition{{Name: "errors", Query: "level:error"}},
+	}
+	queries := defs.rawDataQueries()
+	if got := queries[model.RawDataModuleMySQL]["mysql_up"]; got != "mysql_up" {
+		t.Errorf("MySQL query = %q, want %q", got, "mysql_up")
+	}
+	if got := queries[model.RawDataModuleLogChecks]["errors"]; got != "level:error" {
+		t.Errorf("log check query = %q, want %q", got, "level:error")
+	}
+	if got := len(queries[model.RawDataModuleRedis]); got != 0 {
+		t.Errorf("Redis queries = %d, want 0 without definitions", got)
+	}
+}
+
+func TestSelectModules(t *testing.T) {
+	defer func() { onlyModules = nil }()
+
+	cfg := &config.Config{}
+	cfg.MySQL.Enabled = true
+	plan, err := selectModules(cfg, nil)
+	if err != nil || !plan.host || !plan.mysql || plan.redis {
+		t.Fatalf("selectModules() = %+v, %v, want host and MySQL", plan, err)
+	}
+
+	onlyModules = []string{json.ModuleMySQL}
+	if plan, err = selectModules(cfg, nil); err != nil || plan.host || !plan.mysql {
+		t.Errorf("selectModules() with --only mysql = %+v, %v, want MySQL only", plan, err)
+	}
+
+	// A selected module that is not enabled cannot be run
+	onlyModules = []string{json.ModuleRedis}
+	if _, err := selectModules(cfg, nil); err == nil {
+		t.Error("selectModules() should reject --only of a module that is not enabled")
+	}
+}
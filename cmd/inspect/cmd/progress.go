@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// moduleProgress numbers the modules of an inspection on the console as they start and
+// times each of them, so that a long run shows how far it is and where the time goes. On a
+// terminal, progress bars of the modules and of the whole inspection are drawn below the
+// line of the running module.
+type moduleProgress struct {
+	total   int
+	current int
+	started time.Time
+	bars    *progressBars // nil when the console is not a terminal
+}
+
+// newModuleProgress creates the progress of the modules whose run flag is set.
+func newModuleProgress(modules []moduleRun) *moduleProgress {
+	p := &moduleProgress{}
+	for _, m := range modules {
+		if *m.run {
+			p.total++
+		}
+	}
+	return p
+}
+
+// showBars draws the progress bars of the modules whose run flag is set to out, a terminal.
+// The console log is written around the bars from then on.
+func (p *moduleProgress) showBars(out io.Writer, modules []moduleRun) {
+	p.bars = newProgressBars(out, modules)
+	consoleLog.setBars(p.bars)
+}
+
+// start starts the next module and returns its position, as "[2/5] ".
+func (p *moduleProgress) start() string {
+	p.current++
+	p.started = time.Now()
+	return fmt.Sprintf("[%d/%d] ", p.current, p.total)
+}
+
+// elapsed returns the duration of the current module, as "（耗时 12.3s）".
+func (p *moduleProgress) elapsed() string {
+	return fmt.Sprintf("（耗时 %.1fs）", time.Since(p.started).Seconds())
+}
+
+// track draws the progress bars with module running and returns ctx with the progress of
+// its collection attached. Returns ctx unchanged without progress bars.
+func (p *moduleProgress) track(ctx context.Context, module string) context.Context {
+	if p.bars == nil {
+		return ctx
+	}
+	if bar := p.bars.run(module); bar != nil {
+		return service.ContextWithProgress(ctx, bar)
+	}
+	return ctx
+}
+
+// stop marks the running module completed or failed and clears the progress bars, before
+// the result of the module is printed.
+func (p *moduleProgress) stop(ok bool) {
+	if p.bars != nil {
+		p.bars.stop(ok, time.Since(p.started))
+	}
+}
+
+// progressBarWidth is the number of cells of a progress bar.
+const progressBarWidth = 24
+
+// progressRedrawInterval limits how often the progress bars are redrawn as queries complete.
+const progressRedrawInterval = 100 * time.Millisecond
+
+// barState is the state of the progress bar of a module.
+type barState int
+
+const (
+	barPending barState = iota
+	barRunning
+	barCompleted
+	barFailed
+)
+
+// progressBars draws, in place below the line of the running module, a progress bar per
+// module of the inspection and a bar of the whole inspection. The bar of the running module
+// advances with its metric queries; the bars are cleared when it stops, so that its result
+// is printed as usual.
+type progressBars struct {
+	mu      sync.Mutex
+	out     io.Writer
+	modules []*moduleBar
+	running *moduleBar
+	lines   int       // Lines drawn, 0 when the bars are cleared
+	drawn   time.Time // Last time the bars were drawn
+}
+
+// moduleBar is the progress bar of a module. It receives the progress of the collection of
+// the module when it runs.
+type moduleBar struct {
+	bars    *progressBars
+	key     string
+	title   string
+	state   barState
+	targets int // Hosts or instances queried
+	queries int
+	done    int // Queries completed
+	elapsed time.Duration
+}
+
+var _ service.Progress = (*moduleBar)(nil)
+
+// newProgressBars creates the progress bars of the modules whose run flag is set.
+func newProgressBars(out io.Writer, modules []moduleRun) *progressBars {
+	b := &progressBars{out: out}
+	for _, m := range modules {
+		if *m.run {
+			b.modules = append(b.modules, &moduleBar{bars: b, key: m.key, title: moduleTitles[m.key]})
+		}
+	}
+	return b
+}
+
+// run marks module running, draws the bars and returns the bar of the module.
+func (b *progressBars) run(module string) *moduleBar {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, bar := range b.modules {
+		if bar.key == module {
+			bar.state = barRunning
+			b.running = bar
+			break
+		}
+	}
+	b.draw()
+	return b.running
+}
+
+// stop marks the running module completed or failed after elapsed, and clears the bars.
+func (b *progressBars) stop(ok bool, elapsed time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.running != nil {
+		b.running.state = barFailed
+		if ok {
+			b.running.state = barCompleted
+		}
+		b.running.elapsed = elapsed
+		b.running = nil
+	}
+	b.clear()
+}
+
+// Start records the number of hosts or instances and of queries of the running module.
+func (m *moduleBar) Start(targets, queries int) {
+	m.bars.mu.Lock()
+	defer m.bars.mu.Unlock()
+	m.targets, m.queries, m.done = targets, queries, 0
+	m.bars.draw()
+}
+
+// QueryDone advances the bar of the running module by one query.
+func (m *moduleBar) QueryDone() {
+	m.bars.mu.Lock()
+	defer m.bars.mu.Unlock()
+	m.done++
+	if m.done == m.queries || time.Since(m.bars.drawn) >= progressRedrawInterval {
+		m.bars.draw()
+	}
+}
+
+// draw replaces the bars drawn, if any, with the current ones. Requires mu.
+func (b *progressBars) draw() {
+	if b.running == nil {
+		return // Drawn only while a module runs
+	}
+	b.clear()
+	lines := b.render()
+	fmt.Fprint(b.out, strings.Join(lines, "\n")+"\n")
+	b.lines = len(lines)
+	b.drawn = time.Now()
+}
+
+// clear erases the bars drawn, leaving the cursor where they started. Requires mu.
+func (b *progressBars) clear() {
+	if b.lines > 0 {
+		fmt.Fprintf(b.out, "\033[%dA\033[J", b.lines)
+		b.lines = 0
+	}
+}
+
+// render returns the lines of the bars: one per module, then the whole inspection.
+func (b *progressBars) render() []string {
+	titleWidth := displayWidth("总进度")
+	for _, bar := range b.modules {
+		titleWidth = max(titleWidth, displayWidth(bar.title))
+	}
+
+	lines := make([]string, 0, len(b.modules)+1)
+	completed, finished := 0, 0.0 // Modules stopped, and their share of the inspection
+	for _, bar := range b.modules {
+		lines = append(lines, "   "+padTitle(bar.title, titleWidth)+" "+bar.render())
+		switch bar.state {
+		case barCompleted, barFailed:
+			completed++
+			finished++
+		case barRunning:
+			finished += bar.fraction()
+		}
+	}
+	overall := 0.0
+	if len(b.modules) > 0 {
+		overall = finished / float64(len(b.modules))
+	}
+	lines = append(lines, "   "+padTitle("总进度", titleWidth)+" "+
+		progressLine(overall, percent(overall), fmt.Sprintf("%d/%d 个模块", completed, len(b.modules))))
+	return lines
+}
+
+// fraction returns how much of the queries of the module completed.
+func (m *moduleBar) fraction() float64 {
+	if m.queries == 0 {
+		return 0
+	}
+	return float64(m.done) / float64(m.queries)
+}
+
+// render returns the bar of the module and its state.
+func (m *moduleBar) render() string {
+	switch m.state {
+	case barCompleted:
+		return progressLine(1, percent(1), fmt.Sprintf("完成（耗时 %.1fs）", m.elapsed.Seconds()))
+	case barFailed:
+		return progressLine(m.fraction(), "-", fmt.Sprintf("失败（耗时 %.1fs）", m.elapsed.Seconds()))
+	case barRunning:
+		if m.queries == 0 {
+			return progressLine(0, percent(0), "获取巡检对象...")
+		}
+		text := fmt.Sprintf("%d/%d 项查询", m.done, m.queries)
+		if m.targets > 0 {
+			unit := "个实例"
+			if m.key == json.ModuleHost {
+				unit = "台主机"
+			}
+			text += fmt.Sprintf(" · %d %s", m.targets, unit)
+		}
+		return progressLine(m.fraction(), percent(m.fraction()), text)
+	}
+	return progressLine(0, "", "等待")
+}
+
+// progressLine returns a bar filled to fraction, followed by the percentage and text.
+func progressLine(fraction float64, percent, text string) string {
+	filled := min(max(int(fraction*progressBarWidth), 0), progressBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	return fmt.Sprintf("[%s] %4s  %s", bar, percent, text)
+}
+
+// percent formats fraction as a percentage, as "42%".
+func percent(fraction float64) string {
+	return fmt.Sprintf("%.0f%%", fraction*100)
+}
+
+// padTitle pads a module title with spaces to width terminal columns.
+func padTitle(title string, width int) string {
+	return title + strings.Repeat(" ", max(width-displayWidth(title), 0))
+}
+
+// isTerminal reports whether f is an interactive terminal able to redraw the progress bars.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0 && os.Getenv("TERM") != "dumb"
+}
+
+// consoleLog is the output of the logger. It clears the progress bars, when drawn, while a
+// log line is written and draws them again after it, so that the log does not break the
+// bars redrawn in place.
+var consoleLog = &logOutput{out: os.Stderr}
+
+// logOutput writes log lines around the progress bars.
+type logOutput struct {
+	mu   sync.Mutex
+	out  io.Writer
+	bars *progressBars
+}
+
+// setBars writes the log lines around bars from now on.
+func (w *logOutput) setBars(bars *progressBars) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.bars = bars
+}
+
+// Write writes a log line, clearing the progress bars while it is written.
+func (w *logOutput) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	bars := w.bars
+	w.mu.Unlock()
+	if bars == nil {
+		return w.out.Write(p)
+	}
+
+	bars.mu.Lock()
+	defer bars.mu.Unlock()
+	bars.clear()
+	n, err := w.out.Write(p)
+	bars.draw()
+	return n, err
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/report/json"
+)
+
+func TestModuleProgress(t *testing.T) {
+	host, mysql, skipped := true, true, false
+	p := newModuleProgress([]moduleRun{{"host", &host}, {"redis", &skipped}, {"mysql", &mysql}})
+
+	if got := p.start(); got != "[1/2] " {
+		t.Errorf("start() = %q, want %q", got, "[1/2] ")
+	}
+	p.started = time.Now().Add(-1500 * time.Millisecond)
+	if got := p.elapsed(); got != "（耗时 1.5s）" {
+		t.Errorf("elapsed() = %q, want %q", got, "（耗时 1.5s）")
+	}
+	if got := p.start(); got != "[2/2] " {
+		t.Errorf("start() = %q, want %q", got, "[2/2] ")
+	}
+	if got := p.elapsed(); got != "（耗时 0.0s）" {
+		t.Errorf("elapsed() right after start() = %q, want %q", got, "（耗时 0.0s）")
+	}
+}
+
+func TestProgressBars(t *testing.T) {
+	host, mysql, skipped := true, true, false
+	modules := []moduleRun{{json.ModuleHost, &host}, {json.ModuleRedis, &skipped}, {json.ModuleMySQL, &mysql}}
+	var out bytes.Buffer
+	b := newProgressBars(&out, modules)
+
+	bar := b.run(json.ModuleHost)
+	bar.Start(120, 4)
+	bar.QueryDone()
+	bar.QueryDone()
+	b.mu.Lock()
+	lines := b.render()
+	b.mu.Unlock()
+	want := []string{
+		"   主机巡检   [" + strings.Repeat("█", 12) + strings.Repeat("░", 12) + "]  50%  2/4 项查询 · 120 台主机",
+		"   MySQL 巡检 [" + strings.Repeat("░", 24) + "]       等待",
+		"   总进度     [" + strings.Repeat("█", 6) + strings.Repeat("░", 18) + "]  25%  0/2 个模块",
+	}
+	if !slices.Equal(lines, want) {
+		t.Errorf("render() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	// The bars are cleared when the module stops, and stay cleared until the next one runs
+	out.Reset()
+	b.stop(true, 1500*time.Millisecond)
+	if got := out.String(); got != "\033[3A\033[J" {
+		t.Errorf("stop() wrote %q, want the bars cleared", got)
+	}
+	bar.QueryDone()
+	if out.Len() != len("\033[3A\033[J") {
+		t.Errorf("progress after stop() should not draw the bars, wrote %q", out.String())
+	}
+
+	b.run(json.ModuleMySQL)
+	if got := out.String(); !strings.Contains(got, "完成（耗时 1.5s）") || !strings.Contains(got, "获取巡检对象...") || !strings.Contains(got, "1/2 个模块") {
+		t.Errorf("run() drew %q, want the completed host module and MySQL running", got)
+	}
+}
+
+func TestLogOutput(t *testing.T) {
+	host := true
+	var out, log bytes.Buffer
+	b := newProgressBars(&out, []moduleRun{{json.ModuleHost, &host}})
+	w := &logOutput{out: &log}
+
+	// Without bars drawn, the log is written as is
+	w.setBars(b)
+	fmt.Fprint(w, "line 1\n")
+	if log.String() != "line 1\n" || out.Len() != 0 {
+		t.Errorf("log = %q, bars = %q, want the line only", log.String(), out.String())
+	}
+
+	// The bars drawn are cleared while a line is written, then drawn again
+	b.run(json.ModuleHost)
+	out.Reset()
+	fmt.Fprint(w, "line 2\n")
+	if got := out.String(); !strings.HasPrefix(got, "\033[2A\033[J   主机巡检") {
+		t.Errorf("bars around the log line = %q, want cleared then drawn", got)
+	}
+	if log.String() != "line 1\nline 2\n" {
+		t.Errorf("log = %q, want both lines", log.String())
+	}
+}
+
+func TestModuleProgress_TrackWithoutBars(t *testing.T) {
+	host := true
+	p := newModuleProgress([]moduleRun{{json.ModuleHost, &host}})
+	ctx := context.Background()
+	if got := p.track(ctx, json.ModuleHost); got != ctx {
+		t.Error("track() without progress bars should return the context unchanged")
+	}
+	p.stop(true) // No bars: nothing to clear
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/excel"
+	"inspection-tool/internal/report/theme"
+)
+
+// provenanceModules is the order of the modules in the query table of the provenance sheet.
+var provenanceModules = []string{
+	model.RawDataModuleHost, model.RawDataModuleMySQL, model.RawDataModuleRedis, model.RawDataModuleNginx,
+	model.RawDataModuleTomcat, model.RawDataModuleCassandra, model.RawDataModuleMonitoring, model.RawDataModuleStorage,
+	model.RawDataModuleLogChecks, model.RawDataModuleLVS, model.RawDataModuleWindows, model.RawDataModuleAD,
+	model.RawDataModuleCloud, model.RawDataModuleFirewall,
+}
+
+// newProvenance records how the report of a run was produced: the build, the configuration
+// file and its hash, the inspection time range, the datasources and the queries of the
+// inspected modules (host metrics, then the modules of queries).
+func newProvenance(cfg *config.Config, configFile string, startTime, endTime time.Time, hostMetrics []*model.MetricDefinition, queries map[string]map[string]string) *excel.Provenance {
+	p := &excel.Provenance{
+		Version:    Version,
+		GitCommit:  GitCommit,
+		BuildTime:  BuildTime,
+		ConfigFile: configFile,
+		ConfigHash: fileSHA256(configFile),
+		StartTime:  startTime,
+		EndTime:    endTime,
+	}
+
+	ds := cfg.Datasources
+	p.Entries = append(p.Entries,
+		excel.ProvenanceEntry{Name: "N9E 地址", Value: ds.N9E.Endpoint},
+		excel.ProvenanceEntry{Name: "主机过滤（N9E 查询）", Value: ds.N9E.Query},
+		excel.ProvenanceEntry{Name: metricsSourceName(ds.VictoriaMetrics.Type) + " 地址", Value: ds.VictoriaMetrics.QueryURL()},
+	)
+	if ds.VictoriaMetrics.TenantID != "" {
+		p.Entries = append(p.Entries, excel.ProvenanceEntry{Name: "指标租户", Value: fmt.Sprintf("%s (%s)", ds.VictoriaMetrics.TenantID, ds.VictoriaMetrics.TenantHeader)})
+	}
+	if ds.Logs.Endpoint != "" {
+		p.Entries = append(p.Entries, excel.ProvenanceEntry{Name: "日志后端地址", Value: fmt.Sprintf("%s (%s)", ds.Logs.Endpoint, ds.Logs.Type)})
+	}
+	if ds.CMDB.Endpoint != "" {
+		p.Entries = append(p.Entries, excel.ProvenanceEntry{Name: "CMDB 地址", Value: ds.CMDB.Endpoint})
+	}
+	if countRangeMetrics(hostMetrics) > 0 {
+		p.Entries = append(p.Entries, excel.ProvenanceEntry{Name: "主机指标范围聚合窗口", Value: rangeWindowText(&cfg.Inspection, cfg.Report.Timezone)})
+	}
+	if cfg.LogChecks.Enabled {
+		p.Entries = append(p.Entries, excel.ProvenanceEntry{Name: "日志巡检统计窗口", Value: cfg.LogChecks.Window.String()})
+	}
+	if cfg.Cloud.Enabled && cfg.Cloud.CostSummary.Enabled {
+		p.Entries = append(p.Entries, excel.ProvenanceEntry{Name: "云资源低利用率统计窗口", Value: cfg.Cloud.CostSummary.Window.String()})
+	}
+
+	all := map[string]map[string]string{
+		model.RawDataModuleHost: metricQueries(hostMetrics, func(d *model.MetricDefinition) (string, string) { return d.Name, d.Query }),
+	}
+	for module, moduleQueries := range queries {
+		all[module] = moduleQueries
+	}
+	for _, module := range provenanceModules {
+		names := make([]string, 0, len(all[module]))
+		for name, query := range all[module] {
+			if query != "" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p.Queries = append(p.Queries, excel.ProvenanceQuery{Module: module, Metric: name, Query: all[module][name]})
+		}
+	}
+	return p
+}
+
+// metricsSourceName returns the display name of the metrics backend type.
+func metricsSourceName(metricsType string) string {
+	if metricsType == config.MetricsTypePrometheus {
+		return "Prometheus"
+	}
+	return "VictoriaMetrics"
+}
+
+// fileSHA256 returns the hex SHA-256 of a file, or "" when it cannot be read (e.g. a
+// configuration given by environment variables only).
+func fileSHA256(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// appendProvenanceSheet appends the report metadata sheet to the Excel report at outputPath.
+func appendProvenanceSheet(p *excel.Provenance, outputPath string, timezone *time.Location, reportTheme *theme.Theme, language string, protectionOpts []excel.Option, logger zerolog.Logger) error {
+	opts := append([]excel.Option{excel.WithTheme(reportTheme), excel.WithLanguage(language)}, protectionOpts...)
+	w := excel.NewWriter(timezone, opts...)
+	if err := w.AppendProvenanceSheet(p, outputPath); err != nil {
+		return fmt.Errorf("failed to append provenance sheet: %w", err)
+	}
+
+	logger.Debug().
+		Int("queries", len(p.Queries)).
+		Str("path", outputPath).
+		Msg("provenance sheet appended")
+
+	return nil
+}
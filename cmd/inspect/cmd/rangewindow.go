@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// rangeTimeLayouts are the layouts accepted by --start and --end besides RFC 3339, in the
+// report timezone.
+var rangeTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// applyRangeWindowFlags sets the range aggregation window of the host metrics from --start
+// and --end: the window ends at --end (default now) and starts at --start (default
+// inspection.range_window before the end). The end is pinned even when only --start is
+// given, so every query of the run aggregates over the same window.
+func applyRangeWindowFlags(inspection *config.InspectionConfig, start, end, timezone string, now time.Time) error {
+	if start == "" && end == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.Local
+	}
+
+	endTime := now
+	if end != "" {
+		if endTime, err = parseRangeTime(end, loc); err != nil {
+			return fmt.Errorf("--end: %w", err)
+		}
+		if endTime.After(now) {
+			return fmt.Errorf("--end 不能晚于当前时间: %s", end)
+		}
+	}
+	if start != "" {
+		startTime, err := parseRangeTime(start, loc)
+		if err != nil {
+			return fmt.Errorf("--start: %w", err)
+		}
+		if !startTime.Before(endTime) {
+			return fmt.Errorf("--start 必须早于 --end: %s", start)
+		}
+		inspection.RangeWindow = endTime.Sub(startTime).Truncate(time.Second)
+	}
+	inspection.RangeEnd = endTime.Truncate(time.Second)
+	return nil
+}
+
+// parseRangeTime parses a --start / --end time: RFC 3339, or a rangeTimeLayouts time in loc.
+func parseRangeTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range rangeTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法解析时间 %q（支持 RFC 3339、\"2006-01-02 15:04:05\"、\"2006-01-02 15:04\"、\"2006-01-02\"）", value)
+}
+
+// countRangeMetrics returns the number of active host metrics aggregated over the window.
+func countRangeMetrics(metrics []*model.MetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if m != nil && m.RangeFunction != "" && !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// rangeEndText describes the end of the window for console output, "" when it is the query time.
+func rangeEndText(end time.Time, timezone string) string {
+	if end.IsZero() {
+		return ""
+	}
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		end = end.In(loc)
+	}
+	return fmt.Sprintf("（截至 %s）", end.Format("2006-01-02 15:04:05"))
+}
+
+// rangeWindowText describes the window for the provenance sheet: its length, and its start
+// and end times when pinned by --start / --end.
+func rangeWindowText(inspection *config.InspectionConfig, timezone string) string {
+	if inspection.RangeEnd.IsZero() {
+		return inspection.RangeWindow.String()
+	}
+	start, end := inspection.RangeEnd.Add(-inspection.RangeWindow), inspection.RangeEnd
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		start, end = start.In(loc), end.In(loc)
+	}
+	return fmt.Sprintf("%s (%s ~ %s)", inspection.RangeWindow, start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/service"
+)
+
+// reportFrom is the --from flag of the report command.
+var reportFrom string
+
+// reportCmd represents the report command.
+var reportCmd = &cobra.Command{
+	Use:   "report [--from JSON 巡检结果]",
+	Short: "根据已保存的巡检结果重新生成报告",
+	Long: `读取一次巡检保存的 JSON 结果（report.formats 包含 json 时生成），按当前配置的报告设置
+（主题、封面、水印、模板、语言等）重新生成报告，不查询任何数据源。
+
+巡检结果用 --from 指定（也可作为参数传入），未指定时使用输出目录中最新的 JSON 结果。
+修改报告模板、主题或输出格式后，无需重新查询数据源即可得到新报告。报告文件名按巡检日期生成；
+json 格式即巡检结果本身，不重新生成。
+趋势 sheet 只比较该次巡检之前的结果。Alertmanager 告警为巡检时的实时数据，不随结果保存，
+重新生成的报告中不包含。
+
+示例:
+  # 修改主题后重新生成最近一次巡检的 Excel 和 HTML 报告
+  inspect report -c config.yaml
+
+  # 从指定结果重新生成 Excel 和 HTML 报告
+  inspect report -c config.yaml --from reports/inspection_report_2026-10-15.json -f excel,html
+
+  # 从指定结果生成邮件正文版 HTML
+  inspect report -c config.yaml reports/inspection_report_2026-10-15.json -f html-email -o ./mail`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runReport,
+}
+
+func init() {
+	reportCmd.Flags().StringSliceVarP(&formats, "format", "f", nil, "输出格式 (excel,html,html-email,csv)，覆盖 report.formats，可用逗号分隔多个")
+	reportCmd.Flags().StringVar(&reportFrom, "from", "", "已保存的 JSON 巡检结果（默认使用输出目录中最新的结果）")
+	reportCmd.Flags().StringVarP(&outputDir, "output", "o", "", "输出目录")
+	reportCmd.Flags().StringVarP(&metricsPath, "metrics", "m", "configs/metrics.yaml", "指标定义文件路径（原始数据与数据来源 sheet 使用）")
+	rootCmd.AddCommand(reportCmd)
+}
+
+// runReport executes the report command logic.
+func runReport(cmd *cobra.Command, args []string) {
+	configPath := GetConfigFile()
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 加载配置失败: %v\n", err)
+		os.Exit(1)
+	}
+	logLevel := cfg.Logging.Level
+	if GetLogLevel() != "info" { // If explicitly set via command line
+		logLevel = GetLogLevel()
+	}
+	logger := setupLogger(logLevel, cfg.Logging.Format)
+	if err := validateFormatFlag(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	outputFormats, err := regeneratedFormats(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	outputPath := resolveOutputDir(cfg)
+	sourcePath, err := resolveReportSource(args, outputPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+	results, err := json.ReadReport(sourcePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 读取巡检结果失败: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("📂 巡检结果: %s\n", sourcePath)
+
+	timezone, _ := time.LoadLocation("Asia/Shanghai")
+	if tz, err := time.LoadLocation(cfg.Report.Timezone); err == nil {
+		timezone = tz
+	}
+
+	// Host metric definitions give the queries of the raw data and provenance sheets
+	var metrics []*model.MetricDefinition
+	if results.Host != nil && (cfg.Report.RawDataSheet || cfg.Report.ProvenanceSheet) {
+		if metrics, err = config.LoadMetrics(metricsPath); err != nil {
+			logger.Warn().Err(err).Str("path", metricsPath).Msg("failed to load metrics for the raw data sheet")
+		}
+		metrics = service.ApplyRangeFunctions(metrics, &cfg.Inspection)
+	}
+
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		logger.Error().Err(err).Str("path", outputPath).Msg("failed to create output directory")
+		fmt.Fprintf(os.Stderr, "❌ 创建输出目录失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	reportPaths := generateReports(cfg, &reportInput{
+		results:   results,
+		startTime: results.GeneratedAt,
+		timezone:  timezone,
+		metrics:   metrics,
+	}, outputFormats, outputPath, nil, logger)
+
+	printRunSummary(os.Stdout, buildRunSummaryRows(results), reportPaths)
+	if len(reportPaths) == 0 {
+		os.Exit(1)
+	}
+}
+
+// regeneratedFormats returns the configured output formats without json: the JSON result is the
+// saved inspection itself, and rewriting it would stamp it as a new run.
+func regeneratedFormats(cfg *config.Config) ([]string, error) {
+	var outputFormats []string
+	for _, format := range resolveFormats(cfg) {
+		if format != "json" {
+			outputFormats = append(outputFormats, format)
+		}
+	}
+	if len(outputFormats) == 0 {
+		return nil, fmt.Errorf("没有可生成的报告格式：json 格式即巡检结果本身，不重新生成，请通过 -f 指定其他格式（如 -f excel,html）")
+	}
+	return outputFormats, nil
+}
+
+// resolveReportSource returns the JSON result given by --from or as argument, or the most
+// recent one in the output directory.
+func resolveReportSource(args []string, outputPath string) (string, error) {
+	source := reportFrom
+	if len(args) == 1 {
+		if source != "" && args[0] != source {
+			return "", fmt.Errorf("--from 与参数指定了不同的巡检结果: %s、%s", source, args[0])
+		}
+		source = args[0]
+	}
+	if source != "" {
+		if info, err := os.Stat(source); err != nil || info.IsDir() {
+			return "", fmt.Errorf("巡检结果文件不存在: %s", source)
+		}
+		return source, nil
+	}
+	reports, err := listJSONReports(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("读取目录 %s 失败: %w", outputPath, err)
+	}
+	if len(reports) == 0 {
+		return "", fmt.Errorf("目录 %s 中没有 JSON 巡检结果，请指定文件，或在 report.formats 中加入 json", outputPath)
+	}
+	return reports[0], nil
+}
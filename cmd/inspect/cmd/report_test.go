@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/config"
+)
+
+func TestResolveReportSource(t *testing.T) {
+	defer func(saved string) { reportFrom = saved }(reportFrom)
+
+	dir := t.TempDir()
+	older := filepath.Join(dir, "inspection_report_2026-10-14.json")
+	newer := filepath.Join(dir, "inspection_report_2026-10-15.json")
+	for i, path := range []string{older, newer} {
+		if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := time.Now().Add(time.Duration(i-2) * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Explicit --from, or the same file as argument
+	reportFrom = older
+	if got, err := resolveReportSource(nil, dir); err != nil || got != older {
+		t.Errorf("resolveReportSource() with --from = %q, %v, want %q", got, err, older)
+	}
+	if got, err := resolveReportSource([]string{older}, dir); err != nil || got != older {
+		t.Errorf("resolveReportSource() with --from and argument = %q, %v, want %q", got, err, older)
+	}
+	if _, err := resolveReportSource([]string{newer}, dir); err == nil {
+		t.Error("resolveReportSource() should reject --from and an argument naming different files")
+	}
+
+	// Argument without --from
+	reportFrom = ""
+	if got, err := resolveReportSource([]string{older}, dir); err != nil || got != older {
+		t.Errorf("resolveReportSource() with argument = %q, %v, want %q", got, err, older)
+	}
+
+	// Latest result of the output directory
+	if got, err := resolveReportSource(nil, dir); err != nil || got != newer {
+		t.Errorf("resolveReportSource() = %q, %v, want the latest result %q", got, err, newer)
+	}
+
+	// Missing files
+	reportFrom = filepath.Join(dir, "missing.json")
+	if _, err := resolveReportSource(nil, dir); err == nil {
+		t.Error("resolveReportSource() should fail for a missing --from file")
+	}
+	reportFrom = ""
+	if _, err := resolveReportSource(nil, t.TempDir()); err == nil {
+		t.Error("resolveReportSource() should fail for an output directory without results")
+	}
+}
+
+func TestRegeneratedFormats(t *testing.T) {
+	defer func(saved []string) { formats = saved }(formats)
+	formats = nil
+
+	cfg := &config.Config{}
+	cfg.Report.Formats = []string{"json", "excel", "html"}
+	if got, err := regeneratedFormats(cfg); err != nil || !reflect.DeepEqual(got, []string{"excel", "html"}) {
+		t.Errorf("regeneratedFormats() = %v, %v, want [excel html]", got, err)
+	}
+
+	// Only json configured: nothing to regenerate
+	cfg.Report.Formats = []string{"json"}
+	if got, err := regeneratedFormats(cfg); err == nil {
+		t.Errorf("regeneratedFormats() = %v, want an error when json is the only format", got)
+	}
+
+	// -f overrides report.formats
+	formats = []string{"html-email"}
+	if got, err := regeneratedFormats(cfg); err != nil || !reflect.DeepEqual(got, []string{"html-email"}) {
+		t.Errorf("regeneratedFormats() with -f = %v, %v, want [html-email]", got, err)
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/excel"
+	"inspection-tool/internal/report/json"
+)
+
+// rollupOutput is the --output flag of the rollup command.
+var rollupOutput string
+
+// rollupCmd represents the rollup command.
+var rollupCmd = &cobra.Command{
+	Use:   "rollup [站点名=]<JSON 报告|目录>...",
+	Short: "汇总多个站点的巡检结果",
+	Long: `读取多个站点的 JSON 巡检报告（report.formats 包含 json 时生成），
+生成跨站点的 Excel 汇总报告：站点健康矩阵、各模块告警汇总和站点模块明细。
+
+每个参数对应一个站点，格式为 "站点名=路径"；省略站点名时使用文件名（或目录名）。
+路径为目录时读取其中最新的 JSON 报告。
+
+示例:
+  inspect rollup 北京=reports/bj/inspection_report.json 上海=reports/sh/ -o rollup.xlsx`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runRollup,
+}
+
+func init() {
+	rollupCmd.Flags().StringVarP(&rollupOutput, "output", "o", "", "汇总报告输出路径（默认 rollup_<时间>.xlsx）")
+	rootCmd.AddCommand(rollupCmd)
+}
+
+// runRollup executes the rollup command logic.
+func runRollup(cmd *cobra.Command, args []string) {
+	result := &model.RollupResult{GeneratedAt: time.Now()}
+	for _, arg := range args {
+		site, err := loadRollupSite(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 读取巡检结果失败: %v\n", err)
+			os.Exit(1)
+		}
+		result.Sites = append(result.Sites, site)
+	}
+
+	outputPath := rollupOutput
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("rollup_%s.xlsx", result.GeneratedAt.Format("20060102_150405"))
+	}
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath += ".xlsx"
+	}
+
+	if err := excel.NewWriter(nil).WriteRollup(result, outputPath); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 生成汇总报告失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	printRollup(os.Stdout, result)
+	fmt.Printf("\n✅ 汇总报告已生成: %s\n", outputPath)
+}
+
+// loadRollupSite reads the site of a rollup argument: "name=path" or a path, where path is
+// a JSON report or a directory whose newest JSON report is used.
+func loadRollupSite(arg string) (*model.RollupSite, error) {
+	name, path, ok := strings.Cut(arg, "=")
+	if !ok || name == "" {
+		name, path = "", arg
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		if name == "" {
+			name = filepath.Base(filepath.Clean(path))
+		}
+		if path, err = latestJSONReport(path); err != nil {
+			return nil, err
+		}
+	} else if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	report, err := json.ReadReport(path)
+	if err != nil {
+		return nil, err
+	}
+	return newRollupSite(name, path, report), nil
+}
+
+// latestJSONReport returns the most recently modified JSON report in dir.
+func latestJSONReport(dir string) (string, error) {
+	reports, err := listJSONReports(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(reports) == 0 {
+		return "", fmt.Errorf("目录中没有 JSON 报告: %s", dir)
+	}
+	return reports[0], nil
+}
+
+// listJSONReports returns the JSON files in dir, most recently modified first.
+func listJSONReports(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	modTimes := make(map[string]time.Time, len(matches))
+	reports := make([]string, 0, len(matches))
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		modTimes[path] = info.ModTime()
+		reports = append(reports, path)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return modTimes[reports[i]].After(modTimes[reports[j]])
+	})
+	return reports, nil
+}
+
+// newRollupSite summarizes a JSON report into a rollup site: the object counts of every
+// inspected module, as in the exit summary, and its alert counts by level.
+func newRollupSite(name, source string, report *json.Report) *model.RollupSite {
+	warningAlerts := make(map[string]int)
+	criticalAlerts := make(map[string]int)
+	for _, alert := range report.Alerts {
+		module := json.ModuleName(alert.Module)
+		switch alert.Level {
+		case model.AlertLevelWarning:
+			warningAlerts[module]++
+		case model.AlertLevelCritical:
+			criticalAlerts[module]++
+		}
+	}
+
+	site := &model.RollupSite{
+		Name:           name,
+		Source:         source,
+		InspectionTime: report.GeneratedAt,
+		Version:        report.Version,
+	}
+	rows := buildRunSummaryRows(report)
+	for _, r := range rows {
+		site.Modules = append(site.Modules, &model.RollupModule{
+			Module:         r.module,
+			Total:          r.total,
+			Normal:         r.normal,
+			Warning:        r.warning,
+			Critical:       r.critical,
+			Failed:         r.failed,
+			WarningAlerts:  warningAlerts[r.module],
+			CriticalAlerts: criticalAlerts[r.module],
+		})
+	}
+	return site
+}
+
+// printRollup prints the site health matrix as a table.
+func printRollup(out io.Writer, result *model.RollupResult) {
+	modules := result.Modules()
+	headers := append([]string{"站点", "整体状态", "严重告警", "警告告警"}, modules...)
+	cells := make([][]string, 0, len(result.Sites))
+	for _, site := range result.Sites {
+		warning, critical := site.AlertCounts()
+		row := []string{site.Name, rollupStatusText(site.Status()), fmt.Sprint(critical), fmt.Sprint(warning)}
+		for _, name := range modules {
+			status := "-"
+			if m := site.Module(name); m != nil {
+				status = rollupStatusText(m.Status())
+			}
+			row = append(row, status)
+		}
+		cells = append(cells, row)
+	}
+
+	fmt.Fprintf(out, "\n🌐 站点健康矩阵（%d 个站点）:\n", len(result.Sites))
+	writeTable(out, headers, cells)
+}
+
+// rollupStatusText converts a rollup status to Chinese text.
+func rollupStatusText(status model.HostStatus) string {
+	switch status {
+	case model.HostStatusCritical:
+		return "严重"
+	case model.HostStatusWarning:
+		return "警告"
+	case model.HostStatusFailed:
+		return "失败"
+	default:
+		return "正常"
+	}
+}
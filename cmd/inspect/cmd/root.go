@@ -45,6 +45,7 @@ var rootCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	registerCompletions()
 	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
@@ -7,44 +7,91 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
-	"inspection-tool/internal/client/n9e"
-	"inspection-tool/internal/client/vm"
 	"inspection-tool/internal/config"
+	"inspection-tool/internal/distributed"
 	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/bundle"
+	"inspection-tool/internal/report/csv"
 	"inspection-tool/internal/report/excel"
 	"inspection-tool/internal/report/html"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/publish"
+	"inspection-tool/internal/report/theme"
 	"inspection-tool/internal/service"
 )
 
 // Command flags
 var (
-	outputDir        string   // Output directory for reports
-	formats          []string // Output formats (excel, html)
-	metricsPath      string   // Path to metrics definition file
-	mysqlMetricsPath string   // Path to MySQL metrics definition file
-	mysqlOnly        bool     // Run MySQL inspection only
-	skipMySQL        bool     // Skip MySQL inspection
-	redisMetricsPath string   // Path to Redis metrics definition file
-	redisOnly        bool     // Run Redis inspection only
-	skipRedis        bool     // Skip Redis inspection
-	nginxMetricsPath string   // Path to Nginx metrics definition file
-	nginxOnly        bool     // Run Nginx inspection only
-	skipNginx        bool     // Skip Nginx inspection
-	tomcatMetricsPath string  // Path to Tomcat metrics definition file
-	tomcatOnly        bool    // Run Tomcat inspection only
-	skipTomcat        bool    // Skip Tomcat inspection
+	outputDir             string   // Output directory for reports
+	formats               []string // Output formats (excel, html, html-email, csv, json)
+	metricsPath           string   // Path to metrics definition file
+	mysqlMetricsPath      string   // Path to MySQL metrics definition file
+	mysqlOnly             bool     // Run MySQL inspection only
+	skipMySQL             bool     // Skip MySQL inspection
+	redisMetricsPath      string   // Path to Redis metrics definition file
+	redisOnly             bool     // Run Redis inspection only
+	skipRedis             bool     // Skip Redis inspection
+	nginxMetricsPath      string   // Path to Nginx metrics definition file
+	nginxOnly             bool     // Run Nginx inspection only
+	skipNginx             bool     // Skip Nginx inspection
+	tomcatMetricsPath     string   // Path to Tomcat metrics definition file
+	tomcatOnly            bool     // Run Tomcat inspection only
+	skipTomcat            bool     // Skip Tomcat inspection
+	cassandraMetricsPath  string   // Path to Cassandra metrics definition file
+	cassandraOnly         bool     // Run Cassandra inspection only
+	skipCassandra         bool     // Skip Cassandra inspection
+	monitoringMetricsPath string   // Path to monitoring stack metrics definition file
+	monitoringOnly        bool     // Run monitoring stack inspection only
+	skipMonitoring        bool     // Skip monitoring stack inspection
+	storageMetricsPath    string   // Path to shared storage metrics definition file
+	storageOnly           bool     // Run shared storage inspection only
+	skipStorage           bool     // Skip shared storage inspection
+	logChecksPath         string   // Path to log checks definition file
+	logChecksOnly         bool     // Run log checks inspection only
+	skipLogChecks         bool     // Skip log checks inspection
+	lvsMetricsPath        string   // Path to LVS metrics definition file
+	lvsOnly               bool     // Run LVS inspection only
+	skipLVS               bool     // Skip LVS inspection
+	windowsMetricsPath    string   // Path to Windows metrics definition file
+	windowsOnly           bool     // Run Windows service / IIS inspection only
+	skipWindows           bool     // Skip Windows service / IIS inspection
+	adMetricsPath         string   // Path to AD metrics definition file
+	adOnly                bool     // Run AD / LDAP inspection only
+	skipAD                bool     // Skip AD / LDAP inspection
+	cloudMetricsPath      string   // Path to cloud resource metrics definition file
+	cloudOnly             bool     // Run cloud resource inspection only
+	skipCloud             bool     // Skip cloud resource inspection
+	firewallMetricsPath   string   // Path to firewall metrics definition file
+	firewallOnly          bool     // Run firewall / VPN inspection only
+	skipFirewall          bool     // Skip firewall / VPN inspection
+	ciProvider            string   // CI job log markup (auto, github, gitlab), "" when off
+	presetName            string   // Inspection preset (quick), "" when off
+	rangeStart            string   // Start of the range aggregation window (--start), "" when unset
+	rangeEnd              string   // End of the range aggregation window (--end), "" for now
+	skipHost              bool     // Skip host inspection
+	businessGroups        []string // Business groups overriding inspection.host_filter.business_groups
+	excludeHosts          []string // Host patterns added to inspection.host_filter.exclude
+	localRun              bool     // Ignore distributed.workers and inspect on this instance
+	noCache               bool     // Bypass the query result cache (datasources.victoriametrics.cache)
+	dryRun                bool     // Print the queries and planned reports without querying any datasource
+	resume                bool     // Skip the modules and host metrics completed by the interrupted inspection of the checkpoint
+	failOn                string   // Lowest alert severity failing the run (warning, critical, never)
+	onlyModules           []string // Modules to run, overriding inspection.modules.only
+	skipModules           []string // Modules to skip, overriding inspection.modules.skip
 )
 
-// runCmd represents the run command.
-var runCmd = &cobra.Command{
-	Use:   "run",
-	Short: "执行系统巡检",
+// inspectCmd represents the inspect command.
+var inspectCmd = &cobra.Command{
+	Use:     "inspect",
+	Aliases: []string{"run"},
+	Short:   "执行系统巡检",
 	Long: `执行完整的系统巡检流程，包括：
 1. 从夜莺（N9E）获取主机元信息
 2. 从 VictoriaMetrics 查询监控指标
@@ -52,75 +99,254 @@ var runCmd = &cobra.Command{
 4. 执行 Redis 集群巡检（如果启用）
 5. 执行 Nginx/OpenResty 巡检（如果启用）
 6. 执行 Tomcat 应用巡检（如果启用）
-7. 根据配置的阈值评估告警级别
-8. 生成 Excel 和 HTML 格式的巡检报告
+7. 执行 Cassandra 集群巡检（如果启用）
+8. 执行监控系统自身巡检（VictoriaMetrics/InfluxDB，如果启用）
+9. 执行共享存储巡检（NFS/GlusterFS，如果启用）
+10. 执行日志巡检（Loki/VictoriaLogs，如果启用）
+11. 执行 LVS 负载均衡巡检（IPVS，如果启用）
+12. 执行 Windows 服务与 IIS 巡检（如果启用）
+13. 执行 AD 域控制器巡检（复制、LDAP、SYSVOL，如果启用）
+14. 执行云资源巡检（阿里云 / AWS 的 RDS、Redis、SLB，如果启用）
+15. 根据配置的阈值评估告警级别
+16. 生成 Excel 和 HTML 格式的巡检报告
+
+配置 distributed.workers 时作为协调者运行：按业务组拆分主机巡检、按模块分配其他巡检，
+分发给各 worker（inspect worker）执行，汇总结果后在本机生成一份合并报告。
+
+run 为本命令的别名，已有定时任务中的 "inspect run" 无需修改。
 
 示例:
-  # 使用默认配置执行巡检（包含 Host、MySQL、Redis、Nginx 和 Tomcat）
-  inspect run -c config.yaml
+  # 使用默认配置执行巡检（包含 Host、MySQL、Redis、Nginx、Tomcat、Cassandra、监控系统、共享存储、日志巡检、LVS、Windows、AD 和云资源）
+  inspect inspect -c config.yaml
 
   # 仅执行 MySQL 巡检
-  inspect run -c config.yaml --mysql-only
+  inspect inspect -c config.yaml --mysql-only
 
   # 仅执行 Redis 巡检
-  inspect run -c config.yaml --redis-only
+  inspect inspect -c config.yaml --redis-only
 
   # 仅执行 Nginx 巡检
-  inspect run -c config.yaml --nginx-only
+  inspect inspect -c config.yaml --nginx-only
 
   # 仅执行 Tomcat 巡检
-  inspect run -c config.yaml --tomcat-only
+  inspect inspect -c config.yaml --tomcat-only
+
+  # 仅执行 Cassandra 巡检
+  inspect inspect -c config.yaml --cassandra-only
+
+  # 仅执行监控系统自身巡检
+  inspect inspect -c config.yaml --monitoring-only
+
+  # 仅执行共享存储巡检
+  inspect inspect -c config.yaml --storage-only
+
+  # 仅执行日志巡检
+  inspect inspect -c config.yaml --log-checks-only
+
+  # 仅执行 LVS 巡检
+  inspect inspect -c config.yaml --lvs-only
+
+  # 仅执行 Windows 服务与 IIS 巡检
+  inspect inspect -c config.yaml --windows-only
+
+  # 仅执行 AD 域控制器巡检
+  inspect inspect -c config.yaml --ad-only
+
+  # 仅执行云资源巡检
+  inspect inspect -c config.yaml --cloud-only
+
+  # 仅执行防火墙巡检
+  inspect inspect -c config.yaml --firewall-only
 
   # 跳过 MySQL 巡检
-  inspect run -c config.yaml --skip-mysql
+  inspect inspect -c config.yaml --skip-mysql
 
   # 跳过 Redis 巡检
-  inspect run -c config.yaml --skip-redis
+  inspect inspect -c config.yaml --skip-redis
 
   # 跳过 Nginx 巡检
-  inspect run -c config.yaml --skip-nginx
+  inspect inspect -c config.yaml --skip-nginx
 
   # 跳过 Tomcat 巡检
-  inspect run -c config.yaml --skip-tomcat
+  inspect inspect -c config.yaml --skip-tomcat
+
+  # 跳过 Cassandra 巡检
+  inspect inspect -c config.yaml --skip-cassandra
+
+  # 跳过监控系统自身巡检
+  inspect inspect -c config.yaml --skip-monitoring
+
+  # 跳过共享存储巡检
+  inspect inspect -c config.yaml --skip-storage
+
+  # 跳过日志巡检
+  inspect inspect -c config.yaml --skip-log-checks
+
+  # 跳过 LVS 巡检
+  inspect inspect -c config.yaml --skip-lvs
+
+  # 跳过 Windows 服务与 IIS 巡检
+  inspect inspect -c config.yaml --skip-windows
 
-  # 仅执行 Host 巡检（跳过 MySQL、Redis、Nginx 和 Tomcat）
-  inspect run -c config.yaml --skip-mysql --skip-redis --skip-nginx --skip-tomcat
+  # 跳过 AD 域控制器巡检
+  inspect inspect -c config.yaml --skip-ad
+
+  # 跳过云资源巡检
+  inspect inspect -c config.yaml --skip-cloud
+
+  # 跳过防火墙巡检
+  inspect inspect -c config.yaml --skip-firewall
+
+  # 仅执行指定模块（模块名同 inspection.modules，可用逗号分隔多个）
+  inspect inspect -c config.yaml --only redis,mysql
+
+  # 跳过指定模块
+  inspect inspect -c config.yaml --skip host,cloud
+
+  # 仅执行 Host 巡检（跳过 MySQL、Redis、Nginx、Tomcat、Cassandra、监控系统、共享存储、日志巡检、LVS、Windows、AD、云资源和防火墙）
+  inspect inspect -c config.yaml --skip-mysql --skip-redis --skip-nginx --skip-tomcat --skip-cassandra --skip-monitoring --skip-storage --skip-log-checks --skip-lvs --skip-windows --skip-ad --skip-cloud --skip-firewall
 
   # 指定输出格式和目录
-  inspect run -c config.yaml -f excel,html -o ./reports
+  inspect inspect -c config.yaml -f excel,html -o ./reports
+
+  # 导出 CSV（每个 sheet 一个文件，便于导入 BI / 数据库）
+  inspect inspect -c config.yaml -f csv
+
+  # 导出 JSON（带 schema 版本号，便于程序消费与归档）
+  inspect inspect -c config.yaml -f json
+
+  # 生成邮件正文版 HTML（内联样式、无脚本，可直接粘贴到邮件正文）
+  inspect inspect -c config.yaml -f html-email
+
+  # 快速健康检查（变更前抽查，仅主机 / MySQL / Redis 的可用性、磁盘、内存与复制状态，1 分钟内完成）
+  inspect inspect -c config.yaml --preset quick
+
+  # 预演：打印将执行的查询（已注入主机与实例过滤条件）和将生成的报告，不查询任何数据源
+  inspect inspect -c config.yaml --dry-run
+
+  # 巡检中途被中断（网络抖动、Ctrl-C）后继续，已完成的模块和已采集的主机指标不再查询
+  inspect inspect -c config.yaml --resume
+
+  # 在 CI 流水线中执行（折叠各模块日志，告警输出为 GitHub/GitLab 注解）
+  inspect inspect -c config.yaml --ci
+
+  # 仅在有严重告警时以非零退出码结束（警告不中断定时任务或流水线）
+  inspect inspect -c config.yaml --fail-on critical
+
+  # 跳过查询结果缓存，直接查询指标数据源
+  inspect inspect -c config.yaml --no-cache
+
+  # 仅巡检指定业务组的主机
+  inspect inspect -c config.yaml --business-groups 业务组A,业务组B
+
+  # 排除已下线或已知故障的主机（通配符或 /正则/，可重复指定）
+  inspect inspect -c config.yaml --exclude-host "test-*" --exclude-host "/^web-0[1-3]$/"
 
   # 使用自定义指标定义文件
-  inspect run -c config.yaml -m custom_metrics.yaml --mysql-metrics custom_mysql_metrics.yaml --redis-metrics custom_redis_metrics.yaml --nginx-metrics custom_nginx_metrics.yaml --tomcat-metrics custom_tomcat_metrics.yaml`,
+  inspect inspect -c config.yaml -m custom_metrics.yaml --mysql-metrics custom_mysql_metrics.yaml --redis-metrics custom_redis_metrics.yaml --nginx-metrics custom_nginx_metrics.yaml --tomcat-metrics custom_tomcat_metrics.yaml --cassandra-metrics custom_cassandra_metrics.yaml --monitoring-metrics custom_monitoring_metrics.yaml --storage-metrics custom_storage_metrics.yaml --log-checks custom_log_checks.yaml --lvs-metrics custom_lvs_metrics.yaml --windows-metrics custom_windows_metrics.yaml --ad-metrics custom_ad_metrics.yaml --cloud-metrics custom_cloud_metrics.yaml --firewall-metrics custom_firewall_metrics.yaml`,
 	Run: runInspection,
 }
 
 func init() {
-	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(inspectCmd)
 
 	// Define command-specific flags
-	runCmd.Flags().StringSliceVarP(&formats, "format", "f", nil, "输出格式 (excel,html)，可用逗号分隔多个")
-	runCmd.Flags().StringVarP(&outputDir, "output", "o", "", "输出目录")
-	runCmd.Flags().StringVarP(&metricsPath, "metrics", "m", "configs/metrics.yaml", "指标定义文件路径")
+	inspectCmd.Flags().StringSliceVarP(&formats, "format", "f", nil, "输出格式 (excel,html,html-email,csv,json)，覆盖 report.formats，可用逗号分隔多个")
+	inspectCmd.Flags().StringVarP(&outputDir, "output", "o", "", "输出目录")
+	addMetricsFlags(inspectCmd)
 
 	// MySQL-specific flags
-	runCmd.Flags().StringVar(&mysqlMetricsPath, "mysql-metrics", "configs/mysql-metrics.yaml", "MySQL 指标定义文件路径")
-	runCmd.Flags().BoolVar(&mysqlOnly, "mysql-only", false, "仅执行 MySQL 巡检")
-	runCmd.Flags().BoolVar(&skipMySQL, "skip-mysql", false, "跳过 MySQL 巡检")
+	inspectCmd.Flags().BoolVar(&mysqlOnly, "mysql-only", false, "仅执行 MySQL 巡检")
+	inspectCmd.Flags().BoolVar(&skipMySQL, "skip-mysql", false, "跳过 MySQL 巡检")
 
 	// Redis-specific flags
-	runCmd.Flags().StringVar(&redisMetricsPath, "redis-metrics", "configs/redis-metrics.yaml", "Redis 指标定义文件路径")
-	runCmd.Flags().BoolVar(&redisOnly, "redis-only", false, "仅执行 Redis 巡检")
-	runCmd.Flags().BoolVar(&skipRedis, "skip-redis", false, "跳过 Redis 巡检")
+	inspectCmd.Flags().BoolVar(&redisOnly, "redis-only", false, "仅执行 Redis 巡检")
+	inspectCmd.Flags().BoolVar(&skipRedis, "skip-redis", false, "跳过 Redis 巡检")
 
 	// Nginx-specific flags
-	runCmd.Flags().StringVar(&nginxMetricsPath, "nginx-metrics", "configs/nginx-metrics.yaml", "Nginx 指标定义文件路径")
-	runCmd.Flags().BoolVar(&nginxOnly, "nginx-only", false, "仅执行 Nginx 巡检")
-	runCmd.Flags().BoolVar(&skipNginx, "skip-nginx", false, "跳过 Nginx 巡检")
+	inspectCmd.Flags().BoolVar(&nginxOnly, "nginx-only", false, "仅执行 Nginx 巡检")
+	inspectCmd.Flags().BoolVar(&skipNginx, "skip-nginx", false, "跳过 Nginx 巡检")
 
 	// Tomcat-specific flags
-	runCmd.Flags().StringVar(&tomcatMetricsPath, "tomcat-metrics", "configs/tomcat-metrics.yaml", "Tomcat 指标定义文件路径")
-	runCmd.Flags().BoolVar(&tomcatOnly, "tomcat-only", false, "仅执行 Tomcat 巡检")
-	runCmd.Flags().BoolVar(&skipTomcat, "skip-tomcat", false, "跳过 Tomcat 巡检")
+	inspectCmd.Flags().BoolVar(&tomcatOnly, "tomcat-only", false, "仅执行 Tomcat 巡检")
+	inspectCmd.Flags().BoolVar(&skipTomcat, "skip-tomcat", false, "跳过 Tomcat 巡检")
+
+	// Cassandra-specific flags
+	inspectCmd.Flags().BoolVar(&cassandraOnly, "cassandra-only", false, "仅执行 Cassandra 巡检")
+	inspectCmd.Flags().BoolVar(&skipCassandra, "skip-cassandra", false, "跳过 Cassandra 巡检")
+
+	// Monitoring stack flags
+	inspectCmd.Flags().BoolVar(&monitoringOnly, "monitoring-only", false, "仅执行监控系统自身巡检")
+	inspectCmd.Flags().BoolVar(&skipMonitoring, "skip-monitoring", false, "跳过监控系统自身巡检")
+
+	// Shared storage flags
+	inspectCmd.Flags().BoolVar(&storageOnly, "storage-only", false, "仅执行共享存储（NFS/GlusterFS）巡检")
+	inspectCmd.Flags().BoolVar(&skipStorage, "skip-storage", false, "跳过共享存储巡检")
+
+	// Log checks flags
+	inspectCmd.Flags().BoolVar(&logChecksOnly, "log-checks-only", false, "仅执行日志巡检（Loki/VictoriaLogs）")
+	inspectCmd.Flags().BoolVar(&skipLogChecks, "skip-log-checks", false, "跳过日志巡检")
+
+	// LVS flags
+	inspectCmd.Flags().BoolVar(&lvsOnly, "lvs-only", false, "仅执行 LVS 巡检（IPVS）")
+	inspectCmd.Flags().BoolVar(&skipLVS, "skip-lvs", false, "跳过 LVS 巡检")
+
+	// Windows service / IIS flags
+	inspectCmd.Flags().BoolVar(&windowsOnly, "windows-only", false, "仅执行 Windows 服务与 IIS 巡检")
+	inspectCmd.Flags().BoolVar(&skipWindows, "skip-windows", false, "跳过 Windows 服务与 IIS 巡检")
+
+	// AD / LDAP flags
+	inspectCmd.Flags().BoolVar(&adOnly, "ad-only", false, "仅执行 AD 域控制器巡检")
+	inspectCmd.Flags().BoolVar(&skipAD, "skip-ad", false, "跳过 AD 域控制器巡检")
+
+	// Cloud resource flags
+	inspectCmd.Flags().BoolVar(&cloudOnly, "cloud-only", false, "仅执行云资源巡检")
+	inspectCmd.Flags().BoolVar(&skipCloud, "skip-cloud", false, "跳过云资源巡检")
+
+	// Firewall / VPN flags
+	inspectCmd.Flags().BoolVar(&firewallOnly, "firewall-only", false, "仅执行防火墙巡检")
+	inspectCmd.Flags().BoolVar(&skipFirewall, "skip-firewall", false, "跳过防火墙巡检")
+
+	// CI flags
+	inspectCmd.Flags().StringVar(&ciProvider, "ci", "", "CI 模式：折叠日志分组并为告警输出流水线注解 (auto,github,gitlab)，仅写 --ci 时为 auto")
+	inspectCmd.Flags().Lookup("ci").NoOptDefVal = ciProviderAuto
+
+	// Preset flags
+	inspectCmd.Flags().StringVar(&presetName, "preset", "", "巡检预设 (quick: 快速健康检查，仅查询可用性、磁盘、内存与复制状态并缩短超时)")
+
+	// Host scope and distributed flags
+	inspectCmd.Flags().BoolVar(&skipHost, "skip-host", false, "跳过主机巡检")
+	inspectCmd.Flags().StringSliceVar(&businessGroups, "business-groups", nil, "仅巡检指定业务组的主机（覆盖 inspection.host_filter.business_groups），可用逗号分隔多个")
+	inspectCmd.Flags().StringArrayVar(&excludeHosts, "exclude-host", nil, "排除匹配的主机（通配符如 \"test-*\" 或 /正则/，按主机名、ident 或 IP 匹配，追加到 inspection.host_filter.exclude），可重复指定")
+	inspectCmd.Flags().BoolVar(&localRun, "local", false, "忽略 distributed.workers，在本机执行巡检（worker 执行任务时使用）")
+	inspectCmd.Flags().BoolVar(&noCache, "no-cache", false, "忽略查询结果缓存，直接查询指标数据源（datasources.victoriametrics.cache 启用时）")
+	inspectCmd.Flags().StringSliceVar(&onlyModules, "only", nil, "仅执行指定模块（覆盖 inspection.modules.only），可用逗号分隔多个: "+strings.Join(config.ModuleNames, ","))
+	inspectCmd.Flags().StringSliceVar(&skipModules, "skip", nil, "跳过指定模块（覆盖 inspection.modules.skip），可用逗号分隔多个")
+	inspectCmd.Flags().StringVar(&failOn, "fail-on", failOnWarning, "以非零退出码结束的告警级别 (warning: 有警告或严重告警时，critical: 仅有严重告警时，never: 巡检完成即返回 0)")
+	inspectCmd.Flags().BoolVar(&resume, "resume", false, "从断点恢复被中断的巡检：跳过上次已完成的模块和已采集的主机指标，只巡检未完成的部分（各模块完成、各主机指标采集后自动保存断点到输出目录）")
+	inspectCmd.Flags().BoolVar(&dryRun, "dry-run", false, "预演模式：打印生效的指标、注入过滤条件后的查询与计划生成的报告，不查询数据源、不生成报告")
+
+	// Range aggregation window flags
+	inspectCmd.Flags().StringVar(&rangeStart, "start", "", "范围聚合窗口起始时间（如 \"2026-10-01 00:00\"，按 report.timezone 解析），定义了 range_function 的主机指标在 --start 至 --end 内聚合，覆盖 inspection.range_window")
+	inspectCmd.Flags().StringVar(&rangeEnd, "end", "", "范围聚合窗口结束时间（默认为当前时间）")
+}
+
+// addMetricsFlags adds the flags of the metric definition files of every module to cmd.
+func addMetricsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&metricsPath, "metrics", "m", "configs/metrics.yaml", "指标定义文件路径")
+	cmd.Flags().StringVar(&mysqlMetricsPath, "mysql-metrics", "configs/mysql-metrics.yaml", "MySQL 指标定义文件路径")
+	cmd.Flags().StringVar(&redisMetricsPath, "redis-metrics", "configs/redis-metrics.yaml", "Redis 指标定义文件路径")
+	cmd.Flags().StringVar(&nginxMetricsPath, "nginx-metrics", "configs/nginx-metrics.yaml", "Nginx 指标定义文件路径")
+	cmd.Flags().StringVar(&tomcatMetricsPath, "tomcat-metrics", "configs/tomcat-metrics.yaml", "Tomcat 指标定义文件路径")
+	cmd.Flags().StringVar(&cassandraMetricsPath, "cassandra-metrics", "configs/cassandra-metrics.yaml", "Cassandra 指标定义文件路径")
+	cmd.Flags().StringVar(&monitoringMetricsPath, "monitoring-metrics", "configs/monitoring-metrics.yaml", "监控系统指标定义文件路径")
+	cmd.Flags().StringVar(&storageMetricsPath, "storage-metrics", "configs/storage-metrics.yaml", "共享存储指标定义文件路径")
+	cmd.Flags().StringVar(&logChecksPath, "log-checks", "configs/log-checks.yaml", "日志检查项定义文件路径")
+	cmd.Flags().StringVar(&lvsMetricsPath, "lvs-metrics", "configs/lvs-metrics.yaml", "LVS 指标定义文件路径")
+	cmd.Flags().StringVar(&windowsMetricsPath, "windows-metrics", "configs/windows-metrics.yaml", "Windows 服务与 IIS 指标定义文件路径")
+	cmd.Flags().StringVar(&adMetricsPath, "ad-metrics", "configs/ad-metrics.yaml", "AD 域控制器指标定义文件路径")
+	cmd.Flags().StringVar(&cloudMetricsPath, "cloud-metrics", "configs/cloud-metrics.yaml", "云资源指标定义文件路径")
+	cmd.Flags().StringVar(&firewallMetricsPath, "firewall-metrics", "configs/firewall-metrics.yaml", "防火墙指标定义文件路径")
 }
 
 // runInspection executes the complete inspection workflow.
@@ -153,172 +379,114 @@ func runInspection(cmd *cobra.Command, args []string) {
 		Str("log_format", cfg.Logging.Format).
 		Msg("configuration loaded successfully")
 
-	// Step 2.5: Validate flag mutual exclusion
-	if mysqlOnly && skipMySQL {
-		fmt.Fprintf(os.Stderr, "❌ --mysql-only 和 --skip-mysql 不能同时使用\n")
-		os.Exit(1)
-	}
-	if redisOnly && skipRedis {
-		fmt.Fprintf(os.Stderr, "❌ --redis-only 和 --skip-redis 不能同时使用\n")
-		os.Exit(1)
+	// Host scope override (e.g. the business groups of a distributed worker job)
+	if len(businessGroups) > 0 {
+		cfg.Inspection.HostFilter.BusinessGroups = businessGroups
 	}
-	if redisOnly && mysqlOnly {
-		fmt.Fprintf(os.Stderr, "❌ --redis-only 和 --mysql-only 不能同时使用\n")
-		os.Exit(1)
-	}
-	if nginxOnly && skipNginx {
-		fmt.Fprintf(os.Stderr, "❌ --nginx-only 和 --skip-nginx 不能同时使用\n")
-		os.Exit(1)
-	}
-	if nginxOnly && mysqlOnly {
-		fmt.Fprintf(os.Stderr, "❌ --nginx-only 和 --mysql-only 不能同时使用\n")
-		os.Exit(1)
-	}
-	if nginxOnly && redisOnly {
-		fmt.Fprintf(os.Stderr, "❌ --nginx-only 和 --redis-only 不能同时使用\n")
-		os.Exit(1)
+	if len(excludeHosts) > 0 {
+		if _, err := config.CompileHostPatterns(excludeHosts); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ --exclude-host: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.Inspection.HostFilter.Exclude = append(cfg.Inspection.HostFilter.Exclude, excludeHosts...)
 	}
 
-	// Tomcat flag validation
-	if tomcatOnly && skipTomcat {
-		fmt.Fprintf(os.Stderr, "❌ --tomcat-only 和 --skip-tomcat 不能同时使用\n")
-		os.Exit(1)
-	}
-	if tomcatOnly && mysqlOnly {
-		fmt.Fprintf(os.Stderr, "❌ --tomcat-only 和 --mysql-only 不能同时使用\n")
-		os.Exit(1)
-	}
-	if tomcatOnly && redisOnly {
-		fmt.Fprintf(os.Stderr, "❌ --tomcat-only 和 --redis-only 不能同时使用\n")
-		os.Exit(1)
-	}
-	if tomcatOnly && nginxOnly {
-		fmt.Fprintf(os.Stderr, "❌ --tomcat-only 和 --nginx-only 不能同时使用\n")
-		os.Exit(1)
+	// Query cache bypass, e.g. when the metrics changed since the last run
+	if noCache {
+		cfg.Datasources.VictoriaMetrics.Cache.Enabled = false
 	}
 
-	// Determine execution mode
-	runHostInspection := !mysqlOnly && !redisOnly && !nginxOnly && !tomcatOnly
-	runMySQLInspection := !skipMySQL && !redisOnly && !nginxOnly && !tomcatOnly && cfg.MySQL.Enabled
-	runRedisInspection := !skipRedis && !mysqlOnly && !nginxOnly && !tomcatOnly && cfg.Redis.Enabled
-	runNginxInspection := !skipNginx && !mysqlOnly && !redisOnly && !tomcatOnly && cfg.Nginx.Enabled
-	runTomcatInspection := !skipTomcat && !mysqlOnly && !redisOnly && !nginxOnly && cfg.Tomcat.Enabled
-
-	// If --mysql-only but MySQL is not enabled
-	if mysqlOnly && !cfg.MySQL.Enabled {
-		fmt.Fprintf(os.Stderr, "❌ MySQL 巡检未启用，请在配置文件中设置 mysql.enabled: true\n")
+	// Range aggregation window override (--start / --end)
+	if err := applyRangeWindowFlags(&cfg.Inspection, rangeStart, rangeEnd, cfg.Report.Timezone, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	// If --redis-only but Redis is not enabled
-	if redisOnly && !cfg.Redis.Enabled {
-		fmt.Fprintf(os.Stderr, "❌ Redis 巡检未启用，请在配置文件中设置 redis.enabled: true\n")
+	// Inspection preset: caps timeouts before the datasource clients are created
+	var preset *config.Preset
+	if presetName != "" {
+		preset, err = config.LookupPreset(presetName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		preset.Apply(cfg)
+		fmt.Printf("⚡ 巡检预设: %s - %s\n", preset.Name, preset.Description)
+		logger.Debug().
+			Str("preset", preset.Name).
+			Strs("modules", preset.Modules).
+			Dur("query_timeout", cfg.Datasources.VictoriaMetrics.Timeout).
+			Dur("deadline", preset.Deadline).
+			Msg("inspection preset applied")
+	}
+
+	// CI mode: log groups and alert annotations for pipeline job output
+	ci, err := newCIReporter(os.Stdout, ciProvider)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	// If --nginx-only but Nginx is not enabled
-	if nginxOnly && !cfg.Nginx.Enabled {
-		fmt.Fprintf(os.Stderr, "❌ Nginx 巡检未启用，请在配置文件中设置 nginx.enabled: true\n")
+	// Output formats (--format), checked before any datasource is queried
+	if err := validateFormatFlag(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	// If --tomcat-only but Tomcat is not enabled
-	if tomcatOnly && !cfg.Tomcat.Enabled {
-		fmt.Fprintf(os.Stderr, "❌ Tomcat 巡检未启用，请在配置文件中设置 tomcat.enabled: true\n")
+	// Exit code policy (--fail-on)
+	if err := validateFailOn(failOn); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	logger.Debug().
-		Bool("run_host", runHostInspection).
-		Bool("run_mysql", runMySQLInspection).
-		Bool("run_redis", runRedisInspection).
-		Bool("run_nginx", runNginxInspection).
-		Bool("run_tomcat", runTomcatInspection).
-		Bool("mysql_enabled", cfg.MySQL.Enabled).
-		Bool("redis_enabled", cfg.Redis.Enabled).
-		Bool("nginx_enabled", cfg.Nginx.Enabled).
-		Bool("tomcat_enabled", cfg.Tomcat.Enabled).
-		Msg("execution mode determined")
-
-	// Step 3: Load Host metrics definitions (if needed)
-	var metrics []*model.MetricDefinition
-	if runHostInspection {
-		fmt.Printf("📊 加载主机指标定义: %s", metricsPath)
-		metrics, err = config.LoadMetrics(metricsPath)
-		if err != nil {
-			logger.Error().Err(err).Str("path", metricsPath).Msg("failed to load metrics")
-			fmt.Fprintf(os.Stderr, "\n❌ 加载指标定义失败: %v\n", err)
-			os.Exit(1)
-		}
-		activeCount := config.CountActiveMetrics(metrics)
-		fmt.Printf(" (%d 个活跃指标)\n", activeCount)
-		logger.Debug().Int("active_metrics", activeCount).Int("total_metrics", len(metrics)).Msg("host metrics loaded")
-	}
-
-	// Step 3b: Load MySQL metrics definitions (if needed)
-	var mysqlMetrics []*model.MySQLMetricDefinition
-	if runMySQLInspection {
-		fmt.Printf("📊 加载 MySQL 指标定义: %s", mysqlMetricsPath)
-		mysqlMetrics, err = config.LoadMySQLMetrics(mysqlMetricsPath)
-		if err != nil {
-			logger.Error().Err(err).Str("path", mysqlMetricsPath).Msg("failed to load MySQL metrics")
-			fmt.Fprintf(os.Stderr, "\n❌ 加载 MySQL 指标定义失败: %v\n", err)
-			os.Exit(1)
-		}
-		mysqlActiveCount := config.CountActiveMySQLMetrics(mysqlMetrics)
-		fmt.Printf(" (%d 个活跃指标)\n", mysqlActiveCount)
-		logger.Debug().Int("active_metrics", mysqlActiveCount).Int("total_metrics", len(mysqlMetrics)).Msg("MySQL metrics loaded")
+	// Step 2.5: Validate flag mutual exclusion
+	if err := validateOnlyFlags(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
 	}
 
-	// Step 3c: Load Redis metrics definitions (if needed)
-	var redisMetrics []*model.RedisMetricDefinition
-	if runRedisInspection {
-		fmt.Printf("📊 加载 Redis 指标定义: %s", redisMetricsPath)
-		redisMetrics, err = config.LoadRedisMetrics(redisMetricsPath)
+	// Determine execution mode
+	plan := planModules(cfg, preset)
+
+	// Business group tree: validates and expands the host filter before it is sharded over
+	// the workers or applied to the queries
+	var groupTree *model.BusinessGroupTree
+	if plan.host && cfg.Datasources.N9E.BusinessGroupSync && dryRun {
+		fmt.Println("🌳 业务组: 预演模式不同步夜莺业务组，查询中的业务组不含下级")
+	} else if plan.host && cfg.Datasources.N9E.BusinessGroupSync {
+		groupTree, err = syncBusinessGroups(context.Background(), cfg, logger)
 		if err != nil {
-			logger.Error().Err(err).Str("path", redisMetricsPath).Msg("failed to load Redis metrics")
-			fmt.Fprintf(os.Stderr, "\n❌ 加载 Redis 指标定义失败: %v\n", err)
+			logger.Error().Err(err).Msg("invalid business group filter")
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
 			os.Exit(1)
 		}
-		redisActiveCount := config.CountActiveRedisMetrics(redisMetrics)
-		fmt.Printf(" (%d 个活跃指标)\n", redisActiveCount)
-		logger.Debug().Int("active_metrics", redisActiveCount).Int("total_metrics", len(redisMetrics)).Msg("Redis metrics loaded")
 	}
 
-	// Step 3d: Load Nginx metrics definitions (if needed)
-	var nginxMetrics []*model.NginxMetricDefinition
-	if runNginxInspection {
-		fmt.Printf("📊 加载 Nginx 指标定义: %s", nginxMetricsPath)
-		nginxMetrics, err = config.LoadNginxMetrics(nginxMetricsPath)
-		if err != nil {
-			logger.Error().Err(err).Str("path", nginxMetricsPath).Msg("failed to load Nginx metrics")
-			fmt.Fprintf(os.Stderr, "\n❌ 加载 Nginx 指标定义失败: %v\n", err)
-			os.Exit(1)
-		}
-		nginxActiveCount := config.CountActiveNginxMetrics(nginxMetrics)
-		fmt.Printf(" (%d 个活跃指标)\n", nginxActiveCount)
-		logger.Debug().Int("active_metrics", nginxActiveCount).Int("total_metrics", len(nginxMetrics)).Msg("Nginx metrics loaded")
+	// Distributed mode: the modules are inspected by the workers, this instance merges their
+	// results and renders the reports
+	var workerAssignments []*distributed.Assignment
+	if len(cfg.Distributed.Workers) > 0 && !localRun {
+		workerAssignments = planWorkers(cfg, plan)
 	}
+	plan.log(cfg, logger)
 
-	// Step 3e: Load Tomcat metrics definitions (if needed)
-	var tomcatMetrics []*model.TomcatMetricDefinition
-	if runTomcatInspection {
-		fmt.Printf("📊 加载 Tomcat 指标定义: %s", tomcatMetricsPath)
-		tomcatMetrics, err = config.LoadTomcatMetrics(tomcatMetricsPath)
-		if err != nil {
-			logger.Error().Err(err).Str("path", tomcatMetricsPath).Msg("failed to load Tomcat metrics")
-			fmt.Fprintf(os.Stderr, "\n❌ 加载 Tomcat 指标定义失败: %v\n", err)
-			os.Exit(1)
-		}
-		tomcatActiveCount := config.CountActiveTomcatMetrics(tomcatMetrics)
-		fmt.Printf(" (%d 个活跃指标)\n", tomcatActiveCount)
-		logger.Debug().Int("active_metrics", tomcatActiveCount).Int("total_metrics", len(tomcatMetrics)).Msg("Tomcat metrics loaded")
+	// Step 3: Load metric definitions of the modules to inspect
+	defs, err := loadModuleDefinitions(cfg, plan, preset, os.Stdout, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n❌ %v\n", err)
+		os.Exit(1)
 	}
 
 	// Step 4: Determine output settings
 	outputFormats := resolveFormats(cfg)
 	outputPath := resolveOutputDir(cfg)
 
+	// Dry run: print the queries and the planned reports, then stop before any request
+	if dryRun {
+		printDryRun(os.Stdout, cfg, dryRunModules(cfg, plan, defs, logger), outputFormats, outputPath)
+		return
+	}
+
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputPath, 0755); err != nil {
 		logger.Error().Err(err).Str("path", outputPath).Msg("failed to create output directory")
@@ -326,234 +494,196 @@ func runInspection(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Step 5: Display data source info
-	fmt.Println("🔗 连接数据源...")
-	if runHostInspection {
-		fmt.Printf("   - 夜莺 N9E: %s\n", cfg.Datasources.N9E.Endpoint)
-	}
-	fmt.Printf("   - VictoriaMetrics: %s\n", cfg.Datasources.VictoriaMetrics.Endpoint)
-	fmt.Println()
-	logger.Info().
-		Str("n9e_endpoint", cfg.Datasources.N9E.Endpoint).
-		Str("vm_endpoint", cfg.Datasources.VictoriaMetrics.Endpoint).
-		Msg("connecting to data sources")
-
-	// Step 6: Create clients
-	var n9eClient *n9e.Client
-	if runHostInspection {
-		n9eClient = n9e.NewClient(&cfg.Datasources.N9E, &cfg.HTTP.Retry, logger)
-	}
-	vmClient := vm.NewClient(&cfg.Datasources.VictoriaMetrics, &cfg.HTTP.Retry, logger)
-	logger.Debug().Msg("API clients created")
-
-	// Load timezone for evaluators that need it
-	timezone, _ := time.LoadLocation("Asia/Shanghai")
-
-	// Step 7: Create Host services (if needed)
-	var inspector *service.Inspector
-	if runHostInspection {
-		collector := service.NewCollector(cfg, n9eClient, vmClient, metrics, logger)
-		evaluator := service.NewEvaluator(&cfg.Thresholds, metrics, logger)
-		inspector, err = service.NewInspector(cfg, collector, evaluator, logger, service.WithVersion(Version))
-		if err != nil {
-			logger.Error().Err(err).Msg("failed to create inspector")
-			fmt.Fprintf(os.Stderr, "❌ 创建巡检器失败: %v\n", err)
-			os.Exit(1)
-		}
-		logger.Debug().Msg("host services initialized")
-	}
-
-	// Step 7b: Create MySQL services (if needed)
-	var mysqlInspector *service.MySQLInspector
-	if runMySQLInspection {
-		mysqlCollector := service.NewMySQLCollector(&cfg.MySQL, vmClient, mysqlMetrics, logger)
-		mysqlEvaluator := service.NewMySQLEvaluator(&cfg.MySQL.Thresholds, mysqlMetrics, logger)
-		mysqlInspector, err = service.NewMySQLInspector(cfg, mysqlCollector, mysqlEvaluator, logger,
-			service.WithMySQLVersion(Version))
-		if err != nil {
-			logger.Error().Err(err).Msg("failed to create MySQL inspector")
-			fmt.Fprintf(os.Stderr, "❌ 创建 MySQL 巡检器失败: %v\n", err)
-			os.Exit(1)
-		}
-		logger.Debug().Msg("MySQL services initialized")
+	// Checkpoint saved after each module and host metric; --resume skips the modules and host
+	// metrics an interrupted run completed
+	moduleRuns := plan.runs()
+	checkpoint := newInspectionCheckpoint(outputPath, GetConfigFile(), json.CheckpointScope{
+		Preset:         presetName,
+		BusinessGroups: cfg.Inspection.HostFilter.BusinessGroups,
+		ExcludeHosts:   cfg.Inspection.HostFilter.Exclude,
+		RangeWindow:    cfg.Inspection.RangeWindow,
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+	}, moduleRuns, cfg.Report.Timezone, logger)
+	var resumed *json.Report
+	if resume {
+		resumed = checkpoint.resume(moduleRuns)
+	} else {
+		checkpoint.discard()
 	}
 
-	// Step 7c: Create Redis services (if needed)
-	var redisInspector *service.RedisInspector
-	if runRedisInspection {
-		redisCollector := service.NewRedisCollector(&cfg.Redis, vmClient, redisMetrics, logger)
-		redisEvaluator := service.NewRedisEvaluator(&cfg.Redis.Thresholds, redisMetrics, logger)
-		redisInspector, err = service.NewRedisInspector(cfg, redisCollector, redisEvaluator, logger,
-			service.WithRedisVersion(Version))
-		if err != nil {
-			logger.Error().Err(err).Msg("failed to create Redis inspector")
-			fmt.Fprintf(os.Stderr, "❌ 创建 Redis 巡检器失败: %v\n", err)
-			os.Exit(1)
-		}
-		logger.Debug().Msg("Redis services initialized")
-	}
+	// Step 5: Display data source info
+	printDatasources(cfg, plan, logger)
 
-	// Step 7d: Create Nginx services (if needed)
-	var nginxInspector *service.NginxInspector
-	if runNginxInspection {
-		nginxCollector := service.NewNginxCollector(&cfg.Nginx, vmClient, n9eClient, nginxMetrics, logger)
-		nginxEvaluator := service.NewNginxEvaluator(&cfg.Nginx.Thresholds, nginxMetrics, timezone, logger)
-		nginxInspector, err = service.NewNginxInspector(cfg, nginxCollector, nginxEvaluator, logger,
-			service.WithNginxVersion(Version))
-		if err != nil {
-			logger.Error().Err(err).Msg("failed to create Nginx inspector")
-			fmt.Fprintf(os.Stderr, "❌ 创建 Nginx 巡检器失败: %v\n", err)
-			os.Exit(1)
-		}
-		logger.Debug().Msg("Nginx services initialized")
-	}
-
-	// Step 7e: Create Tomcat services (if needed)
-	var tomcatInspector *service.TomcatInspector
-	if runTomcatInspection {
-		tomcatCollector := service.NewTomcatCollector(&cfg.Tomcat, vmClient, n9eClient, tomcatMetrics, logger)
-		tomcatEvaluator := service.NewTomcatEvaluator(&cfg.Tomcat.Thresholds, tomcatMetrics, timezone, logger)
-		tomcatInspector, err = service.NewTomcatInspector(cfg, tomcatCollector, tomcatEvaluator, logger,
-			service.WithTomcatVersion(Version))
-		if err != nil {
-			logger.Error().Err(err).Msg("failed to create Tomcat inspector")
-			fmt.Fprintf(os.Stderr, "❌ 创建 Tomcat 巡检器失败: %v\n", err)
-			os.Exit(1)
-		}
-		logger.Debug().Msg("Tomcat services initialized")
-	}
+	// Step 6: Create clients and inspectors
+	inspectors := newModuleInspectors(cfg, plan, defs, groupTree, checkpoint.hostMetrics(), logger)
 
 	// Step 8: Execute inspection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	deadline := 5 * time.Minute
+	if preset != nil && preset.Deadline > 0 {
+		deadline = preset.Deadline
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
 	defer cancel()
 	startTime := time.Now()
 
-	var hostResult *model.InspectionResult
-	var mysqlResult *model.MySQLInspectionResults
-	var redisResult *model.RedisInspectionResults
-	var nginxResult *model.NginxInspectionResults
-	var tomcatResult *model.TomcatInspectionResults
-
-	// Execute Host inspection
-	if runHostInspection {
-		fmt.Println("⏳ 开始主机巡检...")
-		hostResult, err = inspector.Run(ctx)
-		if err != nil {
-			logger.Error().Err(err).Msg("host inspection failed")
-			fmt.Fprintf(os.Stderr, "❌ 主机巡检执行失败: %v\n", err)
-			os.Exit(1)
+	results := resumed
+	if results == nil {
+		results = &json.Report{}
+	}
+	runner := &moduleRunner{
+		report:     results,
+		progress:   newModuleProgress(moduleRuns), // Modules resumed from the checkpoint are not counted
+		checkpoint: checkpoint,
+		ci:         ci,
+		logger:     logger,
+	}
+	if isTerminal(os.Stdout) {
+		runner.progress.showBars(os.Stdout, moduleRuns)
+	}
+	runner.run(ctx, plan, inspectors)
+
+	// Execute distributed inspection
+	if len(workerAssignments) > 0 {
+		results = runDistributed(cfg, workerAssignments, ci, logger)
+		// Host metric definitions give the queries of the raw data and provenance sheets
+		if results.Host != nil && (cfg.Report.RawDataSheet || cfg.Report.ProvenanceSheet) {
+			if defs.host, err = config.LoadMetrics(metricsPath); err != nil {
+				logger.Warn().Err(err).Str("path", metricsPath).Msg("failed to load metrics for the raw data sheet")
+			}
+			defs.host = service.ApplyRangeFunctions(defs.host, &cfg.Inspection)
 		}
-		fmt.Printf("\n📊 主机巡检完成！\n")
-		printSummary(hostResult)
 	}
 
-	// Execute MySQL inspection
-	if runMySQLInspection {
-		fmt.Println("\n⏳ 开始 MySQL 巡检...")
-		mysqlResult, err = mysqlInspector.Inspect(ctx)
-		if err != nil {
-			logger.Error().Err(err).Msg("MySQL inspection failed")
-			fmt.Fprintf(os.Stderr, "❌ MySQL 巡检执行失败: %v\n", err)
-			// Don't exit, continue to generate Host report if available
-			if hostResult == nil {
-				os.Exit(1)
-			}
-		} else {
-			fmt.Printf("\n📊 MySQL 巡检完成！\n")
-			printMySQLSummary(mysqlResult)
-		}
+	// Alerts firing in Alertmanager, listed in the combined reports
+	var alertmanagerAlerts []*model.AlertmanagerAlert
+	if cfg.Datasources.Alertmanager.Endpoint != "" {
+		ci.startGroup("Alertmanager 告警")
+		alertmanagerAlerts = collectAlertmanagerAlerts(context.Background(), cfg, logger)
 	}
 
-	// Execute Redis inspection
-	if runRedisInspection {
-		fmt.Println("\n⏳ 开始 Redis 巡检...")
-		redisResult, err = redisInspector.Inspect(ctx)
-		if err != nil {
-			logger.Error().Err(err).Msg("Redis inspection failed")
-			fmt.Fprintf(os.Stderr, "❌ Redis 巡检执行失败: %v\n", err)
-			// Don't exit, continue to generate Host/MySQL report if available
-			if hostResult == nil && mysqlResult == nil && nginxResult == nil {
-				os.Exit(1)
-			}
+	ci.endGroup()
+	fmt.Printf("\n⏱️  总耗时 %.1fs\n", time.Since(startTime).Seconds())
+
+	// Step 9: Generate reports
+	// Use timezone for report generation: the inspectors', else the report timezone when the
+	// modules were inspected by the workers or resumed from the checkpoint
+	timezone := inspectors.timezone()
+	if timezone == nil {
+		if tz, err := time.LoadLocation(cfg.Report.Timezone); err == nil {
+			timezone = tz
 		} else {
-			fmt.Printf("\n📊 Redis 巡检完成！\n")
-			printRedisSummary(redisResult)
+			timezone, _ = time.LoadLocation("Asia/Shanghai")
 		}
 	}
 
-	// Execute Nginx inspection
-	if runNginxInspection {
-		fmt.Println("\n⏳ 开始 Nginx 巡检...")
-		nginxResult, err = nginxInspector.Inspect(ctx)
-		if err != nil {
-			logger.Error().Err(err).Msg("Nginx inspection failed")
-			fmt.Fprintf(os.Stderr, "❌ Nginx 巡检执行失败: %v\n", err)
-			// Don't exit, continue to generate Host/MySQL/Redis report if available
-			if hostResult == nil && mysqlResult == nil && redisResult == nil {
-				os.Exit(1)
-			}
-		} else {
-			fmt.Printf("\n📊 Nginx 巡检完成！\n")
-			printNginxSummary(nginxResult)
-		}
+	// Queries behind the raw data sheet values and the provenance sheet, for audits of the reported numbers
+	var rawDataQueries map[string]map[string]string
+	if cfg.Report.RawDataSheet || cfg.Report.ProvenanceSheet {
+		rawDataQueries = defs.rawDataQueries()
 	}
 
-	// Execute Tomcat inspection
-	if runTomcatInspection {
-		fmt.Println("\n⏳ 开始 Tomcat 巡检...")
-		tomcatResult, err = tomcatInspector.Inspect(ctx)
-		if err != nil {
-			logger.Error().Err(err).Msg("Tomcat inspection failed")
-			fmt.Fprintf(os.Stderr, "❌ Tomcat 巡检执行失败: %v\n", err)
-			// Don't exit, continue to generate other reports if available
-			if hostResult == nil && mysqlResult == nil && redisResult == nil && nginxResult == nil {
-				os.Exit(1)
-			}
-		} else {
-			fmt.Printf("\n📊 Tomcat 巡检完成！\n")
-			printTomcatSummary(tomcatResult)
-		}
+	reportPaths := generateReports(cfg, &reportInput{
+		results:            results,
+		startTime:          startTime,
+		timezone:           timezone,
+		metrics:            defs.host,
+		rawDataQueries:     rawDataQueries,
+		alertmanagerAlerts: alertmanagerAlerts,
+	}, outputFormats, outputPath, ci, logger)
+
+	// CI annotations for warning and critical alerts, after the last log group
+	ci.annotateAlerts(json.FlattenAlerts(results))
+
+	// Exit summary: one line per module before the operator opens the reports
+	printRunSummary(os.Stdout, buildRunSummaryRows(results), reportPaths)
+
+	// The checkpoint is kept while a module is left to resume
+	checkpoint.finish()
+
+	// Exit with appropriate code based on inspection results and --fail-on
+	if exitCode := applyFailOn(inspectionExitCode(results), failOn); exitCode > 0 {
+		os.Exit(exitCode)
 	}
+}
 
-	fmt.Printf("\n⏱️  总耗时 %.1fs\n", time.Since(startTime).Seconds())
+// reportInput is what the reports are generated from besides the configuration: the
+// inspection results, the start of the inspection and the definitions behind the raw data
+// and provenance sheets.
+type reportInput struct {
+	results            *json.Report
+	startTime          time.Time
+	timezone           *time.Location
+	metrics            []*model.MetricDefinition    // Host metric definitions, nil without host results
+	rawDataQueries     map[string]map[string]string // Module -> metric -> query, nil when no sheet needs them
+	alertmanagerAlerts []*model.AlertmanagerAlert
+}
 
-	// Step 9: Generate reports
+// generateReports writes the reports of every output format into outputPath, then bundles
+// and publishes them as configured. A format that fails is reported and skipped. Returns the
+// paths of the generated reports.
+func generateReports(cfg *config.Config, in *reportInput, outputFormats []string, outputPath string, ci *ciReporter, logger zerolog.Logger) []string {
+	results := in.results
+	startTime, timezone, metrics, rawDataQueries, alertmanagerAlerts := in.startTime, in.timezone, in.metrics, in.rawDataQueries, in.alertmanagerAlerts
+
+	ci.startGroup("生成报告")
 	fmt.Println("\n📄 生成报告:")
 	logger.Info().
 		Strs("formats", outputFormats).
 		Str("output_dir", outputPath).
 		Msg("starting report generation")
 
-	// Use timezone for report generation
-	if inspector != nil {
-		timezone = inspector.GetTimezone()
-	} else if mysqlInspector != nil {
-		timezone = mysqlInspector.GetTimezone()
-	} else if redisInspector != nil {
-		timezone = redisInspector.GetTimezone()
-	} else if nginxInspector != nil {
-		timezone = nginxInspector.GetTimezone()
-	} else if tomcatInspector != nil {
-		timezone = tomcatInspector.GetTimezone()
-	}
-
 	// Generate filename base
-	filenameBase := generateFilename(cfg.Report.FilenameTemplate, timezone)
+	filenameBase := generateFilename(cfg.Report.FilenameTemplate, startTime.In(timezone))
+
+	// White-label theme applied to Excel and HTML reports
+	reportTheme := newReportTheme(&cfg.Report.Theme)
+	reportCover := newReportCover(&cfg.Report.Branding, &cfg.Report.Theme)
+	reportWatermark := newReportWatermark(&cfg.Report, reportTheme, startTime.In(timezone))
+	colorScheme := html.WithColorScheme(cfg.Report.HTML.Theme, cfg.Report.HTML.CSSFile)
+	secondaryTimezone := html.WithSecondaryTimezone(loadSecondaryTimezone(&cfg.Report))
+	watermark := html.WithWatermark(reportWatermark)
+
+	// Previous runs compared in the Excel trend sheet, read before this run's JSON report is written;
+	// reports of later runs are left out when regenerating an older run
+	var trendRuns []*model.TrendRun
+	if results.Host != nil && cfg.Report.TrendRuns > 0 {
+		trendRuns = loadTrendRuns(outputPath, cfg.Report.TrendRuns, startTime, logger)
+	}
 
 	// Generate reports for each format
+	var reportPaths []string
+	var htmlReportPath string // Single-file HTML report, embedded in the published page
 	for _, format := range outputFormats {
-		ext := "." + format
-		if format == "excel" {
-			ext = ".xlsx"
-		}
-		reportPath := filepath.Join(outputPath, filenameBase+ext)
+		reportPath := reportOutputPath(format, outputPath, filenameBase, cfg.Report.HTMLSplit)
 
 		var genErr error
 		switch format {
 		case "excel":
-			genErr = generateCombinedExcel(hostResult, mysqlResult, redisResult, nginxResult, tomcatResult, reportPath, timezone, logger)
+			if cfg.Report.Excel.Template != "" {
+				genErr = generateTemplateExcel(results, cfg.Report.Excel.Template, reportPath, startTime, timezone, reportTheme, append(newExcelLayoutOptions(&cfg.Report, &cfg.Thresholds, false, trendRuns, alertmanagerAlerts), newExcelProtectionOptions(&cfg.Report, reportWatermark)...))
+				break
+			}
+			genErr = generateCombinedExcel(results, reportPath, timezone, reportTheme, append(newExcelLayoutOptions(&cfg.Report, &cfg.Thresholds, false, trendRuns, alertmanagerAlerts), newExcelProtectionOptions(&cfg.Report, reportWatermark)...), logger)
+			if genErr == nil && cfg.Report.RawDataSheet {
+				genErr = appendRawDataSheet(results, metrics, rawDataQueries, reportPath, timezone, reportTheme, cfg.Report.Language, newExcelProtectionOptions(&cfg.Report, reportWatermark), logger)
+			}
+			if genErr == nil && cfg.Report.ProvenanceSheet {
+				provenance := newProvenance(cfg, GetConfigFile(), startTime, time.Now(), metrics, rawDataQueries)
+				genErr = appendProvenanceSheet(provenance, reportPath, timezone, reportTheme, cfg.Report.Language, newExcelProtectionOptions(&cfg.Report, reportWatermark), logger)
+			}
 		case "html":
-			genErr = generateCombinedHTML(hostResult, mysqlResult, redisResult, nginxResult, tomcatResult, reportPath, timezone, cfg.Report.HTMLTemplate, logger)
+			if cfg.Report.HTMLSplit {
+				genErr = generateSplitHTML(results, filepath.Dir(reportPath), timezone, reportTheme, reportCover, colorScheme, secondaryTimezone, watermark, cfg.Report.ChartLibrary, cfg.Report.Language, logger)
+				break
+			}
+			genErr = generateCombinedHTML(results, reportPath, timezone, reportTheme, reportCover, colorScheme, secondaryTimezone, watermark, alertmanagerAlerts, cfg.Report.ChartLibrary, cfg.Report.HTMLTemplate, cfg.Report.Language, logger)
+		case "html-email":
+			genErr = generateEmailHTML(results, reportPath, timezone, reportTheme, secondaryTimezone, cfg.Report.Language, logger)
+		case "csv":
+			genErr = generateCSV(results, metrics, rawDataQueries, cfg.Report.RawDataSheet, reportPath, timezone, reportTheme, cfg.Report.Language, newExcelLayoutOptions(&cfg.Report, &cfg.Thresholds, true, trendRuns, alertmanagerAlerts), logger)
+		case "json":
+			genErr = generateJSON(results, reportPath, timezone, logger)
 		default:
 			logger.Error().Str("format", format).Msg("unsupported format")
 			fmt.Fprintf(os.Stderr, "   ❌ 不支持的格式: %s\n", format)
@@ -563,53 +693,112 @@ func runInspection(cmd *cobra.Command, args []string) {
 		if genErr != nil {
 			logger.Error().Err(genErr).Str("format", format).Str("path", reportPath).Msg("failed to generate report")
 			fmt.Fprintf(os.Stderr, "   ❌ %s 报告生成失败: %v\n", format, genErr)
+			ci.error(format+" 报告生成失败", genErr)
 			continue
 		}
 
 		logger.Info().Str("format", format).Str("path", reportPath).Msg("report generated successfully")
 		fmt.Printf("   ✅ %s\n", reportPath)
+		reportPaths = append(reportPaths, reportPath)
+		if format == "html" && !cfg.Report.HTMLSplit {
+			htmlReportPath = reportPath
+		}
 	}
 
-	// Exit with appropriate code based on inspection results
-	exitCode := 0
-	if hostResult != nil {
-		if hostResult.Summary.CriticalHosts > 0 {
-			exitCode = 2
-		} else if hostResult.Summary.WarningHosts > 0 && exitCode < 1 {
-			exitCode = 1
+	// Bundle mode: package the generated reports into one timestamped archive
+	if cfg.Report.Bundle == bundle.ModeZip && len(reportPaths) > 0 {
+		bundlePath := filepath.Join(outputPath, bundle.Filename(filenameBase, time.Now().In(timezone)))
+		if err := bundle.Zip(bundlePath, outputPath, reportPaths); err != nil {
+			logger.Error().Err(err).Str("path", bundlePath).Msg("failed to bundle reports")
+			fmt.Fprintf(os.Stderr, "   ❌ 报告打包失败: %v\n", err)
+			ci.error("报告打包失败", err)
+		} else {
+			logger.Info().Str("path", bundlePath).Int("reports", len(reportPaths)).Msg("reports bundled")
+			fmt.Printf("   📦 %s\n", bundlePath)
+			reportPaths = append(reportPaths, bundlePath)
 		}
 	}
-	if mysqlResult != nil && mysqlResult.Summary != nil {
-		if mysqlResult.Summary.CriticalInstances > 0 {
-			exitCode = 2
-		} else if mysqlResult.Summary.WarningInstances > 0 && exitCode < 1 {
-			exitCode = 1
+
+	// Publish the run as a Confluence page with the reports attached
+	if cfg.Report.Publish.Confluence.Enabled && len(reportPaths) > 0 {
+		title := confluencePageTitle(&cfg.Report.Publish.Confluence, reportTheme, startTime.In(timezone))
+		if pageURL, err := publishConfluence(&cfg.Report.Publish.Confluence, title, htmlReportPath, reportPaths, logger); err != nil {
+			logger.Error().Err(err).Str("title", title).Msg("failed to publish reports to confluence")
+			fmt.Fprintf(os.Stderr, "   ❌ Confluence 发布失败: %v\n", err)
+			ci.error("Confluence 发布失败", err)
+		} else {
+			logger.Info().Str("url", pageURL).Msg("reports published to confluence")
+			fmt.Printf("   📤 %s\n", pageURL)
 		}
 	}
-	if redisResult != nil && redisResult.Summary != nil {
-		if redisResult.Summary.CriticalInstances > 0 {
-			exitCode = 2
-		} else if redisResult.Summary.WarningInstances > 0 && exitCode < 1 {
-			exitCode = 1
+
+	return reportPaths
+}
+
+// reportOutputPath returns the path of the report of the given format: a file for most
+// formats, the directory of the CSV files, or the index page of a split HTML report.
+func reportOutputPath(format, outputPath, filenameBase string, htmlSplit bool) string {
+	switch format {
+	case "excel":
+		return filepath.Join(outputPath, filenameBase+".xlsx")
+	case "html":
+		if htmlSplit {
+			return filepath.Join(outputPath, filenameBase, "index.html")
 		}
-	}
-	if nginxResult != nil && nginxResult.Summary != nil {
-		if nginxResult.Summary.CriticalInstances > 0 {
-			exitCode = 2
-		} else if nginxResult.Summary.WarningInstances > 0 && exitCode < 1 {
-			exitCode = 1
+	case "html-email":
+		// Kept apart from the full HTML report when both are generated
+		return filepath.Join(outputPath, filenameBase+".email.html")
+	case "csv":
+		// CSV output is a directory with one file per Excel sheet
+		return filepath.Join(outputPath, filenameBase+"_csv")
+	}
+	return filepath.Join(outputPath, filenameBase+"."+format)
+}
+
+// inspectionExitCode returns the exit code of the inspection results: 2 when any module has
+// critical objects, 1 when any has warning objects, 0 otherwise.
+func inspectionExitCode(results *json.Report) int {
+	exitCode := 0
+	for _, row := range buildRunSummaryRows(results) {
+		if row.critical > 0 {
+			return 2
 		}
-	}
-	if tomcatResult != nil && tomcatResult.Summary != nil {
-		if tomcatResult.Summary.CriticalInstances > 0 {
-			exitCode = 2
-		} else if tomcatResult.Summary.WarningInstances > 0 && exitCode < 1 {
+		if row.warning > 0 {
 			exitCode = 1
 		}
 	}
-	if exitCode > 0 {
-		os.Exit(exitCode)
+	return exitCode
+}
+
+// Alert severities accepted by --fail-on.
+const (
+	failOnWarning  = "warning"
+	failOnCritical = "critical"
+	failOnNever    = "never"
+)
+
+// validateFailOn returns an error for an unknown --fail-on value.
+func validateFailOn(policy string) error {
+	switch policy {
+	case failOnWarning, failOnCritical, failOnNever:
+		return nil
 	}
+	return fmt.Errorf("不支持的 --fail-on 取值: %s（可选 warning、critical、never）", policy)
+}
+
+// applyFailOn filters the exit code of the inspection results by the --fail-on policy:
+// critical keeps exit code 2 only, never always returns 0. Errors aborting the inspection
+// exit with 1 before and are not affected.
+func applyFailOn(exitCode int, policy string) int {
+	switch policy {
+	case failOnNever:
+		return 0
+	case failOnCritical:
+		if exitCode < 2 {
+			return 0
+		}
+	}
+	return exitCode
 }
 
 // setupLogger creates a zerolog logger with the specified level and format.
@@ -637,11 +826,11 @@ func setupLogger(level string, format string) zerolog.Logger {
 	var output io.Writer
 	if format == "json" {
 		// JSON format - structured logging for log aggregation systems
-		output = os.Stderr
+		output = consoleLog
 	} else {
 		// Console format - human-readable output for development
 		output = zerolog.ConsoleWriter{
-			Out:        os.Stderr,
+			Out:        consoleLog, // os.Stderr, written around the progress bars
 			TimeFormat: "15:04:05",
 			NoColor:    false,
 		}
@@ -672,18 +861,49 @@ func printSummary(result *model.InspectionResult) {
 		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
 		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
 	}
+	if len(result.SourceConflicts) > 0 {
+		fmt.Printf("   来源冲突: %d 个主机指标（见\"来源冲突\" sheet）\n", len(result.SourceConflicts))
+	}
+	if len(result.ActiveAlerts) > 0 {
+		fmt.Printf("   当前告警: %d 条 N9E 告警正在触发（见\"当前告警\" sheet）\n", len(result.ActiveAlerts))
+	}
+}
+
+// validateFormatFlag returns an error naming the first unknown --format value. The formats of
+// the config file are checked when it is loaded.
+func validateFormatFlag() error {
+	names := make([]string, 0, len(reportFormats))
+	for _, f := range reportFormats {
+		names = append(names, f.name)
+	}
+	for _, format := range formatFlagValues() {
+		if !slices.Contains(names, format) {
+			return fmt.Errorf("不支持的输出格式: %q（可选 %s）", format, strings.Join(names, "、"))
+		}
+	}
+	return nil
+}
+
+// formatFlagValues returns the --format values in lower case, without the repeated formats
+// of -f html -f HTML.
+func formatFlagValues() []string {
+	return splitList(strings.ToLower(strings.Join(formats, ",")))
 }
 
 // resolveFormats determines the output formats to use.
-// Command line flags take precedence over config file.
+// Command line flags take precedence over config file; bundle mode adds the bundled formats.
 func resolveFormats(cfg *config.Config) []string {
+	resolved := []string{"excel", "html"} // default
 	if len(formats) > 0 {
-		return formats
+		resolved = formatFlagValues()
+	} else if len(cfg.Report.Formats) > 0 {
+		resolved = cfg.Report.Formats
 	}
-	if len(cfg.Report.Formats) > 0 {
-		return cfg.Report.Formats
+	// Bundle mode always generates the formats packaged in the archive
+	if cfg.Report.Bundle == bundle.ModeZip {
+		resolved = bundle.WithFormats(resolved)
 	}
-	return []string{"excel", "html"} // default
+	return resolved
 }
 
 // resolveOutputDir determines the output directory to use.
@@ -698,16 +918,65 @@ func resolveOutputDir(cfg *config.Config) string {
 	return "./reports" // default
 }
 
+// newReportTheme converts the theme configuration into a report theme.
+// Returns nil when no theme field is configured, keeping the built-in look.
+func newReportTheme(cfg *config.ThemeConfig) *theme.Theme {
+	if *cfg == (config.ThemeConfig{}) {
+		return nil
+	}
+	return &theme.Theme{
+		Title:          cfg.Title,
+		FooterText:     cfg.FooterText,
+		LogoFile:       cfg.LogoFile,
+		PrimaryColor:   cfg.PrimaryColor,
+		SecondaryColor: cfg.SecondaryColor,
+	}
+}
+
+// newReportCover converts the branding configuration into a report cover, using the theme
+// logo when no cover logo is set. Returns nil when no branding field is configured.
+func newReportCover(cfg *config.BrandingConfig, themeCfg *config.ThemeConfig) *theme.Cover {
+	if *cfg == (config.BrandingConfig{}) {
+		return nil
+	}
+	logoFile := cfg.LogoFile
+	if logoFile == "" {
+		logoFile = themeCfg.LogoFile
+	}
+	return &theme.Cover{
+		CustomerName: cfg.CustomerName,
+		Project:      cfg.Project,
+		Engineer:     cfg.Engineer,
+		Period:       cfg.Period,
+		LogoFile:     logoFile,
+	}
+}
+
+// newReportWatermark returns the watermark text of the reports: the configured text, the
+// customer name or the report title, followed by the report date. Returns "" when the
+// watermark is disabled.
+func newReportWatermark(cfg *config.ReportConfig, reportTheme *theme.Theme, date time.Time) string {
+	if !cfg.Watermark.Enabled {
+		return ""
+	}
+	text := strings.TrimSpace(cfg.Watermark.Text)
+	if text == "" {
+		text = strings.TrimSpace(cfg.Branding.CustomerName)
+	}
+	if text == "" {
+		text = reportTheme.GetTitle()
+	}
+	return text + " " + date.Format("2006-01-02")
+}
+
 // generateFilename creates a filename from the template.
-// Supports {{.Date}} placeholder for current date.
-func generateFilename(template string, tz *time.Location) string {
+// Supports {{.Date}} placeholder for the inspection date, in the report timezone.
+func generateFilename(template string, date time.Time) string {
 	if template == "" {
 		template = "inspection_report_{{.Date}}"
 	}
 
-	// Get current date in the configured timezone
-	now := time.Now().In(tz)
-	dateStr := now.Format("2006-01-02")
+	dateStr := date.Format("2006-01-02")
 
 	// Replace placeholders
 	filename := strings.ReplaceAll(template, "{{.Date}}", dateStr)
@@ -788,138 +1057,830 @@ func printTomcatSummary(result *model.TomcatInspectionResults) {
 	}
 }
 
-// generateCombinedExcel creates Excel report with Host, MySQL, Redis, Nginx and Tomcat data in same file.
-func generateCombinedExcel(hostResult *model.InspectionResult, mysqlResult *model.MySQLInspectionResults, redisResult *model.RedisInspectionResults, nginxResult *model.NginxInspectionResults, tomcatResult *model.TomcatInspectionResults, outputPath string, timezone *time.Location, logger zerolog.Logger) error {
-	w := excel.NewWriter(timezone)
+// printCassandraSummary prints the Cassandra inspection result summary.
+func printCassandraSummary(result *model.CassandraInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   Cassandra 节点总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常节点: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告节点: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重节点: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   DN 节点: %d\n", result.Summary.DownNodes)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   Cassandra 告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// printMonitoringSummary prints the monitoring stack inspection result summary.
+func printMonitoringSummary(result *model.MonitoringInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   监控组件总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常组件: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告组件: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重组件: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   DOWN 组件: %d\n", result.Summary.DownInstances)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   监控系统告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// printStorageSummary prints the shared storage inspection result summary.
+func printStorageSummary(result *model.StorageInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   共享存储主机总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常主机: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告主机: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重主机: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   失效挂载点: %d\n", result.Summary.StaleMounts)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   共享存储告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// printLogCheckSummary prints the log checks inspection result summary.
+func printLogCheckSummary(result *model.LogCheckInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   日志检查项总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   查询失败: %d\n", result.Summary.FailedInstances)
+		fmt.Printf("   匹配日志条数: %d\n", result.Summary.MatchedEntries)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   日志告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// printLVSSummary prints the LVS inspection result summary.
+func printLVSSummary(result *model.LVSInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   LVS 调度器总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常调度器: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告调度器: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重调度器: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   无可用真实服务器的虚拟服务: %d\n", result.Summary.ZeroActiveServices)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   LVS 告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// printWindowsSummary prints the Windows service / IIS inspection result summary.
+func printWindowsSummary(result *model.WindowsInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   Windows 主机总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常主机: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告主机: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重主机: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   未运行服务: %d\n", result.Summary.StoppedServices)
+		fmt.Printf("   未运行应用程序池: %d\n", result.Summary.StoppedAppPools)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   Windows 告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// printADSummary prints the AD / LDAP inspection result summary.
+func printADSummary(result *model.ADInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   域控制器总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   复制异常: %d\n", result.Summary.ReplicationFailing)
+		fmt.Printf("   LDAP 不可达: %d\n", result.Summary.LDAPUnreachable)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   AD 告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// printCloudSummary prints the cloud resource inspection result summary.
+func printCloudSummary(result *model.CloudInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   云资源总数: %d (RDS %d / Redis %d / SLB %d)\n", result.Summary.TotalInstances,
+			result.Summary.RDSInstances, result.Summary.RedisInstances, result.Summary.SLBInstances)
+		fmt.Printf("   正常: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重: %d\n", result.Summary.CriticalInstances)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   云资源告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+	if result.CostSummary != nil {
+		fmt.Println()
+		fmt.Printf("   低利用率资源: %d (CPU 峰值 < %.0f%%)\n",
+			len(result.CostSummary.Underutilized), result.CostSummary.IdleCPUThreshold)
+	}
+}
+
+// printFirewallSummary prints the firewall inspection result summary.
+func printFirewallSummary(result *model.FirewallInspectionResults) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Summary != nil {
+		fmt.Printf("   防火墙总数: %d\n", result.Summary.TotalInstances)
+		fmt.Printf("   正常: %d\n", result.Summary.NormalInstances)
+		fmt.Printf("   警告: %d\n", result.Summary.WarningInstances)
+		fmt.Printf("   严重: %d\n", result.Summary.CriticalInstances)
+		fmt.Printf("   断开 VPN 隧道: %d\n", result.Summary.TunnelsDown)
+		fmt.Printf("   HA 异常设备: %d\n", result.Summary.HAAbnormal)
+	}
+	fmt.Println()
+	if result.AlertSummary != nil {
+		fmt.Printf("   防火墙告警总数: %d\n", result.AlertSummary.TotalAlerts)
+		fmt.Printf("   警告级别: %d\n", result.AlertSummary.WarningCount)
+		fmt.Printf("   严重级别: %d\n", result.AlertSummary.CriticalCount)
+	}
+}
+
+// newExcelLayoutOptions returns the Excel writer options of the configured sheet layout, column
+// selection, host grouping, report language, usage thresholds, the previous runs of the trend sheet and the
+// Alertmanager alerts of the combined workbook.
+// CSV exports get neither alert badges (sheet names become file names), alert grouping
+// (subtotal rows would break the one-record-per-row files), the cover sheet nor conditional
+// formatting (usage columns keep their formatted text).
+func newExcelLayoutOptions(cfg *config.ReportConfig, thresholds *config.ThresholdsConfig, csvExport bool, trendRuns []*model.TrendRun, alertmanagerAlerts []*model.AlertmanagerAlert) []excel.Option {
+	opts := []excel.Option{
+		excel.WithSheetOrder(cfg.SheetOrder),
+		excel.WithHideEmptySheets(cfg.HideEmptySheets),
+		excel.WithSheetAlertBadges(cfg.SheetAlertBadges && !csvExport),
+		excel.WithAlertGrouping(cfg.GroupAlerts && !csvExport),
+		excel.WithLanguage(cfg.Language),
+		excel.WithColumns(excel.ModuleHost, cfg.Excel.Columns.Host),
+		excel.WithColumns(excel.ModuleMySQL, cfg.Excel.Columns.MySQL),
+		excel.WithColumns(excel.ModuleRedis, cfg.Excel.Columns.Redis),
+		excel.WithTrend(trendRuns),
+		excel.WithAlertmanagerAlerts(alertmanagerAlerts),
+		excel.WithCapacityRanking(cfg.CapacityTopN),
+		excel.WithHostGrouping(cfg.GroupBy),
+		excel.WithSecondaryTimezone(loadSecondaryTimezone(cfg)),
+		excel.WithRemediation(cfg.Remediation),
+	}
+	if !csvExport {
+		opts = append(opts,
+			excel.WithCover(newReportCover(&cfg.Branding, &cfg.Theme)),
+			excel.WithUsageThresholds(newExcelUsageThresholds(thresholds)),
+		)
+	}
+	return opts
+}
+
+// loadSecondaryTimezone returns the secondary timezone of the report inspection times and
+// its label, or a nil location when none is configured.
+func loadSecondaryTimezone(cfg *config.ReportConfig) (*time.Location, string) {
+	if cfg.SecondaryTimezone == "" {
+		return nil, ""
+	}
+	loc, err := time.LoadLocation(cfg.SecondaryTimezone)
+	if err != nil {
+		return nil, ""
+	}
+	return loc, cfg.SecondaryTimezoneLabel
+}
+
+// confluencePageTitle returns the Confluence page title of a run: the configured title, or the
+// report title, followed by the run time so that each run gets its own page.
+func confluencePageTitle(cfg *config.ConfluencePublishConfig, reportTheme *theme.Theme, runTime time.Time) string {
+	title := cfg.Title
+	if title == "" {
+		title = reportTheme.GetTitle()
+	}
+	return title + " " + runTime.Format("2006-01-02 15:04:05")
+}
+
+// publishConfluence creates the Confluence page of a run, with the single-file HTML report
+// embedded (if any) and the report files attached, and returns the page URL.
+func publishConfluence(cfg *config.ConfluencePublishConfig, title, htmlReportPath string, reportPaths []string, logger zerolog.Logger) (string, error) {
+	tlsConfig, err := cfg.TLS.ClientConfig()
+	if err != nil {
+		return "", err
+	}
+	publisher := publish.NewConfluence(publish.ConfluenceOptions{
+		URL:       cfg.URL,
+		SpaceKey:  cfg.SpaceKey,
+		ParentID:  cfg.ParentID,
+		Username:  cfg.Username,
+		Token:     cfg.Token,
+		EmbedHTML: cfg.EmbedHTML,
+		Timeout:   cfg.Timeout,
+		TLS:       tlsConfig,
+	}, logger)
+	return publisher.Publish(context.Background(), &publish.Page{
+		Title:       title,
+		HTMLReport:  htmlReportPath,
+		Attachments: reportPaths,
+	})
+}
+
+// newExcelUsageThresholds converts the CPU, memory and disk usage thresholds into the Excel
+// conditional formatting thresholds. Disabled thresholds are left out.
+func newExcelUsageThresholds(cfg *config.ThresholdsConfig) map[string]excel.UsageThreshold {
+	thresholds := make(map[string]excel.UsageThreshold)
+	for key, pair := range map[string]*config.ThresholdPair{
+		excel.UsageCPU:    &cfg.CPUUsage,
+		excel.UsageMemory: &cfg.MemoryUsage,
+		excel.UsageDisk:   &cfg.DiskUsage,
+	} {
+		if pair.IsDisabled() {
+			continue
+		}
+		thresholds[key] = excel.UsageThreshold{Warning: pair.Warning, Critical: pair.Critical, Below: pair.IsBelow()}
+	}
+	return thresholds
+}
+
+// newExcelProtectionOptions returns the Excel writer options of the configured sheet protection,
+// workbook password and watermark. They apply to the Excel report only: CSV exports read their
+// temporary workbook back and need it unprotected.
+func newExcelProtectionOptions(cfg *config.ReportConfig, watermark string) []excel.Option {
+	protection := cfg.Excel.Protection
+	return []excel.Option{
+		excel.WithSheetProtection(protection.ProtectSheets, protection.SheetPassword),
+		excel.WithPassword(protection.Password),
+		excel.WithWatermark(watermark),
+	}
+}
+
+// generateCombinedExcel creates Excel report with Host, MySQL, Redis, Nginx, Tomcat, Cassandra, monitoring stack, shared storage, log checks, LVS, Windows, AD, cloud resource and firewall data in same file.
+// layoutOpts control the sheet order, hidden empty module sheets, sheet name badges and report language.
+func generateCombinedExcel(report *json.Report, outputPath string, timezone *time.Location, reportTheme *theme.Theme, layoutOpts []excel.Option, logger zerolog.Logger) error {
+	w := excel.NewWriter(timezone, append([]excel.Option{excel.WithTheme(reportTheme)}, layoutOpts...)...)
+
+	if err := writeExcelSheets(w, report, outputPath, logger); err != nil {
+		return err
+	}
+
+	// Add the table of contents once all module sheets are in place
+	if err := w.Finalize(report, outputPath); err != nil {
+		return fmt.Errorf("failed to finalize Excel report: %w", err)
+	}
+
+	return nil
+}
+
+// generateTemplateExcel fills the customer-provided Excel template at templatePath with the
+// inspection results instead of generating the report layout (see excel.Writer.WriteTemplate).
+func generateTemplateExcel(report *json.Report, templatePath, outputPath string, inspectionTime time.Time, timezone *time.Location, reportTheme *theme.Theme, layoutOpts []excel.Option) error {
+	withAlerts := *report
+	withAlerts.Alerts = json.FlattenAlerts(report)
+
+	w := excel.NewWriter(timezone, append([]excel.Option{excel.WithTheme(reportTheme)}, layoutOpts...)...)
+	data := &excel.TemplateData{
+		InspectionTime: inspectionTime,
+		Host:           report.Host,
+		Alerts:         withAlerts.Alerts,
+		Modules:        newRollupSite("", "", &withAlerts).Modules,
+	}
+	if err := w.WriteTemplate(templatePath, data, outputPath); err != nil {
+		return fmt.Errorf("failed to fill Excel template: %w", err)
+	}
+	return nil
+}
+
+// writeExcelSheets writes the module sheets of the combined Excel report, creating the
+// workbook with the first available module and appending the others to it.
+func writeExcelSheets(w *excel.Writer, report *json.Report, outputPath string, logger zerolog.Logger) error {
 
 	// Only Nginx mode
-	if hostResult == nil && mysqlResult == nil && redisResult == nil && tomcatResult == nil && nginxResult != nil {
-		return w.WriteNginxInspection(nginxResult, outputPath)
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Tomcat == nil && report.Nginx != nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteNginxInspection(report.Nginx, outputPath)
 	}
 
 	// Only Tomcat mode
-	if hostResult == nil && mysqlResult == nil && redisResult == nil && tomcatResult != nil && nginxResult == nil {
-		return w.WriteTomcatInspection(tomcatResult, outputPath)
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Tomcat != nil && report.Nginx == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteTomcatInspection(report.Tomcat, outputPath)
 	}
 
 	// Only Redis mode
-	if hostResult == nil && mysqlResult == nil && redisResult != nil && nginxResult == nil && tomcatResult == nil {
-		return w.WriteRedisInspection(redisResult, outputPath)
+	if report.Host == nil && report.MySQL == nil && report.Redis != nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteRedisInspection(report.Redis, outputPath)
 	}
 
 	// Only MySQL mode
-	if hostResult == nil && mysqlResult != nil && redisResult == nil && nginxResult == nil && tomcatResult == nil {
-		return w.WriteMySQLInspection(mysqlResult, outputPath)
+	if report.Host == nil && report.MySQL != nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteMySQLInspection(report.MySQL, outputPath)
 	}
 
 	// Only Host mode
-	if hostResult != nil && mysqlResult == nil && redisResult == nil && nginxResult == nil && tomcatResult == nil {
-		return w.Write(hostResult, outputPath)
+	if report.Host != nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.Write(report.Host, outputPath)
+	}
+
+	// Only Cassandra mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra != nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteCassandraInspection(report.Cassandra, outputPath)
+	}
+
+	// Only monitoring stack mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring != nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteMonitoringInspection(report.Monitoring, outputPath)
+	}
+
+	// Only shared storage mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage != nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteStorageInspection(report.Storage, outputPath)
+	}
+
+	// Only log checks mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks != nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteLogCheckInspection(report.LogChecks, outputPath)
+	}
+
+	// Only LVS mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS != nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteLVSInspection(report.LVS, outputPath)
+	}
+
+	// Only Windows mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows != nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteWindowsInspection(report.Windows, outputPath)
+	}
+
+	// Only AD mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD != nil && report.Cloud == nil && report.Firewall == nil {
+		return w.WriteADInspection(report.AD, outputPath)
+	}
+
+	// Only cloud mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud != nil && report.Firewall == nil {
+		return w.WriteCloudInspection(report.Cloud, outputPath)
+	}
+
+	// Only firewall mode
+	if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall != nil {
+		return w.WriteFirewallInspection(report.Firewall, outputPath)
 	}
 
 	// Combined mode: write Host first, then append MySQL and/or Redis
-	if hostResult != nil {
-		if err := w.Write(hostResult, outputPath); err != nil {
+	if report.Host != nil {
+		if err := w.Write(report.Host, outputPath); err != nil {
 			return fmt.Errorf("failed to write host report: %w", err)
 		}
 	}
-	if mysqlResult != nil {
-		if hostResult != nil {
-			if err := w.AppendMySQLInspection(mysqlResult, outputPath); err != nil {
+	if report.MySQL != nil {
+		if report.Host != nil {
+			if err := w.AppendMySQLInspection(report.MySQL, outputPath); err != nil {
 				return fmt.Errorf("failed to append MySQL report: %w", err)
 			}
 		} else {
-			if err := w.WriteMySQLInspection(mysqlResult, outputPath); err != nil {
+			if err := w.WriteMySQLInspection(report.MySQL, outputPath); err != nil {
 				return fmt.Errorf("failed to write MySQL report: %w", err)
 			}
 		}
 	}
-	if redisResult != nil {
-		if hostResult != nil || mysqlResult != nil {
-			if err := w.AppendRedisInspection(redisResult, outputPath); err != nil {
+	if report.Redis != nil {
+		if report.Host != nil || report.MySQL != nil {
+			if err := w.AppendRedisInspection(report.Redis, outputPath); err != nil {
 				return fmt.Errorf("failed to append Redis report: %w", err)
 			}
 		} else {
-			if err := w.WriteRedisInspection(redisResult, outputPath); err != nil {
+			if err := w.WriteRedisInspection(report.Redis, outputPath); err != nil {
 				return fmt.Errorf("failed to write Redis report: %w", err)
 			}
 		}
 	}
-	if nginxResult != nil {
-		if hostResult != nil || mysqlResult != nil || redisResult != nil {
-			if err := w.AppendNginxInspection(nginxResult, outputPath); err != nil {
+	if report.Nginx != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil {
+			if err := w.AppendNginxInspection(report.Nginx, outputPath); err != nil {
 				return fmt.Errorf("failed to append Nginx report: %w", err)
 			}
 		} else {
-			if err := w.WriteNginxInspection(nginxResult, outputPath); err != nil {
+			if err := w.WriteNginxInspection(report.Nginx, outputPath); err != nil {
 				return fmt.Errorf("failed to write Nginx report: %w", err)
 			}
 		}
 	}
-	if tomcatResult != nil {
-		if hostResult != nil || mysqlResult != nil || redisResult != nil || nginxResult != nil {
-			if err := w.AppendTomcatInspection(tomcatResult, outputPath); err != nil {
+	if report.Tomcat != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil {
+			if err := w.AppendTomcatInspection(report.Tomcat, outputPath); err != nil {
 				return fmt.Errorf("failed to append Tomcat report: %w", err)
 			}
 		} else {
-			if err := w.WriteTomcatInspection(tomcatResult, outputPath); err != nil {
+			if err := w.WriteTomcatInspection(report.Tomcat, outputPath); err != nil {
 				return fmt.Errorf("failed to write Tomcat report: %w", err)
 			}
 		}
 	}
+	if report.Cassandra != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil {
+			if err := w.AppendCassandraInspection(report.Cassandra, outputPath); err != nil {
+				return fmt.Errorf("failed to append Cassandra report: %w", err)
+			}
+		} else {
+			if err := w.WriteCassandraInspection(report.Cassandra, outputPath); err != nil {
+				return fmt.Errorf("failed to write Cassandra report: %w", err)
+			}
+		}
+	}
+	if report.Monitoring != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil {
+			if err := w.AppendMonitoringInspection(report.Monitoring, outputPath); err != nil {
+				return fmt.Errorf("failed to append monitoring report: %w", err)
+			}
+		} else {
+			if err := w.WriteMonitoringInspection(report.Monitoring, outputPath); err != nil {
+				return fmt.Errorf("failed to write monitoring report: %w", err)
+			}
+		}
+	}
+	if report.Storage != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil || report.Monitoring != nil {
+			if err := w.AppendStorageInspection(report.Storage, outputPath); err != nil {
+				return fmt.Errorf("failed to append storage report: %w", err)
+			}
+		} else {
+			if err := w.WriteStorageInspection(report.Storage, outputPath); err != nil {
+				return fmt.Errorf("failed to write storage report: %w", err)
+			}
+		}
+	}
+	if report.LogChecks != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil || report.Monitoring != nil || report.Storage != nil {
+			if err := w.AppendLogCheckInspection(report.LogChecks, outputPath); err != nil {
+				return fmt.Errorf("failed to append log checks report: %w", err)
+			}
+		} else {
+			if err := w.WriteLogCheckInspection(report.LogChecks, outputPath); err != nil {
+				return fmt.Errorf("failed to write log checks report: %w", err)
+			}
+		}
+	}
+	if report.LVS != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil || report.Monitoring != nil || report.Storage != nil || report.LogChecks != nil {
+			if err := w.AppendLVSInspection(report.LVS, outputPath); err != nil {
+				return fmt.Errorf("failed to append LVS report: %w", err)
+			}
+		} else {
+			if err := w.WriteLVSInspection(report.LVS, outputPath); err != nil {
+				return fmt.Errorf("failed to write LVS report: %w", err)
+			}
+		}
+	}
+	if report.Windows != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil || report.Monitoring != nil || report.Storage != nil || report.LogChecks != nil || report.LVS != nil {
+			if err := w.AppendWindowsInspection(report.Windows, outputPath); err != nil {
+				return fmt.Errorf("failed to append Windows report: %w", err)
+			}
+		} else {
+			if err := w.WriteWindowsInspection(report.Windows, outputPath); err != nil {
+				return fmt.Errorf("failed to write Windows report: %w", err)
+			}
+		}
+	}
+	if report.AD != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil || report.Monitoring != nil || report.Storage != nil || report.LogChecks != nil || report.LVS != nil || report.Windows != nil {
+			if err := w.AppendADInspection(report.AD, outputPath); err != nil {
+				return fmt.Errorf("failed to append AD report: %w", err)
+			}
+		} else {
+			if err := w.WriteADInspection(report.AD, outputPath); err != nil {
+				return fmt.Errorf("failed to write AD report: %w", err)
+			}
+		}
+	}
+	if report.Cloud != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil || report.Monitoring != nil || report.Storage != nil || report.LogChecks != nil || report.LVS != nil || report.Windows != nil || report.AD != nil {
+			if err := w.AppendCloudInspection(report.Cloud, outputPath); err != nil {
+				return fmt.Errorf("failed to append cloud report: %w", err)
+			}
+		} else {
+			if err := w.WriteCloudInspection(report.Cloud, outputPath); err != nil {
+				return fmt.Errorf("failed to write cloud report: %w", err)
+			}
+		}
+	}
+	if report.Firewall != nil {
+		if report.Host != nil || report.MySQL != nil || report.Redis != nil || report.Nginx != nil || report.Tomcat != nil || report.Cassandra != nil || report.Monitoring != nil || report.Storage != nil || report.LogChecks != nil || report.LVS != nil || report.Windows != nil || report.AD != nil || report.Cloud != nil {
+			if err := w.AppendFirewallInspection(report.Firewall, outputPath); err != nil {
+				return fmt.Errorf("failed to append firewall report: %w", err)
+			}
+		} else {
+			if err := w.WriteFirewallInspection(report.Firewall, outputPath); err != nil {
+				return fmt.Errorf("failed to write firewall report: %w", err)
+			}
+		}
+	}
 
 	logger.Debug().
-		Bool("has_host", hostResult != nil).
-		Bool("has_mysql", mysqlResult != nil).
-		Bool("has_redis", redisResult != nil).
-		Bool("has_nginx", nginxResult != nil).
-		Bool("has_tomcat", tomcatResult != nil).
+		Bool("has_host", report.Host != nil).
+		Bool("has_mysql", report.MySQL != nil).
+		Bool("has_redis", report.Redis != nil).
+		Bool("has_nginx", report.Nginx != nil).
+		Bool("has_tomcat", report.Tomcat != nil).
+		Bool("has_cassandra", report.Cassandra != nil).
+		Bool("has_monitoring", report.Monitoring != nil).
+		Bool("has_storage", report.Storage != nil).
+		Bool("has_log_checks", report.LogChecks != nil).
+		Bool("has_lvs", report.LVS != nil).
+		Bool("has_windows", report.Windows != nil).
+		Bool("has_ad", report.AD != nil).
+		Bool("has_cloud", report.Cloud != nil).
+		Bool("has_firewall", report.Firewall != nil).
 		Str("path", outputPath).
 		Msg("combined Excel report generated")
 
 	return nil
 }
 
-// generateCombinedHTML creates HTML report with Host, MySQL, Redis, Nginx and Tomcat data.
-func generateCombinedHTML(hostResult *model.InspectionResult, mysqlResult *model.MySQLInspectionResults, redisResult *model.RedisInspectionResults, nginxResult *model.NginxInspectionResults, tomcatResult *model.TomcatInspectionResults, outputPath string, timezone *time.Location, templatePath string, logger zerolog.Logger) error {
-	w := html.NewWriter(timezone, templatePath)
+// appendRawDataSheet flattens all inspection results into long-format records
+// and appends them as the "原始数据" sheet of an existing Excel report.
+// queries maps a raw data module to the queries of its metrics (see moduleDefinitions.rawDataQueries);
+// host queries come from hostMetrics.
+// protectionOpts open an encrypted report and protect the new sheet like the rest of the report.
+func appendRawDataSheet(report *json.Report, hostMetrics []*model.MetricDefinition, queries map[string]map[string]string, outputPath string, timezone *time.Location, reportTheme *theme.Theme, language string, protectionOpts []excel.Option, logger zerolog.Logger) error {
+	var records []*model.RawDataRecord
+	records = append(records, model.NewHostRawDataRecords(report.Host, hostMetrics)...)
+	records = append(records, model.NewMySQLRawDataRecords(report.MySQL)...)
+	records = append(records, model.NewRedisRawDataRecords(report.Redis)...)
+	records = append(records, model.NewNginxRawDataRecords(report.Nginx)...)
+	records = append(records, model.NewTomcatRawDataRecords(report.Tomcat)...)
+	records = append(records, model.NewCassandraRawDataRecords(report.Cassandra)...)
+	records = append(records, model.NewMonitoringRawDataRecords(report.Monitoring)...)
+	records = append(records, model.NewStorageRawDataRecords(report.Storage)...)
+	records = append(records, model.NewLogCheckRawDataRecords(report.LogChecks)...)
+	records = append(records, model.NewLVSRawDataRecords(report.LVS)...)
+	records = append(records, model.NewWindowsRawDataRecords(report.Windows)...)
+	records = append(records, model.NewADRawDataRecords(report.AD)...)
+	records = append(records, model.NewCloudRawDataRecords(report.Cloud)...)
+	records = append(records, model.NewFirewallRawDataRecords(report.Firewall)...)
+	for module, moduleQueries := range queries {
+		model.SetRawDataQueries(records, module, moduleQueries)
+	}
+
+	opts := append([]excel.Option{excel.WithTheme(reportTheme), excel.WithLanguage(language)}, protectionOpts...)
+	w := excel.NewWriter(timezone, opts...)
+	if err := w.AppendRawDataSheet(records, outputPath); err != nil {
+		return fmt.Errorf("failed to append raw data sheet: %w", err)
+	}
 
-	// Only Redis mode
-	if hostResult == nil && mysqlResult == nil && redisResult != nil && nginxResult == nil && tomcatResult == nil {
-		return w.WriteRedisInspection(redisResult, outputPath)
+	logger.Debug().
+		Int("records", len(records)).
+		Str("path", outputPath).
+		Msg("raw data sheet appended")
+
+	return nil
+}
+
+// metricQueries indexes the queries of metric definitions by metric name.
+func metricQueries[D any](defs []*D, nameQuery func(*D) (string, string)) map[string]string {
+	queries := make(map[string]string, len(defs))
+	for _, def := range defs {
+		if def != nil {
+			name, query := nameQuery(def)
+			queries[name] = query
+		}
 	}
+	return queries
+}
 
-	// Only MySQL mode
-	if hostResult == nil && mysqlResult != nil && redisResult == nil && nginxResult == nil && tomcatResult == nil {
-		return w.WriteMySQLInspection(mysqlResult, outputPath)
+// generateCSV exports the combined Excel report as CSV files (one per sheet) into outputDir.
+// The raw data sheet is exported as well when includeRawData is set; hidden sheets are skipped.
+func generateCSV(report *json.Report, hostMetrics []*model.MetricDefinition, queries map[string]map[string]string, includeRawData bool, outputDir string, timezone *time.Location, reportTheme *theme.Theme, language string, layoutOpts []excel.Option, logger zerolog.Logger) error {
+	w := csv.NewWriter(timezone)
+	err := w.WriteWorkbook(outputDir, func(workbookPath string) error {
+		if err := generateCombinedExcel(report, workbookPath, timezone, reportTheme, layoutOpts, logger); err != nil {
+			return err
+		}
+		if includeRawData {
+			return appendRawDataSheet(report, hostMetrics, queries, workbookPath, timezone, reportTheme, language, nil, logger)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write CSV report: %w", err)
 	}
 
-	// Only Nginx mode
-	if hostResult == nil && mysqlResult == nil && redisResult == nil && nginxResult != nil && tomcatResult == nil {
-		return w.WriteNginxInspection(nginxResult, outputPath)
+	logger.Debug().
+		Bool("raw_data", includeRawData).
+		Str("dir", outputDir).
+		Msg("CSV report generated")
+
+	return nil
+}
+
+// generateJSON generates one JSON report containing every inspection result and a flattened alert list.
+func generateJSON(report *json.Report, outputPath string, timezone *time.Location, logger zerolog.Logger) error {
+	w := json.NewWriter(timezone)
+	if err := w.WriteCombined(report, outputPath); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
 	}
 
-	// Only Tomcat mode
-	if hostResult == nil && mysqlResult == nil && redisResult == nil && nginxResult == nil && tomcatResult != nil {
-		return w.WriteTomcatInspection(tomcatResult, outputPath)
+	logger.Debug().
+		Str("schema_version", json.SchemaVersion).
+		Str("path", outputPath).
+		Msg("JSON report generated")
+
+	return nil
+}
+
+// loadTrendRuns reads the host trend metrics of the n most recent JSON reports in dir generated
+// before the given time, newest first. Unreadable files and reports of other schema versions
+// are skipped.
+func loadTrendRuns(dir string, n int, before time.Time, logger zerolog.Logger) []*model.TrendRun {
+	paths, err := listJSONReports(dir)
+	if err != nil {
+		logger.Warn().Err(err).Str("dir", dir).Msg("failed to list previous JSON reports for trend sheet")
+		return nil
 	}
 
-	// Only Host mode
-	if hostResult != nil && mysqlResult == nil && redisResult == nil && nginxResult == nil && tomcatResult == nil {
-		return w.Write(hostResult, outputPath)
+	var runs []*model.TrendRun
+	for _, path := range paths {
+		if len(runs) == n {
+			break
+		}
+		report, err := json.ReadReport(path)
+		if err != nil {
+			logger.Debug().Err(err).Str("path", path).Msg("skipping file for trend sheet")
+			continue
+		}
+		if !report.GeneratedAt.Before(before) {
+			continue
+		}
+		runs = append(runs, json.NewTrendRun(report))
+	}
+
+	if len(runs) == 0 {
+		logger.Info().Str("dir", dir).Msg("no previous JSON reports found, trend sheet skipped")
+	}
+	return runs
+}
+
+// generateEmailHTML creates the email-friendly HTML report (inline styles, no script),
+// meant to be pasted into an email body.
+func generateEmailHTML(report *json.Report, outputPath string, timezone *time.Location, reportTheme *theme.Theme, secondaryTimezone html.Option, language string, logger zerolog.Logger) error {
+	w := html.NewEmailWriter(timezone, html.WithTheme(reportTheme), secondaryTimezone, html.WithLanguage(language))
+	if err := w.WriteCombined(report, outputPath); err != nil {
+		return fmt.Errorf("failed to write email HTML report: %w", err)
+	}
+
+	logger.Debug().
+		Str("path", outputPath).
+		Msg("email HTML report generated")
+
+	return nil
+}
+
+// generateSplitHTML creates a split HTML report (index.html plus one page per module) in outputDir.
+func generateSplitHTML(report *json.Report, outputDir string, timezone *time.Location, reportTheme *theme.Theme, reportCover *theme.Cover, colorScheme html.Option, secondaryTimezone html.Option, watermark html.Option, chartLibrary string, language string, logger zerolog.Logger) error {
+	w := html.NewWriter(timezone, "", html.WithTheme(reportTheme), html.WithCover(reportCover), colorScheme, secondaryTimezone, watermark, html.WithChartLibrary(chartLibrary), html.WithLanguage(language))
+	if err := w.WriteSplit(report, outputDir); err != nil {
+		return fmt.Errorf("failed to write split HTML report: %w", err)
+	}
+
+	logger.Debug().
+		Bool("has_host", report.Host != nil).
+		Bool("has_mysql", report.MySQL != nil).
+		Bool("has_redis", report.Redis != nil).
+		Bool("has_nginx", report.Nginx != nil).
+		Bool("has_tomcat", report.Tomcat != nil).
+		Bool("has_cassandra", report.Cassandra != nil).
+		Bool("has_monitoring", report.Monitoring != nil).
+		Bool("has_storage", report.Storage != nil).
+		Bool("has_log_checks", report.LogChecks != nil).
+		Bool("has_lvs", report.LVS != nil).
+		Bool("has_windows", report.Windows != nil).
+		Bool("has_ad", report.AD != nil).
+		Bool("has_cloud", report.Cloud != nil).
+		Bool("has_firewall", report.Firewall != nil).
+		Str("dir", outputDir).
+		Msg("split HTML report generated")
+
+	return nil
+}
+
+// generateCombinedHTML creates HTML report with Host, MySQL, Redis, Nginx, Tomcat, Cassandra, monitoring stack, shared storage, log checks, LVS, Windows, AD, cloud resource and firewall data.
+func generateCombinedHTML(report *json.Report, outputPath string, timezone *time.Location, reportTheme *theme.Theme, reportCover *theme.Cover, colorScheme html.Option, secondaryTimezone html.Option, watermark html.Option, alertmanagerAlerts []*model.AlertmanagerAlert, chartLibrary string, templatePath string, language string, logger zerolog.Logger) error {
+	w := html.NewWriter(timezone, templatePath, html.WithTheme(reportTheme), html.WithCover(reportCover), colorScheme, secondaryTimezone, watermark, html.WithAlertmanagerAlerts(alertmanagerAlerts), html.WithChartLibrary(chartLibrary), html.WithLanguage(language))
+
+	// Alertmanager alerts are a section of the combined report, so with firing alerts a single
+	// module is written as a combined report too
+	if len(alertmanagerAlerts) == 0 {
+		// Only Redis mode
+		if report.Host == nil && report.MySQL == nil && report.Redis != nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteRedisInspection(report.Redis, outputPath)
+		}
+
+		// Only MySQL mode
+		if report.Host == nil && report.MySQL != nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteMySQLInspection(report.MySQL, outputPath)
+		}
+
+		// Only Nginx mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx != nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteNginxInspection(report.Nginx, outputPath)
+		}
+
+		// Only Tomcat mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat != nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteTomcatInspection(report.Tomcat, outputPath)
+		}
+
+		// Only Host mode
+		if report.Host != nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.Write(report.Host, outputPath)
+		}
+
+		// Only Cassandra mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra != nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteCassandraInspection(report.Cassandra, outputPath)
+		}
+
+		// Only monitoring stack mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring != nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteMonitoringInspection(report.Monitoring, outputPath)
+		}
+
+		// Only shared storage mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage != nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteStorageInspection(report.Storage, outputPath)
+		}
+
+		// Only log checks mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks != nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteLogCheckInspection(report.LogChecks, outputPath)
+		}
+
+		// Only LVS mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS != nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteLVSInspection(report.LVS, outputPath)
+		}
+
+		// Only Windows mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows != nil && report.AD == nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteWindowsInspection(report.Windows, outputPath)
+		}
+
+		// Only AD mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD != nil && report.Cloud == nil && report.Firewall == nil {
+			return w.WriteADInspection(report.AD, outputPath)
+		}
+
+		// Only cloud mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud != nil && report.Firewall == nil {
+			return w.WriteCloudInspection(report.Cloud, outputPath)
+		}
+
+		// Only firewall mode
+		if report.Host == nil && report.MySQL == nil && report.Redis == nil && report.Nginx == nil && report.Tomcat == nil && report.Cassandra == nil && report.Monitoring == nil && report.Storage == nil && report.LogChecks == nil && report.LVS == nil && report.Windows == nil && report.AD == nil && report.Cloud == nil && report.Firewall != nil {
+			return w.WriteFirewallInspection(report.Firewall, outputPath)
+		}
 	}
 
 	// Combined mode
-	if err := w.WriteCombined(hostResult, mysqlResult, redisResult, nginxResult, tomcatResult, outputPath); err != nil {
+	if err := w.WriteCombined(report, outputPath); err != nil {
 		return fmt.Errorf("failed to write combined HTML report: %w", err)
 	}
 
 	logger.Debug().
-		Bool("has_host", hostResult != nil).
-		Bool("has_mysql", mysqlResult != nil).
-		Bool("has_redis", redisResult != nil).
-		Bool("has_nginx", nginxResult != nil).
-		Bool("has_tomcat", tomcatResult != nil).
+		Bool("has_host", report.Host != nil).
+		Bool("has_mysql", report.MySQL != nil).
+		Bool("has_redis", report.Redis != nil).
+		Bool("has_nginx", report.Nginx != nil).
+		Bool("has_tomcat", report.Tomcat != nil).
+		Bool("has_cassandra", report.Cassandra != nil).
+		Bool("has_monitoring", report.Monitoring != nil).
+		Bool("has_storage", report.Storage != nil).
+		Bool("has_log_checks", report.LogChecks != nil).
+		Bool("has_lvs", report.LVS != nil).
+		Bool("has_windows", report.Windows != nil).
+		Bool("has_ad", report.AD != nil).
+		Bool("has_cloud", report.Cloud != nil).
+		Bool("has_firewall", report.Firewall != nil).
 		Str("path", outputPath).
 		Msg("combined HTML report generated")
 
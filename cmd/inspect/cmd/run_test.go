@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"inspection-tool/internal/config"
+)
+
+func TestValidateFailOn(t *testing.T) {
+	for _, policy := range []string{failOnWarning, failOnCritical, failOnNever} {
+		if err := validateFailOn(policy); err != nil {
+			t.Errorf("validateFailOn(%q) error = %v", policy, err)
+		}
+	}
+	for _, policy := range []string{"", "error", "Warning"} {
+		if err := validateFailOn(policy); err == nil {
+			t.Errorf("validateFailOn(%q) should fail", policy)
+		}
+	}
+}
+
+func TestApplyFailOn(t *testing.T) {
+	tests := []struct {
+		policy   string
+		exitCode int
+		want     int
+	}{
+		{failOnWarning, 0, 0},
+		{failOnWarning, 1, 1},
+		{failOnWarning, 2, 2},
+		{failOnCritical, 0, 0},
+		{failOnCritical, 1, 0},
+		{failOnCritical, 2, 2},
+		{failOnNever, 0, 0},
+		{failOnNever, 1, 0},
+		{failOnNever, 2, 0},
+	}
+	for _, tt := range tests {
+		if got := applyFailOn(tt.exitCode, tt.policy); got != tt.want {
+			t.Errorf("applyFailOn(%d, %q) = %d, want %d", tt.exitCode, tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestValidateFormatFlag(t *testing.T) {
+	defer func(saved []string) { formats = saved }(formats)
+
+	tests := []struct {
+		formats []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"excel", "html-email", "csv", "json"}, false},
+		{[]string{"Excel", "HTML"}, false},
+		{[]string{"excel", "pdf"}, true},
+		{[]string{"xlsx"}, true},
+	}
+	for _, tt := range tests {
+		formats = tt.formats
+		if err := validateFormatFlag(); (err != nil) != tt.wantErr {
+			t.Errorf("validateFormatFlag() with %v error = %v, wantErr %v", tt.formats, err, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveFormats(t *testing.T) {
+	defer func(saved []string) { formats = saved }(formats)
+
+	cfg := &config.Config{}
+	cfg.Report.Formats = []string{"excel"}
+
+	tests := []struct {
+		formats []string
+		want    []string
+	}{
+		{nil, []string{"excel"}}, // From the config file
+		{[]string{"html", "json", "html"}, []string{"html", "json"}}, // Repeated -f html
+		{[]string{"html,json", "HTML"}, []string{"html", "json"}},    // Mixed case
+		{[]string{"CSV", " json "}, []string{"csv", "json"}},
+	}
+	for _, tt := range tests {
+		formats = tt.formats
+		if got := resolveFormats(cfg); !slices.Equal(got, tt.want) {
+			t.Errorf("resolveFormats() with %v = %v, want %v", tt.formats, got, tt.want)
+		}
+	}
+
+	formats = nil
+	if got := resolveFormats(&config.Config{}); !slices.Equal(got, []string{"excel", "html"}) {
+		t.Errorf("resolveFormats() default = %v, want [excel html]", got)
+	}
+}
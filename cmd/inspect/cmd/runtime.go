@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"runtime/debug"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+// applyRuntimeConfig sets the garbage collector target and soft memory limit of a long-running
+// command. Unset values keep the defaults, including GOGC and GOMEMLIMIT.
+func applyRuntimeConfig(cfg *config.RuntimeConfig, logger zerolog.Logger) {
+	if cfg.GCPercent > 0 {
+		debug.SetGCPercent(cfg.GCPercent)
+	}
+	if cfg.MemoryLimitMB > 0 {
+		debug.SetMemoryLimit(cfg.MemoryLimitMB << 20)
+	}
+	if cfg.GCPercent > 0 || cfg.MemoryLimitMB > 0 {
+		logger.Info().Int("gc_percent", cfg.GCPercent).Int64("memory_limit_mb", cfg.MemoryLimitMB).Msg("runtime settings applied")
+	}
+}
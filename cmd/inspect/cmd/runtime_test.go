@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"math"
+	"runtime/debug"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+func TestApplyRuntimeConfig(t *testing.T) {
+	gcPercent := debug.SetGCPercent(100)
+	memoryLimit := debug.SetMemoryLimit(math.MaxInt64)
+	defer func() {
+		debug.SetGCPercent(gcPercent)
+		debug.SetMemoryLimit(memoryLimit)
+	}()
+
+	// Unset values keep the current settings
+	applyRuntimeConfig(&config.RuntimeConfig{}, zerolog.Nop())
+	if got := debug.SetGCPercent(100); got != 100 {
+		t.Errorf("gc percent = %d, want 100 when unset", got)
+	}
+	if got := debug.SetMemoryLimit(-1); got != math.MaxInt64 {
+		t.Errorf("memory limit = %d, want no limit when unset", got)
+	}
+
+	applyRuntimeConfig(&config.RuntimeConfig{GCPercent: 50, MemoryLimitMB: 512}, zerolog.Nop())
+	if got := debug.SetGCPercent(100); got != 50 {
+		t.Errorf("gc percent = %d, want 50", got)
+	}
+	if got := debug.SetMemoryLimit(-1); got != 512<<20 {
+		t.Errorf("memory limit = %d, want %d", got, 512<<20)
+	}
+}
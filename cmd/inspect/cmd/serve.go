@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/config"
+)
+
+// serveListen is the --listen flag of the serve command.
+var serveListen string
+
+// serveCmd represents the serve command.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "通过 HTTP 浏览已生成的报告",
+	Long: `启动只读 HTTP 服务，浏览输出目录中的巡检报告（HTML 报告可直接在浏览器中打开，
+其他格式下载）。服务不执行巡检，巡检仍由定时任务中的 inspect inspect 完成。
+
+默认只监听本机地址；报告包含主机与实例信息，对外提供时请放在带认证的反向代理之后。
+
+示例:
+  inspect serve -c config.yaml
+  inspect serve -o ./reports --listen 0.0.0.0:8080`,
+	Run: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveListen, "listen", "127.0.0.1:8080", "监听地址")
+	serveCmd.Flags().StringVarP(&outputDir, "output", "o", "", "报告目录（默认使用配置文件中的 report.output_dir）")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// runServe executes the serve command logic.
+func runServe(cmd *cobra.Command, args []string) {
+	dir := outputDir
+	logFormat := "console"
+	var runtimeCfg config.RuntimeConfig
+	if dir == "" {
+		cfg, err := config.Load(GetConfigFile())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 加载配置失败: %v\n", err)
+			os.Exit(1)
+		}
+		dir = resolveOutputDir(cfg)
+		logFormat = cfg.Logging.Format
+		runtimeCfg = cfg.Runtime
+	}
+	logger := setupLogger(GetLogLevel(), logFormat)
+	applyRuntimeConfig(&runtimeCfg, logger)
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "❌ 报告目录不存在: %s\n", dir)
+		os.Exit(1)
+	}
+
+	server := &http.Server{
+		Addr:              serveListen,
+		Handler:           http.FileServer(http.Dir(dir)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Printf("🌐 报告服务已启动: http://%s/ (目录 %s)\n", serveListen, dir)
+	logger.Info().Str("listen", serveListen).Str("dir", dir).Msg("report server started")
+	if err := server.ListenAndServe(); err != nil {
+		logger.Error().Err(err).Msg("report server stopped")
+		fmt.Fprintf(os.Stderr, "❌ 报告服务运行失败: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/report/json"
+)
+
+// runSummaryRow is one module row of the exit summary table.
+type runSummaryRow struct {
+	module   string
+	total    int
+	normal   int
+	warning  int
+	critical int
+	failed   int
+	duration time.Duration
+}
+
+// buildRunSummaryRows returns one row per inspected module, in report order.
+// Modules that were skipped or failed to run (nil result) are left out.
+func buildRunSummaryRows(report *json.Report) []runSummaryRow {
+	var rows []runSummaryRow
+	add := func(module string, total, normal, warning, critical, failed int, duration time.Duration) {
+		rows = append(rows, runSummaryRow{module, total, normal, warning, critical, failed, duration})
+	}
+
+	if report.Host != nil && report.Host.Summary != nil {
+		s := report.Host.Summary
+		add("主机", s.TotalHosts, s.NormalHosts, s.WarningHosts, s.CriticalHosts, s.FailedHosts, report.Host.Duration)
+	}
+	if report.MySQL != nil && report.MySQL.Summary != nil {
+		s := report.MySQL.Summary
+		add("MySQL", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.MySQL.Duration)
+	}
+	if report.Redis != nil && report.Redis.Summary != nil {
+		s := report.Redis.Summary
+		add("Redis", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Redis.Duration)
+	}
+	if report.Nginx != nil && report.Nginx.Summary != nil {
+		s := report.Nginx.Summary
+		add("Nginx", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Nginx.Duration)
+	}
+	if report.Tomcat != nil && report.Tomcat.Summary != nil {
+		s := report.Tomcat.Summary
+		add("Tomcat", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Tomcat.Duration)
+	}
+	if report.Cassandra != nil && report.Cassandra.Summary != nil {
+		s := report.Cassandra.Summary
+		add("Cassandra", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Cassandra.Duration)
+	}
+	if report.Monitoring != nil && report.Monitoring.Summary != nil {
+		s := report.Monitoring.Summary
+		add("监控系统", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Monitoring.Duration)
+	}
+	if report.Storage != nil && report.Storage.Summary != nil {
+		s := report.Storage.Summary
+		add("共享存储", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Storage.Duration)
+	}
+	if report.LogChecks != nil && report.LogChecks.Summary != nil {
+		s := report.LogChecks.Summary
+		add("日志巡检", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.LogChecks.Duration)
+	}
+	if report.LVS != nil && report.LVS.Summary != nil {
+		s := report.LVS.Summary
+		add("LVS", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.LVS.Duration)
+	}
+	if report.Windows != nil && report.Windows.Summary != nil {
+		s := report.Windows.Summary
+		add("Windows", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Windows.Duration)
+	}
+	if report.AD != nil && report.AD.Summary != nil {
+		s := report.AD.Summary
+		add("AD", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.AD.Duration)
+	}
+	if report.Cloud != nil && report.Cloud.Summary != nil {
+		s := report.Cloud.Summary
+		add("云资源", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Cloud.Duration)
+	}
+	if report.Firewall != nil && report.Firewall.Summary != nil {
+		s := report.Firewall.Summary
+		add("防火墙", s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, report.Firewall.Duration)
+	}
+
+	return rows
+}
+
+// printRunSummary prints the exit summary: a table of the inspected modules with their
+// instance counts by status and duration, followed by the generated report paths.
+func printRunSummary(out io.Writer, rows []runSummaryRow, reportPaths []string) {
+	headers := []string{"模块", "对象数", "正常", "警告", "严重", "失败", "耗时"}
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		cells = append(cells, []string{
+			r.module,
+			fmt.Sprint(r.total),
+			fmt.Sprint(r.normal),
+			fmt.Sprint(r.warning),
+			fmt.Sprint(r.critical),
+			fmt.Sprint(r.failed),
+			fmt.Sprintf("%.1fs", r.duration.Seconds()),
+		})
+	}
+
+	fmt.Fprintln(out, "\n📋 巡检汇总:")
+	if len(cells) > 0 {
+		writeTable(out, headers, cells)
+	}
+
+	if len(reportPaths) > 0 {
+		fmt.Fprintln(out, "\n📁 报告文件:")
+		for _, path := range reportPaths {
+			fmt.Fprintf(out, "   %s\n", path)
+		}
+	}
+}
+
+// writeTable writes a bordered text table. The first column is left-aligned and the others
+// right-aligned; column widths use the terminal display width so Chinese text lines up.
+func writeTable(out io.Writer, headers []string, rows [][]string) {
+	writeAlignedTable(out, headers, rows, 1)
+}
+
+// writeAlignedTable writes a bordered text table like writeTable, with the columns before
+// rightFrom left-aligned and the others right-aligned.
+func writeAlignedTable(out io.Writer, headers []string, rows [][]string, rightFrom int) {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	border := func() {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat("-", w+2)
+		}
+		fmt.Fprintf(out, "   +%s+\n", strings.Join(parts, "+"))
+	}
+	line := func(cells []string) {
+		parts := make([]string, len(cells))
+		for i, cell := range cells {
+			pad := strings.Repeat(" ", widths[i]-displayWidth(cell))
+			if i < rightFrom {
+				parts[i] = " " + cell + pad + " "
+			} else {
+				parts[i] = " " + pad + cell + " "
+			}
+		}
+		fmt.Fprintf(out, "   |%s|\n", strings.Join(parts, "|"))
+	}
+
+	border()
+	line(headers)
+	border()
+	for _, row := range rows {
+		line(row)
+	}
+	border()
+}
+
+// displayWidth returns the number of terminal columns s occupies: East Asian wide and
+// fullwidth characters take two columns.
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case r >= 0x1100 && r <= 0x115F,
+			r >= 0x2E80 && r <= 0xA4CF,
+			r >= 0xAC00 && r <= 0xD7A3,
+			r >= 0xF900 && r <= 0xFAFF,
+			r >= 0xFE30 && r <= 0xFE4F,
+			r >= 0xFF00 && r <= 0xFF60,
+			r >= 0xFFE0 && r <= 0xFFE6:
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
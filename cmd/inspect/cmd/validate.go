@@ -2,23 +2,69 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
 	"inspection-tool/internal/config"
 )
 
-// validateCmd represents the validate command.
+// Validate command flags
+var (
+	checkConnectivity   bool          // Test the N9E and metrics backend connections
+	connectivityTimeout time.Duration // Timeout of each connectivity test
+)
+
+const validateLong = `加载并验证配置文件，检查格式、必填字段、数值范围（含告警阈值）和业务逻辑约束，
+并加载主机及已启用模块的指标定义文件，检查其格式与定义。
+
+加上 --connectivity 时，再实际连接夜莺（N9E）和指标数据源（VictoriaMetrics / Prometheus），
+失败时给出需要检查的配置项。适合在定时巡检之前、修改配置之后执行。
+
+示例:
+  inspect config validate -c config.yaml
+  inspect config validate -c config.yaml --connectivity
+  inspect validate -c config.yaml -m custom_metrics.yaml`
+
+// configCmd groups the configuration commands.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "配置文件相关命令",
+}
+
+// configValidateCmd represents the config validate command.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "验证配置文件、指标定义与数据源连通性",
+	Long:  validateLong,
+	Run:   runValidate,
+}
+
+// validateCmd represents the validate command, kept as a shortcut of config validate.
 var validateCmd = &cobra.Command{
 	Use:   "validate",
-	Short: "验证配置文件",
-	Long:  "加载并验证配置文件，检查格式、必填字段、数值范围和业务逻辑约束。",
+	Short: "验证配置文件（同 config validate）",
+	Long:  validateLong,
 	Run:   runValidate,
 }
 
 func init() {
+	for _, cmd := range []*cobra.Command{validateCmd, configValidateCmd} {
+		addMetricsFlags(cmd)
+		cmd.Flags().BoolVar(&checkConnectivity, "connectivity", false, "测试夜莺（N9E）与指标数据源的连通性")
+		cmd.Flags().DurationVar(&connectivityTimeout, "timeout", 10*time.Second, "每项连通性测试的超时时间")
+	}
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(validateCmd)
 }
 
@@ -27,11 +73,149 @@ func runValidate(cmd *cobra.Command, args []string) {
 	configPath := GetConfigFile()
 
 	// Load and validate configuration (Load internally calls Validate)
-	_, err := config.Load(configPath)
+	cfg, err := config.Load(configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ 配置验证失败: %v\n", err)
 		os.Exit(1)
 	}
-
 	fmt.Printf("✅ 配置文件验证通过: %s\n", configPath)
+
+	failed := !validateMetricsFiles(cfg)
+	if checkConnectivity {
+		failed = !testConnectivity(cfg, zerolog.Nop()) || failed // Client errors are printed with hints
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// metricsFile is a metric definition file checked by the validate command.
+type metricsFile struct {
+	name    string
+	path    string
+	enabled bool
+	load    func(path string) (int, error) // Returns the number of metrics defined
+}
+
+// countMetrics adapts a metric definition loader to metricsFile.load.
+func countMetrics[T any](load func(path string) ([]T, error)) func(path string) (int, error) {
+	return func(path string) (int, error) {
+		metrics, err := load(path)
+		return len(metrics), err
+	}
+}
+
+// validateMetricsFiles loads the host metric definitions and those of the enabled modules,
+// and reports whether all of them are valid.
+func validateMetricsFiles(cfg *config.Config) bool {
+	files := []metricsFile{
+		{"主机", metricsPath, true, countMetrics(config.LoadMetrics)},
+		{"MySQL", mysqlMetricsPath, cfg.MySQL.Enabled, countMetrics(config.LoadMySQLMetrics)},
+		{"Redis", redisMetricsPath, cfg.Redis.Enabled, countMetrics(config.LoadRedisMetrics)},
+		{"Nginx", nginxMetricsPath, cfg.Nginx.Enabled, countMetrics(config.LoadNginxMetrics)},
+		{"Tomcat", tomcatMetricsPath, cfg.Tomcat.Enabled, countMetrics(config.LoadTomcatMetrics)},
+		{"Cassandra", cassandraMetricsPath, cfg.Cassandra.Enabled, countMetrics(config.LoadCassandraMetrics)},
+		{"监控系统", monitoringMetricsPath, cfg.Monitoring.Enabled, countMetrics(config.LoadMonitoringMetrics)},
+		{"共享存储", storageMetricsPath, cfg.Storage.Enabled, countMetrics(config.LoadStorageMetrics)},
+		{"日志巡检", logChecksPath, cfg.LogChecks.Enabled, countMetrics(config.LoadLogChecks)},
+		{"LVS", lvsMetricsPath, cfg.LVS.Enabled, countMetrics(config.LoadLVSMetrics)},
+		{"Windows", windowsMetricsPath, cfg.Windows.Enabled, countMetrics(config.LoadWindowsMetrics)},
+		{"AD", adMetricsPath, cfg.AD.Enabled, countMetrics(config.LoadADMetrics)},
+		{"云资源", cloudMetricsPath, cfg.Cloud.Enabled, countMetrics(config.LoadCloudMetrics)},
+		{"防火墙", firewallMetricsPath, cfg.Firewall.Enabled, countMetrics(config.LoadFirewallMetrics)},
+	}
+
+	fmt.Println("📊 指标定义:")
+	ok := true
+	for _, f := range files {
+		if !f.enabled {
+			continue
+		}
+		count, err := f.load(f.path)
+		if err != nil {
+			fmt.Printf("   ❌ %s: %s: %v\n", f.name, f.path, err)
+			ok = false
+			continue
+		}
+		fmt.Printf("   ✅ %s: %s (%d 个指标)\n", f.name, f.path, count)
+	}
+	return ok
+}
+
+// testConnectivity runs a request against N9E and the metrics backend, without retries or
+// query cache, and reports whether both succeeded. Failures print the settings to check.
+func testConnectivity(cfg *config.Config, logger zerolog.Logger) bool {
+	retry := cfg.HTTP.Retry
+	retry.MaxRetries = 0
+
+	fmt.Println("🔗 连通性检查:")
+	ok := true
+
+	n9eCfg := cfg.Datasources.N9E
+	client := n9e.NewClient(&n9eCfg, &retry, logger)
+	ctx, cancel := context.WithTimeout(context.Background(), connectivityTimeout)
+	start := time.Now()
+	targets, err := client.GetTargets(ctx)
+	cancel()
+	if err != nil {
+		fmt.Printf("   ❌ 夜莺 N9E %s: %s\n", n9eCfg.Endpoint, shortError(err))
+		fmt.Printf("      → %s\n", connectivityHint("datasources.n9e", err))
+		ok = false
+	} else {
+		fmt.Printf("   ✅ 夜莺 N9E %s: %d 台主机 (%.1fs)\n", n9eCfg.Endpoint, len(targets), time.Since(start).Seconds())
+		if len(targets) == 0 {
+			fmt.Println("      ⚠️  未返回任何主机，检查 datasources.n9e.query 过滤条件与 token 对应用户的业务组权限")
+		}
+	}
+
+	vmCfg := cfg.Datasources.VictoriaMetrics
+	vmCfg.Cache.Enabled = false // Test the backend, not the cache
+	source := vm.NewMetricsSource(&vmCfg, &retry, logger)
+	name := metricsSourceName(vmCfg.Type)
+	ctx, cancel = context.WithTimeout(context.Background(), connectivityTimeout)
+	start = time.Now()
+	_, err = source.Query(ctx, "vector(1)")
+	cancel()
+	if err != nil {
+		fmt.Printf("   ❌ %s %s: %s\n", name, vmCfg.QueryURL(), shortError(err))
+		fmt.Printf("      → %s\n", connectivityHint("datasources.victoriametrics", err))
+		ok = false
+	} else {
+		fmt.Printf("   ✅ %s %s (%.1fs)\n", name, vmCfg.QueryURL(), time.Since(start).Seconds())
+	}
+	return ok
+}
+
+// connectivityHint returns the settings to check after a failed request to the datasource
+// configured under field.
+func connectivityHint(field string, err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var opErr *net.OpError
+	msg := err.Error()
+	switch {
+	case errors.As(err, &dnsErr):
+		return fmt.Sprintf("无法解析域名 %s，检查 %s.endpoint 与本机 DNS 配置", dnsErr.Name, field)
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return fmt.Sprintf("请求超时，检查网络与防火墙是否放通、%s.proxy_url 代理是否可用，或调大 --timeout", field)
+	case errors.As(err, &opErr):
+		return fmt.Sprintf("无法建立连接，检查 %s.endpoint 的地址与端口、服务是否运行、防火墙是否放通", field)
+	case strings.Contains(msg, "x509") || strings.Contains(msg, "certificate"):
+		return fmt.Sprintf("TLS 证书校验失败，检查 %s.tls 的 CA 证书或服务端证书", field)
+	case strings.Contains(msg, "status 401") || strings.Contains(msg, "status 403"):
+		return fmt.Sprintf("认证失败，检查 %s 的 token、用户名密码等认证配置", field)
+	case strings.Contains(msg, "status 404"):
+		return fmt.Sprintf("接口不存在，检查 %s.endpoint 是否为服务根地址", field)
+	}
+	return fmt.Sprintf("检查 %s 配置与服务端日志", field)
+}
+
+// shortError returns the first line of the error message, truncated to 200 characters, so
+// an HTML error page in a response body does not flood the output.
+func shortError(err error) string {
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	if runes := []rune(msg); len(runes) > 200 {
+		msg = string(runes[:200]) + "..."
+	}
+	return msg
 }
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/distributed"
+	"inspection-tool/internal/report/json"
+)
+
+// workerListen is the --listen flag of the worker command.
+var workerListen string
+
+// workerSkipFlags lists the service modules with the inspect flag skipping them.
+var workerSkipFlags = []struct {
+	module string
+	flag   string
+}{
+	{json.ModuleMySQL, "--skip-mysql"}, {json.ModuleRedis, "--skip-redis"},
+	{json.ModuleNginx, "--skip-nginx"}, {json.ModuleTomcat, "--skip-tomcat"},
+	{json.ModuleCassandra, "--skip-cassandra"}, {json.ModuleMonitoring, "--skip-monitoring"},
+	{json.ModuleStorage, "--skip-storage"}, {json.ModuleLogChecks, "--skip-log-checks"},
+	{json.ModuleLVS, "--skip-lvs"}, {json.ModuleWindows, "--skip-windows"},
+	{json.ModuleAD, "--skip-ad"}, {json.ModuleCloud, "--skip-cloud"},
+	{json.ModuleFirewall, "--skip-firewall"},
+}
+
+// workerCmd represents the worker command.
+var workerCmd = &cobra.Command{
+	Use:   "worker [-- inspect 参数...]",
+	Short: "作为分布式巡检的 worker 运行",
+	Long: `启动 HTTP 服务，执行协调者（配置了 distributed.workers 的 inspect inspect）分发的巡检任务。
+
+每个任务以 "inspect inspect --local -f json" 在本机执行，巡检范围为任务指定的模块与主机业务组，
+JSON 结果返回给协调者汇总。任务依次执行；数据源、阈值等使用本机配置文件。
+distributed.token 必须设置且与协调者一致，否则拒绝启动。"--" 之后的参数原样传给每个任务的 inspect inspect。
+
+默认仅监听本机（127.0.0.1:8090），供协调者访问时指定本机的内网地址；worker 使用明文 HTTP，
+跨网络部署时请置于 TLS 反向代理之后（协调者通过 distributed.tls 校验证书）。
+
+示例:
+  inspect worker -c config.yaml --listen 10.0.0.11:8090
+  inspect worker -c config.yaml -- --metrics custom_metrics.yaml`,
+	Run: runWorker,
+}
+
+func init() {
+	workerCmd.Flags().StringVar(&workerListen, "listen", "127.0.0.1:8090", "监听地址（默认仅本机，供协调者访问时指定内网地址）")
+	rootCmd.AddCommand(workerCmd)
+}
+
+// runWorker executes the worker command logic.
+func runWorker(cmd *cobra.Command, args []string) {
+	configPath := GetConfigFile()
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 加载配置失败: %v\n", err)
+		os.Exit(1)
+	}
+	logger := setupLogger(GetLogLevel(), cfg.Logging.Format)
+	applyRuntimeConfig(&cfg.Runtime, logger)
+
+	worker, err := distributed.NewWorker(cfg.Distributed.Token, func(ctx context.Context, job *distributed.Job) (*json.Report, error) {
+		return runWorkerJob(ctx, configPath, job, args)
+	}, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 未设置 distributed.token（或环境变量 INSPECT_DISTRIBUTED_TOKEN），worker 拒绝无认证运行\n")
+		os.Exit(1)
+	}
+
+	fmt.Printf("🌐 worker 已启动: %s\n", workerListen)
+	logger.Info().Str("listen", workerListen).Str("config", configPath).Msg("worker started")
+	if err := http.ListenAndServe(workerListen, worker.Handler()); err != nil {
+		logger.Error().Err(err).Msg("worker stopped")
+		fmt.Fprintf(os.Stderr, "❌ worker 运行失败: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runWorkerJob runs a job as a local inspection writing a JSON report into a temporary
+// directory, and reads the report back. The run exits non-zero when it finds alerts, so
+// only a missing report means the job failed.
+func runWorkerJob(ctx context.Context, configPath string, job *distributed.Job, extraArgs []string) (*json.Report, error) {
+	dir, err := os.MkdirTemp("", "inspect-worker-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	var output bytes.Buffer
+	run := exec.CommandContext(ctx, executable, append(workerRunArgs(configPath, dir, job), extraArgs...)...)
+	run.Stdout = &output
+	run.Stderr = &output
+	runErr := run.Run()
+
+	reports, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("inspection produced no report (%v): %s", runErr, lastLines(output.String(), 10))
+	}
+	return json.ReadReport(reports[0])
+}
+
+// workerRunArgs returns the inspect command arguments of a job: JSON output into dir, the
+// business groups and excluded hosts of the host shard, a skip flag for every module outside
+// the job and the range aggregation window of the coordinator.
+func workerRunArgs(configPath, dir string, job *distributed.Job) []string {
+	args := []string{"inspect", "--config", configPath, "--log-level", GetLogLevel(), "--local", "--format", "json", "--output", dir}
+	if job.HasModule(json.ModuleHost) {
+		if len(job.BusinessGroups) > 0 {
+			args = append(args, "--business-groups", strings.Join(job.BusinessGroups, ","))
+		}
+		for _, pattern := range job.ExcludeHosts {
+			args = append(args, "--exclude-host", pattern)
+		}
+	} else {
+		args = append(args, "--skip-host")
+	}
+	for _, skip := range workerSkipFlags {
+		if !job.HasModule(skip.module) {
+			args = append(args, skip.flag)
+		}
+	}
+	if !job.RangeEnd.IsZero() {
+		args = append(args, "--start", job.RangeStart.Format(time.RFC3339), "--end", job.RangeEnd.Format(time.RFC3339))
+	}
+	return args
+}
+
+// lastLines returns the last n lines of s, for error messages.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
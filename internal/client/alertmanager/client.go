@@ -0,0 +1,197 @@
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/auth"
+	"inspection-tool/internal/client/resilience"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// Client is a client for the Alertmanager v2 API.
+type Client struct {
+	endpoint   string             // API endpoint
+	filters    []string           // Label matchers sent as filter parameters
+	timeout    time.Duration      // Request timeout
+	retry      config.RetryConfig // Retry configuration
+	httpClient *resty.Client      // HTTP client
+	logger     zerolog.Logger     // Logger
+}
+
+// NewClient creates a new Alertmanager API client.
+func NewClient(cfg *config.AlertmanagerConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) *Client {
+	// Set default timeout if not specified
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	// Set default retry config if not specified
+	retry := config.RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  1 * time.Second,
+	}
+	if retryCfg != nil {
+		retry = *retryCfg
+	}
+
+	// Create resty client
+	httpClient := resty.New().
+		SetBaseURL(cfg.Endpoint).
+		SetTimeout(timeout).
+		SetRetryCount(retry.MaxRetries).
+		SetRetryWaitTime(retry.BaseDelay)
+
+	// Additional headers, e.g. required by an API gateway in front of the datasource
+	if len(cfg.Headers) > 0 {
+		httpClient.SetHeaders(cfg.Headers)
+	}
+
+	// Authentication of a reverse proxy in front of Alertmanager
+	switch {
+	case cfg.BearerToken != "":
+		httpClient.SetAuthToken(cfg.BearerToken)
+	case cfg.Username != "":
+		httpClient.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	// Custom CA and client certificate (mTLS); the files were checked when loading the config
+	if tlsConfig, err := cfg.TLS.ClientConfig(); err != nil {
+		logger.Warn().Err(err).Msg("invalid TLS configuration, using the default TLS settings")
+	} else if tlsConfig != nil {
+		httpClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	// Proxy of the datasource; without one, HTTP_PROXY / HTTPS_PROXY / NO_PROXY apply
+	if cfg.ProxyURL != "" {
+		httpClient.SetProxy(cfg.ProxyURL)
+	}
+
+	// Request signing by the auth plugin, e.g. the AK/SK signature of cloud providers
+	auth.Apply(httpClient, cfg.AuthPlugin, logger)
+
+	// Jittered backoff, retry budget and circuit breaker, wrapping the transport configured above
+	resilience.Apply(httpClient, retry, retryCondition, logger)
+
+	return &Client{
+		endpoint:   cfg.Endpoint,
+		filters:    cfg.Filters,
+		timeout:    timeout,
+		retry:      retry,
+		httpClient: httpClient,
+		logger:     logger.With().Str("component", "alertmanager-client").Logger(),
+	}
+}
+
+// retryCondition determines whether a request should be retried.
+// Only retry on timeout, 5xx errors, or connection failures.
+// Do not retry on 4xx errors.
+func retryCondition(resp *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.StatusCode() >= 500
+}
+
+// GetActiveAlerts returns the alerts currently firing in Alertmanager, including silenced and
+// inhibited ones, sorted with the alerts still notifying first. Silenced alerts carry the
+// details of their silences; when the silences cannot be fetched only their IDs are kept.
+func (c *Client) GetActiveAlerts(ctx context.Context) ([]*model.AlertmanagerAlert, error) {
+	alerts, err := c.GetAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.AlertmanagerAlert, 0, len(alerts))
+	silenced := false
+	for i := range alerts {
+		alert := alerts[i].ToAlertmanagerAlert()
+		silenced = silenced || alert.Silenced()
+		result = append(result, alert)
+	}
+
+	if silenced {
+		silences, err := c.GetSilences(ctx)
+		if err != nil {
+			c.logger.Warn().Err(err).Msg("failed to fetch silences, keeping only their IDs")
+		} else {
+			byID := make(map[string]*model.AlertmanagerSilence, len(silences))
+			for i := range silences {
+				byID[silences[i].ID] = silences[i].ToAlertmanagerSilence()
+			}
+			for _, alert := range result {
+				for _, id := range alert.SilencedBy {
+					if s, ok := byID[id]; ok {
+						alert.Silences = append(alert.Silences, s)
+					}
+				}
+			}
+		}
+	}
+
+	model.SortAlertmanagerAlerts(result)
+	return result, nil
+}
+
+// GetAlerts fetches the active, silenced and inhibited alerts matching the configured filters
+// from GET /api/v2/alerts. Unprocessed alerts are left out.
+func (c *Client) GetAlerts(ctx context.Context) ([]GettableAlert, error) {
+	c.logger.Debug().Strs("filters", c.filters).Msg("fetching alerts from Alertmanager")
+
+	var result []GettableAlert
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetQueryParams(map[string]string{
+			"active":      "true",
+			"silenced":    "true",
+			"inhibited":   "true",
+			"unprocessed": "false",
+		}).
+		SetQueryParamsFromValues(url.Values{"filter": c.filters}).
+		Get("/api/v2/alerts")
+
+	if err != nil {
+		c.logger.Error().Err(err).Msg("failed to fetch alerts")
+		return nil, fmt.Errorf("failed to fetch alerts: %w", err)
+	}
+
+	// Check HTTP status code
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Msg("Alertmanager API returned non-200 status")
+		return nil, fmt.Errorf("Alertmanager API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	c.logger.Debug().Int("count", len(result)).Msg("fetched alerts from Alertmanager")
+	return result, nil
+}
+
+// GetSilences fetches the silences from GET /api/v2/silences.
+func (c *Client) GetSilences(ctx context.Context) ([]GettableSilence, error) {
+	var result []GettableSilence
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		Get("/api/v2/silences")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch silences: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Alertmanager API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package alertmanager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+const testAlerts = `[
+	{
+		"fingerprint": "a1",
+		"labels": {"alertname": "HostDown", "severity": "critical", "instance": "10.0.0.1:9100"},
+		"annotations": {"description": "host unreachable"},
+		"startsAt": "2025-01-15T02:30:00Z",
+		"status": {"state": "suppressed", "silencedBy": ["s1"], "inhibitedBy": []}
+	},
+	{
+		"fingerprint": "a2",
+		"labels": {"alertname": "DiskFull", "severity": "warning", "instance": "10.0.0.2:9100"},
+		"annotations": {"summary": "disk / above 90%"},
+		"startsAt": "2025-01-15T03:00:00Z",
+		"status": {"state": "active", "silencedBy": [], "inhibitedBy": []}
+	}
+]`
+
+const testSilences = `[
+	{"id": "s1", "createdBy": "ops", "comment": "maintenance", "endsAt": "2025-01-15T06:00:00Z", "status": {"state": "active"}}
+]`
+
+// setupTestServer creates a test server and Alertmanager client for testing.
+func setupTestServer(t *testing.T, filters []string, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cfg := &config.AlertmanagerConfig{Endpoint: server.URL, Timeout: 5 * time.Second, Filters: filters}
+	retryCfg := &config.RetryConfig{MaxRetries: 1, BaseDelay: 10 * time.Millisecond}
+	return NewClient(cfg, retryCfg, zerolog.Nop())
+}
+
+func TestGetActiveAlerts_Success(t *testing.T) {
+	client := setupTestServer(t, []string{`env="prod"`, `team="ops"`}, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/alerts":
+			query := r.URL.Query()
+			if query.Get("silenced") != "true" || query.Get("inhibited") != "true" {
+				t.Errorf("silenced / inhibited alerts not requested: %s", r.URL.RawQuery)
+			}
+			if got := query["filter"]; len(got) != 2 || got[0] != `env="prod"` || got[1] != `team="ops"` {
+				t.Errorf("filter = %v, want both matchers", got)
+			}
+			w.Write([]byte(testAlerts))
+		case "/api/v2/silences":
+			w.Write([]byte(testSilences))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	alerts, err := client.GetActiveAlerts(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveAlerts failed: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(alerts))
+	}
+
+	// Alerts still notifying come first
+	if alerts[0].Name != "DiskFull" || alerts[0].Summary != "disk / above 90%" || alerts[0].Silenced() {
+		t.Errorf("first alert = %+v, want the active DiskFull alert", alerts[0])
+	}
+	down := alerts[1]
+	if down.Name != "HostDown" || down.Level() != model.AlertLevelCritical || down.Summary != "host unreachable" {
+		t.Errorf("second alert = %+v, want HostDown with the description as summary", down)
+	}
+	if len(down.Silences) != 1 || down.Silences[0].CreatedBy != "ops" || down.Silences[0].Comment != "maintenance" {
+		t.Errorf("silences = %+v, want the ops maintenance silence", down.Silences)
+	}
+}
+
+func TestGetActiveAlerts_SilencesUnavailable(t *testing.T) {
+	client := setupTestServer(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/silences" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testAlerts))
+	})
+
+	alerts, err := client.GetActiveAlerts(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveAlerts failed: %v", err)
+	}
+	down := alerts[1]
+	if !down.Silenced() || len(down.Silences) != 0 || down.StateText() != "已静默" {
+		t.Errorf("alert = %+v, want silence IDs without details", down)
+	}
+}
+
+func TestGetActiveAlerts_APIError(t *testing.T) {
+	client := setupTestServer(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`"bad matcher"`))
+	})
+
+	if _, err := client.GetActiveAlerts(context.Background()); err == nil {
+		t.Fatal("expected error for a 400 response")
+	}
+}
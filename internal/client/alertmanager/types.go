@@ -0,0 +1,73 @@
+// Package alertmanager provides a client for the Alertmanager v2 API.
+package alertmanager
+
+import (
+	"time"
+
+	"inspection-tool/internal/model"
+)
+
+// GettableAlert represents an alert returned by Alertmanager GET /api/v2/alerts.
+type GettableAlert struct {
+	Fingerprint  string            `json:"fingerprint"`  // 告警指纹
+	Labels       map[string]string `json:"labels"`       // 标签
+	Annotations  map[string]string `json:"annotations"`  // 注解
+	StartsAt     time.Time         `json:"startsAt"`     // 开始触发时间
+	EndsAt       time.Time         `json:"endsAt"`       // 预计结束时间
+	UpdatedAt    time.Time         `json:"updatedAt"`    // 更新时间
+	GeneratorURL string            `json:"generatorURL"` // 告警来源（Prometheus 表达式页面）
+	Status       AlertStatus       `json:"status"`       // 告警状态
+}
+
+// AlertStatus is the status of an Alertmanager alert.
+type AlertStatus struct {
+	State       string   `json:"state"`       // unprocessed / active / suppressed
+	SilencedBy  []string `json:"silencedBy"`  // 静默 ID
+	InhibitedBy []string `json:"inhibitedBy"` // 抑制该告警的告警指纹
+}
+
+// GettableSilence represents a silence returned by Alertmanager GET /api/v2/silences.
+type GettableSilence struct {
+	ID        string        `json:"id"`
+	CreatedBy string        `json:"createdBy"` // 创建人
+	Comment   string        `json:"comment"`   // 静默原因
+	StartsAt  time.Time     `json:"startsAt"`  // 开始时间
+	EndsAt    time.Time     `json:"endsAt"`    // 结束时间
+	Status    SilenceStatus `json:"status"`    // 静默状态
+}
+
+// SilenceStatus is the status of an Alertmanager silence.
+type SilenceStatus struct {
+	State string `json:"state"` // expired / active / pending
+}
+
+// ToAlertmanagerAlert converts the API alert to the report model. The summary comes from the
+// summary annotation, falling back to description.
+func (a *GettableAlert) ToAlertmanagerAlert() *model.AlertmanagerAlert {
+	summary := a.Annotations["summary"]
+	if summary == "" {
+		summary = a.Annotations["description"]
+	}
+	return &model.AlertmanagerAlert{
+		Fingerprint: a.Fingerprint,
+		Name:        a.Labels["alertname"],
+		Severity:    a.Labels["severity"],
+		Instance:    a.Labels["instance"],
+		Summary:     summary,
+		Labels:      a.Labels,
+		StartsAt:    a.StartsAt,
+		State:       a.Status.State,
+		SilencedBy:  a.Status.SilencedBy,
+		InhibitedBy: a.Status.InhibitedBy,
+	}
+}
+
+// ToAlertmanagerSilence converts the API silence to the report model.
+func (s *GettableSilence) ToAlertmanagerSilence() *model.AlertmanagerSilence {
+	return &model.AlertmanagerSilence{
+		ID:        s.ID,
+		CreatedBy: s.CreatedBy,
+		Comment:   s.Comment,
+		EndsAt:    s.EndsAt,
+	}
+}
@@ -0,0 +1,99 @@
+// Package auth provides the auth plugins signing the requests of the datasource clients,
+// for endpoints requiring signed requests such as cloud-managed Prometheus.
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+// Signer signs an outgoing request, typically by adding headers computed from the request
+// and a secret key. It is called before every attempt, so retries are signed again.
+type Signer interface {
+	Sign(req *http.Request) error
+}
+
+// SignerFunc adapts a function to the Signer interface.
+type SignerFunc func(req *http.Request) error
+
+// Sign calls f(req).
+func (f SignerFunc) Sign(req *http.Request) error {
+	return f(req)
+}
+
+// Factory creates the signer of an auth plugin from its configuration.
+type Factory func(cfg config.AuthPluginConfig) (Signer, error)
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = map[string]Factory{}
+)
+
+// Register makes an auth plugin available under name, selected by auth_plugin.type. It is
+// meant to be called from an init function and panics when the name is already taken.
+func Register(name string, factory Factory) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if _, exists := plugins[name]; exists {
+		panic(fmt.Sprintf("auth: plugin %q registered twice", name))
+	}
+	plugins[name] = factory
+}
+
+// Plugins returns the names of the registered auth plugins, sorted.
+func Plugins() []string {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the signer of the configured auth plugin, or returns nil when no plugin is
+// configured.
+func New(cfg config.AuthPluginConfig) (Signer, error) {
+	if cfg.IsZero() {
+		return nil, nil
+	}
+	pluginsMu.RLock()
+	factory, ok := plugins[cfg.Type]
+	pluginsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown auth plugin %q (available: %v)", cfg.Type, Plugins())
+	}
+	signer, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("auth plugin %s: %w", cfg.Type, err)
+	}
+	return signer, nil
+}
+
+// Apply signs every request of httpClient with the configured auth plugin. The signature is
+// computed last, over the final URL and headers. An invalid configuration is logged and the
+// requests are sent unsigned, so the datasource reports the authentication failure.
+func Apply(httpClient *resty.Client, cfg config.AuthPluginConfig, logger zerolog.Logger) {
+	signer, err := New(cfg)
+	if err != nil {
+		logger.Error().Err(err).Msg("invalid auth plugin configuration, sending unsigned requests")
+		return
+	}
+	if signer == nil {
+		return
+	}
+	httpClient.SetPreRequestHook(func(_ *resty.Client, req *http.Request) error {
+		if err := signer.Sign(req); err != nil {
+			return fmt.Errorf("failed to sign request: %w", err)
+		}
+		return nil
+	})
+}
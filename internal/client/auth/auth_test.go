@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+func init() {
+	Register("test-hmac", func(cfg config.AuthPluginConfig) (Signer, error) {
+		return SignerFunc(func(req *http.Request) error {
+			req.Header.Set("X-Signature", cfg.Params["key"]+":"+req.URL.RawQuery)
+			return nil
+		}), nil
+	})
+}
+
+func TestNew(t *testing.T) {
+	if signer, err := New(config.AuthPluginConfig{}); signer != nil || err != nil {
+		t.Errorf("New(empty) = %v, %v, want nil, nil", signer, err)
+	}
+	_, err := New(config.AuthPluginConfig{Type: "hmac-md5"})
+	if err == nil || !strings.Contains(err.Error(), "sigv4") {
+		t.Errorf("New(unknown) error = %v, want the available plugins", err)
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a duplicate plugin name")
+		}
+	}()
+	Register(PluginSigV4, newSigV4Signer)
+}
+
+func TestApply(t *testing.T) {
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get("X-Signature")
+	}))
+	defer server.Close()
+
+	httpClient := resty.New().SetBaseURL(server.URL)
+	Apply(httpClient, config.AuthPluginConfig{Type: "test-hmac", Params: map[string]string{"key": "k1"}}, zerolog.Nop())
+
+	if _, err := httpClient.R().SetQueryParam("query", "up").Get("/api/v1/query"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if signature != "k1:query=up" {
+		t.Errorf("X-Signature = %q, want the request signed with the final query", signature)
+	}
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/config"
+)
+
+// testSigV4Signer returns a signer with the credentials and date of the AWS SigV4 test suite.
+func testSigV4Signer(t *testing.T, sessionToken string) *sigV4Signer {
+	t.Helper()
+	signer, err := newSigV4Signer(config.AuthPluginConfig{
+		Type:         PluginSigV4,
+		Region:       "us-east-1",
+		Service:      "service",
+		AccessKey:    "AKIDEXAMPLE",
+		SecretKey:    "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		SessionToken: sessionToken,
+	})
+	if err != nil {
+		t.Fatalf("newSigV4Signer failed: %v", err)
+	}
+	s := signer.(*sigV4Signer)
+	s.now = func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) }
+	return s
+}
+
+func TestSigV4Signer_TestSuite(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"get-vanilla", "https://example.amazonaws.com/", "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{"get-vanilla-query-order-key-case", "https://example.amazonaws.com/?Param2=value2&Param1=value1", "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err := testSigV4Signer(t, "").Sign(req); err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+			want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=" + tt.want
+			if got := req.Header.Get("Authorization"); got != want {
+				t.Errorf("Authorization = %s\nwant %s", got, want)
+			}
+			if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+				t.Errorf("X-Amz-Date = %s, want 20150830T123600Z", got)
+			}
+		})
+	}
+}
+
+func TestSigV4Signer_SessionTokenAndBody(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1/api/v1/query", strings.NewReader("query=up"))
+	if err := testSigV4Signer(t, "token").Sign(req); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "token" {
+		t.Errorf("X-Amz-Security-Token = %q, want token", got)
+	}
+	if got := req.Header.Get("Authorization"); !strings.Contains(got, "SignedHeaders=host;x-amz-date;x-amz-security-token,") {
+		t.Errorf("Authorization = %s, want the session token signed", got)
+	}
+
+	// The body is still sent after being hashed
+	body := make([]byte, 16)
+	n, _ := req.Body.Read(body)
+	if string(body[:n]) != "query=up" {
+		t.Errorf("body = %q, want query=up", body[:n])
+	}
+}
+
+func TestNewSigV4Signer_Credentials(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	if _, err := newSigV4Signer(config.AuthPluginConfig{Type: PluginSigV4, AccessKey: "ak", SecretKey: "sk"}); err == nil {
+		t.Error("expected error without region")
+	}
+	if _, err := newSigV4Signer(config.AuthPluginConfig{Type: PluginSigV4, Region: "us-east-1"}); err == nil {
+		t.Error("expected error without credentials")
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "env-ak")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-sk")
+	signer, err := newSigV4Signer(config.AuthPluginConfig{Type: PluginSigV4, Region: "us-east-1"})
+	if err != nil {
+		t.Fatalf("newSigV4Signer failed: %v", err)
+	}
+	if s := signer.(*sigV4Signer); s.accessKey != "env-ak" || s.service != "aps" {
+		t.Errorf("signer = %+v, want the environment credentials and the aps service", s)
+	}
+}
@@ -0,0 +1,125 @@
+// Package cmdb provides the CMDB sources enriching the host metadata with the owner,
+// application, rack / location and maintenance status of each host.
+package cmdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// TypeHTTP is the built-in source reading a JSON host list over HTTP.
+const TypeHTTP = "http"
+
+// Source returns the host records of a CMDB.
+type Source interface {
+	Records(ctx context.Context) ([]model.CMDBRecord, error)
+}
+
+// Factory creates a CMDB source from its configuration.
+type Factory func(cfg *config.CMDBConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) (Source, error)
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = map[string]Factory{}
+)
+
+func init() {
+	Register(TypeHTTP, func(cfg *config.CMDBConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) (Source, error) {
+		return NewHTTPSource(cfg, retryCfg, logger), nil
+	})
+}
+
+// Register makes a CMDB source available under name, selected by datasources.cmdb.type. It
+// is meant to be called from an init function and panics when the name is already taken.
+func Register(name string, factory Factory) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	if _, exists := sources[name]; exists {
+		panic(fmt.Sprintf("cmdb: source %q registered twice", name))
+	}
+	sources[name] = factory
+}
+
+// Types returns the names of the registered CMDB sources, sorted.
+func Types() []string {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the configured CMDB source, or returns nil when no endpoint is configured. The
+// type defaults to TypeHTTP.
+func New(cfg *config.CMDBConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) (Source, error) {
+	if cfg.Endpoint == "" {
+		return nil, nil
+	}
+	name := cfg.Type
+	if name == "" {
+		name = TypeHTTP
+	}
+	sourcesMu.RLock()
+	factory, ok := sources[name]
+	sourcesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown CMDB type %q (available: %v)", name, Types())
+	}
+	source, err := factory(cfg, retryCfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("CMDB %s: %w", name, err)
+	}
+	return source, nil
+}
+
+// Enrich copies the CMDB attributes of the matching record to each host and returns the
+// number of hosts matched. A host matches the record of its hostname, its ident, or else
+// of its IP; hostnames are compared case-insensitively.
+func Enrich(hosts []*model.HostMeta, records []model.CMDBRecord) int {
+	byName := make(map[string]*model.CMDBRecord, len(records))
+	byIP := make(map[string]*model.CMDBRecord, len(records))
+	for i := range records {
+		r := &records[i]
+		if name := strings.ToLower(r.Hostname); name != "" {
+			if _, ok := byName[name]; !ok {
+				byName[name] = r
+			}
+		}
+		if r.IP != "" {
+			if _, ok := byIP[r.IP]; !ok {
+				byIP[r.IP] = r
+			}
+		}
+	}
+
+	matched := 0
+	for _, host := range hosts {
+		if host == nil {
+			continue
+		}
+		record := byName[strings.ToLower(host.Hostname)]
+		if record == nil && host.Ident != "" {
+			record = byName[strings.ToLower(host.Ident)]
+		}
+		if record == nil && host.IP != "" {
+			record = byIP[host.IP]
+		}
+		if record == nil {
+			continue
+		}
+		host.ApplyCMDB(record)
+		matched++
+	}
+	return matched
+}
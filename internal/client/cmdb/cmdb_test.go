@@ -0,0 +1,91 @@
+package cmdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+func TestEnrich(t *testing.T) {
+	hosts := []*model.HostMeta{
+		{Ident: "web-01@10.0.0.1", Hostname: "web-01", IP: "10.0.0.1"},
+		{Ident: "db-01", Hostname: "db-01", IP: "10.0.0.2"},
+		{Ident: "cache-01", Hostname: "cache-01", IP: "10.0.0.3"},
+		{Ident: "unknown", Hostname: "unknown", IP: "10.0.0.9"},
+	}
+	records := []model.CMDBRecord{
+		{Hostname: "WEB-01", Owner: "alice", Application: "shop", Location: "IDC-A/R01"},
+		{Hostname: "other", IP: "10.0.0.2", Owner: "bob", Maintenance: true},
+		{Hostname: "cache-01", Application: "cache"},
+		{Hostname: "cache-01", Application: "duplicate"},
+	}
+
+	if matched := Enrich(hosts, records); matched != 3 {
+		t.Errorf("matched = %d, want 3", matched)
+	}
+	if h := hosts[0]; h.Owner != "alice" || h.Application != "shop" || h.Location != "IDC-A/R01" || h.Maintenance {
+		t.Errorf("web-01 = %+v, want matched by hostname case-insensitively", h)
+	}
+	if h := hosts[1]; h.Owner != "bob" || !h.Maintenance {
+		t.Errorf("db-01 = %+v, want matched by IP", h)
+	}
+	if h := hosts[2]; h.Application != "cache" {
+		t.Errorf("cache-01 application = %q, want the first record", h.Application)
+	}
+	if h := hosts[3]; h.Owner != "" || h.Application != "" {
+		t.Errorf("unknown = %+v, want no CMDB attributes", h)
+	}
+}
+
+func TestNew(t *testing.T) {
+	source, err := New(&config.CMDBConfig{}, nil, zerolog.Nop())
+	if source != nil || err != nil {
+		t.Errorf("New() without endpoint = %v, %v; want nil, nil", source, err)
+	}
+
+	source, err = New(&config.CMDBConfig{Endpoint: "http://cmdb.local/api/hosts"}, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	if _, ok := source.(*HTTPSource); !ok {
+		t.Errorf("New() = %T, want *HTTPSource by default", source)
+	}
+
+	_, err = New(&config.CMDBConfig{Type: "missing", Endpoint: "http://cmdb.local"}, nil, zerolog.Nop())
+	if err == nil || !strings.Contains(err.Error(), "available: [http") {
+		t.Errorf("New() error = %v, want unknown type listing the available sources", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("static-test", func(cfg *config.CMDBConfig, _ *config.RetryConfig, _ zerolog.Logger) (Source, error) {
+		return staticSource{{Hostname: cfg.Params["host"], Owner: "ops"}}, nil
+	})
+
+	source, err := New(&config.CMDBConfig{Type: "static-test", Endpoint: "http://cmdb.local", Params: map[string]string{"host": "web-01"}}, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	records, err := source.Records(context.Background())
+	if err != nil || len(records) != 1 || records[0].Hostname != "web-01" {
+		t.Errorf("Records() = %v, %v; want the registered source records", records, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() should panic on a duplicate name")
+		}
+	}()
+	Register(TypeHTTP, nil)
+}
+
+type staticSource []model.CMDBRecord
+
+func (s staticSource) Records(context.Context) ([]model.CMDBRecord, error) {
+	return s, nil
+}
@@ -0,0 +1,229 @@
+package cmdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/auth"
+	"inspection-tool/internal/client/resilience"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// HTTPSource reads the host records from a JSON API: the endpoint returns an array of host
+// objects, or an object holding the array at the configured results path. The attributes are
+// read from the configured fields.
+type HTTPSource struct {
+	endpoint          string                  // Host list URL
+	resultsPath       string                  // Dotted path of the host array in the response
+	fields            config.CMDBFieldsConfig // Record fields of the host attributes
+	maintenanceValues map[string]bool         // Lowercased values meaning "in maintenance"
+	httpClient        *resty.Client           // HTTP client
+	logger            zerolog.Logger          // Logger
+}
+
+// NewHTTPSource creates the HTTP JSON CMDB source.
+func NewHTTPSource(cfg *config.CMDBConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) *HTTPSource {
+	// Set default timeout if not specified
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	// Set default retry config if not specified
+	retry := config.RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  1 * time.Second,
+	}
+	if retryCfg != nil {
+		retry = *retryCfg
+	}
+
+	// Create resty client
+	httpClient := resty.New().
+		SetTimeout(timeout).
+		SetHeader("Accept", "application/json").
+		SetRetryCount(retry.MaxRetries).
+		SetRetryWaitTime(retry.BaseDelay)
+
+	// Additional headers, e.g. required by an API gateway in front of the CMDB
+	if len(cfg.Headers) > 0 {
+		httpClient.SetHeaders(cfg.Headers)
+	}
+
+	// Authentication of the CMDB API
+	switch {
+	case cfg.BearerToken != "":
+		httpClient.SetAuthToken(cfg.BearerToken)
+	case cfg.Username != "":
+		httpClient.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	// Custom CA and client certificate (mTLS); the files were checked when loading the config
+	if tlsConfig, err := cfg.TLS.ClientConfig(); err != nil {
+		logger.Warn().Err(err).Msg("invalid TLS configuration, using the default TLS settings")
+	} else if tlsConfig != nil {
+		httpClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	// Proxy of the CMDB; without one, HTTP_PROXY / HTTPS_PROXY / NO_PROXY apply
+	if cfg.ProxyURL != "" {
+		httpClient.SetProxy(cfg.ProxyURL)
+	}
+
+	// Request signing by the auth plugin, e.g. the AK/SK signature of cloud providers
+	auth.Apply(httpClient, cfg.AuthPlugin, logger)
+
+	// Jittered backoff, retry budget and circuit breaker, wrapping the transport configured above
+	resilience.Apply(httpClient, retry, retryCondition, logger)
+
+	maintenanceValues := make(map[string]bool, len(cfg.MaintenanceValues))
+	for _, v := range cfg.MaintenanceValues {
+		maintenanceValues[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+
+	return &HTTPSource{
+		endpoint:          cfg.Endpoint,
+		resultsPath:       cfg.ResultsPath,
+		fields:            withDefaultFields(cfg.Fields),
+		maintenanceValues: maintenanceValues,
+		httpClient:        httpClient,
+		logger:            logger.With().Str("component", "cmdb-client").Logger(),
+	}
+}
+
+// withDefaultFields fills the unconfigured fields with the attribute names.
+func withDefaultFields(f config.CMDBFieldsConfig) config.CMDBFieldsConfig {
+	for _, field := range []struct {
+		value *string
+		name  string
+	}{
+		{&f.Hostname, "hostname"},
+		{&f.IP, "ip"},
+		{&f.Owner, "owner"},
+		{&f.Application, "application"},
+		{&f.Location, "location"},
+		{&f.Maintenance, "maintenance"},
+	} {
+		if *field.value == "" {
+			*field.value = field.name
+		}
+	}
+	return f
+}
+
+// retryCondition determines whether a request should be retried.
+// Only retry on timeout, 5xx errors, or connection failures.
+// Do not retry on 4xx errors.
+func retryCondition(resp *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.StatusCode() >= 500
+}
+
+// Records fetches the host list and maps each host object to a record. Objects without
+// hostname and IP are skipped.
+func (s *HTTPSource) Records(ctx context.Context) ([]model.CMDBRecord, error) {
+	s.logger.Debug().Str("endpoint", s.endpoint).Msg("fetching hosts from CMDB")
+
+	resp, err := s.httpClient.R().
+		SetContext(ctx).
+		Get(s.endpoint)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to fetch CMDB hosts")
+		return nil, fmt.Errorf("failed to fetch CMDB hosts: %w", err)
+	}
+
+	// Check HTTP status code
+	if resp.StatusCode() != http.StatusOK {
+		s.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Msg("CMDB API returned non-200 status")
+		return nil, fmt.Errorf("CMDB API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(resp.Body(), &body); err != nil {
+		return nil, fmt.Errorf("failed to parse CMDB response: %w", err)
+	}
+	items, ok := lookup(body, s.resultsPath).([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("CMDB response has no host array at %q", s.resultsPath)
+	}
+
+	records := make([]model.CMDBRecord, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		record := model.CMDBRecord{
+			Hostname:    stringValue(lookup(obj, s.fields.Hostname)),
+			IP:          stringValue(lookup(obj, s.fields.IP)),
+			Owner:       stringValue(lookup(obj, s.fields.Owner)),
+			Application: stringValue(lookup(obj, s.fields.Application)),
+			Location:    stringValue(lookup(obj, s.fields.Location)),
+			Maintenance: s.inMaintenance(lookup(obj, s.fields.Maintenance)),
+		}
+		if record.Hostname == "" && record.IP == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	s.logger.Debug().Int("count", len(records)).Msg("fetched hosts from CMDB")
+	return records, nil
+}
+
+// inMaintenance reports whether a maintenance field value means the host is in maintenance:
+// true, a non-zero number, or one of the configured maintenance values.
+func (s *HTTPSource) inMaintenance(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return s.maintenanceValues[strings.ToLower(strings.TrimSpace(v))]
+	}
+	return false
+}
+
+// lookup returns the value at a dotted path of a decoded JSON value, or nil when missing. An
+// empty path returns v itself.
+func lookup(v interface{}, path string) interface{} {
+	if path == "" {
+		return v
+	}
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+	return v
+}
+
+// stringValue formats a decoded JSON scalar; objects, arrays and null give "".
+func stringValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package cmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+func TestHTTPSource_Records(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if r.URL.Query().Get("env") != "prod" {
+			t.Errorf("query = %q, want the endpoint query kept", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code": 0, "data": {"items": [
+			{"name": "web-01", "ip": "10.0.0.1", "owner": {"name": "alice"}, "app": "shop", "rack": "IDC-A/R01", "status": "Maintenance"},
+			{"name": "db-01", "ip": "10.0.0.2", "owner": {"name": "bob"}, "app": "order", "rack": 12, "status": "online"},
+			{"ip": "10.0.0.3", "in_maintenance": true},
+			{"owner": {"name": "nobody"}},
+			"not an object"
+		]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.CMDBConfig{
+		Endpoint:    server.URL + "/api/hosts?env=prod",
+		Timeout:     5 * time.Second,
+		BearerToken: "token",
+		ResultsPath: "data.items",
+		Fields: config.CMDBFieldsConfig{
+			Hostname:    "name",
+			Owner:       "owner.name",
+			Application: "app",
+			Location:    "rack",
+			Maintenance: "status",
+		},
+		MaintenanceValues: []string{"maintenance"},
+	}
+	records, err := NewHTTPSource(cfg, &config.RetryConfig{MaxRetries: 0}, zerolog.Nop()).Records(context.Background())
+	if err != nil {
+		t.Fatalf("Records() unexpected error: %v", err)
+	}
+
+	if authorization != "Bearer token" {
+		t.Errorf("Authorization = %q, want Bearer token", authorization)
+	}
+	if len(records) != 3 {
+		t.Fatalf("len(records) = %d, want 3 (objects without hostname and IP skipped): %+v", len(records), records)
+	}
+	if r := records[0]; r.Hostname != "web-01" || r.IP != "10.0.0.1" || r.Owner != "alice" || r.Application != "shop" || r.Location != "IDC-A/R01" || !r.Maintenance {
+		t.Errorf("records[0] = %+v", r)
+	}
+	if r := records[1]; r.Location != "12" || r.Maintenance {
+		t.Errorf("records[1] = %+v, want numeric location formatted and not in maintenance", r)
+	}
+	// The maintenance field is "status"; in_maintenance is not read
+	if r := records[2]; r.IP != "10.0.0.3" || r.Maintenance {
+		t.Errorf("records[2] = %+v", r)
+	}
+}
+
+func TestHTTPSource_DefaultFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"hostname": "web-01", "owner": "alice", "maintenance": 1}]`))
+	}))
+	defer server.Close()
+
+	records, err := NewHTTPSource(&config.CMDBConfig{Endpoint: server.URL}, nil, zerolog.Nop()).Records(context.Background())
+	if err != nil {
+		t.Fatalf("Records() unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Owner != "alice" || !records[0].Maintenance {
+		t.Errorf("records = %+v, want the default fields read", records)
+	}
+}
+
+func TestHTTPSource_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		path   string
+	}{
+		{"non-200 status", http.StatusForbidden, `{"error": "forbidden"}`, ""},
+		{"invalid JSON", http.StatusOK, `not json`, ""},
+		{"no host array", http.StatusOK, `{"data": {"total": 0}}`, "data.items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := &config.CMDBConfig{Endpoint: server.URL, ResultsPath: tt.path}
+			if _, err := NewHTTPSource(cfg, &config.RetryConfig{MaxRetries: 0}, zerolog.Nop()).Records(context.Background()); err == nil {
+				t.Error("Records() should return an error")
+			}
+		})
+	}
+}
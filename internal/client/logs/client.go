@@ -0,0 +1,324 @@
+// Package logs provides a client for log query backends (Loki and VictoriaLogs).
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/auth"
+	"inspection-tool/internal/client/resilience"
+	"inspection-tool/internal/config"
+)
+
+// Client is a client for the Loki or VictoriaLogs query API.
+type Client struct {
+	backend    string             // Backend type (loki or victorialogs)
+	endpoint   string             // API endpoint
+	timeout    time.Duration      // Request timeout
+	retry      config.RetryConfig // Retry configuration
+	httpClient *resty.Client      // HTTP client
+	logger     zerolog.Logger     // Logger
+}
+
+// NewClient creates a new log query client.
+// The backend defaults to Loki when cfg.Type is empty.
+func NewClient(cfg *config.LogsConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) *Client {
+	backend := cfg.Type
+	if backend == "" {
+		backend = config.LogsTypeLoki
+	}
+
+	// Set default timeout if not specified
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	// Set default retry config if not specified
+	retry := config.RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  1 * time.Second,
+	}
+	if retryCfg != nil {
+		retry = *retryCfg
+	}
+
+	// Create resty client
+	httpClient := resty.New().
+		SetBaseURL(cfg.Endpoint).
+		SetTimeout(timeout).
+		SetRetryCount(retry.MaxRetries).
+		SetRetryWaitTime(retry.BaseDelay)
+
+	// Additional headers, e.g. required by an API gateway in front of the datasource
+	if len(cfg.Headers) > 0 {
+		httpClient.SetHeaders(cfg.Headers)
+	}
+
+	// Custom CA and client certificate (mTLS); the files were checked when loading the config
+	if tlsConfig, err := cfg.TLS.ClientConfig(); err != nil {
+		logger.Warn().Err(err).Msg("invalid TLS configuration, using the default TLS settings")
+	} else if tlsConfig != nil {
+		httpClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	// Proxy of the datasource; without one, HTTP_PROXY / HTTPS_PROXY / NO_PROXY apply
+	if cfg.ProxyURL != "" {
+		httpClient.SetProxy(cfg.ProxyURL)
+	}
+
+	// Request signing by the auth plugin, e.g. the AK/SK signature of cloud providers
+	auth.Apply(httpClient, cfg.AuthPlugin, logger)
+
+	// Jittered backoff, retry budget and circuit breaker, wrapping the transport configured above
+	resilience.Apply(httpClient, retry, retryCondition, logger)
+
+	return &Client{
+		backend:    backend,
+		endpoint:   cfg.Endpoint,
+		timeout:    timeout,
+		retry:      retry,
+		httpClient: httpClient,
+		logger:     logger.With().Str("component", "logs-client").Str("backend", backend).Logger(),
+	}
+}
+
+// retryCondition determines whether a request should be retried.
+// Only retry on timeout, 5xx errors, or connection failures.
+// Do not retry on 4xx errors.
+func retryCondition(resp *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.StatusCode() >= 500
+}
+
+// QueryLines returns up to limit most recent log lines matching query within [start, end],
+// in chronological order.
+func (c *Client) QueryLines(ctx context.Context, query string, limit int, start, end time.Time) ([]LogLine, error) {
+	c.logger.Debug().
+		Str("query", query).
+		Int("limit", limit).
+		Time("start", start).
+		Time("end", end).
+		Msg("executing log query")
+
+	var (
+		lines []LogLine
+		err   error
+	)
+	switch c.backend {
+	case config.LogsTypeVictoriaLogs:
+		lines, err = c.queryVictoriaLogs(ctx, query, limit, start, end)
+	default:
+		lines, err = c.queryLoki(ctx, query, limit, start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lines = LatestLines(lines, limit)
+
+	c.logger.Debug().
+		Int("line_count", len(lines)).
+		Msg("log query executed successfully")
+
+	return lines, nil
+}
+
+// QueryCounts returns the number of log entries matching query within the window ending at end.
+// Counts are grouped by the groupBy label/field; an empty groupBy returns a single total.
+// The query must be a log selector/filter (LogQL stream selector with line filters, or a
+// LogsQL filter) without aggregation, since the count aggregation is added here.
+func (c *Client) QueryCounts(ctx context.Context, query, groupBy string, window time.Duration, end time.Time) ([]LogCount, error) {
+	c.logger.Debug().
+		Str("query", query).
+		Str("group_by", groupBy).
+		Dur("window", window).
+		Time("end", end).
+		Msg("executing log count query")
+
+	var (
+		counts []LogCount
+		err    error
+	)
+	switch c.backend {
+	case config.LogsTypeVictoriaLogs:
+		counts, err = c.countVictoriaLogs(ctx, query, groupBy, window, end)
+	default:
+		counts, err = c.countLoki(ctx, query, groupBy, window, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Debug().
+		Int("group_count", len(counts)).
+		Msg("log count query executed successfully")
+
+	return counts, nil
+}
+
+// BuildLokiCountQuery wraps a LogQL log selector into a count_over_time metric query.
+func BuildLokiCountQuery(query, groupBy string, window time.Duration) string {
+	inner := fmt.Sprintf("count_over_time(%s [%ds])", query, int64(window.Seconds()))
+	if groupBy == "" {
+		return fmt.Sprintf("sum(%s)", inner)
+	}
+	return fmt.Sprintf("sum by (%s) (%s)", groupBy, inner)
+}
+
+// BuildVictoriaLogsCountQuery wraps a LogsQL filter into a stats count query over the window.
+func BuildVictoriaLogsCountQuery(query, groupBy string, window time.Duration) string {
+	filter := fmt.Sprintf("_time:%ds (%s)", int64(window.Seconds()), query)
+	if groupBy == "" {
+		return filter + " | stats count() as hits"
+	}
+	return fmt.Sprintf("%s | stats by (%s) count() as hits", filter, groupBy)
+}
+
+// countLoki executes a count query against Loki /loki/api/v1/query.
+func (c *Client) countLoki(ctx context.Context, query, groupBy string, window time.Duration, end time.Time) ([]LogCount, error) {
+	return c.statsQuery(ctx, "Loki", "/loki/api/v1/query", map[string]string{
+		"query": BuildLokiCountQuery(query, groupBy, window),
+		"time":  strconv.FormatInt(end.UnixNano(), 10),
+	})
+}
+
+// countVictoriaLogs executes a count query against VictoriaLogs /select/logsql/stats_query.
+func (c *Client) countVictoriaLogs(ctx context.Context, query, groupBy string, window time.Duration, end time.Time) ([]LogCount, error) {
+	return c.statsQuery(ctx, "VictoriaLogs", "/select/logsql/stats_query", map[string]string{
+		"query": BuildVictoriaLogsCountQuery(query, groupBy, window),
+		"time":  end.UTC().Format(time.RFC3339),
+	})
+}
+
+// statsQuery executes an instant stats query and parses the vector result.
+func (c *Client) statsQuery(ctx context.Context, name, path string, params map[string]string) ([]LogCount, error) {
+	var result StatsQueryResponse
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(&result).
+		Get(path)
+
+	if err != nil {
+		c.logger.Error().Err(err).Str("query", params["query"]).Msgf("failed to execute %s count query", name)
+		return nil, fmt.Errorf("failed to execute %s count query: %w", name, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Str("query", params["query"]).
+			Msgf("%s API returned non-200 status", name)
+		return nil, fmt.Errorf("%s API returned status %d: %s", name, resp.StatusCode(), string(resp.Body()))
+	}
+
+	if !result.IsSuccess() {
+		return nil, fmt.Errorf("%s API error: %s", name, result.Error)
+	}
+
+	return ParseStatsResponse(&result)
+}
+
+// queryLoki executes a log query against Loki /loki/api/v1/query_range.
+func (c *Client) queryLoki(ctx context.Context, query string, limit int, start, end time.Time) ([]LogLine, error) {
+	var result LokiQueryResponse
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"query":     query,
+			"limit":     strconv.Itoa(limit),
+			"start":     strconv.FormatInt(start.UnixNano(), 10),
+			"end":       strconv.FormatInt(end.UnixNano(), 10),
+			"direction": "backward",
+		}).
+		SetResult(&result).
+		Get("/loki/api/v1/query_range")
+
+	if err != nil {
+		c.logger.Error().Err(err).Str("query", query).Msg("failed to execute Loki query")
+		return nil, fmt.Errorf("failed to execute Loki query: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Str("query", query).
+			Msg("Loki API returned non-200 status")
+		return nil, fmt.Errorf("Loki API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	if !result.IsSuccess() {
+		return nil, fmt.Errorf("Loki API error: %s", result.Error)
+	}
+
+	return ParseLokiResponse(&result)
+}
+
+// queryVictoriaLogs executes a log query against VictoriaLogs /select/logsql/query.
+// The response is a stream of JSON lines, one log entry per line.
+func (c *Client) queryVictoriaLogs(ctx context.Context, query string, limit int, start, end time.Time) ([]LogLine, error) {
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"query": query,
+			"limit": strconv.Itoa(limit),
+			"start": start.UTC().Format(time.RFC3339),
+			"end":   end.UTC().Format(time.RFC3339),
+		}).
+		Get("/select/logsql/query")
+
+	if err != nil {
+		c.logger.Error().Err(err).Str("query", query).Msg("failed to execute VictoriaLogs query")
+		return nil, fmt.Errorf("failed to execute VictoriaLogs query: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Str("query", query).
+			Msg("VictoriaLogs API returned non-200 status")
+		return nil, fmt.Errorf("VictoriaLogs API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	var lines []LogLine
+	scanner := bufio.NewScanner(bytes.NewReader(resp.Body()))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var entry VictoriaLogsEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse VictoriaLogs entry: %w", err)
+		}
+		line, err := ParseVictoriaLogsEntry(&entry)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read VictoriaLogs response: %w", err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,215 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+// testLogger creates a disabled logger for testing
+func testLogger() zerolog.Logger {
+	return zerolog.New(nil).Level(zerolog.Disabled)
+}
+
+// writeJSON writes a JSON response with proper headers
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestNewClient_Defaults(t *testing.T) {
+	client := NewClient(&config.LogsConfig{Endpoint: "http://localhost:3100"}, nil, testLogger())
+
+	if client.backend != config.LogsTypeLoki {
+		t.Errorf("expected default backend loki, got %s", client.backend)
+	}
+	if client.timeout != 30*time.Second {
+		t.Errorf("expected default timeout 30s, got %s", client.timeout)
+	}
+	if client.retry.MaxRetries != 3 {
+		t.Errorf("expected default max retries 3, got %d", client.retry.MaxRetries)
+	}
+}
+
+func TestClient_QueryLines_Loki(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/query_range" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("query"); got != `{host="web-01"} |= "error"` {
+			t.Errorf("unexpected query: %s", got)
+		}
+		if got := r.URL.Query().Get("direction"); got != "backward" {
+			t.Errorf("expected direction=backward, got %s", got)
+		}
+		writeJSON(w, map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{
+				"resultType": "streams",
+				"result": []map[string]interface{}{
+					{
+						"stream": map[string]string{"host": "web-01", "filename": "/var/log/a.log"},
+						"values": [][2]string{
+							{"1700000003000000000", "third"},
+							{"1700000001000000000", "first"},
+						},
+					},
+					{
+						"stream": map[string]string{"host": "web-01", "filename": "/var/log/b.log"},
+						"values": [][2]string{{"1700000002000000000", "second"}},
+					},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.LogsConfig{Type: config.LogsTypeLoki, Endpoint: server.URL}, &config.RetryConfig{}, testLogger())
+	end := time.Unix(1700000010, 0)
+	lines, err := client.QueryLines(context.Background(), `{host="web-01"} |= "error"`, 2, end.Add(-time.Hour), end)
+	if err != nil {
+		t.Fatalf("QueryLines failed: %v", err)
+	}
+
+	// Only the two most recent lines, oldest first
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if lines[0].Line != "second" || lines[1].Line != "third" {
+		t.Errorf("unexpected lines order: %q, %q", lines[0].Line, lines[1].Line)
+	}
+}
+
+func TestClient_QueryLines_VictoriaLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/select/logsql/query" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("limit"); got != "10" {
+			t.Errorf("expected limit=10, got %s", got)
+		}
+		w.Write([]byte(`{"_time":"2024-01-01T00:00:02Z","_msg":"second","host":"web-01"}
+{"_time":"2024-01-01T00:00:01Z","_msg":"first","host":"web-01"}
+
+`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.LogsConfig{Type: config.LogsTypeVictoriaLogs, Endpoint: server.URL}, &config.RetryConfig{}, testLogger())
+	end := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	lines, err := client.QueryLines(context.Background(), `host:web-01 error`, 10, end.Add(-time.Hour), end)
+	if err != nil {
+		t.Fatalf("QueryLines failed: %v", err)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if lines[0].Line != "first" || lines[1].Line != "second" {
+		t.Errorf("unexpected lines order: %q, %q", lines[0].Line, lines[1].Line)
+	}
+	if got := lines[0].String(); got != "2024-01-01 00:00:01 first" {
+		t.Errorf("unexpected formatted line: %s", got)
+	}
+}
+
+func TestClient_QueryLines_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("parse error"))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.LogsConfig{Endpoint: server.URL}, &config.RetryConfig{}, testLogger())
+	_, err := client.QueryLines(context.Background(), "{", 10, time.Now().Add(-time.Hour), time.Now())
+	if err == nil {
+		t.Fatal("expected error for 400 response")
+	}
+}
+
+func TestClient_QueryCounts_Loki(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/query" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("query"); got != `sum by (app) (count_over_time({env="prod"} |= "ERROR" [3600s]))` {
+			t.Errorf("unexpected query: %s", got)
+		}
+		writeJSON(w, map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{
+				"resultType": "vector",
+				"result": []map[string]interface{}{
+					{"metric": map[string]string{"app": "order"}, "value": []interface{}{1700000000, "42"}},
+					{"metric": map[string]string{"app": "user"}, "value": []interface{}{1700000000, "3"}},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.LogsConfig{Type: config.LogsTypeLoki, Endpoint: server.URL}, &config.RetryConfig{}, testLogger())
+	counts, err := client.QueryCounts(context.Background(), `{env="prod"} |= "ERROR"`, "app", time.Hour, time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatalf("QueryCounts failed: %v", err)
+	}
+
+	if len(counts) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(counts))
+	}
+	if counts[0].Labels["app"] != "order" || counts[0].Count != 42 {
+		t.Errorf("unexpected first group: %+v", counts[0])
+	}
+}
+
+func TestClient_QueryCounts_VictoriaLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/select/logsql/stats_query" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("query"); got != `_time:900s (error) | stats count() as hits` {
+			t.Errorf("unexpected query: %s", got)
+		}
+		writeJSON(w, map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{
+				"resultType": "vector",
+				"result": []map[string]interface{}{
+					{"metric": map[string]string{"__name__": "hits"}, "value": []interface{}{1700000000, "7"}},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.LogsConfig{Type: config.LogsTypeVictoriaLogs, Endpoint: server.URL}, &config.RetryConfig{}, testLogger())
+	counts, err := client.QueryCounts(context.Background(), "error", "", 15*time.Minute, time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatalf("QueryCounts failed: %v", err)
+	}
+
+	if len(counts) != 1 || counts[0].Count != 7 {
+		t.Fatalf("expected a single count of 7, got %+v", counts)
+	}
+	if len(counts[0].Labels) != 0 {
+		t.Errorf("expected __name__ label to be dropped, got %v", counts[0].Labels)
+	}
+}
+
+func TestLatestLines_NoLimit(t *testing.T) {
+	lines := []LogLine{
+		{Timestamp: time.Unix(2, 0), Line: "b"},
+		{Timestamp: time.Unix(1, 0), Line: "a"},
+	}
+	got := LatestLines(lines, 0)
+	if len(got) != 2 || got[0].Line != "a" {
+		t.Errorf("expected all lines sorted, got %+v", got)
+	}
+}
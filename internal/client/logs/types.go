@@ -0,0 +1,157 @@
+// Package logs provides a client for log query backends (Loki and VictoriaLogs).
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LogLine represents a single log line returned by the log backend.
+type LogLine struct {
+	Timestamp time.Time // 日志时间
+	Line      string    // 日志内容
+}
+
+// String formats the log line as "<timestamp> <line>" for display.
+func (l LogLine) String() string {
+	return fmt.Sprintf("%s %s", l.Timestamp.Format("2006-01-02 15:04:05"), l.Line)
+}
+
+// LokiQueryResponse represents the API response from Loki /loki/api/v1/query_range.
+type LokiQueryResponse struct {
+	Status string   `json:"status"` // 响应状态：success 或 error
+	Data   LokiData `json:"data"`   // 查询数据
+	Error  string   `json:"error"`  // 错误信息（仅在 status=error 时存在）
+}
+
+// IsSuccess returns true if the query was successful.
+func (r *LokiQueryResponse) IsSuccess() bool {
+	return r.Status == "success"
+}
+
+// LokiData contains the result data from a Loki log query.
+type LokiData struct {
+	ResultType string       `json:"resultType"` // 结果类型：streams
+	Result     []LokiStream `json:"result"`     // 日志流列表
+}
+
+// LokiStream represents a single log stream with its entries.
+// Each value is a [timestamp_ns, line] pair.
+type LokiStream struct {
+	Stream map[string]string `json:"stream"` // 流标签
+	Values [][2]string       `json:"values"` // 日志条目 [纳秒时间戳, 日志内容]
+}
+
+// VictoriaLogsEntry represents a single JSON line returned by VictoriaLogs /select/logsql/query.
+type VictoriaLogsEntry struct {
+	Time string `json:"_time"` // RFC3339 时间戳
+	Msg  string `json:"_msg"`  // 日志内容
+}
+
+// LogCount represents the number of log entries matching a query for one group.
+type LogCount struct {
+	Labels map[string]string // 分组字段值（未分组时为空）
+	Count  float64           // 匹配的日志条数
+}
+
+// StatsQueryResponse represents the Prometheus-compatible instant vector response returned by
+// Loki /loki/api/v1/query (metric queries) and VictoriaLogs /select/logsql/stats_query.
+type StatsQueryResponse struct {
+	Status string    `json:"status"` // 响应状态：success 或 error
+	Data   StatsData `json:"data"`   // 查询数据
+	Error  string    `json:"error"`  // 错误信息（仅在 status=error 时存在）
+}
+
+// IsSuccess returns true if the query was successful.
+func (r *StatsQueryResponse) IsSuccess() bool {
+	return r.Status == "success"
+}
+
+// StatsData contains the result data from a stats query.
+type StatsData struct {
+	ResultType string        `json:"resultType"` // 结果类型：vector
+	Result     []StatsSample `json:"result"`     // 样本列表
+}
+
+// StatsSample represents a single sample of an instant vector.
+// Value is a [timestamp, "value"] pair.
+type StatsSample struct {
+	Metric map[string]string `json:"metric"` // 分组标签
+	Value  []interface{}     `json:"value"`  // [时间戳, 值字符串]
+}
+
+// ParseStatsResponse converts a stats query response into log counts.
+// The "__name__" label added by VictoriaLogs is dropped.
+func ParseStatsResponse(resp *StatsQueryResponse) ([]LogCount, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("response is nil")
+	}
+
+	counts := make([]LogCount, 0, len(resp.Data.Result))
+	for _, sample := range resp.Data.Result {
+		if len(sample.Value) != 2 {
+			return nil, fmt.Errorf("invalid sample value: %v", sample.Value)
+		}
+		str, ok := sample.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid sample value type: %T", sample.Value[1])
+		}
+		count, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample value %q: %w", str, err)
+		}
+
+		labels := make(map[string]string, len(sample.Metric))
+		for k, v := range sample.Metric {
+			if k != "__name__" {
+				labels[k] = v
+			}
+		}
+		counts = append(counts, LogCount{Labels: labels, Count: count})
+	}
+
+	return counts, nil
+}
+
+// ParseLokiResponse flattens all streams of a Loki response into log lines.
+func ParseLokiResponse(resp *LokiQueryResponse) ([]LogLine, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("response is nil")
+	}
+
+	var lines []LogLine
+	for _, stream := range resp.Data.Result {
+		for _, v := range stream.Values {
+			ns, err := strconv.ParseInt(v[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Loki timestamp %q: %w", v[0], err)
+			}
+			lines = append(lines, LogLine{Timestamp: time.Unix(0, ns), Line: v[1]})
+		}
+	}
+
+	return lines, nil
+}
+
+// ParseVictoriaLogsEntry converts a VictoriaLogs entry into a log line.
+func ParseVictoriaLogsEntry(entry *VictoriaLogsEntry) (LogLine, error) {
+	ts, err := time.Parse(time.RFC3339Nano, entry.Time)
+	if err != nil {
+		return LogLine{}, fmt.Errorf("invalid VictoriaLogs timestamp %q: %w", entry.Time, err)
+	}
+	return LogLine{Timestamp: ts, Line: entry.Msg}, nil
+}
+
+// LatestLines sorts lines chronologically and keeps only the most recent limit lines.
+// A limit of 0 or less keeps all lines.
+func LatestLines(lines []LogLine, limit int) []LogLine {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Timestamp.Before(lines[j].Timestamp)
+	})
+	if limit > 0 && len(lines) > limit {
+		lines = lines[len(lines)-limit:]
+	}
+	return lines
+}
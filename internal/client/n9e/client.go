@@ -10,6 +10,8 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/rs/zerolog"
 
+	"inspection-tool/internal/client/auth"
+	"inspection-tool/internal/client/resilience"
 	"inspection-tool/internal/config"
 	"inspection-tool/internal/model"
 )
@@ -49,9 +51,38 @@ func NewClient(cfg *config.N9EConfig, retryCfg *config.RetryConfig, logger zerol
 		SetHeader("X-User-Token", cfg.Token).
 		SetHeader("Content-Type", "application/json").
 		SetRetryCount(retry.MaxRetries).
-		SetRetryWaitTime(retry.BaseDelay).
-		SetRetryMaxWaitTime(retry.BaseDelay * 8). // Max wait time for exponential backoff
-		AddRetryCondition(retryCondition)
+		SetRetryWaitTime(retry.BaseDelay)
+
+	// Additional headers, e.g. required by an API gateway in front of the datasource
+	if len(cfg.Headers) > 0 {
+		httpClient.SetHeaders(cfg.Headers)
+	}
+
+	// Authentication of a reverse proxy in front of N9E
+	switch {
+	case cfg.BearerToken != "":
+		httpClient.SetAuthToken(cfg.BearerToken)
+	case cfg.Username != "":
+		httpClient.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	// Custom CA and client certificate (mTLS); the files were checked when loading the config
+	if tlsConfig, err := cfg.TLS.ClientConfig(); err != nil {
+		logger.Warn().Err(err).Msg("invalid TLS configuration, using the default TLS settings")
+	} else if tlsConfig != nil {
+		httpClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	// Proxy of the datasource; without one, HTTP_PROXY / HTTPS_PROXY / NO_PROXY apply
+	if cfg.ProxyURL != "" {
+		httpClient.SetProxy(cfg.ProxyURL)
+	}
+
+	// Request signing by the auth plugin, e.g. the AK/SK signature of cloud providers
+	auth.Apply(httpClient, cfg.AuthPlugin, logger)
+
+	// Jittered backoff, retry budget and circuit breaker, wrapping the transport configured above
+	resilience.Apply(httpClient, retry, retryCondition, logger)
 
 	return &Client{
 		endpoint:   cfg.Endpoint,
@@ -170,6 +201,95 @@ func (c *Client) GetTarget(ctx context.Context, ident string) (*TargetData, erro
 	return &result.Dat, nil
 }
 
+// GetBusinessGroups retrieves all business groups from the N9E API. The hierarchy is encoded
+// in the group names ("电商/支付/网关").
+func (c *Client) GetBusinessGroups(ctx context.Context) ([]model.BusinessGroup, error) {
+	c.logger.Debug().Msg("fetching business groups from N9E")
+
+	var result BusiGroupsResponse
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetQueryParams(map[string]string{
+			"limit": "10000", // Large limit to get all groups
+			"all":   "true",  // All groups, not only those of the token user
+		}).
+		Get("/api/n9e/busi-groups")
+
+	if err != nil {
+		c.logger.Error().Err(err).Msg("failed to fetch business groups")
+		return nil, fmt.Errorf("failed to fetch business groups: %w", err)
+	}
+
+	// Check HTTP status code
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Msg("N9E API returned non-200 status")
+		return nil, fmt.Errorf("N9E API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Check N9E API error field
+	if result.Err != "" {
+		c.logger.Error().Str("api_error", result.Err).Msg("N9E API returned error")
+		return nil, fmt.Errorf("N9E API error: %s", result.Err)
+	}
+
+	groups := make([]model.BusinessGroup, 0, len(result.Dat))
+	for _, g := range result.Dat {
+		group := model.BusinessGroup{ID: g.ID, Name: g.Name}
+		if g.LabelEnable == 1 {
+			group.Label = g.LabelValue
+		}
+		groups = append(groups, group)
+	}
+
+	c.logger.Info().Int("count", len(groups)).Msg("fetched business groups successfully")
+	return groups, nil
+}
+
+// GetActiveAlerts retrieves the firing alert events from the N9E API.
+// All events are fetched with a large limit; the caller matches them to hosts.
+func (c *Client) GetActiveAlerts(ctx context.Context) ([]AlertEventData, error) {
+	c.logger.Debug().Msg("fetching active alerts from N9E")
+
+	var result AlertEventsResponse
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetQueryParams(map[string]string{
+			"limit": "10000", // Large limit to get all events
+			"p":     "1",
+		}).
+		Get("/api/n9e/alert-cur-events/list")
+
+	if err != nil {
+		c.logger.Error().Err(err).Msg("failed to fetch active alerts")
+		return nil, fmt.Errorf("failed to fetch active alerts: %w", err)
+	}
+
+	// Check HTTP status code
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Msg("N9E API returned non-200 status")
+		return nil, fmt.Errorf("N9E API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Check N9E API error field
+	if result.Err != "" {
+		c.logger.Error().Str("api_error", result.Err).Msg("N9E API returned error")
+		return nil, fmt.Errorf("N9E API error: %s", result.Err)
+	}
+
+	c.logger.Info().Int("count", len(result.Dat.List)).Int("total", result.Dat.Total).Msg("fetched active alerts successfully")
+	return result.Dat.List, nil
+}
+
 // GetHostMetas retrieves all hosts and converts them to HostMeta models.
 // This is a convenience method that combines GetTargets and ToHostMeta conversion.
 func (c *Client) GetHostMetas(ctx context.Context) ([]*model.HostMeta, error) {
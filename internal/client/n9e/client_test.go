@@ -114,6 +114,57 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestGetTargets_BasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "inspector" || password != "secret" {
+			t.Errorf("Expected basic auth inspector/secret, got %q/%q (%v)", user, password, ok)
+		}
+		if token := r.Header.Get("X-User-Token"); token != "test-token" {
+			t.Errorf("Expected token 'test-token', got '%s'", token)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"dat": {"list": [], "total": 0}, "err": ""}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.N9EConfig{
+		Endpoint: server.URL,
+		Token:    "test-token",
+		Username: "inspector",
+		Password: "secret",
+		Timeout:  5 * time.Second,
+	}
+	client := NewClient(cfg, &config.RetryConfig{MaxRetries: 0, BaseDelay: 10 * time.Millisecond}, zerolog.Nop())
+	if _, err := client.GetTargets(context.Background()); err != nil {
+		t.Fatalf("GetTargets failed: %v", err)
+	}
+}
+
+func TestGetTargets_Proxy(t *testing.T) {
+	var host string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"dat": {"list": [], "total": 0}, "err": ""}`))
+	}))
+	defer proxy.Close()
+
+	cfg := &config.N9EConfig{
+		Endpoint: "http://n9e.internal.example:17000",
+		Token:    "test-token",
+		Timeout:  5 * time.Second,
+		ProxyURL: proxy.URL,
+	}
+	client := NewClient(cfg, &config.RetryConfig{MaxRetries: 0, BaseDelay: 10 * time.Millisecond}, zerolog.Nop())
+	if _, err := client.GetTargets(context.Background()); err != nil {
+		t.Fatalf("GetTargets through proxy failed: %v", err)
+	}
+	if host != "n9e.internal.example:17000" {
+		t.Errorf("Expected proxy to receive host 'n9e.internal.example:17000', got '%s'", host)
+	}
+}
+
 func TestGetTargets_Success(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		// Verify request path
@@ -257,6 +308,101 @@ func TestGetHostMetaByIdent_Success(t *testing.T) {
 	}
 }
 
+func TestGetBusinessGroups_Success(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/n9e/busi-groups" {
+			t.Errorf("Expected path '/api/n9e/busi-groups', got '%s'", r.URL.Path)
+		}
+		if r.URL.Query().Get("all") != "true" {
+			t.Errorf("Expected all=true, got '%s'", r.URL.Query().Get("all"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"dat": [
+				{"id": 1, "name": "电商/支付", "label_enable": 1, "label_value": "payment"},
+				{"id": 2, "name": "电商/订单", "label_enable": 0, "label_value": "order"}
+			],
+			"err": ""
+		}`))
+	}
+
+	server, client := setupTestServer(t, handler)
+	defer server.Close()
+
+	groups, err := client.GetBusinessGroups(context.Background())
+	if err != nil {
+		t.Fatalf("GetBusinessGroups failed: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].ID != 1 || groups[0].Name != "电商/支付" || groups[0].Label != "payment" {
+		t.Errorf("Unexpected first group: %+v", groups[0])
+	}
+	if groups[1].Label != "" {
+		t.Errorf("Label of a group without label_enable should be empty, got %q", groups[1].Label)
+	}
+}
+
+func TestGetActiveAlerts_Success(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/n9e/alert-cur-events/list" {
+			t.Errorf("Expected path '/api/n9e/alert-cur-events/list', got '%s'", r.URL.Path)
+		}
+		if r.URL.Query().Get("limit") != "10000" {
+			t.Errorf("Expected limit 10000, got '%s'", r.URL.Query().Get("limit"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"dat": {
+				"list": [
+					{"id": 11, "rule_name": "CPU使用率过高", "severity": 1, "target_ident": "host1@10.0.0.1",
+					 "trigger_time": 1790841600, "trigger_value": "95.2", "group_name": "默认业务组", "tags": ["ident=host1"]},
+					{"id": 12, "rule_name": "MySQL 连接数过高", "severity": 2, "target_ident": ""}
+				],
+				"total": 2
+			},
+			"err": ""
+		}`))
+	}
+
+	server, client := setupTestServer(t, handler)
+	defer server.Close()
+
+	events, err := client.GetActiveAlerts(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveAlerts failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	got := events[0]
+	if got.RuleName != "CPU使用率过高" || got.Severity != 1 || got.TargetIdent != "host1@10.0.0.1" ||
+		got.TriggerTime != 1790841600 || got.TriggerValue != "95.2" || len(got.Tags) != 1 {
+		t.Errorf("Unexpected event: %+v", got)
+	}
+}
+
+func TestGetActiveAlerts_APIError(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"dat": {"list": [], "total": 0}, "err": "forbidden"}`))
+	}
+
+	server, client := setupTestServer(t, handler)
+	defer server.Close()
+
+	_, err := client.GetActiveAlerts(context.Background())
+	if err == nil || !contains(err.Error(), "forbidden") {
+		t.Errorf("Expected API error, got %v", err)
+	}
+}
+
 // =============================================================================
 // Error Handling Tests
 // =============================================================================
@@ -4,6 +4,7 @@ package n9e
 import (
 	"encoding/json"
 	"strconv"
+	"time"
 
 	"inspection-tool/internal/model"
 )
@@ -27,6 +28,21 @@ type TargetListData struct {
 	Total int          `json:"total"` // 总数
 }
 
+// BusiGroupsResponse represents the API response from N9E /api/n9e/busi-groups endpoint.
+type BusiGroupsResponse struct {
+	Dat []BusiGroupData `json:"dat"` // 业务组列表
+	Err string          `json:"err"` // 错误信息
+}
+
+// BusiGroupData contains a business group from N9E API. The hierarchy is encoded in the
+// name, levels separated by "/".
+type BusiGroupData struct {
+	ID          int64  `json:"id"`           // 业务组 ID
+	Name        string `json:"name"`         // 业务组名称
+	LabelEnable int    `json:"label_enable"` // 是否作为标签附加到指标（1: 是）
+	LabelValue  string `json:"label_value"`  // 附加到指标的 busigroup 标签值
+}
+
 // TargetData contains target information from N9E API.
 // This structure matches the actual N9E API response format.
 type TargetData struct {
@@ -52,6 +68,31 @@ type TargetData struct {
 	ExtendInfo   string            `json:"extend_info"`   // 扩展信息（可选，部分 API 返回）
 }
 
+// AlertEventsResponse represents the API response from N9E /api/n9e/alert-cur-events/list endpoint.
+type AlertEventsResponse struct {
+	Dat AlertEventListData `json:"dat"` // 告警事件列表数据
+	Err string             `json:"err"` // 错误信息
+}
+
+// AlertEventListData wraps the alert event list with pagination info.
+type AlertEventListData struct {
+	List  []AlertEventData `json:"list"`  // 告警事件列表
+	Total int              `json:"total"` // 总数
+}
+
+// AlertEventData contains a firing (not yet recovered) alert event from N9E API.
+type AlertEventData struct {
+	ID           int64    `json:"id"`            // 事件 ID
+	RuleID       int64    `json:"rule_id"`       // 告警规则 ID
+	RuleName     string   `json:"rule_name"`     // 告警规则名称
+	Severity     int      `json:"severity"`      // 告警级别（1: 一级/严重，2: 二级/警告，3: 三级/提示）
+	GroupName    string   `json:"group_name"`    // 业务组名称
+	TargetIdent  string   `json:"target_ident"`  // 关联主机标识符（非主机类告警为空）
+	TriggerTime  int64    `json:"trigger_time"`  // 触发时间（Unix 秒）
+	TriggerValue string   `json:"trigger_value"` // 触发值
+	Tags         []string `json:"tags"`          // 标签列表（key=value）
+}
+
 // ExtendInfo contains detailed host information parsed from the extend_info JSON string.
 type ExtendInfo struct {
 	CPU        CPUInfo          `json:"cpu"`        // CPU 信息
@@ -224,6 +265,7 @@ func (t *TargetData) ToHostMeta() (*model.HostMeta, error) {
 		OS:         t.OS,
 		CPUCores:   t.CPUNum,
 		DiskMounts: []model.DiskMountInfo{},
+		GroupIDs:   t.GroupIDs,
 	}
 
 	// 如果有 ExtendInfo，尝试解析获取更详细的信息
@@ -266,3 +308,20 @@ func (t *TargetData) ToHostMeta() (*model.HostMeta, error) {
 
 	return hostMeta, nil
 }
+
+// ToActiveAlert converts the alert event to an ActiveAlert of the given host.
+func (e *AlertEventData) ToActiveAlert(hostname string) *model.ActiveAlert {
+	alert := &model.ActiveAlert{
+		Hostname:     hostname,
+		Ident:        e.TargetIdent,
+		RuleName:     e.RuleName,
+		Level:        model.N9ESeverityLevel(e.Severity),
+		TriggerValue: e.TriggerValue,
+		GroupName:    e.GroupName,
+		Tags:         e.Tags,
+	}
+	if e.TriggerTime > 0 {
+		alert.TriggerTime = time.Unix(e.TriggerTime, 0)
+	}
+	return alert
+}
@@ -0,0 +1,91 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+
+	"inspection-tool/internal/config"
+)
+
+// defaultOpenDuration is how long the circuit breaker stays open when not configured.
+const defaultOpenDuration = 30 * time.Second
+
+// breaker is a consecutive-failure circuit breaker. Closed, it lets every request through;
+// after threshold consecutive failures it opens and rejects requests for openDuration; then
+// it lets a single probe through, closing on success and opening again on failure.
+type breaker struct {
+	mu           sync.Mutex
+	threshold    int              // Consecutive failures opening the breaker, 0 when disabled
+	openDuration time.Duration    // How long the breaker stays open
+	failures     int              // Consecutive failures
+	openUntil    time.Time        // End of the open state, zero when closed
+	probing      bool             // A probe request is in flight after the open state
+	now          func() time.Time // Clock, replaced in tests
+}
+
+func newBreaker(cfg config.CircuitBreakerConfig) *breaker {
+	openDuration := cfg.OpenDuration
+	if openDuration <= 0 {
+		openDuration = defaultOpenDuration
+	}
+	return &breaker{threshold: cfg.FailureThreshold, openDuration: openDuration, now: time.Now}
+}
+
+// allow reports whether a request may be sent.
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+	if b.now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// success records a successful request, closing the breaker.
+func (b *breaker) success() {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.probing = false
+}
+
+// failure records a failed request and reports whether it opened the breaker.
+func (b *breaker) failure() bool {
+	if b.threshold <= 0 {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	wasOpen := !b.openUntil.IsZero()
+	if b.probing || b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.openDuration)
+		b.probing = false
+		return !wasOpen
+	}
+	return false
+}
+
+// release records a request without outcome (canceled by the caller), letting another probe
+// through.
+func (b *breaker) release() {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,48 @@
+package resilience
+
+import "sync"
+
+// budget caps the retries of a client relative to its traffic: a retry is allowed while the
+// retries stay below min plus ratio times the original requests. A failing datasource then
+// costs a bounded number of extra requests however many queries a run sends.
+type budget struct {
+	mu       sync.Mutex
+	ratio    float64 // Retries allowed per original request
+	min      int     // Retries always allowed
+	attempts int     // Requests sent, retries included
+	retries  int     // Retries granted
+}
+
+// newBudget creates the retry budget, or returns nil when both ratio and min are zero.
+func newBudget(ratio float64, min int) *budget {
+	if ratio <= 0 && min <= 0 {
+		return nil
+	}
+	return &budget{ratio: ratio, min: min}
+}
+
+// request records a request sent.
+func (b *budget) request() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.attempts++
+	b.mu.Unlock()
+}
+
+// allow reports whether one more retry fits the budget, and takes it when it does.
+func (b *budget) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	originals := b.attempts - b.retries
+	if float64(b.retries+1) > float64(b.min)+b.ratio*float64(originals) {
+		return false
+	}
+	b.retries++
+	return true
+}
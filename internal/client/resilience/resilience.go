@@ -0,0 +1,125 @@
+// Package resilience provides the retry policy of the datasource clients: jittered
+// exponential backoff, a retry budget and a circuit breaker, so a run against a datasource
+// that is down fails in seconds instead of retrying every query for minutes.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker is open.
+// It is never retried.
+var ErrCircuitOpen = errors.New("circuit breaker open: datasource failing, request not sent")
+
+// Apply installs the retry policy of cfg on httpClient. Requests for which retryable returns
+// true are retried within the retry budget, waiting with full-jitter exponential backoff,
+// and every attempt goes through the circuit breaker. It wraps the transport, so it must be
+// called after the TLS and proxy settings.
+func Apply(httpClient *resty.Client, cfg config.RetryConfig, retryable resty.RetryConditionFunc, logger zerolog.Logger) {
+	maxDelay := cfg.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = cfg.BaseDelay * 8
+	}
+
+	b := newBudget(cfg.BudgetRatio, cfg.BudgetMin)
+	cb := newBreaker(cfg.CircuitBreaker)
+	jitter := newJitter()
+
+	httpClient.
+		SetRetryMaxWaitTime(maxDelay).
+		SetRetryAfter(func(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+			attempt := 1
+			if resp != nil && resp.Request != nil && resp.Request.Attempt > 0 {
+				attempt = resp.Request.Attempt
+			}
+			return backoff(cfg.BaseDelay, maxDelay, attempt, jitter), nil
+		}).
+		AddRetryCondition(func(resp *resty.Response, err error) bool {
+			if errors.Is(err, ErrCircuitOpen) || !retryable(resp, err) {
+				return false
+			}
+			if !b.allow() {
+				logger.Warn().Msg("retry budget exhausted, not retrying")
+				return false
+			}
+			return true
+		})
+
+	base := httpClient.GetClient().Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.GetClient().Transport = &transport{base: base, budget: b, breaker: cb, logger: logger}
+}
+
+// backoff returns the wait before retry number attempt: a random duration up to
+// base × 2^(attempt-1), capped at maxDelay and never below base (full jitter), so clients
+// retrying after the same outage do not hit the datasource in lockstep.
+func backoff(base, maxDelay time.Duration, attempt int, jitter func(time.Duration) time.Duration) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	ceiling := base
+	for i := 1; i < attempt && ceiling < maxDelay; i++ {
+		ceiling *= 2
+	}
+	if ceiling > maxDelay {
+		ceiling = maxDelay
+	}
+	if ceiling <= base {
+		return base
+	}
+	return base + jitter(ceiling-base)
+}
+
+// newJitter returns a concurrency-safe random duration in [0, n].
+func newJitter() func(time.Duration) time.Duration {
+	var mu sync.Mutex
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return func(n time.Duration) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return time.Duration(r.Int63n(int64(n) + 1))
+	}
+}
+
+// transport counts the attempts for the retry budget and routes them through the circuit
+// breaker.
+type transport struct {
+	base    http.RoundTripper
+	budget  *budget
+	breaker *breaker
+	logger  zerolog.Logger
+}
+
+// RoundTrip sends req unless the circuit breaker is open, recording the outcome.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+	t.budget.request()
+
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil && (errors.Is(err, context.Canceled) || req.Context().Err() == context.Canceled):
+		// Canceled by the caller: says nothing about the datasource
+		t.breaker.release()
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		if t.breaker.failure() {
+			t.logger.Error().Str("host", req.URL.Host).Msg("datasource failing, circuit breaker opened")
+		}
+	default:
+		t.breaker.success()
+	}
+	return resp, err
+}
@@ -0,0 +1,185 @@
+package resilience
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+func TestBackoff(t *testing.T) {
+	maxJitter := func(n time.Duration) time.Duration { return n }
+	noJitter := func(time.Duration) time.Duration { return 0 }
+	base, maxDelay := 100*time.Millisecond, time.Second
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second}, // capped at max_delay
+		{20, time.Second},
+	}
+	for _, tt := range tests {
+		if got := backoff(base, maxDelay, tt.attempt, maxJitter); got != tt.max {
+			t.Errorf("backoff(attempt %d) upper bound = %v, want %v", tt.attempt, got, tt.max)
+		}
+		if got := backoff(base, maxDelay, tt.attempt, noJitter); got != base {
+			t.Errorf("backoff(attempt %d) lower bound = %v, want %v", tt.attempt, got, base)
+		}
+	}
+
+	jitter := newJitter()
+	for i := 0; i < 100; i++ {
+		if got := backoff(base, maxDelay, 4, jitter); got < base || got > 800*time.Millisecond {
+			t.Fatalf("backoff(attempt 4) = %v, want within [100ms, 800ms]", got)
+		}
+	}
+}
+
+func TestBudget(t *testing.T) {
+	if b := newBudget(0, 0); b != nil || !b.allow() {
+		t.Error("a zero budget should not limit retries")
+	}
+
+	b := newBudget(0.2, 1)
+	for i := 0; i < 10; i++ {
+		b.request()
+	}
+	// 1 + 0.2 × 10 original requests = 3 retries
+	for i := 0; i < 3; i++ {
+		b.request()
+		if !b.allow() {
+			t.Fatalf("retry %d denied, want allowed", i+1)
+		}
+	}
+	b.request()
+	if b.allow() {
+		t.Error("retry 4 allowed, want the budget exhausted")
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := newBreaker(config.CircuitBreakerConfig{FailureThreshold: 3, OpenDuration: 10 * time.Second})
+	b.now = func() time.Time { return now }
+
+	// A success resets the consecutive failures
+	b.failure()
+	b.failure()
+	b.success()
+	b.failure()
+	b.failure()
+	if !b.allow() {
+		t.Fatal("breaker open after 2 consecutive failures, want closed")
+	}
+	if !b.failure() {
+		t.Error("third consecutive failure did not open the breaker")
+	}
+	if b.allow() {
+		t.Error("open breaker allowed a request")
+	}
+
+	// After the open duration a single probe goes through
+	now = now.Add(11 * time.Second)
+	if !b.allow() {
+		t.Fatal("probe denied after the open duration")
+	}
+	if b.allow() {
+		t.Error("second request allowed while probing")
+	}
+	b.failure() // Failed probe: open again
+	if b.allow() {
+		t.Error("breaker closed after a failed probe")
+	}
+
+	now = now.Add(11 * time.Second)
+	if !b.allow() {
+		t.Fatal("probe denied after the second open duration")
+	}
+	b.success()
+	if !b.allow() || !b.allow() {
+		t.Error("breaker still open after a successful probe")
+	}
+
+	disabled := newBreaker(config.CircuitBreakerConfig{})
+	for i := 0; i < 10; i++ {
+		disabled.failure()
+	}
+	if !disabled.allow() {
+		t.Error("disabled breaker denied a request")
+	}
+}
+
+func TestApply_CircuitBreakerFailsFast(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := config.RetryConfig{
+		MaxRetries:     2,
+		BaseDelay:      time.Millisecond,
+		CircuitBreaker: config.CircuitBreakerConfig{FailureThreshold: 3, OpenDuration: time.Minute},
+	}
+	httpClient := resty.New().SetBaseURL(server.URL).SetRetryCount(cfg.MaxRetries).SetRetryWaitTime(cfg.BaseDelay)
+	Apply(httpClient, cfg, func(resp *resty.Response, err error) bool {
+		return err != nil || resp.StatusCode() >= 500
+	}, zerolog.Nop())
+
+	// The first query spends its attempts and opens the breaker
+	if resp, err := httpClient.R().Get("/api/v1/query"); err != nil || resp.StatusCode() != http.StatusServiceUnavailable {
+		t.Fatalf("first query = %v, %v; want the 503 response", resp, err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Fatalf("requests = %d, want 3 attempts", n)
+	}
+
+	// Later queries fail immediately without reaching the datasource or retrying
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := httpClient.R().Get("/api/v1/query"); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("query %d error = %v, want ErrCircuitOpen", i+2, err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("requests = %d, want no request while the breaker is open", n)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("open breaker took %v for 5 queries, want immediate failures", elapsed)
+	}
+}
+
+func TestApply_RetryBudget(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := config.RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond, BudgetMin: 2}
+	httpClient := resty.New().SetBaseURL(server.URL).SetRetryCount(cfg.MaxRetries).SetRetryWaitTime(cfg.BaseDelay)
+	Apply(httpClient, cfg, func(resp *resty.Response, err error) bool {
+		return err != nil || resp.StatusCode() >= 500
+	}, zerolog.Nop())
+
+	for i := 0; i < 3; i++ {
+		httpClient.R().Get("/")
+	}
+	// 3 original requests plus the 2 retries of the budget, instead of 3 × 4 attempts
+	if n := atomic.LoadInt32(&requests); n != 5 {
+		t.Errorf("requests = %d, want 5", n)
+	}
+}
@@ -0,0 +1,212 @@
+package ssh
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// check is a whitelisted command and the parser of its output. Commands run with the C
+// locale so their output does not depend on the language of the host.
+type check struct {
+	metrics []string                                                         // Metrics filled by the check
+	command func(passwordUser string) string                                 // Command run on the host
+	parse   func(output string, now time.Time) ([]*model.MetricValue, error) // Parser of the standard output
+}
+
+// checks are the only commands the fallback collector runs; none of them changes the host.
+var checks = map[string]check{
+	config.SSHCheckDF: {
+		metrics: []string{"disk_usage", "disk_total", "disk_free"},
+		command: func(string) string { return "LC_ALL=C df -PkT" },
+		parse:   parseDF,
+	},
+	config.SSHCheckUptime: {
+		metrics: []string{"uptime", "load_1m", "load_5m", "load_15m"},
+		command: func(string) string { return "cat /proc/uptime /proc/loadavg" },
+		parse:   parseUptime,
+	},
+	config.SSHCheckChronyc: {
+		metrics: []string{"ntp_check"},
+		command: func(string) string { return "LC_ALL=C chronyc -n tracking" },
+		parse:   parseChronyTracking,
+	},
+	config.SSHCheckPasswordExpiry: {
+		metrics: []string{"password_expiry"},
+		command: func(user string) string { return "LC_ALL=C chage -l " + shellQuote(user) },
+		parse:   parseChage,
+	},
+}
+
+// DefaultChecks are the checks run when none are configured.
+var DefaultChecks = []string{config.SSHCheckDF, config.SSHCheckUptime, config.SSHCheckChronyc, config.SSHCheckPasswordExpiry}
+
+// CheckMetrics returns the names of the metrics filled by a check, or nil for an unknown check.
+// Metrics expanded by label (disk_usage) are filled per mount point as "disk_usage:/path".
+func CheckMetrics(name string) []string {
+	return checks[name].metrics
+}
+
+// RunCheck runs a whitelisted check on the host and parses its output. passwordUser is the
+// user whose password expiry is checked; it must be a plain login name.
+func RunCheck(ctx context.Context, runner Runner, host, name, passwordUser string) ([]*model.MetricValue, error) {
+	c, ok := checks[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown SSH check %q", name)
+	}
+	output, err := runner.Run(ctx, host, c.command(passwordUser))
+	if err != nil {
+		return nil, err
+	}
+	values, err := c.parse(output, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s output: %w", name, err)
+	}
+	return values, nil
+}
+
+// parseDF parses `df -PkT`: one line per file system with device, type, 1K blocks, used,
+// available, capacity and mount point. Each mount point gives its usage (as df computes it,
+// used / (used + available)), total and free bytes, labelled with path, device and fstype.
+func parseDF(output string, _ time.Time) ([]*model.MetricValue, error) {
+	var values []*model.MetricValue
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 7 || fields[0] == "Filesystem" {
+			continue
+		}
+		total, err1 := strconv.ParseFloat(fields[2], 64)
+		used, err2 := strconv.ParseFloat(fields[3], 64)
+		avail, err3 := strconv.ParseFloat(fields[4], 64)
+		if err1 != nil || err2 != nil || err3 != nil || total == 0 {
+			continue
+		}
+		path := strings.Join(fields[6:], " ")
+		labels := map[string]string{"path": path, "device": fields[0], "fstype": fields[1]}
+
+		usage := 0.0
+		if used+avail > 0 {
+			usage = used / (used + avail) * 100
+		}
+		for _, v := range []struct {
+			name  string
+			value float64
+		}{
+			{"disk_usage", usage},
+			{"disk_total", total * 1024},
+			{"disk_free", avail * 1024},
+		} {
+			mv := model.NewMetricValue(v.name+":"+path, v.value)
+			mv.Labels = labels
+			values = append(values, mv)
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no file system found")
+	}
+	return values, nil
+}
+
+// parseUptime parses /proc/uptime ("seconds idle") followed by /proc/loadavg ("1m 5m 15m
+// running/total last-pid").
+func parseUptime(output string, _ time.Time) ([]*model.MetricValue, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("expected /proc/uptime and /proc/loadavg, got %d lines", len(lines))
+	}
+	uptime := strings.Fields(lines[0])
+	loadavg := strings.Fields(lines[1])
+	if len(uptime) < 1 || len(loadavg) < 3 {
+		return nil, fmt.Errorf("unexpected format %q", output)
+	}
+
+	var values []*model.MetricValue
+	for _, v := range []struct {
+		name, field string
+	}{
+		{"uptime", uptime[0]},
+		{"load_1m", loadavg[0]},
+		{"load_5m", loadavg[1]},
+		{"load_15m", loadavg[2]},
+	} {
+		value, err := strconv.ParseFloat(v.field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", v.name, v.field)
+		}
+		values = append(values, model.NewMetricValue(v.name, value))
+	}
+	return values, nil
+}
+
+// parseChronyTracking parses `chronyc tracking` into ntp_check, the offset of the system
+// clock from NTP time in milliseconds, e.g. "System time : 0.000012 seconds fast of NTP
+// time". An unsynchronised clock has no meaningful offset and is an error.
+func parseChronyTracking(output string, _ time.Time) ([]*model.MetricValue, error) {
+	fields := colonFields(output)
+	if status := fields["Leap status"]; strings.EqualFold(status, "Not synchronised") {
+		return nil, fmt.Errorf("clock not synchronised")
+	}
+	systemTime, ok := fields["System time"]
+	if !ok {
+		return nil, fmt.Errorf("no System time line")
+	}
+	offset := strings.Fields(systemTime)
+	if len(offset) == 0 {
+		return nil, fmt.Errorf("empty System time")
+	}
+	seconds, err := strconv.ParseFloat(offset[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid System time %q", systemTime)
+	}
+	return []*model.MetricValue{model.NewMetricValue("ntp_check", math.Abs(seconds)*1000)}, nil
+}
+
+// parseChage parses `chage -l` into password_expiry, the days left before the password
+// expires (negative once expired), e.g. "Password expires : Mar 01, 2027". A password that
+// must be changed at next login counts as expiring today; one that never expires gives no
+// value.
+func parseChage(output string, now time.Time) ([]*model.MetricValue, error) {
+	expires, ok := colonFields(output)["Password expires"]
+	if !ok {
+		return nil, fmt.Errorf("no Password expires line")
+	}
+	switch expires {
+	case "never":
+		return nil, nil
+	case "password must be changed":
+		return []*model.MetricValue{model.NewMetricValue("password_expiry", 0)}, nil
+	}
+	date, err := time.ParseInLocation("Jan 02, 2006", expires, now.Location())
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry date %q", expires)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days := math.Round(date.Sub(today).Hours() / 24)
+	return []*model.MetricValue{model.NewMetricValue("password_expiry", days)}, nil
+}
+
+// colonFields splits "Key : value" lines into a map of trimmed keys and values.
+func colonFields(output string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return fields
+}
+
+// shellQuote quotes s as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,212 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// runnerFunc adapts a function to the Runner interface.
+type runnerFunc func(ctx context.Context, host, command string) (string, error)
+
+func (f runnerFunc) Run(ctx context.Context, host, command string) (string, error) {
+	return f(ctx, host, command)
+}
+
+func metricsByName(values []*model.MetricValue) map[string]*model.MetricValue {
+	m := make(map[string]*model.MetricValue, len(values))
+	for _, v := range values {
+		m[v.Name] = v
+	}
+	return m
+}
+
+func TestParseDF(t *testing.T) {
+	output := `Filesystem              Type     1024-blocks      Used Available Capacity Mounted on
+/dev/mapper/centos-root xfs         52403200  41922560  10480640      80% /
+tmpfs                   tmpfs        8126000         0   8126000       0% /dev/shm
+/dev/sdb1               ext4       103081248  10308124  87530716      11% /data dir
+`
+	values, err := parseDF(output, time.Now())
+	if err != nil {
+		t.Fatalf("parseDF() unexpected error: %v", err)
+	}
+	got := metricsByName(values)
+	if len(got) != 9 {
+		t.Fatalf("len(values) = %d, want 9 (3 metrics for 3 mounts)", len(got))
+	}
+
+	root := got["disk_usage:/"]
+	if root == nil || root.RawValue != 80 {
+		t.Fatalf("disk_usage:/ = %+v, want 80", root)
+	}
+	if root.Labels["device"] != "/dev/mapper/centos-root" || root.Labels["fstype"] != "xfs" {
+		t.Errorf("disk_usage:/ labels = %v", root.Labels)
+	}
+	if v := got["disk_total:/"]; v == nil || v.RawValue != 52403200*1024 {
+		t.Errorf("disk_total:/ = %+v", v)
+	}
+	if v := got["disk_free:/"]; v == nil || v.RawValue != 10480640*1024 {
+		t.Errorf("disk_free:/ = %+v", v)
+	}
+	if v := got["disk_usage:/data dir"]; v == nil || v.Labels["path"] != "/data dir" {
+		t.Errorf("disk_usage of a mount point with a space = %+v", v)
+	}
+
+	if _, err := parseDF("Filesystem Type 1024-blocks Used Available Capacity Mounted on\n", time.Now()); err == nil {
+		t.Error("parseDF() without file systems should return an error")
+	}
+}
+
+func TestParseUptime(t *testing.T) {
+	values, err := parseUptime("350735.47 234388.90\n0.15 0.10 0.05 1/234 5678\n", time.Now())
+	if err != nil {
+		t.Fatalf("parseUptime() unexpected error: %v", err)
+	}
+	got := metricsByName(values)
+	for name, want := range map[string]float64{"uptime": 350735.47, "load_1m": 0.15, "load_5m": 0.10, "load_15m": 0.05} {
+		if v := got[name]; v == nil || v.RawValue != want {
+			t.Errorf("%s = %+v, want %v", name, v, want)
+		}
+	}
+
+	if _, err := parseUptime("350735.47 234388.90\n", time.Now()); err == nil {
+		t.Error("parseUptime() without loadavg should return an error")
+	}
+}
+
+func TestParseChronyTracking(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "fast",
+			output: `Reference ID    : C0A80101 (192.168.1.1)
+Stratum         : 3
+System time     : 0.000012345 seconds fast of NTP time
+Leap status     : Normal
+`,
+			want: 0.012345,
+		},
+		{
+			name:   "slow",
+			output: "System time     : 0.250000000 seconds slow of NTP time\nLeap status     : Normal\n",
+			want:   250,
+		},
+		{
+			name:    "not synchronised",
+			output:  "Reference ID    : 00000000 ()\nSystem time     : 0.000000000 seconds fast of NTP time\nLeap status     : Not synchronised\n",
+			wantErr: true,
+		},
+		{
+			name:    "no system time",
+			output:  "506 Cannot talk to daemon\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := parseChronyTracking(tt.output, time.Now())
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseChronyTracking() = %+v, want an error", values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseChronyTracking() unexpected error: %v", err)
+			}
+			if len(values) != 1 || values[0].Name != "ntp_check" || values[0].RawValue < tt.want-1e-9 || values[0].RawValue > tt.want+1e-9 {
+				t.Errorf("parseChronyTracking() = %+v, want ntp_check %v ms", values, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChage(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		expires string
+		want    []float64
+	}{
+		{"future", "Jan 14, 2027", []float64{90}},
+		{"today", "Oct 16, 2026", []float64{0}},
+		{"expired", "Oct 06, 2026", []float64{-10}},
+		{"must be changed", "password must be changed", []float64{0}},
+		{"never", "never", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := "Last password change					: Jul 18, 2026\nPassword expires					: " + tt.expires + "\nPassword inactive					: never\n"
+			values, err := parseChage(output, now)
+			if err != nil {
+				t.Fatalf("parseChage() unexpected error: %v", err)
+			}
+			if len(values) != len(tt.want) {
+				t.Fatalf("parseChage() = %+v, want %v", values, tt.want)
+			}
+			for i, want := range tt.want {
+				if values[i].Name != "password_expiry" || values[i].RawValue != want {
+					t.Errorf("values[%d] = %+v, want password_expiry %v", i, values[i], want)
+				}
+			}
+		})
+	}
+
+	if _, err := parseChage("chage: user 'nobody2' does not exist in /etc/passwd\n", now); err == nil {
+		t.Error("parseChage() without expiry line should return an error")
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	var commands []string
+	runner := runnerFunc(func(_ context.Context, host, command string) (string, error) {
+		if host != "10.0.0.1" {
+			t.Errorf("host = %q, want 10.0.0.1", host)
+		}
+		commands = append(commands, command)
+		if strings.HasPrefix(command, "LC_ALL=C chage") {
+			return "Password expires : never\n", nil
+		}
+		return "", errors.New("connection refused")
+	})
+
+	if _, err := RunCheck(context.Background(), runner, "10.0.0.1", config.SSHCheckPasswordExpiry, "app'; rm -rf /"); err != nil {
+		t.Fatalf("RunCheck() unexpected error: %v", err)
+	}
+	if want := `LC_ALL=C chage -l 'app'\''; rm -rf /'`; commands[0] != want {
+		t.Errorf("command = %q, want the user quoted as %q", commands[0], want)
+	}
+
+	if _, err := RunCheck(context.Background(), runner, "10.0.0.1", config.SSHCheckDF, ""); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("RunCheck() error = %v, want the runner error", err)
+	}
+	if _, err := RunCheck(context.Background(), runner, "10.0.0.1", "rm", ""); err == nil {
+		t.Error("RunCheck() should reject a check outside the whitelist")
+	}
+	if len(commands) != 2 {
+		t.Errorf("commands run = %q, want none for the unknown check", commands)
+	}
+}
+
+func TestCheckMetrics(t *testing.T) {
+	for _, name := range DefaultChecks {
+		if len(CheckMetrics(name)) == 0 {
+			t.Errorf("CheckMetrics(%q) is empty", name)
+		}
+	}
+	if CheckMetrics("unknown") != nil {
+		t.Error("CheckMetrics() of an unknown check should be nil")
+	}
+}
@@ -0,0 +1,164 @@
+// Package ssh provides the SSH fallback collector: a fixed whitelist of read-only commands
+// run on the hosts whose metrics are missing from the metrics backend, parsed into metric
+// values.
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"inspection-tool/internal/config"
+)
+
+// Runner runs a command on a host and returns its standard output.
+type Runner interface {
+	Run(ctx context.Context, host, command string) (string, error)
+}
+
+// Client runs commands over SSH, one connection per command.
+type Client struct {
+	clientConfig *gossh.ClientConfig // Login user, credentials and host key check
+	port         int                 // SSH port
+	timeout      time.Duration       // Connection and command timeout
+	logger       zerolog.Logger      // Logger
+}
+
+// NewClient creates the SSH client. The private key and known_hosts files are read here, so
+// a broken key fails once at startup rather than on every host.
+func NewClient(cfg *config.SSHFallbackConfig, logger zerolog.Logger) (*Client, error) {
+	// Set default timeout and port if not specified
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 22
+	}
+
+	var methods []gossh.AuthMethod
+	if cfg.PrivateKeyFile != "" {
+		signer, err := loadSigner(cfg.PrivateKeyFile, cfg.PrivateKeyPassphrase)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, gossh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		methods = append(methods, gossh.Password(cfg.Password))
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no SSH credential configured (private_key_file or password)")
+	}
+
+	hostKeyCallback, err := hostKeyCallback(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		clientConfig: &gossh.ClientConfig{
+			User:            cfg.User,
+			Auth:            methods,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         timeout,
+		},
+		port:    port,
+		timeout: timeout,
+		logger:  logger.With().Str("component", "ssh-client").Logger(),
+	}, nil
+}
+
+// loadSigner reads a private key, decrypting it with the passphrase when one is given.
+func loadSigner(path, passphrase string) (gossh.Signer, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH private key: %w", err)
+	}
+	var signer gossh.Signer
+	if passphrase != "" {
+		signer, err = gossh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	} else {
+		signer, err = gossh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH private key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// hostKeyCallback checks the host keys against the known_hosts file, ~/.ssh/known_hosts by
+// default, unless host key checking is disabled.
+func hostKeyCallback(cfg *config.SSHFallbackConfig, logger zerolog.Logger) (gossh.HostKeyCallback, error) {
+	if cfg.InsecureIgnoreHostKey {
+		logger.Warn().Msg("SSH host key checking disabled, hosts are not authenticated")
+		return gossh.InsecureIgnoreHostKey(), nil
+	}
+	path := cfg.KnownHostsFile
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate known_hosts: %w", err)
+		}
+		path = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known_hosts: %w", err)
+	}
+	return callback, nil
+}
+
+// Run connects to the host, runs the command and returns its standard output. The
+// connection and the command share the client timeout; cancelling ctx closes the connection.
+func (c *Client) Run(ctx context.Context, host, command string) (string, error) {
+	addr := net.JoinHostPort(host, strconv.Itoa(c.port))
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	sshConn, chans, reqs, err := gossh.NewClientConn(conn, addr, c.clientConfig)
+	if err != nil {
+		conn.Close()
+		return "", fmt.Errorf("SSH handshake with %s failed: %w", addr, err)
+	}
+	client := gossh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to open SSH session on %s: %w", addr, err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	c.logger.Debug().Str("host", host).Str("command", command).Msg("running SSH command")
+	if err := session.Run(command); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("SSH command on %s timed out: %w", addr, ctx.Err())
+		}
+		return "", fmt.Errorf("SSH command %q on %s failed: %w: %s", command, addr, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return stdout.String(), nil
+}
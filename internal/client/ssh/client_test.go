@@ -0,0 +1,204 @@
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"inspection-tool/internal/config"
+)
+
+// newSigner generates an ed25519 key pair.
+func newSigner(t *testing.T) (gossh.Signer, ed25519.PrivateKey) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := gossh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, key
+}
+
+// startServer runs an SSH server accepting the client key and answering every exec request
+// with output, or with exit status 1 for the command "false".
+func startServer(t *testing.T, clientKey gossh.PublicKey, output string) (gossh.Signer, string) {
+	t.Helper()
+	hostSigner, _ := newSigner(t)
+	serverConfig := &gossh.ServerConfig{
+		PublicKeyCallback: func(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+			if conn.User() == "inspect" && string(key.Marshal()) == string(clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, os.ErrPermission
+		},
+	}
+	serverConfig.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveConn(conn, serverConfig, output)
+		}
+	}()
+	return hostSigner, listener.Addr().String()
+}
+
+func serveConn(conn net.Conn, serverConfig *gossh.ServerConfig, output string) {
+	_, chans, reqs, err := gossh.NewServerConn(conn, serverConfig)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go gossh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				req.Reply(true, nil)
+				command := string(req.Payload[4:])
+				status := uint32(0)
+				if command == "false" {
+					status = 1
+					channel.Stderr().Write([]byte("failed\n"))
+				} else {
+					channel.Write([]byte(output))
+				}
+				channel.SendRequest("exit-status", false, gossh.Marshal(struct{ Status uint32 }{status}))
+				return
+			}
+		}()
+	}
+}
+
+// writeClientFiles writes the private key and the known_hosts file of the server.
+func writeClientFiles(t *testing.T, key ed25519.PrivateKey, hostKey gossh.PublicKey, addr string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	block, err := gossh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(addr)}, hostKey) + "\n"
+	if err := os.WriteFile(knownHostsFile, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return keyFile, knownHostsFile
+}
+
+func TestClient_Run(t *testing.T) {
+	clientSigner, clientKey := newSigner(t)
+	hostSigner, addr := startServer(t, clientSigner.PublicKey(), "0.15 0.10 0.05 1/234 5678\n")
+	keyFile, knownHostsFile := writeClientFiles(t, clientKey, hostSigner.PublicKey(), addr)
+
+	host, portText, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portText)
+	cfg := &config.SSHFallbackConfig{
+		User:           "inspect",
+		Port:           port,
+		Timeout:        5 * time.Second,
+		PrivateKeyFile: keyFile,
+		KnownHostsFile: knownHostsFile,
+	}
+	client, err := NewClient(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	output, err := client.Run(context.Background(), host, "cat /proc/loadavg")
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if output != "0.15 0.10 0.05 1/234 5678\n" {
+		t.Errorf("Run() = %q", output)
+	}
+
+	if _, err := client.Run(context.Background(), host, "false"); err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Errorf("Run() error = %v, want the exit status and stderr", err)
+	}
+}
+
+func TestClient_Run_UnknownHostKey(t *testing.T) {
+	clientSigner, clientKey := newSigner(t)
+	_, addr := startServer(t, clientSigner.PublicKey(), "")
+	otherHost, _ := newSigner(t)
+	keyFile, knownHostsFile := writeClientFiles(t, clientKey, otherHost.PublicKey(), addr)
+
+	host, portText, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portText)
+	client, err := NewClient(&config.SSHFallbackConfig{
+		User:           "inspect",
+		Port:           port,
+		PrivateKeyFile: keyFile,
+		KnownHostsFile: knownHostsFile,
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	if _, err := client.Run(context.Background(), host, "uptime"); err == nil || !strings.Contains(err.Error(), "handshake") {
+		t.Errorf("Run() error = %v, want the host key mismatch rejected", err)
+	}
+}
+
+func TestNewClient_Errors(t *testing.T) {
+	dir := t.TempDir()
+	badKey := filepath.Join(dir, "bad_key")
+	if err := os.WriteFile(badKey, []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.SSHFallbackConfig
+		want string
+	}{
+		{"no credential", config.SSHFallbackConfig{User: "inspect", InsecureIgnoreHostKey: true}, "no SSH credential"},
+		{"missing key", config.SSHFallbackConfig{User: "inspect", PrivateKeyFile: filepath.Join(dir, "missing")}, "read SSH private key"},
+		{"invalid key", config.SSHFallbackConfig{User: "inspect", PrivateKeyFile: badKey}, "parse SSH private key"},
+		{"missing known_hosts", config.SSHFallbackConfig{User: "inspect", Password: "secret", KnownHostsFile: filepath.Join(dir, "missing")}, "known_hosts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient(&tt.cfg, zerolog.Nop()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewClient() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
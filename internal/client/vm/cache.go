@@ -0,0 +1,199 @@
+package vm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+// DefaultCacheTTL is how long cached query results are reused when no TTL is configured.
+const DefaultCacheTTL = 10 * time.Minute
+
+// CachedSource is a MetricsSource keeping the parsed results of another source on disk, so
+// re-running the report generation shortly after (e.g. after fixing a report writer bug)
+// does not send the same hundreds of queries to the backend again.
+//
+// Entries are keyed by the backend, the query, the host filter and the query kind (instant
+// query or export). Range-aggregated queries carry their window in the query (the @ end
+// modifier), so a pinned window is part of the key; instant queries are reused for the TTL.
+// Raw responses (Query, QueryWithFilter) are not cached.
+type CachedSource struct {
+	source MetricsSource
+	dir    string
+	ttl    time.Duration
+	scope  string           // Backend identity hashed into the keys
+	now    func() time.Time // Clock, replaced in tests
+	logger zerolog.Logger
+}
+
+var (
+	_ MetricsSource  = (*CachedSource)(nil)
+	_ SeriesExporter = (*CachedSource)(nil)
+)
+
+// NewCachedSource wraps source with the on-disk cache configured in cfg.Cache, creating the
+// cache directory. The directory defaults to inspection-tool/queries in the user cache
+// directory and the TTL to DefaultCacheTTL.
+func NewCachedSource(source MetricsSource, cfg *config.VictoriaMetricsConfig, logger zerolog.Logger) (*CachedSource, error) {
+	dir := cfg.Cache.Dir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			base = ".cache"
+		}
+		dir = filepath.Join(base, "inspection-tool", "queries")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	ttl := cfg.Cache.TTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &CachedSource{
+		source: source,
+		dir:    dir,
+		ttl:    ttl,
+		scope:  cfg.Type + "\x00" + cfg.QueryURL() + "\x00" + cfg.TenantID,
+		now:    time.Now,
+		logger: logger.With().Str("component", "query-cache").Logger(),
+	}, nil
+}
+
+// Query executes an instant query without caching.
+func (c *CachedSource) Query(ctx context.Context, query string) (*QueryResponse, error) {
+	return c.source.Query(ctx, query)
+}
+
+// QueryWithFilter executes an instant query with optional host filtering without caching.
+func (c *CachedSource) QueryWithFilter(ctx context.Context, query string, filter *HostFilter) (*QueryResponse, error) {
+	return c.source.QueryWithFilter(ctx, query, filter)
+}
+
+// QueryResults returns the parsed results of an instant query, from the cache when fresh.
+func (c *CachedSource) QueryResults(ctx context.Context, query string) ([]QueryResult, error) {
+	return c.QueryResultsWithFilter(ctx, query, nil)
+}
+
+// QueryResultsWithFilter returns the parsed results of an instant query with optional host
+// filtering, from the cache when fresh.
+func (c *CachedSource) QueryResultsWithFilter(ctx context.Context, query string, filter *HostFilter) ([]QueryResult, error) {
+	return c.cached("query", query, filter, func() ([]QueryResult, error) {
+		return c.source.QueryResultsWithFilter(ctx, query, filter)
+	})
+}
+
+// QueryByIdent returns the results of a query grouped by host identifier.
+func (c *CachedSource) QueryByIdent(ctx context.Context, query string) (map[string]QueryResult, error) {
+	return c.QueryByIdentWithFilter(ctx, query, nil)
+}
+
+// QueryByIdentWithFilter returns the results of a query with optional host filtering grouped
+// by host identifier.
+func (c *CachedSource) QueryByIdentWithFilter(ctx context.Context, query string, filter *HostFilter) (map[string]QueryResult, error) {
+	results, err := c.QueryResultsWithFilter(ctx, query, filter)
+	if err != nil {
+		return nil, err
+	}
+	return GroupResultsByIdent(results), nil
+}
+
+// ExportResultsWithFilter returns the latest value of each series matching selector, from the
+// cache when fresh. Backends without an export API fall back to an instant query.
+func (c *CachedSource) ExportResultsWithFilter(ctx context.Context, selector string, filter *HostFilter) ([]QueryResult, error) {
+	exporter, ok := c.source.(SeriesExporter)
+	if !ok {
+		return c.QueryResultsWithFilter(ctx, selector, filter)
+	}
+	return c.cached("export", selector, filter, func() ([]QueryResult, error) {
+		return exporter.ExportResultsWithFilter(ctx, selector, filter)
+	})
+}
+
+// cached returns the cached results of a query when younger than the TTL, or runs fetch and
+// stores its results. Cache failures are logged and never fail the query.
+func (c *CachedSource) cached(kind, query string, filter *HostFilter, fetch func() ([]QueryResult, error)) ([]QueryResult, error) {
+	path := filepath.Join(c.dir, c.key(kind, query, filter)+".json")
+
+	if results, ok := c.load(path); ok {
+		c.logger.Debug().Str("query", query).Msg("query results served from cache")
+		return results, nil
+	}
+
+	results, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.store(path, results); err != nil {
+		c.logger.Warn().Err(err).Str("query", query).Msg("failed to cache query results")
+	}
+	return results, nil
+}
+
+// key returns the cache key of a query: a hash of the backend, the query kind, the query and
+// the host filter.
+func (c *CachedSource) key(kind, query string, filter *HostFilter) string {
+	// encoding/json sorts map keys, so equal filters encode identically
+	filterJSON, _ := json.Marshal(filter)
+	h := sha256.New()
+	for _, part := range []string{c.scope, kind, query, string(filterJSON)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// load reads a cache entry, removing it when expired. ok is false for missing, expired or
+// unreadable entries.
+func (c *CachedSource) load(path string) ([]QueryResult, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if c.now().Sub(info.ModTime()) > c.ttl {
+		os.Remove(path)
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var results []QueryResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, false
+	}
+	return results, true
+}
+
+// store writes a cache entry through a temporary file, so concurrent collectors never read
+// a partial entry.
+func (c *CachedSource) store(path string, results []QueryResult) error {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
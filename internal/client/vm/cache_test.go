@@ -0,0 +1,121 @@
+package vm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/config"
+)
+
+// newCacheTestSource creates a cached VictoriaMetrics source counting the requests reaching
+// the backend.
+func newCacheTestSource(t *testing.T, requests *int32) (*CachedSource, string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.URL.Path == "/api/v1/export" {
+			w.Write([]byte(`{"metric":{"ident":"host1"},"values":[7],"timestamps":[1000]}` + "\n"))
+			return
+		}
+		writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector", Result: []Sample{
+			{Metric: Metric{"ident": "host1", "path": "/"}, Value: SampleValue{1702483200.0, "42.5"}},
+		}}})
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	cfg := &config.VictoriaMetricsConfig{
+		Endpoint: server.URL,
+		Cache:    config.QueryCacheConfig{Enabled: true, Dir: dir, TTL: time.Minute},
+	}
+	source, ok := NewMetricsSource(cfg, &config.RetryConfig{}, testLogger()).(*CachedSource)
+	if !ok {
+		t.Fatal("NewMetricsSource did not wrap the client in a CachedSource")
+	}
+	return source, dir
+}
+
+func TestCachedSource_QueryResults(t *testing.T) {
+	var requests int32
+	source, dir := newCacheTestSource(t, &requests)
+	ctx := context.Background()
+	filter := &HostFilter{Tags: map[string]string{"env": "prod", "team": "ops"}}
+
+	for i := 0; i < 2; i++ {
+		results, err := source.QueryResultsWithFilter(ctx, "disk_used_percent", filter)
+		if err != nil {
+			t.Fatalf("QueryResultsWithFilter failed: %v", err)
+		}
+		if len(results) != 1 || results[0].Ident != "host1" || results[0].Value != 42.5 || results[0].Labels["path"] != "/" {
+			t.Fatalf("results = %+v, want host1 / 42.5", results)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("backend requests = %d, want 1 (second query served from cache)", n)
+	}
+
+	// Another filter or query is another entry
+	if _, err := source.QueryResults(ctx, "disk_used_percent"); err != nil {
+		t.Fatalf("QueryResults failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("backend requests = %d, want 2 after querying without filter", n)
+	}
+
+	// Expired entries are fetched again
+	entries, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	old := time.Now().Add(-2 * time.Minute)
+	for _, entry := range entries {
+		os.Chtimes(entry, old, old)
+	}
+	if _, err := source.QueryByIdentWithFilter(ctx, "disk_used_percent", filter); err != nil {
+		t.Fatalf("QueryByIdentWithFilter failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("backend requests = %d, want 3 after the entry expired", n)
+	}
+}
+
+func TestCachedSource_Export(t *testing.T) {
+	var requests int32
+	source, _ := newCacheTestSource(t, &requests)
+
+	for i := 0; i < 2; i++ {
+		results, err := source.ExportResultsWithFilter(context.Background(), "disk_used_percent", nil)
+		if err != nil {
+			t.Fatalf("ExportResultsWithFilter failed: %v", err)
+		}
+		if len(results) != 1 || results[0].Value != 7 {
+			t.Fatalf("results = %+v, want the exported value 7", results)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("backend requests = %d, want 1", n)
+	}
+}
+
+func TestCachedSource_ErrorsNotCached(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := &config.VictoriaMetricsConfig{Endpoint: server.URL, Cache: config.QueryCacheConfig{Enabled: true, Dir: t.TempDir()}}
+	source := NewMetricsSource(cfg, &config.RetryConfig{}, testLogger())
+	for i := 0; i < 2; i++ {
+		if _, err := source.QueryResults(context.Background(), "up"); err == nil {
+			t.Fatal("expected error for a 400 response")
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("backend requests = %d, want 2 (errors are not cached)", n)
+	}
+}
@@ -6,26 +6,51 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/rs/zerolog"
 
+	"inspection-tool/internal/client/auth"
+	"inspection-tool/internal/client/resilience"
 	"inspection-tool/internal/config"
 )
 
+// grafanaOrgHeader selects the Grafana organization of the proxied datasource.
+const grafanaOrgHeader = "X-Grafana-Org-Id"
+
 // Client is a client for the VictoriaMetrics/Prometheus API.
 type Client struct {
 	endpoint   string             // API endpoint
 	timeout    time.Duration      // Request timeout
 	retry      config.RetryConfig // Retry configuration
+	params     map[string]string  // Extra query parameters (e.g. Thanos partial_response)
 	httpClient *resty.Client      // HTTP client
 	logger     zerolog.Logger     // Logger
 }
 
 // NewClient creates a new VictoriaMetrics/Prometheus API client.
 func NewClient(cfg *config.VictoriaMetricsConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) *Client {
+	httpClient, timeout, retry := newHTTPClient(cfg, retryCfg, logger)
+	return &Client{
+		endpoint:   cfg.QueryURL(),
+		timeout:    timeout,
+		retry:      retry,
+		params:     queryParams(cfg),
+		httpClient: httpClient,
+		logger:     logger.With().Str("component", "vm-client").Logger(),
+	}
+}
+
+// newHTTPClient creates the HTTP client of a metrics backend, with the default timeout and
+// retry configuration when not specified. The configured headers, the tenant header of
+// multi-tenant frontends (Cortex / Mimir / Thanos Query) and the basic / bearer
+// authentication, or the Grafana API key in Grafana proxy mode, are sent with every query, over the configured TLS settings and proxy, at
+// most at the configured rate and signed by the configured auth plugin.
+func newHTTPClient(cfg *config.VictoriaMetricsConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) (*resty.Client, time.Duration, config.RetryConfig) {
 	// Set default timeout if not specified
 	timeout := cfg.Timeout
 	if timeout == 0 {
@@ -43,21 +68,75 @@ func NewClient(cfg *config.VictoriaMetricsConfig, retryCfg *config.RetryConfig,
 
 	// Create resty client
 	httpClient := resty.New().
-		SetBaseURL(cfg.Endpoint).
+		SetBaseURL(cfg.QueryURL()).
 		SetTimeout(timeout).
 		SetHeader("Content-Type", "application/x-www-form-urlencoded").
 		SetRetryCount(retry.MaxRetries).
-		SetRetryWaitTime(retry.BaseDelay).
-		SetRetryMaxWaitTime(retry.BaseDelay * 8). // Max wait time for exponential backoff
-		AddRetryCondition(retryCondition)
+		SetRetryWaitTime(retry.BaseDelay)
 
-	return &Client{
-		endpoint:   cfg.Endpoint,
-		timeout:    timeout,
-		retry:      retry,
-		httpClient: httpClient,
-		logger:     logger.With().Str("component", "vm-client").Logger(),
+	headers := make(map[string]string, len(cfg.Headers)+1)
+	for name, value := range cfg.Headers {
+		headers[name] = value
+	}
+	if cfg.Grafana.OrgID > 0 {
+		headers[grafanaOrgHeader] = strconv.FormatInt(cfg.Grafana.OrgID, 10)
+	}
+	if cfg.TenantID != "" {
+		tenantHeader := cfg.TenantHeader
+		if tenantHeader == "" {
+			tenantHeader = config.DefaultTenantHeader
+		}
+		headers[tenantHeader] = cfg.TenantID
+	}
+	if len(headers) > 0 {
+		httpClient.SetHeaders(headers)
 	}
+
+	// Authentication, e.g. for VictoriaMetrics behind vmauth, or the API key of Grafana
+	switch {
+	case cfg.Grafana.Enabled():
+		httpClient.SetAuthToken(cfg.Grafana.APIKey)
+	case cfg.BearerToken != "":
+		httpClient.SetAuthToken(cfg.BearerToken)
+	case cfg.Username != "":
+		httpClient.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	// Custom CA and client certificate (mTLS); the files were checked when loading the config
+	if tlsConfig, err := cfg.TLS.ClientConfig(); err != nil {
+		logger.Warn().Err(err).Msg("invalid TLS configuration, using the default TLS settings")
+	} else if tlsConfig != nil {
+		httpClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	// Proxy of the datasource; without one, HTTP_PROXY / HTTPS_PROXY / NO_PROXY apply
+	if cfg.ProxyURL != "" {
+		httpClient.SetProxy(cfg.ProxyURL)
+	}
+
+	// Rate limit, checked before every attempt so retries count as well
+	if limiter := newRateLimiter(cfg.RateLimit); limiter != nil {
+		httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			return limiter.Wait(req.Context())
+		})
+	}
+
+	// Request signing by the auth plugin, e.g. the AK/SK signature of cloud providers
+	auth.Apply(httpClient, cfg.AuthPlugin, logger)
+
+	// Jittered backoff, retry budget and circuit breaker, wrapping the transport configured above
+	resilience.Apply(httpClient, retry, retryCondition, logger)
+
+	return httpClient, timeout, retry
+}
+
+// queryParams returns the extra query parameters of a metrics backend: the Thanos
+// partial_response parameter when configured.
+func queryParams(cfg *config.VictoriaMetricsConfig) map[string]string {
+	if cfg.PartialResponse == nil {
+		return nil
+	}
+	return map[string]string{"partial_response": strconv.FormatBool(*cfg.PartialResponse)}
 }
 
 // retryCondition determines whether a request should be retried.
@@ -88,10 +167,7 @@ func (c *Client) Query(ctx context.Context, query string) (*QueryResponse, error
 // The filter is applied by injecting label matchers into the query.
 func (c *Client) QueryWithFilter(ctx context.Context, query string, filter *HostFilter) (*QueryResponse, error) {
 	// Apply host filter to query if specified
-	finalQuery := query
-	if filter != nil && !filter.IsEmpty() {
-		finalQuery = c.injectLabelMatchers(query, filter)
-	}
+	finalQuery := injectLabelMatchers(query, filter)
 
 	c.logger.Debug().
 		Str("query", finalQuery).
@@ -101,6 +177,7 @@ func (c *Client) QueryWithFilter(ctx context.Context, query string, filter *Host
 
 	resp, err := c.httpClient.R().
 		SetContext(ctx).
+		SetQueryParams(c.params).
 		SetQueryParam("query", finalQuery).
 		SetResult(&result).
 		Get("/api/v1/query")
@@ -180,9 +257,15 @@ func (c *Client) QueryByIdentWithFilter(ctx context.Context, query string, filte
 	return GroupResultsByIdent(results), nil
 }
 
+// ApplyHostFilter returns the query actually sent for the given host filter, with the
+// filter injected as label matchers. It is used to print the queries of a dry run.
+func ApplyHostFilter(query string, filter *HostFilter) string {
+	return injectLabelMatchers(query, filter)
+}
+
 // injectLabelMatchers injects label matchers into a PromQL query based on the filter.
-// Business groups are joined with OR (regex ~), tags are added with AND.
-func (c *Client) injectLabelMatchers(query string, filter *HostFilter) string {
+// Business groups are joined with OR (regex ~), tags are added with AND in key order.
+func injectLabelMatchers(query string, filter *HostFilter) string {
 	if filter == nil || filter.IsEmpty() {
 		return query
 	}
@@ -200,10 +283,15 @@ func (c *Client) injectLabelMatchers(query string, filter *HostFilter) string {
 		matchers = append(matchers, fmt.Sprintf(`busigroup=~"%s"`, groups))
 	}
 
-	// Tags - AND relation
-	for k, v := range filter.Tags {
+	// Tags - AND relation, sorted so the query is the same on every run
+	keys := make([]string, 0, len(filter.Tags))
+	for k := range filter.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
 		// Escape quotes in tag values
-		escapedValue := strings.ReplaceAll(v, `"`, `\"`)
+		escapedValue := strings.ReplaceAll(filter.Tags[k], `"`, `\"`)
 		matchers = append(matchers, fmt.Sprintf(`%s="%s"`, k, escapedValue))
 	}
 
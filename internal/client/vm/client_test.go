@@ -482,6 +482,22 @@ func TestInjectMatchersToQuery(t *testing.T) {
 	}
 }
 
+func TestApplyHostFilter(t *testing.T) {
+	filter := &HostFilter{
+		BusinessGroups: []string{"prod.web", "prod.db"},
+		Tags:           map[string]string{"region": "bj", "env": "prod"},
+	}
+	want := `100 - mem_available_percent{busigroup=~"prod\.web|prod\.db", env="prod", region="bj"}`
+	for i := 0; i < 5; i++ { // Tags come from a map; the order must not vary
+		if got := ApplyHostFilter("100 - mem_available_percent", filter); got != want {
+			t.Fatalf("ApplyHostFilter() = %q, want %q", got, want)
+		}
+	}
+	if got := ApplyHostFilter("up", nil); got != "up" {
+		t.Errorf("ApplyHostFilter(nil) = %q, want the query unchanged", got)
+	}
+}
+
 func TestEscapeRegex(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -854,26 +870,51 @@ func TestClient_NaN_Inf_Values(t *testing.T) {
 	}
 }
 
-func TestClient_MatrixTypeResponse_Error(t *testing.T) {
+func TestClient_MatrixTypeResponse(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Return matrix type response (range query result)
-		resp := QueryResponse{
-			Status: "success",
-			Data: QueryData{
-				ResultType: "matrix", // Not vector
-				Result:     []Sample{},
-			},
-		}
-		writeJSON(w, resp)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"ident":"host1"},"values":[[1702483140,"10"],[1702483200,"12.5"]]},
+			{"metric":{"ident":"host2"},"values":[[1702483140,"20"],[1702483200,"NaN"]]},
+			{"metric":{"ident":"host3"},"values":[[1702483200,"+Inf"]]}
+		]}}`))
 	}))
 	defer server.Close()
 
 	cfg := &config.VictoriaMetricsConfig{Endpoint: server.URL}
 	client := NewClient(cfg, nil, testLogger())
 
-	_, err := client.QueryResults(context.Background(), "rate(cpu_usage[5m])")
+	results, err := client.QueryByIdent(context.Background(), "cpu_usage[5m]")
+	if err != nil {
+		t.Fatalf("QueryByIdent() error = %v", err)
+	}
+
+	// The last valid value of each series is used; series without one are skipped
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results["host1"].Value != 12.5 {
+		t.Errorf("host1 value = %v, want 12.5 (last value)", results["host1"].Value)
+	}
+	if results["host2"].Value != 20 {
+		t.Errorf("host2 value = %v, want 20 (last valid value)", results["host2"].Value)
+	}
+}
+
+func TestClient_StringTypeResponse_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"success","data":{"resultType":"string","result":[1702483200,"hello"]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.VictoriaMetricsConfig{Endpoint: server.URL}
+	client := NewClient(cfg, nil, testLogger())
+
+	_, err := client.QueryResults(context.Background(), `"hello"`)
 	if err == nil {
-		t.Error("expected error for matrix type response")
+		t.Fatal("expected error for string type response")
 	}
 
 	if !strings.Contains(err.Error(), "expected vector") {
@@ -881,6 +922,44 @@ func TestClient_MatrixTypeResponse_Error(t *testing.T) {
 	}
 }
 
+func TestClient_MalformedSamples(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// One exporter returns malformed samples next to valid ones
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"ident":"host1"},"value":[1702483200,"1.5e+01"]},
+			{"metric":{"ident":"host2"},"value":"broken"},
+			{"metric":{"ident":"host3","port":9100},"value":[1702483200," 42 "]},
+			{"metric":{"ident":"host4"},"value":[1702483200,"nan"]},
+			{"metric":{"ident":"host5"},"value":[1702483200,"Infinity"]},
+			{"metric":{"ident":"host6"},"value":[1702483200]},
+			"not a sample"
+		]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.VictoriaMetricsConfig{Endpoint: server.URL}
+	client := NewClient(cfg, nil, testLogger())
+
+	results, err := client.QueryByIdent(context.Background(), "cpu_usage")
+	if err != nil {
+		t.Fatalf("QueryByIdent() error = %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 valid results, got %d: %v", len(results), results)
+	}
+	if results["host1"].Value != 15 {
+		t.Errorf("host1 value = %v, want 15", results["host1"].Value)
+	}
+	if results["host3"].Value != 42 {
+		t.Errorf("host3 value = %v, want 42", results["host3"].Value)
+	}
+	if results["host3"].Labels["port"] != "9100" {
+		t.Errorf("non-string label should be converted to text, got %q", results["host3"].Labels["port"])
+	}
+}
+
 func TestClient_MaxRetries_Exhausted(t *testing.T) {
 	attempts := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
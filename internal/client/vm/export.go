@@ -0,0 +1,175 @@
+package vm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportLookback is how far back the export API is read: the staleness window of instant
+// queries, so a series exported here is the one an instant query would return.
+const exportLookback = 5 * time.Minute
+
+// maxExportErrorBody limits how much of an error response is read into the error message.
+const maxExportErrorBody = 4096
+
+// SeriesExporter is implemented by metrics backends able to stream raw series, i.e.
+// VictoriaMetrics through /api/v1/export. Collectors use it for metrics returning tens of
+// thousands of series, which would otherwise be buffered as one large query response.
+type SeriesExporter interface {
+	// ExportResultsWithFilter streams the series matching selector with optional host
+	// filtering and returns the latest value of each series.
+	ExportResultsWithFilter(ctx context.Context, selector string, filter *HostFilter) ([]QueryResult, error)
+}
+
+var _ SeriesExporter = (*Client)(nil)
+
+// exportLine is one line of the /api/v1/export NDJSON stream: a block of samples of one
+// series. A series may be split across several lines.
+type exportLine struct {
+	Metric     map[string]string `json:"metric"`
+	Values     []json.RawMessage `json:"values"`
+	Timestamps []int64           `json:"timestamps"`
+}
+
+// exportedSeries is the latest valid sample of an exported series.
+type exportedSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// ExportResultsWithFilter reads the series matching selector over the last five minutes from
+// /api/v1/export and returns the latest valid value of each series, like an instant query.
+// The NDJSON response is decoded line by line, so only one value per series is held in
+// memory. selector must be a series selector (e.g. disk_used_percent{fstype!="tmpfs"}).
+func (c *Client) ExportResultsWithFilter(ctx context.Context, selector string, filter *HostFilter) ([]QueryResult, error) {
+	match := injectLabelMatchers(selector, filter)
+	now := time.Now()
+
+	c.logger.Debug().
+		Str("match", match).
+		Msg("exporting series")
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetQueryParam("match[]", match).
+		SetQueryParam("start", strconv.FormatInt(now.Add(-exportLookback).Unix(), 10)).
+		SetQueryParam("end", strconv.FormatInt(now.Unix(), 10)).
+		Get("/api/v1/export")
+
+	if err != nil {
+		c.logger.Error().Err(err).Str("match", match).Msg("failed to export series")
+		return nil, fmt.Errorf("failed to export series: %w", err)
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	// Check HTTP status code
+	if resp.StatusCode() != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(body, maxExportErrorBody))
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(data)).
+			Str("match", match).
+			Msg("VM export API returned non-200 status")
+		return nil, fmt.Errorf("VM export API returned status %d: %s", resp.StatusCode(), string(data))
+	}
+
+	series, err := decodeExport(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode export of %s: %w", match, err)
+	}
+
+	results := make([]QueryResult, 0, len(series))
+	for _, s := range series {
+		sample := Sample{Metric: s.labels}
+		results = append(results, QueryResult{
+			Ident:  sample.GetIdent(),
+			Value:  s.value,
+			Labels: s.labels,
+		})
+	}
+
+	c.logger.Debug().
+		Int("result_count", len(results)).
+		Msg("series exported successfully")
+
+	return results, nil
+}
+
+// decodeExport decodes an /api/v1/export stream, keeping the latest valid sample of each
+// series in the order the series first appear. Series without a valid value (NaN, Inf,
+// staleness markers) are left out.
+func decodeExport(r io.Reader) ([]*exportedSeries, error) {
+	var ordered []*exportedSeries
+	series := make(map[string]*exportedSeries)
+	decoder := json.NewDecoder(r)
+	for {
+		var line exportLine
+		if err := decoder.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ordered, nil
+			}
+			return nil, err
+		}
+
+		key := seriesKey(line.Metric)
+		for i := len(line.Values) - 1; i >= 0; i-- {
+			if i >= len(line.Timestamps) {
+				continue
+			}
+			value, ok := decodeExportValue(line.Values[i])
+			if !ok {
+				continue
+			}
+			if s, exists := series[key]; !exists {
+				s = &exportedSeries{labels: line.Metric, value: value, timestamp: line.Timestamps[i]}
+				series[key] = s
+				ordered = append(ordered, s)
+			} else if line.Timestamps[i] > s.timestamp {
+				s.value, s.timestamp = value, line.Timestamps[i]
+			}
+			break
+		}
+	}
+}
+
+// decodeExportValue decodes an exported sample value: a JSON number, or a string such as
+// "NaN" or "Inf". ok is false for values that are not finite numbers.
+func decodeExportValue(data json.RawMessage) (float64, bool) {
+	text := strings.Trim(string(data), `"`)
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+// seriesKey returns a canonical key of a label set, identifying the lines of one series.
+func seriesKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package vm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/config"
+)
+
+func newExportTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cfg := &config.VictoriaMetricsConfig{Endpoint: server.URL, Timeout: 5 * time.Second}
+	return NewClient(cfg, &config.RetryConfig{MaxRetries: 0, BaseDelay: 10 * time.Millisecond}, testLogger())
+}
+
+func TestClient_ExportResultsWithFilter(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/export" {
+			t.Errorf("path = %s, want /api/v1/export", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if got := query.Get("match[]"); got != `disk_used_percent{fstype!="tmpfs", busigroup=~"prod"}` {
+			t.Errorf("match[] = %q, want the filtered selector", got)
+		}
+		if query.Get("start") == "" || query.Get("end") == "" {
+			t.Errorf("export window not set: %s", r.URL.RawQuery)
+		}
+		// host1 / is split across two lines; host2 /data ends with a staleness marker;
+		// host3 / has no valid value at all
+		w.Write([]byte(`{"metric":{"__name__":"disk_used_percent","ident":"host1","path":"/"},"values":[10,20],"timestamps":[1000,2000]}
+{"metric":{"__name__":"disk_used_percent","ident":"host2","path":"/data"},"values":[55.5,"NaN"],"timestamps":[1000,2000]}
+{"metric":{"__name__":"disk_used_percent","ident":"host3","path":"/"},"values":["NaN"],"timestamps":[2000]}
+{"metric":{"__name__":"disk_used_percent","path":"/","ident":"host1"},"values":[30],"timestamps":[3000]}
+`))
+	})
+
+	filter := &HostFilter{BusinessGroups: []string{"prod"}}
+	results, err := client.ExportResultsWithFilter(context.Background(), `disk_used_percent{fstype!="tmpfs"}`, filter)
+	if err != nil {
+		t.Fatalf("ExportResultsWithFilter failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d: %+v", len(results), results)
+	}
+	if results[0].Ident != "host1" || results[0].Value != 30 {
+		t.Errorf("results[0] = %+v, want host1 with the latest value 30", results[0])
+	}
+	if results[1].Ident != "host2" || results[1].Value != 55.5 || results[1].Labels["path"] != "/data" {
+		t.Errorf("results[1] = %+v, want host2 /data with the last valid value 55.5", results[1])
+	}
+}
+
+func TestClient_ExportResultsWithFilter_Errors(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
+		client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("cannot parse match[]"))
+		})
+		_, err := client.ExportResultsWithFilter(context.Background(), "up", nil)
+		if err == nil || !strings.Contains(err.Error(), "cannot parse match[]") {
+			t.Errorf("error = %v, want the response body", err)
+		}
+	})
+
+	t.Run("truncated stream", func(t *testing.T) {
+		client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"metric":{"ident":"host1"},"values":[1],"timestamps":[1000]}
+{"metric":{"ident":"host2"},"val`))
+		})
+		if _, err := client.ExportResultsWithFilter(context.Background(), "up", nil); err == nil {
+			t.Error("expected error for a truncated export stream")
+		}
+	})
+}
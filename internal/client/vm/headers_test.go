@@ -0,0 +1,240 @@
+package vm
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/config"
+)
+
+func TestClients_TenantHeadersAndPartialResponse(t *testing.T) {
+	partial := false
+	cfg := config.VictoriaMetricsConfig{
+		TenantID:        "team-a",
+		Headers:         map[string]string{"X-Custom": "1"},
+		PartialResponse: &partial,
+	}
+
+	for _, metricsType := range []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus} {
+		t.Run(metricsType, func(t *testing.T) {
+			var tenant, custom, param string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tenant = r.Header.Get("X-Scope-OrgID")
+				custom = r.Header.Get("X-Custom")
+				if err := r.ParseForm(); err != nil {
+					t.Fatalf("failed to parse form: %v", err)
+				}
+				param = r.Form.Get("partial_response")
+				writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+			}))
+			defer server.Close()
+
+			cfg := cfg
+			cfg.Type = metricsType
+			cfg.Endpoint = server.URL
+			if _, err := NewMetricsSource(&cfg, nil, testLogger()).Query(context.Background(), "up"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tenant != "team-a" || custom != "1" {
+				t.Errorf("headers = tenant %q, custom %q; want team-a, 1", tenant, custom)
+			}
+			if param != "false" {
+				t.Errorf("partial_response = %q, want false", param)
+			}
+		})
+	}
+}
+
+func TestClient_CustomTenantHeader(t *testing.T) {
+	var tenant, param string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("THANOS-TENANT")
+		param = r.URL.Query().Get("partial_response")
+		writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+	}))
+	defer server.Close()
+
+	cfg := &config.VictoriaMetricsConfig{Endpoint: server.URL, TenantID: "team-b", TenantHeader: "THANOS-TENANT"}
+	if _, err := NewClient(cfg, nil, testLogger()).Query(context.Background(), "up"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tenant != "team-b" {
+		t.Errorf("THANOS-TENANT = %q, want team-b", tenant)
+	}
+	// Without configuration, partial_response is left to the frontend default
+	if param != "" {
+		t.Errorf("partial_response = %q, want unset", param)
+	}
+}
+
+func TestClients_Authentication(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.VictoriaMetricsConfig
+		want string
+	}{
+		{"basic", config.VictoriaMetricsConfig{Username: "inspector", Password: "secret"}, "Basic aW5zcGVjdG9yOnNlY3JldA=="},
+		{"bearer", config.VictoriaMetricsConfig{BearerToken: "vmauth-token"}, "Bearer vmauth-token"},
+		{"none", config.VictoriaMetricsConfig{}, ""},
+	}
+
+	for _, tt := range tests {
+		for _, metricsType := range []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus} {
+			t.Run(tt.name+"/"+metricsType, func(t *testing.T) {
+				var got string
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					got = r.Header.Get("Authorization")
+					writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+				}))
+				defer server.Close()
+
+				cfg := tt.cfg
+				cfg.Type = metricsType
+				cfg.Endpoint = server.URL
+				if _, err := NewMetricsSource(&cfg, nil, testLogger()).Query(context.Background(), "up"); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("Authorization = %q, want %q", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestClients_AuthPlugin(t *testing.T) {
+	cfg := config.VictoriaMetricsConfig{
+		Username: "ignored",
+		Password: "ignored",
+		AuthPlugin: config.AuthPluginConfig{
+			Type:      "sigv4",
+			Region:    "us-east-1",
+			AccessKey: "AKIDEXAMPLE",
+			SecretKey: "secret",
+		},
+	}
+
+	for _, metricsType := range []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus} {
+		t.Run(metricsType, func(t *testing.T) {
+			var authorization, date string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				date = r.Header.Get("X-Amz-Date")
+				writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+			}))
+			defer server.Close()
+
+			cfg := cfg
+			cfg.Type = metricsType
+			cfg.Endpoint = server.URL
+			if _, err := NewMetricsSource(&cfg, nil, testLogger()).Query(context.Background(), "up"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// The signature replaces the basic authentication
+			if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || date == "" {
+				t.Errorf("Authorization = %q, X-Amz-Date = %q; want a SigV4 signature", authorization, date)
+			}
+		})
+	}
+}
+
+func TestClients_TLS(t *testing.T) {
+	for _, metricsType := range []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus} {
+		t.Run(metricsType, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+			}))
+			defer server.Close()
+
+			caFile := filepath.Join(t.TempDir(), "ca.crt")
+			if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := config.VictoriaMetricsConfig{
+				Type:     metricsType,
+				Endpoint: server.URL,
+				TLS:      config.TLSConfig{CAFile: caFile},
+			}
+			if _, err := NewMetricsSource(&cfg, &config.RetryConfig{}, testLogger()).Query(context.Background(), "up"); err != nil {
+				t.Fatalf("query over TLS with custom CA failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestClients_Proxy(t *testing.T) {
+	for _, metricsType := range []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus} {
+		t.Run(metricsType, func(t *testing.T) {
+			// An HTTP proxy receives the request with the target host
+			var host string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				host = r.Host
+				writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+			}))
+			defer proxy.Close()
+
+			cfg := config.VictoriaMetricsConfig{
+				Type:     metricsType,
+				Endpoint: "http://vm.internal.example:8428",
+				ProxyURL: proxy.URL,
+			}
+			if _, err := NewMetricsSource(&cfg, &config.RetryConfig{}, testLogger()).Query(context.Background(), "up"); err != nil {
+				t.Fatalf("query through proxy failed: %v", err)
+			}
+			if host != "vm.internal.example:8428" {
+				t.Errorf("proxy received host %q, want vm.internal.example:8428", host)
+			}
+		})
+	}
+}
+
+func TestClients_GrafanaProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		grafana config.GrafanaProxyConfig
+		path    string
+	}{
+		{"datasource id", config.GrafanaProxyConfig{DatasourceID: 3, APIKey: "glsa_key"}, "/api/datasources/proxy/3/api/v1/query"},
+		{"datasource uid", config.GrafanaProxyConfig{DatasourceUID: "vm-prod", APIKey: "glsa_key", OrgID: 2}, "/api/datasources/proxy/uid/vm-prod/api/v1/query"},
+	}
+
+	for _, metricsType := range []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus} {
+		for _, tt := range tests {
+			t.Run(metricsType+"/"+tt.name, func(t *testing.T) {
+				var path, authorization, org string
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					path = r.URL.Path
+					authorization = r.Header.Get("Authorization")
+					org = r.Header.Get("X-Grafana-Org-Id")
+					writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+				}))
+				defer server.Close()
+
+				cfg := &config.VictoriaMetricsConfig{Type: metricsType, Endpoint: server.URL + "/", Grafana: tt.grafana}
+				if _, err := NewMetricsSource(cfg, nil, testLogger()).Query(context.Background(), "up"); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if path != tt.path {
+					t.Errorf("path = %q, want %q", path, tt.path)
+				}
+				if authorization != "Bearer glsa_key" {
+					t.Errorf("Authorization = %q, want Bearer glsa_key", authorization)
+				}
+				wantOrg := ""
+				if tt.grafana.OrgID > 0 {
+					wantOrg = "2"
+				}
+				if org != wantOrg {
+					t.Errorf("X-Grafana-Org-Id = %q, want %q", org, wantOrg)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package vm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+// PrometheusClient is a client for the native Prometheus HTTP API. It only uses the
+// standard /api/v1/query parameters, posts the query as form data so long expressions are
+// not limited by the URL length, and reports the error Prometheus returns in the body of
+// 4xx / 5xx responses.
+type PrometheusClient struct {
+	endpoint   string             // API endpoint
+	timeout    time.Duration      // Request timeout
+	retry      config.RetryConfig // Retry configuration
+	params     map[string]string  // Extra query parameters (e.g. Thanos partial_response)
+	httpClient *resty.Client      // HTTP client
+	logger     zerolog.Logger     // Logger
+}
+
+// NewPrometheusClient creates a new Prometheus API client.
+func NewPrometheusClient(cfg *config.VictoriaMetricsConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) *PrometheusClient {
+	httpClient, timeout, retry := newHTTPClient(cfg, retryCfg, logger)
+	return &PrometheusClient{
+		endpoint:   cfg.QueryURL(),
+		timeout:    timeout,
+		retry:      retry,
+		params:     queryParams(cfg),
+		httpClient: httpClient,
+		logger:     logger.With().Str("component", "prometheus-client").Logger(),
+	}
+}
+
+// Query executes an instant query at the /api/v1/query endpoint.
+func (c *PrometheusClient) Query(ctx context.Context, query string) (*QueryResponse, error) {
+	return c.QueryWithFilter(ctx, query, nil)
+}
+
+// QueryWithFilter executes an instant query with optional host filtering.
+// The filter is applied by injecting label matchers into the query.
+func (c *PrometheusClient) QueryWithFilter(ctx context.Context, query string, filter *HostFilter) (*QueryResponse, error) {
+	finalQuery := injectLabelMatchers(query, filter)
+
+	c.logger.Debug().
+		Str("query", finalQuery).
+		Msg("executing PromQL query")
+
+	form := url.Values{"query": {finalQuery}}
+	for name, value := range c.params {
+		form.Set(name, value)
+	}
+
+	var result QueryResponse
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetBody(form.Encode()).
+		SetResult(&result).
+		Post("/api/v1/query")
+
+	if err != nil {
+		c.logger.Error().Err(err).Str("query", finalQuery).Msg("failed to execute query")
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	// Prometheus reports bad queries (400), execution errors (422) and timeouts (503) in a
+	// JSON body with the error type and message
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Error().
+			Int("status_code", resp.StatusCode()).
+			Str("body", string(resp.Body())).
+			Str("query", finalQuery).
+			Msg("Prometheus API returned non-200 status")
+		var apiErr QueryResponse
+		if json.Unmarshal(resp.Body(), &apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("Prometheus API error [%s] (status %d): %s", apiErr.ErrorType, resp.StatusCode(), apiErr.Error)
+		}
+		return nil, fmt.Errorf("Prometheus API returned status %d: %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	// Check for API-level errors
+	if !result.IsSuccess() {
+		c.logger.Error().
+			Str("error_type", result.ErrorType).
+			Str("error", result.Error).
+			Str("query", finalQuery).
+			Msg("Prometheus API returned error")
+		return nil, fmt.Errorf("Prometheus API error [%s]: %s", result.ErrorType, result.Error)
+	}
+
+	// Log warnings if any
+	if len(result.Warnings) > 0 {
+		c.logger.Warn().
+			Strs("warnings", result.Warnings).
+			Str("query", finalQuery).
+			Msg("Prometheus API returned warnings")
+	}
+
+	c.logger.Debug().
+		Str("result_type", result.Data.ResultType).
+		Int("result_count", len(result.Data.Result)).
+		Msg("query executed successfully")
+
+	return &result, nil
+}
+
+// QueryResults executes an instant query and returns parsed results.
+func (c *PrometheusClient) QueryResults(ctx context.Context, query string) ([]QueryResult, error) {
+	return c.QueryResultsWithFilter(ctx, query, nil)
+}
+
+// QueryResultsWithFilter executes an instant query with optional host filtering
+// and returns parsed results.
+func (c *PrometheusClient) QueryResultsWithFilter(ctx context.Context, query string, filter *HostFilter) ([]QueryResult, error) {
+	resp, err := c.QueryWithFilter(ctx, query, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseQueryResults(resp)
+}
+
+// QueryByIdent executes a query and returns results grouped by host identifier.
+func (c *PrometheusClient) QueryByIdent(ctx context.Context, query string) (map[string]QueryResult, error) {
+	return c.QueryByIdentWithFilter(ctx, query, nil)
+}
+
+// QueryByIdentWithFilter executes a query with optional host filtering
+// and returns results grouped by host identifier.
+func (c *PrometheusClient) QueryByIdentWithFilter(ctx context.Context, query string, filter *HostFilter) (map[string]QueryResult, error) {
+	results, err := c.QueryResultsWithFilter(ctx, query, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return GroupResultsByIdent(results), nil
+}
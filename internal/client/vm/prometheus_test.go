@@ -0,0 +1,120 @@
+package vm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/config"
+)
+
+func TestNewMetricsSource(t *testing.T) {
+	tests := []struct {
+		metricsType string
+		want        string
+	}{
+		{"", "*vm.Client"},
+		{config.MetricsTypeVictoriaMetrics, "*vm.Client"},
+		{config.MetricsTypePrometheus, "*vm.PrometheusClient"},
+	}
+	for _, tt := range tests {
+		cfg := &config.VictoriaMetricsConfig{Type: tt.metricsType, Endpoint: "http://localhost:9090"}
+		source := NewMetricsSource(cfg, nil, testLogger())
+		var got string
+		switch source.(type) {
+		case *Client:
+			got = "*vm.Client"
+		case *PrometheusClient:
+			got = "*vm.PrometheusClient"
+		}
+		if got != tt.want {
+			t.Errorf("NewMetricsSource(type %q) = %s, want %s", tt.metricsType, got, tt.want)
+		}
+	}
+}
+
+func TestPrometheusClient_QueryResultsWithFilter(t *testing.T) {
+	var gotMethod, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			t.Errorf("expected path /api/v1/query, got %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		gotMethod = r.Method
+		gotQuery = r.PostForm.Get("query")
+
+		writeJSON(w, QueryResponse{
+			Status: "success",
+			Data: QueryData{
+				ResultType: "vector",
+				Result: []Sample{
+					{
+						Metric: Metric{"__name__": "up", "instance": "10.0.0.1:9100", "job": "node"},
+						Value:  SampleValue{float64(1702483200), "1"},
+					},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewPrometheusClient(&config.VictoriaMetricsConfig{Type: config.MetricsTypePrometheus, Endpoint: server.URL}, nil, testLogger())
+	filter := &HostFilter{Tags: map[string]string{"env": "prod"}}
+	results, err := client.QueryResultsWithFilter(context.Background(), `up{job="node"}`, filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Standard instant query, posted as form data with the host filter injected
+	if gotMethod != http.MethodPost {
+		t.Errorf("expected POST request, got %s", gotMethod)
+	}
+	if want := `up{job="node", env="prod"}`; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	if len(results) != 1 || results[0].Value != 1 || results[0].Labels["instance"] != "10.0.0.1:9100" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestPrometheusClient_Query_Error(t *testing.T) {
+	t.Run("error_body", func(t *testing.T) {
+		// Prometheus answers bad queries with 400 and the error in the JSON body
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"1:8: parse error: unexpected end of input"}`))
+		}))
+		defer server.Close()
+
+		client := NewPrometheusClient(&config.VictoriaMetricsConfig{Endpoint: server.URL}, nil, testLogger())
+		_, err := client.Query(context.Background(), "invalid{")
+		if err == nil {
+			t.Fatal("expected error for 400 response")
+		}
+		for _, expected := range []string{"Prometheus API error", "bad_data", "400", "parse error"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("error %q should contain %q", err, expected)
+			}
+		}
+	})
+
+	t.Run("plain_body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("bad gateway"))
+		}))
+		defer server.Close()
+
+		retryCfg := &config.RetryConfig{MaxRetries: 0} // Disable retries for test
+		client := NewPrometheusClient(&config.VictoriaMetricsConfig{Endpoint: server.URL}, retryCfg, testLogger())
+		_, err := client.Query(context.Background(), "up")
+		if err == nil || !strings.Contains(err.Error(), "502") || !strings.Contains(err.Error(), "bad gateway") {
+			t.Errorf("error = %v, want status and body", err)
+		}
+	})
+}
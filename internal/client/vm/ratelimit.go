@@ -0,0 +1,79 @@
+package vm
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"inspection-tool/internal/config"
+)
+
+// rateLimiter is a token bucket: tokens refill at rate per second up to burst and every
+// request takes one. When the bucket is empty, requests reserve the next tokens and wait
+// for them in order, so concurrent collectors share the configured rate.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64          // Tokens added per second
+	burst  float64          // Bucket capacity
+	tokens float64          // Available tokens, negative when reserved ahead
+	last   time.Time        // Last refill
+	now    func() time.Time // Clock, replaced in tests
+}
+
+// newRateLimiter creates the limiter configured by cfg, or returns nil when the rate is not
+// limited. The burst defaults to the rate per second, at least one request.
+func newRateLimiter(cfg config.RateLimitConfig) *rateLimiter {
+	if cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := float64(cfg.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(cfg.RequestsPerSecond))
+	}
+	l := &rateLimiter{
+		rate:   cfg.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+	}
+	l.last = l.now()
+	return l
+}
+
+// Wait blocks until a request may be sent, or returns the context error when ctx ends first.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back to the requests still waiting
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
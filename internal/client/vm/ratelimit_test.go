@@ -0,0 +1,94 @@
+package vm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/config"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	if l := newRateLimiter(config.RateLimitConfig{}); l != nil {
+		t.Error("expected no limiter without requests_per_second")
+	}
+	if l := newRateLimiter(config.RateLimitConfig{RequestsPerSecond: 0.5}); l.burst != 1 {
+		t.Errorf("burst = %v, want at least 1", l.burst)
+	}
+	if l := newRateLimiter(config.RateLimitConfig{RequestsPerSecond: 20, Burst: 5}); l.burst != 5 {
+		t.Errorf("burst = %v, want the configured 5", l.burst)
+	}
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newRateLimiter(config.RateLimitConfig{RequestsPerSecond: 10, Burst: 2})
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	// The burst passes immediately, then requests are spaced by 1/rate
+	want := []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i, w := range want {
+		if got := l.reserve(); got != w {
+			t.Errorf("reserve #%d = %v, want %v", i+1, got, w)
+		}
+	}
+
+	// After a pause the bucket refills up to the burst only
+	now = now.Add(10 * time.Second)
+	for i := 0; i < 2; i++ {
+		if got := l.reserve(); got != 0 {
+			t.Errorf("reserve after pause = %v, want 0", got)
+		}
+	}
+	if got := l.reserve(); got != 100*time.Millisecond {
+		t.Errorf("reserve beyond burst = %v, want 100ms", got)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l := newRateLimiter(config.RateLimitConfig{RequestsPerSecond: 0.1, Burst: 1})
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected the context error while waiting 10s for a token")
+	}
+	if l.tokens < -0.01 {
+		t.Errorf("tokens = %v, want the reservation given back", l.tokens)
+	}
+}
+
+func TestClients_RateLimit(t *testing.T) {
+	for _, metricsType := range []string{config.MetricsTypeVictoriaMetrics, config.MetricsTypePrometheus} {
+		t.Run(metricsType, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, QueryResponse{Status: "success", Data: QueryData{ResultType: "vector"}})
+			}))
+			defer server.Close()
+
+			cfg := &config.VictoriaMetricsConfig{
+				Type:      metricsType,
+				Endpoint:  server.URL,
+				RateLimit: config.RateLimitConfig{RequestsPerSecond: 20, Burst: 1},
+			}
+			source := NewMetricsSource(cfg, nil, testLogger())
+
+			start := time.Now()
+			for i := 0; i < 4; i++ {
+				if _, err := source.Query(context.Background(), "up"); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			// One request passes immediately, the three others wait 50ms each
+			if elapsed := time.Since(start); elapsed < 140*time.Millisecond {
+				t.Errorf("4 queries at 20 req/s took %v, want at least 150ms", elapsed)
+			}
+		})
+	}
+}
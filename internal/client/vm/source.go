@@ -0,0 +1,57 @@
+package vm
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+)
+
+// MetricsSource is a metrics backend answering PromQL instant queries: VictoriaMetrics
+// (Client) or a native Prometheus server (PrometheusClient). Collectors query through it,
+// so the backend is selected per configuration.
+type MetricsSource interface {
+	// Query executes an instant query.
+	Query(ctx context.Context, query string) (*QueryResponse, error)
+	// QueryWithFilter executes an instant query with optional host filtering.
+	QueryWithFilter(ctx context.Context, query string, filter *HostFilter) (*QueryResponse, error)
+	// QueryResults executes an instant query and returns parsed results.
+	QueryResults(ctx context.Context, query string) ([]QueryResult, error)
+	// QueryResultsWithFilter executes an instant query with optional host filtering and
+	// returns parsed results.
+	QueryResultsWithFilter(ctx context.Context, query string, filter *HostFilter) ([]QueryResult, error)
+	// QueryByIdent executes a query and returns results grouped by host identifier.
+	QueryByIdent(ctx context.Context, query string) (map[string]QueryResult, error)
+	// QueryByIdentWithFilter executes a query with optional host filtering and returns
+	// results grouped by host identifier.
+	QueryByIdentWithFilter(ctx context.Context, query string, filter *HostFilter) (map[string]QueryResult, error)
+}
+
+var (
+	_ MetricsSource = (*Client)(nil)
+	_ MetricsSource = (*PrometheusClient)(nil)
+)
+
+// NewMetricsSource creates the client of the configured metrics backend.
+// The backend defaults to VictoriaMetrics when cfg.Type is empty. With cfg.Cache enabled the
+// client is wrapped in a CachedSource; when the cache directory cannot be created the
+// client queries the backend directly.
+func NewMetricsSource(cfg *config.VictoriaMetricsConfig, retryCfg *config.RetryConfig, logger zerolog.Logger) MetricsSource {
+	var source MetricsSource
+	if cfg.Type == config.MetricsTypePrometheus {
+		source = NewPrometheusClient(cfg, retryCfg, logger)
+	} else {
+		source = NewClient(cfg, retryCfg, logger)
+	}
+
+	if !cfg.Cache.Enabled {
+		return source
+	}
+	cached, err := NewCachedSource(source, cfg, logger)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to create the query cache directory, querying without cache")
+		return source
+	}
+	return cached
+}
@@ -2,8 +2,11 @@
 package vm
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 )
 
 // QueryResponse represents the API response from /api/v1/query endpoint.
@@ -37,6 +40,45 @@ func (d *QueryData) IsMatrix() bool {
 	return d.ResultType == "matrix"
 }
 
+// IsScalar returns true if the result type is "scalar".
+func (d *QueryData) IsScalar() bool {
+	return d.ResultType == "scalar"
+}
+
+// UnmarshalJSON decodes the result data leniently: a malformed sample is kept without value
+// (and skipped when parsed) instead of failing the whole response, and a scalar result
+// ([timestamp, value]) becomes a single sample without labels.
+func (d *QueryData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	d.ResultType = raw.ResultType
+	d.Result = nil
+	if d.IsScalar() {
+		d.Result = []Sample{{Metric: Metric{}, Value: decodeSampleValue(raw.Result)}}
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw.Result, &items); err != nil {
+		return nil // Not a sample list: no results
+	}
+	d.Result = make([]Sample, 0, len(items))
+	for _, item := range items {
+		var sample Sample
+		if err := sample.UnmarshalJSON(item); err != nil {
+			return err
+		}
+		d.Result = append(d.Result, sample)
+	}
+	return nil
+}
+
 // Sample represents a single sample in the query result.
 // For instant queries (vector), use Value field.
 // For range queries (matrix), use Values field.
@@ -46,6 +88,70 @@ type Sample struct {
 	Values []SampleValue `json:"values"` // 范围查询值列表 [[timestamp, value], ...]
 }
 
+// UnmarshalJSON decodes a sample leniently, since one malformed exporter must not abort the
+// collection of a whole metric: label values that are not strings are converted to text and a
+// malformed value is left empty, so the sample is skipped as NaN.
+func (s *Sample) UnmarshalJSON(data []byte) error {
+	*s = Sample{Metric: Metric{}}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil // Not an object: sample without labels and value
+	}
+
+	var labels map[string]interface{}
+	if err := json.Unmarshal(fields["metric"], &labels); err == nil {
+		for name, value := range labels {
+			switch v := value.(type) {
+			case string:
+				s.Metric[name] = v
+			case nil:
+				s.Metric[name] = ""
+			default:
+				s.Metric[name] = fmt.Sprint(v)
+			}
+		}
+	}
+
+	if raw, ok := fields["value"]; ok {
+		s.Value = decodeSampleValue(raw)
+	}
+	if raw, ok := fields["values"]; ok {
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err == nil {
+			s.Values = make([]SampleValue, 0, len(items))
+			for _, item := range items {
+				s.Values = append(s.Values, decodeSampleValue(item))
+			}
+		}
+	}
+	return nil
+}
+
+// decodeSampleValue decodes a [timestamp, value] pair. Anything else yields an empty pair,
+// which IsNaN reports as invalid.
+func decodeSampleValue(data []byte) SampleValue {
+	var pair []interface{}
+	if err := json.Unmarshal(data, &pair); err != nil || len(pair) != 2 {
+		return SampleValue{}
+	}
+	return SampleValue{pair[0], pair[1]}
+}
+
+// LatestValue returns the value of the sample: the instant value for vectors, or the last
+// valid value of the series for matrices. ok is false when the sample has no valid value.
+func (s *Sample) LatestValue() (SampleValue, bool) {
+	if !s.Value.IsNaN() {
+		return s.Value, true
+	}
+	for i := len(s.Values) - 1; i >= 0; i-- {
+		if !s.Values[i].IsNaN() {
+			return s.Values[i], true
+		}
+	}
+	return SampleValue{}, false
+}
+
 // GetIdent returns the host identifier from metric labels.
 // It tries "ident" first, then "host", then "instance".
 func (s *Sample) GetIdent() string {
@@ -103,6 +209,7 @@ func (v SampleValue) TimestampUnix() int64 {
 }
 
 // Value returns the sample value as float64.
+// Values are usually strings ("12.5", "1.5e+09", "NaN", "+Inf"); surrounding spaces are ignored.
 // Returns 0 and error if parsing fails.
 func (v SampleValue) Value() (float64, error) {
 	if len(v) < 2 {
@@ -112,7 +219,7 @@ func (v SampleValue) Value() (float64, error) {
 	switch val := v[1].(type) {
 	case string:
 		// Prometheus API 返回的值是字符串格式
-		f, err := strconv.ParseFloat(val, 64)
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
 		if err != nil {
 			return 0, fmt.Errorf("failed to parse value %q: %w", val, err)
 		}
@@ -132,16 +239,11 @@ func (v SampleValue) MustValue() float64 {
 }
 
 // IsNaN returns true if the value is NaN, Inf, or invalid.
-// In Prometheus, NaN is represented as the string "NaN".
+// In Prometheus, NaN is represented as the string "NaN" and infinities as "+Inf" / "-Inf";
+// other spellings ("nan", "Infinity") and unparsable values are treated the same way.
 func (v SampleValue) IsNaN() bool {
-	// Check if value element is nil or not present
-	if v[1] == nil {
-		return true
-	}
-	if str, ok := v[1].(string); ok {
-		return str == "NaN" || str == "+Inf" || str == "-Inf"
-	}
-	return false
+	f, err := v.Value()
+	return err != nil || math.IsNaN(f) || math.IsInf(f, 0)
 }
 
 // QueryResult is a convenience wrapper for processing query results.
@@ -152,23 +254,26 @@ type QueryResult struct {
 }
 
 // ParseQueryResults converts QueryResponse to a slice of QueryResult.
-// This is a convenience function for processing vector query results.
+// Vector results use the instant value of each sample; matrix results (e.g. an exporter or
+// recording rule returning a range) use the last valid value of each series; a scalar result
+// becomes one result without labels. Samples without a valid value (NaN, Inf, malformed) are skipped.
 func ParseQueryResults(resp *QueryResponse) ([]QueryResult, error) {
 	if !resp.IsSuccess() {
 		return nil, fmt.Errorf("query failed: %s - %s", resp.ErrorType, resp.Error)
 	}
 
-	if !resp.Data.IsVector() {
-		return nil, fmt.Errorf("unexpected result type: %s (expected vector)", resp.Data.ResultType)
+	if !resp.Data.IsVector() && !resp.Data.IsMatrix() && !resp.Data.IsScalar() {
+		return nil, fmt.Errorf("unexpected result type: %s (expected vector, matrix or scalar)", resp.Data.ResultType)
 	}
 
 	results := make([]QueryResult, 0, len(resp.Data.Result))
 	for _, sample := range resp.Data.Result {
-		if sample.Value.IsNaN() {
-			continue // 跳过 NaN 值
+		sampleValue, ok := sample.LatestValue()
+		if !ok {
+			continue // 跳过 NaN / Inf / 无法解析的值
 		}
 
-		value, err := sample.Value.Value()
+		value, err := sampleValue.Value()
 		if err != nil {
 			continue // 跳过无法解析的值
 		}
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 )
 
@@ -350,18 +351,41 @@ func TestParseQueryResults(t *testing.T) {
 		}
 	})
 
-	t.Run("non-vector result type", func(t *testing.T) {
+	t.Run("unsupported result type", func(t *testing.T) {
 		resp := &QueryResponse{
 			Status: "success",
 			Data: QueryData{
-				ResultType: "matrix",
+				ResultType: "string",
 				Result:     []Sample{},
 			},
 		}
 
 		_, err := ParseQueryResults(resp)
 		if err == nil {
-			t.Error("ParseQueryResults() expected error for non-vector result")
+			t.Error("ParseQueryResults() expected error for string result")
+		}
+	})
+
+	t.Run("matrix uses last valid value", func(t *testing.T) {
+		resp := &QueryResponse{
+			Status: "success",
+			Data: QueryData{
+				ResultType: "matrix",
+				Result: []Sample{
+					{
+						Metric: Metric{"ident": "host1"},
+						Values: []SampleValue{{1702451174.0, "10"}, {1702451234.0, "20"}, {1702451294.0, "NaN"}},
+					},
+				},
+			},
+		}
+
+		results, err := ParseQueryResults(resp)
+		if err != nil {
+			t.Fatalf("ParseQueryResults() error = %v", err)
+		}
+		if len(results) != 1 || results[0].Value != 20 {
+			t.Errorf("ParseQueryResults() = %v, want host1 = 20", results)
 		}
 	})
 
@@ -544,3 +568,75 @@ func TestQueryResponse_ErrorParsing(t *testing.T) {
 		t.Errorf("error = %q, want %q", resp.Error, "invalid query expression")
 	}
 }
+
+// TestQueryData_UnmarshalJSON_Scalar tests that a scalar result becomes one sample.
+func TestQueryData_UnmarshalJSON_Scalar(t *testing.T) {
+	var resp QueryResponse
+	data := `{"status":"success","data":{"resultType":"scalar","result":[1702451234.567,"3.5"]}}`
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	results, err := ParseQueryResults(&resp)
+	if err != nil {
+		t.Fatalf("ParseQueryResults() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Value != 3.5 || results[0].Ident != "" {
+		t.Errorf("ParseQueryResults() = %v, want one result 3.5 without ident", results)
+	}
+}
+
+// FuzzSampleValue checks that a value is either rejected by IsNaN or parses to a finite number.
+func FuzzSampleValue(f *testing.F) {
+	for _, seed := range []string{
+		"12.5", "1.5e+09", "2E-3", "NaN", "+Inf", "-Inf", "nan", "Infinity", " 42 ", "", "abc", "1e400", "0x1p-2",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		v := SampleValue{1702451234.567, s}
+		if v.IsNaN() {
+			return
+		}
+		got, err := v.Value()
+		if err != nil {
+			t.Fatalf("Value(%q) error = %v although IsNaN() = false", s, err)
+		}
+		if math.IsNaN(got) || math.IsInf(got, 0) {
+			t.Fatalf("Value(%q) = %v although IsNaN() = false", s, got)
+		}
+	})
+}
+
+// FuzzParseQueryResults checks that any decodable response parses without panicking and
+// never yields NaN or Inf values.
+func FuzzParseQueryResults(f *testing.F) {
+	for _, seed := range []string{
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"ident":"h1"},"value":[1702451234,"1"]}]}}`,
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"ident":"h1"},"value":[1702451234,"NaN"]}]}}`,
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"ident":1},"value":"x"},"y",null]}}`,
+		`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1,"1e3"],[2,"+Inf"]]}]}}`,
+		`{"status":"success","data":{"resultType":"scalar","result":[1702451234,"-Inf"]}}`,
+		`{"status":"success","data":{"resultType":"vector","result":{}}}`,
+		`{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var resp QueryResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return
+		}
+		results, err := ParseQueryResults(&resp)
+		if err != nil {
+			return
+		}
+		for _, r := range results {
+			if math.IsNaN(r.Value) || math.IsInf(r.Value, 0) {
+				t.Fatalf("ParseQueryResults() returned %v for %s", r.Value, data)
+			}
+		}
+	})
+}
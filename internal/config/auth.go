@@ -0,0 +1,21 @@
+package config
+
+// AuthPluginConfig selects the auth plugin signing every request of a datasource, for
+// endpoints that require signed requests such as cloud-managed Prometheus (AK/SK signing).
+// The built-in plugin is sigv4 (AWS Signature Version 4); other plugins are registered in
+// code and read their settings from Params.
+type AuthPluginConfig struct {
+	Type         string            `mapstructure:"type"`          // 插件名称（内置 sigv4），为空时不签名
+	Region       string            `mapstructure:"region"`        // 地域（如 us-east-1）
+	Service      string            `mapstructure:"service"`       // 签名服务名（sigv4 默认 aps，即 Amazon Managed Service for Prometheus）
+	AccessKey    string            `mapstructure:"access_key"`    // Access Key（AK），为空时读取插件约定的环境变量
+	SecretKey    string            `mapstructure:"secret_key"`    // Secret Key（SK）
+	SessionToken string            `mapstructure:"session_token"` // 临时凭证的 Session Token（可选）
+	Params       map[string]string `mapstructure:"params"`        // 自定义插件的附加参数
+}
+
+// IsZero reports whether no auth plugin is configured, in which case requests are sent
+// unsigned.
+func (c AuthPluginConfig) IsZero() bool {
+	return c.Type == ""
+}
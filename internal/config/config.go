@@ -1,26 +1,47 @@
 // Package config provides configuration management for the inspection tool.
 package config
 
-import "time"
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Config is the root configuration structure for the inspection tool.
 type Config struct {
-	Datasources DatasourcesConfig     `mapstructure:"datasources" validate:"required"`
-	Inspection  InspectionConfig      `mapstructure:"inspection"`
-	Thresholds  ThresholdsConfig      `mapstructure:"thresholds"`
-	Report      ReportConfig          `mapstructure:"report"`
-	Logging     LoggingConfig         `mapstructure:"logging"`
-	HTTP        HTTPConfig            `mapstructure:"http"`
-	MySQL       MySQLInspectionConfig  `mapstructure:"mysql"`
-	Redis       RedisInspectionConfig  `mapstructure:"redis"`
-	Nginx       NginxInspectionConfig  `mapstructure:"nginx"`
-	Tomcat      TomcatInspectionConfig `mapstructure:"tomcat"`
+	Datasources DatasourcesConfig          `mapstructure:"datasources" validate:"required"`
+	Inspection  InspectionConfig           `mapstructure:"inspection"`
+	Thresholds  ThresholdsConfig           `mapstructure:"thresholds"`
+	Report      ReportConfig               `mapstructure:"report"`
+	Logging     LoggingConfig              `mapstructure:"logging"`
+	HTTP        HTTPConfig                 `mapstructure:"http"`
+	MySQL       MySQLInspectionConfig      `mapstructure:"mysql"`
+	Redis       RedisInspectionConfig      `mapstructure:"redis"`
+	Nginx       NginxInspectionConfig      `mapstructure:"nginx"`
+	Tomcat      TomcatInspectionConfig     `mapstructure:"tomcat"`
+	Cassandra   CassandraInspectionConfig  `mapstructure:"cassandra"`
+	Monitoring  MonitoringInspectionConfig `mapstructure:"monitoring"`
+	Storage     StorageInspectionConfig    `mapstructure:"storage"`
+	LogChecks   LogChecksInspectionConfig  `mapstructure:"log_checks"`
+	LVS         LVSInspectionConfig        `mapstructure:"lvs"`
+	Windows     WindowsInspectionConfig    `mapstructure:"windows"`
+	AD          ADInspectionConfig         `mapstructure:"ad"`
+	Cloud       CloudInspectionConfig      `mapstructure:"cloud"`
+	Firewall    FirewallInspectionConfig   `mapstructure:"firewall"`
+	Distributed DistributedConfig          `mapstructure:"distributed"`
+	Runtime     RuntimeConfig              `mapstructure:"runtime"`
 }
 
 // DatasourcesConfig contains configurations for data sources.
 type DatasourcesConfig struct {
 	N9E             N9EConfig             `mapstructure:"n9e" validate:"required"`
 	VictoriaMetrics VictoriaMetricsConfig `mapstructure:"victoriametrics" validate:"required"`
+	Logs            LogsConfig            `mapstructure:"logs"`         // 可选，用于告警日志摘录和日志巡检
+	Alertmanager    AlertmanagerConfig    `mapstructure:"alertmanager"` // 可选，综合报告列出正在触发的 Alertmanager 告警
+	CMDB            CMDBConfig            `mapstructure:"cmdb"`         // 可选，补充主机负责人、应用、位置与维护状态
 }
 
 // N9EConfig contains configuration for N9E (Nightingale) API.
@@ -29,50 +50,507 @@ type N9EConfig struct {
 	Token    string        `mapstructure:"token" validate:"required"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 	Query    string        `mapstructure:"query"` // Host filter query (e.g., "items=短剧项目")
+
+	ActiveAlerts      bool `mapstructure:"active_alerts"`       // 报告中列出巡检主机正在触发的 N9E 告警（默认: true）
+	BusinessGroupSync bool `mapstructure:"business_group_sync"` // 同步业务组树：按业务组归类主机、校验并展开 host_filter.business_groups（默认: true）
+
+	// Authentication of a reverse proxy in front of N9E (the API token is sent as X-User-Token)
+	Username    string `mapstructure:"username"`                                       // Basic 认证用户名
+	Password    string `mapstructure:"password" validate:"required_with=Username"`     // Basic 认证密码
+	BearerToken string `mapstructure:"bearer_token" validate:"excluded_with=Username"` // Bearer Token，与 Basic 认证二选一
+
+	TLS      TLSConfig `mapstructure:"tls"`       // HTTPS 证书校验与 mTLS
+	ProxyURL string    `mapstructure:"proxy_url"` // HTTP / SOCKS5 代理（如 socks5://10.0.0.1:1080），为空时使用 HTTP_PROXY 等环境变量
+
+	Headers    map[string]string `mapstructure:"headers"`     // 附加请求头
+	AuthPlugin AuthPluginConfig  `mapstructure:"auth_plugin"` // 请求签名插件（如云厂商 AK/SK 签名）
 }
 
-// VictoriaMetricsConfig contains configuration for VictoriaMetrics API.
+// Metrics backend types.
+const (
+	MetricsTypeVictoriaMetrics = "victoriametrics"
+	MetricsTypePrometheus      = "prometheus"
+)
+
+// DefaultTenantHeader is the tenant header of Cortex and Mimir.
+const DefaultTenantHeader = "X-Scope-OrgID"
+
+// VictoriaMetricsConfig contains configuration for the metrics query API: VictoriaMetrics, or
+// a native Prometheus server when Type is "prometheus".
 type VictoriaMetricsConfig struct {
+	Type     string        `mapstructure:"type" validate:"omitempty,oneof=victoriametrics prometheus"`
 	Endpoint string        `mapstructure:"endpoint" validate:"required,url"`
 	Timeout  time.Duration `mapstructure:"timeout"`
+
+	// Authentication (e.g. VictoriaMetrics behind vmauth)
+	Username    string `mapstructure:"username"`                                       // Basic 认证用户名
+	Password    string `mapstructure:"password" validate:"required_with=Username"`     // Basic 认证密码
+	BearerToken string `mapstructure:"bearer_token" validate:"excluded_with=Username"` // Bearer Token，与 Basic 认证二选一
+
+	TLS      TLSConfig `mapstructure:"tls"`       // HTTPS 证书校验与 mTLS
+	ProxyURL string    `mapstructure:"proxy_url"` // HTTP / SOCKS5 代理（如 socks5://10.0.0.1:1080），为空时使用 HTTP_PROXY 等环境变量
+
+	// Multi-tenant query frontends (Cortex / Mimir / Thanos Query)
+	TenantID        string            `mapstructure:"tenant_id"`        // 租户 ID，通过 TenantHeader 发送
+	TenantHeader    string            `mapstructure:"tenant_header"`    // 租户请求头（默认 X-Scope-OrgID）
+	Headers         map[string]string `mapstructure:"headers"`          // 附加请求头
+	PartialResponse *bool             `mapstructure:"partial_response"` // Thanos partial_response 参数，未配置时不发送
+
+	AuthPlugin AuthPluginConfig `mapstructure:"auth_plugin"` // 请求签名插件（如云托管 Prometheus 的 AK/SK 签名）
+	Cache      QueryCacheConfig `mapstructure:"cache"`       // 查询结果磁盘缓存
+	RateLimit  RateLimitConfig  `mapstructure:"rate_limit"`  // 查询限速（令牌桶）
+
+	Grafana GrafanaProxyConfig `mapstructure:"grafana"` // 经 Grafana 数据源代理查询，此时 endpoint 为 Grafana 地址
+}
+
+// GrafanaProxyConfig configures querying the metrics backend through the datasource proxy of
+// Grafana (/api/datasources/proxy/:id), for environments where only Grafana is reachable
+// from the inspection host. Grafana authenticates the API key and forwards the queries with
+// the credentials of the datasource.
+type GrafanaProxyConfig struct {
+	DatasourceID  int    `mapstructure:"datasource_id" validate:"gte=0"`                              // 数据源 ID，与 datasource_uid 二选一
+	DatasourceUID string `mapstructure:"datasource_uid" validate:"excluded_with=DatasourceID"`        // 数据源 UID（Grafana 9+）
+	APIKey        string `mapstructure:"api_key" validate:"required_with=DatasourceID DatasourceUID"` // Grafana API Key 或服务账号 Token
+	OrgID         int64  `mapstructure:"org_id" validate:"gte=0"`                                     // 组织 ID（X-Grafana-Org-Id），0 时使用 API Key 所属组织
+}
+
+// Enabled reports whether queries go through the Grafana datasource proxy.
+func (c GrafanaProxyConfig) Enabled() bool {
+	return c.DatasourceID > 0 || c.DatasourceUID != ""
+}
+
+// QueryURL returns the base URL of the metrics API: the endpoint, or the datasource proxy
+// path under it when querying through Grafana.
+func (c VictoriaMetricsConfig) QueryURL() string {
+	switch {
+	case c.Grafana.DatasourceUID != "":
+		return strings.TrimRight(c.Endpoint, "/") + "/api/datasources/proxy/uid/" + url.PathEscape(c.Grafana.DatasourceUID)
+	case c.Grafana.DatasourceID > 0:
+		return strings.TrimRight(c.Endpoint, "/") + "/api/datasources/proxy/" + strconv.Itoa(c.Grafana.DatasourceID)
+	default:
+		return c.Endpoint
+	}
+}
+
+// RateLimitConfig configures the token bucket limiting the queries sent to a datasource, so
+// inspections of production monitoring clusters stay below their query-overload protection.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second" validate:"gte=0"` // 每秒请求数，0 表示不限速（默认: 0）
+	Burst             int     `mapstructure:"burst" validate:"gte=0"`               // 突发请求数，0 时取每秒请求数（至少为 1）
+}
+
+// QueryCacheConfig configures the optional on-disk cache of metric query results, so
+// re-running the report generation within a short window does not query the backend again.
+type QueryCacheConfig struct {
+	Enabled bool          `mapstructure:"enabled"` // 启用查询结果缓存（默认: false）
+	Dir     string        `mapstructure:"dir"`     // 缓存目录，为空时使用用户缓存目录下的 inspection-tool/queries
+	TTL     time.Duration `mapstructure:"ttl"`     // 缓存有效期（默认: 10m）
+}
+
+// DefaultIdentityLabels returns the host identity label chain used when
+// inspection.identity_labels is not configured. Categraf series written to VictoriaMetrics
+// carry the N9E ident label; series scraped by Prometheus are only guaranteed to carry
+// instance (e.g. "10.0.0.1:9100"), so it comes first.
+func (c VictoriaMetricsConfig) DefaultIdentityLabels() []string {
+	if c.Type == MetricsTypePrometheus {
+		return []string{"instance", "host", "ident"}
+	}
+	return []string{"ident", "host", "instance"}
+}
+
+// Log backend types.
+const (
+	LogsTypeLoki         = "loki"
+	LogsTypeVictoriaLogs = "victorialogs"
+)
+
+// LogsConfig contains configuration for the log query backend (Loki or VictoriaLogs).
+// It is optional and only used when a module enables log excerpts or log checks are enabled.
+type LogsConfig struct {
+	Type     string        `mapstructure:"type" validate:"omitempty,oneof=loki victorialogs"`
+	Endpoint string        `mapstructure:"endpoint" validate:"omitempty,url"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	TLS      TLSConfig     `mapstructure:"tls"`       // HTTPS 证书校验与 mTLS
+	ProxyURL string        `mapstructure:"proxy_url"` // HTTP / SOCKS5 代理，为空时使用 HTTP_PROXY 等环境变量
+
+	Headers    map[string]string `mapstructure:"headers"`     // 附加请求头
+	AuthPlugin AuthPluginConfig  `mapstructure:"auth_plugin"` // 请求签名插件（如云厂商 AK/SK 签名）
+}
+
+// AlertmanagerConfig contains configuration for the Alertmanager API. It is optional: without
+// an endpoint no Alertmanager alerts are queried.
+type AlertmanagerConfig struct {
+	Endpoint string        `mapstructure:"endpoint" validate:"omitempty,url"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Filters  []string      `mapstructure:"filters"` // 标签匹配器（如 env="prod"），仅列出匹配的告警
+
+	// Authentication (e.g. Alertmanager behind a reverse proxy)
+	Username    string `mapstructure:"username"`                                       // Basic 认证用户名
+	Password    string `mapstructure:"password" validate:"required_with=Username"`     // Basic 认证密码
+	BearerToken string `mapstructure:"bearer_token" validate:"excluded_with=Username"` // Bearer Token，与 Basic 认证二选一
+
+	TLS      TLSConfig `mapstructure:"tls"`       // HTTPS 证书校验与 mTLS
+	ProxyURL string    `mapstructure:"proxy_url"` // HTTP / SOCKS5 代理，为空时使用 HTTP_PROXY 等环境变量
+
+	Headers    map[string]string `mapstructure:"headers"`     // 附加请求头
+	AuthPlugin AuthPluginConfig  `mapstructure:"auth_plugin"` // 请求签名插件（如云厂商 AK/SK 签名）
+}
+
+// CMDBConfig configures the CMDB the host metadata is enriched from: owner, application,
+// rack / location and maintenance status, matched to the hosts by hostname or IP. The
+// built-in http source reads a JSON host list; other sources are registered in code.
+type CMDBConfig struct {
+	Type     string        `mapstructure:"type"`                              // 数据源类型（内置 http，默认: http）
+	Endpoint string        `mapstructure:"endpoint" validate:"omitempty,url"` // 主机列表接口地址，为空时不启用
+	Timeout  time.Duration `mapstructure:"timeout"`
+
+	// Authentication of the CMDB API
+	Username    string `mapstructure:"username"`                                       // Basic 认证用户名
+	Password    string `mapstructure:"password" validate:"required_with=Username"`     // Basic 认证密码
+	BearerToken string `mapstructure:"bearer_token" validate:"excluded_with=Username"` // Bearer Token，与 Basic 认证二选一
+
+	TLS      TLSConfig `mapstructure:"tls"`       // HTTPS 证书校验与 mTLS
+	ProxyURL string    `mapstructure:"proxy_url"` // HTTP / SOCKS5 代理，为空时使用 HTTP_PROXY 等环境变量
+
+	Headers    map[string]string `mapstructure:"headers"`     // 附加请求头
+	AuthPlugin AuthPluginConfig  `mapstructure:"auth_plugin"` // 请求签名插件（如云厂商 AK/SK 签名）
+
+	ResultsPath       string            `mapstructure:"results_path"`       // 主机列表在响应 JSON 中的路径（如 data.items），为空时响应本身为数组
+	Fields            CMDBFieldsConfig  `mapstructure:"fields"`             // 主机记录的字段映射
+	MaintenanceValues []string          `mapstructure:"maintenance_values"` // 维护状态字段表示维护中的取值（不区分大小写，布尔 true 与非零数值同样视为维护中）
+	Params            map[string]string `mapstructure:"params"`             // 自定义数据源的附加参数
+}
+
+// CMDBFieldsConfig maps the attributes of a host to the fields of a CMDB record. A field may
+// be a dotted path into nested objects (e.g. attrs.rack).
+type CMDBFieldsConfig struct {
+	Hostname    string `mapstructure:"hostname"`    // 主机名字段（默认: hostname）
+	IP          string `mapstructure:"ip"`          // IP 字段（默认: ip）
+	Owner       string `mapstructure:"owner"`       // 负责人字段（默认: owner）
+	Application string `mapstructure:"application"` // 所属应用字段（默认: application）
+	Location    string `mapstructure:"location"`    // 机房 / 机柜位置字段（默认: location）
+	Maintenance string `mapstructure:"maintenance"` // 维护状态字段（默认: maintenance）
+}
+
+// LogExcerptConfig defines how to fetch recent log lines for instances with critical alerts.
+// Query is a Go template rendered per instance with .Hostname, .IP, .Port, .Container and .LogPath.
+type LogExcerptConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Query    string        `mapstructure:"query"`    // LogQL (Loki) 或 LogsQL (VictoriaLogs) 查询模板
+	Lines    int           `mapstructure:"lines"`    // 最多摘录的日志行数
+	Lookback time.Duration `mapstructure:"lookback"` // 日志查询时间范围
 }
 
 // InspectionConfig contains configurations for inspection behavior.
 type InspectionConfig struct {
-	Concurrency int           `mapstructure:"concurrency" validate:"gte=1,lte=100"`
-	HostTimeout time.Duration `mapstructure:"host_timeout"`
-	HostFilter  HostFilter    `mapstructure:"host_filter"`
+	Concurrency    int           `mapstructure:"concurrency" validate:"gte=1,lte=100"`
+	HostTimeout    time.Duration `mapstructure:"host_timeout"`
+	HostFilter     HostFilter    `mapstructure:"host_filter"`
+	IdentityLabels []string      `mapstructure:"identity_labels" validate:"unique,dive,required"` // 主机标识标签解析顺序，最后按 IP 匹配
+
+	// 同一主机由多个采集器（如 node_exporter 与 categraf）上报时的来源优先规则
+	SourcePreference SourcePreference `mapstructure:"source_preference"`
+
+	// Window of the host metrics with a range_function (--start / --end override it per run)
+	RangeWindow time.Duration `mapstructure:"range_window" validate:"gte=0"` // 范围聚合窗口（默认 24h）
+	RangeEnd    time.Time     `mapstructure:"-"`                             // 窗口结束时间（--end），零值为查询时刻
+
+	// 无监控数据的主机与待定巡检项（如 NTP 检查）经 SSH 执行白名单命令补采
+	SSHFallback SSHFallbackConfig `mapstructure:"ssh_fallback"`
+
+	// 每次巡检执行的模块（命令行 --only / --skip 覆盖）
+	Modules ModuleSelection `mapstructure:"modules"`
+}
+
+// ModuleNames lists the inspection modules in report order, as named by --only / --skip,
+// inspection.modules and the presets.
+var ModuleNames = []string{
+	"host", "mysql", "redis", "nginx", "tomcat", "cassandra", "monitoring",
+	"storage", "log_checks", "lvs", "windows", "ad", "cloud", "firewall",
+}
+
+// ModuleSelection selects the modules of a run. A selected module other than host must
+// still be enabled in its own section to be inspected.
+type ModuleSelection struct {
+	Only []string `mapstructure:"only" validate:"unique,dive,oneof=host mysql redis nginx tomcat cassandra monitoring storage log_checks lvs windows ad cloud firewall"` // 仅执行的模块（为空时执行全部已启用模块）
+	Skip []string `mapstructure:"skip" validate:"unique,dive,oneof=host mysql redis nginx tomcat cassandra monitoring storage log_checks lvs windows ad cloud firewall"` // 跳过的模块
+}
+
+// Runs reports whether the module is selected: listed in Only (or Only empty) and not
+// listed in Skip.
+func (s ModuleSelection) Runs(module string) bool {
+	return (len(s.Only) == 0 || slices.Contains(s.Only, module)) && !slices.Contains(s.Skip, module)
+}
+
+// CheckNames returns an error naming the first unknown module of the selection.
+func (s ModuleSelection) CheckNames() error {
+	for _, module := range append(slices.Clone(s.Only), s.Skip...) {
+		if !slices.Contains(ModuleNames, module) {
+			return fmt.Errorf("unknown module %q, available: %s", module, strings.Join(ModuleNames, ", "))
+		}
+	}
+	return nil
+}
+
+// SSH fallback checks, each a fixed whitelisted command run on the host.
+const (
+	SSHCheckDF             = "df"            // 磁盘使用率、总量与可用空间
+	SSHCheckUptime         = "uptime"        // 运行时间与系统负载
+	SSHCheckChronyc        = "chronyc"       // NTP 时间偏差
+	SSHCheckPasswordExpiry = "passwd_expiry" // 密码过期天数
+)
+
+// SSHFallbackConfig configures the SSH fallback collector. For the hosts whose metrics are
+// missing from the metrics backend, and for the pending metrics the backend cannot provide,
+// the whitelisted checks run over SSH and fill the values instead of N/A.
+type SSHFallbackConfig struct {
+	Enabled bool          `mapstructure:"enabled"`                         // 启用 SSH 补采（默认: false）
+	User    string        `mapstructure:"user"`                            // 登录用户
+	Port    int           `mapstructure:"port" validate:"gte=0,lte=65535"` // SSH 端口（默认: 22）
+	Timeout time.Duration `mapstructure:"timeout" validate:"gte=0"`        // 单台主机连接与执行超时（默认: 10s）
+
+	// 认证方式：私钥与密码至少配置一项
+	PrivateKeyFile       string `mapstructure:"private_key_file"`       // 私钥文件
+	PrivateKeyPassphrase string `mapstructure:"private_key_passphrase"` // 私钥口令
+	Password             string `mapstructure:"password"`               // 登录密码
+
+	// 主机密钥校验
+	KnownHostsFile        string `mapstructure:"known_hosts_file"`         // known_hosts 文件（默认: ~/.ssh/known_hosts）
+	InsecureIgnoreHostKey bool   `mapstructure:"insecure_ignore_host_key"` // 跳过主机密钥校验（仅用于测试环境）
+
+	Concurrency  int      `mapstructure:"concurrency" validate:"gte=0,lte=100"`                                // 并发主机数（默认: 10）
+	Checks       []string `mapstructure:"checks" validate:"unique,dive,oneof=df uptime chronyc passwd_expiry"` // 执行的检查项（默认全部）
+	PasswordUser string   `mapstructure:"password_user"`                                                       // 检查密码过期的用户，为空时为登录用户
+}
+
+// SourcePreference selects one collection agent per host and metric when several agents report
+// the same metric for a host. Sources are told apart by Label; sources not listed in the order
+// rank last. An empty Label keeps every series (no deduplication).
+type SourcePreference struct {
+	Label string                 `mapstructure:"label"` // 区分采集来源的标签（如 job、agent）
+	Order []string               `mapstructure:"order" validate:"unique"`
+	Rules []SourcePreferenceRule `mapstructure:"rules"` // 按主机覆盖默认顺序，第一条匹配的规则生效
+}
+
+// SourcePreferenceRule overrides the source order for the hosts matching one of its patterns.
+type SourcePreferenceRule struct {
+	Hosts []string `mapstructure:"hosts"` // 主机名通配符（如 "db-*"）
+	Order []string `mapstructure:"order"`
 }
 
 // HostFilter defines host filtering criteria.
-// BusinessGroups uses OR logic; Tags uses AND logic with BusinessGroups.
+// BusinessGroups uses OR logic; Tags uses AND logic with BusinessGroups. Exclude drops the
+// matching hosts from the hosts returned by N9E.
 type HostFilter struct {
 	BusinessGroups []string          `mapstructure:"business_groups"` // OR relation
 	Tags           map[string]string `mapstructure:"tags"`            // AND relation with business groups
+	Exclude        []string          `mapstructure:"exclude"`         // 排除的主机（通配符或 /正则/），按主机名、ident 或 IP 匹配
 }
 
 // ThresholdsConfig contains threshold configurations for alerts.
 type ThresholdsConfig struct {
-	CPUUsage        ThresholdPair `mapstructure:"cpu_usage"`
-	MemoryUsage     ThresholdPair `mapstructure:"memory_usage"`
-	DiskUsage       ThresholdPair `mapstructure:"disk_usage"`
-	ZombieProcesses ThresholdPair `mapstructure:"zombie_processes"`
-	LoadPerCore     ThresholdPair `mapstructure:"load_per_core"`
+	CPUUsage               ThresholdPair `mapstructure:"cpu_usage"`
+	MemoryUsage            ThresholdPair `mapstructure:"memory_usage"`
+	DiskUsage              ThresholdPair `mapstructure:"disk_usage"`
+	ZombieProcesses        ThresholdPair `mapstructure:"zombie_processes"`
+	LoadPerCore            ThresholdPair `mapstructure:"load_per_core"`
+	MemoryAvailablePercent ThresholdPair `mapstructure:"memory_available_percent"` // 可用内存百分比（下限告警，默认关闭）
 }
 
+// Threshold directions.
+const (
+	ThresholdDirectionAbove = "above" // 值大于等于阈值时告警（默认）
+	ThresholdDirectionBelow = "below" // 值小于等于阈值时告警
+)
+
 // ThresholdPair defines warning and critical thresholds for a metric.
+// Direction "above" (default) alerts when the value rises to the threshold;
+// "below" alerts when it falls to the threshold, so critical must not exceed warning.
+// A threshold of 0 disables its level; a pair with both thresholds set to 0 is disabled.
 type ThresholdPair struct {
-	Warning  float64 `mapstructure:"warning" validate:"gte=0"`
-	Critical float64 `mapstructure:"critical" validate:"gte=0"`
+	Warning   float64 `mapstructure:"warning" validate:"gte=0"`
+	Critical  float64 `mapstructure:"critical" validate:"gte=0"`
+	Direction string  `mapstructure:"direction" validate:"omitempty,oneof=above below"`
+}
+
+// IsBelow reports whether the pair alerts on values falling below the thresholds.
+func (t *ThresholdPair) IsBelow() bool {
+	return t.Direction == ThresholdDirectionBelow
+}
+
+// IsDisabled reports whether both thresholds are 0, which disables the check.
+func (t *ThresholdPair) IsDisabled() bool {
+	return t.Warning == 0 && t.Critical == 0
+}
+
+// Value check operators.
+const (
+	ValueCheckEquals = "equals" // 等于唯一期望值
+	ValueCheckIn     = "in"     // 属于期望值集合
+	ValueCheckPrefix = "prefix" // 以任一期望值为前缀
+)
+
+// ValueCheck defines an expected-value check for a string-valued metric,
+// e.g. MySQL version prefix or Redis maxmemory policy. A mismatch raises an
+// alert at Level (default warning); missing or N/A values are skipped.
+type ValueCheck struct {
+	Metric   string   `mapstructure:"metric"`   // 指标名称（对应指标定义文件中的 name）
+	Operator string   `mapstructure:"operator"` // equals / in / prefix
+	Values   []string `mapstructure:"values"`   // 期望值
+	Level    string   `mapstructure:"level"`    // warning（默认）/ critical
+}
+
+// Matches reports whether value satisfies the check.
+func (c *ValueCheck) Matches(value string) bool {
+	for _, expected := range c.Values {
+		switch c.Operator {
+		case ValueCheckPrefix:
+			if strings.HasPrefix(value, expected) {
+				return true
+			}
+		default:
+			if value == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Expected returns a human-readable description of the expected values.
+func (c *ValueCheck) Expected() string {
+	if c.Operator == ValueCheckPrefix {
+		return strings.Join(c.Values, "* | ") + "*"
+	}
+	return strings.Join(c.Values, " | ")
 }
 
 // ReportConfig contains configurations for report generation.
 type ReportConfig struct {
-	OutputDir        string   `mapstructure:"output_dir"`
-	Formats          []string `mapstructure:"formats" validate:"dive,oneof=excel html"`
-	FilenameTemplate string   `mapstructure:"filename_template"`
-	HTMLTemplate     string   `mapstructure:"html_template"`
-	Timezone         string   `mapstructure:"timezone"`
+	OutputDir        string         `mapstructure:"output_dir"`
+	Formats          []string       `mapstructure:"formats" validate:"dive,oneof=excel html html-email csv json"`
+	FilenameTemplate string         `mapstructure:"filename_template"`
+	HTMLTemplate     string         `mapstructure:"html_template"`
+	Timezone         string         `mapstructure:"timezone"`
+	RawDataSheet     bool           `mapstructure:"raw_data_sheet"`     // Excel / CSV 报告附加"原始数据"长表
+	ProvenanceSheet  bool           `mapstructure:"provenance_sheet"`   // Excel 报告附加"报告元数据"：版本、配置哈希、数据源、查询语句
+	HTMLSplit        bool           `mapstructure:"html_split"`         // HTML 报告拆分为 index.html + 各模块页面
+	SheetAlertBadges bool           `mapstructure:"sheet_alert_badges"` // Excel sheet 名称附加告警数徽标
+	ChartLibrary     string         `mapstructure:"chart_library"`      // 替换内置 ECharts 脚本的本地脚本路径（echarts.min.js，可选）
+	Theme            ThemeConfig    `mapstructure:"theme"`              // 白标主题（按项目 / 客户配置）
+	Branding         BrandingConfig `mapstructure:"branding"`           // 封面（客户、项目、Logo、巡检工程师、巡检周期）
+
+	// Excel sheet 模块顺序：列出的模块排在最前，未列出的模块按默认顺序排在其后
+	SheetOrder      []string `mapstructure:"sheet_order" validate:"dive,oneof=host mysql redis nginx tomcat cassandra monitoring storage log_checks lvs windows ad cloud firewall"`
+	HideEmptySheets bool     `mapstructure:"hide_empty_sheets"` // 隐藏未发现实例的模块 sheet
+	GroupAlerts     bool     `mapstructure:"group_alerts"`      // 异常汇总按主机分组（可折叠大纲 + 小计行）
+
+	// 按 CMDB 属性分组统计主机：每个属性一个统计 sheet（需配置 datasources.cmdb）
+	GroupBy []string `mapstructure:"group_by" validate:"dive,oneof=owner application location"`
+
+	// 趋势 sheet：与输出目录中最近 N 份 JSON 报告对比主机 CPU / 内存 / 磁盘（0 关闭，需 formats 包含 json）
+	TrendRuns int `mapstructure:"trend_runs" validate:"gte=0,lte=10"`
+
+	// 容量排行 sheet：按磁盘利用率、内存利用率、每核负载分别列出前 N 台主机（0 关闭）
+	CapacityTopN int `mapstructure:"capacity_top_n" validate:"gte=0,lte=100"`
+
+	// 报告语言：zh 中文、en 英文、zh-en 中英双语（sheet 名称、表头、状态文字、HTML 标签）
+	Language string `mapstructure:"language" validate:"omitempty,oneof=zh en zh-en"`
+
+	// 第二时区：巡检时间同时显示该时区的时间（如 UTC 或总部时区），便于海外站点由国内值班中心复核
+	SecondaryTimezone      string `mapstructure:"secondary_timezone"`
+	SecondaryTimezoneLabel string `mapstructure:"secondary_timezone_label"` // 第二时区显示名称（默认为时区名，如 UTC）
+
+	// 处理建议：指标名称 → 处理建议文本，填入 Excel / CSV 异常 sheet 的"处理建议"列；未配置的指标使用内置建议
+	Remediation map[string]string `mapstructure:"remediation"`
+
+	Watermark WatermarkConfig `mapstructure:"watermark"` // 保密水印（Excel 打印页、HTML / PDF 页面）
+
+	Excel ExcelReportConfig `mapstructure:"excel"` // Excel 报告布局
+	HTML  HTMLReportConfig  `mapstructure:"html"`  // HTML 报告样式
+
+	// 打包模式：zip 时一次生成 Excel + HTML + JSON（以及 formats 中的其他格式），并打包为带时间戳的 zip
+	Bundle string `mapstructure:"bundle" validate:"omitempty,oneof=zip"`
+
+	Publish PublishConfig `mapstructure:"publish"` // 报告发布（Confluence 等）
+}
+
+// PublishConfig contains the destinations the generated reports are published to after each run.
+type PublishConfig struct {
+	Confluence ConfluencePublishConfig `mapstructure:"confluence"`
+}
+
+// ConfluencePublishConfig publishes each run as a Confluence page: the HTML report embedded in
+// the page and the report files (Excel, HTML, JSON, zip) as attachments.
+type ConfluencePublishConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	URL       string        `mapstructure:"url" validate:"omitempty,url"` // Confluence 地址（如 https://wiki.example.com，Cloud 为 https://<site>.atlassian.net/wiki）
+	SpaceKey  string        `mapstructure:"space_key"`                    // 空间标识
+	ParentID  string        `mapstructure:"parent_id"`                    // 父页面 ID（可选）
+	Title     string        `mapstructure:"title"`                        // 页面标题前缀，后接巡检时间（默认为报告标题）
+	Username  string        `mapstructure:"username"`                     // Cloud 账号邮箱（Basic 认证）；为空时以 token 作为个人访问令牌（Bearer）
+	Token     string        `mapstructure:"token"`                        // API 令牌（建议通过环境变量 INSPECT_REPORT_PUBLISH_CONFLUENCE_TOKEN 设置）
+	EmbedHTML bool          `mapstructure:"embed_html"`                   // 以 HTML 宏将 HTML 报告嵌入页面（需启用 HTML 宏）
+	Timeout   time.Duration `mapstructure:"timeout"`                      // 单个请求超时
+	TLS       TLSConfig     `mapstructure:"tls"`                          // HTTPS 证书校验与 mTLS（自签名证书的内网 Confluence）
+}
+
+// ExcelReportConfig contains Excel-specific report layout settings.
+type ExcelReportConfig struct {
+	Columns    ExcelColumnsConfig    `mapstructure:"columns"`
+	Protection ExcelProtectionConfig `mapstructure:"protection"` // 工作表保护与工作簿加密
+
+	// 客户提供的 Excel 模板路径（可选）：设置后按模板中的占位符填充数据，不再生成固定布局
+	Template string `mapstructure:"template"`
+}
+
+// ExcelProtectionConfig protects the distributed Excel reports: read-only sheets and an optional
+// password required to open the workbook. It does not apply to the temporary workbook of CSV exports.
+type ExcelProtectionConfig struct {
+	ProtectSheets bool   `mapstructure:"protect_sheets"` // 保护所有工作表：单元格（含表头）只读，仍可选择、筛选、调整列宽
+	SheetPassword string `mapstructure:"sheet_password"` // 取消工作表保护的密码（可选）
+	Password      string `mapstructure:"password"`       // 打开工作簿的密码：非空时加密 .xlsx（建议通过环境变量 INSPECT_REPORT_EXCEL_PROTECTION_PASSWORD 设置）
+}
+
+// ExcelColumnsConfig selects which columns of the host detail, MySQL and Redis sheets appear,
+// and in what order. An empty list keeps all columns in the default order.
+type ExcelColumnsConfig struct {
+	Host  []string `mapstructure:"host" validate:"unique,dive,oneof=hostname ip status os os_version kernel_version cpu_cores cpu_usage memory_usage disk_usage_max uptime load_1m load_per_core processes_zombies processes_total disks"`
+	MySQL []string `mapstructure:"mysql" validate:"unique,dive,oneof=inspection_time ip port version server_id cluster_mode sync_status max_connections current_connections qps tps slow_queries threads_running binlog status"`
+	Redis []string `mapstructure:"redis" validate:"unique,dive,oneof=inspection_time ip port app_type version non_root_user connection_status cluster_enabled master_link_status role master_port replication_lag max_clients memory_usage fragmentation_ratio evicted_keys expired_keys keyspace_hit_ratio status"`
+}
+
+// HTMLReportConfig defines the look of the HTML reports.
+type HTMLReportConfig struct {
+	Theme   string `mapstructure:"theme" validate:"omitempty,oneof=light dark custom"` // 配色：light 浅色、dark 深色、custom 自定义 CSS
+	CSSFile string `mapstructure:"css_file"`                                           // 自定义样式表路径（theme 为 custom 时必填）
+}
+
+// ThemeConfig contains the white-label theming bundle applied to Excel and HTML reports.
+// Empty fields keep the built-in look.
+type ThemeConfig struct {
+	Title          string `mapstructure:"title"`           // 报告标题（默认: 系统巡检报告）
+	FooterText     string `mapstructure:"footer_text"`     // 页脚文字（默认: 系统巡检工具）
+	LogoFile       string `mapstructure:"logo_file"`       // Logo 图片路径（PNG / JPEG / GIF）
+	PrimaryColor   string `mapstructure:"primary_color"`   // 主色 #RRGGBB（表头、HTML 页眉）
+	SecondaryColor string `mapstructure:"secondary_color"` // 辅色 #RRGGBB（HTML 页眉渐变终点，默认同主色）
+}
+
+// BrandingConfig contains the cover page added in front of Excel and HTML reports.
+// The cover page is generated when any field is set.
+type BrandingConfig struct {
+	CustomerName string `mapstructure:"customer_name"` // 客户名称
+	Project      string `mapstructure:"project"`       // 项目名称
+	LogoFile     string `mapstructure:"logo_file"`     // 封面 Logo 图片路径（PNG / JPEG / GIF，默认使用 theme.logo_file）
+	Engineer     string `mapstructure:"engineer"`      // 巡检工程师
+	Period       string `mapstructure:"period"`        // 巡检周期（如 "2025 年第一季度"）
+}
+
+// WatermarkConfig contains the diagonal watermark repeated over the Excel printed pages and
+// the HTML pages (including PDF exports printed from them), for confidentiality requirements.
+type WatermarkConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Text    string `mapstructure:"text"` // 水印文字（默认: branding.customer_name，未配置时为报告标题），其后附报告日期
 }
 
 // LoggingConfig contains configurations for logging.
@@ -81,15 +559,49 @@ type LoggingConfig struct {
 	Format string `mapstructure:"format" validate:"oneof=json console"`
 }
 
+// RuntimeConfig contains the garbage collector settings of the long-running commands
+// (worker, serve), so that a process running for months keeps a bounded heap. Zero values
+// keep the Go defaults and the GOGC / GOMEMLIMIT environment variables.
+type RuntimeConfig struct {
+	GCPercent     int   `mapstructure:"gc_percent" validate:"gte=0"`      // GC 目标百分比（同 GOGC），0 使用默认值
+	MemoryLimitMB int64 `mapstructure:"memory_limit_mb" validate:"gte=0"` // 软内存上限（MiB，同 GOMEMLIMIT），0 不限制
+}
+
+// DistributedConfig contains the coordinator / worker settings of distributed inspection.
+// With workers configured, the run command is the coordinator: it shards the inspection
+// across the workers (inspect worker) and renders one combined report from their results.
+type DistributedConfig struct {
+	Workers []string      `mapstructure:"workers" validate:"unique,dive,url"` // worker 地址（如 http://10.0.0.1:8090）
+	Token   string        `mapstructure:"token"`                              // 协调者与 worker 共享的认证令牌（配置 workers 时必填，worker 启动时必填）
+	Timeout time.Duration `mapstructure:"timeout"`                            // 单个 worker 任务超时（默认 30m）
+	TLS     TLSConfig     `mapstructure:"tls"`                                // 访问 https worker 的证书校验与 mTLS
+}
+
 // HTTPConfig contains HTTP client configurations including retry settings.
 type HTTPConfig struct {
 	Retry RetryConfig `mapstructure:"retry"`
 }
 
-// RetryConfig defines retry behavior for HTTP requests.
+// RetryConfig defines retry behavior for HTTP requests. Retries wait with jittered
+// exponential backoff; the retry budget and the circuit breaker apply to each datasource
+// client as a whole and are disabled when zero.
 type RetryConfig struct {
 	MaxRetries int           `mapstructure:"max_retries" validate:"gte=0,lte=10"`
 	BaseDelay  time.Duration `mapstructure:"base_delay"`
+	MaxDelay   time.Duration `mapstructure:"max_delay"` // 单次重试最长等待（默认: base_delay × 8）
+
+	BudgetRatio float64 `mapstructure:"budget_ratio" validate:"gte=0,lte=1"` // 重试预算：重试次数占请求数的比例上限（默认: 0.2），与 budget_min 均为 0 时不限制
+	BudgetMin   int     `mapstructure:"budget_min" validate:"gte=0"`         // 预算比例之外始终允许的重试次数（默认: 10）
+
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"` // 熔断器
+}
+
+// CircuitBreakerConfig configures the circuit breaker of a datasource client: after
+// FailureThreshold consecutive failures (connection errors or 5xx responses) requests fail
+// immediately for OpenDuration, then a single probe request decides whether to close it.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `mapstructure:"failure_threshold" validate:"gte=0"` // 连续失败次数达到阈值后熔断（默认: 5），0 表示不熔断
+	OpenDuration     time.Duration `mapstructure:"open_duration"`                      // 熔断持续时间，到期后放行一个探测请求（默认: 30s）
 }
 
 // =============================================================================
@@ -116,6 +628,23 @@ type MySQLThresholds struct {
 	ConnectionUsageWarning  float64 `mapstructure:"connection_usage_warning" validate:"gte=0,lte=100"`  // Default: 70
 	ConnectionUsageCritical float64 `mapstructure:"connection_usage_critical" validate:"gte=0,lte=100"` // Default: 90
 	MGRMemberCountExpected  int     `mapstructure:"mgr_member_count_expected" validate:"gte=1"`         // Default: 3
+	SlowQueriesWarning      float64 `mapstructure:"slow_queries_warning" validate:"gte=0"`              // Default: 1 (per second), 0 = disabled
+	SlowQueriesCritical     float64 `mapstructure:"slow_queries_critical" validate:"gte=0"`             // Default: 5 (per second), 0 = disabled
+	ThreadsRunningWarning   int     `mapstructure:"threads_running_warning" validate:"gte=0"`           // Default: 32, 0 = disabled
+	ThreadsRunningCritical  int     `mapstructure:"threads_running_critical" validate:"gte=0"`          // Default: 64, 0 = disabled
+
+	// InnoDB 健康阈值
+	InnoDBBufferPoolHitRatioWarning  float64 `mapstructure:"innodb_buffer_pool_hit_ratio_warning" validate:"gte=0,lte=100"`  // Default: 99 (%), 低于触发, 0 = disabled
+	InnoDBBufferPoolHitRatioCritical float64 `mapstructure:"innodb_buffer_pool_hit_ratio_critical" validate:"gte=0,lte=100"` // Default: 95 (%), 低于触发, 0 = disabled
+	InnoDBRowLockWaitsWarning        float64 `mapstructure:"innodb_row_lock_waits_warning" validate:"gte=0"`                 // Default: 10 (per second), 0 = disabled
+	InnoDBRowLockWaitsCritical       float64 `mapstructure:"innodb_row_lock_waits_critical" validate:"gte=0"`                // Default: 50 (per second), 0 = disabled
+	InnoDBDeadlocksWarning           int     `mapstructure:"innodb_deadlocks_warning" validate:"gte=0"`                      // Default: 1 (per hour), 0 = disabled
+	InnoDBDeadlocksCritical          int     `mapstructure:"innodb_deadlocks_critical" validate:"gte=0"`                     // Default: 10 (per hour), 0 = disabled
+	InnoDBHistoryListLengthWarning   int     `mapstructure:"innodb_history_list_length_warning" validate:"gte=0"`            // Default: 100000, 0 = disabled
+	InnoDBHistoryListLengthCritical  int     `mapstructure:"innodb_history_list_length_critical" validate:"gte=0"`           // Default: 1000000, 0 = disabled
+
+	// 字符串/枚举期望值检查（如版本前缀）
+	ValueChecks []ValueCheck `mapstructure:"value_checks"`
 }
 
 // =============================================================================
@@ -143,6 +672,19 @@ type RedisThresholds struct {
 	ConnectionUsageCritical float64 `mapstructure:"connection_usage_critical" validate:"gte=0,lte=100"` // Default: 90
 	ReplicationLagWarning   int64   `mapstructure:"replication_lag_warning" validate:"gte=0"`           // Default: 1MB (1048576)
 	ReplicationLagCritical  int64   `mapstructure:"replication_lag_critical" validate:"gte=0"`          // Default: 10MB (10485760)
+
+	// 内存与 Keyspace 阈值
+	MemoryUsageWarning         float64 `mapstructure:"memory_usage_warning" validate:"gte=0,lte=100"`        // Default: 70 (% of maxmemory), 0 = disabled
+	MemoryUsageCritical        float64 `mapstructure:"memory_usage_critical" validate:"gte=0,lte=100"`       // Default: 90 (% of maxmemory), 0 = disabled
+	FragmentationRatioWarning  float64 `mapstructure:"fragmentation_ratio_warning" validate:"gte=0"`         // Default: 1.5, 0 = disabled
+	FragmentationRatioCritical float64 `mapstructure:"fragmentation_ratio_critical" validate:"gte=0"`        // Default: 2.0, 0 = disabled
+	EvictedKeysWarning         float64 `mapstructure:"evicted_keys_warning" validate:"gte=0"`                // Default: 1 (per second), 0 = disabled
+	EvictedKeysCritical        float64 `mapstructure:"evicted_keys_critical" validate:"gte=0"`               // Default: 100 (per second), 0 = disabled
+	KeyspaceHitRatioWarning    float64 `mapstructure:"keyspace_hit_ratio_warning" validate:"gte=0,lte=100"`  // Default: 0 (disabled), 低于触发
+	KeyspaceHitRatioCritical   float64 `mapstructure:"keyspace_hit_ratio_critical" validate:"gte=0,lte=100"` // Default: 0 (disabled), 低于触发
+
+	// 字符串/枚举期望值检查（如 maxmemory 策略）
+	ValueChecks []ValueCheck `mapstructure:"value_checks"`
 }
 
 // =============================================================================
@@ -151,9 +693,10 @@ type RedisThresholds struct {
 
 // NginxInspectionConfig contains configurations for Nginx inspection.
 type NginxInspectionConfig struct {
-	Enabled        bool            `mapstructure:"enabled"`
-	InstanceFilter NginxFilter     `mapstructure:"instance_filter"`
-	Thresholds     NginxThresholds `mapstructure:"thresholds"`
+	Enabled        bool             `mapstructure:"enabled"`
+	InstanceFilter NginxFilter      `mapstructure:"instance_filter"`
+	Thresholds     NginxThresholds  `mapstructure:"thresholds"`
+	LogExcerpt     LogExcerptConfig `mapstructure:"log_excerpt"`
 }
 
 // NginxFilter defines Nginx instance filtering criteria.
@@ -180,6 +723,7 @@ type TomcatInspectionConfig struct {
 	Enabled        bool             `mapstructure:"enabled"`
 	InstanceFilter TomcatFilter     `mapstructure:"instance_filter"`
 	Thresholds     TomcatThresholds `mapstructure:"thresholds"`
+	LogExcerpt     LogExcerptConfig `mapstructure:"log_excerpt"`
 }
 
 // TomcatFilter defines Tomcat instance filtering criteria.
@@ -202,4 +746,317 @@ type TomcatThresholds struct {
 	// Time since last error in error.log (in minutes).
 	// Default: 10 minutes.
 	LastErrorCriticalMinutes int `mapstructure:"last_error_critical_minutes" validate:"gte=0"`
+	// HeapUsageWarning/Critical define thresholds for JVM heap usage (percentage, 0 = disabled).
+	// Default: 80% / 90%.
+	HeapUsageWarning  float64 `mapstructure:"heap_usage_warning" validate:"gte=0,lte=100"`
+	HeapUsageCritical float64 `mapstructure:"heap_usage_critical" validate:"gte=0,lte=100"`
+	// GCPauseWarningMs/CriticalMs define thresholds for the average GC pause time (milliseconds, 0 = disabled).
+	// Default: 200ms / 500ms.
+	GCPauseWarningMs  float64 `mapstructure:"gc_pause_warning_ms" validate:"gte=0"`
+	GCPauseCriticalMs float64 `mapstructure:"gc_pause_critical_ms" validate:"gte=0"`
+	// ThreadPoolUsageWarning/Critical define thresholds for executor thread pool saturation
+	// (busy threads / max threads, percentage, 0 = disabled). Default: 80% / 95%.
+	ThreadPoolUsageWarning  float64 `mapstructure:"thread_pool_usage_warning" validate:"gte=0,lte=100"`
+	ThreadPoolUsageCritical float64 `mapstructure:"thread_pool_usage_critical" validate:"gte=0,lte=100"`
+}
+
+// =============================================================================
+// Cassandra Inspection Configuration
+// =============================================================================
+
+// CassandraInspectionConfig contains configurations for Cassandra inspection.
+type CassandraInspectionConfig struct {
+	Enabled        bool                `mapstructure:"enabled"`
+	InstanceFilter CassandraFilter     `mapstructure:"instance_filter"`
+	Thresholds     CassandraThresholds `mapstructure:"thresholds"`
+}
+
+// CassandraFilter defines Cassandra node filtering criteria.
+type CassandraFilter struct {
+	HostnamePatterns []string          `mapstructure:"hostname_patterns"` // Hostname patterns (glob, e.g., "GX-CASS-*")
+	BusinessGroups   []string          `mapstructure:"business_groups"`   // Business groups (OR relation)
+	Tags             map[string]string `mapstructure:"tags"`              // Tags (AND relation)
+}
+
+// CassandraThresholds contains threshold configurations for Cassandra alerts.
+// A node reported as DN is always critical and has no configurable threshold.
+type CassandraThresholds struct {
+	// PendingCompactionsWarning/Critical define thresholds for pending compaction tasks (0 = disabled).
+	// Default: 100 / 500.
+	PendingCompactionsWarning  float64 `mapstructure:"pending_compactions_warning" validate:"gte=0"`
+	PendingCompactionsCritical float64 `mapstructure:"pending_compactions_critical" validate:"gte=0"`
+	// DroppedMutationsWarning/Critical define thresholds for mutations dropped in the last 5 minutes (0 = disabled).
+	// Default: 1 / 100.
+	DroppedMutationsWarning  float64 `mapstructure:"dropped_mutations_warning" validate:"gte=0"`
+	DroppedMutationsCritical float64 `mapstructure:"dropped_mutations_critical" validate:"gte=0"`
+	// HeapUsageWarning/Critical define thresholds for JVM heap usage (percentage, 0 = disabled).
+	// Default: 80% / 90%.
+	HeapUsageWarning  float64 `mapstructure:"heap_usage_warning" validate:"gte=0,lte=100"`
+	HeapUsageCritical float64 `mapstructure:"heap_usage_critical" validate:"gte=0,lte=100"`
+	// HintsBacklogWarning/Critical define thresholds for hinted handoff writes in progress (0 = disabled).
+	// Default: 100 / 1000.
+	HintsBacklogWarning  float64 `mapstructure:"hints_backlog_warning" validate:"gte=0"`
+	HintsBacklogCritical float64 `mapstructure:"hints_backlog_critical" validate:"gte=0"`
+}
+
+// =============================================================================
+// Monitoring Self-Inspection Configuration
+// =============================================================================
+
+// MonitoringInspectionConfig contains configurations for inspecting the monitoring
+// stack itself (VictoriaMetrics single/cluster components or InfluxDB).
+type MonitoringInspectionConfig struct {
+	Enabled        bool                 `mapstructure:"enabled"`
+	InstanceFilter MonitoringFilter     `mapstructure:"instance_filter"`
+	Thresholds     MonitoringThresholds `mapstructure:"thresholds"`
+}
+
+// MonitoringFilter defines monitoring component filtering criteria.
+// Components are scrape targets identified by their "instance" and "job" labels.
+type MonitoringFilter struct {
+	InstancePatterns []string `mapstructure:"instance_patterns"` // Instance patterns (glob, e.g., "10.0.0.*:8482")
+	Jobs             []string `mapstructure:"jobs"`              // Job names (OR relation, e.g., "vmstorage")
+}
+
+// MonitoringThresholds contains threshold configurations for monitoring stack alerts.
+// A component whose scrape target is down is always critical and has no configurable threshold.
+type MonitoringThresholds struct {
+	// SlowQueriesWarning/Critical define thresholds for slow queries in the last 5 minutes (0 = disabled).
+	// Default: 10 / 100.
+	SlowQueriesWarning  float64 `mapstructure:"slow_queries_warning" validate:"gte=0"`
+	SlowQueriesCritical float64 `mapstructure:"slow_queries_critical" validate:"gte=0"`
+	// MergeBacklogWarning/Critical define thresholds for rows pending merge/flush (0 = disabled).
+	// Default: 1000000 / 10000000.
+	MergeBacklogWarning  float64 `mapstructure:"merge_backlog_warning" validate:"gte=0"`
+	MergeBacklogCritical float64 `mapstructure:"merge_backlog_critical" validate:"gte=0"`
+	// DiskUsageWarning/Critical define thresholds for storage disk usage (percentage, 0 = disabled).
+	// Default: 80% / 90%.
+	DiskUsageWarning  float64 `mapstructure:"disk_usage_warning" validate:"gte=0,lte=100"`
+	DiskUsageCritical float64 `mapstructure:"disk_usage_critical" validate:"gte=0,lte=100"`
+	// AlertOnZeroIngestion raises a warning when a component that ingests data reports
+	// an ingestion rate of 0 rows/s. Default: true.
+	AlertOnZeroIngestion bool `mapstructure:"alert_on_zero_ingestion"`
+}
+
+// =============================================================================
+// Shared Storage Inspection Configuration
+// =============================================================================
+
+// StorageInspectionConfig contains configurations for shared storage inspection
+// (NFS client mounts, NFS servers and GlusterFS peers/bricks).
+type StorageInspectionConfig struct {
+	Enabled        bool              `mapstructure:"enabled"`
+	InstanceFilter StorageFilter     `mapstructure:"instance_filter"`
+	Thresholds     StorageThresholds `mapstructure:"thresholds"`
+}
+
+// StorageFilter defines shared storage host filtering criteria.
+type StorageFilter struct {
+	HostnamePatterns []string          `mapstructure:"hostname_patterns"` // Hostname patterns (glob, e.g., "GX-NFS-*")
+	BusinessGroups   []string          `mapstructure:"business_groups"`   // Business groups (OR relation)
+	Tags             map[string]string `mapstructure:"tags"`              // Tags (AND relation)
+}
+
+// StorageThresholds contains threshold configurations for shared storage alerts.
+// Stale mounts, disconnected GlusterFS peers and offline bricks are always critical
+// and have no configurable threshold.
+type StorageThresholds struct {
+	// NFSRPCErrorsWarning/Critical define thresholds for NFS server RPC errors in the last 5 minutes (0 = disabled).
+	// Default: 1 / 100.
+	NFSRPCErrorsWarning  float64 `mapstructure:"nfs_rpc_errors_warning" validate:"gte=0"`
+	NFSRPCErrorsCritical float64 `mapstructure:"nfs_rpc_errors_critical" validate:"gte=0"`
+}
+
+// =============================================================================
+// Log Checks Inspection Configuration
+// =============================================================================
+
+// LogChecksInspectionConfig contains configurations for log-based checks.
+// The checks themselves (queries and per-check thresholds) are defined in the log checks file.
+type LogChecksInspectionConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Window  time.Duration `mapstructure:"window"` // 日志统计时间窗口（默认 1h）
+}
+
+// =============================================================================
+// LVS/IPVS Inspection Configuration
+// =============================================================================
+
+// LVSInspectionConfig contains configurations for LVS/IPVS load balancer inspection.
+type LVSInspectionConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	InstanceFilter LVSFilter     `mapstructure:"instance_filter"`
+	Thresholds     LVSThresholds `mapstructure:"thresholds"`
+}
+
+// LVSFilter defines LVS director filtering criteria.
+type LVSFilter struct {
+	HostnamePatterns []string          `mapstructure:"hostname_patterns"` // Hostname patterns (glob, e.g., "GX-LVS-*")
+	BusinessGroups   []string          `mapstructure:"business_groups"`   // Business groups (OR relation)
+	Tags             map[string]string `mapstructure:"tags"`              // Tags (AND relation)
+}
+
+// LVSThresholds contains threshold configurations for LVS alerts.
+// Virtual services without any active real server are always critical
+// and have no configurable threshold.
+type LVSThresholds struct {
+	// ActiveConnectionsWarning/Critical define thresholds for the total active connections
+	// of a director across all virtual services (0 = disabled). Default: 50000 / 100000.
+	ActiveConnectionsWarning  float64 `mapstructure:"active_connections_warning" validate:"gte=0"`
+	ActiveConnectionsCritical float64 `mapstructure:"active_connections_critical" validate:"gte=0"`
+	// AlertOnSyncDaemonStopped raises a warning when the IPVS connection sync daemon
+	// is reported as not running. Default: true.
+	AlertOnSyncDaemonStopped bool `mapstructure:"alert_on_sync_daemon_stopped"`
+}
+
+// =============================================================================
+// Windows Service / IIS Inspection Configuration
+// =============================================================================
+
+// WindowsInspectionConfig contains configurations for Windows service and IIS inspection.
+type WindowsInspectionConfig struct {
+	Enabled        bool              `mapstructure:"enabled"`
+	InstanceFilter WindowsFilter     `mapstructure:"instance_filter"`
+	Services       []string          `mapstructure:"services"` // 需要检查运行状态的服务白名单（不区分大小写）
+	Thresholds     WindowsThresholds `mapstructure:"thresholds"`
+}
+
+// WindowsFilter defines Windows host filtering criteria.
+type WindowsFilter struct {
+	HostnamePatterns []string          `mapstructure:"hostname_patterns"` // Hostname patterns (glob, e.g., "GX-WIN-*")
+	BusinessGroups   []string          `mapstructure:"business_groups"`   // Business groups (OR relation)
+	Tags             map[string]string `mapstructure:"tags"`              // Tags (AND relation)
+}
+
+// WindowsThresholds contains threshold configurations for Windows alerts.
+// Allowlisted services that are not running and IIS application pools that are
+// not in the Running state are always critical and have no configurable threshold.
+type WindowsThresholds struct {
+	// RequestsQueuedWarning/Critical define thresholds for the HTTP.sys request queue
+	// length of each IIS application pool (0 = disabled). Default: 100 / 1000.
+	RequestsQueuedWarning  float64 `mapstructure:"requests_queued_warning" validate:"gte=0"`
+	RequestsQueuedCritical float64 `mapstructure:"requests_queued_critical" validate:"gte=0"`
+}
+
+// =============================================================================
+// AD / LDAP Inspection Configuration
+// =============================================================================
+
+// ADInspectionConfig contains configurations for Active Directory domain controller inspection.
+type ADInspectionConfig struct {
+	Enabled        bool         `mapstructure:"enabled"`
+	InstanceFilter ADFilter     `mapstructure:"instance_filter"`
+	SysvolVolume   string       `mapstructure:"sysvol_volume"` // SYSVOL 所在卷（windows_exporter volume 标签，默认 C:）
+	Thresholds     ADThresholds `mapstructure:"thresholds"`
+}
+
+// ADFilter defines domain controller filtering criteria.
+type ADFilter struct {
+	HostnamePatterns []string          `mapstructure:"hostname_patterns"` // Hostname patterns (glob, e.g., "GX-DC-*")
+	BusinessGroups   []string          `mapstructure:"business_groups"`   // Business groups (OR relation)
+	Tags             map[string]string `mapstructure:"tags"`              // Tags (AND relation)
+}
+
+// ADThresholds contains threshold configurations for AD alerts.
+// Replication failures and failed LDAP probes are always critical
+// and have no configurable threshold.
+type ADThresholds struct {
+	// ReplicationPendingWarning/Critical define thresholds for pending replication
+	// synchronizations (0 = disabled). Default: 50 / 200.
+	ReplicationPendingWarning  float64 `mapstructure:"replication_pending_warning" validate:"gte=0"`
+	ReplicationPendingCritical float64 `mapstructure:"replication_pending_critical" validate:"gte=0"`
+	// LDAPBindLatencyWarning/Critical define thresholds for the LDAP bind latency
+	// measured by blackbox_exporter, in seconds (0 = disabled). Default: 0.5 / 2.
+	LDAPBindLatencyWarning  float64 `mapstructure:"ldap_bind_latency_warning" validate:"gte=0"`
+	LDAPBindLatencyCritical float64 `mapstructure:"ldap_bind_latency_critical" validate:"gte=0"`
+	// SysvolFreeWarning/Critical define thresholds for the free space percentage of the
+	// SYSVOL volume; lower is worse (0 = disabled). Default: 20 / 10.
+	SysvolFreeWarning  float64 `mapstructure:"sysvol_free_warning" validate:"gte=0,lte=100"`
+	SysvolFreeCritical float64 `mapstructure:"sysvol_free_critical" validate:"gte=0,lte=100"`
+}
+
+// =============================================================================
+// Cloud Resource Inspection Configuration
+// =============================================================================
+
+// CloudInspectionConfig contains configurations for managed cloud resource inspection
+// (Aliyun CloudMonitor / AWS CloudWatch metrics collected by Categraf into VictoriaMetrics).
+type CloudInspectionConfig struct {
+	Enabled        bool             `mapstructure:"enabled"`
+	InstanceFilter CloudFilter      `mapstructure:"instance_filter"`
+	Thresholds     CloudThresholds  `mapstructure:"thresholds"`
+	CostSummary    CloudCostSummary `mapstructure:"cost_summary"`
+}
+
+// CloudFilter defines cloud resource filtering criteria.
+type CloudFilter struct {
+	ResourcePatterns []string `mapstructure:"resource_patterns"` // Resource ID or name patterns (glob, e.g., "rm-*")
+	Providers        []string `mapstructure:"providers"`         // Cloud providers (aliyun, aws)
+	ResourceTypes    []string `mapstructure:"resource_types"`    // Resource types (rds, redis, slb)
+	Regions          []string `mapstructure:"regions"`           // Regions (e.g., cn-hangzhou, us-east-1)
+}
+
+// CloudCostSummary configures the optional cost / asset summary of cloud resources:
+// resource counts by provider, type and instance spec, and the under-utilized
+// resources whose CPU usage stays below IdleCPUThreshold for the whole Window.
+type CloudCostSummary struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	Window           time.Duration `mapstructure:"window"`                                      // 低利用率统计窗口（默认 720h，即 30 天）
+	IdleCPUThreshold float64       `mapstructure:"idle_cpu_threshold" validate:"gte=0,lte=100"` // 窗口内 CPU 峰值低于该值视为低利用率（默认 5）
+}
+
+// CloudThresholds contains threshold configurations for cloud resource alerts.
+// Unhealthy SLB / ELB backends are always critical and have no configurable threshold.
+type CloudThresholds struct {
+	// CPUUsageWarning/Critical define thresholds for CPU utilization percentage
+	// (0 = disabled). Default: 70 / 90.
+	CPUUsageWarning  float64 `mapstructure:"cpu_usage_warning" validate:"gte=0,lte=100"`
+	CPUUsageCritical float64 `mapstructure:"cpu_usage_critical" validate:"gte=0,lte=100"`
+	// MemoryUsageWarning/Critical define thresholds for memory utilization percentage
+	// (0 = disabled). Default: 80 / 90.
+	MemoryUsageWarning  float64 `mapstructure:"memory_usage_warning" validate:"gte=0,lte=100"`
+	MemoryUsageCritical float64 `mapstructure:"memory_usage_critical" validate:"gte=0,lte=100"`
+	// ConnectionUsageWarning/Critical define thresholds for connection utilization
+	// percentage of RDS / Redis instances (0 = disabled). Default: 70 / 90.
+	ConnectionUsageWarning  float64 `mapstructure:"connection_usage_warning" validate:"gte=0,lte=100"`
+	ConnectionUsageCritical float64 `mapstructure:"connection_usage_critical" validate:"gte=0,lte=100"`
+	// DiskUsageWarning/Critical define thresholds for storage utilization percentage
+	// of RDS instances (0 = disabled). Default: 80 / 90.
+	DiskUsageWarning  float64 `mapstructure:"disk_usage_warning" validate:"gte=0,lte=100"`
+	DiskUsageCritical float64 `mapstructure:"disk_usage_critical" validate:"gte=0,lte=100"`
+}
+
+// =============================================================================
+// Firewall / VPN Appliance Inspection Configuration
+// =============================================================================
+
+// FirewallInspectionConfig contains configurations for firewall / VPN appliance inspection
+// (SNMP metrics of snmp_exporter stored in VictoriaMetrics, mapped by device profiles).
+type FirewallInspectionConfig struct {
+	Enabled        bool               `mapstructure:"enabled"`
+	InstanceFilter FirewallFilter     `mapstructure:"instance_filter"`
+	Thresholds     FirewallThresholds `mapstructure:"thresholds"`
+}
+
+// FirewallFilter defines firewall device filtering criteria.
+type FirewallFilter struct {
+	DevicePatterns []string `mapstructure:"device_patterns"` // Device address or name patterns (glob, e.g., "10.0.1.*")
+	Profiles       []string `mapstructure:"profiles"`        // Device profiles (fortigate, paloalto, cisco_asa)
+}
+
+// FirewallThresholds contains threshold configurations for firewall device alerts.
+// Down VPN tunnels and abnormal HA states are always critical and have no configurable threshold.
+type FirewallThresholds struct {
+	// CPUUsageWarning/Critical define thresholds for CPU utilization percentage
+	// (0 = disabled). Default: 70 / 90.
+	CPUUsageWarning  float64 `mapstructure:"cpu_usage_warning" validate:"gte=0,lte=100"`
+	CPUUsageCritical float64 `mapstructure:"cpu_usage_critical" validate:"gte=0,lte=100"`
+	// MemoryUsageWarning/Critical define thresholds for memory utilization percentage
+	// (0 = disabled). Default: 80 / 90.
+	MemoryUsageWarning  float64 `mapstructure:"memory_usage_warning" validate:"gte=0,lte=100"`
+	MemoryUsageCritical float64 `mapstructure:"memory_usage_critical" validate:"gte=0,lte=100"`
+	// SessionUsageWarning/Critical define thresholds for session table utilization
+	// percentage (0 = disabled). Default: 70 / 90.
+	SessionUsageWarning  float64 `mapstructure:"session_usage_warning" validate:"gte=0,lte=100"`
+	SessionUsageCritical float64 `mapstructure:"session_usage_critical" validate:"gte=0,lte=100"`
 }
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// HostPatterns matches host names against the patterns of inspection.host_filter.exclude:
+// globs in path.Match syntax ("test-*") and regular expressions between slashes
+// ("/^web-0[1-3]$/").
+type HostPatterns struct {
+	globs   []string
+	regexps []*regexp.Regexp
+}
+
+// CompileHostPatterns parses the patterns, returning an error naming the first invalid one.
+func CompileHostPatterns(patterns []string) (*HostPatterns, error) {
+	p := &HostPatterns{}
+	for _, pattern := range patterns {
+		if expr, ok := regexpPattern(pattern); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid host regexp %q: %w", pattern, err)
+			}
+			p.regexps = append(p.regexps, re)
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid host pattern %q: %w", pattern, err)
+		}
+		p.globs = append(p.globs, pattern)
+	}
+	return p, nil
+}
+
+// regexpPattern returns the expression of a pattern written between slashes.
+func regexpPattern(pattern string) (string, bool) {
+	if len(pattern) < 2 || !strings.HasPrefix(pattern, "/") || !strings.HasSuffix(pattern, "/") {
+		return "", false
+	}
+	return pattern[1 : len(pattern)-1], true
+}
+
+// Match reports whether one of the non-empty names (hostname, ident, IP...) matches a pattern.
+// A nil HostPatterns matches nothing.
+func (p *HostPatterns) Match(names ...string) bool {
+	if p == nil {
+		return false
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		for _, glob := range p.globs {
+			if ok, _ := path.Match(glob, name); ok {
+				return true
+			}
+		}
+		for _, re := range p.regexps {
+			if re.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Len returns the number of patterns.
+func (p *HostPatterns) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.globs) + len(p.regexps)
+}
@@ -0,0 +1,40 @@
+package config
+
+import "testing"
+
+func TestHostPatterns_Match(t *testing.T) {
+	patterns, err := CompileHostPatterns([]string{"test-*", "/^web-0[1-3]$/", "10.0.0.*"})
+	if err != nil {
+		t.Fatalf("CompileHostPatterns() error = %v", err)
+	}
+
+	tests := []struct {
+		names []string
+		want  bool
+	}{
+		{[]string{"test-db-01"}, true},
+		{[]string{"web-02"}, true},
+		{[]string{"web-04"}, false},
+		{[]string{"prod-web-01"}, false}, // Regexp anchored by the pattern
+		{[]string{"db-01", "", "10.0.0.5"}, true},
+		{[]string{"db-01", "db-01", "10.0.1.5"}, false},
+	}
+	for _, tt := range tests {
+		if got := patterns.Match(tt.names...); got != tt.want {
+			t.Errorf("Match(%v) = %v, want %v", tt.names, got, tt.want)
+		}
+	}
+
+	var none *HostPatterns
+	if none.Match("test-db-01") || none.Len() != 0 {
+		t.Error("nil HostPatterns should match nothing")
+	}
+}
+
+func TestCompileHostPatterns_Invalid(t *testing.T) {
+	for _, pattern := range []string{"web-[", "/web-(/"} {
+		if _, err := CompileHostPatterns([]string{pattern}); err == nil {
+			t.Errorf("CompileHostPatterns(%q) expected an error", pattern)
+		}
+	}
+}
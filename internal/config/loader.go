@@ -48,6 +48,11 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// The host identity labels default to the labels of the metrics backend
+	if len(cfg.Inspection.IdentityLabels) == 0 {
+		cfg.Inspection.IdentityLabels = cfg.Datasources.VictoriaMetrics.DefaultIdentityLabels()
+	}
+
 	// Validate configuration
 	if err := Validate(&cfg); err != nil {
 		return nil, err
@@ -60,11 +65,62 @@ func Load(configPath string) (*Config, error) {
 func setDefaults(v *viper.Viper) {
 	// Datasources defaults
 	v.SetDefault("datasources.n9e.timeout", 30*time.Second)
+	v.SetDefault("datasources.n9e.active_alerts", true)
+	v.SetDefault("datasources.n9e.business_group_sync", true)
+	v.SetDefault("datasources.n9e.username", "")
+	v.SetDefault("datasources.n9e.password", "")
+	v.SetDefault("datasources.n9e.bearer_token", "")
+	v.SetDefault("datasources.n9e.proxy_url", "")
+	v.SetDefault("datasources.victoriametrics.type", MetricsTypeVictoriaMetrics)
 	v.SetDefault("datasources.victoriametrics.timeout", 30*time.Second)
+	v.SetDefault("datasources.victoriametrics.username", "")
+	v.SetDefault("datasources.victoriametrics.password", "")
+	v.SetDefault("datasources.victoriametrics.bearer_token", "")
+	v.SetDefault("datasources.victoriametrics.proxy_url", "")
+	v.SetDefault("datasources.victoriametrics.tenant_header", DefaultTenantHeader)
+	v.SetDefault("datasources.victoriametrics.cache.enabled", false)
+	v.SetDefault("datasources.victoriametrics.cache.ttl", 10*time.Minute)
+	v.SetDefault("datasources.victoriametrics.rate_limit.requests_per_second", 0)
+	v.SetDefault("datasources.victoriametrics.rate_limit.burst", 0)
+	v.SetDefault("datasources.victoriametrics.grafana.datasource_id", 0)
+	v.SetDefault("datasources.victoriametrics.grafana.datasource_uid", "")
+	v.SetDefault("datasources.victoriametrics.grafana.api_key", "")
+	v.SetDefault("datasources.victoriametrics.grafana.org_id", 0)
+	v.SetDefault("datasources.logs.type", LogsTypeLoki)
+	v.SetDefault("datasources.logs.timeout", 30*time.Second)
+	v.SetDefault("datasources.logs.proxy_url", "")
+	v.SetDefault("datasources.alertmanager.endpoint", "")
+	v.SetDefault("datasources.alertmanager.timeout", 30*time.Second)
+	v.SetDefault("datasources.alertmanager.username", "")
+	v.SetDefault("datasources.alertmanager.password", "")
+	v.SetDefault("datasources.alertmanager.bearer_token", "")
+	v.SetDefault("datasources.alertmanager.proxy_url", "")
+	v.SetDefault("datasources.cmdb.type", "http")
+	v.SetDefault("datasources.cmdb.endpoint", "")
+	v.SetDefault("datasources.cmdb.timeout", 30*time.Second)
+	v.SetDefault("datasources.cmdb.username", "")
+	v.SetDefault("datasources.cmdb.password", "")
+	v.SetDefault("datasources.cmdb.bearer_token", "")
+	v.SetDefault("datasources.cmdb.proxy_url", "")
+	v.SetDefault("datasources.cmdb.fields.hostname", "hostname")
+	v.SetDefault("datasources.cmdb.fields.ip", "ip")
+	v.SetDefault("datasources.cmdb.fields.owner", "owner")
+	v.SetDefault("datasources.cmdb.fields.application", "application")
+	v.SetDefault("datasources.cmdb.fields.location", "location")
+	v.SetDefault("datasources.cmdb.fields.maintenance", "maintenance")
+	v.SetDefault("datasources.cmdb.maintenance_values", []string{"true", "1", "yes", "maintenance", "维护", "维护中"})
 
 	// Inspection defaults
 	v.SetDefault("inspection.concurrency", 20)
 	v.SetDefault("inspection.host_timeout", 10*time.Second)
+	v.SetDefault("inspection.range_window", 24*time.Hour)
+	v.SetDefault("inspection.ssh_fallback.enabled", false)
+	v.SetDefault("inspection.ssh_fallback.port", 22)
+	v.SetDefault("inspection.ssh_fallback.timeout", 10*time.Second)
+	v.SetDefault("inspection.ssh_fallback.concurrency", 10)
+	v.SetDefault("inspection.ssh_fallback.checks", []string{"df", "uptime", "chronyc", "passwd_expiry"})
+	// inspection.identity_labels defaults to the labels of the metrics backend (see Load)
+	_ = v.BindEnv("inspection.identity_labels")
 
 	// Thresholds defaults - based on PRD
 	v.SetDefault("thresholds.cpu_usage.warning", 70.0)
@@ -77,12 +133,33 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("thresholds.zombie_processes.critical", 10.0)
 	v.SetDefault("thresholds.load_per_core.warning", 0.7)
 	v.SetDefault("thresholds.load_per_core.critical", 1.0)
+	v.SetDefault("thresholds.memory_available_percent.warning", 0.0) // 0 = disabled
+	v.SetDefault("thresholds.memory_available_percent.critical", 0.0)
+	v.SetDefault("thresholds.memory_available_percent.direction", "below")
 
 	// Report defaults
 	v.SetDefault("report.output_dir", "./reports")
 	v.SetDefault("report.formats", []string{"excel", "html"})
 	v.SetDefault("report.filename_template", "inspection_report_{{.Date}}")
 	v.SetDefault("report.timezone", "Asia/Shanghai")
+	v.SetDefault("report.raw_data_sheet", false)
+	v.SetDefault("report.provenance_sheet", false)
+	v.SetDefault("report.html_split", false)
+	v.SetDefault("report.sheet_alert_badges", false)
+	v.SetDefault("report.hide_empty_sheets", false)
+	v.SetDefault("report.group_alerts", false)
+	v.SetDefault("report.capacity_top_n", 10)
+	v.SetDefault("report.watermark.enabled", false)
+	v.SetDefault("report.excel.protection.protect_sheets", false)
+	v.SetDefault("report.excel.protection.sheet_password", "")
+	v.SetDefault("report.excel.protection.password", "")
+	v.SetDefault("report.language", "zh")
+	v.SetDefault("report.html.theme", "light")
+	v.SetDefault("report.publish.confluence.enabled", false)
+	v.SetDefault("report.publish.confluence.username", "")
+	v.SetDefault("report.publish.confluence.token", "")
+	v.SetDefault("report.publish.confluence.embed_html", true)
+	v.SetDefault("report.publish.confluence.timeout", 2*time.Minute)
 
 	// Logging defaults
 	v.SetDefault("logging.level", "info")
@@ -91,12 +168,33 @@ func setDefaults(v *viper.Viper) {
 	// HTTP retry defaults
 	v.SetDefault("http.retry.max_retries", 3)
 	v.SetDefault("http.retry.base_delay", 1*time.Second)
+	v.SetDefault("http.retry.max_delay", 8*time.Second)
+	v.SetDefault("http.retry.budget_ratio", 0.2)
+	v.SetDefault("http.retry.budget_min", 10)
+	v.SetDefault("http.retry.circuit_breaker.failure_threshold", 5)
+	v.SetDefault("http.retry.circuit_breaker.open_duration", 30*time.Second)
+
+	// Distributed inspection defaults
+	v.SetDefault("distributed.token", "") // Known key, so INSPECT_DISTRIBUTED_TOKEN applies
+	v.SetDefault("distributed.timeout", 30*time.Minute)
 
 	// MySQL inspection defaults
 	v.SetDefault("mysql.enabled", false)
 	v.SetDefault("mysql.thresholds.connection_usage_warning", 70.0)
 	v.SetDefault("mysql.thresholds.connection_usage_critical", 90.0)
 	v.SetDefault("mysql.thresholds.mgr_member_count_expected", 3)
+	v.SetDefault("mysql.thresholds.slow_queries_warning", 1.0)
+	v.SetDefault("mysql.thresholds.slow_queries_critical", 5.0)
+	v.SetDefault("mysql.thresholds.threads_running_warning", 32)
+	v.SetDefault("mysql.thresholds.threads_running_critical", 64)
+	v.SetDefault("mysql.thresholds.innodb_buffer_pool_hit_ratio_warning", 99.0)
+	v.SetDefault("mysql.thresholds.innodb_buffer_pool_hit_ratio_critical", 95.0)
+	v.SetDefault("mysql.thresholds.innodb_row_lock_waits_warning", 10.0)
+	v.SetDefault("mysql.thresholds.innodb_row_lock_waits_critical", 50.0)
+	v.SetDefault("mysql.thresholds.innodb_deadlocks_warning", 1)
+	v.SetDefault("mysql.thresholds.innodb_deadlocks_critical", 10)
+	v.SetDefault("mysql.thresholds.innodb_history_list_length_warning", 100000)
+	v.SetDefault("mysql.thresholds.innodb_history_list_length_critical", 1000000)
 
 	// Redis inspection defaults
 	v.SetDefault("redis.enabled", false)
@@ -105,6 +203,14 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("redis.thresholds.connection_usage_critical", 90.0)
 	v.SetDefault("redis.thresholds.replication_lag_warning", 1048576)   // 1MB
 	v.SetDefault("redis.thresholds.replication_lag_critical", 10485760) // 10MB
+	v.SetDefault("redis.thresholds.memory_usage_warning", 70.0)
+	v.SetDefault("redis.thresholds.memory_usage_critical", 90.0)
+	v.SetDefault("redis.thresholds.fragmentation_ratio_warning", 1.5)
+	v.SetDefault("redis.thresholds.fragmentation_ratio_critical", 2.0)
+	v.SetDefault("redis.thresholds.evicted_keys_warning", 1.0)
+	v.SetDefault("redis.thresholds.evicted_keys_critical", 100.0)
+	v.SetDefault("redis.thresholds.keyspace_hit_ratio_warning", 0.0) // 0 = disabled
+	v.SetDefault("redis.thresholds.keyspace_hit_ratio_critical", 0.0)
 
 	// Nginx inspection defaults
 	v.SetDefault("nginx.enabled", false)
@@ -112,4 +218,96 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("nginx.thresholds.connection_usage_critical", 90.0)
 	v.SetDefault("nginx.thresholds.last_error_warning_minutes", 60)
 	v.SetDefault("nginx.thresholds.last_error_critical_minutes", 10)
+	v.SetDefault("nginx.log_excerpt.enabled", false)
+	v.SetDefault("nginx.log_excerpt.lines", 20)
+	v.SetDefault("nginx.log_excerpt.lookback", time.Hour)
+
+	// Tomcat inspection defaults
+	v.SetDefault("tomcat.enabled", false)
+	v.SetDefault("tomcat.thresholds.last_error_warning_minutes", 60)
+	v.SetDefault("tomcat.thresholds.last_error_critical_minutes", 10)
+	v.SetDefault("tomcat.thresholds.heap_usage_warning", 80.0)
+	v.SetDefault("tomcat.thresholds.heap_usage_critical", 90.0)
+	v.SetDefault("tomcat.thresholds.gc_pause_warning_ms", 200.0)
+	v.SetDefault("tomcat.thresholds.gc_pause_critical_ms", 500.0)
+	v.SetDefault("tomcat.thresholds.thread_pool_usage_warning", 80.0)
+	v.SetDefault("tomcat.thresholds.thread_pool_usage_critical", 95.0)
+	v.SetDefault("tomcat.log_excerpt.enabled", false)
+	v.SetDefault("tomcat.log_excerpt.lines", 20)
+	v.SetDefault("tomcat.log_excerpt.lookback", time.Hour)
+
+	// Cassandra inspection defaults
+	v.SetDefault("cassandra.enabled", false)
+	v.SetDefault("cassandra.thresholds.pending_compactions_warning", 100.0)
+	v.SetDefault("cassandra.thresholds.pending_compactions_critical", 500.0)
+	v.SetDefault("cassandra.thresholds.dropped_mutations_warning", 1.0)
+	v.SetDefault("cassandra.thresholds.dropped_mutations_critical", 100.0)
+	v.SetDefault("cassandra.thresholds.heap_usage_warning", 80.0)
+	v.SetDefault("cassandra.thresholds.heap_usage_critical", 90.0)
+	v.SetDefault("cassandra.thresholds.hints_backlog_warning", 100.0)
+	v.SetDefault("cassandra.thresholds.hints_backlog_critical", 1000.0)
+
+	// Monitoring self-inspection defaults
+	v.SetDefault("monitoring.enabled", false)
+	v.SetDefault("monitoring.thresholds.slow_queries_warning", 10.0)
+	v.SetDefault("monitoring.thresholds.slow_queries_critical", 100.0)
+	v.SetDefault("monitoring.thresholds.merge_backlog_warning", 1000000.0)
+	v.SetDefault("monitoring.thresholds.merge_backlog_critical", 10000000.0)
+	v.SetDefault("monitoring.thresholds.disk_usage_warning", 80.0)
+	v.SetDefault("monitoring.thresholds.disk_usage_critical", 90.0)
+	v.SetDefault("monitoring.thresholds.alert_on_zero_ingestion", true)
+
+	// Shared storage inspection defaults
+	v.SetDefault("storage.enabled", false)
+	v.SetDefault("storage.thresholds.nfs_rpc_errors_warning", 1.0)
+	v.SetDefault("storage.thresholds.nfs_rpc_errors_critical", 100.0)
+
+	// LVS/IPVS inspection defaults
+	v.SetDefault("lvs.enabled", false)
+	v.SetDefault("lvs.thresholds.active_connections_warning", 50000.0)
+	v.SetDefault("lvs.thresholds.active_connections_critical", 100000.0)
+	v.SetDefault("lvs.thresholds.alert_on_sync_daemon_stopped", true)
+
+	// Windows service / IIS inspection defaults
+	v.SetDefault("windows.enabled", false)
+	v.SetDefault("windows.services", []string{"W3SVC", "WAS"})
+	v.SetDefault("windows.thresholds.requests_queued_warning", 100.0)
+	v.SetDefault("windows.thresholds.requests_queued_critical", 1000.0)
+
+	// AD / LDAP inspection defaults
+	v.SetDefault("ad.enabled", false)
+	v.SetDefault("ad.sysvol_volume", "C:")
+	v.SetDefault("ad.thresholds.replication_pending_warning", 50.0)
+	v.SetDefault("ad.thresholds.replication_pending_critical", 200.0)
+	v.SetDefault("ad.thresholds.ldap_bind_latency_warning", 0.5)
+	v.SetDefault("ad.thresholds.ldap_bind_latency_critical", 2.0)
+	v.SetDefault("ad.thresholds.sysvol_free_warning", 20.0)
+	v.SetDefault("ad.thresholds.sysvol_free_critical", 10.0)
+
+	// Cloud resource inspection defaults
+	v.SetDefault("cloud.enabled", false)
+	v.SetDefault("cloud.thresholds.cpu_usage_warning", 70.0)
+	v.SetDefault("cloud.thresholds.cpu_usage_critical", 90.0)
+	v.SetDefault("cloud.thresholds.memory_usage_warning", 80.0)
+	v.SetDefault("cloud.thresholds.memory_usage_critical", 90.0)
+	v.SetDefault("cloud.thresholds.connection_usage_warning", 70.0)
+	v.SetDefault("cloud.thresholds.connection_usage_critical", 90.0)
+	v.SetDefault("cloud.thresholds.disk_usage_warning", 80.0)
+	v.SetDefault("cloud.thresholds.disk_usage_critical", 90.0)
+	v.SetDefault("cloud.cost_summary.enabled", false)
+	v.SetDefault("cloud.cost_summary.window", 720*time.Hour)
+	v.SetDefault("cloud.cost_summary.idle_cpu_threshold", 5.0)
+
+	// Firewall / VPN appliance inspection defaults
+	v.SetDefault("firewall.enabled", false)
+	v.SetDefault("firewall.thresholds.cpu_usage_warning", 70.0)
+	v.SetDefault("firewall.thresholds.cpu_usage_critical", 90.0)
+	v.SetDefault("firewall.thresholds.memory_usage_warning", 80.0)
+	v.SetDefault("firewall.thresholds.memory_usage_critical", 90.0)
+	v.SetDefault("firewall.thresholds.session_usage_warning", 70.0)
+	v.SetDefault("firewall.thresholds.session_usage_critical", 90.0)
+
+	// Log checks defaults
+	v.SetDefault("log_checks.enabled", false)
+	v.SetDefault("log_checks.window", 1*time.Hour)
 }
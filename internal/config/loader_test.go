@@ -3,6 +3,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -55,6 +56,9 @@ datasources:
 	if cfg.Inspection.HostTimeout != 10*time.Second {
 		t.Errorf("HostTimeout = %v, want 10s", cfg.Inspection.HostTimeout)
 	}
+	if got := strings.Join(cfg.Inspection.IdentityLabels, ","); got != "ident,host,instance" {
+		t.Errorf("IdentityLabels = %v, want ident,host,instance", got)
+	}
 }
 
 func TestLoad_FileNotFound(t *testing.T) {
@@ -107,3 +111,125 @@ datasources:
 		t.Errorf("N9E token = %v, want env-token (env override)", cfg.Datasources.N9E.Token)
 	}
 }
+
+func TestLoad_ExcelPasswordFromEnvironment(t *testing.T) {
+	content := `
+datasources:
+  n9e:
+    endpoint: "http://localhost:17000"
+    token: "test-token"
+  victoriametrics:
+    endpoint: "http://localhost:8428"
+report:
+  excel:
+    protection:
+      protect_sheets: true
+`
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	// The workbook password is not in the file, only in the environment
+	t.Setenv("INSPECT_REPORT_EXCEL_PROTECTION_PASSWORD", "env-password")
+
+	cfg, err := Load(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	protection := cfg.Report.Excel.Protection
+	if !protection.ProtectSheets {
+		t.Error("ProtectSheets should be true")
+	}
+	if protection.Password != "env-password" {
+		t.Errorf("Password = %q, want env-password", protection.Password)
+	}
+}
+
+func TestLoad_PrometheusIdentityLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "prometheus defaults",
+			content: `
+datasources:
+  n9e:
+    endpoint: "http://localhost:17000"
+    token: "test-token"
+  victoriametrics:
+    type: prometheus
+    endpoint: "http://localhost:9090"
+`,
+			want: "instance,host,ident",
+		},
+		{
+			name: "configured labels are kept",
+			content: `
+datasources:
+  n9e:
+    endpoint: "http://localhost:17000"
+    token: "test-token"
+  victoriametrics:
+    type: prometheus
+    endpoint: "http://localhost:9090"
+inspection:
+  identity_labels: ["node", "instance"]
+`,
+			want: "node,instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + "/config.yaml"
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Datasources.VictoriaMetrics.Type != MetricsTypePrometheus {
+				t.Errorf("Type = %q, want prometheus", cfg.Datasources.VictoriaMetrics.Type)
+			}
+			if got := strings.Join(cfg.Inspection.IdentityLabels, ","); got != tt.want {
+				t.Errorf("IdentityLabels = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_IdentityLabelsFromEnvironment(t *testing.T) {
+	path := t.TempDir() + "/config.yaml"
+	content := `
+datasources:
+  n9e:
+    endpoint: "http://localhost:17000"
+    token: "test-token"
+  victoriametrics:
+    endpoint: "http://localhost:8428"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("INSPECT_INSPECTION_IDENTITY_LABELS", "node,ident")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := strings.Join(cfg.Inspection.IdentityLabels, ","); got != "node,ident" {
+		t.Errorf("IdentityLabels = %v, want node,ident", got)
+	}
+}
@@ -4,6 +4,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -47,11 +48,52 @@ func LoadMetrics(metricsPath string) ([]*model.MetricDefinition, error) {
 		if m.DisplayName == "" {
 			return nil, fmt.Errorf("metric %q has no display_name", m.Name)
 		}
+		if r := m.ValidRange; r != nil && r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return nil, fmt.Errorf("metric %q has valid_range min %v greater than max %v", m.Name, *r.Min, *r.Max)
+		}
+		if !m.RangeFunction.IsValid() {
+			return nil, fmt.Errorf("metric %q has unknown range_function %q (avg, max or p95)", m.Name, m.RangeFunction)
+		}
+		if m.Export && !m.IsPending() {
+			if m.RangeFunction != "" {
+				return nil, fmt.Errorf("metric %q cannot combine export with range_function", m.Name)
+			}
+			if !m.IsSeriesSelector() {
+				return nil, fmt.Errorf("metric %q sets export but its query is not a series selector: %s", m.Name, m.Query)
+			}
+		}
 	}
 
 	return cfg.Metrics, nil
 }
 
+// rejectValidRange rejects valid_range in the metrics under key of a module metrics file
+// (kind names the module in the error). Only host metrics are checked against their valid
+// range; elsewhere the setting would be silently ignored.
+func rejectValidRange(data []byte, kind, key string) error {
+	var cfg map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("failed to parse %s metrics file: %w", kind, err)
+	}
+	node, ok := cfg[key]
+	if !ok {
+		return nil
+	}
+	var metrics []struct {
+		Name       string            `yaml:"name"`
+		ValidRange *model.ValueRange `yaml:"valid_range"`
+	}
+	if err := node.Decode(&metrics); err != nil {
+		return fmt.Errorf("failed to parse %s metrics file: %w", kind, err)
+	}
+	for _, m := range metrics {
+		if m.ValidRange != nil {
+			return fmt.Errorf("%s metric %q sets valid_range, which only applies to host metrics (metrics.yaml)", kind, m.Name)
+		}
+	}
+	return nil
+}
+
 // CountActiveMetrics returns the count of active (non-pending) metrics.
 func CountActiveMetrics(metrics []*model.MetricDefinition) int {
 	count := 0
@@ -92,6 +134,10 @@ func LoadMySQLMetrics(metricsPath string) ([]*model.MySQLMetricDefinition, error
 		return nil, fmt.Errorf("no MySQL metrics defined in file: %s", metricsPath)
 	}
 
+	if err := rejectValidRange(data, "MySQL", "mysql_metrics"); err != nil {
+		return nil, err
+	}
+
 	// Validate each metric definition
 	for i, m := range cfg.Metrics {
 		if m.Name == "" {
@@ -145,6 +191,10 @@ func LoadRedisMetrics(metricsPath string) ([]*model.RedisMetricDefinition, error
 		return nil, fmt.Errorf("no Redis metrics defined in file: %s", metricsPath)
 	}
 
+	if err := rejectValidRange(data, "Redis", "redis_metrics"); err != nil {
+		return nil, err
+	}
+
 	// Validate each metric definition
 	for i, m := range cfg.Metrics {
 		if m.Name == "" {
@@ -198,6 +248,10 @@ func LoadNginxMetrics(metricsPath string) ([]*model.NginxMetricDefinition, error
 		return nil, fmt.Errorf("no Nginx metrics defined in file: %s", metricsPath)
 	}
 
+	if err := rejectValidRange(data, "Nginx", "nginx_metrics"); err != nil {
+		return nil, err
+	}
+
 	// Validate each metric definition
 	for i, m := range cfg.Metrics {
 		if m.Name == "" {
@@ -251,6 +305,10 @@ func LoadTomcatMetrics(metricsPath string) ([]*model.TomcatMetricDefinition, err
 		return nil, fmt.Errorf("no Tomcat metrics defined in file: %s", metricsPath)
 	}
 
+	if err := rejectValidRange(data, "Tomcat", "tomcat_metrics"); err != nil {
+		return nil, err
+	}
+
 	// Validate each metric definition
 	for i, m := range cfg.Metrics {
 		if m.Name == "" {
@@ -274,3 +332,538 @@ func CountActiveTomcatMetrics(metrics []*model.TomcatMetricDefinition) int {
 	}
 	return count
 }
+
+// LoadCassandraMetrics reads Cassandra metric definitions from the specified YAML file.
+// It returns a slice of CassandraMetricDefinition pointers for use with CassandraCollector and CassandraEvaluator.
+func LoadCassandraMetrics(metricsPath string) ([]*model.CassandraMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("Cassandra metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("Cassandra metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Cassandra metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.CassandraMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse Cassandra metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no Cassandra metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "Cassandra", "cassandra_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("Cassandra metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("Cassandra metric %q has no display_name", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveCassandraMetrics returns the count of active (non-pending) Cassandra metrics.
+func CountActiveCassandraMetrics(metrics []*model.CassandraMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadMonitoringMetrics reads monitoring stack metric definitions from the specified YAML file.
+// It returns a slice of MonitoringMetricDefinition pointers for use with MonitoringCollector and MonitoringEvaluator.
+func LoadMonitoringMetrics(metricsPath string) ([]*model.MonitoringMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("monitoring metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("monitoring metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monitoring metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.MonitoringMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse monitoring metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no monitoring metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "monitoring", "monitoring_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("monitoring metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("monitoring metric %q has no display_name", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveMonitoringMetrics returns the count of active (non-pending) monitoring metrics.
+func CountActiveMonitoringMetrics(metrics []*model.MonitoringMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadStorageMetrics reads shared storage metric definitions from the specified YAML file.
+// It returns a slice of StorageMetricDefinition pointers for use with StorageCollector and StorageEvaluator.
+func LoadStorageMetrics(metricsPath string) ([]*model.StorageMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("storage metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("storage metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.StorageMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse storage metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no storage metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "storage", "storage_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("storage metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("storage metric %q has no display_name", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveStorageMetrics returns the count of active (non-pending) storage metrics.
+func CountActiveStorageMetrics(metrics []*model.StorageMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadLogChecks reads log check definitions from the specified YAML file.
+// It returns a slice of LogCheckDefinition pointers for use with LogCheckCollector and LogCheckEvaluator.
+func LoadLogChecks(checksPath string) ([]*model.LogCheckDefinition, error) {
+	if checksPath == "" {
+		return nil, fmt.Errorf("log checks file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(checksPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("log checks file not found: %s", checksPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(checksPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log checks file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.LogChecksConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse log checks file: %w", err)
+	}
+
+	// Validate checks
+	if len(cfg.Checks) == 0 {
+		return nil, fmt.Errorf("no log checks defined in file: %s", checksPath)
+	}
+
+	// Validate each check definition
+	seen := make(map[string]bool, len(cfg.Checks))
+	for i, c := range cfg.Checks {
+		if c.Name == "" {
+			return nil, fmt.Errorf("log check at index %d has no name", i)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("duplicate log check name: %q", c.Name)
+		}
+		seen[c.Name] = true
+		if c.DisplayName == "" {
+			return nil, fmt.Errorf("log check %q has no display_name", c.Name)
+		}
+		if c.Warning > 0 && c.Critical > 0 && c.Warning >= c.Critical {
+			return nil, fmt.Errorf("log check %q: warning threshold (%.0f) must be less than critical threshold (%.0f)", c.Name, c.Warning, c.Critical)
+		}
+	}
+
+	return cfg.Checks, nil
+}
+
+// CountActiveLogChecks returns the count of active (non-pending) log checks.
+func CountActiveLogChecks(checks []*model.LogCheckDefinition) int {
+	count := 0
+	for _, c := range checks {
+		if !c.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadLVSMetrics reads LVS metric definitions from the specified YAML file.
+// It returns a slice of LVSMetricDefinition pointers for use with LVSCollector and LVSEvaluator.
+func LoadLVSMetrics(metricsPath string) ([]*model.LVSMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("LVS metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("LVS metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LVS metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.LVSMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse LVS metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no LVS metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "LVS", "lvs_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("LVS metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("LVS metric %q has no display_name", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveLVSMetrics returns the count of active (non-pending) LVS metrics.
+func CountActiveLVSMetrics(metrics []*model.LVSMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadWindowsMetrics reads Windows metric definitions from the specified YAML file.
+// It returns a slice of WindowsMetricDefinition pointers for use with WindowsCollector and WindowsEvaluator.
+func LoadWindowsMetrics(metricsPath string) ([]*model.WindowsMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("Windows metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("Windows metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Windows metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.WindowsMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse Windows metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no Windows metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "Windows", "windows_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("Windows metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("Windows metric %q has no display_name", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveWindowsMetrics returns the count of active (non-pending) Windows metrics.
+func CountActiveWindowsMetrics(metrics []*model.WindowsMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadADMetrics reads AD metric definitions from the specified YAML file.
+// It returns a slice of ADMetricDefinition pointers for use with ADCollector and ADEvaluator.
+func LoadADMetrics(metricsPath string) ([]*model.ADMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("AD metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("AD metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AD metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.ADMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse AD metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no AD metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "AD", "ad_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("AD metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("AD metric %q has no display_name", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveADMetrics returns the count of active (non-pending) AD metrics.
+func CountActiveADMetrics(metrics []*model.ADMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadCloudMetrics reads cloud metric definitions from the specified YAML file.
+// It returns a slice of CloudMetricDefinition pointers for use with CloudCollector and CloudEvaluator.
+func LoadCloudMetrics(metricsPath string) ([]*model.CloudMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("cloud metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("cloud metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cloud metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.CloudMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse cloud metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no cloud metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "cloud", "cloud_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("cloud metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("cloud metric %q has no display_name", m.Name)
+		}
+		if m.IsPending() {
+			continue
+		}
+		if m.Provider == "" || m.ResourceType == "" || m.Field == "" || m.IDLabel == "" {
+			return nil, fmt.Errorf("cloud metric %q requires provider, resource_type, field and id_label", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveCloudMetrics returns the count of active (non-pending) cloud metrics.
+func CountActiveCloudMetrics(metrics []*model.CloudMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadFirewallMetrics reads firewall metric definitions from the specified YAML file.
+// It returns a slice of FirewallMetricDefinition pointers for use with FirewallCollector and FirewallEvaluator.
+func LoadFirewallMetrics(metricsPath string) ([]*model.FirewallMetricDefinition, error) {
+	if metricsPath == "" {
+		return nil, fmt.Errorf("firewall metrics file path is required")
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("firewall metrics file not found: %s", metricsPath)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read firewall metrics file: %w", err)
+	}
+
+	// Parse YAML
+	var cfg model.FirewallMetricsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse firewall metrics file: %w", err)
+	}
+
+	// Validate metrics
+	if len(cfg.Metrics) == 0 {
+		return nil, fmt.Errorf("no firewall metrics defined in file: %s", metricsPath)
+	}
+
+	if err := rejectValidRange(data, "firewall", "firewall_metrics"); err != nil {
+		return nil, err
+	}
+
+	// Validate each metric definition
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			return nil, fmt.Errorf("firewall metric at index %d has no name", i)
+		}
+		if m.DisplayName == "" {
+			return nil, fmt.Errorf("firewall metric %q has no display_name", m.Name)
+		}
+		if m.IsPending() {
+			continue
+		}
+		if m.Profile == "" || m.Field == "" || m.DeviceLabel == "" {
+			return nil, fmt.Errorf("firewall metric %q requires profile, field and device_label", m.Name)
+		}
+		if !model.IsFirewallField(m.Field) {
+			return nil, fmt.Errorf("firewall metric %q has unknown field %q (%s)", m.Name, m.Field, strings.Join(model.FirewallFields, ", "))
+		}
+		if m.Field == model.FirewallFieldVPNTunnelStatus && m.TunnelLabel == "" {
+			return nil, fmt.Errorf("firewall metric %q requires tunnel_label for field vpn_tunnel_status", m.Name)
+		}
+	}
+
+	return cfg.Metrics, nil
+}
+
+// CountActiveFirewallMetrics returns the count of active (non-pending) firewall metrics.
+func CountActiveFirewallMetrics(metrics []*model.FirewallMetricDefinition) int {
+	count := 0
+	for _, m := range metrics {
+		if !m.IsPending() {
+			count++
+		}
+	}
+	return count
+}
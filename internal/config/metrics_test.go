@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"inspection-tool/internal/model"
 )
 
 func TestLoadMetrics_Success(t *testing.T) {
@@ -124,6 +127,124 @@ metrics:
 	}
 }
 
+func TestLoadMetrics_ValidRange(t *testing.T) {
+	tmpDir := t.TempDir()
+	metricsPath := filepath.Join(tmpDir, "range.yaml")
+	content := `
+metrics:
+  - name: cpu_usage
+    display_name: "CPU 利用率"
+    query: 'cpu_usage'
+    valid_range: {min: 0, max: 100}
+  - name: processes_total
+    display_name: "总进程数"
+    query: 'processes_total'
+    valid_range: {min: 0}
+`
+	if err := os.WriteFile(metricsPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	metrics, err := LoadMetrics(metricsPath)
+	if err != nil {
+		t.Fatalf("LoadMetrics() error = %v", err)
+	}
+	cpu := metrics[0].ValidRange
+	if cpu == nil || cpu.Min == nil || *cpu.Min != 0 || cpu.Max == nil || *cpu.Max != 100 {
+		t.Errorf("cpu_usage valid_range = %+v, want 0-100", cpu)
+	}
+	if procs := metrics[1].ValidRange; procs == nil || procs.Max != nil {
+		t.Errorf("processes_total valid_range = %+v, want min only", procs)
+	}
+}
+
+func TestLoadMetrics_InvalidValidRange(t *testing.T) {
+	tmpDir := t.TempDir()
+	metricsPath := filepath.Join(tmpDir, "bad_range.yaml")
+	content := `
+metrics:
+  - name: cpu_usage
+    display_name: "CPU 利用率"
+    query: 'cpu_usage'
+    valid_range: {min: 100, max: 0}
+`
+	if err := os.WriteFile(metricsPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	_, err := LoadMetrics(metricsPath)
+	if err == nil {
+		t.Fatal("expected error for valid_range min greater than max")
+	}
+}
+
+func TestLoadMetrics_RangeFunction(t *testing.T) {
+	tmpDir := t.TempDir()
+	metricsPath := filepath.Join(tmpDir, "range.yaml")
+	content := `
+metrics:
+  - name: cpu_usage
+    display_name: "CPU 利用率"
+    query: 'cpu_usage'
+    range_function: p95
+`
+	if err := os.WriteFile(metricsPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	metrics, err := LoadMetrics(metricsPath)
+	if err != nil {
+		t.Fatalf("LoadMetrics() error = %v", err)
+	}
+	if metrics[0].RangeFunction != model.RangeFunctionP95 {
+		t.Errorf("range_function = %q, want p95", metrics[0].RangeFunction)
+	}
+
+	content = strings.Replace(content, "p95", "median", 1)
+	if err := os.WriteFile(metricsPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	if _, err := LoadMetrics(metricsPath); err == nil || !strings.Contains(err.Error(), "unknown range_function") {
+		t.Errorf("LoadMetrics() error = %v, want unknown range_function", err)
+	}
+}
+
+func TestLoadMetrics_Export(t *testing.T) {
+	tests := []struct {
+		name    string
+		extra   string
+		query   string
+		wantErr string
+	}{
+		{"series selector", "", `disk_used_percent{fstype!="tmpfs"}`, ""},
+		{"expression", "", "100 - mem_available_percent", "not a series selector"},
+		{"with range_function", "    range_function: max\n", "disk_used_percent", "cannot combine export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metricsPath := filepath.Join(t.TempDir(), "export.yaml")
+			content := "metrics:\n  - name: disk_usage\n    display_name: \"磁盘利用率\"\n    query: '" + tt.query + "'\n    export: true\n" + tt.extra
+			if err := os.WriteFile(metricsPath, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+
+			metrics, err := LoadMetrics(metricsPath)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("LoadMetrics() error = %v", err)
+				}
+				if !metrics[0].Export {
+					t.Error("export = false, want true")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadMetrics() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestLoadMetrics_WithPendingMetrics(t *testing.T) {
 	content := `
 metrics:
@@ -451,6 +572,47 @@ mysql_metrics:
 	}
 }
 
+func TestLoadModuleMetrics_ValidRangeRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		load    func(path string) error
+	}{
+		{"mysql", "mysql_metrics:\n  - name: mysql_up\n    display_name: \"连接状态\"\n    query: \"mysql_up\"\n", func(path string) error {
+			_, err := LoadMySQLMetrics(path)
+			return err
+		}},
+		{"redis", "redis_metrics:\n  - name: redis_up\n    display_name: \"连接状态\"\n    query: \"redis_up\"\n", func(path string) error {
+			_, err := LoadRedisMetrics(path)
+			return err
+		}},
+		{"windows", "windows_metrics:\n  - name: cpu_usage\n    display_name: \"CPU 利用率\"\n    query: \"windows_cpu\"\n", func(path string) error {
+			_, err := LoadWindowsMetrics(path)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "metrics.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+			if err := tt.load(path); err != nil {
+				t.Fatalf("load error = %v, want nil without valid_range", err)
+			}
+
+			content := tt.content + "    valid_range: {min: 0, max: 100}\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+			err := tt.load(path)
+			if err == nil || !strings.Contains(err.Error(), "valid_range") {
+				t.Errorf("load error = %v, want valid_range rejected", err)
+			}
+		})
+	}
+}
+
 func TestLoadMySQLMetrics_RealFile(t *testing.T) {
 	// Test with the actual mysql-metrics.yaml file if it exists
 	metricsPath := "../../configs/mysql-metrics.yaml"
@@ -838,3 +1000,156 @@ redis_metrics:
 		t.Errorf("expected 0 active metrics, got %d", activeCount)
 	}
 }
+
+// ============================================================================
+// Log Checks Loading Tests
+// ============================================================================
+
+func TestLoadLogChecks_Success(t *testing.T) {
+	content := `
+log_checks:
+  - name: error_logs
+    display_name: "错误日志数"
+    query: '{env="prod"} |= "ERROR"'
+    group_by: app
+    warning: 10
+    critical: 100
+  - name: slow_sql
+    display_name: "慢 SQL"
+    status: pending
+`
+	tmpDir := t.TempDir()
+	checksPath := filepath.Join(tmpDir, "log-checks.yaml")
+	if err := os.WriteFile(checksPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	checks, err := LoadLogChecks(checksPath)
+	if err != nil {
+		t.Fatalf("LoadLogChecks() error = %v", err)
+	}
+
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(checks))
+	}
+	if checks[0].GroupBy != "app" || checks[0].Warning != 10 || checks[0].Critical != 100 {
+		t.Errorf("unexpected first check: %+v", checks[0])
+	}
+	if CountActiveLogChecks(checks) != 1 {
+		t.Errorf("expected 1 active check, got %d", CountActiveLogChecks(checks))
+	}
+}
+
+func TestLoadLogChecks_InvalidDefinitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"duplicate name", `
+log_checks:
+  - name: error_logs
+    display_name: "错误日志数"
+  - name: error_logs
+    display_name: "错误日志数"
+`},
+		{"threshold order", `
+log_checks:
+  - name: error_logs
+    display_name: "错误日志数"
+    warning: 100
+    critical: 10
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checksPath := filepath.Join(t.TempDir(), "log-checks.yaml")
+			if err := os.WriteFile(checksPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+
+			if _, err := LoadLogChecks(checksPath); err == nil {
+				t.Error("LoadLogChecks() should return error")
+			}
+		})
+	}
+}
+
+func TestLoadLogChecks_RealFile(t *testing.T) {
+	checksPath := "../../configs/log-checks.yaml"
+	if _, err := os.Stat(checksPath); os.IsNotExist(err) {
+		t.Skip("configs/log-checks.yaml not found, skipping real file test")
+	}
+
+	checks, err := LoadLogChecks(checksPath)
+	if err != nil {
+		t.Fatalf("LoadLogChecks() error = %v", err)
+	}
+
+	if len(checks) == 0 {
+		t.Error("expected at least one check from real file")
+	}
+}
+
+func TestLoadFirewallMetrics_MissingTunnelLabel(t *testing.T) {
+	content := `
+firewall_metrics:
+  - name: fortigate_vpn_tunnel_status
+    display_name: "VPN 隧道状态"
+    query: "fgVpnTunEntStatus == bool 2"
+    profile: fortigate
+    field: vpn_tunnel_status
+    device_label: instance
+`
+	metricsPath := filepath.Join(t.TempDir(), "firewall-metrics.yaml")
+	if err := os.WriteFile(metricsPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	if _, err := LoadFirewallMetrics(metricsPath); err == nil || !strings.Contains(err.Error(), "tunnel_label") {
+		t.Errorf("LoadFirewallMetrics() error = %v, want missing tunnel_label", err)
+	}
+}
+
+func TestLoadFirewallMetrics_UnknownField(t *testing.T) {
+	content := `
+firewall_metrics:
+  - name: fortigate_cpu_usage
+    display_name: "CPU 使用率"
+    query: "fgSysCpuUsage"
+    profile: fortigate
+    field: cpu_usgae
+    device_label: instance
+`
+	metricsPath := filepath.Join(t.TempDir(), "firewall-metrics.yaml")
+	if err := os.WriteFile(metricsPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	_, err := LoadFirewallMetrics(metricsPath)
+	if err == nil || !strings.Contains(err.Error(), "fortigate_cpu_usage") || !strings.Contains(err.Error(), "cpu_usgae") {
+		t.Errorf("LoadFirewallMetrics() error = %v, want unknown field of fortigate_cpu_usage", err)
+	}
+}
+
+func TestLoadFirewallMetrics_RealFile(t *testing.T) {
+	metricsPath := "../../configs/firewall-metrics.yaml"
+	if _, err := os.Stat(metricsPath); os.IsNotExist(err) {
+		t.Skip("configs/firewall-metrics.yaml not found, skipping real file test")
+	}
+
+	metrics, err := LoadFirewallMetrics(metricsPath)
+	if err != nil {
+		t.Fatalf("LoadFirewallMetrics() error = %v", err)
+	}
+
+	profiles := make(map[string]bool)
+	for _, m := range metrics {
+		profiles[m.Profile] = true
+	}
+	for _, profile := range []string{"fortigate", "paloalto", "cisco_asa"} {
+		if !profiles[profile] {
+			t.Errorf("expected metrics of the %s profile", profile)
+		}
+	}
+}
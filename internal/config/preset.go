@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/model"
+)
+
+// Inspection preset names.
+const (
+	PresetQuick = "quick" // 快速健康检查：仅可用性、磁盘、内存与复制状态，缩短超时
+)
+
+// Preset is a named inspection profile applied on top of the configuration: it limits the
+// run to a subset of modules and metrics and caps datasource timeouts and retries.
+type Preset struct {
+	Name        string
+	Description string
+
+	Modules      []string // Modules still inspected (host, mysql, redis, ...)
+	HostMetrics  []string // Host metric names kept (empty keeps all)
+	MySQLMetrics []string // MySQL metric names kept (empty keeps all)
+	RedisMetrics []string // Redis metric names kept (empty keeps all)
+
+	QueryTimeout time.Duration // Upper bound of the N9E / VictoriaMetrics / logs request timeouts
+	HostTimeout  time.Duration // Upper bound of inspection.host_timeout
+	MaxRetries   int           // Upper bound of http.retry.max_retries
+	Deadline     time.Duration // Deadline of the whole inspection
+}
+
+// presets holds the built-in inspection presets.
+var presets = map[string]*Preset{
+	PresetQuick: {
+		Name:        PresetQuick,
+		Description: "快速健康检查（变更前抽查）：仅主机、MySQL、Redis 的可用性、磁盘、内存与复制状态",
+		Modules:     []string{"host", "mysql", "redis"},
+		HostMetrics: []string{"uptime", "memory_usage", "memory_available_percent", "disk_usage"},
+		MySQLMetrics: []string{
+			"mysql_up", "mgr_member_count", "mgr_role_primary", "mgr_state_online", "mgr_members",
+			"slave_running",
+		},
+		RedisMetrics: []string{
+			"redis_up", "redis_cluster_enabled", "redis_master_link_status", "redis_master_port",
+			"redis_connected_slaves", "redis_master_repl_offset", "redis_slave_repl_offset",
+			"redis_used_memory", "redis_maxmemory",
+		},
+		QueryTimeout: 10 * time.Second,
+		HostTimeout:  5 * time.Second,
+		MaxRetries:   1,
+		Deadline:     time.Minute,
+	},
+}
+
+// LookupPreset returns the built-in preset with the given name.
+func LookupPreset(name string) (*Preset, error) {
+	p, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q, available: %s", name, strings.Join(PresetNames(), ", "))
+	}
+	return p, nil
+}
+
+// PresetNames returns the names of the built-in presets, sorted.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply caps the datasource timeouts, host timeout and retries of cfg to the preset limits.
+// Values already below the limits are kept.
+func (p *Preset) Apply(cfg *Config) {
+	capDuration(&cfg.Datasources.N9E.Timeout, p.QueryTimeout)
+	capDuration(&cfg.Datasources.VictoriaMetrics.Timeout, p.QueryTimeout)
+	capDuration(&cfg.Datasources.Logs.Timeout, p.QueryTimeout)
+	capDuration(&cfg.Inspection.HostTimeout, p.HostTimeout)
+	if cfg.HTTP.Retry.MaxRetries > p.MaxRetries {
+		cfg.HTTP.Retry.MaxRetries = p.MaxRetries
+	}
+}
+
+// capDuration lowers *d to limit when d is unset or above it. A zero limit leaves d unchanged.
+func capDuration(d *time.Duration, limit time.Duration) {
+	if limit > 0 && (*d <= 0 || *d > limit) {
+		*d = limit
+	}
+}
+
+// RunsModule reports whether the module is inspected under the preset.
+func (p *Preset) RunsModule(module string) bool {
+	for _, m := range p.Modules {
+		if m == module {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterMetrics keeps the host metrics selected by the preset.
+func (p *Preset) FilterMetrics(metrics []*model.MetricDefinition) []*model.MetricDefinition {
+	return filterByName(metrics, p.HostMetrics, func(m *model.MetricDefinition) string { return m.Name })
+}
+
+// FilterMySQLMetrics keeps the MySQL metrics selected by the preset.
+func (p *Preset) FilterMySQLMetrics(metrics []*model.MySQLMetricDefinition) []*model.MySQLMetricDefinition {
+	return filterByName(metrics, p.MySQLMetrics, func(m *model.MySQLMetricDefinition) string { return m.Name })
+}
+
+// FilterRedisMetrics keeps the Redis metrics selected by the preset.
+func (p *Preset) FilterRedisMetrics(metrics []*model.RedisMetricDefinition) []*model.RedisMetricDefinition {
+	return filterByName(metrics, p.RedisMetrics, func(m *model.RedisMetricDefinition) string { return m.Name })
+}
+
+// filterByName keeps the metrics whose name is listed, in their original order.
+// An empty list keeps all metrics.
+func filterByName[T any](metrics []T, names []string, name func(T) string) []T {
+	if len(names) == 0 {
+		return metrics
+	}
+	keep := make(map[string]bool, len(names))
+	for _, n := range names {
+		keep[n] = true
+	}
+	filtered := make([]T, 0, len(names))
+	for _, m := range metrics {
+		if keep[name(m)] {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/model"
+)
+
+func TestLookupPreset(t *testing.T) {
+	p, err := LookupPreset(PresetQuick)
+	if err != nil {
+		t.Fatalf("LookupPreset() error = %v", err)
+	}
+	if p.Name != PresetQuick {
+		t.Errorf("Name = %q, want %q", p.Name, PresetQuick)
+	}
+
+	_, err = LookupPreset("full")
+	if err == nil {
+		t.Fatal("LookupPreset() should return error for an unknown preset")
+	}
+	if !strings.Contains(err.Error(), PresetQuick) {
+		t.Errorf("error should list available presets, got: %s", err.Error())
+	}
+}
+
+func TestPreset_Apply(t *testing.T) {
+	p, _ := LookupPreset(PresetQuick)
+
+	cfg := &Config{}
+	cfg.Datasources.N9E.Timeout = 30 * time.Second
+	cfg.Datasources.VictoriaMetrics.Timeout = 5 * time.Second
+	cfg.Inspection.HostTimeout = 0
+	cfg.HTTP.Retry.MaxRetries = 3
+
+	p.Apply(cfg)
+
+	if cfg.Datasources.N9E.Timeout != p.QueryTimeout {
+		t.Errorf("N9E timeout = %v, want %v", cfg.Datasources.N9E.Timeout, p.QueryTimeout)
+	}
+	if cfg.Datasources.VictoriaMetrics.Timeout != 5*time.Second {
+		t.Errorf("VM timeout below the limit should be kept, got %v", cfg.Datasources.VictoriaMetrics.Timeout)
+	}
+	if cfg.Datasources.Logs.Timeout != p.QueryTimeout {
+		t.Errorf("unset logs timeout = %v, want %v", cfg.Datasources.Logs.Timeout, p.QueryTimeout)
+	}
+	if cfg.Inspection.HostTimeout != p.HostTimeout {
+		t.Errorf("host timeout = %v, want %v", cfg.Inspection.HostTimeout, p.HostTimeout)
+	}
+	if cfg.HTTP.Retry.MaxRetries != p.MaxRetries {
+		t.Errorf("max retries = %d, want %d", cfg.HTTP.Retry.MaxRetries, p.MaxRetries)
+	}
+}
+
+func TestPreset_RunsModule(t *testing.T) {
+	p, _ := LookupPreset(PresetQuick)
+
+	for _, module := range []string{"host", "mysql", "redis"} {
+		if !p.RunsModule(module) {
+			t.Errorf("quick preset should run %s", module)
+		}
+	}
+	for _, module := range []string{"nginx", "tomcat", "cloud"} {
+		if p.RunsModule(module) {
+			t.Errorf("quick preset should not run %s", module)
+		}
+	}
+}
+
+func TestModuleSelection_Runs(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection ModuleSelection
+		want      []string // Modules run among host, mysql, redis
+	}{
+		{"empty runs all", ModuleSelection{}, []string{"host", "mysql", "redis"}},
+		{"only", ModuleSelection{Only: []string{"redis", "mysql"}}, []string{"mysql", "redis"}},
+		{"skip", ModuleSelection{Skip: []string{"host"}}, []string{"mysql", "redis"}},
+		{"skip wins over only", ModuleSelection{Only: []string{"host", "mysql"}, Skip: []string{"host"}}, []string{"mysql"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, module := range []string{"host", "mysql", "redis"} {
+				if tt.selection.Runs(module) {
+					got = append(got, module)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Runs() selected %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModuleSelection_CheckNames(t *testing.T) {
+	if err := (ModuleSelection{Only: []string{"log_checks"}, Skip: []string{"host"}}).CheckNames(); err != nil {
+		t.Errorf("CheckNames() error = %v", err)
+	}
+	err := (ModuleSelection{Skip: []string{"postgres"}}).CheckNames()
+	if err == nil || !strings.Contains(err.Error(), `"postgres"`) {
+		t.Errorf("CheckNames() error = %v, want the unknown module named", err)
+	}
+}
+
+func TestPreset_FilterMetrics(t *testing.T) {
+	p, _ := LookupPreset(PresetQuick)
+
+	hostMetrics := []*model.MetricDefinition{
+		{Name: "cpu_usage"}, {Name: "memory_usage"}, {Name: "disk_usage"}, {Name: "load_1m"},
+	}
+	got := p.FilterMetrics(hostMetrics)
+	if len(got) != 2 || got[0].Name != "memory_usage" || got[1].Name != "disk_usage" {
+		t.Errorf("FilterMetrics() = %v, want memory_usage and disk_usage", got)
+	}
+
+	mysqlMetrics := []*model.MySQLMetricDefinition{{Name: "mysql_up"}, {Name: "qps"}, {Name: "slave_running"}}
+	gotMySQL := p.FilterMySQLMetrics(mysqlMetrics)
+	if len(gotMySQL) != 2 || gotMySQL[0].Name != "mysql_up" || gotMySQL[1].Name != "slave_running" {
+		t.Errorf("FilterMySQLMetrics() kept %d metrics, want mysql_up and slave_running", len(gotMySQL))
+	}
+
+	redisMetrics := []*model.RedisMetricDefinition{{Name: "redis_up"}, {Name: "redis_keyspace_hits"}}
+	gotRedis := p.FilterRedisMetrics(redisMetrics)
+	if len(gotRedis) != 1 || gotRedis[0].Name != "redis_up" {
+		t.Errorf("FilterRedisMetrics() kept %d metrics, want redis_up", len(gotRedis))
+	}
+
+	// A preset without a metric list keeps all metrics
+	empty := &Preset{}
+	if len(empty.FilterMetrics(hostMetrics)) != len(hostMetrics) {
+		t.Error("empty metric list should keep all metrics")
+	}
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePEM writes a PEM block to a file of dir and returns its path.
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// writeClientCertificate writes a self-signed client certificate and its key to dir.
+func writeClientCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "inspection-tool"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return writePEM(t, dir, "client.crt", "CERTIFICATE", der), writePEM(t, dir, "client.key", "EC PRIVATE KEY", keyDER)
+}
+
+func TestTLSConfig_ClientConfig_Empty(t *testing.T) {
+	cfg, err := TLSConfig{}.ClientConfig()
+	if err != nil || cfg != nil {
+		t.Errorf("ClientConfig() = %v, %v, want nil, nil", cfg, err)
+	}
+}
+
+func TestTLSConfig_ClientConfig_CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile := writePEM(t, t.TempDir(), "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+	tlsConfig, err := TLSConfig{CAFile: caFile}.ClientConfig()
+	if err != nil {
+		t.Fatalf("ClientConfig() error = %v", err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with custom CA failed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestTLSConfig_ClientConfig_MutualTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			t.Error("expected a client certificate")
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeClientCertificate(t, dir)
+	tlsConfig, err := TLSConfig{
+		CAFile:   writePEM(t, dir, "ca.crt", "CERTIFICATE", server.Certificate().Raw),
+		CertFile: certFile,
+		KeyFile:  keyFile,
+	}.ClientConfig()
+	if err != nil {
+		t.Fatalf("ClientConfig() error = %v", err)
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Fatalf("expected 1 client certificate, got %d", len(tlsConfig.Certificates))
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("mTLS request failed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestTLSConfig_ClientConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, _ := writeClientCertificate(t, dir)
+	notPEM := filepath.Join(dir, "ca.txt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  TLSConfig
+		want string
+	}{
+		{"cert without key", TLSConfig{CertFile: certFile}, "must be set together"},
+		{"missing CA file", TLSConfig{CAFile: filepath.Join(dir, "missing.crt")}, "failed to read CA file"},
+		{"CA file without certificate", TLSConfig{CAFile: notPEM}, "no PEM certificate"},
+		{"key not matching", TLSConfig{CertFile: certFile, KeyFile: certFile}, "failed to load client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cfg.ClientConfig()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ClientConfig() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTLSConfig_InsecureSkipVerify(t *testing.T) {
+	tlsConfig, err := TLSConfig{InsecureSkipVerify: true}.ClientConfig()
+	if err != nil {
+		t.Fatalf("ClientConfig() error = %v", err)
+	}
+	if !tlsConfig.InsecureSkipVerify || tlsConfig.RootCAs != nil {
+		t.Errorf("unexpected TLS config: %+v", tlsConfig)
+	}
+}
@@ -3,10 +3,17 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
+
+	"inspection-tool/internal/report/theme"
 )
 
 // ValidationError represents a single validation error with user-friendly message.
@@ -77,6 +84,42 @@ func Validate(cfg *Config) error {
 		validationErrors = append(validationErrors, errs...)
 	}
 
+	if errs := validateReportTheme(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateReportBranding(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateReportChartLibrary(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateReportHTML(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateReportExcelTemplate(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateReportPublish(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateTLSConfigs(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateProxyURLs(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateGrafanaProxy(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
 	if errs := validateMySQLThresholds(cfg); len(errs) > 0 {
 		validationErrors = append(validationErrors, errs...)
 	}
@@ -89,6 +132,62 @@ func Validate(cfg *Config) error {
 		validationErrors = append(validationErrors, errs...)
 	}
 
+	if errs := validateTomcatThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateCassandraThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateMonitoringThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateStorageThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateLVSThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateWindowsThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateADThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateCloudThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateFirewallThresholds(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateLogExcerpts(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateHostExclude(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateSourcePreference(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateSSHFallback(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
+	if errs := validateDistributed(cfg); len(errs) > 0 {
+		validationErrors = append(validationErrors, errs...)
+	}
+
 	if len(validationErrors) > 0 {
 		return validationErrors
 	}
@@ -106,29 +205,47 @@ func validateTimezone(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
-// validateThresholds validates that warning thresholds are less than critical thresholds.
+// validateThresholds validates the order of warning and critical thresholds.
+// For "above" pairs warning must be less than critical; for "below" pairs critical must not
+// exceed warning. A threshold of 0 disables its level, so the order is only checked when
+// both levels are set.
 func validateThresholds(cfg *Config) ValidationErrors {
 	var errors ValidationErrors
 
 	thresholdPairs := []struct {
-		name     string
-		warning  float64
-		critical float64
+		name string
+		pair ThresholdPair
 	}{
-		{"thresholds.cpu_usage", cfg.Thresholds.CPUUsage.Warning, cfg.Thresholds.CPUUsage.Critical},
-		{"thresholds.memory_usage", cfg.Thresholds.MemoryUsage.Warning, cfg.Thresholds.MemoryUsage.Critical},
-		{"thresholds.disk_usage", cfg.Thresholds.DiskUsage.Warning, cfg.Thresholds.DiskUsage.Critical},
-		{"thresholds.zombie_processes", cfg.Thresholds.ZombieProcesses.Warning, cfg.Thresholds.ZombieProcesses.Critical},
-		{"thresholds.load_per_core", cfg.Thresholds.LoadPerCore.Warning, cfg.Thresholds.LoadPerCore.Critical},
+		{"thresholds.cpu_usage", cfg.Thresholds.CPUUsage},
+		{"thresholds.memory_usage", cfg.Thresholds.MemoryUsage},
+		{"thresholds.disk_usage", cfg.Thresholds.DiskUsage},
+		{"thresholds.zombie_processes", cfg.Thresholds.ZombieProcesses},
+		{"thresholds.load_per_core", cfg.Thresholds.LoadPerCore},
+		{"thresholds.memory_available_percent", cfg.Thresholds.MemoryAvailablePercent},
 	}
 
 	for _, tp := range thresholdPairs {
-		if tp.warning >= tp.critical {
+		warning, critical := tp.pair.Warning, tp.pair.Critical
+		if warning == 0 || critical == 0 {
+			continue
+		}
+		if tp.pair.IsBelow() {
+			if warning < critical {
+				errors = append(errors, &ValidationError{
+					Field:   tp.name,
+					Tag:     "threshold_order",
+					Value:   fmt.Sprintf("warning=%v, critical=%v", warning, critical),
+					Message: fmt.Sprintf("warning threshold (%.2f) must be greater than or equal to critical threshold (%.2f) when direction is below", warning, critical),
+				})
+			}
+			continue
+		}
+		if warning >= critical {
 			errors = append(errors, &ValidationError{
 				Field:   tp.name,
 				Tag:     "threshold_order",
-				Value:   fmt.Sprintf("warning=%v, critical=%v", tp.warning, tp.critical),
-				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", tp.warning, tp.critical),
+				Value:   fmt.Sprintf("warning=%v, critical=%v", warning, critical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", warning, critical),
 			})
 		}
 	}
@@ -151,6 +268,273 @@ func validateTimezoneConfig(cfg *Config) ValidationErrors {
 		}
 	}
 
+	if cfg.Report.SecondaryTimezone != "" {
+		if _, err := time.LoadLocation(cfg.Report.SecondaryTimezone); err != nil {
+			errors = append(errors, &ValidationError{
+				Field:   "report.secondary_timezone",
+				Tag:     "timezone",
+				Value:   cfg.Report.SecondaryTimezone,
+				Message: fmt.Sprintf("invalid timezone: %s", cfg.Report.SecondaryTimezone),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateReportTheme validates the white-label theme: colors must be #RRGGBB and
+// the logo must be a readable PNG, JPEG or GIF file.
+func validateReportTheme(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	t := cfg.Report.Theme
+	colors := []struct {
+		name  string
+		value string
+	}{
+		{"report.theme.primary_color", t.PrimaryColor},
+		{"report.theme.secondary_color", t.SecondaryColor},
+	}
+	for _, c := range colors {
+		if c.value != "" && !theme.IsHexColor(c.value) {
+			errors = append(errors, &ValidationError{
+				Field:   c.name,
+				Tag:     "hexcolor",
+				Value:   c.value,
+				Message: fmt.Sprintf("invalid color %q, expected #RRGGBB", c.value),
+			})
+		}
+	}
+
+	errors = append(errors, validateLogoFile("report.theme.logo_file", t.LogoFile)...)
+
+	return errors
+}
+
+// validateReportBranding validates the cover page: the cover logo must be a readable PNG,
+// JPEG or GIF file.
+func validateReportBranding(cfg *Config) ValidationErrors {
+	return validateLogoFile("report.branding.logo_file", cfg.Report.Branding.LogoFile)
+}
+
+// validateLogoFile validates that the logo file of field, if set, is a readable PNG, JPEG or
+// GIF file.
+func validateLogoFile(field, path string) ValidationErrors {
+	if path == "" {
+		return nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		if _, err := os.Stat(path); err != nil {
+			return ValidationErrors{&ValidationError{
+				Field:   field,
+				Tag:     "file",
+				Value:   path,
+				Message: fmt.Sprintf("logo file not readable: %v", err),
+			}}
+		}
+	default:
+		return ValidationErrors{&ValidationError{
+			Field:   field,
+			Tag:     "image",
+			Value:   path,
+			Message: "logo file must be a PNG, JPEG or GIF image",
+		}}
+	}
+	return nil
+}
+
+// validateReportChartLibrary validates that the configured ECharts script exists.
+func validateReportChartLibrary(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	path := cfg.Report.ChartLibrary
+	if path == "" {
+		return errors
+	}
+	if _, err := os.Stat(path); err != nil {
+		errors = append(errors, &ValidationError{
+			Field:   "report.chart_library",
+			Tag:     "file",
+			Value:   path,
+			Message: fmt.Sprintf("chart library not readable: %v", err),
+		})
+	}
+
+	return errors
+}
+
+// validateReportHTML validates that the custom theme of the HTML reports has a readable
+// stylesheet.
+func validateReportHTML(cfg *Config) ValidationErrors {
+	h := cfg.Report.HTML
+	if h.Theme != "custom" {
+		return nil
+	}
+	if h.CSSFile == "" {
+		return ValidationErrors{&ValidationError{
+			Field:   "report.html.css_file",
+			Tag:     "required",
+			Message: "css_file is required when report.html.theme is custom",
+		}}
+	}
+	if _, err := os.Stat(h.CSSFile); err != nil {
+		return ValidationErrors{&ValidationError{
+			Field:   "report.html.css_file",
+			Tag:     "file",
+			Value:   h.CSSFile,
+			Message: fmt.Sprintf("stylesheet not readable: %v", err),
+		}}
+	}
+	return nil
+}
+
+// validateReportExcelTemplate checks that the configured Excel template can be read.
+func validateReportExcelTemplate(cfg *Config) ValidationErrors {
+	path := cfg.Report.Excel.Template
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ValidationErrors{&ValidationError{
+			Field:   "report.excel.template",
+			Tag:     "file",
+			Value:   path,
+			Message: fmt.Sprintf("excel template not readable: %v", err),
+		}}
+	}
+	return nil
+}
+
+// validateReportPublish checks that an enabled Confluence publisher has its URL, space and token.
+func validateReportPublish(cfg *Config) ValidationErrors {
+	c := cfg.Report.Publish.Confluence
+	if !c.Enabled {
+		return nil
+	}
+
+	var errors ValidationErrors
+	for _, field := range []struct {
+		name, value string
+	}{
+		{"url", c.URL},
+		{"space_key", c.SpaceKey},
+		{"token", c.Token},
+	} {
+		if field.value == "" {
+			errors = append(errors, &ValidationError{
+				Field:   "report.publish.confluence." + field.name,
+				Tag:     "required",
+				Message: field.name + " is required when report.publish.confluence is enabled",
+			})
+		}
+	}
+	return errors
+}
+
+// validateTLSConfigs checks that the TLS settings of every HTTP client load: the CA and the
+// client certificate files are read here, so a wrong path fails at startup rather than at
+// the first query.
+func validateTLSConfigs(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+	for _, c := range []struct {
+		field string
+		tls   TLSConfig
+	}{
+		{"datasources.n9e.tls", cfg.Datasources.N9E.TLS},
+		{"datasources.victoriametrics.tls", cfg.Datasources.VictoriaMetrics.TLS},
+		{"datasources.logs.tls", cfg.Datasources.Logs.TLS},
+		{"datasources.alertmanager.tls", cfg.Datasources.Alertmanager.TLS},
+		{"datasources.cmdb.tls", cfg.Datasources.CMDB.TLS},
+		{"report.publish.confluence.tls", cfg.Report.Publish.Confluence.TLS},
+		{"distributed.tls", cfg.Distributed.TLS},
+	} {
+		if _, err := c.tls.ClientConfig(); err != nil {
+			errors = append(errors, &ValidationError{
+				Field:   c.field,
+				Tag:     "tls",
+				Message: fmt.Sprintf("invalid TLS configuration: %v", err),
+			})
+		}
+	}
+	return errors
+}
+
+// validateGrafanaProxy checks that the metrics backend is not given its own credentials when
+// queried through the Grafana datasource proxy: Grafana authenticates the API key and uses
+// the credentials of the datasource.
+func validateGrafanaProxy(cfg *Config) ValidationErrors {
+	vmCfg := cfg.Datasources.VictoriaMetrics
+	if !vmCfg.Grafana.Enabled() {
+		return nil
+	}
+	var errors ValidationErrors
+	for _, field := range []struct {
+		name, value string
+	}{
+		{"username", vmCfg.Username},
+		{"bearer_token", vmCfg.BearerToken},
+	} {
+		if field.value != "" {
+			errors = append(errors, &ValidationError{
+				Field:   "datasources.victoriametrics." + field.name,
+				Tag:     "excluded_with",
+				Message: field.name + " cannot be used with datasources.victoriametrics.grafana, Grafana authenticates with api_key",
+			})
+		}
+	}
+	return errors
+}
+
+// validateDistributed checks that a coordinator has the token the workers require.
+func validateDistributed(cfg *Config) ValidationErrors {
+	if len(cfg.Distributed.Workers) == 0 || cfg.Distributed.Token != "" {
+		return nil
+	}
+	return ValidationErrors{&ValidationError{
+		Field:   "distributed.token",
+		Tag:     "required_with",
+		Message: "distributed.token is required when distributed.workers is set, workers reject unauthenticated jobs",
+	}}
+}
+
+// validateProxyURLs checks that the datasource proxies are HTTP(S) or SOCKS5 URLs with a host.
+func validateProxyURLs(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+	for _, p := range []struct {
+		field, value string
+	}{
+		{"datasources.n9e.proxy_url", cfg.Datasources.N9E.ProxyURL},
+		{"datasources.victoriametrics.proxy_url", cfg.Datasources.VictoriaMetrics.ProxyURL},
+		{"datasources.logs.proxy_url", cfg.Datasources.Logs.ProxyURL},
+		{"datasources.alertmanager.proxy_url", cfg.Datasources.Alertmanager.ProxyURL},
+		{"datasources.cmdb.proxy_url", cfg.Datasources.CMDB.ProxyURL},
+	} {
+		if p.value == "" {
+			continue
+		}
+		u, err := url.Parse(p.value)
+		if err != nil || u.Host == "" {
+			errors = append(errors, &ValidationError{
+				Field:   p.field,
+				Tag:     "url",
+				Value:   p.value,
+				Message: fmt.Sprintf("invalid proxy URL: %s", p.value),
+			})
+			continue
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			errors = append(errors, &ValidationError{
+				Field:   p.field,
+				Tag:     "proxy_scheme",
+				Value:   p.value,
+				Message: fmt.Sprintf("unsupported proxy scheme %q, must be one of: http, https, socks5, socks5h", u.Scheme),
+			})
+		}
+	}
 	return errors
 }
 
@@ -173,6 +557,81 @@ func validateMySQLThresholds(cfg *Config) ValidationErrors {
 		})
 	}
 
+	// Validate slow queries thresholds (warning < critical, 0 = disabled)
+	if cfg.MySQL.Thresholds.SlowQueriesWarning > 0 && cfg.MySQL.Thresholds.SlowQueriesCritical > 0 {
+		if cfg.MySQL.Thresholds.SlowQueriesWarning >= cfg.MySQL.Thresholds.SlowQueriesCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "mysql.thresholds.slow_queries",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.MySQL.Thresholds.SlowQueriesWarning, cfg.MySQL.Thresholds.SlowQueriesCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.MySQL.Thresholds.SlowQueriesWarning, cfg.MySQL.Thresholds.SlowQueriesCritical),
+			})
+		}
+	}
+
+	// Validate threads running thresholds (warning < critical, 0 = disabled)
+	if cfg.MySQL.Thresholds.ThreadsRunningWarning > 0 && cfg.MySQL.Thresholds.ThreadsRunningCritical > 0 {
+		if cfg.MySQL.Thresholds.ThreadsRunningWarning >= cfg.MySQL.Thresholds.ThreadsRunningCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "mysql.thresholds.threads_running",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.MySQL.Thresholds.ThreadsRunningWarning, cfg.MySQL.Thresholds.ThreadsRunningCritical),
+				Message: fmt.Sprintf("warning threshold (%d) must be less than critical threshold (%d)", cfg.MySQL.Thresholds.ThreadsRunningWarning, cfg.MySQL.Thresholds.ThreadsRunningCritical),
+			})
+		}
+	}
+
+	// Validate InnoDB buffer pool hit ratio thresholds (lower is worse: warning >= critical, 0 = disabled)
+	if cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioWarning > 0 && cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioCritical > 0 {
+		if cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioWarning < cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "mysql.thresholds.innodb_buffer_pool_hit_ratio",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioWarning, cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be greater than or equal to critical threshold (%.2f)", cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioWarning, cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioCritical),
+			})
+		}
+	}
+
+	// Validate InnoDB row lock waits thresholds (warning < critical, 0 = disabled)
+	if cfg.MySQL.Thresholds.InnoDBRowLockWaitsWarning > 0 && cfg.MySQL.Thresholds.InnoDBRowLockWaitsCritical > 0 {
+		if cfg.MySQL.Thresholds.InnoDBRowLockWaitsWarning >= cfg.MySQL.Thresholds.InnoDBRowLockWaitsCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "mysql.thresholds.innodb_row_lock_waits",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.MySQL.Thresholds.InnoDBRowLockWaitsWarning, cfg.MySQL.Thresholds.InnoDBRowLockWaitsCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.MySQL.Thresholds.InnoDBRowLockWaitsWarning, cfg.MySQL.Thresholds.InnoDBRowLockWaitsCritical),
+			})
+		}
+	}
+
+	// Validate InnoDB deadlocks thresholds (warning < critical, 0 = disabled)
+	if cfg.MySQL.Thresholds.InnoDBDeadlocksWarning > 0 && cfg.MySQL.Thresholds.InnoDBDeadlocksCritical > 0 {
+		if cfg.MySQL.Thresholds.InnoDBDeadlocksWarning >= cfg.MySQL.Thresholds.InnoDBDeadlocksCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "mysql.thresholds.innodb_deadlocks",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.MySQL.Thresholds.InnoDBDeadlocksWarning, cfg.MySQL.Thresholds.InnoDBDeadlocksCritical),
+				Message: fmt.Sprintf("warning threshold (%d) must be less than critical threshold (%d)", cfg.MySQL.Thresholds.InnoDBDeadlocksWarning, cfg.MySQL.Thresholds.InnoDBDeadlocksCritical),
+			})
+		}
+	}
+
+	// Validate InnoDB history list length thresholds (warning < critical, 0 = disabled)
+	if cfg.MySQL.Thresholds.InnoDBHistoryListLengthWarning > 0 && cfg.MySQL.Thresholds.InnoDBHistoryListLengthCritical > 0 {
+		if cfg.MySQL.Thresholds.InnoDBHistoryListLengthWarning >= cfg.MySQL.Thresholds.InnoDBHistoryListLengthCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "mysql.thresholds.innodb_history_list_length",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.MySQL.Thresholds.InnoDBHistoryListLengthWarning, cfg.MySQL.Thresholds.InnoDBHistoryListLengthCritical),
+				Message: fmt.Sprintf("warning threshold (%d) must be less than critical threshold (%d)", cfg.MySQL.Thresholds.InnoDBHistoryListLengthWarning, cfg.MySQL.Thresholds.InnoDBHistoryListLengthCritical),
+			})
+		}
+	}
+
+	// Validate expected-value checks
+	errors = append(errors, validateValueChecks("mysql.thresholds.value_checks", cfg.MySQL.Thresholds.ValueChecks)...)
+
 	// Validate cluster_mode is set when enabled
 	if cfg.MySQL.ClusterMode == "" {
 		errors = append(errors, &ValidationError{
@@ -215,6 +674,57 @@ func validateRedisThresholds(cfg *Config) ValidationErrors {
 		})
 	}
 
+	// Validate memory usage thresholds (warning < critical, 0 = disabled)
+	if cfg.Redis.Thresholds.MemoryUsageWarning > 0 && cfg.Redis.Thresholds.MemoryUsageCritical > 0 {
+		if cfg.Redis.Thresholds.MemoryUsageWarning >= cfg.Redis.Thresholds.MemoryUsageCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "redis.thresholds.memory_usage",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Redis.Thresholds.MemoryUsageWarning, cfg.Redis.Thresholds.MemoryUsageCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Redis.Thresholds.MemoryUsageWarning, cfg.Redis.Thresholds.MemoryUsageCritical),
+			})
+		}
+	}
+
+	// Validate fragmentation ratio thresholds (warning < critical, 0 = disabled)
+	if cfg.Redis.Thresholds.FragmentationRatioWarning > 0 && cfg.Redis.Thresholds.FragmentationRatioCritical > 0 {
+		if cfg.Redis.Thresholds.FragmentationRatioWarning >= cfg.Redis.Thresholds.FragmentationRatioCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "redis.thresholds.fragmentation_ratio",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Redis.Thresholds.FragmentationRatioWarning, cfg.Redis.Thresholds.FragmentationRatioCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Redis.Thresholds.FragmentationRatioWarning, cfg.Redis.Thresholds.FragmentationRatioCritical),
+			})
+		}
+	}
+
+	// Validate evicted keys thresholds (warning < critical, 0 = disabled)
+	if cfg.Redis.Thresholds.EvictedKeysWarning > 0 && cfg.Redis.Thresholds.EvictedKeysCritical > 0 {
+		if cfg.Redis.Thresholds.EvictedKeysWarning >= cfg.Redis.Thresholds.EvictedKeysCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "redis.thresholds.evicted_keys",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Redis.Thresholds.EvictedKeysWarning, cfg.Redis.Thresholds.EvictedKeysCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Redis.Thresholds.EvictedKeysWarning, cfg.Redis.Thresholds.EvictedKeysCritical),
+			})
+		}
+	}
+
+	// Validate keyspace hit ratio thresholds (lower is worse: warning >= critical, 0 = disabled)
+	if cfg.Redis.Thresholds.KeyspaceHitRatioWarning > 0 && cfg.Redis.Thresholds.KeyspaceHitRatioCritical > 0 {
+		if cfg.Redis.Thresholds.KeyspaceHitRatioWarning < cfg.Redis.Thresholds.KeyspaceHitRatioCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "redis.thresholds.keyspace_hit_ratio",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Redis.Thresholds.KeyspaceHitRatioWarning, cfg.Redis.Thresholds.KeyspaceHitRatioCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be greater than or equal to critical threshold (%.2f)", cfg.Redis.Thresholds.KeyspaceHitRatioWarning, cfg.Redis.Thresholds.KeyspaceHitRatioCritical),
+			})
+		}
+	}
+
+	// Validate expected-value checks
+	errors = append(errors, validateValueChecks("redis.thresholds.value_checks", cfg.Redis.Thresholds.ValueChecks)...)
+
 	// Validate cluster_mode is set when enabled
 	if cfg.Redis.ClusterMode == "" {
 		errors = append(errors, &ValidationError{
@@ -228,33 +738,210 @@ func validateRedisThresholds(cfg *Config) ValidationErrors {
 	return errors
 }
 
-// validateNginxThresholds validates Nginx threshold configuration.
-func validateNginxThresholds(cfg *Config) ValidationErrors {
+// validateValueChecks validates expected-value check definitions.
+// field is the config path of the list, e.g. "mysql.thresholds.value_checks".
+func validateValueChecks(field string, checks []ValueCheck) ValidationErrors {
 	var errors ValidationErrors
 
-	// Skip validation if Nginx inspection is disabled
-	if !cfg.Nginx.Enabled {
-		return errors
-	}
-
-	// Validate connection usage thresholds (warning < critical)
-	if cfg.Nginx.Thresholds.ConnectionUsageWarning >= cfg.Nginx.Thresholds.ConnectionUsageCritical {
-		errors = append(errors, &ValidationError{
-			Field:   "nginx.thresholds.connection_usage",
-			Tag:     "threshold_order",
-			Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Nginx.Thresholds.ConnectionUsageWarning, cfg.Nginx.Thresholds.ConnectionUsageCritical),
-			Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Nginx.Thresholds.ConnectionUsageWarning, cfg.Nginx.Thresholds.ConnectionUsageCritical),
-		})
-	}
+	for i, check := range checks {
+		itemField := fmt.Sprintf("%s[%d]", field, i)
 
-	// Validate last error thresholds (warning > critical, because larger minutes = less severe)
-	if cfg.Nginx.Thresholds.LastErrorWarningMinutes > 0 && cfg.Nginx.Thresholds.LastErrorCriticalMinutes > 0 {
-		if cfg.Nginx.Thresholds.LastErrorWarningMinutes <= cfg.Nginx.Thresholds.LastErrorCriticalMinutes {
+		if check.Metric == "" {
 			errors = append(errors, &ValidationError{
-				Field:   "nginx.thresholds.last_error",
+				Field:   itemField + ".metric",
+				Tag:     "required",
+				Value:   "",
+				Message: "this field is required",
+			})
+		}
+
+		switch check.Operator {
+		case ValueCheckEquals, ValueCheckIn, ValueCheckPrefix:
+		default:
+			errors = append(errors, &ValidationError{
+				Field:   itemField + ".operator",
+				Tag:     "oneof",
+				Value:   check.Operator,
+				Message: "value must be one of: equals in prefix",
+			})
+		}
+
+		if len(check.Values) == 0 {
+			errors = append(errors, &ValidationError{
+				Field:   itemField + ".values",
+				Tag:     "required",
+				Value:   check.Values,
+				Message: "at least one expected value is required",
+			})
+		} else if check.Operator == ValueCheckEquals && len(check.Values) > 1 {
+			errors = append(errors, &ValidationError{
+				Field:   itemField + ".values",
+				Tag:     "value_check",
+				Value:   check.Values,
+				Message: "operator 'equals' accepts exactly one value, use 'in' for a set",
+			})
+		}
+
+		if check.Level != "" && check.Level != "warning" && check.Level != "critical" {
+			errors = append(errors, &ValidationError{
+				Field:   itemField + ".level",
+				Tag:     "oneof",
+				Value:   check.Level,
+				Message: "value must be one of: warning critical",
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateNginxThresholds validates Nginx threshold configuration.
+func validateNginxThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if Nginx inspection is disabled
+	if !cfg.Nginx.Enabled {
+		return errors
+	}
+
+	// Validate connection usage thresholds (warning < critical)
+	if cfg.Nginx.Thresholds.ConnectionUsageWarning >= cfg.Nginx.Thresholds.ConnectionUsageCritical {
+		errors = append(errors, &ValidationError{
+			Field:   "nginx.thresholds.connection_usage",
+			Tag:     "threshold_order",
+			Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Nginx.Thresholds.ConnectionUsageWarning, cfg.Nginx.Thresholds.ConnectionUsageCritical),
+			Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Nginx.Thresholds.ConnectionUsageWarning, cfg.Nginx.Thresholds.ConnectionUsageCritical),
+		})
+	}
+
+	// Validate last error thresholds (warning >= critical, because larger minutes = less severe)
+	if cfg.Nginx.Thresholds.LastErrorWarningMinutes > 0 && cfg.Nginx.Thresholds.LastErrorCriticalMinutes > 0 {
+		if cfg.Nginx.Thresholds.LastErrorWarningMinutes < cfg.Nginx.Thresholds.LastErrorCriticalMinutes {
+			errors = append(errors, &ValidationError{
+				Field:   "nginx.thresholds.last_error",
 				Tag:     "threshold_order",
 				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Nginx.Thresholds.LastErrorWarningMinutes, cfg.Nginx.Thresholds.LastErrorCriticalMinutes),
-				Message: fmt.Sprintf("warning threshold (%d minutes) must be greater than critical threshold (%d minutes) for error log timing", cfg.Nginx.Thresholds.LastErrorWarningMinutes, cfg.Nginx.Thresholds.LastErrorCriticalMinutes),
+				Message: fmt.Sprintf("warning threshold (%d minutes) must be greater than or equal to critical threshold (%d minutes) for error log timing", cfg.Nginx.Thresholds.LastErrorWarningMinutes, cfg.Nginx.Thresholds.LastErrorCriticalMinutes),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateTomcatThresholds validates Tomcat threshold configuration.
+func validateTomcatThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if Tomcat inspection is disabled
+	if !cfg.Tomcat.Enabled {
+		return errors
+	}
+
+	// Validate last error thresholds (warning >= critical, because larger minutes = less severe)
+	if cfg.Tomcat.Thresholds.LastErrorWarningMinutes > 0 && cfg.Tomcat.Thresholds.LastErrorCriticalMinutes > 0 {
+		if cfg.Tomcat.Thresholds.LastErrorWarningMinutes < cfg.Tomcat.Thresholds.LastErrorCriticalMinutes {
+			errors = append(errors, &ValidationError{
+				Field:   "tomcat.thresholds.last_error",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Tomcat.Thresholds.LastErrorWarningMinutes, cfg.Tomcat.Thresholds.LastErrorCriticalMinutes),
+				Message: fmt.Sprintf("warning threshold (%d minutes) must be greater than or equal to critical threshold (%d minutes) for error log timing", cfg.Tomcat.Thresholds.LastErrorWarningMinutes, cfg.Tomcat.Thresholds.LastErrorCriticalMinutes),
+			})
+		}
+	}
+
+	// Validate heap usage thresholds (warning < critical, 0 = disabled)
+	if cfg.Tomcat.Thresholds.HeapUsageWarning > 0 && cfg.Tomcat.Thresholds.HeapUsageCritical > 0 {
+		if cfg.Tomcat.Thresholds.HeapUsageWarning >= cfg.Tomcat.Thresholds.HeapUsageCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "tomcat.thresholds.heap_usage",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Tomcat.Thresholds.HeapUsageWarning, cfg.Tomcat.Thresholds.HeapUsageCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Tomcat.Thresholds.HeapUsageWarning, cfg.Tomcat.Thresholds.HeapUsageCritical),
+			})
+		}
+	}
+
+	// Validate GC pause thresholds (warning < critical, 0 = disabled)
+	if cfg.Tomcat.Thresholds.GCPauseWarningMs > 0 && cfg.Tomcat.Thresholds.GCPauseCriticalMs > 0 {
+		if cfg.Tomcat.Thresholds.GCPauseWarningMs >= cfg.Tomcat.Thresholds.GCPauseCriticalMs {
+			errors = append(errors, &ValidationError{
+				Field:   "tomcat.thresholds.gc_pause",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Tomcat.Thresholds.GCPauseWarningMs, cfg.Tomcat.Thresholds.GCPauseCriticalMs),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Tomcat.Thresholds.GCPauseWarningMs, cfg.Tomcat.Thresholds.GCPauseCriticalMs),
+			})
+		}
+	}
+
+	// Validate thread pool usage thresholds (warning < critical, 0 = disabled)
+	if cfg.Tomcat.Thresholds.ThreadPoolUsageWarning > 0 && cfg.Tomcat.Thresholds.ThreadPoolUsageCritical > 0 {
+		if cfg.Tomcat.Thresholds.ThreadPoolUsageWarning >= cfg.Tomcat.Thresholds.ThreadPoolUsageCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "tomcat.thresholds.thread_pool_usage",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Tomcat.Thresholds.ThreadPoolUsageWarning, cfg.Tomcat.Thresholds.ThreadPoolUsageCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Tomcat.Thresholds.ThreadPoolUsageWarning, cfg.Tomcat.Thresholds.ThreadPoolUsageCritical),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateCassandraThresholds validates Cassandra threshold configuration.
+func validateCassandraThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if Cassandra inspection is disabled
+	if !cfg.Cassandra.Enabled {
+		return errors
+	}
+
+	// Validate pending compactions thresholds (warning < critical, 0 = disabled)
+	if cfg.Cassandra.Thresholds.PendingCompactionsWarning > 0 && cfg.Cassandra.Thresholds.PendingCompactionsCritical > 0 {
+		if cfg.Cassandra.Thresholds.PendingCompactionsWarning >= cfg.Cassandra.Thresholds.PendingCompactionsCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "cassandra.thresholds.pending_compactions",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Cassandra.Thresholds.PendingCompactionsWarning, cfg.Cassandra.Thresholds.PendingCompactionsCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Cassandra.Thresholds.PendingCompactionsWarning, cfg.Cassandra.Thresholds.PendingCompactionsCritical),
+			})
+		}
+	}
+
+	// Validate dropped mutations thresholds (warning < critical, 0 = disabled)
+	if cfg.Cassandra.Thresholds.DroppedMutationsWarning > 0 && cfg.Cassandra.Thresholds.DroppedMutationsCritical > 0 {
+		if cfg.Cassandra.Thresholds.DroppedMutationsWarning >= cfg.Cassandra.Thresholds.DroppedMutationsCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "cassandra.thresholds.dropped_mutations",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Cassandra.Thresholds.DroppedMutationsWarning, cfg.Cassandra.Thresholds.DroppedMutationsCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Cassandra.Thresholds.DroppedMutationsWarning, cfg.Cassandra.Thresholds.DroppedMutationsCritical),
+			})
+		}
+	}
+
+	// Validate heap usage thresholds (warning < critical, 0 = disabled)
+	if cfg.Cassandra.Thresholds.HeapUsageWarning > 0 && cfg.Cassandra.Thresholds.HeapUsageCritical > 0 {
+		if cfg.Cassandra.Thresholds.HeapUsageWarning >= cfg.Cassandra.Thresholds.HeapUsageCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "cassandra.thresholds.heap_usage",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Cassandra.Thresholds.HeapUsageWarning, cfg.Cassandra.Thresholds.HeapUsageCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Cassandra.Thresholds.HeapUsageWarning, cfg.Cassandra.Thresholds.HeapUsageCritical),
+			})
+		}
+	}
+
+	// Validate hints backlog thresholds (warning < critical, 0 = disabled)
+	if cfg.Cassandra.Thresholds.HintsBacklogWarning > 0 && cfg.Cassandra.Thresholds.HintsBacklogCritical > 0 {
+		if cfg.Cassandra.Thresholds.HintsBacklogWarning >= cfg.Cassandra.Thresholds.HintsBacklogCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "cassandra.thresholds.hints_backlog",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Cassandra.Thresholds.HintsBacklogWarning, cfg.Cassandra.Thresholds.HintsBacklogCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Cassandra.Thresholds.HintsBacklogWarning, cfg.Cassandra.Thresholds.HintsBacklogCritical),
 			})
 		}
 	}
@@ -262,6 +949,440 @@ func validateNginxThresholds(cfg *Config) ValidationErrors {
 	return errors
 }
 
+// validateMonitoringThresholds validates monitoring self-inspection threshold configuration.
+func validateMonitoringThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if monitoring self-inspection is disabled
+	if !cfg.Monitoring.Enabled {
+		return errors
+	}
+
+	// Validate slow queries thresholds (warning < critical, 0 = disabled)
+	if cfg.Monitoring.Thresholds.SlowQueriesWarning > 0 && cfg.Monitoring.Thresholds.SlowQueriesCritical > 0 {
+		if cfg.Monitoring.Thresholds.SlowQueriesWarning >= cfg.Monitoring.Thresholds.SlowQueriesCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "monitoring.thresholds.slow_queries",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Monitoring.Thresholds.SlowQueriesWarning, cfg.Monitoring.Thresholds.SlowQueriesCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Monitoring.Thresholds.SlowQueriesWarning, cfg.Monitoring.Thresholds.SlowQueriesCritical),
+			})
+		}
+	}
+
+	// Validate merge backlog thresholds (warning < critical, 0 = disabled)
+	if cfg.Monitoring.Thresholds.MergeBacklogWarning > 0 && cfg.Monitoring.Thresholds.MergeBacklogCritical > 0 {
+		if cfg.Monitoring.Thresholds.MergeBacklogWarning >= cfg.Monitoring.Thresholds.MergeBacklogCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "monitoring.thresholds.merge_backlog",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Monitoring.Thresholds.MergeBacklogWarning, cfg.Monitoring.Thresholds.MergeBacklogCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Monitoring.Thresholds.MergeBacklogWarning, cfg.Monitoring.Thresholds.MergeBacklogCritical),
+			})
+		}
+	}
+
+	// Validate disk usage thresholds (warning < critical, 0 = disabled)
+	if cfg.Monitoring.Thresholds.DiskUsageWarning > 0 && cfg.Monitoring.Thresholds.DiskUsageCritical > 0 {
+		if cfg.Monitoring.Thresholds.DiskUsageWarning >= cfg.Monitoring.Thresholds.DiskUsageCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "monitoring.thresholds.disk_usage",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Monitoring.Thresholds.DiskUsageWarning, cfg.Monitoring.Thresholds.DiskUsageCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Monitoring.Thresholds.DiskUsageWarning, cfg.Monitoring.Thresholds.DiskUsageCritical),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateStorageThresholds validates shared storage threshold configuration.
+func validateStorageThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if shared storage inspection is disabled
+	if !cfg.Storage.Enabled {
+		return errors
+	}
+
+	// Validate NFS RPC error thresholds (warning < critical, 0 = disabled)
+	if cfg.Storage.Thresholds.NFSRPCErrorsWarning > 0 && cfg.Storage.Thresholds.NFSRPCErrorsCritical > 0 {
+		if cfg.Storage.Thresholds.NFSRPCErrorsWarning >= cfg.Storage.Thresholds.NFSRPCErrorsCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "storage.thresholds.nfs_rpc_errors",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Storage.Thresholds.NFSRPCErrorsWarning, cfg.Storage.Thresholds.NFSRPCErrorsCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Storage.Thresholds.NFSRPCErrorsWarning, cfg.Storage.Thresholds.NFSRPCErrorsCritical),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateLVSThresholds validates LVS threshold configuration.
+func validateLVSThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if LVS inspection is disabled
+	if !cfg.LVS.Enabled {
+		return errors
+	}
+
+	// Validate active connection thresholds (warning < critical, 0 = disabled)
+	if cfg.LVS.Thresholds.ActiveConnectionsWarning > 0 && cfg.LVS.Thresholds.ActiveConnectionsCritical > 0 {
+		if cfg.LVS.Thresholds.ActiveConnectionsWarning >= cfg.LVS.Thresholds.ActiveConnectionsCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "lvs.thresholds.active_connections",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.LVS.Thresholds.ActiveConnectionsWarning, cfg.LVS.Thresholds.ActiveConnectionsCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.LVS.Thresholds.ActiveConnectionsWarning, cfg.LVS.Thresholds.ActiveConnectionsCritical),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateWindowsThresholds validates Windows threshold configuration.
+func validateWindowsThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if Windows inspection is disabled
+	if !cfg.Windows.Enabled {
+		return errors
+	}
+
+	// Validate IIS request queue thresholds (warning < critical, 0 = disabled)
+	if cfg.Windows.Thresholds.RequestsQueuedWarning > 0 && cfg.Windows.Thresholds.RequestsQueuedCritical > 0 {
+		if cfg.Windows.Thresholds.RequestsQueuedWarning >= cfg.Windows.Thresholds.RequestsQueuedCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "windows.thresholds.requests_queued",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.Windows.Thresholds.RequestsQueuedWarning, cfg.Windows.Thresholds.RequestsQueuedCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.Windows.Thresholds.RequestsQueuedWarning, cfg.Windows.Thresholds.RequestsQueuedCritical),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateADThresholds validates AD threshold configuration.
+func validateADThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if AD inspection is disabled
+	if !cfg.AD.Enabled {
+		return errors
+	}
+
+	// Validate pending replication thresholds (warning < critical, 0 = disabled)
+	if cfg.AD.Thresholds.ReplicationPendingWarning > 0 && cfg.AD.Thresholds.ReplicationPendingCritical > 0 {
+		if cfg.AD.Thresholds.ReplicationPendingWarning >= cfg.AD.Thresholds.ReplicationPendingCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "ad.thresholds.replication_pending",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.AD.Thresholds.ReplicationPendingWarning, cfg.AD.Thresholds.ReplicationPendingCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.AD.Thresholds.ReplicationPendingWarning, cfg.AD.Thresholds.ReplicationPendingCritical),
+			})
+		}
+	}
+
+	// Validate LDAP bind latency thresholds (warning < critical, 0 = disabled)
+	if cfg.AD.Thresholds.LDAPBindLatencyWarning > 0 && cfg.AD.Thresholds.LDAPBindLatencyCritical > 0 {
+		if cfg.AD.Thresholds.LDAPBindLatencyWarning >= cfg.AD.Thresholds.LDAPBindLatencyCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "ad.thresholds.ldap_bind_latency",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.AD.Thresholds.LDAPBindLatencyWarning, cfg.AD.Thresholds.LDAPBindLatencyCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", cfg.AD.Thresholds.LDAPBindLatencyWarning, cfg.AD.Thresholds.LDAPBindLatencyCritical),
+			})
+		}
+	}
+
+	// Validate SYSVOL free space thresholds (lower is worse: warning > critical, 0 = disabled)
+	if cfg.AD.Thresholds.SysvolFreeWarning > 0 && cfg.AD.Thresholds.SysvolFreeCritical > 0 {
+		if cfg.AD.Thresholds.SysvolFreeWarning <= cfg.AD.Thresholds.SysvolFreeCritical {
+			errors = append(errors, &ValidationError{
+				Field:   "ad.thresholds.sysvol_free",
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", cfg.AD.Thresholds.SysvolFreeWarning, cfg.AD.Thresholds.SysvolFreeCritical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be greater than critical threshold (%.2f)", cfg.AD.Thresholds.SysvolFreeWarning, cfg.AD.Thresholds.SysvolFreeCritical),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateCloudThresholds validates cloud resource threshold configuration.
+func validateCloudThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if cloud inspection is disabled
+	if !cfg.Cloud.Enabled {
+		return errors
+	}
+
+	t := cfg.Cloud.Thresholds
+	thresholdPairs := []struct {
+		name     string
+		warning  float64
+		critical float64
+	}{
+		{"cloud.thresholds.cpu_usage", t.CPUUsageWarning, t.CPUUsageCritical},
+		{"cloud.thresholds.memory_usage", t.MemoryUsageWarning, t.MemoryUsageCritical},
+		{"cloud.thresholds.connection_usage", t.ConnectionUsageWarning, t.ConnectionUsageCritical},
+		{"cloud.thresholds.disk_usage", t.DiskUsageWarning, t.DiskUsageCritical},
+	}
+
+	// Validate each threshold pair (warning < critical, 0 = disabled)
+	for _, tp := range thresholdPairs {
+		if tp.warning > 0 && tp.critical > 0 && tp.warning >= tp.critical {
+			errors = append(errors, &ValidationError{
+				Field:   tp.name,
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", tp.warning, tp.critical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", tp.warning, tp.critical),
+			})
+		}
+	}
+
+	// The cost summary needs a positive window to judge under-utilization
+	if cfg.Cloud.CostSummary.Enabled && cfg.Cloud.CostSummary.Window <= 0 {
+		errors = append(errors, &ValidationError{
+			Field:   "cloud.cost_summary.window",
+			Tag:     "gt",
+			Value:   cfg.Cloud.CostSummary.Window,
+			Message: "window must be greater than 0 when cost summary is enabled",
+		})
+	}
+
+	return errors
+}
+
+// validateFirewallThresholds validates firewall device threshold configuration.
+func validateFirewallThresholds(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	// Skip validation if firewall inspection is disabled
+	if !cfg.Firewall.Enabled {
+		return errors
+	}
+
+	t := cfg.Firewall.Thresholds
+	thresholdPairs := []struct {
+		name     string
+		warning  float64
+		critical float64
+	}{
+		{"firewall.thresholds.cpu_usage", t.CPUUsageWarning, t.CPUUsageCritical},
+		{"firewall.thresholds.memory_usage", t.MemoryUsageWarning, t.MemoryUsageCritical},
+		{"firewall.thresholds.session_usage", t.SessionUsageWarning, t.SessionUsageCritical},
+	}
+
+	// Validate each threshold pair (warning < critical, 0 = disabled)
+	for _, tp := range thresholdPairs {
+		if tp.warning > 0 && tp.critical > 0 && tp.warning >= tp.critical {
+			errors = append(errors, &ValidationError{
+				Field:   tp.name,
+				Tag:     "threshold_order",
+				Value:   fmt.Sprintf("warning=%v, critical=%v", tp.warning, tp.critical),
+				Message: fmt.Sprintf("warning threshold (%.2f) must be less than critical threshold (%.2f)", tp.warning, tp.critical),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateLogExcerpts validates log excerpt configuration of the modules that enable it,
+// together with the log checks settings that share the same log backend.
+// An enabled excerpt needs a log backend endpoint, a query template and a positive line count;
+// enabled log checks need a log backend endpoint and a positive window.
+func validateLogExcerpts(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+
+	excerpts := []struct {
+		module  string
+		enabled bool
+		excerpt LogExcerptConfig
+	}{
+		{"nginx", cfg.Nginx.Enabled, cfg.Nginx.LogExcerpt},
+		{"tomcat", cfg.Tomcat.Enabled, cfg.Tomcat.LogExcerpt},
+	}
+
+	// Log checks query the same backend as log excerpts
+	needsBackend := cfg.LogChecks.Enabled
+	if cfg.LogChecks.Enabled && cfg.LogChecks.Window <= 0 {
+		errors = append(errors, &ValidationError{
+			Field:   "log_checks.window",
+			Tag:     "gt",
+			Value:   cfg.LogChecks.Window,
+			Message: "window must be greater than 0 when log checks are enabled",
+		})
+	}
+
+	for _, e := range excerpts {
+		if !e.enabled || !e.excerpt.Enabled {
+			continue
+		}
+		needsBackend = true
+
+		field := e.module + ".log_excerpt"
+		if strings.TrimSpace(e.excerpt.Query) == "" {
+			errors = append(errors, &ValidationError{
+				Field:   field + ".query",
+				Tag:     "required",
+				Value:   e.excerpt.Query,
+				Message: "log query is required when log excerpt is enabled",
+			})
+		}
+		if e.excerpt.Lines <= 0 || e.excerpt.Lines > 1000 {
+			errors = append(errors, &ValidationError{
+				Field:   field + ".lines",
+				Tag:     "range",
+				Value:   e.excerpt.Lines,
+				Message: fmt.Sprintf("lines (%d) must be between 1 and 1000", e.excerpt.Lines),
+			})
+		}
+	}
+
+	if needsBackend && cfg.Datasources.Logs.Endpoint == "" {
+		errors = append(errors, &ValidationError{
+			Field:   "datasources.logs.endpoint",
+			Tag:     "required",
+			Value:   cfg.Datasources.Logs.Endpoint,
+			Message: "log backend endpoint is required when log excerpt or log checks are enabled",
+		})
+	}
+
+	return errors
+}
+
+// validateHostExclude checks that every host exclusion pattern is a valid glob or regexp.
+func validateHostExclude(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+	for i, pattern := range cfg.Inspection.HostFilter.Exclude {
+		if _, err := CompileHostPatterns([]string{pattern}); err != nil {
+			errors = append(errors, &ValidationError{
+				Field:   fmt.Sprintf("inspection.host_filter.exclude[%d]", i),
+				Tag:     "pattern",
+				Value:   pattern,
+				Message: err.Error(),
+			})
+		}
+	}
+	return errors
+}
+
+// validateSourcePreference checks the collection source preference: order and rules need the
+// source label, and every rule needs valid host patterns and a source order.
+func validateSourcePreference(cfg *Config) ValidationErrors {
+	var errors ValidationErrors
+	pref := cfg.Inspection.SourcePreference
+
+	if pref.Label == "" && (len(pref.Order) > 0 || len(pref.Rules) > 0) {
+		errors = append(errors, &ValidationError{
+			Field:   "inspection.source_preference.label",
+			Tag:     "required",
+			Value:   pref.Label,
+			Message: "source label is required when a source order or rules are configured",
+		})
+	}
+
+	for i, rule := range pref.Rules {
+		field := fmt.Sprintf("inspection.source_preference.rules[%d]", i)
+		if len(rule.Hosts) == 0 {
+			errors = append(errors, &ValidationError{
+				Field:   field + ".hosts",
+				Tag:     "required",
+				Value:   rule.Hosts,
+				Message: "at least one host pattern is required",
+			})
+		}
+		for _, pattern := range rule.Hosts {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errors = append(errors, &ValidationError{
+					Field:   field + ".hosts",
+					Tag:     "pattern",
+					Value:   pattern,
+					Message: fmt.Sprintf("invalid host pattern %q: %v", pattern, err),
+				})
+			}
+		}
+		if len(rule.Order) == 0 {
+			errors = append(errors, &ValidationError{
+				Field:   field + ".order",
+				Tag:     "required",
+				Value:   rule.Order,
+				Message: "source order is required",
+			})
+		}
+	}
+
+	return errors
+}
+
+// sshUserPattern matches the login names accepted by useradd, so the password expiry check
+// can pass password_user to chage without shell quoting issues.
+var sshUserPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9._-]*\$?$`)
+
+// validateSSHFallback checks the SSH fallback collector when enabled: a login user and a
+// credential are required, the key and known_hosts files must exist, and the user whose
+// password expiry is checked must be a plain login name.
+func validateSSHFallback(cfg *Config) ValidationErrors {
+	ssh := cfg.Inspection.SSHFallback
+	if !ssh.Enabled {
+		return nil
+	}
+
+	var errors ValidationErrors
+	if ssh.User == "" {
+		errors = append(errors, &ValidationError{
+			Field:   "inspection.ssh_fallback.user",
+			Tag:     "required",
+			Message: "login user is required when the SSH fallback is enabled",
+		})
+	}
+	if ssh.PrivateKeyFile == "" && ssh.Password == "" {
+		errors = append(errors, &ValidationError{
+			Field:   "inspection.ssh_fallback.private_key_file",
+			Tag:     "required",
+			Message: "private_key_file or password is required when the SSH fallback is enabled",
+		})
+	}
+	for _, f := range []struct {
+		field, path string
+	}{
+		{"inspection.ssh_fallback.private_key_file", ssh.PrivateKeyFile},
+		{"inspection.ssh_fallback.known_hosts_file", ssh.KnownHostsFile},
+	} {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			errors = append(errors, &ValidationError{
+				Field:   f.field,
+				Tag:     "file",
+				Value:   f.path,
+				Message: fmt.Sprintf("file not accessible: %v", err),
+			})
+		}
+	}
+	if ssh.PasswordUser != "" && !sshUserPattern.MatchString(ssh.PasswordUser) {
+		errors = append(errors, &ValidationError{
+			Field:   "inspection.ssh_fallback.password_user",
+			Tag:     "pattern",
+			Value:   ssh.PasswordUser,
+			Message: fmt.Sprintf("invalid user name %q", ssh.PasswordUser),
+		})
+	}
+	return errors
+}
+
 // formatFieldName converts the validator field namespace to a user-friendly format.
 // Example: "Config.Datasources.N9E.Endpoint" -> "datasources.n9e.endpoint"
 func formatFieldName(namespace string) string {
@@ -294,10 +1415,16 @@ func translateError(fe validator.FieldError) string {
 		return fmt.Sprintf("value must be less than or equal to %s", fe.Param())
 	case "oneof":
 		return fmt.Sprintf("value must be one of: %s", fe.Param())
+	case "unique":
+		return "list contains duplicate values"
 	case "dive":
 		return fmt.Sprintf("invalid value in list: %v", fe.Value())
 	case "timezone":
 		return fmt.Sprintf("invalid timezone: %v", fe.Value())
+	case "required_with":
+		return fmt.Sprintf("this field is required when %s is set", strings.ToLower(fe.Param()))
+	case "excluded_with":
+		return fmt.Sprintf("this field cannot be set together with %s", strings.ToLower(fe.Param()))
 	default:
 		return fmt.Sprintf("validation failed on '%s' tag for field '%s'", fe.Tag(), field)
 	}
@@ -2,6 +2,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -260,6 +262,73 @@ func TestValidate_ThresholdWarningEqualsCritical(t *testing.T) {
 	}
 }
 
+func TestValidate_ThresholdDirectionBelow(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Thresholds.MemoryAvailablePercent = ThresholdPair{Warning: 20, Critical: 10, Direction: ThresholdDirectionBelow}
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should accept warning > critical for direction below, got: %v", err)
+	}
+}
+
+func TestValidate_ThresholdDirectionBelow_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Thresholds.MemoryAvailablePercent = ThresholdPair{Warning: 10, Critical: 20, Direction: ThresholdDirectionBelow}
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when warning <= critical for direction below")
+	}
+
+	errStr := err.Error()
+	if !strings.Contains(errStr, "thresholds.memory_available_percent") {
+		t.Errorf("error should mention field 'thresholds.memory_available_percent', got: %s", errStr)
+	}
+	if !strings.Contains(errStr, "greater than") {
+		t.Errorf("error should require warning greater than critical, got: %s", errStr)
+	}
+}
+
+func TestValidate_ThresholdSingleLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		pair ThresholdPair
+	}{
+		{"below critical only", ThresholdPair{Critical: 10, Direction: ThresholdDirectionBelow}},
+		{"below warning only", ThresholdPair{Warning: 20, Direction: ThresholdDirectionBelow}},
+		{"below equal thresholds", ThresholdPair{Warning: 10, Critical: 10, Direction: ThresholdDirectionBelow}},
+		{"above critical only", ThresholdPair{Critical: 90}},
+		{"above warning only", ThresholdPair{Warning: 70}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.Thresholds.MemoryAvailablePercent = tt.pair
+			if err := Validate(cfg); err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestValidate_ThresholdDirection_Invalid(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Thresholds.CPUUsage.Direction = "sideways"
+
+	if err := Validate(cfg); err == nil {
+		t.Fatal("Validate() should reject unknown threshold direction")
+	}
+}
+
+func TestValidate_ThresholdDisabled_SkipsOrderCheck(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Thresholds.MemoryAvailablePercent = ThresholdPair{Direction: ThresholdDirectionBelow}
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should skip disabled threshold pair, got: %v", err)
+	}
+}
+
 func TestValidate_InvalidTimezone(t *testing.T) {
 	cfg := newValidConfig()
 	cfg.Report.Timezone = "Invalid/Timezone"
@@ -278,6 +347,24 @@ func TestValidate_InvalidTimezone(t *testing.T) {
 	}
 }
 
+func TestValidate_InvalidSecondaryTimezone(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.SecondaryTimezone = "Invalid/Timezone"
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for invalid secondary timezone")
+	}
+	if !strings.Contains(err.Error(), "report.secondary_timezone") {
+		t.Errorf("error should mention field 'report.secondary_timezone', got: %s", err.Error())
+	}
+
+	cfg.Report.SecondaryTimezone = "UTC"
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should accept secondary timezone UTC, got: %v", err)
+	}
+}
+
 func TestValidate_EmptyTimezone(t *testing.T) {
 	cfg := newValidConfig()
 	cfg.Report.Timezone = "" // Empty is allowed (will use default)
@@ -464,6 +551,52 @@ func TestValidate_MySQLThresholds_EqualValues(t *testing.T) {
 	}
 }
 
+func TestValidate_MySQLSlowQueriesThresholds_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.MySQL.Enabled = true
+	cfg.MySQL.ClusterMode = "mgr"
+	cfg.MySQL.Thresholds.SlowQueriesWarning = 10
+	cfg.MySQL.Thresholds.SlowQueriesCritical = 5 // warning > critical
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when slow_queries warning >= critical")
+	}
+
+	errStr := err.Error()
+	if !strings.Contains(errStr, "mysql.thresholds.slow_queries") {
+		t.Errorf("error should mention 'mysql.thresholds.slow_queries', got: %s", errStr)
+	}
+}
+
+func TestValidate_MySQLThreadsRunningThresholds_Disabled(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.MySQL.Enabled = true
+	cfg.MySQL.ClusterMode = "mgr"
+	cfg.MySQL.Thresholds.ThreadsRunningWarning = 32
+	cfg.MySQL.Thresholds.ThreadsRunningCritical = 0 // 0 = disabled, order check skipped
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should skip threads_running order check when disabled, got: %v", err)
+	}
+}
+
+func TestValidate_MySQLBufferPoolHitRatio_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.MySQL.Enabled = true
+	cfg.MySQL.ClusterMode = "mgr"
+	cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioWarning = 90
+	cfg.MySQL.Thresholds.InnoDBBufferPoolHitRatioCritical = 95 // 命中率越低越差，warning 应大于 critical
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when hit ratio warning <= critical")
+	}
+	if !strings.Contains(err.Error(), "mysql.thresholds.innodb_buffer_pool_hit_ratio") {
+		t.Errorf("error should mention innodb_buffer_pool_hit_ratio, got: %s", err.Error())
+	}
+}
+
 func TestValidate_MySQLEnabled_MissingClusterMode(t *testing.T) {
 	cfg := newValidConfig()
 	cfg.MySQL.Enabled = true
@@ -534,3 +667,915 @@ func TestValidate_MySQLMultipleErrors(t *testing.T) {
 		t.Errorf("error should mention 'mysql.thresholds.connection_usage', got: %s", errStr)
 	}
 }
+
+// ============================================================================
+// Redis Validation Tests
+// ============================================================================
+
+func TestValidate_RedisMemoryUsage_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Redis.Enabled = true
+	cfg.Redis.ClusterMode = "3m3s"
+	cfg.Redis.Thresholds.ConnectionUsageWarning = 70
+	cfg.Redis.Thresholds.ConnectionUsageCritical = 90
+	cfg.Redis.Thresholds.MemoryUsageWarning = 90
+	cfg.Redis.Thresholds.MemoryUsageCritical = 80
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when memory usage warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "redis.thresholds.memory_usage") {
+		t.Errorf("error should mention memory_usage, got: %s", err.Error())
+	}
+}
+
+func TestValidate_RedisKeyspaceHitRatio_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Redis.Enabled = true
+	cfg.Redis.ClusterMode = "3m3s"
+	cfg.Redis.Thresholds.ConnectionUsageWarning = 70
+	cfg.Redis.Thresholds.ConnectionUsageCritical = 90
+	cfg.Redis.Thresholds.KeyspaceHitRatioWarning = 80
+	cfg.Redis.Thresholds.KeyspaceHitRatioCritical = 90 // 命中率越低越差，warning 应大于 critical
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when hit ratio warning <= critical")
+	}
+	if !strings.Contains(err.Error(), "redis.thresholds.keyspace_hit_ratio") {
+		t.Errorf("error should mention keyspace_hit_ratio, got: %s", err.Error())
+	}
+}
+
+// ============================================================================
+// Tomcat Validation Tests
+// ============================================================================
+
+func TestValidate_TomcatHeapUsage_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Tomcat.Enabled = true
+	cfg.Tomcat.Thresholds.HeapUsageWarning = 90
+	cfg.Tomcat.Thresholds.HeapUsageCritical = 80
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when heap usage warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "tomcat.thresholds.heap_usage") {
+		t.Errorf("error should mention heap_usage, got: %s", err.Error())
+	}
+}
+
+func TestValidate_TomcatDisabled_SkipsThresholds(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Tomcat.Enabled = false
+	cfg.Tomcat.Thresholds.GCPauseWarningMs = 500
+	cfg.Tomcat.Thresholds.GCPauseCriticalMs = 200
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should skip Tomcat thresholds when disabled, got: %v", err)
+	}
+}
+
+// ============================================================================
+// Value Check Tests
+// ============================================================================
+
+func TestValueCheck_Matches(t *testing.T) {
+	tests := []struct {
+		name  string
+		check ValueCheck
+		value string
+		want  bool
+	}{
+		{"equals match", ValueCheck{Operator: ValueCheckEquals, Values: []string{"noeviction"}}, "noeviction", true},
+		{"equals mismatch", ValueCheck{Operator: ValueCheckEquals, Values: []string{"noeviction"}}, "allkeys-lru", false},
+		{"in match", ValueCheck{Operator: ValueCheckIn, Values: []string{"allkeys-lru", "volatile-lru"}}, "volatile-lru", true},
+		{"in mismatch", ValueCheck{Operator: ValueCheckIn, Values: []string{"allkeys-lru", "volatile-lru"}}, "noeviction", false},
+		{"prefix match", ValueCheck{Operator: ValueCheckPrefix, Values: []string{"8.0"}}, "8.0.39", true},
+		{"prefix mismatch", ValueCheck{Operator: ValueCheckPrefix, Values: []string{"8.0"}}, "5.7.44", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.check.Matches(tt.value); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueCheck_Expected(t *testing.T) {
+	prefix := ValueCheck{Operator: ValueCheckPrefix, Values: []string{"8.0", "8.4"}}
+	if got := prefix.Expected(); got != "8.0* | 8.4*" {
+		t.Errorf("Expected() = %q, want %q", got, "8.0* | 8.4*")
+	}
+
+	in := ValueCheck{Operator: ValueCheckIn, Values: []string{"a", "b"}}
+	if got := in.Expected(); got != "a | b" {
+		t.Errorf("Expected() = %q, want %q", got, "a | b")
+	}
+}
+
+func TestValidate_MySQLValueChecks_Invalid(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.MySQL.Enabled = true
+	cfg.MySQL.ClusterMode = "mgr"
+	cfg.MySQL.Thresholds.ValueChecks = []ValueCheck{
+		{Metric: "mysql_version", Operator: "regex", Values: []string{"8.*"}},
+		{Metric: "mysql_version", Operator: ValueCheckEquals, Values: []string{"8.0.39", "8.0.40"}},
+		{Operator: ValueCheckIn, Values: nil, Level: "info"},
+	}
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for invalid value checks")
+	}
+
+	errStr := err.Error()
+	for _, want := range []string{
+		"mysql.thresholds.value_checks[0].operator",
+		"mysql.thresholds.value_checks[1].values",
+		"mysql.thresholds.value_checks[2].metric",
+		"mysql.thresholds.value_checks[2].values",
+		"mysql.thresholds.value_checks[2].level",
+	} {
+		if !strings.Contains(errStr, want) {
+			t.Errorf("error should mention %q, got: %s", want, errStr)
+		}
+	}
+}
+
+func TestValidate_RedisValueChecks_Valid(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Redis.Enabled = true
+	cfg.Redis.ClusterMode = "3m3s"
+	cfg.Redis.Thresholds.ConnectionUsageWarning = 70
+	cfg.Redis.Thresholds.ConnectionUsageCritical = 90
+	cfg.Redis.Thresholds.ReplicationLagWarning = 1048576
+	cfg.Redis.Thresholds.ReplicationLagCritical = 10485760
+	cfg.Redis.Thresholds.ValueChecks = []ValueCheck{
+		{Metric: "redis_maxmemory_policy", Operator: ValueCheckIn, Values: []string{"allkeys-lru"}, Level: "critical"},
+	}
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+// ============================================================================
+// Cassandra Validation Tests
+// ============================================================================
+
+func TestValidate_CassandraPendingCompactions_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Cassandra.Enabled = true
+	cfg.Cassandra.Thresholds.PendingCompactionsWarning = 500
+	cfg.Cassandra.Thresholds.PendingCompactionsCritical = 100
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when pending compactions warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "cassandra.thresholds.pending_compactions") {
+		t.Errorf("error should mention pending_compactions, got: %s", err.Error())
+	}
+}
+
+func TestValidate_CassandraDisabled_SkipsThresholds(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Cassandra.Enabled = false
+	cfg.Cassandra.Thresholds.HintsBacklogWarning = 1000
+	cfg.Cassandra.Thresholds.HintsBacklogCritical = 100
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should skip Cassandra thresholds when disabled, got: %v", err)
+	}
+}
+
+// ============================================================================
+// Monitoring Self-Inspection Validation Tests
+// ============================================================================
+
+func TestValidate_MonitoringDiskUsage_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Monitoring.Enabled = true
+	cfg.Monitoring.Thresholds.DiskUsageWarning = 90
+	cfg.Monitoring.Thresholds.DiskUsageCritical = 80
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when disk usage warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "monitoring.thresholds.disk_usage") {
+		t.Errorf("error should mention disk_usage, got: %s", err.Error())
+	}
+}
+
+func TestValidate_MonitoringDisabled_SkipsThresholds(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Monitoring.Enabled = false
+	cfg.Monitoring.Thresholds.MergeBacklogWarning = 1000
+	cfg.Monitoring.Thresholds.MergeBacklogCritical = 100
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should skip monitoring thresholds when disabled, got: %v", err)
+	}
+}
+
+// ============================================================================
+// Shared Storage Validation Tests
+// ============================================================================
+
+func TestValidate_StorageNFSRPCErrors_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Storage.Enabled = true
+	cfg.Storage.Thresholds.NFSRPCErrorsWarning = 100
+	cfg.Storage.Thresholds.NFSRPCErrorsCritical = 10
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when NFS RPC errors warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "storage.thresholds.nfs_rpc_errors") {
+		t.Errorf("error should mention nfs_rpc_errors, got: %s", err.Error())
+	}
+}
+
+func TestValidate_StorageDisabled_SkipsThresholds(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Storage.Enabled = false
+	cfg.Storage.Thresholds.NFSRPCErrorsWarning = 100
+	cfg.Storage.Thresholds.NFSRPCErrorsCritical = 10
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() should skip storage thresholds when disabled, got: %v", err)
+	}
+}
+
+// ============================================================================
+// Log Excerpt Validation Tests
+// ============================================================================
+
+func TestValidate_LogExcerpt_RequiresBackendAndQuery(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Nginx.Enabled = true
+	cfg.Nginx.LogExcerpt = LogExcerptConfig{Enabled: true, Lines: 20}
+	cfg.Tomcat.Enabled = true
+	cfg.Tomcat.LogExcerpt = LogExcerptConfig{Enabled: true, Query: `{host="{{.Hostname}}"}`, Lines: 20}
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when log excerpt is enabled without backend and query")
+	}
+
+	verrs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %T", err)
+	}
+	fields := make(map[string]int)
+	for _, e := range verrs {
+		fields[e.Field]++
+	}
+	if fields["datasources.logs.endpoint"] != 1 {
+		t.Errorf("expected one datasources.logs.endpoint error, got %d", fields["datasources.logs.endpoint"])
+	}
+	if fields["nginx.log_excerpt.query"] != 1 {
+		t.Errorf("expected nginx.log_excerpt.query error, got: %s", err.Error())
+	}
+	if fields["tomcat.log_excerpt.query"] != 0 {
+		t.Errorf("unexpected tomcat.log_excerpt.query error: %s", err.Error())
+	}
+}
+
+func TestValidate_LogExcerpt_Valid(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Datasources.Logs = LogsConfig{Type: LogsTypeVictoriaLogs, Endpoint: "http://localhost:9428"}
+	cfg.Tomcat.Enabled = true
+	cfg.Tomcat.LogExcerpt = LogExcerptConfig{Enabled: true, Query: `host:"{{.Hostname}}" error`, Lines: 20}
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestValidate_LogExcerpt_InvalidLines(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Datasources.Logs = LogsConfig{Endpoint: "http://localhost:3100"}
+	cfg.Nginx.Enabled = true
+	cfg.Nginx.LogExcerpt = LogExcerptConfig{Enabled: true, Query: `{host="{{.Hostname}}"}`, Lines: 0}
+
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "nginx.log_excerpt.lines") {
+		t.Errorf("expected nginx.log_excerpt.lines error, got: %v", err)
+	}
+}
+
+func TestValidate_LogChecks_RequiresBackendAndWindow(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.LogChecks = LogChecksInspectionConfig{Enabled: true}
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when log checks are enabled without backend and window")
+	}
+	if !strings.Contains(err.Error(), "datasources.logs.endpoint") {
+		t.Errorf("expected datasources.logs.endpoint error, got: %s", err.Error())
+	}
+	if !strings.Contains(err.Error(), "log_checks.window") {
+		t.Errorf("expected log_checks.window error, got: %s", err.Error())
+	}
+}
+
+func TestValidate_LogChecks_Valid(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Datasources.Logs = LogsConfig{Type: LogsTypeLoki, Endpoint: "http://localhost:3100"}
+	cfg.LogChecks = LogChecksInspectionConfig{Enabled: true, Window: time.Hour}
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+// ============================================================================
+// LVS Validation Tests
+// ============================================================================
+
+func TestValidate_LVSActiveConnections_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.LVS.Enabled = true
+	cfg.LVS.Thresholds.ActiveConnectionsWarning = 100000
+	cfg.LVS.Thresholds.ActiveConnectionsCritical = 50000
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when active connections warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "lvs.thresholds.active_connections") {
+		t.Errorf("error should mention active_connections, got: %s", err.Error())
+	}
+}
+
+func TestValidate_WindowsRequestsQueued_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Windows.Enabled = true
+	cfg.Windows.Thresholds.RequestsQueuedWarning = 1000
+	cfg.Windows.Thresholds.RequestsQueuedCritical = 100
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when requests queued warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "windows.thresholds.requests_queued") {
+		t.Errorf("error should mention requests_queued, got: %s", err.Error())
+	}
+}
+
+func TestValidate_ADSysvolFree_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.AD.Enabled = true
+	cfg.AD.Thresholds.SysvolFreeWarning = 10
+	cfg.AD.Thresholds.SysvolFreeCritical = 20
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when SYSVOL free warning <= critical")
+	}
+	if !strings.Contains(err.Error(), "ad.thresholds.sysvol_free") {
+		t.Errorf("error should mention sysvol_free, got: %s", err.Error())
+	}
+}
+
+func TestValidate_CloudConnectionUsage_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Cloud.Enabled = true
+	cfg.Cloud.Thresholds.ConnectionUsageWarning = 90
+	cfg.Cloud.Thresholds.ConnectionUsageCritical = 70
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when connection usage warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "cloud.thresholds.connection_usage") {
+		t.Errorf("error should mention connection_usage, got: %s", err.Error())
+	}
+}
+
+func TestValidate_CloudCostSummary_InvalidWindow(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Cloud.Enabled = true
+	cfg.Cloud.CostSummary.Enabled = true
+	cfg.Cloud.CostSummary.Window = 0
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when cost summary window is not positive")
+	}
+	if !strings.Contains(err.Error(), "cloud.cost_summary.window") {
+		t.Errorf("error should mention cost_summary.window, got: %s", err.Error())
+	}
+}
+
+func TestValidate_FirewallSessionUsage_InvalidOrder(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Firewall.Enabled = true
+	cfg.Firewall.Thresholds.SessionUsageWarning = 90
+	cfg.Firewall.Thresholds.SessionUsageCritical = 70
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error when session usage warning >= critical")
+	}
+	if !strings.Contains(err.Error(), "firewall.thresholds.session_usage") {
+		t.Errorf("error should mention session_usage, got: %s", err.Error())
+	}
+}
+
+func TestValidate_ReportTheme_Invalid(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.Theme.PrimaryColor = "blue"
+	cfg.Report.Theme.LogoFile = "/nonexistent/logo.png"
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for invalid theme")
+	}
+	for _, field := range []string{"report.theme.primary_color", "report.theme.logo_file"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error should mention %s, got: %s", field, err.Error())
+		}
+	}
+}
+
+func TestValidate_ReportBranding_Logo(t *testing.T) {
+	for _, logo := range []string{"/nonexistent/cover.png", "cover.bmp"} {
+		cfg := newValidConfig()
+		cfg.Report.Branding = BrandingConfig{CustomerName: "ACME", LogoFile: logo}
+
+		err := Validate(cfg)
+		if err == nil {
+			t.Fatalf("Validate() should return error for cover logo %q", logo)
+		}
+		if !strings.Contains(err.Error(), "report.branding.logo_file") {
+			t.Errorf("error should mention report.branding.logo_file, got: %s", err.Error())
+		}
+	}
+
+	cfg := newValidConfig()
+	cfg.Report.Branding = BrandingConfig{CustomerName: "ACME", Project: "核心系统", Engineer: "张三", Period: "2025 年第一季度"}
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() without cover logo error = %v", err)
+	}
+}
+
+func TestValidate_ReportChartLibrary_Missing(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.ChartLibrary = "/nonexistent/echarts.min.js"
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for a missing chart library")
+	}
+	if !strings.Contains(err.Error(), "report.chart_library") {
+		t.Errorf("error should mention report.chart_library, got: %s", err.Error())
+	}
+}
+
+func TestValidate_ReportHTMLTheme(t *testing.T) {
+	cssFile := filepath.Join(t.TempDir(), "brand.css")
+	if err := os.WriteFile(cssFile, []byte(".header { background: #003366; }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []HTMLReportConfig{{}, {Theme: "light"}, {Theme: "dark"}, {Theme: "custom", CSSFile: cssFile}} {
+		cfg := newValidConfig()
+		cfg.Report.HTML = h
+		if err := Validate(cfg); err != nil {
+			t.Errorf("Validate() with HTML config %+v error = %v", h, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		html HTMLReportConfig
+		want string
+	}{
+		{"unknown theme", HTMLReportConfig{Theme: "solarized"}, "report.html.theme"},
+		{"custom without stylesheet", HTMLReportConfig{Theme: "custom"}, "report.html.css_file"},
+		{"missing stylesheet", HTMLReportConfig{Theme: "custom", CSSFile: "/nonexistent/brand.css"}, "report.html.css_file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.Report.HTML = tt.html
+			err := Validate(cfg)
+			if err == nil {
+				t.Fatal("Validate() should return error")
+			}
+			if !strings.Contains(strings.ToLower(err.Error()), tt.want) {
+				t.Errorf("error should mention %s, got: %s", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidate_ReportSheetOrder_InvalidModule(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.SheetOrder = []string{"mysql", "oracle"}
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for an unknown sheet order module")
+	}
+	if !strings.Contains(err.Error(), "report.sheetorder") {
+		t.Errorf("error should mention report.sheetorder, got: %s", err.Error())
+	}
+}
+
+func TestValidate_ReportLanguage(t *testing.T) {
+	for _, lang := range []string{"", "zh", "en", "zh-en"} {
+		cfg := newValidConfig()
+		cfg.Report.Language = lang
+		if err := Validate(cfg); err != nil {
+			t.Errorf("Validate() with language %q error = %v", lang, err)
+		}
+	}
+
+	cfg := newValidConfig()
+	cfg.Report.Language = "fr"
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for an unsupported report language")
+	}
+	if !strings.Contains(err.Error(), "report.language") {
+		t.Errorf("error should mention report.language, got: %s", err.Error())
+	}
+}
+
+func TestValidate_ReportExcelColumns(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.Excel.Columns.Host = []string{"hostname", "ip", "disks"}
+	cfg.Report.Excel.Columns.MySQL = []string{"ip", "port", "status"}
+	cfg.Report.Excel.Columns.Redis = []string{"ip", "role", "memory_usage"}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		set   func(cfg *Config)
+		field string
+	}{
+		{"unknown host column", func(cfg *Config) { cfg.Report.Excel.Columns.Host = []string{"hostname", "gpu_usage"} }, "report.excel.columns.host"},
+		{"unknown mysql column", func(cfg *Config) { cfg.Report.Excel.Columns.MySQL = []string{"role"} }, "report.excel.columns.mysql"},
+		{"duplicate redis column", func(cfg *Config) { cfg.Report.Excel.Columns.Redis = []string{"ip", "ip"} }, "report.excel.columns.redis"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			tt.set(cfg)
+			err := Validate(cfg)
+			if err == nil {
+				t.Fatal("Validate() should return error")
+			}
+			if !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("error should mention %s, got: %s", tt.field, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidate_ReportBundle(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.Bundle = "zip"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	cfg.Report.Bundle = "tar"
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for an unknown bundle mode")
+	}
+	if !strings.Contains(err.Error(), "report.bundle") {
+		t.Errorf("error should mention report.bundle, got: %s", err.Error())
+	}
+}
+
+func TestValidate_SourcePreference(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Inspection.SourcePreference = SourcePreference{
+		Label: "job",
+		Order: []string{"categraf", "node-exporter"},
+		Rules: []SourcePreferenceRule{{Hosts: []string{"db-*"}, Order: []string{"node-exporter"}}},
+	}
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+
+	cfg.Inspection.SourcePreference = SourcePreference{
+		Order: []string{"categraf"},
+		Rules: []SourcePreferenceRule{{Hosts: []string{"db-["}}},
+	}
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for an invalid source preference")
+	}
+	for _, field := range []string{"inspection.source_preference.label", "inspection.source_preference.rules[0].hosts", "inspection.source_preference.rules[0].order"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected %s error, got: %v", field, err)
+		}
+	}
+}
+
+func TestValidate_ReportPublishConfluence(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.Publish.Confluence = ConfluencePublishConfig{Enabled: true, URL: "https://wiki.example.com"}
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should require space_key and token for an enabled Confluence publisher")
+	}
+	for _, field := range []string{"report.publish.confluence.space_key", "report.publish.confluence.token"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error should mention %s, got: %s", field, err.Error())
+		}
+	}
+
+	cfg.Report.Publish.Confluence.SpaceKey = "OPS"
+	cfg.Report.Publish.Confluence.Token = "secret"
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestValidate_ReportExcelTemplate(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.Excel.Template = "/nonexistent/template.xlsx"
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for a missing Excel template")
+	}
+	if !strings.Contains(err.Error(), "report.excel.template") {
+		t.Errorf("error should mention report.excel.template, got: %s", err.Error())
+	}
+
+	cfg.Report.Excel.Template = filepath.Join(t.TempDir(), "template.xlsx")
+	if err := os.WriteFile(cfg.Report.Excel.Template, []byte("xlsx"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestValidate_CapacityTopNOutOfRange(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.CapacityTopN = 101
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() should return error for capacity_top_n above 100")
+	}
+	if !strings.Contains(err.Error(), "report.capacitytopn") {
+		t.Errorf("error should mention report.capacitytopn, got: %s", err.Error())
+	}
+}
+
+func TestValidate_DatasourceAuth(t *testing.T) {
+	t.Run("password required with username", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Datasources.VictoriaMetrics.Username = "inspector"
+
+		err := Validate(cfg)
+		if err == nil || !strings.Contains(err.Error(), "datasources.victoriametrics.password") {
+			t.Errorf("Validate() error = %v, want missing VM password", err)
+		}
+	})
+
+	t.Run("bearer token excludes basic auth", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Datasources.N9E.Username = "inspector"
+		cfg.Datasources.N9E.Password = "secret"
+		cfg.Datasources.N9E.BearerToken = "token"
+
+		err := Validate(cfg)
+		if err == nil || !strings.Contains(err.Error(), "cannot be set together with username") {
+			t.Errorf("Validate() error = %v, want conflicting N9E authentication", err)
+		}
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Datasources.VictoriaMetrics.Username = "inspector"
+		cfg.Datasources.VictoriaMetrics.Password = "secret"
+
+		if err := Validate(cfg); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+}
+
+func TestValidate_GrafanaProxy(t *testing.T) {
+	t.Run("api key required", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Datasources.VictoriaMetrics.Grafana.DatasourceID = 3
+
+		err := Validate(cfg)
+		if err == nil || !strings.Contains(err.Error(), "datasources.victoriametrics.grafana.apikey") {
+			t.Errorf("Validate() error = %v, want missing Grafana API key", err)
+		}
+	})
+
+	t.Run("id excludes uid", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Datasources.VictoriaMetrics.Grafana = GrafanaProxyConfig{DatasourceID: 3, DatasourceUID: "vm-prod", APIKey: "key"}
+
+		err := Validate(cfg)
+		if err == nil || !strings.Contains(err.Error(), "datasources.victoriametrics.grafana.datasourceuid") {
+			t.Errorf("Validate() error = %v, want conflicting datasource id and uid", err)
+		}
+	})
+
+	t.Run("datasource credentials rejected", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Datasources.VictoriaMetrics.Grafana = GrafanaProxyConfig{DatasourceUID: "vm-prod", APIKey: "key"}
+		cfg.Datasources.VictoriaMetrics.BearerToken = "token"
+
+		err := Validate(cfg)
+		if err == nil || !strings.Contains(err.Error(), "datasources.victoriametrics.bearer_token") {
+			t.Errorf("Validate() error = %v, want bearer_token rejected in Grafana mode", err)
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Datasources.VictoriaMetrics.Grafana = GrafanaProxyConfig{DatasourceUID: "vm-prod", APIKey: "key", OrgID: 2}
+
+		if err := Validate(cfg); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+}
+
+func TestValidate_ReportGroupBy(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Report.GroupBy = []string{"owner", "application", "location"}
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.Report.GroupBy = []string{"owner", "rack"}
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "report.groupby") {
+		t.Errorf("Validate() error = %v, want invalid group_by key", err)
+	}
+}
+
+func TestValidate_TLS(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Datasources.VictoriaMetrics.TLS.CAFile = filepath.Join(t.TempDir(), "missing.crt")
+	cfg.Distributed.TLS.KeyFile = "client.key"
+
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, field := range []string{"datasources.victoriametrics.tls", "distributed.tls"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error = %v, want error on %s", err, field)
+		}
+	}
+}
+
+func TestValidate_ProxyURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   string
+		wantErr string
+	}{
+		{"http proxy", "http://proxy.example.com:3128", ""},
+		{"socks5 proxy", "socks5://10.0.0.1:1080", ""},
+		{"unsupported scheme", "ftp://proxy.example.com", "unsupported proxy scheme"},
+		{"missing host", "proxy.example.com:3128", "invalid proxy URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.Datasources.VictoriaMetrics.ProxyURL = tt.proxy
+
+			err := Validate(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_SSHFallback(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(keyFile, []byte("key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ssh  SSHFallbackConfig
+		want []string // Expected error fields, none when valid
+	}{
+		{"disabled", SSHFallbackConfig{}, nil},
+		{"valid", SSHFallbackConfig{Enabled: true, User: "inspect", PrivateKeyFile: keyFile, Checks: []string{"df", "chronyc"}}, nil},
+		{"user and credential required", SSHFallbackConfig{Enabled: true}, []string{"inspection.ssh_fallback.user", "inspection.ssh_fallback.private_key_file"}},
+		{"missing files", SSHFallbackConfig{Enabled: true, User: "inspect", PrivateKeyFile: keyFile + ".missing", KnownHostsFile: "/nonexistent/known_hosts"}, []string{"inspection.ssh_fallback.private_key_file", "inspection.ssh_fallback.known_hosts_file"}},
+		{"unsafe password user", SSHFallbackConfig{Enabled: true, User: "inspect", Password: "secret", PasswordUser: "root; reboot"}, []string{"inspection.ssh_fallback.password_user"}},
+		{"unknown check", SSHFallbackConfig{Enabled: true, User: "inspect", Password: "secret", Checks: []string{"df", "reboot"}}, []string{"inspection.sshfallback.checks[1]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.Inspection.SSHFallback = tt.ssh
+
+			err := Validate(cfg)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() should fail on %v", tt.want)
+			}
+			for _, field := range tt.want {
+				if !strings.Contains(err.Error(), field) {
+					t.Errorf("Validate() error = %v, want %s", err, field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_ModuleSelection(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Inspection.Modules = ModuleSelection{Only: []string{"host", "redis"}, Skip: []string{"postgres"}}
+
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "inspection.modules.skip[0]") {
+		t.Errorf("Validate() error = %v, want inspection.modules.skip[0]", err)
+	}
+
+	cfg.Inspection.Modules.Skip = nil
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestValidate_DistributedToken(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Distributed.Workers = []string{}
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() error = %v, want nil without workers", err)
+	}
+
+	cfg.Distributed.Workers = []string{"http://10.0.0.11:8090"}
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "distributed.token") {
+		t.Errorf("Validate() error = %v, want distributed.token required with workers", err)
+	}
+
+	cfg.Distributed.Token = "secret"
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() error = %v, want nil with a token", err)
+	}
+}
+
+func TestValidate_Runtime(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Runtime = RuntimeConfig{GCPercent: 50, MemoryLimitMB: 512}
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	cfg.Runtime.MemoryLimitMB = -1
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "runtime.") {
+		t.Errorf("Validate() error = %v, want the negative memory limit rejected", err)
+	}
+}
+
+func TestValidate_HostExclude(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Inspection.HostFilter.Exclude = []string{"test-*", "/^web-(/"}
+
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "inspection.host_filter.exclude[1]") {
+		t.Errorf("Validate() error = %v, want inspection.host_filter.exclude[1]", err)
+	}
+}
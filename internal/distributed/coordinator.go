@@ -0,0 +1,216 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// jobsPath is the worker endpoint that runs a job.
+const jobsPath = "/v1/jobs"
+
+// Coordinator sends the jobs of a plan to the workers and merges their results.
+type Coordinator struct {
+	client *http.Client
+	token  string
+	logger zerolog.Logger
+}
+
+// NewCoordinator creates a coordinator. token authenticates the requests to the workers;
+// timeout bounds each worker job, zero meaning no limit; tlsConfig is the CA and client
+// certificate of https workers (nil for the defaults).
+func NewCoordinator(token string, timeout time.Duration, tlsConfig *tls.Config, logger zerolog.Logger) *Coordinator {
+	client := &http.Client{Timeout: timeout}
+	if tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		client.Transport = transport
+	}
+	return &Coordinator{
+		client: client,
+		token:  token,
+		logger: logger.With().Str("component", "coordinator").Logger(),
+	}
+}
+
+// Run sends the jobs to their workers concurrently and merges the returned reports.
+// A failed job fails the run: a report missing a shard would understate the findings.
+func (c *Coordinator) Run(ctx context.Context, assignments []*Assignment) (*json.Report, error) {
+	reports := make([]*json.Report, len(assignments))
+	errs := make([]error, len(assignments))
+
+	var wg sync.WaitGroup
+	for i, a := range assignments {
+		wg.Add(1)
+		go func(i int, a *Assignment) {
+			defer wg.Done()
+			start := time.Now()
+			reports[i], errs[i] = c.runJob(ctx, a)
+			c.logger.Info().
+				Str("worker", a.Worker).
+				Strs("modules", a.Job.Modules).
+				Strs("business_groups", a.Job.BusinessGroups).
+				Dur("duration", time.Since(start)).
+				Err(errs[i]).
+				Msg("worker job finished")
+		}(i, a)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", assignments[i].Worker, err))
+		}
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("%d worker job(s) failed: %s", len(failed), strings.Join(failed, "; "))
+	}
+
+	return Merge(reports), nil
+}
+
+// runJob sends one job to its worker and decodes the returned report.
+func (c *Coordinator) runJob(ctx context.Context, a *Assignment) (*json.Report, error) {
+	body, err := stdjson.Marshal(a.Job)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(a.Worker, "/")+jobsPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("worker returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var report json.Report
+	if err := stdjson.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode worker report: %w", err)
+	}
+	if report.SchemaVersion != json.SchemaVersion {
+		return nil, fmt.Errorf("unsupported worker report schema version %q (expected %q)", report.SchemaVersion, json.SchemaVersion)
+	}
+	return &report, nil
+}
+
+// Merge combines the reports of the workers. The host shards are merged into one host
+// result: a host found by several shards (member of several business groups) is kept once,
+// and the summaries are recomputed. Every other module is inspected by a single worker,
+// whose result is kept as is.
+func Merge(reports []*json.Report) *json.Report {
+	merged := &json.Report{
+		SchemaVersion: json.SchemaVersion,
+		GeneratedAt:   time.Now(),
+	}
+
+	seen := make(map[string]bool)
+	for _, r := range reports {
+		if r == nil {
+			continue
+		}
+		if merged.Version == "" {
+			merged.Version = r.Version
+		}
+
+		if r.Host != nil {
+			if merged.Host == nil {
+				merged.Host = &model.InspectionResult{InspectionTime: r.Host.InspectionTime, Version: r.Host.Version}
+			}
+			if r.Host.InspectionTime.Before(merged.Host.InspectionTime) {
+				merged.Host.InspectionTime = r.Host.InspectionTime
+			}
+			if r.Host.Duration > merged.Host.Duration {
+				merged.Host.Duration = r.Host.Duration
+			}
+			added := make(map[string]bool)
+			for _, host := range r.Host.Hosts {
+				if host == nil || seen[host.Hostname] {
+					continue
+				}
+				seen[host.Hostname] = true
+				added[host.Hostname] = true
+				merged.Host.AddHost(host)
+			}
+			merged.Host.SourceConflicts = append(merged.Host.SourceConflicts, r.Host.SourceConflicts...)
+			// Active alerts of a host kept from this shard, so a host in several shards is listed once
+			for _, alert := range r.Host.ActiveAlerts {
+				if added[alert.Hostname] {
+					merged.Host.ActiveAlerts = append(merged.Host.ActiveAlerts, alert)
+				}
+			}
+		}
+
+		if merged.MySQL == nil {
+			merged.MySQL = r.MySQL
+		}
+		if merged.Redis == nil {
+			merged.Redis = r.Redis
+		}
+		if merged.Nginx == nil {
+			merged.Nginx = r.Nginx
+		}
+		if merged.Tomcat == nil {
+			merged.Tomcat = r.Tomcat
+		}
+		if merged.Cassandra == nil {
+			merged.Cassandra = r.Cassandra
+		}
+		if merged.Monitoring == nil {
+			merged.Monitoring = r.Monitoring
+		}
+		if merged.Storage == nil {
+			merged.Storage = r.Storage
+		}
+		if merged.LogChecks == nil {
+			merged.LogChecks = r.LogChecks
+		}
+		if merged.LVS == nil {
+			merged.LVS = r.LVS
+		}
+		if merged.Windows == nil {
+			merged.Windows = r.Windows
+		}
+		if merged.AD == nil {
+			merged.AD = r.AD
+		}
+		if merged.Cloud == nil {
+			merged.Cloud = r.Cloud
+		}
+		if merged.Firewall == nil {
+			merged.Firewall = r.Firewall
+		}
+	}
+
+	if merged.Host != nil {
+		merged.Host.Summary = model.NewInspectionSummary(merged.Host.Hosts)
+		merged.Host.AlertSummary = model.NewAlertSummary(merged.Host.Alerts)
+		model.CorrelateActiveAlerts(merged.Host.ActiveAlerts, merged.Host.Alerts)
+	}
+	merged.Alerts = json.FlattenAlerts(merged)
+	return merged
+}
@@ -0,0 +1,220 @@
+package distributed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/pkg/testfixtures"
+)
+
+func TestPlan(t *testing.T) {
+	workers := []string{"http://w1", "http://w2", "http://w3"}
+	modules := []string{json.ModuleHost, json.ModuleMySQL, json.ModuleRedis}
+
+	got := Plan(workers, []string{"a", "b", "c", "d"}, modules)
+	want := []*Assignment{
+		{Worker: "http://w1", Job: &Job{BusinessGroups: []string{"a", "d"}, Modules: []string{json.ModuleHost, json.ModuleMySQL}}},
+		{Worker: "http://w2", Job: &Job{BusinessGroups: []string{"b"}, Modules: []string{json.ModuleHost, json.ModuleRedis}}},
+		{Worker: "http://w3", Job: &Job{BusinessGroups: []string{"c"}, Modules: []string{json.ModuleHost}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Plan() = %s, want %s", describe(got), describe(want))
+	}
+}
+
+func TestPlan_NoBusinessGroups(t *testing.T) {
+	got := Plan([]string{"http://w1", "http://w2"}, nil, []string{json.ModuleHost, json.ModuleMySQL})
+	want := []*Assignment{
+		{Worker: "http://w1", Job: &Job{Modules: []string{json.ModuleHost}}},
+		{Worker: "http://w2", Job: &Job{Modules: []string{json.ModuleMySQL}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Plan() = %s, want %s", describe(got), describe(want))
+	}
+}
+
+func TestPlan_IdleWorkers(t *testing.T) {
+	got := Plan([]string{"http://w1", "http://w2", "http://w3"}, nil, []string{json.ModuleMySQL})
+	if len(got) != 1 || got[0].Worker != "http://w1" {
+		t.Errorf("Plan() = %s, want one job on the first worker", describe(got))
+	}
+}
+
+func TestMerge(t *testing.T) {
+	host1 := testfixtures.NewHost("host-1", "10.0.0.1")
+	testfixtures.AddHostAlert(host1, "cpu_usage", 95)
+	host2 := testfixtures.NewHost("host-2", "10.0.0.2")
+	testfixtures.AddHostAlert(host2, "memory_usage", 75)
+	shard1 := testfixtures.NewInspectionResult(host1, testfixtures.NewHost("shared", "10.0.0.9"))
+	shard2 := testfixtures.NewInspectionResult(testfixtures.NewHost("shared", "10.0.0.9"), host2)
+	shard2.Duration = 2 * shard1.Duration
+	shard1.ActiveAlerts = []*model.ActiveAlert{{Hostname: "shared", RuleName: "磁盘只读", Level: model.AlertLevelCritical}}
+	shard2.ActiveAlerts = []*model.ActiveAlert{
+		{Hostname: "shared", RuleName: "磁盘只读", Level: model.AlertLevelCritical},
+		{Hostname: "host-2", RuleName: "内存使用率过高", Level: model.AlertLevelWarning},
+	}
+	mysql := testfixtures.DefaultMySQLInspectionResults()
+
+	merged := Merge([]*json.Report{
+		{SchemaVersion: json.SchemaVersion, Host: shard1},
+		{SchemaVersion: json.SchemaVersion, Host: shard2, MySQL: mysql},
+	})
+
+	if merged.Host.Summary.TotalHosts != 3 {
+		t.Errorf("TotalHosts = %d, want 3 (shared host kept once)", merged.Host.Summary.TotalHosts)
+	}
+	if merged.Host.Summary.CriticalHosts != 1 || merged.Host.Summary.WarningHosts != 1 {
+		t.Errorf("summary = %+v, want 1 critical and 1 warning host", merged.Host.Summary)
+	}
+	if merged.Host.AlertSummary.TotalAlerts != 2 {
+		t.Errorf("TotalAlerts = %d, want 2", merged.Host.AlertSummary.TotalAlerts)
+	}
+	if len(merged.Host.ActiveAlerts) != 2 || merged.Host.ActiveAlerts[1].Hostname != "host-2" ||
+		len(merged.Host.ActiveAlerts[1].Inspection) != 1 {
+		t.Errorf("active alerts = %+v, want the shared host once and host-2 correlated", merged.Host.ActiveAlerts)
+	}
+	if merged.Host.Duration != shard2.Duration {
+		t.Errorf("Duration = %v, want the slowest shard %v", merged.Host.Duration, shard2.Duration)
+	}
+	if merged.MySQL != mysql {
+		t.Error("MySQL result of its worker should be kept")
+	}
+	if len(merged.Alerts) != 2+len(mysql.Alerts) {
+		t.Errorf("alerts = %d, want host and MySQL alerts", len(merged.Alerts))
+	}
+}
+
+func TestCoordinator_Run(t *testing.T) {
+	var jobs []*Job
+	worker, err := NewWorker("secret", func(ctx context.Context, job *Job) (*json.Report, error) {
+		jobs = append(jobs, job)
+		return &json.Report{
+			SchemaVersion: json.SchemaVersion,
+			Host:          testfixtures.NewInspectionResult(testfixtures.NewHost("host-"+job.BusinessGroups[0], "10.0.0.1")),
+		}, nil
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(worker.Handler())
+	defer server.Close()
+
+	c := NewCoordinator("secret", time.Minute, nil, zerolog.Nop())
+	report, err := c.Run(context.Background(), []*Assignment{
+		{Worker: server.URL, Job: &Job{BusinessGroups: []string{"a"}, Modules: []string{json.ModuleHost}}},
+		{Worker: server.URL + "/", Job: &Job{BusinessGroups: []string{"b"}, Modules: []string{json.ModuleHost}}},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Errorf("worker ran %d jobs, want 2", len(jobs))
+	}
+	if report.Host == nil || report.Host.Summary.TotalHosts != 2 {
+		t.Errorf("merged report = %+v, want the hosts of both shards", report.Host)
+	}
+}
+
+func TestCoordinator_RunFailures(t *testing.T) {
+	worker, err := NewWorker("secret", func(ctx context.Context, job *Job) (*json.Report, error) {
+		return nil, errors.New("datasource unreachable")
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(worker.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"job error", "secret", "datasource unreachable"},
+		{"wrong token", "wrong", "401"},
+		{"no token", "", "401"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCoordinator(tt.token, time.Minute, nil, zerolog.Nop())
+			_, err := c.Run(context.Background(), []*Assignment{{Worker: server.URL, Job: &Job{Modules: []string{json.ModuleMySQL}}}})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Run() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWorker_RequiresToken(t *testing.T) {
+	if _, err := NewWorker("", nil, zerolog.Nop()); err == nil {
+		t.Error("NewWorker() should reject an empty token")
+	}
+}
+
+func TestWorker_Unauthorized(t *testing.T) {
+	ran := false
+	worker, err := NewWorker("secret", func(ctx context.Context, job *Job) (*json.Report, error) {
+		ran = true
+		return &json.Report{SchemaVersion: json.SchemaVersion, Host: &model.InspectionResult{}}, nil
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, authorization := range []string{"", "Bearer ", "Bearer wrong", "secret"} {
+		req := httptest.NewRequest(http.MethodPost, jobsPath, strings.NewReader(`{"modules":["host"]}`))
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		worker.Handler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d with Authorization %q, want %d", rec.Code, authorization, http.StatusUnauthorized)
+		}
+	}
+	if ran {
+		t.Error("an unauthenticated request should not run a job")
+	}
+}
+
+func TestWorker_InvalidJob(t *testing.T) {
+	worker, err := NewWorker("secret", func(ctx context.Context, job *Job) (*json.Report, error) {
+		return &json.Report{SchemaVersion: json.SchemaVersion, Host: &model.InspectionResult{}}, nil
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, jobsPath, strings.NewReader(`{"modules":[]}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	worker.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d for a job without module", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = httptest.NewRecorder()
+	worker.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, jobsPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d for GET", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+// describe formats assignments for test failures.
+func describe(assignments []*Assignment) string {
+	parts := make([]string, 0, len(assignments))
+	for _, a := range assignments {
+		parts = append(parts, a.Worker+": "+a.Job.String()+" "+strings.Join(a.Job.BusinessGroups, ","))
+	}
+	return "[" + strings.Join(parts, "; ") + "]"
+}
@@ -0,0 +1,118 @@
+// Package distributed splits an inspection across several tool instances for very large
+// estates. The coordinator (the run command with distributed.workers configured) shards the
+// host business groups and the service modules into one job per worker, sends the jobs over a
+// simple HTTP protocol, and merges the JSON reports returned by the workers (inspect worker)
+// into the results of a single combined report.
+//
+// Protocol:
+//
+//	POST /v1/jobs   Job as JSON -> JSON report (schema of the json report package)
+//	GET  /healthz   -> 200 "ok"
+//
+// When a token is configured, requests carry it as "Authorization: Bearer <token>".
+package distributed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/report/json"
+)
+
+// Job is the part of an inspection a worker runs: the modules to inspect (json.ModuleHost,
+// json.ModuleMySQL, ...) and, for the host module, the business groups of its hosts.
+// Empty business groups keep the host filter of the worker configuration; a zero range
+// window keeps the range_window of the worker configuration. Excluded hosts are added to
+// the exclusions of the worker configuration.
+type Job struct {
+	BusinessGroups []string  `json:"business_groups,omitempty"`
+	ExcludeHosts   []string  `json:"exclude_hosts,omitempty"` // Host patterns of --exclude-host
+	Modules        []string  `json:"modules"`
+	RangeStart     time.Time `json:"range_start,omitzero"` // Range aggregation window of the coordinator (--start / --end)
+	RangeEnd       time.Time `json:"range_end,omitzero"`
+}
+
+// HasModule reports whether the job inspects the module.
+func (j *Job) HasModule(module string) bool {
+	for _, m := range j.Modules {
+		if m == module {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the job for console output (e.g. "主机(业务组 a, b), mysql").
+func (j *Job) String() string {
+	parts := make([]string, 0, len(j.Modules))
+	for _, m := range j.Modules {
+		name := json.ModuleName(m)
+		if m == json.ModuleHost && len(j.BusinessGroups) > 0 {
+			name = fmt.Sprintf("%s(业务组 %s)", name, strings.Join(j.BusinessGroups, ", "))
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Assignment is a job sent to a worker.
+type Assignment struct {
+	Worker string // Worker base URL
+	Job    *Job
+}
+
+// Plan shards the inspection across the workers:
+//   - the host module is split by business group, round-robin over the workers (all hosts go
+//     to the first worker when no business group is configured);
+//   - every other module is inspected whole by one worker, continuing the round-robin after
+//     the host shards so the load spreads over the workers.
+//
+// Each worker gets at most one job; workers without work are left out.
+func Plan(workers []string, businessGroups []string, modules []string) []*Assignment {
+	if len(workers) == 0 {
+		return nil
+	}
+
+	jobs := make([]*Job, len(workers))
+	for i := range jobs {
+		jobs[i] = &Job{}
+	}
+
+	next := 0
+	for _, module := range modules {
+		if module != json.ModuleHost {
+			continue
+		}
+		shards := len(workers)
+		if len(businessGroups) < shards {
+			shards = len(businessGroups)
+		}
+		if shards == 0 {
+			shards = 1
+		}
+		for i := 0; i < shards; i++ {
+			jobs[i].Modules = append(jobs[i].Modules, json.ModuleHost)
+		}
+		for i, group := range businessGroups {
+			jobs[i%shards].BusinessGroups = append(jobs[i%shards].BusinessGroups, group)
+		}
+		next = shards
+	}
+	for _, module := range modules {
+		if module == json.ModuleHost {
+			continue
+		}
+		job := jobs[next%len(workers)]
+		job.Modules = append(job.Modules, module)
+		next++
+	}
+
+	var assignments []*Assignment
+	for i, job := range jobs {
+		if len(job.Modules) > 0 {
+			assignments = append(assignments, &Assignment{Worker: workers[i], Job: job})
+		}
+	}
+	return assignments
+}
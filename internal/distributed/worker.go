@@ -0,0 +1,109 @@
+package distributed
+
+import (
+	"context"
+	"crypto/subtle"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/report/json"
+)
+
+// RunFunc runs a job on the worker and returns its JSON report.
+type RunFunc func(ctx context.Context, job *Job) (*json.Report, error)
+
+// Worker serves the jobs of a coordinator. Jobs run one at a time: each one is a full
+// inspection of its shard, already parallel across hosts and instances.
+type Worker struct {
+	token  string
+	run    RunFunc
+	mu     sync.Mutex
+	logger zerolog.Logger
+}
+
+// NewWorker creates a worker running the jobs with run. The coordinator has to send token;
+// an empty token is rejected, since the reports list the whole inventory.
+func NewWorker(token string, run RunFunc, logger zerolog.Logger) (*Worker, error) {
+	if token == "" {
+		return nil, errors.New("worker token is required")
+	}
+	return &Worker{
+		token:  token,
+		run:    run,
+		logger: logger.With().Str("component", "worker").Logger(),
+	}, nil
+}
+
+// Handler returns the HTTP handler of the job protocol.
+func (w *Worker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(rw http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(rw, "ok")
+	})
+	mux.HandleFunc(jobsPath, w.handleJob)
+	return mux
+}
+
+// handleJob runs a job posted by the coordinator and writes its report.
+func (w *Worker) handleJob(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+w.token)) != 1 {
+		http.Error(rw, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var job Job
+	if err := stdjson.NewDecoder(r.Body).Decode(&job); err != nil {
+		http.Error(rw, fmt.Sprintf("invalid job: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(job.Modules) == 0 {
+		http.Error(rw, "invalid job: no module", http.StatusBadRequest)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	start := time.Now()
+	w.logger.Info().Strs("modules", job.Modules).Strs("business_groups", job.BusinessGroups).Msg("job started")
+	report, err := w.run(r.Context(), &job)
+	if err != nil {
+		w.logger.Error().Err(err).Dur("duration", time.Since(start)).Msg("job failed")
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.logger.Info().Dur("duration", time.Since(start)).Msg("job finished")
+
+	rw.Header().Set("Content-Type", "application/json")
+	if err := stdjson.NewEncoder(rw).Encode(report); err != nil {
+		w.logger.Error().Err(err).Msg("failed to write job report")
+	}
+	w.releaseMemory()
+}
+
+// releaseMemory returns the memory of the last job to the operating system once its report
+// is sent, and logs the heap left: the worker idles until the next run, so nothing is kept
+// from one job to the next and a worker running for months does not grow.
+func (w *Worker) releaseMemory() {
+	debug.FreeOSMemory()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	w.logger.Info().
+		Uint64("heap_alloc", stats.HeapAlloc).
+		Uint64("heap_sys", stats.HeapSys).
+		Uint64("heap_released", stats.HeapReleased).
+		Uint32("num_gc", stats.NumGC).
+		Msg("job memory released")
+}
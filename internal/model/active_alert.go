@@ -0,0 +1,97 @@
+// Package model provides data models for the inspection tool.
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// ActiveAlert represents an alert rule currently firing in N9E for an inspected host.
+type ActiveAlert struct {
+	Hostname     string     `json:"hostname"`                    // 主机名
+	Ident        string     `json:"ident"`                       // N9E 主机标识符
+	RuleName     string     `json:"rule_name"`                   // 告警规则名称
+	Level        AlertLevel `json:"level"`                       // 告警级别（N9E 三级告警为 normal）
+	TriggerTime  time.Time  `json:"trigger_time"`                // 触发时间
+	TriggerValue string     `json:"trigger_value,omitempty"`     // 触发值
+	GroupName    string     `json:"group_name,omitempty"`        // 业务组
+	Tags         []string   `json:"tags,omitempty"`              // 标签（key=value）
+	Inspection   []string   `json:"inspection_alerts,omitempty"` // 同一主机的巡检告警（指标显示名称）
+}
+
+// N9ESeverityLevel converts an N9E alert severity (1: critical, 2: warning, 3: info) to an
+// alert level. Info events map to normal.
+func N9ESeverityLevel(severity int) AlertLevel {
+	switch severity {
+	case 1:
+		return AlertLevelCritical
+	case 2:
+		return AlertLevelWarning
+	default:
+		return AlertLevelNormal
+	}
+}
+
+// LevelText returns the Chinese text of the N9E severity of the alert.
+func (a *ActiveAlert) LevelText() string {
+	switch a.Level {
+	case AlertLevelCritical:
+		return "严重"
+	case AlertLevelWarning:
+		return "警告"
+	default:
+		return "提示"
+	}
+}
+
+// CorrelateActiveAlerts records on each active alert the inspection alerts of the same host,
+// so the report shows which firing rules the inspection also found and which it missed.
+// Active alerts are sorted by severity, hostname and rule name.
+func CorrelateActiveAlerts(active []*ActiveAlert, alerts []*Alert) {
+	byHost := make(map[string][]string)
+	for _, alert := range alerts {
+		name := alert.MetricDisplayName
+		if name == "" {
+			name = alert.MetricName
+		}
+		if !containsString(byHost[alert.Hostname], name) {
+			byHost[alert.Hostname] = append(byHost[alert.Hostname], name)
+		}
+	}
+
+	for _, a := range active {
+		a.Inspection = byHost[a.Hostname]
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		if pi, pj := activeAlertPriority(active[i].Level), activeAlertPriority(active[j].Level); pi != pj {
+			return pi > pj
+		}
+		if active[i].Hostname != active[j].Hostname {
+			return active[i].Hostname < active[j].Hostname
+		}
+		return active[i].RuleName < active[j].RuleName
+	})
+}
+
+// activeAlertPriority returns a numeric priority for sorting (higher = more severe).
+func activeAlertPriority(level AlertLevel) int {
+	switch level {
+	case AlertLevelCritical:
+		return 2
+	case AlertLevelWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestN9ESeverityLevel(t *testing.T) {
+	tests := map[int]AlertLevel{1: AlertLevelCritical, 2: AlertLevelWarning, 3: AlertLevelNormal, 0: AlertLevelNormal}
+	for severity, want := range tests {
+		if got := N9ESeverityLevel(severity); got != want {
+			t.Errorf("N9ESeverityLevel(%d) = %s, want %s", severity, got, want)
+		}
+	}
+}
+
+func TestCorrelateActiveAlerts(t *testing.T) {
+	active := []*ActiveAlert{
+		{Hostname: "host-b", RuleName: "内存使用率过高", Level: AlertLevelWarning},
+		{Hostname: "host-a", RuleName: "磁盘使用率过高", Level: AlertLevelWarning},
+		{Hostname: "host-c", RuleName: "主机失联", Level: AlertLevelCritical},
+	}
+	alerts := []*Alert{
+		{Hostname: "host-a", MetricName: "disk_usage", MetricDisplayName: "磁盘利用率"},
+		{Hostname: "host-a", MetricName: "disk_usage", MetricDisplayName: "磁盘利用率", Labels: map[string]string{"path": "/data"}},
+		{Hostname: "host-a", MetricName: "cpu_usage"},
+	}
+
+	CorrelateActiveAlerts(active, alerts)
+
+	var order []string
+	for _, a := range active {
+		order = append(order, a.Hostname)
+	}
+	if want := []string{"host-c", "host-a", "host-b"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if want := []string{"磁盘利用率", "cpu_usage"}; !reflect.DeepEqual(active[1].Inspection, want) {
+		t.Errorf("host-a inspection alerts = %v, want %v", active[1].Inspection, want)
+	}
+	if active[2].Inspection != nil {
+		t.Errorf("host-b inspection alerts = %v, want none", active[2].Inspection)
+	}
+}
@@ -0,0 +1,361 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// 域控制器状态枚举
+// =============================================================================
+
+type ADInstanceStatus string
+
+const (
+	ADStatusNormal   ADInstanceStatus = "normal"
+	ADStatusWarning  ADInstanceStatus = "warning"
+	ADStatusCritical ADInstanceStatus = "critical"
+	ADStatusFailed   ADInstanceStatus = "failed"
+)
+
+func (s ADInstanceStatus) IsHealthy() bool {
+	return s == ADStatusNormal
+}
+
+func (s ADInstanceStatus) IsWarning() bool {
+	return s == ADStatusWarning
+}
+
+func (s ADInstanceStatus) IsCritical() bool {
+	return s == ADStatusCritical
+}
+
+func (s ADInstanceStatus) IsFailed() bool {
+	return s == ADStatusFailed
+}
+
+// =============================================================================
+// 域控制器结构体
+// =============================================================================
+
+// ADInstance represents an Active Directory domain controller.
+// Domain controllers are identified by hostname.
+type ADInstance struct {
+	Identifier string `json:"identifier"`
+	Hostname   string `json:"hostname"`
+	IP         string `json:"ip"`
+}
+
+func NewADInstance(hostname string) *ADInstance {
+	return &ADInstance{
+		Identifier: hostname,
+		Hostname:   hostname,
+	}
+}
+
+func (i *ADInstance) SetIP(ip string) {
+	if i == nil {
+		return
+	}
+	i.IP = ip
+}
+
+func (i *ADInstance) String() string {
+	if i == nil {
+		return "ADInstance(nil)"
+	}
+	return fmt.Sprintf("ADInstance(%s)", i.Identifier)
+}
+
+// =============================================================================
+// 域控制器告警结构体
+// =============================================================================
+
+type ADAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewADAlert(identifier, metricName string, currentValue float64, level AlertLevel) *ADAlert {
+	return &ADAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *ADAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *ADAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// 域控制器指标值结构体
+// =============================================================================
+
+type ADMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// 域控制器巡检结果结构体
+// =============================================================================
+
+type ADInspectionResult struct {
+	Instance            *ADInstance               `json:"instance"`
+	ReplicationPending  float64                   `json:"replication_pending"`  // 待处理的复制同步数（-1 表示未采集）
+	ReplicationFailures float64                   `json:"replication_failures"` // 最近一次复制失败的复制伙伴数（-1 表示未采集）
+	LDAPProbeSuccess    float64                   `json:"ldap_probe_success"`   // LDAP 探测是否成功（1/0，-1 表示未采集）
+	LDAPBindLatency     float64                   `json:"ldap_bind_latency"`    // LDAP 绑定延迟（秒，-1 表示未采集）
+	SysvolFreePercent   float64                   `json:"sysvol_free_percent"`  // SYSVOL 所在卷剩余空间百分比（-1 表示未采集）
+	Metrics             map[string]*ADMetricValue `json:"-"`                    // 指标映射（内部使用，不序列化）
+	Status              ADInstanceStatus          `json:"status"`
+	Alerts              []*ADAlert                `json:"alerts,omitempty"`
+	CollectedAt         time.Time                 `json:"collected_at"`
+	Error               string                    `json:"error,omitempty"`
+}
+
+func NewADInspectionResult(instance *ADInstance) *ADInspectionResult {
+	return &ADInspectionResult{
+		Instance:            instance,
+		Status:              ADStatusNormal,
+		Alerts:              make([]*ADAlert, 0),
+		ReplicationPending:  -1,
+		ReplicationFailures: -1,
+		LDAPProbeSuccess:    -1,
+		LDAPBindLatency:     -1,
+		SysvolFreePercent:   -1,
+	}
+}
+
+func (r *ADInspectionResult) AddAlert(alert *ADAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *ADInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *ADInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// HasReplicationPending returns true if the pending replication count was collected.
+func (r *ADInspectionResult) HasReplicationPending() bool {
+	return r != nil && r.ReplicationPending >= 0
+}
+
+// HasReplicationFailures returns true if the replication failure count was collected.
+func (r *ADInspectionResult) HasReplicationFailures() bool {
+	return r != nil && r.ReplicationFailures >= 0
+}
+
+// HasLDAPProbe returns true if an LDAP probe result was collected.
+func (r *ADInspectionResult) HasLDAPProbe() bool {
+	return r != nil && r.LDAPProbeSuccess >= 0
+}
+
+// HasLDAPBindLatency returns true if the LDAP bind latency was collected.
+func (r *ADInspectionResult) HasLDAPBindLatency() bool {
+	return r != nil && r.LDAPBindLatency >= 0
+}
+
+// HasSysvolFree returns true if the SYSVOL free space was collected.
+func (r *ADInspectionResult) HasSysvolFree() bool {
+	return r != nil && r.SysvolFreePercent >= 0
+}
+
+// IsReplicationFailing returns true if at least one replication partner failed its last sync.
+func (r *ADInspectionResult) IsReplicationFailing() bool {
+	return r != nil && r.ReplicationFailures > 0
+}
+
+// IsLDAPUnreachable returns true if the LDAP probe was collected and failed.
+func (r *ADInspectionResult) IsLDAPUnreachable() bool {
+	return r.HasLDAPProbe() && r.LDAPProbeSuccess == 0
+}
+
+func (r *ADInspectionResult) SetMetric(mv *ADMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*ADMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *ADInspectionResult) GetMetric(name string) *ADMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// 域控制器巡检摘要结构体
+// =============================================================================
+
+type ADInspectionSummary struct {
+	TotalInstances     int `json:"total_instances"`
+	NormalInstances    int `json:"normal_instances"`
+	WarningInstances   int `json:"warning_instances"`
+	CriticalInstances  int `json:"critical_instances"`
+	FailedInstances    int `json:"failed_instances"`
+	ReplicationFailing int `json:"replication_failing"` // 复制失败的域控制器数
+	LDAPUnreachable    int `json:"ldap_unreachable"`    // LDAP 探测失败的域控制器数
+}
+
+func NewADInspectionSummary(results []*ADInspectionResult) *ADInspectionSummary {
+	summary := &ADInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case ADStatusNormal:
+			summary.NormalInstances++
+		case ADStatusWarning:
+			summary.WarningInstances++
+		case ADStatusCritical:
+			summary.CriticalInstances++
+		case ADStatusFailed:
+			summary.FailedInstances++
+		}
+
+		if result.IsReplicationFailing() {
+			summary.ReplicationFailing++
+		}
+		if result.IsLDAPUnreachable() {
+			summary.LDAPUnreachable++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 域控制器告警摘要结构体
+// =============================================================================
+
+type ADAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewADAlertSummary(alerts []*ADAlert) *ADAlertSummary {
+	summary := &ADAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 域控制器完整巡检结果容器
+// =============================================================================
+
+type ADInspectionResults struct {
+	InspectionTime time.Time             `json:"inspection_time"`
+	Duration       time.Duration         `json:"duration"`
+	Summary        *ADInspectionSummary  `json:"summary"`
+	Results        []*ADInspectionResult `json:"results"`
+	Alerts         []*ADAlert            `json:"alerts"`
+	AlertSummary   *ADAlertSummary       `json:"alert_summary"`
+	Version        string                `json:"version,omitempty"`
+}
+
+func NewADInspectionResults(inspectionTime time.Time) *ADInspectionResults {
+	return &ADInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*ADInspectionResult, 0),
+		Alerts:         make([]*ADAlert, 0),
+	}
+}
+
+func (r *ADInspectionResults) AddResult(result *ADInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *ADInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewADInspectionSummary(r.Results)
+	r.AlertSummary = NewADAlertSummary(r.Alerts)
+}
+
+func (r *ADInspectionResults) GetResultByIdentifier(identifier string) *ADInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *ADInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *ADInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *ADInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
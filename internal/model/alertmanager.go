@@ -0,0 +1,90 @@
+// Package model provides data models for the inspection tool.
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Alertmanager alert states.
+const (
+	AlertmanagerStateActive     = "active"     // 触发中
+	AlertmanagerStateSuppressed = "suppressed" // 已被静默或抑制
+)
+
+// AlertmanagerAlert represents an alert firing in Alertmanager.
+type AlertmanagerAlert struct {
+	Fingerprint string                 `json:"fingerprint"`            // 告警指纹
+	Name        string                 `json:"name"`                   // alertname 标签
+	Severity    string                 `json:"severity,omitempty"`     // severity 标签
+	Instance    string                 `json:"instance,omitempty"`     // instance 标签
+	Summary     string                 `json:"summary,omitempty"`      // summary（或 description）注解
+	Labels      map[string]string      `json:"labels,omitempty"`       // 全部标签
+	StartsAt    time.Time              `json:"starts_at"`              // 开始触发时间
+	State       string                 `json:"state"`                  // active / suppressed
+	SilencedBy  []string               `json:"silenced_by,omitempty"`  // 静默 ID
+	InhibitedBy []string               `json:"inhibited_by,omitempty"` // 抑制该告警的告警指纹
+	Silences    []*AlertmanagerSilence `json:"silences,omitempty"`     // 静默详情（查询失败时为空）
+}
+
+// AlertmanagerSilence describes a silence muting an Alertmanager alert.
+type AlertmanagerSilence struct {
+	ID        string    `json:"id"`
+	CreatedBy string    `json:"created_by,omitempty"` // 创建人
+	Comment   string    `json:"comment,omitempty"`    // 静默原因
+	EndsAt    time.Time `json:"ends_at"`              // 静默结束时间
+}
+
+// Silenced reports whether the alert is muted by at least one silence.
+func (a *AlertmanagerAlert) Silenced() bool {
+	return len(a.SilencedBy) > 0
+}
+
+// Inhibited reports whether the alert is inhibited by another alert.
+func (a *AlertmanagerAlert) Inhibited() bool {
+	return len(a.InhibitedBy) > 0
+}
+
+// Level converts the severity label to an alert level: critical, page, error and emergency
+// are critical, warning is warning and anything else (info, none, missing) is normal.
+func (a *AlertmanagerAlert) Level() AlertLevel {
+	switch a.Severity {
+	case "critical", "page", "error", "emergency":
+		return AlertLevelCritical
+	case "warning", "warn":
+		return AlertLevelWarning
+	default:
+		return AlertLevelNormal
+	}
+}
+
+// StateText returns the Chinese text of the alert state; silences take precedence over
+// inhibitions.
+func (a *AlertmanagerAlert) StateText() string {
+	switch {
+	case a.Silenced():
+		return "已静默"
+	case a.Inhibited():
+		return "已抑制"
+	default:
+		return "触发中"
+	}
+}
+
+// SortAlertmanagerAlerts sorts alerts with the alerts still notifying first, then by
+// severity, alert name and instance.
+func SortAlertmanagerAlerts(alerts []*AlertmanagerAlert) {
+	sort.SliceStable(alerts, func(i, j int) bool {
+		si, sj := alerts[i].State == AlertmanagerStateSuppressed, alerts[j].State == AlertmanagerStateSuppressed
+		if si != sj {
+			return !si
+		}
+		if pi, pj := activeAlertPriority(alerts[i].Level()), activeAlertPriority(alerts[j].Level()); pi != pj {
+			return pi > pj
+		}
+		if alerts[i].Name != alerts[j].Name {
+			return alerts[i].Name < alerts[j].Name
+		}
+		return alerts[i].Instance < alerts[j].Instance
+	})
+}
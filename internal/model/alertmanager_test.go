@@ -0,0 +1,47 @@
+package model
+
+import "testing"
+
+func TestAlertmanagerAlert_LevelAndState(t *testing.T) {
+	tests := []struct {
+		alert     AlertmanagerAlert
+		wantLevel AlertLevel
+		wantState string
+	}{
+		{AlertmanagerAlert{Severity: "critical"}, AlertLevelCritical, "触发中"},
+		{AlertmanagerAlert{Severity: "page", InhibitedBy: []string{"f1"}}, AlertLevelCritical, "已抑制"},
+		{AlertmanagerAlert{Severity: "warning", SilencedBy: []string{"s1"}, InhibitedBy: []string{"f1"}}, AlertLevelWarning, "已静默"},
+		{AlertmanagerAlert{Severity: "info"}, AlertLevelNormal, "触发中"},
+		{AlertmanagerAlert{}, AlertLevelNormal, "触发中"},
+	}
+	for _, tt := range tests {
+		if got := tt.alert.Level(); got != tt.wantLevel {
+			t.Errorf("Level(%q) = %s, want %s", tt.alert.Severity, got, tt.wantLevel)
+		}
+		if got := tt.alert.StateText(); got != tt.wantState {
+			t.Errorf("StateText(%+v) = %s, want %s", tt.alert, got, tt.wantState)
+		}
+	}
+}
+
+func TestSortAlertmanagerAlerts(t *testing.T) {
+	alerts := []*AlertmanagerAlert{
+		{Name: "HostDown", Severity: "critical", State: AlertmanagerStateSuppressed, SilencedBy: []string{"s1"}},
+		{Name: "DiskFull", Severity: "warning", State: AlertmanagerStateActive, Instance: "b"},
+		{Name: "DiskFull", Severity: "warning", State: AlertmanagerStateActive, Instance: "a"},
+		{Name: "Watchdog", Severity: "none", State: AlertmanagerStateActive},
+		{Name: "NodeDown", Severity: "critical", State: AlertmanagerStateActive},
+	}
+	SortAlertmanagerAlerts(alerts)
+
+	want := []string{"NodeDown", "DiskFull/a", "DiskFull/b", "Watchdog", "HostDown"}
+	for i, a := range alerts {
+		got := a.Name
+		if a.Instance != "" {
+			got += "/" + a.Instance
+		}
+		if got != want[i] {
+			t.Errorf("alerts[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package model
+
+// BoolLabels defines the texts used to present a boolean metric in reports.
+type BoolLabels struct {
+	True  string // 值为 true 时显示
+	False string // 值为 false 时显示
+}
+
+// Text returns the label for the given value.
+func (l BoolLabels) Text(value bool) string {
+	if value {
+		return l.True
+	}
+	return l.False
+}
+
+// Predefined boolean label sets.
+var (
+	BoolLabelsYesNo      = BoolLabels{True: "是", False: "否"}
+	BoolLabelsEnabled    = BoolLabels{True: "启用", False: "禁用"}
+	BoolLabelsOnline     = BoolLabels{True: "在线", False: "离线"}
+	BoolLabelsNormal     = BoolLabels{True: "正常", False: "异常"}
+	BoolLabelsLink       = BoolLabels{True: "正常", False: "断开"}
+	BoolLabelsRunning    = BoolLabels{True: "运行", False: "停止"}
+	BoolLabelsConfigured = BoolLabels{True: "已配置", False: "未配置"}
+)
+
+// boolMetricLabels maps boolean metrics to their presentation labels.
+// Metrics not listed here are presented with BoolLabelsYesNo.
+var boolMetricLabels = map[string]BoolLabels{
+	// MySQL
+	"mysql_up":         BoolLabelsNormal,
+	"mgr_state_online": BoolLabelsOnline,
+	"binlog_enabled":   BoolLabelsEnabled,
+	"sync_status":      BoolLabelsNormal,
+	"non_root_user":    BoolLabelsYesNo,
+
+	// Redis
+	"redis_up":                 BoolLabelsNormal,
+	"redis_cluster_enabled":    BoolLabelsEnabled,
+	"redis_master_link_status": BoolLabelsLink,
+
+	// Nginx
+	"nginx_up":             BoolLabelsRunning,
+	"nginx_error_page_4xx": BoolLabelsConfigured,
+	"nginx_error_page_5xx": BoolLabelsConfigured,
+	"nginx_non_root_user":  BoolLabelsYesNo,
+
+	// Tomcat
+	"tomcat_up":            BoolLabelsRunning,
+	"tomcat_non_root_user": BoolLabelsYesNo,
+}
+
+// GetBoolLabels returns the presentation labels for a boolean metric.
+func GetBoolLabels(metricName string) BoolLabels {
+	if labels, ok := boolMetricLabels[metricName]; ok {
+		return labels
+	}
+	return BoolLabelsYesNo
+}
+
+// FormatBool returns the presentation text of a boolean metric value.
+// All report writers should use it so that the same metric reads the same everywhere.
+func FormatBool(metricName string, value bool) string {
+	return GetBoolLabels(metricName).Text(value)
+}
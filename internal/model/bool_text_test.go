@@ -0,0 +1,42 @@
+package model
+
+import "testing"
+
+func TestFormatBool(t *testing.T) {
+	tests := []struct {
+		metric string
+		value  bool
+		want   string
+	}{
+		{"binlog_enabled", true, "启用"},
+		{"binlog_enabled", false, "禁用"},
+		{"redis_cluster_enabled", true, "启用"},
+		{"mgr_state_online", true, "在线"},
+		{"mgr_state_online", false, "离线"},
+		{"redis_up", false, "异常"},
+		{"redis_master_link_status", false, "断开"},
+		{"nginx_up", true, "运行"},
+		{"nginx_error_page_4xx", false, "未配置"},
+		{"tomcat_non_root_user", true, "是"},
+		{"unknown_metric", true, "是"},
+		{"unknown_metric", false, "否"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			if got := FormatBool(tt.metric, tt.value); got != tt.want {
+				t.Errorf("FormatBool(%q, %v) = %q, want %q", tt.metric, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoolLabels_Text(t *testing.T) {
+	labels := BoolLabels{True: "开", False: "关"}
+	if got := labels.Text(true); got != "开" {
+		t.Errorf("Text(true) = %q, want %q", got, "开")
+	}
+	if got := labels.Text(false); got != "关" {
+		t.Errorf("Text(false) = %q, want %q", got, "关")
+	}
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// BusinessGroupLabel is the series label carrying the N9E business group of a host.
+const BusinessGroupLabel = "busigroup"
+
+// UngroupedBusinessGroup is the group name of hosts without business group.
+const UngroupedBusinessGroup = "未分组"
+
+// BusinessGroupSeparator separates the levels of an N9E business group name
+// (e.g. "电商/支付/网关"); the N9E web UI shows the groups as a tree by this separator.
+const BusinessGroupSeparator = "/"
+
+// NewBusinessGroupStats groups hosts by N9E business group, so each team sees its own slice
+// of the inspection. See NewHostGroupStats.
+func NewBusinessGroupStats(hosts []*HostResult) []*HostGroupStats {
+	return NewHostGroupStats(hosts, HostGroupBusinessGroup)
+}
+
+// averageMetric returns the average value of metric over the hosts reporting it,
+// or -1 when none does.
+func averageMetric(hosts []*HostResult, metric string) float64 {
+	var sum float64
+	var count int
+	for _, host := range hosts {
+		mv := host.GetMetric(metric)
+		if mv == nil || mv.IsNA {
+			continue
+		}
+		sum += mv.RawValue
+		count++
+	}
+	if count == 0 {
+		return -1
+	}
+	return sum / float64(count)
+}
+
+// BusinessGroup is an N9E business group.
+type BusinessGroup struct {
+	ID    int64  `json:"id"`              // 业务组 ID
+	Name  string `json:"name"`            // 业务组名称（以 / 分隔层级）
+	Label string `json:"label,omitempty"` // 附加到指标的 busigroup 标签值（未启用时为空）
+}
+
+// BusinessGroupTree is the business group hierarchy synchronized from N9E. A level of the
+// tree is a group itself or only a path prefix of deeper groups (e.g. "电商" for "电商/支付").
+type BusinessGroupTree struct {
+	names  map[int64]string  // Group name by ID
+	labels map[string]string // busigroup label value by group name
+	groups []string          // Group names, sorted
+}
+
+// NewBusinessGroupTree builds the tree of the given groups.
+func NewBusinessGroupTree(groups []BusinessGroup) *BusinessGroupTree {
+	t := &BusinessGroupTree{
+		names:  make(map[int64]string, len(groups)),
+		labels: make(map[string]string, len(groups)),
+	}
+	for _, g := range groups {
+		if g.Name == "" {
+			continue
+		}
+		t.names[g.ID] = g.Name
+		if _, ok := t.labels[g.Name]; !ok {
+			t.groups = append(t.groups, g.Name)
+		}
+		t.labels[g.Name] = g.Label
+	}
+	sort.Strings(t.groups)
+	return t
+}
+
+// Len returns the number of business groups.
+func (t *BusinessGroupTree) Len() int {
+	return len(t.groups)
+}
+
+// GroupName returns the group of a host from the IDs of its groups: the first group name in
+// order when the host belongs to several, "" when none is known.
+func (t *BusinessGroupTree) GroupName(ids []int64) string {
+	name := ""
+	for _, id := range ids {
+		if n, ok := t.names[id]; ok && (name == "" || n < name) {
+			name = n
+		}
+	}
+	return name
+}
+
+// subtree returns the groups at or below name in the tree.
+func (t *BusinessGroupTree) subtree(name string) []string {
+	prefix := strings.TrimSuffix(name, BusinessGroupSeparator) + BusinessGroupSeparator
+	var groups []string
+	for _, group := range t.groups {
+		if group == name || strings.HasPrefix(group, prefix) {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// hasLabel reports whether value is the busigroup label value of a group.
+func (t *BusinessGroupTree) hasLabel(value string) bool {
+	for _, label := range t.labels {
+		if label != "" && label == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether value names a business group, a level of the tree above groups,
+// or is the busigroup label value of a group.
+func (t *BusinessGroupTree) Contains(value string) bool {
+	return len(t.subtree(value)) > 0 || t.hasLabel(value)
+}
+
+// Unknown returns the values that Contains does not find in the tree.
+func (t *BusinessGroupTree) Unknown(values []string) []string {
+	var unknown []string
+	for _, value := range values {
+		if !t.Contains(value) {
+			unknown = append(unknown, value)
+		}
+	}
+	return unknown
+}
+
+// Expand converts business group filter values to the busigroup label values the metrics are
+// matched on: a group name or tree level becomes the label values of every group of its
+// subtree (the name itself for groups without label), other values are kept as they are.
+// The result has no duplicates and keeps the order of values.
+func (t *BusinessGroupTree) Expand(values []string) []string {
+	var expanded []string
+	seen := make(map[string]bool)
+	add := func(value string) {
+		if !seen[value] {
+			seen[value] = true
+			expanded = append(expanded, value)
+		}
+	}
+	for _, value := range values {
+		groups := t.subtree(value)
+		if len(groups) == 0 {
+			add(value)
+			continue
+		}
+		for _, group := range groups {
+			if label := t.labels[group]; label != "" {
+				add(label)
+			} else {
+				add(group)
+			}
+		}
+	}
+	return expanded
+}
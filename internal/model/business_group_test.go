@@ -0,0 +1,131 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewBusinessGroupStats(t *testing.T) {
+	host := func(name, group string, status HostStatus, cpu float64, alerts ...AlertLevel) *HostResult {
+		h := &HostResult{Hostname: name, BusinessGroup: group, Status: status}
+		h.SetMetric(&MetricValue{Name: "cpu_usage", RawValue: cpu, IsNA: cpu < 0})
+		for _, level := range alerts {
+			h.Alerts = append(h.Alerts, &Alert{Hostname: name, Level: level})
+		}
+		return h
+	}
+	hosts := []*HostResult{
+		host("web-1", "payment", HostStatusCritical, 90, AlertLevelCritical, AlertLevelWarning),
+		host("legacy-1", "", HostStatusNormal, 10),
+		host("web-2", "payment", HostStatusNormal, 30),
+		host("db-1", "order", HostStatusFailed, -1),
+		nil,
+	}
+
+	stats := NewBusinessGroupStats(hosts)
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(stats))
+	}
+	wantNames := []string{"order", "payment", UngroupedBusinessGroup}
+	for i, want := range wantNames {
+		if stats[i].Name != want {
+			t.Errorf("stats[%d].Name = %q, want %q", i, stats[i].Name, want)
+		}
+	}
+
+	payment := stats[1]
+	if payment.Summary.TotalHosts != 2 || payment.Summary.CriticalHosts != 1 || payment.Summary.NormalHosts != 1 {
+		t.Errorf("payment summary = %+v", payment.Summary)
+	}
+	if payment.AlertSummary.CriticalCount != 1 || payment.AlertSummary.WarningCount != 1 {
+		t.Errorf("payment alerts = %+v", payment.AlertSummary)
+	}
+	if payment.AvgCPUUsage != 60 {
+		t.Errorf("payment AvgCPUUsage = %v, want 60", payment.AvgCPUUsage)
+	}
+	if payment.HasAvgMemoryUsage() {
+		t.Errorf("payment AvgMemoryUsage = %v, want no data", payment.AvgMemoryUsage)
+	}
+	if stats[0].HasAvgCPUUsage() {
+		t.Errorf("order AvgCPUUsage = %v, want no data (N/A only)", stats[0].AvgCPUUsage)
+	}
+}
+
+func TestNewBusinessGroupStats_NoGroups(t *testing.T) {
+	hosts := []*HostResult{{Hostname: "host-1"}, {Hostname: "host-2"}}
+	if stats := NewBusinessGroupStats(hosts); stats != nil {
+		t.Errorf("expected nil without business groups, got %d groups", len(stats))
+	}
+}
+
+func TestBusinessGroupTree(t *testing.T) {
+	tree := NewBusinessGroupTree([]BusinessGroup{
+		{ID: 1, Name: "电商/支付", Label: "payment"},
+		{ID: 2, Name: "电商/订单"},
+		{ID: 3, Name: "电商/支付/网关", Label: "pay-gw"},
+		{ID: 4, Name: "运维"},
+		{ID: 5, Name: ""},
+	})
+
+	if tree.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", tree.Len())
+	}
+	if got := tree.GroupName([]int64{4, 3, 99}); got != "电商/支付/网关" {
+		t.Errorf("GroupName() = %q, want the first name in order", got)
+	}
+	if got := tree.GroupName([]int64{99}); got != "" {
+		t.Errorf("GroupName() of unknown IDs = %q, want empty", got)
+	}
+
+	for _, value := range []string{"电商", "电商/", "电商/支付", "运维", "payment"} {
+		if !tree.Contains(value) {
+			t.Errorf("Contains(%q) = false, want true", value)
+		}
+	}
+	if got := tree.Unknown([]string{"电商", "电", "电商/支", "财务"}); !reflect.DeepEqual(got, []string{"电", "电商/支", "财务"}) {
+		t.Errorf("Unknown() = %v", got)
+	}
+
+	tests := []struct {
+		values []string
+		want   []string
+	}{
+		{[]string{"电商"}, []string{"payment", "pay-gw", "电商/订单"}},
+		{[]string{"电商/支付", "payment"}, []string{"payment", "pay-gw"}},
+		{[]string{"运维", "legacy"}, []string{"运维", "legacy"}},
+	}
+	for _, tt := range tests {
+		if got := tree.Expand(tt.values); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expand(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestNewHostGroupStats_CMDBKeys(t *testing.T) {
+	hosts := []*HostResult{
+		{Hostname: "web-1", Owner: "alice", Application: "shop", Location: "IDC-A"},
+		{Hostname: "web-2", Owner: "alice", Application: "shop"},
+		{Hostname: "db-1", Owner: "bob", Application: "order", Location: "IDC-B"},
+	}
+
+	for _, tt := range []struct {
+		key  HostGroupKey
+		want []string
+	}{
+		{HostGroupOwner, []string{"alice", "bob"}},
+		{HostGroupApplication, []string{"order", "shop"}},
+		{HostGroupLocation, []string{"IDC-A", "IDC-B", UngroupedBusinessGroup}},
+	} {
+		var names []string
+		for _, s := range NewHostGroupStats(hosts, tt.key) {
+			names = append(names, s.Name)
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("NewHostGroupStats(%s) groups = %v, want %v", tt.key, names, tt.want)
+		}
+	}
+
+	if stats := NewHostGroupStats(hosts, HostGroupBusinessGroup); stats != nil {
+		t.Errorf("expected nil without business groups, got %d groups", len(stats))
+	}
+}
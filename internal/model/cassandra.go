@@ -0,0 +1,398 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Cassandra 节点状态枚举
+// =============================================================================
+
+type CassandraInstanceStatus string
+
+const (
+	CassandraStatusNormal   CassandraInstanceStatus = "normal"
+	CassandraStatusWarning  CassandraInstanceStatus = "warning"
+	CassandraStatusCritical CassandraInstanceStatus = "critical"
+	CassandraStatusFailed   CassandraInstanceStatus = "failed"
+)
+
+func (s CassandraInstanceStatus) IsHealthy() bool {
+	return s == CassandraStatusNormal
+}
+
+func (s CassandraInstanceStatus) IsWarning() bool {
+	return s == CassandraStatusWarning
+}
+
+func (s CassandraInstanceStatus) IsCritical() bool {
+	return s == CassandraStatusCritical
+}
+
+func (s CassandraInstanceStatus) IsFailed() bool {
+	return s == CassandraStatusFailed
+}
+
+// Cassandra gossip node states as shown by "nodetool status".
+const (
+	CassandraNodeStateUp   = "UN" // Up / Normal
+	CassandraNodeStateDown = "DN" // Down / Normal
+)
+
+// =============================================================================
+// Cassandra 节点结构体
+// =============================================================================
+
+type CassandraInstance struct {
+	Identifier  string `json:"identifier"`
+	Hostname    string `json:"hostname"`
+	IP          string `json:"ip"`
+	Port        int    `json:"port"`
+	ClusterName string `json:"cluster_name"`
+	Datacenter  string `json:"datacenter"`
+	Rack        string `json:"rack"`
+	Version     string `json:"version"`
+}
+
+func GenerateCassandraIdentifier(hostname string, port int) string {
+	return fmt.Sprintf("%s:%d", hostname, port)
+}
+
+func NewCassandraInstance(hostname string, port int) *CassandraInstance {
+	return &CassandraInstance{
+		Identifier: GenerateCassandraIdentifier(hostname, port),
+		Hostname:   hostname,
+		Port:       port,
+	}
+}
+
+func (i *CassandraInstance) SetIP(ip string) {
+	if i == nil {
+		return
+	}
+	i.IP = ip
+}
+
+func (i *CassandraInstance) SetClusterName(name string) {
+	if i == nil {
+		return
+	}
+	i.ClusterName = name
+}
+
+func (i *CassandraInstance) SetDatacenter(dc string) {
+	if i == nil {
+		return
+	}
+	i.Datacenter = dc
+}
+
+func (i *CassandraInstance) SetRack(rack string) {
+	if i == nil {
+		return
+	}
+	i.Rack = rack
+}
+
+func (i *CassandraInstance) SetVersion(version string) {
+	if i == nil {
+		return
+	}
+	i.Version = version
+}
+
+func (i *CassandraInstance) String() string {
+	if i == nil {
+		return "CassandraInstance(nil)"
+	}
+	return fmt.Sprintf("CassandraInstance(%s:%d)", i.Hostname, i.Port)
+}
+
+// =============================================================================
+// Cassandra 告警结构体
+// =============================================================================
+
+type CassandraAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewCassandraAlert(identifier, metricName string, currentValue float64, level AlertLevel) *CassandraAlert {
+	return &CassandraAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *CassandraAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *CassandraAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// Cassandra 指标值结构体
+// =============================================================================
+
+type CassandraMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// Cassandra 巡检结果结构体
+// =============================================================================
+
+type CassandraInspectionResult struct {
+	Instance           *CassandraInstance               `json:"instance"`
+	Up                 bool                             `json:"up"`                  // 节点状态（true=UN, false=DN）
+	DownEndpoints      int                              `json:"down_endpoints"`      // 本节点视角下处于 DN 状态的节点数（-1 表示未采集）
+	PendingCompactions float64                          `json:"pending_compactions"` // 待执行 Compaction 任务数（-1 表示未采集）
+	DroppedMutations   float64                          `json:"dropped_mutations"`   // 近 5 分钟丢弃的 Mutation 数（-1 表示未采集）
+	HeapUsagePercent   float64                          `json:"heap_usage_percent"`  // JVM 堆内存使用率（-1 表示未采集）
+	HintsBacklog       float64                          `json:"hints_backlog"`       // 正在写入的 hints 数（-1 表示未采集）
+	Metrics            map[string]*CassandraMetricValue `json:"-"`                   // 指标映射（内部使用，不序列化）
+	Status             CassandraInstanceStatus          `json:"status"`
+	Alerts             []*CassandraAlert                `json:"alerts,omitempty"`
+	CollectedAt        time.Time                        `json:"collected_at"`
+	Error              string                           `json:"error,omitempty"`
+}
+
+func NewCassandraInspectionResult(instance *CassandraInstance) *CassandraInspectionResult {
+	return &CassandraInspectionResult{
+		Instance:           instance,
+		Status:             CassandraStatusNormal,
+		Alerts:             make([]*CassandraAlert, 0),
+		DownEndpoints:      -1,
+		PendingCompactions: -1,
+		DroppedMutations:   -1,
+		HeapUsagePercent:   -1,
+		HintsBacklog:       -1,
+	}
+}
+
+func (r *CassandraInspectionResult) AddAlert(alert *CassandraAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *CassandraInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *CassandraInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// NodeState returns the nodetool-style state of the node ("UN" or "DN").
+func (r *CassandraInspectionResult) NodeState() string {
+	if r != nil && r.Up {
+		return CassandraNodeStateUp
+	}
+	return CassandraNodeStateDown
+}
+
+// HasDownEndpoints returns true if the failure detector down endpoint count was collected.
+func (r *CassandraInspectionResult) HasDownEndpoints() bool {
+	return r != nil && r.DownEndpoints >= 0
+}
+
+// HasPendingCompactions returns true if the pending compaction count was collected.
+func (r *CassandraInspectionResult) HasPendingCompactions() bool {
+	return r != nil && r.PendingCompactions >= 0
+}
+
+// HasDroppedMutations returns true if the dropped mutation count was collected.
+func (r *CassandraInspectionResult) HasDroppedMutations() bool {
+	return r != nil && r.DroppedMutations >= 0
+}
+
+// HasHeapUsage returns true if the JVM heap usage was collected.
+func (r *CassandraInspectionResult) HasHeapUsage() bool {
+	return r != nil && r.HeapUsagePercent >= 0
+}
+
+// HasHintsBacklog returns true if the hinted handoff backlog was collected.
+func (r *CassandraInspectionResult) HasHintsBacklog() bool {
+	return r != nil && r.HintsBacklog >= 0
+}
+
+func (r *CassandraInspectionResult) SetMetric(mv *CassandraMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*CassandraMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *CassandraInspectionResult) GetMetric(name string) *CassandraMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// Cassandra 巡检摘要结构体
+// =============================================================================
+
+type CassandraInspectionSummary struct {
+	TotalInstances    int `json:"total_instances"`
+	NormalInstances   int `json:"normal_instances"`
+	WarningInstances  int `json:"warning_instances"`
+	CriticalInstances int `json:"critical_instances"`
+	FailedInstances   int `json:"failed_instances"`
+	DownNodes         int `json:"down_nodes"` // 处于 DN 状态的节点数
+}
+
+func NewCassandraInspectionSummary(results []*CassandraInspectionResult) *CassandraInspectionSummary {
+	summary := &CassandraInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case CassandraStatusNormal:
+			summary.NormalInstances++
+		case CassandraStatusWarning:
+			summary.WarningInstances++
+		case CassandraStatusCritical:
+			summary.CriticalInstances++
+		case CassandraStatusFailed:
+			summary.FailedInstances++
+		}
+
+		if !result.Up {
+			summary.DownNodes++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// Cassandra 告警摘要结构体
+// =============================================================================
+
+type CassandraAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewCassandraAlertSummary(alerts []*CassandraAlert) *CassandraAlertSummary {
+	summary := &CassandraAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// Cassandra 完整巡检结果容器
+// =============================================================================
+
+type CassandraInspectionResults struct {
+	InspectionTime time.Time                    `json:"inspection_time"`
+	Duration       time.Duration                `json:"duration"`
+	Summary        *CassandraInspectionSummary  `json:"summary"`
+	Results        []*CassandraInspectionResult `json:"results"`
+	Alerts         []*CassandraAlert            `json:"alerts"`
+	AlertSummary   *CassandraAlertSummary       `json:"alert_summary"`
+	Version        string                       `json:"version,omitempty"`
+}
+
+func NewCassandraInspectionResults(inspectionTime time.Time) *CassandraInspectionResults {
+	return &CassandraInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*CassandraInspectionResult, 0),
+		Alerts:         make([]*CassandraAlert, 0),
+	}
+}
+
+func (r *CassandraInspectionResults) AddResult(result *CassandraInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *CassandraInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewCassandraInspectionSummary(r.Results)
+	r.AlertSummary = NewCassandraAlertSummary(r.Alerts)
+}
+
+func (r *CassandraInspectionResults) GetResultByIdentifier(identifier string) *CassandraInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *CassandraInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *CassandraInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *CassandraInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
@@ -0,0 +1,518 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// 云资源状态枚举
+// =============================================================================
+
+type CloudInstanceStatus string
+
+const (
+	CloudStatusNormal   CloudInstanceStatus = "normal"
+	CloudStatusWarning  CloudInstanceStatus = "warning"
+	CloudStatusCritical CloudInstanceStatus = "critical"
+	CloudStatusFailed   CloudInstanceStatus = "failed"
+)
+
+func (s CloudInstanceStatus) IsHealthy() bool {
+	return s == CloudStatusNormal
+}
+
+func (s CloudInstanceStatus) IsWarning() bool {
+	return s == CloudStatusWarning
+}
+
+func (s CloudInstanceStatus) IsCritical() bool {
+	return s == CloudStatusCritical
+}
+
+func (s CloudInstanceStatus) IsFailed() bool {
+	return s == CloudStatusFailed
+}
+
+// =============================================================================
+// 云厂商与资源类型
+// =============================================================================
+
+// Cloud providers.
+const (
+	CloudProviderAliyun = "aliyun"
+	CloudProviderAWS    = "aws"
+)
+
+// Cloud resource types.
+const (
+	CloudResourceTypeRDS   = "rds"
+	CloudResourceTypeRedis = "redis"
+	CloudResourceTypeSLB   = "slb"
+)
+
+// CloudProviderText returns the display text of a cloud provider.
+func CloudProviderText(provider string) string {
+	switch provider {
+	case CloudProviderAliyun:
+		return "阿里云"
+	case CloudProviderAWS:
+		return "AWS"
+	default:
+		return provider
+	}
+}
+
+// CloudResourceTypeText returns the display text of a cloud resource type.
+func CloudResourceTypeText(resourceType string) string {
+	switch resourceType {
+	case CloudResourceTypeRDS:
+		return "RDS"
+	case CloudResourceTypeRedis:
+		return "Redis"
+	case CloudResourceTypeSLB:
+		return "SLB"
+	default:
+		return resourceType
+	}
+}
+
+// =============================================================================
+// 云资源结构体
+// =============================================================================
+
+// CloudResource represents a managed cloud resource (RDS, Redis or SLB instance).
+// Resources are identified by their cloud resource ID.
+type CloudResource struct {
+	Identifier   string `json:"identifier"`
+	Provider     string `json:"provider"`      // 云厂商（aliyun、aws）
+	ResourceType string `json:"resource_type"` // 资源类型（rds、redis、slb）
+	ResourceID   string `json:"resource_id"`   // 云资源 ID（如 rm-bp1xxx、prod-db-01）
+	Name         string `json:"name"`          // 资源名称（未上报时为空）
+	Region       string `json:"region"`        // 地域（未上报时为空）
+	Spec         string `json:"spec"`          // 实例规格（如 rds.mysql.s2.large，未上报时为空）
+}
+
+func NewCloudResource(provider, resourceType, resourceID string) *CloudResource {
+	return &CloudResource{
+		Identifier:   resourceID,
+		Provider:     strings.ToLower(provider),
+		ResourceType: strings.ToLower(resourceType),
+		ResourceID:   resourceID,
+	}
+}
+
+// Key returns the unique key of the resource across providers and resource types.
+func (r *CloudResource) Key() string {
+	if r == nil {
+		return ""
+	}
+	return CloudResourceKey(r.Provider, r.ResourceType, r.ResourceID)
+}
+
+// CloudResourceKey builds the unique key of a cloud resource.
+func CloudResourceKey(provider, resourceType, resourceID string) string {
+	return strings.ToLower(provider) + "/" + strings.ToLower(resourceType) + "/" + resourceID
+}
+
+// DisplayName returns the resource name, falling back to the resource ID.
+func (r *CloudResource) DisplayName() string {
+	if r == nil {
+		return ""
+	}
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.ResourceID
+}
+
+func (r *CloudResource) String() string {
+	if r == nil {
+		return "CloudResource(nil)"
+	}
+	return fmt.Sprintf("CloudResource(%s)", r.Key())
+}
+
+// =============================================================================
+// 云资源告警结构体
+// =============================================================================
+
+type CloudAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewCloudAlert(identifier, metricName string, currentValue float64, level AlertLevel) *CloudAlert {
+	return &CloudAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *CloudAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *CloudAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// 云资源指标值结构体
+// =============================================================================
+
+type CloudMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// 云资源巡检结果结构体
+// =============================================================================
+
+type CloudInspectionResult struct {
+	Instance          *CloudResource               `json:"instance"`
+	CPUUsage          float64                      `json:"cpu_usage"`          // CPU 使用率（%，-1 表示未采集）
+	MemoryUsage       float64                      `json:"memory_usage"`       // 内存使用率（%，-1 表示未采集）
+	ConnectionUsage   float64                      `json:"connection_usage"`   // 连接数使用率（%，-1 表示未采集）
+	DiskUsage         float64                      `json:"disk_usage"`         // 存储空间使用率（%，-1 表示未采集）
+	ActiveConnections float64                      `json:"active_connections"` // 活跃连接数（-1 表示未采集）
+	UnhealthyBackends float64                      `json:"unhealthy_backends"` // 不健康后端服务器数（仅 SLB，-1 表示未采集）
+	PeakCPUUsage      float64                      `json:"peak_cpu_usage"`     // 成本统计窗口内 CPU 峰值（%，-1 表示未采集）
+	Metrics           map[string]*CloudMetricValue `json:"-"`                  // 指标映射（按归一化字段，内部使用，不序列化）
+	Status            CloudInstanceStatus          `json:"status"`
+	Alerts            []*CloudAlert                `json:"alerts,omitempty"`
+	CollectedAt       time.Time                    `json:"collected_at"`
+	Error             string                       `json:"error,omitempty"`
+}
+
+func NewCloudInspectionResult(instance *CloudResource) *CloudInspectionResult {
+	return &CloudInspectionResult{
+		Instance:          instance,
+		Status:            CloudStatusNormal,
+		Alerts:            make([]*CloudAlert, 0),
+		CPUUsage:          -1,
+		MemoryUsage:       -1,
+		ConnectionUsage:   -1,
+		DiskUsage:         -1,
+		ActiveConnections: -1,
+		UnhealthyBackends: -1,
+		PeakCPUUsage:      -1,
+	}
+}
+
+func (r *CloudInspectionResult) AddAlert(alert *CloudAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *CloudInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *CloudInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// HasCPUUsage returns true if the CPU usage was collected.
+func (r *CloudInspectionResult) HasCPUUsage() bool {
+	return r != nil && r.CPUUsage >= 0
+}
+
+// HasMemoryUsage returns true if the memory usage was collected.
+func (r *CloudInspectionResult) HasMemoryUsage() bool {
+	return r != nil && r.MemoryUsage >= 0
+}
+
+// HasConnectionUsage returns true if the connection usage was collected.
+func (r *CloudInspectionResult) HasConnectionUsage() bool {
+	return r != nil && r.ConnectionUsage >= 0
+}
+
+// HasDiskUsage returns true if the disk usage was collected.
+func (r *CloudInspectionResult) HasDiskUsage() bool {
+	return r != nil && r.DiskUsage >= 0
+}
+
+// HasActiveConnections returns true if the active connection count was collected.
+func (r *CloudInspectionResult) HasActiveConnections() bool {
+	return r != nil && r.ActiveConnections >= 0
+}
+
+// HasPeakCPUUsage returns true if the CPU peak over the cost summary window was collected.
+func (r *CloudInspectionResult) HasPeakCPUUsage() bool {
+	return r != nil && r.PeakCPUUsage >= 0
+}
+
+// HasUnhealthyBackends returns true if at least one SLB backend server is unhealthy.
+func (r *CloudInspectionResult) HasUnhealthyBackends() bool {
+	return r != nil && r.UnhealthyBackends > 0
+}
+
+func (r *CloudInspectionResult) SetMetric(mv *CloudMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*CloudMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *CloudInspectionResult) GetMetric(name string) *CloudMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// 云资源巡检摘要结构体
+// =============================================================================
+
+type CloudInspectionSummary struct {
+	TotalInstances    int `json:"total_instances"`
+	NormalInstances   int `json:"normal_instances"`
+	WarningInstances  int `json:"warning_instances"`
+	CriticalInstances int `json:"critical_instances"`
+	FailedInstances   int `json:"failed_instances"`
+	RDSInstances      int `json:"rds_instances"`   // RDS 实例数
+	RedisInstances    int `json:"redis_instances"` // Redis 实例数
+	SLBInstances      int `json:"slb_instances"`   // SLB 实例数
+}
+
+func NewCloudInspectionSummary(results []*CloudInspectionResult) *CloudInspectionSummary {
+	summary := &CloudInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case CloudStatusNormal:
+			summary.NormalInstances++
+		case CloudStatusWarning:
+			summary.WarningInstances++
+		case CloudStatusCritical:
+			summary.CriticalInstances++
+		case CloudStatusFailed:
+			summary.FailedInstances++
+		}
+
+		if result.Instance == nil {
+			continue
+		}
+		switch result.Instance.ResourceType {
+		case CloudResourceTypeRDS:
+			summary.RDSInstances++
+		case CloudResourceTypeRedis:
+			summary.RedisInstances++
+		case CloudResourceTypeSLB:
+			summary.SLBInstances++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 云资源告警摘要结构体
+// =============================================================================
+
+type CloudAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewCloudAlertSummary(alerts []*CloudAlert) *CloudAlertSummary {
+	summary := &CloudAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 云资源成本 / 资产汇总结构体
+// =============================================================================
+
+// CloudAssetGroup counts the resources of one provider, resource type and instance spec.
+type CloudAssetGroup struct {
+	Provider      string `json:"provider"`
+	ResourceType  string `json:"resource_type"`
+	Spec          string `json:"spec"` // 实例规格（未上报时为空）
+	Count         int    `json:"count"`
+	Underutilized int    `json:"underutilized"` // 低利用率资源数
+}
+
+// CloudCostSummary is the cost / asset summary of the inspected cloud resources.
+// A resource is under-utilized when its CPU peak over Window stays below IdleCPUThreshold;
+// resources without a collected CPU peak (e.g. SLB) are never flagged.
+type CloudCostSummary struct {
+	Window           time.Duration            `json:"window"`
+	IdleCPUThreshold float64                  `json:"idle_cpu_threshold"`
+	Groups           []*CloudAssetGroup       `json:"groups"`
+	Underutilized    []*CloudInspectionResult `json:"-"` // 低利用率资源（按 CPU 峰值升序）
+}
+
+// NewCloudCostSummary groups the results by provider, resource type and spec,
+// and collects the under-utilized resources.
+func NewCloudCostSummary(results []*CloudInspectionResult, window time.Duration, idleCPUThreshold float64) *CloudCostSummary {
+	summary := &CloudCostSummary{
+		Window:           window,
+		IdleCPUThreshold: idleCPUThreshold,
+		Groups:           make([]*CloudAssetGroup, 0),
+		Underutilized:    make([]*CloudInspectionResult, 0),
+	}
+
+	groups := make(map[string]*CloudAssetGroup)
+	for _, result := range results {
+		if result == nil || result.Instance == nil {
+			continue
+		}
+
+		r := result.Instance
+		key := r.Provider + "/" + r.ResourceType + "/" + r.Spec
+		group, ok := groups[key]
+		if !ok {
+			group = &CloudAssetGroup{Provider: r.Provider, ResourceType: r.ResourceType, Spec: r.Spec}
+			groups[key] = group
+			summary.Groups = append(summary.Groups, group)
+		}
+		group.Count++
+
+		if result.IsUnderutilized(idleCPUThreshold) {
+			group.Underutilized++
+			summary.Underutilized = append(summary.Underutilized, result)
+		}
+	}
+
+	sort.Slice(summary.Groups, func(i, j int) bool {
+		a, b := summary.Groups[i], summary.Groups[j]
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		return a.Spec < b.Spec
+	})
+	sort.SliceStable(summary.Underutilized, func(i, j int) bool {
+		return summary.Underutilized[i].PeakCPUUsage < summary.Underutilized[j].PeakCPUUsage
+	})
+
+	return summary
+}
+
+// IsUnderutilized returns true if the CPU peak over the cost summary window was
+// collected and stays below threshold.
+func (r *CloudInspectionResult) IsUnderutilized(threshold float64) bool {
+	return r.HasPeakCPUUsage() && r.PeakCPUUsage < threshold
+}
+
+// =============================================================================
+// 云资源完整巡检结果容器
+// =============================================================================
+
+type CloudInspectionResults struct {
+	InspectionTime time.Time                `json:"inspection_time"`
+	Duration       time.Duration            `json:"duration"`
+	Summary        *CloudInspectionSummary  `json:"summary"`
+	Results        []*CloudInspectionResult `json:"results"`
+	Alerts         []*CloudAlert            `json:"alerts"`
+	AlertSummary   *CloudAlertSummary       `json:"alert_summary"`
+	CostSummary    *CloudCostSummary        `json:"cost_summary,omitempty"` // 成本 / 资产汇总（未启用时为 nil）
+	Version        string                   `json:"version,omitempty"`
+}
+
+func NewCloudInspectionResults(inspectionTime time.Time) *CloudInspectionResults {
+	return &CloudInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*CloudInspectionResult, 0),
+		Alerts:         make([]*CloudAlert, 0),
+	}
+}
+
+func (r *CloudInspectionResults) AddResult(result *CloudInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *CloudInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewCloudInspectionSummary(r.Results)
+	r.AlertSummary = NewCloudAlertSummary(r.Alerts)
+}
+
+func (r *CloudInspectionResults) GetResultByIdentifier(identifier string) *CloudInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *CloudInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *CloudInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *CloudInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
@@ -0,0 +1,44 @@
+package model
+
+// CloudMetricDefinition defines a cloud resource metric to be collected.
+// Maps to YAML in configs/cloud-metrics.yaml.
+type CloudMetricDefinition struct {
+	Name         string   `yaml:"name" json:"name"`
+	DisplayName  string   `yaml:"display_name" json:"display_name"`
+	Query        string   `yaml:"query" json:"query"`
+	Category     string   `yaml:"category" json:"category"`
+	Provider     string   `yaml:"provider" json:"provider"`           // 云厂商（aliyun、aws）
+	ResourceType string   `yaml:"resource_type" json:"resource_type"` // 资源类型（rds、redis、slb）
+	Field        string   `yaml:"field" json:"field"`                 // 归一化字段（cpu_usage、memory_usage 等）
+	IDLabel      string   `yaml:"id_label" json:"id_label"`           // 资源 ID 所在标签
+	NameLabel    string   `yaml:"name_label" json:"name_label"`       // 资源名称所在标签（可选）
+	RegionLabel  string   `yaml:"region_label" json:"region_label"`   // 地域所在标签（可选）
+	SpecLabel    string   `yaml:"spec_label" json:"spec_label"`       // 实例规格所在标签（可选）
+	LabelExtract []string `yaml:"label_extract" json:"label_extract"` // 从标签提取的字段
+	Format       string   `yaml:"format" json:"format"`
+	Status       string   `yaml:"status" json:"status"` // pending=待实现
+	Note         string   `yaml:"note" json:"note"`
+}
+
+// IsPending 判断指标是否待实现
+func (m *CloudMetricDefinition) IsPending() bool {
+	return m.Status == "pending" || m.Query == ""
+}
+
+// HasLabelExtract 判断是否需要从标签提取值
+func (m *CloudMetricDefinition) HasLabelExtract() bool {
+	return len(m.LabelExtract) > 0
+}
+
+// GetDisplayName 获取指标显示名称
+func (m *CloudMetricDefinition) GetDisplayName() string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return m.Name
+}
+
+// CloudMetricsConfig represents the root structure of cloud-metrics.yaml.
+type CloudMetricsConfig struct {
+	Metrics []*CloudMetricDefinition `yaml:"cloud_metrics" json:"cloud_metrics"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// NewCloudCostSummary Tests
+// ============================================================================
+
+func TestNewCloudCostSummary(t *testing.T) {
+	newResult := func(provider, resourceType, id, spec string, peak float64) *CloudInspectionResult {
+		resource := NewCloudResource(provider, resourceType, id)
+		resource.Spec = spec
+		result := NewCloudInspectionResult(resource)
+		result.PeakCPUUsage = peak
+		return result
+	}
+
+	results := []*CloudInspectionResult{
+		newResult(CloudProviderAliyun, CloudResourceTypeRDS, "rm-1", "rds.mysql.s2.large", 3),
+		newResult(CloudProviderAliyun, CloudResourceTypeRDS, "rm-2", "rds.mysql.s2.large", 40),
+		newResult(CloudProviderAliyun, CloudResourceTypeRDS, "rm-3", "rds.mysql.s2.large", 1.5),
+		newResult(CloudProviderAWS, CloudResourceTypeRDS, "prod-db", "db.r5.large", 5),
+		newResult(CloudProviderAliyun, CloudResourceTypeSLB, "lb-1", "", -1), // No CPU metric
+	}
+
+	summary := NewCloudCostSummary(results, 720*time.Hour, 5)
+
+	if len(summary.Groups) != 3 {
+		t.Fatalf("expected 3 asset groups, got %d", len(summary.Groups))
+	}
+	first := summary.Groups[0]
+	if first.Provider != CloudProviderAliyun || first.ResourceType != CloudResourceTypeRDS || first.Count != 3 || first.Underutilized != 2 {
+		t.Errorf("unexpected first group: %+v", first)
+	}
+	if summary.Groups[1].ResourceType != CloudResourceTypeSLB || summary.Groups[1].Underutilized != 0 {
+		t.Errorf("SLB without CPU peak should not be flagged: %+v", summary.Groups[1])
+	}
+
+	// Peak equal to the threshold is not under-utilized; sorted by peak ascending
+	if len(summary.Underutilized) != 2 {
+		t.Fatalf("expected 2 under-utilized resources, got %d", len(summary.Underutilized))
+	}
+	if summary.Underutilized[0].Instance.ResourceID != "rm-3" || summary.Underutilized[1].Instance.ResourceID != "rm-1" {
+		t.Errorf("unexpected under-utilized order: %s, %s",
+			summary.Underutilized[0].Instance.ResourceID, summary.Underutilized[1].Instance.ResourceID)
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+// CMDBRecord is the CMDB entry of a host, matched to the inspected hosts by hostname or IP.
+type CMDBRecord struct {
+	Hostname    string `json:"hostname,omitempty"`    // 主机名
+	IP          string `json:"ip,omitempty"`          // IP 地址
+	Owner       string `json:"owner,omitempty"`       // 负责人
+	Application string `json:"application,omitempty"` // 所属应用
+	Location    string `json:"location,omitempty"`    // 机房 / 机柜位置
+	Maintenance bool   `json:"maintenance,omitempty"` // 维护中
+}
+
+// ApplyCMDB copies the CMDB attributes of record to the host.
+func (m *HostMeta) ApplyCMDB(record *CMDBRecord) {
+	m.Owner = record.Owner
+	m.Application = record.Application
+	m.Location = record.Location
+	m.Maintenance = record.Maintenance
+}
+
+// HasCMDB reports whether the host carries CMDB attributes.
+func (r *HostResult) HasCMDB() bool {
+	return r.Owner != "" || r.Application != "" || r.Location != "" || r.Maintenance
+}
+
+// AnyHostHasCMDB reports whether at least one host carries CMDB attributes, in which case the
+// reports show the CMDB columns.
+func AnyHostHasCMDB(hosts []*HostResult) bool {
+	for _, host := range hosts {
+		if host != nil && host.HasCMDB() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// AlertChangeType classifies how an alert changed between two inspection runs.
+type AlertChangeType string
+
+const (
+	AlertChangeNew        AlertChangeType = "new"        // 新增
+	AlertChangeResolved   AlertChangeType = "resolved"   // 已恢复
+	AlertChangeEscalated  AlertChangeType = "escalated"  // 升级（警告 → 严重）
+	AlertChangeDowngraded AlertChangeType = "downgraded" // 降级（严重 → 警告）
+)
+
+// ReportDiff is the comparison of two inspection runs: the alerts that appeared, were
+// resolved or changed level, and the host metric values that changed.
+type ReportDiff struct {
+	OldTime      time.Time      `json:"old_time"`      // 旧巡检时间
+	NewTime      time.Time      `json:"new_time"`      // 新巡检时间
+	Alerts       []*AlertChange `json:"alerts"`        // 告警变化（新增、恢复、级别变化）
+	MetricDeltas []*MetricDelta `json:"metric_deltas"` // 主机指标变化
+	AddedHosts   []string       `json:"added_hosts"`   // 仅在新巡检中出现的主机
+	RemovedHosts []string       `json:"removed_hosts"` // 仅在旧巡检中出现的主机
+}
+
+// AlertChange is an alert that differs between two inspection runs. The level and value
+// of the run without the alert are empty.
+type AlertChange struct {
+	Type              AlertChangeType `json:"type"`
+	Module            string          `json:"module"`     // 模块名称（主机、MySQL 等）
+	Identifier        string          `json:"identifier"` // 巡检对象
+	MetricName        string          `json:"metric_name"`
+	MetricDisplayName string          `json:"metric_display_name"`
+	OldLevel          AlertLevel      `json:"old_level,omitempty"`
+	NewLevel          AlertLevel      `json:"new_level,omitempty"`
+	OldValue          string          `json:"old_value,omitempty"` // 旧格式化值
+	NewValue          string          `json:"new_value,omitempty"` // 新格式化值
+	Message           string          `json:"message"`             // 最近一次的告警信息
+}
+
+// MetricDelta is a host metric whose value changed between two inspection runs.
+type MetricDelta struct {
+	Hostname     string  `json:"hostname"`
+	MetricName   string  `json:"metric_name"`
+	OldValue     float64 `json:"old_value"`
+	NewValue     float64 `json:"new_value"`
+	OldFormatted string  `json:"old_formatted"`
+	NewFormatted string  `json:"new_formatted"`
+}
+
+// Delta returns the change of the metric value (new - old).
+func (d *MetricDelta) Delta() float64 {
+	return d.NewValue - d.OldValue
+}
+
+// CountAlerts returns the number of alert changes of type t.
+func (d *ReportDiff) CountAlerts(t AlertChangeType) int {
+	count := 0
+	for _, a := range d.Alerts {
+		if a.Type == t {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,31 @@
+package model
+
+// ExecutiveSummaryTopRisks is the number of risks listed in the executive summary.
+const ExecutiveSummaryTopRisks = 10
+
+// ExecutiveSummary is the management overview of an inspection run: the alert totals and the
+// most severe risks across all modules, so the detail sheets need not be read.
+type ExecutiveSummary struct {
+	CriticalAlerts  int              `json:"critical_alerts"`  // 严重告警数
+	WarningAlerts   int              `json:"warning_alerts"`   // 警告告警数
+	AffectedSystems int              `json:"affected_systems"` // 存在告警的巡检对象数
+	Risks           []*ExecutiveRisk `json:"risks"`            // 主要风险，按严重程度排序
+}
+
+// ExecutiveRisk is one risk of the executive summary: the alerts of one metric in one module,
+// with the systems they affect and a one-line remediation hint.
+type ExecutiveRisk struct {
+	Module            string     `json:"module"` // 模块名称（主机、MySQL 等）
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	Level             AlertLevel `json:"level"`          // 最高告警级别
+	CriticalCount     int        `json:"critical_count"` // 严重告警数
+	WarningCount      int        `json:"warning_count"`  // 警告告警数
+	Systems           []string   `json:"systems"`        // 受影响的巡检对象，按名称排序
+	Remediation       string     `json:"remediation"`    // 处理建议
+}
+
+// HasRisks reports whether the run raised any alert.
+func (s *ExecutiveSummary) HasRisks() bool {
+	return s != nil && len(s.Risks) > 0
+}
@@ -0,0 +1,454 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// 防火墙设备状态枚举
+// =============================================================================
+
+type FirewallDeviceStatus string
+
+const (
+	FirewallStatusNormal   FirewallDeviceStatus = "normal"
+	FirewallStatusWarning  FirewallDeviceStatus = "warning"
+	FirewallStatusCritical FirewallDeviceStatus = "critical"
+	FirewallStatusFailed   FirewallDeviceStatus = "failed"
+)
+
+func (s FirewallDeviceStatus) IsHealthy() bool {
+	return s == FirewallStatusNormal
+}
+
+func (s FirewallDeviceStatus) IsWarning() bool {
+	return s == FirewallStatusWarning
+}
+
+func (s FirewallDeviceStatus) IsCritical() bool {
+	return s == FirewallStatusCritical
+}
+
+func (s FirewallDeviceStatus) IsFailed() bool {
+	return s == FirewallStatusFailed
+}
+
+// =============================================================================
+// 设备 profile
+// =============================================================================
+
+// Built-in firewall device profiles of configs/firewall-metrics.yaml.
+const (
+	FirewallProfileFortiGate = "fortigate"
+	FirewallProfilePaloAlto  = "paloalto"
+	FirewallProfileCiscoASA  = "cisco_asa"
+)
+
+// FirewallProfileText returns the display text of a device profile.
+// Profiles added in the metric definitions are shown as configured.
+func FirewallProfileText(profile string) string {
+	switch profile {
+	case FirewallProfileFortiGate:
+		return "FortiGate"
+	case FirewallProfilePaloAlto:
+		return "Palo Alto"
+	case FirewallProfileCiscoASA:
+		return "Cisco ASA"
+	default:
+		return profile
+	}
+}
+
+// =============================================================================
+// 防火墙设备结构体
+// =============================================================================
+
+// FirewallDevice represents a firewall / VPN appliance polled by snmp_exporter.
+// Devices are identified by profile and device address.
+type FirewallDevice struct {
+	Identifier string `json:"identifier"`
+	Profile    string `json:"profile"` // 设备 profile（fortigate、paloalto、cisco_asa）
+	Address    string `json:"address"` // 设备地址（snmp_exporter 的 instance 标签）
+	Name       string `json:"name"`    // 设备名称（如 sysName，未上报时为空）
+}
+
+func NewFirewallDevice(profile, address string) *FirewallDevice {
+	return &FirewallDevice{
+		Identifier: address,
+		Profile:    strings.ToLower(profile),
+		Address:    address,
+	}
+}
+
+// Key returns the unique key of the device across profiles.
+func (d *FirewallDevice) Key() string {
+	if d == nil {
+		return ""
+	}
+	return FirewallDeviceKey(d.Profile, d.Address)
+}
+
+// FirewallDeviceKey builds the unique key of a firewall device.
+func FirewallDeviceKey(profile, address string) string {
+	return strings.ToLower(profile) + "/" + address
+}
+
+// DisplayName returns the device name, falling back to the device address.
+func (d *FirewallDevice) DisplayName() string {
+	if d == nil {
+		return ""
+	}
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.Address
+}
+
+func (d *FirewallDevice) String() string {
+	if d == nil {
+		return "FirewallDevice(nil)"
+	}
+	return fmt.Sprintf("FirewallDevice(%s)", d.Key())
+}
+
+// =============================================================================
+// VPN 隧道结构体
+// =============================================================================
+
+// FirewallTunnel represents a single site-to-site VPN tunnel of a device.
+type FirewallTunnel struct {
+	Name string `json:"name"`
+	Up   bool   `json:"up"`
+}
+
+// =============================================================================
+// 防火墙设备告警结构体
+// =============================================================================
+
+type FirewallAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewFirewallAlert(identifier, metricName string, currentValue float64, level AlertLevel) *FirewallAlert {
+	return &FirewallAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *FirewallAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *FirewallAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// 防火墙设备指标值结构体
+// =============================================================================
+
+type FirewallMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// 防火墙设备巡检结果结构体
+// =============================================================================
+
+type FirewallInspectionResult struct {
+	Device       *FirewallDevice                 `json:"device"`
+	CPUUsage     float64                         `json:"cpu_usage"`     // CPU 使用率（%，-1 表示未采集）
+	MemoryUsage  float64                         `json:"memory_usage"`  // 内存使用率（%，-1 表示未采集）
+	SessionUsage float64                         `json:"session_usage"` // 会话表使用率（%，-1 表示未采集）
+	Sessions     float64                         `json:"sessions"`      // 当前会话数（-1 表示未采集）
+	HAStatus     float64                         `json:"ha_status"`     // HA 状态（1=正常，0=异常，-1 表示未采集）
+	HAState      string                          `json:"ha_state"`      // HA 状态原文（如 active、passive，未上报时为空）
+	Tunnels      []*FirewallTunnel               `json:"tunnels,omitempty"`
+	TunnelsDown  int                             `json:"tunnels_down"` // 断开的 VPN 隧道数
+	Metrics      map[string]*FirewallMetricValue `json:"-"`            // 指标映射（按归一化字段，内部使用，不序列化）
+	Status       FirewallDeviceStatus            `json:"status"`
+	Alerts       []*FirewallAlert                `json:"alerts,omitempty"`
+	CollectedAt  time.Time                       `json:"collected_at"`
+	Error        string                          `json:"error,omitempty"`
+}
+
+func NewFirewallInspectionResult(device *FirewallDevice) *FirewallInspectionResult {
+	return &FirewallInspectionResult{
+		Device:       device,
+		Status:       FirewallStatusNormal,
+		Tunnels:      make([]*FirewallTunnel, 0),
+		Alerts:       make([]*FirewallAlert, 0),
+		CPUUsage:     -1,
+		MemoryUsage:  -1,
+		SessionUsage: -1,
+		Sessions:     -1,
+		HAStatus:     -1,
+	}
+}
+
+func (r *FirewallInspectionResult) AddAlert(alert *FirewallAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *FirewallInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *FirewallInspectionResult) GetIdentifier() string {
+	if r == nil || r.Device == nil {
+		return ""
+	}
+	return r.Device.Identifier
+}
+
+// AddTunnel adds a VPN tunnel to the result, counting it when down.
+func (r *FirewallInspectionResult) AddTunnel(tunnel *FirewallTunnel) {
+	if r == nil || tunnel == nil {
+		return
+	}
+	r.Tunnels = append(r.Tunnels, tunnel)
+	if !tunnel.Up {
+		r.TunnelsDown++
+	}
+}
+
+// DownTunnelNames returns the names of all down VPN tunnels.
+func (r *FirewallInspectionResult) DownTunnelNames() []string {
+	if r == nil {
+		return nil
+	}
+	var names []string
+	for _, t := range r.Tunnels {
+		if !t.Up {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// HasCPUUsage returns true if the CPU usage was collected.
+func (r *FirewallInspectionResult) HasCPUUsage() bool {
+	return r != nil && r.CPUUsage >= 0
+}
+
+// HasMemoryUsage returns true if the memory usage was collected.
+func (r *FirewallInspectionResult) HasMemoryUsage() bool {
+	return r != nil && r.MemoryUsage >= 0
+}
+
+// HasSessionUsage returns true if the session table usage was collected.
+func (r *FirewallInspectionResult) HasSessionUsage() bool {
+	return r != nil && r.SessionUsage >= 0
+}
+
+// HasSessions returns true if the session count was collected.
+func (r *FirewallInspectionResult) HasSessions() bool {
+	return r != nil && r.Sessions >= 0
+}
+
+// HasHAStatus returns true if the HA status was collected.
+func (r *FirewallInspectionResult) HasHAStatus() bool {
+	return r != nil && r.HAStatus >= 0
+}
+
+// IsHAAbnormal returns true if the HA status was collected and is abnormal.
+func (r *FirewallInspectionResult) IsHAAbnormal() bool {
+	return r.HasHAStatus() && r.HAStatus < 1
+}
+
+// HAText returns the HA status for display: the reported HA state when available,
+// otherwise 正常 / 异常, or N/A when the HA status was not collected.
+func (r *FirewallInspectionResult) HAText() string {
+	switch {
+	case !r.HasHAStatus():
+		return "N/A"
+	case r.HAState != "":
+		return r.HAState
+	case r.IsHAAbnormal():
+		return "异常"
+	default:
+		return "正常"
+	}
+}
+
+func (r *FirewallInspectionResult) SetMetric(mv *FirewallMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*FirewallMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *FirewallInspectionResult) GetMetric(name string) *FirewallMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// 防火墙设备巡检摘要结构体
+// =============================================================================
+
+type FirewallInspectionSummary struct {
+	TotalInstances    int `json:"total_instances"`
+	NormalInstances   int `json:"normal_instances"`
+	WarningInstances  int `json:"warning_instances"`
+	CriticalInstances int `json:"critical_instances"`
+	FailedInstances   int `json:"failed_instances"`
+	TunnelsDown       int `json:"tunnels_down"` // 所有设备断开的 VPN 隧道总数
+	HAAbnormal        int `json:"ha_abnormal"`  // HA 状态异常的设备数
+}
+
+func NewFirewallInspectionSummary(results []*FirewallInspectionResult) *FirewallInspectionSummary {
+	summary := &FirewallInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case FirewallStatusNormal:
+			summary.NormalInstances++
+		case FirewallStatusWarning:
+			summary.WarningInstances++
+		case FirewallStatusCritical:
+			summary.CriticalInstances++
+		case FirewallStatusFailed:
+			summary.FailedInstances++
+		}
+
+		summary.TunnelsDown += result.TunnelsDown
+		if result.IsHAAbnormal() {
+			summary.HAAbnormal++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 防火墙设备告警摘要结构体
+// =============================================================================
+
+type FirewallAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewFirewallAlertSummary(alerts []*FirewallAlert) *FirewallAlertSummary {
+	summary := &FirewallAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 防火墙设备完整巡检结果容器
+// =============================================================================
+
+type FirewallInspectionResults struct {
+	InspectionTime time.Time                   `json:"inspection_time"`
+	Duration       time.Duration               `json:"duration"`
+	Summary        *FirewallInspectionSummary  `json:"summary"`
+	Results        []*FirewallInspectionResult `json:"results"`
+	Alerts         []*FirewallAlert            `json:"alerts"`
+	AlertSummary   *FirewallAlertSummary       `json:"alert_summary"`
+	Version        string                      `json:"version,omitempty"`
+}
+
+func NewFirewallInspectionResults(inspectionTime time.Time) *FirewallInspectionResults {
+	return &FirewallInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*FirewallInspectionResult, 0),
+		Alerts:         make([]*FirewallAlert, 0),
+	}
+}
+
+func (r *FirewallInspectionResults) AddResult(result *FirewallInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *FirewallInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewFirewallInspectionSummary(r.Results)
+	r.AlertSummary = NewFirewallAlertSummary(r.Alerts)
+}
+
+func (r *FirewallInspectionResults) GetResultByIdentifier(identifier string) *FirewallInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *FirewallInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *FirewallInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *FirewallInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
@@ -0,0 +1,77 @@
+package model
+
+// Normalized firewall metric fields. Profile-specific metric definitions map onto
+// these fields so devices of all vendors are evaluated alike.
+const (
+	FirewallFieldCPUUsage        = "cpu_usage"
+	FirewallFieldMemoryUsage     = "memory_usage"
+	FirewallFieldSessionUsage    = "session_usage"
+	FirewallFieldSessions        = "sessions"
+	FirewallFieldVPNTunnelStatus = "vpn_tunnel_status"
+	FirewallFieldHAStatus        = "ha_status"
+)
+
+// FirewallFields lists the normalized fields the firewall collector handles.
+var FirewallFields = []string{
+	FirewallFieldCPUUsage,
+	FirewallFieldMemoryUsage,
+	FirewallFieldSessionUsage,
+	FirewallFieldSessions,
+	FirewallFieldVPNTunnelStatus,
+	FirewallFieldHAStatus,
+}
+
+// IsFirewallField reports whether field is a normalized firewall metric field.
+func IsFirewallField(field string) bool {
+	for _, f := range FirewallFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// FirewallMetricDefinition defines a firewall / VPN appliance metric to be collected.
+// Maps to YAML in configs/firewall-metrics.yaml.
+//
+// Definitions are grouped into device profiles: every profile maps the metrics one
+// vendor's snmp_exporter module exposes onto the same normalized fields, so new
+// vendors are supported by adding definitions without code changes.
+type FirewallMetricDefinition struct {
+	Name         string   `yaml:"name" json:"name"`
+	DisplayName  string   `yaml:"display_name" json:"display_name"`
+	Query        string   `yaml:"query" json:"query"`
+	Category     string   `yaml:"category" json:"category"`
+	Profile      string   `yaml:"profile" json:"profile"`             // 设备 profile（fortigate、paloalto、cisco_asa）
+	Field        string   `yaml:"field" json:"field"`                 // 归一化字段（cpu_usage、session_usage 等）
+	DeviceLabel  string   `yaml:"device_label" json:"device_label"`   // 设备地址所在标签（通常为 instance）
+	NameLabel    string   `yaml:"name_label" json:"name_label"`       // 设备名称所在标签（可选）
+	TunnelLabel  string   `yaml:"tunnel_label" json:"tunnel_label"`   // VPN 隧道名称所在标签（仅 vpn_tunnel_status）
+	LabelExtract []string `yaml:"label_extract" json:"label_extract"` // 从标签提取的字段
+	Format       string   `yaml:"format" json:"format"`
+	Status       string   `yaml:"status" json:"status"` // pending=待实现
+	Note         string   `yaml:"note" json:"note"`
+}
+
+// IsPending 判断指标是否待实现
+func (m *FirewallMetricDefinition) IsPending() bool {
+	return m.Status == "pending" || m.Query == ""
+}
+
+// HasLabelExtract 判断是否需要从标签提取值
+func (m *FirewallMetricDefinition) HasLabelExtract() bool {
+	return len(m.LabelExtract) > 0
+}
+
+// GetDisplayName 获取指标显示名称
+func (m *FirewallMetricDefinition) GetDisplayName() string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return m.Name
+}
+
+// FirewallMetricsConfig represents the root structure of firewall-metrics.yaml.
+type FirewallMetricsConfig struct {
+	Metrics []*FirewallMetricDefinition `yaml:"firewall_metrics" json:"firewall_metrics"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "testing"
+
+// ============================================================================
+// NewFirewallInspectionSummary Tests
+// ============================================================================
+
+func TestNewFirewallInspectionSummary(t *testing.T) {
+	withTunnels := NewFirewallInspectionResult(NewFirewallDevice(FirewallProfileFortiGate, "10.0.1.1"))
+	withTunnels.AddTunnel(&FirewallTunnel{Name: "to-branch", Up: false})
+	withTunnels.AddTunnel(&FirewallTunnel{Name: "to-dc", Up: true})
+	withTunnels.Status = FirewallStatusCritical
+
+	haAbnormal := NewFirewallInspectionResult(NewFirewallDevice(FirewallProfilePaloAlto, "10.0.3.1"))
+	haAbnormal.HAStatus = 0
+	haAbnormal.Status = FirewallStatusCritical
+
+	standalone := NewFirewallInspectionResult(NewFirewallDevice(FirewallProfileCiscoASA, "10.0.2.1")) // No HA metric
+
+	summary := NewFirewallInspectionSummary([]*FirewallInspectionResult{withTunnels, haAbnormal, standalone})
+
+	if summary.TotalInstances != 3 || summary.CriticalInstances != 2 || summary.NormalInstances != 1 {
+		t.Errorf("unexpected status counts: %+v", summary)
+	}
+	if summary.TunnelsDown != 1 {
+		t.Errorf("TunnelsDown = %d, want 1", summary.TunnelsDown)
+	}
+	if summary.HAAbnormal != 1 {
+		t.Errorf("HAAbnormal = %d, want 1 (devices without HA metric are not abnormal)", summary.HAAbnormal)
+	}
+	if names := withTunnels.DownTunnelNames(); len(names) != 1 || names[0] != "to-branch" {
+		t.Errorf("DownTunnelNames() = %v, want [to-branch]", names)
+	}
+}
+
+func TestFirewallProfileText(t *testing.T) {
+	if got := FirewallProfileText(FirewallProfileCiscoASA); got != "Cisco ASA" {
+		t.Errorf("FirewallProfileText(cisco_asa) = %q, want Cisco ASA", got)
+	}
+	if got := FirewallProfileText("hillstone"); got != "hillstone" {
+		t.Errorf("FirewallProfileText() of a custom profile = %q, want it unchanged", got)
+	}
+}
@@ -0,0 +1,117 @@
+package model
+
+import "sort"
+
+// HostGroupKey is a host attribute the reports group hosts by.
+type HostGroupKey string
+
+const (
+	HostGroupBusinessGroup HostGroupKey = "business_group" // N9E 业务组
+	HostGroupOwner         HostGroupKey = "owner"          // 负责人（CMDB）
+	HostGroupApplication   HostGroupKey = "application"    // 所属应用（CMDB）
+	HostGroupLocation      HostGroupKey = "location"       // 机房 / 机柜位置（CMDB）
+)
+
+// HostGroupKeys lists the supported grouping keys.
+var HostGroupKeys = []HostGroupKey{HostGroupBusinessGroup, HostGroupOwner, HostGroupApplication, HostGroupLocation}
+
+// Label returns the column header of the grouping key.
+func (k HostGroupKey) Label() string {
+	switch k {
+	case HostGroupBusinessGroup:
+		return "业务组"
+	case HostGroupOwner:
+		return "负责人"
+	case HostGroupApplication:
+		return "应用"
+	case HostGroupLocation:
+		return "位置"
+	}
+	return string(k)
+}
+
+// Value returns the value of the grouping key for host, empty when unknown.
+func (k HostGroupKey) Value(host *HostResult) string {
+	switch k {
+	case HostGroupBusinessGroup:
+		return host.BusinessGroup
+	case HostGroupOwner:
+		return host.Owner
+	case HostGroupApplication:
+		return host.Application
+	case HostGroupLocation:
+		return host.Location
+	}
+	return ""
+}
+
+// HostGroupStats aggregates the hosts sharing a value of a grouping key (business group,
+// owner, application or location).
+type HostGroupStats struct {
+	Name           string             `json:"name"`             // 分组名称
+	Summary        *InspectionSummary `json:"summary"`          // 主机状态统计
+	AlertSummary   *AlertSummary      `json:"alert_summary"`    // 告警统计
+	AvgCPUUsage    float64            `json:"avg_cpu_usage"`    // 平均 CPU 使用率（%，-1 表示无数据）
+	AvgMemoryUsage float64            `json:"avg_memory_usage"` // 平均内存使用率（%，-1 表示无数据）
+}
+
+// NewHostGroupStats groups hosts by the value of key, ordered by group name with the hosts
+// without value last (UngroupedBusinessGroup). Averages skip N/A values. Returns nil when no
+// host has a value, as a single ungrouped row would only repeat the summary.
+func NewHostGroupStats(hosts []*HostResult, key HostGroupKey) []*HostGroupStats {
+	groups := make(map[string][]*HostResult)
+	grouped := false
+	for _, host := range hosts {
+		if host == nil {
+			continue
+		}
+		name := key.Value(host)
+		if name == "" {
+			name = UngroupedBusinessGroup
+		} else {
+			grouped = true
+		}
+		groups[name] = append(groups[name], host)
+	}
+	if !grouped {
+		return nil
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		if name != UngroupedBusinessGroup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[UngroupedBusinessGroup]; ok {
+		names = append(names, UngroupedBusinessGroup)
+	}
+
+	stats := make([]*HostGroupStats, 0, len(names))
+	for _, name := range names {
+		members := groups[name]
+		var alerts []*Alert
+		for _, host := range members {
+			alerts = append(alerts, host.Alerts...)
+		}
+		stats = append(stats, &HostGroupStats{
+			Name:           name,
+			Summary:        NewInspectionSummary(members),
+			AlertSummary:   NewAlertSummary(alerts),
+			AvgCPUUsage:    averageMetric(members, "cpu_usage"),
+			AvgMemoryUsage: averageMetric(members, "memory_usage"),
+		})
+	}
+	return stats
+}
+
+// HasAvgCPUUsage returns true if at least one host of the group reported its CPU usage.
+func (s *HostGroupStats) HasAvgCPUUsage() bool {
+	return s.AvgCPUUsage >= 0
+}
+
+// HasAvgMemoryUsage returns true if at least one host of the group reported its memory usage.
+func (s *HostGroupStats) HasAvgMemoryUsage() bool {
+	return s.AvgMemoryUsage >= 0
+}
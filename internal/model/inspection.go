@@ -37,15 +37,22 @@ func NewInspectionSummary(hosts []*HostResult) *InspectionSummary {
 // HostResult represents the inspection result for a single host.
 type HostResult struct {
 	// 基础信息
-	Hostname      string     `json:"hostname"`       // 主机名
-	IP            string     `json:"ip"`             // IP 地址
-	OS            string     `json:"os"`             // 操作系统类型
-	OSVersion     string     `json:"os_version"`     // 操作系统版本
-	KernelVersion string     `json:"kernel_version"` // 内核版本
-	CPUCores      int        `json:"cpu_cores"`      // CPU 核心数
-	CPUModel      string     `json:"cpu_model"`      // CPU 型号
-	MemoryTotal   int64      `json:"memory_total"`   // 内存总量（bytes）
-	Status        HostStatus `json:"status"`         // 整体状态
+	Hostname      string     `json:"hostname"`                 // 主机名
+	IP            string     `json:"ip"`                       // IP 地址
+	OS            string     `json:"os"`                       // 操作系统类型
+	OSVersion     string     `json:"os_version"`               // 操作系统版本
+	KernelVersion string     `json:"kernel_version"`           // 内核版本
+	CPUCores      int        `json:"cpu_cores"`                // CPU 核心数
+	CPUModel      string     `json:"cpu_model"`                // CPU 型号
+	MemoryTotal   int64      `json:"memory_total"`             // 内存总量（bytes）
+	Status        HostStatus `json:"status"`                   // 整体状态
+	BusinessGroup string     `json:"business_group,omitempty"` // N9E 业务组
+
+	// CMDB 信息
+	Owner       string `json:"owner,omitempty"`       // 负责人
+	Application string `json:"application,omitempty"` // 所属应用
+	Location    string `json:"location,omitempty"`    // 机房 / 机柜位置
+	Maintenance bool   `json:"maintenance,omitempty"` // 维护中
 
 	// 指标数据
 	Metrics map[string]*MetricValue `json:"metrics"` // 指标集合，key = 指标名称
@@ -78,6 +85,11 @@ func NewHostResult(meta *HostMeta) *HostResult {
 		CPUModel:      meta.CPUModel,
 		MemoryTotal:   meta.MemoryTotal,
 		Status:        HostStatusNormal,
+		BusinessGroup: meta.BusinessGroup,
+		Owner:         meta.Owner,
+		Application:   meta.Application,
+		Location:      meta.Location,
+		Maintenance:   meta.Maintenance,
 		Metrics:       make(map[string]*MetricValue),
 		Alerts:        make([]*Alert, 0),
 	}
@@ -136,10 +148,25 @@ type InspectionResult struct {
 	Alerts       []*Alert      `json:"alerts"`        // 所有告警列表
 	AlertSummary *AlertSummary `json:"alert_summary"` // 告警摘要统计
 
+	// 多采集来源冲突
+	SourceConflicts []*SourceConflict `json:"source_conflicts,omitempty"` // 多个采集器上报不同值的主机指标
+
+	// N9E 当前告警
+	ActiveAlerts []*ActiveAlert `json:"active_alerts,omitempty"` // 巡检主机正在触发的告警（已关联巡检告警）
+
 	// 元数据
 	Version string `json:"version,omitempty"` // 工具版本号
 }
 
+// SourceConflict records a host metric reported by several collection agents (e.g.
+// node_exporter and categraf) with different values. Only the value of Source is used.
+type SourceConflict struct {
+	Hostname string             `json:"hostname"` // 主机名
+	Metric   string             `json:"metric"`   // 指标名称（展开指标含标签值，如 disk_usage:/data）
+	Source   string             `json:"source"`   // 采用的来源
+	Values   map[string]float64 `json:"values"`   // 各来源上报的值
+}
+
 // NewInspectionResult creates a new InspectionResult with the given inspection time.
 func NewInspectionResult(inspectionTime time.Time) *InspectionResult {
 	return &InspectionResult{
@@ -0,0 +1,300 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// 日志巡检状态枚举
+// =============================================================================
+
+type LogCheckStatus string
+
+const (
+	LogCheckStatusNormal   LogCheckStatus = "normal"
+	LogCheckStatusWarning  LogCheckStatus = "warning"
+	LogCheckStatusCritical LogCheckStatus = "critical"
+	LogCheckStatusFailed   LogCheckStatus = "failed"
+)
+
+func (s LogCheckStatus) IsHealthy() bool {
+	return s == LogCheckStatusNormal
+}
+
+func (s LogCheckStatus) IsWarning() bool {
+	return s == LogCheckStatusWarning
+}
+
+func (s LogCheckStatus) IsCritical() bool {
+	return s == LogCheckStatusCritical
+}
+
+func (s LogCheckStatus) IsFailed() bool {
+	return s == LogCheckStatusFailed
+}
+
+// =============================================================================
+// 日志巡检项结构体
+// =============================================================================
+
+// LogCheckInstance represents one evaluated unit of a log check: the check itself, or
+// one group of it when the check groups its counts (e.g. per service).
+type LogCheckInstance struct {
+	Identifier  string  `json:"identifier"`   // 检查项名称，分组时为 "检查项/分组"
+	CheckName   string  `json:"check_name"`   // 检查项名称
+	DisplayName string  `json:"display_name"` // 检查项显示名称
+	Group       string  `json:"group"`        // 分组字段值（未分组时为空）
+	Warning     float64 `json:"warning"`      // 警告阈值（0 表示不检查）
+	Critical    float64 `json:"critical"`     // 严重阈值（0 表示不检查）
+}
+
+func GenerateLogCheckIdentifier(checkName, group string) string {
+	if group == "" {
+		return checkName
+	}
+	return fmt.Sprintf("%s/%s", checkName, group)
+}
+
+func NewLogCheckInstance(checkName, displayName, group string) *LogCheckInstance {
+	return &LogCheckInstance{
+		Identifier:  GenerateLogCheckIdentifier(checkName, group),
+		CheckName:   checkName,
+		DisplayName: displayName,
+		Group:       group,
+	}
+}
+
+func (i *LogCheckInstance) SetThresholds(warning, critical float64) {
+	if i == nil {
+		return
+	}
+	i.Warning = warning
+	i.Critical = critical
+}
+
+func (i *LogCheckInstance) String() string {
+	if i == nil {
+		return "LogCheckInstance(nil)"
+	}
+	return fmt.Sprintf("LogCheckInstance(%s)", i.Identifier)
+}
+
+// =============================================================================
+// 日志巡检告警结构体
+// =============================================================================
+
+type LogCheckAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewLogCheckAlert(identifier, metricName string, currentValue float64, level AlertLevel) *LogCheckAlert {
+	return &LogCheckAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *LogCheckAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *LogCheckAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// 日志巡检结果结构体
+// =============================================================================
+
+type LogCheckInspectionResult struct {
+	Instance    *LogCheckInstance `json:"instance"`
+	Count       float64           `json:"count"` // 时间窗口内匹配的日志条数（-1 表示查询失败）
+	Status      LogCheckStatus    `json:"status"`
+	Alerts      []*LogCheckAlert  `json:"alerts,omitempty"`
+	CollectedAt time.Time         `json:"collected_at"`
+	Error       string            `json:"error,omitempty"`
+}
+
+func NewLogCheckInspectionResult(instance *LogCheckInstance) *LogCheckInspectionResult {
+	return &LogCheckInspectionResult{
+		Instance: instance,
+		Status:   LogCheckStatusNormal,
+		Alerts:   make([]*LogCheckAlert, 0),
+		Count:    -1,
+	}
+}
+
+func (r *LogCheckInspectionResult) AddAlert(alert *LogCheckAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *LogCheckInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *LogCheckInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// HasCount returns true if the log count was collected.
+func (r *LogCheckInspectionResult) HasCount() bool {
+	return r != nil && r.Count >= 0
+}
+
+// =============================================================================
+// 日志巡检摘要结构体
+// =============================================================================
+
+type LogCheckInspectionSummary struct {
+	TotalInstances    int `json:"total_instances"`
+	NormalInstances   int `json:"normal_instances"`
+	WarningInstances  int `json:"warning_instances"`
+	CriticalInstances int `json:"critical_instances"`
+	FailedInstances   int `json:"failed_instances"`
+	MatchedEntries    int `json:"matched_entries"` // 所有检查项匹配的日志总条数
+}
+
+func NewLogCheckInspectionSummary(results []*LogCheckInspectionResult) *LogCheckInspectionSummary {
+	summary := &LogCheckInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case LogCheckStatusNormal:
+			summary.NormalInstances++
+		case LogCheckStatusWarning:
+			summary.WarningInstances++
+		case LogCheckStatusCritical:
+			summary.CriticalInstances++
+		case LogCheckStatusFailed:
+			summary.FailedInstances++
+		}
+
+		if result.HasCount() {
+			summary.MatchedEntries += int(result.Count)
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 日志巡检告警摘要结构体
+// =============================================================================
+
+type LogCheckAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewLogCheckAlertSummary(alerts []*LogCheckAlert) *LogCheckAlertSummary {
+	summary := &LogCheckAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 日志巡检完整结果容器
+// =============================================================================
+
+type LogCheckInspectionResults struct {
+	InspectionTime time.Time                   `json:"inspection_time"`
+	Duration       time.Duration               `json:"duration"`
+	Summary        *LogCheckInspectionSummary  `json:"summary"`
+	Results        []*LogCheckInspectionResult `json:"results"`
+	Alerts         []*LogCheckAlert            `json:"alerts"`
+	AlertSummary   *LogCheckAlertSummary       `json:"alert_summary"`
+	Version        string                      `json:"version,omitempty"`
+}
+
+func NewLogCheckInspectionResults(inspectionTime time.Time) *LogCheckInspectionResults {
+	return &LogCheckInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*LogCheckInspectionResult, 0),
+		Alerts:         make([]*LogCheckAlert, 0),
+	}
+}
+
+func (r *LogCheckInspectionResults) AddResult(result *LogCheckInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *LogCheckInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewLogCheckInspectionSummary(r.Results)
+	r.AlertSummary = NewLogCheckAlertSummary(r.Alerts)
+}
+
+func (r *LogCheckInspectionResults) GetResultByIdentifier(identifier string) *LogCheckInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *LogCheckInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *LogCheckInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *LogCheckInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
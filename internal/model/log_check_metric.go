@@ -0,0 +1,32 @@
+package model
+
+// LogCheckDefinition defines a log-based check to be run against the log backend.
+// Maps to YAML in configs/log-checks.yaml.
+type LogCheckDefinition struct {
+	Name        string  `yaml:"name" json:"name"`
+	DisplayName string  `yaml:"display_name" json:"display_name"`
+	Query       string  `yaml:"query" json:"query"`       // LogQL 日志选择器 (Loki) 或 LogsQL 过滤器 (VictoriaLogs)
+	GroupBy     string  `yaml:"group_by" json:"group_by"` // 分组字段（可选，如 service）
+	Warning     float64 `yaml:"warning" json:"warning"`   // 警告阈值：窗口内匹配条数（0 表示不检查）
+	Critical    float64 `yaml:"critical" json:"critical"` // 严重阈值：窗口内匹配条数（0 表示不检查）
+	Status      string  `yaml:"status" json:"status"`     // pending=待实现
+	Note        string  `yaml:"note" json:"note"`
+}
+
+// IsPending 判断检查项是否待实现
+func (c *LogCheckDefinition) IsPending() bool {
+	return c.Status == "pending" || c.Query == ""
+}
+
+// GetDisplayName 获取检查项显示名称
+func (c *LogCheckDefinition) GetDisplayName() string {
+	if c.DisplayName != "" {
+		return c.DisplayName
+	}
+	return c.Name
+}
+
+// LogChecksConfig represents the root structure of log-checks.yaml.
+type LogChecksConfig struct {
+	Checks []*LogCheckDefinition `yaml:"log_checks" json:"log_checks"`
+}
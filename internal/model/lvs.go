@@ -0,0 +1,436 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// LVS 调度器状态枚举
+// =============================================================================
+
+type LVSInstanceStatus string
+
+const (
+	LVSStatusNormal   LVSInstanceStatus = "normal"
+	LVSStatusWarning  LVSInstanceStatus = "warning"
+	LVSStatusCritical LVSInstanceStatus = "critical"
+	LVSStatusFailed   LVSInstanceStatus = "failed"
+)
+
+func (s LVSInstanceStatus) IsHealthy() bool {
+	return s == LVSStatusNormal
+}
+
+func (s LVSInstanceStatus) IsWarning() bool {
+	return s == LVSStatusWarning
+}
+
+func (s LVSInstanceStatus) IsCritical() bool {
+	return s == LVSStatusCritical
+}
+
+func (s LVSInstanceStatus) IsFailed() bool {
+	return s == LVSStatusFailed
+}
+
+// =============================================================================
+// LVS 调度器结构体
+// =============================================================================
+
+// LVSInstance represents an LVS director (a host running IPVS virtual services).
+// Directors are identified by hostname.
+type LVSInstance struct {
+	Identifier string `json:"identifier"`
+	Hostname   string `json:"hostname"`
+	IP         string `json:"ip"`
+}
+
+func NewLVSInstance(hostname string) *LVSInstance {
+	return &LVSInstance{
+		Identifier: hostname,
+		Hostname:   hostname,
+	}
+}
+
+func (i *LVSInstance) SetIP(ip string) {
+	if i == nil {
+		return
+	}
+	i.IP = ip
+}
+
+func (i *LVSInstance) String() string {
+	if i == nil {
+		return "LVSInstance(nil)"
+	}
+	return fmt.Sprintf("LVSInstance(%s)", i.Identifier)
+}
+
+// =============================================================================
+// LVS 虚拟服务与真实服务器结构体
+// =============================================================================
+
+// LVSRealServer represents a real server (backend) of an IPVS virtual service.
+type LVSRealServer struct {
+	Address             string  `json:"address"`              // 真实服务器地址（IP:端口）
+	Weight              float64 `json:"weight"`               // 权重（0 表示不再分配新连接）
+	ActiveConnections   float64 `json:"active_connections"`   // 活跃连接数
+	InactiveConnections float64 `json:"inactive_connections"` // 非活跃连接数
+}
+
+// IsActive returns true if the real server receives new connections (weight > 0).
+func (rs *LVSRealServer) IsActive() bool {
+	return rs != nil && rs.Weight > 0
+}
+
+// LVSVirtualService represents an IPVS virtual service and its real servers.
+type LVSVirtualService struct {
+	Protocol    string           `json:"protocol"` // 协议（TCP、UDP，防火墙标记服务为 FWM）
+	Address     string           `json:"address"`  // 虚拟服务地址（VIP:端口，或防火墙标记）
+	RealServers []*LVSRealServer `json:"real_servers"`
+}
+
+// String returns the virtual service in "PROTO address" form, e.g. "TCP 10.0.0.100:80".
+func (vs *LVSVirtualService) String() string {
+	if vs == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %s", vs.Protocol, vs.Address)
+}
+
+// ActiveRealServers returns the number of real servers with weight > 0.
+func (vs *LVSVirtualService) ActiveRealServers() int {
+	if vs == nil {
+		return 0
+	}
+	count := 0
+	for _, rs := range vs.RealServers {
+		if rs.IsActive() {
+			count++
+		}
+	}
+	return count
+}
+
+// ActiveConnections returns the total active connections across all real servers.
+func (vs *LVSVirtualService) ActiveConnections() float64 {
+	if vs == nil {
+		return 0
+	}
+	var total float64
+	for _, rs := range vs.RealServers {
+		total += rs.ActiveConnections
+	}
+	return total
+}
+
+// GetRealServer returns the real server with the given address, creating it if needed.
+func (vs *LVSVirtualService) GetRealServer(address string) *LVSRealServer {
+	for _, rs := range vs.RealServers {
+		if rs.Address == address {
+			return rs
+		}
+	}
+	rs := &LVSRealServer{Address: address}
+	vs.RealServers = append(vs.RealServers, rs)
+	return rs
+}
+
+// =============================================================================
+// LVS 调度器告警结构体
+// =============================================================================
+
+type LVSAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewLVSAlert(identifier, metricName string, currentValue float64, level AlertLevel) *LVSAlert {
+	return &LVSAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *LVSAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *LVSAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// LVS 调度器指标值结构体
+// =============================================================================
+
+type LVSMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// LVS 调度器巡检结果结构体
+// =============================================================================
+
+type LVSInspectionResult struct {
+	Instance           *LVSInstance               `json:"instance"`
+	VirtualServices    []*LVSVirtualService       `json:"virtual_services,omitempty"` // IPVS 虚拟服务
+	ZeroActiveServices int                        `json:"zero_active_services"`       // 无活跃真实服务器的虚拟服务数
+	ActiveConnections  float64                    `json:"active_connections"`         // 所有虚拟服务的活跃连接总数（-1 表示未采集）
+	SyncDaemonRunning  float64                    `json:"sync_daemon_running"`        // 连接同步守护进程是否运行（1/0，-1 表示未采集）
+	SyncDaemonState    string                     `json:"sync_daemon_state"`          // 同步守护进程角色（master/backup）
+	Metrics            map[string]*LVSMetricValue `json:"-"`                          // 指标映射（内部使用，不序列化）
+	Status             LVSInstanceStatus          `json:"status"`
+	Alerts             []*LVSAlert                `json:"alerts,omitempty"`
+	CollectedAt        time.Time                  `json:"collected_at"`
+	Error              string                     `json:"error,omitempty"`
+}
+
+func NewLVSInspectionResult(instance *LVSInstance) *LVSInspectionResult {
+	return &LVSInspectionResult{
+		Instance:          instance,
+		Status:            LVSStatusNormal,
+		VirtualServices:   make([]*LVSVirtualService, 0),
+		Alerts:            make([]*LVSAlert, 0),
+		ActiveConnections: -1,
+		SyncDaemonRunning: -1,
+	}
+}
+
+func (r *LVSInspectionResult) AddAlert(alert *LVSAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *LVSInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *LVSInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// GetVirtualService returns the virtual service with the given protocol and address, creating it if needed.
+func (r *LVSInspectionResult) GetVirtualService(protocol, address string) *LVSVirtualService {
+	for _, vs := range r.VirtualServices {
+		if vs.Protocol == protocol && vs.Address == address {
+			return vs
+		}
+	}
+	vs := &LVSVirtualService{Protocol: protocol, Address: address}
+	r.VirtualServices = append(r.VirtualServices, vs)
+	return vs
+}
+
+// ZeroActiveServiceNames returns the virtual services that have no active real server.
+func (r *LVSInspectionResult) ZeroActiveServiceNames() []string {
+	if r == nil {
+		return nil
+	}
+	var names []string
+	for _, vs := range r.VirtualServices {
+		if vs.ActiveRealServers() == 0 {
+			names = append(names, vs.String())
+		}
+	}
+	return names
+}
+
+// RealServerCount returns the total number of real servers across all virtual services.
+func (r *LVSInspectionResult) RealServerCount() int {
+	if r == nil {
+		return 0
+	}
+	count := 0
+	for _, vs := range r.VirtualServices {
+		count += len(vs.RealServers)
+	}
+	return count
+}
+
+// HasActiveConnections returns true if the active connection count was collected.
+func (r *LVSInspectionResult) HasActiveConnections() bool {
+	return r != nil && r.ActiveConnections >= 0
+}
+
+// HasSyncDaemon returns true if the sync daemon state was collected.
+func (r *LVSInspectionResult) HasSyncDaemon() bool {
+	return r != nil && r.SyncDaemonRunning >= 0
+}
+
+func (r *LVSInspectionResult) SetMetric(mv *LVSMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*LVSMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *LVSInspectionResult) GetMetric(name string) *LVSMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// LVS 调度器巡检摘要结构体
+// =============================================================================
+
+type LVSInspectionSummary struct {
+	TotalInstances     int `json:"total_instances"`
+	NormalInstances    int `json:"normal_instances"`
+	WarningInstances   int `json:"warning_instances"`
+	CriticalInstances  int `json:"critical_instances"`
+	FailedInstances    int `json:"failed_instances"`
+	ZeroActiveServices int `json:"zero_active_services"` // 无活跃真实服务器的虚拟服务总数
+}
+
+func NewLVSInspectionSummary(results []*LVSInspectionResult) *LVSInspectionSummary {
+	summary := &LVSInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case LVSStatusNormal:
+			summary.NormalInstances++
+		case LVSStatusWarning:
+			summary.WarningInstances++
+		case LVSStatusCritical:
+			summary.CriticalInstances++
+		case LVSStatusFailed:
+			summary.FailedInstances++
+		}
+
+		summary.ZeroActiveServices += result.ZeroActiveServices
+	}
+
+	return summary
+}
+
+// =============================================================================
+// LVS 调度器告警摘要结构体
+// =============================================================================
+
+type LVSAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewLVSAlertSummary(alerts []*LVSAlert) *LVSAlertSummary {
+	summary := &LVSAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// LVS 调度器完整巡检结果容器
+// =============================================================================
+
+type LVSInspectionResults struct {
+	InspectionTime time.Time              `json:"inspection_time"`
+	Duration       time.Duration          `json:"duration"`
+	Summary        *LVSInspectionSummary  `json:"summary"`
+	Results        []*LVSInspectionResult `json:"results"`
+	Alerts         []*LVSAlert            `json:"alerts"`
+	AlertSummary   *LVSAlertSummary       `json:"alert_summary"`
+	Version        string                 `json:"version,omitempty"`
+}
+
+func NewLVSInspectionResults(inspectionTime time.Time) *LVSInspectionResults {
+	return &LVSInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*LVSInspectionResult, 0),
+		Alerts:         make([]*LVSAlert, 0),
+	}
+}
+
+func (r *LVSInspectionResults) AddResult(result *LVSInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *LVSInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewLVSInspectionSummary(r.Results)
+	r.AlertSummary = NewLVSAlertSummary(r.Alerts)
+}
+
+func (r *LVSInspectionResults) GetResultByIdentifier(identifier string) *LVSInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *LVSInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *LVSInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *LVSInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
@@ -1,6 +1,8 @@
 // Package model provides data models for the inspection tool.
 package model
 
+import "regexp"
+
 // MetricStatus represents the evaluation status of a metric value.
 type MetricStatus string
 
@@ -9,6 +11,7 @@ const (
 	MetricStatusWarning  MetricStatus = "warning"  // 警告
 	MetricStatusCritical MetricStatus = "critical" // 严重
 	MetricStatusPending  MetricStatus = "pending"  // 待定/N/A
+	MetricStatusAnomaly  MetricStatus = "anomaly"  // 数据异常（超出合理取值范围）
 )
 
 // MetricCategory represents the category of a metric.
@@ -41,6 +44,25 @@ const (
 	AggregateAvg AggregateType = "avg" // 取平均值
 )
 
+// RangeFunction is how a metric is aggregated over the inspection window instead of taking
+// its instant value, so short spikes between two inspections are not missed.
+type RangeFunction string
+
+const (
+	RangeFunctionAvg RangeFunction = "avg" // 窗口内平均值
+	RangeFunctionMax RangeFunction = "max" // 窗口内最大值
+	RangeFunctionP95 RangeFunction = "p95" // 窗口内 95 分位值
+)
+
+// IsValid returns true if the range function is empty (instant value) or a known function.
+func (f RangeFunction) IsValid() bool {
+	switch f {
+	case "", RangeFunctionAvg, RangeFunctionMax, RangeFunctionP95:
+		return true
+	}
+	return false
+}
+
 // MetricDefinition defines the metadata for a metric, loaded from metrics.yaml.
 type MetricDefinition struct {
 	Name          string         `yaml:"name" json:"name"`                                           // 指标唯一标识
@@ -53,6 +75,35 @@ type MetricDefinition struct {
 	ExpandByLabel string         `yaml:"expand_by_label,omitempty" json:"expand_by_label,omitempty"` // 按标签展开
 	Status        string         `yaml:"status,omitempty" json:"status,omitempty"`                   // pending=待实现
 	Note          string         `yaml:"note,omitempty" json:"note,omitempty"`                       // 备注说明
+	ValidRange    *ValueRange    `yaml:"valid_range,omitempty" json:"valid_range,omitempty"`         // 合理取值范围（超出视为数据异常）
+	RangeFunction RangeFunction  `yaml:"range_function,omitempty" json:"range_function,omitempty"`   // 巡检窗口内聚合（avg/max/p95），为空取即时值
+	Export        bool           `yaml:"export,omitempty" json:"export,omitempty"`                   // 通过导出接口流式读取（仅限序列选择器）
+}
+
+// seriesSelectorPattern matches a PromQL series selector: a metric name with an optional
+// label selector, or a label selector alone.
+var seriesSelectorPattern = regexp.MustCompile(`^\s*(?:[a-zA-Z_:][a-zA-Z0-9_:]*\s*(?:\{[^{}]*\})?|\{[^{}]*\})\s*$`)
+
+// ValueRange is the range of plausible values of a metric (e.g. 0–100 for percentages).
+// Values outside it come from broken collection (e.g. a counter reset) rather than the host.
+// Either bound may be omitted.
+type ValueRange struct {
+	Min *float64 `yaml:"min,omitempty" json:"min,omitempty"` // 下限（含）
+	Max *float64 `yaml:"max,omitempty" json:"max,omitempty"` // 上限（含）
+}
+
+// Contains returns true if value lies within the range. A nil range contains every value.
+func (r *ValueRange) Contains(value float64) bool {
+	if r == nil {
+		return true
+	}
+	if r.Min != nil && value < *r.Min {
+		return false
+	}
+	if r.Max != nil && value > *r.Max {
+		return false
+	}
+	return true
 }
 
 // IsPending returns true if this metric is marked as pending (not yet implemented).
@@ -60,6 +111,12 @@ func (d *MetricDefinition) IsPending() bool {
 	return d.Status == "pending" || d.Query == ""
 }
 
+// IsSeriesSelector returns true if the query is a plain series selector without functions or
+// operators (e.g. disk_used_percent{fstype!="tmpfs"}), the only queries the export API serves.
+func (d *MetricDefinition) IsSeriesSelector() bool {
+	return seriesSelectorPattern.MatchString(d.Query)
+}
+
 // HasExpandLabel returns true if this metric should be expanded by a label (e.g., disk by path).
 func (d *MetricDefinition) HasExpandLabel() bool {
 	return d.ExpandByLabel != ""
@@ -0,0 +1,366 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// 监控组件状态枚举
+// =============================================================================
+
+type MonitoringInstanceStatus string
+
+const (
+	MonitoringStatusNormal   MonitoringInstanceStatus = "normal"
+	MonitoringStatusWarning  MonitoringInstanceStatus = "warning"
+	MonitoringStatusCritical MonitoringInstanceStatus = "critical"
+	MonitoringStatusFailed   MonitoringInstanceStatus = "failed"
+)
+
+func (s MonitoringInstanceStatus) IsHealthy() bool {
+	return s == MonitoringStatusNormal
+}
+
+func (s MonitoringInstanceStatus) IsWarning() bool {
+	return s == MonitoringStatusWarning
+}
+
+func (s MonitoringInstanceStatus) IsCritical() bool {
+	return s == MonitoringStatusCritical
+}
+
+func (s MonitoringInstanceStatus) IsFailed() bool {
+	return s == MonitoringStatusFailed
+}
+
+// Scrape target states of a monitoring component (from the "up" metric).
+const (
+	MonitoringTargetStateUp   = "UP"
+	MonitoringTargetStateDown = "DOWN"
+)
+
+// =============================================================================
+// 监控组件结构体
+// =============================================================================
+
+// MonitoringInstance represents a single component of the monitoring stack
+// (vmstorage, vmselect, vminsert, single-node VictoriaMetrics or InfluxDB).
+// Components are identified by their scrape target "instance" label.
+type MonitoringInstance struct {
+	Identifier string `json:"identifier"` // instance 标签（如 10.0.0.1:8482）
+	Hostname   string `json:"hostname"`
+	Port       int    `json:"port"`
+	Component  string `json:"component"` // 组件类型（job 标签，如 vmstorage、influxdb）
+	Version    string `json:"version"`
+}
+
+func NewMonitoringInstance(identifier, hostname string, port int) *MonitoringInstance {
+	return &MonitoringInstance{
+		Identifier: identifier,
+		Hostname:   hostname,
+		Port:       port,
+	}
+}
+
+func (i *MonitoringInstance) SetComponent(component string) {
+	if i == nil {
+		return
+	}
+	i.Component = component
+}
+
+func (i *MonitoringInstance) SetVersion(version string) {
+	if i == nil {
+		return
+	}
+	i.Version = version
+}
+
+func (i *MonitoringInstance) String() string {
+	if i == nil {
+		return "MonitoringInstance(nil)"
+	}
+	return fmt.Sprintf("MonitoringInstance(%s, %s)", i.Component, i.Identifier)
+}
+
+// =============================================================================
+// 监控组件告警结构体
+// =============================================================================
+
+type MonitoringAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewMonitoringAlert(identifier, metricName string, currentValue float64, level AlertLevel) *MonitoringAlert {
+	return &MonitoringAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *MonitoringAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *MonitoringAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// 监控组件指标值结构体
+// =============================================================================
+
+type MonitoringMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// 监控组件巡检结果结构体
+// =============================================================================
+
+type MonitoringInspectionResult struct {
+	Instance         *MonitoringInstance               `json:"instance"`
+	Up               bool                              `json:"up"`                 // 采集目标状态（true=UP, false=DOWN）
+	IngestionRate    float64                           `json:"ingestion_rate"`     // 写入速率，行/秒（-1 表示未采集）
+	SlowQueries      float64                           `json:"slow_queries"`       // 近 5 分钟慢查询数（-1 表示未采集）
+	MergeBacklog     float64                           `json:"merge_backlog"`      // 待合并/落盘的行数（-1 表示未采集）
+	DiskUsagePercent float64                           `json:"disk_usage_percent"` // 存储磁盘使用率（-1 表示未采集）
+	Metrics          map[string]*MonitoringMetricValue `json:"-"`                  // 指标映射（内部使用，不序列化）
+	Status           MonitoringInstanceStatus          `json:"status"`
+	Alerts           []*MonitoringAlert                `json:"alerts,omitempty"`
+	CollectedAt      time.Time                         `json:"collected_at"`
+	Error            string                            `json:"error,omitempty"`
+}
+
+func NewMonitoringInspectionResult(instance *MonitoringInstance) *MonitoringInspectionResult {
+	return &MonitoringInspectionResult{
+		Instance:         instance,
+		Status:           MonitoringStatusNormal,
+		Alerts:           make([]*MonitoringAlert, 0),
+		IngestionRate:    -1,
+		SlowQueries:      -1,
+		MergeBacklog:     -1,
+		DiskUsagePercent: -1,
+	}
+}
+
+func (r *MonitoringInspectionResult) AddAlert(alert *MonitoringAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *MonitoringInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *MonitoringInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// TargetState returns the scrape target state of the component ("UP" or "DOWN").
+func (r *MonitoringInspectionResult) TargetState() string {
+	if r != nil && r.Up {
+		return MonitoringTargetStateUp
+	}
+	return MonitoringTargetStateDown
+}
+
+// HasIngestionRate returns true if the ingestion rate was collected.
+func (r *MonitoringInspectionResult) HasIngestionRate() bool {
+	return r != nil && r.IngestionRate >= 0
+}
+
+// HasSlowQueries returns true if the slow query count was collected.
+func (r *MonitoringInspectionResult) HasSlowQueries() bool {
+	return r != nil && r.SlowQueries >= 0
+}
+
+// HasMergeBacklog returns true if the merge backlog was collected.
+func (r *MonitoringInspectionResult) HasMergeBacklog() bool {
+	return r != nil && r.MergeBacklog >= 0
+}
+
+// HasDiskUsage returns true if the storage disk usage was collected.
+func (r *MonitoringInspectionResult) HasDiskUsage() bool {
+	return r != nil && r.DiskUsagePercent >= 0
+}
+
+func (r *MonitoringInspectionResult) SetMetric(mv *MonitoringMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*MonitoringMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *MonitoringInspectionResult) GetMetric(name string) *MonitoringMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// 监控组件巡检摘要结构体
+// =============================================================================
+
+type MonitoringInspectionSummary struct {
+	TotalInstances    int `json:"total_instances"`
+	NormalInstances   int `json:"normal_instances"`
+	WarningInstances  int `json:"warning_instances"`
+	CriticalInstances int `json:"critical_instances"`
+	FailedInstances   int `json:"failed_instances"`
+	DownInstances     int `json:"down_instances"` // 采集目标处于 DOWN 状态的组件数
+}
+
+func NewMonitoringInspectionSummary(results []*MonitoringInspectionResult) *MonitoringInspectionSummary {
+	summary := &MonitoringInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case MonitoringStatusNormal:
+			summary.NormalInstances++
+		case MonitoringStatusWarning:
+			summary.WarningInstances++
+		case MonitoringStatusCritical:
+			summary.CriticalInstances++
+		case MonitoringStatusFailed:
+			summary.FailedInstances++
+		}
+
+		if !result.Up {
+			summary.DownInstances++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 监控组件告警摘要结构体
+// =============================================================================
+
+type MonitoringAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewMonitoringAlertSummary(alerts []*MonitoringAlert) *MonitoringAlertSummary {
+	summary := &MonitoringAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 监控组件完整巡检结果容器
+// =============================================================================
+
+type MonitoringInspectionResults struct {
+	InspectionTime time.Time                     `json:"inspection_time"`
+	Duration       time.Duration                 `json:"duration"`
+	Summary        *MonitoringInspectionSummary  `json:"summary"`
+	Results        []*MonitoringInspectionResult `json:"results"`
+	Alerts         []*MonitoringAlert            `json:"alerts"`
+	AlertSummary   *MonitoringAlertSummary       `json:"alert_summary"`
+	Version        string                        `json:"version,omitempty"`
+}
+
+func NewMonitoringInspectionResults(inspectionTime time.Time) *MonitoringInspectionResults {
+	return &MonitoringInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*MonitoringInspectionResult, 0),
+		Alerts:         make([]*MonitoringAlert, 0),
+	}
+}
+
+func (r *MonitoringInspectionResults) AddResult(result *MonitoringInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *MonitoringInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewMonitoringInspectionSummary(r.Results)
+	r.AlertSummary = NewMonitoringAlertSummary(r.Alerts)
+}
+
+func (r *MonitoringInspectionResults) GetResultByIdentifier(identifier string) *MonitoringInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *MonitoringInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *MonitoringInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *MonitoringInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
@@ -3,6 +3,7 @@ package model
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -93,6 +94,85 @@ func (r MySQLMGRRole) IsSecondary() bool {
 	return r == MGRRoleSecondary
 }
 
+// ParseMySQLMGRRole converts a MEMBER_ROLE value to MySQLMGRRole (case-insensitive).
+func ParseMySQLMGRRole(role string) MySQLMGRRole {
+	switch MySQLMGRRole(strings.ToUpper(role)) {
+	case MGRRolePrimary:
+		return MGRRolePrimary
+	case MGRRoleSecondary:
+		return MGRRoleSecondary
+	default:
+		return MGRRoleUnknown
+	}
+}
+
+// =============================================================================
+// MySQL MGR 组成员
+// =============================================================================
+
+// MGR member states (performance_schema.replication_group_members.MEMBER_STATE).
+const (
+	MGRMemberStateOnline     = "ONLINE"
+	MGRMemberStateRecovering = "RECOVERING"
+)
+
+// MySQLMGRMember represents one member of an MGR group as reported by an instance.
+type MySQLMGRMember struct {
+	MemberID           string       `json:"member_id"`           // MEMBER_ID (server_uuid)
+	Host               string       `json:"host"`                // MEMBER_HOST
+	Port               int          `json:"port"`                // MEMBER_PORT
+	State              string       `json:"state"`               // ONLINE, RECOVERING, OFFLINE, ERROR, UNREACHABLE
+	Role               MySQLMGRRole `json:"role"`                // PRIMARY / SECONDARY
+	TransactionsQueued int          `json:"transactions_queued"` // 认证队列中的事务数，-1 表示未采集
+}
+
+// NewMySQLMGRMember creates a new MySQLMGRMember with uncollected fields set to -1.
+func NewMySQLMGRMember(memberID string) *MySQLMGRMember {
+	return &MySQLMGRMember{
+		MemberID:           memberID,
+		Role:               MGRRoleUnknown,
+		TransactionsQueued: -1,
+	}
+}
+
+// Address returns "host:port" of the member, or the member ID if the host is unknown.
+func (m *MySQLMGRMember) Address() string {
+	if m.Host == "" {
+		return m.MemberID
+	}
+	if m.Port == 0 {
+		return m.Host
+	}
+	return fmt.Sprintf("%s:%d", m.Host, m.Port)
+}
+
+// IsOnline returns true if the member state is ONLINE.
+func (m *MySQLMGRMember) IsOnline() bool {
+	return m.State == MGRMemberStateOnline
+}
+
+// HasTransactionsQueued returns true if the queued transaction count was collected.
+func (m *MySQLMGRMember) HasTransactionsQueued() bool {
+	return m.TransactionsQueued >= 0
+}
+
+// MySQLMGRCluster groups the members of one MGR group across the inspected instances.
+type MySQLMGRCluster struct {
+	Name    string            `json:"name"`    // 组名（group_replication_group_name），未上报时为首个实例地址
+	Members []*MySQLMGRMember `json:"members"` // 组成员（PRIMARY 在前，按地址排序）
+}
+
+// OnlineMembers returns the number of ONLINE members in the group.
+func (c *MySQLMGRCluster) OnlineMembers() int {
+	count := 0
+	for _, m := range c.Members {
+		if m.IsOnline() {
+			count++
+		}
+	}
+	return count
+}
+
 // =============================================================================
 // MySQL 实例结构体
 // =============================================================================
@@ -191,15 +271,16 @@ func (m *MySQLInstance) String() string {
 
 // MySQLAlert represents a threshold violation alert for a MySQL instance.
 type MySQLAlert struct {
-	Address           string     `json:"address"`             // 实例地址 (IP:Port)
-	MetricName        string     `json:"metric_name"`         // 指标名称
-	MetricDisplayName string     `json:"metric_display_name"` // 指标中文显示名称
-	CurrentValue      float64    `json:"current_value"`       // 当前值
-	FormattedValue    string     `json:"formatted_value"`     // 格式化后的当前值
-	WarningThreshold  float64    `json:"warning_threshold"`   // 警告阈值
-	CriticalThreshold float64    `json:"critical_threshold"`  // 严重阈值
-	Level             AlertLevel `json:"level"`               // 告警级别 (复用 alert.go 的 AlertLevel)
-	Message           string     `json:"message"`             // 告警消息
+	Address           string     `json:"address"`                  // 实例地址 (IP:Port)
+	MetricName        string     `json:"metric_name"`              // 指标名称
+	MetricDisplayName string     `json:"metric_display_name"`      // 指标中文显示名称
+	CurrentValue      float64    `json:"current_value"`            // 当前值
+	FormattedValue    string     `json:"formatted_value"`          // 格式化后的当前值
+	WarningThreshold  float64    `json:"warning_threshold"`        // 警告阈值
+	CriticalThreshold float64    `json:"critical_threshold"`       // 严重阈值
+	Level             AlertLevel `json:"level"`                    // 告警级别 (复用 alert.go 的 AlertLevel)
+	Message           string     `json:"message"`                  // 告警消息
+	ExpectedValue     string     `json:"expected_value,omitempty"` // 期望值（仅字符串/枚举检查）
 }
 
 // NewMySQLAlert creates a new MySQLAlert with the given parameters.
@@ -262,14 +343,28 @@ type MySQLInspectionResult struct {
 	MaxConnections     int `json:"max_connections"`
 	CurrentConnections int `json:"current_connections"`
 
+	// 性能指标（基于计数器速率计算）
+	QPS                  float64 `json:"qps"`                     // 每秒查询数
+	TPS                  float64 `json:"tps"`                     // 每秒事务数（commit + rollback）
+	SlowQueriesPerSecond float64 `json:"slow_queries_per_second"` // 每秒新增慢查询数
+	ThreadsRunning       int     `json:"threads_running"`         // 正在运行的线程数
+
+	// InnoDB 健康指标
+	InnoDBBufferPoolHitRatio    float64 `json:"innodb_buffer_pool_hit_ratio"`     // 缓冲池命中率（%）
+	InnoDBRowLockWaitsPerSecond float64 `json:"innodb_row_lock_waits_per_second"` // 每秒行锁等待次数
+	InnoDBDeadlocks             int     `json:"innodb_deadlocks"`                 // 最近 1 小时新增死锁数
+	InnoDBHistoryListLength     int     `json:"innodb_history_list_length"`       // Undo History List 长度
+
 	// Binlog 配置
 	BinlogEnabled       bool `json:"binlog_enabled"`
 	BinlogExpireSeconds int  `json:"binlog_expire_seconds"`
 
 	// MGR 专属字段 (仅 MGR 模式有效)
-	MGRMemberCount int          `json:"mgr_member_count"`
-	MGRRole        MySQLMGRRole `json:"mgr_role"`
-	MGRStateOnline bool         `json:"mgr_state_online"`
+	MGRMemberCount int               `json:"mgr_member_count"`
+	MGRRole        MySQLMGRRole      `json:"mgr_role"`
+	MGRStateOnline bool              `json:"mgr_state_online"`
+	MGRGroupName   string            `json:"mgr_group_name,omitempty"` // 所属 MGR 组名
+	MGRMembers     []*MySQLMGRMember `json:"mgr_members,omitempty"`    // 本实例视角下的组成员
 
 	// 待实现项 (MVP 阶段显示 N/A)
 	NonRootUser string `json:"non_root_user"`
@@ -360,6 +455,18 @@ func (r *MySQLInspectionResult) GetMetric(name string) *MySQLMetricValue {
 	return r.Metrics[name]
 }
 
+// GetMGRMember returns the MGR member with the given ID, creating it if missing.
+func (r *MySQLInspectionResult) GetMGRMember(memberID string) *MySQLMGRMember {
+	for _, m := range r.MGRMembers {
+		if m.MemberID == memberID {
+			return m
+		}
+	}
+	m := NewMySQLMGRMember(memberID)
+	r.MGRMembers = append(r.MGRMembers, m)
+	return m
+}
+
 // =============================================================================
 // MySQL 巡检摘要与结果集合
 // =============================================================================
@@ -477,6 +584,147 @@ func (r *MySQLInspectionResults) GetResultByAddress(address string) *MySQLInspec
 	return nil
 }
 
+// MGRClusters groups the MGR members reported by all instances into one entry per group.
+// Instances of the same group report the same member list, so members are merged by ID
+// and the first collected queue length wins. Groups are keyed by group name when
+// reported, otherwise by their sorted member IDs.
+func (r *MySQLInspectionResults) MGRClusters() []*MySQLMGRCluster {
+	results := make([]*MySQLInspectionResult, 0, len(r.Results))
+	for _, result := range r.Results {
+		if result != nil && len(result.MGRMembers) > 0 {
+			results = append(results, result)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].GetAddress() < results[j].GetAddress()
+	})
+
+	var clusters []*MySQLMGRCluster
+	byKey := make(map[string]*MySQLMGRCluster)
+	membersByKey := make(map[string]map[string]*MySQLMGRMember)
+	for _, result := range results {
+		key := result.MGRGroupName
+		if key == "" {
+			ids := make([]string, 0, len(result.MGRMembers))
+			for _, m := range result.MGRMembers {
+				ids = append(ids, m.MemberID)
+			}
+			sort.Strings(ids)
+			key = strings.Join(ids, ",")
+		}
+
+		cluster, ok := byKey[key]
+		if !ok {
+			name := result.MGRGroupName
+			if name == "" {
+				name = result.GetAddress()
+			}
+			cluster = &MySQLMGRCluster{Name: name}
+			byKey[key] = cluster
+			membersByKey[key] = make(map[string]*MySQLMGRMember)
+			clusters = append(clusters, cluster)
+		}
+
+		members := membersByKey[key]
+		for _, m := range result.MGRMembers {
+			existing, ok := members[m.MemberID]
+			if !ok {
+				member := *m
+				members[m.MemberID] = &member
+				cluster.Members = append(cluster.Members, &member)
+				continue
+			}
+			if existing.Host == "" {
+				existing.Host, existing.Port = m.Host, m.Port
+				existing.State, existing.Role = m.State, m.Role
+			}
+			if !existing.HasTransactionsQueued() {
+				existing.TransactionsQueued = m.TransactionsQueued
+			}
+		}
+	}
+
+	for _, cluster := range clusters {
+		sort.Slice(cluster.Members, func(i, j int) bool {
+			a, b := cluster.Members[i], cluster.Members[j]
+			if a.Role.IsPrimary() != b.Role.IsPrimary() {
+				return a.Role.IsPrimary()
+			}
+			return a.Address() < b.Address()
+		})
+	}
+	return clusters
+}
+
+// MySQLReplicationGroup is a group of the MySQL replication topology: an MGR group, or the
+// instances of another cluster mode, whose replication sources are not collected.
+type MySQLReplicationGroup struct {
+	Name    string                    // MGR 组名（其他模式为空）
+	Mode    MySQLClusterMode          // 集群模式
+	Members []*MySQLReplicationMember // 组成员（MGR 组 PRIMARY 在前）
+}
+
+// MySQLReplicationMember is an instance of a replication group.
+type MySQLReplicationMember struct {
+	Address string                 // 实例地址
+	Role    MySQLMGRRole           // MGR 角色（其他模式为 UNKNOWN）
+	State   string                 // MGR 成员状态（其他模式为空）
+	Result  *MySQLInspectionResult // 巡检结果（成员未纳入巡检时为 nil）
+}
+
+// ReplicationGroups returns the replication topology of the instances: one group per MGR
+// group, with the members reported by MGRClusters matched to the inspected instances by
+// address, followed by one group per cluster mode holding the other instances in address
+// order.
+func (r *MySQLInspectionResults) ReplicationGroups() []*MySQLReplicationGroup {
+	byAddress := make(map[string]*MySQLInspectionResult)
+	for _, result := range r.Results {
+		if result != nil && result.Instance != nil {
+			byAddress[result.GetAddress()] = result
+		}
+	}
+
+	var groups []*MySQLReplicationGroup
+	grouped := make(map[string]bool)
+	for _, cluster := range r.MGRClusters() {
+		group := &MySQLReplicationGroup{Name: cluster.Name, Mode: ClusterModeMGR}
+		for _, m := range cluster.Members {
+			address := m.Address()
+			group.Members = append(group.Members, &MySQLReplicationMember{
+				Address: address,
+				Role:    m.Role,
+				State:   m.State,
+				Result:  byAddress[address],
+			})
+			grouped[address] = true
+		}
+		groups = append(groups, group)
+	}
+
+	others := make(map[MySQLClusterMode]*MySQLReplicationGroup)
+	var modes []MySQLClusterMode
+	for address, result := range byAddress {
+		if grouped[address] {
+			continue
+		}
+		mode := result.Instance.ClusterMode
+		group, ok := others[mode]
+		if !ok {
+			group = &MySQLReplicationGroup{Mode: mode}
+			others[mode] = group
+			modes = append(modes, mode)
+		}
+		group.Members = append(group.Members, &MySQLReplicationMember{Address: address, Role: MGRRoleUnknown, Result: result})
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+	for _, mode := range modes {
+		group := others[mode]
+		sort.Slice(group.Members, func(i, j int) bool { return group.Members[i].Address < group.Members[j].Address })
+		groups = append(groups, group)
+	}
+	return groups
+}
+
 // GetCriticalResults returns all instances with critical status.
 func (r *MySQLInspectionResults) GetCriticalResults() []*MySQLInspectionResult {
 	var critical []*MySQLInspectionResult
@@ -535,7 +783,7 @@ type MySQLMetricDefinition struct {
 	Name         string `yaml:"name"`          // 指标唯一标识
 	DisplayName  string `yaml:"display_name"`  // 中文显示名称
 	Query        string `yaml:"query"`         // PromQL 查询表达式
-	Category     string `yaml:"category"`      // 分类 (connection, info, mgr, binlog, log, status, replication, security)
+	Category     string `yaml:"category"`      // 分类 (connection, info, mgr, binlog, log, status, performance, replication, security)
 	ClusterMode  string `yaml:"cluster_mode"`  // 适用的集群模式（可选：mgr, dual-master, master-slave）
 	LabelExtract string `yaml:"label_extract"` // 从指标标签提取值（可选，如 version, member_id）
 	Format       string `yaml:"format"`        // 格式化类型（可选：size, duration, percent）
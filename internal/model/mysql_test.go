@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestMGRMember returns an MGR member of the test group.
+func newTestMGRMember(id, host string, role MySQLMGRRole, state string) *MySQLMGRMember {
+	m := NewMySQLMGRMember(id)
+	m.Host = host
+	m.Port = 3306
+	m.Role = role
+	m.State = state
+	return m
+}
+
+func TestMySQLInspectionResults_ReplicationGroups(t *testing.T) {
+	members := []*MySQLMGRMember{
+		newTestMGRMember("uuid-2", "10.0.0.2", MGRRoleSecondary, MGRMemberStateOnline),
+		newTestMGRMember("uuid-9", "10.0.0.9", MGRRoleSecondary, "UNREACHABLE"),
+		newTestMGRMember("uuid-1", "10.0.0.1", MGRRolePrimary, MGRMemberStateOnline),
+	}
+	results := NewMySQLInspectionResults(time.Now())
+	for _, inst := range []*MySQLInstance{
+		NewMySQLInstanceWithClusterMode("10.0.0.5:3306", ClusterModeMasterSlave),
+		NewMySQLInstanceWithClusterMode("10.0.0.2:3306", ClusterModeMGR),
+		NewMySQLInstanceWithClusterMode("10.0.0.6:3306", ClusterModeDualMaster),
+		NewMySQLInstanceWithClusterMode("10.0.0.1:3306", ClusterModeMGR),
+		NewMySQLInstanceWithClusterMode("10.0.0.4:3306", ClusterModeMasterSlave),
+	} {
+		result := NewMySQLInspectionResult(inst)
+		if inst.ClusterMode.IsMGR() {
+			result.MGRGroupName = "group-a"
+			result.MGRMembers = members
+		}
+		results.AddResult(result)
+	}
+
+	groups := results.ReplicationGroups()
+	type member struct {
+		address   string
+		role      MySQLMGRRole
+		inspected bool
+	}
+	want := []struct {
+		name    string
+		mode    MySQLClusterMode
+		members []member
+	}{
+		{"group-a", ClusterModeMGR, []member{
+			{"10.0.0.1:3306", MGRRolePrimary, true},
+			{"10.0.0.2:3306", MGRRoleSecondary, true},
+			{"10.0.0.9:3306", MGRRoleSecondary, false},
+		}},
+		{"", ClusterModeDualMaster, []member{{"10.0.0.6:3306", MGRRoleUnknown, true}}},
+		{"", ClusterModeMasterSlave, []member{
+			{"10.0.0.4:3306", MGRRoleUnknown, true},
+			{"10.0.0.5:3306", MGRRoleUnknown, true},
+		}},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+	for i, w := range want {
+		g := groups[i]
+		if g.Name != w.name || g.Mode != w.mode || len(g.Members) != len(w.members) {
+			t.Fatalf("group %d = %s/%s with %d members, want %s/%s with %d", i, g.Name, g.Mode, len(g.Members), w.name, w.mode, len(w.members))
+		}
+		for j, wm := range w.members {
+			m := g.Members[j]
+			if m.Address != wm.address || m.Role != wm.role || (m.Result != nil) != wm.inspected {
+				t.Errorf("group %d member %d = %s/%s inspected=%v, want %s/%s inspected=%v", i, j, m.Address, m.Role, m.Result != nil, wm.address, wm.role, wm.inspected)
+			}
+		}
+	}
+	if state := groups[0].Members[2].State; state != "UNREACHABLE" {
+		t.Errorf("member state = %q, want UNREACHABLE", state)
+	}
+}
@@ -3,6 +3,7 @@ package model
 
 import (
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -171,21 +172,37 @@ func (u *NginxUpstreamStatus) IsHealthy() bool {
 	return u.Status
 }
 
+// NginxUpstreamSummary aggregates the backend health of a single upstream group.
+type NginxUpstreamSummary struct {
+	UpstreamName      string  `json:"upstream_name"`      // upstream 组名
+	TotalBackends     int     `json:"total_backends"`     // 后端总数
+	HealthyBackends   int     `json:"healthy_backends"`   // 正常后端数
+	UnhealthyBackends int     `json:"unhealthy_backends"` // 异常后端数
+	Error5xxRate      float64 `json:"error_5xx_rate"`     // 5xx 响应比例（%）
+	Has5xxRate        bool    `json:"has_5xx_rate"`       // 是否采集到 5xx 比例
+}
+
+// AllDown returns true if the upstream has backends and none of them is healthy.
+func (s *NginxUpstreamSummary) AllDown() bool {
+	return s.TotalBackends > 0 && s.HealthyBackends == 0
+}
+
 // =============================================================================
 // Nginx 告警结构体
 // =============================================================================
 
 // NginxAlert represents a threshold violation alert for a Nginx instance.
 type NginxAlert struct {
-	Identifier        string     `json:"identifier"`          // 实例唯一标识
-	MetricName        string     `json:"metric_name"`         // 指标名称
-	MetricDisplayName string     `json:"metric_display_name"` // 指标中文显示名称
-	CurrentValue      float64    `json:"current_value"`       // 当前值
-	FormattedValue    string     `json:"formatted_value"`     // 格式化后的当前值
-	WarningThreshold  float64    `json:"warning_threshold"`   // 警告阈值
-	CriticalThreshold float64    `json:"critical_threshold"`  // 严重阈值
-	Level             AlertLevel `json:"level"`               // 告警级别 (复用 alert.go 的 AlertLevel)
-	Message           string     `json:"message"`             // 告警消息
+	Identifier        string     `json:"identifier"`            // 实例唯一标识
+	MetricName        string     `json:"metric_name"`           // 指标名称
+	MetricDisplayName string     `json:"metric_display_name"`   // 指标中文显示名称
+	CurrentValue      float64    `json:"current_value"`         // 当前值
+	FormattedValue    string     `json:"formatted_value"`       // 格式化后的当前值
+	WarningThreshold  float64    `json:"warning_threshold"`     // 警告阈值
+	CriticalThreshold float64    `json:"critical_threshold"`    // 严重阈值
+	Level             AlertLevel `json:"level"`                 // 告警级别 (复用 alert.go 的 AlertLevel)
+	Message           string     `json:"message"`               // 告警消息
+	LogExcerpt        []string   `json:"log_excerpt,omitempty"` // 日志摘录（仅严重告警，需启用 log_excerpt）
 }
 
 // NewNginxAlert creates a new NginxAlert with the given parameters.
@@ -254,7 +271,8 @@ type NginxInspectionResult struct {
 	NonRootUser bool `json:"non_root_user"` // 是否非 root 用户启动
 
 	// Upstream 后端状态
-	UpstreamStatus []NginxUpstreamStatus `json:"upstream_status"` // 后端健康状态（可为空数组）
+	UpstreamStatus   []NginxUpstreamStatus `json:"upstream_status"`              // 后端健康状态（可为空数组）
+	Upstream5xxRates map[string]float64    `json:"upstream_5xx_rates,omitempty"` // 各 upstream 5xx 响应比例（key = upstream 组名）
 
 	// 整体状态和告警
 	Status NginxInstanceStatus `json:"status"`
@@ -370,6 +388,57 @@ func (r *NginxInspectionResult) GetUnhealthyUpstreams() []NginxUpstreamStatus {
 	return unhealthy
 }
 
+// SetUpstream5xxRate records the 5xx response rate (percent) of an upstream group.
+func (r *NginxInspectionResult) SetUpstream5xxRate(upstreamName string, rate float64) {
+	if r.Upstream5xxRates == nil {
+		r.Upstream5xxRates = make(map[string]float64)
+	}
+	r.Upstream5xxRates[upstreamName] = rate
+}
+
+// HasUpstreamData returns true if this instance has any upstream status or 5xx rate data.
+func (r *NginxInspectionResult) HasUpstreamData() bool {
+	return len(r.UpstreamStatus) > 0 || len(r.Upstream5xxRates) > 0
+}
+
+// GetUpstreamSummaries aggregates backend status and 5xx rates per upstream group.
+// Results are sorted by upstream name.
+func (r *NginxInspectionResult) GetUpstreamSummaries() []NginxUpstreamSummary {
+	byName := make(map[string]*NginxUpstreamSummary)
+	get := func(name string) *NginxUpstreamSummary {
+		s, ok := byName[name]
+		if !ok {
+			s = &NginxUpstreamSummary{UpstreamName: name}
+			byName[name] = s
+		}
+		return s
+	}
+
+	for _, u := range r.UpstreamStatus {
+		s := get(u.UpstreamName)
+		s.TotalBackends++
+		if u.Status {
+			s.HealthyBackends++
+		} else {
+			s.UnhealthyBackends++
+		}
+	}
+	for name, rate := range r.Upstream5xxRates {
+		s := get(name)
+		s.Error5xxRate = rate
+		s.Has5xxRate = true
+	}
+
+	summaries := make([]NginxUpstreamSummary, 0, len(byName))
+	for _, s := range byName {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].UpstreamName < summaries[j].UpstreamName
+	})
+	return summaries
+}
+
 // FormatLastErrorTime formats the last error timestamp to a human-readable string.
 // Returns "无错误" if the timestamp is 0 (no error).
 // Otherwise, returns the time formatted as "2006-01-02 15:04:05".
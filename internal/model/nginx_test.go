@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+)
+
+// ============================================================================
+// GetUpstreamSummaries Tests
+// ============================================================================
+
+func TestNginxInspectionResult_GetUpstreamSummaries(t *testing.T) {
+	result := NewNginxInspectionResult(NewNginxInstance("GX-NM-NGX-01", 80))
+	result.AddUpstreamStatus(NginxUpstreamStatus{UpstreamName: "web", BackendAddress: "10.0.0.1:8080", Status: true})
+	result.AddUpstreamStatus(NginxUpstreamStatus{UpstreamName: "web", BackendAddress: "10.0.0.2:8080", Status: false})
+	result.AddUpstreamStatus(NginxUpstreamStatus{UpstreamName: "api", BackendAddress: "10.0.0.3:8080", Status: false})
+	result.SetUpstream5xxRate("web", 1.5)
+	result.SetUpstream5xxRate("static", 0)
+
+	summaries := result.GetUpstreamSummaries()
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 summaries, got %d", len(summaries))
+	}
+
+	// Sorted by upstream name
+	api, static, web := summaries[0], summaries[1], summaries[2]
+	if api.UpstreamName != "api" || static.UpstreamName != "static" || web.UpstreamName != "web" {
+		t.Fatalf("unexpected order: %s, %s, %s", api.UpstreamName, static.UpstreamName, web.UpstreamName)
+	}
+
+	if api.TotalBackends != 1 || api.UnhealthyBackends != 1 || !api.AllDown() {
+		t.Errorf("api: expected 1 unhealthy backend and all down, got %+v", api)
+	}
+	if api.Has5xxRate {
+		t.Error("api: expected no 5xx rate")
+	}
+
+	if web.TotalBackends != 2 || web.HealthyBackends != 1 || web.UnhealthyBackends != 1 {
+		t.Errorf("web: unexpected counts %+v", web)
+	}
+	if web.AllDown() {
+		t.Error("web: expected not all down")
+	}
+	if !web.Has5xxRate || web.Error5xxRate != 1.5 {
+		t.Errorf("web: expected 5xx rate 1.5, got %+v", web)
+	}
+
+	// Rate-only upstream has no backends and is never reported as all down
+	if static.TotalBackends != 0 || static.AllDown() || !static.Has5xxRate {
+		t.Errorf("static: unexpected summary %+v", static)
+	}
+}
+
+func TestNginxInspectionResult_HasUpstreamData(t *testing.T) {
+	result := NewNginxInspectionResult(NewNginxInstance("GX-NM-NGX-01", 80))
+	if result.HasUpstreamData() {
+		t.Error("expected no upstream data on new result")
+	}
+
+	result.SetUpstream5xxRate("web", 0.2)
+	if !result.HasUpstreamData() {
+		t.Error("expected upstream data after setting 5xx rate")
+	}
+}
@@ -0,0 +1,636 @@
+// Package model provides data models for the inspection tool.
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// 原始数据（长表格式）
+// =============================================================================
+
+// Raw data module names (used as the "模块" column in the raw data sheet).
+const (
+	RawDataModuleHost       = "主机"
+	RawDataModuleMySQL      = "MySQL"
+	RawDataModuleRedis      = "Redis"
+	RawDataModuleNginx      = "Nginx"
+	RawDataModuleTomcat     = "Tomcat"
+	RawDataModuleCassandra  = "Cassandra"
+	RawDataModuleMonitoring = "监控系统"
+	RawDataModuleStorage    = "共享存储"
+	RawDataModuleLogChecks  = "日志"
+	RawDataModuleLVS        = "LVS"
+	RawDataModuleWindows    = "Windows"
+	RawDataModuleAD         = "AD"
+	RawDataModuleCloud      = "云资源"
+	RawDataModuleFirewall   = "防火墙"
+)
+
+// RawDataRecord represents a single metric observation in long/tidy format.
+// One record per (target, metric) pair, so analysts can pivot freely without
+// re-querying the monitoring system.
+type RawDataRecord struct {
+	Module    string            `json:"module"`           // 巡检模块（主机、MySQL、Redis、Nginx、Tomcat、Cassandra、监控系统）
+	Target    string            `json:"target"`           // 巡检对象（主机名或实例标识）
+	Metric    string            `json:"metric"`           // 指标名称
+	Value     float64           `json:"value"`            // 原始数值
+	Text      string            `json:"text,omitempty"`   // 字符串值（从标签提取的指标）
+	Unit      string            `json:"unit"`             // 单位
+	Status    MetricStatus      `json:"status"`           // 指标状态
+	IsNA      bool              `json:"is_na"`            // 是否为 N/A
+	Timestamp time.Time         `json:"timestamp"`        // 采集时间
+	Labels    map[string]string `json:"labels,omitempty"` // 原始标签
+	Query     string            `json:"query,omitempty"`  // 采集使用的查询表达式
+}
+
+// NewHostRawDataRecords flattens host inspection results into raw data records.
+// Units and queries are resolved from the metric definitions; expanded metrics
+// such as "disk_usage:/home" use the definition of their base metric.
+func NewHostRawDataRecords(result *InspectionResult, defs []*MetricDefinition) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	units := make(map[string]string, len(defs))
+	queries := make(map[string]string, len(defs))
+	for _, def := range defs {
+		if def != nil {
+			units[def.Name] = def.Unit
+			queries[def.Name] = def.Query
+		}
+	}
+
+	var records []*RawDataRecord
+	for _, host := range result.Hosts {
+		if host == nil {
+			continue
+		}
+		for _, name := range sortedKeys(host.Metrics) {
+			mv := host.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			baseName := rawDataBaseMetric(name)
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleHost,
+				Target:    host.Hostname,
+				Metric:    name,
+				Value:     mv.RawValue,
+				Unit:      units[baseName],
+				Status:    mv.Status,
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, host.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+				Query:     queries[baseName],
+			})
+		}
+	}
+	return records
+}
+
+// SetRawDataQueries fills the query of the records of module from queries, keyed
+// by metric name, so auditors can re-run the query behind a reported number.
+// Records with a query already set are left unchanged.
+func SetRawDataQueries(records []*RawDataRecord, module string, queries map[string]string) {
+	for _, rec := range records {
+		if rec == nil || rec.Module != module || rec.Query != "" {
+			continue
+		}
+		rec.Query = queries[rawDataBaseMetric(rec.Metric)]
+	}
+}
+
+// NewMySQLRawDataRecords flattens MySQL inspection results into raw data records.
+// Metric status is derived from the instance alerts.
+func NewMySQLRawDataRecords(result *MySQLInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleMySQL,
+				Target:    r.GetAddress(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewRedisRawDataRecords flattens Redis inspection results into raw data records.
+// Metric status is derived from the instance alerts.
+func NewRedisRawDataRecords(result *RedisInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleRedis,
+				Target:    r.GetAddress(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewNginxRawDataRecords flattens Nginx inspection results into raw data records.
+// Metric status is derived from the instance alerts.
+func NewNginxRawDataRecords(result *NginxInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleNginx,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewTomcatRawDataRecords flattens Tomcat inspection results into raw data records.
+// Metric status is derived from the instance alerts.
+func NewTomcatRawDataRecords(result *TomcatInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleTomcat,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewCassandraRawDataRecords flattens Cassandra inspection results into raw data records.
+// Metric status is derived from the node alerts.
+func NewCassandraRawDataRecords(result *CassandraInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleCassandra,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewMonitoringRawDataRecords flattens monitoring stack inspection results into raw data records.
+// Metric status is derived from the component alerts.
+func NewMonitoringRawDataRecords(result *MonitoringInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleMonitoring,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewStorageRawDataRecords flattens shared storage inspection results into raw data records.
+// Metric status is derived from the component alerts.
+func NewStorageRawDataRecords(result *StorageInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleStorage,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewLogCheckRawDataRecords flattens log check results into raw data records,
+// one record per check (or check group) holding the matched entry count.
+func NewLogCheckRawDataRecords(result *LogCheckInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil || r.Instance == nil {
+			continue
+		}
+		var level AlertLevel
+		for _, alert := range r.Alerts {
+			level = alert.Level
+		}
+		var labels map[string]string
+		if r.Instance.Group != "" {
+			labels = map[string]string{"group": r.Instance.Group}
+		}
+		records = append(records, &RawDataRecord{
+			Module:    RawDataModuleLogChecks,
+			Target:    r.GetIdentifier(),
+			Metric:    r.Instance.CheckName,
+			Value:     r.Count,
+			Unit:      "条",
+			Status:    rawDataStatus(!r.HasCount(), level),
+			IsNA:      !r.HasCount(),
+			Timestamp: rawDataTimestamp(0, r.CollectedAt, result.InspectionTime),
+			Labels:    labels,
+		})
+	}
+	return records
+}
+
+// NewLVSRawDataRecords flattens LVS inspection results into raw data records.
+// Metric status is derived from the director alerts.
+func NewLVSRawDataRecords(result *LVSInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleLVS,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewWindowsRawDataRecords flattens Windows inspection results into raw data records.
+// Metric status is derived from the host alerts.
+func NewWindowsRawDataRecords(result *WindowsInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleWindows,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewADRawDataRecords flattens AD inspection results into raw data records.
+// Metric status is derived from the domain controller alerts.
+func NewADRawDataRecords(result *ADInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleAD,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewCloudRawDataRecords flattens cloud resource inspection results into raw data records.
+// Metric status is derived from the cloud resource alerts.
+func NewCloudRawDataRecords(result *CloudInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleCloud,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, levels[name]),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// NewFirewallRawDataRecords flattens firewall inspection results into raw data records.
+// Metric status is derived from the device alerts; a per-tunnel metric such as
+// "vpn_tunnel_status:to-branch" takes the level of the tunnel alert only when the tunnel is down.
+func NewFirewallRawDataRecords(result *FirewallInspectionResults) []*RawDataRecord {
+	if result == nil {
+		return nil
+	}
+
+	var records []*RawDataRecord
+	for _, r := range result.Results {
+		if r == nil {
+			continue
+		}
+		levels := make(map[string]AlertLevel, len(r.Alerts))
+		for _, alert := range r.Alerts {
+			levels[alert.MetricName] = alert.Level
+		}
+		for _, name := range sortedKeys(r.Metrics) {
+			mv := r.Metrics[name]
+			if mv == nil {
+				continue
+			}
+			level := levels[name]
+			if baseName := rawDataBaseMetric(name); baseName != name && mv.RawValue < 1 {
+				level = levels[baseName]
+			}
+			records = append(records, &RawDataRecord{
+				Module:    RawDataModuleFirewall,
+				Target:    r.GetIdentifier(),
+				Metric:    name,
+				Value:     mv.RawValue,
+				Text:      mv.StringValue,
+				Status:    rawDataStatus(mv.IsNA, level),
+				IsNA:      mv.IsNA,
+				Timestamp: rawDataTimestamp(mv.Timestamp, r.CollectedAt, result.InspectionTime),
+				Labels:    mv.Labels,
+			})
+		}
+	}
+	return records
+}
+
+// rawDataStatus converts an alert level into a metric status.
+// N/A metrics are always reported as pending.
+func rawDataStatus(isNA bool, level AlertLevel) MetricStatus {
+	if isNA {
+		return MetricStatusPending
+	}
+	switch level {
+	case AlertLevelCritical:
+		return MetricStatusCritical
+	case AlertLevelWarning:
+		return MetricStatusWarning
+	default:
+		return MetricStatusNormal
+	}
+}
+
+// rawDataTimestamp picks the most precise timestamp available:
+// metric timestamp, then collection time, then inspection start time.
+func rawDataTimestamp(unix int64, collectedAt, inspectionTime time.Time) time.Time {
+	if unix > 0 {
+		return time.Unix(unix, 0)
+	}
+	if !collectedAt.IsZero() {
+		return collectedAt
+	}
+	return inspectionTime
+}
+
+// rawDataBaseMetric returns the base metric of an expanded metric name
+// (e.g. "disk_usage" for "disk_usage:/home").
+func rawDataBaseMetric(name string) string {
+	if idx := strings.Index(name, ":"); idx > 0 {
+		return name[:idx]
+	}
+	return name
+}
+
+// sortedKeys returns the keys of a metric map in ascending order,
+// so raw data output is stable across runs.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,182 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Raw Data Record Tests
+// ============================================================================
+
+func TestNewHostRawDataRecords(t *testing.T) {
+	inspectionTime := time.Date(2025, 12, 13, 10, 0, 0, 0, time.UTC)
+	collectedAt := inspectionTime.Add(5 * time.Second)
+
+	result := &InspectionResult{
+		InspectionTime: inspectionTime,
+		Hosts: []*HostResult{
+			{
+				Hostname:    "host-1",
+				CollectedAt: collectedAt,
+				Metrics: map[string]*MetricValue{
+					"memory_usage": {Name: "memory_usage", RawValue: 60, Status: MetricStatusNormal},
+					"cpu_usage":    {Name: "cpu_usage", RawValue: 95, Status: MetricStatusCritical, Timestamp: 1765591200},
+					"disk_usage:/home": {
+						Name:     "disk_usage:/home",
+						RawValue: 75,
+						Status:   MetricStatusWarning,
+						Labels:   map[string]string{"path": "/home"},
+					},
+				},
+			},
+		},
+	}
+	defs := []*MetricDefinition{
+		{Name: "cpu_usage", Unit: "%", Query: "cpu_query"},
+		{Name: "memory_usage", Unit: "%", Query: "memory_query"},
+		{Name: "disk_usage", Unit: "%", Query: "disk_query"},
+	}
+
+	records := NewHostRawDataRecords(result, defs)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	// Records are sorted by metric name
+	wantMetrics := []string{"cpu_usage", "disk_usage:/home", "memory_usage"}
+	for i, want := range wantMetrics {
+		if records[i].Metric != want {
+			t.Errorf("records[%d].Metric = %q, want %q", i, records[i].Metric, want)
+		}
+		if records[i].Module != RawDataModuleHost {
+			t.Errorf("records[%d].Module = %q, want %q", i, records[i].Module, RawDataModuleHost)
+		}
+		if records[i].Target != "host-1" {
+			t.Errorf("records[%d].Target = %q, want host-1", i, records[i].Target)
+		}
+		if records[i].Unit != "%" {
+			t.Errorf("records[%d].Unit = %q, want %%", i, records[i].Unit)
+		}
+	}
+
+	if records[0].Status != MetricStatusCritical {
+		t.Errorf("cpu_usage status = %q, want critical", records[0].Status)
+	}
+	if !records[0].Timestamp.Equal(time.Unix(1765591200, 0)) {
+		t.Errorf("cpu_usage timestamp = %v, want metric timestamp", records[0].Timestamp)
+	}
+	if !records[2].Timestamp.Equal(collectedAt) {
+		t.Errorf("memory_usage timestamp = %v, want collectedAt %v", records[2].Timestamp, collectedAt)
+	}
+	if records[1].Labels["path"] != "/home" {
+		t.Errorf("disk_usage:/home labels = %v, want path=/home", records[1].Labels)
+	}
+	if records[1].Query != "disk_query" {
+		t.Errorf("disk_usage:/home query = %q, want the disk_usage query", records[1].Query)
+	}
+}
+
+func TestSetRawDataQueries(t *testing.T) {
+	records := []*RawDataRecord{
+		{Module: RawDataModuleMySQL, Metric: "connection_usage"},
+		{Module: RawDataModuleMySQL, Metric: "unknown_metric"},
+		{Module: RawDataModuleRedis, Metric: "connection_usage"},
+		{Module: RawDataModuleMySQL, Metric: "slow_queries", Query: "kept"},
+		nil,
+	}
+
+	SetRawDataQueries(records, RawDataModuleMySQL, map[string]string{
+		"connection_usage": "mysql_query",
+		"slow_queries":     "other_query",
+	})
+
+	want := []string{"mysql_query", "", "", "kept"}
+	for i, w := range want {
+		if records[i].Query != w {
+			t.Errorf("records[%d].Query = %q, want %q", i, records[i].Query, w)
+		}
+	}
+}
+
+func TestNewHostRawDataRecords_Nil(t *testing.T) {
+	if records := NewHostRawDataRecords(nil, nil); records != nil {
+		t.Errorf("expected nil records, got %d", len(records))
+	}
+}
+
+func TestNewMySQLRawDataRecords(t *testing.T) {
+	inspectionTime := time.Date(2025, 12, 13, 10, 0, 0, 0, time.UTC)
+
+	result := &MySQLInspectionResults{
+		InspectionTime: inspectionTime,
+		Results: []*MySQLInspectionResult{
+			{
+				Instance: &MySQLInstance{Address: "172.18.182.91:3306"},
+				Metrics: map[string]*MySQLMetricValue{
+					"threads_running":     {Name: "threads_running", RawValue: 80},
+					"mysql_version":       {Name: "mysql_version", StringValue: "8.0.39"},
+					"non_root_user":       {Name: "non_root_user", IsNA: true},
+					"current_connections": {Name: "current_connections", RawValue: 100},
+				},
+				Alerts: []*MySQLAlert{
+					{MetricName: "threads_running", Level: AlertLevelCritical},
+				},
+			},
+		},
+	}
+
+	records := NewMySQLRawDataRecords(result)
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(records))
+	}
+
+	byMetric := make(map[string]*RawDataRecord)
+	for _, r := range records {
+		if r.Module != RawDataModuleMySQL {
+			t.Errorf("Module = %q, want %q", r.Module, RawDataModuleMySQL)
+		}
+		if r.Target != "172.18.182.91:3306" {
+			t.Errorf("Target = %q, want 172.18.182.91:3306", r.Target)
+		}
+		if !r.Timestamp.Equal(inspectionTime) {
+			t.Errorf("Timestamp = %v, want inspection time fallback", r.Timestamp)
+		}
+		byMetric[r.Metric] = r
+	}
+
+	if got := byMetric["threads_running"].Status; got != MetricStatusCritical {
+		t.Errorf("threads_running status = %q, want critical", got)
+	}
+	if got := byMetric["current_connections"].Status; got != MetricStatusNormal {
+		t.Errorf("current_connections status = %q, want normal", got)
+	}
+	if got := byMetric["non_root_user"].Status; got != MetricStatusPending {
+		t.Errorf("non_root_user status = %q, want pending", got)
+	}
+	if got := byMetric["mysql_version"].Text; got != "8.0.39" {
+		t.Errorf("mysql_version text = %q, want 8.0.39", got)
+	}
+}
+
+func TestRawDataStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		isNA     bool
+		level    AlertLevel
+		expected MetricStatus
+	}{
+		{"no alert", false, "", MetricStatusNormal},
+		{"warning", false, AlertLevelWarning, MetricStatusWarning},
+		{"critical", false, AlertLevelCritical, MetricStatusCritical},
+		{"N/A overrides alert", true, AlertLevelCritical, MetricStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rawDataStatus(tt.isNA, tt.level); got != tt.expected {
+				t.Errorf("rawDataStatus(%v, %q) = %q, want %q", tt.isNA, tt.level, got, tt.expected)
+			}
+		})
+	}
+}
@@ -172,15 +172,16 @@ func (r *RedisInstance) String() string {
 
 // RedisAlert represents a threshold violation alert for a Redis instance.
 type RedisAlert struct {
-	Address           string     `json:"address"`             // 实例地址 (IP:Port)
-	MetricName        string     `json:"metric_name"`         // 指标名称
-	MetricDisplayName string     `json:"metric_display_name"` // 指标中文显示名称
-	CurrentValue      float64    `json:"current_value"`       // 当前值
-	FormattedValue    string     `json:"formatted_value"`     // 格式化后的当前值
-	WarningThreshold  float64    `json:"warning_threshold"`   // 警告阈值
-	CriticalThreshold float64    `json:"critical_threshold"`  // 严重阈值
-	Level             AlertLevel `json:"level"`               // 告警级别 (复用 alert.go 的 AlertLevel)
-	Message           string     `json:"message"`             // 告警消息
+	Address           string     `json:"address"`                  // 实例地址 (IP:Port)
+	MetricName        string     `json:"metric_name"`              // 指标名称
+	MetricDisplayName string     `json:"metric_display_name"`      // 指标中文显示名称
+	CurrentValue      float64    `json:"current_value"`            // 当前值
+	FormattedValue    string     `json:"formatted_value"`          // 格式化后的当前值
+	WarningThreshold  float64    `json:"warning_threshold"`        // 警告阈值
+	CriticalThreshold float64    `json:"critical_threshold"`       // 严重阈值
+	Level             AlertLevel `json:"level"`                    // 告警级别 (复用 alert.go 的 AlertLevel)
+	Message           string     `json:"message"`                  // 告警消息
+	ExpectedValue     string     `json:"expected_value,omitempty"` // 期望值（仅字符串/枚举检查）
 }
 
 // NewRedisAlert creates a new RedisAlert with the given parameters.
@@ -248,6 +249,16 @@ type RedisInspectionResult struct {
 	// master 节点
 	ConnectedSlaves int `json:"connected_slaves"` // 连接的 slave 数量
 
+	// 内存相关
+	UsedMemory            int64   `json:"used_memory"`             // 已用内存（字节）
+	MaxMemory             int64   `json:"max_memory"`              // maxmemory 配置（字节），0 表示未限制
+	MemFragmentationRatio float64 `json:"mem_fragmentation_ratio"` // 内存碎片率
+
+	// Keyspace 相关（基于计数器速率计算）
+	EvictedKeysPerSecond float64 `json:"evicted_keys_per_second"` // 每秒驱逐 key 数
+	ExpiredKeysPerSecond float64 `json:"expired_keys_per_second"` // 每秒过期 key 数
+	KeyspaceHitRatio     float64 `json:"keyspace_hit_ratio"`      // 命中率（%），无访问时为 0
+
 	// 运行时间
 	Uptime int64 `json:"uptime"` // 秒
 
@@ -313,6 +324,22 @@ func (r *RedisInspectionResult) GetConnectionUsagePercent() float64 {
 	return float64(r.ConnectedClients) / float64(r.MaxClients) * 100
 }
 
+// GetMemoryUsagePercent calculates used_memory as a percentage of maxmemory.
+// Returns 0 if MaxMemory is 0 (unlimited).
+func (r *RedisInspectionResult) GetMemoryUsagePercent() float64 {
+	if r.MaxMemory == 0 {
+		return 0
+	}
+	return float64(r.UsedMemory) / float64(r.MaxMemory) * 100
+}
+
+// HasKeyspaceHitRatio returns true if the keyspace hit ratio was calculated
+// (hit/miss metrics collected and at least one lookup in the window).
+func (r *RedisInspectionResult) HasKeyspaceHitRatio() bool {
+	m := r.GetMetric("redis_keyspace_hit_ratio")
+	return m != nil && !m.IsNA
+}
+
 // GetAddress returns the instance address, or empty string if instance is nil.
 func (r *RedisInspectionResult) GetAddress() string {
 	if r.Instance == nil {
@@ -568,13 +595,14 @@ func (r *RedisInspectionResults) HasMultipleClusters() bool {
 
 // RedisMetricDefinition defines a Redis metric from redis-metrics.yaml.
 type RedisMetricDefinition struct {
-	Name        string `yaml:"name"`         // Metric unique identifier
-	DisplayName string `yaml:"display_name"` // Chinese display name
-	Query       string `yaml:"query"`        // PromQL query expression
-	Category    string `yaml:"category"`     // Category: connection, cluster, replication, status, info, security
-	Format      string `yaml:"format"`       // Format type: size, duration, percent (optional)
-	Status      string `yaml:"status"`       // Status: pending = not yet implemented (optional)
-	Note        string `yaml:"note"`         // Note/description
+	Name         string `yaml:"name"`          // Metric unique identifier
+	DisplayName  string `yaml:"display_name"`  // Chinese display name
+	Query        string `yaml:"query"`         // PromQL query expression
+	Category     string `yaml:"category"`      // Category: connection, cluster, replication, status, info, security
+	Format       string `yaml:"format"`        // Format type: size, duration, percent (optional)
+	Status       string `yaml:"status"`        // Status: pending = not yet implemented (optional)
+	Note         string `yaml:"note"`          // Note/description
+	LabelExtract string `yaml:"label_extract"` // Extract string value from a label (optional)
 }
 
 // IsPending returns true if this metric is not yet implemented.
@@ -674,3 +702,62 @@ func (c *RedisCluster) GetSlaveCount() int {
 	}
 	return count
 }
+
+// ReplicationGroups returns the master → slave replication groups of this cluster.
+func (c *RedisCluster) ReplicationGroups() []*RedisReplicationGroup {
+	return GroupRedisReplication(c.Instances)
+}
+
+// RedisReplicationGroup is a master and the slaves replicating from it.
+// Master is nil for the slaves whose master could not be identified.
+type RedisReplicationGroup struct {
+	Master     *RedisInspectionResult   // Master node (nil when not identified)
+	MasterPort int                      // Master port reported by the slaves
+	Slaves     []*RedisInspectionResult // Slaves, in instance order
+}
+
+// GroupRedisReplication groups the instances by replication: one group per master, in
+// instance order, with the slaves whose master port (redis_master_port) matches the port of
+// exactly one master. Slaves report the master port only, so when several masters share the
+// port (e.g. one master on port 7000 per host) or none matches, the slaves are grouped by
+// master port in groups without master, after the master groups. Instances of unknown role
+// are left out.
+func GroupRedisReplication(results []*RedisInspectionResult) []*RedisReplicationGroup {
+	var groups []*RedisReplicationGroup
+	mastersByPort := make(map[int][]*RedisReplicationGroup)
+	for _, r := range results {
+		if r == nil || r.Instance == nil || !r.Instance.Role.IsMaster() {
+			continue
+		}
+		group := &RedisReplicationGroup{Master: r, MasterPort: r.Instance.Port}
+		groups = append(groups, group)
+		mastersByPort[r.Instance.Port] = append(mastersByPort[r.Instance.Port], group)
+	}
+
+	unresolved := make(map[int]*RedisReplicationGroup)
+	for _, r := range results {
+		if r == nil || r.Instance == nil || !r.Instance.Role.IsSlave() {
+			continue
+		}
+		if masters := mastersByPort[r.MasterPort]; len(masters) == 1 {
+			masters[0].Slaves = append(masters[0].Slaves, r)
+			continue
+		}
+		group, ok := unresolved[r.MasterPort]
+		if !ok {
+			group = &RedisReplicationGroup{MasterPort: r.MasterPort}
+			unresolved[r.MasterPort] = group
+		}
+		group.Slaves = append(group.Slaves, r)
+	}
+
+	ports := make([]int, 0, len(unresolved))
+	for port := range unresolved {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	for _, port := range ports {
+		groups = append(groups, unresolved[port])
+	}
+	return groups
+}
@@ -509,3 +509,35 @@ func TestRedisInspectionResults_GroupByClusters_ShanxiScenario(t *testing.T) {
 		t.Errorf("cluster 2 should have 3 slaves, got %d", cluster2.GetSlaveCount())
 	}
 }
+
+func TestGroupRedisReplication(t *testing.T) {
+	node := func(address string, role RedisRole, masterPort int) *RedisInspectionResult {
+		r := NewRedisInspectionResult(NewRedisInstanceWithRole(address, role))
+		r.MasterPort = masterPort
+		return r
+	}
+	results := []*RedisInspectionResult{
+		node("10.0.0.1:6379", RedisRoleMaster, 0),
+		node("10.0.0.2:6380", RedisRoleSlave, 6379),
+		node("10.0.0.3:6380", RedisRoleSlave, 6379),
+		// Port 7000 is shared by two masters: the slave cannot be matched
+		node("10.0.0.1:7000", RedisRoleMaster, 0),
+		node("10.0.0.2:7000", RedisRoleMaster, 0),
+		node("10.0.0.3:7001", RedisRoleSlave, 7000),
+		node("10.0.0.4:7002", RedisRoleUnknown, 0),
+	}
+
+	groups := GroupRedisReplication(results)
+	if len(groups) != 4 {
+		t.Fatalf("len(groups) = %d, want 4 (3 masters + 1 unresolved)", len(groups))
+	}
+	if groups[0].Master != results[0] || len(groups[0].Slaves) != 2 {
+		t.Errorf("first group = %+v, want the 6379 master with its 2 slaves", groups[0])
+	}
+	if len(groups[1].Slaves) != 0 || len(groups[2].Slaves) != 0 {
+		t.Error("masters sharing a port should not get the ambiguous slave")
+	}
+	if last := groups[3]; last.Master != nil || last.MasterPort != 7000 || len(last.Slaves) != 1 || last.Slaves[0] != results[5] {
+		t.Errorf("unresolved group = %+v, want the 7001 slave under master port 7000", last)
+	}
+}
@@ -0,0 +1,128 @@
+package model
+
+import "time"
+
+// RollupResult is the cross-site roll-up of the inspection runs of several sites,
+// used for the executive report of the rollup command.
+type RollupResult struct {
+	GeneratedAt time.Time     `json:"generated_at"` // 汇总生成时间
+	Sites       []*RollupSite `json:"sites"`        // 各站点汇总，按输入顺序
+}
+
+// RollupSite is the roll-up of one site's inspection run.
+type RollupSite struct {
+	Name           string          `json:"name"`              // 站点名称
+	Source         string          `json:"source"`            // 巡检结果文件路径
+	InspectionTime time.Time       `json:"inspection_time"`   // 巡检时间（报告生成时间）
+	Version        string          `json:"version,omitempty"` // 工具版本号
+	Modules        []*RollupModule `json:"modules"`           // 各模块汇总，按报告顺序
+}
+
+// RollupModule is the roll-up of one module of a site: its object counts by status and
+// its alert counts by level.
+type RollupModule struct {
+	Module         string `json:"module"`          // 模块名称（主机、MySQL 等）
+	Total          int    `json:"total"`           // 对象总数
+	Normal         int    `json:"normal"`          // 正常对象数
+	Warning        int    `json:"warning"`         // 警告对象数
+	Critical       int    `json:"critical"`        // 严重对象数
+	Failed         int    `json:"failed"`          // 失败对象数
+	WarningAlerts  int    `json:"warning_alerts"`  // 警告告警数
+	CriticalAlerts int    `json:"critical_alerts"` // 严重告警数
+}
+
+// Status returns the status of the module: the most severe status of its objects.
+// Failed objects rank below warnings, since they were not inspected rather than found faulty.
+func (m *RollupModule) Status() HostStatus {
+	switch {
+	case m.Critical > 0:
+		return HostStatusCritical
+	case m.Warning > 0:
+		return HostStatusWarning
+	case m.Failed > 0:
+		return HostStatusFailed
+	default:
+		return HostStatusNormal
+	}
+}
+
+// Module returns the roll-up of the named module, or nil if the site did not inspect it.
+func (s *RollupSite) Module(name string) *RollupModule {
+	for _, m := range s.Modules {
+		if m.Module == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Status returns the overall status of the site: the most severe status of its modules.
+func (s *RollupSite) Status() HostStatus {
+	status := HostStatusNormal
+	for _, m := range s.Modules {
+		if ms := m.Status(); rollupStatusPriority(ms) > rollupStatusPriority(status) {
+			status = ms
+		}
+	}
+	return status
+}
+
+// AlertCounts returns the number of warning and critical alerts of the site.
+func (s *RollupSite) AlertCounts() (warning, critical int) {
+	for _, m := range s.Modules {
+		warning += m.WarningAlerts
+		critical += m.CriticalAlerts
+	}
+	return warning, critical
+}
+
+// Modules returns the names of the modules inspected by any site, in the order they
+// first appear.
+func (r *RollupResult) Modules() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, site := range r.Sites {
+		for _, m := range site.Modules {
+			if !seen[m.Module] {
+				seen[m.Module] = true
+				names = append(names, m.Module)
+			}
+		}
+	}
+	return names
+}
+
+// ModuleTotal sums the roll-up of the named module over all sites. Sites is the number
+// of sites that inspected the module.
+func (r *RollupResult) ModuleTotal(name string) (total *RollupModule, sites int) {
+	total = &RollupModule{Module: name}
+	for _, site := range r.Sites {
+		m := site.Module(name)
+		if m == nil {
+			continue
+		}
+		sites++
+		total.Total += m.Total
+		total.Normal += m.Normal
+		total.Warning += m.Warning
+		total.Critical += m.Critical
+		total.Failed += m.Failed
+		total.WarningAlerts += m.WarningAlerts
+		total.CriticalAlerts += m.CriticalAlerts
+	}
+	return total, sites
+}
+
+// rollupStatusPriority orders statuses by severity for RollupSite.Status.
+func rollupStatusPriority(status HostStatus) int {
+	switch status {
+	case HostStatusCritical:
+		return 3
+	case HostStatusWarning:
+		return 2
+	case HostStatusFailed:
+		return 1
+	default:
+		return 0
+	}
+}
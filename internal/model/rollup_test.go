@@ -0,0 +1,53 @@
+package model
+
+import "testing"
+
+func TestRollupResult(t *testing.T) {
+	result := &RollupResult{
+		Sites: []*RollupSite{
+			{
+				Name: "北京",
+				Modules: []*RollupModule{
+					{Module: "主机", Total: 10, Normal: 9, Warning: 1, WarningAlerts: 2},
+					{Module: "MySQL", Total: 2, Normal: 2},
+				},
+			},
+			{
+				Name: "上海",
+				Modules: []*RollupModule{
+					{Module: "主机", Total: 5, Normal: 3, Critical: 1, Failed: 1, CriticalAlerts: 1},
+					{Module: "Redis", Total: 3, Normal: 2, Failed: 1},
+				},
+			},
+		},
+	}
+
+	if got := result.Modules(); len(got) != 3 || got[0] != "主机" || got[1] != "MySQL" || got[2] != "Redis" {
+		t.Errorf("Modules() = %v, want [主机 MySQL Redis]", got)
+	}
+
+	total, sites := result.ModuleTotal("主机")
+	if sites != 2 || total.Total != 15 || total.Critical != 1 || total.WarningAlerts != 2 || total.CriticalAlerts != 1 {
+		t.Errorf("ModuleTotal(主机) = %+v over %d sites", total, sites)
+	}
+	if _, sites := result.ModuleTotal("Nginx"); sites != 0 {
+		t.Errorf("ModuleTotal(Nginx) sites = %d, want 0", sites)
+	}
+
+	beijing, shanghai := result.Sites[0], result.Sites[1]
+	if got := beijing.Status(); got != HostStatusWarning {
+		t.Errorf("北京 Status() = %s, want warning", got)
+	}
+	if got := shanghai.Status(); got != HostStatusCritical {
+		t.Errorf("上海 Status() = %s, want critical", got)
+	}
+	if got := shanghai.Module("Redis").Status(); got != HostStatusFailed {
+		t.Errorf("上海 Redis Status() = %s, want failed", got)
+	}
+	if beijing.Module("Redis") != nil {
+		t.Error("Module() should return nil for a module the site did not inspect")
+	}
+	if warning, critical := shanghai.AlertCounts(); warning != 0 || critical != 1 {
+		t.Errorf("上海 AlertCounts() = %d, %d, want 0, 1", warning, critical)
+	}
+}
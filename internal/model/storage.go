@@ -0,0 +1,400 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// 共享存储主机状态枚举
+// =============================================================================
+
+type StorageInstanceStatus string
+
+const (
+	StorageStatusNormal   StorageInstanceStatus = "normal"
+	StorageStatusWarning  StorageInstanceStatus = "warning"
+	StorageStatusCritical StorageInstanceStatus = "critical"
+	StorageStatusFailed   StorageInstanceStatus = "failed"
+)
+
+func (s StorageInstanceStatus) IsHealthy() bool {
+	return s == StorageStatusNormal
+}
+
+func (s StorageInstanceStatus) IsWarning() bool {
+	return s == StorageStatusWarning
+}
+
+func (s StorageInstanceStatus) IsCritical() bool {
+	return s == StorageStatusCritical
+}
+
+func (s StorageInstanceStatus) IsFailed() bool {
+	return s == StorageStatusFailed
+}
+
+// =============================================================================
+// 共享存储主机结构体
+// =============================================================================
+
+// StorageInstance represents a host taking part in shared storage, either as a
+// client with NFS/GlusterFS mounts, an NFS server or a GlusterFS peer.
+// Hosts are identified by hostname.
+type StorageInstance struct {
+	Identifier string   `json:"identifier"`
+	Hostname   string   `json:"hostname"`
+	IP         string   `json:"ip"`
+	Roles      []string `json:"roles"` // 角色（如 挂载客户端、NFS 服务端、GlusterFS 节点）
+}
+
+func NewStorageInstance(hostname string) *StorageInstance {
+	return &StorageInstance{
+		Identifier: hostname,
+		Hostname:   hostname,
+	}
+}
+
+func (i *StorageInstance) SetIP(ip string) {
+	if i == nil {
+		return
+	}
+	i.IP = ip
+}
+
+// AddRole adds a role to the host, ignoring duplicates.
+func (i *StorageInstance) AddRole(role string) {
+	if i == nil || role == "" {
+		return
+	}
+	for _, r := range i.Roles {
+		if r == role {
+			return
+		}
+	}
+	i.Roles = append(i.Roles, role)
+}
+
+// RoleText returns the roles joined for display.
+func (i *StorageInstance) RoleText() string {
+	if i == nil {
+		return ""
+	}
+	return strings.Join(i.Roles, ", ")
+}
+
+func (i *StorageInstance) String() string {
+	if i == nil {
+		return "StorageInstance(nil)"
+	}
+	return fmt.Sprintf("StorageInstance(%s)", i.Identifier)
+}
+
+// =============================================================================
+// 共享存储挂载点结构体
+// =============================================================================
+
+// StorageMount represents a single NFS or GlusterFS mount on a client host.
+type StorageMount struct {
+	MountPoint string `json:"mount_point"`
+	FSType     string `json:"fs_type"`
+	Device     string `json:"device"` // 远端导出路径（如 10.0.0.1:/export/data）
+	Stale      bool   `json:"stale"`  // 挂载失效（statfs 失败，如 NFS stale file handle）
+}
+
+// String returns the mount in "mountpoint (fstype, device)" form.
+func (m *StorageMount) String() string {
+	if m == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s, %s)", m.MountPoint, m.FSType, m.Device)
+}
+
+// =============================================================================
+// 共享存储主机告警结构体
+// =============================================================================
+
+type StorageAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewStorageAlert(identifier, metricName string, currentValue float64, level AlertLevel) *StorageAlert {
+	return &StorageAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *StorageAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *StorageAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// 共享存储主机指标值结构体
+// =============================================================================
+
+type StorageMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// 共享存储主机巡检结果结构体
+// =============================================================================
+
+type StorageInspectionResult struct {
+	Instance                 *StorageInstance               `json:"instance"`
+	Mounts                   []*StorageMount                `json:"mounts,omitempty"`           // NFS/GlusterFS 挂载点
+	StaleMounts              int                            `json:"stale_mounts"`               // 失效挂载点数量
+	GlusterPeersDisconnected float64                        `json:"gluster_peers_disconnected"` // GlusterFS 断开的 peer 数（-1 表示未采集）
+	GlusterBricksOffline     float64                        `json:"gluster_bricks_offline"`     // GlusterFS 离线 brick 数（-1 表示未采集）
+	NFSRPCErrors             float64                        `json:"nfs_rpc_errors"`             // NFS 服务端近 5 分钟 RPC 错误数（-1 表示未采集）
+	Metrics                  map[string]*StorageMetricValue `json:"-"`                          // 指标映射（内部使用，不序列化）
+	Status                   StorageInstanceStatus          `json:"status"`
+	Alerts                   []*StorageAlert                `json:"alerts,omitempty"`
+	CollectedAt              time.Time                      `json:"collected_at"`
+	Error                    string                         `json:"error,omitempty"`
+}
+
+func NewStorageInspectionResult(instance *StorageInstance) *StorageInspectionResult {
+	return &StorageInspectionResult{
+		Instance:                 instance,
+		Status:                   StorageStatusNormal,
+		Mounts:                   make([]*StorageMount, 0),
+		Alerts:                   make([]*StorageAlert, 0),
+		GlusterPeersDisconnected: -1,
+		GlusterBricksOffline:     -1,
+		NFSRPCErrors:             -1,
+	}
+}
+
+func (r *StorageInspectionResult) AddAlert(alert *StorageAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *StorageInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *StorageInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// AddMount adds a mount point to the result.
+func (r *StorageInspectionResult) AddMount(mount *StorageMount) {
+	if r == nil || mount == nil {
+		return
+	}
+	r.Mounts = append(r.Mounts, mount)
+}
+
+// StaleMountPoints returns the mount points of all stale mounts.
+func (r *StorageInspectionResult) StaleMountPoints() []string {
+	if r == nil {
+		return nil
+	}
+	var points []string
+	for _, m := range r.Mounts {
+		if m.Stale {
+			points = append(points, m.MountPoint)
+		}
+	}
+	return points
+}
+
+// HasGlusterPeers returns true if the GlusterFS peer status was collected.
+func (r *StorageInspectionResult) HasGlusterPeers() bool {
+	return r != nil && r.GlusterPeersDisconnected >= 0
+}
+
+// HasGlusterBricks returns true if the GlusterFS brick status was collected.
+func (r *StorageInspectionResult) HasGlusterBricks() bool {
+	return r != nil && r.GlusterBricksOffline >= 0
+}
+
+// HasNFSRPCErrors returns true if the NFS server RPC error count was collected.
+func (r *StorageInspectionResult) HasNFSRPCErrors() bool {
+	return r != nil && r.NFSRPCErrors >= 0
+}
+
+func (r *StorageInspectionResult) SetMetric(mv *StorageMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*StorageMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *StorageInspectionResult) GetMetric(name string) *StorageMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// 共享存储主机巡检摘要结构体
+// =============================================================================
+
+type StorageInspectionSummary struct {
+	TotalInstances    int `json:"total_instances"`
+	NormalInstances   int `json:"normal_instances"`
+	WarningInstances  int `json:"warning_instances"`
+	CriticalInstances int `json:"critical_instances"`
+	FailedInstances   int `json:"failed_instances"`
+	StaleMounts       int `json:"stale_mounts"` // 所有主机的失效挂载点总数
+}
+
+func NewStorageInspectionSummary(results []*StorageInspectionResult) *StorageInspectionSummary {
+	summary := &StorageInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case StorageStatusNormal:
+			summary.NormalInstances++
+		case StorageStatusWarning:
+			summary.WarningInstances++
+		case StorageStatusCritical:
+			summary.CriticalInstances++
+		case StorageStatusFailed:
+			summary.FailedInstances++
+		}
+
+		summary.StaleMounts += result.StaleMounts
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 共享存储主机告警摘要结构体
+// =============================================================================
+
+type StorageAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewStorageAlertSummary(alerts []*StorageAlert) *StorageAlertSummary {
+	summary := &StorageAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// 共享存储主机完整巡检结果容器
+// =============================================================================
+
+type StorageInspectionResults struct {
+	InspectionTime time.Time                  `json:"inspection_time"`
+	Duration       time.Duration              `json:"duration"`
+	Summary        *StorageInspectionSummary  `json:"summary"`
+	Results        []*StorageInspectionResult `json:"results"`
+	Alerts         []*StorageAlert            `json:"alerts"`
+	AlertSummary   *StorageAlertSummary       `json:"alert_summary"`
+	Version        string                     `json:"version,omitempty"`
+}
+
+func NewStorageInspectionResults(inspectionTime time.Time) *StorageInspectionResults {
+	return &StorageInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*StorageInspectionResult, 0),
+		Alerts:         make([]*StorageAlert, 0),
+	}
+}
+
+func (r *StorageInspectionResults) AddResult(result *StorageInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *StorageInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewStorageInspectionSummary(r.Results)
+	r.AlertSummary = NewStorageAlertSummary(r.Alerts)
+}
+
+func (r *StorageInspectionResults) GetResultByIdentifier(identifier string) *StorageInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *StorageInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *StorageInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *StorageInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
@@ -148,6 +148,7 @@ type TomcatAlert struct {
 	CriticalThreshold float64    `json:"critical_threshold"`
 	Level             AlertLevel `json:"level"`
 	Message           string     `json:"message"`
+	LogExcerpt        []string   `json:"log_excerpt,omitempty"` // 日志摘录（仅严重告警，需启用 log_excerpt）
 }
 
 func NewTomcatAlert(identifier, metricName string, currentValue float64, level AlertLevel) *TomcatAlert {
@@ -194,6 +195,9 @@ type TomcatInspectionResult struct {
 	LastErrorTimestamp     int64                   `json:"last_error_timestamp"`
 	LastErrorTimeFormatted string                  `json:"last_error_time_formatted"`
 	NonRootUser            bool                    `json:"non_root_user"`
+	HeapUsagePercent       float64                 `json:"heap_usage_percent"`        // JVM 堆内存使用率（-1 表示未采集）
+	GCPauseAvgMs           float64                 `json:"gc_pause_avg_ms"`           // 平均 GC 暂停时间，毫秒（-1 表示未采集）
+	ThreadPoolUsagePercent float64                 `json:"thread_pool_usage_percent"` // Executor 线程池饱和度（-1 表示未采集）
 	PID                    int                     `json:"-"`
 	Metrics                map[string]*TomcatMetricValue `json:"-"` // 指标映射（内部使用，不序列化）
 	Status                 TomcatInstanceStatus    `json:"status"`
@@ -204,10 +208,13 @@ type TomcatInspectionResult struct {
 
 func NewTomcatInspectionResult(instance *TomcatInstance) *TomcatInspectionResult {
 	return &TomcatInspectionResult{
-		Instance:           instance,
-		Status:             TomcatStatusNormal,
-		Alerts:             make([]*TomcatAlert, 0),
-		LastErrorTimestamp: 0,
+		Instance:               instance,
+		Status:                 TomcatStatusNormal,
+		Alerts:                 make([]*TomcatAlert, 0),
+		LastErrorTimestamp:     0,
+		HeapUsagePercent:       -1,
+		GCPauseAvgMs:           -1,
+		ThreadPoolUsagePercent: -1,
 	}
 }
 
@@ -265,6 +272,21 @@ func (r *TomcatInspectionResult) GetIdentifier() string {
 	return r.Instance.Identifier
 }
 
+// HasHeapUsage returns true if the JVM heap usage was collected.
+func (r *TomcatInspectionResult) HasHeapUsage() bool {
+	return r != nil && r.HeapUsagePercent >= 0
+}
+
+// HasGCPause returns true if the average GC pause time was collected.
+func (r *TomcatInspectionResult) HasGCPause() bool {
+	return r != nil && r.GCPauseAvgMs >= 0
+}
+
+// HasThreadPoolUsage returns true if the executor thread pool saturation was collected.
+func (r *TomcatInspectionResult) HasThreadPoolUsage() bool {
+	return r != nil && r.ThreadPoolUsagePercent >= 0
+}
+
 func (r *TomcatInspectionResult) SetMetric(mv *TomcatMetricValue) {
 	if r == nil || mv == nil {
 		return
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// TrendMetrics are the host metrics compared with previous inspection runs in the trend sheet.
+var TrendMetrics = []string{"cpu_usage", "memory_usage", "disk_usage_max"}
+
+// TrendRun holds the host metric values of a previous inspection run.
+type TrendRun struct {
+	InspectionTime time.Time                     `json:"inspection_time"` // 巡检时间
+	Values         map[string]map[string]float64 `json:"values"`          // 主机名 -> 指标名 -> 原始值（不含 N/A）
+}
+
+// Value returns the value of metric on hostname in the run, and whether it was collected.
+func (r *TrendRun) Value(hostname, metric string) (float64, bool) {
+	if r == nil {
+		return 0, false
+	}
+	value, ok := r.Values[hostname][metric]
+	return value, ok
+}
+
+// TrendChange returns the relative change from previous to current in percent.
+// ok is false when previous is zero and current is not, as the change is then undefined.
+func TrendChange(previous, current float64) (percent float64, ok bool) {
+	if previous == 0 {
+		return 0, current == 0
+	}
+	return (current - previous) / math.Abs(previous) * 100, true
+}
@@ -0,0 +1,40 @@
+package model
+
+import "testing"
+
+func TestTrendRun_Value(t *testing.T) {
+	run := &TrendRun{Values: map[string]map[string]float64{"host-1": {"cpu_usage": 40}}}
+
+	if v, ok := run.Value("host-1", "cpu_usage"); !ok || v != 40 {
+		t.Errorf("Value(host-1, cpu_usage) = %v, %v, want 40, true", v, ok)
+	}
+	if _, ok := run.Value("host-1", "memory_usage"); ok {
+		t.Error("Value() of a missing metric should not be ok")
+	}
+	if _, ok := run.Value("host-2", "cpu_usage"); ok {
+		t.Error("Value() of a missing host should not be ok")
+	}
+	if _, ok := (*TrendRun)(nil).Value("host-1", "cpu_usage"); ok {
+		t.Error("Value() of a nil run should not be ok")
+	}
+}
+
+func TestTrendChange(t *testing.T) {
+	tests := []struct {
+		previous, current float64
+		percent           float64
+		ok                bool
+	}{
+		{40, 50, 25, true},
+		{50, 40, -20, true},
+		{40, 40, 0, true},
+		{0, 0, 0, true},
+		{0, 10, 0, false},
+	}
+	for _, tt := range tests {
+		percent, ok := TrendChange(tt.previous, tt.current)
+		if percent != tt.percent || ok != tt.ok {
+			t.Errorf("TrendChange(%v, %v) = %v, %v, want %v, %v", tt.previous, tt.current, percent, ok, tt.percent, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Windows 主机状态枚举
+// =============================================================================
+
+type WindowsInstanceStatus string
+
+const (
+	WindowsStatusNormal   WindowsInstanceStatus = "normal"
+	WindowsStatusWarning  WindowsInstanceStatus = "warning"
+	WindowsStatusCritical WindowsInstanceStatus = "critical"
+	WindowsStatusFailed   WindowsInstanceStatus = "failed"
+)
+
+func (s WindowsInstanceStatus) IsHealthy() bool {
+	return s == WindowsStatusNormal
+}
+
+func (s WindowsInstanceStatus) IsWarning() bool {
+	return s == WindowsStatusWarning
+}
+
+func (s WindowsInstanceStatus) IsCritical() bool {
+	return s == WindowsStatusCritical
+}
+
+func (s WindowsInstanceStatus) IsFailed() bool {
+	return s == WindowsStatusFailed
+}
+
+// =============================================================================
+// Windows 主机结构体
+// =============================================================================
+
+// WindowsInstance represents a Windows host monitored by windows_exporter.
+// Hosts are identified by hostname.
+type WindowsInstance struct {
+	Identifier string `json:"identifier"`
+	Hostname   string `json:"hostname"`
+	IP         string `json:"ip"`
+}
+
+func NewWindowsInstance(hostname string) *WindowsInstance {
+	return &WindowsInstance{
+		Identifier: hostname,
+		Hostname:   hostname,
+	}
+}
+
+func (i *WindowsInstance) SetIP(ip string) {
+	if i == nil {
+		return
+	}
+	i.IP = ip
+}
+
+func (i *WindowsInstance) String() string {
+	if i == nil {
+		return "WindowsInstance(nil)"
+	}
+	return fmt.Sprintf("WindowsInstance(%s)", i.Identifier)
+}
+
+// =============================================================================
+// Windows 服务与 IIS 应用程序池结构体
+// =============================================================================
+
+// WindowsService represents the state of an allowlisted Windows service.
+type WindowsService struct {
+	Name  string `json:"name"`  // 服务名称（windows_exporter 上报的小写名称）
+	State string `json:"state"` // 服务状态（running、stopped、start pending 等）
+}
+
+// IsRunning returns true if the service is in the running state.
+func (s *WindowsService) IsRunning() bool {
+	return s != nil && strings.EqualFold(s.State, "running")
+}
+
+// IISAppPool represents an IIS application pool.
+type IISAppPool struct {
+	Name           string  `json:"name"`            // 应用程序池名称
+	State          string  `json:"state"`           // 应用程序池状态（Running、Stopped 等，空表示未采集）
+	RequestsQueued float64 `json:"requests_queued"` // HTTP.sys 请求队列长度（-1 表示未采集）
+}
+
+// IsRunning returns true if the application pool is in the Running state.
+func (p *IISAppPool) IsRunning() bool {
+	return p != nil && strings.EqualFold(p.State, "running")
+}
+
+// HasState returns true if the application pool state was collected.
+func (p *IISAppPool) HasState() bool {
+	return p != nil && p.State != ""
+}
+
+// HasRequestsQueued returns true if the request queue length was collected.
+func (p *IISAppPool) HasRequestsQueued() bool {
+	return p != nil && p.RequestsQueued >= 0
+}
+
+// =============================================================================
+// Windows 主机告警结构体
+// =============================================================================
+
+type WindowsAlert struct {
+	Identifier        string     `json:"identifier"`
+	MetricName        string     `json:"metric_name"`
+	MetricDisplayName string     `json:"metric_display_name"`
+	CurrentValue      float64    `json:"current_value"`
+	FormattedValue    string     `json:"formatted_value"`
+	WarningThreshold  float64    `json:"warning_threshold"`
+	CriticalThreshold float64    `json:"critical_threshold"`
+	Level             AlertLevel `json:"level"`
+	Message           string     `json:"message"`
+}
+
+func NewWindowsAlert(identifier, metricName string, currentValue float64, level AlertLevel) *WindowsAlert {
+	return &WindowsAlert{
+		Identifier:   identifier,
+		MetricName:   metricName,
+		CurrentValue: currentValue,
+		Level:        level,
+	}
+}
+
+func (a *WindowsAlert) IsWarning() bool {
+	return a != nil && a.Level == AlertLevelWarning
+}
+
+func (a *WindowsAlert) IsCritical() bool {
+	return a != nil && a.Level == AlertLevelCritical
+}
+
+// =============================================================================
+// Windows 主机指标值结构体
+// =============================================================================
+
+type WindowsMetricValue struct {
+	Name           string            `json:"name"`
+	RawValue       float64           `json:"raw_value"`
+	StringValue    string            `json:"string_value,omitempty"` // 标签提取的字符串值
+	FormattedValue string            `json:"formatted_value"`
+	IsNA           bool              `json:"is_na"`
+	Timestamp      int64             `json:"timestamp"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// =============================================================================
+// Windows 主机巡检结果结构体
+// =============================================================================
+
+type WindowsInspectionResult struct {
+	Instance        *WindowsInstance               `json:"instance"`
+	Services        []*WindowsService              `json:"services,omitempty"`  // 白名单内的 Windows 服务
+	StoppedServices int                            `json:"stopped_services"`    // 未运行的服务数
+	AppPools        []*IISAppPool                  `json:"app_pools,omitempty"` // IIS 应用程序池
+	StoppedAppPools int                            `json:"stopped_app_pools"`   // 未运行的应用程序池数
+	RequestsQueued  float64                        `json:"requests_queued"`     // 所有应用程序池的请求队列总长度（-1 表示未采集）
+	Metrics         map[string]*WindowsMetricValue `json:"-"`                   // 指标映射（内部使用，不序列化）
+	Status          WindowsInstanceStatus          `json:"status"`
+	Alerts          []*WindowsAlert                `json:"alerts,omitempty"`
+	CollectedAt     time.Time                      `json:"collected_at"`
+	Error           string                         `json:"error,omitempty"`
+}
+
+func NewWindowsInspectionResult(instance *WindowsInstance) *WindowsInspectionResult {
+	return &WindowsInspectionResult{
+		Instance:       instance,
+		Status:         WindowsStatusNormal,
+		Services:       make([]*WindowsService, 0),
+		AppPools:       make([]*IISAppPool, 0),
+		Alerts:         make([]*WindowsAlert, 0),
+		RequestsQueued: -1,
+	}
+}
+
+func (r *WindowsInspectionResult) AddAlert(alert *WindowsAlert) {
+	if r == nil || alert == nil {
+		return
+	}
+	r.Alerts = append(r.Alerts, alert)
+}
+
+func (r *WindowsInspectionResult) HasAlerts() bool {
+	return r != nil && len(r.Alerts) > 0
+}
+
+func (r *WindowsInspectionResult) GetIdentifier() string {
+	if r == nil || r.Instance == nil {
+		return ""
+	}
+	return r.Instance.Identifier
+}
+
+// GetService returns the service with the given name, creating it if needed.
+func (r *WindowsInspectionResult) GetService(name string) *WindowsService {
+	for _, svc := range r.Services {
+		if svc.Name == name {
+			return svc
+		}
+	}
+	svc := &WindowsService{Name: name}
+	r.Services = append(r.Services, svc)
+	return svc
+}
+
+// GetAppPool returns the IIS application pool with the given name, creating it if needed.
+func (r *WindowsInspectionResult) GetAppPool(name string) *IISAppPool {
+	for _, pool := range r.AppPools {
+		if pool.Name == name {
+			return pool
+		}
+	}
+	pool := &IISAppPool{Name: name, RequestsQueued: -1}
+	r.AppPools = append(r.AppPools, pool)
+	return pool
+}
+
+// StoppedServiceNames returns the allowlisted services that are not running.
+func (r *WindowsInspectionResult) StoppedServiceNames() []string {
+	if r == nil {
+		return nil
+	}
+	var names []string
+	for _, svc := range r.Services {
+		if !svc.IsRunning() {
+			names = append(names, svc.Name)
+		}
+	}
+	return names
+}
+
+// StoppedAppPoolNames returns the IIS application pools whose state was collected and is not Running.
+func (r *WindowsInspectionResult) StoppedAppPoolNames() []string {
+	if r == nil {
+		return nil
+	}
+	var names []string
+	for _, pool := range r.AppPools {
+		if pool.HasState() && !pool.IsRunning() {
+			names = append(names, pool.Name)
+		}
+	}
+	return names
+}
+
+// HasIIS returns true if any IIS application pool was collected.
+func (r *WindowsInspectionResult) HasIIS() bool {
+	return r != nil && len(r.AppPools) > 0
+}
+
+// HasRequestsQueued returns true if the request queue length was collected.
+func (r *WindowsInspectionResult) HasRequestsQueued() bool {
+	return r != nil && r.RequestsQueued >= 0
+}
+
+func (r *WindowsInspectionResult) SetMetric(mv *WindowsMetricValue) {
+	if r == nil || mv == nil {
+		return
+	}
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]*WindowsMetricValue)
+	}
+	r.Metrics[mv.Name] = mv
+}
+
+func (r *WindowsInspectionResult) GetMetric(name string) *WindowsMetricValue {
+	if r == nil || r.Metrics == nil {
+		return nil
+	}
+	return r.Metrics[name]
+}
+
+// =============================================================================
+// Windows 主机巡检摘要结构体
+// =============================================================================
+
+type WindowsInspectionSummary struct {
+	TotalInstances    int `json:"total_instances"`
+	NormalInstances   int `json:"normal_instances"`
+	WarningInstances  int `json:"warning_instances"`
+	CriticalInstances int `json:"critical_instances"`
+	FailedInstances   int `json:"failed_instances"`
+	StoppedServices   int `json:"stopped_services"`  // 未运行的服务总数
+	StoppedAppPools   int `json:"stopped_app_pools"` // 未运行的应用程序池总数
+}
+
+func NewWindowsInspectionSummary(results []*WindowsInspectionResult) *WindowsInspectionSummary {
+	summary := &WindowsInspectionSummary{
+		TotalInstances: len(results),
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		switch result.Status {
+		case WindowsStatusNormal:
+			summary.NormalInstances++
+		case WindowsStatusWarning:
+			summary.WarningInstances++
+		case WindowsStatusCritical:
+			summary.CriticalInstances++
+		case WindowsStatusFailed:
+			summary.FailedInstances++
+		}
+
+		summary.StoppedServices += result.StoppedServices
+		summary.StoppedAppPools += result.StoppedAppPools
+	}
+
+	return summary
+}
+
+// =============================================================================
+// Windows 主机告警摘要结构体
+// =============================================================================
+
+type WindowsAlertSummary struct {
+	TotalAlerts   int `json:"total_alerts"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+func NewWindowsAlertSummary(alerts []*WindowsAlert) *WindowsAlertSummary {
+	summary := &WindowsAlertSummary{
+		TotalAlerts: len(alerts),
+	}
+
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+
+		switch alert.Level {
+		case AlertLevelWarning:
+			summary.WarningCount++
+		case AlertLevelCritical:
+			summary.CriticalCount++
+		}
+	}
+
+	return summary
+}
+
+// =============================================================================
+// Windows 主机完整巡检结果容器
+// =============================================================================
+
+type WindowsInspectionResults struct {
+	InspectionTime time.Time                  `json:"inspection_time"`
+	Duration       time.Duration              `json:"duration"`
+	Summary        *WindowsInspectionSummary  `json:"summary"`
+	Results        []*WindowsInspectionResult `json:"results"`
+	Alerts         []*WindowsAlert            `json:"alerts"`
+	AlertSummary   *WindowsAlertSummary       `json:"alert_summary"`
+	Version        string                     `json:"version,omitempty"`
+}
+
+func NewWindowsInspectionResults(inspectionTime time.Time) *WindowsInspectionResults {
+	return &WindowsInspectionResults{
+		InspectionTime: inspectionTime,
+		Results:        make([]*WindowsInspectionResult, 0),
+		Alerts:         make([]*WindowsAlert, 0),
+	}
+}
+
+func (r *WindowsInspectionResults) AddResult(result *WindowsInspectionResult) {
+	if r == nil || result == nil {
+		return
+	}
+	r.Results = append(r.Results, result)
+
+	if result.HasAlerts() {
+		r.Alerts = append(r.Alerts, result.Alerts...)
+	}
+}
+
+func (r *WindowsInspectionResults) Finalize(endTime time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.Duration = endTime.Sub(r.InspectionTime)
+	r.Summary = NewWindowsInspectionSummary(r.Results)
+	r.AlertSummary = NewWindowsAlertSummary(r.Alerts)
+}
+
+func (r *WindowsInspectionResults) GetResultByIdentifier(identifier string) *WindowsInspectionResult {
+	if r == nil {
+		return nil
+	}
+
+	for _, result := range r.Results {
+		if result != nil && result.GetIdentifier() == identifier {
+			return result
+		}
+	}
+	return nil
+}
+
+func (r *WindowsInspectionResults) HasCritical() bool {
+	return r != nil && r.Summary != nil && r.Summary.CriticalInstances > 0
+}
+
+func (r *WindowsInspectionResults) HasWarning() bool {
+	return r != nil && r.Summary != nil && r.Summary.WarningInstances > 0
+}
+
+func (r *WindowsInspectionResults) HasAlerts() bool {
+	return r != nil && r.AlertSummary != nil && r.AlertSummary.TotalAlerts > 0
+}
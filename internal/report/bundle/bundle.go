@@ -0,0 +1,107 @@
+// Package bundle packages the reports generated in one run into a single archive,
+// so a complete delivery (Excel + HTML + JSON) is one file to hand over or upload.
+package bundle
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ModeZip is the report.bundle value that packages the reports into a zip archive.
+const ModeZip = "zip"
+
+// Formats are the report formats always generated in bundle mode.
+var Formats = []string{"excel", "html", "json"}
+
+// WithFormats returns formats extended with the bundle formats missing from it.
+// The configured formats keep their order; formats is not modified.
+func WithFormats(formats []string) []string {
+	result := append([]string(nil), formats...)
+	for _, f := range Formats {
+		found := false
+		for _, existing := range formats {
+			if existing == f {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// Filename returns the archive file name for the report file name base, timestamped with t,
+// e.g. "inspection_report_20251213_20251213_100000.zip".
+func Filename(base string, t time.Time) string {
+	return fmt.Sprintf("%s_%s.zip", base, t.Format("20060102_150405"))
+}
+
+// Zip writes the report files and directories at paths into a zip archive at zipPath.
+// Entries are named relative to baseDir (the report output directory); directories such as
+// CSV exports and split HTML reports are added recursively.
+func Zip(zipPath, baseDir string, paths []string) (err error) {
+	out, err := os.Create(zipPath)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(zipPath)
+		}
+	}()
+
+	zw := zip.NewWriter(out)
+	for _, path := range paths {
+		if err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			return addFile(zw, baseDir, file, info)
+		}); err != nil {
+			zw.Close()
+			return fmt.Errorf("failed to add %s to archive: %w", path, err)
+		}
+	}
+	return zw.Close()
+}
+
+// addFile copies one file into the archive.
+func addFile(zw *zip.Writer, baseDir, file string, info os.FileInfo) error {
+	name, err := filepath.Rel(baseDir, file)
+	if err != nil || strings.HasPrefix(name, "..") {
+		// Files outside the output directory are stored by base name
+		name = filepath.Base(file)
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	header.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	_, err = io.Copy(w, in)
+	return err
+}
@@ -0,0 +1,110 @@
+package bundle
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestWithFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats []string
+		want    []string
+	}{
+		{"empty", nil, []string{"excel", "html", "json"}},
+		{"keeps configured order", []string{"json", "csv"}, []string{"json", "csv", "excel", "html"}},
+		{"all present", []string{"html", "excel", "json"}, []string{"html", "excel", "json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formats := append([]string(nil), tt.formats...)
+			if got := WithFormats(formats); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WithFormats(%v) = %v, want %v", tt.formats, got, tt.want)
+			}
+			if !reflect.DeepEqual(formats, append([]string(nil), tt.formats...)) {
+				t.Errorf("WithFormats() modified its input: %v", formats)
+			}
+		})
+	}
+}
+
+func TestFilename(t *testing.T) {
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	got := Filename("inspection_report_20251213", time.Date(2025, 12, 13, 10, 30, 5, 0, tz))
+	if want := "inspection_report_20251213_20251213_103005.zip"; got != want {
+		t.Errorf("Filename() = %q, want %q", got, want)
+	}
+}
+
+func TestZip(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "report.xlsx"), "xlsx")
+	writeFile(t, filepath.Join(dir, "report.html"), "<html></html>")
+	writeFile(t, filepath.Join(dir, "report_csv", "异常汇总.csv"), "a,b\n")
+	writeFile(t, filepath.Join(dir, "unrelated.txt"), "not bundled")
+
+	zipPath := filepath.Join(dir, "report.zip")
+	paths := []string{
+		filepath.Join(dir, "report.xlsx"),
+		filepath.Join(dir, "report.html"),
+		filepath.Join(dir, "report_csv"),
+	}
+	if err := Zip(zipPath, dir, paths); err != nil {
+		t.Fatalf("Zip() error = %v", err)
+	}
+
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer zr.Close()
+
+	contents := make(map[string]string)
+	var names []string
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[f.Name] = string(data)
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+
+	want := []string{"report.html", "report.xlsx", "report_csv/异常汇总.csv"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("archive entries = %v, want %v", names, want)
+	}
+	if contents["report_csv/异常汇总.csv"] != "a,b\n" {
+		t.Errorf("csv entry content = %q", contents["report_csv/异常汇总.csv"])
+	}
+}
+
+func TestZip_MissingReport(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "report.zip")
+	if err := Zip(zipPath, dir, []string{filepath.Join(dir, "missing.xlsx")}); err == nil {
+		t.Fatal("Zip() should fail for a missing report")
+	}
+	if _, err := os.Stat(zipPath); !os.IsNotExist(err) {
+		t.Error("incomplete archive should be removed")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,177 @@
+// Package csv provides CSV report generation for the inspection tool.
+// It implements the report.ReportWriter interface by exporting every sheet of
+// the Excel report as a separate UTF-8 CSV file, so BI tools can ingest the
+// detail and alert data without parsing .xlsx files.
+package csv
+
+import (
+	stdcsv "encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/excel"
+)
+
+// utf8BOM is written at the start of every file so that Excel opens Chinese
+// headers correctly; most BI tools (pandas utf-8-sig, Power BI) strip it.
+const utf8BOM = "\ufeff"
+
+// Writer implements report.ReportWriter for CSV format.
+// The output path is a directory that receives one <sheet>.csv file per sheet.
+type Writer struct {
+	timezone *time.Location
+}
+
+// NewWriter creates a new CSV report writer.
+// If timezone is nil, it defaults to Asia/Shanghai.
+func NewWriter(timezone *time.Location) *Writer {
+	if timezone == nil {
+		timezone, _ = time.LoadLocation("Asia/Shanghai")
+	}
+	return &Writer{
+		timezone: timezone,
+	}
+}
+
+// Format returns the format identifier for this writer.
+func (w *Writer) Format() string {
+	return "csv"
+}
+
+// Write generates CSV files from the host inspection result into the outputPath directory.
+func (w *Writer) Write(result *model.InspectionResult, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("inspection result is nil")
+	}
+
+	return w.convertWith(outputPath, func(workbookPath string) error {
+		return excel.NewWriter(w.timezone).Write(result, workbookPath)
+	})
+}
+
+// WriteWorkbook builds a temporary Excel workbook with build and exports its sheets
+// into the outputDir directory. It lets callers reuse any Excel writer entry point
+// (combined report, raw data sheet) without duplicating the sheet layouts.
+func (w *Writer) WriteWorkbook(outputDir string, build func(workbookPath string) error) error {
+	if build == nil {
+		return fmt.Errorf("workbook builder is nil")
+	}
+	return w.convertWith(outputDir, build)
+}
+
+// convertWith runs build against a temporary .xlsx file and converts the result.
+func (w *Writer) convertWith(outputDir string, build func(workbookPath string) error) error {
+	tmpDir, err := os.MkdirTemp("", "inspection-csv-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	workbookPath := filepath.Join(tmpDir, "report.xlsx")
+	if err := build(workbookPath); err != nil {
+		return fmt.Errorf("failed to build workbook: %w", err)
+	}
+
+	_, err = ConvertWorkbook(workbookPath, outputDir)
+	return err
+}
+
+// ConvertWorkbook exports every sheet of the Excel workbook at workbookPath as a
+// separate CSV file in outputDir, keeping the sheet order. Cell values are written
+// unformatted (raw numbers instead of display formats).
+//
+// Returns the paths of the generated files.
+func ConvertWorkbook(workbookPath, outputDir string) ([]string, error) {
+	f, err := excelize.OpenFile(workbookPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workbook: %w", err)
+	}
+	defer f.Close()
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	sheets := f.GetSheetList()
+	paths := make([]string, 0, len(sheets))
+	for _, sheet := range sheets {
+		// Hidden sheets (e.g. empty module sheets) are not exported
+		if visible, err := f.GetSheetVisible(sheet); err == nil && !visible {
+			continue
+		}
+
+		rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+		}
+
+		path := filepath.Join(outputDir, sheetFileName(sheet)+".csv")
+		if err := writeRows(path, rows); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// writeRows writes rows to a CSV file, padding short rows so every line has the
+// same number of columns (GetRows trims trailing empty cells).
+func writeRows(path string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(utf8BOM); err != nil {
+		return err
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	cw := stdcsv.NewWriter(file)
+	for _, row := range rows {
+		if len(row) < width {
+			padded := make([]string, width)
+			copy(padded, row)
+			row = padded
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// sheetFileName converts a sheet name into a safe file name, replacing path
+// separators and characters that are invalid on Windows.
+func sheetFileName(sheet string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(sheet))
+	if name == "" {
+		return "sheet"
+	}
+	return name
+}
@@ -0,0 +1,129 @@
+package csv
+
+import (
+	stdcsv "encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/pkg/testfixtures"
+)
+
+// createTestInspectionResult creates a host inspection result with one warning alert.
+func createTestInspectionResult() *model.InspectionResult {
+	host2 := testfixtures.NewHost("host-2", "192.168.1.2")
+	testfixtures.AddHostAlert(host2, "cpu_usage", 75.0)
+	return testfixtures.NewInspectionResult(testfixtures.NewHost("host-1", "192.168.1.1"), host2)
+}
+
+// readCSV reads a generated CSV file, checking and stripping the UTF-8 BOM.
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, utf8BOM) {
+		t.Errorf("%s should start with a UTF-8 BOM", path)
+	}
+	rows, err := stdcsv.NewReader(strings.NewReader(strings.TrimPrefix(content, utf8BOM))).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+	return rows
+}
+
+func TestWriter_Format(t *testing.T) {
+	if got := NewWriter(nil).Format(); got != "csv" {
+		t.Errorf("Format() = %q, want csv", got)
+	}
+}
+
+func TestWriter_Write_NilResult(t *testing.T) {
+	if err := NewWriter(nil).Write(nil, t.TempDir()); err == nil {
+		t.Error("Write() should return error for nil result")
+	}
+}
+
+func TestWriter_Write_OneFilePerSheet(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "report")
+
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputDir); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	for _, name := range []string{"巡检概览.csv", "详细数据.csv", "异常汇总.csv"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("expected %s to be generated: %v", name, err)
+		}
+	}
+
+	rows := readCSV(t, filepath.Join(outputDir, "异常汇总.csv"))
+	if len(rows) != 2 {
+		t.Fatalf("expected header + 1 alert row, got %d rows", len(rows))
+	}
+	if !strings.Contains(strings.Join(rows[1], ","), "host-2") {
+		t.Errorf("alert row should mention host-2, got %v", rows[1])
+	}
+}
+
+func TestConvertWorkbook_PadsRowsAndSanitizesNames(t *testing.T) {
+	tmpDir := t.TempDir()
+	workbookPath := filepath.Join(tmpDir, "in.xlsx")
+
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "A|B")
+	f.SetCellValue("A|B", "A1", "名称")
+	f.SetCellValue("A|B", "B1", "值")
+	f.SetCellValue("A|B", "C1", "备注")
+	f.SetCellValue("A|B", "A2", "x")
+	f.SetCellValue("A|B", "B2", 12.5)
+	if err := f.SaveAs(workbookPath); err != nil {
+		t.Fatalf("failed to save workbook: %v", err)
+	}
+	f.Close()
+
+	paths, err := ConvertWorkbook(workbookPath, filepath.Join(tmpDir, "out"))
+	if err != nil {
+		t.Fatalf("ConvertWorkbook() error = %v", err)
+	}
+	if len(paths) != 1 || filepath.Base(paths[0]) != "A_B.csv" {
+		t.Fatalf("unexpected output files: %v", paths)
+	}
+
+	rows := readCSV(t, paths[0])
+	if len(rows) != 2 || len(rows[1]) != 3 {
+		t.Fatalf("expected 2 rows of 3 columns, got %v", rows)
+	}
+	if rows[1][1] != "12.5" {
+		t.Errorf("numeric cell should be exported raw, got %q", rows[1][1])
+	}
+}
+
+func TestConvertWorkbook_SkipsHiddenSheets(t *testing.T) {
+	tmpDir := t.TempDir()
+	workbookPath := filepath.Join(tmpDir, "in.xlsx")
+
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "A1", "visible")
+	f.NewSheet("Hidden")
+	f.SetCellValue("Hidden", "A1", "hidden")
+	f.SetSheetVisible("Hidden", false)
+	if err := f.SaveAs(workbookPath); err != nil {
+		t.Fatalf("failed to save workbook: %v", err)
+	}
+	f.Close()
+
+	paths, err := ConvertWorkbook(workbookPath, filepath.Join(tmpDir, "out"))
+	if err != nil {
+		t.Fatalf("ConvertWorkbook() error = %v", err)
+	}
+	if len(paths) != 1 || filepath.Base(paths[0]) != "Sheet1.csv" {
+		t.Errorf("hidden sheet should be skipped, got files: %v", paths)
+	}
+}
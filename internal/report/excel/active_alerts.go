@@ -0,0 +1,88 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// createActiveAlertsSheet lists the alert rules firing in N9E for the inspected hosts, next to
+// the inspection alerts of the same host, so rules the inspection did not catch (and hosts the
+// inspection flags without a firing rule) stand out. Without active alerts no sheet is created.
+func (w *Writer) createActiveAlertsSheet(f *excelize.File, result *model.InspectionResult) error {
+	if len(result.ActiveAlerts) == 0 {
+		return nil
+	}
+	if _, err := f.NewSheet(sheetActiveAlerts); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"主机名", "告警级别", "告警规则", "触发时间", "触发值", "业务组", "标签", "巡检告警"}
+	widths := []float64{wideColWidth, 12, 30, w.inspectionTimeWidth(20), defaultColWidth, defaultColWidth, 40, 40}
+	w.writeFrozenHeader(f, sheetActiveAlerts, headers, widths, headerStyle)
+
+	for i, a := range result.ActiveAlerts {
+		row := i + 2
+		triggerTime := "-"
+		if !a.TriggerTime.IsZero() {
+			triggerTime = w.formatInspectionTime(a.TriggerTime)
+		}
+		values := []interface{}{
+			a.Hostname, a.LevelText(), a.RuleName, triggerTime,
+			valueOrDash(a.TriggerValue), valueOrDash(a.GroupName), valueOrDash(strings.Join(a.Tags, ", ")),
+			w.inspectionAlertsText(a.Inspection),
+		}
+		for col, value := range values {
+			f.SetCellValue(sheetActiveAlerts, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+
+		levelCell := fmt.Sprintf("B%d", row)
+		switch a.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetActiveAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetActiveAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	f.AutoFilter(sheetActiveAlerts, fmt.Sprintf("A1:H%d", len(result.ActiveAlerts)+1), nil)
+
+	return nil
+}
+
+// inspectionAlertsText joins the inspection alerts correlated with an active alert. The names
+// are translated one by one, as the joined text is not a report string.
+func (w *Writer) inspectionAlertsText(names []string) string {
+	if len(names) == 0 {
+		return "无"
+	}
+	texts := make([]string, len(names))
+	for i, name := range names {
+		texts[i] = w.tr.Text(name)
+	}
+	return strings.Join(texts, ", ")
+}
+
+// valueOrDash returns s, or "-" when s is empty.
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,98 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+)
+
+func TestWriter_Write_ActiveAlertsSheet(t *testing.T) {
+	result := createTestInspectionResult()
+	result.ActiveAlerts = []*model.ActiveAlert{
+		{
+			Hostname: "host-1", RuleName: "CPU使用率过高", Level: model.AlertLevelCritical,
+			TriggerTime: time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC), TriggerValue: "95.2",
+			Tags: []string{"ident=host-1", "env=prod"}, Inspection: []string{"CPU利用率"},
+		},
+		{Hostname: "host-2", RuleName: "主机失联", Level: model.AlertLevelNormal},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(time.UTC).Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetActiveAlerts)
+	if err != nil {
+		t.Fatalf("active alerts sheet missing: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("active alerts sheet has %d rows, want 3", len(rows))
+	}
+	want := []string{"host-1", "严重", "CPU使用率过高", "2025-01-15 10:30:00", "95.2", "-", "ident=host-1, env=prod", "CPU利用率"}
+	for i, cell := range want {
+		if rows[1][i] != cell {
+			t.Errorf("row 2 column %d = %q, want %q", i+1, rows[1][i], cell)
+		}
+	}
+	if got := rows[2]; got[1] != "提示" || got[3] != "-" || got[7] != "无" {
+		t.Errorf("row 3 = %v, want info level without trigger time or inspection alerts", got)
+	}
+}
+
+func TestWriter_Write_ActiveAlertsSheet_English(t *testing.T) {
+	result := createTestInspectionResult()
+	result.ActiveAlerts = []*model.ActiveAlert{
+		{Hostname: "host-1", RuleName: "CPU使用率过高", Level: model.AlertLevelWarning, Inspection: []string{"CPU利用率", "内存利用率"}},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(time.UTC, WithLanguage(i18n.LanguageEN)).Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Active Alerts")
+	if err != nil {
+		t.Fatalf("active alerts sheet missing: %v", err)
+	}
+	if rows[0][7] != "Inspection Alerts" || rows[1][1] != "Warning" {
+		t.Errorf("header / level not translated: %v, %v", rows[0], rows[1])
+	}
+	if rows[1][7] != "CPU Usage, Memory Usage" {
+		t.Errorf("inspection alerts = %q, want translated names", rows[1][7])
+	}
+}
+
+func TestWriter_Write_NoActiveAlerts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetActiveAlerts); idx != -1 {
+		t.Error("active alerts sheet should only be created with active alerts")
+	}
+}
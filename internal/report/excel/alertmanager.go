@@ -0,0 +1,88 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// WithAlertmanagerAlerts adds an "Alertmanager 告警" sheet to combined workbooks listing the
+// alerts firing in Alertmanager, with the silences muting them. No alerts means no sheet.
+func WithAlertmanagerAlerts(alerts []*model.AlertmanagerAlert) Option {
+	return func(w *Writer) {
+		w.alertmanagerAlerts = alerts
+	}
+}
+
+// createAlertmanagerSheet lists the Alertmanager alerts, already sorted with the alerts still
+// notifying first. Silenced alerts show who created the silences, why and until when; when
+// the silence details could not be fetched, the silence IDs are shown as the reason.
+func (w *Writer) createAlertmanagerSheet(f *excelize.File) error {
+	if len(w.alertmanagerAlerts) == 0 {
+		return nil
+	}
+	if _, err := f.NewSheet(sheetAlertmanager); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"告警名称", "告警级别", "实例", "摘要", "开始时间", "状态", "静默创建人", "静默原因", "静默截止"}
+	widths := []float64{wideColWidth, 12, wideColWidth, 40, w.inspectionTimeWidth(20), narrowColWidth, defaultColWidth, 30, w.inspectionTimeWidth(20)}
+	w.writeFrozenHeader(f, sheetAlertmanager, headers, widths, headerStyle)
+
+	for i, a := range w.alertmanagerAlerts {
+		row := i + 2
+		startsAt := "-"
+		if !a.StartsAt.IsZero() {
+			startsAt = w.formatInspectionTime(a.StartsAt)
+		}
+		createdBy, comment, until := "-", "-", "-"
+		if len(a.Silences) > 0 {
+			creators := make([]string, 0, len(a.Silences))
+			comments := make([]string, 0, len(a.Silences))
+			ends := make([]string, 0, len(a.Silences))
+			for _, s := range a.Silences {
+				creators = append(creators, s.CreatedBy)
+				comments = append(comments, s.Comment)
+				ends = append(ends, w.formatInspectionTime(s.EndsAt))
+			}
+			createdBy, comment, until = strings.Join(creators, "; "), strings.Join(comments, "; "), strings.Join(ends, "; ")
+		} else if a.Silenced() {
+			comment = strings.Join(a.SilencedBy, "; ")
+		}
+		values := []interface{}{
+			a.Name, valueOrDash(a.Severity), valueOrDash(a.Instance), valueOrDash(a.Summary), startsAt,
+			a.StateText(), createdBy, comment, until,
+		}
+		for col, value := range values {
+			f.SetCellValue(sheetAlertmanager, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+
+		levelCell := fmt.Sprintf("B%d", row)
+		switch a.Level() {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetAlertmanager, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetAlertmanager, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	f.AutoFilter(sheetAlertmanager, fmt.Sprintf("A1:I%d", len(w.alertmanagerAlerts)+1), nil)
+
+	return nil
+}
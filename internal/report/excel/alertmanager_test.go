@@ -0,0 +1,112 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+)
+
+// testAlertmanagerAlerts returns a firing alert and a silenced one.
+func testAlertmanagerAlerts() []*model.AlertmanagerAlert {
+	return []*model.AlertmanagerAlert{
+		{
+			Name: "DiskFull", Severity: "warning", Instance: "10.0.0.2:9100", Summary: "disk / above 90%",
+			StartsAt: time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC), State: model.AlertmanagerStateActive,
+		},
+		{
+			Name: "HostDown", Severity: "critical", Instance: "10.0.0.1:9100",
+			StartsAt: time.Date(2025, 1, 15, 2, 30, 0, 0, time.UTC), State: model.AlertmanagerStateSuppressed,
+			SilencedBy: []string{"s1"},
+			Silences: []*model.AlertmanagerSilence{
+				{ID: "s1", CreatedBy: "ops", Comment: "maintenance", EndsAt: time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+}
+
+func TestWriter_Finalize_AlertmanagerSheet(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	hostResult := createTestInspectionResult()
+
+	w := NewWriter(time.UTC, WithAlertmanagerAlerts(testAlertmanagerAlerts()))
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetAlertmanager)
+	if err != nil {
+		t.Fatalf("Alertmanager sheet missing: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Alertmanager sheet has %d rows, want 3", len(rows))
+	}
+	want := []string{"DiskFull", "warning", "10.0.0.2:9100", "disk / above 90%", "2025-01-15 03:00:00", "触发中", "-", "-", "-"}
+	for i, cell := range want {
+		if rows[1][i] != cell {
+			t.Errorf("row 2 column %d = %q, want %q", i+1, rows[1][i], cell)
+		}
+	}
+	if got := rows[2]; got[3] != "-" || got[5] != "已静默" || got[6] != "ops" || got[7] != "maintenance" || got[8] != "2025-01-15 06:00:00" {
+		t.Errorf("row 3 = %v, want the silenced alert with its silence", got)
+	}
+}
+
+func TestWriter_WriteCombined_AlertmanagerSheet_English(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	w := NewWriter(time.UTC, WithLanguage(i18n.LanguageEN), WithAlertmanagerAlerts(testAlertmanagerAlerts()))
+	if err := w.WriteCombined(&json.Report{Host: createTestInspectionResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Alertmanager Alerts")
+	if err != nil {
+		t.Fatalf("Alertmanager sheet missing: %v", err)
+	}
+	if rows[0][6] != "Silenced By" || rows[1][5] != "Firing" || rows[2][5] != "Silenced" {
+		t.Errorf("headers / states not translated: %v, %v, %v", rows[0], rows[1], rows[2])
+	}
+}
+
+func TestWriter_Finalize_NoAlertmanagerAlerts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	hostResult := createTestInspectionResult()
+
+	w := NewWriter(nil)
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetAlertmanager); idx != -1 {
+		t.Error("Alertmanager sheet should only be created with Alertmanager alerts")
+	}
+}
@@ -0,0 +1,122 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// WithHostGrouping adds a host statistics sheet per CMDB attribute ("负责人统计", "应用统计",
+// "位置统计"), aggregating the hosts like the business group sheet. keys are model.HostGroupKey
+// values; unknown keys and the business group (always aggregated) are ignored.
+func WithHostGrouping(keys []string) Option {
+	return func(w *Writer) {
+		w.hostGroupBy = nil
+		for _, key := range keys {
+			if hostGroupSheet(model.HostGroupKey(key)) != "" {
+				w.hostGroupBy = append(w.hostGroupBy, model.HostGroupKey(key))
+			}
+		}
+	}
+}
+
+// hostGroupSheet returns the statistics sheet of a CMDB grouping key, or "" for other keys.
+func hostGroupSheet(key model.HostGroupKey) string {
+	switch key {
+	case model.HostGroupOwner:
+		return sheetOwnerGroups
+	case model.HostGroupApplication:
+		return sheetApplicationGroups
+	case model.HostGroupLocation:
+		return sheetLocationGroups
+	}
+	return ""
+}
+
+// createBusinessGroupsSheet aggregates the hosts by N9E business group: host counts by status,
+// average CPU / memory usage and alert counts, so each team sees its own slice. Without
+// business group (no busigroup label on the host series) no sheet is created.
+func (w *Writer) createBusinessGroupsSheet(f *excelize.File, result *model.InspectionResult) error {
+	return w.createHostGroupSheet(f, result, model.HostGroupBusinessGroup, sheetBusinessGroups)
+}
+
+// createHostGroupSheets creates the statistics sheets of the CMDB grouping keys selected by
+// WithHostGrouping.
+func (w *Writer) createHostGroupSheets(f *excelize.File, result *model.InspectionResult) error {
+	for _, key := range w.hostGroupBy {
+		if err := w.createHostGroupSheet(f, result, key, hostGroupSheet(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createHostGroupSheet aggregates the hosts by the value of key into sheet. Without any host
+// having a value no sheet is created.
+func (w *Writer) createHostGroupSheet(f *excelize.File, result *model.InspectionResult, key model.HostGroupKey, sheet string) error {
+	stats := model.NewHostGroupStats(result.Hosts, key)
+	if len(stats) == 0 {
+		return nil
+	}
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	percentStyle, err := w.createPercentStyle(f)
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{key.Label(), "主机数", "正常", "警告", "严重", "失败", "平均CPU利用率", "平均内存利用率", "严重告警", "警告告警"}
+	widths := []float64{wideColWidth, narrowColWidth, narrowColWidth, narrowColWidth, narrowColWidth, narrowColWidth, defaultColWidth, defaultColWidth, narrowColWidth, narrowColWidth}
+	w.writeFrozenHeader(f, sheet, headers, widths, headerStyle)
+
+	for i, s := range stats {
+		row := i + 2
+		values := []interface{}{
+			s.Name,
+			s.Summary.TotalHosts, s.Summary.NormalHosts, s.Summary.WarningHosts, s.Summary.CriticalHosts, s.Summary.FailedHosts,
+			averageCellValue(s.AvgCPUUsage, s.HasAvgCPUUsage()), averageCellValue(s.AvgMemoryUsage, s.HasAvgMemoryUsage()),
+			s.AlertSummary.CriticalCount, s.AlertSummary.WarningCount,
+		}
+		for col, value := range values {
+			f.SetCellValue(sheet, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+
+		f.SetCellStyle(sheet, fmt.Sprintf("G%d", row), fmt.Sprintf("H%d", row), percentStyle)
+		if s.AlertSummary.CriticalCount > 0 {
+			cell := fmt.Sprintf("I%d", row)
+			f.SetCellStyle(sheet, cell, cell, criticalStyle)
+		}
+		if s.AlertSummary.WarningCount > 0 {
+			cell := fmt.Sprintf("J%d", row)
+			f.SetCellStyle(sheet, cell, cell, warningStyle)
+		}
+	}
+
+	f.AutoFilter(sheet, fmt.Sprintf("A1:%s%d", columnName(len(headers)), len(stats)+1), nil)
+
+	return nil
+}
+
+// averageCellValue returns an average usage as a ratio for the percent format, or "N/A".
+func averageCellValue(percent float64, ok bool) interface{} {
+	if !ok {
+		return "N/A"
+	}
+	return percent / 100
+}
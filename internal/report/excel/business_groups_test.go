@@ -0,0 +1,138 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/pkg/testfixtures"
+)
+
+func TestWriter_Write_BusinessGroupsSheet(t *testing.T) {
+	web := testfixtures.NewHost("web-1", "10.0.0.1")
+	web.BusinessGroup = "payment"
+	testfixtures.AddHostAlert(web, "cpu_usage", 95)
+	db := testfixtures.NewHost("db-1", "10.0.0.2")
+	db.BusinessGroup = "order"
+	result := testfixtures.NewInspectionResult(web, db, testfixtures.NewHost("legacy-1", "10.0.0.3"))
+
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetBusinessGroups)
+	if err != nil {
+		t.Fatalf("business groups sheet missing: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("business groups sheet has %d rows, want header and 3 groups", len(rows))
+	}
+	if got := rows[0][0]; got != "业务组" {
+		t.Errorf("header A1 = %q, want 业务组", got)
+	}
+	payment := rows[2]
+	if payment[0] != "payment" || payment[1] != "1" || payment[4] != "1" || payment[8] != "1" {
+		t.Errorf("payment row = %v, want 1 critical host with 1 critical alert", payment)
+	}
+	if got := rows[3][0]; got != "未分组" {
+		t.Errorf("last group = %q, want 未分组", got)
+	}
+
+	// Averages stay numeric, shown as percentages
+	if value, _ := f.GetCellValue(sheetBusinessGroups, "G3"); value != "95.0%" {
+		t.Errorf("payment average CPU = %q, want 95.0%%", value)
+	}
+}
+
+func TestWriter_Write_NoBusinessGroups(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetBusinessGroups); idx >= 0 {
+		t.Error("business groups sheet should not be created without business groups")
+	}
+}
+
+func TestWriter_Write_CMDBGroupsAndColumns(t *testing.T) {
+	web := testfixtures.NewHost("web-1", "10.0.0.1")
+	web.Owner, web.Application, web.Location = "alice", "shop", "IDC-A/R01"
+	db := testfixtures.NewHost("db-1", "10.0.0.2")
+	db.Owner, db.Application, db.Maintenance = "bob", "shop", true
+	result := testfixtures.NewInspectionResult(web, db)
+
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil, WithHostGrouping([]string{"application", "business_group", "unknown"})).Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetApplicationGroups)
+	if err != nil {
+		t.Fatalf("application groups sheet missing: %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "应用" || rows[1][0] != "shop" || rows[1][1] != "2" {
+		t.Errorf("application groups rows = %v, want one group of 2 hosts", rows)
+	}
+	for _, sheet := range []string{sheetOwnerGroups, sheetLocationGroups, sheetBusinessGroups} {
+		if idx, _ := f.GetSheetIndex(sheet); idx >= 0 {
+			t.Errorf("sheet %s should not be created", sheet)
+		}
+	}
+
+	detail, err := f.GetRows(sheetDetail)
+	if err != nil {
+		t.Fatalf("detail sheet missing: %v", err)
+	}
+	if got := detail[0][3:7]; got[0] != "负责人" || got[3] != "维护状态" {
+		t.Errorf("detail headers = %v, want the CMDB columns after the status", detail[0])
+	}
+	for _, row := range detail[1:] {
+		if row[0] == "db-1" && (row[3] != "bob" || row[6] != "维护中") {
+			t.Errorf("db-1 row = %v, want owner bob in maintenance", row)
+		}
+	}
+}
+
+func TestWriter_Write_NoCMDBColumns(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil, WithHostGrouping([]string{"owner"})).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetOwnerGroups); idx >= 0 {
+		t.Error("owner groups sheet should not be created without CMDB data")
+	}
+	header, _ := f.GetRows(sheetDetail)
+	for _, cell := range header[0] {
+		if cell == "负责人" {
+			t.Error("CMDB columns should be left out without CMDB data")
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// capacityBlock is a ranking of the capacity sheet: the hosts with the highest value of a metric.
+type capacityBlock struct {
+	metric  string   // Ranked metric
+	title   string   // Block title, formatted with the number of hosts
+	headers []string // Headers after the common rank / hostname / IP / business group columns
+	values  func(host *model.HostResult, mv *model.MetricValue) []interface{}
+}
+
+// capacityBlocks are the rankings of the capacity sheet, in sheet order.
+var capacityBlocks = []capacityBlock{
+	{
+		metric:  "disk_usage_max",
+		title:   "磁盘利用率 Top %d",
+		headers: []string{"磁盘最大利用率", "挂载点"},
+		values: func(host *model.HostResult, mv *model.MetricValue) []interface{} {
+			return []interface{}{mv.RawValue / 100, fullestMountPoint(host)}
+		},
+	},
+	{
+		metric:  "memory_usage",
+		title:   "内存利用率 Top %d",
+		headers: []string{"内存利用率"},
+		values: func(host *model.HostResult, mv *model.MetricValue) []interface{} {
+			return []interface{}{mv.RawValue / 100}
+		},
+	},
+	{
+		metric:  "load_per_core",
+		title:   "每核负载 Top %d",
+		headers: []string{"每核负载", "1分钟负载", "CPU核心数"},
+		values: func(host *model.HostResult, mv *model.MetricValue) []interface{} {
+			load := interface{}("N/A")
+			if m := host.GetMetric("load_1m"); m != nil && !m.IsNA {
+				load = m.RawValue
+			}
+			return []interface{}{mv.RawValue, load, host.CPUCores}
+		},
+	},
+}
+
+// WithCapacityRanking adds a capacity ranking sheet ("容量排行") to host reports listing the
+// top n hosts by disk usage, memory usage and load per core, so capacity planning does not
+// require sorting the detail sheet by hand. Zero (the default) means no sheet.
+func WithCapacityRanking(n int) Option {
+	return func(w *Writer) {
+		w.capacityTopN = n
+	}
+}
+
+// createCapacitySheet creates the capacity ranking worksheet: one block per ranked metric,
+// each with its title, its own header row and up to capacityTopN hosts, highest first.
+// Metrics not collected on any host have no block; without any block no sheet is created.
+func (w *Writer) createCapacitySheet(f *excelize.File, result *model.InspectionResult) error {
+	if w.capacityTopN <= 0 || result == nil {
+		return nil
+	}
+	rankings := make([][]*model.HostResult, len(capacityBlocks))
+	empty := true
+	for i, block := range capacityBlocks {
+		rankings[i] = topHostsByMetric(result.Hosts, block.metric, w.capacityTopN)
+		if len(rankings[i]) > 0 {
+			empty = false
+		}
+	}
+	if empty {
+		return nil
+	}
+	if _, err := f.NewSheet(sheetCapacity); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	titleStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 12, Color: w.headerBgColor()},
+	})
+	if err != nil {
+		return err
+	}
+	percentStyle, err := w.createPercentStyle(f)
+	if err != nil {
+		return err
+	}
+	decimalFormat := "0.00"
+	decimalStyle, err := f.NewStyle(&excelize.Style{
+		CustomNumFmt: &decimalFormat,
+		Alignment:    &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	widths := []float64{narrowColWidth, wideColWidth, defaultColWidth, defaultColWidth, 14, 14, 12, 10}
+	for i, width := range widths {
+		col := columnName(i + 1)
+		f.SetColWidth(sheetCapacity, col, col, width)
+	}
+
+	row := 1
+	for i, block := range capacityBlocks {
+		hosts := rankings[i]
+		if len(hosts) == 0 {
+			continue
+		}
+
+		f.SetCellValue(sheetCapacity, fmt.Sprintf("A%d", row), fmt.Sprintf(block.title, w.capacityTopN))
+		f.SetCellStyle(sheetCapacity, fmt.Sprintf("A%d", row), fmt.Sprintf("A%d", row), titleStyle)
+		row++
+
+		headers := append([]string{"排名", "主机名", "IP地址", "业务组"}, block.headers...)
+		headers = append(headers, "状态")
+		for col, header := range headers {
+			cell := fmt.Sprintf("%s%d", columnName(col+1), row)
+			f.SetCellValue(sheetCapacity, cell, header)
+			f.SetCellStyle(sheetCapacity, cell, cell, headerStyle)
+		}
+		row++
+
+		for rank, host := range hosts {
+			mv := host.GetMetric(block.metric)
+			values := append([]interface{}{rank + 1, host.Hostname, host.IP, host.BusinessGroup}, block.values(host, mv)...)
+			values = append(values, metricStatusText(mv.Status))
+			for col, value := range values {
+				f.SetCellValue(sheetCapacity, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+			}
+
+			// Percentages are stored as ratios, loads keep two decimals
+			valueCell := fmt.Sprintf("E%d", row)
+			switch block.metric {
+			case "load_per_core":
+				f.SetCellStyle(sheetCapacity, valueCell, fmt.Sprintf("F%d", row), decimalStyle)
+			default:
+				f.SetCellStyle(sheetCapacity, valueCell, valueCell, percentStyle)
+			}
+			statusCell := fmt.Sprintf("%s%d", columnName(len(values)), row)
+			switch mv.Status {
+			case model.MetricStatusCritical:
+				f.SetCellStyle(sheetCapacity, statusCell, statusCell, criticalStyle)
+			case model.MetricStatusWarning:
+				f.SetCellStyle(sheetCapacity, statusCell, statusCell, warningStyle)
+			}
+			row++
+		}
+		row++ // Blank row between the blocks
+	}
+
+	return nil
+}
+
+// topHostsByMetric returns up to n hosts with the highest value of metric, highest first.
+// Hosts without a collected value are skipped.
+func topHostsByMetric(hosts []*model.HostResult, metric string, n int) []*model.HostResult {
+	result := make([]*model.HostResult, 0, len(hosts))
+	for _, host := range hosts {
+		if host == nil {
+			continue
+		}
+		if mv := host.GetMetric(metric); mv != nil && !mv.IsNA {
+			result = append(result, host)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].GetMetric(metric).RawValue > result[j].GetMetric(metric).RawValue
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// fullestMountPoint returns the mount point with the highest disk usage of a host, or "-"
+// when the per-mount-point usage was not collected.
+func fullestMountPoint(host *model.HostResult) string {
+	path, max := "-", -1.0
+	for name, mv := range host.Metrics {
+		if mv == nil || mv.IsNA || !strings.HasPrefix(name, "disk_usage:") {
+			continue
+		}
+		if mv.RawValue > max || (mv.RawValue == max && name < "disk_usage:"+path) {
+			path, max = strings.TrimPrefix(name, "disk_usage:"), mv.RawValue
+		}
+	}
+	return path
+}
@@ -0,0 +1,70 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriter_Write_CapacitySheet(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil, WithCapacityRanking(2)).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetCapacity)
+	if err != nil {
+		t.Fatalf("capacity sheet missing: %v", err)
+	}
+	// Disk and memory blocks; load per core is not collected in the test data
+	if len(rows) != 9 {
+		t.Fatalf("capacity sheet has %d rows, want 2 blocks of title, header and 2 hosts: %v", len(rows), rows)
+	}
+
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"A1", "磁盘利用率 Top 2"},
+		{"E2", "磁盘最大利用率"},
+		{"G2", "状态"},
+		{"B3", "host-3"},
+		{"E3", "88.0%"},
+		{"F3", "/"},
+		{"G3", "警告"},
+		{"B4", "host-1"},
+		{"A6", "内存利用率 Top 2"},
+		{"F7", "状态"},
+		{"B8", "host-3"},
+		{"B9", "host-2"},
+	}
+	for _, tt := range tests {
+		if got, _ := f.GetCellValue(sheetCapacity, tt.cell); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestWriter_Write_CapacitySheetDisabled(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetCapacity); idx != -1 {
+		t.Error("capacity sheet should not be created without WithCapacityRanking")
+	}
+}
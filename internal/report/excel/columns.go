@@ -0,0 +1,342 @@
+package excel
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// Column keys accepted by WithColumns for the host detail sheet ("详细数据"), in the default order.
+// HostColumnDisks expands to one "磁盘:<path>" column per mount point.
+var HostColumnKeys = []string{
+	"hostname", "ip", "status", "owner", "application", "location", "maintenance",
+	"os", "os_version", "kernel_version",
+	"cpu_cores", "cpu_usage", "memory_usage", "disk_usage_max",
+	"uptime", "load_1m", "load_per_core", "processes_zombies", "processes_total",
+	HostColumnDisks,
+}
+
+// HostColumnDisks is the host column key of the per-mount-point disk usage columns.
+const HostColumnDisks = "disks"
+
+// hostCMDBColumnKeys are the host columns filled by the CMDB, left out of the detail sheet
+// when no host was enriched.
+var hostCMDBColumnKeys = []string{"owner", "application", "location", "maintenance"}
+
+// Column keys accepted by WithColumns for the MySQL inspection sheet, in the default order.
+var MySQLColumnKeys = []string{
+	"inspection_time", "ip", "port", "version", "server_id",
+	"cluster_mode", "sync_status", "max_connections", "current_connections", "qps", "tps",
+	"slow_queries", "threads_running", "binlog", "status",
+}
+
+// Column keys accepted by WithColumns for the Redis inspection and Redis cluster sheets,
+// in the default order.
+var RedisColumnKeys = []string{
+	"inspection_time", "ip", "port", "app_type", "version",
+	"non_root_user", "connection_status", "cluster_enabled", "master_link_status",
+	"role", "master_port", "replication_lag", "max_clients", "memory_usage",
+	"fragmentation_ratio", "evicted_keys", "expired_keys", "keyspace_hit_ratio", "status",
+}
+
+// sheetColumn describes one column of an inspection sheet: the config key that selects it,
+// its header and width, and how the cell of a row is written.
+type sheetColumn[T any] struct {
+	key    string
+	header string
+	width  float64
+	write  func(c columnCell, r T)
+}
+
+// columnCell is the cell a sheetColumn writes, with the styles used to highlight it.
+type columnCell struct {
+	cell     *excelize.Cell // Streamed cell being written
+	warning  int
+	critical int
+	normal   int
+}
+
+// set writes the cell value.
+func (c columnCell) set(value interface{}) {
+	c.cell.Value = value
+}
+
+// style applies a cell style; zero means no style.
+func (c columnCell) style(style int) {
+	if style > 0 {
+		c.cell.StyleID = style
+	}
+}
+
+// alertStyle returns the highlight style of an alert level (0 for other levels).
+func (c columnCell) alertStyle(level model.AlertLevel) int {
+	switch level {
+	case model.AlertLevelCritical:
+		return c.critical
+	case model.AlertLevelWarning:
+		return c.warning
+	}
+	return 0
+}
+
+// selectColumns returns the columns of the given keys in that order. An empty key list keeps
+// all columns in their default order; unknown keys are ignored. All columns sharing a key
+// (e.g. the per-mount-point disk columns) are selected together.
+func selectColumns[T any](columns []sheetColumn[T], keys []string) []sheetColumn[T] {
+	if len(keys) == 0 {
+		return columns
+	}
+	selected := make([]sheetColumn[T], 0, len(columns))
+	for _, key := range keys {
+		for _, col := range columns {
+			if col.key == key {
+				selected = append(selected, col)
+			}
+		}
+	}
+	return selected
+}
+
+// dropColumns returns the columns whose key is not in keys.
+func dropColumns[T any](columns []sheetColumn[T], keys []string) []sheetColumn[T] {
+	kept := make([]sheetColumn[T], 0, len(columns))
+	for _, col := range columns {
+		if !slices.Contains(keys, col.key) {
+			kept = append(kept, col)
+		}
+	}
+	return kept
+}
+
+// writeSheetColumns queues the sheet to be streamed on save: the header row of the given
+// columns, frozen, and one row per item starting from row 2.
+func writeSheetColumns[T any](w *Writer, f *excelize.File, sheet string, columns []sheetColumn[T], items []T, headerStyle, warningStyle, criticalStyle, normalStyle int) error {
+	return w.streamSheet(f, sheet, func(sw *excelize.StreamWriter) error {
+		header := make([]excelize.Cell, len(columns))
+		widths := make([]float64, len(columns))
+		for i, col := range columns {
+			header[i] = excelize.Cell{StyleID: headerStyle, Value: col.header}
+			widths[i] = col.width
+		}
+		if err := w.streamHeader(sw, header, widths); err != nil {
+			return err
+		}
+
+		for i, item := range items {
+			cells := make([]excelize.Cell, len(columns))
+			for j, col := range columns {
+				col.write(columnCell{
+					cell:     &cells[j],
+					warning:  warningStyle,
+					critical: criticalStyle,
+					normal:   normalStyle,
+				}, item)
+			}
+			if err := w.streamRow(sw, i+2, cells); err != nil { // Start from row 2
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// hostColumns returns the host detail sheet columns, with one disk column per mount point.
+// Usage columns with a threshold (WithUsageThresholds) are written as ratios in percentStyle
+// and colored by the conditional formatting of the sheet.
+func (w *Writer) hostColumns(diskPaths []string, percentStyle int) []sheetColumn[*model.HostResult] {
+	metric := func(name string, highlight bool) func(c columnCell, host *model.HostResult) {
+		return func(c columnCell, host *model.HostResult) {
+			if _, ok := w.usageThreshold(name); ok {
+				if m := host.Metrics[name]; m != nil && !m.IsNA {
+					c.set(m.RawValue / 100)
+					c.style(percentStyle)
+					return
+				}
+			}
+			c.set(metricCellValue(host.Metrics[name]))
+			if highlight {
+				c.style(metricCellStyle(host.Metrics[name], c.warning, c.critical))
+			}
+		}
+	}
+
+	columns := []sheetColumn[*model.HostResult]{
+		{"hostname", "主机名", 20, func(c columnCell, host *model.HostResult) { c.set(host.Hostname) }},
+		{"ip", "IP地址", 15, func(c columnCell, host *model.HostResult) { c.set(host.IP) }},
+		{"status", "状态", 10, func(c columnCell, host *model.HostResult) {
+			c.set(statusText(host.Status))
+			c.style(w.getStatusStyle(host.Status, c.normal, c.warning, c.critical))
+		}},
+		{"owner", "负责人", 12, func(c columnCell, host *model.HostResult) { c.set(host.Owner) }},
+		{"application", "应用", 15, func(c columnCell, host *model.HostResult) { c.set(host.Application) }},
+		{"location", "位置", 15, func(c columnCell, host *model.HostResult) { c.set(host.Location) }},
+		{"maintenance", "维护状态", 10, func(c columnCell, host *model.HostResult) { c.set(maintenanceText(host.Maintenance)) }},
+		{"os", "操作系统", 12, func(c columnCell, host *model.HostResult) { c.set(host.OS) }},
+		{"os_version", "系统版本", 20, func(c columnCell, host *model.HostResult) { c.set(host.OSVersion) }},
+		{"kernel_version", "内核版本", 30, func(c columnCell, host *model.HostResult) { c.set(host.KernelVersion) }},
+		{"cpu_cores", "CPU核心数", 10, func(c columnCell, host *model.HostResult) { c.set(host.CPUCores) }},
+		{"cpu_usage", "CPU利用率", 12, metric("cpu_usage", true)},
+		{"memory_usage", "内存利用率", 12, metric("memory_usage", true)},
+		{"disk_usage_max", "磁盘最大利用率", 14, metric("disk_usage_max", true)},
+		{"uptime", "运行时间", 15, metric("uptime", false)},
+		{"load_1m", "1分钟负载", 12, metric("load_1m", false)},
+		{"load_per_core", "每核负载", 10, metric("load_per_core", true)},
+		{"processes_zombies", "僵尸进程", 10, metric("processes_zombies", true)},
+		{"processes_total", "总进程数", 10, metric("processes_total", false)},
+	}
+
+	// Disk usage by path
+	for _, path := range diskPaths {
+		columns = append(columns, sheetColumn[*model.HostResult]{
+			HostColumnDisks, fmt.Sprintf("磁盘:%s", path), 15, metric(fmt.Sprintf("disk_usage:%s", path), true),
+		})
+	}
+
+	return columns
+}
+
+// maintenanceText returns the maintenance status cell of a host.
+func maintenanceText(maintenance bool) string {
+	if maintenance {
+		return "维护中"
+	}
+	return ""
+}
+
+// mysqlColumns returns the MySQL inspection sheet columns.
+func (w *Writer) mysqlColumns(inspectionTime time.Time) []sheetColumn[*model.MySQLInspectionResult] {
+	// alertMetric writes a value and highlights it when the instance has an alert on the metric.
+	alertMetric := func(metricName string, value func(r *model.MySQLInspectionResult) interface{}) func(c columnCell, r *model.MySQLInspectionResult) {
+		return func(c columnCell, r *model.MySQLInspectionResult) {
+			c.set(value(r))
+			for _, alert := range r.Alerts {
+				if alert.MetricName == metricName {
+					c.style(c.alertStyle(alert.Level))
+				}
+			}
+		}
+	}
+
+	return []sheetColumn[*model.MySQLInspectionResult]{
+		{"inspection_time", "巡检时间", w.inspectionTimeWidth(20), func(c columnCell, _ *model.MySQLInspectionResult) {
+			c.set(w.formatInspectionTime(inspectionTime))
+		}},
+		{"ip", "IP地址", 15, func(c columnCell, r *model.MySQLInspectionResult) { c.set(r.Instance.IP) }},
+		{"port", "端口", 8, func(c columnCell, r *model.MySQLInspectionResult) { c.set(r.Instance.Port) }},
+		{"version", "数据库版本", 12, func(c columnCell, r *model.MySQLInspectionResult) { c.set(r.Instance.Version) }},
+		{"server_id", "Server ID", 12, func(c columnCell, r *model.MySQLInspectionResult) { c.set(r.Instance.ServerID) }},
+		{"cluster_mode", "集群模式", 12, func(c columnCell, r *model.MySQLInspectionResult) {
+			c.set(mysqlClusterModeText(r.Instance.ClusterMode))
+		}},
+		{"sync_status", "同步状态", 10, func(c columnCell, r *model.MySQLInspectionResult) { c.set(w.getMySQLSyncStatus(r)) }},
+		{"max_connections", "最大连接数", 12, func(c columnCell, r *model.MySQLInspectionResult) { c.set(r.MaxConnections) }},
+		{"current_connections", "当前连接数", 12, func(c columnCell, r *model.MySQLInspectionResult) { c.set(r.CurrentConnections) }},
+		{"qps", "QPS", 10, func(c columnCell, r *model.MySQLInspectionResult) { c.set(fmt.Sprintf("%.1f", r.QPS)) }},
+		{"tps", "TPS", 10, func(c columnCell, r *model.MySQLInspectionResult) { c.set(fmt.Sprintf("%.1f", r.TPS)) }},
+		{"slow_queries", "慢查询/秒", 12, alertMetric("slow_queries", func(r *model.MySQLInspectionResult) interface{} {
+			return fmt.Sprintf("%.2f", r.SlowQueriesPerSecond)
+		})},
+		{"threads_running", "运行线程数", 12, alertMetric("threads_running", func(r *model.MySQLInspectionResult) interface{} {
+			return r.ThreadsRunning
+		})},
+		{"binlog", "Binlog状态", 12, func(c columnCell, r *model.MySQLInspectionResult) {
+			c.set(model.FormatBool("binlog_enabled", r.BinlogEnabled))
+		}},
+		{"status", "整体状态", 10, func(c columnCell, r *model.MySQLInspectionResult) {
+			c.set(mysqlStatusText(r.Status))
+			switch r.Status {
+			case model.MySQLStatusCritical:
+				c.style(c.critical)
+			case model.MySQLStatusWarning:
+				c.style(c.warning)
+			case model.MySQLStatusNormal:
+				c.style(c.normal)
+			}
+		}},
+	}
+}
+
+// redisColumns returns the Redis inspection sheet columns, shared by the Redis cluster sheets.
+func (w *Writer) redisColumns(inspectionTime time.Time) []sheetColumn[*model.RedisInspectionResult] {
+	// alertMetric writes a value and highlights it when the instance has an alert on the metric.
+	alertMetric := func(metricName string, value func(r *model.RedisInspectionResult) string) func(c columnCell, r *model.RedisInspectionResult) {
+		return func(c columnCell, r *model.RedisInspectionResult) {
+			c.set(value(r))
+			for _, alert := range r.Alerts {
+				if alert.MetricName == metricName {
+					c.style(c.alertStyle(alert.Level))
+				}
+			}
+		}
+	}
+
+	return []sheetColumn[*model.RedisInspectionResult]{
+		{"inspection_time", "巡检时间", w.inspectionTimeWidth(18), func(c columnCell, _ *model.RedisInspectionResult) {
+			c.set(w.formatInspectionTime(inspectionTime))
+		}},
+		{"ip", "IP地址", 15, func(c columnCell, r *model.RedisInspectionResult) {
+			if r.Instance != nil {
+				c.set(r.Instance.IP)
+			}
+		}},
+		{"port", "端口", 8, func(c columnCell, r *model.RedisInspectionResult) {
+			if r.Instance != nil {
+				c.set(r.Instance.Port)
+			}
+		}},
+		{"app_type", "应用类型", 8, func(c columnCell, _ *model.RedisInspectionResult) { c.set("Redis") }},
+		{"version", "Redis版本", 12, func(c columnCell, r *model.RedisInspectionResult) {
+			if r.Instance != nil && r.Instance.Version != "" {
+				c.set(r.Instance.Version)
+			} else {
+				c.set("N/A")
+			}
+		}},
+		{"non_root_user", "是否普通用户启动", 15, func(c columnCell, r *model.RedisInspectionResult) { c.set(r.NonRootUser) }},
+		{"connection_status", "连接状态", 10, func(c columnCell, r *model.RedisInspectionResult) {
+			c.set(model.FormatBool("redis_up", r.ConnectionStatus))
+		}},
+		{"cluster_enabled", "集群模式", 10, func(c columnCell, r *model.RedisInspectionResult) {
+			c.set(model.FormatBool("redis_cluster_enabled", r.ClusterEnabled))
+		}},
+		{"master_link_status", "主从链接状态", 12, func(c columnCell, r *model.RedisInspectionResult) {
+			c.set(w.getMasterLinkStatusText(r))
+		}},
+		{"role", "节点角色", 10, func(c columnCell, r *model.RedisInspectionResult) {
+			if r.Instance != nil {
+				c.set(redisRoleText(r.Instance.Role))
+			} else {
+				c.set("未知")
+			}
+		}},
+		{"master_port", "Master端口", 10, func(c columnCell, r *model.RedisInspectionResult) { c.set(w.getMasterPortText(r)) }},
+		{"replication_lag", "复制延迟", 12, func(c columnCell, r *model.RedisInspectionResult) { c.set(w.getReplicationLagText(r)) }},
+		{"max_clients", "最大连接数", 10, func(c columnCell, r *model.RedisInspectionResult) { c.set(r.MaxClients) }},
+		{"memory_usage", "内存使用率", 10, alertMetric("memory_usage", w.getMemoryUsageText)},
+		{"fragmentation_ratio", "碎片率", 8, alertMetric("fragmentation_ratio", func(r *model.RedisInspectionResult) string {
+			return fmt.Sprintf("%.2f", r.MemFragmentationRatio)
+		})},
+		{"evicted_keys", "驱逐/秒", 10, alertMetric("evicted_keys", func(r *model.RedisInspectionResult) string {
+			return fmt.Sprintf("%.2f", r.EvictedKeysPerSecond)
+		})},
+		{"expired_keys", "过期/秒", 10, func(c columnCell, r *model.RedisInspectionResult) {
+			c.set(fmt.Sprintf("%.2f", r.ExpiredKeysPerSecond))
+		}},
+		{"keyspace_hit_ratio", "命中率", 10, alertMetric("keyspace_hit_ratio", w.getKeyspaceHitRatioText)},
+		{"status", "整体状态", 10, func(c columnCell, r *model.RedisInspectionResult) {
+			c.set(redisStatusText(r.Status))
+			switch r.Status {
+			case model.RedisStatusCritical:
+				c.style(c.critical)
+			case model.RedisStatusWarning:
+				c.style(c.warning)
+			case model.RedisStatusNormal:
+				c.style(c.normal)
+			}
+		}},
+	}
+}
@@ -0,0 +1,182 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSelectColumns(t *testing.T) {
+	w := NewWriter(nil)
+	columns := w.hostColumns([]string{"/", "/data"}, 0)
+
+	tests := []struct {
+		name string
+		keys []string
+		want []string
+	}{
+		{
+			name: "empty keys keep default order",
+			keys: nil,
+			want: []string{"主机名", "IP地址", "状态", "负责人", "应用", "位置", "维护状态", "操作系统", "系统版本", "内核版本",
+				"CPU核心数", "CPU利用率", "内存利用率", "磁盘最大利用率",
+				"运行时间", "1分钟负载", "每核负载", "僵尸进程", "总进程数", "磁盘:/", "磁盘:/data"},
+		},
+		{
+			name: "selected keys in given order",
+			keys: []string{"ip", "hostname", "disks", "status"},
+			want: []string{"IP地址", "主机名", "磁盘:/", "磁盘:/data", "状态"},
+		},
+		{
+			name: "unknown keys ignored",
+			keys: []string{"hostname", "oracle"},
+			want: []string{"主机名"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectColumns(columns, tt.keys)
+			if len(got) != len(tt.want) {
+				t.Fatalf("selectColumns() returned %d columns, want %d", len(got), len(tt.want))
+			}
+			for i, col := range got {
+				if col.header != tt.want[i] {
+					t.Errorf("column %d = %q, want %q", i, col.header, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestColumnKeys_MatchColumns(t *testing.T) {
+	w := NewWriter(nil)
+
+	var hostKeys []string
+	for _, col := range w.hostColumns([]string{"/"}, 0) {
+		hostKeys = append(hostKeys, col.key)
+	}
+	var mysqlKeys []string
+	for _, col := range w.mysqlColumns(createTestMySQLInspectionResults().InspectionTime) {
+		mysqlKeys = append(mysqlKeys, col.key)
+	}
+	var redisKeys []string
+	for _, col := range w.redisColumns(createTestRedisInspectionResults().InspectionTime) {
+		redisKeys = append(redisKeys, col.key)
+	}
+
+	for _, tt := range []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"host", hostKeys, HostColumnKeys},
+		{"mysql", mysqlKeys, MySQLColumnKeys},
+		{"redis", redisKeys, RedisColumnKeys},
+	} {
+		if len(tt.got) != len(tt.want) {
+			t.Fatalf("%s: %d columns, want %d keys", tt.name, len(tt.got), len(tt.want))
+		}
+		for i := range tt.got {
+			if tt.got[i] != tt.want[i] {
+				t.Errorf("%s column %d key = %q, want %q", tt.name, i, tt.got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestWriter_DetailSheet_Columns(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "test_report.xlsx")
+
+	w := NewWriter(nil, WithColumns(ModuleHost, []string{"ip", "hostname", "status", "disks"}))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetDetail)
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	want := []string{"IP地址", "主机名", "状态", "磁盘:/", "磁盘:/home"}
+	if len(rows[0]) != len(want) {
+		t.Fatalf("header = %v, want %v", rows[0], want)
+	}
+	for i, header := range want {
+		if rows[0][i] != header {
+			t.Errorf("header %d = %q, want %q", i, rows[0][i], header)
+		}
+	}
+	if rows[1][0] != "192.168.1.1" || rows[1][1] != "host-1" || rows[1][2] != "正常" {
+		t.Errorf("row 2 = %v, want IP, hostname and status of host-1", rows[1])
+	}
+
+	// The status style follows the status column
+	style, _ := f.GetCellStyle(sheetDetail, "C2")
+	if style == 0 {
+		t.Error("status cell C2 should be styled")
+	}
+}
+
+func TestWriter_MySQLSheet_Columns(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "test_mysql_report.xlsx")
+
+	w := NewWriter(nil, WithColumns(ModuleMySQL, []string{"status", "ip", "port"}))
+	if err := w.WriteMySQLInspection(createTestMySQLInspectionResults(), outputPath); err != nil {
+		t.Fatalf("WriteMySQLInspection() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetMySQL)
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(rows[0]) != 3 || rows[0][0] != "整体状态" || rows[0][1] != "IP地址" || rows[0][2] != "端口" {
+		t.Errorf("header = %v, want [整体状态 IP地址 端口]", rows[0])
+	}
+}
+
+func TestWriter_RedisSheet_Columns(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "test_redis_report.xlsx")
+
+	w := NewWriter(nil, WithColumns(ModuleRedis, []string{"ip", "role", "status"}))
+	if err := w.WriteRedisInspection(createTestRedisInspectionResults(), outputPath); err != nil {
+		t.Fatalf("WriteRedisInspection() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		cell     string
+		expected string
+	}{
+		{"A1", "IP地址"},
+		{"B1", "节点角色"},
+		{"C1", "整体状态"},
+		{"D1", ""},
+		{"A2", "192.18.102.2"},
+		{"B2", "主"},
+		{"C2", "正常"},
+	}
+	for _, tt := range tests {
+		value, _ := f.GetCellValue(sheetRedis, tt.cell)
+		if value != tt.expected {
+			t.Errorf("Cell %s = %q, want %q", tt.cell, value, tt.expected)
+		}
+	}
+}
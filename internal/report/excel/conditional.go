@@ -0,0 +1,136 @@
+package excel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// Usage threshold keys accepted by WithUsageThresholds.
+const (
+	UsageCPU    = "cpu_usage"
+	UsageMemory = "memory_usage"
+	UsageDisk   = "disk_usage" // Applies to the maximum and per-mount-point disk usage columns
+)
+
+// UsageThreshold is the warning and critical threshold of a usage column, in percent. A
+// threshold of 0 disables its level.
+type UsageThreshold struct {
+	Warning  float64
+	Critical float64
+	Below    bool // Lower values are worse
+}
+
+// usageColumnMetrics maps the host detail sheet column keys to the usage metric they show;
+// the per-mount-point disk columns share the "disk_usage:" prefix.
+var usageColumnMetrics = map[string]string{
+	"cpu_usage":      "cpu_usage",
+	"memory_usage":   "memory_usage",
+	"disk_usage_max": "disk_usage_max",
+	HostColumnDisks:  "disk_usage:",
+}
+
+// WithUsageThresholds colors the CPU, memory and disk usage columns of the host detail sheet
+// ("详细数据") with native Excel conditional formatting instead of per-cell styles: the usage
+// is written as a number, highlighted by warning / critical rules and shown with a data bar,
+// so values edited afterwards keep the right coloring. thresholds is keyed by UsageCPU,
+// UsageMemory and UsageDisk; usage columns without a threshold keep per-cell styles.
+func WithUsageThresholds(thresholds map[string]UsageThreshold) Option {
+	return func(w *Writer) {
+		w.usageThresholds = thresholds
+	}
+}
+
+// usageThreshold returns the threshold of a usage metric (e.g. "disk_usage:/data"), if any.
+func (w *Writer) usageThreshold(metric string) (UsageThreshold, bool) {
+	key := metric
+	switch {
+	case metric == "disk_usage_max", strings.HasPrefix(metric, "disk_usage:"):
+		key = UsageDisk
+	case metric != UsageCPU && metric != UsageMemory:
+		return UsageThreshold{}, false
+	}
+	t, ok := w.usageThresholds[key]
+	return t, ok
+}
+
+// createPercentStyle creates the number format of the usage cells colored by conditional
+// formatting: their values are ratios (0.455) shown as percentages ("45.5%").
+func (w *Writer) createPercentStyle(f *excelize.File) (int, error) {
+	format := "0.0%"
+	return f.NewStyle(&excelize.Style{
+		CustomNumFmt: &format,
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+}
+
+// setUsageConditionalFormats adds the conditional formatting rules of the usage columns with a
+// threshold to rows 2 to rows+1 of the sheet: critical and warning fills, then a data bar.
+// The rules are kept when the sheet rows are streamed.
+func (w *Writer) setUsageConditionalFormats(f *excelize.File, sheet string, columns []sheetColumn[*model.HostResult], rows int) error {
+	if rows == 0 || len(w.usageThresholds) == 0 {
+		return nil
+	}
+
+	warningFormat, err := f.NewConditionalStyle(&excelize.Style{
+		Font: &excelize.Font{Color: colorWarningFg},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{colorWarningBg}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+	criticalFormat, err := f.NewConditionalStyle(&excelize.Style{
+		Font: &excelize.Font{Color: colorCriticalFg},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{colorCriticalBg}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, col := range columns {
+		metric, ok := usageColumnMetrics[col.key]
+		if !ok {
+			continue
+		}
+		threshold, ok := w.usageThreshold(metric)
+		if !ok {
+			continue
+		}
+
+		column, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		// A threshold of 0 disables its level
+		var rules []excelize.ConditionalFormatOptions
+		if threshold.Critical != 0 {
+			rules = append(rules, excelize.ConditionalFormatOptions{Type: "formula", Criteria: usageRule(column, threshold.Critical, threshold.Below), Format: &criticalFormat, StopIfTrue: true})
+		}
+		if threshold.Warning != 0 {
+			rules = append(rules, excelize.ConditionalFormatOptions{Type: "formula", Criteria: usageRule(column, threshold.Warning, threshold.Below), Format: &warningFormat, StopIfTrue: true})
+		}
+		rules = append(rules, excelize.ConditionalFormatOptions{Type: "data_bar", Criteria: "=", MinType: "num", MinValue: "0", MaxType: "num", MaxValue: "1", BarColor: "#" + w.headerBgColor()})
+		if err := f.SetConditionalFormat(sheet, fmt.Sprintf("%s2:%s%d", column, column, rows+1), rules); err != nil {
+			return fmt.Errorf("failed to set conditional format of %s: %w", col.header, err)
+		}
+	}
+	return nil
+}
+
+// usageRule returns the formula of a threshold rule, relative to the first data row. Excel
+// ranks text above any number, so "N/A" cells are excluded explicitly.
+func usageRule(column string, percent float64, below bool) string {
+	operator := ">="
+	if below {
+		operator = "<="
+	}
+	ratio := strconv.FormatFloat(percent/100, 'f', -1, 64)
+	return fmt.Sprintf("AND(ISNUMBER(%s2),%s2%s%s)", column, column, operator, ratio)
+}
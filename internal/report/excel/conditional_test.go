@@ -0,0 +1,90 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriter_Write_UsageConditionalFormats(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+
+	w := NewWriter(nil, WithUsageThresholds(map[string]UsageThreshold{
+		UsageCPU:  {Warning: 70, Critical: 90},
+		UsageDisk: {Warning: 80, Critical: 90},
+	}))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	formats, err := f.GetConditionalFormats(sheetDetail)
+	if err != nil {
+		t.Fatalf("GetConditionalFormats() error = %v", err)
+	}
+	// CPU, maximum disk usage and the "/" and "/home" disk columns; memory has no threshold
+	for ref, warning := range map[string]string{"H2:H4": "0.7", "J2:J4": "0.8", "P2:P4": "0.8", "Q2:Q4": "0.8"} {
+		rules, ok := formats[ref]
+		if !ok {
+			t.Errorf("missing conditional format of %s in %v", ref, formats)
+			continue
+		}
+		column := ref[:1]
+		if len(rules) != 3 ||
+			rules[0].Criteria != "AND(ISNUMBER("+column+"2),"+column+"2>=0.9)" ||
+			rules[1].Criteria != "AND(ISNUMBER("+column+"2),"+column+"2>="+warning+")" ||
+			rules[2].Type != "data_bar" {
+			t.Errorf("rules of %s = %+v, want critical, warning %s and data bar", ref, rules, warning)
+		}
+	}
+	if _, ok := formats["I2:I4"]; ok {
+		t.Error("memory usage without threshold should not be conditionally formatted")
+	}
+
+	// Usage is a ratio displayed as a percentage, without per-cell highlight
+	if raw, _ := f.GetCellValue(sheetDetail, "H3", excelize.Options{RawCellValue: true}); raw != "0.75" {
+		t.Errorf("H3 raw value = %q, want 0.75", raw)
+	}
+	if value, _ := f.GetCellValue(sheetDetail, "H3"); value != "75.0%" {
+		t.Errorf("H3 = %q, want 75.0%%", value)
+	}
+	h2, _ := f.GetCellStyle(sheetDetail, "H2")
+	h3, _ := f.GetCellStyle(sheetDetail, "H3")
+	if h2 != h3 {
+		t.Errorf("H2 style = %d, H3 style = %d, want the same percent style", h2, h3)
+	}
+
+	// Columns without threshold keep their text and per-cell styles
+	if value, _ := f.GetCellValue(sheetDetail, "I2"); value != "60.0%" {
+		t.Errorf("I2 = %q, want 60.0%%", value)
+	}
+}
+
+func TestWriter_Write_NoUsageThresholds(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+
+	w := NewWriter(nil)
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	formats, err := f.GetConditionalFormats(sheetDetail)
+	if err != nil {
+		t.Fatalf("GetConditionalFormats() error = %v", err)
+	}
+	if len(formats) != 0 {
+		t.Errorf("conditional formats = %v, want none without thresholds", formats)
+	}
+}
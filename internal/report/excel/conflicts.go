@@ -0,0 +1,65 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// createSourceConflictsSheet lists the host metrics reported by several collection agents with
+// different values, and the source whose value the report uses. Without conflicts no sheet
+// is created.
+func (w *Writer) createSourceConflictsSheet(f *excelize.File, result *model.InspectionResult) error {
+	if len(result.SourceConflicts) == 0 {
+		return nil
+	}
+	if _, err := f.NewSheet(sheetSourceConflicts); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"主机名", "指标", "采用来源", "各来源取值"}
+	widths := []float64{wideColWidth, wideColWidth, defaultColWidth, 60}
+	w.writeFrozenHeader(f, sheetSourceConflicts, headers, widths, headerStyle)
+
+	for i, c := range result.SourceConflicts {
+		row := i + 2
+		values := []interface{}{c.Hostname, c.Metric, c.Source, sourceValuesText(c.Values)}
+		for col, value := range values {
+			f.SetCellValue(sheetSourceConflicts, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+	}
+
+	f.AutoFilter(sheetSourceConflicts, fmt.Sprintf("A1:D%d", len(result.SourceConflicts)+1), nil)
+
+	return nil
+}
+
+// sourceValuesText formats the values of each source ordered by source name,
+// e.g. "categraf=45.2, node-exporter=51".
+func sourceValuesText(values map[string]float64) string {
+	sources := make([]string, 0, len(values))
+	for source := range values {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	parts := make([]string, 0, len(sources))
+	for _, source := range sources {
+		name := source
+		if name == "" {
+			name = "-"
+		}
+		parts = append(parts, name+"="+strconv.FormatFloat(values[source], 'f', -1, 64))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,56 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+func TestWriter_Write_SourceConflictsSheet(t *testing.T) {
+	result := createTestInspectionResult()
+	result.SourceConflicts = []*model.SourceConflict{
+		{Hostname: "host-1", Metric: "cpu_usage", Source: "categraf", Values: map[string]float64{"node-exporter": 51, "categraf": 45.5}},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetSourceConflicts)
+	if err != nil {
+		t.Fatalf("source conflicts sheet missing: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("source conflicts sheet has %d rows, want 2", len(rows))
+	}
+	if got := rows[1]; got[0] != "host-1" || got[2] != "categraf" || got[3] != "categraf=45.5, node-exporter=51" {
+		t.Errorf("conflict row = %v", got)
+	}
+}
+
+func TestWriter_Write_NoSourceConflicts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetSourceConflicts); idx != -1 {
+		t.Error("source conflicts sheet should only be created with conflicts")
+	}
+}
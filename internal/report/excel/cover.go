@@ -0,0 +1,102 @@
+package excel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/report/theme"
+)
+
+// WithCover adds a cover sheet ("封面") in front of combined reports with the customer,
+// project, inspection engineer, period and logo of c. A nil cover means no cover sheet.
+func WithCover(c *theme.Cover) Option {
+	return func(w *Writer) {
+		w.cover = c
+	}
+}
+
+// createCoverSheet creates the cover sheet as the first sheet of the workbook: the logo, the
+// report title, the configured cover fields and the report date. The sheet is created with its
+// localized name, as it is added after the workbook was localized.
+func (w *Writer) createCoverSheet(f *excelize.File) error {
+	if w.cover == nil {
+		return nil
+	}
+
+	sheet := w.tr.SheetName(sheetCover)
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+	if err := f.MoveSheet(sheet, f.GetSheetList()[0]); err != nil {
+		return err
+	}
+
+	showGridLines := false
+	if err := f.SetSheetView(sheet, 0, &excelize.ViewOptions{ShowGridLines: &showGridLines}); err != nil {
+		return err
+	}
+	f.SetColWidth(sheet, "A", "A", 4)
+	f.SetColWidth(sheet, "B", "B", 20)
+	f.SetColWidth(sheet, "C", "C", 50)
+
+	titleStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Size: 24, Color: w.headerBgColor()},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	})
+	if err != nil {
+		return err
+	}
+	labelStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Size: 12, Color: "666666"},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	})
+	if err != nil {
+		return err
+	}
+	valueStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Logo (rows 2-6)
+	if w.cover.HasLogo() {
+		logo, ext, err := w.cover.LoadLogo()
+		if err != nil {
+			return err
+		}
+		if err := f.MergeCell(sheet, "B2", "C6"); err != nil {
+			return err
+		}
+		if err := f.AddPictureFromBytes(sheet, "B2", &excelize.Picture{
+			Extension: ext,
+			File:      logo,
+			Format:    &excelize.GraphicOptions{AutoFit: true, LockAspectRatio: true},
+		}); err != nil {
+			return fmt.Errorf("failed to add cover logo: %w", err)
+		}
+	}
+
+	// Title (row 8)
+	f.MergeCell(sheet, "B8", "C8")
+	f.SetCellValue(sheet, "B8", w.theme.GetTitle())
+	f.SetCellStyle(sheet, "B8", "C8", titleStyle)
+	f.SetRowHeight(sheet, 8, 40)
+
+	// Cover fields (from row 10)
+	fields := append(w.cover.Fields(), theme.CoverField{Label: "报告日期", Value: time.Now().In(w.timezone).Format("2006-01-02")})
+	for i, field := range fields {
+		row := i + 10
+		f.SetCellValue(sheet, fmt.Sprintf("B%d", row), field.Label)
+		f.SetCellStyle(sheet, fmt.Sprintf("B%d", row), fmt.Sprintf("B%d", row), labelStyle)
+		f.SetCellValue(sheet, fmt.Sprintf("C%d", row), field.Value)
+		f.SetCellStyle(sheet, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), valueStyle)
+		f.SetRowHeight(sheet, row, 24)
+	}
+
+	return nil
+}
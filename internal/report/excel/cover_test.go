@@ -0,0 +1,84 @@
+package excel
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
+)
+
+func TestWriter_Finalize_CoverSheet(t *testing.T) {
+	dir := t.TempDir()
+	logoPath := filepath.Join(dir, "logo.png")
+	file, err := os.Create(logoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(file, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	outputPath := filepath.Join(dir, "report.xlsx")
+	hostResult := createTestInspectionResult()
+
+	w := NewWriter(nil, WithCover(&theme.Cover{CustomerName: "ACME", Project: "核心系统", Engineer: "张三", LogoFile: logoPath}))
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if sheets := f.GetSheetList(); sheets[0] != sheetCover {
+		t.Errorf("sheets = %v, want the cover first", sheets)
+	}
+	if active := f.GetSheetName(f.GetActiveSheetIndex()); active != sheetCover {
+		t.Errorf("active sheet = %q, want the cover", active)
+	}
+
+	want := map[string]string{"B10": "客户名称", "C10": "ACME", "C11": "核心系统", "B12": "巡检工程师", "C12": "张三", "B13": "报告日期"}
+	for cell, expected := range want {
+		if value, _ := f.GetCellValue(sheetCover, cell); value != expected {
+			t.Errorf("%s = %q, want %q", cell, value, expected)
+		}
+	}
+	if pictures, err := f.GetPictures(sheetCover, "B2"); err != nil || len(pictures) != 1 {
+		t.Errorf("expected the logo at B2, got %d pictures (err = %v)", len(pictures), err)
+	}
+}
+
+func TestWriter_Finalize_NoCover(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	hostResult := createTestInspectionResult()
+
+	w := NewWriter(nil)
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetCover); idx >= 0 {
+		t.Error("no cover sheet expected without WithCover")
+	}
+}
@@ -0,0 +1,218 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// Sheet names of the inspection diff workbook.
+const (
+	sheetDiffSummary = "对比概览" // Run times and change counts
+	sheetDiffAlerts  = "告警变化" // New, resolved, escalated and downgraded alerts
+	sheetDiffMetrics = "指标变化" // Host metric deltas
+)
+
+// alertChangeText converts an alert change type to Chinese text.
+func alertChangeText(t model.AlertChangeType) string {
+	switch t {
+	case model.AlertChangeNew:
+		return "新增"
+	case model.AlertChangeResolved:
+		return "已恢复"
+	case model.AlertChangeEscalated:
+		return "升级"
+	case model.AlertChangeDowngraded:
+		return "降级"
+	default:
+		return "未知"
+	}
+}
+
+// WriteDiff generates the comparison report of two inspection runs: a summary, the alert
+// changes and the host metric deltas.
+func (w *Writer) WriteDiff(diff *model.ReportDiff, outputPath string) error {
+	if diff == nil {
+		return fmt.Errorf("report diff is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createDiffSummarySheet(f, diff); err != nil {
+		return fmt.Errorf("failed to create diff summary sheet: %w", err)
+	}
+	if err := w.createDiffAlertsSheet(f, diff); err != nil {
+		return fmt.Errorf("failed to create alert changes sheet: %w", err)
+	}
+	if err := w.createDiffMetricsSheet(f, diff); err != nil {
+		return fmt.Errorf("failed to create metric changes sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	idx, _ := f.GetSheetIndex(sheetDiffSummary)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// createDiffSummarySheet lists the times of both runs and the number of changes.
+func (w *Writer) createDiffSummarySheet(f *excelize.File, diff *model.ReportDiff) error {
+	if _, err := f.NewSheet(sheetDiffSummary); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	w.writeFrozenHeader(f, sheetDiffSummary, []string{"项目", "值"}, []float64{20, 60}, headerStyle)
+
+	rows := [][]interface{}{
+		{"旧巡检时间", w.formatReportTime(diff.OldTime)},
+		{"新巡检时间", w.formatReportTime(diff.NewTime)},
+		{"新增告警", diff.CountAlerts(model.AlertChangeNew)},
+		{"已恢复告警", diff.CountAlerts(model.AlertChangeResolved)},
+		{"升级告警", diff.CountAlerts(model.AlertChangeEscalated)},
+		{"降级告警", diff.CountAlerts(model.AlertChangeDowngraded)},
+		{"指标变化", len(diff.MetricDeltas)},
+		{"新增主机", hostListText(diff.AddedHosts)},
+		{"移除主机", hostListText(diff.RemovedHosts)},
+	}
+	for i, row := range rows {
+		f.SetCellValue(sheetDiffSummary, fmt.Sprintf("A%d", i+2), row[0])
+		f.SetCellValue(sheetDiffSummary, fmt.Sprintf("B%d", i+2), row[1])
+	}
+
+	return nil
+}
+
+// createDiffAlertsSheet lists the alert changes, highlighting the change type: new and
+// escalated alerts in the color of their new level, resolved alerts as normal.
+func (w *Writer) createDiffAlertsSheet(f *excelize.File, diff *model.ReportDiff) error {
+	if _, err := f.NewSheet(sheetDiffAlerts); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"变化", "模块", "巡检对象", "指标", "旧级别", "新级别", "旧值", "新值", "告警信息"}
+	widths := []float64{narrowColWidth, 12, wideColWidth, 20, narrowColWidth, narrowColWidth, defaultColWidth, defaultColWidth, 50}
+	w.writeFrozenHeader(f, sheetDiffAlerts, headers, widths, headerStyle)
+
+	levelText := func(level model.AlertLevel) string {
+		if level == "" {
+			return "-"
+		}
+		return alertLevelText(level)
+	}
+
+	for i, a := range diff.Alerts {
+		row := i + 2
+		metric := a.MetricDisplayName
+		if metric == "" {
+			metric = a.MetricName
+		}
+		values := []interface{}{alertChangeText(a.Type), a.Module, a.Identifier, metric, levelText(a.OldLevel), levelText(a.NewLevel), a.OldValue, a.NewValue, a.Message}
+		for col, value := range values {
+			f.SetCellValue(sheetDiffAlerts, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+
+		style := normalStyle
+		if a.Type != model.AlertChangeResolved {
+			style = warningStyle
+			if a.NewLevel == model.AlertLevelCritical {
+				style = criticalStyle
+			}
+		}
+		cell := fmt.Sprintf("A%d", row)
+		f.SetCellStyle(sheetDiffAlerts, cell, cell, style)
+	}
+
+	if len(diff.Alerts) > 0 {
+		f.AutoFilter(sheetDiffAlerts, fmt.Sprintf("A1:%s%d", columnName(len(headers)), len(diff.Alerts)+1), nil)
+	}
+
+	return nil
+}
+
+// createDiffMetricsSheet lists the host metric deltas; the delta stays numeric so it can be
+// sorted and filtered.
+func (w *Writer) createDiffMetricsSheet(f *excelize.File, diff *model.ReportDiff) error {
+	if _, err := f.NewSheet(sheetDiffMetrics); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"主机名", "指标", "旧值", "新值", "变化量"}
+	widths := []float64{wideColWidth, wideColWidth, defaultColWidth, defaultColWidth, defaultColWidth}
+	w.writeFrozenHeader(f, sheetDiffMetrics, headers, widths, headerStyle)
+
+	formatted := func(text string, value float64) interface{} {
+		if text != "" {
+			return text
+		}
+		return value
+	}
+
+	for i, d := range diff.MetricDeltas {
+		row := i + 2
+		values := []interface{}{d.Hostname, d.MetricName, formatted(d.OldFormatted, d.OldValue), formatted(d.NewFormatted, d.NewValue), d.Delta()}
+		for col, value := range values {
+			f.SetCellValue(sheetDiffMetrics, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+	}
+
+	if len(diff.MetricDeltas) > 0 {
+		f.AutoFilter(sheetDiffMetrics, fmt.Sprintf("A1:E%d", len(diff.MetricDeltas)+1), nil)
+	}
+
+	return nil
+}
+
+// formatReportTime returns an inspection run time in the report timezone, or "-" if unknown.
+func (w *Writer) formatReportTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return w.formatInspectionTime(t)
+}
+
+// hostListText joins host names for a summary cell, or "-" when there are none.
+func hostListText(hosts []string) string {
+	if len(hosts) == 0 {
+		return "-"
+	}
+	return strings.Join(hosts, ", ")
+}
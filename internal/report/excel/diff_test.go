@@ -0,0 +1,68 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+func TestWriter_WriteDiff(t *testing.T) {
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	diff := &model.ReportDiff{
+		OldTime: time.Date(2025, 12, 12, 10, 0, 0, 0, tz),
+		NewTime: time.Date(2025, 12, 13, 10, 0, 0, 0, tz),
+		Alerts: []*model.AlertChange{
+			{Type: model.AlertChangeNew, Module: "主机", Identifier: "host-1", MetricName: "cpu_usage", MetricDisplayName: "CPU 利用率", NewLevel: model.AlertLevelCritical, NewValue: "95.0%"},
+			{Type: model.AlertChangeResolved, Module: "Redis", Identifier: "redis-1:6379", MetricName: "memory_usage", OldLevel: model.AlertLevelWarning, OldValue: "80.0%"},
+		},
+		MetricDeltas: []*model.MetricDelta{
+			{Hostname: "host-1", MetricName: "cpu_usage", OldValue: 40, NewValue: 95, OldFormatted: "40.0%", NewFormatted: "95.0%"},
+		},
+		AddedHosts: []string{"host-9"},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "diff.xlsx")
+	if err := NewWriter(nil).WriteDiff(diff, outputPath); err != nil {
+		t.Fatalf("WriteDiff() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open diff report: %v", err)
+	}
+	defer f.Close()
+
+	summary, _ := f.GetRows(sheetDiffSummary)
+	values := make(map[string]string)
+	for _, row := range summary[1:] {
+		values[row[0]] = row[1]
+	}
+	if values["新巡检时间"] != "2025-12-13 10:00:00" || values["新增告警"] != "1" || values["已恢复告警"] != "1" || values["新增主机"] != "host-9" || values["移除主机"] != "-" {
+		t.Errorf("unexpected summary: %v", values)
+	}
+
+	alerts, _ := f.GetRows(sheetDiffAlerts)
+	if len(alerts) != 3 {
+		t.Fatalf("alert changes sheet has %d rows, want 3", len(alerts))
+	}
+	if got := alerts[1]; got[0] != "新增" || got[3] != "CPU 利用率" || got[4] != "-" || got[5] != "严重" {
+		t.Errorf("new alert row = %v", got)
+	}
+	if got := alerts[2]; got[0] != "已恢复" || got[3] != "memory_usage" || got[4] != "警告" || got[5] != "-" {
+		t.Errorf("resolved alert row = %v", got)
+	}
+
+	if delta, _ := f.GetCellValue(sheetDiffMetrics, "E2"); delta != "55" {
+		t.Errorf("metric delta = %q, want 55", delta)
+	}
+}
+
+func TestWriter_WriteDiff_Nil(t *testing.T) {
+	if err := NewWriter(nil).WriteDiff(nil, filepath.Join(t.TempDir(), "diff.xlsx")); err == nil {
+		t.Error("WriteDiff(nil) should return error")
+	}
+}
@@ -0,0 +1,106 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// executiveSystemsShown is the number of affected systems named in a risk row; the others
+// are only counted.
+const executiveSystemsShown = 5
+
+// createExecutiveSummarySheet creates the "管理摘要" sheet at the front of the workbook:
+// the alert totals, then the top risks across all modules with their affected systems and a
+// remediation hint. The sheet is created with its localized name, as it is added after the
+// workbook was localized.
+func (w *Writer) createExecutiveSummarySheet(f *excelize.File, summary *model.ExecutiveSummary) error {
+	sheet := w.tr.SheetName(sheetExecutiveSummary)
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+	if err := f.MoveSheet(sheet, f.GetSheetList()[0]); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Alert totals (rows 1-3)
+	totals := []struct {
+		label string
+		value int
+		style int
+	}{
+		{"严重告警", summary.CriticalAlerts, criticalStyle},
+		{"警告告警", summary.WarningAlerts, warningStyle},
+		{"受影响系统", summary.AffectedSystems, warningStyle},
+	}
+	for i, total := range totals {
+		row := i + 1
+		f.SetCellValue(sheet, fmt.Sprintf("A%d", row), total.label)
+		f.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("A%d", row), headerStyle)
+		f.SetCellValue(sheet, fmt.Sprintf("B%d", row), total.value)
+		if total.value > 0 {
+			f.SetCellStyle(sheet, fmt.Sprintf("B%d", row), fmt.Sprintf("B%d", row), total.style)
+		}
+	}
+
+	// Top risks (from row 5)
+	headers := []string{"序号", "风险级别", "模块", "风险项", "严重告警", "警告告警", "受影响系统", "处理建议"}
+	widths := []float64{12, narrowColWidth, 12, wideColWidth, narrowColWidth, narrowColWidth, 40, 55}
+	for i, header := range headers {
+		col := columnName(i + 1)
+		f.SetColWidth(sheet, col, col, widths[i])
+		f.SetCellValue(sheet, fmt.Sprintf("%s5", col), header)
+		f.SetCellStyle(sheet, fmt.Sprintf("%s5", col), fmt.Sprintf("%s5", col), headerStyle)
+	}
+	f.SetRowHeight(sheet, 5, 25)
+
+	if len(summary.Risks) == 0 {
+		f.SetCellValue(sheet, "A6", "本次巡检未发现风险")
+		return nil
+	}
+
+	for i, risk := range summary.Risks {
+		row := i + 6
+		metric := risk.MetricDisplayName
+		if metric == "" {
+			metric = risk.MetricName
+		}
+		values := []interface{}{i + 1, alertLevelText(risk.Level), risk.Module, metric, risk.CriticalCount, risk.WarningCount, executiveSystemsText(risk.Systems), risk.Remediation}
+		for col, value := range values {
+			f.SetCellValue(sheet, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+
+		style := warningStyle
+		if risk.Level == model.AlertLevelCritical {
+			style = criticalStyle
+		}
+		f.SetCellStyle(sheet, fmt.Sprintf("B%d", row), fmt.Sprintf("B%d", row), style)
+	}
+
+	return nil
+}
+
+// executiveSystemsText names the first executiveSystemsShown affected systems, followed by
+// the total when there are more (e.g. "host-1, host-2, ... 等共 8 个").
+func executiveSystemsText(systems []string) string {
+	if len(systems) <= executiveSystemsShown {
+		return hostListText(systems)
+	}
+	return fmt.Sprintf("%s 等共 %d 个", strings.Join(systems[:executiveSystemsShown], ", "), len(systems))
+}
@@ -0,0 +1,93 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_Finalize_ExecutiveSummarySheet(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	hostResult := createTestInspectionResult()
+
+	w := NewWriter(nil)
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if sheets[0] != sheetExecutiveSummary && (sheets[0] != sheetTOC || sheets[1] != sheetExecutiveSummary) {
+		t.Errorf("sheets = %v, want the executive summary first after the table of contents", sheets)
+	}
+
+	rows, err := f.GetRows(sheetExecutiveSummary)
+	if err != nil {
+		t.Fatalf("executive summary sheet missing: %v", err)
+	}
+	if len(rows) != 7 {
+		t.Fatalf("executive summary sheet has %d rows, want totals, header and two risks", len(rows))
+	}
+	if rows[0][1] != "2" || rows[1][1] != "1" || rows[2][1] != "2" {
+		t.Errorf("totals = %v %v %v, want 2 critical, 1 warning, 2 systems", rows[0], rows[1], rows[2])
+	}
+	if got := rows[5]; got[1] != "严重" || got[2] != "主机" || got[3] != "CPU利用率" || got[6] != "host-2, host-3" || got[7] == "" {
+		t.Errorf("first risk row = %v, want CPU on host-2 and host-3", got)
+	}
+	if got := rows[6]; got[3] != "内存利用率" || got[6] != "host-3" {
+		t.Errorf("second risk row = %v, want memory on host-3", got)
+	}
+}
+
+func TestWriter_Finalize_ExecutiveSummaryNoRisks(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	hostResult := createTestInspectionResult()
+	hostResult.Alerts = nil
+	hostResult.AlertSummary = model.NewAlertSummary(nil)
+
+	w := NewWriter(nil)
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if value, _ := f.GetCellValue(sheetExecutiveSummary, "A6"); value != "本次巡检未发现风险" {
+		t.Errorf("A6 = %q, want the no risk note", value)
+	}
+}
+
+func TestExecutiveSystemsText(t *testing.T) {
+	tests := []struct {
+		systems  []string
+		expected string
+	}{
+		{nil, "-"},
+		{[]string{"host-1", "host-2"}, "host-1, host-2"},
+		{[]string{"h1", "h2", "h3", "h4", "h5", "h6", "h7"}, "h1, h2, h3, h4, h5 等共 7 个"},
+	}
+	for _, tt := range tests {
+		if got := executiveSystemsText(tt.systems); got != tt.expected {
+			t.Errorf("executiveSystemsText(%v) = %q, want %q", tt.systems, got, tt.expected)
+		}
+	}
+}
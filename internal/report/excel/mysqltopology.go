@@ -0,0 +1,154 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// mysqlTopologyRow is a line of the MySQL replication topology tree: a group title, or a
+// member with its tree prefix.
+type mysqlTopologyRow struct {
+	Text   string                        // Group title, or tree prefix and member address
+	Member *model.MySQLReplicationMember // nil for group titles
+}
+
+// mysqlTopologyRows lays the replication groups out as a text tree. In MGR groups the other
+// members hang under the first PRIMARY member; the instances of the other cluster modes are
+// listed flat, as their replication sources are not collected.
+func mysqlTopologyRows(groups []*model.MySQLReplicationGroup) []mysqlTopologyRow {
+	var rows []mysqlTopologyRow
+	branch := func(prefix string, last bool) string {
+		if last {
+			return prefix + "└─ "
+		}
+		return prefix + "├─ "
+	}
+	for _, g := range groups {
+		if !g.Mode.IsMGR() {
+			rows = append(rows, mysqlTopologyRow{Text: mysqlClusterModeText(g.Mode) + "（未采集复制来源）"})
+			for i, m := range g.Members {
+				rows = append(rows, mysqlTopologyRow{Text: branch("", i == len(g.Members)-1) + m.Address, Member: m})
+			}
+			continue
+		}
+
+		rows = append(rows, mysqlTopologyRow{Text: "MGR 组: " + g.Name})
+		var primary *model.MySQLReplicationMember
+		var members []*model.MySQLReplicationMember
+		for _, m := range g.Members {
+			if primary == nil && m.Role.IsPrimary() {
+				primary = m
+				continue
+			}
+			members = append(members, m)
+		}
+		prefix := ""
+		if primary != nil {
+			rows = append(rows, mysqlTopologyRow{Text: branch("", true) + primary.Address, Member: primary})
+			prefix = "   "
+		}
+		for i, m := range members {
+			rows = append(rows, mysqlTopologyRow{Text: branch(prefix, i == len(members)-1) + m.Address, Member: m})
+		}
+	}
+	return rows
+}
+
+// createMySQLTopologySheet creates the MySQL replication topology worksheet: the MGR groups
+// and the other instances as a text tree, with the role, Server ID, member state, sync status
+// and overall status of each instance. Without MGR groups no sheet is created, as the
+// replication sources of the other cluster modes are not collected.
+func (w *Writer) createMySQLTopologySheet(f *excelize.File, result *model.MySQLInspectionResults) error {
+	if len(result.MGRClusters()) == 0 {
+		return nil
+	}
+	rows := mysqlTopologyRows(result.ReplicationGroups())
+
+	// Create sheet
+	_, err := f.NewSheet(sheetMySQLTopology)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	groupStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: w.headerBgColor()},
+	})
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"拓扑", "角色", "Server ID", "成员状态", "同步状态", "整体状态"}
+	widths := []float64{48, 10, 14, 14, 12, 12}
+	for i, header := range headers {
+		col := columnName(i + 1)
+		f.SetColWidth(sheetMySQLTopology, col, col, widths[i])
+		cell := col + "1"
+		f.SetCellValue(sheetMySQLTopology, cell, header)
+		f.SetCellStyle(sheetMySQLTopology, cell, cell, headerStyle)
+	}
+	f.SetPanes(sheetMySQLTopology, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	for i, r := range rows {
+		rowStr := fmt.Sprint(i + 2)
+		f.SetCellValue(sheetMySQLTopology, "A"+rowStr, r.Text)
+		m := r.Member
+		if m == nil {
+			f.SetCellStyle(sheetMySQLTopology, "A"+rowStr, "A"+rowStr, groupStyle)
+			continue
+		}
+
+		role, state := "-", "-"
+		if m.Role != model.MGRRoleUnknown {
+			role = mysqlMGRRoleText(m.Role)
+		}
+		if m.State != "" {
+			state = m.State
+		}
+		serverID, syncStatus, status := "N/A", "N/A", "未纳入巡检"
+		if m.Result != nil {
+			if m.Result.Instance.ServerID != "" {
+				serverID = m.Result.Instance.ServerID
+			}
+			syncStatus = w.getMySQLSyncStatus(m.Result)
+			status = mysqlStatusText(m.Result.Status)
+		}
+		f.SetCellValue(sheetMySQLTopology, "B"+rowStr, role)
+		f.SetCellValue(sheetMySQLTopology, "C"+rowStr, serverID)
+		f.SetCellValue(sheetMySQLTopology, "D"+rowStr, state)
+		f.SetCellValue(sheetMySQLTopology, "E"+rowStr, syncStatus)
+		f.SetCellValue(sheetMySQLTopology, "F"+rowStr, status)
+
+		// Member state: RECOVERING is transient, any other non-ONLINE state is critical
+		switch {
+		case m.State == model.MGRMemberStateRecovering:
+			f.SetCellStyle(sheetMySQLTopology, "D"+rowStr, "D"+rowStr, warningStyle)
+		case m.State != "" && m.State != model.MGRMemberStateOnline:
+			f.SetCellStyle(sheetMySQLTopology, "D"+rowStr, "D"+rowStr, criticalStyle)
+		}
+		if m.Result != nil {
+			switch m.Result.Status {
+			case model.MySQLStatusCritical, model.MySQLStatusFailed:
+				f.SetCellStyle(sheetMySQLTopology, "F"+rowStr, "F"+rowStr, criticalStyle)
+			case model.MySQLStatusWarning:
+				f.SetCellStyle(sheetMySQLTopology, "F"+rowStr, "F"+rowStr, warningStyle)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+func TestMySQLTopologyRows(t *testing.T) {
+	member := func(address string, role model.MySQLMGRRole) *model.MySQLReplicationMember {
+		return &model.MySQLReplicationMember{Address: address, Role: role}
+	}
+	groups := []*model.MySQLReplicationGroup{
+		{Name: "group-a", Mode: model.ClusterModeMGR, Members: []*model.MySQLReplicationMember{
+			member("10.0.0.1:3306", model.MGRRolePrimary),
+			member("10.0.0.2:3306", model.MGRRoleSecondary),
+			member("10.0.0.3:3306", model.MGRRoleSecondary),
+		}},
+		{Name: "group-b", Mode: model.ClusterModeMGR, Members: []*model.MySQLReplicationMember{
+			member("10.0.1.1:3306", model.MGRRoleSecondary),
+		}},
+		{Mode: model.ClusterModeMasterSlave, Members: []*model.MySQLReplicationMember{
+			member("10.0.2.1:3306", model.MGRRoleUnknown),
+			member("10.0.2.2:3306", model.MGRRoleUnknown),
+		}},
+	}
+
+	want := []string{
+		"MGR 组: group-a",
+		"└─ 10.0.0.1:3306",
+		"   ├─ 10.0.0.2:3306",
+		"   └─ 10.0.0.3:3306",
+		"MGR 组: group-b",
+		"└─ 10.0.1.1:3306",
+		"主从（未采集复制来源）",
+		"├─ 10.0.2.1:3306",
+		"└─ 10.0.2.2:3306",
+	}
+	rows := mysqlTopologyRows(groups)
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, text := range want {
+		if rows[i].Text != text {
+			t.Errorf("row %d = %q, want %q", i, rows[i].Text, text)
+		}
+		if isTitle := rows[i].Member == nil; isTitle != (i == 0 || i == 4 || i == 6) {
+			t.Errorf("row %d member = %v, want title rows without member", i, rows[i].Member)
+		}
+	}
+}
+
+func TestWriter_WriteMySQLInspection_Topology(t *testing.T) {
+	result := createTestMySQLInspectionResults()
+	var members []*model.MySQLMGRMember
+	for i, host := range []string{"172.18.182.91", "172.18.182.92", "172.18.182.93"} {
+		m := model.NewMySQLMGRMember(host)
+		m.Host = host
+		m.Port = 3306
+		m.Role = model.MGRRoleSecondary
+		m.State = model.MGRMemberStateOnline
+		if i == 0 {
+			m.Role = model.MGRRolePrimary
+		}
+		members = append(members, m)
+	}
+	members[2].State = "UNREACHABLE"
+	for _, r := range result.Results {
+		r.MGRGroupName = "group-a"
+		r.MGRMembers = members
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "mysql.xlsx")
+	if err := NewWriter(nil).WriteMySQLInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteMySQLInspection() error = %v", err)
+	}
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetMySQLTopology)
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	want := [][]string{
+		{"拓扑", "角色", "Server ID", "成员状态", "同步状态", "整体状态"},
+		{"MGR 组: group-a"},
+		{"└─ 172.18.182.91:3306", "主节点", "91", "ONLINE"},
+		{"   ├─ 172.18.182.92:3306", "从节点", "92", "ONLINE"},
+		{"   └─ 172.18.182.93:3306", "从节点", "93", "UNREACHABLE"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+	}
+	for i, cells := range want {
+		for j, value := range cells {
+			if j >= len(rows[i]) || rows[i][j] != value {
+				t.Errorf("row %d = %v, want prefix %v", i+1, rows[i], cells)
+				break
+			}
+		}
+	}
+	if status, _ := f.GetCellValue(sheetMySQLTopology, "F5"); status != "严重" {
+		t.Errorf("F5 = %q, want the critical instance status", status)
+	}
+}
+
+func TestWriter_WriteMySQLInspection_NoTopologyWithoutMGR(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "mysql.xlsx")
+	if err := NewWriter(nil).WriteMySQLInspection(createTestMySQLInspectionResults(), outputPath); err != nil {
+		t.Fatalf("WriteMySQLInspection() error = %v", err)
+	}
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	if index, _ := f.GetSheetIndex(sheetMySQLTopology); index >= 0 {
+		t.Error("topology sheet should not be created without MGR groups")
+	}
+}
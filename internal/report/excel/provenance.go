@@ -0,0 +1,138 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Provenance records how a report was produced, so any number in it can be traced back to the
+// query and datasource it came from.
+type Provenance struct {
+	Version    string            // 工具版本
+	GitCommit  string            // 构建的 Git 提交
+	BuildTime  string            // 构建时间
+	ConfigFile string            // 配置文件路径
+	ConfigHash string            // 配置文件 SHA-256
+	StartTime  time.Time         // 巡检开始时间
+	EndTime    time.Time         // 巡检结束时间（报告生成时间）
+	Entries    []ProvenanceEntry // 数据源地址、主机过滤、查询窗口等，按填写顺序输出
+	Queries    []ProvenanceQuery // 报告数值背后的查询语句
+}
+
+// ProvenanceEntry is a name / value row of the provenance sheet.
+type ProvenanceEntry struct {
+	Name  string
+	Value string
+}
+
+// ProvenanceQuery is the query expression of a module metric.
+type ProvenanceQuery struct {
+	Module string // 模块（与"原始数据"sheet 一致）
+	Metric string // 指标名称
+	Query  string // PromQL / LogQL 查询语句
+}
+
+// AppendProvenanceSheet adds the report metadata sheet ("报告元数据") to an existing Excel file:
+// the tool version and build, the configuration file and its hash, the inspection time range,
+// the datasources, and the query expression of every metric.
+func (w *Writer) AppendProvenanceSheet(p *Provenance, existingPath string) error {
+	// Ensure path has .xlsx extension
+	if !strings.HasSuffix(strings.ToLower(existingPath), ".xlsx") {
+		existingPath = existingPath + ".xlsx"
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createProvenanceSheet(f, p); err != nil {
+		return fmt.Errorf("failed to create provenance sheet: %w", err)
+	}
+	// Localize before linking the sheet from the table of contents
+	if err := w.localize(f); err != nil {
+		return fmt.Errorf("failed to localize provenance sheet: %w", err)
+	}
+	if err := w.appendTOCEntry(f, w.tr.SheetName(sheetProvenance), "报告元数据"); err != nil {
+		return fmt.Errorf("failed to update table of contents: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// createProvenanceSheet writes the provenance sheet: the name / value rows, then the query
+// table (module, metric, query) below a blank row.
+func (w *Writer) createProvenanceSheet(f *excelize.File, p *Provenance) error {
+	if _, err := f.NewSheet(sheetProvenance); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	wrapStyle, err := f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{Vertical: "top", WrapText: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	f.SetColWidth(sheetProvenance, "A", "A", defaultColWidth+5)
+	f.SetColWidth(sheetProvenance, "B", "B", wideColWidth+5)
+	f.SetColWidth(sheetProvenance, "C", "C", 100)
+
+	timeRange := fmt.Sprintf("%s ~ %s（即时查询，取查询执行时刻的最新值）", w.formatReportTime(p.StartTime), w.formatReportTime(p.EndTime))
+	entries := []ProvenanceEntry{
+		{"工具版本", p.Version},
+		{"Git 提交", p.GitCommit},
+		{"构建时间", p.BuildTime},
+		{"配置文件", p.ConfigFile},
+		{"配置文件 SHA-256", p.ConfigHash},
+		{"查询时间范围", timeRange},
+	}
+	entries = append(entries, p.Entries...)
+
+	writeHeader := func(row int, headers ...string) {
+		for i, header := range headers {
+			cell := fmt.Sprintf("%s%d", columnName(i+1), row)
+			f.SetCellValue(sheetProvenance, cell, header)
+			f.SetCellStyle(sheetProvenance, cell, cell, headerStyle)
+		}
+	}
+
+	// Values span columns B and C
+	writeHeader(1, "项目", "值")
+	f.MergeCell(sheetProvenance, "B1", "C1")
+	row := 2
+	for _, e := range entries {
+		value := e.Value
+		if value == "" {
+			value = "-"
+		}
+		f.SetCellValue(sheetProvenance, fmt.Sprintf("A%d", row), e.Name)
+		f.SetCellValue(sheetProvenance, fmt.Sprintf("B%d", row), value)
+		f.MergeCell(sheetProvenance, fmt.Sprintf("B%d", row), fmt.Sprintf("C%d", row))
+		row++
+	}
+
+	if len(p.Queries) == 0 {
+		return nil
+	}
+	row++
+	writeHeader(row, "模块", "指标", "查询语句")
+	row++
+	for _, q := range p.Queries {
+		f.SetCellValue(sheetProvenance, fmt.Sprintf("A%d", row), q.Module)
+		f.SetCellValue(sheetProvenance, fmt.Sprintf("B%d", row), q.Metric)
+		f.SetCellValue(sheetProvenance, fmt.Sprintf("C%d", row), q.Query)
+		f.SetCellStyle(sheetProvenance, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), wrapStyle)
+		row++
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+)
+
+func TestWriter_AppendProvenanceSheet(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	w := NewWriter(nil)
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	p := &Provenance{
+		Version:    "v1.2.0",
+		GitCommit:  "3849bde",
+		ConfigFile: "config.yaml",
+		ConfigHash: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		StartTime:  time.Date(2025, 12, 13, 10, 0, 0, 0, tz),
+		EndTime:    time.Date(2025, 12, 13, 10, 0, 42, 0, tz),
+		Entries:    []ProvenanceEntry{{Name: "VictoriaMetrics 地址", Value: "http://vm.example.com:8428"}},
+		Queries: []ProvenanceQuery{
+			{Module: model.RawDataModuleHost, Metric: "cpu_usage", Query: "100 - avg(rate(cpu_usage_idle[5m]))"},
+			{Module: model.RawDataModuleMySQL, Metric: "mysql_up", Query: "mysql_up"},
+		},
+	}
+	if err := w.AppendProvenanceSheet(p, outputPath); err != nil {
+		t.Fatalf("AppendProvenanceSheet() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetProvenance)
+	if err != nil {
+		t.Fatalf("provenance sheet missing: %v", err)
+	}
+	values := make(map[string]string)
+	for _, row := range rows {
+		if len(row) >= 2 {
+			values[row[0]] = row[1]
+		}
+	}
+	tests := map[string]string{
+		"工具版本":               "v1.2.0",
+		"Git 提交":             "3849bde",
+		"构建时间":               "-",
+		"配置文件 SHA-256":       p.ConfigHash,
+		"查询时间范围":             "2025-12-13 10:00:00 ~ 2025-12-13 10:00:42（即时查询，取查询执行时刻的最新值）",
+		"VictoriaMetrics 地址": "http://vm.example.com:8428",
+	}
+	for name, want := range tests {
+		if got := values[name]; got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	// Query table below the name / value rows
+	last := rows[len(rows)-1]
+	if len(last) != 3 || last[0] != model.RawDataModuleMySQL || last[2] != "mysql_up" {
+		t.Errorf("last query row = %v", last)
+	}
+	if header := rows[len(rows)-3]; len(header) != 3 || header[2] != "查询语句" {
+		t.Errorf("query header = %v", header)
+	}
+}
+
+func TestWriter_AppendProvenanceSheet_English(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	w := NewWriter(nil, WithLanguage(i18n.LanguageEN))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendProvenanceSheet(&Provenance{Version: "dev"}, outputPath); err != nil {
+		t.Fatalf("AppendProvenanceSheet() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if got, _ := f.GetCellValue("Report Metadata", "A2"); got != "Tool Version" {
+		t.Errorf("A2 = %q, want Tool Version", got)
+	}
+}
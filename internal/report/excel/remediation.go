@@ -0,0 +1,34 @@
+package excel
+
+import (
+	"strings"
+
+	"inspection-tool/internal/report/json"
+)
+
+// remediationColWidth is the width of the "处理建议" column of the alerts sheets.
+const remediationColWidth = 40.0
+
+// WithRemediation sets the remediation texts of the "处理建议" column of the alerts sheets,
+// keyed by metric name (e.g. "cpu_usage", "mysql_connection_usage"). Expanded metrics such as
+// "disk_usage:/home" use the text of their base metric. Metrics without a configured text get
+// the built-in hint of their metric type.
+func WithRemediation(hints map[string]string) Option {
+	return func(w *Writer) {
+		w.remediationHints = hints
+	}
+}
+
+// remediation returns the remediation text of an alert metric.
+func (w *Writer) remediation(metric string) string {
+	if hint, ok := w.remediationHints[metric]; ok && hint != "" {
+		return hint
+	}
+	if idx := strings.Index(metric, ":"); idx >= 0 {
+		metric = metric[:idx]
+	}
+	if hint, ok := w.remediationHints[metric]; ok && hint != "" {
+		return hint
+	}
+	return json.RemediationHint(metric)
+}
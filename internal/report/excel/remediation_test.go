@@ -0,0 +1,63 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_Remediation(t *testing.T) {
+	w := NewWriter(nil, WithRemediation(map[string]string{
+		"cpu_usage":       "联系业务方排查高负载进程",
+		"disk_usage":      "清理 /data 下的归档日志",
+		"memory_usage":    "",
+		"disk_usage:/tmp": "重启后自动清理",
+	}))
+
+	tests := map[string]string{
+		"cpu_usage":        "联系业务方排查高负载进程",
+		"disk_usage:/home": "清理 /data 下的归档日志",
+		"disk_usage:/tmp":  "重启后自动清理",
+		"memory_usage":     json.RemediationHint("memory_usage"),
+		"load_per_core":    json.RemediationHint("load_per_core"),
+	}
+	for metric, want := range tests {
+		if got := w.remediation(metric); got != want {
+			t.Errorf("remediation(%q) = %q, want %q", metric, got, want)
+		}
+	}
+}
+
+func TestWriter_Write_AlertsRemediationColumn(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	w := NewWriter(nil, WithRemediation(map[string]string{"cpu_usage": "联系业务方排查高负载进程"}))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if header, _ := f.GetCellValue(sheetAlerts, "H1"); header != "处理建议" {
+		t.Errorf("H1 = %q, want 处理建议", header)
+	}
+	rows, err := f.GetRows(sheetAlerts)
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	for _, row := range rows[1:] {
+		if len(row) < 8 || row[7] == "" {
+			t.Errorf("alert row without remediation: %v", row)
+			continue
+		}
+		if row[2] == "CPU利用率" && row[7] != "联系业务方排查高负载进程" {
+			t.Errorf("CPU remediation = %q, want the configured text", row[7])
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// Sheet names of the cross-site roll-up workbook.
+const (
+	sheetRollupMatrix  = "站点健康矩阵" // Site × module status matrix
+	sheetRollupModules = "模块告警汇总" // Object and alert totals per module over all sites
+	sheetRollupDetail  = "站点模块明细" // One row per site and module
+)
+
+// rollupStyles holds the cell styles of the roll-up sheets.
+type rollupStyles struct {
+	header, normal, warning, critical int
+}
+
+// WriteRollup generates the cross-site executive report: a site health matrix, the alert
+// totals by module and the per-site module details.
+func (w *Writer) WriteRollup(result *model.RollupResult, outputPath string) error {
+	if result == nil || len(result.Sites) == 0 {
+		return fmt.Errorf("rollup result has no sites")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	var styles rollupStyles
+	var err error
+	if styles.header, err = w.createHeaderStyle(f); err != nil {
+		return err
+	}
+	if styles.normal, err = w.createNormalStyle(f); err != nil {
+		return err
+	}
+	if styles.warning, err = w.createWarningStyle(f); err != nil {
+		return err
+	}
+	if styles.critical, err = w.createCriticalStyle(f); err != nil {
+		return err
+	}
+
+	if err := w.createRollupMatrixSheet(f, result, styles); err != nil {
+		return fmt.Errorf("failed to create site health matrix sheet: %w", err)
+	}
+	if err := w.createRollupModulesSheet(f, result, styles); err != nil {
+		return fmt.Errorf("failed to create module alerts sheet: %w", err)
+	}
+	if err := w.createRollupDetailSheet(f, result, styles); err != nil {
+		return fmt.Errorf("failed to create site module detail sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	idx, _ := f.GetSheetIndex(sheetRollupMatrix)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// createRollupMatrixSheet creates the site health matrix: one row per site with its overall
+// status and alert counts, and one status column per module ("-" when not inspected).
+func (w *Writer) createRollupMatrixSheet(f *excelize.File, result *model.RollupResult, styles rollupStyles) error {
+	if _, err := f.NewSheet(sheetRollupMatrix); err != nil {
+		return err
+	}
+
+	modules := result.Modules()
+	headers := append([]string{"站点", "巡检时间", "整体状态", "严重告警", "警告告警"}, modules...)
+	widths := []float64{20, 20, 12, 12, 12}
+	w.writeFrozenHeader(f, sheetRollupMatrix, headers, widths, styles.header)
+
+	for i, site := range result.Sites {
+		row := i + 2
+		warning, critical := site.AlertCounts()
+		values := []interface{}{site.Name, w.formatReportTime(site.InspectionTime), statusText(site.Status()), critical, warning}
+		for col, value := range values {
+			f.SetCellValue(sheetRollupMatrix, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+		}
+		statusCell := fmt.Sprintf("C%d", row)
+		if style := w.getStatusStyle(site.Status(), styles.normal, styles.warning, styles.critical); style != 0 {
+			f.SetCellStyle(sheetRollupMatrix, statusCell, statusCell, style)
+		}
+
+		for j, name := range modules {
+			cell := fmt.Sprintf("%s%d", columnName(len(values)+j+1), row)
+			m := site.Module(name)
+			if m == nil {
+				f.SetCellValue(sheetRollupMatrix, cell, "-")
+				continue
+			}
+			f.SetCellValue(sheetRollupMatrix, cell, statusText(m.Status()))
+			if style := w.getStatusStyle(m.Status(), styles.normal, styles.warning, styles.critical); style != 0 {
+				f.SetCellStyle(sheetRollupMatrix, cell, cell, style)
+			}
+		}
+	}
+
+	return nil
+}
+
+// createRollupModulesSheet creates the module totals: object counts by status and alert
+// counts by level of every module summed over all sites, followed by a total row.
+func (w *Writer) createRollupModulesSheet(f *excelize.File, result *model.RollupResult, styles rollupStyles) error {
+	if _, err := f.NewSheet(sheetRollupModules); err != nil {
+		return err
+	}
+
+	headers := []string{"模块", "站点数", "对象数", "正常", "警告", "严重", "失败", "严重告警", "警告告警", "告警总数"}
+	widths := []float64{15, 10, 10, 10, 10, 10, 10, 12, 12, 12}
+	w.writeFrozenHeader(f, sheetRollupModules, headers, widths, styles.header)
+
+	sum := &model.RollupModule{Module: "合计"}
+	row := 2
+	for _, name := range result.Modules() {
+		total, sites := result.ModuleTotal(name)
+		w.writeRollupCounts(f, sheetRollupModules, row, []interface{}{name, sites}, total, styles)
+		sum.Total += total.Total
+		sum.Normal += total.Normal
+		sum.Warning += total.Warning
+		sum.Critical += total.Critical
+		sum.Failed += total.Failed
+		sum.WarningAlerts += total.WarningAlerts
+		sum.CriticalAlerts += total.CriticalAlerts
+		row++
+	}
+	w.writeRollupCounts(f, sheetRollupModules, row, []interface{}{sum.Module, len(result.Sites)}, sum, styles)
+
+	return nil
+}
+
+// createRollupDetailSheet creates one row per site and module with its counts.
+func (w *Writer) createRollupDetailSheet(f *excelize.File, result *model.RollupResult, styles rollupStyles) error {
+	if _, err := f.NewSheet(sheetRollupDetail); err != nil {
+		return err
+	}
+
+	headers := []string{"站点", "模块", "对象数", "正常", "警告", "严重", "失败", "严重告警", "警告告警", "告警总数"}
+	widths := []float64{20, 15, 10, 10, 10, 10, 10, 12, 12, 12}
+	w.writeFrozenHeader(f, sheetRollupDetail, headers, widths, styles.header)
+
+	row := 2
+	for _, site := range result.Sites {
+		for _, m := range site.Modules {
+			w.writeRollupCounts(f, sheetRollupDetail, row, []interface{}{site.Name, m.Module}, m, styles)
+			row++
+		}
+	}
+
+	if row > 2 {
+		f.AutoFilter(sheetRollupDetail, fmt.Sprintf("A1:%s%d", columnName(len(headers)), row-1), nil)
+	}
+
+	return nil
+}
+
+// writeFrozenHeader writes a frozen header row and sets the column widths; columns beyond
+// widths get the default width.
+func (w *Writer) writeFrozenHeader(f *excelize.File, sheet string, headers []string, widths []float64, headerStyle int) {
+	for i, header := range headers {
+		col := columnName(i + 1)
+		width := defaultColWidth
+		if i < len(widths) {
+			width = widths[i]
+		}
+		f.SetColWidth(sheet, col, col, width)
+		f.SetCellValue(sheet, col+"1", header)
+		f.SetCellStyle(sheet, col+"1", col+"1", headerStyle)
+	}
+	f.SetRowHeight(sheet, 1, 25)
+
+	f.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+}
+
+// writeRollupCounts writes the leading cells followed by the counts of m, highlighting
+// non-zero warning and critical counts.
+func (w *Writer) writeRollupCounts(f *excelize.File, sheet string, row int, leading []interface{}, m *model.RollupModule, styles rollupStyles) {
+	values := append(leading, m.Total, m.Normal, m.Warning, m.Critical, m.Failed, m.CriticalAlerts, m.WarningAlerts, m.WarningAlerts+m.CriticalAlerts)
+	for i, value := range values {
+		f.SetCellValue(sheet, fmt.Sprintf("%s%d", columnName(i+1), row), value)
+	}
+
+	highlight := func(offset, count, style int) {
+		if count == 0 {
+			return
+		}
+		cell := fmt.Sprintf("%s%d", columnName(len(leading)+offset), row)
+		f.SetCellStyle(sheet, cell, cell, style)
+	}
+	highlight(3, m.Warning, styles.warning)
+	highlight(4, m.Critical, styles.critical)
+	highlight(6, m.CriticalAlerts, styles.critical)
+	highlight(7, m.WarningAlerts, styles.warning)
+}
@@ -0,0 +1,93 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// createTestRollupResult creates a roll-up of two sites; only the first inspected MySQL.
+func createTestRollupResult() *model.RollupResult {
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return &model.RollupResult{
+		GeneratedAt: time.Date(2025, 12, 14, 9, 0, 0, 0, tz),
+		Sites: []*model.RollupSite{
+			{
+				Name:           "北京",
+				InspectionTime: time.Date(2025, 12, 13, 10, 0, 0, 0, tz),
+				Modules: []*model.RollupModule{
+					{Module: "主机", Total: 10, Normal: 9, Warning: 1, WarningAlerts: 2},
+					{Module: "MySQL", Total: 2, Normal: 2},
+				},
+			},
+			{
+				Name:           "上海",
+				InspectionTime: time.Date(2025, 12, 13, 11, 0, 0, 0, tz),
+				Modules: []*model.RollupModule{
+					{Module: "主机", Total: 5, Normal: 4, Critical: 1, CriticalAlerts: 3},
+				},
+			},
+		},
+	}
+}
+
+func TestWriter_WriteRollup(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "rollup") // Extension is added by the writer
+	if err := NewWriter(nil).WriteRollup(createTestRollupResult(), outputPath); err != nil {
+		t.Fatalf("WriteRollup() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath + ".xlsx")
+	if err != nil {
+		t.Fatalf("failed to open rollup report: %v", err)
+	}
+	defer f.Close()
+
+	if sheets := f.GetSheetList(); len(sheets) != 3 || sheets[0] != sheetRollupMatrix {
+		t.Errorf("sheets = %v, want matrix, modules and detail sheets", sheets)
+	}
+
+	// Matrix: site, time, overall status, critical / warning alerts, then one column per module
+	matrix, _ := f.GetRows(sheetRollupMatrix)
+	want := [][]string{
+		{"站点", "巡检时间", "整体状态", "严重告警", "警告告警", "主机", "MySQL"},
+		{"北京", "2025-12-13 10:00:00", "警告", "0", "2", "警告", "正常"},
+		{"上海", "2025-12-13 11:00:00", "严重", "3", "0", "严重", "-"},
+	}
+	for r, row := range want {
+		for c, value := range row {
+			if r >= len(matrix) || c >= len(matrix[r]) || matrix[r][c] != value {
+				t.Errorf("matrix row %d = %v, want %v", r+1, matrix[r], row)
+				break
+			}
+		}
+	}
+
+	// Module totals with a total row
+	modules, _ := f.GetRows(sheetRollupModules)
+	if len(modules) != 4 {
+		t.Fatalf("module sheet has %d rows, want header, 2 modules and total", len(modules))
+	}
+	if got := modules[1]; got[0] != "主机" || got[1] != "2" || got[2] != "15" || got[7] != "3" || got[8] != "2" || got[9] != "5" {
+		t.Errorf("host totals = %v", got)
+	}
+	if got := modules[3]; got[0] != "合计" || got[1] != "2" || got[2] != "17" {
+		t.Errorf("total row = %v", got)
+	}
+
+	detail, _ := f.GetRows(sheetRollupDetail)
+	if len(detail) != 4 || detail[3][0] != "上海" || detail[3][1] != "主机" {
+		t.Errorf("detail rows = %v, want one row per site and module", detail)
+	}
+}
+
+func TestWriter_WriteRollup_NoSites(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "rollup.xlsx")
+	if err := NewWriter(nil).WriteRollup(&model.RollupResult{}, outputPath); err == nil {
+		t.Error("WriteRollup() should fail without sites")
+	}
+}
@@ -0,0 +1,109 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// sheetStream is a sheet whose rows are written with the excelize StreamWriter, which keeps
+// large sheets (tens of thousands of hosts) fast and memory-bounded.
+//
+// Cells written through a StreamWriter cannot be read or changed afterwards, so the rows are
+// only streamed when the workbook is saved: after localize and applyTheme have run on the still
+// empty sheet. The sheet keeps its header / footer, properties and name changes, and its text
+// is translated while it is streamed.
+type sheetStream struct {
+	f     *excelize.File
+	id    int // Sheet ID, stable across sheet renames (localization, badges)
+	write func(sw *excelize.StreamWriter) error
+}
+
+// streamSheet queues the rows of an existing, empty sheet to be streamed when f is saved.
+func (w *Writer) streamSheet(f *excelize.File, sheet string, write func(sw *excelize.StreamWriter) error) error {
+	for id, name := range f.GetSheetMap() {
+		if name == sheet {
+			w.streams = append(w.streams, &sheetStream{f: f, id: id, write: write})
+			return nil
+		}
+	}
+	return fmt.Errorf("sheet %s does not exist", sheet)
+}
+
+// flushStreams streams the queued sheets of f.
+func (w *Writer) flushStreams(f *excelize.File) error {
+	var queued []*sheetStream
+	pending := w.streams[:0]
+	for _, s := range w.streams {
+		if s.f == f {
+			queued = append(queued, s)
+		} else {
+			pending = append(pending, s)
+		}
+	}
+	w.streams = pending
+
+	sheets := f.GetSheetMap()
+	for _, s := range queued {
+		sheet, ok := sheets[s.id]
+		if !ok {
+			continue // Sheet was deleted
+		}
+		sw, err := f.NewStreamWriter(sheet)
+		if err != nil {
+			return fmt.Errorf("failed to stream sheet %s: %w", sheet, err)
+		}
+		if err := s.write(sw); err != nil {
+			return fmt.Errorf("failed to stream sheet %s: %w", sheet, err)
+		}
+		if err := sw.Flush(); err != nil {
+			return fmt.Errorf("failed to stream sheet %s: %w", sheet, err)
+		}
+	}
+	return nil
+}
+
+// streamHeader sets the column widths, writes the header row and freezes it.
+// It must be called before any other row is streamed.
+func (w *Writer) streamHeader(sw *excelize.StreamWriter, header []excelize.Cell, widths []float64) error {
+	for i, width := range widths {
+		if err := sw.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+
+	// Freeze header row
+	if err := sw.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		Split:       false,
+		XSplit:      0,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+
+	return w.streamRow(sw, 1, header, excelize.RowOpts{Height: 25})
+}
+
+// streamRow writes the cells of a row starting at column A, translating text into the
+// report language. Cells without value and style are left empty.
+func (w *Writer) streamRow(sw *excelize.StreamWriter, row int, cells []excelize.Cell, opts ...excelize.RowOpts) error {
+	values := make([]interface{}, len(cells))
+	for i, c := range cells {
+		if c.Value == nil && c.StyleID == 0 {
+			continue
+		}
+		if text, ok := c.Value.(string); ok && text != "" {
+			c.Value = w.tr.Text(text)
+		}
+		values[i] = c
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, row)
+	if err != nil {
+		return err
+	}
+	return sw.SetRow(cell, values, opts...)
+}
@@ -0,0 +1,137 @@
+package excel
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+)
+
+func TestWriter_Write_LargeFleet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large fleet report in short mode")
+	}
+
+	const hostCount = 20000
+	result := &model.InspectionResult{}
+	for i := 0; i < hostCount; i++ {
+		host := &model.HostResult{
+			Hostname: fmt.Sprintf("host-%05d", i),
+			IP:       fmt.Sprintf("10.%d.%d.%d", i/65536, i/256%256, i%256),
+			Status:   model.HostStatusNormal,
+			Metrics: map[string]*model.MetricValue{
+				"cpu_usage":        {Name: "cpu_usage", FormattedValue: "20.0%", Status: model.MetricStatusNormal},
+				"disk_usage:/":     {Name: "disk_usage", FormattedValue: "50.0%", Status: model.MetricStatusNormal},
+				"disk_usage:/data": {Name: "disk_usage", IsNA: true},
+			},
+		}
+		if i%10 == 0 {
+			host.Status = model.HostStatusWarning
+			host.Metrics["cpu_usage"] = &model.MetricValue{Name: "cpu_usage", FormattedValue: "85.0%", Status: model.MetricStatusWarning}
+			result.Alerts = append(result.Alerts, &model.Alert{
+				Hostname:          host.Hostname,
+				MetricName:        "cpu_usage",
+				MetricDisplayName: "CPU利用率",
+				FormattedValue:    "85.0%",
+				WarningThreshold:  70,
+				CriticalThreshold: 90,
+				Level:             model.AlertLevelWarning,
+				Message:           "CPU利用率超过警告阈值",
+			})
+		}
+		result.Hosts = append(result.Hosts, host)
+	}
+	result.Summary = model.NewInspectionSummary(result.Hosts)
+	result.AlertSummary = model.NewAlertSummary(result.Alerts)
+
+	outputPath := filepath.Join(t.TempDir(), "large.xlsx")
+	w := NewWriter(nil)
+	if err := w.Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(w.streams) != 0 {
+		t.Errorf("streams left after save = %d, want 0", len(w.streams))
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetDetail)
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(rows) != hostCount+1 {
+		t.Fatalf("detail rows = %d, want %d", len(rows), hostCount+1)
+	}
+	if got := rows[hostCount][0]; got != "host-19999" {
+		t.Errorf("last host = %q, want host-19999", got)
+	}
+
+	// CPU usage of host-00000 is highlighted as a warning
+	warningStyle, _ := f.GetCellStyle(sheetDetail, "H2")
+	normalStyle, _ := f.GetCellStyle(sheetDetail, "H3")
+	if warningStyle == 0 || warningStyle == normalStyle {
+		t.Errorf("warning cell style = %d, normal cell style = %d, want distinct highlight", warningStyle, normalStyle)
+	}
+	if got, _ := f.GetCellValue(sheetDetail, "P2"); got != "50.0%" {
+		t.Errorf("disk / cell = %q, want 50.0%%", got)
+	}
+	if got, _ := f.GetCellValue(sheetDetail, "Q2"); got != "N/A" {
+		t.Errorf("disk /data cell = %q, want N/A", got)
+	}
+
+	panes, err := f.GetPanes(sheetDetail)
+	if err != nil {
+		t.Fatalf("GetPanes() error = %v", err)
+	}
+	if !panes.Freeze || panes.YSplit != 1 {
+		t.Errorf("detail panes = %+v, want frozen header row", panes)
+	}
+
+	alertRows, err := f.GetRows(sheetAlerts)
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(alertRows) != hostCount/10+1 {
+		t.Errorf("alert rows = %d, want %d", len(alertRows), hostCount/10+1)
+	}
+}
+
+func TestWriter_StreamedSheets_Localized(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	w := NewWriter(nil, WithLanguage(i18n.LanguageEN), WithAlertGrouping(true))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	detail := w.tr.SheetName(sheetDetail)
+	alerts := w.tr.SheetName(sheetAlerts)
+	for _, check := range []struct{ sheet, cell, want string }{
+		{detail, "A1", w.tr.Text("主机名")},
+		{detail, "C2", w.tr.Text("正常")},
+		{alerts, "B1", w.tr.Text("告警级别")},
+		{alerts, "C2", w.tr.Text("小计")},
+	} {
+		if got, _ := f.GetCellValue(check.sheet, check.cell); got != check.want {
+			t.Errorf("%s!%s = %q, want %q", check.sheet, check.cell, got, check.want)
+		}
+	}
+
+	// Alert rows of a group are collapsible
+	if level, _ := f.GetRowOutlineLevel(alerts, 3); level != 1 {
+		t.Errorf("alert row outline level = %d, want 1", level)
+	}
+}
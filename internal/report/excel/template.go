@@ -0,0 +1,288 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// Template tables: a row with "{{<table>.<field>}}" placeholders is repeated once per item.
+const (
+	TemplateTableHosts   = "hosts"   // 主机：hostname、ip、status、os、os_version、kernel_version、cpu_cores、business_group 及任意指标名称
+	TemplateTableAlerts  = "alerts"  // 全部模块告警：module、identifier、level、metric、value、warning_threshold、critical_threshold、message、remediation
+	TemplateTableModules = "modules" // 各模块汇总：name、status、total、normal、warning、critical、failed、warning_alerts、critical_alerts
+)
+
+// templatePlaceholder matches "{{name}}" and "{{table.field}}" placeholders.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)(?:\.([A-Za-z0-9_:/.-]+))?\s*\}\}`)
+
+// TemplateData holds the inspection results filled into a customer-provided Excel template.
+type TemplateData struct {
+	InspectionTime time.Time               // 巡检时间
+	Host           *model.InspectionResult // 主机巡检结果（可选）：主机汇总与 hosts 表
+	Alerts         []*json.Alert           // 全部模块告警：告警汇总与 alerts 表
+	Modules        []*model.RollupModule   // 各模块汇总：modules 表
+}
+
+// WriteTemplate fills the customer workbook at templatePath with the inspection results and
+// saves it at outputPath, keeping the customer layout instead of generating the report sheets.
+//
+// Cells may hold placeholders anywhere in their text. "{{name}}" is replaced by a report value
+// (title, inspection_time, report_date, total_hosts, normal_hosts, warning_hosts,
+// critical_hosts, failed_hosts, total_alerts, critical_alerts, warning_alerts); a cell holding
+// only a count placeholder becomes a number. A row with "{{<table>.<field>}}" placeholders is
+// repeated once per item of the table (TemplateTableHosts, TemplateTableAlerts,
+// TemplateTableModules), keeping the row style; it is cleared when the table is empty.
+// Unknown placeholders are left unchanged so template mistakes stay visible.
+func (w *Writer) WriteTemplate(templatePath string, data *TemplateData, outputPath string) error {
+	f, err := excelize.OpenFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel template: %w", err)
+	}
+	defer f.Close()
+
+	values := w.templateValues(data)
+	tables := map[string][]map[string]interface{}{
+		TemplateTableHosts:   w.templateHostRows(data.Host),
+		TemplateTableAlerts:  w.templateAlertRows(data.Alerts),
+		TemplateTableModules: templateModuleRows(data.Modules),
+	}
+
+	for _, sheet := range f.GetSheetList() {
+		if err := fillTemplateSheet(f, sheet, values, tables); err != nil {
+			return fmt.Errorf("failed to fill sheet %s: %w", sheet, err)
+		}
+	}
+
+	// The customer layout is kept as is: no localization or theme, only the watermark and the
+	// protection settings
+	if err := w.applyWatermark(f); err != nil {
+		return err
+	}
+	if err := w.protect(f); err != nil {
+		return err
+	}
+	return f.SaveAs(outputPath, w.saveOptions())
+}
+
+// templateValues returns the report values of the "{{name}}" placeholders.
+func (w *Writer) templateValues(data *TemplateData) map[string]interface{} {
+	var critical, warning int
+	for _, alert := range data.Alerts {
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			critical++
+		case model.AlertLevelWarning:
+			warning++
+		}
+	}
+
+	values := map[string]interface{}{
+		"title":           w.theme.GetTitle(),
+		"inspection_time": w.formatInspectionTime(data.InspectionTime),
+		"report_date":     data.InspectionTime.In(w.timezone).Format("2006-01-02"),
+		"total_alerts":    len(data.Alerts),
+		"critical_alerts": critical,
+		"warning_alerts":  warning,
+	}
+	hosts := &model.InspectionSummary{}
+	if data.Host != nil {
+		if hosts = data.Host.Summary; hosts == nil {
+			hosts = model.NewInspectionSummary(data.Host.Hosts)
+		}
+	}
+	values["total_hosts"] = hosts.TotalHosts
+	values["normal_hosts"] = hosts.NormalHosts
+	values["warning_hosts"] = hosts.WarningHosts
+	values["critical_hosts"] = hosts.CriticalHosts
+	values["failed_hosts"] = hosts.FailedHosts
+	return values
+}
+
+// templateHostRows returns the rows of the hosts table. Metric fields hold the formatted
+// metric value, or "N/A" when the metric was not collected.
+func (w *Writer) templateHostRows(result *model.InspectionResult) []map[string]interface{} {
+	if result == nil {
+		return nil
+	}
+	rows := make([]map[string]interface{}, 0, len(result.Hosts))
+	for _, host := range result.Hosts {
+		if host == nil {
+			continue
+		}
+		row := map[string]interface{}{
+			"hostname":       host.Hostname,
+			"ip":             host.IP,
+			"status":         statusText(host.Status),
+			"os":             host.OS,
+			"os_version":     host.OSVersion,
+			"kernel_version": host.KernelVersion,
+			"cpu_cores":      host.CPUCores,
+			"business_group": host.BusinessGroup,
+			"owner":          host.Owner,
+			"application":    host.Application,
+			"location":       host.Location,
+			"maintenance":    maintenanceText(host.Maintenance),
+		}
+		for name, metric := range host.Metrics {
+			if _, ok := row[name]; ok || metric == nil {
+				continue
+			}
+			if metric.IsNA {
+				row[name] = "N/A"
+			} else {
+				row[name] = metric.FormattedValue
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// templateAlertRows returns the rows of the alerts table, in report order.
+func (w *Writer) templateAlertRows(alerts []*json.Alert) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(alerts))
+	for _, alert := range alerts {
+		metric := alert.MetricDisplayName
+		if metric == "" {
+			metric = alert.MetricName
+		}
+		rows = append(rows, map[string]interface{}{
+			"module":             json.ModuleName(alert.Module),
+			"identifier":         alert.Identifier,
+			"level":              alertLevelText(alert.Level),
+			"metric":             metric,
+			"value":              alert.FormattedValue,
+			"warning_threshold":  formatThreshold(alert.WarningThreshold, alert.MetricName),
+			"critical_threshold": formatThreshold(alert.CriticalThreshold, alert.MetricName),
+			"message":            alert.Message,
+			"remediation":        w.remediation(alert.MetricName),
+		})
+	}
+	return rows
+}
+
+// templateModuleRows returns the rows of the modules table, in report order.
+func templateModuleRows(modules []*model.RollupModule) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(modules))
+	for _, m := range modules {
+		rows = append(rows, map[string]interface{}{
+			"name":            m.Module,
+			"status":          statusText(m.Status()),
+			"total":           m.Total,
+			"normal":          m.Normal,
+			"warning":         m.Warning,
+			"critical":        m.Critical,
+			"failed":          m.Failed,
+			"warning_alerts":  m.WarningAlerts,
+			"critical_alerts": m.CriticalAlerts,
+		})
+	}
+	return rows
+}
+
+// fillTemplateSheet replaces the placeholders of a sheet, repeating the table rows.
+func fillTemplateSheet(f *excelize.File, sheet string, values map[string]interface{}, tables map[string][]map[string]interface{}) error {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return err
+	}
+
+	// Rows are processed top down; inserted table rows shift the rows below
+	offset := 0
+	for i, cells := range rows {
+		row := i + 1 + offset
+		table := templateRowTable(cells, tables)
+		if table == "" {
+			for col, text := range cells {
+				if err := fillTemplateCell(f, sheet, col+1, row, text, func(name, field string) (interface{}, bool) {
+					value, ok := values[name]
+					return value, ok && field == ""
+				}); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		items := tables[table]
+		// Copy the template row once per additional item, keeping its style
+		for n := 1; n < len(items); n++ {
+			if err := f.DuplicateRow(sheet, row); err != nil {
+				return err
+			}
+		}
+		if len(items) == 0 {
+			items = []map[string]interface{}{{}}
+		}
+		for n, item := range items {
+			for col, text := range cells {
+				if err := fillTemplateCell(f, sheet, col+1, row+n, text, func(name, field string) (interface{}, bool) {
+					if name != table {
+						value, ok := values[name]
+						return value, ok && field == ""
+					}
+					// Fields without a value (empty table, metric not collected) are cleared
+					if value, ok := item[field]; ok {
+						return value, true
+					}
+					return "", true
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		offset += len(items) - 1
+	}
+	return nil
+}
+
+// templateRowTable returns the table of the first "{{<table>.<field>}}" placeholder of a row,
+// or "" when the row has none.
+func templateRowTable(cells []string, tables map[string][]map[string]interface{}) string {
+	for _, text := range cells {
+		for _, m := range templatePlaceholder.FindAllStringSubmatch(text, -1) {
+			if _, ok := tables[m[1]]; ok && m[2] != "" {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+// fillTemplateCell replaces the placeholders of a cell with the values returned by lookup.
+// A cell holding only a placeholder takes the value as is, so numbers stay numbers.
+func fillTemplateCell(f *excelize.File, sheet string, col, row int, text string, lookup func(name, field string) (interface{}, bool)) error {
+	if !strings.Contains(text, "{{") {
+		return nil
+	}
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return err
+	}
+
+	if m := templatePlaceholder.FindStringSubmatch(text); m != nil && m[0] == strings.TrimSpace(text) {
+		if value, ok := lookup(m[1], m[2]); ok {
+			return f.SetCellValue(sheet, cell, value)
+		}
+		return nil
+	}
+
+	filled := templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		m := templatePlaceholder.FindStringSubmatch(placeholder)
+		if value, ok := lookup(m[1], m[2]); ok {
+			return fmt.Sprint(value)
+		}
+		return placeholder
+	})
+	if filled == text {
+		return nil
+	}
+	return f.SetCellValue(sheet, cell, filled)
+}
@@ -0,0 +1,123 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_WriteTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "template.xlsx")
+	outputPath := filepath.Join(dir, "report.xlsx")
+
+	tpl := excelize.NewFile()
+	rows := [][]interface{}{
+		{"巡检时间：{{inspection_time}}"},
+		{"主机总数", "{{ total_hosts }}", "{{unknown}}"},
+		{"主机名", "IP", "CPU", "状态"},
+		{"{{hosts.hostname}}", "{{hosts.ip}}", "{{hosts.cpu_usage}}", "{{hosts.status}}"},
+		{"合计", "=COUNTA(A4:A4)"},
+		{"{{alerts.level}}：{{alerts.message}}"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := tpl.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tpl.SaveAs(templatePath); err != nil {
+		t.Fatal(err)
+	}
+	tpl.Close()
+
+	result := createTestInspectionResult()
+	data := &TemplateData{
+		InspectionTime: result.InspectionTime,
+		Host:           result,
+		Alerts:         json.FlattenAlerts(&json.Report{Host: result}),
+	}
+	if err := NewWriter(nil).WriteTemplate(templatePath, data, outputPath); err != nil {
+		t.Fatalf("WriteTemplate() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if got, _ := f.GetCellValue("Sheet1", "A1"); got != "巡检时间：2025-12-13 10:00:00" {
+		t.Errorf("A1 = %q", got)
+	}
+	if typ, _ := f.GetCellType("Sheet1", "B2"); typ == excelize.CellTypeSharedString || typ == excelize.CellTypeInlineString {
+		t.Errorf("total_hosts should be written as a number, got cell type %v", typ)
+	}
+	if got, _ := f.GetCellValue("Sheet1", "C2"); got != "{{unknown}}" {
+		t.Errorf("unknown placeholder should be kept, got %q", got)
+	}
+
+	// One hosts row per host, the rows below shifted down
+	for i, host := range result.Hosts {
+		row := i + 4
+		if got, _ := f.GetCellValue("Sheet1", cellName(1, row)); got != host.Hostname {
+			t.Errorf("A%d = %q, want %q", row, got, host.Hostname)
+		}
+		want := "N/A"
+		if m := host.Metrics["cpu_usage"]; m != nil && !m.IsNA {
+			want = m.FormattedValue
+		}
+		if got, _ := f.GetCellValue("Sheet1", cellName(3, row)); got != want {
+			t.Errorf("C%d = %q, want %q", row, got, want)
+		}
+	}
+	totalRow := len(result.Hosts) + 4
+	if got, _ := f.GetCellValue("Sheet1", cellName(1, totalRow)); got != "合计" {
+		t.Errorf("A%d = %q, want the row below the hosts table", totalRow, got)
+	}
+	if got, _ := f.GetCellValue("Sheet1", cellName(1, totalRow+1)); got != "警告：CPU利用率 75.0% 超过警告阈值 70.0%" {
+		t.Errorf("alerts row = %q", got)
+	}
+}
+
+func TestWriter_WriteTemplate_EmptyTable(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "template.xlsx")
+	outputPath := filepath.Join(dir, "report.xlsx")
+
+	tpl := excelize.NewFile()
+	tpl.SetCellValue("Sheet1", "A1", "{{modules.name}}")
+	tpl.SetCellValue("Sheet1", "B1", "{{modules.critical_alerts}}")
+	tpl.SetCellValue("Sheet1", "A2", "告警数：{{total_alerts}}")
+	if err := tpl.SaveAs(templatePath); err != nil {
+		t.Fatal(err)
+	}
+	tpl.Close()
+
+	data := &TemplateData{Modules: []*model.RollupModule{}}
+	if err := NewWriter(nil).WriteTemplate(templatePath, data, outputPath); err != nil {
+		t.Fatalf("WriteTemplate() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if got, _ := f.GetCellValue("Sheet1", "A1"); got != "" {
+		t.Errorf("empty table row should be cleared, got %q", got)
+	}
+	if got, _ := f.GetCellValue("Sheet1", "A2"); got != "告警数：0" {
+		t.Errorf("A2 = %q", got)
+	}
+}
+
+func cellName(col, row int) string {
+	cell, _ := excelize.CoordinatesToCellName(col, row)
+	return cell
+}
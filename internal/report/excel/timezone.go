@@ -0,0 +1,36 @@
+package excel
+
+import "time"
+
+// WithSecondaryTimezone shows inspection times in a second timezone next to the report
+// timezone, e.g. "2025-01-15 18:30:00 (UTC 2025-01-15 10:30:00)", so overseas sites can be
+// reviewed from headquarters. label names the timezone in the cells; empty uses the location
+// name. A nil location keeps single timezone times.
+func WithSecondaryTimezone(loc *time.Location, label string) Option {
+	return func(w *Writer) {
+		w.secondaryTimezone = loc
+		w.secondaryTimezoneLabel = label
+		if loc != nil && label == "" {
+			w.secondaryTimezoneLabel = loc.String()
+		}
+	}
+}
+
+// formatInspectionTime formats an inspection time in the report timezone, followed by the
+// secondary timezone when one is set.
+func (w *Writer) formatInspectionTime(t time.Time) string {
+	text := t.In(w.timezone).Format("2006-01-02 15:04:05")
+	if w.secondaryTimezone == nil {
+		return text
+	}
+	return text + " (" + w.secondaryTimezoneLabel + " " + t.In(w.secondaryTimezone).Format("2006-01-02 15:04:05") + ")"
+}
+
+// inspectionTimeWidth returns the width of an inspection time column, widened to fit the
+// secondary timezone.
+func (w *Writer) inspectionTimeWidth(width float64) float64 {
+	if w.secondaryTimezone == nil {
+		return width
+	}
+	return max(width, float64(len(w.formatInspectionTime(time.Time{})))+2)
+}
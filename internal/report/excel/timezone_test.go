@@ -0,0 +1,56 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriter_FormatInspectionTime(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	inspectionTime := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		w    *Writer
+		want string
+	}{
+		{"single timezone", NewWriter(shanghai), "2025-01-15 18:30:00"},
+		{"location name", NewWriter(shanghai, WithSecondaryTimezone(time.UTC, "")), "2025-01-15 18:30:00 (UTC 2025-01-15 10:30:00)"},
+		{"label", NewWriter(time.UTC, WithSecondaryTimezone(shanghai, "北京时间")), "2025-01-15 10:30:00 (北京时间 2025-01-15 18:30:00)"},
+		{"nil location", NewWriter(shanghai, WithSecondaryTimezone(nil, "UTC")), "2025-01-15 18:30:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.w.formatInspectionTime(inspectionTime); got != tt.want {
+				t.Errorf("formatInspectionTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_Write_SecondaryTimezone(t *testing.T) {
+	result := createTestInspectionResult()
+	result.InspectionTime = time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil, WithSecondaryTimezone(time.UTC, "")).Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	want := "2025-01-15 18:30:00 (UTC 2025-01-15 10:30:00)"
+	if got, _ := f.GetCellValue(sheetSummary, "B3"); got != want {
+		t.Errorf("summary inspection time = %q, want %q", got, want)
+	}
+	if width, _ := f.GetColWidth(sheetSummary, "B"); width < float64(len(want)) {
+		t.Errorf("summary column width = %v, want at least %d", width, len(want))
+	}
+}
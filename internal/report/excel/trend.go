@@ -0,0 +1,145 @@
+package excel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// trendMetricNames maps the trend metrics to their column names in the host detail sheet.
+var trendMetricNames = map[string]string{
+	"cpu_usage":      "CPU利用率",
+	"memory_usage":   "内存利用率",
+	"disk_usage_max": "磁盘最大利用率",
+}
+
+// WithTrend adds a trend sheet ("趋势") to host reports comparing the CPU, memory and disk
+// usage of every host with previous inspection runs, newest first. No runs means no sheet.
+func WithTrend(runs []*model.TrendRun) Option {
+	return func(w *Writer) {
+		w.trend = runs
+	}
+}
+
+// createTrendSheet creates the trend worksheet: one row per host and trend metric with the
+// current value, the values of the previous runs and the change against the last and the
+// oldest of them. Rising values are highlighted as warnings, falling values as normal.
+func (w *Writer) createTrendSheet(f *excelize.File, result *model.InspectionResult) error {
+	if len(w.trend) == 0 {
+		return nil
+	}
+	if _, err := f.NewSheet(sheetTrend); err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"主机名", "指标", "本次"}
+	widths := []float64{wideColWidth, defaultColWidth, narrowColWidth + 2}
+	for _, run := range w.trend {
+		headers = append(headers, w.formatReportTime(run.InspectionTime))
+		widths = append(widths, 20)
+	}
+	headers = append(headers, "较上次变化")
+	widths = append(widths, 14)
+	oldest := w.trend[len(w.trend)-1]
+	if len(w.trend) > 1 {
+		headers = append(headers, "较最早变化")
+		widths = append(widths, 14)
+	}
+	w.writeFrozenHeader(f, sheetTrend, headers, widths, headerStyle)
+
+	row := 2
+	for _, host := range result.Hosts {
+		for _, name := range model.TrendMetrics {
+			var current *float64
+			if mv := host.Metrics[name]; mv != nil && !mv.IsNA {
+				current = &mv.RawValue
+			}
+
+			values := []interface{}{host.Hostname, trendMetricNames[name], trendCellValue(current)}
+			hasHistory := false
+			for _, run := range w.trend {
+				value, ok := run.Value(host.Hostname, name)
+				if ok {
+					hasHistory = true
+					values = append(values, trendCellValue(&value))
+				} else {
+					values = append(values, "-")
+				}
+			}
+			if current == nil && !hasHistory {
+				continue
+			}
+
+			for col, value := range values {
+				f.SetCellValue(sheetTrend, fmt.Sprintf("%s%d", columnName(col+1), row), value)
+			}
+
+			changes := []*model.TrendRun{w.trend[0]}
+			if len(w.trend) > 1 {
+				changes = append(changes, oldest)
+			}
+			for i, run := range changes {
+				cell := fmt.Sprintf("%s%d", columnName(len(values)+i+1), row)
+				previous, ok := run.Value(host.Hostname, name)
+				text, direction := "-", 0
+				if current != nil && ok {
+					text, direction = trendChangeText(previous, *current)
+				}
+				f.SetCellValue(sheetTrend, cell, text)
+				switch {
+				case direction > 0:
+					f.SetCellStyle(sheetTrend, cell, cell, warningStyle)
+				case direction < 0:
+					f.SetCellStyle(sheetTrend, cell, cell, normalStyle)
+				}
+			}
+			row++
+		}
+	}
+
+	if row > 2 {
+		f.AutoFilter(sheetTrend, fmt.Sprintf("A1:%s%d", columnName(len(headers)), row-1), nil)
+	}
+
+	return nil
+}
+
+// trendCellValue returns a metric value rounded to two decimals, or "N/A" if not collected.
+func trendCellValue(value *float64) interface{} {
+	if value == nil {
+		return "N/A"
+	}
+	return math.Round(*value*100) / 100
+}
+
+// trendChangeText formats the change from previous to current with an arrow and the
+// percentage (e.g. "↑ 12.5%"), and returns its direction: 1 rising, -1 falling, 0 unchanged.
+func trendChangeText(previous, current float64) (string, int) {
+	percent, ok := model.TrendChange(previous, current)
+	switch {
+	case !ok:
+		return "↑", 1
+	case percent > 0:
+		return fmt.Sprintf("↑ %.1f%%", percent), 1
+	case percent < 0:
+		return fmt.Sprintf("↓ %.1f%%", -percent), -1
+	default:
+		return "→ 0.0%", 0
+	}
+}
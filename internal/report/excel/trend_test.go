@@ -0,0 +1,99 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+func TestWriter_Write_TrendSheet(t *testing.T) {
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	runs := []*model.TrendRun{
+		{
+			InspectionTime: time.Date(2025, 12, 12, 10, 0, 0, 0, tz),
+			Values: map[string]map[string]float64{
+				"host-1": {"cpu_usage": 40, "memory_usage": 60},
+			},
+		},
+		{
+			InspectionTime: time.Date(2025, 12, 11, 10, 0, 0, 0, tz),
+			Values: map[string]map[string]float64{
+				"host-1": {"cpu_usage": 50},
+			},
+		},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil, WithTrend(runs)).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetTrend)
+	if err != nil {
+		t.Fatalf("trend sheet missing: %v", err)
+	}
+	want := []string{"主机名", "指标", "本次", "2025-12-12 10:00:00", "2025-12-11 10:00:00", "较上次变化", "较最早变化"}
+	for i, header := range want {
+		if rows[0][i] != header {
+			t.Errorf("header %d = %q, want %q", i, rows[0][i], header)
+		}
+	}
+
+	// host-1: cpu 45.5 (40 → ↑ 13.8%, 50 → ↓ 9.0%), memory 60 (unchanged, no oldest value)
+	if got := rows[1]; got[0] != "host-1" || got[1] != "CPU利用率" || got[2] != "45.5" || got[5] != "↑ 13.8%" || got[6] != "↓ 9.0%" {
+		t.Errorf("cpu trend row = %v", got)
+	}
+	if got := rows[2]; got[1] != "内存利用率" || got[4] != "-" || got[5] != "→ 0.0%" || got[6] != "-" {
+		t.Errorf("memory trend row = %v", got)
+	}
+	// The other hosts have no history but keep their current values
+	if len(rows) != 1+3*3 {
+		t.Errorf("trend sheet has %d rows, want 10", len(rows))
+	}
+}
+
+func TestWriter_Write_NoTrend(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetTrend); idx != -1 {
+		t.Error("trend sheet should only be created with previous runs")
+	}
+}
+
+func TestTrendChangeText(t *testing.T) {
+	tests := []struct {
+		previous, current float64
+		text              string
+		direction         int
+	}{
+		{40, 50, "↑ 25.0%", 1},
+		{50, 40, "↓ 20.0%", -1},
+		{40, 40, "→ 0.0%", 0},
+		{0, 10, "↑", 1},
+	}
+	for _, tt := range tests {
+		text, direction := trendChangeText(tt.previous, tt.current)
+		if text != tt.text || direction != tt.direction {
+			t.Errorf("trendChangeText(%v, %v) = %q, %d, want %q, %d", tt.previous, tt.current, text, direction, tt.text, tt.direction)
+		}
+	}
+}
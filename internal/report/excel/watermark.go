@@ -0,0 +1,95 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/report/theme"
+)
+
+// Size of the watermark image on the printed page.
+const (
+	watermarkWidth  = "480pt"
+	watermarkHeight = "320pt"
+)
+
+// WithWatermark adds a diagonal watermark (e.g. the customer name and report date) to every
+// sheet. Excel shows header / footer pictures in the page layout view and on printed or
+// PDF-exported pages only, so the normal view is unchanged. Empty means no watermark.
+func WithWatermark(text string) Option {
+	return func(w *Writer) {
+		w.watermark = text
+	}
+}
+
+// applyWatermark adds the watermark picture to the center of the page footer of every sheet;
+// the picture is larger than the footer, so it covers the page. The theme footer sections are
+// kept. Sheets already watermarked (e.g. when appending to an existing report) are left unchanged.
+func (w *Writer) applyWatermark(f *excelize.File) error {
+	if w.watermark == "" {
+		return nil
+	}
+	picture := theme.WatermarkSVG(w.watermark)
+
+	for _, sheet := range f.GetSheetList() {
+		hf, err := f.GetHeaderFooter(sheet)
+		if err != nil {
+			return err
+		}
+		if hf == nil {
+			hf = &excelize.HeaderFooterOptions{}
+		}
+		if strings.Contains(hf.OddFooter, "&G") {
+			continue
+		}
+		if err := f.AddHeaderFooterImage(sheet, &excelize.HeaderFooterImageOptions{
+			Position:  excelize.HeaderFooterImagePositionCenter,
+			File:      picture,
+			IsFooter:  true,
+			Extension: ".svg",
+			Width:     watermarkWidth,
+			Height:    watermarkHeight,
+		}); err != nil {
+			return fmt.Errorf("failed to add watermark to sheet %s: %w", sheet, err)
+		}
+		hf.OddFooter = withCenterPicture(hf.OddFooter)
+		if err := f.SetHeaderFooter(sheet, hf); err != nil {
+			return fmt.Errorf("failed to set footer of sheet %s: %w", sheet, err)
+		}
+	}
+	return nil
+}
+
+// withCenterPicture adds the picture code (&G) to the center section of a header / footer
+// definition, keeping the left and right sections. Text before the first section code belongs
+// to the center section, as in Excel.
+func withCenterPicture(definition string) string {
+	sections := map[byte]*strings.Builder{'L': {}, 'C': {}, 'R': {}}
+	current := sections['C']
+	for i := 0; i < len(definition); i++ {
+		if definition[i] == '&' && i+1 < len(definition) {
+			if section, ok := sections[definition[i+1]]; ok {
+				current = section
+				i++
+				continue
+			}
+			// Keep the other codes, including the escaped "&&", as they are
+			current.WriteString(definition[i : i+2])
+			i++
+			continue
+		}
+		current.WriteByte(definition[i])
+	}
+
+	var b strings.Builder
+	if left := sections['L'].String(); left != "" {
+		b.WriteString("&L" + left)
+	}
+	b.WriteString("&C&G" + sections['C'].String())
+	if right := sections['R'].String(); right != "" {
+		b.WriteString("&R" + right)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package excel
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/report/theme"
+)
+
+func TestWriter_Write_Watermark(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	w := NewWriter(nil, WithTheme(&theme.Theme{FooterText: "ACME 运维服务"}), WithWatermark("ACME 2025-12-13"))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	// Appending a sheet keeps a single watermark per sheet
+	if err := w.AppendProvenanceSheet(&Provenance{Version: "v1.0.0"}, outputPath); err != nil {
+		t.Fatalf("AppendProvenanceSheet() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open report: %v", err)
+	}
+	sheets := f.GetSheetList()
+	for _, sheet := range sheets {
+		hf, err := f.GetHeaderFooter(sheet)
+		if err != nil || hf == nil {
+			t.Fatalf("GetHeaderFooter(%q) error = %v", sheet, err)
+		}
+		if !strings.Contains(hf.OddFooter, "&C&G") || !strings.Contains(hf.OddFooter, "&LACME 运维服务") || strings.Count(hf.OddFooter, "&G") != 1 {
+			t.Errorf("sheet %q footer = %q, want the theme footer and one watermark picture", sheet, hf.OddFooter)
+		}
+	}
+	f.Close()
+
+	// The watermark picture holds the text
+	zr, err := zip.OpenReader(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	var pictures int
+	for _, file := range zr.File {
+		if !strings.HasPrefix(file.Name, "xl/media/") || !strings.HasSuffix(file.Name, ".svg") {
+			continue
+		}
+		pictures++
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if !strings.Contains(string(data), "ACME 2025-12-13") {
+			t.Errorf("%s does not hold the watermark text", file.Name)
+		}
+	}
+	if pictures == 0 {
+		t.Error("report has no watermark picture")
+	}
+}
+
+func TestWithCenterPicture(t *testing.T) {
+	tests := map[string]string{
+		"":                        "&C&G",
+		"&LACME&R第 &P 页 / 共 &N 页": "&LACME&C&G&R第 &P 页 / 共 &N 页",
+		"&C&B标题":                  "&C&G&B标题",
+		"A && B&R右":               "&C&GA && B&R右",
+	}
+	for input, want := range tests {
+		if got := withCenterPicture(input); got != want {
+			t.Errorf("withCenterPicture(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
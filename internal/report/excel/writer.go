@@ -13,6 +13,9 @@ import (
 	"github.com/xuri/excelize/v2"
 
 	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
 )
 
 const (
@@ -22,12 +25,57 @@ const (
 	sheetAlerts  = "异常汇总"
 	sheetMySQL       = "MySQL 巡检" // MySQL inspection sheet
 	sheetMySQLAlerts = "MySQL 异常" // MySQL alerts sheet
+	sheetMySQLMGRMembers = "MySQL MGR 成员" // MySQL MGR group member detail sheet
+	sheetMySQLTopology = "MySQL 复制拓扑" // MySQL replication topology tree sheet
 	sheetRedis       = "Redis 巡检" // Redis inspection sheet
 	sheetRedisAlerts = "Redis 异常" // Redis alerts sheet
 	sheetNginx       = "Nginx 巡检" // Nginx inspection sheet
 	sheetNginxAlerts = "Nginx 异常" // Nginx alerts sheet
+	sheetNginxUpstream = "Nginx Upstream" // Nginx upstream summary sheet
 	sheetTomcat      = "Tomcat 巡检" // Tomcat inspection sheet
 	sheetTomcatAlerts = "Tomcat 异常" // Tomcat alerts sheet
+	sheetCassandra       = "Cassandra 巡检" // Cassandra inspection sheet
+	sheetCassandraAlerts = "Cassandra 异常" // Cassandra alerts sheet
+	sheetMonitoring       = "监控系统巡检" // Monitoring stack inspection sheet
+	sheetMonitoringAlerts = "监控系统异常" // Monitoring stack alerts sheet
+	sheetStorage          = "共享存储巡检" // Shared storage inspection sheet
+	sheetStorageAlerts    = "共享存储异常" // Shared storage alerts sheet
+	sheetLogChecks        = "日志巡检" // Log checks inspection sheet
+	sheetLogCheckAlerts   = "日志异常" // Log checks alerts sheet
+	sheetLVS              = "LVS 巡检" // LVS inspection sheet
+	sheetLVSRealServers   = "LVS 真实服务器" // LVS real server detail sheet
+	sheetLVSAlerts        = "LVS 异常" // LVS alerts sheet
+	sheetWindows          = "Windows 服务巡检" // Windows service / IIS inspection sheet
+	sheetWindowsAlerts    = "Windows 异常" // Windows alerts sheet
+	sheetAD               = "AD 巡检" // AD / LDAP inspection sheet
+	sheetADAlerts         = "AD 异常" // AD alerts sheet
+	sheetCloud            = "云资源巡检" // Cloud resource inspection sheet
+	sheetCloudAlerts      = "云资源异常" // Cloud resource alerts sheet
+	sheetCloudCost        = "云资源成本" // Cloud resource cost / asset summary sheet
+	sheetFirewall         = "防火墙巡检" // Firewall / VPN appliance inspection sheet
+	sheetFirewallTunnels  = "VPN 隧道" // Firewall VPN tunnel detail sheet
+	sheetFirewallAlerts   = "防火墙异常" // Firewall alerts sheet
+	sheetRawData      = "原始数据"      // Raw metric data sheet (long format)
+	sheetTOC          = "目录"        // Table of contents sheet (combined workbooks only)
+	sheetCharts       = "图表"        // Host status / alert / disk usage charts sheet
+	sheetTrend        = "趋势"        // Host CPU / memory / disk trend against previous runs
+	sheetSourceConflicts = "来源冲突" // Host metrics reported with different values by several agents
+	sheetActiveAlerts = "当前告警" // Alert rules firing in N9E for the inspected hosts
+	sheetAlertmanager = "Alertmanager 告警" // Alerts firing in Alertmanager (combined workbooks only)
+	sheetBusinessGroups = "业务组统计" // Host statistics by N9E business group
+	sheetOwnerGroups = "负责人统计" // Host statistics by CMDB owner
+	sheetApplicationGroups = "应用统计" // Host statistics by CMDB application
+	sheetLocationGroups = "位置统计" // Host statistics by CMDB location
+	sheetCapacity = "容量排行" // Top hosts by disk usage, memory usage and load per core
+	sheetProvenance = "报告元数据" // Tool version, configuration, datasources and queries behind the report
+	sheetExecutiveSummary = "管理摘要" // Top risks across all modules (combined workbooks only)
+	sheetCover = "封面" // Customer / project cover sheet (combined workbooks only)
+
+	// Minimum number of sheets before a combined workbook gets a table of contents
+	tocMinSheets = 5
+
+	// Number of hosts shown in the top disk usage chart
+	chartTopDiskHosts = 10
 
 	// Default sheet to remove
 	defaultSheet = "Sheet1"
@@ -41,6 +89,8 @@ const (
 	colorHeaderFg   = "FFFFFF" // White text for header
 	colorNormalBg   = "C6EFCE" // Green background for normal
 	colorNormalFg   = "006100" // Dark green text for normal
+	colorLinkFg     = "0563C1" // Blue text for hyperlinks
+	colorSubtotalBg = "D9E1F2" // Light blue background for subtotal rows
 
 	// Column widths
 	defaultColWidth = 15.0
@@ -50,18 +100,127 @@ const (
 
 // Writer implements report.ReportWriter for Excel format.
 type Writer struct {
-	timezone *time.Location
+	timezone        *time.Location
+	theme           *theme.Theme // White-label theme (optional)
+	sheetBadges     bool         // Append alert counts to sheet names
+	sheetOrder      []string     // Module keys whose sheets come first, in order
+	hideEmptySheets bool         // Hide the sheets of modules without instances
+	groupAlerts     bool         // Group the host alerts sheet by host with collapsible outlines
+	columns         map[string][]string // Selected column keys per module (ModuleHost, ModuleMySQL, ModuleRedis)
+	tr              *i18n.Translator // Report language (nil: Chinese)
+	streams         []*sheetStream   // Sheets streamed when their workbook is saved
+	protectSheets   bool             // Protect every sheet (read-only cells)
+	sheetPassword   string           // Password to unprotect the sheets (optional)
+	password        string           // Password to open the workbook; non-empty encrypts it
+	trend           []*model.TrendRun // Previous runs compared in the trend sheet, newest first
+	cover           *theme.Cover      // Cover sheet of combined reports (optional)
+	usageThresholds map[string]UsageThreshold // Usage columns colored by conditional formatting
+	secondaryTimezone      *time.Location // Second timezone of inspection times (optional)
+	secondaryTimezoneLabel string         // Name of the second timezone in the cells
+	remediationHints       map[string]string // Remediation texts of the alerts sheets by metric name
+	capacityTopN           int               // Hosts per ranking of the capacity sheet (0: no sheet)
+	hostGroupBy            []model.HostGroupKey // CMDB attributes with a host statistics sheet each
+	watermark              string            // Watermark text of the printed pages (optional)
+	alertmanagerAlerts     []*model.AlertmanagerAlert // Alerts firing in Alertmanager, listed in combined workbooks
+}
+
+// Option is a functional option for configuring a Writer.
+type Option func(*Writer)
+
+// WithTheme applies a white-label theme (title, header color, logo, footer text) to the reports.
+func WithTheme(t *theme.Theme) Option {
+	return func(w *Writer) {
+		w.theme = t
+	}
+}
+
+// WithSheetAlertBadges appends the module alert counts to sheet names of combined reports
+// (e.g. "MySQL 巡检 (2⚠ 1✖)"), so severity is visible from the tab bar.
+func WithSheetAlertBadges(enabled bool) Option {
+	return func(w *Writer) {
+		w.sheetBadges = enabled
+	}
+}
+
+// WithSheetOrder places the sheets of the given modules (ModuleHost, ModuleMySQL, ...) first in
+// combined reports, in the given order. Unlisted modules follow in the default order.
+func WithSheetOrder(modules []string) Option {
+	return func(w *Writer) {
+		w.sheetOrder = modules
+	}
+}
+
+// WithHideEmptySheets hides the sheets of modules that found no instances in combined reports
+// (e.g. an empty "Nginx 巡检" sheet when no Nginx instance was discovered).
+func WithHideEmptySheets(enabled bool) Option {
+	return func(w *Writer) {
+		w.hideEmptySheets = enabled
+	}
+}
+
+// WithAlertGrouping groups the host alerts sheet ("异常汇总") by host: each host gets a subtotal
+// row followed by its alerts as a collapsible outline group.
+func WithAlertGrouping(enabled bool) Option {
+	return func(w *Writer) {
+		w.groupAlerts = enabled
+	}
+}
+
+// WithColumns selects which columns of a module inspection sheet appear, and in what order.
+// module is ModuleHost (the "详细数据" sheet), ModuleMySQL or ModuleRedis; keys come from
+// HostColumnKeys, MySQLColumnKeys or RedisColumnKeys. Empty keys keep the default columns.
+func WithColumns(module string, keys []string) Option {
+	return func(w *Writer) {
+		if len(keys) == 0 {
+			return
+		}
+		if w.columns == nil {
+			w.columns = make(map[string][]string)
+		}
+		w.columns[module] = keys
+	}
+}
+
+// WithSheetProtection protects every sheet of the saved workbooks: cells, including the header
+// rows, are read-only while selecting, filtering and resizing columns stay allowed. password is
+// required to unprotect the sheets in Excel; empty means no password.
+func WithSheetProtection(enabled bool, password string) Option {
+	return func(w *Writer) {
+		w.protectSheets = enabled
+		w.sheetPassword = password
+	}
+}
+
+// WithPassword encrypts the saved workbooks with a password required to open them. The same
+// password is used to open existing workbooks when appending sheets. Empty means no encryption.
+func WithPassword(password string) Option {
+	return func(w *Writer) {
+		w.password = password
+	}
+}
+
+// WithLanguage sets the report language (i18n.LanguageZH, i18n.LanguageEN or
+// i18n.LanguageBilingual). Sheet names, headers and status texts are translated when the
+// workbook is saved.
+func WithLanguage(lang string) Option {
+	return func(w *Writer) {
+		w.tr = i18n.New(lang)
+	}
 }
 
 // NewWriter creates a new Excel report writer.
 // If timezone is nil, it defaults to Asia/Shanghai.
-func NewWriter(timezone *time.Location) *Writer {
+func NewWriter(timezone *time.Location, opts ...Option) *Writer {
 	if timezone == nil {
 		timezone, _ = time.LoadLocation("Asia/Shanghai")
 	}
-	return &Writer{
+	w := &Writer{
 		timezone: timezone,
 	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
 }
 
 // Format returns the format identifier for this writer.
@@ -97,6 +256,34 @@ func (w *Writer) Write(result *model.InspectionResult, outputPath string) error
 		return fmt.Errorf("failed to create alerts sheet: %w", err)
 	}
 
+	if err := w.createActiveAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create active alerts sheet: %w", err)
+	}
+
+	if err := w.createChartsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create charts sheet: %w", err)
+	}
+
+	if err := w.createTrendSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create trend sheet: %w", err)
+	}
+
+	if err := w.createSourceConflictsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create source conflicts sheet: %w", err)
+	}
+
+	if err := w.createBusinessGroupsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create business groups sheet: %w", err)
+	}
+
+	if err := w.createHostGroupSheets(f, result); err != nil {
+		return fmt.Errorf("failed to create host group sheets: %w", err)
+	}
+
+	if err := w.createCapacitySheet(f, result); err != nil {
+		return fmt.Errorf("failed to create capacity ranking sheet: %w", err)
+	}
+
 	// Remove default Sheet1
 	if err := f.DeleteSheet(defaultSheet); err != nil {
 		// Ignore error if sheet doesn't exist
@@ -113,7 +300,7 @@ func (w *Writer) Write(result *model.InspectionResult, outputPath string) error
 	}
 
 	// Save the file
-	if err := f.SaveAs(outputPath); err != nil {
+	if err := w.saveAs(f, outputPath); err != nil {
 		return fmt.Errorf("failed to save Excel file: %w", err)
 	}
 
@@ -138,7 +325,7 @@ func (w *Writer) createSummarySheet(f *excelize.File, result *model.InspectionRe
 		},
 		Fill: excelize.Fill{
 			Type:    "pattern",
-			Color:   []string{colorHeaderBg},
+			Color:   []string{w.headerBgColor()},
 			Pattern: 1,
 		},
 		Alignment: &excelize.Alignment{
@@ -181,11 +368,11 @@ func (w *Writer) createSummarySheet(f *excelize.File, result *model.InspectionRe
 
 	// Set column widths
 	f.SetColWidth(sheetSummary, "A", "A", 20)
-	f.SetColWidth(sheetSummary, "B", "B", 30)
+	f.SetColWidth(sheetSummary, "B", "B", w.inspectionTimeWidth(30))
 
 	// Title
 	f.MergeCell(sheetSummary, "A1", "B1")
-	f.SetCellValue(sheetSummary, "A1", "系统巡检报告")
+	f.SetCellValue(sheetSummary, "A1", w.theme.GetTitle())
 	f.SetCellStyle(sheetSummary, "A1", "B1", titleStyle)
 	f.SetRowHeight(sheetSummary, 1, 30)
 
@@ -194,7 +381,7 @@ func (w *Writer) createSummarySheet(f *excelize.File, result *model.InspectionRe
 		label string
 		value interface{}
 	}{
-		{"巡检时间", result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")},
+		{"巡检时间", w.formatInspectionTime(result.InspectionTime)},
 		{"巡检耗时", formatDuration(result.Duration)},
 		{"主机总数", result.Summary.TotalHosts},
 		{"正常主机", result.Summary.NormalHosts},
@@ -255,92 +442,21 @@ func (w *Writer) createDetailSheet(f *excelize.File, result *model.InspectionRes
 		return err
 	}
 
-	// Define headers
-	headers := []string{
-		"主机名", "IP地址", "状态", "操作系统", "系统版本", "内核版本",
-		"CPU核心数", "CPU利用率", "内存利用率", "磁盘最大利用率",
-		"运行时间", "1分钟负载", "每核负载", "僵尸进程", "总进程数",
-	}
-
-	// Get unique disk paths from all hosts
-	diskPaths := w.collectDiskPaths(result.Hosts)
-	for _, path := range diskPaths {
-		headers = append(headers, fmt.Sprintf("磁盘:%s", path))
-	}
-
-	// Set column widths
-	colWidths := map[string]float64{
-		"A": 20, "B": 15, "C": 10, "D": 12, "E": 20, "F": 30,
-		"G": 10, "H": 12, "I": 12, "J": 14,
-		"K": 15, "L": 12, "M": 10, "N": 10, "O": 10,
-	}
-	for col, width := range colWidths {
-		f.SetColWidth(sheetDetail, col, col, width)
-	}
-
-	// Set disk column widths
-	for i := range diskPaths {
-		col := columnName(16 + i) // Starting from column P
-		f.SetColWidth(sheetDetail, col, col, 15)
+	percentStyle, err := w.createPercentStyle(f)
+	if err != nil {
+		return err
 	}
 
-	// Write headers
-	for i, header := range headers {
-		cell := fmt.Sprintf("%s1", columnName(i+1))
-		f.SetCellValue(sheetDetail, cell, header)
-		f.SetCellStyle(sheetDetail, cell, cell, headerStyle)
+	// Disk columns follow the unique disk paths of all hosts
+	columns := w.hostColumns(w.collectDiskPaths(result.Hosts), percentStyle)
+	if !model.AnyHostHasCMDB(result.Hosts) {
+		columns = dropColumns(columns, hostCMDBColumnKeys)
 	}
-	f.SetRowHeight(sheetDetail, 1, 25)
-
-	// Freeze header row
-	f.SetPanes(sheetDetail, &excelize.Panes{
-		Freeze:      true,
-		Split:       false,
-		XSplit:      0,
-		YSplit:      1,
-		TopLeftCell: "A2",
-		ActivePane:  "bottomLeft",
-	})
-
-	// Write host data
-	for i, host := range result.Hosts {
-		row := i + 2 // Start from row 2
-		rowStr := fmt.Sprintf("%d", row)
-
-		// Basic info
-		f.SetCellValue(sheetDetail, "A"+rowStr, host.Hostname)
-		f.SetCellValue(sheetDetail, "B"+rowStr, host.IP)
-		f.SetCellValue(sheetDetail, "C"+rowStr, statusText(host.Status))
-		f.SetCellValue(sheetDetail, "D"+rowStr, host.OS)
-		f.SetCellValue(sheetDetail, "E"+rowStr, host.OSVersion)
-		f.SetCellValue(sheetDetail, "F"+rowStr, host.KernelVersion)
-		f.SetCellValue(sheetDetail, "G"+rowStr, host.CPUCores)
-
-		// Metrics
-		w.setMetricCell(f, sheetDetail, "H"+rowStr, host.Metrics["cpu_usage"], warningStyle, criticalStyle, normalStyle)
-		w.setMetricCell(f, sheetDetail, "I"+rowStr, host.Metrics["memory_usage"], warningStyle, criticalStyle, normalStyle)
-		w.setMetricCell(f, sheetDetail, "J"+rowStr, host.Metrics["disk_usage_max"], warningStyle, criticalStyle, normalStyle)
-		w.setMetricCell(f, sheetDetail, "K"+rowStr, host.Metrics["uptime"], 0, 0, 0)
-		w.setMetricCell(f, sheetDetail, "L"+rowStr, host.Metrics["load_1m"], 0, 0, 0)
-		w.setMetricCell(f, sheetDetail, "M"+rowStr, host.Metrics["load_per_core"], warningStyle, criticalStyle, normalStyle)
-		w.setMetricCell(f, sheetDetail, "N"+rowStr, host.Metrics["processes_zombies"], warningStyle, criticalStyle, normalStyle)
-		w.setMetricCell(f, sheetDetail, "O"+rowStr, host.Metrics["processes_total"], 0, 0, 0)
-
-		// Disk usage by path
-		for j, path := range diskPaths {
-			col := columnName(16 + j)
-			metricName := fmt.Sprintf("disk_usage:%s", path)
-			w.setMetricCell(f, sheetDetail, col+rowStr, host.Metrics[metricName], warningStyle, criticalStyle, normalStyle)
-		}
-
-		// Apply status style to entire row
-		statusStyle := w.getStatusStyle(host.Status, normalStyle, warningStyle, criticalStyle)
-		if statusStyle > 0 {
-			f.SetCellStyle(sheetDetail, "C"+rowStr, "C"+rowStr, statusStyle)
-		}
+	columns = selectColumns(columns, w.columns[ModuleHost])
+	if err := w.setUsageConditionalFormats(f, sheetDetail, columns, len(result.Hosts)); err != nil {
+		return err
 	}
-
-	return nil
+	return writeSheetColumns(w, f, sheetDetail, columns, result.Hosts, headerStyle, warningStyle, criticalStyle, normalStyle)
 }
 
 // createAlertsSheet creates the alerts summary worksheet.
@@ -367,33 +483,9 @@ func (w *Writer) createAlertsSheet(f *excelize.File, result *model.InspectionRes
 		return err
 	}
 
-	// Define headers
-	headers := []string{"主机名", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息"}
-
-	// Set column widths
-	colWidths := []float64{20, 12, 15, 15, 12, 12, 40}
-	for i, width := range colWidths {
-		col := columnName(i + 1)
-		f.SetColWidth(sheetAlerts, col, col, width)
-	}
-
-	// Write headers
-	for i, header := range headers {
-		cell := fmt.Sprintf("%s1", columnName(i+1))
-		f.SetCellValue(sheetAlerts, cell, header)
-		f.SetCellStyle(sheetAlerts, cell, cell, headerStyle)
+	if w.groupAlerts {
+		return w.writeGroupedAlerts(f, result.Alerts, headerStyle, warningStyle, criticalStyle)
 	}
-	f.SetRowHeight(sheetAlerts, 1, 25)
-
-	// Freeze header row
-	f.SetPanes(sheetAlerts, &excelize.Panes{
-		Freeze:      true,
-		Split:       false,
-		XSplit:      0,
-		YSplit:      1,
-		TopLeftCell: "A2",
-		ActivePane:  "bottomLeft",
-	})
 
 	// Sort alerts by level (critical first) then by hostname
 	alerts := make([]*model.Alert, len(result.Alerts))
@@ -405,36 +497,306 @@ func (w *Writer) createAlertsSheet(f *excelize.File, result *model.InspectionRes
 		return alerts[i].Hostname < alerts[j].Hostname
 	})
 
-	// Write alert data
-	for i, alert := range alerts {
-		row := i + 2
-		rowStr := fmt.Sprintf("%d", row)
+	return w.streamSheet(f, sheetAlerts, func(sw *excelize.StreamWriter) error {
+		if err := w.streamAlertsHeader(sw, headerStyle); err != nil {
+			return err
+		}
+		for i, alert := range alerts {
+			// Apply style based on alert level
+			var style int
+			if alert.Level == model.AlertLevelCritical {
+				style = criticalStyle
+			} else if alert.Level == model.AlertLevelWarning {
+				style = warningStyle
+			}
+			if err := w.streamRow(sw, i+2, w.alertCells(alert, style)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-		f.SetCellValue(sheetAlerts, "A"+rowStr, alert.Hostname)
-		f.SetCellValue(sheetAlerts, "B"+rowStr, alertLevelText(alert.Level))
-		f.SetCellValue(sheetAlerts, "C"+rowStr, alert.MetricDisplayName)
-		f.SetCellValue(sheetAlerts, "D"+rowStr, alert.FormattedValue)
-		f.SetCellValue(sheetAlerts, "E"+rowStr, formatThreshold(alert.WarningThreshold, alert.MetricName))
-		f.SetCellValue(sheetAlerts, "F"+rowStr, formatThreshold(alert.CriticalThreshold, alert.MetricName))
-		f.SetCellValue(sheetAlerts, "G"+rowStr, alert.Message)
+// streamAlertsHeader writes the header row of the host alerts sheet.
+func (w *Writer) streamAlertsHeader(sw *excelize.StreamWriter, headerStyle int) error {
+	headers := []string{"主机名", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息", "处理建议"}
+	colWidths := []float64{20, 12, 15, 15, 12, 12, 40, remediationColWidth}
 
-		// Apply style based on alert level
-		var style int
-		if alert.Level == model.AlertLevelCritical {
-			style = criticalStyle
-		} else if alert.Level == model.AlertLevelWarning {
-			style = warningStyle
+	cells := make([]excelize.Cell, len(headers))
+	for i, header := range headers {
+		cells[i] = excelize.Cell{StyleID: headerStyle, Value: header}
+	}
+	return w.streamHeader(sw, cells, colWidths)
+}
+
+// alertCells returns the cells of an alert row on the host alerts sheet; levelStyle highlights
+// the level cell.
+func (w *Writer) alertCells(alert *model.Alert, levelStyle int) []excelize.Cell {
+	return []excelize.Cell{
+		{Value: alert.Hostname},
+		{StyleID: levelStyle, Value: alertLevelText(alert.Level)},
+		{Value: alert.MetricDisplayName},
+		{Value: alert.FormattedValue},
+		{Value: formatThreshold(alert.WarningThreshold, alert.MetricName)},
+		{Value: formatThreshold(alert.CriticalThreshold, alert.MetricName)},
+		{Value: alert.Message},
+		{Value: w.remediation(alert.MetricName)},
+	}
+}
+
+// alertGroup holds the alerts of one host on the grouped alerts sheet.
+type alertGroup struct {
+	hostname string
+	alerts   []*model.Alert
+	critical int
+	warning  int
+}
+
+// groupAlertsByHost groups alerts by hostname. Hosts with the most critical, then warning
+// alerts come first; alerts within a group are sorted by level then metric name.
+func groupAlertsByHost(alerts []*model.Alert) []*alertGroup {
+	byHost := make(map[string]*alertGroup)
+	var groups []*alertGroup
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
 		}
-		if style > 0 {
-			f.SetCellStyle(sheetAlerts, "B"+rowStr, "B"+rowStr, style)
+		g, ok := byHost[alert.Hostname]
+		if !ok {
+			g = &alertGroup{hostname: alert.Hostname}
+			byHost[alert.Hostname] = g
+			groups = append(groups, g)
+		}
+		g.alerts = append(g.alerts, alert)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			g.critical++
+		case model.AlertLevelWarning:
+			g.warning++
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].critical != groups[j].critical {
+			return groups[i].critical > groups[j].critical
+		}
+		if groups[i].warning != groups[j].warning {
+			return groups[i].warning > groups[j].warning
+		}
+		return groups[i].hostname < groups[j].hostname
+	})
+	for _, g := range groups {
+		sort.SliceStable(g.alerts, func(i, j int) bool {
+			if g.alerts[i].Level != g.alerts[j].Level {
+				return alertLevelPriority(g.alerts[i].Level) > alertLevelPriority(g.alerts[j].Level)
+			}
+			return g.alerts[i].MetricDisplayName < g.alerts[j].MetricDisplayName
+		})
+	}
+
+	return groups
+}
+
+// writeGroupedAlerts writes the host alerts grouped by host. Each group starts with a subtotal
+// row (hostname, highest level, alert counts); its alert rows are outline level 1 so the group
+// can be collapsed in Excel.
+func (w *Writer) writeGroupedAlerts(f *excelize.File, alerts []*model.Alert, headerStyle, warningStyle, criticalStyle int) error {
+	subtotalStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Color:   []string{colorSubtotalBg},
+			Pattern: 1,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Subtotal rows are placed above their alert rows. Sheet properties are kept by the stream.
+	summaryBelow := false
+	if err := f.SetSheetProps(sheetAlerts, &excelize.SheetPropsOptions{OutlineSummaryBelow: &summaryBelow}); err != nil {
+		return err
+	}
+
+	levelStyle := func(level model.AlertLevel) int {
+		switch level {
+		case model.AlertLevelCritical:
+			return criticalStyle
+		case model.AlertLevelWarning:
+			return warningStyle
+		}
+		return 0
+	}
+
+	groups := groupAlertsByHost(alerts)
+	return w.streamSheet(f, sheetAlerts, func(sw *excelize.StreamWriter) error {
+		if err := w.streamAlertsHeader(sw, headerStyle); err != nil {
+			return err
+		}
+
+		row := 2
+		for _, g := range groups {
+			subtotal := make([]excelize.Cell, 8)
+			for i := range subtotal {
+				subtotal[i].StyleID = subtotalStyle
+			}
+			subtotal[0].Value = g.hostname
+			subtotal[1].Value = alertLevelText(g.alerts[0].Level)
+			if style := levelStyle(g.alerts[0].Level); style > 0 {
+				subtotal[1].StyleID = style
+			}
+			subtotal[2].Value = "小计"
+			subtotal[6].Value = fmt.Sprintf("共 %d 条告警（严重 %d，警告 %d）", len(g.alerts), g.critical, g.warning)
+			if err := w.streamRow(sw, row, subtotal); err != nil {
+				return err
+			}
+			row++
+
+			for _, alert := range g.alerts {
+				if err := w.streamRow(sw, row, w.alertCells(alert, levelStyle(alert.Level)), excelize.RowOpts{OutlineLevel: 1}); err != nil {
+					return err
+				}
+				row++
+			}
+		}
+		return nil
+	})
+}
+
+// createChartsSheet creates the charts worksheet: host status pie chart, alert level bar chart
+// and top disk usage bars. The chart data is written to the left of the charts.
+func (w *Writer) createChartsSheet(f *excelize.File, result *model.InspectionResult) error {
+	// Create sheet. Charts reference the sheet by name, so it is created with its localized name.
+	sheetCharts := w.tr.SheetName(sheetCharts)
+	_, err := f.NewSheet(sheetCharts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	f.SetColWidth(sheetCharts, "A", "A", wideColWidth)
+	f.SetColWidth(sheetCharts, "B", "B", defaultColWidth)
+
+	writeHeaders := func(row int, headers ...string) {
+		for i, header := range headers {
+			cell := fmt.Sprintf("%s%d", columnName(i+1), row)
+			f.SetCellValue(sheetCharts, cell, header)
+			f.SetCellStyle(sheetCharts, cell, cell, headerStyle)
+		}
+	}
+
+	// Host status distribution (rows 1-5)
+	summary := result.Summary
+	if summary == nil {
+		summary = model.NewInspectionSummary(result.Hosts)
+	}
+	writeHeaders(1, "主机状态", "主机数")
+	statusData := []struct {
+		label string
+		count int
+	}{
+		{"正常", summary.NormalHosts},
+		{"警告", summary.WarningHosts},
+		{"严重", summary.CriticalHosts},
+		{"采集失败", summary.FailedHosts},
+	}
+	for i, item := range statusData {
+		row := i + 2
+		f.SetCellValue(sheetCharts, fmt.Sprintf("A%d", row), item.label)
+		f.SetCellValue(sheetCharts, fmt.Sprintf("B%d", row), item.count)
+	}
+
+	// Alert level distribution (rows 8-10)
+	alertSummary := result.AlertSummary
+	if alertSummary == nil {
+		alertSummary = model.NewAlertSummary(result.Alerts)
+	}
+	writeHeaders(8, "告警级别", "告警数")
+	f.SetCellValue(sheetCharts, "A9", "严重")
+	f.SetCellValue(sheetCharts, "B9", alertSummary.CriticalCount)
+	f.SetCellValue(sheetCharts, "A10", "警告")
+	f.SetCellValue(sheetCharts, "B10", alertSummary.WarningCount)
+
+	// Top disk usage (rows 13+)
+	diskHosts := topDiskUsageHosts(result.Hosts, chartTopDiskHosts)
+	writeHeaders(13, "主机名", "磁盘最大使用率(%)")
+	for i, host := range diskHosts {
+		row := i + 14
+		f.SetCellValue(sheetCharts, fmt.Sprintf("A%d", row), host.Hostname)
+		f.SetCellValue(sheetCharts, fmt.Sprintf("B%d", row), host.Metrics["disk_usage_max"].RawValue)
+	}
+
+	sheetRef := "'" + sheetCharts + "'"
+	if err := f.AddChart(sheetCharts, "D1", &excelize.Chart{
+		Type: excelize.Pie,
+		Series: []excelize.ChartSeries{{
+			Name:       sheetRef + "!$B$1",
+			Categories: sheetRef + "!$A$2:$A$5",
+			Values:     sheetRef + "!$B$2:$B$5",
+		}},
+		Title:    []excelize.RichTextRun{{Text: w.tr.T("主机状态分布")}},
+		Legend:   excelize.ChartLegend{Position: "right"},
+		PlotArea: excelize.ChartPlotArea{ShowPercent: true},
+	}); err != nil {
+		return fmt.Errorf("failed to add host status chart: %w", err)
+	}
+
+	if err := f.AddChart(sheetCharts, "D17", &excelize.Chart{
+		Type: excelize.Col,
+		Series: []excelize.ChartSeries{{
+			Name:       sheetRef + "!$B$8",
+			Categories: sheetRef + "!$A$9:$A$10",
+			Values:     sheetRef + "!$B$9:$B$10",
+		}},
+		Title:    []excelize.RichTextRun{{Text: w.tr.T("告警级别分布")}},
+		Legend:   excelize.ChartLegend{Position: "none"},
+		PlotArea: excelize.ChartPlotArea{ShowVal: true},
+	}); err != nil {
+		return fmt.Errorf("failed to add alert level chart: %w", err)
+	}
+
+	if len(diskHosts) > 0 {
+		lastRow := 13 + len(diskHosts)
+		if err := f.AddChart(sheetCharts, "D33", &excelize.Chart{
+			Type: excelize.Bar,
+			Series: []excelize.ChartSeries{{
+				Name:       sheetRef + "!$B$13",
+				Categories: fmt.Sprintf("%s!$A$14:$A$%d", sheetRef, lastRow),
+				Values:     fmt.Sprintf("%s!$B$14:$B$%d", sheetRef, lastRow),
+			}},
+			Title:    []excelize.RichTextRun{{Text: w.tr.Text(fmt.Sprintf("磁盘使用率 Top %d", chartTopDiskHosts))}},
+			Legend:   excelize.ChartLegend{Position: "none"},
+			XAxis:    excelize.ChartAxis{ReverseOrder: true}, // Highest usage on top
+			PlotArea: excelize.ChartPlotArea{ShowVal: true},
+		}); err != nil {
+			return fmt.Errorf("failed to add disk usage chart: %w", err)
 		}
 	}
 
 	return nil
 }
 
+// topDiskUsageHosts returns up to n hosts with the highest max disk usage, highest first.
+// Hosts without a collected disk usage are skipped.
+func topDiskUsageHosts(hosts []*model.HostResult, n int) []*model.HostResult {
+	return topHostsByMetric(hosts, "disk_usage_max", n)
+}
+
 // Helper functions
 
+// headerBgColor returns the header background color, taken from the theme when configured.
+func (w *Writer) headerBgColor() string {
+	if w.theme.HasPrimaryColor() {
+		return theme.ExcelColor(w.theme.PrimaryColor)
+	}
+	return colorHeaderBg
+}
+
 func (w *Writer) createHeaderStyle(f *excelize.File) (int, error) {
 	return f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
@@ -444,7 +806,7 @@ func (w *Writer) createHeaderStyle(f *excelize.File) (int, error) {
 		},
 		Fill: excelize.Fill{
 			Type:    "pattern",
-			Color:   []string{colorHeaderBg},
+			Color:   []string{w.headerBgColor()},
 			Pattern: 1,
 		},
 		Alignment: &excelize.Alignment{
@@ -505,30 +867,45 @@ func (w *Writer) createNormalStyle(f *excelize.File) (int, error) {
 	})
 }
 
-func (w *Writer) setMetricCell(f *excelize.File, sheet, cell string, metric *model.MetricValue, warningStyle, criticalStyle, normalStyle int) {
+// createLogExcerptStyle creates a top-aligned, wrapped monospace style for log excerpt cells.
+func (w *Writer) createLogExcerptStyle(f *excelize.File) (int, error) {
+	return f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Family: "Consolas",
+			Size:   9,
+		},
+		Alignment: &excelize.Alignment{
+			Vertical: "top",
+			WrapText: true,
+		},
+	})
+}
+
+// formatLogExcerpt joins log excerpt lines into a single multi-line cell value.
+func formatLogExcerpt(lines []string) string {
+	return strings.Join(lines, "\n")
+}
+
+// metricCellValue returns the cell text of a metric: its formatted value, or "N/A".
+func metricCellValue(metric *model.MetricValue) string {
 	if metric == nil || metric.IsNA {
-		f.SetCellValue(sheet, cell, "N/A")
-		return
+		return "N/A"
 	}
+	return metric.FormattedValue
+}
 
-	f.SetCellValue(sheet, cell, metric.FormattedValue)
-
-	// Apply style based on metric status
-	var style int
+// metricCellStyle returns the highlight style of a metric status (0 for normal and N/A metrics).
+func metricCellStyle(metric *model.MetricValue, warningStyle, criticalStyle int) int {
+	if metric == nil || metric.IsNA {
+		return 0
+	}
 	switch metric.Status {
 	case model.MetricStatusCritical:
-		style = criticalStyle
+		return criticalStyle
 	case model.MetricStatusWarning:
-		style = warningStyle
-	case model.MetricStatusNormal:
-		// Only apply normal style if styles are provided
-		if normalStyle > 0 && warningStyle > 0 && criticalStyle > 0 {
-			// Don't apply normal style to avoid visual clutter
-		}
-	}
-	if style > 0 {
-		f.SetCellStyle(sheet, cell, cell, style)
+		return warningStyle
 	}
+	return 0
 }
 
 func (w *Writer) collectDiskPaths(hosts []*model.HostResult) []string {
@@ -633,7 +1010,7 @@ func alertLevelPriority(level model.AlertLevel) int {
 // formatThreshold formats a threshold value based on metric type.
 func formatThreshold(value float64, metricName string) string {
 	switch metricName {
-	case "cpu_usage", "memory_usage", "disk_usage_max":
+	case "cpu_usage", "memory_usage", "disk_usage_max", "memory_available_percent":
 		return fmt.Sprintf("%.1f%%", value)
 	case "load_per_core":
 		return fmt.Sprintf("%.2f", value)
@@ -678,26 +1055,22 @@ func mysqlClusterModeText(mode model.MySQLClusterMode) string {
 	}
 }
 
-// boolToText converts boolean to Chinese text (启用/禁用).
-func boolToText(b bool) string {
-	if b {
-		return "启用"
-	}
-	return "禁用"
-}
-
 // getMySQLSyncStatus returns sync status text based on cluster mode.
 func (w *Writer) getMySQLSyncStatus(r *model.MySQLInspectionResult) string {
 	if r.Instance.ClusterMode.IsMGR() {
-		if r.MGRStateOnline {
-			return "在线"
-		}
-		return "离线"
+		return model.FormatBool("mgr_state_online", r.MGRStateOnline)
 	}
-	if r.SyncStatus {
-		return "正常"
+	return model.FormatBool("sync_status", r.SyncStatus)
+}
+
+// expectedThresholdTexts returns the warning and critical threshold texts of an
+// expected-value alert: the expectation under the alert's level, "-" under the other.
+func expectedThresholdTexts(expected string, level model.AlertLevel) (string, string) {
+	text := "期望: " + expected
+	if level == model.AlertLevelCritical {
+		return "-", text
 	}
-	return "异常"
+	return text, "-"
 }
 
 // formatMySQLThreshold formats a MySQL alert threshold value based on metric type.
@@ -705,13 +1078,14 @@ func formatMySQLThreshold(value float64, metricName string) string {
 	switch metricName {
 	case "connection_usage":
 		return fmt.Sprintf("%.1f%%", value)
-	case "mgr_member_count":
+	case "mgr_member_count", "threads_running", "innodb_deadlocks", "innodb_history_list_length":
 		return fmt.Sprintf("%.0f", value)
+	case "slow_queries", "innodb_row_lock_waits":
+		return fmt.Sprintf("%.2f/s", value)
+	case "innodb_buffer_pool_hit_ratio":
+		return fmt.Sprintf("%.2f%%", value)
 	case "mgr_state_online":
-		if value > 0 {
-			return "在线"
-		}
-		return "离线"
+		return model.FormatBool("mgr_state_online", value > 0)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -741,6 +1115,14 @@ func (w *Writer) WriteMySQLInspection(result *model.MySQLInspectionResults, outp
 		return fmt.Errorf("failed to create MySQL sheet: %w", err)
 	}
 
+	// Create MySQL MGR member sheet
+	if err := w.createMySQLMGRMembersSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create MySQL MGR member sheet: %w", err)
+	}
+	if err := w.createMySQLTopologySheet(f, result); err != nil {
+		return fmt.Errorf("failed to create MySQL topology sheet: %w", err)
+	}
+
 	// Create MySQL alerts sheet
 	if err := w.createMySQLAlertsSheet(f, result); err != nil {
 		return fmt.Errorf("failed to create MySQL alerts sheet: %w", err)
@@ -756,7 +1138,7 @@ func (w *Writer) WriteMySQLInspection(result *model.MySQLInspectionResults, outp
 	f.SetActiveSheet(idx)
 
 	// Save the file
-	if err := f.SaveAs(outputPath); err != nil {
+	if err := w.saveAs(f, outputPath); err != nil {
 		return fmt.Errorf("failed to save Excel file: %w", err)
 	}
 
@@ -792,85 +1174,101 @@ func (w *Writer) createMySQLSheet(f *excelize.File, result *model.MySQLInspectio
 		return err
 	}
 
-	// Define headers
-	headers := []string{
-		"巡检时间", "IP地址", "端口", "数据库版本", "Server ID",
-		"集群模式", "同步状态", "最大连接数", "当前连接数", "Binlog状态", "整体状态",
+	columns := selectColumns(w.mysqlColumns(result.InspectionTime), w.columns[ModuleMySQL])
+	return writeSheetColumns(w, f, sheetMySQL, columns, result.Results, headerStyle, warningStyle, criticalStyle, normalStyle)
+}
+
+// mysqlMGRRoleText converts an MGR member role to Chinese text.
+func mysqlMGRRoleText(role model.MySQLMGRRole) string {
+	switch role {
+	case model.MGRRolePrimary:
+		return "主节点"
+	case model.MGRRoleSecondary:
+		return "从节点"
+	default:
+		return "未知"
 	}
+}
 
-	// Set column widths
-	colWidths := map[string]float64{
-		"A": 20, // 巡检时间
-		"B": 15, // IP地址
-		"C": 8,  // 端口
-		"D": 12, // 数据库版本
-		"E": 12, // Server ID
-		"F": 12, // 集群模式
-		"G": 10, // 同步状态
-		"H": 12, // 最大连接数
-		"I": 12, // 当前连接数
-		"J": 12, // Binlog状态
-		"K": 10, // 整体状态
+// createMySQLMGRMembersSheet creates the MGR group member worksheet,
+// one row per member grouped by MGR group.
+func (w *Writer) createMySQLMGRMembersSheet(f *excelize.File, result *model.MySQLInspectionResults) error {
+	clusters := result.MGRClusters()
+	if len(clusters) == 0 {
+		return nil
 	}
-	for col, width := range colWidths {
-		f.SetColWidth(sheetMySQL, col, col, width)
+
+	// Create sheet
+	_, err := f.NewSheet(sheetMySQLMGRMembers)
+	if err != nil {
+		return err
 	}
 
-	// Write headers
-	for i, header := range headers {
-		cell := fmt.Sprintf("%s1", columnName(i+1))
-		f.SetCellValue(sheetMySQL, cell, header)
-		f.SetCellStyle(sheetMySQL, cell, cell, headerStyle)
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
 	}
-	f.SetRowHeight(sheetMySQL, 1, 25)
 
-	// Freeze header row
-	f.SetPanes(sheetMySQL, &excelize.Panes{
-		Freeze:      true,
-		Split:       false,
-		XSplit:      0,
-		YSplit:      1,
-		TopLeftCell: "A2",
-		ActivePane:  "bottomLeft",
-	})
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
 
-	// Write MySQL instance data
-	for i, r := range result.Results {
-		row := i + 2 // Start from row 2
-		rowStr := fmt.Sprintf("%d", row)
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
 
-		// A: 巡检时间
-		f.SetCellValue(sheetMySQL, "A"+rowStr, result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"))
-		// B: IP地址
-		f.SetCellValue(sheetMySQL, "B"+rowStr, r.Instance.IP)
-		// C: 端口
-		f.SetCellValue(sheetMySQL, "C"+rowStr, r.Instance.Port)
-		// D: 数据库版本
-		f.SetCellValue(sheetMySQL, "D"+rowStr, r.Instance.Version)
-		// E: Server ID
-		f.SetCellValue(sheetMySQL, "E"+rowStr, r.Instance.ServerID)
-		// F: 集群模式
-		f.SetCellValue(sheetMySQL, "F"+rowStr, mysqlClusterModeText(r.Instance.ClusterMode))
-		// G: 同步状态
-		f.SetCellValue(sheetMySQL, "G"+rowStr, w.getMySQLSyncStatus(r))
-		// H: 最大连接数
-		f.SetCellValue(sheetMySQL, "H"+rowStr, r.MaxConnections)
-		// I: 当前连接数
-		f.SetCellValue(sheetMySQL, "I"+rowStr, r.CurrentConnections)
-		// J: Binlog状态
-		f.SetCellValue(sheetMySQL, "J"+rowStr, boolToText(r.BinlogEnabled))
-		// K: 整体状态
-		f.SetCellValue(sheetMySQL, "K"+rowStr, mysqlStatusText(r.Status))
+	headers := []string{
+		"MGR 组", "成员地址", "成员 ID", "角色", "成员状态", "队列事务数",
+	}
 
-		// Apply conditional format to status column
-		statusCell := "K" + rowStr
-		switch r.Status {
-		case model.MySQLStatusCritical:
-			f.SetCellStyle(sheetMySQL, statusCell, statusCell, criticalStyle)
-		case model.MySQLStatusWarning:
-			f.SetCellStyle(sheetMySQL, statusCell, statusCell, warningStyle)
-		case model.MySQLStatusNormal:
-			f.SetCellStyle(sheetMySQL, statusCell, statusCell, normalStyle)
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 38, "B": 22, "C": 38, "D": 10, "E": 14, "F": 12,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetMySQLMGRMembers, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetMySQLMGRMembers, cell, header)
+		f.SetCellStyle(sheetMySQLMGRMembers, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetMySQLMGRMembers, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write member rows
+	row := 2
+	for _, cluster := range clusters {
+		for _, m := range cluster.Members {
+			rowStr := fmt.Sprint(row)
+			f.SetCellValue(sheetMySQLMGRMembers, "A"+rowStr, cluster.Name)
+			f.SetCellValue(sheetMySQLMGRMembers, "B"+rowStr, m.Address())
+			f.SetCellValue(sheetMySQLMGRMembers, "C"+rowStr, m.MemberID)
+			f.SetCellValue(sheetMySQLMGRMembers, "D"+rowStr, mysqlMGRRoleText(m.Role))
+			if m.HasTransactionsQueued() {
+				f.SetCellValue(sheetMySQLMGRMembers, "F"+rowStr, m.TransactionsQueued)
+			} else {
+				f.SetCellValue(sheetMySQLMGRMembers, "F"+rowStr, "N/A")
+			}
+
+			// State column: RECOVERING is transient, any other non-ONLINE state is critical
+			stateCell := "E" + rowStr
+			state := m.State
+			if state == "" {
+				state = "N/A"
+			}
+			f.SetCellValue(sheetMySQLMGRMembers, stateCell, state)
+			switch {
+			case m.State == model.MGRMemberStateRecovering:
+				f.SetCellStyle(sheetMySQLMGRMembers, stateCell, stateCell, warningStyle)
+			case m.State != "" && !m.IsOnline():
+				f.SetCellStyle(sheetMySQLMGRMembers, stateCell, stateCell, criticalStyle)
+			}
+			row++
 		}
 	}
 
@@ -902,10 +1300,10 @@ func (w *Writer) createMySQLAlertsSheet(f *excelize.File, result *model.MySQLIns
 	}
 
 	// Define headers
-	headers := []string{"实例地址", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息"}
+	headers := []string{"实例地址", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息", "处理建议"}
 
 	// Set column widths
-	colWidths := []float64{20, 12, 15, 15, 12, 12, 40}
+	colWidths := []float64{20, 12, 15, 15, 12, 12, 40, remediationColWidth}
 	for i, width := range colWidths {
 		col := columnName(i + 1)
 		f.SetColWidth(sheetMySQLAlerts, col, col, width)
@@ -948,9 +1346,15 @@ func (w *Writer) createMySQLAlertsSheet(f *excelize.File, result *model.MySQLIns
 		f.SetCellValue(sheetMySQLAlerts, "B"+rowStr, alertLevelText(alert.Level))
 		f.SetCellValue(sheetMySQLAlerts, "C"+rowStr, alert.MetricDisplayName)
 		f.SetCellValue(sheetMySQLAlerts, "D"+rowStr, alert.FormattedValue)
-		f.SetCellValue(sheetMySQLAlerts, "E"+rowStr, formatMySQLThreshold(alert.WarningThreshold, alert.MetricName))
-		f.SetCellValue(sheetMySQLAlerts, "F"+rowStr, formatMySQLThreshold(alert.CriticalThreshold, alert.MetricName))
+		warningText := formatMySQLThreshold(alert.WarningThreshold, alert.MetricName)
+		criticalText := formatMySQLThreshold(alert.CriticalThreshold, alert.MetricName)
+		if alert.ExpectedValue != "" {
+			warningText, criticalText = expectedThresholdTexts(alert.ExpectedValue, alert.Level)
+		}
+		f.SetCellValue(sheetMySQLAlerts, "E"+rowStr, warningText)
+		f.SetCellValue(sheetMySQLAlerts, "F"+rowStr, criticalText)
 		f.SetCellValue(sheetMySQLAlerts, "G"+rowStr, alert.Message)
+		f.SetCellValue(sheetMySQLAlerts, "H"+rowStr, w.remediation(alert.MetricName))
 
 		// Apply style based on alert level
 		var style int
@@ -980,7 +1384,7 @@ func (w *Writer) AppendMySQLInspection(result *model.MySQLInspectionResults, exi
 	}
 
 	// Open existing Excel file
-	f, err := excelize.OpenFile(existingPath)
+	f, err := w.openFile(existingPath)
 	if err != nil {
 		return fmt.Errorf("failed to open existing file: %w", err)
 	}
@@ -991,13 +1395,21 @@ func (w *Writer) AppendMySQLInspection(result *model.MySQLInspectionResults, exi
 		return fmt.Errorf("failed to create MySQL sheet: %w", err)
 	}
 
+	// Add MySQL MGR member worksheet (reuse existing method)
+	if err := w.createMySQLMGRMembersSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create MySQL MGR member sheet: %w", err)
+	}
+	if err := w.createMySQLTopologySheet(f, result); err != nil {
+		return fmt.Errorf("failed to create MySQL topology sheet: %w", err)
+	}
+
 	// Add MySQL alerts worksheet (reuse existing method)
 	if err := w.createMySQLAlertsSheet(f, result); err != nil {
 		return fmt.Errorf("failed to create MySQL alerts sheet: %w", err)
 	}
 
 	// Save the file
-	if err := f.Save(); err != nil {
+	if err := w.save(f); err != nil {
 		return fmt.Errorf("failed to save file: %w", err)
 	}
 
@@ -1036,14 +1448,6 @@ func redisRoleText(role model.RedisRole) string {
 	}
 }
 
-// redisBoolText converts boolean to Chinese text for display (是/否).
-func redisBoolText(b bool) string {
-	if b {
-		return "是"
-	}
-	return "否"
-}
-
 // formatReplicationLag formats replication lag in bytes to human-readable format.
 func formatReplicationLag(lag int64) string {
 	if lag <= 0 {
@@ -1069,15 +1473,14 @@ func formatReplicationLag(lag int64) string {
 // formatRedisThreshold formats a Redis alert threshold value based on metric type.
 func formatRedisThreshold(value float64, metricName string) string {
 	switch metricName {
-	case "connection_usage":
+	case "connection_usage", "memory_usage", "keyspace_hit_ratio":
 		return fmt.Sprintf("%.1f%%", value)
+	case "evicted_keys":
+		return fmt.Sprintf("%.2f/s", value)
 	case "replication_lag":
 		return formatReplicationLag(int64(value))
 	case "master_link_status":
-		if value > 0 {
-			return "正常"
-		}
-		return "断开"
+		return model.FormatBool("redis_master_link_status", value > 0)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -1088,7 +1491,7 @@ func (w *Writer) getMasterLinkStatusText(r *model.RedisInspectionResult) string
 	if r.Instance == nil || r.Instance.Role.IsMaster() {
 		return "N/A"
 	}
-	return redisBoolText(r.MasterLinkStatus)
+	return model.FormatBool("redis_master_link_status", r.MasterLinkStatus)
 }
 
 // getMasterPortText returns master port text (N/A for master nodes).
@@ -1102,6 +1505,22 @@ func (w *Writer) getMasterPortText(r *model.RedisInspectionResult) string {
 	return fmt.Sprintf("%d", r.MasterPort)
 }
 
+// getMemoryUsageText returns memory usage text (N/A when maxmemory is unlimited).
+func (w *Writer) getMemoryUsageText(r *model.RedisInspectionResult) string {
+	if r.MaxMemory == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", r.GetMemoryUsagePercent())
+}
+
+// getKeyspaceHitRatioText returns keyspace hit ratio text (N/A when not calculated).
+func (w *Writer) getKeyspaceHitRatioText(r *model.RedisInspectionResult) string {
+	if !r.HasKeyspaceHitRatio() {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", r.KeyspaceHitRatio)
+}
+
 // getReplicationLagText returns replication lag text (N/A for master nodes).
 func (w *Writer) getReplicationLagText(r *model.RedisInspectionResult) string {
 	if r.Instance == nil || r.Instance.Role.IsMaster() {
@@ -1149,7 +1568,7 @@ func (w *Writer) WriteRedisInspection(result *model.RedisInspectionResults, outp
 	f.SetActiveSheet(idx)
 
 	// Save the file
-	if err := f.SaveAs(outputPath); err != nil {
+	if err := w.saveAs(f, outputPath); err != nil {
 		return fmt.Errorf("failed to save Excel file: %w", err)
 	}
 
@@ -1185,111 +1604,8 @@ func (w *Writer) createRedisSheet(f *excelize.File, result *model.RedisInspectio
 		return err
 	}
 
-	// Define headers
-	headers := []string{
-		"巡检时间", "IP地址", "端口", "应用类型", "Redis版本",
-		"是否普通用户启动", "连接状态", "集群模式", "主从链接状态",
-		"节点角色", "Master端口", "复制延迟", "最大连接数", "整体状态",
-	}
-
-	// Set column widths
-	colWidths := map[string]float64{
-		"A": 18, // 巡检时间
-		"B": 15, // IP地址
-		"C": 8,  // 端口
-		"D": 8,  // 应用类型
-		"E": 12, // Redis版本
-		"F": 15, // 是否普通用户启动
-		"G": 10, // 连接状态
-		"H": 10, // 集群模式
-		"I": 12, // 主从链接状态
-		"J": 10, // 节点角色
-		"K": 10, // Master端口
-		"L": 12, // 复制延迟
-		"M": 10, // 最大连接数
-		"N": 10, // 整体状态
-	}
-	for col, width := range colWidths {
-		f.SetColWidth(sheetRedis, col, col, width)
-	}
-
-	// Write headers
-	for i, header := range headers {
-		cell := fmt.Sprintf("%s1", columnName(i+1))
-		f.SetCellValue(sheetRedis, cell, header)
-		f.SetCellStyle(sheetRedis, cell, cell, headerStyle)
-	}
-	f.SetRowHeight(sheetRedis, 1, 25)
-
-	// Freeze header row
-	f.SetPanes(sheetRedis, &excelize.Panes{
-		Freeze:      true,
-		Split:       false,
-		XSplit:      0,
-		YSplit:      1,
-		TopLeftCell: "A2",
-		ActivePane:  "bottomLeft",
-	})
-
-	// Write Redis instance data
-	for i, r := range result.Results {
-		row := i + 2 // Start from row 2
-		rowStr := fmt.Sprintf("%d", row)
-
-		// A: 巡检时间
-		f.SetCellValue(sheetRedis, "A"+rowStr, result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"))
-		// B: IP地址
-		if r.Instance != nil {
-			f.SetCellValue(sheetRedis, "B"+rowStr, r.Instance.IP)
-		}
-		// C: 端口
-		if r.Instance != nil {
-			f.SetCellValue(sheetRedis, "C"+rowStr, r.Instance.Port)
-		}
-		// D: 应用类型
-		f.SetCellValue(sheetRedis, "D"+rowStr, "Redis")
-		// E: Redis版本
-		if r.Instance != nil && r.Instance.Version != "" {
-			f.SetCellValue(sheetRedis, "E"+rowStr, r.Instance.Version)
-		} else {
-			f.SetCellValue(sheetRedis, "E"+rowStr, "N/A")
-		}
-		// F: 是否普通用户启动
-		f.SetCellValue(sheetRedis, "F"+rowStr, r.NonRootUser)
-		// G: 连接状态
-		f.SetCellValue(sheetRedis, "G"+rowStr, redisBoolText(r.ConnectionStatus))
-		// H: 集群模式
-		f.SetCellValue(sheetRedis, "H"+rowStr, redisBoolText(r.ClusterEnabled))
-		// I: 主从链接状态
-		f.SetCellValue(sheetRedis, "I"+rowStr, w.getMasterLinkStatusText(r))
-		// J: 节点角色
-		if r.Instance != nil {
-			f.SetCellValue(sheetRedis, "J"+rowStr, redisRoleText(r.Instance.Role))
-		} else {
-			f.SetCellValue(sheetRedis, "J"+rowStr, "未知")
-		}
-		// K: Master端口
-		f.SetCellValue(sheetRedis, "K"+rowStr, w.getMasterPortText(r))
-		// L: 复制延迟
-		f.SetCellValue(sheetRedis, "L"+rowStr, w.getReplicationLagText(r))
-		// M: 最大连接数
-		f.SetCellValue(sheetRedis, "M"+rowStr, r.MaxClients)
-		// N: 整体状态
-		f.SetCellValue(sheetRedis, "N"+rowStr, redisStatusText(r.Status))
-
-		// Apply conditional format to status column
-		statusCell := "N" + rowStr
-		switch r.Status {
-		case model.RedisStatusCritical:
-			f.SetCellStyle(sheetRedis, statusCell, statusCell, criticalStyle)
-		case model.RedisStatusWarning:
-			f.SetCellStyle(sheetRedis, statusCell, statusCell, warningStyle)
-		case model.RedisStatusNormal:
-			f.SetCellStyle(sheetRedis, statusCell, statusCell, normalStyle)
-		}
-	}
-
-	return nil
+	columns := selectColumns(w.redisColumns(result.InspectionTime), w.columns[ModuleRedis])
+	return writeSheetColumns(w, f, sheetRedis, columns, result.Results, headerStyle, warningStyle, criticalStyle, normalStyle)
 }
 
 // createRedisAlertsSheet creates the Redis alerts summary worksheet.
@@ -1317,10 +1633,10 @@ func (w *Writer) createRedisAlertsSheet(f *excelize.File, result *model.RedisIns
 	}
 
 	// Define headers
-	headers := []string{"实例地址", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息"}
+	headers := []string{"实例地址", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息", "处理建议"}
 
 	// Set column widths
-	colWidths := []float64{20, 12, 15, 15, 12, 12, 40}
+	colWidths := []float64{20, 12, 15, 15, 12, 12, 40, remediationColWidth}
 	for i, width := range colWidths {
 		col := columnName(i + 1)
 		f.SetColWidth(sheetRedisAlerts, col, col, width)
@@ -1363,9 +1679,15 @@ func (w *Writer) createRedisAlertsSheet(f *excelize.File, result *model.RedisIns
 		f.SetCellValue(sheetRedisAlerts, "B"+rowStr, alertLevelText(alert.Level))
 		f.SetCellValue(sheetRedisAlerts, "C"+rowStr, alert.MetricDisplayName)
 		f.SetCellValue(sheetRedisAlerts, "D"+rowStr, alert.FormattedValue)
-		f.SetCellValue(sheetRedisAlerts, "E"+rowStr, formatRedisThreshold(alert.WarningThreshold, alert.MetricName))
-		f.SetCellValue(sheetRedisAlerts, "F"+rowStr, formatRedisThreshold(alert.CriticalThreshold, alert.MetricName))
+		warningText := formatRedisThreshold(alert.WarningThreshold, alert.MetricName)
+		criticalText := formatRedisThreshold(alert.CriticalThreshold, alert.MetricName)
+		if alert.ExpectedValue != "" {
+			warningText, criticalText = expectedThresholdTexts(alert.ExpectedValue, alert.Level)
+		}
+		f.SetCellValue(sheetRedisAlerts, "E"+rowStr, warningText)
+		f.SetCellValue(sheetRedisAlerts, "F"+rowStr, criticalText)
 		f.SetCellValue(sheetRedisAlerts, "G"+rowStr, alert.Message)
+		f.SetCellValue(sheetRedisAlerts, "H"+rowStr, w.remediation(alert.MetricName))
 
 		// Apply style based on alert level
 		var style int
@@ -1396,7 +1718,7 @@ func (w *Writer) AppendRedisInspection(result *model.RedisInspectionResults, exi
 	}
 
 	// Open existing Excel file
-	f, err := excelize.OpenFile(existingPath)
+	f, err := w.openFile(existingPath)
 	if err != nil {
 		return fmt.Errorf("failed to open existing file: %w", err)
 	}
@@ -1425,7 +1747,7 @@ func (w *Writer) AppendRedisInspection(result *model.RedisInspectionResults, exi
 	}
 
 	// Save the file
-	if err := f.Save(); err != nil {
+	if err := w.save(f); err != nil {
 		return fmt.Errorf("failed to save file: %w", err)
 	}
 
@@ -1469,106 +1791,15 @@ func (w *Writer) createRedisClusterSheet(f *excelize.File, cluster *model.RedisC
 		return err
 	}
 
-	// Define headers (same as createRedisSheet)
-	headers := []string{
-		"巡检时间", "IP地址", "端口", "应用类型", "Redis版本",
-		"是否普通用户启动", "连接状态", "集群模式", "主从链接状态",
-		"节点角色", "Master端口", "复制延迟", "最大连接数", "整体状态",
-	}
-
-	// Set column widths
-	colWidths := map[string]float64{
-		"A": 18, "B": 15, "C": 8, "D": 8, "E": 12,
-		"F": 15, "G": 10, "H": 10, "I": 12,
-		"J": 10, "K": 10, "L": 12, "M": 10, "N": 10,
-	}
-	for col, width := range colWidths {
-		f.SetColWidth(sheetName, col, col, width)
-	}
-
-	// Write headers
-	for i, header := range headers {
-		cell := fmt.Sprintf("%s1", columnName(i+1))
-		f.SetCellValue(sheetName, cell, header)
-		f.SetCellStyle(sheetName, cell, cell, headerStyle)
-	}
-	f.SetRowHeight(sheetName, 1, 25)
-
-	// Freeze header row
-	f.SetPanes(sheetName, &excelize.Panes{
-		Freeze:      true,
-		Split:       false,
-		XSplit:      0,
-		YSplit:      1,
-		TopLeftCell: "A2",
-		ActivePane:  "bottomLeft",
-	})
-
-	// Write Redis instance data for this cluster
-	for i, r := range cluster.Instances {
-		row := i + 2
-		rowStr := fmt.Sprintf("%d", row)
-
-		// A: 巡检时间
-		f.SetCellValue(sheetName, "A"+rowStr, inspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"))
-		// B: IP地址
-		if r.Instance != nil {
-			f.SetCellValue(sheetName, "B"+rowStr, r.Instance.IP)
-		}
-		// C: 端口
-		if r.Instance != nil {
-			f.SetCellValue(sheetName, "C"+rowStr, r.Instance.Port)
-		}
-		// D: 应用类型
-		f.SetCellValue(sheetName, "D"+rowStr, "Redis")
-		// E: Redis版本
-		if r.Instance != nil && r.Instance.Version != "" {
-			f.SetCellValue(sheetName, "E"+rowStr, r.Instance.Version)
-		} else {
-			f.SetCellValue(sheetName, "E"+rowStr, "N/A")
-		}
-		// F: 是否普通用户启动
-		f.SetCellValue(sheetName, "F"+rowStr, r.NonRootUser)
-		// G: 连接状态
-		f.SetCellValue(sheetName, "G"+rowStr, redisBoolText(r.ConnectionStatus))
-		// H: 集群模式
-		f.SetCellValue(sheetName, "H"+rowStr, redisBoolText(r.ClusterEnabled))
-		// I: 主从链接状态
-		f.SetCellValue(sheetName, "I"+rowStr, w.getMasterLinkStatusText(r))
-		// J: 节点角色
-		if r.Instance != nil {
-			f.SetCellValue(sheetName, "J"+rowStr, redisRoleText(r.Instance.Role))
-		} else {
-			f.SetCellValue(sheetName, "J"+rowStr, "未知")
-		}
-		// K: Master端口
-		f.SetCellValue(sheetName, "K"+rowStr, w.getMasterPortText(r))
-		// L: 复制延迟
-		f.SetCellValue(sheetName, "L"+rowStr, w.getReplicationLagText(r))
-		// M: 最大连接数
-		f.SetCellValue(sheetName, "M"+rowStr, r.MaxClients)
-		// N: 整体状态
-		f.SetCellValue(sheetName, "N"+rowStr, redisStatusText(r.Status))
-
-		// Apply conditional format to status column
-		statusCell := "N" + rowStr
-		switch r.Status {
-		case model.RedisStatusCritical:
-			f.SetCellStyle(sheetName, statusCell, statusCell, criticalStyle)
-		case model.RedisStatusWarning:
-			f.SetCellStyle(sheetName, statusCell, statusCell, warningStyle)
-		case model.RedisStatusNormal:
-			f.SetCellStyle(sheetName, statusCell, statusCell, normalStyle)
-		}
-	}
-
-	return nil
+	// Same columns as createRedisSheet
+	columns := selectColumns(w.redisColumns(inspectionTime), w.columns[ModuleRedis])
+	return writeSheetColumns(w, f, sheetName, columns, cluster.Instances, headerStyle, warningStyle, criticalStyle, normalStyle)
 }
 
-// WriteCombined generates an Excel report combining Host, MySQL, Redis, Nginx, and Tomcat inspection results.
-func (w *Writer) WriteCombined(hostResult *model.InspectionResult, mysqlResult *model.MySQLInspectionResults, redisResult *model.RedisInspectionResults, nginxResult *model.NginxInspectionResults, tomcatResult *model.TomcatInspectionResults, outputPath string) error {
+// WriteCombined generates an Excel report combining Host, MySQL, Redis, Nginx, Tomcat, Cassandra, monitoring stack, shared storage, log checks, LVS, Windows, AD, cloud resource, and firewall inspection results.
+func (w *Writer) WriteCombined(report *json.Report, outputPath string) error {
 	// At least one result must be present
-	if hostResult == nil && mysqlResult == nil && redisResult == nil && nginxResult == nil && tomcatResult == nil {
+	if report.Empty() {
 		return fmt.Errorf("all inspection results are nil")
 	}
 
@@ -1582,81 +1813,261 @@ func (w *Writer) WriteCombined(hostResult *model.InspectionResult, mysqlResult *
 	defer f.Close()
 
 	// Create Host sheets if available
-	if hostResult != nil {
-		if err := w.createSummarySheet(f, hostResult); err != nil {
+	if report.Host != nil {
+		if err := w.createSummarySheet(f, report.Host); err != nil {
 			return fmt.Errorf("failed to create summary sheet: %w", err)
 		}
-		if err := w.createDetailSheet(f, hostResult); err != nil {
+		if err := w.createDetailSheet(f, report.Host); err != nil {
 			return fmt.Errorf("failed to create detail sheet: %w", err)
 		}
-		if err := w.createAlertsSheet(f, hostResult); err != nil {
+		if err := w.createAlertsSheet(f, report.Host); err != nil {
 			return fmt.Errorf("failed to create alerts sheet: %w", err)
 		}
+		if err := w.createActiveAlertsSheet(f, report.Host); err != nil {
+			return fmt.Errorf("failed to create active alerts sheet: %w", err)
+		}
+		if err := w.createChartsSheet(f, report.Host); err != nil {
+			return fmt.Errorf("failed to create charts sheet: %w", err)
+		}
+		if err := w.createTrendSheet(f, report.Host); err != nil {
+			return fmt.Errorf("failed to create trend sheet: %w", err)
+		}
+		if err := w.createSourceConflictsSheet(f, report.Host); err != nil {
+			return fmt.Errorf("failed to create source conflicts sheet: %w", err)
+		}
+		if err := w.createBusinessGroupsSheet(f, report.Host); err != nil {
+			return fmt.Errorf("failed to create business groups sheet: %w", err)
+		}
+		if err := w.createHostGroupSheets(f, report.Host); err != nil {
+			return fmt.Errorf("failed to create host group sheets: %w", err)
+		}
+		if err := w.createCapacitySheet(f, report.Host); err != nil {
+			return fmt.Errorf("failed to create capacity ranking sheet: %w", err)
+		}
 	}
 
 	// Create MySQL sheets if available
-	if mysqlResult != nil {
-		if err := w.createMySQLSheet(f, mysqlResult); err != nil {
+	if report.MySQL != nil {
+		if err := w.createMySQLSheet(f, report.MySQL); err != nil {
 			return fmt.Errorf("failed to create MySQL sheet: %w", err)
 		}
-		if err := w.createMySQLAlertsSheet(f, mysqlResult); err != nil {
+		if err := w.createMySQLMGRMembersSheet(f, report.MySQL); err != nil {
+			return fmt.Errorf("failed to create MySQL MGR member sheet: %w", err)
+		}
+		if err := w.createMySQLTopologySheet(f, report.MySQL); err != nil {
+			return fmt.Errorf("failed to create MySQL topology sheet: %w", err)
+		}
+		if err := w.createMySQLAlertsSheet(f, report.MySQL); err != nil {
 			return fmt.Errorf("failed to create MySQL alerts sheet: %w", err)
 		}
 	}
 
 	// Create Redis sheets if available
-	if redisResult != nil {
-		if err := w.createRedisSheet(f, redisResult); err != nil {
+	if report.Redis != nil {
+		if err := w.createRedisSheet(f, report.Redis); err != nil {
 			return fmt.Errorf("failed to create Redis sheet: %w", err)
 		}
-		if err := w.createRedisAlertsSheet(f, redisResult); err != nil {
+		if err := w.createRedisAlertsSheet(f, report.Redis); err != nil {
 			return fmt.Errorf("failed to create Redis alerts sheet: %w", err)
 		}
 	}
 
 	// Create Nginx sheets if available
-	if nginxResult != nil {
-		if err := w.createNginxSheet(f, nginxResult); err != nil {
+	if report.Nginx != nil {
+		if err := w.createNginxSheet(f, report.Nginx); err != nil {
 			return fmt.Errorf("failed to create Nginx sheet: %w", err)
 		}
-		if err := w.createNginxAlertsSheet(f, nginxResult); err != nil {
+		if err := w.createNginxUpstreamSheet(f, report.Nginx); err != nil {
+			return fmt.Errorf("failed to create Nginx upstream sheet: %w", err)
+		}
+		if err := w.createNginxAlertsSheet(f, report.Nginx); err != nil {
 			return fmt.Errorf("failed to create Nginx alerts sheet: %w", err)
 		}
 	}
 
 	// Create Tomcat sheets if available
-	if tomcatResult != nil {
-		if err := w.createTomcatSheet(f, tomcatResult); err != nil {
+	if report.Tomcat != nil {
+		if err := w.createTomcatSheet(f, report.Tomcat); err != nil {
 			return fmt.Errorf("failed to create Tomcat sheet: %w", err)
 		}
-		if err := w.createTomcatAlertsSheet(f, tomcatResult); err != nil {
+		if err := w.createTomcatAlertsSheet(f, report.Tomcat); err != nil {
 			return fmt.Errorf("failed to create Tomcat alerts sheet: %w", err)
 		}
 	}
 
+	// Create Cassandra sheets if available
+	if report.Cassandra != nil {
+		if err := w.createCassandraSheet(f, report.Cassandra); err != nil {
+			return fmt.Errorf("failed to create Cassandra sheet: %w", err)
+		}
+		if err := w.createCassandraAlertsSheet(f, report.Cassandra); err != nil {
+			return fmt.Errorf("failed to create Cassandra alerts sheet: %w", err)
+		}
+	}
+
+	// Create monitoring stack sheets if available
+	if report.Monitoring != nil {
+		if err := w.createMonitoringSheet(f, report.Monitoring); err != nil {
+			return fmt.Errorf("failed to create monitoring sheet: %w", err)
+		}
+		if err := w.createMonitoringAlertsSheet(f, report.Monitoring); err != nil {
+			return fmt.Errorf("failed to create monitoring alerts sheet: %w", err)
+		}
+	}
+
+	// Create shared storage sheets if available
+	if report.Storage != nil {
+		if err := w.createStorageSheet(f, report.Storage); err != nil {
+			return fmt.Errorf("failed to create storage sheet: %w", err)
+		}
+		if err := w.createStorageAlertsSheet(f, report.Storage); err != nil {
+			return fmt.Errorf("failed to create storage alerts sheet: %w", err)
+		}
+	}
+
+	// Create log checks sheets if available
+	if report.LogChecks != nil {
+		if err := w.createLogChecksSheet(f, report.LogChecks); err != nil {
+			return fmt.Errorf("failed to create log checks sheet: %w", err)
+		}
+		if err := w.createLogCheckAlertsSheet(f, report.LogChecks); err != nil {
+			return fmt.Errorf("failed to create log checks alerts sheet: %w", err)
+		}
+	}
+
+	// Create LVS sheets if available
+	if report.LVS != nil {
+		if err := w.createLVSSheet(f, report.LVS); err != nil {
+			return fmt.Errorf("failed to create LVS sheet: %w", err)
+		}
+		if err := w.createLVSRealServersSheet(f, report.LVS); err != nil {
+			return fmt.Errorf("failed to create LVS real servers sheet: %w", err)
+		}
+		if err := w.createLVSAlertsSheet(f, report.LVS); err != nil {
+			return fmt.Errorf("failed to create LVS alerts sheet: %w", err)
+		}
+	}
+
+	// Create Windows sheets if available
+	if report.Windows != nil {
+		if err := w.createWindowsSheet(f, report.Windows); err != nil {
+			return fmt.Errorf("failed to create Windows sheet: %w", err)
+		}
+		if err := w.createWindowsAlertsSheet(f, report.Windows); err != nil {
+			return fmt.Errorf("failed to create Windows alerts sheet: %w", err)
+		}
+	}
+
+	// Create AD sheets if available
+	if report.AD != nil {
+		if err := w.createADSheet(f, report.AD); err != nil {
+			return fmt.Errorf("failed to create AD sheet: %w", err)
+		}
+		if err := w.createADAlertsSheet(f, report.AD); err != nil {
+			return fmt.Errorf("failed to create AD alerts sheet: %w", err)
+		}
+	}
+
+	// Create cloud resource sheets if available
+	if report.Cloud != nil {
+		if err := w.createCloudSheet(f, report.Cloud); err != nil {
+			return fmt.Errorf("failed to create cloud sheet: %w", err)
+		}
+		if err := w.createCloudAlertsSheet(f, report.Cloud); err != nil {
+			return fmt.Errorf("failed to create cloud alerts sheet: %w", err)
+		}
+		if err := w.createCloudCostSheet(f, report.Cloud); err != nil {
+			return fmt.Errorf("failed to create cloud cost sheet: %w", err)
+		}
+	}
+
+	// Create firewall sheets if available
+	if report.Firewall != nil {
+		if err := w.createFirewallSheet(f, report.Firewall); err != nil {
+			return fmt.Errorf("failed to create firewall sheet: %w", err)
+		}
+		if err := w.createFirewallTunnelsSheet(f, report.Firewall); err != nil {
+			return fmt.Errorf("failed to create firewall tunnels sheet: %w", err)
+		}
+		if err := w.createFirewallAlertsSheet(f, report.Firewall); err != nil {
+			return fmt.Errorf("failed to create firewall alerts sheet: %w", err)
+		}
+	}
+
+	if err := w.createAlertmanagerSheet(f); err != nil {
+		return fmt.Errorf("failed to create Alertmanager sheet: %w", err)
+	}
+
 	// Remove default Sheet1
 	if err := f.DeleteSheet(defaultSheet); err != nil {
 		// Ignore error if sheet doesn't exist
 	}
 
+	// Localize sheet names before anything links to them
+	if err := w.localize(f); err != nil {
+		return fmt.Errorf("failed to localize workbook: %w", err)
+	}
+
+	// Add alert badges to sheet names, then create table of contents when the workbook has many sheets
+	tocEntries := buildTOCEntries(report)
+	w.localizeTOCEntries(tocEntries)
+	if err := w.arrangeSheets(f, tocEntries); err != nil {
+		return fmt.Errorf("failed to arrange sheets: %w", err)
+	}
+	renamed, err := w.applySheetBadges(f, tocEntries)
+	if err != nil {
+		return fmt.Errorf("failed to add alert badges to sheet names: %w", err)
+	}
+	if err := w.createTOCSheet(f, tocEntries); err != nil {
+		return fmt.Errorf("failed to create table of contents sheet: %w", err)
+	}
+
 	// Set active sheet to summary (or first available sheet)
 	activeSheet := sheetSummary
-	if hostResult == nil {
-		if mysqlResult != nil {
+	if report.Host == nil {
+		if report.MySQL != nil {
 			activeSheet = sheetMySQL
-		} else if redisResult != nil {
+		} else if report.Redis != nil {
 			activeSheet = sheetRedis
-		} else if nginxResult != nil {
+		} else if report.Nginx != nil {
 			activeSheet = sheetNginx
-		} else if tomcatResult != nil {
+		} else if report.Tomcat != nil {
 			activeSheet = sheetTomcat
+		} else if report.Cassandra != nil {
+			activeSheet = sheetCassandra
+		} else if report.Monitoring != nil {
+			activeSheet = sheetMonitoring
+		} else if report.Storage != nil {
+			activeSheet = sheetStorage
+		} else if report.LogChecks != nil {
+			activeSheet = sheetLogChecks
+		} else if report.LVS != nil {
+			activeSheet = sheetLVS
+		} else if report.Windows != nil {
+			activeSheet = sheetWindows
+		} else if report.AD != nil {
+			activeSheet = sheetAD
+		} else if report.Cloud != nil {
+			activeSheet = sheetCloud
+		} else if report.Firewall != nil {
+			activeSheet = sheetFirewall
 		}
 	}
+	activeSheet = w.tr.SheetName(activeSheet)
+	if name, ok := renamed[activeSheet]; ok {
+		activeSheet = name
+	}
+	if idx, _ := f.GetSheetIndex(sheetTOC); idx >= 0 {
+		activeSheet = sheetTOC
+	}
 	idx, _ := f.GetSheetIndex(activeSheet)
+	if visible, _ := f.GetSheetVisible(activeSheet); !visible {
+		idx = firstVisibleSheetIndex(f)
+	}
 	f.SetActiveSheet(idx)
 
 	// Save the file
-	if err := f.SaveAs(outputPath); err != nil {
+	if err := w.saveAs(f, outputPath); err != nil {
 		return fmt.Errorf("failed to save Excel file: %w", err)
 	}
 
@@ -1701,7 +2112,7 @@ func (w *Writer) createNginxSheet(f *excelize.File, result *model.NginxInspectio
 
 	// Set column widths
 	colWidths := map[string]float64{
-		"A": 20, // 巡检时间
+		"A": w.inspectionTimeWidth(20), // 巡检时间
 		"B": 18, // 主机标识符
 		"C": 15, // 主机名
 		"D": 15, // IP地址
@@ -1748,7 +2159,7 @@ func (w *Writer) createNginxSheet(f *excelize.File, result *model.NginxInspectio
 		rowStr := fmt.Sprintf("%d", row)
 
 		// A: 巡检时间
-		f.SetCellValue(sheetName, "A"+rowStr, result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"))
+		f.SetCellValue(sheetName, "A"+rowStr, w.formatInspectionTime(result.InspectionTime))
 		// B: 主机标识符
 		if r.Instance != nil {
 			f.SetCellValue(sheetName, "B"+rowStr, r.Instance.Identifier)
@@ -1786,11 +2197,7 @@ func (w *Writer) createNginxSheet(f *excelize.File, result *model.NginxInspectio
 			f.SetCellValue(sheetName, "I"+rowStr, r.Instance.ErrorLogPath)
 		}
 		// J: 运行状态
-		if r.Up {
-			f.SetCellValue(sheetName, "J"+rowStr, "运行")
-		} else {
-			f.SetCellValue(sheetName, "J"+rowStr, "停止")
-		}
+		f.SetCellValue(sheetName, "J"+rowStr, model.FormatBool("nginx_up", r.Up))
 		// K: 活跃连接数
 		f.SetCellValue(sheetName, "K"+rowStr, r.ActiveConnections)
 		// L: 连接使用率
@@ -1813,17 +2220,9 @@ func (w *Writer) createNginxSheet(f *excelize.File, result *model.NginxInspectio
 		// N: Worker连接数
 		f.SetCellValue(sheetName, "N"+rowStr, r.WorkerConnections)
 		// O: 4xx错误页
-		if r.ErrorPage4xxConfigured {
-			f.SetCellValue(sheetName, "O"+rowStr, "已配置")
-		} else {
-			f.SetCellValue(sheetName, "O"+rowStr, "未配置")
-		}
+		f.SetCellValue(sheetName, "O"+rowStr, model.FormatBool("nginx_error_page_4xx", r.ErrorPage4xxConfigured))
 		// P: 5xx错误页
-		if r.ErrorPage5xxConfigured {
-			f.SetCellValue(sheetName, "P"+rowStr, "已配置")
-		} else {
-			f.SetCellValue(sheetName, "P"+rowStr, "未配置")
-		}
+		f.SetCellValue(sheetName, "P"+rowStr, model.FormatBool("nginx_error_page_5xx", r.ErrorPage5xxConfigured))
 		// Q: 最近错误时间
 		if r.LastErrorTimestamp > 0 {
 			f.SetCellValue(sheetName, "Q"+rowStr, time.Unix(r.LastErrorTimestamp, 0).In(w.timezone).Format("2006-01-02 15:04:05"))
@@ -1831,11 +2230,7 @@ func (w *Writer) createNginxSheet(f *excelize.File, result *model.NginxInspectio
 			f.SetCellValue(sheetName, "Q"+rowStr, "无错误")
 		}
 		// R: 非root用户
-		if r.NonRootUser {
-			f.SetCellValue(sheetName, "R"+rowStr, "是")
-		} else {
-			f.SetCellValue(sheetName, "R"+rowStr, "否")
-		}
+		f.SetCellValue(sheetName, "R"+rowStr, model.FormatBool("nginx_non_root_user", r.NonRootUser))
 		// S: 整体状态
 		f.SetCellValue(sheetName, "S"+rowStr, nginxStatusText(r.Status))
 
@@ -1854,10 +2249,22 @@ func (w *Writer) createNginxSheet(f *excelize.File, result *model.NginxInspectio
 	return nil
 }
 
-// createNginxAlertsSheet creates the Nginx alerts sheet.
-func (w *Writer) createNginxAlertsSheet(f *excelize.File, result *model.NginxInspectionResults) error {
+// createNginxUpstreamSheet creates the per-upstream summary sheet.
+// The sheet is skipped when no instance has upstream data.
+func (w *Writer) createNginxUpstreamSheet(f *excelize.File, result *model.NginxInspectionResults) error {
+	hasData := false
+	for _, r := range result.Results {
+		if r.HasUpstreamData() {
+			hasData = true
+			break
+		}
+	}
+	if !hasData {
+		return nil
+	}
+
 	// Create sheet
-	_, err := f.NewSheet(sheetNginxAlerts)
+	_, err := f.NewSheet(sheetNginxUpstream)
 	if err != nil {
 		return err
 	}
@@ -1868,11 +2275,6 @@ func (w *Writer) createNginxAlertsSheet(f *excelize.File, result *model.NginxIns
 		return err
 	}
 
-	warningStyle, err := w.createWarningStyle(f)
-	if err != nil {
-		return err
-	}
-
 	criticalStyle, err := w.createCriticalStyle(f)
 	if err != nil {
 		return err
@@ -1880,25 +2282,25 @@ func (w *Writer) createNginxAlertsSheet(f *excelize.File, result *model.NginxIns
 
 	// Define headers
 	headers := []string{
-		"主机标识符", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息",
+		"主机标识符", "Upstream", "后端总数", "正常后端", "异常后端", "5xx 比例", "状态",
 	}
 
 	// Set column widths
 	colWidths := map[string]float64{
 		"A": 18, // 主机标识符
-		"B": 10, // 告警级别
-		"C": 20, // 指标名称
-		"D": 15, // 当前值
-		"E": 12, // 警告阈值
-		"F": 12, // 严重阈值
-		"G": 50, // 告警消息
+		"B": 24, // Upstream
+		"C": 10, // 后端总数
+		"D": 10, // 正常后端
+		"E": 10, // 异常后端
+		"F": 12, // 5xx 比例
+		"G": 14, // 状态
 	}
 	for col, width := range colWidths {
-		f.SetColWidth(sheetNginxAlerts, col, col, width)
+		f.SetColWidth(sheetNginxUpstream, col, col, width)
 	}
 
 	// Write headers
-	sheetName := sheetNginxAlerts
+	sheetName := sheetNginxUpstream
 	for i, header := range headers {
 		cell := fmt.Sprintf("%s1", string(rune('A'+i)))
 		f.SetCellValue(sheetName, cell, header)
@@ -1914,29 +2316,133 @@ func (w *Writer) createNginxAlertsSheet(f *excelize.File, result *model.NginxIns
 		ActivePane:  "bottomLeft",
 	})
 
-	// Write alert data
-	for i, alert := range result.Alerts {
-		row := i + 2
-		rowStr := fmt.Sprintf("%d", row)
+	// Write upstream data
+	row := 2
+	for _, r := range result.Results {
+		for _, summary := range r.GetUpstreamSummaries() {
+			rowStr := fmt.Sprintf("%d", row)
+
+			// A: 主机标识符
+			f.SetCellValue(sheetName, "A"+rowStr, r.GetIdentifier())
+			// B: Upstream
+			f.SetCellValue(sheetName, "B"+rowStr, summary.UpstreamName)
+			// C-E: 后端数量
+			f.SetCellValue(sheetName, "C"+rowStr, summary.TotalBackends)
+			f.SetCellValue(sheetName, "D"+rowStr, summary.HealthyBackends)
+			f.SetCellValue(sheetName, "E"+rowStr, summary.UnhealthyBackends)
+			// F: 5xx 比例
+			f.SetCellValue(sheetName, "F"+rowStr, formatUpstream5xxRate(summary))
+			// G: 状态
+			statusCell := "G" + rowStr
+			f.SetCellValue(sheetName, statusCell, upstreamStatusText(summary))
+			if summary.AllDown() {
+				f.SetCellStyle(sheetName, statusCell, statusCell, criticalStyle)
+			}
 
-		// A: 主机标识符
-		f.SetCellValue(sheetName, "A"+rowStr, alert.Identifier)
-		// B: 告警级别
-		f.SetCellValue(sheetName, "B"+rowStr, alert.Level)
-		// C: 指标名称
-		f.SetCellValue(sheetName, "C"+rowStr, alert.MetricDisplayName)
-		// D: 当前值
-		f.SetCellValue(sheetName, "D"+rowStr, alert.FormattedValue)
-		// E: 警告阈值
-		f.SetCellValue(sheetName, "E"+rowStr, formatNginxThreshold(alert.WarningThreshold))
-		// F: 严重阈值
-		f.SetCellValue(sheetName, "F"+rowStr, formatNginxThreshold(alert.CriticalThreshold))
-		// G: 告警消息
-		f.SetCellValue(sheetName, "G"+rowStr, alert.Message)
+			row++
+		}
+	}
 
-		// Apply conditional format to alert level column
-		levelCell := "B" + rowStr
-		switch alert.Level {
+	return nil
+}
+
+// createNginxAlertsSheet creates the Nginx alerts sheet.
+func (w *Writer) createNginxAlertsSheet(f *excelize.File, result *model.NginxInspectionResults) error {
+	// Create sheet
+	_, err := f.NewSheet(sheetNginxAlerts)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	logExcerptStyle, err := w.createLogExcerptStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers
+	headers := []string{
+		"主机标识符", "告警级别", "指标名称", "当前值", "警告阈值", "严重阈值", "告警消息", "日志摘录", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 18, // 主机标识符
+		"B": 10, // 告警级别
+		"C": 20, // 指标名称
+		"D": 15, // 当前值
+		"E": 12, // 警告阈值
+		"F": 12, // 严重阈值
+		"G": 50, // 告警消息
+		"H": 80, // 日志摘录
+		"I": remediationColWidth, // 处理建议
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetNginxAlerts, col, col, width)
+	}
+
+	// Write headers
+	sheetName := sheetNginxAlerts
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", string(rune('A'+i)))
+		f.SetCellValue(sheetName, cell, header)
+		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      0,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+
+	// Write alert data
+	for i, alert := range result.Alerts {
+		row := i + 2
+		rowStr := fmt.Sprintf("%d", row)
+
+		// A: 主机标识符
+		f.SetCellValue(sheetName, "A"+rowStr, alert.Identifier)
+		// B: 告警级别
+		f.SetCellValue(sheetName, "B"+rowStr, alert.Level)
+		// C: 指标名称
+		f.SetCellValue(sheetName, "C"+rowStr, alert.MetricDisplayName)
+		// D: 当前值
+		f.SetCellValue(sheetName, "D"+rowStr, alert.FormattedValue)
+		// E: 警告阈值
+		f.SetCellValue(sheetName, "E"+rowStr, formatNginxThreshold(alert.WarningThreshold))
+		// F: 严重阈值
+		f.SetCellValue(sheetName, "F"+rowStr, formatNginxThreshold(alert.CriticalThreshold))
+		// G: 告警消息
+		f.SetCellValue(sheetName, "G"+rowStr, alert.Message)
+		// H: 日志摘录
+		if len(alert.LogExcerpt) > 0 {
+			f.SetCellValue(sheetName, "H"+rowStr, formatLogExcerpt(alert.LogExcerpt))
+			f.SetCellStyle(sheetName, "H"+rowStr, "H"+rowStr, logExcerptStyle)
+		}
+		// I: 处理建议
+		f.SetCellValue(sheetName, "I"+rowStr, w.remediation(alert.MetricName))
+
+		// Apply conditional format to alert level column
+		levelCell := "B" + rowStr
+		switch alert.Level {
 		case model.AlertLevelCritical:
 			f.SetCellStyle(sheetName, levelCell, levelCell, criticalStyle)
 		case model.AlertLevelWarning:
@@ -1963,6 +2469,28 @@ func nginxStatusText(status model.NginxInstanceStatus) string {
 	}
 }
 
+// formatUpstream5xxRate formats the 5xx rate of an upstream, "N/A" if not collected.
+func formatUpstream5xxRate(summary model.NginxUpstreamSummary) string {
+	if !summary.Has5xxRate {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f%%", summary.Error5xxRate)
+}
+
+// upstreamStatusText returns the health text of an upstream group.
+func upstreamStatusText(summary model.NginxUpstreamSummary) string {
+	switch {
+	case summary.TotalBackends == 0:
+		return "N/A"
+	case summary.AllDown():
+		return "全部异常"
+	case summary.UnhealthyBackends > 0:
+		return "部分异常"
+	default:
+		return "正常"
+	}
+}
+
 // formatNginxThreshold formats threshold value for display.
 func formatNginxThreshold(value float64) string {
 	if value == 0 {
@@ -1991,14 +2519,6 @@ func tomcatStatusText(status model.TomcatInstanceStatus) string {
 	}
 }
 
-// tomcatBoolToText converts boolean to Chinese text (是/否).
-func tomcatBoolToText(b bool) string {
-	if b {
-		return "是"
-	}
-	return "否"
-}
-
 // getTomcatPortOrContainer returns container name if container deployment,
 // otherwise returns port number.
 func getTomcatPortOrContainer(r *model.TomcatInspectionResult) string {
@@ -2017,6 +2537,10 @@ func formatTomcatThreshold(value float64, metricName string) string {
 	case "last_error_timestamp":
 		// Time-based thresholds (in minutes)
 		return fmt.Sprintf("%.0f分钟", value)
+	case "tomcat_jvm_heap_usage", "tomcat_thread_pool_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "tomcat_gc_pause_avg_ms":
+		return fmt.Sprintf("%.0f ms", value)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -2042,6 +2566,11 @@ func (w *Writer) WriteNginxInspection(result *model.NginxInspectionResults, outp
 		return fmt.Errorf("failed to create Nginx sheet: %w", err)
 	}
 
+	// Create Nginx upstream sheet (only when upstream data exists)
+	if err := w.createNginxUpstreamSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Nginx upstream sheet: %w", err)
+	}
+
 	// Create Nginx alerts sheet
 	if err := w.createNginxAlertsSheet(f, result); err != nil {
 		return fmt.Errorf("failed to create Nginx alerts sheet: %w", err)
@@ -2057,7 +2586,7 @@ func (w *Writer) WriteNginxInspection(result *model.NginxInspectionResults, outp
 	f.SetActiveSheet(idx)
 
 	// Save the file
-	if err := f.SaveAs(outputPath); err != nil {
+	if err := w.saveAs(f, outputPath); err != nil {
 		return fmt.Errorf("failed to save Excel file: %w", err)
 	}
 
@@ -2071,7 +2600,7 @@ func (w *Writer) AppendNginxInspection(result *model.NginxInspectionResults, exi
 	}
 
 	// Open existing Excel file
-	f, err := excelize.OpenFile(existingPath)
+	f, err := w.openFile(existingPath)
 	if err != nil {
 		return fmt.Errorf("failed to open existing Excel file: %w", err)
 	}
@@ -2082,13 +2611,18 @@ func (w *Writer) AppendNginxInspection(result *model.NginxInspectionResults, exi
 		return fmt.Errorf("failed to create Nginx sheet: %w", err)
 	}
 
+	// Create Nginx upstream sheet (only when upstream data exists)
+	if err := w.createNginxUpstreamSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Nginx upstream sheet: %w", err)
+	}
+
 	// Create Nginx alerts sheet
 	if err := w.createNginxAlertsSheet(f, result); err != nil {
 		return fmt.Errorf("failed to create Nginx alerts sheet: %w", err)
 	}
 
 	// Save the file
-	if err := f.Save(); err != nil {
+	if err := w.save(f); err != nil {
 		return fmt.Errorf("failed to save Excel file: %w", err)
 	}
 
@@ -2128,18 +2662,19 @@ func (w *Writer) createTomcatSheet(f *excelize.File, result *model.TomcatInspect
 		return err
 	}
 
-	// Define headers (15 columns)
+	// Define headers (18 columns)
 	headers := []string{
 		"巡检时间", "主机名", "IP地址", "应用类型", "端口", "容器名",
 		"版本", "安装路径", "日志路径", "JVM配置",
-		"连接数", "运行时长", "非root用户", "最近错误时间", "整体状态",
+		"连接数", "运行时长", "非root用户", "堆内存使用率", "平均GC暂停",
+		"线程池饱和度", "最近错误时间", "整体状态",
 	}
 
 	// Set column widths
 	colWidths := map[string]float64{
-		"A": 20, "B": 18, "C": 15, "D": 12, "E": 10, "F": 18,
+		"A": w.inspectionTimeWidth(20), "B": 18, "C": 15, "D": 12, "E": 10, "F": 18,
 		"G": 12, "H": 30, "I": 30, "J": 25, "K": 12, "L": 18,
-		"M": 14, "N": 20, "O": 12,
+		"M": 14, "N": 14, "O": 12, "P": 14, "Q": 20, "R": 12,
 	}
 
 	for col, width := range colWidths {
@@ -2159,7 +2694,7 @@ func (w *Writer) createTomcatSheet(f *excelize.File, result *model.TomcatInspect
 	// Write data rows
 	for i, r := range result.Results {
 		row := i + 2
-		inspectionTime := result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
 
 		f.SetCellValue(sheetTomcat, "A"+fmt.Sprint(row), inspectionTime)
 		f.SetCellValue(sheetTomcat, "B"+fmt.Sprint(row), r.Instance.Hostname)
@@ -2173,11 +2708,12 @@ func (w *Writer) createTomcatSheet(f *excelize.File, result *model.TomcatInspect
 		f.SetCellValue(sheetTomcat, "J"+fmt.Sprint(row), r.Instance.JVMConfig)
 		f.SetCellValue(sheetTomcat, "K"+fmt.Sprint(row), r.Connections)
 		f.SetCellValue(sheetTomcat, "L"+fmt.Sprint(row), r.UptimeFormatted)
-		f.SetCellValue(sheetTomcat, "M"+fmt.Sprint(row), tomcatBoolToText(r.NonRootUser))
-		f.SetCellValue(sheetTomcat, "N"+fmt.Sprint(row), r.LastErrorTimeFormatted)
+		f.SetCellValue(sheetTomcat, "M"+fmt.Sprint(row), model.FormatBool("tomcat_non_root_user", r.NonRootUser))
+		w.writeTomcatJVMCells(f, fmt.Sprint(row), r, warningStyle, criticalStyle)
+		f.SetCellValue(sheetTomcat, "Q"+fmt.Sprint(row), r.LastErrorTimeFormatted)
 
 		// Status column with conditional formatting
-		statusCell := "O" + fmt.Sprint(row)
+		statusCell := "R" + fmt.Sprint(row)
 		statusText := tomcatStatusText(r.Status)
 		f.SetCellValue(sheetTomcat, statusCell, statusText)
 
@@ -2194,6 +2730,47 @@ func (w *Writer) createTomcatSheet(f *excelize.File, result *model.TomcatInspect
 	return nil
 }
 
+// writeTomcatJVMCells writes the JVM heap, GC pause and thread pool columns (N-P) of a Tomcat row,
+// highlighting cells that have a corresponding alert.
+func (w *Writer) writeTomcatJVMCells(f *excelize.File, rowStr string, r *model.TomcatInspectionResult, warningStyle, criticalStyle int) {
+	heapUsage, gcPause, threadPoolUsage := "N/A", "N/A", "N/A"
+	if r.HasHeapUsage() {
+		heapUsage = fmt.Sprintf("%.1f%%", r.HeapUsagePercent)
+	}
+	if r.HasGCPause() {
+		gcPause = fmt.Sprintf("%.0f ms", r.GCPauseAvgMs)
+	}
+	if r.HasThreadPoolUsage() {
+		threadPoolUsage = fmt.Sprintf("%.1f%%", r.ThreadPoolUsagePercent)
+	}
+
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"N", "tomcat_jvm_heap_usage", heapUsage},
+		{"O", "tomcat_gc_pause_avg_ms", gcPause},
+		{"P", "tomcat_thread_pool_usage", threadPoolUsage},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetTomcat, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetTomcat, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetTomcat, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
 // createTomcatAlertsSheet creates the Tomcat alerts worksheet.
 func (w *Writer) createTomcatAlertsSheet(f *excelize.File, result *model.TomcatInspectionResults) error {
 	if result == nil || len(result.Alerts) == 0 {
@@ -2221,14 +2798,19 @@ func (w *Writer) createTomcatAlertsSheet(f *excelize.File, result *model.TomcatI
 		return err
 	}
 
+	logExcerptStyle, err := w.createLogExcerptStyle(f)
+	if err != nil {
+		return err
+	}
+
 	headers := []string{
 		"实例标识", "告警级别", "指标名称", "当前值",
-		"警告阈值", "严重阈值", "告警消息",
+		"警告阈值", "严重阈值", "告警消息", "日志摘录", "处理建议",
 	}
 
 	// Set column widths
 	colWidths := map[string]float64{
-		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40,
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": 80, "I": remediationColWidth,
 	}
 	for col, width := range colWidths {
 		f.SetColWidth(sheetTomcatAlerts, col, col, width)
@@ -2263,6 +2845,12 @@ func (w *Writer) createTomcatAlertsSheet(f *excelize.File, result *model.TomcatI
 		f.SetCellValue(sheetTomcatAlerts, "E"+fmt.Sprint(row), formatTomcatThreshold(alert.WarningThreshold, alert.MetricName))
 		f.SetCellValue(sheetTomcatAlerts, "F"+fmt.Sprint(row), formatTomcatThreshold(alert.CriticalThreshold, alert.MetricName))
 		f.SetCellValue(sheetTomcatAlerts, "G"+fmt.Sprint(row), alert.Message)
+		if len(alert.LogExcerpt) > 0 {
+			cell := "H" + fmt.Sprint(row)
+			f.SetCellValue(sheetTomcatAlerts, cell, formatLogExcerpt(alert.LogExcerpt))
+			f.SetCellStyle(sheetTomcatAlerts, cell, cell, logExcerptStyle)
+		}
+		f.SetCellValue(sheetTomcatAlerts, "I"+fmt.Sprint(row), w.remediation(alert.MetricName))
 
 		// Color code the level column
 		levelCell := "B" + fmt.Sprint(row)
@@ -2307,7 +2895,7 @@ func (w *Writer) WriteTomcatInspection(result *model.TomcatInspectionResults, ou
 	idx, _ := f.GetSheetIndex(sheetTomcat)
 	f.SetActiveSheet(idx)
 
-	return f.SaveAs(outputPath)
+	return w.saveAs(f, outputPath)
 }
 
 // AppendTomcatInspection appends Tomcat sheets to an existing Excel file.
@@ -2316,7 +2904,7 @@ func (w *Writer) AppendTomcatInspection(result *model.TomcatInspectionResults, e
 		return fmt.Errorf("tomcat inspection result is nil")
 	}
 
-	f, err := excelize.OpenFile(existingPath)
+	f, err := w.openFile(existingPath)
 	if err != nil {
 		return fmt.Errorf("failed to open existing file: %w", err)
 	}
@@ -2330,5 +2918,3792 @@ func (w *Writer) AppendTomcatInspection(result *model.TomcatInspectionResults, e
 		return fmt.Errorf("failed to create Tomcat alerts sheet: %w", err)
 	}
 
-	return f.Save()
+	return w.save(f)
+}
+
+// =============================================================================
+// Cassandra Report Helper Functions
+// ============================================================================
+
+// cassandraStatusText converts Cassandra node status to Chinese text.
+func cassandraStatusText(status model.CassandraInstanceStatus) string {
+	switch status {
+	case model.CassandraStatusNormal:
+		return "正常"
+	case model.CassandraStatusWarning:
+		return "警告"
+	case model.CassandraStatusCritical:
+		return "严重"
+	case model.CassandraStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatCassandraThreshold formats a Cassandra alert threshold value.
+func formatCassandraThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "cassandra_up":
+		return model.CassandraNodeStateUp
+	case "cassandra_down_endpoints":
+		// Peer DN is only a warning, there is no critical threshold
+		if value == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.0f", value)
+	case "cassandra_heap_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "cassandra_pending_compactions", "cassandra_dropped_mutations", "cassandra_hints_backlog":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// formatCassandraCount formats a Cassandra count metric, "N/A" if not collected.
+func formatCassandraCount(value float64, collected bool) string {
+	if !collected {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// createCassandraSheet creates the Cassandra inspection worksheet.
+func (w *Writer) createCassandraSheet(f *excelize.File, result *model.CassandraInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetCassandra)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (15 columns)
+	headers := []string{
+		"巡检时间", "主机名", "IP地址", "端口", "集群名", "数据中心", "机架", "版本",
+		"节点状态", "DN节点数", "待执行Compaction", "丢弃Mutation(5m)", "堆内存使用率",
+		"Hints积压", "整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 18, "C": 15, "D": 10, "E": 18, "F": 12, "G": 10, "H": 10,
+		"I": 10, "J": 10, "K": 16, "L": 16, "M": 14, "N": 12, "O": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetCassandra, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetCassandra, cell, header)
+		f.SetCellStyle(sheetCassandra, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetCassandra, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetCassandra, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetCassandra, "B"+rowStr, r.Instance.Hostname)
+		f.SetCellValue(sheetCassandra, "C"+rowStr, r.Instance.IP)
+		f.SetCellValue(sheetCassandra, "D"+rowStr, r.Instance.Port)
+		f.SetCellValue(sheetCassandra, "E"+rowStr, r.Instance.ClusterName)
+		f.SetCellValue(sheetCassandra, "F"+rowStr, r.Instance.Datacenter)
+		f.SetCellValue(sheetCassandra, "G"+rowStr, r.Instance.Rack)
+		f.SetCellValue(sheetCassandra, "H"+rowStr, r.Instance.Version)
+		w.writeCassandraMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "O" + rowStr
+		f.SetCellValue(sheetCassandra, statusCell, cassandraStatusText(r.Status))
+
+		switch r.Status {
+		case model.CassandraStatusCritical:
+			f.SetCellStyle(sheetCassandra, statusCell, statusCell, criticalStyle)
+		case model.CassandraStatusWarning:
+			f.SetCellStyle(sheetCassandra, statusCell, statusCell, warningStyle)
+		case model.CassandraStatusNormal:
+			f.SetCellStyle(sheetCassandra, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeCassandraMetricCells writes the node state and metric columns (I-N) of a Cassandra row,
+// highlighting cells that have a corresponding alert.
+func (w *Writer) writeCassandraMetricCells(f *excelize.File, rowStr string, r *model.CassandraInspectionResult, warningStyle, criticalStyle int) {
+	heapUsage := "N/A"
+	if r.HasHeapUsage() {
+		heapUsage = fmt.Sprintf("%.1f%%", r.HeapUsagePercent)
+	}
+
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"I", "cassandra_up", r.NodeState()},
+		{"J", "cassandra_down_endpoints", formatCassandraCount(float64(r.DownEndpoints), r.HasDownEndpoints())},
+		{"K", "cassandra_pending_compactions", formatCassandraCount(r.PendingCompactions, r.HasPendingCompactions())},
+		{"L", "cassandra_dropped_mutations", formatCassandraCount(r.DroppedMutations, r.HasDroppedMutations())},
+		{"M", "cassandra_heap_usage", heapUsage},
+		{"N", "cassandra_hints_backlog", formatCassandraCount(r.HintsBacklog, r.HasHintsBacklog())},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetCassandra, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetCassandra, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetCassandra, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createCassandraAlertsSheet creates the Cassandra alerts worksheet.
+func (w *Writer) createCassandraAlertsSheet(f *excelize.File, result *model.CassandraInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetCassandraAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"节点标识", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetCassandraAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetCassandraAlerts, cell, header)
+		f.SetCellStyle(sheetCassandraAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetCassandraAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.CassandraAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetCassandraAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetCassandraAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetCassandraAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetCassandraAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetCassandraAlerts, "E"+fmt.Sprint(row), formatCassandraThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetCassandraAlerts, "F"+fmt.Sprint(row), formatCassandraThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetCassandraAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetCassandraAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetCassandraAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetCassandraAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteCassandraInspection generates a standalone Excel report for Cassandra inspection.
+func (w *Writer) WriteCassandraInspection(result *model.CassandraInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("cassandra inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createCassandraSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Cassandra sheet: %w", err)
+	}
+
+	if err := w.createCassandraAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Cassandra alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to Cassandra
+	idx, _ := f.GetSheetIndex(sheetCassandra)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendCassandraInspection appends Cassandra sheets to an existing Excel file.
+func (w *Writer) AppendCassandraInspection(result *model.CassandraInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("cassandra inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createCassandraSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Cassandra sheet: %w", err)
+	}
+
+	if err := w.createCassandraAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Cassandra alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// Monitoring Stack Report Helper Functions
+// ============================================================================
+
+// monitoringStatusText converts monitoring component status to Chinese text.
+func monitoringStatusText(status model.MonitoringInstanceStatus) string {
+	switch status {
+	case model.MonitoringStatusNormal:
+		return "正常"
+	case model.MonitoringStatusWarning:
+		return "警告"
+	case model.MonitoringStatusCritical:
+		return "严重"
+	case model.MonitoringStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatMonitoringThreshold formats a monitoring stack alert threshold value.
+func formatMonitoringThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "monitoring_up":
+		return model.MonitoringTargetStateUp
+	case "monitoring_ingestion_rate":
+		// Zero ingestion has no numeric threshold
+		return "-"
+	case "monitoring_disk_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "monitoring_slow_queries", "monitoring_merge_backlog":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// createMonitoringSheet creates the monitoring stack inspection worksheet.
+func (w *Writer) createMonitoringSheet(f *excelize.File, result *model.MonitoringInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetMonitoring)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (12 columns)
+	headers := []string{
+		"巡检时间", "组件", "实例", "主机名", "端口", "版本",
+		"采集状态", "写入速率(行/秒)", "慢查询(5m)", "待合并行数", "存储磁盘使用率", "整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 16, "C": 24, "D": 18, "E": 10, "F": 14,
+		"G": 10, "H": 16, "I": 12, "J": 14, "K": 14, "L": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetMonitoring, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetMonitoring, cell, header)
+		f.SetCellStyle(sheetMonitoring, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetMonitoring, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetMonitoring, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetMonitoring, "B"+rowStr, r.Instance.Component)
+		f.SetCellValue(sheetMonitoring, "C"+rowStr, r.Instance.Identifier)
+		f.SetCellValue(sheetMonitoring, "D"+rowStr, r.Instance.Hostname)
+		f.SetCellValue(sheetMonitoring, "E"+rowStr, r.Instance.Port)
+		f.SetCellValue(sheetMonitoring, "F"+rowStr, r.Instance.Version)
+		w.writeMonitoringMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "L" + rowStr
+		f.SetCellValue(sheetMonitoring, statusCell, monitoringStatusText(r.Status))
+
+		switch r.Status {
+		case model.MonitoringStatusCritical:
+			f.SetCellStyle(sheetMonitoring, statusCell, statusCell, criticalStyle)
+		case model.MonitoringStatusWarning:
+			f.SetCellStyle(sheetMonitoring, statusCell, statusCell, warningStyle)
+		case model.MonitoringStatusNormal:
+			f.SetCellStyle(sheetMonitoring, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeMonitoringMetricCells writes the target state and metric columns (G-K) of a monitoring row,
+// highlighting cells that have a corresponding alert.
+func (w *Writer) writeMonitoringMetricCells(f *excelize.File, rowStr string, r *model.MonitoringInspectionResult, warningStyle, criticalStyle int) {
+	ingestionRate := "N/A"
+	if r.HasIngestionRate() {
+		ingestionRate = fmt.Sprintf("%.0f", r.IngestionRate)
+	}
+	diskUsage := "N/A"
+	if r.HasDiskUsage() {
+		diskUsage = fmt.Sprintf("%.1f%%", r.DiskUsagePercent)
+	}
+
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"G", "monitoring_up", r.TargetState()},
+		{"H", "monitoring_ingestion_rate", ingestionRate},
+		{"I", "monitoring_slow_queries", formatCassandraCount(r.SlowQueries, r.HasSlowQueries())},
+		{"J", "monitoring_merge_backlog", formatCassandraCount(r.MergeBacklog, r.HasMergeBacklog())},
+		{"K", "monitoring_disk_usage", diskUsage},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetMonitoring, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetMonitoring, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetMonitoring, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createMonitoringAlertsSheet creates the monitoring stack alerts worksheet.
+func (w *Writer) createMonitoringAlertsSheet(f *excelize.File, result *model.MonitoringInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetMonitoringAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"实例", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetMonitoringAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetMonitoringAlerts, cell, header)
+		f.SetCellStyle(sheetMonitoringAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetMonitoringAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.MonitoringAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetMonitoringAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetMonitoringAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetMonitoringAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetMonitoringAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetMonitoringAlerts, "E"+fmt.Sprint(row), formatMonitoringThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetMonitoringAlerts, "F"+fmt.Sprint(row), formatMonitoringThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetMonitoringAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetMonitoringAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetMonitoringAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetMonitoringAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteMonitoringInspection generates a standalone Excel report for monitoring stack inspection.
+func (w *Writer) WriteMonitoringInspection(result *model.MonitoringInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("monitoring inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createMonitoringSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create monitoring sheet: %w", err)
+	}
+
+	if err := w.createMonitoringAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create monitoring alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to monitoring stack
+	idx, _ := f.GetSheetIndex(sheetMonitoring)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendMonitoringInspection appends monitoring stack sheets to an existing Excel file.
+func (w *Writer) AppendMonitoringInspection(result *model.MonitoringInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("monitoring inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createMonitoringSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create monitoring sheet: %w", err)
+	}
+
+	if err := w.createMonitoringAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create monitoring alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// Shared Storage Report Helper Functions
+// ============================================================================
+
+// storageStatusText converts shared storage host status to Chinese text.
+func storageStatusText(status model.StorageInstanceStatus) string {
+	switch status {
+	case model.StorageStatusNormal:
+		return "正常"
+	case model.StorageStatusWarning:
+		return "警告"
+	case model.StorageStatusCritical:
+		return "严重"
+	case model.StorageStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatStorageThreshold formats a shared storage alert threshold value.
+func formatStorageThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "storage_mount_status":
+		return "失效"
+	case "storage_gluster_peers_disconnected", "storage_gluster_bricks_offline", "storage_nfs_rpc_errors":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// createStorageSheet creates the shared storage inspection worksheet.
+func (w *Writer) createStorageSheet(f *excelize.File, result *model.StorageInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetStorage)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (11 columns)
+	headers := []string{
+		"巡检时间", "主机名", "IP", "角色", "挂载数", "失效挂载数",
+		"失效挂载点", "GlusterFS 断开节点", "离线 Brick", "NFS RPC 错误(5m)", "整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 20, "C": 16, "D": 24, "E": 10, "F": 12,
+		"G": 30, "H": 18, "I": 12, "J": 16, "K": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetStorage, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetStorage, cell, header)
+		f.SetCellStyle(sheetStorage, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetStorage, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetStorage, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetStorage, "B"+rowStr, r.Instance.Hostname)
+		f.SetCellValue(sheetStorage, "C"+rowStr, r.Instance.IP)
+		f.SetCellValue(sheetStorage, "D"+rowStr, r.Instance.RoleText())
+		f.SetCellValue(sheetStorage, "E"+rowStr, len(r.Mounts))
+		w.writeStorageMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "K" + rowStr
+		f.SetCellValue(sheetStorage, statusCell, storageStatusText(r.Status))
+
+		switch r.Status {
+		case model.StorageStatusCritical:
+			f.SetCellStyle(sheetStorage, statusCell, statusCell, criticalStyle)
+		case model.StorageStatusWarning:
+			f.SetCellStyle(sheetStorage, statusCell, statusCell, warningStyle)
+		case model.StorageStatusNormal:
+			f.SetCellStyle(sheetStorage, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeStorageMetricCells writes the mount, GlusterFS and NFS columns (F-J) of a storage row,
+// highlighting cells that have a corresponding alert.
+func (w *Writer) writeStorageMetricCells(f *excelize.File, rowStr string, r *model.StorageInspectionResult, warningStyle, criticalStyle int) {
+	staleMounts := "-"
+	if points := r.StaleMountPoints(); len(points) > 0 {
+		staleMounts = strings.Join(points, ", ")
+	}
+
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"F", "storage_mount_status", fmt.Sprint(r.StaleMounts)},
+		{"G", "storage_mount_status", staleMounts},
+		{"H", "storage_gluster_peers_disconnected", formatCassandraCount(r.GlusterPeersDisconnected, r.HasGlusterPeers())},
+		{"I", "storage_gluster_bricks_offline", formatCassandraCount(r.GlusterBricksOffline, r.HasGlusterBricks())},
+		{"J", "storage_nfs_rpc_errors", formatCassandraCount(r.NFSRPCErrors, r.HasNFSRPCErrors())},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetStorage, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetStorage, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetStorage, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createStorageAlertsSheet creates the shared storage alerts worksheet.
+func (w *Writer) createStorageAlertsSheet(f *excelize.File, result *model.StorageInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetStorageAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"主机名", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetStorageAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetStorageAlerts, cell, header)
+		f.SetCellStyle(sheetStorageAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetStorageAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.StorageAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetStorageAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetStorageAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetStorageAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetStorageAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetStorageAlerts, "E"+fmt.Sprint(row), formatStorageThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetStorageAlerts, "F"+fmt.Sprint(row), formatStorageThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetStorageAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetStorageAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetStorageAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetStorageAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteStorageInspection generates a standalone Excel report for shared storage inspection.
+func (w *Writer) WriteStorageInspection(result *model.StorageInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("storage inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createStorageSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create storage sheet: %w", err)
+	}
+
+	if err := w.createStorageAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create storage alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to shared storage
+	idx, _ := f.GetSheetIndex(sheetStorage)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendStorageInspection appends shared storage sheets to an existing Excel file.
+func (w *Writer) AppendStorageInspection(result *model.StorageInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("storage inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createStorageSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create storage sheet: %w", err)
+	}
+
+	if err := w.createStorageAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create storage alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// Log Checks Report Helper Functions
+// ============================================================================
+
+// logCheckStatusText converts log check status to Chinese text.
+func logCheckStatusText(status model.LogCheckStatus) string {
+	switch status {
+	case model.LogCheckStatusNormal:
+		return "正常"
+	case model.LogCheckStatusWarning:
+		return "警告"
+	case model.LogCheckStatusCritical:
+		return "严重"
+	case model.LogCheckStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatLogCheckThreshold formats a log check threshold; 0 means the level is not checked.
+func formatLogCheckThreshold(value float64) string {
+	if value <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// createLogChecksSheet creates the log checks inspection worksheet.
+func (w *Writer) createLogChecksSheet(f *excelize.File, result *model.LogCheckInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetLogChecks)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (9 columns)
+	headers := []string{
+		"巡检时间", "检查项", "名称", "分组", "匹配条数",
+		"警告阈值", "严重阈值", "错误信息", "状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 24, "C": 20, "D": 20, "E": 12,
+		"F": 12, "G": 12, "H": 36, "I": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetLogChecks, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetLogChecks, cell, header)
+		f.SetCellStyle(sheetLogChecks, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetLogChecks, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		group := r.Instance.Group
+		if group == "" {
+			group = "-"
+		}
+
+		f.SetCellValue(sheetLogChecks, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetLogChecks, "B"+rowStr, r.Instance.CheckName)
+		f.SetCellValue(sheetLogChecks, "C"+rowStr, r.Instance.DisplayName)
+		f.SetCellValue(sheetLogChecks, "D"+rowStr, group)
+		f.SetCellValue(sheetLogChecks, "E"+rowStr, formatCassandraCount(r.Count, r.HasCount()))
+		f.SetCellValue(sheetLogChecks, "F"+rowStr, formatLogCheckThreshold(r.Instance.Warning))
+		f.SetCellValue(sheetLogChecks, "G"+rowStr, formatLogCheckThreshold(r.Instance.Critical))
+		f.SetCellValue(sheetLogChecks, "H"+rowStr, r.Error)
+
+		// Count and status columns with conditional formatting
+		countCell := "E" + rowStr
+		statusCell := "I" + rowStr
+		f.SetCellValue(sheetLogChecks, statusCell, logCheckStatusText(r.Status))
+
+		switch r.Status {
+		case model.LogCheckStatusCritical:
+			f.SetCellStyle(sheetLogChecks, countCell, countCell, criticalStyle)
+			f.SetCellStyle(sheetLogChecks, statusCell, statusCell, criticalStyle)
+		case model.LogCheckStatusWarning:
+			f.SetCellStyle(sheetLogChecks, countCell, countCell, warningStyle)
+			f.SetCellStyle(sheetLogChecks, statusCell, statusCell, warningStyle)
+		case model.LogCheckStatusNormal:
+			f.SetCellStyle(sheetLogChecks, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// createLogCheckAlertsSheet creates the log checks alerts worksheet.
+func (w *Writer) createLogCheckAlertsSheet(f *excelize.File, result *model.LogCheckInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetLogCheckAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"检查项", "告警级别", "名称", "匹配条数",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 30, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 50, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetLogCheckAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetLogCheckAlerts, cell, header)
+		f.SetCellStyle(sheetLogCheckAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetLogCheckAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.LogCheckAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetLogCheckAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetLogCheckAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetLogCheckAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetLogCheckAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetLogCheckAlerts, "E"+fmt.Sprint(row), formatLogCheckThreshold(alert.WarningThreshold))
+		f.SetCellValue(sheetLogCheckAlerts, "F"+fmt.Sprint(row), formatLogCheckThreshold(alert.CriticalThreshold))
+		f.SetCellValue(sheetLogCheckAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetLogCheckAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetLogCheckAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetLogCheckAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteLogCheckInspection generates a standalone Excel report for log checks inspection.
+func (w *Writer) WriteLogCheckInspection(result *model.LogCheckInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("log checks inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createLogChecksSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create log checks sheet: %w", err)
+	}
+
+	if err := w.createLogCheckAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create log checks alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to log checks
+	idx, _ := f.GetSheetIndex(sheetLogChecks)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendLogCheckInspection appends log checks sheets to an existing Excel file.
+func (w *Writer) AppendLogCheckInspection(result *model.LogCheckInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("log checks inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createLogChecksSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create log checks sheet: %w", err)
+	}
+
+	if err := w.createLogCheckAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create log checks alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// LVS Report Helper Functions
+// ============================================================================
+
+// lvsStatusText converts LVS director status to Chinese text.
+func lvsStatusText(status model.LVSInstanceStatus) string {
+	switch status {
+	case model.LVSStatusNormal:
+		return "正常"
+	case model.LVSStatusWarning:
+		return "警告"
+	case model.LVSStatusCritical:
+		return "严重"
+	case model.LVSStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatLVSThreshold formats an LVS alert threshold value.
+func formatLVSThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "lvs_zero_active_services":
+		return "无可用"
+	case "lvs_sync_daemon_running":
+		return "未运行"
+	case "lvs_active_connections":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// lvsSyncDaemonText formats the connection sync daemon state, e.g. "运行 (master)".
+func lvsSyncDaemonText(r *model.LVSInspectionResult) string {
+	if !r.HasSyncDaemon() {
+		return "N/A"
+	}
+	text := "未运行"
+	if r.SyncDaemonRunning != 0 {
+		text = "运行"
+	}
+	if r.SyncDaemonState != "" {
+		text += " (" + r.SyncDaemonState + ")"
+	}
+	return text
+}
+
+// createLVSSheet creates the LVS inspection worksheet.
+func (w *Writer) createLVSSheet(f *excelize.File, result *model.LVSInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetLVS)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (10 columns)
+	headers := []string{
+		"巡检时间", "主机名", "IP", "虚拟服务数", "真实服务器数",
+		"无可用服务数", "无可用真实服务器的虚拟服务", "活跃连接数", "连接同步", "整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 20, "C": 16, "D": 12, "E": 14,
+		"F": 14, "G": 36, "H": 14, "I": 16, "J": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetLVS, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetLVS, cell, header)
+		f.SetCellStyle(sheetLVS, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetLVS, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetLVS, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetLVS, "B"+rowStr, r.Instance.Hostname)
+		f.SetCellValue(sheetLVS, "C"+rowStr, r.Instance.IP)
+		f.SetCellValue(sheetLVS, "D"+rowStr, len(r.VirtualServices))
+		f.SetCellValue(sheetLVS, "E"+rowStr, r.RealServerCount())
+		w.writeLVSMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "J" + rowStr
+		f.SetCellValue(sheetLVS, statusCell, lvsStatusText(r.Status))
+
+		switch r.Status {
+		case model.LVSStatusCritical:
+			f.SetCellStyle(sheetLVS, statusCell, statusCell, criticalStyle)
+		case model.LVSStatusWarning:
+			f.SetCellStyle(sheetLVS, statusCell, statusCell, warningStyle)
+		case model.LVSStatusNormal:
+			f.SetCellStyle(sheetLVS, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeLVSMetricCells writes the virtual service, connection and sync daemon columns (F-I)
+// of an LVS row, highlighting cells that have a corresponding alert.
+func (w *Writer) writeLVSMetricCells(f *excelize.File, rowStr string, r *model.LVSInspectionResult, warningStyle, criticalStyle int) {
+	zeroActive := "-"
+	if names := r.ZeroActiveServiceNames(); len(names) > 0 {
+		zeroActive = strings.Join(names, ", ")
+	}
+
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"F", "lvs_zero_active_services", fmt.Sprint(r.ZeroActiveServices)},
+		{"G", "lvs_zero_active_services", zeroActive},
+		{"H", "lvs_active_connections", formatCassandraCount(r.ActiveConnections, r.HasActiveConnections())},
+		{"I", "lvs_sync_daemon_running", lvsSyncDaemonText(r)},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetLVS, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetLVS, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetLVS, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createLVSRealServersSheet creates the LVS virtual service detail worksheet,
+// one row per real server.
+func (w *Writer) createLVSRealServersSheet(f *excelize.File, result *model.LVSInspectionResults) error {
+	hasData := false
+	for _, r := range result.Results {
+		if len(r.VirtualServices) > 0 {
+			hasData = true
+			break
+		}
+	}
+	if !hasData {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetLVSRealServers)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"调度器", "虚拟服务", "真实服务器", "权重", "活跃连接", "非活跃连接", "状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 20, "B": 26, "C": 22, "D": 8, "E": 12, "F": 12, "G": 20,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetLVSRealServers, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetLVSRealServers, cell, header)
+		f.SetCellStyle(sheetLVSRealServers, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetLVSRealServers, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write real server rows
+	row := 2
+	for _, r := range result.Results {
+		for _, vs := range r.VirtualServices {
+			noActive := vs.ActiveRealServers() == 0
+			for _, rs := range vs.RealServers {
+				rowStr := fmt.Sprint(row)
+				f.SetCellValue(sheetLVSRealServers, "A"+rowStr, r.GetIdentifier())
+				f.SetCellValue(sheetLVSRealServers, "B"+rowStr, vs.String())
+				f.SetCellValue(sheetLVSRealServers, "C"+rowStr, rs.Address)
+				f.SetCellValue(sheetLVSRealServers, "D"+rowStr, rs.Weight)
+				f.SetCellValue(sheetLVSRealServers, "E"+rowStr, rs.ActiveConnections)
+				f.SetCellValue(sheetLVSRealServers, "F"+rowStr, rs.InactiveConnections)
+
+				// Status column: weight 0 means the real server is drained
+				statusCell := "G" + rowStr
+				switch {
+				case noActive:
+					f.SetCellValue(sheetLVSRealServers, statusCell, "已摘除（服务无可用）")
+					f.SetCellStyle(sheetLVSRealServers, statusCell, statusCell, criticalStyle)
+				case !rs.IsActive():
+					f.SetCellValue(sheetLVSRealServers, statusCell, "已摘除")
+					f.SetCellStyle(sheetLVSRealServers, statusCell, statusCell, warningStyle)
+				default:
+					f.SetCellValue(sheetLVSRealServers, statusCell, "正常")
+				}
+				row++
+			}
+		}
+	}
+
+	return nil
+}
+
+// createLVSAlertsSheet creates the LVS alerts worksheet.
+func (w *Writer) createLVSAlertsSheet(f *excelize.File, result *model.LVSInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetLVSAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"调度器", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetLVSAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetLVSAlerts, cell, header)
+		f.SetCellStyle(sheetLVSAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetLVSAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.LVSAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetLVSAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetLVSAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetLVSAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetLVSAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetLVSAlerts, "E"+fmt.Sprint(row), formatLVSThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetLVSAlerts, "F"+fmt.Sprint(row), formatLVSThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetLVSAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetLVSAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetLVSAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetLVSAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteLVSInspection generates a standalone Excel report for LVS inspection.
+func (w *Writer) WriteLVSInspection(result *model.LVSInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("LVS inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createLVSSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create LVS sheet: %w", err)
+	}
+
+	if err := w.createLVSRealServersSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create LVS real servers sheet: %w", err)
+	}
+
+	if err := w.createLVSAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create LVS alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to LVS
+	idx, _ := f.GetSheetIndex(sheetLVS)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendLVSInspection appends LVS sheets to an existing Excel file.
+func (w *Writer) AppendLVSInspection(result *model.LVSInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("LVS inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createLVSSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create LVS sheet: %w", err)
+	}
+
+	if err := w.createLVSRealServersSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create LVS real servers sheet: %w", err)
+	}
+
+	if err := w.createLVSAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create LVS alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// Windows Report Helper Functions
+// ============================================================================
+
+// windowsStatusText converts Windows host status to Chinese text.
+func windowsStatusText(status model.WindowsInstanceStatus) string {
+	switch status {
+	case model.WindowsStatusNormal:
+		return "正常"
+	case model.WindowsStatusWarning:
+		return "警告"
+	case model.WindowsStatusCritical:
+		return "严重"
+	case model.WindowsStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatWindowsThreshold formats a Windows alert threshold value.
+func formatWindowsThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "windows_stopped_services", "windows_stopped_app_pools":
+		return "未运行"
+	case "windows_iis_requests_queued":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// windowsNamesText joins object names for display, "-" if there are none.
+func windowsNamesText(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// createWindowsSheet creates the Windows inspection worksheet.
+func (w *Writer) createWindowsSheet(f *excelize.File, result *model.WindowsInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetWindows)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (10 columns)
+	headers := []string{
+		"巡检时间", "主机名", "IP", "监控服务数", "未运行服务数",
+		"未运行服务", "应用程序池数", "未运行应用程序池", "请求队列", "整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 20, "C": 16, "D": 12, "E": 14,
+		"F": 30, "G": 14, "H": 30, "I": 12, "J": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetWindows, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetWindows, cell, header)
+		f.SetCellStyle(sheetWindows, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetWindows, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetWindows, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetWindows, "B"+rowStr, r.Instance.Hostname)
+		f.SetCellValue(sheetWindows, "C"+rowStr, r.Instance.IP)
+		f.SetCellValue(sheetWindows, "D"+rowStr, len(r.Services))
+		w.writeWindowsMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "J" + rowStr
+		f.SetCellValue(sheetWindows, statusCell, windowsStatusText(r.Status))
+
+		switch r.Status {
+		case model.WindowsStatusCritical:
+			f.SetCellStyle(sheetWindows, statusCell, statusCell, criticalStyle)
+		case model.WindowsStatusWarning:
+			f.SetCellStyle(sheetWindows, statusCell, statusCell, warningStyle)
+		case model.WindowsStatusNormal:
+			f.SetCellStyle(sheetWindows, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeWindowsMetricCells writes the service and IIS columns (E-I) of a Windows row,
+// highlighting cells that have a corresponding alert.
+func (w *Writer) writeWindowsMetricCells(f *excelize.File, rowStr string, r *model.WindowsInspectionResult, warningStyle, criticalStyle int) {
+	appPools := "N/A"
+	if r.HasIIS() {
+		appPools = fmt.Sprint(len(r.AppPools))
+	}
+
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"E", "windows_stopped_services", fmt.Sprint(r.StoppedServices)},
+		{"F", "windows_stopped_services", windowsNamesText(r.StoppedServiceNames())},
+		{"G", "", appPools},
+		{"H", "windows_stopped_app_pools", windowsNamesText(r.StoppedAppPoolNames())},
+		{"I", "windows_iis_requests_queued", formatCassandraCount(r.RequestsQueued, r.HasRequestsQueued())},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetWindows, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetWindows, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetWindows, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createWindowsAlertsSheet creates the Windows alerts worksheet.
+func (w *Writer) createWindowsAlertsSheet(f *excelize.File, result *model.WindowsInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetWindowsAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"主机名", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetWindowsAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetWindowsAlerts, cell, header)
+		f.SetCellStyle(sheetWindowsAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetWindowsAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.WindowsAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetWindowsAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetWindowsAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetWindowsAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetWindowsAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetWindowsAlerts, "E"+fmt.Sprint(row), formatWindowsThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetWindowsAlerts, "F"+fmt.Sprint(row), formatWindowsThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetWindowsAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetWindowsAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetWindowsAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetWindowsAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteWindowsInspection generates a standalone Excel report for Windows inspection.
+func (w *Writer) WriteWindowsInspection(result *model.WindowsInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("Windows inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createWindowsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Windows sheet: %w", err)
+	}
+
+	if err := w.createWindowsAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Windows alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to Windows
+	idx, _ := f.GetSheetIndex(sheetWindows)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendWindowsInspection appends Windows sheets to an existing Excel file.
+func (w *Writer) AppendWindowsInspection(result *model.WindowsInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("Windows inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createWindowsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Windows sheet: %w", err)
+	}
+
+	if err := w.createWindowsAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create Windows alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// AD Report Helper Functions
+// ============================================================================
+
+// adStatusText converts domain controller status to Chinese text.
+func adStatusText(status model.ADInstanceStatus) string {
+	switch status {
+	case model.ADStatusNormal:
+		return "正常"
+	case model.ADStatusWarning:
+		return "警告"
+	case model.ADStatusCritical:
+		return "严重"
+	case model.ADStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatADThreshold formats an AD alert threshold value.
+func formatADThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "ad_replication_failures":
+		return "复制失败"
+	case "ad_ldap_probe_success":
+		return "探测失败"
+	case "ad_ldap_bind_latency":
+		return fmt.Sprintf("%.0fms", value*1000)
+	case "ad_sysvol_free_percent":
+		return fmt.Sprintf("%.0f%%", value)
+	case "ad_replication_pending":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// adLDAPText formats the LDAP probe result of a domain controller.
+func adLDAPText(r *model.ADInspectionResult) string {
+	switch {
+	case !r.HasLDAPProbe():
+		return "N/A"
+	case r.IsLDAPUnreachable():
+		return "失败"
+	default:
+		return "成功"
+	}
+}
+
+// formatADLatency formats the LDAP bind latency in milliseconds.
+func formatADLatency(r *model.ADInspectionResult) string {
+	if !r.HasLDAPBindLatency() {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.0fms", r.LDAPBindLatency*1000)
+}
+
+// formatADSysvolFree formats the SYSVOL free space percentage.
+func formatADSysvolFree(r *model.ADInspectionResult) string {
+	if !r.HasSysvolFree() {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", r.SysvolFreePercent)
+}
+
+// createADSheet creates the AD inspection worksheet.
+func (w *Writer) createADSheet(f *excelize.File, result *model.ADInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetAD)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (9 columns)
+	headers := []string{
+		"巡检时间", "主机名", "IP", "待处理复制同步", "复制失败伙伴",
+		"LDAP 探测", "LDAP 绑定延迟", "SYSVOL 剩余空间", "整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 20, "C": 16, "D": 16, "E": 14,
+		"F": 12, "G": 14, "H": 16, "I": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetAD, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetAD, cell, header)
+		f.SetCellStyle(sheetAD, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetAD, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetAD, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetAD, "B"+rowStr, r.Instance.Hostname)
+		f.SetCellValue(sheetAD, "C"+rowStr, r.Instance.IP)
+		w.writeADMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "I" + rowStr
+		f.SetCellValue(sheetAD, statusCell, adStatusText(r.Status))
+
+		switch r.Status {
+		case model.ADStatusCritical:
+			f.SetCellStyle(sheetAD, statusCell, statusCell, criticalStyle)
+		case model.ADStatusWarning:
+			f.SetCellStyle(sheetAD, statusCell, statusCell, warningStyle)
+		case model.ADStatusNormal:
+			f.SetCellStyle(sheetAD, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeADMetricCells writes the replication, LDAP and SYSVOL columns (D-H) of an AD row,
+// highlighting cells that have a corresponding alert.
+func (w *Writer) writeADMetricCells(f *excelize.File, rowStr string, r *model.ADInspectionResult, warningStyle, criticalStyle int) {
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"D", "ad_replication_pending", formatCassandraCount(r.ReplicationPending, r.HasReplicationPending())},
+		{"E", "ad_replication_failures", formatCassandraCount(r.ReplicationFailures, r.HasReplicationFailures())},
+		{"F", "ad_ldap_probe_success", adLDAPText(r)},
+		{"G", "ad_ldap_bind_latency", formatADLatency(r)},
+		{"H", "ad_sysvol_free_percent", formatADSysvolFree(r)},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetAD, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetAD, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetAD, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createADAlertsSheet creates the AD alerts worksheet.
+func (w *Writer) createADAlertsSheet(f *excelize.File, result *model.ADInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetADAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"主机名", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetADAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetADAlerts, cell, header)
+		f.SetCellStyle(sheetADAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetADAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.ADAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetADAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetADAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetADAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetADAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetADAlerts, "E"+fmt.Sprint(row), formatADThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetADAlerts, "F"+fmt.Sprint(row), formatADThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetADAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetADAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetADAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetADAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteADInspection generates a standalone Excel report for AD inspection.
+func (w *Writer) WriteADInspection(result *model.ADInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("AD inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createADSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create AD sheet: %w", err)
+	}
+
+	if err := w.createADAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create AD alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to AD
+	idx, _ := f.GetSheetIndex(sheetAD)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendADInspection appends AD sheets to an existing Excel file.
+func (w *Writer) AppendADInspection(result *model.ADInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("AD inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createADSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create AD sheet: %w", err)
+	}
+
+	if err := w.createADAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create AD alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// Cloud Report Helper Functions
+// ============================================================================
+
+// cloudStatusText converts cloud resource status to Chinese text.
+func cloudStatusText(status model.CloudInstanceStatus) string {
+	switch status {
+	case model.CloudStatusNormal:
+		return "正常"
+	case model.CloudStatusWarning:
+		return "警告"
+	case model.CloudStatusCritical:
+		return "严重"
+	case model.CloudStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatCloudThreshold formats a cloud resource alert threshold value.
+func formatCloudThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "unhealthy_backends":
+		return "存在不健康后端"
+	case "cpu_usage", "memory_usage", "connection_usage", "disk_usage":
+		return fmt.Sprintf("%.0f%%", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// formatCloudPercent formats a cloud resource utilization percentage.
+func formatCloudPercent(value float64, collected bool) string {
+	if !collected {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", value)
+}
+
+// createCloudSheet creates the cloud resource inspection worksheet.
+func (w *Writer) createCloudSheet(f *excelize.File, result *model.CloudInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetCloud)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (13 columns)
+	headers := []string{
+		"巡检时间", "资源 ID", "资源名称", "云厂商", "资源类型", "地域",
+		"CPU 使用率", "内存使用率", "连接数使用率", "存储使用率", "活跃连接数", "不健康后端",
+		"整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 26, "C": 20, "D": 10, "E": 10, "F": 14,
+		"G": 12, "H": 12, "I": 14, "J": 12, "K": 12, "L": 12, "M": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetCloud, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetCloud, cell, header)
+		f.SetCellStyle(sheetCloud, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetCloud, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetCloud, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetCloud, "B"+rowStr, r.Instance.ResourceID)
+		f.SetCellValue(sheetCloud, "C"+rowStr, r.Instance.Name)
+		f.SetCellValue(sheetCloud, "D"+rowStr, model.CloudProviderText(r.Instance.Provider))
+		f.SetCellValue(sheetCloud, "E"+rowStr, model.CloudResourceTypeText(r.Instance.ResourceType))
+		f.SetCellValue(sheetCloud, "F"+rowStr, r.Instance.Region)
+		w.writeCloudMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "M" + rowStr
+		f.SetCellValue(sheetCloud, statusCell, cloudStatusText(r.Status))
+
+		switch r.Status {
+		case model.CloudStatusCritical:
+			f.SetCellStyle(sheetCloud, statusCell, statusCell, criticalStyle)
+		case model.CloudStatusWarning:
+			f.SetCellStyle(sheetCloud, statusCell, statusCell, warningStyle)
+		case model.CloudStatusNormal:
+			f.SetCellStyle(sheetCloud, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeCloudMetricCells writes the utilization and backend columns (G-L) of a cloud
+// resource row, highlighting cells that have a corresponding alert.
+func (w *Writer) writeCloudMetricCells(f *excelize.File, rowStr string, r *model.CloudInspectionResult, warningStyle, criticalStyle int) {
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"G", "cpu_usage", formatCloudPercent(r.CPUUsage, r.HasCPUUsage())},
+		{"H", "memory_usage", formatCloudPercent(r.MemoryUsage, r.HasMemoryUsage())},
+		{"I", "connection_usage", formatCloudPercent(r.ConnectionUsage, r.HasConnectionUsage())},
+		{"J", "disk_usage", formatCloudPercent(r.DiskUsage, r.HasDiskUsage())},
+		{"K", "active_connections", formatCassandraCount(r.ActiveConnections, r.HasActiveConnections())},
+		{"L", "unhealthy_backends", formatCassandraCount(r.UnhealthyBackends, r.UnhealthyBackends >= 0)},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetCloud, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetCloud, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetCloud, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createCloudAlertsSheet creates the cloud resource alerts worksheet.
+func (w *Writer) createCloudAlertsSheet(f *excelize.File, result *model.CloudInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetCloudAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"资源 ID", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 25, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetCloudAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetCloudAlerts, cell, header)
+		f.SetCellStyle(sheetCloudAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetCloudAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.CloudAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetCloudAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetCloudAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetCloudAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetCloudAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetCloudAlerts, "E"+fmt.Sprint(row), formatCloudThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetCloudAlerts, "F"+fmt.Sprint(row), formatCloudThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetCloudAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetCloudAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetCloudAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetCloudAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// formatCostWindow formats the cost summary window in Chinese, e.g. "30 天" or "12 小时".
+func formatCostWindow(window time.Duration) string {
+	if window >= 24*time.Hour && window%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d 天", int64(window/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%.0f 小时", window.Hours())
+}
+
+// createCloudCostSheet creates the cloud resource cost / asset summary worksheet:
+// resource counts by provider, type and spec, followed by the under-utilized resources.
+// The sheet is only created when the cost summary is enabled.
+func (w *Writer) createCloudCostSheet(f *excelize.File, result *model.CloudInspectionResults) error {
+	if result == nil || result.CostSummary == nil || len(result.Results) == 0 {
+		return nil
+	}
+	cost := result.CostSummary
+
+	// Create sheet
+	_, err := f.NewSheet(sheetCloudCost)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 26, "B": 20, "C": 12, "D": 12, "E": 14, "F": 22, "G": 16,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetCloudCost, col, col, width)
+	}
+
+	// Criteria row
+	f.SetCellValue(sheetCloudCost, "A1", fmt.Sprintf("统计窗口: %s，低利用率判定: CPU 峰值 < %.0f%%",
+		formatCostWindow(cost.Window), cost.IdleCPUThreshold))
+
+	// Asset groups
+	row := 3
+	writeHeaders := func(headers []string) {
+		for i, header := range headers {
+			cell := fmt.Sprintf("%s%d", columnName(i+1), row)
+			f.SetCellValue(sheetCloudCost, cell, header)
+			f.SetCellStyle(sheetCloudCost, cell, cell, headerStyle)
+		}
+		row++
+	}
+
+	writeHeaders([]string{"云厂商", "资源类型", "实例规格", "资源数", "低利用率资源数"})
+	for _, g := range cost.Groups {
+		rowStr := fmt.Sprint(row)
+		spec := g.Spec
+		if spec == "" {
+			spec = "未知"
+		}
+		f.SetCellValue(sheetCloudCost, "A"+rowStr, model.CloudProviderText(g.Provider))
+		f.SetCellValue(sheetCloudCost, "B"+rowStr, model.CloudResourceTypeText(g.ResourceType))
+		f.SetCellValue(sheetCloudCost, "C"+rowStr, spec)
+		f.SetCellValue(sheetCloudCost, "D"+rowStr, g.Count)
+		f.SetCellValue(sheetCloudCost, "E"+rowStr, g.Underutilized)
+		if g.Underutilized > 0 {
+			f.SetCellStyle(sheetCloudCost, "E"+rowStr, "E"+rowStr, warningStyle)
+		}
+		row++
+	}
+
+	// Under-utilized resources
+	row++
+	if len(cost.Underutilized) == 0 {
+		f.SetCellValue(sheetCloudCost, fmt.Sprintf("A%d", row), "无低利用率资源")
+		return nil
+	}
+
+	writeHeaders([]string{"资源 ID", "资源名称", "云厂商", "资源类型", "地域", "实例规格", "窗口内 CPU 峰值"})
+	for _, r := range cost.Underutilized {
+		rowStr := fmt.Sprint(row)
+		f.SetCellValue(sheetCloudCost, "A"+rowStr, r.Instance.ResourceID)
+		f.SetCellValue(sheetCloudCost, "B"+rowStr, r.Instance.Name)
+		f.SetCellValue(sheetCloudCost, "C"+rowStr, model.CloudProviderText(r.Instance.Provider))
+		f.SetCellValue(sheetCloudCost, "D"+rowStr, model.CloudResourceTypeText(r.Instance.ResourceType))
+		f.SetCellValue(sheetCloudCost, "E"+rowStr, r.Instance.Region)
+		f.SetCellValue(sheetCloudCost, "F"+rowStr, r.Instance.Spec)
+		f.SetCellValue(sheetCloudCost, "G"+rowStr, formatCloudPercent(r.PeakCPUUsage, r.HasPeakCPUUsage()))
+		f.SetCellStyle(sheetCloudCost, "G"+rowStr, "G"+rowStr, warningStyle)
+		row++
+	}
+
+	return nil
+}
+
+// WriteCloudInspection generates a standalone Excel report for cloud resource inspection.
+func (w *Writer) WriteCloudInspection(result *model.CloudInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("cloud inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createCloudSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create cloud sheet: %w", err)
+	}
+
+	if err := w.createCloudAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create cloud alerts sheet: %w", err)
+	}
+
+	if err := w.createCloudCostSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create cloud cost sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to cloud resources
+	idx, _ := f.GetSheetIndex(sheetCloud)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendCloudInspection appends cloud resource sheets to an existing Excel file.
+func (w *Writer) AppendCloudInspection(result *model.CloudInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("cloud inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createCloudSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create cloud sheet: %w", err)
+	}
+
+	if err := w.createCloudAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create cloud alerts sheet: %w", err)
+	}
+
+	if err := w.createCloudCostSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create cloud cost sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// =============================================================================
+// Firewall Report Helper Functions
+// ============================================================================
+
+// firewallStatusText converts firewall device status to Chinese text.
+func firewallStatusText(status model.FirewallDeviceStatus) string {
+	switch status {
+	case model.FirewallStatusNormal:
+		return "正常"
+	case model.FirewallStatusWarning:
+		return "警告"
+	case model.FirewallStatusCritical:
+		return "严重"
+	case model.FirewallStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// formatFirewallThreshold formats a firewall device alert threshold value.
+func formatFirewallThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "vpn_tunnel_status":
+		return "存在断开隧道"
+	case "ha_status":
+		return "HA 状态异常"
+	case "cpu_usage", "memory_usage", "session_usage":
+		return fmt.Sprintf("%.0f%%", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// formatFirewallTunnels formats the VPN tunnel count of a device as "down/total".
+func formatFirewallTunnels(r *model.FirewallInspectionResult) string {
+	if len(r.Tunnels) == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d/%d", r.TunnelsDown, len(r.Tunnels))
+}
+
+// createFirewallSheet creates the firewall device inspection worksheet.
+func (w *Writer) createFirewallSheet(f *excelize.File, result *model.FirewallInspectionResults) error {
+	if result == nil || len(result.Results) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetFirewall)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	normalStyle, err := w.createNormalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers (11 columns)
+	headers := []string{
+		"巡检时间", "设备地址", "设备名称", "设备类型",
+		"CPU 使用率", "内存使用率", "会话表使用率", "当前会话数", "VPN 隧道（断开/总数）", "HA 状态",
+		"整体状态",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": w.inspectionTimeWidth(20), "B": 20, "C": 20, "D": 14,
+		"E": 12, "F": 12, "G": 14, "H": 12, "I": 22, "J": 14, "K": 12,
+	}
+
+	for col, width := range colWidths {
+		f.SetColWidth(sheetFirewall, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetFirewall, cell, header)
+		f.SetCellStyle(sheetFirewall, cell, cell, headerStyle)
+	}
+
+	// Freeze header row
+	f.SetPanes(sheetFirewall, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write data rows
+	for i, r := range result.Results {
+		row := i + 2
+		rowStr := fmt.Sprint(row)
+		inspectionTime := w.formatInspectionTime(result.InspectionTime)
+
+		f.SetCellValue(sheetFirewall, "A"+rowStr, inspectionTime)
+		f.SetCellValue(sheetFirewall, "B"+rowStr, r.Device.Address)
+		f.SetCellValue(sheetFirewall, "C"+rowStr, r.Device.Name)
+		f.SetCellValue(sheetFirewall, "D"+rowStr, model.FirewallProfileText(r.Device.Profile))
+		w.writeFirewallMetricCells(f, rowStr, r, warningStyle, criticalStyle)
+
+		// Status column with conditional formatting
+		statusCell := "K" + rowStr
+		f.SetCellValue(sheetFirewall, statusCell, firewallStatusText(r.Status))
+
+		switch r.Status {
+		case model.FirewallStatusCritical:
+			f.SetCellStyle(sheetFirewall, statusCell, statusCell, criticalStyle)
+		case model.FirewallStatusWarning:
+			f.SetCellStyle(sheetFirewall, statusCell, statusCell, warningStyle)
+		case model.FirewallStatusNormal:
+			f.SetCellStyle(sheetFirewall, statusCell, statusCell, normalStyle)
+		}
+	}
+
+	return nil
+}
+
+// writeFirewallMetricCells writes the utilization, session, tunnel and HA columns (E-J)
+// of a firewall device row, highlighting cells that have a corresponding alert.
+func (w *Writer) writeFirewallMetricCells(f *excelize.File, rowStr string, r *model.FirewallInspectionResult, warningStyle, criticalStyle int) {
+	cells := []struct {
+		col    string
+		metric string
+		value  string
+	}{
+		{"E", "cpu_usage", formatCloudPercent(r.CPUUsage, r.HasCPUUsage())},
+		{"F", "memory_usage", formatCloudPercent(r.MemoryUsage, r.HasMemoryUsage())},
+		{"G", "session_usage", formatCloudPercent(r.SessionUsage, r.HasSessionUsage())},
+		{"H", "sessions", formatCassandraCount(r.Sessions, r.HasSessions())},
+		{"I", "vpn_tunnel_status", formatFirewallTunnels(r)},
+		{"J", "ha_status", r.HAText()},
+	}
+
+	for _, c := range cells {
+		cell := c.col + rowStr
+		f.SetCellValue(sheetFirewall, cell, c.value)
+		for _, alert := range r.Alerts {
+			if alert.MetricName != c.metric {
+				continue
+			}
+			switch alert.Level {
+			case model.AlertLevelCritical:
+				f.SetCellStyle(sheetFirewall, cell, cell, criticalStyle)
+			case model.AlertLevelWarning:
+				f.SetCellStyle(sheetFirewall, cell, cell, warningStyle)
+			}
+		}
+	}
+}
+
+// createFirewallTunnelsSheet creates the VPN tunnel detail worksheet, one row per tunnel.
+// The sheet is only created when at least one device reports VPN tunnels.
+func (w *Writer) createFirewallTunnelsSheet(f *excelize.File, result *model.FirewallInspectionResults) error {
+	hasData := false
+	for _, r := range result.Results {
+		if len(r.Tunnels) > 0 {
+			hasData = true
+			break
+		}
+	}
+	if !hasData {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetFirewallTunnels)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"设备地址", "设备名称", "隧道名称", "状态"}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 20, "B": 20, "C": 30, "D": 10,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetFirewallTunnels, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetFirewallTunnels, cell, header)
+		f.SetCellStyle(sheetFirewallTunnels, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetFirewallTunnels, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Write tunnel rows
+	row := 2
+	for _, r := range result.Results {
+		for _, t := range r.Tunnels {
+			rowStr := fmt.Sprint(row)
+			f.SetCellValue(sheetFirewallTunnels, "A"+rowStr, r.Device.Address)
+			f.SetCellValue(sheetFirewallTunnels, "B"+rowStr, r.Device.Name)
+			f.SetCellValue(sheetFirewallTunnels, "C"+rowStr, t.Name)
+
+			statusCell := "D" + rowStr
+			if t.Up {
+				f.SetCellValue(sheetFirewallTunnels, statusCell, "正常")
+			} else {
+				f.SetCellValue(sheetFirewallTunnels, statusCell, "断开")
+				f.SetCellStyle(sheetFirewallTunnels, statusCell, statusCell, criticalStyle)
+			}
+			row++
+		}
+	}
+
+	return nil
+}
+
+// createFirewallAlertsSheet creates the firewall device alerts worksheet.
+func (w *Writer) createFirewallAlertsSheet(f *excelize.File, result *model.FirewallInspectionResults) error {
+	if result == nil || len(result.Alerts) == 0 {
+		return nil
+	}
+
+	// Create sheet
+	_, err := f.NewSheet(sheetFirewallAlerts)
+	if err != nil {
+		return err
+	}
+
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"设备地址", "告警级别", "指标名称", "当前值",
+		"警告阈值", "严重阈值", "告警消息", "处理建议",
+	}
+
+	// Set column widths
+	colWidths := map[string]float64{
+		"A": 20, "B": 12, "C": 20, "D": 15, "E": 15, "F": 15, "G": 40, "H": remediationColWidth,
+	}
+	for col, width := range colWidths {
+		f.SetColWidth(sheetFirewallAlerts, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetFirewallAlerts, cell, header)
+		f.SetCellStyle(sheetFirewallAlerts, cell, cell, headerStyle)
+	}
+
+	f.SetPanes(sheetFirewallAlerts, &excelize.Panes{Freeze: true, YSplit: 1})
+
+	// Sort alerts: critical first, then by identifier
+	alerts := make([]*model.FirewallAlert, len(result.Alerts))
+	copy(alerts, result.Alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Level != alerts[j].Level {
+			return alertLevelPriority(alerts[i].Level) > alertLevelPriority(alerts[j].Level)
+		}
+		return alerts[i].Identifier < alerts[j].Identifier
+	})
+
+	// Write alert rows
+	for i, alert := range alerts {
+		row := i + 2
+		f.SetCellValue(sheetFirewallAlerts, "A"+fmt.Sprint(row), alert.Identifier)
+		f.SetCellValue(sheetFirewallAlerts, "B"+fmt.Sprint(row), alertLevelText(alert.Level))
+		f.SetCellValue(sheetFirewallAlerts, "C"+fmt.Sprint(row), alert.MetricDisplayName)
+		f.SetCellValue(sheetFirewallAlerts, "D"+fmt.Sprint(row), alert.FormattedValue)
+		f.SetCellValue(sheetFirewallAlerts, "E"+fmt.Sprint(row), formatFirewallThreshold(alert.WarningThreshold, alert.MetricName))
+		f.SetCellValue(sheetFirewallAlerts, "F"+fmt.Sprint(row), formatFirewallThreshold(alert.CriticalThreshold, alert.MetricName))
+		f.SetCellValue(sheetFirewallAlerts, "G"+fmt.Sprint(row), alert.Message)
+		f.SetCellValue(sheetFirewallAlerts, "H"+fmt.Sprint(row), w.remediation(alert.MetricName))
+
+		// Color code the level column
+		levelCell := "B" + fmt.Sprint(row)
+		switch alert.Level {
+		case model.AlertLevelCritical:
+			f.SetCellStyle(sheetFirewallAlerts, levelCell, levelCell, criticalStyle)
+		case model.AlertLevelWarning:
+			f.SetCellStyle(sheetFirewallAlerts, levelCell, levelCell, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// WriteFirewallInspection generates a standalone Excel report for firewall inspection.
+func (w *Writer) WriteFirewallInspection(result *model.FirewallInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("firewall inspection result is nil")
+	}
+
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".xlsx") {
+		outputPath = outputPath + ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := w.createFirewallSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create firewall sheet: %w", err)
+	}
+
+	if err := w.createFirewallTunnelsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create firewall tunnels sheet: %w", err)
+	}
+
+	if err := w.createFirewallAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create firewall alerts sheet: %w", err)
+	}
+
+	// Remove default Sheet1
+	if err := f.DeleteSheet(defaultSheet); err != nil {
+		// Ignore error
+	}
+
+	// Set active sheet to firewall devices
+	idx, _ := f.GetSheetIndex(sheetFirewall)
+	f.SetActiveSheet(idx)
+
+	return w.saveAs(f, outputPath)
+}
+
+// AppendFirewallInspection appends firewall sheets to an existing Excel file.
+func (w *Writer) AppendFirewallInspection(result *model.FirewallInspectionResults, existingPath string) error {
+	if result == nil {
+		return fmt.Errorf("firewall inspection result is nil")
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createFirewallSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create firewall sheet: %w", err)
+	}
+
+	if err := w.createFirewallTunnelsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create firewall tunnels sheet: %w", err)
+	}
+
+	if err := w.createFirewallAlertsSheet(f, result); err != nil {
+		return fmt.Errorf("failed to create firewall alerts sheet: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// ============================================================================
+// Raw Data Sheet
+// ============================================================================
+
+// metricStatusText converts metric status to Chinese text.
+func metricStatusText(status model.MetricStatus) string {
+	switch status {
+	case model.MetricStatusNormal:
+		return "正常"
+	case model.MetricStatusWarning:
+		return "警告"
+	case model.MetricStatusCritical:
+		return "严重"
+	case model.MetricStatusPending:
+		return "N/A"
+	case model.MetricStatusAnomaly:
+		return "数据异常"
+	default:
+		return "未知"
+	}
+}
+
+// createRawDataSheet creates the raw data worksheet in long/tidy format,
+// one row per (target, metric) observation, suitable for pivot tables.
+func (w *Writer) createRawDataSheet(f *excelize.File, records []*model.RawDataRecord) error {
+	// Create sheet
+	_, err := f.NewSheet(sheetRawData)
+	if err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers
+	headers := []string{"模块", "巡检对象", "指标", "值", "单位", "状态", "采集时间", "查询语句"}
+
+	// Set column widths
+	colWidths := []float64{10, 25, 25, 15, 10, 10, 20, 60}
+	for i, width := range colWidths {
+		col := columnName(i + 1)
+		f.SetColWidth(sheetRawData, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetRawData, cell, header)
+		f.SetCellStyle(sheetRawData, cell, cell, headerStyle)
+	}
+	f.SetRowHeight(sheetRawData, 1, 25)
+
+	// Freeze header row
+	f.SetPanes(sheetRawData, &excelize.Panes{
+		Freeze:      true,
+		Split:       false,
+		XSplit:      0,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+
+	// Write data rows (numeric values stay numeric so pivot tables can aggregate them)
+	row := 2
+	for _, rec := range records {
+		if rec == nil {
+			continue
+		}
+		rowStr := fmt.Sprintf("%d", row)
+
+		f.SetCellValue(sheetRawData, "A"+rowStr, rec.Module)
+		f.SetCellValue(sheetRawData, "B"+rowStr, rec.Target)
+		f.SetCellValue(sheetRawData, "C"+rowStr, rec.Metric)
+		switch {
+		case rec.IsNA:
+			f.SetCellValue(sheetRawData, "D"+rowStr, "N/A")
+		case rec.Text != "":
+			f.SetCellValue(sheetRawData, "D"+rowStr, rec.Text)
+		default:
+			f.SetCellValue(sheetRawData, "D"+rowStr, rec.Value)
+		}
+		f.SetCellValue(sheetRawData, "E"+rowStr, rec.Unit)
+		f.SetCellValue(sheetRawData, "F"+rowStr, metricStatusText(rec.Status))
+		if !rec.Timestamp.IsZero() {
+			f.SetCellValue(sheetRawData, "G"+rowStr, rec.Timestamp.In(w.timezone).Format("2006-01-02 15:04:05"))
+		}
+		f.SetCellValue(sheetRawData, "H"+rowStr, rec.Query)
+		row++
+	}
+
+	// Enable auto filter on the data range
+	if row > 2 {
+		f.AutoFilter(sheetRawData, fmt.Sprintf("A1:H%d", row-1), nil)
+	}
+
+	return nil
+}
+
+// AppendRawDataSheet appends the raw data sheet to an existing Excel file.
+func (w *Writer) AppendRawDataSheet(records []*model.RawDataRecord, existingPath string) error {
+	// Ensure path has .xlsx extension
+	if !strings.HasSuffix(strings.ToLower(existingPath), ".xlsx") {
+		existingPath = existingPath + ".xlsx"
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createRawDataSheet(f, records); err != nil {
+		return fmt.Errorf("failed to create raw data sheet: %w", err)
+	}
+	// Localize before linking the sheet from the table of contents
+	if err := w.localize(f); err != nil {
+		return fmt.Errorf("failed to localize raw data sheet: %w", err)
+	}
+	if err := w.appendTOCEntry(f, w.tr.SheetName(sheetRawData), "原始数据"); err != nil {
+		return fmt.Errorf("failed to update table of contents: %w", err)
+	}
+
+	return w.save(f)
+}
+
+// ============================================================================
+// Table of Contents
+// ============================================================================
+
+// Module keys used by WithSheetOrder, in the default sheet order.
+const (
+	ModuleHost       = "host"
+	ModuleMySQL      = "mysql"
+	ModuleRedis      = "redis"
+	ModuleNginx      = "nginx"
+	ModuleTomcat     = "tomcat"
+	ModuleCassandra  = "cassandra"
+	ModuleMonitoring = "monitoring"
+	ModuleStorage    = "storage"
+	ModuleLogChecks  = "log_checks"
+	ModuleLVS        = "lvs"
+	ModuleWindows    = "windows"
+	ModuleAD         = "ad"
+	ModuleCloud      = "cloud"
+	ModuleFirewall   = "firewall"
+)
+
+// tocEntry describes one sheet listed in the table of contents.
+type tocEntry struct {
+	key        string // Module key (see ModuleHost etc.)
+	module     string // 所属模块
+	showAlerts bool   // Whether the module alert counts are shown for this sheet
+	critical   int
+	warning    int
+	empty      bool // The module found no instances
+}
+
+// buildTOCEntries maps every sheet a combined workbook may contain to its module and alert counts.
+// Alert counts are attached to the module's inspection and alerts sheets; auxiliary sheets
+// (MGR members, upstreams, real servers, cost, VPN tunnels) only carry the module name.
+func buildTOCEntries(report *json.Report) map[string]tocEntry {
+	entries := make(map[string]tocEntry)
+	add := func(key, module string, instances, critical, warning int, mainSheets []string, auxSheets ...string) {
+		for _, sheet := range mainSheets {
+			entries[sheet] = tocEntry{key: key, module: module, showAlerts: true, critical: critical, warning: warning, empty: instances == 0}
+		}
+		for _, sheet := range auxSheets {
+			entries[sheet] = tocEntry{key: key, module: module, empty: instances == 0}
+		}
+	}
+
+	if report.Host != nil && report.Host.AlertSummary != nil {
+		add(ModuleHost, "主机", len(report.Host.Hosts), report.Host.AlertSummary.CriticalCount, report.Host.AlertSummary.WarningCount, []string{sheetSummary, sheetDetail, sheetAlerts}, sheetActiveAlerts, sheetCharts, sheetTrend, sheetSourceConflicts, sheetBusinessGroups, sheetOwnerGroups, sheetApplicationGroups, sheetLocationGroups, sheetCapacity)
+	}
+	if report.MySQL != nil && report.MySQL.AlertSummary != nil {
+		add(ModuleMySQL, "MySQL", len(report.MySQL.Results), report.MySQL.AlertSummary.CriticalCount, report.MySQL.AlertSummary.WarningCount, []string{sheetMySQL, sheetMySQLAlerts}, sheetMySQLMGRMembers, sheetMySQLTopology)
+	}
+	if report.Redis != nil && report.Redis.AlertSummary != nil {
+		add(ModuleRedis, "Redis", len(report.Redis.Results), report.Redis.AlertSummary.CriticalCount, report.Redis.AlertSummary.WarningCount, []string{sheetRedis, sheetRedisAlerts})
+	}
+	if report.Nginx != nil && report.Nginx.AlertSummary != nil {
+		add(ModuleNginx, "Nginx", len(report.Nginx.Results), report.Nginx.AlertSummary.CriticalCount, report.Nginx.AlertSummary.WarningCount, []string{sheetNginx, sheetNginxAlerts}, sheetNginxUpstream)
+	}
+	if report.Tomcat != nil && report.Tomcat.AlertSummary != nil {
+		add(ModuleTomcat, "Tomcat", len(report.Tomcat.Results), report.Tomcat.AlertSummary.CriticalCount, report.Tomcat.AlertSummary.WarningCount, []string{sheetTomcat, sheetTomcatAlerts})
+	}
+	if report.Cassandra != nil && report.Cassandra.AlertSummary != nil {
+		add(ModuleCassandra, "Cassandra", len(report.Cassandra.Results), report.Cassandra.AlertSummary.CriticalCount, report.Cassandra.AlertSummary.WarningCount, []string{sheetCassandra, sheetCassandraAlerts})
+	}
+	if report.Monitoring != nil && report.Monitoring.AlertSummary != nil {
+		add(ModuleMonitoring, "监控系统", len(report.Monitoring.Results), report.Monitoring.AlertSummary.CriticalCount, report.Monitoring.AlertSummary.WarningCount, []string{sheetMonitoring, sheetMonitoringAlerts})
+	}
+	if report.Storage != nil && report.Storage.AlertSummary != nil {
+		add(ModuleStorage, "共享存储", len(report.Storage.Results), report.Storage.AlertSummary.CriticalCount, report.Storage.AlertSummary.WarningCount, []string{sheetStorage, sheetStorageAlerts})
+	}
+	if report.LogChecks != nil && report.LogChecks.AlertSummary != nil {
+		add(ModuleLogChecks, "日志巡检", len(report.LogChecks.Results), report.LogChecks.AlertSummary.CriticalCount, report.LogChecks.AlertSummary.WarningCount, []string{sheetLogChecks, sheetLogCheckAlerts})
+	}
+	if report.LVS != nil && report.LVS.AlertSummary != nil {
+		add(ModuleLVS, "LVS", len(report.LVS.Results), report.LVS.AlertSummary.CriticalCount, report.LVS.AlertSummary.WarningCount, []string{sheetLVS, sheetLVSAlerts}, sheetLVSRealServers)
+	}
+	if report.Windows != nil && report.Windows.AlertSummary != nil {
+		add(ModuleWindows, "Windows", len(report.Windows.Results), report.Windows.AlertSummary.CriticalCount, report.Windows.AlertSummary.WarningCount, []string{sheetWindows, sheetWindowsAlerts})
+	}
+	if report.AD != nil && report.AD.AlertSummary != nil {
+		add(ModuleAD, "AD", len(report.AD.Results), report.AD.AlertSummary.CriticalCount, report.AD.AlertSummary.WarningCount, []string{sheetAD, sheetADAlerts})
+	}
+	if report.Cloud != nil && report.Cloud.AlertSummary != nil {
+		add(ModuleCloud, "云资源", len(report.Cloud.Results), report.Cloud.AlertSummary.CriticalCount, report.Cloud.AlertSummary.WarningCount, []string{sheetCloud, sheetCloudAlerts}, sheetCloudCost)
+	}
+	if report.Firewall != nil && report.Firewall.AlertSummary != nil {
+		add(ModuleFirewall, "防火墙", len(report.Firewall.Results), report.Firewall.AlertSummary.CriticalCount, report.Firewall.AlertSummary.WarningCount, []string{sheetFirewall, sheetFirewallAlerts}, sheetFirewallTunnels)
+	}
+	// Alertmanager alerts span all modules
+	entries[sheetAlertmanager] = tocEntry{module: "Alertmanager"}
+
+	return entries
+}
+
+// lookupTOCEntry returns the entry of sheet. Per-cluster Redis sheets ("Redis-{网段}")
+// belong to the Redis module and carry no alert counts.
+func lookupTOCEntry(entries map[string]tocEntry, sheet string) (tocEntry, bool) {
+	if entry, ok := entries[sheet]; ok {
+		return entry, true
+	}
+	if strings.HasPrefix(sheet, "Redis-") {
+		for _, entry := range entries {
+			if entry.key == ModuleRedis {
+				return tocEntry{key: ModuleRedis, module: entry.module, empty: entry.empty}, true
+			}
+		}
+		return tocEntry{key: ModuleRedis, module: "Redis"}, true
+	}
+	return tocEntry{}, false
+}
+
+// arrangeSheets moves the sheets of the modules listed by WithSheetOrder to the front, in that
+// order, and hides the sheets of modules without instances when WithHideEmptySheets is set.
+// Sheets of unlisted modules keep their relative order after the listed ones.
+func (w *Writer) arrangeSheets(f *excelize.File, entries map[string]tocEntry) error {
+	if len(w.sheetOrder) > 0 {
+		sheets := f.GetSheetList()
+		desired := make([]string, 0, len(sheets))
+		placed := make(map[string]bool, len(sheets))
+		for _, key := range w.sheetOrder {
+			for _, sheet := range sheets {
+				if entry, ok := lookupTOCEntry(entries, sheet); ok && entry.key == key && !placed[sheet] {
+					desired = append(desired, sheet)
+					placed[sheet] = true
+				}
+			}
+		}
+		for _, sheet := range sheets {
+			if !placed[sheet] {
+				desired = append(desired, sheet)
+			}
+		}
+
+		for i, sheet := range desired {
+			current := f.GetSheetList()
+			if current[i] != sheet {
+				if err := f.MoveSheet(sheet, current[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if w.hideEmptySheets {
+		sheets := f.GetSheetList()
+		var hide []string
+		for _, sheet := range sheets {
+			if entry, ok := lookupTOCEntry(entries, sheet); ok && entry.empty {
+				hide = append(hide, sheet)
+			}
+		}
+		// A workbook needs at least one visible sheet
+		if len(hide) == len(sheets) {
+			return nil
+		}
+		for _, sheet := range hide {
+			if err := f.SetSheetVisible(sheet, false); err != nil {
+				return err
+			}
+		}
+		if visible, _ := f.GetSheetVisible(f.GetSheetName(f.GetActiveSheetIndex())); !visible {
+			f.SetActiveSheet(firstVisibleSheetIndex(f))
+		}
+	}
+
+	return nil
+}
+
+// visibleSheets returns the visible sheets of the workbook in order.
+func visibleSheets(f *excelize.File) []string {
+	var sheets []string
+	for _, sheet := range f.GetSheetList() {
+		if visible, err := f.GetSheetVisible(sheet); err == nil && visible {
+			sheets = append(sheets, sheet)
+		}
+	}
+	return sheets
+}
+
+// firstVisibleSheetIndex returns the index of the first visible sheet.
+func firstVisibleSheetIndex(f *excelize.File) int {
+	if sheets := visibleSheets(f); len(sheets) > 0 {
+		idx, _ := f.GetSheetIndex(sheets[0])
+		return idx
+	}
+	return 0
+}
+
+// Finalize adds the workbook-level layout and navigation to an existing combined report built
+// with Write and the Append* methods: the "Alertmanager 告警" sheet of WithAlertmanagerAlerts is
+// appended after the module sheets, sheets are reordered and empty module sheets hidden as
+// configured, sheets are localized into the report language, alert badges are appended to sheet
+// names when enabled, and a "目录" sheet is created when the workbook has at least tocMinSheets
+// visible sheets and becomes the active sheet. A "管理摘要" sheet with the top risks of all
+// modules is placed first, right after the table of contents. With WithCover, a "封面" sheet
+// precedes them all and is the active sheet instead.
+func (w *Writer) Finalize(report *json.Report, existingPath string) error {
+	// Ensure path has .xlsx extension
+	if !strings.HasSuffix(strings.ToLower(existingPath), ".xlsx") {
+		existingPath = existingPath + ".xlsx"
+	}
+
+	f, err := w.openFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to open existing file: %w", err)
+	}
+	defer f.Close()
+
+	if err := w.createAlertmanagerSheet(f); err != nil {
+		return fmt.Errorf("failed to create Alertmanager sheet: %w", err)
+	}
+
+	// Localize sheet names before anything links to them
+	if err := w.localize(f); err != nil {
+		return fmt.Errorf("failed to localize workbook: %w", err)
+	}
+
+	tocEntries := buildTOCEntries(report)
+	w.localizeTOCEntries(tocEntries)
+	if err := w.arrangeSheets(f, tocEntries); err != nil {
+		return fmt.Errorf("failed to arrange sheets: %w", err)
+	}
+	if _, err := w.applySheetBadges(f, tocEntries); err != nil {
+		return fmt.Errorf("failed to add alert badges to sheet names: %w", err)
+	}
+	alerts := json.FlattenAlerts(report)
+	if err := w.createExecutiveSummarySheet(f, json.NewExecutiveSummary(alerts)); err != nil {
+		return fmt.Errorf("failed to create executive summary sheet: %w", err)
+	}
+	if err := w.createTOCSheet(f, tocEntries); err != nil {
+		return fmt.Errorf("failed to create table of contents sheet: %w", err)
+	}
+	if err := w.createCoverSheet(f); err != nil {
+		return fmt.Errorf("failed to create cover sheet: %w", err)
+	}
+	if idx, _ := f.GetSheetIndex(w.tr.SheetName(sheetCover)); idx >= 0 {
+		f.SetActiveSheet(idx)
+	} else if idx, _ := f.GetSheetIndex(sheetTOC); idx >= 0 {
+		f.SetActiveSheet(idx)
+	} else if idx, _ := f.GetSheetIndex(w.tr.SheetName(sheetExecutiveSummary)); idx >= 0 {
+		f.SetActiveSheet(idx)
+	}
+
+	return w.save(f)
+}
+
+// createTOCSheet creates the "目录" sheet as the first sheet of the workbook, with a hyperlink
+// to every other visible sheet and per-sheet alert counts. It does nothing when the workbook
+// has fewer than tocMinSheets visible sheets.
+func (w *Writer) createTOCSheet(f *excelize.File, entries map[string]tocEntry) error {
+	sheets := visibleSheets(f)
+	if len(sheets) < tocMinSheets {
+		return nil
+	}
+
+	if _, err := f.NewSheet(sheetTOC); err != nil {
+		return err
+	}
+	if err := f.MoveSheet(sheetTOC, f.GetSheetList()[0]); err != nil {
+		return err
+	}
+
+	// Create styles
+	headerStyle, err := w.createHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+
+	warningStyle, err := w.createWarningStyle(f)
+	if err != nil {
+		return err
+	}
+
+	criticalStyle, err := w.createCriticalStyle(f)
+	if err != nil {
+		return err
+	}
+
+	linkStyle, err := w.createLinkStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Define headers
+	headers := []string{"序号", "工作表", "所属模块", "严重告警", "警告告警"}
+
+	// Set column widths
+	colWidths := []float64{8, 25, 15, 12, 12}
+	for i, width := range colWidths {
+		col := columnName(i + 1)
+		f.SetColWidth(sheetTOC, col, col, width)
+	}
+
+	// Write headers
+	for i, header := range headers {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		f.SetCellValue(sheetTOC, cell, header)
+		f.SetCellStyle(sheetTOC, cell, cell, headerStyle)
+	}
+	f.SetRowHeight(sheetTOC, 1, 25)
+
+	// Write one row per sheet, keeping the workbook order
+	for i, sheet := range sheets {
+		row := i + 2
+		rowStr := fmt.Sprintf("%d", row)
+
+		f.SetCellValue(sheetTOC, "A"+rowStr, i+1)
+		if err := setSheetLink(f, sheetTOC, "B"+rowStr, sheet, linkStyle); err != nil {
+			return err
+		}
+
+		entry, ok := lookupTOCEntry(entries, sheet)
+		if !ok {
+			continue
+		}
+		f.SetCellValue(sheetTOC, "C"+rowStr, entry.module)
+		if !entry.showAlerts {
+			continue
+		}
+		f.SetCellValue(sheetTOC, "D"+rowStr, entry.critical)
+		f.SetCellValue(sheetTOC, "E"+rowStr, entry.warning)
+		if entry.critical > 0 {
+			f.SetCellStyle(sheetTOC, "D"+rowStr, "D"+rowStr, criticalStyle)
+		}
+		if entry.warning > 0 {
+			f.SetCellStyle(sheetTOC, "E"+rowStr, "E"+rowStr, warningStyle)
+		}
+	}
+
+	return nil
+}
+
+// appendTOCEntry adds a row for sheet to an existing table of contents.
+// It does nothing when the workbook has no table of contents.
+func (w *Writer) appendTOCEntry(f *excelize.File, sheet, module string) error {
+	sheetTOC := w.tr.SheetName(sheetTOC)
+	if idx, _ := f.GetSheetIndex(sheetTOC); idx < 0 {
+		return nil
+	}
+
+	rows, err := f.GetRows(sheetTOC)
+	if err != nil {
+		return err
+	}
+	row := len(rows) + 1
+	rowStr := fmt.Sprintf("%d", row)
+
+	linkStyle, err := w.createLinkStyle(f)
+	if err != nil {
+		return err
+	}
+
+	f.SetCellValue(sheetTOC, "A"+rowStr, row-1)
+	if err := setSheetLink(f, sheetTOC, "B"+rowStr, sheet, linkStyle); err != nil {
+		return err
+	}
+	f.SetCellValue(sheetTOC, "C"+rowStr, w.tr.Text(module))
+	return nil
+}
+
+// applySheetBadges appends the alert counts to the names of the sheets that carry them in the
+// table of contents, when sheet badges are enabled. Sheets without alerts keep their names.
+// entries is re-keyed to the new names; the returned map maps old names to new names.
+func (w *Writer) applySheetBadges(f *excelize.File, entries map[string]tocEntry) (map[string]string, error) {
+	renamed := make(map[string]string)
+	if !w.sheetBadges {
+		return renamed, nil
+	}
+
+	for _, sheet := range f.GetSheetList() {
+		entry, ok := entries[sheet]
+		if !ok || !entry.showAlerts {
+			continue
+		}
+		name := sheetBadgeName(sheet, entry.critical, entry.warning)
+		if name == sheet {
+			continue
+		}
+		if err := f.SetSheetName(sheet, name); err != nil {
+			return nil, err
+		}
+		delete(entries, sheet)
+		entries[name] = entry
+		renamed[sheet] = name
+	}
+
+	return renamed, nil
+}
+
+// sheetBadgeName returns the sheet name with an alert badge, e.g. "MySQL 巡检 (2⚠ 1✖)".
+// The base name is shortened when needed to keep the result within Excel's 31-character limit.
+// Returns the name unchanged when there are no alerts.
+func sheetBadgeName(name string, critical, warning int) string {
+	var parts []string
+	if warning > 0 {
+		parts = append(parts, fmt.Sprintf("%d⚠", warning))
+	}
+	if critical > 0 {
+		parts = append(parts, fmt.Sprintf("%d✖", critical))
+	}
+	if len(parts) == 0 {
+		return name
+	}
+
+	badge := []rune(" (" + strings.Join(parts, " ") + ")")
+	base := []rune(name)
+	if len(base)+len(badge) > excelize.MaxSheetNameLength {
+		keep := excelize.MaxSheetNameLength - len(badge)
+		if keep < 0 {
+			keep = 0
+		}
+		base = []rune(strings.TrimSpace(string(base[:keep])))
+	}
+	return string(base) + string(badge)
+}
+
+// createLinkStyle creates the style for hyperlink cells (blue, underlined).
+func (w *Writer) createLinkStyle(f *excelize.File) (int, error) {
+	return f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Color:     colorLinkFg,
+			Underline: "single",
+		},
+	})
+}
+
+// setSheetLink writes the sheet name into a cell of the table of contents sheet toc as an
+// internal hyperlink.
+func setSheetLink(f *excelize.File, toc, cell, sheet string, linkStyle int) error {
+	f.SetCellValue(toc, cell, sheet)
+	f.SetCellStyle(toc, cell, cell, linkStyle)
+	location := fmt.Sprintf("'%s'!A1", strings.ReplaceAll(sheet, "'", "''"))
+	return f.SetCellHyperLink(toc, cell, location, "Location")
+}
+
+// ============================================================================
+// Localization
+// ============================================================================
+
+// localize translates the workbook into the report language: text cells that are report
+// strings (headers, status texts, labels) and the sheet names. Translated text is not
+// translated again, so appending to a localized workbook and saving it again is safe.
+// Sheets are localized before hyperlinks or charts reference them by name.
+func (w *Writer) localize(f *excelize.File) error {
+	if !w.tr.Enabled() {
+		return nil
+	}
+
+	for _, sheet := range f.GetSheetList() {
+		rows, err := f.GetRows(sheet)
+		if err != nil {
+			return fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+		}
+		for r, row := range rows {
+			for c, value := range row {
+				if value == "" {
+					continue
+				}
+				text := w.tr.Text(value)
+				if text == value {
+					continue
+				}
+				cell, err := excelize.CoordinatesToCellName(c+1, r+1)
+				if err != nil {
+					return err
+				}
+				if err := f.SetCellStr(sheet, cell, text); err != nil {
+					return err
+				}
+			}
+		}
+
+		if name := w.tr.SheetName(sheet); name != sheet {
+			if err := f.SetSheetName(sheet, name); err != nil {
+				return fmt.Errorf("failed to rename sheet %s: %w", sheet, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// localizeTOCEntries re-keys the table of contents entries to the localized sheet names.
+func (w *Writer) localizeTOCEntries(entries map[string]tocEntry) {
+	if !w.tr.Enabled() {
+		return
+	}
+	for sheet, entry := range entries {
+		if name := w.tr.SheetName(sheet); name != sheet {
+			delete(entries, sheet)
+			entries[name] = entry
+		}
+	}
+}
+
+// ============================================================================
+// White-label Theme
+// ============================================================================
+
+// saveAs localizes the workbook, applies the theme, watermark and sheet protection, streams
+// the queued sheets and saves the workbook to outputPath, encrypted when a password is set.
+func (w *Writer) saveAs(f *excelize.File, outputPath string) error {
+	if err := w.localize(f); err != nil {
+		return err
+	}
+	if err := w.applyTheme(f); err != nil {
+		return err
+	}
+	if err := w.applyWatermark(f); err != nil {
+		return err
+	}
+	if err := w.protect(f); err != nil {
+		return err
+	}
+	if err := w.flushStreams(f); err != nil {
+		return err
+	}
+	return f.SaveAs(outputPath, w.saveOptions())
+}
+
+// save localizes the workbook, applies the theme, watermark and sheet protection, streams
+// the queued sheets and saves the workbook to its original path, encrypted when a password is set.
+func (w *Writer) save(f *excelize.File) error {
+	if err := w.localize(f); err != nil {
+		return err
+	}
+	if err := w.applyTheme(f); err != nil {
+		return err
+	}
+	if err := w.applyWatermark(f); err != nil {
+		return err
+	}
+	if err := w.protect(f); err != nil {
+		return err
+	}
+	if err := w.flushStreams(f); err != nil {
+		return err
+	}
+	return f.Save(w.saveOptions())
+}
+
+// applyTheme sets the print header and footer of every sheet from the theme, so that
+// printed and PDF-exported workbooks carry the customer logo, title and footer text.
+// Sheets that already have a header (e.g. when appending to an existing report) are left unchanged.
+func (w *Writer) applyTheme(f *excelize.File) error {
+	if w.theme == nil {
+		return nil
+	}
+
+	var logo []byte
+	var logoExt string
+	if w.theme.HasLogo() {
+		var err error
+		if logo, logoExt, err = w.theme.LoadLogo(); err != nil {
+			return err
+		}
+	}
+
+	header := "&C&B" + escapeHeaderFooter(w.theme.GetTitle())
+	if logo != nil {
+		header = "&L&G" + header
+	}
+	footer := "&L" + escapeHeaderFooter(w.theme.GetFooterText()) + "&R" + w.tr.T("第 &P 页 / 共 &N 页")
+
+	for _, sheet := range f.GetSheetList() {
+		if hf, err := f.GetHeaderFooter(sheet); err == nil && hf != nil && hf.OddHeader != "" {
+			continue
+		}
+		if logo != nil {
+			if err := f.AddHeaderFooterImage(sheet, &excelize.HeaderFooterImageOptions{
+				Position:  excelize.HeaderFooterImagePositionLeft,
+				File:      logo,
+				Extension: logoExt,
+				Width:     "96pt",
+				Height:    "32pt",
+			}); err != nil {
+				return fmt.Errorf("failed to add logo to sheet %s: %w", sheet, err)
+			}
+		}
+		if err := f.SetHeaderFooter(sheet, &excelize.HeaderFooterOptions{
+			OddHeader: header,
+			OddFooter: footer,
+		}); err != nil {
+			return fmt.Errorf("failed to set header/footer of sheet %s: %w", sheet, err)
+		}
+	}
+
+	return f.SetDocProps(&excelize.DocProperties{Title: w.theme.GetTitle()})
+}
+
+// ============================================================================
+// Protection
+// ============================================================================
+
+// openFile opens an existing workbook, decrypting it with the workbook password when set.
+func (w *Writer) openFile(path string) (*excelize.File, error) {
+	return excelize.OpenFile(path, excelize.Options{Password: w.password})
+}
+
+// saveOptions returns the save options: the workbook password encrypts the file.
+func (w *Writer) saveOptions() excelize.Options {
+	return excelize.Options{Password: w.password}
+}
+
+// protect protects every sheet when sheet protection is enabled. Protected cells are read-only;
+// selecting cells, filtering and resizing columns stay allowed so the report remains usable.
+// Queued sheets keep the protection when they are streamed.
+func (w *Writer) protect(f *excelize.File) error {
+	if !w.protectSheets {
+		return nil
+	}
+	for _, sheet := range f.GetSheetList() {
+		if err := f.ProtectSheet(sheet, &excelize.SheetProtectionOptions{
+			Password:            w.sheetPassword,
+			AlgorithmName:       "SHA-512",
+			SelectLockedCells:   true,
+			SelectUnlockedCells: true,
+			AutoFilter:          true,
+			FormatColumns:       true,
+		}); err != nil {
+			return fmt.Errorf("failed to protect sheet %s: %w", sheet, err)
+		}
+	}
+	return nil
+}
+
+// escapeHeaderFooter escapes "&", the control character of Excel header / footer codes.
+func escapeHeaderFooter(s string) string {
+	return strings.ReplaceAll(s, "&", "&&")
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +12,10 @@ import (
 	"github.com/xuri/excelize/v2"
 
 	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
+	"inspection-tool/pkg/testfixtures"
 )
 
 func TestNewWriter(t *testing.T) {
@@ -258,191 +263,41 @@ func TestWriter_EmptyResult(t *testing.T) {
 	}
 	defer f.Close()
 
+	// Summary, detail, alerts and charts
 	sheets := f.GetSheetList()
-	if len(sheets) != 3 {
-		t.Errorf("Expected 3 sheets, got %d", len(sheets))
+	if len(sheets) != 4 {
+		t.Errorf("Expected 4 sheets, got %d", len(sheets))
 	}
 }
 
 // Helper functions
 
+// createTestInspectionResult returns three hosts built with the shared fixtures: host-1 is
+// normal, host-2 has a CPU warning and host-3 critical CPU and memory alerts, with a disk
+// usage over the warning threshold but not alerted.
 func createTestInspectionResult() *model.InspectionResult {
-	tz, _ := time.LoadLocation("Asia/Shanghai")
-	inspectionTime := time.Date(2025, 12, 13, 10, 0, 0, 0, tz)
-
-	// Create hosts
-	host1 := &model.HostResult{
-		Hostname:      "host-1",
-		IP:            "192.168.1.1",
-		OS:            "Linux",
-		OSVersion:     "CentOS 7.9",
-		KernelVersion: "3.10.0-1160.el7",
-		CPUCores:      4,
-		Status:        model.HostStatusNormal,
-		Metrics: map[string]*model.MetricValue{
-			"cpu_usage": {
-				Name:           "cpu_usage",
-				RawValue:       45.5,
-				FormattedValue: "45.5%",
-				Status:         model.MetricStatusNormal,
-			},
-			"memory_usage": {
-				Name:           "memory_usage",
-				RawValue:       60.0,
-				FormattedValue: "60.0%",
-				Status:         model.MetricStatusNormal,
-			},
-			"disk_usage_max": {
-				Name:           "disk_usage_max",
-				RawValue:       55.0,
-				FormattedValue: "55.0%",
-				Status:         model.MetricStatusNormal,
-			},
-			"disk_usage:/": {
-				Name:           "disk_usage:/",
-				RawValue:       55.0,
-				FormattedValue: "55.0%",
-				Status:         model.MetricStatusNormal,
-				Labels:         map[string]string{"path": "/"},
-			},
-		},
-	}
-
-	host2 := &model.HostResult{
-		Hostname:      "host-2",
-		IP:            "192.168.1.2",
-		OS:            "Linux",
-		OSVersion:     "Ubuntu 22.04",
-		KernelVersion: "5.15.0-generic",
-		CPUCores:      8,
-		Status:        model.HostStatusWarning,
-		Metrics: map[string]*model.MetricValue{
-			"cpu_usage": {
-				Name:           "cpu_usage",
-				RawValue:       75.0,
-				FormattedValue: "75.0%",
-				Status:         model.MetricStatusWarning,
-			},
-			"memory_usage": {
-				Name:           "memory_usage",
-				RawValue:       65.0,
-				FormattedValue: "65.0%",
-				Status:         model.MetricStatusNormal,
-			},
-			"disk_usage_max": {
-				Name:           "disk_usage_max",
-				RawValue:       50.0,
-				FormattedValue: "50.0%",
-				Status:         model.MetricStatusNormal,
-			},
-		},
-		Alerts: []*model.Alert{
-			{
-				Hostname:          "host-2",
-				MetricName:        "cpu_usage",
-				MetricDisplayName: "CPU利用率",
-				CurrentValue:      75.0,
-				FormattedValue:    "75.0%",
-				WarningThreshold:  70.0,
-				CriticalThreshold: 90.0,
-				Level:             model.AlertLevelWarning,
-				Message:           "CPU利用率 75.0% 超过警告阈值 70.0%",
-			},
-		},
-	}
-
-	host3 := &model.HostResult{
-		Hostname:      "host-3",
-		IP:            "192.168.1.3",
-		OS:            "Linux",
-		OSVersion:     "Rocky 9.0",
-		KernelVersion: "5.14.0-70.el9",
-		CPUCores:      16,
-		Status:        model.HostStatusCritical,
-		Metrics: map[string]*model.MetricValue{
-			"cpu_usage": {
-				Name:           "cpu_usage",
-				RawValue:       95.0,
-				FormattedValue: "95.0%",
-				Status:         model.MetricStatusCritical,
-			},
-			"memory_usage": {
-				Name:           "memory_usage",
-				RawValue:       92.0,
-				FormattedValue: "92.0%",
-				Status:         model.MetricStatusCritical,
-			},
-			"disk_usage_max": {
-				Name:           "disk_usage_max",
-				RawValue:       88.0,
-				FormattedValue: "88.0%",
-				Status:         model.MetricStatusWarning,
-			},
-			"disk_usage:/": {
-				Name:           "disk_usage:/",
-				RawValue:       88.0,
-				FormattedValue: "88.0%",
-				Status:         model.MetricStatusWarning,
-				Labels:         map[string]string{"path": "/"},
-			},
-			"disk_usage:/home": {
-				Name:           "disk_usage:/home",
-				RawValue:       45.0,
-				FormattedValue: "45.0%",
-				Status:         model.MetricStatusNormal,
-				Labels:         map[string]string{"path": "/home"},
-			},
-		},
-		Alerts: []*model.Alert{
-			{
-				Hostname:          "host-3",
-				MetricName:        "cpu_usage",
-				MetricDisplayName: "CPU利用率",
-				CurrentValue:      95.0,
-				FormattedValue:    "95.0%",
-				WarningThreshold:  70.0,
-				CriticalThreshold: 90.0,
-				Level:             model.AlertLevelCritical,
-				Message:           "CPU利用率 95.0% 超过严重阈值 90.0%",
-			},
-			{
-				Hostname:          "host-3",
-				MetricName:        "memory_usage",
-				MetricDisplayName: "内存利用率",
-				CurrentValue:      92.0,
-				FormattedValue:    "92.0%",
-				WarningThreshold:  70.0,
-				CriticalThreshold: 90.0,
-				Level:             model.AlertLevelCritical,
-				Message:           "内存利用率 92.0% 超过严重阈值 90.0%",
-			},
-		},
-	}
-
-	// Collect all alerts
-	allAlerts := make([]*model.Alert, 0)
-	allAlerts = append(allAlerts, host2.Alerts...)
-	allAlerts = append(allAlerts, host3.Alerts...)
-
-	return &model.InspectionResult{
-		InspectionTime: inspectionTime,
-		Duration:       5 * time.Second,
-		Summary: &model.InspectionSummary{
-			TotalHosts:    3,
-			NormalHosts:   1,
-			WarningHosts:  1,
-			CriticalHosts: 1,
-			FailedHosts:   0,
-		},
-		Hosts:  []*model.HostResult{host1, host2, host3},
-		Alerts: allAlerts,
-		AlertSummary: &model.AlertSummary{
-			TotalAlerts:   3,
-			WarningCount:  1,
-			CriticalCount: 2,
-		},
-		Version: "1.0.0-test",
-	}
+	host1 := testfixtures.NewHost("host-1", "192.168.1.1")
+	testfixtures.SetHostMetric(host1, "cpu_usage", 45.5, nil)
+	testfixtures.SetHostMetric(host1, "memory_usage", 60.0, nil)
+	testfixtures.SetHostMetric(host1, "disk_usage_max", 55.0, nil)
+	testfixtures.SetHostMetric(host1, "disk_usage:/", 55.0, map[string]string{"path": "/"})
+
+	host2 := testfixtures.NewHost("host-2", "192.168.1.2")
+	host2.OSVersion, host2.KernelVersion, host2.CPUCores = "Ubuntu 22.04", "5.15.0-generic", 8
+	delete(host2.Metrics, "disk_usage:/")
+	testfixtures.SetHostMetric(host2, "memory_usage", 65.0, nil)
+	testfixtures.SetHostMetric(host2, "disk_usage_max", 50.0, nil)
+	testfixtures.AddHostAlert(host2, "cpu_usage", 75.0)
+
+	host3 := testfixtures.NewHost("host-3", "192.168.1.3")
+	host3.OSVersion, host3.KernelVersion, host3.CPUCores = "Rocky 9.0", "5.14.0-70.el9", 16
+	testfixtures.SetHostMetric(host3, "disk_usage_max", 88.0, nil).Status = model.MetricStatusWarning
+	testfixtures.SetHostMetric(host3, "disk_usage:/", 88.0, map[string]string{"path": "/"}).Status = model.MetricStatusWarning
+	testfixtures.SetHostMetric(host3, "disk_usage:/home", 45.0, map[string]string{"path": "/home"})
+	testfixtures.AddHostAlert(host3, "cpu_usage", 95.0)
+	testfixtures.AddHostAlert(host3, "memory_usage", 92.0)
+
+	return testfixtures.NewInspectionResult(host1, host2, host3)
 }
 
 func TestColumnName(t *testing.T) {
@@ -654,7 +509,7 @@ func TestWriter_MySQLSheet_Headers(t *testing.T) {
 	}
 	defer f.Close()
 
-	// Verify all 11 headers
+	// Verify all 15 headers
 	expectedHeaders := []struct {
 		cell   string
 		header string
@@ -668,8 +523,12 @@ func TestWriter_MySQLSheet_Headers(t *testing.T) {
 		{"G1", "同步状态"},
 		{"H1", "最大连接数"},
 		{"I1", "当前连接数"},
-		{"J1", "Binlog状态"},
-		{"K1", "整体状态"},
+		{"J1", "QPS"},
+		{"K1", "TPS"},
+		{"L1", "慢查询/秒"},
+		{"M1", "运行线程数"},
+		{"N1", "Binlog状态"},
+		{"O1", "整体状态"},
 	}
 
 	for _, eh := range expectedHeaders {
@@ -710,8 +569,12 @@ func TestWriter_MySQLSheet_DataMapping(t *testing.T) {
 		{"G2", "在线"},                  // 同步状态
 		{"H2", "1000"},                // 最大连接数
 		{"I2", "100"},                 // 当前连接数
-		{"J2", "启用"},                  // Binlog状态
-		{"K2", "正常"},                  // 整体状态
+		{"J2", "1520.5"},              // QPS
+		{"K2", "210.3"},               // TPS
+		{"L2", "0.05"},                // 慢查询/秒
+		{"M2", "4"},                   // 运行线程数
+		{"N2", "启用"},                  // Binlog状态
+		{"O2", "正常"},                  // 整体状态
 	}
 
 	for _, tt := range tests {
@@ -744,9 +607,9 @@ func TestWriter_MySQLSheet_ConditionalFormat(t *testing.T) {
 		cell   string
 		status string
 	}{
-		{"K2", "正常"},
-		{"K3", "警告"},
-		{"K4", "严重"},
+		{"O2", "正常"},
+		{"O3", "警告"},
+		{"O4", "严重"},
 	}
 
 	for _, es := range expectedStatuses {
@@ -757,105 +620,31 @@ func TestWriter_MySQLSheet_ConditionalFormat(t *testing.T) {
 	}
 }
 
-// Helper function to create test MySQL inspection results
+// createTestMySQLInspectionResults returns three MGR members built with the shared fixtures:
+// 172.18.182.91 is normal, 172.18.182.92 has a connection usage warning and 172.18.182.93 is
+// offline, a critical alert.
 func createTestMySQLInspectionResults() *model.MySQLInspectionResults {
-	tz, _ := time.LoadLocation("Asia/Shanghai")
-	inspectionTime := time.Date(2025, 12, 16, 10, 0, 0, 0, tz)
-
-	// Create alerts for warning and critical instances
-	warningAlert := &model.MySQLAlert{
-		Address:           "172.18.182.92:3306",
-		MetricName:        "connection_usage",
-		MetricDisplayName: "连接使用率",
-		CurrentValue:      80.0,
-		FormattedValue:    "80.0%",
-		WarningThreshold:  70.0,
-		CriticalThreshold: 90.0,
-		Level:             model.AlertLevelWarning,
-		Message:           "连接使用率 80.0% 超过警告阈值 70.0%",
-	}
-
-	criticalAlert := &model.MySQLAlert{
-		Address:           "172.18.182.93:3306",
-		MetricName:        "mgr_state_online",
-		MetricDisplayName: "MGR 在线状态",
-		CurrentValue:      0,
-		FormattedValue:    "离线",
-		WarningThreshold:  0,
-		CriticalThreshold: 1,
-		Level:             model.AlertLevelCritical,
-		Message:           "MGR 节点离线",
-	}
-
-	return &model.MySQLInspectionResults{
-		InspectionTime: inspectionTime,
-		Duration:       2 * time.Second,
-		Summary: &model.MySQLInspectionSummary{
-			TotalInstances:    3,
-			NormalInstances:   1,
-			WarningInstances:  1,
-			CriticalInstances: 1,
-		},
-		Results: []*model.MySQLInspectionResult{
-			// Normal instance
-			{
-				Instance: &model.MySQLInstance{
-					Address:     "172.18.182.91:3306",
-					IP:          "172.18.182.91",
-					Port:        3306,
-					Version:     "8.0.39",
-					ServerID:    "91",
-					ClusterMode: model.ClusterModeMGR,
-				},
-				MaxConnections:     1000,
-				CurrentConnections: 100,
-				MGRStateOnline:     true,
-				BinlogEnabled:      true,
-				Status:             model.MySQLStatusNormal,
-			},
-			// Warning instance (high connection usage)
-			{
-				Instance: &model.MySQLInstance{
-					Address:     "172.18.182.92:3306",
-					IP:          "172.18.182.92",
-					Port:        3306,
-					Version:     "8.0.39",
-					ServerID:    "92",
-					ClusterMode: model.ClusterModeMGR,
-				},
-				MaxConnections:     1000,
-				CurrentConnections: 800,
-				MGRStateOnline:     true,
-				BinlogEnabled:      true,
-				Status:             model.MySQLStatusWarning,
-				Alerts:             []*model.MySQLAlert{warningAlert},
-			},
-			// Critical instance (MGR offline)
-			{
-				Instance: &model.MySQLInstance{
-					Address:     "172.18.182.93:3306",
-					IP:          "172.18.182.93",
-					Port:        3306,
-					Version:     "8.0.39",
-					ServerID:    "93",
-					ClusterMode: model.ClusterModeMGR,
-				},
-				MaxConnections:     1000,
-				CurrentConnections: 50,
-				MGRStateOnline:     false,
-				BinlogEnabled:      true,
-				Status:             model.MySQLStatusCritical,
-				Alerts:             []*model.MySQLAlert{criticalAlert},
-			},
-		},
-		Alerts: []*model.MySQLAlert{warningAlert, criticalAlert},
-		AlertSummary: &model.MySQLAlertSummary{
-			TotalAlerts:   2,
-			WarningCount:  1,
-			CriticalCount: 1,
-		},
-		Version: "1.0.0-test",
-	}
+	normal := testfixtures.NewMySQLResult("172.18.182.91:3306")
+	normal.Instance.SetServerID("91")
+	normal.SlowQueriesPerSecond = 0.05
+
+	warning := testfixtures.NewMySQLResult("172.18.182.92:3306")
+	warning.Instance.SetServerID("92")
+	warning.CurrentConnections = 800
+	testfixtures.AddMySQLAlert(warning, "connection_usage", 80.0)
+
+	critical := testfixtures.NewMySQLResult("172.18.182.93:3306")
+	critical.Instance.SetServerID("93")
+	critical.CurrentConnections = 50
+	critical.MGRStateOnline = false
+	offline := model.NewMySQLAlert(critical.GetAddress(), "mgr_state_online", 0, model.AlertLevelCritical)
+	offline.MetricDisplayName = "MGR 在线状态"
+	offline.FormattedValue = "离线"
+	offline.CriticalThreshold = 1
+	offline.Message = "MGR 节点离线"
+	critical.AddAlert(offline)
+
+	return testfixtures.NewMySQLInspectionResults(normal, warning, critical)
 }
 
 // Test MySQL helper functions
@@ -900,24 +689,6 @@ func TestMySQLClusterModeText(t *testing.T) {
 	}
 }
 
-func TestBoolToText(t *testing.T) {
-	tests := []struct {
-		value bool
-		want  string
-	}{
-		{true, "启用"},
-		{false, "禁用"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.want, func(t *testing.T) {
-			if got := boolToText(tt.value); got != tt.want {
-				t.Errorf("boolToText(%v) = %q, want %q", tt.value, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestGetMySQLSyncStatus(t *testing.T) {
 	w := NewWriter(nil)
 
@@ -1181,6 +952,18 @@ func TestWriter_MySQLAlertsSheet_EmptyAlerts(t *testing.T) {
 	}
 }
 
+func TestExpectedThresholdTexts(t *testing.T) {
+	warning, critical := expectedThresholdTexts("8.0*", model.AlertLevelWarning)
+	if warning != "期望: 8.0*" || critical != "-" {
+		t.Errorf("warning level: got (%q, %q)", warning, critical)
+	}
+
+	warning, critical = expectedThresholdTexts("allkeys-lru", model.AlertLevelCritical)
+	if warning != "-" || critical != "期望: allkeys-lru" {
+		t.Errorf("critical level: got (%q, %q)", warning, critical)
+	}
+}
+
 func TestFormatMySQLThreshold(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1321,7 +1104,7 @@ func TestWriter_RedisSheet_Headers(t *testing.T) {
 	}
 	defer f.Close()
 
-	// Verify all 14 headers
+	// Verify all 19 headers
 	expectedHeaders := []struct {
 		cell   string
 		header string
@@ -1339,7 +1122,12 @@ func TestWriter_RedisSheet_Headers(t *testing.T) {
 		{"K1", "Master端口"},
 		{"L1", "复制延迟"},
 		{"M1", "最大连接数"},
-		{"N1", "整体状态"},
+		{"N1", "内存使用率"},
+		{"O1", "碎片率"},
+		{"P1", "驱逐/秒"},
+		{"Q1", "过期/秒"},
+		{"R1", "命中率"},
+		{"S1", "整体状态"},
 	}
 
 	for _, eh := range expectedHeaders {
@@ -1377,14 +1165,16 @@ func TestWriter_RedisSheet_DataMapping_Master(t *testing.T) {
 		{"D2", "Redis"},        // 应用类型
 		{"E2", "6.2.6"},        // Redis版本
 		{"F2", "N/A"},          // 是否普通用户启动
-		{"G2", "是"},           // 连接状态
-		{"H2", "是"},           // 集群模式
+		{"G2", "正常"},          // 连接状态
+		{"H2", "启用"},          // 集群模式
 		{"I2", "N/A"},          // 主从链接状态 (master shows N/A)
 		{"J2", "主"},           // 节点角色
 		{"K2", "N/A"},          // Master端口 (master shows N/A)
 		{"L2", "N/A"},          // 复制延迟 (master shows N/A)
 		{"M2", "10000"},        // 最大连接数
-		{"N2", "正常"},          // 整体状态
+		{"N2", "N/A"},          // 内存使用率 (maxmemory unlimited)
+		{"R2", "N/A"},          // 命中率 (not calculated)
+		{"S2", "正常"},          // 整体状态
 	}
 
 	for _, tt := range tests {
@@ -1419,11 +1209,11 @@ func TestWriter_RedisSheet_DataMapping_Slave(t *testing.T) {
 	}{
 		{"B3", "192.18.102.2"},  // IP地址
 		{"C3", "7001"},         // 端口
-		{"I3", "是"},           // 主从链接状态 (slave shows status)
+		{"I3", "正常"},          // 主从链接状态 (slave shows status)
 		{"J3", "从"},           // 节点角色
 		{"K3", "7000"},         // Master端口
 		{"L3", "0 B"},          // 复制延迟
-		{"N3", "正常"},          // 整体状态
+		{"S3", "正常"},          // 整体状态
 	}
 
 	for _, tt := range tests {
@@ -1456,12 +1246,12 @@ func TestWriter_RedisSheet_ConditionalFormat(t *testing.T) {
 		cell   string
 		status string
 	}{
-		{"N2", "正常"},
-		{"N3", "正常"},
-		{"N4", "正常"},
-		{"N5", "警告"},
-		{"N6", "警告"},
-		{"N7", "严重"},
+		{"S2", "正常"},
+		{"S3", "正常"},
+		{"S4", "正常"},
+		{"S5", "警告"},
+		{"S6", "警告"},
+		{"S7", "严重"},
 	}
 
 	for _, es := range expectedStatuses {
@@ -1472,6 +1262,78 @@ func TestWriter_RedisSheet_ConditionalFormat(t *testing.T) {
 	}
 }
 
+func TestWriter_RedisSheet_MemoryColumns(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "test_redis_memory.xlsx")
+
+	r := &model.RedisInspectionResult{
+		Instance: &model.RedisInstance{
+			Address: "192.18.102.2:7000",
+			IP:      "192.18.102.2",
+			Port:    7000,
+			Role:    model.RedisRoleMaster,
+		},
+		ConnectionStatus:      true,
+		UsedMemory:            950,
+		MaxMemory:             1000,
+		MemFragmentationRatio: 1.23,
+		EvictedKeysPerSecond:  2.5,
+		ExpiredKeysPerSecond:  10,
+		KeyspaceHitRatio:      98.76,
+		Status:                model.RedisStatusCritical,
+		Alerts: []*model.RedisAlert{
+			{
+				Address:    "192.18.102.2:7000",
+				MetricName: "memory_usage",
+				Level:      model.AlertLevelCritical,
+			},
+		},
+	}
+	r.SetMetric(&model.RedisMetricValue{Name: "redis_keyspace_hit_ratio", RawValue: 98.76})
+
+	result := &model.RedisInspectionResults{
+		InspectionTime: time.Now(),
+		Summary:        &model.RedisInspectionSummary{TotalInstances: 1, CriticalInstances: 1},
+		Results:        []*model.RedisInspectionResult{r},
+	}
+
+	w := NewWriter(nil)
+	if err := w.WriteRedisInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteRedisInspection() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		cell     string
+		expected string
+	}{
+		{"N2", "95.0%"}, // 内存使用率
+		{"O2", "1.23"},  // 碎片率
+		{"P2", "2.50"},  // 驱逐/秒
+		{"Q2", "10.00"}, // 过期/秒
+		{"R2", "98.8%"}, // 命中率
+		{"S2", "严重"},    // 整体状态
+	}
+	for _, tt := range tests {
+		value, _ := f.GetCellValue(sheetRedis, tt.cell)
+		if value != tt.expected {
+			t.Errorf("Cell %s = %q, want %q", tt.cell, value, tt.expected)
+		}
+	}
+
+	// Memory usage cell should be highlighted by its alert
+	styleN, _ := f.GetCellStyle(sheetRedis, "N2")
+	styleO, _ := f.GetCellStyle(sheetRedis, "O2")
+	if styleN == styleO {
+		t.Error("memory usage cell should have alert style")
+	}
+}
+
 // ============================================================================
 // Redis Alerts Sheet Tests
 // ============================================================================
@@ -1691,24 +1553,6 @@ func TestRedisRoleText(t *testing.T) {
 	}
 }
 
-func TestRedisBoolText(t *testing.T) {
-	tests := []struct {
-		value bool
-		want  string
-	}{
-		{true, "是"},
-		{false, "否"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.want, func(t *testing.T) {
-			if got := redisBoolText(tt.value); got != tt.want {
-				t.Errorf("redisBoolText(%v) = %q, want %q", tt.value, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestFormatReplicationLag(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1801,7 +1645,7 @@ func TestGetMasterLinkStatusText(t *testing.T) {
 				Instance:         &model.RedisInstance{Role: model.RedisRoleSlave},
 				MasterLinkStatus: true,
 			},
-			want: "是",
+			want: "正常",
 		},
 		{
 			name: "slave link down",
@@ -1809,7 +1653,7 @@ func TestGetMasterLinkStatusText(t *testing.T) {
 				Instance:         &model.RedisInstance{Role: model.RedisRoleSlave},
 				MasterLinkStatus: false,
 			},
-			want: "否",
+			want: "断开",
 		},
 		{
 			name: "nil instance returns N/A",
@@ -1998,190 +1842,49 @@ func TestWriter_AppendRedisInspection_Success(t *testing.T) {
 	}
 }
 
-// Helper function to create test Redis inspection results
+// newTestRedisNode returns a normal Redis 6.2 cluster node built with the shared fixtures,
+// without maxmemory or hit ratio. Replicas follow the master on port 7000.
+func newTestRedisNode(address string, role model.RedisRole, clients int) *model.RedisInspectionResult {
+	result := testfixtures.NewRedisResult(address, role)
+	result.Instance.SetVersion("6.2.6")
+	result.ConnectedClients = clients
+	result.NonRootUser = "N/A"
+	result.MaxMemory = 0
+	result.KeyspaceHitRatio = 0
+	if role == model.RedisRoleSlave {
+		result.MasterPort = 7000
+	}
+	return result
+}
+
+// createTestRedisInspectionResults returns three Redis 6.2 master/replica pairs built with the
+// shared fixtures: the 192.18.102.2 pair and the 192.18.102.4 master are normal, the
+// 192.18.102.3 pair has connection usage warnings and the 192.18.102.4 replica a broken
+// master link, a critical alert.
 func createTestRedisInspectionResults() *model.RedisInspectionResults {
-	tz, _ := time.LoadLocation("Asia/Shanghai")
-	inspectionTime := time.Date(2025, 12, 18, 10, 0, 0, 0, tz)
-
-	// Create alerts for warning and critical instances
-	warningAlert1 := &model.RedisAlert{
-		Address:           "192.18.102.3:7000",
-		MetricName:        "connection_usage",
-		MetricDisplayName: "连接使用率",
-		CurrentValue:      75.0,
-		FormattedValue:    "75.0%",
-		WarningThreshold:  70.0,
-		CriticalThreshold: 90.0,
-		Level:             model.AlertLevelWarning,
-		Message:           "连接使用率 75.0% 超过警告阈值 70.0%",
-	}
-
-	warningAlert2 := &model.RedisAlert{
-		Address:           "192.18.102.3:7001",
-		MetricName:        "connection_usage",
-		MetricDisplayName: "连接使用率",
-		CurrentValue:      80.0,
-		FormattedValue:    "80.0%",
-		WarningThreshold:  70.0,
-		CriticalThreshold: 90.0,
-		Level:             model.AlertLevelWarning,
-		Message:           "连接使用率 80.0% 超过警告阈值 70.0%",
-	}
-
-	criticalAlert := &model.RedisAlert{
-		Address:           "192.18.102.4:7001",
-		MetricName:        "master_link_status",
-		MetricDisplayName: "主从链接状态",
-		CurrentValue:      0,
-		FormattedValue:    "断开",
-		WarningThreshold:  0,
-		CriticalThreshold: 1,
-		Level:             model.AlertLevelCritical,
-		Message:           "主从链接断开",
-	}
-
-	return &model.RedisInspectionResults{
-		InspectionTime: inspectionTime,
-		Duration:       3 * time.Second,
-		Summary: &model.RedisInspectionSummary{
-			TotalInstances:    6,
-			NormalInstances:   3,
-			WarningInstances:  2,
-			CriticalInstances: 1,
-		},
-		Results: []*model.RedisInspectionResult{
-			// Normal master instance
-			{
-				Instance: &model.RedisInstance{
-					Address:        "192.18.102.2:7000",
-					IP:             "192.18.102.2",
-					Port:           7000,
-					ApplicationType: "Redis",
-					Version:        "6.2.6",
-					Role:           model.RedisRoleMaster,
-					ClusterEnabled: true,
-				},
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MaxClients:       10000,
-				ConnectedClients: 500,
-				ConnectedSlaves:  1,
-				NonRootUser:      "N/A",
-				Status:           model.RedisStatusNormal,
-			},
-			// Normal slave instance
-			{
-				Instance: &model.RedisInstance{
-					Address:        "192.18.102.2:7001",
-					IP:             "192.18.102.2",
-					Port:           7001,
-					ApplicationType: "Redis",
-					Version:        "6.2.6",
-					Role:           model.RedisRoleSlave,
-					ClusterEnabled: true,
-				},
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MasterLinkStatus: true,
-				MasterPort:       7000,
-				ReplicationLag:   0,
-				MaxClients:       10000,
-				ConnectedClients: 100,
-				NonRootUser:      "N/A",
-				Status:           model.RedisStatusNormal,
-			},
-			// Normal master instance
-			{
-				Instance: &model.RedisInstance{
-					Address:        "192.18.102.4:7000",
-					IP:             "192.18.102.4",
-					Port:           7000,
-					ApplicationType: "Redis",
-					Version:        "6.2.6",
-					Role:           model.RedisRoleMaster,
-					ClusterEnabled: true,
-				},
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MaxClients:       10000,
-				ConnectedClients: 300,
-				ConnectedSlaves:  1,
-				NonRootUser:      "N/A",
-				Status:           model.RedisStatusNormal,
-			},
-			// Warning master instance (high connection usage)
-			{
-				Instance: &model.RedisInstance{
-					Address:        "192.18.102.3:7000",
-					IP:             "192.18.102.3",
-					Port:           7000,
-					ApplicationType: "Redis",
-					Version:        "6.2.6",
-					Role:           model.RedisRoleMaster,
-					ClusterEnabled: true,
-				},
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MaxClients:       10000,
-				ConnectedClients: 7500,
-				ConnectedSlaves:  1,
-				NonRootUser:      "N/A",
-				Status:           model.RedisStatusWarning,
-				Alerts:           []*model.RedisAlert{warningAlert1},
-			},
-			// Warning slave instance (high connection usage)
-			{
-				Instance: &model.RedisInstance{
-					Address:        "192.18.102.3:7001",
-					IP:             "192.18.102.3",
-					Port:           7001,
-					ApplicationType: "Redis",
-					Version:        "6.2.6",
-					Role:           model.RedisRoleSlave,
-					ClusterEnabled: true,
-				},
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MasterLinkStatus: true,
-				MasterPort:       7000,
-				ReplicationLag:   0,
-				MaxClients:       10000,
-				ConnectedClients: 8000,
-				NonRootUser:      "N/A",
-				Status:           model.RedisStatusWarning,
-				Alerts:           []*model.RedisAlert{warningAlert2},
-			},
-			// Critical slave instance (master link down)
-			{
-				Instance: &model.RedisInstance{
-					Address:        "192.18.102.4:7001",
-					IP:             "192.18.102.4",
-					Port:           7001,
-					ApplicationType: "Redis",
-					Version:        "6.2.6",
-					Role:           model.RedisRoleSlave,
-					ClusterEnabled: true,
-				},
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MasterLinkStatus: false,
-				MasterPort:       7000,
-				ReplicationLag:   10485760, // 10MB
-				MaxClients:       10000,
-				ConnectedClients: 200,
-				NonRootUser:      "N/A",
-				Status:           model.RedisStatusCritical,
-				Alerts:           []*model.RedisAlert{criticalAlert},
-			},
-		},
-		Alerts: []*model.RedisAlert{warningAlert1, warningAlert2, criticalAlert},
-		AlertSummary: &model.RedisAlertSummary{
-			TotalAlerts:   3,
-			WarningCount:  2,
-			CriticalCount: 1,
-		},
-		Version: "1.0.0-test",
-	}
+	warningMaster := newTestRedisNode("192.18.102.3:7000", model.RedisRoleMaster, 7500)
+	testfixtures.AddRedisAlert(warningMaster, "connection_usage", 75.0)
+	warningSlave := newTestRedisNode("192.18.102.3:7001", model.RedisRoleSlave, 8000)
+	testfixtures.AddRedisAlert(warningSlave, "connection_usage", 80.0)
+
+	criticalSlave := newTestRedisNode("192.18.102.4:7001", model.RedisRoleSlave, 200)
+	criticalSlave.MasterLinkStatus = false
+	criticalSlave.ReplicationLag = 10485760 // 10MB
+	linkDown := model.NewRedisAlert(criticalSlave.GetAddress(), "master_link_status", 0, model.AlertLevelCritical)
+	linkDown.MetricDisplayName = "主从链接状态"
+	linkDown.FormattedValue = "断开"
+	linkDown.CriticalThreshold = 1
+	linkDown.Message = "主从链接断开"
+	criticalSlave.AddAlert(linkDown)
+
+	return testfixtures.NewRedisInspectionResults(
+		newTestRedisNode("192.18.102.2:7000", model.RedisRoleMaster, 500),
+		newTestRedisNode("192.18.102.2:7001", model.RedisRoleSlave, 100),
+		newTestRedisNode("192.18.102.4:7000", model.RedisRoleMaster, 300),
+		warningMaster,
+		warningSlave,
+		criticalSlave,
+	)
 }
 
 // ============================================================================
@@ -2349,115 +2052,1058 @@ func TestWriter_RedisClusterSheet_InstanceCount(t *testing.T) {
 // Cluster 1: 192.18.102.x (6 instances: 3 masters + 3 slaves)
 // Cluster 2: 192.18.107.x (6 instances: 3 masters + 3 slaves)
 func createTestRedisMultiClusterResults() *model.RedisInspectionResults {
-	results := &model.RedisInspectionResults{
-		InspectionTime: time.Now(),
-		Results:        make([]*model.RedisInspectionResult, 0, 12),
-	}
-
-	// Cluster 1: 192.18.102.x - 3 masters, 3 slaves
-	for i := 2; i <= 4; i++ {
-		ip := fmt.Sprintf("192.18.102.%d", i)
-		// Master
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address:         fmt.Sprintf("%s:7000", ip),
-				IP:              ip,
-				Port:            7000,
-				ApplicationType: "Redis",
-				Version:         "6.2.6",
-				Role:            model.RedisRoleMaster,
-				ClusterEnabled:  true,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MaxClients:       10000,
-			ConnectedClients: 100,
-			ConnectedSlaves:  1,
-			NonRootUser:      "N/A",
-			Status:           model.RedisStatusNormal,
-		})
-		// Slave
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address:         fmt.Sprintf("%s:7001", ip),
-				IP:              ip,
-				Port:            7001,
-				ApplicationType: "Redis",
-				Version:         "6.2.6",
-				Role:            model.RedisRoleSlave,
-				ClusterEnabled:  true,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MasterLinkStatus: true,
-			MasterPort:       7000,
-			MaxClients:       10000,
-			ConnectedClients: 50,
-			NonRootUser:      "N/A",
-			Status:           model.RedisStatusNormal,
-		})
+	var nodes []*model.RedisInspectionResult
+	for _, cluster := range []struct {
+		subnet  string
+		hosts   []int
+		clients int // Connected clients of the masters, half of it on the replicas
+	}{
+		{"192.18.102", []int{2, 3, 4}, 100},
+		{"192.18.107", []int{5, 6, 7}, 200},
+	} {
+		for _, host := range cluster.hosts {
+			ip := fmt.Sprintf("%s.%d", cluster.subnet, host)
+			nodes = append(nodes,
+				newTestRedisNode(ip+":7000", model.RedisRoleMaster, cluster.clients),
+				newTestRedisNode(ip+":7001", model.RedisRoleSlave, cluster.clients/2))
+		}
 	}
 
-	// Cluster 2: 192.18.107.x - 3 masters, 3 slaves
-	for i := 5; i <= 7; i++ {
-		ip := fmt.Sprintf("192.18.107.%d", i)
-		// Master
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address:         fmt.Sprintf("%s:7000", ip),
-				IP:              ip,
-				Port:            7000,
-				ApplicationType: "Redis",
-				Version:         "6.2.6",
-				Role:            model.RedisRoleMaster,
-				ClusterEnabled:  true,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MaxClients:       10000,
-			ConnectedClients: 200,
-			ConnectedSlaves:  1,
-			NonRootUser:      "N/A",
-			Status:           model.RedisStatusNormal,
-		})
-		// Slave
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address:         fmt.Sprintf("%s:7001", ip),
-				IP:              ip,
-				Port:            7001,
-				ApplicationType: "Redis",
-				Version:         "6.2.6",
-				Role:            model.RedisRoleSlave,
-				ClusterEnabled:  true,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MasterLinkStatus: true,
-			MasterPort:       7000,
-			MaxClients:       10000,
-			ConnectedClients: 100,
-			NonRootUser:      "N/A",
-			Status:           model.RedisStatusNormal,
+	results := testfixtures.NewRedisInspectionResults(nodes...)
+	results.GroupByClusters()
+	return results
+}
+
+// ============================================================================
+// Raw Data Sheet Tests
+// ============================================================================
+
+func TestWriter_AppendRawDataSheet(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "raw_data_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	w := NewWriter(nil)
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	collectedAt := time.Date(2025, 12, 13, 10, 0, 5, 0, tz)
+	records := []*model.RawDataRecord{
+		{Module: model.RawDataModuleHost, Target: "host-1", Metric: "cpu_usage", Value: 45.5, Unit: "%", Status: model.MetricStatusNormal, Timestamp: collectedAt, Query: "100 - avg(rate(cpu_usage_idle[5m]))"},
+		{Module: model.RawDataModuleMySQL, Target: "172.18.182.91:3306", Metric: "mysql_version", Text: "8.0.39", Status: model.MetricStatusNormal, Timestamp: collectedAt},
+		{Module: model.RawDataModuleMySQL, Target: "172.18.182.91:3306", Metric: "non_root_user", IsNA: true, Status: model.MetricStatusPending, Timestamp: collectedAt},
+	}
+	if err := w.AppendRawDataSheet(records, outputPath); err != nil {
+		t.Fatalf("AppendRawDataSheet() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	// Host sheets remain, raw data sheet is appended last
+	sheets := f.GetSheetList()
+	if sheets[len(sheets)-1] != sheetRawData {
+		t.Errorf("last sheet = %q, want %q", sheets[len(sheets)-1], sheetRawData)
+	}
+	if idx, _ := f.GetSheetIndex(sheetSummary); idx < 0 {
+		t.Errorf("summary sheet missing after append")
+	}
+
+	expectedHeaders := []string{"模块", "巡检对象", "指标", "值", "单位", "状态", "采集时间", "查询语句"}
+	for i, expected := range expectedHeaders {
+		cell := fmt.Sprintf("%s1", columnName(i+1))
+		value, _ := f.GetCellValue(sheetRawData, cell)
+		if value != expected {
+			t.Errorf("Header %s = %q, want %q", cell, value, expected)
+		}
+	}
+
+	tests := []struct {
+		cell     string
+		expected string
+	}{
+		{"A2", "主机"},
+		{"B2", "host-1"},
+		{"C2", "cpu_usage"},
+		{"D2", "45.5"},
+		{"E2", "%"},
+		{"F2", "正常"},
+		{"G2", "2025-12-13 10:00:05"},
+		{"H2", "100 - avg(rate(cpu_usage_idle[5m]))"},
+		{"D3", "8.0.39"},
+		{"D4", "N/A"},
+		{"F4", "N/A"},
+	}
+	for _, tt := range tests {
+		value, _ := f.GetCellValue(sheetRawData, tt.cell)
+		if value != tt.expected {
+			t.Errorf("Cell %s = %q, want %q", tt.cell, value, tt.expected)
+		}
+	}
+
+	// Numeric values must stay numeric for pivot table aggregation
+	cellType, _ := f.GetCellType(sheetRawData, "D2")
+	if cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+		t.Errorf("D2 should be numeric, got cell type %v", cellType)
+	}
+}
+
+func TestWriter_AppendRawDataSheet_MissingFile(t *testing.T) {
+	w := NewWriter(nil)
+	err := w.AppendRawDataSheet(nil, filepath.Join(t.TempDir(), "missing.xlsx"))
+	if err == nil {
+		t.Error("AppendRawDataSheet() on missing file should return error")
+	}
+}
+
+func TestMetricStatusText(t *testing.T) {
+	tests := []struct {
+		status   model.MetricStatus
+		expected string
+	}{
+		{model.MetricStatusNormal, "正常"},
+		{model.MetricStatusWarning, "警告"},
+		{model.MetricStatusCritical, "严重"},
+		{model.MetricStatusPending, "N/A"},
+		{model.MetricStatusAnomaly, "数据异常"},
+		{model.MetricStatus("unknown"), "未知"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := metricStatusText(tt.status); got != tt.expected {
+				t.Errorf("metricStatusText(%q) = %q, want %q", tt.status, got, tt.expected)
+			}
 		})
 	}
+}
 
-	// Call GroupByClusters to populate Clusters field
-	results.GroupByClusters()
+// ============================================================================
+// Nginx Upstream Sheet Tests
+// ============================================================================
+
+func TestWriter_NginxUpstreamSheet(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "test_nginx_report.xlsx")
+
+	r := model.NewNginxInspectionResult(model.NewNginxInstance("GX-NM-NGX-01", 80))
+	r.AddUpstreamStatus(model.NginxUpstreamStatus{UpstreamName: "api", BackendAddress: "10.0.0.1:8080", Status: false})
+	r.AddUpstreamStatus(model.NginxUpstreamStatus{UpstreamName: "web", BackendAddress: "10.0.0.2:8080", Status: true})
+	r.SetUpstream5xxRate("web", 1.234)
+
+	result := model.NewNginxInspectionResults(time.Now())
+	result.AddResult(r)
+
+	w := NewWriter(nil)
+	if err := w.WriteNginxInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteNginxInspection() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetNginxUpstream)
+	if err != nil {
+		t.Fatalf("GetRows(%q) error = %v", sheetNginxUpstream, err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d", len(rows))
+	}
 
-	results.Summary = &model.RedisInspectionSummary{
-		TotalInstances:    12,
-		NormalInstances:   12,
-		WarningInstances:  0,
-		CriticalInstances: 0,
-		FailedInstances:   0,
+	// api: all backends down, no 5xx data
+	if rows[1][1] != "api" || rows[1][5] != "N/A" || rows[1][6] != "全部异常" {
+		t.Errorf("unexpected api row: %v", rows[1])
 	}
-	results.AlertSummary = &model.RedisAlertSummary{
-		TotalAlerts:   0,
-		WarningCount:  0,
-		CriticalCount: 0,
+	// web: healthy with 5xx rate
+	if rows[2][1] != "web" || rows[2][5] != "1.23%" || rows[2][6] != "正常" {
+		t.Errorf("unexpected web row: %v", rows[2])
 	}
-	results.Version = "1.0.0-test"
+}
 
-	return results
+func TestWriter_NginxUpstreamSheet_SkippedWithoutData(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "test_nginx_report.xlsx")
+
+	result := model.NewNginxInspectionResults(time.Now())
+	result.AddResult(model.NewNginxInspectionResult(model.NewNginxInstance("GX-NM-NGX-01", 80)))
+
+	w := NewWriter(nil)
+	if err := w.WriteNginxInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteNginxInspection() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	for _, s := range f.GetSheetList() {
+		if s == sheetNginxUpstream {
+			t.Errorf("Sheet %q should not exist without upstream data", sheetNginxUpstream)
+		}
+	}
+}
+
+func TestWriter_CloudCostSheet(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "test_cloud_report.xlsx")
+
+	resource := model.NewCloudResource(model.CloudProviderAliyun, model.CloudResourceTypeRDS, "rm-bp1")
+	resource.Spec = "rds.mysql.s2.large"
+	r := model.NewCloudInspectionResult(resource)
+	r.PeakCPUUsage = 2.5
+
+	result := model.NewCloudInspectionResults(time.Now())
+	result.AddResult(r)
+	result.Finalize(time.Now())
+	result.CostSummary = model.NewCloudCostSummary(result.Results, 720*time.Hour, 5)
+
+	w := NewWriter(nil)
+	if err := w.WriteCloudInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteCloudInspection() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetCloudCost)
+	if err != nil {
+		t.Fatalf("GetRows(%q) error = %v", sheetCloudCost, err)
+	}
+	if len(rows) != 7 {
+		t.Fatalf("expected criteria + 2 tables (7 rows), got %d: %v", len(rows), rows)
+	}
+	if !strings.Contains(rows[0][0], "30 天") || !strings.Contains(rows[0][0], "5%") {
+		t.Errorf("unexpected criteria row: %v", rows[0])
+	}
+	if rows[3][2] != "rds.mysql.s2.large" || rows[3][3] != "1" || rows[3][4] != "1" {
+		t.Errorf("unexpected asset group row: %v", rows[3])
+	}
+	if rows[6][0] != "rm-bp1" || rows[6][6] != "2.5%" {
+		t.Errorf("unexpected under-utilized row: %v", rows[6])
+	}
+}
+
+func TestWriter_FirewallSheets(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "test_firewall_report.xlsx")
+
+	device := model.NewFirewallDevice(model.FirewallProfileFortiGate, "10.0.1.1")
+	device.Name = "fw-hq-01"
+	r := model.NewFirewallInspectionResult(device)
+	r.CPUUsage = 35
+	r.HAStatus = 1
+	r.AddTunnel(&model.FirewallTunnel{Name: "to-branch", Up: false})
+	r.AddTunnel(&model.FirewallTunnel{Name: "to-dc", Up: true})
+	r.Status = model.FirewallStatusCritical
+	alert := model.NewFirewallAlert(r.GetIdentifier(), "vpn_tunnel_status", 1, model.AlertLevelCritical)
+	alert.Message = "1 条 VPN 隧道断开：to-branch"
+	r.AddAlert(alert)
+
+	result := model.NewFirewallInspectionResults(time.Now())
+	result.AddResult(r)
+	result.Finalize(time.Now())
+
+	w := NewWriter(nil)
+	if err := w.WriteFirewallInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteFirewallInspection() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetFirewall)
+	if err != nil {
+		t.Fatalf("GetRows(%q) error = %v", sheetFirewall, err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected header + 1 device row, got %d", len(rows))
+	}
+	if rows[1][3] != "FortiGate" || rows[1][4] != "35.0%" || rows[1][6] != "N/A" || rows[1][8] != "1/2" || rows[1][9] != "正常" {
+		t.Errorf("unexpected device row: %v", rows[1])
+	}
+
+	tunnels, err := f.GetRows(sheetFirewallTunnels)
+	if err != nil {
+		t.Fatalf("GetRows(%q) error = %v", sheetFirewallTunnels, err)
+	}
+	if len(tunnels) != 3 || tunnels[1][2] != "to-branch" || tunnels[1][3] != "断开" || tunnels[2][3] != "正常" {
+		t.Errorf("unexpected tunnel rows: %v", tunnels)
+	}
+
+	alerts, err := f.GetRows(sheetFirewallAlerts)
+	if err != nil {
+		t.Fatalf("GetRows(%q) error = %v", sheetFirewallAlerts, err)
+	}
+	if len(alerts) != 2 || alerts[1][4] != "存在断开隧道" {
+		t.Errorf("unexpected alert rows: %v", alerts)
+	}
+}
+
+func TestWriter_Write_Theme(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "test_theme_report.xlsx")
+
+	w := NewWriter(nil, WithTheme(&theme.Theme{
+		Title:        "ACME & Co 巡检报告",
+		FooterText:   "ACME 运维服务",
+		PrimaryColor: "#0a7f3c",
+	}))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	if title, _ := f.GetCellValue(sheetSummary, "A1"); title != "ACME & Co 巡检报告" {
+		t.Errorf("summary title = %q, want themed title", title)
+	}
+	for _, sheet := range f.GetSheetList() {
+		hf, err := f.GetHeaderFooter(sheet)
+		if err != nil || hf == nil {
+			t.Fatalf("GetHeaderFooter(%q) error = %v", sheet, err)
+		}
+		if !strings.Contains(hf.OddHeader, "ACME && Co 巡检报告") || !strings.Contains(hf.OddFooter, "ACME 运维服务") {
+			t.Errorf("sheet %q has unexpected header/footer: %q / %q", sheet, hf.OddHeader, hf.OddFooter)
+		}
+	}
+
+	styleID, _ := f.GetCellStyle(sheetDetail, "A1")
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		t.Fatalf("GetStyle() error = %v", err)
+	}
+	if len(style.Fill.Color) == 0 || style.Fill.Color[0] != "0A7F3C" {
+		t.Errorf("header fill = %v, want theme primary color", style.Fill.Color)
+	}
+}
+
+func TestWriter_WriteCombined_TOCSheet(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "toc_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	hostResult.AlertSummary = model.NewAlertSummary(hostResult.Alerts)
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+
+	w := NewWriter(nil)
+	if err := w.WriteCombined(&json.Report{Host: hostResult, MySQL: mysqlResult}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if sheets[0] != sheetTOC {
+		t.Fatalf("first sheet = %q, want %q", sheets[0], sheetTOC)
+	}
+	if active := f.GetSheetName(f.GetActiveSheetIndex()); active != sheetTOC {
+		t.Errorf("active sheet = %q, want %q", active, sheetTOC)
+	}
+
+	// One row per other sheet, in workbook order, each linking to its sheet
+	for i, sheet := range sheets[1:] {
+		cell := fmt.Sprintf("B%d", i+2)
+		if value, _ := f.GetCellValue(sheetTOC, cell); value != sheet {
+			t.Errorf("TOC %s = %q, want %q", cell, value, sheet)
+		}
+		ok, target, err := f.GetCellHyperLink(sheetTOC, cell)
+		if err != nil || !ok || target != fmt.Sprintf("'%s'!A1", sheet) {
+			t.Errorf("TOC %s hyperlink = %v %q (err %v), want link to %q", cell, ok, target, err, sheet)
+		}
+	}
+
+	// Alert counts are shown on the inspection and alerts sheets, not on auxiliary sheets
+	for i, sheet := range sheets[1:] {
+		row := i + 2
+		critical, _ := f.GetCellValue(sheetTOC, fmt.Sprintf("D%d", row))
+		warning, _ := f.GetCellValue(sheetTOC, fmt.Sprintf("E%d", row))
+		switch sheet {
+		case sheetAlerts:
+			if critical != fmt.Sprint(hostResult.AlertSummary.CriticalCount) || warning != fmt.Sprint(hostResult.AlertSummary.WarningCount) {
+				t.Errorf("host alert counts = %q/%q, want %d/%d", critical, warning, hostResult.AlertSummary.CriticalCount, hostResult.AlertSummary.WarningCount)
+			}
+		case sheetMySQLAlerts:
+			if critical != fmt.Sprint(mysqlResult.AlertSummary.CriticalCount) || warning != fmt.Sprint(mysqlResult.AlertSummary.WarningCount) {
+				t.Errorf("MySQL alert counts = %q/%q, want %d/%d", critical, warning, mysqlResult.AlertSummary.CriticalCount, mysqlResult.AlertSummary.WarningCount)
+			}
+		case sheetMySQLMGRMembers:
+			if critical != "" || warning != "" {
+				t.Errorf("auxiliary sheet should not carry alert counts, got %q/%q", critical, warning)
+			}
+		}
+	}
+
+	// Appending the raw data sheet adds it to the table of contents
+	if err := w.AppendRawDataSheet(nil, outputPath); err != nil {
+		t.Fatalf("AppendRawDataSheet() error = %v", err)
+	}
+	f2, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen Excel file: %v", err)
+	}
+	defer f2.Close()
+	rows, _ := f2.GetRows(sheetTOC)
+	if last := rows[len(rows)-1]; len(last) < 2 || last[1] != sheetRawData {
+		t.Errorf("last TOC row = %v, want raw data sheet", last)
+	}
+}
+
+func TestWriter_WriteCombined_NoTOCForFewSheets(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "host_only.xlsx")
+
+	w := NewWriter(nil)
+	if err := w.WriteCombined(&json.Report{Host: createTestInspectionResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetTOC); idx >= 0 {
+		t.Errorf("host-only workbook should not have a %q sheet", sheetTOC)
+	}
+}
+
+func TestWriter_Finalize_AddsTOCToAppendedReport(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "appended_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+
+	w := NewWriter(nil)
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendMySQLInspection(mysqlResult, outputPath); err != nil {
+		t.Fatalf("AppendMySQLInspection() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult, MySQL: mysqlResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if sheets[0] != sheetTOC {
+		t.Fatalf("first sheet = %q, want %q", sheets[0], sheetTOC)
+	}
+	if active := f.GetSheetName(f.GetActiveSheetIndex()); active != sheetTOC {
+		t.Errorf("active sheet = %q, want %q", active, sheetTOC)
+	}
+	rows, _ := f.GetRows(sheetTOC)
+	if len(rows) != len(sheets) {
+		t.Errorf("TOC has %d rows, want header + %d sheets", len(rows), len(sheets)-1)
+	}
+}
+
+func TestSheetBadgeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		critical int
+		warning  int
+		expected string
+	}{
+		{sheetMySQL, 1, 2, "MySQL 巡检 (2⚠ 1✖)"},
+		{sheetMySQL, 0, 3, "MySQL 巡检 (3⚠)"},
+		{sheetMySQL, 4, 0, "MySQL 巡检 (4✖)"},
+		{sheetMySQL, 0, 0, sheetMySQL},
+		{"ABCDEFGHIJKLMNOPQRSTUVWXYZ 0123", 10, 20, "ABCDEFGHIJKLMNOPQRSTU (20⚠ 10✖)"},
+	}
+
+	for _, tt := range tests {
+		got := sheetBadgeName(tt.name, tt.critical, tt.warning)
+		if got != tt.expected {
+			t.Errorf("sheetBadgeName(%q, %d, %d) = %q, want %q", tt.name, tt.critical, tt.warning, got, tt.expected)
+		}
+		if n := len([]rune(got)); n > excelize.MaxSheetNameLength {
+			t.Errorf("sheetBadgeName(%q) has %d characters, exceeds limit", tt.name, n)
+		}
+	}
+}
+
+func TestWriter_Finalize_SheetAlertBadges(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "badges_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	hostResult.AlertSummary = model.NewAlertSummary(hostResult.Alerts)
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+
+	w := NewWriter(nil, WithSheetAlertBadges(true))
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendMySQLInspection(mysqlResult, outputPath); err != nil {
+		t.Fatalf("AppendMySQLInspection() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult, MySQL: mysqlResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	badged := sheetBadgeName(sheetMySQLAlerts, mysqlResult.AlertSummary.CriticalCount, mysqlResult.AlertSummary.WarningCount)
+	if badged == sheetMySQLAlerts {
+		t.Fatal("test data should contain MySQL alerts")
+	}
+	if idx, _ := f.GetSheetIndex(badged); idx < 0 {
+		t.Errorf("sheet %q not found, got sheets: %v", badged, f.GetSheetList())
+	}
+
+	// The table of contents links to the renamed sheets
+	rows, _ := f.GetRows(sheetTOC)
+	found := false
+	for i, row := range rows {
+		if len(row) > 1 && row[1] == badged {
+			found = true
+			_, target, _ := f.GetCellHyperLink(sheetTOC, fmt.Sprintf("B%d", i+1))
+			if target != fmt.Sprintf("'%s'!A1", badged) {
+				t.Errorf("TOC link = %q, want link to %q", target, badged)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("TOC should list %q", badged)
+	}
+}
+
+func TestWriter_Finalize_NoBadgesByDefault(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "no_badges_report.xlsx")
+
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+
+	w := NewWriter(nil)
+	if err := w.WriteMySQLInspection(mysqlResult, outputPath); err != nil {
+		t.Fatalf("WriteMySQLInspection() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{MySQL: mysqlResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetMySQLAlerts); idx < 0 {
+		t.Errorf("sheet %q should keep its name, got sheets: %v", sheetMySQLAlerts, f.GetSheetList())
+	}
+}
+
+func TestWriter_ChartsSheet(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "charts_report.xlsx")
+
+	result := createTestInspectionResult()
+	w := NewWriter(nil)
+	if err := w.Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheetCharts); idx < 0 {
+		t.Fatalf("sheet %q not found, got sheets: %v", sheetCharts, f.GetSheetList())
+	}
+
+	tests := []struct {
+		cell     string
+		expected string
+	}{
+		{"A2", "正常"},
+		{"B2", fmt.Sprint(result.Summary.NormalHosts)},
+		{"B3", fmt.Sprint(result.Summary.WarningHosts)},
+		{"A9", "严重"},
+		{"A10", "警告"},
+		{"B10", "1"},
+		{"A13", "主机名"},
+	}
+	for _, tt := range tests {
+		if value, _ := f.GetCellValue(sheetCharts, tt.cell); value != tt.expected {
+			t.Errorf("Cell %s = %q, want %q", tt.cell, value, tt.expected)
+		}
+	}
+
+	// Disk usage rows are sorted by usage, highest first
+	first, _ := f.GetCellValue(sheetCharts, "B14", excelize.Options{RawCellValue: true})
+	second, _ := f.GetCellValue(sheetCharts, "B15", excelize.Options{RawCellValue: true})
+	v1, err1 := strconv.ParseFloat(first, 64)
+	v2, err2 := strconv.ParseFloat(second, 64)
+	if err1 != nil || err2 != nil || v1 < v2 {
+		t.Errorf("disk usage rows not sorted descending: %q, %q", first, second)
+	}
+}
+
+func TestTopDiskUsageHosts(t *testing.T) {
+	disk := func(value float64) map[string]*model.MetricValue {
+		return map[string]*model.MetricValue{"disk_usage_max": {Name: "disk_usage_max", RawValue: value}}
+	}
+	hosts := []*model.HostResult{
+		{Hostname: "a", Metrics: disk(40)},
+		{Hostname: "b", Metrics: disk(90)},
+		{Hostname: "c", Metrics: map[string]*model.MetricValue{"disk_usage_max": {Name: "disk_usage_max", IsNA: true}}},
+		{Hostname: "d", Metrics: map[string]*model.MetricValue{}},
+		{Hostname: "e", Metrics: disk(70)},
+		nil,
+	}
+
+	got := topDiskUsageHosts(hosts, 2)
+	if len(got) != 2 || got[0].Hostname != "b" || got[1].Hostname != "e" {
+		names := make([]string, 0, len(got))
+		for _, h := range got {
+			names = append(names, h.Hostname)
+		}
+		t.Errorf("topDiskUsageHosts() = %v, want [b e]", names)
+	}
+}
+
+func TestWriter_WriteCombined_SheetOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "ordered_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	hostResult.AlertSummary = model.NewAlertSummary(hostResult.Alerts)
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+
+	w := NewWriter(nil, WithSheetOrder([]string{ModuleMySQL}))
+	if err := w.WriteCombined(&json.Report{Host: hostResult, MySQL: mysqlResult}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	// TOC first, then the MySQL sheets, then the host sheets in their default order
+	sheets := f.GetSheetList()
+	want := []string{sheetTOC, sheetMySQL, sheetMySQLAlerts, sheetSummary, sheetDetail, sheetAlerts, sheetCharts}
+	if strings.Join(sheets, ",") != strings.Join(want, ",") {
+		t.Errorf("sheets = %v, want %v", sheets, want)
+	}
+	if value, _ := f.GetCellValue(sheetTOC, "B2"); value != sheetMySQL {
+		t.Errorf("first TOC entry = %q, want %q", value, sheetMySQL)
+	}
+}
+
+func TestWriter_Finalize_HideEmptySheets(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "hidden_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	hostResult.AlertSummary = model.NewAlertSummary(hostResult.Alerts)
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+	nginxResult := model.NewNginxInspectionResults(time.Now())
+	nginxResult.AlertSummary = model.NewNginxAlertSummary(nil)
+
+	w := NewWriter(nil, WithHideEmptySheets(true))
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendMySQLInspection(mysqlResult, outputPath); err != nil {
+		t.Fatalf("AppendMySQLInspection() error = %v", err)
+	}
+	if err := w.AppendNginxInspection(nginxResult, outputPath); err != nil {
+		t.Fatalf("AppendNginxInspection() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult, MySQL: mysqlResult, Nginx: nginxResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	for _, sheet := range []string{sheetNginx, sheetNginxAlerts} {
+		if idx, _ := f.GetSheetIndex(sheet); idx < 0 {
+			t.Fatalf("sheet %q missing, sheets = %v", sheet, f.GetSheetList())
+		}
+		if visible, err := f.GetSheetVisible(sheet); err != nil || visible {
+			t.Errorf("sheet %q visible = %v (err %v), want hidden", sheet, visible, err)
+		}
+	}
+	if visible, _ := f.GetSheetVisible(sheetSummary); !visible {
+		t.Errorf("host sheet %q should stay visible", sheetSummary)
+	}
+
+	// Hidden sheets are not listed in the table of contents
+	rows, err := f.GetRows(sheetTOC)
+	if err != nil || len(rows) < 2 {
+		t.Fatalf("TOC rows = %v (err %v), want a table of contents", rows, err)
+	}
+	for _, row := range rows[1:] {
+		if len(row) > 1 && (row[1] == sheetNginx || row[1] == sheetNginxAlerts) {
+			t.Errorf("TOC should not list hidden sheet %q", row[1])
+		}
+	}
+}
+
+func TestWriter_Finalize_KeepsEmptySheetsByDefault(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "visible_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	hostResult.AlertSummary = model.NewAlertSummary(hostResult.Alerts)
+	nginxResult := model.NewNginxInspectionResults(time.Now())
+	nginxResult.AlertSummary = model.NewNginxAlertSummary(nil)
+
+	w := NewWriter(nil)
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendNginxInspection(nginxResult, outputPath); err != nil {
+		t.Fatalf("AppendNginxInspection() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult, Nginx: nginxResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	if visible, _ := f.GetSheetVisible(sheetNginx); !visible {
+		t.Errorf("sheet %q should be visible by default", sheetNginx)
+	}
+}
+
+func TestGroupAlertsByHost(t *testing.T) {
+	alerts := []*model.Alert{
+		{Hostname: "web-1", MetricDisplayName: "内存利用率", Level: model.AlertLevelWarning},
+		{Hostname: "db-1", MetricDisplayName: "磁盘利用率", Level: model.AlertLevelWarning},
+		{Hostname: "web-1", MetricDisplayName: "CPU利用率", Level: model.AlertLevelCritical},
+		{Hostname: "app-1", MetricDisplayName: "CPU利用率", Level: model.AlertLevelWarning},
+		{Hostname: "app-1", MetricDisplayName: "内存利用率", Level: model.AlertLevelWarning},
+		nil,
+	}
+
+	groups := groupAlertsByHost(alerts)
+	if len(groups) != 3 {
+		t.Fatalf("groupAlertsByHost() returned %d groups, want 3", len(groups))
+	}
+
+	// Most critical alerts first, then most warnings, then hostname
+	wantHosts := []string{"web-1", "app-1", "db-1"}
+	for i, g := range groups {
+		if g.hostname != wantHosts[i] {
+			t.Errorf("group %d = %q, want %q", i, g.hostname, wantHosts[i])
+		}
+	}
+	if groups[0].critical != 1 || groups[0].warning != 1 {
+		t.Errorf("web-1 counts = %d/%d, want 1/1", groups[0].critical, groups[0].warning)
+	}
+	if groups[0].alerts[0].Level != model.AlertLevelCritical {
+		t.Errorf("critical alert should come first within the group")
+	}
+}
+
+func TestWriter_Write_GroupedAlerts(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "grouped_report.xlsx")
+
+	result := createTestInspectionResult()
+	result.Alerts = []*model.Alert{
+		{Hostname: "host-2", MetricName: "cpu_usage", MetricDisplayName: "CPU利用率", FormattedValue: "75.0%", Level: model.AlertLevelWarning},
+		{Hostname: "host-3", MetricName: "cpu_usage", MetricDisplayName: "CPU利用率", FormattedValue: "95.0%", Level: model.AlertLevelCritical},
+		{Hostname: "host-3", MetricName: "memory_usage", MetricDisplayName: "内存利用率", FormattedValue: "92.0%", Level: model.AlertLevelCritical},
+	}
+	result.AlertSummary = model.NewAlertSummary(result.Alerts)
+
+	w := NewWriter(nil, WithAlertGrouping(true))
+	if err := w.Write(result, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	// Rows: host-3 subtotal, 2 alerts, host-2 subtotal, 1 alert
+	wantRows := []struct {
+		host    string
+		metric  string
+		outline uint8
+	}{
+		{"host-3", "小计", 0},
+		{"host-3", "CPU利用率", 1},
+		{"host-3", "内存利用率", 1},
+		{"host-2", "小计", 0},
+		{"host-2", "CPU利用率", 1},
+	}
+	for i, want := range wantRows {
+		row := i + 2
+		host, _ := f.GetCellValue(sheetAlerts, fmt.Sprintf("A%d", row))
+		metric, _ := f.GetCellValue(sheetAlerts, fmt.Sprintf("C%d", row))
+		if host != want.host || metric != want.metric {
+			t.Errorf("row %d = %q/%q, want %q/%q", row, host, metric, want.host, want.metric)
+		}
+		if level, _ := f.GetRowOutlineLevel(sheetAlerts, row); level != want.outline {
+			t.Errorf("row %d outline level = %d, want %d", row, level, want.outline)
+		}
+	}
+
+	if subtotal, _ := f.GetCellValue(sheetAlerts, "G2"); subtotal != "共 2 条告警（严重 2，警告 0）" {
+		t.Errorf("host-3 subtotal = %q", subtotal)
+	}
+
+	props, err := f.GetSheetProps(sheetAlerts)
+	if err != nil || props.OutlineSummaryBelow == nil || *props.OutlineSummaryBelow {
+		t.Errorf("subtotal rows should be placed above their groups")
+	}
+}
+
+func TestWriter_WriteCombined_English(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "english_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	hostResult.AlertSummary = model.NewAlertSummary(hostResult.Alerts)
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+
+	w := NewWriter(nil, WithLanguage(i18n.LanguageEN))
+	if err := w.WriteCombined(&json.Report{Host: hostResult, MySQL: mysqlResult}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	want := []string{"Contents", "Overview", "Details", "Alerts", "Charts", "MySQL", "MySQL Alerts"}
+	if strings.Join(sheets, ",") != strings.Join(want, ",") {
+		t.Errorf("sheets = %v, want %v", sheets, want)
+	}
+	if f.GetSheetName(f.GetActiveSheetIndex()) != "Contents" {
+		t.Errorf("active sheet = %q, want Contents", f.GetSheetName(f.GetActiveSheetIndex()))
+	}
+
+	// Table of contents headers, links and modules
+	if value, _ := f.GetCellValue("Contents", "A1"); value != "No." {
+		t.Errorf("TOC header = %q, want No.", value)
+	}
+	if value, _ := f.GetCellValue("Contents", "C2"); value != "Host" {
+		t.Errorf("TOC module = %q, want Host", value)
+	}
+	if ok, target, _ := f.GetCellHyperLink("Contents", "B2"); !ok || target != "'Overview'!A1" {
+		t.Errorf("TOC link = %v %q, want 'Overview'!A1", ok, target)
+	}
+
+	// Headers and status texts
+	rows, err := f.GetRows("Details")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if rows[0][0] != "Hostname" {
+		t.Errorf("detail header = %q, want Hostname", rows[0][0])
+	}
+	for _, row := range rows {
+		for _, value := range row {
+			if value == "正常" || value == "警告" || value == "严重" {
+				t.Errorf("status text %q should be translated", value)
+			}
+		}
+	}
+
+	// Charts reference the localized sheet name
+	if value, _ := f.GetCellValue("Charts", "A2"); value != "Normal" {
+		t.Errorf("chart label = %q, want Normal", value)
+	}
+}
+
+func TestWriter_Finalize_Bilingual(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "bilingual_report.xlsx")
+
+	hostResult := createTestInspectionResult()
+	hostResult.AlertSummary = model.NewAlertSummary(hostResult.Alerts)
+	mysqlResult := createTestMySQLInspectionResults()
+	mysqlResult.AlertSummary = model.NewMySQLAlertSummary(mysqlResult.Alerts)
+
+	w := NewWriter(nil, WithLanguage(i18n.LanguageBilingual), WithSheetAlertBadges(true))
+	if err := w.Write(hostResult, outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.AppendMySQLInspection(mysqlResult, outputPath); err != nil {
+		t.Fatalf("AppendMySQLInspection() error = %v", err)
+	}
+	if err := w.Finalize(&json.Report{Host: hostResult, MySQL: mysqlResult}, outputPath); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+	if err := w.AppendRawDataSheet(model.NewHostRawDataRecords(hostResult, nil), outputPath); err != nil {
+		t.Fatalf("AppendRawDataSheet() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if sheets[0] != "目录 Contents" {
+		t.Fatalf("first sheet = %q, want the bilingual table of contents", sheets[0])
+	}
+	for _, sheet := range sheets {
+		if len([]rune(sheet)) > excelize.MaxSheetNameLength {
+			t.Errorf("sheet name %q exceeds the Excel limit", sheet)
+		}
+	}
+	if sheets[len(sheets)-1] != "原始数据 Raw Data" {
+		t.Errorf("last sheet = %q, want 原始数据 Raw Data", sheets[len(sheets)-1])
+	}
+
+	// Every TOC link points to an existing sheet
+	rows, err := f.GetRows("目录 Contents")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if rows[0][0] != "序号 / No." {
+		t.Errorf("TOC header = %q, want 序号 / No.", rows[0][0])
+	}
+	for i := 2; i <= len(rows); i++ {
+		cell := fmt.Sprintf("B%d", i)
+		ok, target, _ := f.GetCellHyperLink("目录 Contents", cell)
+		if !ok {
+			t.Errorf("TOC cell %s has no link", cell)
+			continue
+		}
+		sheet := strings.TrimSuffix(strings.TrimPrefix(target, "'"), "'!A1")
+		if idx, _ := f.GetSheetIndex(sheet); idx < 0 {
+			t.Errorf("TOC link %q points to a missing sheet", target)
+		}
+	}
+}
+
+func TestWriter_SheetProtection(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "protected.xlsx")
+	w := NewWriter(nil, WithSheetProtection(true, "secret"))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	for _, sheet := range f.GetSheetList() {
+		if err := f.UnprotectSheet(sheet, "wrong"); err != excelize.ErrUnprotectSheetPassword {
+			t.Errorf("UnprotectSheet(%s, wrong) error = %v, want %v", sheet, err, excelize.ErrUnprotectSheetPassword)
+		}
+		if err := f.UnprotectSheet(sheet, "secret"); err != nil {
+			t.Errorf("UnprotectSheet(%s, secret) error = %v", sheet, err)
+		}
+	}
+
+	// Protection does not change the content of streamed sheets
+	if host, _ := f.GetCellValue(sheetDetail, "A2"); host != "host-1" {
+		t.Errorf("detail A2 = %q, want host-1", host)
+	}
+}
+
+func TestWriter_SheetProtection_Disabled(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	if err := f.UnprotectSheet(sheetSummary, "secret"); err != excelize.ErrUnprotectSheet {
+		t.Errorf("UnprotectSheet() error = %v, want %v (sheet not protected)", err, excelize.ErrUnprotectSheet)
+	}
+}
+
+func TestWriter_Password(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "encrypted.xlsx")
+	w := NewWriter(nil, WithPassword("open-sesame"))
+	if err := w.Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	// Appending opens the encrypted workbook with the same password
+	records := []*model.RawDataRecord{
+		{Module: model.RawDataModuleHost, Target: "host-1", Metric: "cpu_usage", Value: 45.5, Unit: "%", Status: model.MetricStatusNormal},
+	}
+	if err := w.AppendRawDataSheet(records, outputPath); err != nil {
+		t.Fatalf("AppendRawDataSheet() error = %v", err)
+	}
+
+	if f, err := excelize.OpenFile(outputPath); err == nil {
+		f.Close()
+		t.Fatal("OpenFile() without password should fail for an encrypted workbook")
+	}
+
+	f, err := excelize.OpenFile(outputPath, excelize.Options{Password: "open-sesame"})
+	if err != nil {
+		t.Fatalf("OpenFile() with password error = %v", err)
+	}
+	defer f.Close()
+
+	if host, _ := f.GetCellValue(sheetDetail, "A2"); host != "host-1" {
+		t.Errorf("detail A2 = %q, want host-1", host)
+	}
+	if idx, _ := f.GetSheetIndex(sheetRawData); idx < 0 {
+		t.Error("raw data sheet should be appended to the encrypted workbook")
+	}
 }
@@ -0,0 +1,43 @@
+package html
+
+import (
+	"strings"
+
+	"inspection-tool/internal/model"
+)
+
+// ActiveAlertData represents an alert rule firing in N9E formatted for template rendering.
+type ActiveAlertData struct {
+	Hostname         string
+	RuleName         string
+	Level            string
+	LevelClass       string // badge class: critical, warning or normal (N9E info)
+	TriggerTime      string
+	TriggerValue     string
+	GroupName        string
+	Tags             string
+	InspectionAlerts []string // Inspection alerts of the same host, one badge each so they are translated
+}
+
+// convertActiveAlerts converts the active alerts, already sorted by severity, for template rendering.
+func (w *Writer) convertActiveAlerts(alerts []*model.ActiveAlert) []*ActiveAlertData {
+	result := make([]*ActiveAlertData, 0, len(alerts))
+	for _, a := range alerts {
+		data := &ActiveAlertData{
+			Hostname:         a.Hostname,
+			RuleName:         a.RuleName,
+			Level:            a.LevelText(),
+			LevelClass:       string(a.Level),
+			TriggerTime:      "-",
+			TriggerValue:     a.TriggerValue,
+			GroupName:        a.GroupName,
+			Tags:             strings.Join(a.Tags, ", "),
+			InspectionAlerts: a.Inspection,
+		}
+		if !a.TriggerTime.IsZero() {
+			data.TriggerTime = w.formatInspectionTime(a.TriggerTime)
+		}
+		result = append(result, data)
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+)
+
+// createTestResultWithActiveAlerts returns a result with a critical active alert correlated with
+// an inspection alert and an info alert without one.
+func createTestResultWithActiveAlerts() *model.InspectionResult {
+	result := createTestResult()
+	result.ActiveAlerts = []*model.ActiveAlert{
+		{
+			Hostname: "test-host-1", RuleName: "CPU使用率过高", Level: model.AlertLevelCritical,
+			TriggerTime: time.Date(2025, 1, 15, 2, 30, 0, 0, time.UTC), TriggerValue: "95.2",
+			Inspection: []string{"CPU利用率"},
+		},
+		{Hostname: "test-host-1", RuleName: "进程数过多", Level: model.AlertLevelNormal},
+	}
+	return result
+}
+
+func TestWriter_Write_ActiveAlerts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+	if err := NewWriter(time.UTC, "").Write(createTestResultWithActiveAlerts(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	for _, want := range []string{
+		"当前告警",
+		`<span class="badge badge-critical">严重</span>`,
+		"CPU使用率过高",
+		"2025-01-15 02:30:00",
+		`<span class="badge badge-warning">CPU利用率</span>`,
+		`<span class="badge badge-normal">提示</span>`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+}
+
+func TestWriter_Write_NoActiveAlerts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+	if err := NewWriter(nil, "").Write(createTestResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if strings.Contains(string(content), "active-alerts-table\"") {
+		t.Error("active alerts section should only be rendered with active alerts")
+	}
+}
+
+func TestWriter_WriteCombined_ActiveAlertsEnglish(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+	w := NewWriter(time.UTC, "", WithLanguage(i18n.LanguageEN))
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithActiveAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	for _, want := range []string{"Active Alerts", "Inspection Alerts", ">CPU Usage<", ">Info<"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package html
+
+import (
+	"strings"
+
+	"inspection-tool/internal/model"
+)
+
+// AlertmanagerAlertData represents an alert firing in Alertmanager formatted for template rendering.
+type AlertmanagerAlertData struct {
+	Name         string
+	Severity     string // severity 标签，未设置时为 "-"
+	LevelClass   string // badge class: critical, warning or normal
+	Instance     string
+	Summary      string
+	StartsAt     string
+	State        string // 触发中 / 已静默 / 已抑制
+	StateClass   string // badge class: critical for alerts still notifying, failed for muted ones
+	SilencedBy   string // 静默创建人
+	SilenceNote  string // 静默原因
+	SilenceUntil string // 静默截止时间
+}
+
+// WithAlertmanagerAlerts adds an "Alertmanager 告警" section to combined reports listing the
+// alerts firing in Alertmanager, with the silences muting them. No alerts means no section.
+func WithAlertmanagerAlerts(alerts []*model.AlertmanagerAlert) Option {
+	return func(w *Writer) {
+		w.alertmanagerAlerts = alerts
+	}
+}
+
+// convertAlertmanagerAlerts converts the Alertmanager alerts, already sorted, for template rendering.
+func (w *Writer) convertAlertmanagerAlerts(alerts []*model.AlertmanagerAlert) []*AlertmanagerAlertData {
+	result := make([]*AlertmanagerAlertData, 0, len(alerts))
+	for _, a := range alerts {
+		data := &AlertmanagerAlertData{
+			Name:         a.Name,
+			Severity:     a.Severity,
+			LevelClass:   string(a.Level()),
+			Instance:     a.Instance,
+			Summary:      a.Summary,
+			StartsAt:     "-",
+			State:        a.StateText(),
+			StateClass:   "critical",
+			SilencedBy:   "-",
+			SilenceNote:  "-",
+			SilenceUntil: "-",
+		}
+		if data.Severity == "" {
+			data.Severity = "-"
+		}
+		if !a.StartsAt.IsZero() {
+			data.StartsAt = w.formatInspectionTime(a.StartsAt)
+		}
+		if a.Silenced() || a.Inhibited() {
+			data.StateClass = "failed"
+		}
+		if len(a.Silences) > 0 {
+			creators := make([]string, 0, len(a.Silences))
+			comments := make([]string, 0, len(a.Silences))
+			until := make([]string, 0, len(a.Silences))
+			for _, s := range a.Silences {
+				creators = append(creators, s.CreatedBy)
+				comments = append(comments, s.Comment)
+				until = append(until, w.formatInspectionTime(s.EndsAt))
+			}
+			data.SilencedBy = strings.Join(creators, "; ")
+			data.SilenceNote = strings.Join(comments, "; ")
+			data.SilenceUntil = strings.Join(until, "; ")
+		} else if a.Silenced() {
+			data.SilenceNote = strings.Join(a.SilencedBy, "; ")
+		}
+		result = append(result, data)
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+)
+
+// testAlertmanagerAlerts returns a firing alert and a silenced one.
+func testAlertmanagerAlerts() []*model.AlertmanagerAlert {
+	return []*model.AlertmanagerAlert{
+		{Name: "DiskFull", Severity: "warning", Instance: "10.0.0.2:9100", State: model.AlertmanagerStateActive},
+		{
+			Name: "HostDown", Severity: "critical", State: model.AlertmanagerStateSuppressed, SilencedBy: []string{"s1"},
+			Silences: []*model.AlertmanagerSilence{
+				{ID: "s1", CreatedBy: "ops", Comment: "maintenance", EndsAt: time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+}
+
+func TestWriter_WriteCombined_AlertmanagerAlerts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+	w := NewWriter(time.UTC, "", WithAlertmanagerAlerts(testAlertmanagerAlerts()))
+	if err := w.WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	for _, want := range []string{
+		"Alertmanager 告警",
+		`<span class="badge badge-warning">warning</span>`,
+		`<span class="badge badge-critical">触发中</span>`,
+		`<span class="badge badge-failed">已静默</span>`,
+		"<td>maintenance</td>",
+		"<td>2025-01-15 06:00:00</td>",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+}
+
+func TestWriter_WriteCombined_AlertmanagerAlertsEnglish(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+	w := NewWriter(time.UTC, "", WithLanguage(i18n.LanguageEN), WithAlertmanagerAlerts(testAlertmanagerAlerts()))
+	if err := w.WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	for _, want := range []string{"Alertmanager Alerts", "Firing Alerts", ">Silenced<", ">Silence Comment<"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+}
+
+func TestWriter_WriteCombined_NoAlertmanagerAlerts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+	if err := NewWriter(nil, "").WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if strings.Contains(string(content), `id="alertmanager-alerts-table"`) {
+		t.Error("Alertmanager section should only be rendered with Alertmanager alerts")
+	}
+}
@@ -0,0 +1,338 @@
+package html
+
+import (
+	"embed"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+)
+
+// Chart colors, matching the summary card colors of the templates.
+const (
+	chartColorNormal   = "#28a745"
+	chartColorWarning  = "#ffc107"
+	chartColorCritical = "#dc3545"
+	chartColorFailed   = "#6c757d"
+	chartColorCPU      = "#667eea"
+	chartColorMemory   = "#48bb78"
+
+	// Number of hosts visible at once in the CPU / memory chart before scrolling
+	chartVisibleHosts = 20
+)
+
+// HostChartsData holds the ECharts options of the host charts.
+// The options are rendered as JSON in the template script.
+type HostChartsData struct {
+	Status    map[string]interface{} // 主机状态环形图
+	Resources map[string]interface{} // 各主机 CPU / 内存利用率柱状图
+	Alerts    map[string]interface{} // 告警分布（按指标与级别）
+}
+
+// AlertChartsData holds the ECharts options of the alert distribution charts shown with the
+// executive summary of combined reports.
+type AlertChartsData struct {
+	Source map[string]interface{} // 告警来源分布（按模块与级别）
+	Level  map[string]interface{} // 告警级别分布环形图
+}
+
+// bundledAssets holds the report assets built into the binary. assets/echarts.min.js is
+// the ECharts release downloaded by "make echarts"; binaries built without it only render
+// charts with WithChartLibrary.
+//
+//go:embed assets
+var bundledAssets embed.FS
+
+// bundledChartLibrary is the ECharts script built into the binary, nil when none was bundled.
+var bundledChartLibrary, _ = bundledAssets.ReadFile("assets/echarts.min.js")
+
+// WithChartLibrary replaces the bundled ECharts script of the interactive charts with a
+// local one (echarts.min.js), e.g. another ECharts release. The script is embedded inline,
+// so the report stays self-contained. An empty path keeps the bundled script.
+func WithChartLibrary(path string) Option {
+	return func(w *Writer) {
+		w.chartLibraryPath = path
+	}
+}
+
+// chartsEnabled reports whether an ECharts script is available to render the charts.
+func (w *Writer) chartsEnabled() bool {
+	return w.chartLibraryPath != "" || len(bundledChartLibrary) > 0
+}
+
+// chartLibrary returns the ECharts script for inline embedding: the configured script, or
+// the bundled one. An unreadable file falls back to the bundled script; the file is checked
+// when the configuration is validated.
+func (w *Writer) chartLibrary() template.JS {
+	data := bundledChartLibrary
+	if w.chartLibraryPath != "" {
+		if script, err := os.ReadFile(w.chartLibraryPath); err == nil {
+			data = script
+		}
+	}
+	// Keep the script element from being closed early by the library source
+	return template.JS(strings.ReplaceAll(string(data), "</script", `<\/script`))
+}
+
+// prepareHostCharts builds the host chart options.
+// Returns nil when charts are disabled or there are no hosts.
+func (w *Writer) prepareHostCharts(result *model.InspectionResult) *HostChartsData {
+	if !w.chartsEnabled() || result == nil || len(result.Hosts) == 0 {
+		return nil
+	}
+
+	summary := result.Summary
+	if summary == nil {
+		summary = model.NewInspectionSummary(result.Hosts)
+	}
+
+	return &HostChartsData{
+		Status:    hostStatusChart(summary, w.tr),
+		Resources: hostResourceChart(result.Hosts, w.tr),
+		Alerts:    alertDistributionChart(result.Alerts, w.tr),
+	}
+}
+
+// prepareAlertCharts builds the alert distribution charts of all modules.
+// Returns nil when charts are disabled or there are no alerts.
+func (w *Writer) prepareAlertCharts(alerts []*json.Alert) *AlertChartsData {
+	if !w.chartsEnabled() || len(alerts) == 0 {
+		return nil
+	}
+
+	return &AlertChartsData{
+		Source: alertSourceChart(alerts, w.tr),
+		Level:  alertLevelChart(alerts, w.tr),
+	}
+}
+
+// hostStatusChart returns the donut chart option of the host status distribution.
+// Chart texts are rendered by the chart script, so they are translated here.
+func hostStatusChart(summary *model.InspectionSummary, tr *i18n.Translator) map[string]interface{} {
+	item := func(name string, value int, color string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":      name,
+			"value":     value,
+			"itemStyle": map[string]interface{}{"color": color},
+		}
+	}
+
+	return map[string]interface{}{
+		"title":   map[string]interface{}{"text": tr.T("主机状态分布"), "left": "center"},
+		"tooltip": map[string]interface{}{"trigger": "item", "formatter": "{b}: {c} ({d}%)"},
+		"legend":  map[string]interface{}{"bottom": 0},
+		"series": []interface{}{
+			map[string]interface{}{
+				"type":   "pie",
+				"radius": []string{"45%", "70%"},
+				"label":  map[string]interface{}{"formatter": "{b}: {c}"},
+				"data": []interface{}{
+					item(tr.T("正常"), summary.NormalHosts, chartColorNormal),
+					item(tr.T("警告"), summary.WarningHosts, chartColorWarning),
+					item(tr.T("严重"), summary.CriticalHosts, chartColorCritical),
+					item(tr.T("失败"), summary.FailedHosts, chartColorFailed),
+				},
+			},
+		},
+	}
+}
+
+// hostResourceChart returns the bar chart option of the CPU and memory usage per host.
+// Metrics that were not collected are left empty.
+func hostResourceChart(hosts []*model.HostResult, tr *i18n.Translator) map[string]interface{} {
+	names := make([]string, 0, len(hosts))
+	cpu := make([]interface{}, 0, len(hosts))
+	memory := make([]interface{}, 0, len(hosts))
+	for _, host := range hosts {
+		if host == nil {
+			continue
+		}
+		names = append(names, host.Hostname)
+		cpu = append(cpu, chartMetricValue(host.Metrics["cpu_usage"]))
+		memory = append(memory, chartMetricValue(host.Metrics["memory_usage"]))
+	}
+
+	option := map[string]interface{}{
+		"title":   map[string]interface{}{"text": tr.T("主机 CPU / 内存利用率"), "left": "center"},
+		"tooltip": map[string]interface{}{"trigger": "axis"},
+		"legend":  map[string]interface{}{"top": 28},
+		"grid":    map[string]interface{}{"left": 48, "right": 24, "top": 64, "bottom": 80},
+		"xAxis": map[string]interface{}{
+			"type":      "category",
+			"data":      names,
+			"axisLabel": map[string]interface{}{"rotate": 30, "interval": 0},
+		},
+		"yAxis": map[string]interface{}{"type": "value", "max": 100, "name": "%"},
+		"series": []interface{}{
+			map[string]interface{}{"name": tr.T("CPU利用率"), "type": "bar", "data": cpu, "itemStyle": map[string]interface{}{"color": chartColorCPU}},
+			map[string]interface{}{"name": tr.T("内存利用率"), "type": "bar", "data": memory, "itemStyle": map[string]interface{}{"color": chartColorMemory}},
+		},
+	}
+
+	// Scroll through large host lists instead of squeezing the bars
+	if len(names) > chartVisibleHosts {
+		option["dataZoom"] = []interface{}{
+			map[string]interface{}{"type": "slider", "startValue": 0, "endValue": chartVisibleHosts - 1, "bottom": 8},
+		}
+	}
+
+	return option
+}
+
+// alertDistributionChart returns the stacked bar chart option of the alerts per metric and level,
+// metrics with the most alerts first.
+func alertDistributionChart(alerts []*model.Alert, tr *i18n.Translator) map[string]interface{} {
+	type counts struct {
+		name     string
+		warning  int
+		critical int
+	}
+	byMetric := make(map[string]*counts)
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+		name := alert.MetricDisplayName
+		if name == "" {
+			name = alert.MetricName
+		}
+		c, ok := byMetric[name]
+		if !ok {
+			c = &counts{name: name}
+			byMetric[name] = c
+		}
+		switch alert.Level {
+		case model.AlertLevelWarning:
+			c.warning++
+		case model.AlertLevelCritical:
+			c.critical++
+		}
+	}
+
+	list := make([]*counts, 0, len(byMetric))
+	for _, c := range byMetric {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		ti, tj := list[i].warning+list[i].critical, list[j].warning+list[j].critical
+		if ti != tj {
+			return ti > tj
+		}
+		return list[i].name < list[j].name
+	})
+
+	names := make([]string, 0, len(list))
+	warning := make([]int, 0, len(list))
+	critical := make([]int, 0, len(list))
+	for _, c := range list {
+		names = append(names, c.name)
+		warning = append(warning, c.warning)
+		critical = append(critical, c.critical)
+	}
+
+	return map[string]interface{}{
+		"title":   map[string]interface{}{"text": tr.T("告警分布"), "left": "center"},
+		"tooltip": map[string]interface{}{"trigger": "axis"},
+		"legend":  map[string]interface{}{"top": 28},
+		"grid":    map[string]interface{}{"left": 48, "right": 24, "top": 64, "bottom": 48},
+		"xAxis":   map[string]interface{}{"type": "category", "data": names, "axisLabel": map[string]interface{}{"interval": 0}},
+		"yAxis":   map[string]interface{}{"type": "value", "minInterval": 1},
+		"series": []interface{}{
+			map[string]interface{}{"name": tr.T("严重"), "type": "bar", "stack": "level", "data": critical, "itemStyle": map[string]interface{}{"color": chartColorCritical}},
+			map[string]interface{}{"name": tr.T("警告"), "type": "bar", "stack": "level", "data": warning, "itemStyle": map[string]interface{}{"color": chartColorWarning}},
+		},
+	}
+}
+
+// alertSourceChart returns the stacked bar chart option of the alerts per module and level,
+// modules in report order.
+func alertSourceChart(alerts []*json.Alert, tr *i18n.Translator) map[string]interface{} {
+	var modules []string
+	index := make(map[string]int)
+	var warning, critical []int
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+		i, ok := index[alert.Module]
+		if !ok {
+			i = len(modules)
+			index[alert.Module] = i
+			modules = append(modules, tr.T(json.ModuleName(alert.Module)))
+			warning = append(warning, 0)
+			critical = append(critical, 0)
+		}
+		switch alert.Level {
+		case model.AlertLevelWarning:
+			warning[i]++
+		case model.AlertLevelCritical:
+			critical[i]++
+		}
+	}
+
+	return map[string]interface{}{
+		"title":   map[string]interface{}{"text": tr.T("告警来源分布"), "left": "center"},
+		"tooltip": map[string]interface{}{"trigger": "axis"},
+		"legend":  map[string]interface{}{"top": 28},
+		"grid":    map[string]interface{}{"left": 48, "right": 24, "top": 64, "bottom": 48},
+		"xAxis":   map[string]interface{}{"type": "category", "data": modules, "axisLabel": map[string]interface{}{"interval": 0}},
+		"yAxis":   map[string]interface{}{"type": "value", "minInterval": 1},
+		"series": []interface{}{
+			map[string]interface{}{"name": tr.T("严重"), "type": "bar", "stack": "level", "data": critical, "itemStyle": map[string]interface{}{"color": chartColorCritical}},
+			map[string]interface{}{"name": tr.T("警告"), "type": "bar", "stack": "level", "data": warning, "itemStyle": map[string]interface{}{"color": chartColorWarning}},
+		},
+	}
+}
+
+// alertLevelChart returns the donut chart option of the alert level distribution.
+func alertLevelChart(alerts []*json.Alert, tr *i18n.Translator) map[string]interface{} {
+	var warning, critical int
+	for _, alert := range alerts {
+		if alert == nil {
+			continue
+		}
+		switch alert.Level {
+		case model.AlertLevelWarning:
+			warning++
+		case model.AlertLevelCritical:
+			critical++
+		}
+	}
+
+	item := func(name string, value int, color string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":      name,
+			"value":     value,
+			"itemStyle": map[string]interface{}{"color": color},
+		}
+	}
+
+	return map[string]interface{}{
+		"title":   map[string]interface{}{"text": tr.T("告警级别分布"), "left": "center"},
+		"tooltip": map[string]interface{}{"trigger": "item", "formatter": "{b}: {c} ({d}%)"},
+		"legend":  map[string]interface{}{"bottom": 0},
+		"series": []interface{}{
+			map[string]interface{}{
+				"type":   "pie",
+				"radius": []string{"45%", "70%"},
+				"label":  map[string]interface{}{"formatter": "{b}: {c}"},
+				"data": []interface{}{
+					item(tr.T("严重"), critical, chartColorCritical),
+					item(tr.T("警告"), warning, chartColorWarning),
+				},
+			},
+		},
+	}
+}
+
+// chartMetricValue returns the raw metric value, or nil (an empty bar) when not collected.
+func chartMetricValue(metric *model.MetricValue) interface{} {
+	if metric == nil || metric.IsNA {
+		return nil
+	}
+	return metric.RawValue
+}
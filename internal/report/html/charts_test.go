@@ -0,0 +1,231 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// writeTestChartLibrary writes a stand-in for echarts.min.js and returns its path.
+func writeTestChartLibrary(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "echarts.min.js")
+	script := "window.echarts = { init: function () { return { setOption: function () {}, resize: function () {} }; } }; // </script>"
+	if err := os.WriteFile(path, []byte(script), 0644); err != nil {
+		t.Fatalf("failed to write chart library: %v", err)
+	}
+	return path
+}
+
+func TestWriter_Write_WithChartLibrary(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	w := NewWriter(nil, "", WithChartLibrary(writeTestChartLibrary(t)))
+	if err := w.Write(createTestResultWithAlerts(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	html := string(content)
+
+	for _, want := range []string{
+		"window.echarts = {",     // Library embedded inline
+		`// <\/script>`,          // Library cannot close the script element
+		`id="host-status-chart"`, // Chart containers
+		`id="host-alerts-chart"`,
+		`id="host-resource-chart"`,
+		"主机状态分布", // Chart options
+		"test-host-2",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report should contain %q", want)
+		}
+	}
+}
+
+func TestWriter_WriteCombined_WithChartLibrary(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+
+	w := NewWriter(nil, "", WithChartLibrary(writeTestChartLibrary(t)))
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if !strings.Contains(string(content), `id="host-resource-chart"`) || !strings.Contains(string(content), "window.echarts = {") {
+		t.Error("combined report should contain the host charts and the inline library")
+	}
+	if !strings.Contains(string(content), `id="alert-source-chart"`) || !strings.Contains(string(content), "告警来源分布") {
+		t.Error("combined report should contain the alert distribution charts")
+	}
+}
+
+// withBundledChartLibrary replaces the bundled ECharts script for the test.
+func withBundledChartLibrary(t *testing.T, script []byte) {
+	t.Helper()
+	saved := bundledChartLibrary
+	bundledChartLibrary = script
+	t.Cleanup(func() { bundledChartLibrary = saved })
+}
+
+func TestWriter_Write_BundledChartLibrary(t *testing.T) {
+	withBundledChartLibrary(t, []byte("window.echarts = { bundled: true };"))
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	if err := NewWriter(nil, "").Write(createTestResultWithAlerts(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if !strings.Contains(string(content), `id="host-status-chart"`) || !strings.Contains(string(content), "window.echarts = { bundled: true };") {
+		t.Error("report should contain the charts and the bundled library without chart_library")
+	}
+}
+
+func TestWriter_WriteCombined_BundledAlertCharts(t *testing.T) {
+	withBundledChartLibrary(t, []byte("window.echarts = { bundled: true };"))
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts(), MySQL: createTestMySQLInspectionResultsWithAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	for _, want := range []string{`id="alert-source-chart"`, `id="alert-level-chart"`, "告警来源分布"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("combined report should contain %q without chart_library", want)
+		}
+	}
+}
+
+func TestWriter_Write_ChartLibraryOverridesBundled(t *testing.T) {
+	withBundledChartLibrary(t, []byte("window.echarts = { bundled: true };"))
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	w := NewWriter(nil, "", WithChartLibrary(writeTestChartLibrary(t)))
+	if err := w.Write(createTestResultWithAlerts(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if strings.Contains(string(content), "bundled: true") || !strings.Contains(string(content), "window.echarts = {") {
+		t.Error("report should embed the configured library instead of the bundled one")
+	}
+}
+
+func TestWriter_Write_NoChartsWithoutLibrary(t *testing.T) {
+	withBundledChartLibrary(t, nil)
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	w := NewWriter(nil, "")
+	if err := w.Write(createTestResultWithAlerts(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if strings.Contains(string(content), "host-status-chart") {
+		t.Error("report should not contain charts without a bundled or configured library")
+	}
+}
+
+func TestHostResourceChart(t *testing.T) {
+	hosts := []*model.HostResult{
+		{Hostname: "a", Metrics: map[string]*model.MetricValue{
+			"cpu_usage":    {RawValue: 10},
+			"memory_usage": {RawValue: 20},
+		}},
+		{Hostname: "b", Metrics: map[string]*model.MetricValue{
+			"cpu_usage": {IsNA: true},
+		}},
+	}
+
+	option := hostResourceChart(hosts, nil)
+	series := option["series"].([]interface{})
+	cpu := series[0].(map[string]interface{})["data"].([]interface{})
+	memory := series[1].(map[string]interface{})["data"].([]interface{})
+
+	if cpu[0] != 10.0 || memory[0] != 20.0 {
+		t.Errorf("host a values = %v / %v, want 10 / 20", cpu[0], memory[0])
+	}
+	if cpu[1] != nil || memory[1] != nil {
+		t.Errorf("metrics not collected should be empty, got %v / %v", cpu[1], memory[1])
+	}
+	if _, ok := option["dataZoom"]; ok {
+		t.Error("small host lists should not scroll")
+	}
+}
+
+func TestAlertDistributionChart(t *testing.T) {
+	alerts := []*model.Alert{
+		{MetricDisplayName: "CPU利用率", Level: model.AlertLevelWarning},
+		{MetricDisplayName: "磁盘利用率", Level: model.AlertLevelCritical},
+		{MetricDisplayName: "磁盘利用率", Level: model.AlertLevelWarning},
+		{MetricName: "load_per_core", Level: model.AlertLevelCritical},
+	}
+
+	option := alertDistributionChart(alerts, nil)
+	names := option["xAxis"].(map[string]interface{})["data"].([]string)
+	want := []string{"磁盘利用率", "CPU利用率", "load_per_core"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("categories = %v, want %v", names, want)
+	}
+
+	series := option["series"].([]interface{})
+	critical := series[0].(map[string]interface{})["data"].([]int)
+	warning := series[1].(map[string]interface{})["data"].([]int)
+	if critical[0] != 1 || warning[0] != 1 || critical[2] != 1 || warning[1] != 1 {
+		t.Errorf("unexpected counts: critical %v, warning %v", critical, warning)
+	}
+}
+
+func TestAlertSourceChart(t *testing.T) {
+	alerts := []*json.Alert{
+		{Module: json.ModuleHost, Level: model.AlertLevelCritical},
+		{Module: json.ModuleHost, Level: model.AlertLevelWarning},
+		{Module: json.ModuleMySQL, Level: model.AlertLevelWarning},
+		{Module: json.ModuleRedis, Level: model.AlertLevelCritical},
+	}
+
+	option := alertSourceChart(alerts, nil)
+	names := option["xAxis"].(map[string]interface{})["data"].([]string)
+	want := []string{"主机", "MySQL", "Redis"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("categories = %v, want %v", names, want)
+	}
+
+	series := option["series"].([]interface{})
+	critical := series[0].(map[string]interface{})["data"].([]int)
+	warning := series[1].(map[string]interface{})["data"].([]int)
+	if critical[0] != 1 || warning[0] != 1 || warning[1] != 1 || critical[1] != 0 || critical[2] != 1 {
+		t.Errorf("unexpected counts: critical %v, warning %v", critical, warning)
+	}
+
+	level := alertLevelChart(alerts, nil)
+	data := level["series"].([]interface{})[0].(map[string]interface{})["data"].([]interface{})
+	if data[0].(map[string]interface{})["value"] != 2 || data[1].(map[string]interface{})["value"] != 2 {
+		t.Errorf("unexpected level counts: %v", data)
+	}
+}
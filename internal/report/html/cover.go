@@ -0,0 +1,69 @@
+package html
+
+import (
+	"html/template"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/report/theme"
+)
+
+// WithCover adds a cover page (customer, project, inspection engineer, period and logo of c)
+// at the top of the reports. A nil cover means no cover page.
+func WithCover(c *theme.Cover) Option {
+	return func(w *Writer) {
+		w.cover = c
+	}
+}
+
+// coverTemplate renders the cover page. It uses its own class names so the report styles
+// (e.g. the theme table header colors) do not apply, and breaks the page when printed.
+var coverTemplate = template.Must(template.New("cover").Parse(`<style>
+        .cover-page { min-height: 90vh; display: flex; flex-direction: column; justify-content: center; padding: 60px; background: #fff; page-break-after: always; break-after: page; }
+        .cover-logo { max-height: 96px; max-width: 320px; margin-bottom: 40px; }
+        .cover-title { font-size: 36px; font-weight: 700; color: {{.Color}}; margin-bottom: 40px; }
+        .cover-field { display: flex; font-size: 18px; padding: 10px 0; border-bottom: 1px solid #eee; max-width: 640px; }
+        .cover-label { width: 160px; color: #666; font-weight: 600; }
+    </style>
+    <div class="cover-page">
+        {{if .Logo}}<img class="cover-logo" src="{{.Logo}}" alt="logo">{{end}}
+        <div class="cover-title">{{.Title}}</div>
+        {{range .Fields}}<div class="cover-field"><span class="cover-label">{{.Label}}</span><span class="cover-value">{{.Value}}</span></div>
+        {{end}}
+    </div>`))
+
+// coverPage renders the cover page, or nothing without a cover.
+// An unreadable logo is omitted; the file is checked when the configuration is validated.
+func (w *Writer) coverPage() (template.HTML, error) {
+	if w.cover == nil {
+		return "", nil
+	}
+
+	color := "#333"
+	if w.theme.HasPrimaryColor() {
+		color = w.theme.PrimaryColor
+	}
+	var logo template.URL
+	if w.cover.HasLogo() {
+		if uri, err := w.cover.LogoDataURI(); err == nil {
+			logo = template.URL(uri)
+		}
+	}
+	data := struct {
+		Color  template.CSS
+		Logo   template.URL
+		Title  string
+		Fields []theme.CoverField
+	}{
+		Color:  template.CSS(color),
+		Logo:   logo,
+		Title:  w.theme.GetTitle(),
+		Fields: append(w.cover.Fields(), theme.CoverField{Label: "报告日期", Value: time.Now().In(w.timezone).Format("2006-01-02")}),
+	}
+
+	var buf strings.Builder
+	if err := coverTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
@@ -0,0 +1,80 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
+)
+
+func TestWriter_WriteCombined_Cover(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+
+	w := NewWriter(nil, "", WithCover(&theme.Cover{CustomerName: "ACME", Project: "核心系统", Period: "2025-Q1"}))
+	if err := w.WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	html := string(content)
+
+	for _, expected := range []string{`class="cover-page"`, "客户名称", "ACME", "核心系统", "2025-Q1", "报告日期"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected combined report to contain %q", expected)
+		}
+	}
+	if strings.Contains(html, "巡检工程师") {
+		t.Error("empty cover fields should be omitted")
+	}
+	if strings.Index(html, "cover-page") > strings.Index(html, "管理摘要") {
+		t.Error("cover page should come first")
+	}
+}
+
+func TestWriter_WriteSplit_Cover(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "split")
+
+	w := NewWriter(nil, "", WithCover(&theme.Cover{CustomerName: "ACME"}))
+	if err := w.WriteSplit(&json.Report{Host: createTestResult()}, outputDir); err != nil {
+		t.Fatalf("WriteSplit failed: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	if !strings.Contains(string(index), `class="cover-page"`) {
+		t.Error("expected the split index to contain the cover page")
+	}
+
+	hosts, err := os.ReadFile(filepath.Join(outputDir, "hosts.html"))
+	if err != nil {
+		t.Fatalf("failed to read hosts page: %v", err)
+	}
+	if strings.Contains(string(hosts), `class="cover-page"`) {
+		t.Error("module pages should not repeat the cover page")
+	}
+}
+
+func TestWriter_Write_NoCover(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	w := NewWriter(nil, "")
+	if err := w.Write(createTestResult(), outputPath); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if strings.Contains(string(content), "cover-page") {
+		t.Error("no cover page expected without WithCover")
+	}
+}
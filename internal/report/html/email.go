@@ -0,0 +1,273 @@
+package html
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// emailMaxAlerts is the number of alerts listed per module in the email report;
+// the remaining alerts are only counted, the full list is in the attached report.
+const emailMaxAlerts = 50
+
+// Email layout settings.
+const (
+	emailWidth              = 720       // Maximum content width in pixels
+	emailDefaultHeaderColor = "#667eea" // Header color used when the theme has no primary color
+)
+
+// EmailWriter implements report.ReportWriter for the "html-email" format: a compact HTML
+// report with inline styles and a table-based layout that can be pasted directly into an
+// email body. It contains no script and is limited to emailWidth pixels.
+type EmailWriter struct {
+	w *Writer
+}
+
+// EmailTemplateData is the data of the email template.
+type EmailTemplateData struct {
+	Title          string
+	Width          int // 内容最大宽度（像素）
+	InspectionTime string
+	Duration       string
+	Version        string
+	FooterText     string
+	HeaderColor    string // #RRGGBB
+	TotalCritical  int
+	TotalWarning   int
+	Modules        []*EmailModuleData
+}
+
+// EmailModuleData is the overview row and alert list of one inspected module.
+type EmailModuleData struct {
+	Name          string // 模块名称
+	Instances     int    // 巡检对象数
+	Critical      int
+	Warning       int
+	Alerts        []*EmailAlertData // 告警（最多 emailMaxAlerts 条，严重在前）
+	OmittedAlerts int               // 未列出的告警数
+}
+
+// EmailAlertData is one alert row of the email report.
+type EmailAlertData struct {
+	Identifier string
+	Level      string // 告警级别文本
+	LevelColor string // 告警级别背景色
+	Metric     string
+	Value      string
+	Message    string
+	priority   int // Sort priority of the level, critical first
+}
+
+// NewEmailWriter creates a new email HTML report writer.
+// If timezone is nil, it defaults to Asia/Shanghai. Only the theme title, primary color
+// and footer text apply; logos are left out since mail clients block embedded images.
+func NewEmailWriter(timezone *time.Location, opts ...Option) *EmailWriter {
+	return &EmailWriter{w: NewWriter(timezone, "", opts...)}
+}
+
+// Format returns the format identifier for this writer.
+func (e *EmailWriter) Format() string {
+	return "html-email"
+}
+
+// Write generates an email HTML report from the host inspection result.
+func (e *EmailWriter) Write(result *model.InspectionResult, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("inspection result is nil")
+	}
+
+	return e.WriteCombined(&json.Report{Host: result}, outputPath)
+}
+
+// WriteCombined generates one email HTML report with the module overview and the alerts of
+// Host, MySQL, Redis, Nginx, Tomcat, Cassandra, monitoring stack, shared storage, log checks,
+// LVS, Windows, AD, and cloud resource inspection results.
+func (e *EmailWriter) WriteCombined(report *json.Report, outputPath string) error {
+	// At least one result must be present
+	if report.Empty() {
+		return fmt.Errorf("all inspection results are nil")
+	}
+
+	// Ensure output path has .html extension
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".html") {
+		outputPath = outputPath + ".html"
+	}
+
+	tmpl, err := template.ParseFS(embeddedTemplates, "templates/email.html")
+	if err != nil {
+		return fmt.Errorf("failed to parse embedded email template: %w", err)
+	}
+
+	data := e.prepareEmailTemplateData(report)
+
+	if err := executeTemplateToFile(tmpl, data, outputPath, e.w.resourceDir(), e.w.tr); err != nil {
+		return fmt.Errorf("failed to write email report: %w", err)
+	}
+
+	return nil
+}
+
+// prepareEmailTemplateData builds the module overview and alert lists of the email report.
+// Modules are listed in the combined report order.
+func (e *EmailWriter) prepareEmailTemplateData(report *json.Report) *EmailTemplateData {
+	combined := e.w.prepareCombinedTemplateData(report)
+
+	data := &EmailTemplateData{
+		Title:          combined.Title,
+		Width:          emailWidth,
+		InspectionTime: combined.InspectionTime,
+		Duration:       combined.Duration,
+		FooterText:     e.w.theme.GetFooterText(),
+		HeaderColor:    emailDefaultHeaderColor,
+	}
+	if e.w.theme.HasPrimaryColor() {
+		data.HeaderColor = e.w.theme.PrimaryColor
+	}
+
+	var module *EmailModuleData
+	begin := func(name, version string, instances int) {
+		if data.Version == "" {
+			data.Version = version
+		}
+		module = &EmailModuleData{Name: name, Instances: instances}
+		data.Modules = append(data.Modules, module)
+	}
+	add := func(identifier, metric, value string, level model.AlertLevel, message string) {
+		switch level {
+		case model.AlertLevelCritical:
+			module.Critical++
+		case model.AlertLevelWarning:
+			module.Warning++
+		}
+		module.Alerts = append(module.Alerts, &EmailAlertData{
+			Identifier: identifier,
+			Level:      alertLevelText(level),
+			LevelColor: emailLevelColor(level),
+			Metric:     metric,
+			Value:      value,
+			Message:    message,
+			priority:   alertLevelPriority(level),
+		})
+	}
+
+	if report.Host != nil {
+		begin("主机", report.Host.Version, len(report.Host.Hosts))
+		for _, a := range report.Host.Alerts {
+			add(a.Hostname, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.MySQL != nil {
+		begin("MySQL", report.MySQL.Version, len(report.MySQL.Results))
+		for _, a := range report.MySQL.Alerts {
+			add(a.Address, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Redis != nil {
+		begin("Redis", report.Redis.Version, len(report.Redis.Results))
+		for _, a := range report.Redis.Alerts {
+			add(a.Address, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Nginx != nil {
+		begin("Nginx", report.Nginx.Version, len(report.Nginx.Results))
+		for _, a := range report.Nginx.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Tomcat != nil {
+		begin("Tomcat", report.Tomcat.Version, len(report.Tomcat.Results))
+		for _, a := range report.Tomcat.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Cassandra != nil {
+		begin("Cassandra", report.Cassandra.Version, len(report.Cassandra.Results))
+		for _, a := range report.Cassandra.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Monitoring != nil {
+		begin("监控系统", report.Monitoring.Version, len(report.Monitoring.Results))
+		for _, a := range report.Monitoring.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Storage != nil {
+		begin("共享存储", report.Storage.Version, len(report.Storage.Results))
+		for _, a := range report.Storage.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.LogChecks != nil {
+		begin("日志巡检", report.LogChecks.Version, len(report.LogChecks.Results))
+		for _, a := range report.LogChecks.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.LVS != nil {
+		begin("LVS", report.LVS.Version, len(report.LVS.Results))
+		for _, a := range report.LVS.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Windows != nil {
+		begin("Windows", report.Windows.Version, len(report.Windows.Results))
+		for _, a := range report.Windows.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.AD != nil {
+		begin("AD", report.AD.Version, len(report.AD.Results))
+		for _, a := range report.AD.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Cloud != nil {
+		begin("云资源", report.Cloud.Version, len(report.Cloud.Results))
+		for _, a := range report.Cloud.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+	if report.Firewall != nil {
+		begin("防火墙", report.Firewall.Version, len(report.Firewall.Results))
+		for _, a := range report.Firewall.Alerts {
+			add(a.Identifier, a.MetricDisplayName, a.FormattedValue, a.Level, a.Message)
+		}
+	}
+
+	for _, m := range data.Modules {
+		data.TotalCritical += m.Critical
+		data.TotalWarning += m.Warning
+
+		// Critical alerts first, then by identifier
+		sort.SliceStable(m.Alerts, func(i, j int) bool {
+			if m.Alerts[i].priority != m.Alerts[j].priority {
+				return m.Alerts[i].priority > m.Alerts[j].priority
+			}
+			return m.Alerts[i].Identifier < m.Alerts[j].Identifier
+		})
+		if len(m.Alerts) > emailMaxAlerts {
+			m.OmittedAlerts = len(m.Alerts) - emailMaxAlerts
+			m.Alerts = m.Alerts[:emailMaxAlerts]
+		}
+	}
+
+	return data
+}
+
+// emailLevelColor returns the inline background color of an alert level cell.
+func emailLevelColor(level model.AlertLevel) string {
+	switch level {
+	case model.AlertLevelCritical:
+		return "#f8d7da"
+	case model.AlertLevelWarning:
+		return "#fff3cd"
+	default:
+		return "#d4edda"
+	}
+}
@@ -0,0 +1,92 @@
+package html
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
+)
+
+func TestEmailWriter_Format(t *testing.T) {
+	if got := NewEmailWriter(nil).Format(); got != "html-email" {
+		t.Errorf("Format() = %q, want %q", got, "html-email")
+	}
+}
+
+func TestEmailWriter_Write_NilResult(t *testing.T) {
+	if err := NewEmailWriter(nil).Write(nil, filepath.Join(t.TempDir(), "email.html")); err == nil {
+		t.Error("Write() should return error for nil result")
+	}
+}
+
+func TestEmailWriter_WriteCombined(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "email.html")
+
+	w := NewEmailWriter(nil, WithTheme(&theme.Theme{Title: "客户巡检日报", PrimaryColor: "#123456", FooterText: "运维中心"}))
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts(), MySQL: createTestMySQLInspectionResultsWithAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	html := string(content)
+
+	// Email clients strip scripts and style blocks; all styling must be inline
+	for _, forbidden := range []string{"<script", "<style", "<link", "http://", "https://"} {
+		if strings.Contains(html, forbidden) {
+			t.Errorf("email report should not contain %q", forbidden)
+		}
+	}
+	for _, want := range []string{"max-width: 720px", "客户巡检日报", "#123456", "运维中心", "主机 告警", "MySQL 告警"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("email report should contain %q", want)
+		}
+	}
+}
+
+func TestEmailWriter_NoAlerts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "email.html")
+
+	if err := NewEmailWriter(nil).Write(createTestResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	content, _ := os.ReadFile(outputPath)
+	if !strings.Contains(string(content), "本次巡检未发现告警") {
+		t.Error("email report without alerts should say so")
+	}
+}
+
+func TestPrepareEmailTemplateData_SortsAndLimitsAlerts(t *testing.T) {
+	result := createTestResult()
+	result.Alerts = nil
+	for i := 0; i < emailMaxAlerts+5; i++ {
+		result.Alerts = append(result.Alerts, &model.Alert{Hostname: fmt.Sprintf("host-%03d", i), MetricDisplayName: "CPU利用率", Level: model.AlertLevelWarning})
+	}
+	result.Alerts = append(result.Alerts, &model.Alert{Hostname: "host-999", MetricDisplayName: "内存利用率", Level: model.AlertLevelCritical})
+
+	data := NewEmailWriter(nil).prepareEmailTemplateData(&json.Report{Host: result})
+	if len(data.Modules) != 1 {
+		t.Fatalf("expected 1 module, got %d", len(data.Modules))
+	}
+
+	m := data.Modules[0]
+	if m.Critical != 1 || m.Warning != emailMaxAlerts+5 {
+		t.Errorf("counts = %d/%d, want 1/%d", m.Critical, m.Warning, emailMaxAlerts+5)
+	}
+	if data.TotalCritical != 1 || data.TotalWarning != emailMaxAlerts+5 {
+		t.Errorf("totals = %d/%d", data.TotalCritical, data.TotalWarning)
+	}
+	if len(m.Alerts) != emailMaxAlerts || m.OmittedAlerts != 6 {
+		t.Errorf("listed %d alerts, omitted %d; want %d and 6", len(m.Alerts), m.OmittedAlerts, emailMaxAlerts)
+	}
+	if m.Alerts[0].Identifier != "host-999" {
+		t.Errorf("critical alert should be listed first, got %q", m.Alerts[0].Identifier)
+	}
+}
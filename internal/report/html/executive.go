@@ -0,0 +1,78 @@
+package html
+
+import (
+	"fmt"
+	"strings"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// executiveSystemsShown is the number of affected systems named per risk; the others are
+// only counted.
+const executiveSystemsShown = 5
+
+// ExecutiveSummaryData holds the management overview shown at the top of combined reports
+// and the split report index.
+type ExecutiveSummaryData struct {
+	CriticalAlerts  int
+	WarningAlerts   int
+	AffectedSystems int
+	Risks           []*ExecutiveRiskData
+}
+
+// ExecutiveRiskData represents one of the top risks of the executive summary.
+type ExecutiveRiskData struct {
+	Index         int
+	Level         string
+	LevelClass    string // "critical" or "warning", for the badge
+	Module        string
+	Metric        string
+	CriticalCount int
+	WarningCount  int
+	Systems       string
+	Remediation   string
+}
+
+// prepareExecutiveSummary builds the executive summary of all module results.
+func (w *Writer) prepareExecutiveSummary(report *json.Report) *ExecutiveSummaryData {
+	alerts := json.FlattenAlerts(report)
+	summary := json.NewExecutiveSummary(alerts)
+
+	data := &ExecutiveSummaryData{
+		CriticalAlerts:  summary.CriticalAlerts,
+		WarningAlerts:   summary.WarningAlerts,
+		AffectedSystems: summary.AffectedSystems,
+	}
+	for i, risk := range summary.Risks {
+		metric := risk.MetricDisplayName
+		if metric == "" {
+			metric = risk.MetricName
+		}
+		levelClass := "warning"
+		if risk.Level == model.AlertLevelCritical {
+			levelClass = "critical"
+		}
+		data.Risks = append(data.Risks, &ExecutiveRiskData{
+			Index:         i + 1,
+			Level:         alertLevelText(risk.Level),
+			LevelClass:    levelClass,
+			Module:        risk.Module,
+			Metric:        metric,
+			CriticalCount: risk.CriticalCount,
+			WarningCount:  risk.WarningCount,
+			Systems:       executiveSystemsText(risk.Systems),
+			Remediation:   risk.Remediation,
+		})
+	}
+	return data
+}
+
+// executiveSystemsText names the first executiveSystemsShown affected systems, followed by
+// the total when there are more (e.g. "host-1, host-2, ... 等共 8 个").
+func executiveSystemsText(systems []string) string {
+	if len(systems) <= executiveSystemsShown {
+		return strings.Join(systems, ", ")
+	}
+	return fmt.Sprintf("%s 等共 %d 个", strings.Join(systems[:executiveSystemsShown], ", "), len(systems))
+}
@@ -0,0 +1,89 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_WriteCombined_ExecutiveSummary(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts(), MySQL: createTestMySQLInspectionResults()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	html := string(content)
+
+	for _, expected := range []string{"管理摘要", `id="executive-risks-table"`, "内存利用率", "test-host-1", "排查内存占用高的进程或缓存"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected combined report to contain %q", expected)
+		}
+	}
+	if strings.Index(html, "管理摘要") > strings.Index(html, "主机巡检") {
+		t.Error("executive summary should come before the module sections")
+	}
+	// The critical memory risk ranks before the CPU warning
+	if strings.Index(html, "排查内存占用高的进程或缓存") > strings.Index(html, "定位高负载进程或慢请求") {
+		t.Error("critical risks should be listed first")
+	}
+}
+
+func TestWriter_WriteCombined_ExecutiveSummaryNoRisks(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), "本次巡检未发现风险") {
+		t.Error("expected the no risk note without alerts")
+	}
+}
+
+func TestWriter_WriteSplit_ExecutiveSummary(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "split")
+
+	w := NewWriter(nil, "")
+	if err := w.WriteSplit(&json.Report{Host: createTestResultWithAlerts(), MySQL: createTestMySQLInspectionResults()}, outputDir); err != nil {
+		t.Fatalf("WriteSplit failed: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	if !strings.Contains(string(index), `id="executive-risks-table"`) {
+		t.Error("expected the split index to contain the executive summary")
+	}
+
+	hosts, err := os.ReadFile(filepath.Join(outputDir, "hosts.html"))
+	if err != nil {
+		t.Fatalf("failed to read hosts page: %v", err)
+	}
+	if strings.Contains(string(hosts), "管理摘要") {
+		t.Error("module pages should not repeat the executive summary")
+	}
+}
+
+func TestExecutiveSystemsText(t *testing.T) {
+	if got := executiveSystemsText([]string{"a", "b"}); got != "a, b" {
+		t.Errorf("executiveSystemsText() = %q, want %q", got, "a, b")
+	}
+	if got := executiveSystemsText([]string{"a", "b", "c", "d", "e", "f"}); got != "a, b, c, d, e 等共 6 个" {
+		t.Errorf("executiveSystemsText() = %q", got)
+	}
+}
@@ -0,0 +1,284 @@
+package html
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"inspection-tool/internal/report/i18n"
+)
+
+// HTML reports are single self-contained files: they are opened offline and attached to
+// emails, so every stylesheet, script, font and image is inlined. Local resources referenced
+// by user-defined templates are inlined when the report is written; remote resources are
+// rejected since they would not load offline.
+
+// maxCSSImportDepth bounds nested @import inlining.
+const maxCSSImportDepth = 8
+
+var (
+	scriptBlockPattern = regexp.MustCompile(`(?is)<script\b([^>]*)>(.*?)</script\s*>`)
+	styleBlockPattern  = regexp.MustCompile(`(?is)(<style\b[^>]*>)(.*?)(</style\s*>)`)
+	linkTagPattern     = regexp.MustCompile(`(?is)<link\b[^>]*>`)
+	tagSrcPattern      = regexp.MustCompile(`(?is)(<(?:img|source|video|audio|embed|iframe|input|track)\b[^>]*?\bsrc\s*=\s*)(["'])([^"']*)(["'])`)
+	srcAttrPattern     = regexp.MustCompile(`(?is)\s*\bsrc\s*=\s*(["'])([^"']*)["']`)
+	hrefAttrPattern    = regexp.MustCompile(`(?is)(\bhref\s*=\s*)(["'])([^"']*)(["'])`)
+	relAttrPattern     = regexp.MustCompile(`(?is)\brel\s*=\s*["']([^"']*)["']`)
+	cssURLPattern      = regexp.MustCompile(`(?i)url\(\s*(["']?)([^"')]+?)(["']?)\s*\)`)
+	cssImportPattern   = regexp.MustCompile(`(?i)@import\s+(?:url\(\s*)?["']?([^"')\s;]+)["']?\s*\)?[^;]*;`)
+	uriSchemePattern   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+	remoteRefPattern   = regexp.MustCompile(`(?i)^(https?:|ftp:|//)`)
+)
+
+// resourceMimeTypes covers the font and icon types missing from the mime package defaults.
+var resourceMimeTypes = map[string]string{
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".ttf":   "font/ttf",
+	".otf":   "font/otf",
+	".eot":   "application/vnd.ms-fontobject",
+	".ico":   "image/x-icon",
+}
+
+// executeTemplateToFile renders tmpl with data into the file at path, translated by tr. Local
+// resources are inlined relative to resourceDir; the report is not written when it references
+// remote resources.
+func executeTemplateToFile(tmpl *template.Template, data interface{}, path, resourceDir string, tr *i18n.Translator) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	content, err := inlineResources(localizeHTML(buf.String(), tr), resourceDir)
+	if err != nil {
+		return fmt.Errorf("failed to inline report resources: %w", err)
+	}
+	if refs := externalReferences(content); len(refs) > 0 {
+		return fmt.Errorf("report references external resources, which do not load offline: %s", strings.Join(refs, ", "))
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	return nil
+}
+
+// resourceDir returns the directory local template resources are resolved against:
+// the directory of the user-defined template, or the working directory.
+func (w *Writer) resourceDir() string {
+	if w.templatePath != "" {
+		return filepath.Dir(w.templatePath)
+	}
+	return "."
+}
+
+// inlineResources replaces the local stylesheets, scripts, images and fonts referenced by
+// doc with their content. Remote references are left untouched. Script bodies are not
+// scanned, so library code is never mistaken for markup.
+func inlineResources(doc, baseDir string) (string, error) {
+	in := &inliner{baseDir: baseDir}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range scriptBlockPattern.FindAllStringSubmatchIndex(doc, -1) {
+		b.WriteString(in.markup(doc[last:m[0]]))
+		b.WriteString(in.script(doc[m[0]:m[1]], doc[m[2]:m[3]]))
+		last = m[1]
+	}
+	b.WriteString(in.markup(doc[last:]))
+
+	if in.err != nil {
+		return "", in.err
+	}
+	return b.String(), nil
+}
+
+// externalReferences returns the remote resources referenced by doc: script sources,
+// link targets, element sources and stylesheet urls / imports. Hyperlinks are not resources.
+func externalReferences(doc string) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if remoteRefPattern.MatchString(ref) && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	markup := scriptBlockPattern.ReplaceAllStringFunc(doc, func(block string) string {
+		attrs := scriptBlockPattern.FindStringSubmatch(block)[1]
+		if m := srcAttrPattern.FindStringSubmatch(attrs); m != nil {
+			add(m[2])
+		}
+		return ""
+	})
+	for _, tag := range linkTagPattern.FindAllString(markup, -1) {
+		if m := hrefAttrPattern.FindStringSubmatch(tag); m != nil {
+			add(m[3])
+		}
+	}
+	for _, m := range tagSrcPattern.FindAllStringSubmatch(markup, -1) {
+		add(m[3])
+	}
+	for _, block := range styleBlockPattern.FindAllStringSubmatch(markup, -1) {
+		for _, m := range cssImportPattern.FindAllStringSubmatch(block[2], -1) {
+			add(m[1])
+		}
+		for _, m := range cssURLPattern.FindAllStringSubmatch(block[2], -1) {
+			add(m[2])
+		}
+	}
+
+	return refs
+}
+
+// inliner replaces local resource references with their content, keeping the first error.
+type inliner struct {
+	baseDir string
+	err     error
+}
+
+// markup inlines the linked stylesheets, style blocks and element sources of an HTML fragment.
+func (in *inliner) markup(s string) string {
+	s = linkTagPattern.ReplaceAllStringFunc(s, in.link)
+	s = styleBlockPattern.ReplaceAllStringFunc(s, func(block string) string {
+		m := styleBlockPattern.FindStringSubmatch(block)
+		return m[1] + in.css(m[2], in.baseDir, 0) + m[3]
+	})
+	return tagSrcPattern.ReplaceAllStringFunc(s, func(tag string) string {
+		m := tagSrcPattern.FindStringSubmatch(tag)
+		return m[1] + m[2] + in.dataURI(m[3], in.baseDir) + m[4]
+	})
+}
+
+// link replaces a local stylesheet link with a style block, and inlines other local link
+// targets (e.g. icons) as data URIs.
+func (in *inliner) link(tag string) string {
+	m := hrefAttrPattern.FindStringSubmatch(tag)
+	if m == nil || !isLocalRef(m[3]) {
+		return tag
+	}
+
+	rel := ""
+	if r := relAttrPattern.FindStringSubmatch(tag); r != nil {
+		rel = strings.ToLower(r[1])
+	}
+	if !strings.Contains(rel, "stylesheet") {
+		return strings.Replace(tag, m[0], m[1]+m[2]+in.dataURI(m[3], in.baseDir)+m[4], 1)
+	}
+
+	path := resolveRef(m[3], in.baseDir)
+	data, err := in.read(path)
+	if err != nil {
+		return tag
+	}
+	return "<style>\n" + in.css(string(data), filepath.Dir(path), 0) + "\n</style>"
+}
+
+// script inlines the source of a local external script.
+func (in *inliner) script(block, attrs string) string {
+	m := srcAttrPattern.FindStringSubmatch(attrs)
+	if m == nil || !isLocalRef(m[2]) {
+		return block
+	}
+
+	data, err := in.read(resolveRef(m[2], in.baseDir))
+	if err != nil {
+		return block
+	}
+	// Keep the script element from being closed early by the inlined source
+	source := strings.ReplaceAll(string(data), "</script", `<\/script`)
+	return "<script" + strings.Replace(attrs, m[0], "", 1) + ">\n" + source + "\n</script>"
+}
+
+// css inlines the local imports and urls (fonts, images) of a stylesheet located in dir.
+func (in *inliner) css(s, dir string, depth int) string {
+	s = cssImportPattern.ReplaceAllStringFunc(s, func(rule string) string {
+		ref := cssImportPattern.FindStringSubmatch(rule)[1]
+		if !isLocalRef(ref) || depth >= maxCSSImportDepth {
+			return rule
+		}
+		path := resolveRef(ref, dir)
+		data, err := in.read(path)
+		if err != nil {
+			return rule
+		}
+		return in.css(string(data), filepath.Dir(path), depth+1)
+	})
+	return cssURLPattern.ReplaceAllStringFunc(s, func(u string) string {
+		m := cssURLPattern.FindStringSubmatch(u)
+		if !isLocalRef(m[2]) {
+			return u
+		}
+		return `url("` + in.dataURI(m[2], dir) + `")`
+	})
+}
+
+// dataURI returns the local resource ref as a base64 data URI; other refs are returned as is.
+func (in *inliner) dataURI(ref, dir string) string {
+	if !isLocalRef(ref) {
+		return ref
+	}
+	path := resolveRef(ref, dir)
+	data, err := in.read(path)
+	if err != nil {
+		return ref
+	}
+	return "data:" + resourceMimeType(path, data) + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// read reads a local resource, recording the first failure.
+func (in *inliner) read(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && in.err == nil {
+		in.err = fmt.Errorf("failed to read resource %s: %w", path, err)
+	}
+	return data, err
+}
+
+// isLocalRef reports whether ref is a local file path (as opposed to a data URI, fragment,
+// template placeholder or URL with a scheme).
+func isLocalRef(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "//") || strings.HasPrefix(ref, "{{") {
+		return false
+	}
+	if filepath.IsAbs(ref) {
+		return true
+	}
+	return !uriSchemePattern.MatchString(ref)
+}
+
+// resolveRef resolves a local ref against dir, dropping any query or fragment.
+func resolveRef(ref, dir string) string {
+	ref = strings.TrimSpace(ref)
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		ref = ref[:i]
+	}
+	if filepath.IsAbs(ref) {
+		return ref
+	}
+	return filepath.Join(dir, filepath.FromSlash(ref))
+}
+
+// resourceMimeType returns the MIME type of a resource from its extension, falling back to
+// content sniffing.
+func resourceMimeType(path string, data []byte) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if t, ok := resourceMimeTypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return strings.SplitN(t, ";", 2)[0]
+	}
+	return http.DetectContentType(data)
+}
@@ -0,0 +1,167 @@
+package html
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
+)
+
+// pngPixel is a 1x1 transparent PNG.
+var pngPixel = []byte{
+	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52,
+	0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x08, 0x06, 0x00, 0x00, 0x00, 0x1f, 0x15, 0xc4,
+	0x89, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x44, 0x41, 0x54, 0x78, 0x9c, 0x63, 0x00, 0x01, 0x00, 0x00,
+	0x05, 0x00, 0x01, 0x0d, 0x0a, 0x2d, 0xb4, 0x00, 0x00, 0x00, 0x00, 0x49, 0x45, 0x4e, 0x44, 0xae,
+	0x42, 0x60, 0x82,
+}
+
+func TestWriter_WriteCombined_SelfContained(t *testing.T) {
+	tmpDir := t.TempDir()
+	logoPath := filepath.Join(tmpDir, "logo.png")
+	if err := os.WriteFile(logoPath, pngPixel, 0644); err != nil {
+		t.Fatalf("failed to write logo: %v", err)
+	}
+	chartPath := filepath.Join(tmpDir, "echarts.min.js")
+	if err := os.WriteFile(chartPath, []byte(`var echarts={init:function(){return {setOption:function(){}}}};`), 0644); err != nil {
+		t.Fatalf("failed to write chart library: %v", err)
+	}
+
+	w := NewWriter(nil, "", WithTheme(&theme.Theme{LogoFile: logoPath}), WithChartLibrary(chartPath))
+	outputPath := filepath.Join(tmpDir, "report.html")
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts(), MySQL: createTestMySQLInspectionResultsWithAlerts(), Redis: createTestRedisInspectionResultsWithAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	html := string(content)
+
+	// No resource may be loaded from the network
+	for _, marker := range []string{"http://", "https://", `src="//`, `href="//`} {
+		if strings.Contains(html, marker) {
+			t.Errorf("report should not reference external URLs, found %q", marker)
+		}
+	}
+	if refs := externalReferences(html); len(refs) > 0 {
+		t.Errorf("externalReferences() = %v, want none", refs)
+	}
+	if !strings.Contains(html, `src="data:image/png;base64,`) {
+		t.Error("logo should be embedded as a data URI")
+	}
+}
+
+func TestWriter_WriteSplit_SelfContained(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "report")
+
+	w := NewWriter(nil, "")
+	if err := w.WriteSplit(&json.Report{Host: createTestResultWithAlerts(), MySQL: createTestMySQLInspectionResultsWithAlerts()}, outputDir); err != nil {
+		t.Fatalf("WriteSplit() error = %v", err)
+	}
+
+	pages, _ := filepath.Glob(filepath.Join(outputDir, "*.html"))
+	if len(pages) == 0 {
+		t.Fatal("WriteSplit() wrote no pages")
+	}
+	for _, page := range pages {
+		content, err := os.ReadFile(page)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", page, err)
+		}
+		if strings.Contains(string(content), "http://") || strings.Contains(string(content), "https://") {
+			t.Errorf("%s should not reference external URLs", filepath.Base(page))
+		}
+	}
+}
+
+func TestInlineResources_LocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"css/report.css":  []byte(`@font-face { font-family: R; src: url("../fonts/r.woff2") format("woff2"); } @import "extra.css";`),
+		"css/extra.css":   []byte(`.bg { background: url(../img/bg.png); }`),
+		"fonts/r.woff2":   []byte("wOF2"),
+		"img/bg.png":      pngPixel,
+		"img/logo.png":    pngPixel,
+		"js/report.js":    []byte(`document.write("</script>");`),
+		"img/favicon.ico": pngPixel,
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	doc := `<html><head>
+<link rel="icon" href="img/favicon.ico">
+<link rel="stylesheet" href="css/report.css">
+<style>.logo { background: url('img/logo.png'); } .x { fill: url(#grad); }</style>
+<script src="js/report.js"></script>
+<script>var s = 'url(not-a-file.png)';</script>
+</head><body><img src="img/logo.png"><a href="docs/guide.html">guide</a></body></html>`
+
+	got, err := inlineResources(doc, dir)
+	if err != nil {
+		t.Fatalf("inlineResources() error = %v", err)
+	}
+
+	for _, ref := range []string{"css/report.css", "js/report.js", "img/logo.png", "r.woff2", "bg.png", "extra.css", "favicon.ico"} {
+		if strings.Contains(got, ref) {
+			t.Errorf("local resource %q should be inlined:\n%s", ref, got)
+		}
+	}
+	for _, want := range []string{"data:font/woff2;base64,", "data:image/png;base64,", "data:image/x-icon;base64,", `document.write("<\/script>");`, "url(#grad)", "url(not-a-file.png)", `href="docs/guide.html"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("inlined document should contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestInlineResources_MissingFile(t *testing.T) {
+	if _, err := inlineResources(`<img src="missing.png">`, t.TempDir()); err == nil {
+		t.Error("inlineResources() should fail for a missing local resource")
+	}
+}
+
+func TestExternalReferences(t *testing.T) {
+	doc := `<link rel="stylesheet" href="https://cdn.example.com/style.css">
+<script src="//cdn.example.com/lib.js"></script>
+<script>var u = "https://example.com/api"; var img = '<img src="http://example.com/x.png">';</script>
+<style>@import url("https://fonts.example.com/css"); .a { background: url(http://example.com/bg.png); }</style>
+<img src="data:image/png;base64,AAAA">
+<a href="https://example.com/docs">docs</a>`
+
+	refs := externalReferences(doc)
+	want := []string{
+		"//cdn.example.com/lib.js",
+		"https://cdn.example.com/style.css",
+		"https://fonts.example.com/css",
+		"http://example.com/bg.png",
+	}
+	if strings.Join(refs, " ") != strings.Join(want, " ") {
+		t.Errorf("externalReferences() = %v, want %v", refs, want)
+	}
+}
+
+func TestExecuteTemplateToFile_RejectsRemoteResources(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "report.html")
+	tmpl := template.Must(template.New("t").Parse(`<script src="https://cdn.example.com/echarts.min.js"></script>`))
+
+	err := executeTemplateToFile(tmpl, nil, outputPath, tmpDir, nil)
+	if err == nil || !strings.Contains(err.Error(), "https://cdn.example.com/echarts.min.js") {
+		t.Fatalf("executeTemplateToFile() error = %v, want an external resource error", err)
+	}
+	if _, statErr := os.Stat(outputPath); !os.IsNotExist(statErr) {
+		t.Error("report should not be written when it references external resources")
+	}
+}
@@ -0,0 +1,48 @@
+package html
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"inspection-tool/internal/report/i18n"
+)
+
+var (
+	rawTextBlockPattern = regexp.MustCompile(`(?is)<script\b.*?</script\s*>|<style\b.*?</style\s*>`)
+	textNodePattern     = regexp.MustCompile(`>([^<>]+)<`)
+	htmlLangPattern     = regexp.MustCompile(`(?i)(<html\b[^>]*\blang\s*=\s*)(["'])[^"']*(["'])`)
+)
+
+// localizeHTML translates the text of a rendered report into the report language of tr: text
+// nodes that are report strings (headings, column headers, status texts, labels) are replaced
+// and the lang attribute of the document is set. Scripts and style sheets are left unchanged.
+func localizeHTML(doc string, tr *i18n.Translator) string {
+	if !tr.Enabled() {
+		return doc
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range rawTextBlockPattern.FindAllStringIndex(doc, -1) {
+		b.WriteString(localizeMarkup(doc[last:m[0]], tr))
+		b.WriteString(doc[m[0]:m[1]])
+		last = m[1]
+	}
+	b.WriteString(localizeMarkup(doc[last:], tr))
+
+	return htmlLangPattern.ReplaceAllString(b.String(), "${1}${2}"+tr.HTMLLang()+"${3}")
+}
+
+// localizeMarkup translates the text nodes of an HTML fragment without scripts or styles.
+func localizeMarkup(s string, tr *i18n.Translator) string {
+	return textNodePattern.ReplaceAllStringFunc(s, func(node string) string {
+		raw := node[1 : len(node)-1]
+		text := html.UnescapeString(raw)
+		translated := tr.Text(text)
+		if translated == text {
+			return node
+		}
+		return ">" + html.EscapeString(translated) + "<"
+	})
+}
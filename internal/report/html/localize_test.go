@@ -0,0 +1,81 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+)
+
+func TestLocalizeHTML(t *testing.T) {
+	doc := `<html lang="zh-CN"><head><title>系统巡检报告</title>
+<style>.x::after { content: "严重"; }</style>
+<script>const statusOrder = {'严重': 0};</script>
+</head><body><th>主机名</th><td><span class="critical">严重</span></td>
+<div>&#128197; 巡检时间: 2025-01-01</div><p>统计窗口: 30 天，低利用率判定: CPU 峰值 &lt; 5%</p><td>web-01</td></body></html>`
+
+	got := localizeHTML(doc, i18n.New(i18n.LanguageEN))
+
+	for _, want := range []string{
+		`<html lang="en">`,
+		"<title>System Inspection Report</title>",
+		"<th>Hostname</th>",
+		`<span class="critical">Critical</span>`,
+		"<div>📅 Inspection Time: 2025-01-01</div>",
+		"<p>Window: 30 days, idle when peak CPU &lt; 5%</p>",
+		"<td>web-01</td>",
+		`content: "严重";`,
+		`{'严重': 0}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("localized document should contain %q:\n%s", want, got)
+		}
+	}
+
+	if got := localizeHTML(doc, nil); got != doc {
+		t.Error("localizeHTML() without a translator should return the document unchanged")
+	}
+}
+
+func TestWriter_WriteCombined_Bilingual(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	w := NewWriter(nil, "", WithLanguage(i18n.LanguageBilingual))
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts(), MySQL: createTestMySQLInspectionResultsWithAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	html := string(content)
+	for _, want := range []string{`<html lang="zh-CN">`, "主机名 / Hostname", "严重 / Critical"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("bilingual report should contain %q", want)
+		}
+	}
+}
+
+func TestEmailWriter_WriteCombined_English(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.email.html")
+
+	w := NewEmailWriter(nil, WithLanguage(i18n.LanguageEN))
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	html := string(content)
+	for _, want := range []string{`<html lang="en">`, "Critical Alerts", "Host Alerts", ">Target<"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("English email report should contain %q", want)
+		}
+	}
+}
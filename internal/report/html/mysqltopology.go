@@ -0,0 +1,135 @@
+package html
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"inspection-tool/internal/model"
+)
+
+// MySQLTopologyData is the replication diagram of the MySQL instances.
+type MySQLTopologyData struct {
+	Groups []*MySQLTopologyGroup
+}
+
+// MySQLTopologyGroup is an MGR group, or the other instances of a cluster mode, in the
+// topology diagram.
+type MySQLTopologyGroup struct {
+	Title   string             // "MGR 组: <name>（在线 x/y）" or the cluster mode
+	MGR     bool               // MGR group: primary → member arrows
+	Primary *MySQLTopologyNode // MGR only: nil when the group has no PRIMARY member
+	Members []*MySQLTopologyNode
+}
+
+// MySQLTopologyNode is an instance of the topology diagram.
+type MySQLTopologyNode struct {
+	Address     string
+	Role        string // "主"/"从", empty outside MGR groups
+	ServerID    string // "N/A" when not inspected
+	SyncStatus  string // MGR state online / sync status, "N/A" when not inspected
+	State       string // MGR member state, empty outside MGR groups
+	StateDown   bool   // MGR member state other than ONLINE / RECOVERING
+	Inspected   bool   // false for MGR members outside the inspection scope
+	Status      string // "正常"/"警告"/"严重"/"失败"
+	StatusClass string // Badge class: normal / warning / critical / failed
+}
+
+// newMySQLTopology builds the topology diagram of a MySQL inspection, or nil without MGR
+// groups: the replication sources of the other cluster modes are not collected, so there
+// is no replication to show.
+func newMySQLTopology(result *model.MySQLInspectionResults) *MySQLTopologyData {
+	if result == nil || len(result.MGRClusters()) == 0 {
+		return nil
+	}
+	groups := result.ReplicationGroups()
+
+	data := &MySQLTopologyData{}
+	for _, g := range groups {
+		group := &MySQLTopologyGroup{Title: mysqlClusterModeText(g.Mode), MGR: g.Mode.IsMGR()}
+		online := 0
+		for _, m := range g.Members {
+			if m.State == model.MGRMemberStateOnline {
+				online++
+			}
+			node := newMySQLTopologyNode(m)
+			// The first PRIMARY member is the source of the arrows, multi-primary groups list
+			// the other primaries as members
+			if group.MGR && group.Primary == nil && m.Role.IsPrimary() {
+				group.Primary = node
+				continue
+			}
+			group.Members = append(group.Members, node)
+		}
+		if group.MGR {
+			group.Title = fmt.Sprintf("MGR 组: %s（在线 %d/%d）", g.Name, online, len(g.Members))
+		}
+		data.Groups = append(data.Groups, group)
+	}
+	return data
+}
+
+// newMySQLTopologyNode converts a replication group member to a topology node.
+func newMySQLTopologyNode(m *model.MySQLReplicationMember) *MySQLTopologyNode {
+	node := &MySQLTopologyNode{
+		Address:    m.Address,
+		ServerID:   "N/A",
+		SyncStatus: "N/A",
+		State:      m.State,
+	}
+	switch m.Role {
+	case model.MGRRolePrimary:
+		node.Role = "主"
+	case model.MGRRoleSecondary:
+		node.Role = "从"
+	}
+	if m.State != "" {
+		node.StateDown = m.State != model.MGRMemberStateOnline && m.State != model.MGRMemberStateRecovering
+	}
+	if r := m.Result; r != nil {
+		node.Inspected = true
+		if r.Instance.ServerID != "" {
+			node.ServerID = r.Instance.ServerID
+		}
+		node.SyncStatus = getMySQLSyncStatus(r)
+		node.Status = mysqlStatusText(r.Status)
+		node.StatusClass = strings.TrimPrefix(mysqlStatusClass(r.Status), "status-")
+	}
+	return node
+}
+
+// mysqlTopologyTemplate renders the topology diagram: one row per MGR group, with an arrow from
+// the primary to each member labelled with the member state (dashed in red when the member
+// left the group), then the instances of the other cluster modes, whose replication sources
+// are not collected.
+var mysqlTopologyTemplate = template.Must(template.New("mysql-topology").Parse(topologyStyle + `
+    <div class="mysql-topology">
+        <div class="topology-title">复制拓扑</div>
+        {{define "mysql-topology-node"}}{{if .Role}}<span class="topology-role">{{.Role}}</span>{{end}}{{.Address}}<span class="topology-detail">Server ID {{.ServerID}}</span><span class="topology-detail">同步: {{.SyncStatus}}</span>{{if .Inspected}}<span class="badge badge-{{.StatusClass}}">{{.Status}}</span>{{else}}<span class="topology-hint">未纳入巡检</span>{{end}}{{end}}
+        {{range .Groups}}<div class="topology-group-title">{{.Title}}</div>
+        {{if .MGR}}<div class="topology-group">
+            {{with .Primary}}<div class="topology-node">{{template "mysql-topology-node" .}}</div>
+            {{else}}<div class="topology-node topology-unknown"><span class="topology-role">主</span><span class="topology-hint">无主节点</span></div>
+            {{end}}{{if .Members}}<div class="topology-slaves">
+                {{range .Members}}<div class="topology-slave">
+                    <div class="topology-edge{{if .StateDown}} topology-link-down{{end}}"><span>{{.State}}</span></div>
+                    <div class="topology-node topology-slave-node">{{template "mysql-topology-node" .}}</div>
+                </div>
+                {{end}}
+            </div>{{else}}<span class="topology-note">无其他成员</span>{{end}}
+        </div>
+        {{else}}<div class="topology-group topology-members">
+            {{range .Members}}<div class="topology-node topology-slave-node">{{template "mysql-topology-node" .}}</div>
+            {{end}}<span class="topology-note">未采集复制来源，无法确定主从关系</span>
+        </div>
+        {{end}}{{end}}
+    </div>`))
+
+// mysqlTopology renders the topology diagram, or nothing without one, for the mysqlTopology
+// template function.
+func mysqlTopology(data *MySQLTopologyData) (template.HTML, error) {
+	if data == nil {
+		return "", nil
+	}
+	return renderTopology(mysqlTopologyTemplate, data)
+}
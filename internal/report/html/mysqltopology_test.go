@@ -0,0 +1,95 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+)
+
+// withTestMGRMembers adds the member list of an MGR group to the test instances: both
+// inspected instances and an unreachable member outside the inspection scope.
+func withTestMGRMembers(result *model.MySQLInspectionResults) *model.MySQLInspectionResults {
+	var members []*model.MySQLMGRMember
+	for i, host := range []string{"172.18.182.91", "172.18.182.92", "172.18.182.93"} {
+		m := model.NewMySQLMGRMember(host)
+		m.Host = host
+		m.Port = 3306
+		m.Role = model.MGRRoleSecondary
+		m.State = model.MGRMemberStateOnline
+		if i == 0 {
+			m.Role = model.MGRRolePrimary
+		}
+		members = append(members, m)
+	}
+	members[2].State = "UNREACHABLE"
+	for _, r := range result.Results {
+		r.MGRGroupName = "group-a"
+		r.MGRMembers = members
+	}
+	return result
+}
+
+func TestWriter_WriteMySQLInspection_Topology(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "mysql.html")
+	result := withTestMGRMembers(createTestMySQLInspectionResults())
+
+	if err := NewWriter(nil, "").WriteMySQLInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteMySQLInspection failed: %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	html := string(content)
+
+	for _, expected := range []string{`class="mysql-topology"`, "复制拓扑", "MGR 组: group-a（在线 2/3）", "Server ID 1002", "同步: 在线", "topology-link-down", "UNREACHABLE", "未纳入巡检"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected MySQL report to contain %q", expected)
+		}
+	}
+	primary := strings.Index(html, `<span class="topology-role">主</span>172.18.182.91:3306`)
+	secondary := strings.Index(html, `<span class="topology-role">从</span>172.18.182.92:3306`)
+	if primary < 0 || secondary < primary {
+		t.Error("the primary should come before its members")
+	}
+}
+
+func TestWriter_WriteCombined_MySQLTopology(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+	result := withTestMGRMembers(createTestMySQLInspectionResults())
+	standalone := &model.MySQLInstance{Address: "172.18.182.95:3306", IP: "172.18.182.95", Port: 3306, ServerID: "2001", ClusterMode: model.ClusterModeMasterSlave}
+	result.Results = append(result.Results, &model.MySQLInspectionResult{Instance: standalone, Status: model.MySQLStatusNormal})
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{MySQL: result}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	html := string(content)
+
+	if got := strings.Count(html, `class="mysql-topology"`); got != 1 {
+		t.Errorf("got %d topology diagrams, want 1", got)
+	}
+	// Master-slave instances are listed without arrows: their sources are not collected
+	for _, expected := range []string{"172.18.182.95:3306", "Server ID 2001", "未采集复制来源，无法确定主从关系"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected combined report to contain %q", expected)
+		}
+	}
+}
+
+func TestMySQLTopology_NoMGR(t *testing.T) {
+	if topology := newMySQLTopology(createTestMySQLInspectionResults()); topology != nil {
+		t.Errorf("newMySQLTopology() = %+v, want nil without MGR groups", topology)
+	}
+	if html, err := mysqlTopology(nil); err != nil || html != "" {
+		t.Errorf("mysqlTopology(nil) = %q, %v", html, err)
+	}
+}
@@ -0,0 +1,92 @@
+package html
+
+import (
+	"html/template"
+	"strings"
+
+	"inspection-tool/internal/model"
+)
+
+// RedisTopologyData is the master → slave replication diagram of a Redis cluster.
+type RedisTopologyData struct {
+	Groups []*RedisTopologyGroup
+}
+
+// RedisTopologyGroup is a master and its slaves in the topology diagram.
+type RedisTopologyGroup struct {
+	Master     *RedisTopologyNode // nil when the master could not be identified
+	MasterPort int                // Master port reported by the slaves
+	Slaves     []*RedisTopologyNode
+}
+
+// RedisTopologyNode is an instance of the topology diagram.
+type RedisTopologyNode struct {
+	Address     string
+	Status      string // "正常"/"警告"/"严重"/"失败"
+	StatusClass string // Badge class: normal / warning / critical / failed
+	LinkDown    bool   // Slave only: master link down
+	Lag         string // Slave only: replication lag
+}
+
+// newRedisTopology builds the topology diagram of the instances, or nil when no instance is
+// a slave: standalone masters have no replication to show.
+func newRedisTopology(results []*model.RedisInspectionResult) *RedisTopologyData {
+	groups := model.GroupRedisReplication(results)
+	data := &RedisTopologyData{}
+	slaves := 0
+	for _, g := range groups {
+		group := &RedisTopologyGroup{MasterPort: g.MasterPort}
+		if g.Master != nil {
+			group.Master = newRedisTopologyNode(g.Master)
+		}
+		for _, s := range g.Slaves {
+			node := newRedisTopologyNode(s)
+			node.LinkDown = !s.MasterLinkStatus
+			node.Lag = getRedisReplicationLag(s)
+			group.Slaves = append(group.Slaves, node)
+		}
+		slaves += len(group.Slaves)
+		data.Groups = append(data.Groups, group)
+	}
+	if slaves == 0 {
+		return nil
+	}
+	return data
+}
+
+// newRedisTopologyNode converts an instance to a topology node.
+func newRedisTopologyNode(r *model.RedisInspectionResult) *RedisTopologyNode {
+	return &RedisTopologyNode{
+		Address:     r.Instance.Address,
+		Status:      redisStatusText(r.Status),
+		StatusClass: strings.TrimPrefix(redisStatusClass(r.Status), "status-"),
+	}
+}
+
+// redisTopologyTemplate renders the topology diagram: one row per master, with an arrow to
+// each of its slaves. Broken master links are drawn dashed in red.
+var redisTopologyTemplate = template.Must(template.New("redis-topology").Parse(topologyStyle + `
+    <div class="redis-topology">
+        <div class="topology-title">主从拓扑</div>
+        {{range .Groups}}<div class="topology-group">
+            {{with .Master}}<div class="topology-node"><span class="topology-role">主</span>{{.Address}}<span class="badge badge-{{.StatusClass}}">{{.Status}}</span></div>
+            {{else}}<div class="topology-node topology-unknown"><span class="topology-role">主</span>*:{{.MasterPort}}<span class="topology-hint">无法唯一确定主节点</span></div>
+            {{end}}{{if .Slaves}}<div class="topology-slaves">
+                {{range .Slaves}}<div class="topology-slave">
+                    <div class="topology-edge{{if .LinkDown}} topology-link-down{{end}}"><span>{{if .LinkDown}}链接断开{{else}}复制延迟 {{.Lag}}{{end}}</span></div>
+                    <div class="topology-node topology-slave-node"><span class="topology-role">从</span>{{.Address}}<span class="badge badge-{{.StatusClass}}">{{.Status}}</span></div>
+                </div>
+                {{end}}
+            </div>{{else}}<span class="topology-note">无从节点</span>{{end}}
+        </div>
+        {{end}}
+    </div>`))
+
+// redisTopology renders the topology diagram, or nothing without one, for the redisTopology
+// template function.
+func redisTopology(data *RedisTopologyData) (template.HTML, error) {
+	if data == nil {
+		return "", nil
+	}
+	return renderTopology(redisTopologyTemplate, data)
+}
@@ -0,0 +1,71 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_WriteRedisInspection_Topology(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "redis.html")
+	result := createTestRedisInspectionResults()
+	result.Results[1].MasterLinkStatus = false
+
+	if err := NewWriter(nil, "").WriteRedisInspection(result, outputPath); err != nil {
+		t.Fatalf("WriteRedisInspection failed: %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	html := string(content)
+
+	for _, expected := range []string{`class="redis-topology"`, "主从拓扑", "192.18.102.2:7000", "topology-link-down", "链接断开"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected Redis report to contain %q", expected)
+		}
+	}
+	master := strings.Index(html, `<span class="topology-role">主</span>192.18.102.2:7000`)
+	slave := strings.Index(html, `<span class="topology-role">从</span>192.18.102.2:7001`)
+	if master < 0 || slave < master {
+		t.Error("the master should come before its slave")
+	}
+}
+
+func TestWriter_WriteCombined_RedisTopology_Unresolved(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+	result := createTestRedisMultiClusterResults()
+	result.GroupByClusters()
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{Redis: result}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	html := string(content)
+
+	// Three masters share port 7000 in each cluster: the slaves are not matched to a master
+	if got := strings.Count(html, `class="redis-topology"`); got != 2 {
+		t.Errorf("got %d topology diagrams, want one per cluster", got)
+	}
+	if got := strings.Count(html, "无法唯一确定主节点"); got != 2 {
+		t.Errorf("got %d unresolved master nodes, want one per cluster", got)
+	}
+}
+
+func TestRedisTopology_NoSlaves(t *testing.T) {
+	result := createTestRedisInspectionResults()
+	result.Results = result.Results[:1]
+	if topology := newRedisTopology(result.Results); topology != nil {
+		t.Errorf("newRedisTopology() = %+v, want nil without slaves", topology)
+	}
+	if html, err := redisTopology(nil); err != nil || html != "" {
+		t.Errorf("redisTopology(nil) = %q, %v", html, err)
+	}
+}
@@ -0,0 +1,63 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_WriteCombined_ColorScheme(t *testing.T) {
+	cssFile := filepath.Join(t.TempDir(), "brand.css")
+	if err := os.WriteFile(cssFile, []byte(".header { background: #003366; } /* </style> */"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opt     Option
+		want    []string
+		notWant []string
+	}{
+		{"light", WithColorScheme(ColorSchemeLight, ""), nil, []string{"#1a202c", "#003366"}},
+		{"dark", WithColorScheme(ColorSchemeDark, ""), []string{"@media screen", "body { background-color: #1a202c"}, []string{"#003366"}},
+		{"custom", WithColorScheme(ColorSchemeCustom, cssFile), []string{".header { background: #003366; }", `<\/style>`}, []string{"#1a202c"}},
+		{"unreadable custom", WithColorScheme(ColorSchemeCustom, "/nonexistent/brand.css"), nil, []string{"#1a202c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), "combined.html")
+			w := NewWriter(nil, "", tt.opt)
+			if err := w.WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+				t.Fatalf("WriteCombined failed: %v", err)
+			}
+			content, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatalf("failed to read output file: %v", err)
+			}
+			html := string(content)
+
+			for _, s := range tt.want {
+				if !strings.Contains(html, s) {
+					t.Errorf("expected report to contain %q", s)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(html, s) {
+					t.Errorf("report should not contain %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_ChartTheme(t *testing.T) {
+	if got := NewWriter(nil, "", WithColorScheme(ColorSchemeDark, "")).chartTheme(); got != "dark" {
+		t.Errorf("dark chartTheme() = %q, want dark", got)
+	}
+	if got := NewWriter(nil, "").chartTheme(); got != "" {
+		t.Errorf("default chartTheme() = %q, want empty", got)
+	}
+}
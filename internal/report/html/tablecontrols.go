@@ -0,0 +1,110 @@
+package html
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// tableControlsMinRows is the number of rows from which a table gets the search box and the
+// status filter; shorter tables are read at a glance.
+const tableControlsMinRows = 10
+
+// tableControlsMarkup is the toolbar cloned above each long table. It is plain markup rather
+// than script text so the labels are translated with the rest of the report.
+const tableControlsMarkup = `<template id="table-controls-template">
+        <div class="table-controls">
+            <label class="table-search">搜索 <input type="search" autocomplete="off"></label>
+            <label class="table-filter">状态筛选
+                <select>
+                    <option value="">全部</option>
+                    <option value="critical">严重</option>
+                    <option value="warning">警告</option>
+                    <option value="failed">失败</option>
+                    <option value="normal">正常</option>
+                </select>
+            </label>
+            <span class="table-count"></span>
+        </div>
+    </template>`
+
+const tableControlsStyle = `<style>
+        .table-controls { display: flex; flex-wrap: wrap; align-items: center; gap: 12px; margin-bottom: 10px; font-size: 13px; }
+        .table-controls input, .table-controls select { margin-left: 4px; padding: 4px 8px; border: 1px solid #ccc; border-radius: 4px; font-size: 13px; }
+        .table-controls input { width: 220px; }
+        .table-controls .table-count { color: #888; }
+        @media print { .table-controls { display: none; } }
+    </style>`
+
+// tableControlsScript adds the search box and the status filter to the long tables of the
+// report. The search matches the row text; the filter matches the badge of the status (or
+// alert level) column. Rows are hidden in place, so the column sort keeps working.
+const tableControlsScript = `<script>
+        // Table search and status filter
+        (function() {
+            const minRows = %d;
+
+            function statusColumn(table) {
+                const headers = Array.from(table.querySelectorAll('thead th'));
+                return headers.findIndex(h => h.dataset.sort === 'status' || h.dataset.sort === 'level');
+            }
+
+            function setupTableControls(table, template) {
+                const tbody = table.tBodies[0];
+                const rows = Array.from(tbody.rows);
+                const column = statusColumn(table);
+                const texts = new Map(rows.map(row => [row, row.textContent.toLowerCase()]));
+
+                const controls = template.content.firstElementChild.cloneNode(true);
+                const search = controls.querySelector('input');
+                const filter = controls.querySelector('select');
+                const count = controls.querySelector('.table-count');
+
+                function badge(row, status) {
+                    const cell = column >= 0 ? row.cells[column] : row;
+                    return cell && cell.querySelector('.badge-' + status) !== null;
+                }
+
+                // Only offer the statuses present in the table
+                Array.from(filter.options).forEach(option => {
+                    if (option.value && !rows.some(row => badge(row, option.value))) option.remove();
+                });
+                if (filter.options.length <= 2) filter.parentElement.remove();
+
+                function apply() {
+                    const query = search.value.trim().toLowerCase();
+                    const status = filter.value;
+                    let shown = 0;
+                    rows.forEach(row => {
+                        const visible = (!query || texts.get(row).includes(query)) && (!status || badge(row, status));
+                        row.style.display = visible ? '' : 'none';
+                        if (visible) shown++;
+                    });
+                    count.textContent = shown + ' / ' + rows.length;
+                }
+
+                search.addEventListener('input', apply);
+                filter.addEventListener('change', apply);
+                apply();
+
+                // Above the scrolling wrapper, so the toolbar stays in view
+                const anchor = table.closest('.table-wrapper') || table;
+                anchor.parentNode.insertBefore(controls, anchor);
+            }
+
+            document.addEventListener('DOMContentLoaded', function() {
+                const template = document.getElementById('table-controls-template');
+                if (!template) return;
+                document.querySelectorAll('table[id]').forEach(table => {
+                    if (table.tBodies.length > 0 && table.tBodies[0].rows.length >= minRows) {
+                        setupTableControls(table, template);
+                    }
+                });
+            });
+        })();
+    </script>`
+
+// tableControls returns the markup, style and script of the table search box and status
+// filter, for the tableControls template function.
+func tableControls() template.HTML {
+	return template.HTML(tableControlsMarkup + "\n    " + tableControlsStyle + "\n    " + fmt.Sprintf(tableControlsScript, tableControlsMinRows))
+}
@@ -0,0 +1,41 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_WriteCombined_TableControls(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	w := NewWriter(nil, "", WithLanguage(i18n.LanguageEN))
+	if err := w.WriteCombined(&json.Report{Host: createTestResultWithAlerts()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	html := string(content)
+	for _, want := range []string{
+		`<template id="table-controls-template">`,
+		// The toolbar labels are translated with the report
+		">Search <input",
+		">Status Filter",
+		`<option value="critical">Critical</option>`,
+		"const minRows = 10;",
+		// Alert tables are sortable by level
+		`<th class="sortable" data-sort="level">Alert Level</th>`,
+		"setupTableSorting('host-alerts-table', 0);",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/report/json"
+)
+
+func TestWriter_WriteCombined_SecondaryTimezone(t *testing.T) {
+	result := createTestResult()
+	result.InspectionTime = time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+	w := NewWriter(nil, "", WithSecondaryTimezone(time.UTC, "UTC"))
+	if err := w.WriteCombined(&json.Report{Host: result}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+
+	if want := "2025-01-15 18:30:00 (UTC 2025-01-15 10:30:00)"; !strings.Contains(string(content), want) {
+		t.Errorf("expected report to contain %q", want)
+	}
+}
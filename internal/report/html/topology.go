@@ -0,0 +1,45 @@
+package html
+
+import (
+	"html/template"
+	"strings"
+)
+
+// topologyStyle is the style of the replication diagrams. The containers set the accent
+// color of the module (--topology-accent); the diagrams use their own class names and colors
+// so they render the same in every report template.
+const topologyStyle = `<style>
+        .redis-topology { --topology-accent: #dc382d; }
+        .mysql-topology { --topology-accent: #00758f; }
+        .redis-topology, .mysql-topology { background: #fff; border-radius: 12px; box-shadow: 0 2px 4px rgba(0, 0, 0, 0.05); padding: 16px 20px; margin-bottom: 24px; overflow-x: auto; }
+        .topology-title { font-size: 15px; font-weight: 600; color: var(--topology-accent); margin-bottom: 12px; }
+        .topology-group-title { font-size: 13px; font-weight: 600; color: #555; padding-top: 10px; }
+        .topology-group { display: flex; align-items: center; padding: 10px 0; border-bottom: 1px dashed #eee; page-break-inside: avoid; break-inside: avoid; }
+        .topology-group:last-child { border-bottom: none; }
+        .topology-group.topology-members { flex-wrap: wrap; gap: 8px; }
+        .topology-slaves { display: flex; flex-direction: column; gap: 8px; }
+        .topology-slave { display: flex; align-items: center; }
+        .topology-node { display: inline-flex; align-items: center; gap: 8px; min-width: 220px; padding: 8px 12px; border: 2px solid var(--topology-accent); border-radius: 8px; background: #fff; font-size: 13px; white-space: nowrap; }
+        .topology-node.topology-slave-node { border-color: #999; }
+        .topology-node.topology-unknown { border-style: dashed; border-color: #999; color: #888; }
+        .topology-role { display: inline-block; padding: 1px 6px; border-radius: 4px; background: var(--topology-accent); color: #fff; font-size: 12px; }
+        .topology-slave-node .topology-role { background: #888; }
+        .topology-detail { font-size: 11px; color: #666; }
+        .topology-edge { position: relative; width: 120px; margin: 0 8px 0 12px; border-top: 2px solid #999; text-align: center; }
+        .topology-edge::after { content: ""; position: absolute; right: -2px; top: -7px; border-left: 10px solid #999; border-top: 6px solid transparent; border-bottom: 6px solid transparent; }
+        .topology-edge span { position: relative; top: -20px; font-size: 11px; color: #888; }
+        .topology-edge.topology-link-down { border-top: 2px dashed #c00000; }
+        .topology-edge.topology-link-down::after { border-left-color: #c00000; }
+        .topology-edge.topology-link-down span { color: #c00000; }
+        .topology-note { margin-left: 20px; font-size: 12px; color: #888; }
+        .topology-hint { font-size: 11px; }
+    </style>`
+
+// renderTopology executes a topology diagram template into trusted template HTML.
+func renderTopology(tmpl *template.Template, data interface{}) (template.HTML, error) {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
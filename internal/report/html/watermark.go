@@ -0,0 +1,28 @@
+package html
+
+import (
+	"fmt"
+
+	"inspection-tool/internal/report/theme"
+)
+
+// WithWatermark repeats text diagonally over the report pages (e.g. the customer name and
+// report date), in the browser and in printed or PDF-exported reports. Empty means no watermark.
+func WithWatermark(text string) Option {
+	return func(w *Writer) {
+		w.watermark = text
+	}
+}
+
+// watermarkStyle returns the CSS rule of the watermark, or "" without a watermark. The tiles
+// cover the viewport from a fixed overlay that ignores the mouse, so the report stays usable;
+// fixed elements are repeated on every printed page.
+func (w *Writer) watermarkStyle() string {
+	if w.watermark == "" {
+		return ""
+	}
+	return fmt.Sprintf("body::after { content: \"\"; position: fixed; top: 0; left: 0; right: 0; bottom: 0; "+
+		"background: url(\"%s\") repeat; background-size: %dpx %dpx; pointer-events: none; z-index: 9999; "+
+		"-webkit-print-color-adjust: exact; print-color-adjust: exact; }",
+		theme.WatermarkDataURI(w.watermark), theme.WatermarkWidth, theme.WatermarkHeight)
+}
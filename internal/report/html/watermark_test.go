@@ -0,0 +1,47 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
+)
+
+func TestWriter_WriteSplit_Watermark(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "split")
+
+	w := NewWriter(nil, "", WithWatermark("ACME 2025-12-13"))
+	if err := w.WriteSplit(&json.Report{Host: createTestResult()}, outputDir); err != nil {
+		t.Fatalf("WriteSplit failed: %v", err)
+	}
+
+	// Every page carries the watermark, printed as well
+	for _, page := range []string{"index.html", "hosts.html"} {
+		content, err := os.ReadFile(filepath.Join(outputDir, page))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", page, err)
+		}
+		html := string(content)
+		if !strings.Contains(html, theme.WatermarkDataURI("ACME 2025-12-13")) || !strings.Contains(html, "print-color-adjust: exact") {
+			t.Errorf("expected %s to contain the watermark", page)
+		}
+	}
+}
+
+func TestWriter_Write_NoWatermark(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.html")
+
+	if err := NewWriter(nil, "").Write(createTestResult(), outputPath); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if strings.Contains(string(content), "image/svg+xml") {
+		t.Error("no watermark expected without WithWatermark")
+	}
+}
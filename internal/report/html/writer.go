@@ -14,6 +14,9 @@ import (
 	"time"
 
 	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/i18n"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
 )
 
 //go:embed templates/*.html
@@ -22,7 +25,36 @@ var embeddedTemplates embed.FS
 // Writer implements report.ReportWriter for HTML format.
 type Writer struct {
 	timezone     *time.Location
-	templatePath string // User-defined template path (optional)
+	templatePath     string       // User-defined template path (optional)
+	theme            *theme.Theme // White-label theme (optional)
+	chartLibraryPath string       // ECharts script replacing the bundled one (optional)
+	tr               *i18n.Translator // Report language (nil: Chinese)
+	cover            *theme.Cover     // Cover page (optional)
+	colorScheme      string           // Color scheme (ColorSchemeLight when empty)
+	customCSSFile    string           // Stylesheet of ColorSchemeCustom
+	secondaryTimezone      *time.Location // Second timezone of inspection times (optional)
+	secondaryTimezoneLabel string         // Name of the second timezone
+	watermark              string         // Watermark text repeated over the pages (optional)
+	alertmanagerAlerts     []*model.AlertmanagerAlert // Alerts firing in Alertmanager, listed in combined reports
+}
+
+// Option is a functional option for configuring a Writer.
+type Option func(*Writer)
+
+// WithTheme applies a white-label theme (title, header colors, logo, footer text) to the reports.
+func WithTheme(t *theme.Theme) Option {
+	return func(w *Writer) {
+		w.theme = t
+	}
+}
+
+// WithLanguage sets the report language (i18n.LanguageZH, i18n.LanguageEN or
+// i18n.LanguageBilingual). The text of the rendered report is translated, so user-defined
+// templates written with the default Chinese labels are localized as well.
+func WithLanguage(lang string) Option {
+	return func(w *Writer) {
+		w.tr = i18n.New(lang)
+	}
 }
 
 // TemplateData holds all data passed to the HTML template.
@@ -34,9 +66,12 @@ type TemplateData struct {
 	AlertSummary   *model.AlertSummary
 	Hosts          []*HostData
 	Alerts         []*AlertData
+	ActiveAlerts   []*ActiveAlertData // N9E 当前告警
 	DiskPaths      []string
+	HasCMDB        bool // 主机带有 CMDB 信息时显示负责人、应用、位置与维护状态列
 	Version        string
 	GeneratedAt    string
+	HostCharts     *HostChartsData // 交互式图表（未启用时为 nil）
 }
 
 // HostData represents host data formatted for template rendering.
@@ -51,6 +86,10 @@ type HostData struct {
 	CPUCores      int
 	CPUModel      string
 	MemoryTotal   string
+	Owner         string
+	Application   string
+	Location      string
+	Maintenance   bool
 	Metrics       map[string]*MetricData
 	AlertCount    int
 }
@@ -81,14 +120,18 @@ type AlertData struct {
 // NewWriter creates a new HTML report writer.
 // If timezone is nil, it defaults to Asia/Shanghai.
 // If templatePath is empty, the embedded default template will be used.
-func NewWriter(timezone *time.Location, templatePath string) *Writer {
+func NewWriter(timezone *time.Location, templatePath string, opts ...Option) *Writer {
 	if timezone == nil {
 		timezone, _ = time.LoadLocation("Asia/Shanghai")
 	}
-	return &Writer{
+	w := &Writer{
 		timezone:     timezone,
 		templatePath: templatePath,
 	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
 }
 
 // Format returns the format identifier for this writer.
@@ -116,19 +159,82 @@ func (w *Writer) Write(result *model.InspectionResult, outputPath string) error
 	// Prepare template data
 	data := w.prepareTemplateData(result)
 
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+	// Render the report with all resources inlined
+	if err := executeTemplateToFile(tmpl, data, outputPath, w.resourceDir(), w.tr); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
 	}
-	defer file.Close()
 
-	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	return nil
+}
+
+// withThemeFuncs adds the white-label theme functions to funcMap:
+//   - themeStyle: a <style> block overriding the header / table header colors and the
+//     colors of the color scheme
+//   - themeLogo: the logo as a data URI (empty without a logo)
+//   - footerText: the theme footer text, or the given default
+//   - chartLibrary: the inline ECharts script (empty when charts are disabled)
+//   - chartTheme: the ECharts theme of the color scheme (empty for the default theme)
+//   - coverPage: the cover page (empty without a cover)
+//   - tableControls: the search box and status filter of the long tables
+//   - redisTopology: the master → slave diagram of a Redis cluster (empty without slaves)
+//   - mysqlTopology: the MGR group diagram of the MySQL instances (empty without MGR groups)
+func (w *Writer) withThemeFuncs(funcMap template.FuncMap) template.FuncMap {
+	funcMap["themeStyle"] = w.themeStyle
+	funcMap["themeLogo"] = w.themeLogo
+	funcMap["chartLibrary"] = w.chartLibrary
+	funcMap["chartTheme"] = w.chartTheme
+	funcMap["coverPage"] = w.coverPage
+	funcMap["tableControls"] = tableControls
+	funcMap["redisTopology"] = redisTopology
+	funcMap["mysqlTopology"] = mysqlTopology
+	funcMap["footerText"] = func(defaultText string) string {
+		if w.theme == nil || strings.TrimSpace(w.theme.FooterText) == "" {
+			return defaultText
+		}
+		return w.theme.FooterText
 	}
+	return funcMap
+}
 
-	return nil
+// themeStyle returns the CSS overrides of the theme colors and logo, followed by the
+// color scheme so a custom stylesheet can override the theme as well, and the watermark.
+// Colors are validated as #RRGGBB, so they are safe to embed.
+func (w *Writer) themeStyle() template.HTML {
+	var rules []string
+	if w.theme.HasPrimaryColor() {
+		primary, secondary := w.theme.PrimaryColor, w.theme.GetSecondaryColor()
+		rules = append(rules,
+			fmt.Sprintf(".header { background: linear-gradient(135deg, %s 0%%, %s 100%%); }", primary, secondary),
+			fmt.Sprintf("th { background: %s; }", primary),
+			fmt.Sprintf("@media print { .header, th { background: %s !important; } }", primary),
+		)
+	}
+	if w.theme.HasLogo() {
+		rules = append(rules, ".header-logo { display: block; max-height: 48px; margin-bottom: 12px; }")
+	}
+	if css := w.schemeStyle(); css != "" {
+		rules = append(rules, css)
+	}
+	if css := w.watermarkStyle(); css != "" {
+		rules = append(rules, css)
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return template.HTML("<style>\n        " + strings.Join(rules, "\n        ") + "\n    </style>")
+}
+
+// themeLogo returns the theme logo as a data URI so the report stays self-contained.
+// An unreadable logo is omitted; the file is checked when the configuration is validated.
+func (w *Writer) themeLogo() template.URL {
+	if !w.theme.HasLogo() {
+		return ""
+	}
+	uri, err := w.theme.LogoDataURI()
+	if err != nil {
+		return ""
+	}
+	return template.URL(uri)
 }
 
 // loadTemplate loads the HTML template.
@@ -145,7 +251,7 @@ func (w *Writer) loadTemplate() (*template.Template, error) {
 	// Try user-defined template first
 	if w.templatePath != "" {
 		if _, err := os.Stat(w.templatePath); err == nil {
-			tmpl, err := template.New(filepath.Base(w.templatePath)).Funcs(funcMap).ParseFiles(w.templatePath)
+			tmpl, err := template.New(filepath.Base(w.templatePath)).Funcs(w.withThemeFuncs(funcMap)).ParseFiles(w.templatePath)
 			if err != nil {
 				return nil, fmt.Errorf("failed to parse user template: %w", err)
 			}
@@ -155,7 +261,7 @@ func (w *Writer) loadTemplate() (*template.Template, error) {
 	}
 
 	// Load embedded default template
-	tmpl, err := template.New("default.html").Funcs(funcMap).ParseFS(embeddedTemplates, "templates/default.html")
+	tmpl, err := template.New("default.html").Funcs(w.withThemeFuncs(funcMap)).ParseFS(embeddedTemplates, "templates/default.html")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse embedded template: %w", err)
 	}
@@ -177,16 +283,19 @@ func (w *Writer) prepareTemplateData(result *model.InspectionResult) *TemplateDa
 	alerts := w.convertAlerts(result.Alerts)
 
 	return &TemplateData{
-		Title:          "系统巡检报告",
-		InspectionTime: result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"),
+		Title:          w.theme.GetTitle(),
+		InspectionTime: w.formatInspectionTime(result.InspectionTime),
 		Duration:       formatDuration(result.Duration),
 		Summary:        result.Summary,
 		AlertSummary:   result.AlertSummary,
 		Hosts:          hosts,
 		Alerts:         alerts,
+		ActiveAlerts:   w.convertActiveAlerts(result.ActiveAlerts),
 		DiskPaths:      diskPaths,
+		HasCMDB:        model.AnyHostHasCMDB(result.Hosts),
 		Version:        result.Version,
 		GeneratedAt:    time.Now().In(w.timezone).Format("2006-01-02 15:04:05"),
+		HostCharts:     w.prepareHostCharts(result),
 	}
 }
 
@@ -208,6 +317,10 @@ func (w *Writer) convertHostData(host *model.HostResult) *HostData {
 		CPUCores:      host.CPUCores,
 		CPUModel:      host.CPUModel,
 		MemoryTotal:   formatSize(host.MemoryTotal),
+		Owner:         host.Owner,
+		Application:   host.Application,
+		Location:      host.Location,
+		Maintenance:   host.Maintenance,
 		Metrics:       metrics,
 		AlertCount:    len(host.Alerts),
 	}
@@ -366,6 +479,8 @@ func metricStatusClass(status model.MetricStatus) string {
 		return "metric-critical"
 	case model.MetricStatusPending:
 		return "metric-pending"
+	case model.MetricStatusAnomaly:
+		return "metric-anomaly"
 	default:
 		return ""
 	}
@@ -414,7 +529,7 @@ func alertLevelPriority(level model.AlertLevel) int {
 // formatThreshold formats a threshold value based on metric type.
 func formatThreshold(value float64, metricName string) string {
 	switch metricName {
-	case "cpu_usage", "memory_usage", "disk_usage_max":
+	case "cpu_usage", "memory_usage", "disk_usage_max", "memory_available_percent":
 		return fmt.Sprintf("%.1f%%", value)
 	case "load_per_core":
 		return fmt.Sprintf("%.2f", value)
@@ -437,6 +552,8 @@ type MySQLTemplateData struct {
 	Summary        *model.MySQLInspectionSummary
 	AlertSummary   *model.MySQLAlertSummary
 	Instances      []*MySQLInstanceData
+	MGRClusters    []*MySQLMGRClusterData
+	Topology       *MySQLTopologyData // Replication diagram (nil without MGR groups)
 	Alerts         []*MySQLAlertData
 	Version        string
 	GeneratedAt    string
@@ -453,12 +570,34 @@ type MySQLInstanceData struct {
 	SyncStatus         string
 	MaxConnections     int
 	CurrentConnections int
+	QPS                string
+	TPS                string
+	SlowQueries        string
+	ThreadsRunning     int
 	BinlogEnabled      string
 	Status             string
 	StatusClass        string
 	AlertCount         int
 }
 
+// MySQLMGRClusterData represents one MGR group and its members formatted for template.
+type MySQLMGRClusterData struct {
+	Name          string
+	OnlineMembers int
+	TotalMembers  int
+	Members       []*MySQLMGRMemberData
+}
+
+// MySQLMGRMemberData represents an MGR group member formatted for template.
+type MySQLMGRMemberData struct {
+	Address            string
+	MemberID           string
+	Role               string
+	State              string
+	StateClass         string // normal / warning / critical
+	TransactionsQueued string // 未采集为 "N/A"
+}
+
 // MySQLAlertData represents MySQL alert data formatted for template.
 type MySQLAlertData struct {
 	Address           string
@@ -525,23 +664,44 @@ func mysqlClusterModeText(mode model.MySQLClusterMode) string {
 // getMySQLSyncStatus returns sync status text based on cluster mode.
 func getMySQLSyncStatus(r *model.MySQLInspectionResult) string {
 	if r.Instance.ClusterMode.IsMGR() {
-		if r.MGRStateOnline {
-			return "在线"
-		}
-		return "离线"
+		return model.FormatBool("mgr_state_online", r.MGRStateOnline)
 	}
-	if r.SyncStatus {
-		return "正常"
+	return model.FormatBool("sync_status", r.SyncStatus)
+}
+
+// expectedThresholdTexts returns the warning and critical threshold texts of an
+// expected-value alert: the expectation under the alert's level, "-" under the other.
+func expectedThresholdTexts(expected string, level model.AlertLevel) (string, string) {
+	text := "期望: " + expected
+	if level == model.AlertLevelCritical {
+		return "-", text
+	}
+	return text, "-"
+}
+
+// mysqlMGRRoleText converts an MGR member role to Chinese text.
+func mysqlMGRRoleText(role model.MySQLMGRRole) string {
+	switch role {
+	case model.MGRRolePrimary:
+		return "主节点"
+	case model.MGRRoleSecondary:
+		return "从节点"
+	default:
+		return "未知"
 	}
-	return "异常"
 }
 
-// boolToText converts boolean to Chinese text (启用/禁用).
-func boolToText(b bool) string {
-	if b {
-		return "启用"
+// mysqlMGRStateClass returns the badge class for an MGR member state.
+// RECOVERING is transient, any other non-ONLINE state is critical.
+func mysqlMGRStateClass(m *model.MySQLMGRMember) string {
+	switch {
+	case m.State == "" || m.IsOnline():
+		return "normal"
+	case m.State == model.MGRMemberStateRecovering:
+		return "warning"
+	default:
+		return "critical"
 	}
-	return "禁用"
 }
 
 // formatMySQLThreshold formats a MySQL alert threshold value based on metric type.
@@ -549,13 +709,14 @@ func formatMySQLThreshold(value float64, metricName string) string {
 	switch metricName {
 	case "connection_usage":
 		return fmt.Sprintf("%.1f%%", value)
-	case "mgr_member_count":
+	case "mgr_member_count", "threads_running", "innodb_deadlocks", "innodb_history_list_length":
 		return fmt.Sprintf("%.0f", value)
+	case "slow_queries", "innodb_row_lock_waits":
+		return fmt.Sprintf("%.2f/s", value)
+	case "innodb_buffer_pool_hit_ratio":
+		return fmt.Sprintf("%.2f%%", value)
 	case "mgr_state_online":
-		if value > 0 {
-			return "在线"
-		}
-		return "离线"
+		return model.FormatBool("mgr_state_online", value > 0)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -585,16 +746,9 @@ func (w *Writer) WriteMySQLInspection(result *model.MySQLInspectionResults, outp
 	// Prepare template data
 	data := w.prepareMySQLTemplateData(result)
 
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-
-	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute MySQL template: %w", err)
+	// Render the report with all resources inlined
+	if err := executeTemplateToFile(tmpl, data, outputPath, w.resourceDir(), w.tr); err != nil {
+		return fmt.Errorf("failed to write MySQL report: %w", err)
 	}
 
 	return nil
@@ -611,7 +765,7 @@ func (w *Writer) loadMySQLTemplate() (*template.Template, error) {
 	}
 
 	// Load embedded MySQL template
-	tmpl, err := template.New("mysql.html").Funcs(funcMap).ParseFS(embeddedTemplates, "templates/mysql.html")
+	tmpl, err := template.New("mysql.html").Funcs(w.withThemeFuncs(funcMap)).ParseFS(embeddedTemplates, "templates/mysql.html")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse embedded MySQL template: %w", err)
 	}
@@ -631,11 +785,13 @@ func (w *Writer) prepareMySQLTemplateData(result *model.MySQLInspectionResults)
 
 	return &MySQLTemplateData{
 		Title:          "MySQL 巡检报告",
-		InspectionTime: result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"),
+		InspectionTime: w.formatInspectionTime(result.InspectionTime),
 		Duration:       formatDuration(result.Duration),
 		Summary:        result.Summary,
 		AlertSummary:   result.AlertSummary,
 		Instances:      instances,
+		MGRClusters:    w.convertMySQLMGRClusters(result),
+		Topology:       newMySQLTopology(result),
 		Alerts:         alerts,
 		Version:        result.Version,
 		GeneratedAt:    time.Now().In(w.timezone).Format("2006-01-02 15:04:05"),
@@ -654,13 +810,51 @@ func (w *Writer) convertMySQLInstanceData(r *model.MySQLInspectionResult) *MySQL
 		SyncStatus:         getMySQLSyncStatus(r),
 		MaxConnections:     r.MaxConnections,
 		CurrentConnections: r.CurrentConnections,
-		BinlogEnabled:      boolToText(r.BinlogEnabled),
+		QPS:                fmt.Sprintf("%.1f", r.QPS),
+		TPS:                fmt.Sprintf("%.1f", r.TPS),
+		SlowQueries:        fmt.Sprintf("%.2f", r.SlowQueriesPerSecond),
+		ThreadsRunning:     r.ThreadsRunning,
+		BinlogEnabled:      model.FormatBool("binlog_enabled", r.BinlogEnabled),
 		Status:             mysqlStatusText(r.Status),
 		StatusClass:        mysqlStatusClass(r.Status),
 		AlertCount:         len(r.Alerts),
 	}
 }
 
+// convertMySQLMGRClusters converts the MGR groups of a MySQL inspection for template rendering.
+func (w *Writer) convertMySQLMGRClusters(result *model.MySQLInspectionResults) []*MySQLMGRClusterData {
+	clusters := result.MGRClusters()
+	data := make([]*MySQLMGRClusterData, 0, len(clusters))
+	for _, cluster := range clusters {
+		members := make([]*MySQLMGRMemberData, 0, len(cluster.Members))
+		for _, m := range cluster.Members {
+			state := m.State
+			if state == "" {
+				state = "N/A"
+			}
+			queued := "N/A"
+			if m.HasTransactionsQueued() {
+				queued = fmt.Sprint(m.TransactionsQueued)
+			}
+			members = append(members, &MySQLMGRMemberData{
+				Address:            m.Address(),
+				MemberID:           m.MemberID,
+				Role:               mysqlMGRRoleText(m.Role),
+				State:              state,
+				StateClass:         mysqlMGRStateClass(m),
+				TransactionsQueued: queued,
+			})
+		}
+		data = append(data, &MySQLMGRClusterData{
+			Name:          cluster.Name,
+			OnlineMembers: cluster.OnlineMembers(),
+			TotalMembers:  len(cluster.Members),
+			Members:       members,
+		})
+	}
+	return data
+}
+
 // convertMySQLAlerts converts and sorts MySQL alerts for template rendering.
 func (w *Writer) convertMySQLAlerts(alerts []*model.MySQLAlert) []*MySQLAlertData {
 	// Make a copy for sorting
@@ -678,13 +872,18 @@ func (w *Writer) convertMySQLAlerts(alerts []*model.MySQLAlert) []*MySQLAlertDat
 	// Convert to MySQLAlertData
 	result := make([]*MySQLAlertData, 0, len(sortedAlerts))
 	for _, alert := range sortedAlerts {
+		warningText := formatMySQLThreshold(alert.WarningThreshold, alert.MetricName)
+		criticalText := formatMySQLThreshold(alert.CriticalThreshold, alert.MetricName)
+		if alert.ExpectedValue != "" {
+			warningText, criticalText = expectedThresholdTexts(alert.ExpectedValue, alert.Level)
+		}
 		result = append(result, &MySQLAlertData{
 			Address:           alert.Address,
 			MetricName:        alert.MetricName,
 			MetricDisplayName: alert.MetricDisplayName,
 			CurrentValue:      alert.FormattedValue,
-			WarningThreshold:  formatMySQLThreshold(alert.WarningThreshold, alert.MetricName),
-			CriticalThreshold: formatMySQLThreshold(alert.CriticalThreshold, alert.MetricName),
+			WarningThreshold:  warningText,
+			CriticalThreshold: criticalText,
 			Level:             alertLevelText(alert.Level),
 			LevelClass:        alertLevelClass(alert.Level),
 			Message:           alert.Message,
@@ -708,12 +907,17 @@ type CombinedTemplateData struct {
 	HostAlertSummary *model.AlertSummary
 	Hosts            []*HostData
 	HostAlerts       []*AlertData
+	HostActiveAlerts []*ActiveAlertData // N9E 当前告警
 	DiskPaths        []string
+	HasCMDB          bool            // 主机带有 CMDB 信息时显示负责人、应用、位置与维护状态列
+	HostCharts       *HostChartsData // 交互式图表（未启用时为 nil）
 	// MySQL data
 	HasMySQL          bool
 	MySQLSummary      *model.MySQLInspectionSummary
 	MySQLAlertSummary *model.MySQLAlertSummary
 	MySQLInstances    []*MySQLInstanceData
+	MySQLMGRClusters  []*MySQLMGRClusterData
+	MySQLTopology     *MySQLTopologyData // Replication diagram (nil without MGR groups)
 	MySQLAlerts       []*MySQLAlertData
 	// Redis data
 	HasRedis                 bool
@@ -722,12 +926,14 @@ type CombinedTemplateData struct {
 	RedisSummary             *model.RedisInspectionSummary
 	RedisAlertSummary        *model.RedisAlertSummary
 	RedisInstances           []*RedisInstanceData
+	RedisTopology            *RedisTopologyData // Master → slave diagram of the single cluster (nil without slaves)
 	RedisAlerts              []*RedisAlertData
 	// Nginx data
 	HasNginx          bool
 	NginxSummary      *model.NginxInspectionSummary
 	NginxAlertSummary *model.NginxAlertSummary
 	NginxInstances    []*NginxInstanceData
+	NginxUpstreams    []*NginxUpstreamData
 	NginxAlerts       []*NginxAlertData
 	// Tomcat data
 	HasTomcat          bool
@@ -735,15 +941,83 @@ type CombinedTemplateData struct {
 	TomcatAlertSummary *model.TomcatAlertSummary
 	TomcatInstances    []*TomcatInstanceData
 	TomcatAlerts       []*TomcatAlertData
+	// Cassandra data
+	HasCassandra          bool
+	CassandraSummary      *model.CassandraInspectionSummary
+	CassandraAlertSummary *model.CassandraAlertSummary
+	CassandraInstances    []*CassandraInstanceData
+	CassandraAlerts       []*CassandraAlertData
+	// Monitoring stack data
+	HasMonitoring          bool
+	MonitoringSummary      *model.MonitoringInspectionSummary
+	MonitoringAlertSummary *model.MonitoringAlertSummary
+	MonitoringInstances    []*MonitoringInstanceData
+	MonitoringAlerts       []*MonitoringAlertData
+	// Shared storage data
+	HasStorage          bool
+	StorageSummary      *model.StorageInspectionSummary
+	StorageAlertSummary *model.StorageAlertSummary
+	StorageInstances    []*StorageInstanceData
+	StorageAlerts       []*StorageAlertData
+	// Log checks data
+	HasLogChecks         bool
+	LogCheckSummary      *model.LogCheckInspectionSummary
+	LogCheckAlertSummary *model.LogCheckAlertSummary
+	LogCheckInstances    []*LogCheckInstanceData
+	LogCheckAlerts       []*LogCheckAlertData
+	// LVS data
+	HasLVS          bool
+	LVSSummary      *model.LVSInspectionSummary
+	LVSAlertSummary *model.LVSAlertSummary
+	LVSInstances    []*LVSInstanceData
+	LVSRealServers  []*LVSRealServerData
+	LVSAlerts       []*LVSAlertData
+	// Windows service / IIS data
+	HasWindows          bool
+	WindowsSummary      *model.WindowsInspectionSummary
+	WindowsAlertSummary *model.WindowsAlertSummary
+	WindowsInstances    []*WindowsInstanceData
+	WindowsAlerts       []*WindowsAlertData
+
+	// AD / LDAP data
+	HasAD          bool
+	ADSummary      *model.ADInspectionSummary
+	ADAlertSummary *model.ADAlertSummary
+	ADInstances    []*ADInstanceData
+	ADAlerts       []*ADAlertData
+
+	// Cloud resource data
+	HasCloud          bool
+	CloudSummary      *model.CloudInspectionSummary
+	CloudAlertSummary *model.CloudAlertSummary
+	CloudInstances    []*CloudInstanceData
+	CloudAlerts       []*CloudAlertData
+	CloudCost         *CloudCostData // 成本 / 资产汇总（未启用时为 nil）
+
+	// Firewall / VPN appliance data
+	HasFirewall          bool
+	FirewallSummary      *model.FirewallInspectionSummary
+	FirewallAlertSummary *model.FirewallAlertSummary
+	FirewallInstances    []*FirewallInstanceData
+	FirewallTunnels      []*FirewallTunnelData
+	FirewallAlerts       []*FirewallAlertData
+	// Alerts firing in Alertmanager (empty on split report module pages)
+	AlertmanagerAlerts []*AlertmanagerAlertData
+	// Executive summary of all modules (nil on split report module pages)
+	Executive *ExecutiveSummaryData
+	// Alert distribution charts of all modules (nil when charts are disabled)
+	AlertCharts *AlertChartsData
+	// Split report navigation (empty for single-file reports)
+	Pages []*PageLink
 	// Common
 	Version     string
 	GeneratedAt string
 }
 
-// WriteCombined generates an HTML report combining Host, MySQL, Redis, Nginx, and Tomcat inspection results.
-func (w *Writer) WriteCombined(hostResult *model.InspectionResult, mysqlResult *model.MySQLInspectionResults, redisResult *model.RedisInspectionResults, nginxResult *model.NginxInspectionResults, tomcatResult *model.TomcatInspectionResults, outputPath string) error {
+// WriteCombined generates an HTML report combining Host, MySQL, Redis, Nginx, Tomcat, Cassandra, monitoring stack, shared storage, log checks, LVS, Windows, AD, and cloud resource inspection results.
+func (w *Writer) WriteCombined(report *json.Report, outputPath string) error {
 	// At least one result must be present
-	if hostResult == nil && mysqlResult == nil && redisResult == nil && nginxResult == nil && tomcatResult == nil {
+	if report.Empty() {
 		return fmt.Errorf("all inspection results are nil")
 	}
 
@@ -759,18 +1033,14 @@ func (w *Writer) WriteCombined(hostResult *model.InspectionResult, mysqlResult *
 	}
 
 	// Prepare combined template data
-	data := w.prepareCombinedTemplateData(hostResult, mysqlResult, redisResult, nginxResult, tomcatResult)
-
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
+	data := w.prepareCombinedTemplateData(report)
+	data.Executive = w.prepareExecutiveSummary(report)
+	data.AlertCharts = w.prepareAlertCharts(json.FlattenAlerts(report))
+	data.AlertmanagerAlerts = w.convertAlertmanagerAlerts(w.alertmanagerAlerts)
 
-	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute combined template: %w", err)
+	// Render the report with all resources inlined
+	if err := executeTemplateToFile(tmpl, data, outputPath, w.resourceDir(), w.tr); err != nil {
+		return fmt.Errorf("failed to write combined report: %w", err)
 	}
 
 	return nil
@@ -787,7 +1057,7 @@ func (w *Writer) loadCombinedTemplate() (*template.Template, error) {
 	}
 
 	// Load embedded combined template
-	tmpl, err := template.New("combined.html").Funcs(funcMap).ParseFS(embeddedTemplates, "templates/combined.html")
+	tmpl, err := template.New("combined.html").Funcs(w.withThemeFuncs(funcMap)).ParseFS(embeddedTemplates, "templates/combined.html")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse embedded combined template: %w", err)
 	}
@@ -795,137 +1065,342 @@ func (w *Writer) loadCombinedTemplate() (*template.Template, error) {
 }
 
 // prepareCombinedTemplateData prepares data for the combined template.
-func (w *Writer) prepareCombinedTemplateData(hostResult *model.InspectionResult, mysqlResult *model.MySQLInspectionResults, redisResult *model.RedisInspectionResults, nginxResult *model.NginxInspectionResults, tomcatResult *model.TomcatInspectionResults) *CombinedTemplateData {
+func (w *Writer) prepareCombinedTemplateData(report *json.Report) *CombinedTemplateData {
 	data := &CombinedTemplateData{
-		Title:       "系统巡检报告",
+		Title:       w.theme.GetTitle(),
 		GeneratedAt: time.Now().In(w.timezone).Format("2006-01-02 15:04:05"),
 	}
 
 	// Determine inspection time and duration from available results
-	if hostResult != nil {
-		data.InspectionTime = hostResult.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")
-		data.Duration = formatDuration(hostResult.Duration)
-		data.Version = hostResult.Version
-	} else if mysqlResult != nil {
-		data.InspectionTime = mysqlResult.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")
-		data.Duration = formatDuration(mysqlResult.Duration)
-		data.Version = mysqlResult.Version
-	} else if redisResult != nil {
-		data.InspectionTime = redisResult.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")
-		data.Duration = formatDuration(redisResult.Duration)
-		data.Version = redisResult.Version
-	} else if nginxResult != nil {
-		data.InspectionTime = nginxResult.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")
-		data.Duration = formatDuration(nginxResult.Duration)
-		data.Version = nginxResult.Version
-	} else if tomcatResult != nil {
-		data.InspectionTime = tomcatResult.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")
-		data.Duration = formatDuration(tomcatResult.Duration)
-		data.Version = tomcatResult.Version
+	if report.Host != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Host.InspectionTime)
+		data.Duration = formatDuration(report.Host.Duration)
+		data.Version = report.Host.Version
+	} else if report.MySQL != nil {
+		data.InspectionTime = w.formatInspectionTime(report.MySQL.InspectionTime)
+		data.Duration = formatDuration(report.MySQL.Duration)
+		data.Version = report.MySQL.Version
+	} else if report.Redis != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Redis.InspectionTime)
+		data.Duration = formatDuration(report.Redis.Duration)
+		data.Version = report.Redis.Version
+	} else if report.Nginx != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Nginx.InspectionTime)
+		data.Duration = formatDuration(report.Nginx.Duration)
+		data.Version = report.Nginx.Version
+	} else if report.Tomcat != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Tomcat.InspectionTime)
+		data.Duration = formatDuration(report.Tomcat.Duration)
+		data.Version = report.Tomcat.Version
+	} else if report.Cassandra != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Cassandra.InspectionTime)
+		data.Duration = formatDuration(report.Cassandra.Duration)
+		data.Version = report.Cassandra.Version
+	} else if report.Monitoring != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Monitoring.InspectionTime)
+		data.Duration = formatDuration(report.Monitoring.Duration)
+		data.Version = report.Monitoring.Version
+	} else if report.Storage != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Storage.InspectionTime)
+		data.Duration = formatDuration(report.Storage.Duration)
+		data.Version = report.Storage.Version
+	} else if report.LogChecks != nil {
+		data.InspectionTime = w.formatInspectionTime(report.LogChecks.InspectionTime)
+		data.Duration = formatDuration(report.LogChecks.Duration)
+		data.Version = report.LogChecks.Version
+	} else if report.LVS != nil {
+		data.InspectionTime = w.formatInspectionTime(report.LVS.InspectionTime)
+		data.Duration = formatDuration(report.LVS.Duration)
+		data.Version = report.LVS.Version
+	} else if report.Windows != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Windows.InspectionTime)
+		data.Duration = formatDuration(report.Windows.Duration)
+		data.Version = report.Windows.Version
+	} else if report.AD != nil {
+		data.InspectionTime = w.formatInspectionTime(report.AD.InspectionTime)
+		data.Duration = formatDuration(report.AD.Duration)
+		data.Version = report.AD.Version
+	} else if report.Cloud != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Cloud.InspectionTime)
+		data.Duration = formatDuration(report.Cloud.Duration)
+		data.Version = report.Cloud.Version
+	} else if report.Firewall != nil {
+		data.InspectionTime = w.formatInspectionTime(report.Firewall.InspectionTime)
+		data.Duration = formatDuration(report.Firewall.Duration)
+		data.Version = report.Firewall.Version
 	}
 
 	// Fill Host data if available
-	if hostResult != nil {
+	if report.Host != nil {
 		data.HasHost = true
-		data.HostSummary = hostResult.Summary
-		data.HostAlertSummary = hostResult.AlertSummary
-		data.DiskPaths = w.collectDiskPaths(hostResult.Hosts)
+		data.HostSummary = report.Host.Summary
+		data.HostAlertSummary = report.Host.AlertSummary
+		data.DiskPaths = w.collectDiskPaths(report.Host.Hosts)
+		data.HasCMDB = model.AnyHostHasCMDB(report.Host.Hosts)
 
 		// Convert hosts
-		hosts := make([]*HostData, 0, len(hostResult.Hosts))
-		for _, host := range hostResult.Hosts {
+		hosts := make([]*HostData, 0, len(report.Host.Hosts))
+		for _, host := range report.Host.Hosts {
 			hosts = append(hosts, w.convertHostData(host))
 		}
 		data.Hosts = hosts
 
 		// Convert host alerts
-		data.HostAlerts = w.convertAlerts(hostResult.Alerts)
+		data.HostAlerts = w.convertAlerts(report.Host.Alerts)
+		data.HostActiveAlerts = w.convertActiveAlerts(report.Host.ActiveAlerts)
+		data.HostCharts = w.prepareHostCharts(report.Host)
 	}
 
 	// Fill MySQL data if available
-	if mysqlResult != nil {
+	if report.MySQL != nil {
 		data.HasMySQL = true
-		data.MySQLSummary = mysqlResult.Summary
-		data.MySQLAlertSummary = mysqlResult.AlertSummary
+		data.MySQLSummary = report.MySQL.Summary
+		data.MySQLAlertSummary = report.MySQL.AlertSummary
 
 		// Convert MySQL instances
-		instances := make([]*MySQLInstanceData, 0, len(mysqlResult.Results))
-		for _, r := range mysqlResult.Results {
+		instances := make([]*MySQLInstanceData, 0, len(report.MySQL.Results))
+		for _, r := range report.MySQL.Results {
 			instances = append(instances, w.convertMySQLInstanceData(r))
 		}
 		data.MySQLInstances = instances
+		data.MySQLMGRClusters = w.convertMySQLMGRClusters(report.MySQL)
+		data.MySQLTopology = newMySQLTopology(report.MySQL)
 
 		// Convert MySQL alerts
-		data.MySQLAlerts = w.convertMySQLAlerts(mysqlResult.Alerts)
+		data.MySQLAlerts = w.convertMySQLAlerts(report.MySQL.Alerts)
 	}
 
 	// Fill Redis data if available
-	if redisResult != nil {
+	if report.Redis != nil {
 		data.HasRedis = true
-		data.RedisSummary = redisResult.Summary
-		data.RedisAlertSummary = redisResult.AlertSummary
+		data.RedisSummary = report.Redis.Summary
+		data.RedisAlertSummary = report.Redis.AlertSummary
 
 		// Check for multiple clusters
-		if redisResult.HasMultipleClusters() {
+		if report.Redis.HasMultipleClusters() {
 			data.HasMultipleRedisClusters = true
 			// Convert clusters
-			redisClusters := make([]*RedisClusterData, 0, len(redisResult.Clusters))
-			for _, cluster := range redisResult.Clusters {
+			redisClusters := make([]*RedisClusterData, 0, len(report.Redis.Clusters))
+			for _, cluster := range report.Redis.Clusters {
 				redisClusters = append(redisClusters, w.convertRedisClusterData(cluster))
 			}
 			data.RedisClusters = redisClusters
 		} else {
 			// Single cluster: use flat display
-			redisInstances := make([]*RedisInstanceData, 0, len(redisResult.Results))
-			for _, r := range redisResult.Results {
+			redisInstances := make([]*RedisInstanceData, 0, len(report.Redis.Results))
+			for _, r := range report.Redis.Results {
 				redisInstances = append(redisInstances, w.convertRedisInstanceData(r))
 			}
 			data.RedisInstances = redisInstances
+			data.RedisTopology = newRedisTopology(report.Redis.Results)
 		}
 
 		// Convert Redis alerts (always needed for combined alerts section)
-		data.RedisAlerts = w.convertRedisAlerts(redisResult.Alerts)
+		data.RedisAlerts = w.convertRedisAlerts(report.Redis.Alerts)
 	}
 
 	// Fill Nginx data if available
-	if nginxResult != nil {
+	if report.Nginx != nil {
 		data.HasNginx = true
-		data.NginxSummary = nginxResult.Summary
-		data.NginxAlertSummary = nginxResult.AlertSummary
+		data.NginxSummary = report.Nginx.Summary
+		data.NginxAlertSummary = report.Nginx.AlertSummary
 
 		// If no other result provided inspection time, use Nginx's
 		if data.InspectionTime == "" {
-			data.InspectionTime = nginxResult.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05")
-			data.Duration = formatDuration(nginxResult.Duration)
-			data.Version = nginxResult.Version
+			data.InspectionTime = w.formatInspectionTime(report.Nginx.InspectionTime)
+			data.Duration = formatDuration(report.Nginx.Duration)
+			data.Version = report.Nginx.Version
 		}
 
 		// Convert Nginx instances
-		nginxInstances := make([]*NginxInstanceData, 0, len(nginxResult.Results))
-		for _, r := range nginxResult.Results {
+		nginxInstances := make([]*NginxInstanceData, 0, len(report.Nginx.Results))
+		for _, r := range report.Nginx.Results {
 			nginxInstances = append(nginxInstances, w.convertNginxInstanceData(r))
 		}
 		data.NginxInstances = nginxInstances
 
+		// Convert Nginx upstream summaries
+		data.NginxUpstreams = w.convertNginxUpstreams(report.Nginx.Results)
+
 		// Convert Nginx alerts
-		data.NginxAlerts = w.convertNginxAlerts(nginxResult.Alerts)
+		data.NginxAlerts = w.convertNginxAlerts(report.Nginx.Alerts)
 	}
 
 	// Fill Tomcat data if available
-	if tomcatResult != nil {
+	if report.Tomcat != nil {
 		data.HasTomcat = true
-		data.TomcatSummary = tomcatResult.Summary
-		data.TomcatAlertSummary = tomcatResult.AlertSummary
+		data.TomcatSummary = report.Tomcat.Summary
+		data.TomcatAlertSummary = report.Tomcat.AlertSummary
 
 		// Convert Tomcat instances
-		tomcatInstances := make([]*TomcatInstanceData, 0, len(tomcatResult.Results))
-		for _, r := range tomcatResult.Results {
+		tomcatInstances := make([]*TomcatInstanceData, 0, len(report.Tomcat.Results))
+		for _, r := range report.Tomcat.Results {
 			tomcatInstances = append(tomcatInstances, w.convertTomcatInstanceData(r))
 		}
 		data.TomcatInstances = tomcatInstances
 
 		// Convert Tomcat alerts
-		data.TomcatAlerts = w.convertTomcatAlerts(tomcatResult.Alerts)
+		data.TomcatAlerts = w.convertTomcatAlerts(report.Tomcat.Alerts)
+	}
+
+	// Fill Cassandra data if available
+	if report.Cassandra != nil {
+		data.HasCassandra = true
+		data.CassandraSummary = report.Cassandra.Summary
+		data.CassandraAlertSummary = report.Cassandra.AlertSummary
+
+		// Convert Cassandra nodes
+		cassandraInstances := make([]*CassandraInstanceData, 0, len(report.Cassandra.Results))
+		for _, r := range report.Cassandra.Results {
+			cassandraInstances = append(cassandraInstances, w.convertCassandraInstanceData(r))
+		}
+		data.CassandraInstances = cassandraInstances
+
+		// Convert Cassandra alerts
+		data.CassandraAlerts = w.convertCassandraAlerts(report.Cassandra.Alerts)
+	}
+
+	// Fill monitoring stack data if available
+	if report.Monitoring != nil {
+		data.HasMonitoring = true
+		data.MonitoringSummary = report.Monitoring.Summary
+		data.MonitoringAlertSummary = report.Monitoring.AlertSummary
+
+		// Convert monitoring components
+		monitoringInstances := make([]*MonitoringInstanceData, 0, len(report.Monitoring.Results))
+		for _, r := range report.Monitoring.Results {
+			monitoringInstances = append(monitoringInstances, w.convertMonitoringInstanceData(r))
+		}
+		data.MonitoringInstances = monitoringInstances
+
+		// Convert monitoring alerts
+		data.MonitoringAlerts = w.convertMonitoringAlerts(report.Monitoring.Alerts)
+	}
+
+	// Fill shared storage data if available
+	if report.Storage != nil {
+		data.HasStorage = true
+		data.StorageSummary = report.Storage.Summary
+		data.StorageAlertSummary = report.Storage.AlertSummary
+
+		// Convert storage hosts
+		storageInstances := make([]*StorageInstanceData, 0, len(report.Storage.Results))
+		for _, r := range report.Storage.Results {
+			storageInstances = append(storageInstances, w.convertStorageInstanceData(r))
+		}
+		data.StorageInstances = storageInstances
+
+		// Convert storage alerts
+		data.StorageAlerts = w.convertStorageAlerts(report.Storage.Alerts)
+	}
+
+	// Fill log checks data if available
+	if report.LogChecks != nil {
+		data.HasLogChecks = true
+		data.LogCheckSummary = report.LogChecks.Summary
+		data.LogCheckAlertSummary = report.LogChecks.AlertSummary
+
+		// Convert log check results
+		logCheckInstances := make([]*LogCheckInstanceData, 0, len(report.LogChecks.Results))
+		for _, r := range report.LogChecks.Results {
+			logCheckInstances = append(logCheckInstances, w.convertLogCheckInstanceData(r))
+		}
+		data.LogCheckInstances = logCheckInstances
+
+		// Convert log check alerts
+		data.LogCheckAlerts = w.convertLogCheckAlerts(report.LogChecks.Alerts)
+	}
+
+	// Fill LVS data if available
+	if report.LVS != nil {
+		data.HasLVS = true
+		data.LVSSummary = report.LVS.Summary
+		data.LVSAlertSummary = report.LVS.AlertSummary
+
+		// Convert LVS directors and their real servers
+		lvsInstances := make([]*LVSInstanceData, 0, len(report.LVS.Results))
+		for _, r := range report.LVS.Results {
+			lvsInstances = append(lvsInstances, w.convertLVSInstanceData(r))
+			data.LVSRealServers = append(data.LVSRealServers, w.convertLVSRealServers(r)...)
+		}
+		data.LVSInstances = lvsInstances
+
+		// Convert LVS alerts
+		data.LVSAlerts = w.convertLVSAlerts(report.LVS.Alerts)
+	}
+
+	// Fill Windows data if available
+	if report.Windows != nil {
+		data.HasWindows = true
+		data.WindowsSummary = report.Windows.Summary
+		data.WindowsAlertSummary = report.Windows.AlertSummary
+
+		// Convert Windows hosts
+		windowsInstances := make([]*WindowsInstanceData, 0, len(report.Windows.Results))
+		for _, r := range report.Windows.Results {
+			windowsInstances = append(windowsInstances, w.convertWindowsInstanceData(r))
+		}
+		data.WindowsInstances = windowsInstances
+
+		// Convert Windows alerts
+		data.WindowsAlerts = w.convertWindowsAlerts(report.Windows.Alerts)
+	}
+
+	// Fill AD data if available
+	if report.AD != nil {
+		data.HasAD = true
+		data.ADSummary = report.AD.Summary
+		data.ADAlertSummary = report.AD.AlertSummary
+
+		// Convert domain controllers
+		adInstances := make([]*ADInstanceData, 0, len(report.AD.Results))
+		for _, r := range report.AD.Results {
+			adInstances = append(adInstances, w.convertADInstanceData(r))
+		}
+		data.ADInstances = adInstances
+
+		// Convert AD alerts
+		data.ADAlerts = w.convertADAlerts(report.AD.Alerts)
+	}
+
+	// Fill cloud resource data if available
+	if report.Cloud != nil {
+		data.HasCloud = true
+		data.CloudSummary = report.Cloud.Summary
+		data.CloudAlertSummary = report.Cloud.AlertSummary
+
+		// Convert cloud resources
+		cloudInstances := make([]*CloudInstanceData, 0, len(report.Cloud.Results))
+		for _, r := range report.Cloud.Results {
+			cloudInstances = append(cloudInstances, w.convertCloudInstanceData(r))
+		}
+		data.CloudInstances = cloudInstances
+
+		// Convert cloud resource alerts
+		data.CloudAlerts = w.convertCloudAlerts(report.Cloud.Alerts)
+
+		// Convert cost summary if enabled
+		if report.Cloud.CostSummary != nil {
+			data.CloudCost = w.convertCloudCostData(report.Cloud.CostSummary)
+		}
+	}
+
+	// Fill firewall data if available
+	if report.Firewall != nil {
+		data.HasFirewall = true
+		data.FirewallSummary = report.Firewall.Summary
+		data.FirewallAlertSummary = report.Firewall.AlertSummary
+
+		// Convert firewall devices and their VPN tunnels
+		firewallInstances := make([]*FirewallInstanceData, 0, len(report.Firewall.Results))
+		for _, r := range report.Firewall.Results {
+			firewallInstances = append(firewallInstances, w.convertFirewallInstanceData(r))
+			data.FirewallTunnels = append(data.FirewallTunnels, w.convertFirewallTunnels(r)...)
+		}
+		data.FirewallInstances = firewallInstances
+
+		// Convert firewall alerts
+		data.FirewallAlerts = w.convertFirewallAlerts(report.Firewall.Alerts)
 	}
 
 	return data
@@ -943,6 +1418,7 @@ type RedisTemplateData struct {
 	Summary        *model.RedisInspectionSummary
 	AlertSummary   *model.RedisAlertSummary
 	Instances      []*RedisInstanceData
+	Topology       *RedisTopologyData // Master → slave diagram (nil without slaves)
 	Alerts         []*RedisAlertData
 	Version        string
 	GeneratedAt    string
@@ -960,6 +1436,7 @@ type NginxTemplateData struct {
 	Summary        *model.NginxInspectionSummary
 	AlertSummary   *model.NginxAlertSummary
 	Instances      []*NginxInstanceData
+	Upstreams      []*NginxUpstreamData
 	Alerts         []*NginxAlertData
 	Version        string
 	GeneratedAt    string
@@ -967,23 +1444,28 @@ type NginxTemplateData struct {
 
 // RedisInstanceData represents Redis instance data formatted for template.
 type RedisInstanceData struct {
-	Address          string
-	IP               string
-	Port             int
-	Version          string // N/A for MVP
-	Role             string // "主"/"从"/"未知"
-	ClusterEnabled   string // "启用"/"禁用"
-	ConnectionStatus string // "正常"/"异常"
-	MaxClients       int
-	ConnectedClients int
-	ConnectionUsage  string // 格式化百分比
-	ConnectedSlaves  int    // 仅主节点显示
-	MasterLinkStatus string // 仅从节点：同步状态
-	MasterPort       string // 仅从节点：Master 端口
-	ReplicationLag   string // 仅从节点：格式化延迟
-	Status           string // "正常"/"警告"/"严重"/"失败"
-	StatusClass      string // CSS class
-	AlertCount       int
+	Address            string
+	IP                 string
+	Port               int
+	Version            string // N/A for MVP
+	Role               string // "主"/"从"/"未知"
+	ClusterEnabled     string // "启用"/"禁用"
+	ConnectionStatus   string // "正常"/"异常"
+	MaxClients         int
+	ConnectedClients   int
+	ConnectionUsage    string // 格式化百分比
+	ConnectedSlaves    int    // 仅主节点显示
+	MasterLinkStatus   string // 仅从节点：同步状态
+	MasterPort         string // 仅从节点：Master 端口
+	ReplicationLag     string // 仅从节点：格式化延迟
+	MemoryUsage        string // 内存使用率（maxmemory 未设置时为 N/A）
+	FragmentationRatio string // 内存碎片率
+	EvictedKeys        string // 驱逐速率（/秒）
+	ExpiredKeys        string // 过期速率（/秒）
+	KeyspaceHitRatio   string // Keyspace 命中率（未计算时为 N/A）
+	Status             string // "正常"/"警告"/"严重"/"失败"
+	StatusClass        string // CSS class
+	AlertCount         int
 }
 
 // RedisAlertData represents Redis alert data formatted for template.
@@ -1006,6 +1488,7 @@ type RedisClusterData struct {
 	Summary      *model.RedisInspectionSummary
 	AlertSummary *model.RedisAlertSummary
 	Instances    []*RedisInstanceData
+	Topology     *RedisTopologyData // Master → slave diagram (nil without slaves)
 	Alerts       []*RedisAlertData
 }
 
@@ -1057,14 +1540,6 @@ func redisRoleText(role model.RedisRole) string {
 	}
 }
 
-// redisConnectionStatusText returns connection status text.
-func redisConnectionStatusText(r *model.RedisInspectionResult) string {
-	if r.ConnectionStatus {
-		return "正常"
-	}
-	return "异常"
-}
-
 // formatRedisConnectionUsage formats connection usage percentage.
 func formatRedisConnectionUsage(r *model.RedisInspectionResult) string {
 	if r.MaxClients == 0 {
@@ -1074,15 +1549,28 @@ func formatRedisConnectionUsage(r *model.RedisInspectionResult) string {
 	return fmt.Sprintf("%.1f%%", usage)
 }
 
+// formatRedisMemoryUsage formats used_memory / maxmemory as percentage string.
+func formatRedisMemoryUsage(r *model.RedisInspectionResult) string {
+	if r.MaxMemory == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", r.GetMemoryUsagePercent())
+}
+
+// formatRedisKeyspaceHitRatio formats keyspace hit ratio as percentage string.
+func formatRedisKeyspaceHitRatio(r *model.RedisInspectionResult) string {
+	if !r.HasKeyspaceHitRatio() {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", r.KeyspaceHitRatio)
+}
+
 // getRedisLinkStatus returns master link status text (only for slave nodes).
 func getRedisLinkStatus(r *model.RedisInspectionResult) string {
 	if r.Instance.Role != model.RedisRoleSlave {
 		return "N/A"
 	}
-	if r.MasterLinkStatus {
-		return "正常"
-	}
-	return "异常"
+	return model.FormatBool("redis_master_link_status", r.MasterLinkStatus)
 }
 
 // getRedisReplicationLag returns replication lag text (only for slave nodes).
@@ -1110,15 +1598,14 @@ func getRedisMasterPort(r *model.RedisInspectionResult) string {
 // formatRedisThreshold formats a Redis alert threshold value based on metric type.
 func formatRedisThreshold(value float64, metricName string) string {
 	switch metricName {
-	case "connection_usage":
+	case "connection_usage", "memory_usage", "keyspace_hit_ratio":
 		return fmt.Sprintf("%.1f%%", value)
+	case "evicted_keys":
+		return fmt.Sprintf("%.2f/s", value)
 	case "replication_lag":
 		return formatSize(int64(value))
 	case "master_link_status":
-		if value > 0 {
-			return "正常"
-		}
-		return "异常"
+		return model.FormatBool("redis_master_link_status", value > 0)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -1148,16 +1635,9 @@ func (w *Writer) WriteRedisInspection(result *model.RedisInspectionResults, outp
 	// Prepare template data
 	data := w.prepareRedisTemplateData(result)
 
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-
-	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute Redis template: %w", err)
+	// Render the report with all resources inlined
+	if err := executeTemplateToFile(tmpl, data, outputPath, w.resourceDir(), w.tr); err != nil {
+		return fmt.Errorf("failed to write Redis report: %w", err)
 	}
 
 	return nil
@@ -1183,16 +1663,9 @@ func (w *Writer) WriteNginxInspection(result *model.NginxInspectionResults, outp
 	// Prepare template data
 	data := w.prepareNginxTemplateData(result)
 
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-
-	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute Nginx template: %w", err)
+	// Render the report with all resources inlined
+	if err := executeTemplateToFile(tmpl, data, outputPath, w.resourceDir(), w.tr); err != nil {
+		return fmt.Errorf("failed to write Nginx report: %w", err)
 	}
 
 	return nil
@@ -1209,7 +1682,7 @@ func (w *Writer) loadRedisTemplate() (*template.Template, error) {
 	}
 
 	// Load embedded Redis template
-	tmpl, err := template.New("redis.html").Funcs(funcMap).ParseFS(embeddedTemplates, "templates/redis.html")
+	tmpl, err := template.New("redis.html").Funcs(w.withThemeFuncs(funcMap)).ParseFS(embeddedTemplates, "templates/redis.html")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse embedded Redis template: %w", err)
 	}
@@ -1229,11 +1702,12 @@ func (w *Writer) prepareRedisTemplateData(result *model.RedisInspectionResults)
 
 	return &RedisTemplateData{
 		Title:          "Redis 巡检报告",
-		InspectionTime: result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"),
+		InspectionTime: w.formatInspectionTime(result.InspectionTime),
 		Duration:       formatDuration(result.Duration),
 		Summary:        result.Summary,
 		AlertSummary:   result.AlertSummary,
 		Instances:      instances,
+		Topology:       newRedisTopology(result.Results),
 		Alerts:         alerts,
 		Version:        result.Version,
 		GeneratedAt:    time.Now().In(w.timezone).Format("2006-01-02 15:04:05"),
@@ -1248,23 +1722,28 @@ func (w *Writer) convertRedisInstanceData(r *model.RedisInspectionResult) *Redis
 	}
 
 	return &RedisInstanceData{
-		Address:          r.GetAddress(),
-		IP:               r.Instance.IP,
-		Port:             r.Instance.Port,
-		Version:          version,
-		Role:             redisRoleText(r.Instance.Role),
-		ClusterEnabled:   boolToText(r.ClusterEnabled),
-		ConnectionStatus: redisConnectionStatusText(r),
-		MaxClients:       r.MaxClients,
-		ConnectedClients: r.ConnectedClients,
-		ConnectionUsage:  formatRedisConnectionUsage(r),
-		ConnectedSlaves:  r.ConnectedSlaves,
-		MasterLinkStatus: getRedisLinkStatus(r),
-		MasterPort:       getRedisMasterPort(r),
-		ReplicationLag:   getRedisReplicationLag(r),
-		Status:           redisStatusText(r.Status),
-		StatusClass:      redisStatusClass(r.Status),
-		AlertCount:       len(r.Alerts),
+		Address:            r.GetAddress(),
+		IP:                 r.Instance.IP,
+		Port:               r.Instance.Port,
+		Version:            version,
+		Role:               redisRoleText(r.Instance.Role),
+		ClusterEnabled:     model.FormatBool("redis_cluster_enabled", r.ClusterEnabled),
+		ConnectionStatus:   model.FormatBool("redis_up", r.ConnectionStatus),
+		MaxClients:         r.MaxClients,
+		ConnectedClients:   r.ConnectedClients,
+		ConnectionUsage:    formatRedisConnectionUsage(r),
+		ConnectedSlaves:    r.ConnectedSlaves,
+		MasterLinkStatus:   getRedisLinkStatus(r),
+		MasterPort:         getRedisMasterPort(r),
+		ReplicationLag:     getRedisReplicationLag(r),
+		MemoryUsage:        formatRedisMemoryUsage(r),
+		FragmentationRatio: fmt.Sprintf("%.2f", r.MemFragmentationRatio),
+		EvictedKeys:        fmt.Sprintf("%.2f", r.EvictedKeysPerSecond),
+		ExpiredKeys:        fmt.Sprintf("%.2f", r.ExpiredKeysPerSecond),
+		KeyspaceHitRatio:   formatRedisKeyspaceHitRatio(r),
+		Status:             redisStatusText(r.Status),
+		StatusClass:        redisStatusClass(r.Status),
+		AlertCount:         len(r.Alerts),
 	}
 }
 
@@ -1285,13 +1764,18 @@ func (w *Writer) convertRedisAlerts(alerts []*model.RedisAlert) []*RedisAlertDat
 	// Convert to RedisAlertData
 	result := make([]*RedisAlertData, 0, len(sortedAlerts))
 	for _, alert := range sortedAlerts {
+		warningText := formatRedisThreshold(alert.WarningThreshold, alert.MetricName)
+		criticalText := formatRedisThreshold(alert.CriticalThreshold, alert.MetricName)
+		if alert.ExpectedValue != "" {
+			warningText, criticalText = expectedThresholdTexts(alert.ExpectedValue, alert.Level)
+		}
 		result = append(result, &RedisAlertData{
 			Address:           alert.Address,
 			MetricName:        alert.MetricName,
 			MetricDisplayName: alert.MetricDisplayName,
 			CurrentValue:      alert.FormattedValue,
-			WarningThreshold:  formatRedisThreshold(alert.WarningThreshold, alert.MetricName),
-			CriticalThreshold: formatRedisThreshold(alert.CriticalThreshold, alert.MetricName),
+			WarningThreshold:  warningText,
+			CriticalThreshold: criticalText,
 			Level:             alertLevelText(alert.Level),
 			LevelClass:        alertLevelClass(alert.Level),
 			Message:           alert.Message,
@@ -1321,6 +1805,7 @@ func (w *Writer) convertRedisClusterData(cluster *model.RedisCluster) *RedisClus
 		Summary:      cluster.Summary,
 		AlertSummary: cluster.AlertSummary,
 		Instances:    instances,
+		Topology:     newRedisTopology(cluster.Instances),
 		Alerts:       alerts,
 	}
 }
@@ -1354,6 +1839,18 @@ type NginxInstanceData struct {
 	AlertCount             int
 }
 
+// NginxUpstreamData represents a per-upstream health summary formatted for template.
+type NginxUpstreamData struct {
+	Identifier        string
+	UpstreamName      string
+	TotalBackends     int
+	HealthyBackends   int
+	UnhealthyBackends int
+	Error5xxRate      string // 格式化百分比，未采集为 "N/A"
+	Status            string // "正常" / "部分异常" / "全部异常" / "N/A"
+	StatusClass       string // CSS class
+}
+
 // NginxAlertData represents Nginx alert data formatted for template.
 type NginxAlertData struct {
 	Identifier        string
@@ -1365,6 +1862,7 @@ type NginxAlertData struct {
 	Level             string
 	LevelClass        string
 	Message           string
+	LogExcerpt        []string
 }
 
 // ============================================================================
@@ -1403,30 +1901,6 @@ func nginxStatusClass(status model.NginxInstanceStatus) string {
 	}
 }
 
-// nginxBoolToText converts nginx boolean metric to Chinese text.
-func nginxBoolToText(value bool) string {
-	if value {
-		return "是"
-	}
-	return "否"
-}
-
-// nginxConfiguredText returns configuration status text.
-func nginxConfiguredText(configured bool) string {
-	if configured {
-		return "已配置"
-	}
-	return "未配置"
-}
-
-// nginxUpText returns Nginx running status text.
-func nginxUpText(up bool) string {
-	if up {
-		return "运行"
-	}
-	return "停止"
-}
-
 // formatNginxConnectionUsage formats connection usage percentage.
 func formatNginxConnectionUsage(usagePercent float64) string {
 	if usagePercent < 0 {
@@ -1447,6 +1921,28 @@ func formatNginxThreshold(value float64, metricName string) string {
 	}
 }
 
+// formatUpstream5xxRate formats the 5xx rate of an upstream, "N/A" if not collected.
+func formatUpstream5xxRate(summary model.NginxUpstreamSummary) string {
+	if !summary.Has5xxRate {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f%%", summary.Error5xxRate)
+}
+
+// upstreamStatusTextAndClass returns the health text and CSS class of an upstream group.
+func upstreamStatusTextAndClass(summary model.NginxUpstreamSummary) (string, string) {
+	switch {
+	case summary.TotalBackends == 0:
+		return "N/A", "normal"
+	case summary.AllDown():
+		return "全部异常", "critical"
+	case summary.UnhealthyBackends > 0:
+		return "部分异常", "warning"
+	default:
+		return "正常", "normal"
+	}
+}
+
 // ============================================================================
 // Nginx Report Methods
 // ============================================================================
@@ -1470,21 +1966,45 @@ func (w *Writer) convertNginxInstanceData(r *model.NginxInspectionResult) *Nginx
 		Version:                r.Instance.Version,
 		InstallPath:            r.Instance.InstallPath,
 		ErrorLogPath:           r.Instance.ErrorLogPath,
-		Up:                     nginxUpText(r.Up),
+		Up:                     model.FormatBool("nginx_up", r.Up),
 		ActiveConnections:      r.ActiveConnections,
 		WorkerProcesses:        r.WorkerProcesses,
 		WorkerConnections:      r.WorkerConnections,
 		ConnectionUsagePercent: formatNginxConnectionUsage(r.ConnectionUsagePercent),
-		ErrorPage4xx:           nginxConfiguredText(r.ErrorPage4xxConfigured),
-		ErrorPage5xx:           nginxConfiguredText(r.ErrorPage5xxConfigured),
+		ErrorPage4xx:           model.FormatBool("nginx_error_page_4xx", r.ErrorPage4xxConfigured),
+		ErrorPage5xx:           model.FormatBool("nginx_error_page_5xx", r.ErrorPage5xxConfigured),
 		LastErrorTime:          r.FormatLastErrorTime(w.timezone),
-		NonRootUser:            nginxBoolToText(r.NonRootUser),
+		NonRootUser:            model.FormatBool("nginx_non_root_user", r.NonRootUser),
 		Status:                 nginxStatusText(r.Status),
 		StatusClass:            nginxStatusClass(r.Status),
 		AlertCount:             len(r.Alerts),
 	}
 }
 
+// convertNginxUpstreams flattens per-instance upstream summaries for template rendering.
+func (w *Writer) convertNginxUpstreams(results []*model.NginxInspectionResult) []*NginxUpstreamData {
+	var upstreams []*NginxUpstreamData
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		for _, summary := range r.GetUpstreamSummaries() {
+			status, class := upstreamStatusTextAndClass(summary)
+			upstreams = append(upstreams, &NginxUpstreamData{
+				Identifier:        r.GetIdentifier(),
+				UpstreamName:      summary.UpstreamName,
+				TotalBackends:     summary.TotalBackends,
+				HealthyBackends:   summary.HealthyBackends,
+				UnhealthyBackends: summary.UnhealthyBackends,
+				Error5xxRate:      formatUpstream5xxRate(summary),
+				Status:            status,
+				StatusClass:       class,
+			})
+		}
+	}
+	return upstreams
+}
+
 // convertNginxAlerts converts and sorts Nginx alerts for template rendering.
 func (w *Writer) convertNginxAlerts(alerts []*model.NginxAlert) []*NginxAlertData {
 	// Make a copy for sorting
@@ -1512,6 +2032,7 @@ func (w *Writer) convertNginxAlerts(alerts []*model.NginxAlert) []*NginxAlertDat
 			Level:             alertLevelText(alert.Level),
 			LevelClass:        alertLevelClass(alert.Level),
 			Message:           alert.Message,
+			LogExcerpt:        alert.LogExcerpt,
 		})
 	}
 	return result
@@ -1526,15 +2047,13 @@ func (w *Writer) loadNginxTemplate() (*template.Template, error) {
 		"statusClass":              statusClass,
 		"alertClass":               alertLevelClass,
 		"nginxStatusText":          nginxStatusText,
-		"nginxBoolToText":          nginxBoolToText,
-		"nginxConfiguredText":      nginxConfiguredText,
-		"nginxUpText":              nginxUpText,
+		"formatBool":               model.FormatBool,
 		"formatNginxConnectionUsage": formatNginxConnectionUsage,
 		"formatNginxThreshold":     formatNginxThreshold,
 	}
 
 	// Load embedded Nginx template
-	tmpl, err := template.New("nginx.html").Funcs(funcMap).ParseFS(embeddedTemplates, "templates/nginx.html")
+	tmpl, err := template.New("nginx.html").Funcs(w.withThemeFuncs(funcMap)).ParseFS(embeddedTemplates, "templates/nginx.html")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse embedded nginx template: %w", err)
 	}
@@ -1545,7 +2064,7 @@ func (w *Writer) loadNginxTemplate() (*template.Template, error) {
 func (w *Writer) prepareNginxTemplateData(result *model.NginxInspectionResults) *NginxTemplateData {
 	data := &NginxTemplateData{
 		Title:          "Nginx 巡检报告",
-		InspectionTime: result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"),
+		InspectionTime: w.formatInspectionTime(result.InspectionTime),
 		Duration:       formatDuration(result.Duration),
 		Summary:        result.Summary,
 		AlertSummary:   result.AlertSummary,
@@ -1560,6 +2079,9 @@ func (w *Writer) prepareNginxTemplateData(result *model.NginxInspectionResults)
 	}
 	data.Instances = instances
 
+	// Convert upstream summaries
+	data.Upstreams = w.convertNginxUpstreams(result.Results)
+
 	// Convert alerts
 	data.Alerts = w.convertNginxAlerts(result.Alerts)
 
@@ -1598,6 +2120,9 @@ type TomcatInstanceData struct {
 	Connections          int
 	UptimeFormatted      string
 	NonRootUser          string
+	HeapUsage            string // 堆内存使用率，未采集为 "N/A"
+	GCPause              string // 平均 GC 暂停时间，未采集为 "N/A"
+	ThreadPoolUsage      string // 线程池饱和度，未采集为 "N/A"
 	LastErrorTimeFormatted string
 	Status               string
 	StatusClass          string
@@ -1615,6 +2140,7 @@ type TomcatAlertData struct {
 	Level             string
 	LevelClass        string
 	Message           string
+	LogExcerpt        []string
 }
 
 // =============================================================================
@@ -1653,14 +2179,6 @@ func tomcatStatusClass(status model.TomcatInstanceStatus) string {
 	}
 }
 
-// tomcatBoolToText converts boolean to Chinese text (是/否).
-func tomcatBoolToText(b bool) string {
-	if b {
-		return "是"
-	}
-	return "否"
-}
-
 // getTomcatPortOrContainer returns container name if container deployment,
 // otherwise returns port number as string.
 func getTomcatPortOrContainer(r *model.TomcatInspectionResult) string {
@@ -1678,11 +2196,31 @@ func formatTomcatThreshold(value float64, metricName string) string {
 	switch metricName {
 	case "last_error_timestamp":
 		return fmt.Sprintf("%.0f分钟", value)
+	case "tomcat_jvm_heap_usage", "tomcat_thread_pool_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "tomcat_gc_pause_avg_ms":
+		return fmt.Sprintf("%.0f ms", value)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
 }
 
+// formatTomcatJVMPercent formats a Tomcat JVM percentage metric, "N/A" if not collected.
+func formatTomcatJVMPercent(value float64, collected bool) string {
+	if !collected {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", value)
+}
+
+// formatTomcatGCPause formats the average GC pause time, "N/A" if not collected.
+func formatTomcatGCPause(r *model.TomcatInspectionResult) string {
+	if !r.HasGCPause() {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.0f ms", r.GCPauseAvgMs)
+}
+
 // loadTomcatTemplate loads the embedded Tomcat HTML template.
 func (w *Writer) loadTomcatTemplate() (*template.Template, error) {
 	funcMap := template.FuncMap{
@@ -1692,7 +2230,7 @@ func (w *Writer) loadTomcatTemplate() (*template.Template, error) {
 		"alertClass":     func(l model.AlertLevel) string { return alertLevelClass(l) },
 	}
 
-	tmpl, err := template.New("tomcat.html").Funcs(funcMap).ParseFS(embeddedTemplates, "templates/tomcat.html")
+	tmpl, err := template.New("tomcat.html").Funcs(w.withThemeFuncs(funcMap)).ParseFS(embeddedTemplates, "templates/tomcat.html")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse embedded tomcat template: %w", err)
 	}
@@ -1714,7 +2252,10 @@ func (w *Writer) convertTomcatInstanceData(r *model.TomcatInspectionResult) *Tom
 		JVMConfig:             r.Instance.JVMConfig,
 		Connections:           r.Connections,
 		UptimeFormatted:       r.UptimeFormatted,
-		NonRootUser:           tomcatBoolToText(r.NonRootUser),
+		NonRootUser:           model.FormatBool("tomcat_non_root_user", r.NonRootUser),
+		HeapUsage:             formatTomcatJVMPercent(r.HeapUsagePercent, r.HasHeapUsage()),
+		GCPause:               formatTomcatGCPause(r),
+		ThreadPoolUsage:       formatTomcatJVMPercent(r.ThreadPoolUsagePercent, r.HasThreadPoolUsage()),
 		LastErrorTimeFormatted: r.LastErrorTimeFormatted,
 		Status:                tomcatStatusText(r.Status),
 		StatusClass:           tomcatStatusClass(r.Status),
@@ -1746,6 +2287,7 @@ func (w *Writer) convertTomcatAlerts(alerts []*model.TomcatAlert) []*TomcatAlert
 			Level:             alertLevelText(alert.Level),
 			LevelClass:        alertLevelClass(alert.Level),
 			Message:           alert.Message,
+			LogExcerpt:        alert.LogExcerpt,
 		})
 	}
 	return result
@@ -1762,7 +2304,7 @@ func (w *Writer) prepareTomcatTemplateData(result *model.TomcatInspectionResults
 
 	return &TomcatTemplateData{
 		Title:          "Tomcat 巡检报告",
-		InspectionTime: result.InspectionTime.In(w.timezone).Format("2006-01-02 15:04:05"),
+		InspectionTime: w.formatInspectionTime(result.InspectionTime),
 		Duration:       formatDuration(result.Duration),
 		Summary:        result.Summary,
 		AlertSummary:   result.AlertSummary,
@@ -1790,15 +2332,1757 @@ func (w *Writer) WriteTomcatInspection(result *model.TomcatInspectionResults, ou
 
 	data := w.prepareTomcatTemplateData(result)
 
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+	if err := executeTemplateToFile(tmpl, data, outputPath, w.resourceDir(), w.tr); err != nil {
+		return fmt.Errorf("failed to write Tomcat report: %w", err)
+	}
+
+	return nil
+}
+
+// =============================================================================
+// Cassandra Report Data Structures
+// ============================================================================
+
+// CassandraInstanceData represents Cassandra node data formatted for template.
+type CassandraInstanceData struct {
+	Identifier         string
+	Hostname           string
+	IP                 string
+	Port               int
+	ClusterName        string
+	Datacenter         string
+	Rack               string
+	Version            string
+	NodeState          string // UN / DN
+	DownEndpoints      string // 未采集为 "N/A"
+	PendingCompactions string // 未采集为 "N/A"
+	DroppedMutations   string // 未采集为 "N/A"
+	HeapUsage          string // 未采集为 "N/A"
+	HintsBacklog       string // 未采集为 "N/A"
+	Status             string
+	StatusClass        string
+	AlertCount         int
+}
+
+// CassandraAlertData represents Cassandra alert data formatted for template.
+type CassandraAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// Cassandra Report Helper Functions
+// ============================================================================
+
+// cassandraStatusText converts Cassandra node status to Chinese text.
+func cassandraStatusText(status model.CassandraInstanceStatus) string {
+	switch status {
+	case model.CassandraStatusNormal:
+		return "正常"
+	case model.CassandraStatusWarning:
+		return "警告"
+	case model.CassandraStatusCritical:
+		return "严重"
+	case model.CassandraStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// cassandraStatusClass returns the CSS class for Cassandra node status.
+func cassandraStatusClass(status model.CassandraInstanceStatus) string {
+	switch status {
+	case model.CassandraStatusNormal:
+		return "status-normal"
+	case model.CassandraStatusWarning:
+		return "status-warning"
+	case model.CassandraStatusCritical:
+		return "status-critical"
+	case model.CassandraStatusFailed:
+		return "status-failed"
+	default:
+		return ""
 	}
-	defer file.Close()
+}
 
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+// formatCassandraThreshold formats a Cassandra alert threshold value.
+func formatCassandraThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "cassandra_up":
+		return model.CassandraNodeStateUp
+	case "cassandra_down_endpoints":
+		// Peer DN is only a warning, there is no critical threshold
+		if value == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.0f", value)
+	case "cassandra_heap_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "cassandra_pending_compactions", "cassandra_dropped_mutations", "cassandra_hints_backlog":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
 	}
+}
 
-	return nil
+// formatCassandraCount formats a Cassandra count metric, "N/A" if not collected.
+func formatCassandraCount(value float64, collected bool) string {
+	if !collected {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// convertCassandraInstanceData converts CassandraInspectionResult to CassandraInstanceData.
+func (w *Writer) convertCassandraInstanceData(r *model.CassandraInspectionResult) *CassandraInstanceData {
+	heapUsage := "N/A"
+	if r.HasHeapUsage() {
+		heapUsage = fmt.Sprintf("%.1f%%", r.HeapUsagePercent)
+	}
+
+	return &CassandraInstanceData{
+		Identifier:         r.Instance.Identifier,
+		Hostname:           r.Instance.Hostname,
+		IP:                 r.Instance.IP,
+		Port:               r.Instance.Port,
+		ClusterName:        r.Instance.ClusterName,
+		Datacenter:         r.Instance.Datacenter,
+		Rack:               r.Instance.Rack,
+		Version:            r.Instance.Version,
+		NodeState:          r.NodeState(),
+		DownEndpoints:      formatCassandraCount(float64(r.DownEndpoints), r.HasDownEndpoints()),
+		PendingCompactions: formatCassandraCount(r.PendingCompactions, r.HasPendingCompactions()),
+		DroppedMutations:   formatCassandraCount(r.DroppedMutations, r.HasDroppedMutations()),
+		HeapUsage:          heapUsage,
+		HintsBacklog:       formatCassandraCount(r.HintsBacklog, r.HasHintsBacklog()),
+		Status:             cassandraStatusText(r.Status),
+		StatusClass:        cassandraStatusClass(r.Status),
+		AlertCount:         len(r.Alerts),
+	}
+}
+
+// convertCassandraAlerts converts CassandraAlert slice to CassandraAlertData slice.
+func (w *Writer) convertCassandraAlerts(alerts []*model.CassandraAlert) []*CassandraAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.CassandraAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*CassandraAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &CassandraAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatCassandraThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatCassandraThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteCassandraInspection generates an HTML report for Cassandra inspection results.
+// Cassandra has no dedicated template; the combined template renders the Cassandra section only.
+func (w *Writer) WriteCassandraInspection(result *model.CassandraInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("cassandra inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{Cassandra: result}, outputPath)
+}
+
+// =============================================================================
+// Monitoring Stack Report Data Structures
+// ============================================================================
+
+// MonitoringInstanceData represents monitoring component data formatted for template.
+type MonitoringInstanceData struct {
+	Identifier    string
+	Component     string
+	Hostname      string
+	Port          int
+	Version       string
+	TargetState   string // UP / DOWN
+	IngestionRate string // 未采集为 "N/A"
+	SlowQueries   string // 未采集为 "N/A"
+	MergeBacklog  string // 未采集为 "N/A"
+	DiskUsage     string // 未采集为 "N/A"
+	Status        string
+	StatusClass   string
+	AlertCount    int
+}
+
+// MonitoringAlertData represents monitoring component alert data formatted for template.
+type MonitoringAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// Monitoring Stack Report Helper Functions
+// ============================================================================
+
+// monitoringStatusText converts monitoring component status to Chinese text.
+func monitoringStatusText(status model.MonitoringInstanceStatus) string {
+	switch status {
+	case model.MonitoringStatusNormal:
+		return "正常"
+	case model.MonitoringStatusWarning:
+		return "警告"
+	case model.MonitoringStatusCritical:
+		return "严重"
+	case model.MonitoringStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// monitoringStatusClass returns the CSS class for monitoring component status.
+func monitoringStatusClass(status model.MonitoringInstanceStatus) string {
+	switch status {
+	case model.MonitoringStatusNormal:
+		return "status-normal"
+	case model.MonitoringStatusWarning:
+		return "status-warning"
+	case model.MonitoringStatusCritical:
+		return "status-critical"
+	case model.MonitoringStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatMonitoringThreshold formats a monitoring stack alert threshold value.
+func formatMonitoringThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "monitoring_up":
+		return model.MonitoringTargetStateUp
+	case "monitoring_ingestion_rate":
+		// Zero ingestion has no numeric threshold
+		return "-"
+	case "monitoring_disk_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "monitoring_slow_queries", "monitoring_merge_backlog":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// convertMonitoringInstanceData converts MonitoringInspectionResult to MonitoringInstanceData.
+func (w *Writer) convertMonitoringInstanceData(r *model.MonitoringInspectionResult) *MonitoringInstanceData {
+	diskUsage := "N/A"
+	if r.HasDiskUsage() {
+		diskUsage = fmt.Sprintf("%.1f%%", r.DiskUsagePercent)
+	}
+
+	return &MonitoringInstanceData{
+		Identifier:    r.Instance.Identifier,
+		Component:     r.Instance.Component,
+		Hostname:      r.Instance.Hostname,
+		Port:          r.Instance.Port,
+		Version:       r.Instance.Version,
+		TargetState:   r.TargetState(),
+		IngestionRate: formatCassandraCount(r.IngestionRate, r.HasIngestionRate()),
+		SlowQueries:   formatCassandraCount(r.SlowQueries, r.HasSlowQueries()),
+		MergeBacklog:  formatCassandraCount(r.MergeBacklog, r.HasMergeBacklog()),
+		DiskUsage:     diskUsage,
+		Status:        monitoringStatusText(r.Status),
+		StatusClass:   monitoringStatusClass(r.Status),
+		AlertCount:    len(r.Alerts),
+	}
+}
+
+// convertMonitoringAlerts converts MonitoringAlert slice to MonitoringAlertData slice.
+func (w *Writer) convertMonitoringAlerts(alerts []*model.MonitoringAlert) []*MonitoringAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.MonitoringAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*MonitoringAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &MonitoringAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatMonitoringThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatMonitoringThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteMonitoringInspection generates an HTML report for monitoring stack inspection results.
+// The monitoring stack has no dedicated template; the combined template renders its section only.
+func (w *Writer) WriteMonitoringInspection(result *model.MonitoringInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("monitoring inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{Monitoring: result}, outputPath)
+}
+
+// =============================================================================
+// Shared Storage Report Data Structures
+// ============================================================================
+
+// StorageInstanceData represents shared storage host data formatted for template.
+type StorageInstanceData struct {
+	Hostname                 string
+	IP                       string
+	Roles                    string
+	MountCount               int
+	StaleMounts              int
+	StaleMountPoints         string // 无失效挂载为 "-"
+	GlusterPeersDisconnected string // 未采集为 "N/A"
+	GlusterBricksOffline     string // 未采集为 "N/A"
+	NFSRPCErrors             string // 未采集为 "N/A"
+	Status                   string
+	StatusClass              string
+	AlertCount               int
+}
+
+// StorageAlertData represents shared storage alert data formatted for template.
+type StorageAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// Shared Storage Report Helper Functions
+// ============================================================================
+
+// storageStatusText converts shared storage host status to Chinese text.
+func storageStatusText(status model.StorageInstanceStatus) string {
+	switch status {
+	case model.StorageStatusNormal:
+		return "正常"
+	case model.StorageStatusWarning:
+		return "警告"
+	case model.StorageStatusCritical:
+		return "严重"
+	case model.StorageStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// storageStatusClass returns the CSS class for shared storage host status.
+func storageStatusClass(status model.StorageInstanceStatus) string {
+	switch status {
+	case model.StorageStatusNormal:
+		return "status-normal"
+	case model.StorageStatusWarning:
+		return "status-warning"
+	case model.StorageStatusCritical:
+		return "status-critical"
+	case model.StorageStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatStorageThreshold formats a shared storage alert threshold value.
+func formatStorageThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "storage_mount_status":
+		return "失效"
+	case "storage_gluster_peers_disconnected", "storage_gluster_bricks_offline", "storage_nfs_rpc_errors":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// convertStorageInstanceData converts StorageInspectionResult to StorageInstanceData.
+func (w *Writer) convertStorageInstanceData(r *model.StorageInspectionResult) *StorageInstanceData {
+	staleMountPoints := "-"
+	if points := r.StaleMountPoints(); len(points) > 0 {
+		staleMountPoints = strings.Join(points, ", ")
+	}
+
+	return &StorageInstanceData{
+		Hostname:                 r.Instance.Hostname,
+		IP:                       r.Instance.IP,
+		Roles:                    r.Instance.RoleText(),
+		MountCount:               len(r.Mounts),
+		StaleMounts:              r.StaleMounts,
+		StaleMountPoints:         staleMountPoints,
+		GlusterPeersDisconnected: formatCassandraCount(r.GlusterPeersDisconnected, r.HasGlusterPeers()),
+		GlusterBricksOffline:     formatCassandraCount(r.GlusterBricksOffline, r.HasGlusterBricks()),
+		NFSRPCErrors:             formatCassandraCount(r.NFSRPCErrors, r.HasNFSRPCErrors()),
+		Status:                   storageStatusText(r.Status),
+		StatusClass:              storageStatusClass(r.Status),
+		AlertCount:               len(r.Alerts),
+	}
+}
+
+// convertStorageAlerts converts StorageAlert slice to StorageAlertData slice.
+func (w *Writer) convertStorageAlerts(alerts []*model.StorageAlert) []*StorageAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.StorageAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*StorageAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &StorageAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatStorageThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatStorageThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteStorageInspection generates an HTML report for shared storage inspection results.
+// Shared storage has no dedicated template; the combined template renders its section only.
+func (w *Writer) WriteStorageInspection(result *model.StorageInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("storage inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{Storage: result}, outputPath)
+}
+
+// =============================================================================
+// Log Checks Report Data Structures
+// ============================================================================
+
+// LogCheckInstanceData represents one log check (or check group) formatted for template.
+type LogCheckInstanceData struct {
+	CheckName   string
+	DisplayName string
+	Group       string // 未分组为 "-"
+	Count       string // 查询失败为 "N/A"
+	Warning     string // 未设置为 "-"
+	Critical    string // 未设置为 "-"
+	Error       string
+	Status      string
+	StatusClass string
+	AlertCount  int
+}
+
+// LogCheckAlertData represents log check alert data formatted for template.
+type LogCheckAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// Log Checks Report Helper Functions
+// ============================================================================
+
+// logCheckStatusText converts log check status to Chinese text.
+func logCheckStatusText(status model.LogCheckStatus) string {
+	switch status {
+	case model.LogCheckStatusNormal:
+		return "正常"
+	case model.LogCheckStatusWarning:
+		return "警告"
+	case model.LogCheckStatusCritical:
+		return "严重"
+	case model.LogCheckStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// logCheckStatusClass returns the CSS class for log check status.
+func logCheckStatusClass(status model.LogCheckStatus) string {
+	switch status {
+	case model.LogCheckStatusNormal:
+		return "status-normal"
+	case model.LogCheckStatusWarning:
+		return "status-warning"
+	case model.LogCheckStatusCritical:
+		return "status-critical"
+	case model.LogCheckStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatLogCheckThreshold formats a log check threshold; 0 means the level is not checked.
+func formatLogCheckThreshold(value float64) string {
+	if value <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// convertLogCheckInstanceData converts LogCheckInspectionResult to LogCheckInstanceData.
+func (w *Writer) convertLogCheckInstanceData(r *model.LogCheckInspectionResult) *LogCheckInstanceData {
+	group := r.Instance.Group
+	if group == "" {
+		group = "-"
+	}
+
+	return &LogCheckInstanceData{
+		CheckName:   r.Instance.CheckName,
+		DisplayName: r.Instance.DisplayName,
+		Group:       group,
+		Count:       formatCassandraCount(r.Count, r.HasCount()),
+		Warning:     formatLogCheckThreshold(r.Instance.Warning),
+		Critical:    formatLogCheckThreshold(r.Instance.Critical),
+		Error:       r.Error,
+		Status:      logCheckStatusText(r.Status),
+		StatusClass: logCheckStatusClass(r.Status),
+		AlertCount:  len(r.Alerts),
+	}
+}
+
+// convertLogCheckAlerts converts LogCheckAlert slice to LogCheckAlertData slice.
+func (w *Writer) convertLogCheckAlerts(alerts []*model.LogCheckAlert) []*LogCheckAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.LogCheckAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*LogCheckAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &LogCheckAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatLogCheckThreshold(alert.WarningThreshold),
+			CriticalThreshold: formatLogCheckThreshold(alert.CriticalThreshold),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteLogCheckInspection generates an HTML report for log checks inspection results.
+// Log checks have no dedicated template; the combined template renders their section only.
+func (w *Writer) WriteLogCheckInspection(result *model.LogCheckInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("log checks inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{LogChecks: result}, outputPath)
+}
+
+// =============================================================================
+// LVS Report Data Structures
+// ============================================================================
+
+// LVSInstanceData represents an LVS director formatted for template.
+type LVSInstanceData struct {
+	Hostname               string
+	IP                     string
+	VirtualServiceCount    int
+	RealServerCount        int
+	ZeroActiveServices     int
+	ZeroActiveServiceNames string // 无则为 "-"
+	ActiveConnections      string // 未采集为 "N/A"
+	SyncDaemon             string // 例如 "运行 (master)"，未采集为 "N/A"
+	Status                 string
+	StatusClass            string
+	AlertCount             int
+}
+
+// LVSRealServerData represents one real server of an LVS virtual service formatted for template.
+type LVSRealServerData struct {
+	Director            string
+	VirtualService      string
+	Address             string
+	Weight              string
+	ActiveConnections   string
+	InactiveConnections string
+	Status              string
+	StatusClass         string
+}
+
+// LVSAlertData represents LVS alert data formatted for template.
+type LVSAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// LVS Report Helper Functions
+// ============================================================================
+
+// lvsStatusText converts LVS director status to Chinese text.
+func lvsStatusText(status model.LVSInstanceStatus) string {
+	switch status {
+	case model.LVSStatusNormal:
+		return "正常"
+	case model.LVSStatusWarning:
+		return "警告"
+	case model.LVSStatusCritical:
+		return "严重"
+	case model.LVSStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// lvsStatusClass returns the CSS class for LVS director status.
+func lvsStatusClass(status model.LVSInstanceStatus) string {
+	switch status {
+	case model.LVSStatusNormal:
+		return "status-normal"
+	case model.LVSStatusWarning:
+		return "status-warning"
+	case model.LVSStatusCritical:
+		return "status-critical"
+	case model.LVSStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatLVSThreshold formats an LVS alert threshold value.
+func formatLVSThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "lvs_zero_active_services":
+		return "无可用"
+	case "lvs_sync_daemon_running":
+		return "未运行"
+	case "lvs_active_connections":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// lvsSyncDaemonText formats the connection sync daemon state, e.g. "运行 (master)".
+func lvsSyncDaemonText(r *model.LVSInspectionResult) string {
+	if !r.HasSyncDaemon() {
+		return "N/A"
+	}
+	text := "未运行"
+	if r.SyncDaemonRunning != 0 {
+		text = "运行"
+	}
+	if r.SyncDaemonState != "" {
+		text += " (" + r.SyncDaemonState + ")"
+	}
+	return text
+}
+
+// convertLVSInstanceData converts LVSInspectionResult to LVSInstanceData.
+func (w *Writer) convertLVSInstanceData(r *model.LVSInspectionResult) *LVSInstanceData {
+	zeroActiveNames := "-"
+	if names := r.ZeroActiveServiceNames(); len(names) > 0 {
+		zeroActiveNames = strings.Join(names, ", ")
+	}
+
+	return &LVSInstanceData{
+		Hostname:               r.Instance.Hostname,
+		IP:                     r.Instance.IP,
+		VirtualServiceCount:    len(r.VirtualServices),
+		RealServerCount:        r.RealServerCount(),
+		ZeroActiveServices:     r.ZeroActiveServices,
+		ZeroActiveServiceNames: zeroActiveNames,
+		ActiveConnections:      formatCassandraCount(r.ActiveConnections, r.HasActiveConnections()),
+		SyncDaemon:             lvsSyncDaemonText(r),
+		Status:                 lvsStatusText(r.Status),
+		StatusClass:            lvsStatusClass(r.Status),
+		AlertCount:             len(r.Alerts),
+	}
+}
+
+// convertLVSRealServers flattens the real servers of an LVS director's virtual services.
+func (w *Writer) convertLVSRealServers(r *model.LVSInspectionResult) []*LVSRealServerData {
+	result := make([]*LVSRealServerData, 0, r.RealServerCount())
+	for _, vs := range r.VirtualServices {
+		noActive := vs.ActiveRealServers() == 0
+		for _, rs := range vs.RealServers {
+			status, statusClass := "正常", "status-normal"
+			switch {
+			case noActive:
+				status, statusClass = "已摘除（服务无可用）", "status-critical"
+			case !rs.IsActive():
+				status, statusClass = "已摘除", "status-warning"
+			}
+			result = append(result, &LVSRealServerData{
+				Director:            r.GetIdentifier(),
+				VirtualService:      vs.String(),
+				Address:             rs.Address,
+				Weight:              fmt.Sprintf("%.0f", rs.Weight),
+				ActiveConnections:   fmt.Sprintf("%.0f", rs.ActiveConnections),
+				InactiveConnections: fmt.Sprintf("%.0f", rs.InactiveConnections),
+				Status:              status,
+				StatusClass:         statusClass,
+			})
+		}
+	}
+	return result
+}
+
+// convertLVSAlerts converts LVSAlert slice to LVSAlertData slice.
+func (w *Writer) convertLVSAlerts(alerts []*model.LVSAlert) []*LVSAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.LVSAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*LVSAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &LVSAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatLVSThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatLVSThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteLVSInspection generates an HTML report for LVS inspection results.
+// LVS has no dedicated template; the combined template renders its section only.
+func (w *Writer) WriteLVSInspection(result *model.LVSInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("LVS inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{LVS: result}, outputPath)
+}
+
+// =============================================================================
+// Windows Report Data Structures
+// ============================================================================
+
+// WindowsInstanceData represents a Windows host formatted for template.
+type WindowsInstanceData struct {
+	Hostname            string
+	IP                  string
+	ServiceCount        int
+	StoppedServices     int
+	StoppedServiceNames string // 无则为 "-"
+	AppPoolCount        string // 未采集到 IIS 为 "N/A"
+	StoppedAppPoolNames string // 无则为 "-"
+	RequestsQueued      string // 未采集为 "N/A"
+	Status              string
+	StatusClass         string
+	AlertCount          int
+}
+
+// WindowsAlertData represents Windows alert data formatted for template.
+type WindowsAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// Windows Report Helper Functions
+// ============================================================================
+
+// windowsStatusText converts Windows host status to Chinese text.
+func windowsStatusText(status model.WindowsInstanceStatus) string {
+	switch status {
+	case model.WindowsStatusNormal:
+		return "正常"
+	case model.WindowsStatusWarning:
+		return "警告"
+	case model.WindowsStatusCritical:
+		return "严重"
+	case model.WindowsStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// windowsStatusClass returns the CSS class for Windows host status.
+func windowsStatusClass(status model.WindowsInstanceStatus) string {
+	switch status {
+	case model.WindowsStatusNormal:
+		return "status-normal"
+	case model.WindowsStatusWarning:
+		return "status-warning"
+	case model.WindowsStatusCritical:
+		return "status-critical"
+	case model.WindowsStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatWindowsThreshold formats a Windows alert threshold value.
+func formatWindowsThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "windows_stopped_services", "windows_stopped_app_pools":
+		return "未运行"
+	case "windows_iis_requests_queued":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// windowsNamesText joins object names for display, "-" if there are none.
+func windowsNamesText(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// convertWindowsInstanceData converts WindowsInspectionResult to WindowsInstanceData.
+func (w *Writer) convertWindowsInstanceData(r *model.WindowsInspectionResult) *WindowsInstanceData {
+	appPools := "N/A"
+	if r.HasIIS() {
+		appPools = fmt.Sprint(len(r.AppPools))
+	}
+
+	return &WindowsInstanceData{
+		Hostname:            r.Instance.Hostname,
+		IP:                  r.Instance.IP,
+		ServiceCount:        len(r.Services),
+		StoppedServices:     r.StoppedServices,
+		StoppedServiceNames: windowsNamesText(r.StoppedServiceNames()),
+		AppPoolCount:        appPools,
+		StoppedAppPoolNames: windowsNamesText(r.StoppedAppPoolNames()),
+		RequestsQueued:      formatCassandraCount(r.RequestsQueued, r.HasRequestsQueued()),
+		Status:              windowsStatusText(r.Status),
+		StatusClass:         windowsStatusClass(r.Status),
+		AlertCount:          len(r.Alerts),
+	}
+}
+
+// convertWindowsAlerts converts WindowsAlert slice to WindowsAlertData slice.
+func (w *Writer) convertWindowsAlerts(alerts []*model.WindowsAlert) []*WindowsAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.WindowsAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*WindowsAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &WindowsAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatWindowsThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatWindowsThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteWindowsInspection generates an HTML report for Windows inspection results.
+// Windows has no dedicated template; the combined template renders its section only.
+func (w *Writer) WriteWindowsInspection(result *model.WindowsInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("Windows inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{Windows: result}, outputPath)
+}
+
+// =============================================================================
+// AD Report Data Structures
+// ============================================================================
+
+// ADInstanceData represents a domain controller formatted for template.
+type ADInstanceData struct {
+	Hostname            string
+	IP                  string
+	ReplicationPending  string // 未采集为 "N/A"
+	ReplicationFailures string // 未采集为 "N/A"
+	LDAPProbe           string // 成功 / 失败 / N/A
+	LDAPBindLatency     string // 毫秒，未采集为 "N/A"
+	SysvolFree          string // 百分比，未采集为 "N/A"
+	Status              string
+	StatusClass         string
+	AlertCount          int
+}
+
+// ADAlertData represents AD alert data formatted for template.
+type ADAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// AD Report Helper Functions
+// ============================================================================
+
+// adStatusText converts domain controller status to Chinese text.
+func adStatusText(status model.ADInstanceStatus) string {
+	switch status {
+	case model.ADStatusNormal:
+		return "正常"
+	case model.ADStatusWarning:
+		return "警告"
+	case model.ADStatusCritical:
+		return "严重"
+	case model.ADStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// adStatusClass returns the CSS class for domain controller status.
+func adStatusClass(status model.ADInstanceStatus) string {
+	switch status {
+	case model.ADStatusNormal:
+		return "status-normal"
+	case model.ADStatusWarning:
+		return "status-warning"
+	case model.ADStatusCritical:
+		return "status-critical"
+	case model.ADStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatADThreshold formats an AD alert threshold value.
+func formatADThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "ad_replication_failures":
+		return "复制失败"
+	case "ad_ldap_probe_success":
+		return "探测失败"
+	case "ad_ldap_bind_latency":
+		return fmt.Sprintf("%.0fms", value*1000)
+	case "ad_sysvol_free_percent":
+		return fmt.Sprintf("%.0f%%", value)
+	case "ad_replication_pending":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// convertADInstanceData converts ADInspectionResult to ADInstanceData.
+func (w *Writer) convertADInstanceData(r *model.ADInspectionResult) *ADInstanceData {
+	ldap := "N/A"
+	if r.HasLDAPProbe() {
+		ldap = "成功"
+		if r.IsLDAPUnreachable() {
+			ldap = "失败"
+		}
+	}
+	latency := "N/A"
+	if r.HasLDAPBindLatency() {
+		latency = fmt.Sprintf("%.0fms", r.LDAPBindLatency*1000)
+	}
+	sysvol := "N/A"
+	if r.HasSysvolFree() {
+		sysvol = fmt.Sprintf("%.1f%%", r.SysvolFreePercent)
+	}
+
+	return &ADInstanceData{
+		Hostname:            r.Instance.Hostname,
+		IP:                  r.Instance.IP,
+		ReplicationPending:  formatCassandraCount(r.ReplicationPending, r.HasReplicationPending()),
+		ReplicationFailures: formatCassandraCount(r.ReplicationFailures, r.HasReplicationFailures()),
+		LDAPProbe:           ldap,
+		LDAPBindLatency:     latency,
+		SysvolFree:          sysvol,
+		Status:              adStatusText(r.Status),
+		StatusClass:         adStatusClass(r.Status),
+		AlertCount:          len(r.Alerts),
+	}
+}
+
+// convertADAlerts converts ADAlert slice to ADAlertData slice.
+func (w *Writer) convertADAlerts(alerts []*model.ADAlert) []*ADAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.ADAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*ADAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &ADAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatADThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatADThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteADInspection generates an HTML report for AD inspection results.
+// AD has no dedicated template; the combined template renders its section only.
+func (w *Writer) WriteADInspection(result *model.ADInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("AD inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{AD: result}, outputPath)
+}
+
+// =============================================================================
+// Cloud Resource Report Data Structures
+// ============================================================================
+
+// CloudInstanceData represents a cloud resource formatted for template.
+type CloudInstanceData struct {
+	ResourceID        string
+	Name              string
+	Provider          string // 阿里云 / AWS
+	ResourceType      string // RDS / Redis / SLB
+	Region            string
+	CPUUsage          string // 百分比，未采集为 "N/A"
+	MemoryUsage       string // 百分比，未采集为 "N/A"
+	ConnectionUsage   string // 百分比，未采集为 "N/A"
+	DiskUsage         string // 百分比，未采集为 "N/A"
+	ActiveConnections string // 未采集为 "N/A"
+	UnhealthyBackends string // 仅 SLB，未采集为 "N/A"
+	Status            string
+	StatusClass       string
+	AlertCount        int
+}
+
+// CloudAlertData represents cloud resource alert data formatted for template.
+type CloudAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// CloudCostData represents the cloud resource cost / asset summary formatted for template.
+type CloudCostData struct {
+	Window           string // 统计窗口（如 "30 天"）
+	IdleCPUThreshold string // 低利用率 CPU 峰值阈值（如 "5%"）
+	Groups           []*CloudAssetGroupData
+	Underutilized    []*CloudUnderutilizedData
+}
+
+// CloudAssetGroupData represents the resource count of one provider, type and spec.
+type CloudAssetGroupData struct {
+	Provider      string
+	ResourceType  string
+	Spec          string // 未上报时为 "未知"
+	Count         int
+	Underutilized int
+}
+
+// CloudUnderutilizedData represents an under-utilized cloud resource.
+type CloudUnderutilizedData struct {
+	ResourceID   string
+	Name         string
+	Provider     string
+	ResourceType string
+	Region       string
+	Spec         string
+	PeakCPUUsage string
+}
+
+// =============================================================================
+// Cloud Resource Report Helper Functions
+// ============================================================================
+
+// cloudStatusText converts cloud resource status to Chinese text.
+func cloudStatusText(status model.CloudInstanceStatus) string {
+	switch status {
+	case model.CloudStatusNormal:
+		return "正常"
+	case model.CloudStatusWarning:
+		return "警告"
+	case model.CloudStatusCritical:
+		return "严重"
+	case model.CloudStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// cloudStatusClass returns the CSS class for cloud resource status.
+func cloudStatusClass(status model.CloudInstanceStatus) string {
+	switch status {
+	case model.CloudStatusNormal:
+		return "status-normal"
+	case model.CloudStatusWarning:
+		return "status-warning"
+	case model.CloudStatusCritical:
+		return "status-critical"
+	case model.CloudStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatCloudThreshold formats a cloud resource alert threshold value.
+func formatCloudThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "unhealthy_backends":
+		return "存在不健康后端"
+	case "cpu_usage", "memory_usage", "connection_usage", "disk_usage":
+		return fmt.Sprintf("%.0f%%", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// formatCloudPercent formats a cloud resource utilization percentage.
+func formatCloudPercent(value float64, collected bool) string {
+	if !collected {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", value)
+}
+
+// convertCloudInstanceData converts CloudInspectionResult to CloudInstanceData.
+func (w *Writer) convertCloudInstanceData(r *model.CloudInspectionResult) *CloudInstanceData {
+	return &CloudInstanceData{
+		ResourceID:        r.Instance.ResourceID,
+		Name:              r.Instance.Name,
+		Provider:          model.CloudProviderText(r.Instance.Provider),
+		ResourceType:      model.CloudResourceTypeText(r.Instance.ResourceType),
+		Region:            r.Instance.Region,
+		CPUUsage:          formatCloudPercent(r.CPUUsage, r.HasCPUUsage()),
+		MemoryUsage:       formatCloudPercent(r.MemoryUsage, r.HasMemoryUsage()),
+		ConnectionUsage:   formatCloudPercent(r.ConnectionUsage, r.HasConnectionUsage()),
+		DiskUsage:         formatCloudPercent(r.DiskUsage, r.HasDiskUsage()),
+		ActiveConnections: formatCassandraCount(r.ActiveConnections, r.HasActiveConnections()),
+		UnhealthyBackends: formatCassandraCount(r.UnhealthyBackends, r.UnhealthyBackends >= 0),
+		Status:            cloudStatusText(r.Status),
+		StatusClass:       cloudStatusClass(r.Status),
+		AlertCount:        len(r.Alerts),
+	}
+}
+
+// convertCloudAlerts converts CloudAlert slice to CloudAlertData slice.
+func (w *Writer) convertCloudAlerts(alerts []*model.CloudAlert) []*CloudAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.CloudAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*CloudAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &CloudAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatCloudThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatCloudThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// formatCostWindow formats the cost summary window in Chinese, e.g. "30 天" or "12 小时".
+func formatCostWindow(window time.Duration) string {
+	if window >= 24*time.Hour && window%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d 天", int64(window/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%.0f 小时", window.Hours())
+}
+
+// convertCloudCostData converts CloudCostSummary to CloudCostData.
+func (w *Writer) convertCloudCostData(cost *model.CloudCostSummary) *CloudCostData {
+	data := &CloudCostData{
+		Window:           formatCostWindow(cost.Window),
+		IdleCPUThreshold: fmt.Sprintf("%.0f%%", cost.IdleCPUThreshold),
+		Groups:           make([]*CloudAssetGroupData, 0, len(cost.Groups)),
+		Underutilized:    make([]*CloudUnderutilizedData, 0, len(cost.Underutilized)),
+	}
+
+	for _, g := range cost.Groups {
+		spec := g.Spec
+		if spec == "" {
+			spec = "未知"
+		}
+		data.Groups = append(data.Groups, &CloudAssetGroupData{
+			Provider:      model.CloudProviderText(g.Provider),
+			ResourceType:  model.CloudResourceTypeText(g.ResourceType),
+			Spec:          spec,
+			Count:         g.Count,
+			Underutilized: g.Underutilized,
+		})
+	}
+
+	for _, r := range cost.Underutilized {
+		data.Underutilized = append(data.Underutilized, &CloudUnderutilizedData{
+			ResourceID:   r.Instance.ResourceID,
+			Name:         r.Instance.Name,
+			Provider:     model.CloudProviderText(r.Instance.Provider),
+			ResourceType: model.CloudResourceTypeText(r.Instance.ResourceType),
+			Region:       r.Instance.Region,
+			Spec:         r.Instance.Spec,
+			PeakCPUUsage: formatCloudPercent(r.PeakCPUUsage, r.HasPeakCPUUsage()),
+		})
+	}
+
+	return data
+}
+
+// WriteCloudInspection generates an HTML report for cloud resource inspection results.
+// Cloud resources have no dedicated template; the combined template renders their section only.
+func (w *Writer) WriteCloudInspection(result *model.CloudInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("cloud inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{Cloud: result}, outputPath)
+}
+
+// =============================================================================
+// Firewall Report Data Structures
+// ============================================================================
+
+// FirewallInstanceData represents a firewall device formatted for template.
+type FirewallInstanceData struct {
+	Address      string
+	Name         string
+	Profile      string // FortiGate / Palo Alto / Cisco ASA
+	CPUUsage     string // 百分比，未采集为 "N/A"
+	MemoryUsage  string // 百分比，未采集为 "N/A"
+	SessionUsage string // 百分比，未采集为 "N/A"
+	Sessions     string // 未采集为 "N/A"
+	Tunnels      string // 断开/总数，无隧道为 "N/A"
+	TunnelsClass string
+	HAStatus     string // 未采集为 "N/A"
+	HAClass      string
+	Status       string
+	StatusClass  string
+	AlertCount   int
+}
+
+// FirewallTunnelData represents a firewall VPN tunnel formatted for template.
+type FirewallTunnelData struct {
+	Address string
+	Name    string // 设备名称
+	Tunnel  string
+	Status  string // 正常 / 断开
+	Up      bool
+}
+
+// FirewallAlertData represents firewall alert data formatted for template.
+type FirewallAlertData struct {
+	Identifier        string
+	MetricName        string
+	MetricDisplayName string
+	CurrentValue      string
+	WarningThreshold  string
+	CriticalThreshold string
+	Level             string
+	LevelClass        string
+	Message           string
+}
+
+// =============================================================================
+// Firewall Report Helper Functions
+// ============================================================================
+
+// firewallStatusText converts firewall device status to Chinese text.
+func firewallStatusText(status model.FirewallDeviceStatus) string {
+	switch status {
+	case model.FirewallStatusNormal:
+		return "正常"
+	case model.FirewallStatusWarning:
+		return "警告"
+	case model.FirewallStatusCritical:
+		return "严重"
+	case model.FirewallStatusFailed:
+		return "失败"
+	default:
+		return "未知"
+	}
+}
+
+// firewallStatusClass returns the CSS class for firewall device status.
+func firewallStatusClass(status model.FirewallDeviceStatus) string {
+	switch status {
+	case model.FirewallStatusNormal:
+		return "status-normal"
+	case model.FirewallStatusWarning:
+		return "status-warning"
+	case model.FirewallStatusCritical:
+		return "status-critical"
+	case model.FirewallStatusFailed:
+		return "status-failed"
+	default:
+		return ""
+	}
+}
+
+// formatFirewallThreshold formats a firewall device alert threshold value.
+func formatFirewallThreshold(value float64, metricName string) string {
+	switch metricName {
+	case "vpn_tunnel_status":
+		return "存在断开隧道"
+	case "ha_status":
+		return "HA 状态异常"
+	case "cpu_usage", "memory_usage", "session_usage":
+		return fmt.Sprintf("%.0f%%", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// convertFirewallInstanceData converts FirewallInspectionResult to FirewallInstanceData.
+func (w *Writer) convertFirewallInstanceData(r *model.FirewallInspectionResult) *FirewallInstanceData {
+	data := &FirewallInstanceData{
+		Address:      r.Device.Address,
+		Name:         r.Device.Name,
+		Profile:      model.FirewallProfileText(r.Device.Profile),
+		CPUUsage:     formatCloudPercent(r.CPUUsage, r.HasCPUUsage()),
+		MemoryUsage:  formatCloudPercent(r.MemoryUsage, r.HasMemoryUsage()),
+		SessionUsage: formatCloudPercent(r.SessionUsage, r.HasSessionUsage()),
+		Sessions:     formatCassandraCount(r.Sessions, r.HasSessions()),
+		Tunnels:      "N/A",
+		HAStatus:     r.HAText(),
+		Status:       firewallStatusText(r.Status),
+		StatusClass:  firewallStatusClass(r.Status),
+		AlertCount:   len(r.Alerts),
+	}
+	if len(r.Tunnels) > 0 {
+		data.Tunnels = fmt.Sprintf("%d/%d", r.TunnelsDown, len(r.Tunnels))
+	}
+	if r.TunnelsDown > 0 {
+		data.TunnelsClass = "alert-critical"
+	}
+	if r.IsHAAbnormal() {
+		data.HAClass = "alert-critical"
+	}
+	return data
+}
+
+// convertFirewallTunnels converts the VPN tunnels of a firewall device to FirewallTunnelData.
+func (w *Writer) convertFirewallTunnels(r *model.FirewallInspectionResult) []*FirewallTunnelData {
+	tunnels := make([]*FirewallTunnelData, 0, len(r.Tunnels))
+	for _, t := range r.Tunnels {
+		status := "正常"
+		if !t.Up {
+			status = "断开"
+		}
+		tunnels = append(tunnels, &FirewallTunnelData{
+			Address: r.Device.Address,
+			Name:    r.Device.Name,
+			Tunnel:  t.Name,
+			Status:  status,
+			Up:      t.Up,
+		})
+	}
+	return tunnels
+}
+
+// convertFirewallAlerts converts FirewallAlert slice to FirewallAlertData slice.
+func (w *Writer) convertFirewallAlerts(alerts []*model.FirewallAlert) []*FirewallAlertData {
+	// Sort by level (critical first)
+	sortedAlerts := make([]*model.FirewallAlert, len(alerts))
+	copy(sortedAlerts, alerts)
+	sort.Slice(sortedAlerts, func(i, j int) bool {
+		if sortedAlerts[i].Level != sortedAlerts[j].Level {
+			return alertLevelPriority(sortedAlerts[i].Level) > alertLevelPriority(sortedAlerts[j].Level)
+		}
+		return sortedAlerts[i].Identifier < sortedAlerts[j].Identifier
+	})
+
+	result := make([]*FirewallAlertData, 0, len(sortedAlerts))
+	for _, alert := range sortedAlerts {
+		result = append(result, &FirewallAlertData{
+			Identifier:        alert.Identifier,
+			MetricName:        alert.MetricName,
+			MetricDisplayName: alert.MetricDisplayName,
+			CurrentValue:      alert.FormattedValue,
+			WarningThreshold:  formatFirewallThreshold(alert.WarningThreshold, alert.MetricName),
+			CriticalThreshold: formatFirewallThreshold(alert.CriticalThreshold, alert.MetricName),
+			Level:             alertLevelText(alert.Level),
+			LevelClass:        alertLevelClass(alert.Level),
+			Message:           alert.Message,
+		})
+	}
+	return result
+}
+
+// WriteFirewallInspection generates an HTML report for firewall inspection results.
+// Firewalls have no dedicated template; the combined template renders their section only.
+func (w *Writer) WriteFirewallInspection(result *model.FirewallInspectionResults, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("firewall inspection result is nil")
+	}
+
+	return w.WriteCombined(&json.Report{Firewall: result}, outputPath)
+}
+
+// =============================================================================
+// Split Report (index.html + per-module pages)
+// =============================================================================
+
+// Split report file names.
+const (
+	splitIndexFile      = "index.html"
+	splitHostFile       = "hosts.html"
+	splitMySQLFile      = "mysql.html"
+	splitRedisFile      = "redis.html"
+	splitNginxFile      = "nginx.html"
+	splitTomcatFile     = "tomcat.html"
+	splitCassandraFile  = "cassandra.html"
+	splitMonitoringFile = "monitoring.html"
+	splitStorageFile    = "storage.html"
+	splitLogChecksFile  = "logs.html"
+	splitLVSFile        = "lvs.html"
+	splitWindowsFile    = "windows.html"
+	splitADFile         = "ad.html"
+	splitCloudFile      = "cloud.html"
+	splitFirewallFile   = "firewall.html"
+)
+
+// PageLink represents a navigation link between pages of a split report.
+type PageLink struct {
+	Title  string
+	File   string
+	Active bool
+}
+
+// IndexModuleData represents a module summary card on the split report index.
+type IndexModuleData struct {
+	Title       string
+	File        string
+	Total       int
+	Normal      int
+	Warning     int
+	Critical    int
+	Failed      int
+	Alerts      int
+	StatusClass string
+}
+
+// IndexTemplateData holds all data passed to the split report index template.
+type IndexTemplateData struct {
+	Title          string
+	InspectionTime string
+	Duration       string
+	Pages          []*PageLink
+	Modules        []*IndexModuleData
+	Executive      *ExecutiveSummaryData
+	Version        string
+	GeneratedAt    string
+}
+
+// splitPage describes one module page of a split report.
+type splitPage struct {
+	module *IndexModuleData
+	data   *CombinedTemplateData
+}
+
+// WriteSplit generates a split HTML report in outputDir: index.html with a
+// per-module overview plus one cross-linked page per inspected module.
+// Each module page is rendered with the combined template so that it looks
+// the same as the corresponding section of the single-file report.
+func (w *Writer) WriteSplit(report *json.Report, outputDir string) error {
+	// At least one result must be present
+	if report.Empty() {
+		return fmt.Errorf("all inspection results are nil")
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create split report directory: %w", err)
+	}
+
+	pages := w.prepareSplitPages(report)
+
+	tmpl, err := w.loadCombinedTemplate()
+	if err != nil {
+		return fmt.Errorf("failed to load combined template: %w", err)
+	}
+
+	for i, page := range pages {
+		page.data.Pages = splitPageLinks(pages, i+1)
+		if err := executeTemplateToFile(tmpl, page.data, filepath.Join(outputDir, page.module.File), w.resourceDir(), w.tr); err != nil {
+			return fmt.Errorf("failed to write %s: %w", page.module.File, err)
+		}
+	}
+
+	indexTmpl, err := w.loadIndexTemplate()
+	if err != nil {
+		return fmt.Errorf("failed to load index template: %w", err)
+	}
+
+	data := &IndexTemplateData{
+		Title:       w.theme.GetTitle(),
+		GeneratedAt: time.Now().In(w.timezone).Format("2006-01-02 15:04:05"),
+		Pages:       splitPageLinks(pages, 0),
+		Executive:   w.prepareExecutiveSummary(report),
+	}
+	if len(pages) > 0 {
+		data.InspectionTime = pages[0].data.InspectionTime
+		data.Duration = pages[0].data.Duration
+		data.Version = pages[0].data.Version
+	}
+	for _, page := range pages {
+		data.Modules = append(data.Modules, page.module)
+	}
+
+	if err := executeTemplateToFile(indexTmpl, data, filepath.Join(outputDir, splitIndexFile), w.resourceDir(), w.tr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", splitIndexFile, err)
+	}
+
+	return nil
+}
+
+// prepareSplitPages prepares template data for each inspected module, in report order.
+func (w *Writer) prepareSplitPages(report *json.Report) []*splitPage {
+	var pages []*splitPage
+
+	add := func(title, file string, data *CombinedTemplateData, total, normal, warning, critical, failed, alerts int) {
+		data.Title = w.theme.GetTitle() + " - " + title
+		pages = append(pages, &splitPage{
+			module: &IndexModuleData{
+				Title:       title,
+				File:        file,
+				Total:       total,
+				Normal:      normal,
+				Warning:     warning,
+				Critical:    critical,
+				Failed:      failed,
+				Alerts:      alerts,
+				StatusClass: moduleStatusClass(warning, critical),
+			},
+			data: data,
+		})
+	}
+
+	if report.Host != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Host: report.Host})
+		s, a := report.Host.Summary, report.Host.AlertSummary
+		add("主机巡检", splitHostFile, data, s.TotalHosts, s.NormalHosts, s.WarningHosts, s.CriticalHosts, s.FailedHosts, a.TotalAlerts)
+	}
+	if report.MySQL != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{MySQL: report.MySQL})
+		s, a := report.MySQL.Summary, report.MySQL.AlertSummary
+		add("MySQL 巡检", splitMySQLFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Redis != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Redis: report.Redis})
+		s, a := report.Redis.Summary, report.Redis.AlertSummary
+		add("Redis 巡检", splitRedisFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Nginx != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Nginx: report.Nginx})
+		s, a := report.Nginx.Summary, report.Nginx.AlertSummary
+		add("Nginx 巡检", splitNginxFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Tomcat != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Tomcat: report.Tomcat})
+		s, a := report.Tomcat.Summary, report.Tomcat.AlertSummary
+		add("Tomcat 巡检", splitTomcatFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Cassandra != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Cassandra: report.Cassandra})
+		s, a := report.Cassandra.Summary, report.Cassandra.AlertSummary
+		add("Cassandra 巡检", splitCassandraFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Monitoring != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Monitoring: report.Monitoring})
+		s, a := report.Monitoring.Summary, report.Monitoring.AlertSummary
+		add("监控系统巡检", splitMonitoringFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Storage != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Storage: report.Storage})
+		s, a := report.Storage.Summary, report.Storage.AlertSummary
+		add("共享存储巡检", splitStorageFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.LogChecks != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{LogChecks: report.LogChecks})
+		s, a := report.LogChecks.Summary, report.LogChecks.AlertSummary
+		add("日志巡检", splitLogChecksFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.LVS != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{LVS: report.LVS})
+		s, a := report.LVS.Summary, report.LVS.AlertSummary
+		add("LVS 巡检", splitLVSFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Windows != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Windows: report.Windows})
+		s, a := report.Windows.Summary, report.Windows.AlertSummary
+		add("Windows 服务巡检", splitWindowsFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.AD != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{AD: report.AD})
+		s, a := report.AD.Summary, report.AD.AlertSummary
+		add("AD 巡检", splitADFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Cloud != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Cloud: report.Cloud})
+		s, a := report.Cloud.Summary, report.Cloud.AlertSummary
+		add("云资源巡检", splitCloudFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+	if report.Firewall != nil {
+		data := w.prepareCombinedTemplateData(&json.Report{Firewall: report.Firewall})
+		s, a := report.Firewall.Summary, report.Firewall.AlertSummary
+		add("防火墙巡检", splitFirewallFile, data, s.TotalInstances, s.NormalInstances, s.WarningInstances, s.CriticalInstances, s.FailedInstances, a.TotalAlerts)
+	}
+
+	return pages
+}
+
+// splitPageLinks builds the navigation bar for a split report page.
+// active is 0 for the index page and i+1 for pages[i].
+func splitPageLinks(pages []*splitPage, active int) []*PageLink {
+	links := make([]*PageLink, 0, len(pages)+1)
+	links = append(links, &PageLink{Title: "总览", File: splitIndexFile, Active: active == 0})
+	for i, page := range pages {
+		links = append(links, &PageLink{Title: page.module.Title, File: page.module.File, Active: active == i+1})
+	}
+	return links
+}
+
+// moduleStatusClass returns the CSS class summarizing a module's worst status.
+func moduleStatusClass(warning, critical int) string {
+	switch {
+	case critical > 0:
+		return "critical"
+	case warning > 0:
+		return "warning"
+	default:
+		return "normal"
+	}
+}
+
+// loadIndexTemplate loads the split report index template.
+func (w *Writer) loadIndexTemplate() (*template.Template, error) {
+	tmpl, err := template.New("index.html").Funcs(w.withThemeFuncs(template.FuncMap{})).ParseFS(embeddedTemplates, "templates/index.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse embedded index template: %w", err)
+	}
+	return tmpl, nil
 }
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"inspection-tool/internal/model"
+	"inspection-tool/internal/report/json"
+	"inspection-tool/internal/report/theme"
+	"inspection-tool/pkg/testfixtures"
 )
 
 func TestNewWriter(t *testing.T) {
@@ -243,6 +246,35 @@ func TestPrepareTemplateData(t *testing.T) {
 	}
 }
 
+func TestWriter_Write_CMDBColumns(t *testing.T) {
+	w := NewWriter(nil, "")
+
+	// Without CMDB data the columns are left out
+	outputPath := filepath.Join(t.TempDir(), "plain.html")
+	if err := w.Write(createTestResult(), outputPath); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	content, _ := os.ReadFile(outputPath)
+	if strings.Contains(string(content), ">负责人</th>") {
+		t.Error("CMDB columns should be left out without CMDB data")
+	}
+
+	result := createTestResult()
+	result.Hosts[0].Owner = "alice"
+	result.Hosts[0].Application = "shop"
+	result.Hosts[0].Maintenance = true
+	outputPath = filepath.Join(t.TempDir(), "cmdb.html")
+	if err := w.Write(result, outputPath); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	content, _ = os.ReadFile(outputPath)
+	for _, expected := range []string{">负责人</th>", "<td>alice</td>", "<td>shop</td>", "维护中"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("expected content to contain '%s'", expected)
+		}
+	}
+}
+
 func TestCollectDiskPaths(t *testing.T) {
 	w := NewWriter(nil, "")
 	hosts := []*model.HostResult{
@@ -408,6 +440,7 @@ func TestMetricStatusClass(t *testing.T) {
 		{model.MetricStatusWarning, "metric-warning"},
 		{model.MetricStatusCritical, "metric-critical"},
 		{model.MetricStatusPending, "metric-pending"},
+		{model.MetricStatusAnomaly, "metric-anomaly"},
 	}
 
 	for _, tt := range tests {
@@ -442,83 +475,35 @@ func TestFormatThreshold(t *testing.T) {
 
 // Helper functions
 
+// newTestHosts returns two hosts built with the shared fixtures and no alerts: test-host-1 is
+// normal and test-host-2 has a CPU usage over the warning threshold.
+func newTestHosts() []*model.HostResult {
+	host1 := testfixtures.NewHost("test-host-1", "192.168.1.1")
+	host1.KernelVersion = "3.10.0"
+	delete(host1.Metrics, "disk_usage:/")
+	testfixtures.SetHostMetric(host1, "cpu_usage", 25.5, nil)
+	testfixtures.SetHostMetric(host1, "memory_usage", 45.2, nil)
+	testfixtures.SetHostMetric(host1, "disk_usage_max", 60.1, nil)
+
+	host2 := testfixtures.NewHost("test-host-2", "192.168.1.2")
+	delete(host2.Metrics, "disk_usage:/")
+	testfixtures.SetHostMetric(host2, "disk_usage_max", 45.0, nil)
+	testfixtures.SetHostMetric(host2, "cpu_usage", 75.0, nil).Status = model.MetricStatusWarning
+	host2.Status = model.HostStatusWarning
+	return []*model.HostResult{host1, host2}
+}
+
 func createTestResult() *model.InspectionResult {
-	return &model.InspectionResult{
-		InspectionTime: time.Now(),
-		Duration:       5 * time.Second,
-		Summary: &model.InspectionSummary{
-			TotalHosts:    2,
-			NormalHosts:   1,
-			WarningHosts:  1,
-			CriticalHosts: 0,
-			FailedHosts:   0,
-		},
-		AlertSummary: &model.AlertSummary{
-			TotalAlerts:   1,
-			WarningCount:  1,
-			CriticalCount: 0,
-		},
-		Hosts: []*model.HostResult{
-			{
-				Hostname:      "test-host-1",
-				IP:            "192.168.1.1",
-				OS:            "Linux",
-				OSVersion:     "CentOS 7.9",
-				KernelVersion: "3.10.0",
-				CPUCores:      4,
-				Status:        model.HostStatusNormal,
-				Metrics: map[string]*model.MetricValue{
-					"cpu_usage":      {Name: "cpu_usage", FormattedValue: "25.5%", Status: model.MetricStatusNormal},
-					"memory_usage":   {Name: "memory_usage", FormattedValue: "45.2%", Status: model.MetricStatusNormal},
-					"disk_usage_max": {Name: "disk_usage_max", FormattedValue: "60.1%", Status: model.MetricStatusNormal},
-				},
-			},
-			{
-				Hostname: "test-host-2",
-				IP:       "192.168.1.2",
-				OS:       "Linux",
-				Status:   model.HostStatusWarning,
-				Metrics: map[string]*model.MetricValue{
-					"cpu_usage":      {Name: "cpu_usage", FormattedValue: "75.0%", Status: model.MetricStatusWarning},
-					"memory_usage":   {Name: "memory_usage", FormattedValue: "50.0%", Status: model.MetricStatusNormal},
-					"disk_usage_max": {Name: "disk_usage_max", FormattedValue: "45.0%", Status: model.MetricStatusNormal},
-				},
-			},
-		},
-		Alerts: []*model.Alert{},
-	}
+	return testfixtures.NewInspectionResult(newTestHosts()...)
 }
 
+// createTestResultWithAlerts returns the hosts of createTestResult with a critical memory
+// alert on test-host-1 and a CPU warning on test-host-2.
 func createTestResultWithAlerts() *model.InspectionResult {
-	result := createTestResult()
-	result.Alerts = []*model.Alert{
-		{
-			Hostname:          "test-host-1",
-			MetricName:        "memory_usage",
-			MetricDisplayName: "内存利用率",
-			CurrentValue:      92.5,
-			FormattedValue:    "92.5%",
-			WarningThreshold:  70,
-			CriticalThreshold: 90,
-			Level:             model.AlertLevelCritical,
-			Message:           "内存利用率达到严重阈值",
-		},
-		{
-			Hostname:          "test-host-2",
-			MetricName:        "cpu_usage",
-			MetricDisplayName: "CPU利用率",
-			CurrentValue:      75.0,
-			FormattedValue:    "75.0%",
-			WarningThreshold:  70,
-			CriticalThreshold: 90,
-			Level:             model.AlertLevelWarning,
-			Message:           "CPU利用率达到警告阈值",
-		},
-	}
-	result.AlertSummary.TotalAlerts = 2
-	result.AlertSummary.WarningCount = 1
-	result.AlertSummary.CriticalCount = 1
-	return result
+	hosts := newTestHosts()
+	testfixtures.AddHostAlert(hosts[0], "memory_usage", 92.5)
+	testfixtures.AddHostAlert(hosts[1], "cpu_usage", 75.0)
+	return testfixtures.NewInspectionResult(hosts...)
 }
 
 // ============================================================================
@@ -893,12 +878,15 @@ func TestGetMySQLSyncStatus(t *testing.T) {
 	}
 }
 
-func TestHTMLBoolToText(t *testing.T) {
-	if boolToText(true) != "启用" {
-		t.Error("boolToText(true) should return '启用'")
+func TestExpectedThresholdTexts(t *testing.T) {
+	warning, critical := expectedThresholdTexts("8.0*", model.AlertLevelWarning)
+	if warning != "期望: 8.0*" || critical != "-" {
+		t.Errorf("warning level: got (%q, %q)", warning, critical)
 	}
-	if boolToText(false) != "禁用" {
-		t.Error("boolToText(false) should return '禁用'")
+
+	warning, critical = expectedThresholdTexts("allkeys-lru", model.AlertLevelCritical)
+	if warning != "-" || critical != "期望: allkeys-lru" {
+		t.Errorf("critical level: got (%q, %q)", warning, critical)
 	}
 }
 
@@ -925,109 +913,28 @@ func TestFormatMySQLThreshold(t *testing.T) {
 
 // MySQL test helper functions
 
-func createTestMySQLInspectionResults() *model.MySQLInspectionResults {
-	instance1 := &model.MySQLInstance{
-		Address:     "172.18.182.91:3306",
-		IP:          "172.18.182.91",
-		Port:        3306,
-		Version:     "8.0.39",
-		ServerID:    "1001",
-		ClusterMode: model.ClusterModeMGR,
-	}
-	instance2 := &model.MySQLInstance{
-		Address:     "172.18.182.92:3306",
-		IP:          "172.18.182.92",
-		Port:        3306,
-		Version:     "8.0.39",
-		ServerID:    "1002",
-		ClusterMode: model.ClusterModeMGR,
-	}
+// newTestMySQLResults returns two normal MGR members built with the shared fixtures.
+func newTestMySQLResults() []*model.MySQLInspectionResult {
+	member1 := testfixtures.NewMySQLResult("172.18.182.91:3306")
+	member1.Instance.SetServerID("1001")
+	member2 := testfixtures.NewMySQLResult("172.18.182.92:3306")
+	member2.Instance.SetServerID("1002")
+	member2.CurrentConnections = 150
+	return []*model.MySQLInspectionResult{member1, member2}
+}
 
-	return &model.MySQLInspectionResults{
-		InspectionTime: time.Now(),
-		Duration:       5 * time.Second,
-		Summary: &model.MySQLInspectionSummary{
-			TotalInstances:    2,
-			NormalInstances:   2,
-			WarningInstances:  0,
-			CriticalInstances: 0,
-			FailedInstances:   0,
-		},
-		AlertSummary: &model.MySQLAlertSummary{
-			TotalAlerts:   0,
-			WarningCount:  0,
-			CriticalCount: 0,
-		},
-		Results: []*model.MySQLInspectionResult{
-			{
-				Instance:           instance1,
-				ConnectionStatus:   true,
-				MaxConnections:     1000,
-				CurrentConnections: 100,
-				BinlogEnabled:      true,
-				MGRMemberCount:     3,
-				MGRStateOnline:     true,
-				Status:             model.MySQLStatusNormal,
-				Alerts:             []*model.MySQLAlert{},
-			},
-			{
-				Instance:           instance2,
-				ConnectionStatus:   true,
-				MaxConnections:     1000,
-				CurrentConnections: 150,
-				BinlogEnabled:      true,
-				MGRMemberCount:     3,
-				MGRStateOnline:     true,
-				Status:             model.MySQLStatusNormal,
-				Alerts:             []*model.MySQLAlert{},
-			},
-		},
-		Alerts:  []*model.MySQLAlert{},
-		Version: "1.0.0",
-	}
+func createTestMySQLInspectionResults() *model.MySQLInspectionResults {
+	return testfixtures.NewMySQLInspectionResults(newTestMySQLResults()...)
 }
 
+// createTestMySQLInspectionResultsWithAlerts returns the members of
+// createTestMySQLInspectionResults with a connection usage warning on the first and a
+// critical one on the second.
 func createTestMySQLInspectionResultsWithAlerts() *model.MySQLInspectionResults {
-	result := createTestMySQLInspectionResults()
-
-	// Add alerts
-	alert1 := &model.MySQLAlert{
-		Address:           "172.18.182.91:3306",
-		MetricName:        "connection_usage",
-		MetricDisplayName: "连接使用率",
-		CurrentValue:      85.0,
-		FormattedValue:    "85.0%",
-		WarningThreshold:  70,
-		CriticalThreshold: 90,
-		Level:             model.AlertLevelWarning,
-		Message:           "连接使用率达到警告阈值",
-	}
-	alert2 := &model.MySQLAlert{
-		Address:           "172.18.182.92:3306",
-		MetricName:        "connection_usage",
-		MetricDisplayName: "连接使用率",
-		CurrentValue:      95.0,
-		FormattedValue:    "95.0%",
-		WarningThreshold:  70,
-		CriticalThreshold: 90,
-		Level:             model.AlertLevelCritical,
-		Message:           "连接使用率达到严重阈值",
-	}
-
-	result.Alerts = []*model.MySQLAlert{alert1, alert2}
-	result.Results[0].Alerts = []*model.MySQLAlert{alert1}
-	result.Results[0].Status = model.MySQLStatusWarning
-	result.Results[1].Alerts = []*model.MySQLAlert{alert2}
-	result.Results[1].Status = model.MySQLStatusCritical
-
-	result.Summary.NormalInstances = 0
-	result.Summary.WarningInstances = 1
-	result.Summary.CriticalInstances = 1
-	result.AlertSummary.TotalAlerts = 2
-	result.AlertSummary.WarningCount = 1
-	result.AlertSummary.CriticalCount = 1
-
-	return result
+	results := newTestMySQLResults()
+	testfixtures.AddMySQLAlert(results[0], "connection_usage", 85.0)
+	testfixtures.AddMySQLAlert(results[1], "connection_usage", 95.0)
+	return testfixtures.NewMySQLInspectionResults(results...)
 }
 
 // ============================================================================
@@ -1432,7 +1339,7 @@ func TestGetRedisLinkStatus(t *testing.T) {
 				Instance:         &model.RedisInstance{Role: model.RedisRoleSlave},
 				MasterLinkStatus: false,
 			},
-			expected: "异常",
+			expected: "断开",
 		},
 	}
 
@@ -1581,7 +1488,7 @@ func TestFormatRedisThreshold(t *testing.T) {
 		{70.0, "connection_usage", "70.0%"},
 		{1048576.0, "replication_lag", "1.00 MB"},
 		{1.0, "master_link_status", "正常"},
-		{0.0, "master_link_status", "异常"},
+		{0.0, "master_link_status", "断开"},
 		{1.23, "other_metric", "1.23"},
 	}
 
@@ -1602,7 +1509,7 @@ func TestWriter_WriteCombined_WithRedis(t *testing.T) {
 	mysqlResult := createTestMySQLInspectionResults()
 	redisResult := createTestRedisInspectionResults()
 
-	err := w.WriteCombined(hostResult, mysqlResult, redisResult, nil, nil, outputPath)
+	err := w.WriteCombined(&json.Report{Host: hostResult, MySQL: mysqlResult, Redis: redisResult}, outputPath)
 	if err != nil {
 		t.Fatalf("WriteCombined with Redis failed: %v", err)
 	}
@@ -1639,7 +1546,7 @@ func TestWriter_WriteCombined_OnlyRedis(t *testing.T) {
 	w := NewWriter(nil, "")
 	redisResult := createTestRedisInspectionResults()
 
-	err := w.WriteCombined(nil, nil, redisResult, nil, nil, outputPath)
+	err := w.WriteCombined(&json.Report{Redis: redisResult}, outputPath)
 	if err != nil {
 		t.Fatalf("WriteCombined with only Redis failed: %v", err)
 	}
@@ -1668,109 +1575,29 @@ func TestWriter_WriteCombined_OnlyRedis(t *testing.T) {
 
 // Redis test helper functions
 
-func createTestRedisInspectionResults() *model.RedisInspectionResults {
-	instance1 := &model.RedisInstance{
-		Address: "192.18.102.2:7000",
-		IP:      "192.18.102.2",
-		Port:    7000,
-		Version: "",
-		Role:    model.RedisRoleMaster,
-	}
-	instance2 := &model.RedisInstance{
-		Address: "192.18.102.2:7001",
-		IP:      "192.18.102.2",
-		Port:    7001,
-		Version: "",
-		Role:    model.RedisRoleSlave,
-	}
+// newTestRedisResults returns a normal master and replica on 192.18.102.2 built with the
+// shared fixtures.
+func newTestRedisResults() []*model.RedisInspectionResult {
+	master := testfixtures.NewRedisResult("192.18.102.2:7000", model.RedisRoleMaster)
+	master.ConnectedClients = 100
+	replica := testfixtures.NewRedisResult("192.18.102.2:7001", model.RedisRoleSlave)
+	replica.ConnectedClients = 50
+	replica.MasterPort = 7000
+	return []*model.RedisInspectionResult{master, replica}
+}
 
-	return &model.RedisInspectionResults{
-		InspectionTime: time.Now(),
-		Duration:       5 * time.Second,
-		Summary: &model.RedisInspectionSummary{
-			TotalInstances:    2,
-			NormalInstances:   2,
-			WarningInstances:  0,
-			CriticalInstances: 0,
-			FailedInstances:   0,
-		},
-		AlertSummary: &model.RedisAlertSummary{
-			TotalAlerts:   0,
-			WarningCount:  0,
-			CriticalCount: 0,
-		},
-		Results: []*model.RedisInspectionResult{
-			{
-				Instance:         instance1,
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MaxClients:       10000,
-				ConnectedClients: 100,
-				ConnectedSlaves:  2,
-				MasterLinkStatus: true,
-				MasterPort:       0,
-				ReplicationLag:   0,
-				Status:           model.RedisStatusNormal,
-				Alerts:           []*model.RedisAlert{},
-			},
-			{
-				Instance:         instance2,
-				ConnectionStatus: true,
-				ClusterEnabled:   true,
-				MaxClients:       10000,
-				ConnectedClients: 50,
-				ConnectedSlaves:  0,
-				MasterLinkStatus: true,
-				MasterPort:       7000,
-				ReplicationLag:   0,
-				Status:           model.RedisStatusNormal,
-				Alerts:           []*model.RedisAlert{},
-			},
-		},
-		Alerts:  []*model.RedisAlert{},
-		Version: "1.0.0",
-	}
+func createTestRedisInspectionResults() *model.RedisInspectionResults {
+	return testfixtures.NewRedisInspectionResults(newTestRedisResults()...)
 }
 
+// createTestRedisInspectionResultsWithAlerts returns the nodes of
+// createTestRedisInspectionResults with a connection usage warning on the master and a
+// critical one on the replica.
 func createTestRedisInspectionResultsWithAlerts() *model.RedisInspectionResults {
-	result := createTestRedisInspectionResults()
-
-	// Add alerts
-	alert1 := &model.RedisAlert{
-		Address:           "192.18.102.2:7000",
-		MetricName:        "connection_usage",
-		MetricDisplayName: "连接使用率",
-		CurrentValue:      85.0,
-		FormattedValue:    "85.0%",
-		WarningThreshold:  70,
-		CriticalThreshold: 90,
-		Level:             model.AlertLevelWarning,
-		Message:           "连接使用率达到警告阈值",
-	}
-	alert2 := &model.RedisAlert{
-		Address:           "192.18.102.3:7000",
-		MetricName:        "connection_usage",
-		MetricDisplayName: "连接使用率",
-		CurrentValue:      95.0,
-		FormattedValue:    "95.0%",
-		WarningThreshold:  70,
-		CriticalThreshold: 90,
-		Level:             model.AlertLevelCritical,
-		Message:           "连接使用率达到严重阈值",
-	}
-
-	result.Alerts = []*model.RedisAlert{alert1, alert2}
-	result.Results[0].Alerts = []*model.RedisAlert{alert1}
-	result.Results[0].Status = model.RedisStatusWarning
-
-	result.Summary.NormalInstances = 1
-	result.Summary.WarningInstances = 1
-	result.Summary.CriticalInstances = 0
-	result.AlertSummary.TotalAlerts = 2
-	result.AlertSummary.WarningCount = 1
-	result.AlertSummary.CriticalCount = 1
-
-	return result
+	results := newTestRedisResults()
+	testfixtures.AddRedisAlert(results[0], "connection_usage", 85.0)
+	testfixtures.AddRedisAlert(results[1], "connection_usage", 95.0)
+	return testfixtures.NewRedisInspectionResults(results...)
 }
 
 // ============================================================================
@@ -1786,7 +1613,7 @@ func TestWriter_WriteCombined_MultipleRedisClusters(t *testing.T) {
 	// Create multi-cluster results
 	redisResult := createTestRedisMultiClusterResults()
 
-	err := w.WriteCombined(nil, nil, redisResult, nil, nil, outputPath)
+	err := w.WriteCombined(&json.Report{Redis: redisResult}, outputPath)
 	if err != nil {
 		t.Fatalf("WriteCombined failed: %v", err)
 	}
@@ -1824,7 +1651,7 @@ func TestWriter_WriteCombined_SingleClusterNoMultiClusterSections(t *testing.T)
 	// Create single-cluster results (all same network segment)
 	redisResult := createTestRedisInspectionResults()
 
-	err := w.WriteCombined(nil, nil, redisResult, nil, nil, outputPath)
+	err := w.WriteCombined(&json.Report{Redis: redisResult}, outputPath)
 	if err != nil {
 		t.Fatalf("WriteCombined failed: %v", err)
 	}
@@ -1914,100 +1741,304 @@ func TestWriter_ConvertRedisClusterData_Nil(t *testing.T) {
 
 // createTestRedisMultiClusterResults creates test data with 2 clusters (陕西项目场景)
 func createTestRedisMultiClusterResults() *model.RedisInspectionResults {
-	results := &model.RedisInspectionResults{
-		InspectionTime: time.Now(),
-		Duration:       5 * time.Second,
-		Results:        make([]*model.RedisInspectionResult, 0, 12),
-	}
-
-	// Cluster 1: 192.18.102.x - 3 masters, 3 slaves
-	for i := 2; i <= 4; i++ {
-		ip := fmt.Sprintf("192.18.102.%d", i)
-		// Master
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address: fmt.Sprintf("%s:7000", ip),
-				IP:      ip,
-				Port:    7000,
-				Role:    model.RedisRoleMaster,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MaxClients:       10000,
-			ConnectedClients: 100,
-			ConnectedSlaves:  1,
-			Status:           model.RedisStatusNormal,
-		})
-		// Slave
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address: fmt.Sprintf("%s:7001", ip),
-				IP:      ip,
-				Port:    7001,
-				Role:    model.RedisRoleSlave,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MasterLinkStatus: true,
-			MasterPort:       7000,
-			MaxClients:       10000,
-			ConnectedClients: 50,
-			Status:           model.RedisStatusNormal,
-		})
+	var nodes []*model.RedisInspectionResult
+	for _, cluster := range []struct {
+		subnet  string
+		hosts   []int
+		clients int // Connected clients of the masters, half of it on the replicas
+	}{
+		{"192.18.102", []int{2, 3, 4}, 100},
+		{"192.18.107", []int{5, 6, 7}, 200},
+	} {
+		for _, host := range cluster.hosts {
+			ip := fmt.Sprintf("%s.%d", cluster.subnet, host)
+			master := testfixtures.NewRedisResult(ip+":7000", model.RedisRoleMaster)
+			master.ConnectedClients = cluster.clients
+			replica := testfixtures.NewRedisResult(ip+":7001", model.RedisRoleSlave)
+			replica.ConnectedClients = cluster.clients / 2
+			replica.MasterPort = 7000
+			nodes = append(nodes, master, replica)
+		}
 	}
 
-	// Cluster 2: 192.18.107.x - 3 masters, 3 slaves
-	for i := 5; i <= 7; i++ {
-		ip := fmt.Sprintf("192.18.107.%d", i)
-		// Master
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address: fmt.Sprintf("%s:7000", ip),
-				IP:      ip,
-				Port:    7000,
-				Role:    model.RedisRoleMaster,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MaxClients:       10000,
-			ConnectedClients: 200,
-			ConnectedSlaves:  1,
-			Status:           model.RedisStatusNormal,
-		})
-		// Slave
-		results.Results = append(results.Results, &model.RedisInspectionResult{
-			Instance: &model.RedisInstance{
-				Address: fmt.Sprintf("%s:7001", ip),
-				IP:      ip,
-				Port:    7001,
-				Role:    model.RedisRoleSlave,
-			},
-			ConnectionStatus: true,
-			ClusterEnabled:   true,
-			MasterLinkStatus: true,
-			MasterPort:       7000,
-			MaxClients:       10000,
-			ConnectedClients: 100,
-			Status:           model.RedisStatusNormal,
-		})
+	results := testfixtures.NewRedisInspectionResults(nodes...)
+	results.GroupByClusters()
+	return results
+}
+
+// ============================================================================
+// Nginx Upstream Tests
+// ============================================================================
+
+func TestWriter_ConvertNginxUpstreams(t *testing.T) {
+	r := model.NewNginxInspectionResult(model.NewNginxInstance("GX-NM-NGX-01", 80))
+	r.AddUpstreamStatus(model.NginxUpstreamStatus{UpstreamName: "api", BackendAddress: "10.0.0.1:8080", Status: false})
+	r.AddUpstreamStatus(model.NginxUpstreamStatus{UpstreamName: "web", BackendAddress: "10.0.0.2:8080", Status: true})
+	r.AddUpstreamStatus(model.NginxUpstreamStatus{UpstreamName: "web", BackendAddress: "10.0.0.3:8080", Status: false})
+	r.SetUpstream5xxRate("web", 0.5)
+
+	w := NewWriter(nil, "")
+	upstreams := w.convertNginxUpstreams([]*model.NginxInspectionResult{r, nil})
+
+	if len(upstreams) != 2 {
+		t.Fatalf("expected 2 upstreams, got %d", len(upstreams))
+	}
+	if upstreams[0].UpstreamName != "api" || upstreams[0].Status != "全部异常" || upstreams[0].StatusClass != "critical" {
+		t.Errorf("unexpected api upstream: %+v", upstreams[0])
+	}
+	if upstreams[0].Error5xxRate != "N/A" {
+		t.Errorf("expected api 5xx rate N/A, got %s", upstreams[0].Error5xxRate)
+	}
+	if upstreams[1].Status != "部分异常" || upstreams[1].Error5xxRate != "0.50%" {
+		t.Errorf("unexpected web upstream: %+v", upstreams[1])
 	}
+}
 
-	// Call GroupByClusters to populate Clusters field
-	results.GroupByClusters()
+func TestWriter_WriteCombined_WithNginxUpstreams(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "combined_with_nginx.html")
 
-	results.Summary = &model.RedisInspectionSummary{
-		TotalInstances:    12,
-		NormalInstances:   12,
-		WarningInstances:  0,
-		CriticalInstances: 0,
-		FailedInstances:   0,
+	r := model.NewNginxInspectionResult(model.NewNginxInstance("GX-NM-NGX-01", 80))
+	r.AddUpstreamStatus(model.NginxUpstreamStatus{UpstreamName: "backend_pool", BackendAddress: "10.0.0.1:8080", Status: false})
+	nginxResult := model.NewNginxInspectionResults(time.Now())
+	nginxResult.AddResult(r)
+	nginxResult.Finalize(time.Now())
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{Nginx: nginxResult}, outputPath); err != nil {
+		t.Fatalf("WriteCombined with Nginx failed: %v", err)
 	}
-	results.AlertSummary = &model.RedisAlertSummary{
-		TotalAlerts:   0,
-		WarningCount:  0,
-		CriticalCount: 0,
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
 	}
-	results.Version = "1.0.0-test"
 
-	return results
+	contentStr := string(content)
+	for _, expected := range []string{"Nginx Upstream 概览", "backend_pool", "全部异常"} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("expected content to contain '%s'", expected)
+		}
+	}
+}
+
+// ============================================================================
+// Split Report Tests
+// ============================================================================
+
+func TestWriter_WriteSplit_NilResults(t *testing.T) {
+	w := NewWriter(nil, "")
+	if err := w.WriteSplit(&json.Report{}, t.TempDir()); err == nil {
+		t.Error("expected error for all nil results")
+	}
+}
+
+func TestWriter_WriteSplit_Success(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "split")
+
+	w := NewWriter(nil, "")
+	hostResult := createTestResultWithAlerts()
+	mysqlResult := createTestMySQLInspectionResults()
+	redisResult := createTestRedisInspectionResults()
+
+	if err := w.WriteSplit(&json.Report{Host: hostResult, MySQL: mysqlResult, Redis: redisResult}, outputDir); err != nil {
+		t.Fatalf("WriteSplit failed: %v", err)
+	}
+
+	// Only inspected modules get a page
+	for _, name := range []string{"index.html", "hosts.html", "mysql.html", "redis.html"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("expected %s to exist: %v", name, err)
+		}
+	}
+	for _, name := range []string{"nginx.html", "tomcat.html", "cassandra.html"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s not to exist", name)
+		}
+	}
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	for _, expected := range []string{`href="hosts.html"`, `href="mysql.html"`, `href="redis.html"`, "主机巡检", "MySQL 巡检"} {
+		if !strings.Contains(string(index), expected) {
+			t.Errorf("expected index to contain %q", expected)
+		}
+	}
+
+	// Module pages contain only their own section and link back to the index
+	hosts, err := os.ReadFile(filepath.Join(outputDir, "hosts.html"))
+	if err != nil {
+		t.Fatalf("failed to read hosts page: %v", err)
+	}
+	hostsStr := string(hosts)
+	if !strings.Contains(hostsStr, `href="index.html"`) {
+		t.Error("expected hosts page to link back to index.html")
+	}
+	if !strings.Contains(hostsStr, `href="hosts.html" class="active"`) {
+		t.Error("expected hosts page link to be active")
+	}
+	if strings.Contains(hostsStr, "MySQL 数据库巡检") {
+		t.Error("hosts page should not contain the MySQL section")
+	}
+}
+
+func TestWriter_WriteCombined_NoPageNav(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "combined.html")
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if strings.Contains(string(content), `<nav class="page-nav">`) {
+		t.Error("single-file report should not render split navigation")
+	}
+}
+
+func TestModuleStatusClass(t *testing.T) {
+	tests := []struct {
+		warning, critical int
+		expected          string
+	}{
+		{0, 0, "normal"},
+		{2, 0, "warning"},
+		{2, 1, "critical"},
+	}
+	for _, tt := range tests {
+		if got := moduleStatusClass(tt.warning, tt.critical); got != tt.expected {
+			t.Errorf("moduleStatusClass(%d, %d) = %s, want %s", tt.warning, tt.critical, got, tt.expected)
+		}
+	}
+}
+
+func TestWriter_WriteCombined_TomcatLogExcerpt(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "tomcat_log_excerpt.html")
+
+	result := model.NewTomcatInspectionResults(time.Now())
+	inspResult := model.NewTomcatInspectionResult(model.NewTomcatInstance("app-01", 8080))
+	inspResult.Status = model.TomcatStatusCritical
+	inspResult.Alerts = []*model.TomcatAlert{{
+		Identifier:        "app-01:8080",
+		MetricName:        "tomcat_last_error_timestamp",
+		MetricDisplayName: "最近错误时间",
+		Level:             model.AlertLevelCritical,
+		Message:           "5 分钟前出现错误日志",
+		LogExcerpt:        []string{"2024-01-01 10:00:00 java.lang.OutOfMemoryError: Java heap space"},
+	}}
+	result.AddResult(inspResult)
+	result.Finalize(time.Now())
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCombined(&json.Report{Tomcat: result}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	contentStr := string(content)
+	for _, expected := range []string{"日志摘录 (1 行)", "java.lang.OutOfMemoryError: Java heap space"} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("expected content to contain %q", expected)
+		}
+	}
+}
+
+func TestWriter_WriteCloudInspection_CostSummary(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "cloud_cost.html")
+
+	resource := model.NewCloudResource(model.CloudProviderAWS, model.CloudResourceTypeRDS, "prod-db-01")
+	resource.Spec = "db.r5.large"
+	r := model.NewCloudInspectionResult(resource)
+	r.PeakCPUUsage = 1.2
+	cloudResult := model.NewCloudInspectionResults(time.Now())
+	cloudResult.AddResult(r)
+	cloudResult.Finalize(time.Now())
+	cloudResult.CostSummary = model.NewCloudCostSummary(cloudResult.Results, 720*time.Hour, 5)
+
+	w := NewWriter(nil, "")
+	if err := w.WriteCloudInspection(cloudResult, outputPath); err != nil {
+		t.Fatalf("WriteCloudInspection failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, expected := range []string{"云资源成本 / 资产汇总", "30 天", "db.r5.large", "cloud-underutilized-table", "1.2%"} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("expected content to contain '%s'", expected)
+		}
+	}
+}
+
+func TestWriter_WriteFirewallInspection(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "firewall.html")
+
+	r := model.NewFirewallInspectionResult(model.NewFirewallDevice(model.FirewallProfilePaloAlto, "10.0.3.1"))
+	r.HAStatus = 0
+	r.HAState = "suspended"
+	r.AddTunnel(&model.FirewallTunnel{Name: "to-branch", Up: false})
+	r.Status = model.FirewallStatusCritical
+	firewallResult := model.NewFirewallInspectionResults(time.Now())
+	firewallResult.AddResult(r)
+	firewallResult.Finalize(time.Now())
+
+	w := NewWriter(nil, "")
+	if err := w.WriteFirewallInspection(firewallResult, outputPath); err != nil {
+		t.Fatalf("WriteFirewallInspection failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, expected := range []string{"防火墙巡检概览", "Palo Alto", "suspended", "firewall-tunnels-table", "to-branch", "1/1"} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("expected content to contain '%s'", expected)
+		}
+	}
+}
+
+func TestWriter_Write_Theme(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "themed.html")
+
+	w := NewWriter(nil, "", WithTheme(&theme.Theme{
+		Title:          "ACME 巡检报告",
+		FooterText:     "ACME 运维服务",
+		PrimaryColor:   "#0a7f3c",
+		SecondaryColor: "#064d24",
+	}))
+	if err := w.WriteCombined(&json.Report{Host: createTestResult()}, outputPath); err != nil {
+		t.Fatalf("WriteCombined failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, expected := range []string{"<h1>ACME 巡检报告</h1>", "ACME 运维服务</p>", "linear-gradient(135deg, #0a7f3c 0%, #064d24 100%)"} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("expected content to contain '%s'", expected)
+		}
+	}
+	if strings.Contains(contentStr, "系统巡检工具") || strings.Contains(contentStr, "header-logo") {
+		t.Error("themed report should not contain the default footer or a logo")
+	}
 }
@@ -0,0 +1,544 @@
+package i18n
+
+// catalog maps the Chinese report strings to English. Entries with format verbs (%s, %d,
+// %.1f, ...) also match text formatted from them; the English format must use the verbs in
+// the same order.
+var catalog = map[string]string{
+	// Sheet names
+	"巡检概览":            "Overview",
+	"详细数据":            "Details",
+	"异常汇总":            "Alerts",
+	"MySQL 巡检":        "MySQL",
+	"MySQL 异常":        "MySQL Alerts",
+	"MySQL MGR 成员":    "MySQL MGR Members",
+	"MySQL 复制拓扑":      "MySQL Replication Topology",
+	"Redis 巡检":        "Redis",
+	"Redis 异常":        "Redis Alerts",
+	"Nginx 巡检":        "Nginx",
+	"Nginx 异常":        "Nginx Alerts",
+	"Nginx Upstream":  "Nginx Upstream",
+	"Tomcat 巡检":       "Tomcat",
+	"Tomcat 异常":       "Tomcat Alerts",
+	"Cassandra 巡检":    "Cassandra",
+	"Cassandra 异常":    "Cassandra Alerts",
+	"监控系统巡检":          "Monitoring",
+	"监控系统异常":          "Monitoring Alerts",
+	"共享存储巡检":          "Storage",
+	"共享存储异常":          "Storage Alerts",
+	"日志巡检":            "Log Checks",
+	"日志异常":            "Log Check Alerts",
+	"LVS 巡检":          "LVS",
+	"LVS 真实服务器":       "LVS Real Servers",
+	"LVS 异常":          "LVS Alerts",
+	"Windows 服务巡检":    "Windows Services",
+	"Windows 异常":      "Windows Alerts",
+	"AD 巡检":           "AD",
+	"AD 异常":           "AD Alerts",
+	"云资源巡检":           "Cloud Resources",
+	"云资源异常":           "Cloud Alerts",
+	"云资源成本":           "Cloud Cost",
+	"防火墙巡检":           "Firewalls",
+	"VPN 隧道":          "VPN Tunnels",
+	"防火墙异常":           "Firewall Alerts",
+	"原始数据":            "Raw Data",
+	"目录":              "Contents",
+	"站点健康矩阵":          "Site Health Matrix",
+	"模块告警汇总":          "Alerts by Module",
+	"站点模块明细":          "Site Modules",
+	"对比概览":            "Comparison",
+	"告警变化":            "Alert Changes",
+	"指标变化":            "Metric Changes",
+	"图表":              "Charts",
+	"趋势":              "Trend",
+	"来源冲突":            "Source Conflicts",
+	"当前告警":            "Active Alerts",
+	"Alertmanager 告警": "Alertmanager Alerts",
+	"业务组统计":           "Business Groups",
+	"负责人统计":           "Hosts by Owner",
+	"应用统计":            "Hosts by Application",
+	"位置统计":            "Hosts by Location",
+	"管理摘要":            "Executive Summary",
+	"封面":              "Cover",
+
+	// Cover
+	"客户名称":  "Customer",
+	"项目名称":  "Project",
+	"巡检周期":  "Inspection Period",
+	"巡检工程师": "Engineer",
+	"报告日期":  "Report Date",
+
+	// Modules
+	"日志":   "Logs",
+	"主机":   "Host",
+	"监控系统": "Monitoring",
+	"共享存储": "Shared Storage",
+	"云资源":  "Cloud Resources",
+	"防火墙":  "Firewall",
+
+	// Status and alert levels
+	"正常":          "Normal",
+	"警告":          "Warning",
+	"严重":          "Critical",
+	"失败":          "Failed",
+	"未知":          "Unknown",
+	"提示":          "Info",
+	"成功":          "Success",
+	"异常":          "Abnormal",
+	"部分异常":        "Partially Abnormal",
+	"全部异常":        "All Abnormal",
+	"数据异常":        "Invalid Data",
+	"%s (数据异常)":   "%s (invalid data)",
+	"运行":          "Running",
+	"运行 (master)": "Running (master)",
+	"未运行":         "Not Running",
+	"停止":          "Stopped",
+	"启用":          "Enabled",
+	"禁用":          "Disabled",
+	"已配置":         "Configured",
+	"未配置":         "Not Configured",
+	"是":           "Yes",
+	"否":           "No",
+	"主":           "Master",
+	"从":           "Slave",
+	"主节点":         "Master",
+	"从节点":         "Replica",
+	"主从":          "Master-Slave",
+	"链接断开":        "Link Down",
+	"双主":          "Dual Master",
+	"失效":          "Stale",
+	"无可用":         "Unavailable",
+	"已摘除":         "Removed",
+	"已摘除（服务无可用）":  "Removed (service unavailable)",
+	"存在不健康后端":     "Unhealthy Backends",
+	"探测失败":        "Probe Failed",
+	"复制失败":        "Replication Failed",
+	"采集失败":        "Collection Failed",
+	"无错误":         "No Errors",
+	"总览":          "Overview",
+	"在线":          "Online",
+	"离线":          "Offline",
+	"断开":          "Disconnected",
+
+	// Column headers and labels
+	"1分钟负载":          "Load (1m)",
+	"4xx错误页":         "4xx Error Pages",
+	"5xx 比例":         "5xx Ratio",
+	"5xx错误页":         "5xx Error Pages",
+	"Binlog状态":       "Binlog Status",
+	"CPU 使用率":        "CPU Usage",
+	"CPU利用率":         "CPU Usage",
+	"CPU核心":          "CPU Cores",
+	"CPU核心数":         "CPU Cores",
+	"DN 节点":          "DataNodes",
+	"DN节点数":          "DataNodes",
+	"DOWN 组件":        "Down Components",
+	"GlusterFS 断开节点": "GlusterFS Disconnected Peers",
+	"Hints积压":        "Pending Hints",
+	"IP地址":           "IP Address",
+	"JVM配置":          "JVM Options",
+	"LDAP 不可达":       "LDAP Unreachable",
+	"LDAP 探测":        "LDAP Probe",
+	"LDAP 绑定延迟":      "LDAP Bind Latency",
+	"MGR 组":          "MGR Group",
+	"Master端口":       "Master Port",
+	"NFS RPC 错误(5m)": "NFS RPC Errors (5m)",
+	"Redis版本":        "Redis Version",
+	"SYSVOL 剩余空间":    "SYSVOL Free Space",
+	"Worker进程":       "Worker Processes",
+	"Worker进程数":      "Worker Processes",
+	"Worker连接数":      "Worker Connections",
+	"不健康后端":          "Unhealthy Backends",
+	"丢弃Mutation(5m)": "Dropped Mutations (5m)",
+	"严重主机":           "Critical Hosts",
+	"严重告警":           "Critical Alerts",
+	"严重实例":           "Critical Instances",
+	"严重组件":           "Critical Components",
+	"严重节点":           "Critical Nodes",
+	"严重调度器":          "Critical Directors",
+	"严重阈值":           "Critical Threshold",
+	"主从链接状态":         "Master Link Status",
+	"主从拓扑":           "Replication Topology",
+	"复制拓扑":           "Replication Topology",
+	"无主节点":           "No Primary",
+	"无其他成员":          "No Other Members",
+	"未纳入巡检":          "Not Inspected",
+	"拓扑":             "Topology",
+	"未采集复制来源，无法确定主从关系": "Replication sources not collected, master / slave relationship unknown",
+	"主机名":                "Hostname",
+	"主机总数":               "Total Hosts",
+	"主机数":                "Hosts",
+	"主机标识符":              "Host Identifier",
+	"主机状态":               "Host Status",
+	"主机状态分布":             "Host Status Distribution",
+	"主机详情":               "Host Details",
+	"云厂商":                "Provider",
+	"云资源详情":              "Cloud Resource Details",
+	"云资源成本 / 资产汇总":       "Cloud Cost / Asset Summary",
+	"低利用率资源数":            "Idle Resources",
+	"值":                  "Value",
+	"僵尸进程":               "Zombie Processes",
+	"全部":                 "All",
+	"共享存储主机详情":           "Shared Storage Host Details",
+	"内存%":                "Memory %",
+	"内存使用率":              "Memory Usage",
+	"内存利用率":              "Memory Usage",
+	"内核版本":               "Kernel Version",
+	"写入速率(行/秒)":          "Write Rate (rows/s)",
+	"分组":                 "Group",
+	"匹配日志条数":             "Matched Lines",
+	"匹配条数":               "Matches",
+	"单位":                 "Unit",
+	"同步状态":               "Sync Status",
+	"名称":                 "Name",
+	"后端总数":               "Total Backends",
+	"告警":                 "Alerts",
+	"告警总数":               "Total Alerts",
+	"告警数":                "Alerts",
+	"告警消息":               "Alert Message",
+	"告警级别":               "Alert Level",
+	"告警级别分布":             "Alert Level Distribution",
+	"告警分布":               "Alert Distribution",
+	"告警来源分布":             "Alerts by Source",
+	"命中率":                "Hit Ratio",
+	"地域":                 "Region",
+	"域控制器总数":             "Total Domain Controllers",
+	"域控制器详情":             "Domain Controller Details",
+	"堆内存使用率":             "Heap Usage",
+	"复制失败伙伴":             "Failed Replication Partners",
+	"复制延迟":               "Replication Lag",
+	"复制延迟 %s":            "Lag %s",
+	"复制异常":               "Replication Errors",
+	"失效挂载数":              "Stale Mounts",
+	"失效挂载点":              "Stale Mount Points",
+	"失败主机":               "Failed Hosts",
+	"失败实例":               "Failed Instances",
+	"存储使用率":              "Storage Usage",
+	"存储磁盘使用率":            "Storage Disk Usage",
+	"安装路径":               "Install Path",
+	"实例":                 "Instance",
+	"实例地址":               "Instance Address",
+	"实例总数":               "Total Instances",
+	"实例数":                "Instances",
+	"实例标识":               "Instance ID",
+	"实例规格":               "Instance Type",
+	"容器名":                "Container",
+	"小计":                 "Subtotal",
+	"巡检对象":               "Target",
+	"巡检时间":               "Inspection Time",
+	"巡检耗时":               "Duration",
+	"搜索":                 "Search",
+	"耗时":                 "Duration",
+	"版本":                 "Version",
+	"工作表":                "Sheet",
+	"工具版本":               "Tool Version",
+	"平均GC暂停":             "Avg GC Pause",
+	"平均CPU利用率":           "Avg CPU Usage",
+	"平均内存利用率":            "Avg Memory Usage",
+	"业务组":                "Business Group",
+	"负责人":                "Owner",
+	"应用":                 "Application",
+	"位置":                 "Location",
+	"维护状态":               "Maintenance",
+	"维护中":                "In Maintenance",
+	"容量排行":               "Capacity Ranking",
+	"报告元数据":              "Report Metadata",
+	"Git 提交":             "Git Commit",
+	"构建时间":               "Build Time",
+	"配置文件":               "Config File",
+	"配置文件 SHA-256":       "Config File SHA-256",
+	"查询时间范围":             "Query Time Range",
+	"N9E 地址":             "N9E Endpoint",
+	"主机过滤（N9E 查询）":       "Host Filter (N9E Query)",
+	"VictoriaMetrics 地址": "VictoriaMetrics Endpoint",
+	"Prometheus 地址":      "Prometheus Endpoint",
+	"指标租户":               "Metrics Tenant",
+	"日志后端地址":             "Log Backend Endpoint",
+	"CMDB 地址":            "CMDB Endpoint",
+	"日志巡检统计窗口":           "Log Check Window",
+	"主机指标范围聚合窗口":         "Host Metric Range Window",
+	"云资源低利用率统计窗口":        "Cloud Idle Resource Window",
+	"未分组":                "Ungrouped",
+	"序号":                 "No.",
+	"应用程序池数":             "App Pools",
+	"应用类型":               "App Type",
+	"异常后端":               "Unhealthy Backends",
+	"当前值":                "Current Value",
+	"当前连接数":              "Current Connections",
+	"待合并行数":              "Pending Merge Rows",
+	"待处理复制同步":            "Pending Replication Syncs",
+	"待执行Compaction":      "Pending Compactions",
+	"总数":                 "Total",
+	"总进程":                "Processes",
+	"总进程数":               "Processes",
+	"慢查询(5m)":            "Slow Queries (5m)",
+	"慢查询/秒":              "Slow Queries/s",
+	"成员 ID":              "Member ID",
+	"成员地址":               "Member Address",
+	"成员状态":               "Member State",
+	"所属模块":               "Module",
+	"挂载数":                "Mounts",
+	"指标":                 "Metric",
+	"排名":                 "Rank",
+	"挂载点":                "Mount Point",
+	"指标名称":               "Metric Name",
+	"操作系统":               "OS",
+	"数据中心":               "Datacenter",
+	"数据库版本":              "Database Version",
+	"整体状态":               "Overall Status",
+	"无低利用率资源":            "No Idle Resources",
+	"无可用服务数":             "Unavailable Services",
+	"无可用真实服务器的虚拟服务": "Virtual Services Without Available Real Servers",
+	"无可用虚拟服务":       "Unavailable Virtual Services",
+	"无从节点":          "No Slaves",
+	"无法唯一确定主节点":     "Master Not Uniquely Identified",
+	"日志巡检概览":        "Log Checks Overview",
+	"日志异常汇总":        "Log Check Alerts",
+	"日志摘录":          "Log Excerpt",
+	"日志检查项详情":       "Log Check Details",
+	"日志路径":          "Log Path",
+	"是否普通用户启动":      "Non-root User",
+	"最大连接数":         "Max Connections",
+	"最近错误":          "Last Error",
+	"最近错误时间":        "Last Error Time",
+	"未运行应用程序池":      "Stopped App Pools",
+	"未运行服务":         "Stopped Services",
+	"未运行服务数":        "Stopped Services",
+	"本次巡检未发现告警。":    "No alerts were found in this inspection.",
+	"机架":            "Rack",
+	"权重":            "Weight",
+	"标识符":           "Identifier",
+	"检查项":           "Check",
+	"检查项总数":         "Total Checks",
+	"模块":            "Module",
+	"站点":            "Site",
+	"站点数":           "Sites",
+	"对象数":           "Objects",
+	"合计":            "Total",
+	"变化":            "Change",
+	"项目":            "Item",
+	"告警信息":          "Alert Message",
+	"旧级别":           "Old Level",
+	"新级别":           "New Level",
+	"旧值":            "Old Value",
+	"新值":            "New Value",
+	"变化量":           "Delta",
+	"旧巡检时间":         "Previous Inspection",
+	"新巡检时间":         "Current Inspection",
+	"新增告警":          "New Alerts",
+	"已恢复告警":         "Resolved Alerts",
+	"升级告警":          "Escalated Alerts",
+	"降级告警":          "Downgraded Alerts",
+	"新增主机":          "Added Hosts",
+	"移除主机":          "Removed Hosts",
+	"新增":            "New",
+	"已恢复":           "Resolved",
+	"升级":            "Escalated",
+	"降级":            "Downgraded",
+	"本次":            "Current",
+	"采用来源":          "Source Used",
+	"各来源取值":         "Values by Source",
+	"告警规则":          "Alert Rule",
+	"触发时间":          "Triggered At",
+	"触发值":           "Trigger Value",
+	"标签":            "Tags",
+	"巡检告警":          "Inspection Alerts",
+	"无":             "None",
+	"告警名称":          "Alert Name",
+	"摘要":            "Summary",
+	"开始时间":          "Started At",
+	"触发中":           "Firing",
+	"已静默":           "Silenced",
+	"已抑制":           "Inhibited",
+	"静默创建人":         "Silenced By",
+	"静默原因":          "Silence Comment",
+	"静默截止":          "Silenced Until",
+	"较上次变化":         "vs. Last Run",
+	"较最早变化":         "vs. Oldest Run",
+	"主要风险":          "Top Risks",
+	"风险级别":          "Risk Level",
+	"风险项":           "Risk",
+	"受影响系统":         "Affected Systems",
+	"处理建议":          "Remediation",
+	"本次巡检未发现风险":     "No risks found in this inspection",
+	"按安全与运维基线修正配置":  "Fix the configuration according to the security and operations baseline",
+	"检查复制/集群链路与成员状态，修复同步中断并确认数据一致": "Check replication / cluster links and member states, fix broken sync and verify data consistency",
+	"查看相关错误日志与慢请求，定位并修复异常根因":       "Review the related error logs and slow requests, find and fix the root cause",
+	"确认服务与节点存活，重启或切换故障实例并排查宕机原因":   "Confirm services and nodes are up, restart or fail over faulty instances and find the cause of the outage",
+	"清理过期日志与临时文件，或扩容磁盘，避免空间写满":     "Clean up expired logs and temporary files or expand the disk before it fills up",
+	"分析 JVM 堆与 GC 情况，调整堆大小或排查内存泄漏": "Analyze JVM heap and GC, adjust the heap size or look for memory leaks",
+	"排查内存占用高的进程或缓存，调整内存配置或扩容":      "Find processes or caches using much memory, tune the memory settings or add memory",
+	"定位高负载进程或慢请求，优化业务或扩容计算资源":      "Find high-load processes or slow requests, optimize the workload or add compute capacity",
+	"检查连接与线程池使用，排查连接泄漏并按需调大上限":     "Check connection and thread pool usage, look for connection leaks and raise the limits if needed",
+	"排查积压与锁等待，优化写入负载或调整后台任务参数":     "Investigate backlogs and lock waits, optimize the write load or tune background tasks",
+	"参考对应模块异常明细，核查配置与负载后处理":        "See the alert details of the module and check its configuration and load",
+	"正常主机":           "Normal Hosts",
+	"正常后端":           "Healthy Backends",
+	"正常实例":           "Normal Instances",
+	"正常组件":           "Normal Components",
+	"正常节点":           "Normal Nodes",
+	"正常调度器":          "Normal Directors",
+	"每核负载":           "Load per Core",
+	"活跃连接":           "Active Connections",
+	"活跃连接数":          "Active Connections",
+	"状态":             "Status",
+	"状态筛选":           "Status Filter",
+	"监控服务数":          "Monitored Services",
+	"监控组件详情":         "Monitoring Component Details",
+	"目标":             "Target",
+	"真实服务器":          "Real Servers",
+	"真实服务器数":         "Real Servers",
+	"碎片率":            "Fragmentation Ratio",
+	"磁盘%":            "Disk %",
+	"磁盘最大使用率(%)":     "Max Disk Usage (%)",
+	"磁盘最大利用率":        "Max Disk Usage",
+	"离线 Brick":       "Offline Bricks",
+	"窗口内 CPU 峰值":     "Peak CPU in Window",
+	"端口":             "Port",
+	"端口/容器":          "Port/Container",
+	"系统版本":           "OS Version",
+	"级别":             "Level",
+	"线程池饱和度":         "Thread Pool Saturation",
+	"组件":             "Component",
+	"组件总数":           "Total Components",
+	"节点总数":           "Total Nodes",
+	"节点标识":           "Node ID",
+	"节点状态":           "Node Status",
+	"节点角色":           "Node Role",
+	"虚拟服务":           "Virtual Service",
+	"虚拟服务数":          "Virtual Services",
+	"角色":             "Role",
+	"警告主机":           "Warning Hosts",
+	"警告告警":           "Warning Alerts",
+	"警告实例":           "Warning Instances",
+	"警告组件":           "Warning Components",
+	"警告节点":           "Warning Nodes",
+	"警告调度器":          "Warning Directors",
+	"警告阈值":           "Warning Threshold",
+	"请求队列":           "Request Queue",
+	"调度器":            "Director",
+	"调度器总数":          "Total Directors",
+	"资源 ID":          "Resource ID",
+	"资源名称":           "Resource Name",
+	"资源总数":           "Total Resources",
+	"资源数":            "Resources",
+	"资源类型":           "Resource Type",
+	"过期/秒":           "Expired/s",
+	"运行时长":           "Uptime",
+	"运行时间":           "Uptime",
+	"运行状态":           "Running Status",
+	"运行线程数":          "Threads Running",
+	"连接使用率":          "Connection Usage",
+	"连接同步":           "Connection Sync",
+	"连接数":            "Connections",
+	"连接数使用率":         "Connection Usage",
+	"连接状态":           "Connection Status",
+	"连接的从节点":         "Connected Replicas",
+	"采集时间":           "Collected At",
+	"查询语句":           "Query",
+	"采集状态":           "Collection Status",
+	"错误信息":           "Error",
+	"错误日志路径":         "Error Log Path",
+	"队列事务数":          "Queued Transactions",
+	"集群名":            "Cluster",
+	"集群模式":           "Cluster Mode",
+	"非root用户":        "Non-root User",
+	"非活跃连接":          "Inactive Connections",
+	"驱逐/秒":           "Evicted/s",
+	"主机 CPU / 内存利用率": "Host CPU / Memory Usage",
+	"设备地址":           "Device Address",
+	"设备名称":           "Device Name",
+	"设备类型":           "Device Type",
+	"设备总数":           "Total Devices",
+	"会话表使用率":         "Session Table Usage",
+	"当前会话数":          "Active Sessions",
+	"VPN 隧道（断开/总数）":  "VPN Tunnels (Down/Total)",
+	"隧道名称":           "Tunnel Name",
+	"断开隧道":           "Tunnels Down",
+	"存在断开隧道":         "Tunnels Down",
+	"HA 状态":          "HA Status",
+	"HA 异常":          "HA Abnormal",
+	"HA 状态异常":        "HA Abnormal",
+
+	// HTML section titles
+	"AD 域控制器巡检":         "AD Domain Controller Inspection",
+	"AD 域控制器巡检概览":       "AD Domain Controller Overview",
+	"AD 异常汇总":           "AD Alerts",
+	"Cassandra 巡检概览":    "Cassandra Overview",
+	"Cassandra 异常汇总":    "Cassandra Alerts",
+	"Cassandra 节点详情":    "Cassandra Node Details",
+	"LVS 巡检概览":          "LVS Overview",
+	"LVS 异常汇总":          "LVS Alerts",
+	"LVS 调度器详情":         "LVS Director Details",
+	"MySQL 实例详情":        "MySQL Instance Details",
+	"MySQL 巡检报告":        "MySQL Inspection Report",
+	"MySQL 巡检概览":        "MySQL Overview",
+	"MySQL 异常汇总":        "MySQL Alerts",
+	"MySQL MGR 组成员":     "MySQL MGR Group Members",
+	"MySQL 数据库巡检":       "MySQL Database Inspection",
+	"MySQL 巡检工具":        "MySQL Inspection Tool",
+	"Nginx Upstream 概览": "Nginx Upstream Overview",
+	"Nginx 实例详情":        "Nginx Instance Details",
+	"Nginx 巡检报告":        "Nginx Inspection Report",
+	"Nginx 巡检概览":        "Nginx Overview",
+	"Nginx 异常汇总":        "Nginx Alerts",
+	"Redis 实例详情":        "Redis Instance Details",
+	"Redis 巡检报告":        "Redis Inspection Report",
+	"Redis 巡检概览":        "Redis Overview",
+	"Redis 异常汇总":        "Redis Alerts",
+	"Redis 数据库巡检":       "Redis Database Inspection",
+	"Redis 巡检工具":        "Redis Inspection Tool",
+	"Tomcat 实例详情":       "Tomcat Instance Details",
+	"Tomcat 巡检报告":       "Tomcat Inspection Report",
+	"Tomcat 巡检概览":       "Tomcat Overview",
+	"Tomcat 异常汇总":       "Tomcat Alerts",
+	"Tomcat 巡检工具":       "Tomcat Inspection Tool",
+	"Windows 主机详情":      "Windows Host Details",
+	"Windows 异常汇总":      "Windows Alerts",
+	"Windows 服务巡检概览":    "Windows Services Overview",
+	"主机巡检":              "Host Inspection",
+	"云资源巡检概览":           "Cloud Resources Overview",
+	"云资源异常汇总":           "Cloud Resource Alerts",
+	"防火墙巡检概览":           "Firewall Overview",
+	"防火墙异常汇总":           "Firewall Alerts",
+	"防火墙设备详情":           "Firewall Device Details",
+	"正在触发的告警":           "Firing Alerts",
+	"共享存储巡检概览":          "Shared Storage Overview",
+	"共享存储异常汇总":          "Shared Storage Alerts",
+	"监控系统巡检概览":          "Monitoring Overview",
+	"监控系统异常汇总":          "Monitoring Alerts",
+	"系统巡检报告":            "System Inspection Report",
+	"阿里云":               "Alibaba Cloud",
+	"条":                 "entries",
+	"系统巡检工具":            "System Inspection Tool",
+	"第 &P 页 / 共 &N 页":   "Page &P of &N",
+
+	// Formatted texts
+	"%.1f秒":        "%.1fs",
+	"%.1f分钟":       "%.1f min",
+	"%.1f小时":       "%.1f h",
+	"%.0f分钟":       "%.0f min",
+	"%.0f 小时":      "%.0f hours",
+	"%d 天":         "%d days",
+	"%d天 %s":       "%dd %s",
+	"%s 告警":        "%s Alerts",
+	"巡检时间: %s":     "Inspection Time: %s",
+	"耗时: %s":       "Duration: %s",
+	"版本: %s":       "Version: %s",
+	"期望: %s":       "Expected: %s",
+	"报告生成时间: %s":   "Generated At: %s",
+	"磁盘:%s":        "Disk:%s",
+	"磁盘使用率 Top %d": "Top %d Disk Usage",
+	"磁盘利用率 Top %d": "Top %d Disk Usage",
+	"内存利用率 Top %d": "Top %d Memory Usage",
+	"每核负载 Top %d":  "Top %d Load per Core",
+	"%s ~ %s（即时查询，取查询执行时刻的最新值）": "%s ~ %s (instant queries, latest value at query time)",
+	"Redis 集群 - %s":                "Redis Cluster - %s",
+	"MGR 组: %s（在线 %d/%d）":          "MGR Group: %s (online %d/%d)",
+	"同步: %s":                       "Sync: %s",
+	"%s（未采集复制来源）":                  "%s (replication sources not collected)",
+	"日志摘录 (%d 行)":                  "Log Excerpt (%d lines)",
+	"共 %d 条告警（严重 %d，警告 %d）":        "%d alerts (%d critical, %d warning)",
+	"另有 %d 条告警未列出，详见完整巡检报告。":       "%d more alerts are not listed, see the full inspection report.",
+	"统计窗口: %s，低利用率判定: CPU 峰值 < %s": "Window: %s, idle when peak CPU < %s",
+	"%s 等共 %d 个":                   "%s (%d in total)",
+}
@@ -0,0 +1,261 @@
+// Package i18n provides the localization of report strings (sheet names, column headers,
+// status texts and template labels). Reports are built with Chinese strings; a Translator
+// maps them to English, or to Chinese / English bilingual text, when the report is written.
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Report languages.
+const (
+	LanguageZH        = "zh"    // Chinese (default)
+	LanguageEN        = "en"    // English
+	LanguageBilingual = "zh-en" // Chinese followed by English
+)
+
+// maxSheetNameLength is Excel's sheet name length limit, in characters.
+const maxSheetNameLength = 31
+
+// bilingualSeparator joins the Chinese and English text in bilingual reports.
+const bilingualSeparator = " / "
+
+// Translator localizes report strings into a report language.
+// A nil Translator, or one for LanguageZH, returns strings unchanged.
+type Translator struct {
+	lang string
+}
+
+// New returns a Translator for lang. Unknown languages and "" fall back to Chinese.
+func New(lang string) *Translator {
+	switch lang {
+	case LanguageEN, LanguageBilingual:
+		return &Translator{lang: lang}
+	default:
+		return &Translator{lang: LanguageZH}
+	}
+}
+
+// Language returns the report language of the translator.
+func (t *Translator) Language() string {
+	if t == nil {
+		return LanguageZH
+	}
+	return t.lang
+}
+
+// HTMLLang returns the value of the HTML lang attribute for the report language.
+func (t *Translator) HTMLLang() string {
+	if t.Language() == LanguageEN {
+		return "en"
+	}
+	return "zh-CN"
+}
+
+// Enabled reports whether strings are translated at all.
+func (t *Translator) Enabled() bool {
+	return t.Language() != LanguageZH
+}
+
+// T translates a catalog string. Strings not in the catalog are returned unchanged.
+func (t *Translator) T(zh string) string {
+	if !t.Enabled() {
+		return zh
+	}
+	en, ok := catalog[zh]
+	if !ok {
+		return zh
+	}
+	return t.join(zh, en)
+}
+
+// Text translates a piece of report text: a catalog string, a formatted catalog string
+// (e.g. "巡检时间: 2025-01-01 10:00"), either of them after a leading icon, or several of
+// them separated by "|". Text that does not match is returned unchanged, so translating
+// translated text is a no-op.
+func (t *Translator) Text(s string) string {
+	if !t.Enabled() {
+		return s
+	}
+	if en, ok := t.translate(s); ok {
+		return en
+	}
+
+	// "报告生成时间: ... | 版本: ... | 系统巡检工具"
+	if strings.Contains(s, "|") {
+		parts := strings.Split(s, "|")
+		changed := false
+		for i, part := range parts {
+			core := strings.TrimFunc(part, unicode.IsSpace)
+			if core == "" {
+				continue
+			}
+			if en, ok := t.translate(core); ok {
+				parts[i] = strings.Replace(part, core, en, 1)
+				changed = true
+			}
+		}
+		if changed {
+			return strings.Join(parts, "|")
+		}
+	}
+
+	return s
+}
+
+// SheetName translates a sheet name. Bilingual names are "中文 English", falling back to the
+// English name when longer than Excel's 31-character limit; names without a translation that
+// fits keep the Chinese name.
+func (t *Translator) SheetName(zh string) string {
+	if !t.Enabled() {
+		return zh
+	}
+	en, ok := catalog[zh]
+	if !ok || len([]rune(en)) > maxSheetNameLength {
+		return zh
+	}
+	if t.lang == LanguageBilingual && en != zh {
+		if name := zh + " " + en; len([]rune(name)) <= maxSheetNameLength {
+			return name
+		}
+	}
+	return en
+}
+
+// translate translates s as a whole, keeping a leading icon (emoji) and surrounding spaces.
+func (t *Translator) translate(s string) (string, bool) {
+	core := strings.TrimFunc(s, unicode.IsSpace)
+	if core == "" {
+		return s, false
+	}
+	if i := strings.IndexFunc(core, isTextRune); i > 0 {
+		core = core[i:]
+	}
+
+	// Bilingual text that is already translated
+	if t.lang == LanguageBilingual {
+		if i := strings.LastIndex(core, bilingualSeparator); i >= 0 {
+			if en, ok := lookup(core[:i]); ok && en == core[i+len(bilingualSeparator):] {
+				return s, false
+			}
+		}
+	}
+
+	en, ok := lookup(core)
+	if !ok {
+		return s, false
+	}
+	return strings.Replace(s, core, t.join(core, en), 1), true
+}
+
+// lookup returns the English text of a catalog string or of text formatted from a catalog format.
+func lookup(zh string) (string, bool) {
+	if en, ok := catalog[zh]; ok {
+		return en, true
+	}
+	return matchPattern(zh)
+}
+
+// join returns the text for the report language from the Chinese and English text.
+func (t *Translator) join(zh, en string) string {
+	if t.lang == LanguageBilingual && en != zh {
+		return zh + bilingualSeparator + en
+	}
+	return en
+}
+
+// isTextRune reports whether r starts the text after a leading icon.
+func isTextRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// pattern is a catalog entry with format verbs, matched against formatted text.
+type pattern struct {
+	zh      string         // Chinese format
+	literal string         // Longest literal part, checked before the regular expression
+	re      *regexp.Regexp // Matches text formatted from the Chinese format
+	en      string         // English format
+}
+
+var (
+	formatVerbPattern = regexp.MustCompile(`%%|%(?:\.\d+)?[dfs]`)
+	patterns          = compilePatterns()
+)
+
+// compilePatterns compiles the catalog entries with format verbs into patterns.
+func compilePatterns() []*pattern {
+	var list []*pattern
+	for zh, en := range catalog {
+		if !formatVerbPattern.MatchString(strings.ReplaceAll(zh, "%%", "")) {
+			continue
+		}
+		p := &pattern{zh: zh, en: en}
+		var expr strings.Builder
+		expr.WriteString("^")
+		last := 0
+		for _, loc := range formatVerbPattern.FindAllStringIndex(zh, -1) {
+			literal := zh[last:loc[0]]
+			if len(literal) > len(p.literal) {
+				p.literal = literal
+			}
+			expr.WriteString(regexp.QuoteMeta(literal))
+			switch verb := zh[loc[0]:loc[1]]; {
+			case verb == "%%":
+				expr.WriteString("%")
+			case strings.HasSuffix(verb, "s"):
+				// Values never span the "|" separating the parts of a text
+				expr.WriteString(`([^|]+?)`)
+			default:
+				expr.WriteString(`(-?\d+(?:\.\d+)?)`)
+			}
+			last = loc[1]
+		}
+		if literal := zh[last:]; len(literal) > len(p.literal) {
+			p.literal = literal
+		}
+		expr.WriteString(regexp.QuoteMeta(zh[last:]))
+		expr.WriteString("$")
+		p.re = regexp.MustCompile(expr.String())
+		list = append(list, p)
+	}
+
+	// Try the most specific patterns first, so overlapping patterns match deterministically
+	sort.Slice(list, func(i, j int) bool {
+		if len(list[i].literal) != len(list[j].literal) {
+			return len(list[i].literal) > len(list[j].literal)
+		}
+		return list[i].zh < list[j].zh
+	})
+	return list
+}
+
+// matchPattern translates text formatted from a catalog format, substituting the formatted
+// values into the English format in order. Values that are report strings themselves (e.g. the
+// module name of "主机 告警" or the duration of "耗时: 5.0秒") are translated as well.
+func matchPattern(s string) (string, bool) {
+	for _, p := range patterns {
+		if !strings.Contains(s, p.literal) {
+			continue
+		}
+		m := p.re.FindStringSubmatch(s)
+		if m == nil {
+			continue
+		}
+		args := m[1:]
+		return formatVerbPattern.ReplaceAllStringFunc(p.en, func(verb string) string {
+			if verb == "%%" || len(args) == 0 {
+				return "%"
+			}
+			arg := args[0]
+			args = args[1:]
+			if en, ok := lookup(arg); ok {
+				return en
+			}
+			return arg
+		}), true
+	}
+	return "", false
+}
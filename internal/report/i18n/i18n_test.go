@@ -0,0 +1,128 @@
+package i18n
+
+import "testing"
+
+func TestTranslator_T(t *testing.T) {
+	tests := []struct {
+		lang string
+		in   string
+		want string
+	}{
+		{LanguageZH, "正常", "正常"},
+		{LanguageEN, "正常", "Normal"},
+		{LanguageBilingual, "正常", "正常 / Normal"},
+		{LanguageEN, "未收录的文本", "未收录的文本"},
+		{"", "严重", "严重"},
+		{"fr", "严重", "严重"},
+	}
+
+	for _, tt := range tests {
+		if got := New(tt.lang).T(tt.in); got != tt.want {
+			t.Errorf("New(%q).T(%q) = %q, want %q", tt.lang, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTranslator_NilIsChinese(t *testing.T) {
+	var tr *Translator
+	if got := tr.Text("巡检时间: 2025-01-01"); got != "巡检时间: 2025-01-01" {
+		t.Errorf("nil Translator Text() = %q, want unchanged", got)
+	}
+	if tr.Enabled() {
+		t.Error("nil Translator should not be enabled")
+	}
+}
+
+func TestTranslator_Text(t *testing.T) {
+	en := New(LanguageEN)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"  严重  ", "  Critical  "},
+		{"巡检时间: 2025-01-01 10:00:00", "Inspection Time: 2025-01-01 10:00:00"},
+		{"📅 巡检时间: 2025-01-01", "📅 Inspection Time: 2025-01-01"},
+		{"3.5秒", "3.5s"},
+		{"30 天", "30 days"},
+		{"共 3 条告警（严重 1，警告 2）", "3 alerts (1 critical, 2 warning)"},
+		{"主机 告警", "Host Alerts"},
+		{"耗时: 5.0秒", "Duration: 5.0s"},
+		{"4500.0% (数据异常)", "4500.0% (invalid data)"},
+		{"MGR 组: g1（在线 2/3）", "MGR Group: g1 (online 2/3)"},
+		{"报告生成时间: 2025-01-01 | 版本: v1.0 | 系统巡检工具", "Generated At: 2025-01-01 | Version: v1.0 | System Inspection Tool"},
+		{"web-01", "web-01"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := en.Text(tt.in); got != tt.want {
+			t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTranslator_Text_Idempotent(t *testing.T) {
+	inputs := []string{"严重", "巡检时间: 2025-01-01", "共 3 条告警（严重 1，警告 2）", "报告生成时间: x | 版本: v1 | 系统巡检工具"}
+	for _, lang := range []string{LanguageEN, LanguageBilingual} {
+		tr := New(lang)
+		for _, in := range inputs {
+			once := tr.Text(in)
+			if twice := tr.Text(once); twice != once {
+				t.Errorf("[%s] Text(Text(%q)) = %q, want %q", lang, in, twice, once)
+			}
+		}
+	}
+}
+
+func TestTranslator_Text_Bilingual(t *testing.T) {
+	tr := New(LanguageBilingual)
+	if got, want := tr.Text("巡检时间: 2025-01-01"), "巡检时间: 2025-01-01 / Inspection Time: 2025-01-01"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+	if got, want := tr.T("Nginx Upstream"), "Nginx Upstream"; got != want {
+		t.Errorf("T() = %q, want %q (identical translations are not repeated)", got, want)
+	}
+}
+
+func TestTranslator_SheetName(t *testing.T) {
+	tests := []struct {
+		lang string
+		in   string
+		want string
+	}{
+		{LanguageZH, "异常汇总", "异常汇总"},
+		{LanguageEN, "异常汇总", "Alerts"},
+		{LanguageBilingual, "异常汇总", "异常汇总 Alerts"},
+		{LanguageBilingual, "Nginx Upstream", "Nginx Upstream"},
+		{LanguageEN, "Redis-192.168.1", "Redis-192.168.1"},
+	}
+
+	for _, tt := range tests {
+		got := New(tt.lang).SheetName(tt.in)
+		if got != tt.want {
+			t.Errorf("New(%q).SheetName(%q) = %q, want %q", tt.lang, tt.in, got, tt.want)
+		}
+		if n := len([]rune(got)); n > maxSheetNameLength {
+			t.Errorf("SheetName(%q) = %q has %d characters, exceeds %d", tt.in, got, n, maxSheetNameLength)
+		}
+	}
+}
+
+func TestCatalog_SheetNamesFitExcelLimit(t *testing.T) {
+	tr := New(LanguageBilingual)
+	for zh := range catalog {
+		if n := len([]rune(tr.SheetName(zh))); n > maxSheetNameLength {
+			t.Errorf("SheetName(%q) has %d characters, exceeds %d", zh, n, maxSheetNameLength)
+		}
+	}
+}
+
+func TestCatalog_PatternVerbsMatch(t *testing.T) {
+	for zh, en := range catalog {
+		zhVerbs := formatVerbPattern.FindAllString(zh, -1)
+		enVerbs := formatVerbPattern.FindAllString(en, -1)
+		if len(zhVerbs) != len(enVerbs) {
+			t.Errorf("catalog[%q] = %q: %d format verbs, want %d", zh, en, len(enVerbs), len(zhVerbs))
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CheckpointFilename is the name of the checkpoint file in the report output directory.
+const CheckpointFilename = ".inspection_checkpoint.json"
+
+// Checkpoint holds the results of the modules an inspection has completed, and the partial
+// progress of the modules still running. It is saved after each module and each partial
+// result, so that a run interrupted mid-way can resume without querying again what was
+// already collected.
+type Checkpoint struct {
+	SchemaVersion string          `json:"schema_version"`
+	StartedAt     time.Time       `json:"started_at"`
+	ConfigPath    string          `json:"config_path"` // Configuration file of the inspection
+	Scope         CheckpointScope `json:"scope"`
+
+	// Modules maps the key of each completed module to its result, encoded the way the
+	// module is in Report.
+	Modules map[string]stdjson.RawMessage `json:"modules"`
+
+	// Partial maps the key of each module that has not completed to the results it
+	// collected so far, by an identifier of the module (as the metric name for the host
+	// module). The partial results of a module are dropped once it completes.
+	Partial map[string]map[string]stdjson.RawMessage `json:"partial,omitempty"`
+}
+
+// CheckpointScope is what the results of an inspection depend on besides the configuration
+// file. A checkpoint is only resumed by an inspection of the same scope, so that results of
+// other modules, hosts or time windows are not merged into its report.
+type CheckpointScope struct {
+	Modules        []string      `json:"modules"` // Module keys to inspect, resumed ones included
+	Preset         string        `json:"preset,omitempty"`
+	BusinessGroups []string      `json:"business_groups,omitempty"`
+	ExcludeHosts   []string      `json:"exclude_hosts,omitempty"`
+	RangeWindow    time.Duration `json:"range_window,omitempty"`
+	RangeStart     string        `json:"range_start,omitempty"` // --start as given, "" when unset
+	RangeEnd       string        `json:"range_end,omitempty"`   // --end as given, "" for the query time
+}
+
+// NewCheckpoint creates an empty checkpoint for an inspection of configPath over scope,
+// started at startedAt.
+func NewCheckpoint(configPath string, scope CheckpointScope, startedAt time.Time) *Checkpoint {
+	return &Checkpoint{
+		SchemaVersion: SchemaVersion,
+		StartedAt:     startedAt,
+		ConfigPath:    configPath,
+		Scope:         scope,
+		Modules:       make(map[string]stdjson.RawMessage),
+	}
+}
+
+// Complete records the result of a completed module.
+func (c *Checkpoint) Complete(module string, result any) error {
+	data, err := stdjson.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s result: %w", module, err)
+	}
+	c.Modules[module] = data
+	delete(c.Partial, module)
+	return nil
+}
+
+// SavePartial records a partial result of a module that has not completed.
+func (c *Checkpoint) SavePartial(module, key string, result any) error {
+	data, err := stdjson.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s result %s: %w", module, key, err)
+	}
+	if c.Partial == nil {
+		c.Partial = make(map[string]map[string]stdjson.RawMessage)
+	}
+	if c.Partial[module] == nil {
+		c.Partial[module] = make(map[string]stdjson.RawMessage)
+	}
+	c.Partial[module][key] = data
+	return nil
+}
+
+// LoadPartial decodes a partial result of a module into result. Reports false when the
+// module saved no result under key.
+func (c *Checkpoint) LoadPartial(module, key string, result any) (bool, error) {
+	data, ok := c.Partial[module][key]
+	if !ok {
+		return false, nil
+	}
+	if err := stdjson.Unmarshal(data, result); err != nil {
+		return false, fmt.Errorf("failed to decode %s result %s: %w", module, key, err)
+	}
+	return true, nil
+}
+
+// PartialCount returns the number of partial results saved by a module.
+func (c *Checkpoint) PartialCount(module string) int {
+	return len(c.Partial[module])
+}
+
+// Done reports whether the module completed.
+func (c *Checkpoint) Done(module string) bool {
+	_, ok := c.Modules[module]
+	return ok
+}
+
+// Restore returns a report holding the results of the given modules that completed, and
+// the keys of those modules in the given order.
+func (c *Checkpoint) Restore(modules []string) (*Report, []string, error) {
+	selected := make(map[string]stdjson.RawMessage)
+	var restored []string
+	for _, module := range modules {
+		if data, ok := c.Modules[module]; ok {
+			selected[module] = data
+			restored = append(restored, module)
+		}
+	}
+
+	// The module keys are the field names of Report
+	data, err := stdjson.Marshal(selected)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode checkpoint results: %w", err)
+	}
+	report := &Report{SchemaVersion: SchemaVersion, GeneratedAt: c.StartedAt}
+	if err := stdjson.Unmarshal(data, report); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode checkpoint results: %w", err)
+	}
+	return report, restored, nil
+}
+
+// Save writes the checkpoint to path. The file is replaced atomically, so a run killed
+// while saving keeps the previous checkpoint.
+func (c *Checkpoint) Save(path string) error {
+	data, err := stdjson.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ReadCheckpoint reads a checkpoint written by Save. Checkpoints of another schema version
+// are rejected, like the reports.
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var checkpoint Checkpoint
+	if err := stdjson.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if checkpoint.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("unsupported checkpoint schema version %q in %s (expected %q)", checkpoint.SchemaVersion, path, SchemaVersion)
+	}
+	if checkpoint.Modules == nil {
+		checkpoint.Modules = make(map[string]stdjson.RawMessage)
+	}
+
+	return &checkpoint, nil
+}
@@ -0,0 +1,134 @@
+package json
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/model"
+)
+
+func TestCheckpoint_SaveAndRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CheckpointFilename)
+	startedAt := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	scope := CheckpointScope{
+		Modules:        []string{ModuleHost, ModuleMySQL, ModuleRedis},
+		BusinessGroups: []string{"业务组A"},
+		ExcludeHosts:   []string{"test-*"},
+		RangeWindow:    24 * time.Hour,
+		RangeStart:     "2026-10-15 00:00",
+	}
+	checkpoint := NewCheckpoint("/etc/inspect/config.yaml", scope, startedAt)
+	if err := checkpoint.Complete(ModuleHost, createTestInspectionResult()); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := checkpoint.Complete(ModuleRedis, &model.RedisInspectionResults{Duration: time.Minute}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := checkpoint.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	saved, err := ReadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("ReadCheckpoint() error = %v", err)
+	}
+	if saved.ConfigPath != "/etc/inspect/config.yaml" || !saved.StartedAt.Equal(startedAt) {
+		t.Errorf("ReadCheckpoint() = %+v, want the saved config path and start time", saved)
+	}
+	if !reflect.DeepEqual(saved.Scope, scope) {
+		t.Errorf("ReadCheckpoint() Scope = %+v, want %+v", saved.Scope, scope)
+	}
+	if !saved.Done(ModuleHost) || !saved.Done(ModuleRedis) || saved.Done(ModuleMySQL) {
+		t.Errorf("Done() = host %v, redis %v, mysql %v, want true, true, false",
+			saved.Done(ModuleHost), saved.Done(ModuleRedis), saved.Done(ModuleMySQL))
+	}
+
+	// Only the requested modules are restored
+	report, restored, err := saved.Restore([]string{ModuleMySQL, ModuleRedis})
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if !slices.Equal(restored, []string{ModuleRedis}) {
+		t.Errorf("Restore() restored %v, want [redis]", restored)
+	}
+	if report.Host != nil || report.MySQL != nil {
+		t.Error("Restore() should leave the modules not requested or not completed nil")
+	}
+	if report.Redis == nil || report.Redis.Duration != time.Minute {
+		t.Errorf("Restore() Redis = %+v, want the saved result", report.Redis)
+	}
+
+	report, _, err = saved.Restore([]string{ModuleHost})
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if report.Host == nil || report.Host.Summary.WarningHosts != 1 {
+		t.Errorf("Restore() Host = %+v, want the saved result", report.Host)
+	}
+
+	// The temporary file of the atomic write is removed
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("output directory holds %d files, want only the checkpoint", len(entries))
+	}
+}
+
+func TestCheckpoint_Partial(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CheckpointFilename)
+	checkpoint := NewCheckpoint("/etc/inspect/config.yaml", CheckpointScope{Modules: []string{ModuleHost}}, time.Now())
+	if err := checkpoint.SavePartial(ModuleHost, "cpu_usage", []float64{45.5, 78.2}); err != nil {
+		t.Fatalf("SavePartial() error = %v", err)
+	}
+	if err := checkpoint.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	saved, err := ReadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("ReadCheckpoint() error = %v", err)
+	}
+	if saved.Done(ModuleHost) || saved.PartialCount(ModuleHost) != 1 {
+		t.Errorf("Done() = %v, PartialCount() = %d, want false and 1", saved.Done(ModuleHost), saved.PartialCount(ModuleHost))
+	}
+	var values []float64
+	if ok, err := saved.LoadPartial(ModuleHost, "cpu_usage", &values); !ok || err != nil || !slices.Equal(values, []float64{45.5, 78.2}) {
+		t.Errorf("LoadPartial() = %v, %v, values %v, want the saved values", ok, err, values)
+	}
+	if ok, err := saved.LoadPartial(ModuleHost, "memory_usage", &values); ok || err != nil {
+		t.Errorf("LoadPartial() of an unsaved result = %v, %v, want false and no error", ok, err)
+	}
+
+	// The partial results are dropped once the module completes
+	if err := saved.Complete(ModuleHost, createTestInspectionResult()); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if saved.PartialCount(ModuleHost) != 0 {
+		t.Errorf("PartialCount() after Complete() = %d, want 0", saved.PartialCount(ModuleHost))
+	}
+}
+
+func TestReadCheckpoint_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	oldSchema := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(oldSchema, []byte(`{"schema_version":"0","modules":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCheckpoint(oldSchema); err == nil {
+		t.Error("ReadCheckpoint() should reject another schema version")
+	}
+
+	if _, err := ReadCheckpoint(filepath.Join(dir, "missing.json")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadCheckpoint() error = %v, want a not exist error", err)
+	}
+}
@@ -0,0 +1,153 @@
+package json
+
+import (
+	"sort"
+
+	"inspection-tool/internal/model"
+)
+
+// alertKey identifies an alert across inspection runs.
+type alertKey struct {
+	module, identifier, metric string
+}
+
+// Diff compares two JSON reports: alerts only in newer are new, alerts only in older are
+// resolved, and alerts in both with another level are escalated or downgraded. Alert
+// changes follow the order of the report they come from (new and changed alerts first).
+// Host metric values present in both reports are compared per host; unchanged values and
+// N/A values are left out.
+func Diff(older, newer *Report) *model.ReportDiff {
+	diff := &model.ReportDiff{
+		OldTime:      older.GeneratedAt,
+		NewTime:      newer.GeneratedAt,
+		Alerts:       make([]*model.AlertChange, 0),
+		MetricDeltas: make([]*model.MetricDelta, 0),
+	}
+
+	oldAlerts := indexAlerts(older.Alerts)
+	newAlerts := indexAlerts(newer.Alerts)
+
+	for _, a := range newer.Alerts {
+		key := alertKey{a.Module, a.Identifier, a.MetricName}
+		old, ok := oldAlerts[key]
+		switch {
+		case !ok:
+			diff.Alerts = append(diff.Alerts, newAlertChange(model.AlertChangeNew, nil, a))
+		case alertLevelRank(a.Level) > alertLevelRank(old.Level):
+			diff.Alerts = append(diff.Alerts, newAlertChange(model.AlertChangeEscalated, old, a))
+		case alertLevelRank(a.Level) < alertLevelRank(old.Level):
+			diff.Alerts = append(diff.Alerts, newAlertChange(model.AlertChangeDowngraded, old, a))
+		}
+	}
+	for _, a := range older.Alerts {
+		if _, ok := newAlerts[alertKey{a.Module, a.Identifier, a.MetricName}]; !ok {
+			diff.Alerts = append(diff.Alerts, newAlertChange(model.AlertChangeResolved, a, nil))
+		}
+	}
+
+	diffHosts(diff, older.Host, newer.Host)
+	return diff
+}
+
+// indexAlerts maps alerts by module, identifier and metric. Repeated alerts keep the first.
+func indexAlerts(alerts []*Alert) map[alertKey]*Alert {
+	index := make(map[alertKey]*Alert, len(alerts))
+	for _, a := range alerts {
+		key := alertKey{a.Module, a.Identifier, a.MetricName}
+		if _, ok := index[key]; !ok {
+			index[key] = a
+		}
+	}
+	return index
+}
+
+// newAlertChange builds an alert change from the older and newer alert; either may be nil.
+func newAlertChange(t model.AlertChangeType, older, newer *Alert) *model.AlertChange {
+	latest := newer
+	if latest == nil {
+		latest = older
+	}
+	change := &model.AlertChange{
+		Type:              t,
+		Module:            ModuleName(latest.Module),
+		Identifier:        latest.Identifier,
+		MetricName:        latest.MetricName,
+		MetricDisplayName: latest.MetricDisplayName,
+		Message:           latest.Message,
+	}
+	if older != nil {
+		change.OldLevel = older.Level
+		change.OldValue = older.FormattedValue
+	}
+	if newer != nil {
+		change.NewLevel = newer.Level
+		change.NewValue = newer.FormattedValue
+	}
+	return change
+}
+
+// alertLevelRank orders alert levels by severity.
+func alertLevelRank(level model.AlertLevel) int {
+	switch level {
+	case model.AlertLevelCritical:
+		return 2
+	case model.AlertLevelWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// diffHosts adds the host metric deltas and the added and removed hosts to diff.
+func diffHosts(diff *model.ReportDiff, older, newer *model.InspectionResult) {
+	oldHosts := make(map[string]*model.HostResult)
+	if older != nil {
+		for _, h := range older.Hosts {
+			if h != nil {
+				oldHosts[h.Hostname] = h
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	if newer != nil {
+		for _, h := range newer.Hosts {
+			if h == nil {
+				continue
+			}
+			seen[h.Hostname] = true
+			old, ok := oldHosts[h.Hostname]
+			if !ok {
+				diff.AddedHosts = append(diff.AddedHosts, h.Hostname)
+				continue
+			}
+			names := make([]string, 0, len(h.Metrics))
+			for name := range h.Metrics {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				mv, prev := h.Metrics[name], old.Metrics[name]
+				if mv == nil || prev == nil || mv.IsNA || prev.IsNA || mv.RawValue == prev.RawValue {
+					continue
+				}
+				diff.MetricDeltas = append(diff.MetricDeltas, &model.MetricDelta{
+					Hostname:     h.Hostname,
+					MetricName:   name,
+					OldValue:     prev.RawValue,
+					NewValue:     mv.RawValue,
+					OldFormatted: prev.FormattedValue,
+					NewFormatted: mv.FormattedValue,
+				})
+			}
+		}
+	}
+
+	if older != nil {
+		for _, h := range older.Hosts {
+			if h != nil && !seen[h.Hostname] {
+				diff.RemovedHosts = append(diff.RemovedHosts, h.Hostname)
+			}
+		}
+	}
+}
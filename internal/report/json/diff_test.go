@@ -0,0 +1,96 @@
+package json
+
+import (
+	"testing"
+
+	"inspection-tool/internal/model"
+)
+
+func TestDiff(t *testing.T) {
+	alert := func(module, identifier, metric string, level model.AlertLevel) *Alert {
+		return &Alert{Module: module, Identifier: identifier, MetricName: metric, Level: level, FormattedValue: string(level)}
+	}
+	host := func(name string, cpu float64, disk *model.MetricValue) *model.HostResult {
+		return &model.HostResult{Hostname: name, Metrics: map[string]*model.MetricValue{
+			"cpu_usage":  {Name: "cpu_usage", RawValue: cpu},
+			"disk_usage": disk,
+		}}
+	}
+
+	older := &Report{
+		Host: &model.InspectionResult{Hosts: []*model.HostResult{
+			host("host-1", 40, &model.MetricValue{RawValue: 50}),
+			host("host-2", 80, model.NewNAMetricValue("disk_usage")),
+			host("host-old", 10, nil),
+		}},
+		Alerts: []*Alert{
+			alert(ModuleHost, "host-2", "cpu_usage", model.AlertLevelWarning),
+			alert(ModuleHost, "host-1", "memory_usage", model.AlertLevelCritical),
+			alert(ModuleMySQL, "db-1:3306", "connection_usage", model.AlertLevelCritical),
+		},
+	}
+	newer := &Report{
+		Host: &model.InspectionResult{Hosts: []*model.HostResult{
+			host("host-1", 40, &model.MetricValue{RawValue: 55}),
+			host("host-2", 95, &model.MetricValue{RawValue: 60}),
+			host("host-new", 5, nil),
+		}},
+		Alerts: []*Alert{
+			alert(ModuleHost, "host-2", "cpu_usage", model.AlertLevelCritical),
+			alert(ModuleMySQL, "db-1:3306", "connection_usage", model.AlertLevelWarning),
+			alert(ModuleRedis, "redis-1:6379", "memory_usage", model.AlertLevelWarning),
+		},
+	}
+
+	diff := Diff(older, newer)
+
+	want := []struct {
+		typ        model.AlertChangeType
+		module     string
+		identifier string
+	}{
+		{model.AlertChangeEscalated, "主机", "host-2"},
+		{model.AlertChangeDowngraded, "MySQL", "db-1:3306"},
+		{model.AlertChangeNew, "Redis", "redis-1:6379"},
+		{model.AlertChangeResolved, "主机", "host-1"},
+	}
+	if len(diff.Alerts) != len(want) {
+		t.Fatalf("Diff() returned %d alert changes, want %d", len(diff.Alerts), len(want))
+	}
+	for i, w := range want {
+		if a := diff.Alerts[i]; a.Type != w.typ || a.Module != w.module || a.Identifier != w.identifier {
+			t.Errorf("alert change %d = %+v, want %s %s %s", i, a, w.typ, w.module, w.identifier)
+		}
+	}
+	if resolved := diff.Alerts[3]; resolved.OldLevel != model.AlertLevelCritical || resolved.NewLevel != "" || resolved.NewValue != "" {
+		t.Errorf("resolved alert should only carry the old level and value: %+v", resolved)
+	}
+	if diff.CountAlerts(model.AlertChangeNew) != 1 || diff.CountAlerts(model.AlertChangeResolved) != 1 {
+		t.Errorf("unexpected alert change counts")
+	}
+
+	// host-1 disk 50 → 55 and host-2 cpu 80 → 95; host-2 disk was N/A and host-1 cpu is unchanged
+	if len(diff.MetricDeltas) != 2 {
+		t.Fatalf("Diff() returned %d metric deltas, want 2: %+v", len(diff.MetricDeltas), diff.MetricDeltas)
+	}
+	if d := diff.MetricDeltas[0]; d.Hostname != "host-1" || d.MetricName != "disk_usage" || d.Delta() != 5 {
+		t.Errorf("metric delta 0 = %+v, want host-1 disk_usage +5", d)
+	}
+	if d := diff.MetricDeltas[1]; d.Hostname != "host-2" || d.MetricName != "cpu_usage" || d.Delta() != 15 {
+		t.Errorf("metric delta 1 = %+v, want host-2 cpu_usage +15", d)
+	}
+
+	if len(diff.AddedHosts) != 1 || diff.AddedHosts[0] != "host-new" {
+		t.Errorf("AddedHosts = %v, want [host-new]", diff.AddedHosts)
+	}
+	if len(diff.RemovedHosts) != 1 || diff.RemovedHosts[0] != "host-old" {
+		t.Errorf("RemovedHosts = %v, want [host-old]", diff.RemovedHosts)
+	}
+}
+
+func TestDiff_WithoutHosts(t *testing.T) {
+	diff := Diff(&Report{}, &Report{Alerts: []*Alert{{Module: ModuleCloud, Identifier: "rm-1", Level: model.AlertLevelCritical}}})
+	if len(diff.Alerts) != 1 || diff.Alerts[0].Module != "云资源" || len(diff.MetricDeltas) != 0 {
+		t.Errorf("Diff() = %+v, want one new cloud alert and no metric deltas", diff)
+	}
+}
@@ -0,0 +1,121 @@
+package json
+
+import (
+	"sort"
+	"strings"
+
+	"inspection-tool/internal/model"
+)
+
+// remediationHint maps a metric name keyword to a one-line remediation hint.
+type remediationHint struct {
+	keywords []string
+	hint     string
+}
+
+// remediationHints are matched in order against the metric name; the first rule with a
+// keyword contained in the name wins, so the more specific rules come first.
+var remediationHints = []remediationHint{
+	{[]string{"vpn_tunnel", "ha_status"}, "检查 VPN 对端与 HA 心跳链路，恢复隧道协商与主备同步"},
+	{[]string{"session"}, "排查会话数异常增长的源地址（如攻击或连接泄漏），调整会话老化时间或扩容设备"},
+	{[]string{"non_root", "ntp", "public_network", "slow_query_log", "policy"}, "按安全与运维基线修正配置"},
+	{[]string{"repl", "slave", "master_link", "mgr_", "sync_daemon"}, "检查复制/集群链路与成员状态，修复同步中断并确认数据一致"},
+	{[]string{"error", "5xx", "4xx", "failures", "dropped", "slow"}, "查看相关错误日志与慢请求，定位并修复异常根因"},
+	{[]string{"_up", "down", "offline", "unhealthy", "disconnected", "probe", "state", "mount_status"}, "确认服务与节点存活，重启或切换故障实例并排查宕机原因"},
+	{[]string{"disk", "sysvol", "binlog"}, "清理过期日志与临时文件，或扩容磁盘，避免空间写满"},
+	{[]string{"heap", "gc_"}, "分析 JVM 堆与 GC 情况，调整堆大小或排查内存泄漏"},
+	{[]string{"memory", "mem_", "evicted"}, "排查内存占用高的进程或缓存，调整内存配置或扩容"},
+	{[]string{"cpu", "load", "threads_running"}, "定位高负载进程或慢请求，优化业务或扩容计算资源"},
+	{[]string{"connection", "clients", "thread_pool", "queued", "active"}, "检查连接与线程池使用，排查连接泄漏并按需调大上限"},
+	{[]string{"compaction", "backlog", "hints", "pending", "lock", "deadlock", "history_list"}, "排查积压与锁等待，优化写入负载或调整后台任务参数"},
+}
+
+// defaultRemediationHint is used for metrics matched by no remediation hint.
+const defaultRemediationHint = "参考对应模块异常明细，核查配置与负载后处理"
+
+// NewExecutiveSummary builds the executive summary of a flattened alert list: alerts are grouped
+// by module and metric (expanded metrics such as "disk_usage:/home" count as their base metric),
+// and the ExecutiveSummaryTopRisks most severe groups are kept, ranked by critical alerts,
+// affected systems and warning alerts. Ties keep the report order of the alerts.
+func NewExecutiveSummary(alerts []*Alert) *model.ExecutiveSummary {
+	summary := &model.ExecutiveSummary{Risks: make([]*model.ExecutiveRisk, 0)}
+
+	type riskKey struct {
+		module, metric string
+	}
+	risks := make(map[riskKey]*model.ExecutiveRisk)
+	systems := make(map[riskKey]map[string]bool)
+	affected := make(map[string]bool)
+	var order []riskKey
+
+	for _, a := range alerts {
+		metric := a.MetricName
+		if idx := strings.Index(metric, ":"); idx >= 0 {
+			metric = metric[:idx]
+		}
+		key := riskKey{a.Module, metric}
+
+		risk, ok := risks[key]
+		if !ok {
+			risk = &model.ExecutiveRisk{
+				Module:            ModuleName(a.Module),
+				MetricName:        metric,
+				MetricDisplayName: a.MetricDisplayName,
+				Level:             model.AlertLevelWarning,
+				Remediation:       RemediationHint(metric),
+			}
+			risks[key] = risk
+			systems[key] = make(map[string]bool)
+			order = append(order, key)
+		}
+
+		switch a.Level {
+		case model.AlertLevelCritical:
+			risk.CriticalCount++
+			risk.Level = model.AlertLevelCritical
+			summary.CriticalAlerts++
+		case model.AlertLevelWarning:
+			risk.WarningCount++
+			summary.WarningAlerts++
+		}
+		if !systems[key][a.Identifier] {
+			systems[key][a.Identifier] = true
+			risk.Systems = append(risk.Systems, a.Identifier)
+		}
+		affected[a.Module+"/"+a.Identifier] = true
+	}
+	summary.AffectedSystems = len(affected)
+
+	for _, key := range order {
+		sort.Strings(risks[key].Systems)
+		summary.Risks = append(summary.Risks, risks[key])
+	}
+	sort.SliceStable(summary.Risks, func(i, j int) bool {
+		a, b := summary.Risks[i], summary.Risks[j]
+		if a.CriticalCount != b.CriticalCount {
+			return a.CriticalCount > b.CriticalCount
+		}
+		if len(a.Systems) != len(b.Systems) {
+			return len(a.Systems) > len(b.Systems)
+		}
+		return a.WarningCount > b.WarningCount
+	})
+	if len(summary.Risks) > model.ExecutiveSummaryTopRisks {
+		summary.Risks = summary.Risks[:model.ExecutiveSummaryTopRisks]
+	}
+
+	return summary
+}
+
+// RemediationHint returns the one-line remediation hint of a metric.
+func RemediationHint(metric string) string {
+	metric = strings.ToLower(metric)
+	for _, rule := range remediationHints {
+		for _, keyword := range rule.keywords {
+			if strings.Contains(metric, keyword) {
+				return rule.hint
+			}
+		}
+	}
+	return defaultRemediationHint
+}
@@ -0,0 +1,93 @@
+package json
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"inspection-tool/internal/model"
+)
+
+func TestNewExecutiveSummary(t *testing.T) {
+	alert := func(module, identifier, metric string, level model.AlertLevel) *Alert {
+		return &Alert{Module: module, Identifier: identifier, MetricName: metric, MetricDisplayName: metric, Level: level}
+	}
+
+	alerts := []*Alert{
+		alert(ModuleHost, "host-2", "cpu_usage", model.AlertLevelWarning),
+		alert(ModuleHost, "host-1", "cpu_usage", model.AlertLevelWarning),
+		alert(ModuleHost, "host-1", "disk_usage:/", model.AlertLevelWarning),
+		alert(ModuleHost, "host-1", "disk_usage:/data", model.AlertLevelCritical),
+		alert(ModuleMySQL, "db-1:3306", "connection_usage", model.AlertLevelWarning),
+	}
+
+	summary := NewExecutiveSummary(alerts)
+
+	if summary.CriticalAlerts != 1 || summary.WarningAlerts != 4 || summary.AffectedSystems != 3 {
+		t.Errorf("totals = (%d, %d, %d), want (1, 4, 3)", summary.CriticalAlerts, summary.WarningAlerts, summary.AffectedSystems)
+	}
+	if len(summary.Risks) != 3 {
+		t.Fatalf("NewExecutiveSummary() returned %d risks, want 3", len(summary.Risks))
+	}
+
+	disk := summary.Risks[0]
+	if disk.MetricName != "disk_usage" || disk.Level != model.AlertLevelCritical || disk.CriticalCount != 1 || disk.WarningCount != 1 {
+		t.Errorf("first risk = %+v, want the critical disk_usage risk", disk)
+	}
+	if !reflect.DeepEqual(disk.Systems, []string{"host-1"}) {
+		t.Errorf("disk risk systems = %v, want [host-1]", disk.Systems)
+	}
+	cpu := summary.Risks[1]
+	if cpu.MetricName != "cpu_usage" || cpu.Module != "主机" || !reflect.DeepEqual(cpu.Systems, []string{"host-1", "host-2"}) {
+		t.Errorf("second risk = %+v, want cpu_usage on host-1 and host-2", cpu)
+	}
+	if mysql := summary.Risks[2]; mysql.Module != "MySQL" || mysql.Level != model.AlertLevelWarning {
+		t.Errorf("third risk = %+v, want the MySQL warning", mysql)
+	}
+	if disk.Remediation == defaultRemediationHint || cpu.Remediation == defaultRemediationHint {
+		t.Error("known metrics should get a specific remediation hint")
+	}
+}
+
+func TestNewExecutiveSummary_TopRisks(t *testing.T) {
+	var alerts []*Alert
+	for i := 0; i < model.ExecutiveSummaryTopRisks+5; i++ {
+		alerts = append(alerts, &Alert{Module: ModuleHost, Identifier: "host-1", MetricName: fmt.Sprintf("metric_%d", i), Level: model.AlertLevelWarning})
+	}
+	alerts = append(alerts, &Alert{Module: ModuleRedis, Identifier: "redis-1:6379", MetricName: "redis_up", Level: model.AlertLevelCritical})
+
+	summary := NewExecutiveSummary(alerts)
+	if len(summary.Risks) != model.ExecutiveSummaryTopRisks {
+		t.Fatalf("NewExecutiveSummary() returned %d risks, want %d", len(summary.Risks), model.ExecutiveSummaryTopRisks)
+	}
+	if summary.Risks[0].MetricName != "redis_up" {
+		t.Errorf("first risk = %s, want the critical redis_up", summary.Risks[0].MetricName)
+	}
+	if summary.Risks[1].MetricName != "metric_0" {
+		t.Errorf("second risk = %s, ties should keep the report order", summary.Risks[1].MetricName)
+	}
+}
+
+func TestNewExecutiveSummary_NoAlerts(t *testing.T) {
+	summary := NewExecutiveSummary(nil)
+	if summary.HasRisks() || summary.Risks == nil {
+		t.Errorf("NewExecutiveSummary(nil) = %+v, want an empty risk list", summary)
+	}
+}
+
+func TestRemediationHint(t *testing.T) {
+	tests := map[string]string{
+		"redis_master_link_status": "检查复制/集群链路与成员状态，修复同步中断并确认数据一致",
+		"upstream_all_down":        "确认服务与节点存活，重启或切换故障实例并排查宕机原因",
+		"nginx_upstream_5xx_rate":  "查看相关错误日志与慢请求，定位并修复异常根因",
+		"disk_usage_max":           "清理过期日志与临时文件，或扩容磁盘，避免空间写满",
+		"threads_running":          "定位高负载进程或慢请求，优化业务或扩容计算资源",
+		"connection_usage":         "检查连接与线程池使用，排查连接泄漏并按需调大上限",
+		"lvs_backend_weight":       defaultRemediationHint,
+	}
+	for metric, expected := range tests {
+		if got := RemediationHint(metric); got != expected {
+			t.Errorf("RemediationHint(%q) = %q, want %q", metric, got, expected)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package json
+
+import "inspection-tool/internal/model"
+
+// NewTrendRun extracts the host trend metrics (model.TrendMetrics) of a JSON report, for
+// comparing a new inspection run with previous ones. N/A values are left out.
+func NewTrendRun(r *Report) *model.TrendRun {
+	run := &model.TrendRun{
+		InspectionTime: r.GeneratedAt,
+		Values:         make(map[string]map[string]float64),
+	}
+	if r.Host == nil {
+		return run
+	}
+
+	for _, h := range r.Host.Hosts {
+		if h == nil {
+			continue
+		}
+		values := make(map[string]float64, len(model.TrendMetrics))
+		for _, name := range model.TrendMetrics {
+			if mv := h.Metrics[name]; mv != nil && !mv.IsNA {
+				values[name] = mv.RawValue
+			}
+		}
+		if len(values) > 0 {
+			run.Values[h.Hostname] = values
+		}
+	}
+	return run
+}
@@ -0,0 +1,42 @@
+package json
+
+import (
+	"testing"
+	"time"
+
+	"inspection-tool/internal/model"
+)
+
+func TestNewTrendRun(t *testing.T) {
+	generatedAt := time.Date(2025, 12, 12, 10, 0, 0, 0, time.UTC)
+	report := &Report{
+		GeneratedAt: generatedAt,
+		Host: &model.InspectionResult{Hosts: []*model.HostResult{
+			{Hostname: "host-1", Metrics: map[string]*model.MetricValue{
+				"cpu_usage":      {RawValue: 40},
+				"memory_usage":   model.NewNAMetricValue("memory_usage"),
+				"disk_usage_max": {RawValue: 70},
+				"load_1m":        {RawValue: 2},
+			}},
+			{Hostname: "host-2", Metrics: map[string]*model.MetricValue{
+				"cpu_usage": model.NewNAMetricValue("cpu_usage"),
+			}},
+			nil,
+		}},
+	}
+
+	run := NewTrendRun(report)
+	if !run.InspectionTime.Equal(generatedAt) {
+		t.Errorf("InspectionTime = %v, want %v", run.InspectionTime, generatedAt)
+	}
+	if got := run.Values["host-1"]; len(got) != 2 || got["cpu_usage"] != 40 || got["disk_usage_max"] != 70 {
+		t.Errorf("host-1 values = %v, want cpu_usage 40 and disk_usage_max 70", got)
+	}
+	if _, ok := run.Values["host-2"]; ok {
+		t.Error("hosts without trend values should be left out")
+	}
+
+	if run := NewTrendRun(&Report{}); len(run.Values) != 0 {
+		t.Errorf("report without hosts should have no values, got %v", run.Values)
+	}
+}
@@ -0,0 +1,337 @@
+// Package json provides JSON report generation for the inspection tool.
+// It implements the report.ReportWriter interface by serializing the host result,
+// every service result and a flattened alert list into one document with a
+// versioned schema, for programmatic consumption and archival.
+package json
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"inspection-tool/internal/model"
+)
+
+// SchemaVersion is the version of the JSON report schema. It is bumped whenever a
+// field is renamed or removed; adding fields keeps the version.
+const SchemaVersion = "1"
+
+// Module keys used in the report document and in Alert.Module.
+const (
+	ModuleHost       = "host"
+	ModuleMySQL      = "mysql"
+	ModuleRedis      = "redis"
+	ModuleNginx      = "nginx"
+	ModuleTomcat     = "tomcat"
+	ModuleCassandra  = "cassandra"
+	ModuleMonitoring = "monitoring"
+	ModuleStorage    = "storage"
+	ModuleLogChecks  = "log_checks"
+	ModuleLVS        = "lvs"
+	ModuleWindows    = "windows"
+	ModuleAD         = "ad"
+	ModuleCloud      = "cloud"
+	ModuleFirewall   = "firewall"
+)
+
+// moduleNames maps the module keys to the module names used in the reports and console output.
+var moduleNames = map[string]string{
+	ModuleHost:       "主机",
+	ModuleMySQL:      "MySQL",
+	ModuleRedis:      "Redis",
+	ModuleNginx:      "Nginx",
+	ModuleTomcat:     "Tomcat",
+	ModuleCassandra:  "Cassandra",
+	ModuleMonitoring: "监控系统",
+	ModuleStorage:    "共享存储",
+	ModuleLogChecks:  "日志巡检",
+	ModuleLVS:        "LVS",
+	ModuleWindows:    "Windows",
+	ModuleAD:         "AD",
+	ModuleCloud:      "云资源",
+	ModuleFirewall:   "防火墙",
+}
+
+// ModuleName returns the display name of a module key, or the key itself if unknown.
+func ModuleName(key string) string {
+	if name, ok := moduleNames[key]; ok {
+		return name
+	}
+	return key
+}
+
+// Report is the root document of the JSON report.
+// Modules that were not inspected are omitted.
+type Report struct {
+	SchemaVersion string    `json:"schema_version"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	Version       string    `json:"version,omitempty"` // 工具版本号
+
+	Host       *model.InspectionResult            `json:"host,omitempty"`
+	MySQL      *model.MySQLInspectionResults      `json:"mysql,omitempty"`
+	Redis      *model.RedisInspectionResults      `json:"redis,omitempty"`
+	Nginx      *model.NginxInspectionResults      `json:"nginx,omitempty"`
+	Tomcat     *model.TomcatInspectionResults     `json:"tomcat,omitempty"`
+	Cassandra  *model.CassandraInspectionResults  `json:"cassandra,omitempty"`
+	Monitoring *model.MonitoringInspectionResults `json:"monitoring,omitempty"`
+	Storage    *model.StorageInspectionResults    `json:"storage,omitempty"`
+	LogChecks  *model.LogCheckInspectionResults   `json:"log_checks,omitempty"`
+	LVS        *model.LVSInspectionResults        `json:"lvs,omitempty"`
+	Windows    *model.WindowsInspectionResults    `json:"windows,omitempty"`
+	AD         *model.ADInspectionResults         `json:"ad,omitempty"`
+	Cloud      *model.CloudInspectionResults      `json:"cloud,omitempty"`
+	Firewall   *model.FirewallInspectionResults   `json:"firewall,omitempty"`
+
+	// Alerts lists the alerts of all modules in one shape, so consumers do not
+	// need to know each module's alert type.
+	Alerts []*Alert `json:"alerts"`
+}
+
+// Alert is a module-independent alert record.
+type Alert struct {
+	Module            string           `json:"module"`     // 模块（host、mysql、redis 等）
+	Identifier        string           `json:"identifier"` // 巡检对象（主机名、实例地址、资源 ID 等）
+	MetricName        string           `json:"metric_name"`
+	MetricDisplayName string           `json:"metric_display_name"`
+	CurrentValue      float64          `json:"current_value"`
+	FormattedValue    string           `json:"formatted_value"`
+	WarningThreshold  float64          `json:"warning_threshold"`
+	CriticalThreshold float64          `json:"critical_threshold"`
+	Level             model.AlertLevel `json:"level"`
+	Message           string           `json:"message"`
+}
+
+// Writer implements report.ReportWriter for JSON format.
+type Writer struct {
+	timezone *time.Location
+}
+
+// NewWriter creates a new JSON report writer.
+// If timezone is nil, it defaults to Asia/Shanghai.
+func NewWriter(timezone *time.Location) *Writer {
+	if timezone == nil {
+		timezone, _ = time.LoadLocation("Asia/Shanghai")
+	}
+	return &Writer{
+		timezone: timezone,
+	}
+}
+
+// Format returns the format identifier for this writer.
+func (w *Writer) Format() string {
+	return "json"
+}
+
+// Write generates a JSON report from the host inspection result.
+func (w *Writer) Write(result *model.InspectionResult, outputPath string) error {
+	if result == nil {
+		return fmt.Errorf("inspection result is nil")
+	}
+
+	return w.WriteCombined(&Report{Host: result}, outputPath)
+}
+
+// WriteCombined generates one JSON report containing Host, MySQL, Redis, Nginx, Tomcat,
+// Cassandra, monitoring stack, shared storage, log checks, LVS, Windows, AD, cloud
+// resource, and firewall inspection results.
+func (w *Writer) WriteCombined(report *Report, outputPath string) error {
+	// At least one result must be present
+	if report.Empty() {
+		return fmt.Errorf("all inspection results are nil")
+	}
+
+	// Ensure output path has .json extension
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".json") {
+		outputPath = outputPath + ".json"
+	}
+
+	doc := report.modules()
+	doc.SchemaVersion = SchemaVersion
+	doc.GeneratedAt = time.Now().In(w.timezone)
+	doc.Alerts = make([]*Alert, 0)
+	doc.collectAlerts()
+
+	data, err := stdjson.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON report: %w", err)
+	}
+
+	// Ensure output directory exists
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+
+	return nil
+}
+
+// ReadReport reads a JSON report written by WriteCombined. Reports of another schema
+// version are rejected, since their fields may have been renamed or removed.
+func ReadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON report: %w", err)
+	}
+
+	var report Report
+	if err := stdjson.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON report %s: %w", path, err)
+	}
+	if report.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("unsupported JSON report schema version %q in %s (expected %q)", report.SchemaVersion, path, SchemaVersion)
+	}
+
+	return &report, nil
+}
+
+// FlattenAlerts returns the alerts of all module results of report as module-independent
+// records, in report order. Nil results are skipped.
+func FlattenAlerts(report *Report) []*Alert {
+	flat := report.modules()
+	flat.collectAlerts()
+	return flat.Alerts
+}
+
+// Empty reports whether the report holds no module result.
+func (r *Report) Empty() bool {
+	return r == nil || (r.Host == nil && r.MySQL == nil && r.Redis == nil && r.Nginx == nil && r.Tomcat == nil && r.Cassandra == nil && r.Monitoring == nil && r.Storage == nil && r.LogChecks == nil && r.LVS == nil && r.Windows == nil && r.AD == nil && r.Cloud == nil && r.Firewall == nil)
+}
+
+// modules returns a copy of r holding only the module results, so that writers can fill
+// in the document fields and alerts without changing the caller's report.
+func (r *Report) modules() *Report {
+	if r == nil {
+		return &Report{}
+	}
+	return &Report{
+		Host:       r.Host,
+		MySQL:      r.MySQL,
+		Redis:      r.Redis,
+		Nginx:      r.Nginx,
+		Tomcat:     r.Tomcat,
+		Cassandra:  r.Cassandra,
+		Monitoring: r.Monitoring,
+		Storage:    r.Storage,
+		LogChecks:  r.LogChecks,
+		LVS:        r.LVS,
+		Windows:    r.Windows,
+		AD:         r.AD,
+		Cloud:      r.Cloud,
+		Firewall:   r.Firewall,
+	}
+}
+
+// collectAlerts flattens the alerts of every module into r.Alerts and takes the tool
+// version from the first module that reports one.
+func (r *Report) collectAlerts() {
+	add := func(module, identifier, metricName, displayName string, current float64, formatted string, warning, critical float64, level model.AlertLevel, message string) {
+		r.Alerts = append(r.Alerts, &Alert{
+			Module:            module,
+			Identifier:        identifier,
+			MetricName:        metricName,
+			MetricDisplayName: displayName,
+			CurrentValue:      current,
+			FormattedValue:    formatted,
+			WarningThreshold:  warning,
+			CriticalThreshold: critical,
+			Level:             level,
+			Message:           message,
+		})
+	}
+	version := func(v string) {
+		if r.Version == "" {
+			r.Version = v
+		}
+	}
+
+	if r.Host != nil {
+		version(r.Host.Version)
+		for _, a := range r.Host.Alerts {
+			add(ModuleHost, a.Hostname, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.MySQL != nil {
+		version(r.MySQL.Version)
+		for _, a := range r.MySQL.Alerts {
+			add(ModuleMySQL, a.Address, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Redis != nil {
+		version(r.Redis.Version)
+		for _, a := range r.Redis.Alerts {
+			add(ModuleRedis, a.Address, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Nginx != nil {
+		version(r.Nginx.Version)
+		for _, a := range r.Nginx.Alerts {
+			add(ModuleNginx, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Tomcat != nil {
+		version(r.Tomcat.Version)
+		for _, a := range r.Tomcat.Alerts {
+			add(ModuleTomcat, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Cassandra != nil {
+		version(r.Cassandra.Version)
+		for _, a := range r.Cassandra.Alerts {
+			add(ModuleCassandra, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Monitoring != nil {
+		version(r.Monitoring.Version)
+		for _, a := range r.Monitoring.Alerts {
+			add(ModuleMonitoring, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Storage != nil {
+		version(r.Storage.Version)
+		for _, a := range r.Storage.Alerts {
+			add(ModuleStorage, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.LogChecks != nil {
+		version(r.LogChecks.Version)
+		for _, a := range r.LogChecks.Alerts {
+			add(ModuleLogChecks, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.LVS != nil {
+		version(r.LVS.Version)
+		for _, a := range r.LVS.Alerts {
+			add(ModuleLVS, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Windows != nil {
+		version(r.Windows.Version)
+		for _, a := range r.Windows.Alerts {
+			add(ModuleWindows, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.AD != nil {
+		version(r.AD.Version)
+		for _, a := range r.AD.Alerts {
+			add(ModuleAD, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Cloud != nil {
+		version(r.Cloud.Version)
+		for _, a := range r.Cloud.Alerts {
+			add(ModuleCloud, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+	if r.Firewall != nil {
+		version(r.Firewall.Version)
+		for _, a := range r.Firewall.Alerts {
+			add(ModuleFirewall, a.Identifier, a.MetricName, a.MetricDisplayName, a.CurrentValue, a.FormattedValue, a.WarningThreshold, a.CriticalThreshold, a.Level, a.Message)
+		}
+	}
+}
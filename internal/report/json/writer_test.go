@@ -0,0 +1,132 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"inspection-tool/internal/model"
+	"inspection-tool/pkg/testfixtures"
+)
+
+// createTestInspectionResult creates a host inspection result with one warning alert.
+func createTestInspectionResult() *model.InspectionResult {
+	host2 := testfixtures.NewHost("host-2", "192.168.1.2")
+	testfixtures.AddHostAlert(host2, "cpu_usage", 75.0)
+	return testfixtures.NewInspectionResult(testfixtures.NewHost("host-1", "192.168.1.1"), host2)
+}
+
+func TestWriter_Format(t *testing.T) {
+	if got := NewWriter(nil).Format(); got != "json" {
+		t.Errorf("Format() = %q, want %q", got, "json")
+	}
+}
+
+func TestWriter_Write_NilResult(t *testing.T) {
+	if err := NewWriter(nil).Write(nil, filepath.Join(t.TempDir(), "report.json")); err == nil {
+		t.Error("Write(nil) should return error")
+	}
+}
+
+func TestWriter_WriteCombined(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report") // Extension is added by the writer
+
+	cloudResult := model.NewCloudInspectionResults(time.Now())
+	resource := model.NewCloudInspectionResult(model.NewCloudResource(model.CloudProviderAliyun, model.CloudResourceTypeRDS, "rm-bp1"))
+	resource.AddAlert(model.NewCloudAlert("rm-bp1", "cpu_usage", 95, model.AlertLevelCritical))
+	cloudResult.AddResult(resource)
+	cloudResult.Finalize(time.Now())
+
+	w := NewWriter(nil)
+	if err := w.WriteCombined(&Report{Host: createTestInspectionResult(), Cloud: cloudResult}, outputPath); err != nil {
+		t.Fatalf("WriteCombined() error = %v", err)
+	}
+
+	data, err := os.ReadFile(outputPath + ".json")
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	var doc map[string]stdjson.RawMessage
+	if err := stdjson.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"schema_version", "generated_at", "version", "host", "cloud", "alerts"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("report should contain %q", key)
+		}
+	}
+	if _, ok := doc["mysql"]; ok {
+		t.Error("modules that were not inspected should be omitted")
+	}
+
+	var report Report
+	if err := stdjson.Unmarshal(data, &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if report.SchemaVersion != SchemaVersion || report.Version != "1.0.0-test" {
+		t.Errorf("unexpected schema/tool version: %q / %q", report.SchemaVersion, report.Version)
+	}
+	if len(report.Alerts) != 2 {
+		t.Fatalf("expected 2 flattened alerts, got %d", len(report.Alerts))
+	}
+	if a := report.Alerts[0]; a.Module != ModuleHost || a.Identifier != "host-2" || a.Level != model.AlertLevelWarning {
+		t.Errorf("unexpected host alert: %+v", a)
+	}
+	if a := report.Alerts[1]; a.Module != ModuleCloud || a.Identifier != "rm-bp1" || a.Level != model.AlertLevelCritical {
+		t.Errorf("unexpected cloud alert: %+v", a)
+	}
+}
+
+func TestFlattenAlerts(t *testing.T) {
+	cloudResult := model.NewCloudInspectionResults(time.Now())
+	resource := model.NewCloudInspectionResult(model.NewCloudResource(model.CloudProviderAliyun, model.CloudResourceTypeRDS, "rm-bp1"))
+	resource.AddAlert(model.NewCloudAlert("rm-bp1", "cpu_usage", 95, model.AlertLevelCritical))
+	cloudResult.AddResult(resource)
+	cloudResult.Finalize(time.Now())
+
+	alerts := FlattenAlerts(&Report{Host: createTestInspectionResult(), Cloud: cloudResult})
+	if len(alerts) != 2 {
+		t.Fatalf("FlattenAlerts() returned %d alerts, want 2", len(alerts))
+	}
+	if alerts[0].Module != ModuleHost || alerts[0].Identifier != "host-2" || alerts[0].Level != model.AlertLevelWarning {
+		t.Errorf("alerts[0] = %+v, want host warning for host-2", alerts[0])
+	}
+	if alerts[1].Module != ModuleCloud || alerts[1].Identifier != "rm-bp1" || alerts[1].Level != model.AlertLevelCritical {
+		t.Errorf("alerts[1] = %+v, want cloud critical for rm-bp1", alerts[1])
+	}
+
+	if alerts := FlattenAlerts(&Report{}); len(alerts) != 0 {
+		t.Errorf("FlattenAlerts() of nil results returned %d alerts, want 0", len(alerts))
+	}
+}
+
+func TestReadReport(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "report.json")
+	if err := NewWriter(nil).Write(createTestInspectionResult(), outputPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	report, err := ReadReport(outputPath)
+	if err != nil {
+		t.Fatalf("ReadReport() error = %v", err)
+	}
+	if report.Host == nil || report.Host.Summary.WarningHosts != 1 || len(report.Alerts) != 1 {
+		t.Errorf("ReadReport() = %+v, want the written host result", report)
+	}
+
+	oldSchema := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(oldSchema, []byte(`{"schema_version":"0","alerts":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadReport(oldSchema); err == nil {
+		t.Error("ReadReport() should reject another schema version")
+	}
+
+	if _, err := ReadReport(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("ReadReport() should fail for a missing file")
+	}
+}
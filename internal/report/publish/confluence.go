@@ -0,0 +1,209 @@
+// Package publish publishes the reports generated in one run to external systems, so the
+// inspection results reach the team wiki without a manual upload.
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ConfluenceOptions configures a Confluence publisher.
+type ConfluenceOptions struct {
+	URL       string        // Confluence base URL, e.g. https://wiki.example.com or https://example.atlassian.net/wiki
+	SpaceKey  string        // Space of the report pages
+	ParentID  string        // Parent page ID (optional)
+	Username  string        // Basic auth user (Confluence Cloud account email); empty uses the token as a bearer token
+	Token     string        // API token (Cloud) or personal access token (Server / Data Center)
+	EmbedHTML bool          // Embed the HTML report in the page with the HTML macro
+	Timeout   time.Duration // Timeout of each request, zero meaning no limit
+	TLS       *tls.Config   // Custom CA / client certificate (optional)
+}
+
+// Page is a report page: one page per inspection run.
+type Page struct {
+	Title       string   // Page title, unique in the space
+	HTMLReport  string   // Single-file HTML report embedded in the page (optional)
+	Attachments []string // Report files attached to the page (Excel, HTML, JSON, zip, ...)
+}
+
+// Confluence publishes report pages through the Confluence REST API.
+type Confluence struct {
+	client *http.Client
+	opts   ConfluenceOptions
+	logger zerolog.Logger
+}
+
+// NewConfluence creates a Confluence publisher.
+func NewConfluence(opts ConfluenceOptions, logger zerolog.Logger) *Confluence {
+	opts.URL = strings.TrimRight(opts.URL, "/")
+	client := &http.Client{Timeout: opts.Timeout}
+	if opts.TLS != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = opts.TLS
+		client.Transport = transport
+	}
+	return &Confluence{
+		client: client,
+		opts:   opts,
+		logger: logger.With().Str("component", "confluence").Logger(),
+	}
+}
+
+// contentResponse is the part of the Confluence content response used by the publisher.
+type contentResponse struct {
+	ID    string `json:"id"`
+	Links struct {
+		Base  string `json:"base"`
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// Publish creates the report page, attaches the report files and returns the page URL.
+// Directories (CSV exports, split HTML reports) cannot be attached and are skipped.
+func (c *Confluence) Publish(ctx context.Context, page *Page) (string, error) {
+	var report string
+	if c.opts.EmbedHTML && page.HTMLReport != "" {
+		data, err := os.ReadFile(page.HTMLReport)
+		if err != nil {
+			return "", fmt.Errorf("failed to read HTML report: %w", err)
+		}
+		report = string(data)
+	}
+
+	created, err := c.createPage(ctx, page.Title, pageBody(report))
+	if err != nil {
+		return "", fmt.Errorf("failed to create page: %w", err)
+	}
+
+	for _, path := range page.Attachments {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		if err := c.attach(ctx, created.ID, path); err != nil {
+			return "", fmt.Errorf("failed to attach %s: %w", filepath.Base(path), err)
+		}
+		c.logger.Debug().Str("page", created.ID).Str("file", path).Msg("report attached")
+	}
+
+	base := created.Links.Base
+	if base == "" {
+		base = c.opts.URL
+	}
+	return base + created.Links.WebUI, nil
+}
+
+// createPage creates a page in the configured space, under the parent page if set.
+func (c *Confluence) createPage(ctx context.Context, title, body string) (*contentResponse, error) {
+	content := map[string]interface{}{
+		"type":  "page",
+		"title": title,
+		"space": map[string]string{"key": c.opts.SpaceKey},
+		"body": map[string]interface{}{
+			"storage": map[string]string{"value": body, "representation": "storage"},
+		},
+	}
+	if c.opts.ParentID != "" {
+		content["ancestors"] = []map[string]string{{"id": c.opts.ParentID}}
+	}
+	payload, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.opts.URL+"/rest/api/content", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var created contentResponse
+	if err := c.do(req, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// attach uploads a file as an attachment of the page.
+func (c *Confluence) attach(ctx context.Context, pageID, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.opts.URL+"/rest/api/content/"+pageID+"/child/attachment", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	// Attachment uploads are rejected by the XSRF check without this header
+	req.Header.Set("X-Atlassian-Token", "nocheck")
+
+	return c.do(req, nil)
+}
+
+// do sends an authenticated request and decodes the JSON response into out (if not nil).
+func (c *Confluence) do(req *http.Request, out interface{}) error {
+	if c.opts.Username != "" {
+		req.SetBasicAuth(c.opts.Username, c.opts.Token)
+	} else if c.opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.opts.Token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("confluence returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// pageBody returns the page content in the Confluence storage format: a note with the
+// attachment list, followed by the HTML report in an HTML macro when report is not empty.
+// The HTML macro must be enabled on the Confluence site (Server / Data Center).
+func pageBody(report string) string {
+	var sb strings.Builder
+	sb.WriteString("<p>本页由巡检工具自动发布，完整报告文件见附件。</p>")
+	sb.WriteString(`<ac:structured-macro ac:name="attachments" />`)
+	if report != "" {
+		sb.WriteString(`<ac:structured-macro ac:name="html"><ac:plain-text-body><![CDATA[`)
+		// A CDATA section cannot contain its end marker; split it across two sections
+		sb.WriteString(strings.ReplaceAll(report, "]]>", "]]]]><![CDATA[>"))
+		sb.WriteString(`]]></ac:plain-text-body></ac:structured-macro>`)
+	}
+	return sb.String()
+}
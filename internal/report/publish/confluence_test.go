@@ -0,0 +1,117 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestConfluence_Publish(t *testing.T) {
+	dir := t.TempDir()
+	htmlPath := filepath.Join(dir, "report.html")
+	excelPath := filepath.Join(dir, "report.xlsx")
+	if err := os.WriteFile(htmlPath, []byte("<html><script>if (a[b[0]]>1) {}</script></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(excelPath, []byte("xlsx"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "report_csv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var page map[string]interface{}
+	var attached []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "ops@example.com" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/wiki/rest/api/content":
+			json.NewDecoder(r.Body).Decode(&page)
+			io.WriteString(w, `{"id": "123", "_links": {"base": "https://example.atlassian.net/wiki", "webui": "/spaces/OPS/pages/123"}}`)
+		case "/wiki/rest/api/content/123/child/attachment":
+			if r.Header.Get("X-Atlassian-Token") != "nocheck" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, header, err := r.FormFile("file")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			attached = append(attached, header.Filename)
+			io.WriteString(w, `{"results": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewConfluence(ConfluenceOptions{
+		URL:       server.URL + "/wiki/",
+		SpaceKey:  "OPS",
+		ParentID:  "42",
+		Username:  "ops@example.com",
+		Token:     "secret",
+		EmbedHTML: true,
+	}, zerolog.Nop())
+	url, err := c.Publish(context.Background(), &Page{
+		Title:       "系统巡检报告 2025-12-13 10:00:00",
+		HTMLReport:  htmlPath,
+		Attachments: []string{excelPath, htmlPath, filepath.Join(dir, "report_csv")},
+	})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if url != "https://example.atlassian.net/wiki/spaces/OPS/pages/123" {
+		t.Errorf("url = %q", url)
+	}
+	if page["title"] != "系统巡检报告 2025-12-13 10:00:00" || page["space"].(map[string]interface{})["key"] != "OPS" {
+		t.Errorf("unexpected page: %v", page)
+	}
+	if ancestors := page["ancestors"].([]interface{}); ancestors[0].(map[string]interface{})["id"] != "42" {
+		t.Errorf("ancestors = %v, want parent 42", ancestors)
+	}
+	body := page["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	if !strings.Contains(body, `ac:name="html"`) || !strings.Contains(body, "a[b[0]]]]><![CDATA[>1") {
+		t.Errorf("page body should embed the escaped HTML report, got %q", body)
+	}
+	if strings.Join(attached, ",") != "report.xlsx,report.html" {
+		t.Errorf("attached = %v, want the report files only", attached)
+	}
+}
+
+func TestConfluence_Publish_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat" {
+			t.Errorf("Authorization = %q, want bearer token", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"message": "A page with this title already exists"}`)
+	}))
+	defer server.Close()
+
+	c := NewConfluence(ConfluenceOptions{URL: server.URL, SpaceKey: "OPS", Token: "pat"}, zerolog.Nop())
+	_, err := c.Publish(context.Background(), &Page{Title: "巡检报告"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Publish() error = %v, want the Confluence message", err)
+	}
+}
+
+func TestPageBody_WithoutReport(t *testing.T) {
+	body := pageBody("")
+	if strings.Contains(body, `ac:name="html"`) || !strings.Contains(body, `ac:name="attachments"`) {
+		t.Errorf("pageBody() = %q, want the attachment list only", body)
+	}
+}
@@ -1,6 +1,6 @@
 // Package report provides report generation functionality for inspection results.
 // It defines the ReportWriter interface and provides a registry for managing
-// different report formats (Excel, HTML, etc.).
+// different report formats (Excel, HTML, CSV, JSON, etc.).
 package report
 
 import (
@@ -9,8 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"inspection-tool/internal/report/csv"
 	"inspection-tool/internal/report/excel"
 	"inspection-tool/internal/report/html"
+	"inspection-tool/internal/report/json"
 )
 
 // Registry manages report writers for different formats.
@@ -19,7 +21,7 @@ type Registry struct {
 	writers map[string]ReportWriter
 }
 
-// NewRegistry creates a new report registry with pre-registered Excel and HTML writers.
+// NewRegistry creates a new report registry with pre-registered Excel, HTML, email HTML, CSV and JSON writers.
 // If timezone is nil, defaults to Asia/Shanghai.
 // htmlTemplatePath is optional; if empty, the HTML writer will use the embedded default template.
 func NewRegistry(timezone *time.Location, htmlTemplatePath string) *Registry {
@@ -31,6 +33,9 @@ func NewRegistry(timezone *time.Location, htmlTemplatePath string) *Registry {
 	// Create writers
 	excelWriter := excel.NewWriter(timezone)
 	htmlWriter := html.NewWriter(timezone, htmlTemplatePath)
+	csvWriter := csv.NewWriter(timezone)
+	jsonWriter := json.NewWriter(timezone)
+	emailWriter := html.NewEmailWriter(timezone)
 
 	// Build registry
 	r := &Registry{
@@ -40,6 +45,9 @@ func NewRegistry(timezone *time.Location, htmlTemplatePath string) *Registry {
 	// Register writers using their Format() return values
 	r.writers[excelWriter.Format()] = excelWriter
 	r.writers[htmlWriter.Format()] = htmlWriter
+	r.writers[csvWriter.Format()] = csvWriter
+	r.writers[jsonWriter.Format()] = jsonWriter
+	r.writers[emailWriter.Format()] = emailWriter
 
 	return r
 }
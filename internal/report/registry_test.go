@@ -14,9 +14,9 @@ func TestNewRegistry(t *testing.T) {
 			t.Fatal("expected non-nil registry")
 		}
 
-		// Should have both excel and html writers
-		if len(r.writers) != 2 {
-			t.Errorf("expected 2 writers, got %d", len(r.writers))
+		// Should have excel, html, html-email, csv and json writers
+		if len(r.writers) != 5 {
+			t.Errorf("expected 5 writers, got %d", len(r.writers))
 		}
 
 		// Verify writers are registered
@@ -26,6 +26,15 @@ func TestNewRegistry(t *testing.T) {
 		if _, ok := r.writers["html"]; !ok {
 			t.Error("expected html writer to be registered")
 		}
+		if _, ok := r.writers["csv"]; !ok {
+			t.Error("expected csv writer to be registered")
+		}
+		if _, ok := r.writers["json"]; !ok {
+			t.Error("expected json writer to be registered")
+		}
+		if _, ok := r.writers["html-email"]; !ok {
+			t.Error("expected html-email writer to be registered")
+		}
 	})
 
 	t.Run("with custom timezone", func(t *testing.T) {
@@ -36,9 +45,9 @@ func TestNewRegistry(t *testing.T) {
 			t.Fatal("expected non-nil registry")
 		}
 
-		// Should still have both writers
-		if len(r.writers) != 2 {
-			t.Errorf("expected 2 writers, got %d", len(r.writers))
+		// Should still have all writers
+		if len(r.writers) != 5 {
+			t.Errorf("expected 5 writers, got %d", len(r.writers))
 		}
 	})
 
@@ -92,6 +101,38 @@ func TestRegistry_Get_HTML(t *testing.T) {
 	}
 }
 
+func TestRegistry_Get_CSV(t *testing.T) {
+	r := NewRegistry(nil, "")
+
+	writer, err := r.Get("csv")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if writer == nil {
+		t.Fatal("expected non-nil writer")
+	}
+	if writer.Format() != "csv" {
+		t.Errorf("expected format 'csv', got %q", writer.Format())
+	}
+}
+
+func TestRegistry_Get_JSON(t *testing.T) {
+	r := NewRegistry(nil, "")
+
+	writer, err := r.Get("json")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if writer == nil {
+		t.Fatal("expected non-nil writer")
+	}
+	if writer.Format() != "json" {
+		t.Errorf("expected format 'json', got %q", writer.Format())
+	}
+}
+
 func TestRegistry_Get_Unknown(t *testing.T) {
 	r := NewRegistry(nil, "")
 
@@ -152,12 +193,12 @@ func TestRegistry_GetAll(t *testing.T) {
 
 	formats := r.GetAll()
 
-	if len(formats) != 2 {
-		t.Errorf("expected 2 formats, got %d", len(formats))
+	if len(formats) != 5 {
+		t.Errorf("expected 5 formats, got %d", len(formats))
 	}
 
 	// Should be sorted alphabetically
-	expected := []string{"excel", "html"}
+	expected := []string{"csv", "excel", "html", "html-email", "json"}
 	for i, format := range expected {
 		if formats[i] != format {
 			t.Errorf("expected formats[%d] = %q, got %q", i, format, formats[i])
@@ -174,6 +215,8 @@ func TestRegistry_Has(t *testing.T) {
 	}{
 		{"excel", true},
 		{"html", true},
+		{"csv", true},
+		{"json", true},
 		{"pdf", false},
 		{"Excel", true},   // case insensitive
 		{"HTML", true},    // case insensitive
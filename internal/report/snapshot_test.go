@@ -0,0 +1,219 @@
+package report
+
+import (
+	stdjson "encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"inspection-tool/internal/model"
+)
+
+// update rewrites the golden files from the current writers instead of comparing against them:
+//
+//	go test ./internal/report -run TestSnapshot -update
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// volatilePatterns match output that changes between runs (report generation time); they are
+// replaced before the snapshot is compared.
+var volatilePatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(报告生成时间[:：]\s*)\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`), "${1}<generated_at>"},
+	{regexp.MustCompile(`("generated_at":\s*)"[^"]*"`), `${1}"<generated_at>"`},
+}
+
+// snapshotFormats lists the report formats under snapshot test with the file name each writer
+// renders to and how its output is turned into a comparable text snapshot.
+var snapshotFormats = []struct {
+	format   string
+	output   string
+	snapshot func(t *testing.T, path string) string
+}{
+	{"excel", "report.xlsx", snapshotWorkbook},
+	{"html", "report.html", snapshotFile},
+	{"html-email", "report.html", snapshotFile},
+	{"csv", "report_csv", snapshotDir},
+	{"json", "report.json", snapshotFile},
+}
+
+// TestSnapshot renders every fixture in testdata/fixtures with every report writer and compares
+// the output against testdata/golden/<fixture>.<format>.golden. Run with -update after an
+// intended output change and review the golden file diff.
+func TestSnapshot(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found in testdata/fixtures")
+	}
+
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	registry := NewRegistry(tz, "")
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		result := loadFixture(t, fixture)
+
+		for _, sf := range snapshotFormats {
+			t.Run(name+"/"+sf.format, func(t *testing.T) {
+				writer, err := registry.Get(sf.format)
+				if err != nil {
+					t.Fatal(err)
+				}
+				output := filepath.Join(t.TempDir(), sf.output)
+				if err := writer.Write(result, output); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+
+				got := scrubVolatile(sf.snapshot(t, output))
+				compareGolden(t, filepath.Join("testdata", "golden", fmt.Sprintf("%s.%s.golden", name, sf.format)), got)
+			})
+		}
+	}
+}
+
+// loadFixture reads an inspection result fixture.
+func loadFixture(t *testing.T, path string) *model.InspectionResult {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var result model.InspectionResult
+	if err := stdjson.Unmarshal(data, &result); err != nil {
+		t.Fatalf("failed to parse fixture %s: %v", path, err)
+	}
+	return &result
+}
+
+// scrubVolatile replaces the output that changes between runs.
+func scrubVolatile(s string) string {
+	for _, p := range volatilePatterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// compareGolden compares got against the golden file, or rewrites it with -update.
+func compareGolden(t *testing.T, golden, got string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if got == string(want) {
+		return
+	}
+
+	gotLines := strings.Split(got, "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Fatalf("output differs from %s at line %d (run with -update if the change is intended):\n got: %q\nwant: %q", golden, i+1, g, w)
+		}
+	}
+}
+
+// snapshotFile returns the content of a single-file report.
+func snapshotFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	return string(data)
+}
+
+// snapshotDir returns the files of a report directory (CSV export), in name order.
+func snapshotDir(t *testing.T, dir string) string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read report directory: %v", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "== %s ==\n", name)
+		b.WriteString(snapshotFile(t, filepath.Join(dir, name)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// snapshotWorkbook returns the cells of an Excel report, sheet by sheet. XLSX files are zip
+// archives with timestamps, so they are compared at cell level: each row lists its cell values,
+// tab-separated, with the fill color of highlighted cells in braces (e.g. "95.7%{#FFC7CE}").
+func snapshotWorkbook(t *testing.T, path string) string {
+	t.Helper()
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	defer f.Close()
+
+	var b strings.Builder
+	for _, sheet := range f.GetSheetList() {
+		fmt.Fprintf(&b, "== %s ==\n", sheet)
+		rows, err := f.GetRows(sheet)
+		if err != nil {
+			t.Fatalf("failed to read sheet %s: %v", sheet, err)
+		}
+		for r, row := range rows {
+			cells := make([]string, len(row))
+			for c, value := range row {
+				cell, _ := excelize.CoordinatesToCellName(c+1, r+1)
+				cells[c] = value + cellFill(f, sheet, cell)
+			}
+			b.WriteString(strings.Join(cells, "\t"))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// cellFill returns the fill color of a cell in braces, or "" when the cell has no fill.
+func cellFill(f *excelize.File, sheet, cell string) string {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil || styleID == 0 {
+		return ""
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil || style == nil || len(style.Fill.Color) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(style.Fill.Color, ",") + "}"
+}
@@ -0,0 +1,201 @@
+// Package theme defines the white-label theming bundle applied to generated reports.
+// A theme carries the customer logo, primary colors, footer text and report title;
+// the Excel and HTML writers fall back to their built-in look for every field left empty.
+package theme
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Built-in report title and footer text used when the theme leaves them empty.
+const (
+	DefaultTitle      = "系统巡检报告"
+	DefaultFooterText = "系统巡检工具"
+)
+
+// hexColorPattern matches a #RRGGBB color.
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Theme is the white-label theming bundle of a project.
+type Theme struct {
+	Title          string // 报告标题（默认: 系统巡检报告）
+	FooterText     string // 页脚文字（默认: 系统巡检工具）
+	LogoFile       string // Logo 图片路径（PNG / JPEG / GIF）
+	PrimaryColor   string // 主色，#RRGGBB（表头、HTML 页眉）
+	SecondaryColor string // 辅色，#RRGGBB（HTML 页眉渐变终点，默认同主色）
+}
+
+// GetTitle returns the report title, falling back to DefaultTitle.
+// It is safe to call on a nil theme.
+func (t *Theme) GetTitle() string {
+	if t == nil || strings.TrimSpace(t.Title) == "" {
+		return DefaultTitle
+	}
+	return t.Title
+}
+
+// GetFooterText returns the footer text, falling back to DefaultFooterText.
+// It is safe to call on a nil theme.
+func (t *Theme) GetFooterText() string {
+	if t == nil || strings.TrimSpace(t.FooterText) == "" {
+		return DefaultFooterText
+	}
+	return t.FooterText
+}
+
+// HasPrimaryColor returns true if a valid primary color is configured.
+func (t *Theme) HasPrimaryColor() bool {
+	return t != nil && IsHexColor(t.PrimaryColor)
+}
+
+// GetSecondaryColor returns the secondary color, falling back to the primary color.
+func (t *Theme) GetSecondaryColor() string {
+	if t == nil {
+		return ""
+	}
+	if IsHexColor(t.SecondaryColor) {
+		return t.SecondaryColor
+	}
+	return t.PrimaryColor
+}
+
+// HasLogo returns true if a logo file is configured.
+func (t *Theme) HasLogo() bool {
+	return t != nil && t.LogoFile != ""
+}
+
+// LoadLogo reads the logo file and returns its content and lower-case extension (e.g. ".png").
+func (t *Theme) LoadLogo() ([]byte, string, error) {
+	if !t.HasLogo() {
+		return nil, "", fmt.Errorf("no logo file configured")
+	}
+	return loadLogo(t.LogoFile)
+}
+
+// LogoDataURI returns the logo as a base64 data URI suitable for embedding in HTML.
+func (t *Theme) LogoDataURI() (string, error) {
+	if !t.HasLogo() {
+		return "", fmt.Errorf("no logo file configured")
+	}
+	return logoDataURI(t.LogoFile)
+}
+
+// Cover is the cover page of a report: the customer, project, inspection engineer and
+// period, with an optional logo. Empty fields are left off the cover page.
+type Cover struct {
+	CustomerName string // 客户名称
+	Project      string // 项目名称
+	Engineer     string // 巡检工程师
+	Period       string // 巡检周期
+	LogoFile     string // 封面 Logo 图片路径（PNG / JPEG / GIF）
+}
+
+// CoverField is a labeled line of the cover page.
+type CoverField struct {
+	Label string
+	Value string
+}
+
+// Fields returns the configured cover fields in display order.
+// It is safe to call on a nil cover.
+func (c *Cover) Fields() []CoverField {
+	if c == nil {
+		return nil
+	}
+	var fields []CoverField
+	for _, f := range []CoverField{
+		{"客户名称", c.CustomerName},
+		{"项目名称", c.Project},
+		{"巡检周期", c.Period},
+		{"巡检工程师", c.Engineer},
+	} {
+		if strings.TrimSpace(f.Value) != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// HasLogo returns true if a cover logo file is configured.
+func (c *Cover) HasLogo() bool {
+	return c != nil && c.LogoFile != ""
+}
+
+// LoadLogo reads the cover logo file and returns its content and lower-case extension.
+func (c *Cover) LoadLogo() ([]byte, string, error) {
+	if !c.HasLogo() {
+		return nil, "", fmt.Errorf("no cover logo file configured")
+	}
+	return loadLogo(c.LogoFile)
+}
+
+// LogoDataURI returns the cover logo as a base64 data URI suitable for embedding in HTML.
+func (c *Cover) LogoDataURI() (string, error) {
+	if !c.HasLogo() {
+		return "", fmt.Errorf("no cover logo file configured")
+	}
+	return logoDataURI(c.LogoFile)
+}
+
+// loadLogo reads a logo file and returns its content and lower-case extension.
+func loadLogo(path string) ([]byte, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read logo file: %w", err)
+	}
+	return data, strings.ToLower(filepath.Ext(path)), nil
+}
+
+// logoDataURI reads a logo file as a base64 data URI, rejecting files that are not images.
+func logoDataURI(path string) (string, error) {
+	data, _, err := loadLogo(path)
+	if err != nil {
+		return "", err
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return "", fmt.Errorf("logo file %s is not an image (%s)", path, mimeType)
+	}
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// IsHexColor reports whether s is a #RRGGBB color.
+func IsHexColor(s string) bool {
+	return hexColorPattern.MatchString(s)
+}
+
+// ExcelColor converts a #RRGGBB color to the RRGGBB form used by Excel styles.
+func ExcelColor(s string) string {
+	return strings.ToUpper(strings.TrimPrefix(s, "#"))
+}
+
+// Watermark tile size (SVG user units). The HTML reports repeat the tile over the page; the
+// Excel reports scale it to the printed page.
+const (
+	WatermarkWidth  = 480
+	WatermarkHeight = 320
+)
+
+// WatermarkSVG returns a watermark tile: text written diagonally in light gray on a
+// transparent background. The text is rendered by the viewer, so no font is embedded.
+func WatermarkSVG(text string) []byte {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[2]d" viewBox="0 0 %[1]d %[2]d">`+
+		`<text x="%[3]d" y="%[4]d" transform="rotate(-30 %[3]d %[4]d)" text-anchor="middle" dominant-baseline="middle" `+
+		`font-family="Microsoft YaHei, PingFang SC, Noto Sans CJK SC, sans-serif" font-size="26" fill="#999999" fill-opacity="0.35">%[5]s</text></svg>`,
+		WatermarkWidth, WatermarkHeight, WatermarkWidth/2, WatermarkHeight/2, escaped.String()))
+}
+
+// WatermarkDataURI returns the watermark tile as a base64 data URI suitable for CSS.
+func WatermarkDataURI(text string) string {
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(WatermarkSVG(text))
+}
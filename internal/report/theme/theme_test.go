@@ -0,0 +1,98 @@
+package theme
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTheme_Defaults(t *testing.T) {
+	var nilTheme *Theme
+	if nilTheme.GetTitle() != DefaultTitle || nilTheme.GetFooterText() != DefaultFooterText {
+		t.Error("nil theme should fall back to the built-in title and footer")
+	}
+	if nilTheme.HasPrimaryColor() || nilTheme.HasLogo() {
+		t.Error("nil theme should have no color or logo")
+	}
+
+	th := &Theme{Title: "ACME 巡检报告", PrimaryColor: "#0a7f3c"}
+	if th.GetTitle() != "ACME 巡检报告" || th.GetFooterText() != DefaultFooterText {
+		t.Errorf("unexpected title/footer: %q / %q", th.GetTitle(), th.GetFooterText())
+	}
+	if th.GetSecondaryColor() != "#0a7f3c" {
+		t.Errorf("secondary color should default to primary, got %q", th.GetSecondaryColor())
+	}
+}
+
+func TestIsHexColor(t *testing.T) {
+	tests := map[string]bool{"#4472C4": true, "#abcdef": true, "4472C4": false, "#fff": false, "red": false, "": false}
+	for input, want := range tests {
+		if got := IsHexColor(input); got != want {
+			t.Errorf("IsHexColor(%q) = %v, want %v", input, got, want)
+		}
+	}
+	if got := ExcelColor("#0a7f3c"); got != "0A7F3C" {
+		t.Errorf("ExcelColor() = %q, want 0A7F3C", got)
+	}
+}
+
+func TestTheme_LogoDataURI(t *testing.T) {
+	logoPath := filepath.Join(t.TempDir(), "logo.png")
+	file, err := os.Create(logoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(file, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	uri, err := (&Theme{LogoFile: logoPath}).LogoDataURI()
+	if err != nil {
+		t.Fatalf("LogoDataURI() error = %v", err)
+	}
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Errorf("unexpected data URI prefix: %.40s", uri)
+	}
+
+	notImage := filepath.Join(t.TempDir(), "logo.png")
+	os.WriteFile(notImage, []byte("plain text"), 0o644)
+	if _, err := (&Theme{LogoFile: notImage}).LogoDataURI(); err == nil {
+		t.Error("LogoDataURI() should reject a non-image file")
+	}
+}
+
+func TestCover_Fields(t *testing.T) {
+	var nilCover *Cover
+	if nilCover.Fields() != nil || nilCover.HasLogo() {
+		t.Error("nil cover should have no fields or logo")
+	}
+
+	cover := &Cover{CustomerName: "ACME", Engineer: "张三", Period: "2025 年第一季度"}
+	fields := cover.Fields()
+	want := []CoverField{{"客户名称", "ACME"}, {"巡检周期", "2025 年第一季度"}, {"巡检工程师", "张三"}}
+	if len(fields) != len(want) {
+		t.Fatalf("Fields() = %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("Fields()[%d] = %v, want %v", i, fields[i], want[i])
+		}
+	}
+}
+
+func TestWatermarkSVG(t *testing.T) {
+	svg := string(WatermarkSVG("ACME <机密> 2025-12-13"))
+	if !strings.HasPrefix(svg, "<svg ") || !strings.Contains(svg, "rotate(-30") {
+		t.Errorf("unexpected watermark SVG: %s", svg)
+	}
+	if !strings.Contains(svg, "ACME &lt;机密&gt; 2025-12-13") {
+		t.Errorf("watermark text should be escaped, got %s", svg)
+	}
+	if uri := WatermarkDataURI("ACME"); !strings.HasPrefix(uri, "data:image/svg+xml;base64,") {
+		t.Errorf("unexpected data URI prefix: %.40s", uri)
+	}
+}
@@ -0,0 +1,478 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// AD metric names.
+const (
+	adReplicationPendingMetric  = "ad_replication_pending"
+	adReplicationFailuresMetric = "ad_replication_failures"
+	adLDAPProbeSuccessMetric    = "ad_ldap_probe_success"
+	adLDAPBindLatencyMetric     = "ad_ldap_bind_latency"
+	adSysvolFreeMetric          = "ad_sysvol_free_percent"
+)
+
+// adDiscoveryMetrics lists the metrics whose hosts are treated as domain controllers.
+var adDiscoveryMetrics = map[string]bool{
+	adReplicationPendingMetric:  true,
+	adReplicationFailuresMetric: true,
+}
+
+// adProbeMetrics lists the blackbox_exporter metrics, whose series are matched to domain
+// controllers by probe target instead of hostname labels.
+var adProbeMetrics = map[string]bool{
+	adLDAPProbeSuccessMetric: true,
+	adLDAPBindLatencyMetric:  true,
+}
+
+// =============================================================================
+// AD Collector
+// =============================================================================
+
+// ADCollector is the data collection service for Active Directory domain controllers.
+// It integrates with VictoriaMetrics to collect replication, LDAP probe and SYSVOL
+// metrics and N9E to obtain host IP addresses. Hosts are identified by hostname.
+type ADCollector struct {
+	vmClient       vm.MetricsSource
+	n9eClient      *n9e.Client // 用于获取 IP 地址
+	config         *config.ADInspectionConfig
+	metrics        []*model.ADMetricDefinition
+	metricDefs     map[string]*model.ADMetricDefinition
+	instanceFilter *ADInstanceFilter
+	logger         zerolog.Logger
+}
+
+// ADInstanceFilter defines filtering criteria for domain controllers.
+type ADInstanceFilter struct {
+	HostnamePatterns []string          // Hostname patterns (glob, e.g., "GX-DC-*")
+	BusinessGroups   []string          // Business groups (OR relation)
+	Tags             map[string]string // Tags (AND relation)
+}
+
+// NewADCollector creates a new ADCollector instance.
+func NewADCollector(
+	cfg *config.ADInspectionConfig,
+	vmClient vm.MetricsSource,
+	n9eClient *n9e.Client,
+	metrics []*model.ADMetricDefinition,
+	logger zerolog.Logger,
+) *ADCollector {
+	c := &ADCollector{
+		vmClient:  vmClient,
+		n9eClient: n9eClient,
+		config:    cfg,
+		metrics:   metrics,
+		logger:    logger.With().Str("component", "ad-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.ADMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	// Build instance filter from config
+	c.instanceFilter = c.buildInstanceFilter()
+
+	return c
+}
+
+// buildInstanceFilter converts config.ADFilter to ADInstanceFilter.
+func (c *ADCollector) buildInstanceFilter() *ADInstanceFilter {
+	if c.config == nil {
+		return nil
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.HostnamePatterns) == 0 &&
+		len(filter.BusinessGroups) == 0 &&
+		len(filter.Tags) == 0 {
+		return nil
+	}
+
+	return &ADInstanceFilter{
+		HostnamePatterns: filter.HostnamePatterns,
+		BusinessGroups:   filter.BusinessGroups,
+		Tags:             filter.Tags,
+	}
+}
+
+// GetConfig returns the AD inspection configuration.
+func (c *ADCollector) GetConfig() *config.ADInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *ADCollector) GetMetrics() []*model.ADMetricDefinition {
+	return c.metrics
+}
+
+// GetInstanceFilter returns the instance filter.
+func (c *ADCollector) GetInstanceFilter() *ADInstanceFilter {
+	return c.instanceFilter
+}
+
+// IsEmpty returns true if the instance filter has no filtering criteria.
+func (f *ADInstanceFilter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.HostnamePatterns) == 0 &&
+		len(f.BusinessGroups) == 0 &&
+		len(f.Tags) == 0
+}
+
+// ToVMHostFilter converts ADInstanceFilter to vm.HostFilter.
+// Note: HostnamePatterns are not supported in vm.HostFilter and are
+// handled separately in the DiscoverInstances method.
+func (f *ADInstanceFilter) ToVMHostFilter() *vm.HostFilter {
+	if f == nil || f.IsEmpty() {
+		return nil
+	}
+
+	if len(f.BusinessGroups) == 0 && len(f.Tags) == 0 {
+		return nil
+	}
+
+	return &vm.HostFilter{
+		BusinessGroups: f.BusinessGroups,
+		Tags:           f.Tags,
+	}
+}
+
+// =============================================================================
+// 域控制器发现
+// =============================================================================
+
+// DiscoverInstances discovers all domain controllers.
+// Domain controllers are the union of the hosts returned by the replication metrics.
+// IP addresses are retrieved from N9E API.
+func (c *ADCollector) DiscoverInstances(ctx context.Context) ([]*model.ADInstance, error) {
+	c.logger.Info().Msg("starting domain controller discovery")
+
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	instanceMap := make(map[string]*model.ADInstance)
+	var order []string
+	queried := 0
+
+	for _, metric := range c.metrics {
+		if !adDiscoveryMetrics[metric.Name] || metric.IsPending() {
+			continue
+		}
+
+		results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("failed to query discovery metric, continuing with others")
+			continue
+		}
+		queried++
+
+		for _, result := range results {
+			hostname := labelHostname(result.Labels)
+			if hostname == "" {
+				c.logger.Warn().Interface("labels", result.Labels).Msg("missing hostname labels")
+				continue
+			}
+
+			if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
+				c.logger.Debug().Str("hostname", hostname).Msg("hostname filtered out")
+				continue
+			}
+
+			instance, exists := instanceMap[hostname]
+			if !exists {
+				instance = model.NewADInstance(hostname)
+				instanceMap[hostname] = instance
+				order = append(order, hostname)
+			}
+		}
+	}
+
+	if queried == 0 {
+		return nil, fmt.Errorf("failed to query any AD discovery metric")
+	}
+
+	instances := make([]*model.ADInstance, 0, len(order))
+	for _, hostname := range order {
+		instance := instanceMap[hostname]
+		instance.SetIP(n9eHostIP(ctx, c.n9eClient, hostname, c.logger))
+		instances = append(instances, instance)
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Msg("domain controller discovery completed")
+
+	return instances, nil
+}
+
+// =============================================================================
+// AD 指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all domain controllers.
+//
+// Flow:
+//  1. Initialize result objects for each host
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values from metrics
+//  6. Return results map (key = identifier)
+//
+// Single metric failure does not abort the entire collection.
+func (c *ADCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.ADInstance,
+	metrics []*model.ADMetricDefinition,
+) (map[string]*model.ADInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting AD metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by identifier)
+	resultsMap := make(map[string]*model.ADInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Identifier] = model.NewADInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.ADMetricDefinition
+	var activeMetrics []*model.ADMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("AD metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for all pending metrics on all hosts.
+func (c *ADCollector) setPendingMetrics(
+	resultsMap map[string]*model.ADInspectionResult,
+	pendingMetrics []*model.ADMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending AD metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			result.SetMetric(&model.ADMetricValue{
+				Name:           metric.Name,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// collectMetricConcurrent collects a single metric for all hosts (concurrent-safe).
+// Probe series are matched by target; when several series match one domain controller
+// (probes on 389/636, several volumes) the value is chosen by mergeADValue.
+func (c *ADCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.ADMetricDefinition,
+	resultsMap map[string]*model.ADInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting AD metric (concurrent)")
+
+	// Query VictoriaMetrics
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		var inspResult *model.ADInspectionResult
+		if adProbeMetrics[metric.Name] {
+			inspResult = matchADProbeTarget(resultsMap, result.Labels["instance"])
+		} else {
+			inspResult = resultsMap[labelHostname(result.Labels)]
+		}
+		if inspResult == nil {
+			continue
+		}
+
+		// Only the configured SYSVOL volume is relevant
+		if metric.Name == adSysvolFreeMetric && c.config != nil && c.config.SysvolVolume != "" {
+			if volume := result.Labels["volume"]; volume != "" && !strings.EqualFold(volume, c.config.SysvolVolume) {
+				continue
+			}
+		}
+
+		value := result.Value
+		if existing := inspResult.GetMetric(metric.Name); existing != nil && !existing.IsNA {
+			value = mergeADValue(metric.Name, existing.RawValue, value)
+		}
+
+		mv := &model.ADMetricValue{
+			Name:      metric.Name,
+			RawValue:  value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields.
+// NaN/Inf values are treated as not collected.
+func (c *ADCollector) extractFieldsFromMetrics(resultsMap map[string]*model.ADInspectionResult) {
+	for _, result := range resultsMap {
+		fields := map[string]*float64{
+			adReplicationPendingMetric:  &result.ReplicationPending,
+			adReplicationFailuresMetric: &result.ReplicationFailures,
+			adLDAPProbeSuccessMetric:    &result.LDAPProbeSuccess,
+			adLDAPBindLatencyMetric:     &result.LDAPBindLatency,
+			adSysvolFreeMetric:          &result.SysvolFreePercent,
+		}
+		for name, field := range fields {
+			mv := result.GetMetric(name)
+			if mv == nil || mv.IsNA || math.IsNaN(mv.RawValue) || math.IsInf(mv.RawValue, 0) {
+				continue
+			}
+			*field = mv.RawValue
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
+
+// mergeADValue combines two series of the same metric matched to one domain controller,
+// keeping the worst value: the lowest probe success and free space, the highest otherwise.
+func mergeADValue(metricName string, existing, value float64) float64 {
+	switch metricName {
+	case adLDAPProbeSuccessMetric, adSysvolFreeMetric:
+		return math.Min(existing, value)
+	default:
+		return math.Max(existing, value)
+	}
+}
+
+// matchADProbeTarget returns the domain controller probed by a blackbox_exporter target
+// such as "dc01.corp.local:389" or "ldaps://10.0.0.10:636". A target matches when its host
+// equals the hostname, the first label of the FQDN equals the hostname, or it equals the IP.
+func matchADProbeTarget(resultsMap map[string]*model.ADInspectionResult, target string) *model.ADInspectionResult {
+	host := target
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "/"))
+	if host == "" {
+		return nil
+	}
+	shortName := strings.SplitN(host, ".", 2)[0]
+
+	for _, result := range resultsMap {
+		if result.Instance == nil {
+			continue
+		}
+		hostname := strings.ToLower(result.Instance.Hostname)
+		if host == hostname || shortName == hostname || host == result.Instance.IP {
+			return result
+		}
+	}
+	return nil
+}
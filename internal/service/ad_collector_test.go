@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// createTestADCollector creates an ADCollector querying fake VM and N9E servers.
+func createTestADCollector(t *testing.T, series map[string][]fakeSeries, ips map[string]string, cfg *config.ADInspectionConfig, metrics []*model.ADMetricDefinition) *ADCollector {
+	t.Helper()
+	vmServer := newFakeVMServer(t, series)
+	n9eServer := newFakeN9EServer(t, ips)
+	return NewADCollector(cfg, newFakeVMClient(vmServer.URL), newFakeN9EClient(n9eServer.URL), metrics, zerolog.Nop())
+}
+
+// createTestADMetricDefs returns the AD metrics, querying the fake VM server by metric name.
+func createTestADMetricDefs() []*model.ADMetricDefinition {
+	return []*model.ADMetricDefinition{
+		{Name: "ad_replication_pending", Query: "ad_replication_pending"},
+		{Name: "ad_replication_failures", Query: "ad_replication_failures"},
+		{Name: "ad_ldap_probe_success", Query: "ad_ldap_probe_success"},
+		{Name: "ad_ldap_bind_latency", Query: "ad_ldap_bind_latency"},
+		{Name: "ad_sysvol_free_percent", Query: "ad_sysvol_free_percent"},
+	}
+}
+
+func TestADCollector_DiscoverInstances(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"ad_replication_pending": {
+			{map[string]string{"agent_hostname": "GX-DC-01"}, 0},
+			{map[string]string{"agent_hostname": "SH-DC-01"}, 0}, // Filtered out
+			{map[string]string{"instance": "10.0.0.9:9182"}, 0},  // No hostname
+		},
+		"ad_replication_failures": {
+			{map[string]string{"agent_hostname": "GX-DC-01"}, 0}, // Same controller
+			{map[string]string{"ident": "GX-DC-02"}, 0},
+		},
+		"ad_ldap_probe_success": {
+			{map[string]string{"instance": "GX-DC-03:389"}, 1}, // Probes do not discover controllers
+		},
+	}
+	cfg := &config.ADInspectionConfig{InstanceFilter: config.ADFilter{HostnamePatterns: []string{"GX-DC-*"}}}
+	collector := createTestADCollector(t, series, map[string]string{"GX-DC-01": "10.0.0.10"}, cfg, createTestADMetricDefs())
+
+	instances, err := collector.DiscoverInstances(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverInstances() error = %v", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("DiscoverInstances() returned %d controllers, want 2: %+v", len(instances), instances)
+	}
+	if instances[0].Hostname != "GX-DC-01" || instances[0].IP != "10.0.0.10" {
+		t.Errorf("first controller = %+v, want GX-DC-01 with its N9E IP", instances[0])
+	}
+	if instances[1].Hostname != "GX-DC-02" || instances[1].IP != "N/A" {
+		t.Errorf("second controller = %+v, want GX-DC-02 without N9E IP", instances[1])
+	}
+}
+
+func TestADCollector_DiscoverInstances_QueryError(t *testing.T) {
+	collector := NewADCollector(nil, newFakeVMClient("http://127.0.0.1:1"), nil, createTestADMetricDefs(), zerolog.Nop())
+	if _, err := collector.DiscoverInstances(context.Background()); err == nil {
+		t.Error("DiscoverInstances() should fail when no discovery metric can be queried")
+	}
+}
+
+func TestADCollector_CollectMetrics(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"ad_replication_pending": {
+			{map[string]string{"agent_hostname": "GX-DC-01"}, 2},
+			{map[string]string{"agent_hostname": "GX-DC-02"}, 0},
+		},
+		"ad_replication_failures": {
+			// Several replication partners: the worst one is kept
+			{map[string]string{"agent_hostname": "GX-DC-01", "partner": "GX-DC-02"}, 0},
+			{map[string]string{"agent_hostname": "GX-DC-01", "partner": "GX-DC-03"}, 3},
+		},
+		"ad_ldap_probe_success": {
+			{map[string]string{"instance": "ldaps://10.0.0.10:636"}, 1},   // Matched by IP
+			{map[string]string{"instance": "gx-dc-02.corp.local:389"}, 0}, // Matched by short name
+			{map[string]string{"instance": "gx-dc-09.corp.local:389"}, 1}, // Unknown controller
+		},
+		"ad_ldap_bind_latency": {
+			{map[string]string{"instance": "gx-dc-01.corp.local:389"}, 0.05},
+		},
+		"ad_sysvol_free_percent": {
+			{map[string]string{"agent_hostname": "GX-DC-01", "volume": "C:"}, 40},
+			{map[string]string{"agent_hostname": "GX-DC-01", "volume": "D:"}, 5}, // Not the SYSVOL volume
+		},
+	}
+	dc1 := model.NewADInstance("GX-DC-01")
+	dc1.SetIP("10.0.0.10")
+	instances := []*model.ADInstance{dc1, model.NewADInstance("GX-DC-02")}
+	metrics := createTestADMetricDefs()
+	collector := createTestADCollector(t, series, nil, &config.ADInspectionConfig{SysvolVolume: "c:"}, metrics)
+
+	results, err := collector.CollectMetrics(context.Background(), instances, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+
+	first := results["GX-DC-01"]
+	if first.ReplicationPending != 2 || first.ReplicationFailures != 3 {
+		t.Errorf("first replication = %v pending, %v failures, want 2 and 3", first.ReplicationPending, first.ReplicationFailures)
+	}
+	if first.LDAPProbeSuccess != 1 || first.LDAPBindLatency != 0.05 {
+		t.Errorf("first LDAP probe = %v success, %v latency, want 1 and 0.05", first.LDAPProbeSuccess, first.LDAPBindLatency)
+	}
+	if first.SysvolFreePercent != 40 {
+		t.Errorf("SysvolFreePercent = %v, want 40 of the configured volume", first.SysvolFreePercent)
+	}
+
+	second := results["GX-DC-02"]
+	if second.LDAPProbeSuccess != 0 || second.LDAPBindLatency != -1 || second.SysvolFreePercent != -1 {
+		t.Errorf("second = %v success, %v latency, %v sysvol, want 0, -1 and -1",
+			second.LDAPProbeSuccess, second.LDAPBindLatency, second.SysvolFreePercent)
+	}
+}
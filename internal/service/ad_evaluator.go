@@ -0,0 +1,298 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// AD Evaluator
+// =============================================================================
+
+// ADEvaluationResult represents the evaluation result for a single domain controller.
+type ADEvaluationResult struct {
+	Identifier string                 `json:"identifier"` // 域控制器标识符
+	Status     model.ADInstanceStatus `json:"status"`     // 域控制器整体状态
+	Alerts     []*model.ADAlert       `json:"alerts"`     // 告警列表
+}
+
+// ADEvaluator evaluates AD metrics against thresholds.
+type ADEvaluator struct {
+	thresholds *config.ADThresholds                 // 阈值配置
+	metricDefs map[string]*model.ADMetricDefinition // 指标定义映射（用于获取显示名称）
+	timezone   *time.Location                       // 时区
+	logger     zerolog.Logger                       // 日志器
+}
+
+// NewADEvaluator creates a new ADEvaluator with the given threshold configuration.
+func NewADEvaluator(
+	thresholds *config.ADThresholds,
+	metrics []*model.ADMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *ADEvaluator {
+	metricDefs := make(map[string]*model.ADMetricDefinition)
+	for _, m := range metrics {
+		metricDefs[m.Name] = m
+	}
+
+	return &ADEvaluator{
+		thresholds: thresholds,
+		metricDefs: metricDefs,
+		timezone:   timezone,
+		logger:     logger.With().Str("component", "ad_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all domain controllers and returns the complete evaluation results.
+func (e *ADEvaluator) EvaluateAll(
+	results map[string]*model.ADInspectionResult,
+) []*ADEvaluationResult {
+	evalResults := make([]*ADEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("AD evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single domain controller against configured thresholds.
+// Replication failures and failed LDAP probes are critical from the first occurrence.
+func (e *ADEvaluator) Evaluate(
+	result *model.ADInspectionResult,
+) *ADEvaluationResult {
+	evalResult := &ADEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.ADStatusNormal,
+		Alerts:     make([]*model.ADAlert, 0),
+	}
+
+	// Skip failed instances
+	if result.Error != "" {
+		evalResult.Status = model.ADStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed domain controller")
+		return evalResult
+	}
+
+	// 1. Evaluate replication (failing partners -> Critical, pending syncs -> thresholds)
+	if result.IsReplicationFailing() {
+		evalResult.Alerts = append(evalResult.Alerts,
+			e.createAlert(result.GetIdentifier(), adReplicationFailuresMetric, result.ReplicationFailures, model.AlertLevelCritical))
+	}
+	if result.HasReplicationPending() {
+		if alert := e.evaluateThreshold(result, adReplicationPendingMetric, result.ReplicationPending,
+			e.thresholds.ReplicationPendingWarning, e.thresholds.ReplicationPendingCritical); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+	}
+
+	// 2. Evaluate LDAP probe (failed -> Critical, bind latency -> thresholds)
+	if result.IsLDAPUnreachable() {
+		evalResult.Alerts = append(evalResult.Alerts,
+			e.createAlert(result.GetIdentifier(), adLDAPProbeSuccessMetric, 0, model.AlertLevelCritical))
+	} else if result.HasLDAPBindLatency() {
+		if alert := e.evaluateThreshold(result, adLDAPBindLatencyMetric, result.LDAPBindLatency,
+			e.thresholds.LDAPBindLatencyWarning, e.thresholds.LDAPBindLatencyCritical); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+	}
+
+	// 3. Evaluate SYSVOL free space (lower is worse)
+	if result.HasSysvolFree() {
+		if alert := e.evaluateLowerThreshold(result, adSysvolFreeMetric, result.SysvolFreePercent,
+			e.thresholds.SysvolFreeWarning, e.thresholds.SysvolFreeCritical); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+	}
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("domain controller evaluation completed")
+
+	return evalResult
+}
+
+// evaluateThreshold evaluates a "higher is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *ADEvaluator) evaluateThreshold(
+	result *model.ADInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.ADAlert {
+	return e.alertAt(result, metricName, value, config.ThresholdPair{Warning: warning, Critical: critical})
+}
+
+// evaluateLowerThreshold evaluates a "lower is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *ADEvaluator) evaluateLowerThreshold(
+	result *model.ADInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.ADAlert {
+	return e.alertAt(result, metricName, value, belowThreshold(warning, critical))
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *ADEvaluator) alertAt(
+	result *model.ADInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.ADAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *ADEvaluator) determineInstanceStatus(
+	alerts []*model.ADAlert,
+) model.ADInstanceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.ADStatusCritical
+	}
+	if hasWarning {
+		return model.ADStatusWarning
+	}
+	return model.ADStatusNormal
+}
+
+// createAlert creates an ADAlert with formatted message.
+func (e *ADEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.ADAlert {
+	displayName := metricName
+	if def, exists := e.metricDefs[metricName]; exists {
+		displayName = def.GetDisplayName()
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.ADAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(metricName, currentValue, level),
+	}
+}
+
+// formatValue formats metric value for display.
+func (e *ADEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case adLDAPProbeSuccessMetric:
+		if value != 0 {
+			return "成功"
+		}
+		return "失败"
+	case adLDAPBindLatencyMetric:
+		return fmt.Sprintf("%.0fms", value*1000)
+	case adSysvolFreeMetric:
+		return fmt.Sprintf("%.1f%%", value)
+	case adReplicationPendingMetric, adReplicationFailuresMetric:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *ADEvaluator) generateAlertMessage(
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case adReplicationFailuresMetric:
+		return fmt.Sprintf("%.0f 个复制伙伴最近一次同步失败，目录数据可能不一致", currentValue)
+	case adLDAPProbeSuccessMetric:
+		return "LDAP 绑定探测失败，域控制器无法提供认证服务"
+	case adReplicationPendingMetric:
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("待处理复制同步数 %.0f，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.ReplicationPendingCritical)
+		}
+		return fmt.Sprintf("待处理复制同步数 %.0f，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.ReplicationPendingWarning)
+	case adLDAPBindLatencyMetric:
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("LDAP 绑定延迟 %.0fms，已超过严重阈值 %.0fms",
+				currentValue*1000, e.thresholds.LDAPBindLatencyCritical*1000)
+		}
+		return fmt.Sprintf("LDAP 绑定延迟 %.0fms，已超过警告阈值 %.0fms",
+			currentValue*1000, e.thresholds.LDAPBindLatencyWarning*1000)
+	case adSysvolFreeMetric:
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("SYSVOL 所在卷剩余空间 %.1f%%，已低于严重阈值 %.0f%%",
+				currentValue, e.thresholds.SysvolFreeCritical)
+		}
+		return fmt.Sprintf("SYSVOL 所在卷剩余空间 %.1f%%，已低于警告阈值 %.0f%%",
+			currentValue, e.thresholds.SysvolFreeWarning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric.
+// Replication failures and failed LDAP probes are critical from the first occurrence.
+func (e *ADEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case adReplicationPendingMetric:
+		return e.thresholds.ReplicationPendingWarning, e.thresholds.ReplicationPendingCritical
+	case adLDAPBindLatencyMetric:
+		return e.thresholds.LDAPBindLatencyWarning, e.thresholds.LDAPBindLatencyCritical
+	case adSysvolFreeMetric:
+		return e.thresholds.SysvolFreeWarning, e.thresholds.SysvolFreeCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *ADEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{adReplicationPendingMetric, adLDAPBindLatencyMetric, adSysvolFreeMetric}, adSysvolFreeMetric)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestADEvaluator creates an AD evaluator with default thresholds for testing.
+func createTestADEvaluator() *ADEvaluator {
+	thresholds := &config.ADThresholds{
+		ReplicationPendingWarning:  50,
+		ReplicationPendingCritical: 200,
+		LDAPBindLatencyWarning:     0.5,
+		LDAPBindLatencyCritical:    2,
+		SysvolFreeWarning:          20,
+		SysvolFreeCritical:         10,
+	}
+	metrics := []*model.ADMetricDefinition{
+		{Name: "ad_replication_failures", DisplayName: "复制失败伙伴数"},
+		{Name: "ad_ldap_probe_success", DisplayName: "LDAP 探测"},
+		{Name: "ad_ldap_bind_latency", DisplayName: "LDAP 绑定延迟"},
+		{Name: "ad_sysvol_free_percent", DisplayName: "SYSVOL 剩余空间"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewADEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// createTestADResult creates a healthy domain controller result.
+func createTestADResult() *model.ADInspectionResult {
+	result := model.NewADInspectionResult(model.NewADInstance("DC01"))
+	result.ReplicationPending = 0
+	result.ReplicationFailures = 0
+	result.LDAPProbeSuccess = 1
+	result.LDAPBindLatency = 0.02
+	result.SysvolFreePercent = 60
+	return result
+}
+
+// =============================================================================
+// Collector Helper Tests
+// =============================================================================
+
+func TestMatchADProbeTarget(t *testing.T) {
+	dc01 := model.NewADInspectionResult(model.NewADInstance("DC01"))
+	dc02 := model.NewADInspectionResult(model.NewADInstance("dc02.corp.local"))
+	dc02.Instance.SetIP("10.0.0.12")
+	resultsMap := map[string]*model.ADInspectionResult{"DC01": dc01, "dc02.corp.local": dc02}
+
+	tests := []struct {
+		target string
+		want   *model.ADInspectionResult
+	}{
+		{"dc01.corp.local:389", dc01},
+		{"DC01:636", dc01},
+		{"ldaps://dc02.corp.local:636", dc02},
+		{"10.0.0.12:389", dc02},
+		{"dc03.corp.local:389", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := matchADProbeTarget(resultsMap, tt.target); got != tt.want {
+			t.Errorf("matchADProbeTarget(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestMergeADValue(t *testing.T) {
+	if got := mergeADValue(adLDAPProbeSuccessMetric, 1, 0); got != 0 {
+		t.Errorf("probe success should keep the failed probe, got %v", got)
+	}
+	if got := mergeADValue(adLDAPBindLatencyMetric, 0.1, 0.3); got != 0.3 {
+		t.Errorf("latency should keep the slowest probe, got %v", got)
+	}
+}
+
+// =============================================================================
+// Evaluator Tests
+// =============================================================================
+
+func TestADEvaluator_Healthy(t *testing.T) {
+	e := createTestADEvaluator()
+	eval := e.Evaluate(createTestADResult())
+
+	if eval.Status != model.ADStatusNormal || len(eval.Alerts) != 0 {
+		t.Errorf("expected normal status without alerts, got %s with %d alerts", eval.Status, len(eval.Alerts))
+	}
+}
+
+func TestADEvaluator_ReplicationAndLDAPFailures(t *testing.T) {
+	e := createTestADEvaluator()
+	result := createTestADResult()
+	result.ReplicationFailures = 2
+	result.LDAPProbeSuccess = 0
+	result.LDAPBindLatency = 5 // Timed out probe; must not raise a second LDAP alert
+
+	eval := e.Evaluate(result)
+
+	if eval.Status != model.ADStatusCritical {
+		t.Errorf("expected critical status, got %s", eval.Status)
+	}
+	if len(eval.Alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(eval.Alerts))
+	}
+	if eval.Alerts[0].MetricName != adReplicationFailuresMetric || !strings.Contains(eval.Alerts[0].Message, "2 个复制伙伴") {
+		t.Errorf("unexpected replication alert: %+v", eval.Alerts[0])
+	}
+	if eval.Alerts[1].MetricName != adLDAPProbeSuccessMetric || eval.Alerts[1].FormattedValue != "失败" {
+		t.Errorf("unexpected LDAP alert: %+v", eval.Alerts[1])
+	}
+}
+
+func TestADEvaluator_Thresholds(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *model.ADInspectionResult)
+		metric string
+		want   model.AlertLevel
+	}{
+		{"pending warning", func(r *model.ADInspectionResult) { r.ReplicationPending = 60 }, adReplicationPendingMetric, model.AlertLevelWarning},
+		{"latency critical", func(r *model.ADInspectionResult) { r.LDAPBindLatency = 2.5 }, adLDAPBindLatencyMetric, model.AlertLevelCritical},
+		{"sysvol warning", func(r *model.ADInspectionResult) { r.SysvolFreePercent = 15 }, adSysvolFreeMetric, model.AlertLevelWarning},
+		{"sysvol critical", func(r *model.ADInspectionResult) { r.SysvolFreePercent = 8 }, adSysvolFreeMetric, model.AlertLevelCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := createTestADEvaluator()
+			result := createTestADResult()
+			tt.modify(result)
+
+			eval := e.Evaluate(result)
+			if len(eval.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(eval.Alerts))
+			}
+			if eval.Alerts[0].MetricName != tt.metric || eval.Alerts[0].Level != tt.want {
+				t.Errorf("expected %s %s alert, got %s %s", tt.want, tt.metric, eval.Alerts[0].Level, eval.Alerts[0].MetricName)
+			}
+		})
+	}
+}
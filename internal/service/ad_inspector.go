@@ -0,0 +1,236 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// ADInspector orchestrates the complete AD inspection workflow, coordinating
+// instance discovery, data collection, threshold evaluation, and result aggregation.
+type ADInspector struct {
+	collector *ADCollector
+	evaluator *ADEvaluator
+	config    *config.Config
+	timezone  *time.Location
+	version   string
+	logger    zerolog.Logger
+}
+
+// ADInspectorOption is a functional option for configuring an ADInspector.
+type ADInspectorOption func(*ADInspector)
+
+// NewADInspector creates a new ADInspector with the given dependencies.
+//
+// Parameters:
+//   - cfg: Complete configuration including AD inspection config
+//   - collector: AD data collector
+//   - evaluator: Threshold evaluator
+//   - logger: Structured logger
+//   - opts: Optional configuration via functional options
+//
+// Returns:
+//   - *ADInspector: Configured inspector instance
+//   - error: Timezone loading error or validation failure
+func NewADInspector(
+	cfg *config.Config,
+	collector *ADCollector,
+	evaluator *ADEvaluator,
+	logger zerolog.Logger,
+	opts ...ADInspectorOption,
+) (*ADInspector, error) {
+	// Validate required parameters
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if collector == nil {
+		return nil, fmt.Errorf("collector cannot be nil")
+	}
+	if evaluator == nil {
+		return nil, fmt.Errorf("evaluator cannot be nil")
+	}
+
+	// Determine timezone (from config or use default)
+	tzName := defaultTimezone
+	if cfg.Report.Timezone != "" {
+		tzName = cfg.Report.Timezone
+	}
+
+	// Load timezone
+	loc, err := time.LoadLocation(tzName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s: %w", tzName, err)
+	}
+
+	i := &ADInspector{
+		collector: collector,
+		evaluator: evaluator,
+		config:    cfg,
+		timezone:  loc,
+		version:   "dev",
+		logger:    logger.With().Str("component", "ad_inspector").Logger(),
+	}
+
+	// Apply functional options
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
+}
+
+// WithADVersion sets the tool version to include in the inspection result.
+func WithADVersion(version string) ADInspectorOption {
+	return func(i *ADInspector) {
+		i.version = version
+	}
+}
+
+// GetTimezone returns the configured timezone.
+func (i *ADInspector) GetTimezone() *time.Location {
+	return i.timezone
+}
+
+// GetVersion returns the configured version.
+func (i *ADInspector) GetVersion() string {
+	return i.version
+}
+
+// Inspect executes the complete AD inspection workflow:
+// 1. Discovers domain controllers
+// 2. Collects metrics for all instances
+// 3. Evaluates thresholds and generates alerts
+// 4. Aggregates results into ADInspectionResults
+//
+// Returns:
+//   - *model.ADInspectionResults: Complete inspection result with summary
+//   - error: Fatal errors that prevent inspection (discovery/config loading failures)
+func (i *ADInspector) Inspect(ctx context.Context) (*model.ADInspectionResults, error) {
+	// Step 1: Record start time (Asia/Shanghai)
+	startTime := time.Now().In(i.timezone)
+	i.logger.Info().
+		Time("start_time", startTime).
+		Str("timezone", i.timezone.String()).
+		Msg("starting AD inspection")
+
+	// Step 2: Create result container
+	result := model.NewADInspectionResults(startTime)
+	result.Version = i.version
+
+	// Step 3: Discover instances
+	i.logger.Debug().Msg("step 1: discovering domain controllers")
+	instances, err := i.collector.DiscoverInstances(ctx)
+	if err != nil {
+		i.logger.Error().Err(err).Msg("instance discovery failed")
+		return nil, fmt.Errorf("instance discovery failed: %w", err)
+	}
+
+	// Step 4: Handle empty instance list (graceful degradation)
+	if len(instances) == 0 {
+		i.logger.Warn().Msg("no domain controllers found, completing inspection with empty result")
+		endTime := time.Now().In(i.timezone)
+		result.Finalize(endTime)
+		return result, nil
+	}
+
+	i.logger.Info().Int("instance_count", len(instances)).Msg("discovered domain controllers")
+
+	// Step 5: Load metric definitions (use collector's internal metrics)
+	i.logger.Debug().Msg("step 2: loading AD metric definitions")
+	metrics := i.collector.GetMetrics()
+	if len(metrics) == 0 {
+		i.logger.Error().Msg("no AD metrics defined")
+		return nil, fmt.Errorf("no AD metrics defined")
+	}
+
+	i.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("step 3: collecting metrics")
+
+	resultsMap, err := i.collector.CollectMetrics(ctx, instances, metrics)
+	if err != nil {
+		i.logger.Error().Err(err).Msg("metrics collection failed")
+		return nil, fmt.Errorf("metrics collection failed: %w", err)
+	}
+
+	// Step 6: Evaluate thresholds
+	i.logger.Debug().
+		Int("results_count", len(resultsMap)).
+		Msg("step 4: evaluating thresholds")
+
+	_ = i.evaluator.EvaluateAll(resultsMap)
+
+	// Step 7: Build results
+	i.logger.Debug().Msg("step 5: building inspection results")
+	i.buildInspectionResults(result, resultsMap)
+
+	// Step 8: Finalize (calculate Duration, Summary, AlertSummary)
+	endTime := time.Now().In(i.timezone)
+	result.Finalize(endTime)
+
+	i.logger.Info().
+		Int("total_instances", result.Summary.TotalInstances).
+		Int("normal_instances", result.Summary.NormalInstances).
+		Int("warning_instances", result.Summary.WarningInstances).
+		Int("critical_instances", result.Summary.CriticalInstances).
+		Int("failed_instances", result.Summary.FailedInstances).
+		Int("replication_failing", result.Summary.ReplicationFailing).
+		Int("ldap_unreachable", result.Summary.LDAPUnreachable).
+		Int("total_alerts", result.AlertSummary.TotalAlerts).
+		Dur("duration", result.Duration).
+		Msg("AD inspection completed")
+
+	// Step 9: Log critical alerts if any
+	if result.HasCritical() {
+		i.logger.Warn().
+			Int("critical_count", result.Summary.CriticalInstances).
+			Int("critical_alerts", result.AlertSummary.CriticalCount).
+			Msg("AD inspection found critical issues")
+	}
+
+	return result, nil
+}
+
+// buildInspectionResults merges collection results into ADInspectionResults.
+func (i *ADInspector) buildInspectionResults(
+	result *model.ADInspectionResults,
+	resultsMap map[string]*model.ADInspectionResult,
+) {
+	// Iterate through all instance results
+	for _, inspResult := range resultsMap {
+		if inspResult == nil {
+			continue
+		}
+
+		// Convert timestamp to configured timezone
+		inspResult.CollectedAt = inspResult.CollectedAt.In(i.timezone)
+
+		// Add to result container (automatically aggregates alerts)
+		result.AddResult(inspResult)
+	}
+
+	i.logger.Debug().
+		Int("total_results", len(result.Results)).
+		Int("total_alerts", len(result.Alerts)).
+		Msg("inspection results merged")
+}
+
+// IsEnabled returns true if AD inspection is enabled in the configuration.
+func (i *ADInspector) IsEnabled() bool {
+	return i.config != nil && i.config.AD.Enabled
+}
+
+// GetConfig returns the AD inspection configuration.
+func (i *ADInspector) GetConfig() *config.ADInspectionConfig {
+	if i.config == nil {
+		return nil
+	}
+	return &i.config.AD
+}
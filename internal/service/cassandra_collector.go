@@ -0,0 +1,474 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Cassandra Collector
+// =============================================================================
+
+// CassandraCollector is the data collection service for Cassandra nodes.
+// It integrates with VictoriaMetrics to collect Cassandra monitoring metrics
+// and N9E to obtain host IP addresses.
+type CassandraCollector struct {
+	vmClient       vm.MetricsSource
+	n9eClient      *n9e.Client // 用于获取 IP 地址
+	config         *config.CassandraInspectionConfig
+	metrics        []*model.CassandraMetricDefinition
+	metricDefs     map[string]*model.CassandraMetricDefinition
+	instanceFilter *CassandraInstanceFilter
+	logger         zerolog.Logger
+}
+
+// CassandraInstanceFilter defines filtering criteria for Cassandra nodes.
+type CassandraInstanceFilter struct {
+	HostnamePatterns []string          // Hostname patterns (glob, e.g., "GX-CASS-*")
+	BusinessGroups   []string          // Business groups (OR relation)
+	Tags             map[string]string // Tags (AND relation)
+}
+
+// NewCassandraCollector creates a new CassandraCollector instance.
+func NewCassandraCollector(
+	cfg *config.CassandraInspectionConfig,
+	vmClient vm.MetricsSource,
+	n9eClient *n9e.Client,
+	metrics []*model.CassandraMetricDefinition,
+	logger zerolog.Logger,
+) *CassandraCollector {
+	c := &CassandraCollector{
+		vmClient:  vmClient,
+		n9eClient: n9eClient,
+		config:    cfg,
+		metrics:   metrics,
+		logger:    logger.With().Str("component", "cassandra-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.CassandraMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	// Build instance filter from config
+	c.instanceFilter = c.buildInstanceFilter()
+
+	return c
+}
+
+// buildInstanceFilter converts config.CassandraFilter to CassandraInstanceFilter.
+func (c *CassandraCollector) buildInstanceFilter() *CassandraInstanceFilter {
+	if c.config == nil {
+		return nil
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.HostnamePatterns) == 0 &&
+		len(filter.BusinessGroups) == 0 &&
+		len(filter.Tags) == 0 {
+		return nil
+	}
+
+	return &CassandraInstanceFilter{
+		HostnamePatterns: filter.HostnamePatterns,
+		BusinessGroups:   filter.BusinessGroups,
+		Tags:             filter.Tags,
+	}
+}
+
+// GetConfig returns the Cassandra inspection configuration.
+func (c *CassandraCollector) GetConfig() *config.CassandraInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *CassandraCollector) GetMetrics() []*model.CassandraMetricDefinition {
+	return c.metrics
+}
+
+// GetInstanceFilter returns the instance filter.
+func (c *CassandraCollector) GetInstanceFilter() *CassandraInstanceFilter {
+	return c.instanceFilter
+}
+
+// IsEmpty returns true if the instance filter has no filtering criteria.
+func (f *CassandraInstanceFilter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.HostnamePatterns) == 0 &&
+		len(f.BusinessGroups) == 0 &&
+		len(f.Tags) == 0
+}
+
+// ToVMHostFilter converts CassandraInstanceFilter to vm.HostFilter.
+// Note: HostnamePatterns are not supported in vm.HostFilter and are
+// handled separately in the DiscoverInstances method.
+func (f *CassandraInstanceFilter) ToVMHostFilter() *vm.HostFilter {
+	if f == nil || f.IsEmpty() {
+		return nil
+	}
+
+	if len(f.BusinessGroups) == 0 && len(f.Tags) == 0 {
+		return nil
+	}
+
+	return &vm.HostFilter{
+		BusinessGroups: f.BusinessGroups,
+		Tags:           f.Tags,
+	}
+}
+
+// =============================================================================
+// Cassandra 节点发现
+// =============================================================================
+
+// DiscoverInstances discovers all Cassandra nodes by querying the cassandra_up metric.
+// Unlike other modules, nodes reporting cassandra_up=0 are kept so that DN nodes
+// appear in the report. Metadata (cluster_name, datacenter, rack, version) is taken
+// from cassandra_info and IP addresses are retrieved from N9E API.
+func (c *CassandraCollector) DiscoverInstances(ctx context.Context) ([]*model.CassandraInstance, error) {
+	c.logger.Info().Msg("starting Cassandra node discovery")
+
+	// Step 1: Query cassandra_up to get all nodes (UN and DN)
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	results, err := c.vmClient.QueryResultsWithFilter(ctx, "cassandra_up", vmFilter)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("failed to query cassandra_up metric")
+		return nil, fmt.Errorf("failed to query cassandra_up: %w", err)
+	}
+
+	c.logger.Debug().Int("raw_results", len(results)).Msg("received cassandra_up query results")
+
+	// Step 2: Query cassandra_info to get node metadata
+	infoResults, err := c.vmClient.QueryResultsWithFilter(ctx, "cassandra_info", vmFilter)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("failed to query cassandra_info, continuing with limited metadata")
+		infoResults = []vm.QueryResult{}
+	}
+	infoMap := c.buildInfoMap(infoResults)
+
+	// Step 3: Extract instances and apply filters
+	var instances []*model.CassandraInstance
+	seenIdentifiers := make(map[string]bool)
+
+	for _, result := range results {
+		hostname := labelHostname(result.Labels)
+		if hostname == "" {
+			c.logger.Warn().Interface("labels", result.Labels).Msg("missing hostname labels")
+			continue
+		}
+
+		if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
+			c.logger.Debug().Str("hostname", hostname).Msg("hostname filtered out")
+			continue
+		}
+
+		// Port from cassandra_up labels, falling back to cassandra_info
+		port := parsePortLabel(result.Labels["port"])
+		if port == 0 {
+			port = parsePortLabel(infoMap[hostname]["port"])
+		}
+		if port == 0 {
+			c.logger.Warn().
+				Str("hostname", hostname).
+				Msg("skipping node: no port info")
+			continue
+		}
+
+		identifier := model.GenerateCassandraIdentifier(hostname, port)
+		if seenIdentifiers[identifier] {
+			c.logger.Debug().Str("identifier", identifier).Msg("skipping duplicate node")
+			continue
+		}
+
+		instance := model.NewCassandraInstance(hostname, port)
+		c.populateInstanceFromInfo(instance, infoMap, hostname)
+		instance.SetIP(n9eHostIP(ctx, c.n9eClient, hostname, c.logger))
+
+		instances = append(instances, instance)
+		seenIdentifiers[identifier] = true
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Int("filtered_out", len(results)-len(instances)).
+		Msg("Cassandra node discovery completed")
+
+	return instances, nil
+}
+
+// buildInfoMap builds a map of hostname -> labels from cassandra_info.
+func (c *CassandraCollector) buildInfoMap(results []vm.QueryResult) map[string]map[string]string {
+	infoMap := make(map[string]map[string]string)
+
+	for _, result := range results {
+		hostname := labelHostname(result.Labels)
+		if hostname != "" {
+			infoMap[hostname] = result.Labels
+		}
+	}
+
+	return infoMap
+}
+
+// parsePortLabel parses a port label value, returning 0 if empty or invalid.
+func parsePortLabel(value string) int {
+	if value == "" {
+		return 0
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return port
+}
+
+// populateInstanceFromInfo populates instance fields from cassandra_info labels.
+func (c *CassandraCollector) populateInstanceFromInfo(
+	instance *model.CassandraInstance,
+	infoMap map[string]map[string]string,
+	hostname string,
+) {
+	info, ok := infoMap[hostname]
+	if !ok {
+		return
+	}
+
+	if name := info["cluster_name"]; name != "" {
+		instance.SetClusterName(name)
+	}
+	if dc := info["datacenter"]; dc != "" {
+		instance.SetDatacenter(dc)
+	}
+	if rack := info["rack"]; rack != "" {
+		instance.SetRack(rack)
+	}
+	if version := info["version"]; version != "" {
+		instance.SetVersion(version)
+	}
+}
+
+// =============================================================================
+// Cassandra 指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all Cassandra nodes.
+//
+// Flow:
+//  1. Initialize result objects for each node
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values from metrics
+//  6. Return results map (key = identifier)
+//
+// Single metric failure does not abort the entire collection.
+func (c *CassandraCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.CassandraInstance,
+	metrics []*model.CassandraMetricDefinition,
+) (map[string]*model.CassandraInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting Cassandra metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by identifier)
+	resultsMap := make(map[string]*model.CassandraInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Identifier] = model.NewCassandraInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.CassandraMetricDefinition
+	var activeMetrics []*model.CassandraMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("Cassandra metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for all pending metrics on all nodes.
+func (c *CassandraCollector) setPendingMetrics(
+	resultsMap map[string]*model.CassandraInspectionResult,
+	pendingMetrics []*model.CassandraMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending Cassandra metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			result.SetMetric(&model.CassandraMetricValue{
+				Name:           metric.Name,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// collectMetricConcurrent collects a single metric for all nodes (concurrent-safe).
+// Metrics with label_extract additionally store the joined label values as StringValue.
+func (c *CassandraCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.CassandraMetricDefinition,
+	resultsMap map[string]*model.CassandraInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting Cassandra metric (concurrent)")
+
+	// Query VictoriaMetrics
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		hostname := labelHostname(result.Labels)
+		if hostname == "" {
+			continue
+		}
+		identifier := model.GenerateCassandraIdentifier(hostname, parsePortLabel(result.Labels["port"]))
+
+		inspResult, ok := resultsMap[identifier]
+		if !ok {
+			continue
+		}
+
+		mv := &model.CassandraMetricValue{
+			Name:      metric.Name,
+			RawValue:  result.Value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields.
+// NaN/Inf values (e.g. no data in the rate window) are treated as not collected.
+func (c *CassandraCollector) extractFieldsFromMetrics(resultsMap map[string]*model.CassandraInspectionResult) {
+	for _, result := range resultsMap {
+		// cassandra_up -> Up (UN/DN)
+		if mv := result.GetMetric("cassandra_up"); mv != nil && !mv.IsNA {
+			result.Up = mv.RawValue == 1
+		}
+
+		if mv := result.GetMetric("cassandra_down_endpoints"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.DownEndpoints = int(mv.RawValue)
+		}
+		if mv := result.GetMetric("cassandra_pending_compactions"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.PendingCompactions = mv.RawValue
+		}
+		if mv := result.GetMetric("cassandra_dropped_mutations"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.DroppedMutations = mv.RawValue
+		}
+		if mv := result.GetMetric("cassandra_heap_usage"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.HeapUsagePercent = mv.RawValue
+		}
+		if mv := result.GetMetric("cassandra_hints_backlog"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.HintsBacklog = mv.RawValue
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// createTestCassandraCollector creates a CassandraCollector querying fake VM and N9E servers.
+func createTestCassandraCollector(t *testing.T, series map[string][]fakeSeries, ips map[string]string, cfg *config.CassandraInspectionConfig, metrics []*model.CassandraMetricDefinition) *CassandraCollector {
+	t.Helper()
+	vmServer := newFakeVMServer(t, series)
+	n9eServer := newFakeN9EServer(t, ips)
+	return NewCassandraCollector(cfg, newFakeVMClient(vmServer.URL), newFakeN9EClient(n9eServer.URL), metrics, zerolog.Nop())
+}
+
+func TestCassandraCollector_DiscoverInstances(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"cassandra_up": {
+			{map[string]string{"agent_hostname": "GX-CASS-01", "port": "9042"}, 1},
+			{map[string]string{"ident": "GX-CASS-02"}, 0},                          // DN node, port from cassandra_info
+			{map[string]string{"agent_hostname": "GX-CASS-01", "port": "9042"}, 1}, // Duplicate
+			{map[string]string{"agent_hostname": "SH-CASS-01", "port": "9042"}, 1}, // Filtered out
+			{map[string]string{"port": "9042"}, 1},                                 // No hostname
+			{map[string]string{"agent_hostname": "GX-CASS-03"}, 1},                 // No port
+		},
+		"cassandra_info": {
+			{map[string]string{"agent_hostname": "GX-CASS-01", "cluster_name": "prod", "datacenter": "dc1", "rack": "rack1", "version": "4.1.3"}, 1},
+			{map[string]string{"agent_hostname": "GX-CASS-02", "port": "9042", "datacenter": "dc2"}, 1},
+		},
+	}
+	cfg := &config.CassandraInspectionConfig{InstanceFilter: config.CassandraFilter{HostnamePatterns: []string{"GX-CASS-*"}}}
+	collector := createTestCassandraCollector(t, series, map[string]string{"GX-CASS-01": "10.0.0.1"}, cfg, nil)
+
+	instances, err := collector.DiscoverInstances(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverInstances() error = %v", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("DiscoverInstances() returned %d nodes, want 2: %+v", len(instances), instances)
+	}
+
+	first := instances[0]
+	if first.Identifier != "GX-CASS-01:9042" || first.IP != "10.0.0.1" || first.ClusterName != "prod" ||
+		first.Datacenter != "dc1" || first.Rack != "rack1" || first.Version != "4.1.3" {
+		t.Errorf("first node = %+v", first)
+	}
+	second := instances[1]
+	if second.Identifier != "GX-CASS-02:9042" || second.IP != "N/A" || second.Datacenter != "dc2" {
+		t.Errorf("second node = %+v, want the DN node with the port of cassandra_info and no N9E IP", second)
+	}
+}
+
+func TestCassandraCollector_DiscoverInstances_QueryError(t *testing.T) {
+	collector := NewCassandraCollector(nil, newFakeVMClient("http://127.0.0.1:1"), nil, nil, zerolog.Nop())
+	if _, err := collector.DiscoverInstances(context.Background()); err == nil {
+		t.Error("DiscoverInstances() should fail when cassandra_up cannot be queried")
+	}
+}
+
+func TestCassandraCollector_CollectMetrics(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"cassandra_up":             {{map[string]string{"agent_hostname": "GX-CASS-01", "port": "9042"}, 1}},
+		"cassandra_heap_usage":     {{map[string]string{"agent_hostname": "GX-CASS-01", "port": "9042"}, 62.5}},
+		"cassandra_down_endpoints": {{map[string]string{"agent_hostname": "GX-CASS-01", "port": "9042"}, 1}, {map[string]string{"agent_hostname": "GX-CASS-09", "port": "9042"}, 3}},
+	}
+	metrics := []*model.CassandraMetricDefinition{
+		{Name: "cassandra_up", Query: "cassandra_up"},
+		{Name: "cassandra_heap_usage", Query: "cassandra_heap_usage"},
+		{Name: "cassandra_down_endpoints", Query: "cassandra_down_endpoints"},
+		{Name: "cassandra_pending_compactions", Status: "pending"},
+	}
+	collector := createTestCassandraCollector(t, series, nil, nil, metrics)
+
+	instance := model.NewCassandraInstance("GX-CASS-01", 9042)
+	results, err := collector.CollectMetrics(context.Background(), []*model.CassandraInstance{instance}, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("CollectMetrics() returned %d results, want only the discovered node", len(results))
+	}
+
+	result := results["GX-CASS-01:9042"]
+	if !result.Up || result.HeapUsagePercent != 62.5 || result.DownEndpoints != 1 {
+		t.Errorf("result = up %v, heap %v, down endpoints %d", result.Up, result.HeapUsagePercent, result.DownEndpoints)
+	}
+	if result.PendingCompactions != -1 {
+		t.Errorf("PendingCompactions = %v, want -1 for a pending metric", result.PendingCompactions)
+	}
+	if mv := result.GetMetric("cassandra_pending_compactions"); mv == nil || !mv.IsNA {
+		t.Errorf("pending metric = %+v, want N/A", mv)
+	}
+	if result.CollectedAt.IsZero() {
+		t.Error("CollectedAt should be set")
+	}
+}
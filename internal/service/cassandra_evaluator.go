@@ -0,0 +1,334 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Cassandra Evaluator
+// =============================================================================
+
+// CassandraEvaluationResult represents the evaluation result for a single Cassandra node.
+type CassandraEvaluationResult struct {
+	Identifier string                        `json:"identifier"` // 节点标识符
+	Status     model.CassandraInstanceStatus `json:"status"`     // 节点整体状态
+	Alerts     []*model.CassandraAlert       `json:"alerts"`     // 告警列表
+}
+
+// CassandraEvaluator evaluates Cassandra node metrics against thresholds.
+type CassandraEvaluator struct {
+	thresholds *config.CassandraThresholds                 // 阈值配置
+	metricDefs map[string]*model.CassandraMetricDefinition // 指标定义映射（用于获取显示名称）
+	timezone   *time.Location                              // 时区
+	logger     zerolog.Logger                              // 日志器
+}
+
+// NewCassandraEvaluator creates a new CassandraEvaluator with the given threshold configuration.
+func NewCassandraEvaluator(
+	thresholds *config.CassandraThresholds,
+	metrics []*model.CassandraMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *CassandraEvaluator {
+	metricDefs := make(map[string]*model.CassandraMetricDefinition)
+	for _, m := range metrics {
+		metricDefs[m.Name] = m
+	}
+
+	return &CassandraEvaluator{
+		thresholds: thresholds,
+		metricDefs: metricDefs,
+		timezone:   timezone,
+		logger:     logger.With().Str("component", "cassandra_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all Cassandra nodes and returns the complete evaluation results.
+func (e *CassandraEvaluator) EvaluateAll(
+	results map[string]*model.CassandraInspectionResult,
+) []*CassandraEvaluationResult {
+	evalResults := make([]*CassandraEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("Cassandra evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single Cassandra node against configured thresholds.
+// A DN node only raises the node status alert, since its other metrics are stale.
+func (e *CassandraEvaluator) Evaluate(
+	result *model.CassandraInspectionResult,
+) *CassandraEvaluationResult {
+	evalResult := &CassandraEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.CassandraStatusNormal,
+		Alerts:     make([]*model.CassandraAlert, 0),
+	}
+
+	// Skip failed instances
+	if result.Error != "" {
+		evalResult.Status = model.CassandraStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed node")
+		return evalResult
+	}
+
+	// 1. Evaluate node status (UN/DN)
+	if alert := e.evaluateNodeStatus(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	} else {
+		// 2. Evaluate cluster view, compactions, dropped mutations, heap and hints
+		if alert := e.evaluateDownEndpoints(result); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+		if result.HasPendingCompactions() {
+			if alert := e.evaluateThreshold(result, "cassandra_pending_compactions", result.PendingCompactions,
+				e.thresholds.PendingCompactionsWarning, e.thresholds.PendingCompactionsCritical); alert != nil {
+				evalResult.Alerts = append(evalResult.Alerts, alert)
+			}
+		}
+		if result.HasDroppedMutations() {
+			if alert := e.evaluateThreshold(result, "cassandra_dropped_mutations", result.DroppedMutations,
+				e.thresholds.DroppedMutationsWarning, e.thresholds.DroppedMutationsCritical); alert != nil {
+				evalResult.Alerts = append(evalResult.Alerts, alert)
+			}
+		}
+		if result.HasHeapUsage() {
+			if alert := e.evaluateThreshold(result, "cassandra_heap_usage", result.HeapUsagePercent,
+				e.thresholds.HeapUsageWarning, e.thresholds.HeapUsageCritical); alert != nil {
+				evalResult.Alerts = append(evalResult.Alerts, alert)
+			}
+		}
+		if result.HasHintsBacklog() {
+			if alert := e.evaluateThreshold(result, "cassandra_hints_backlog", result.HintsBacklog,
+				e.thresholds.HintsBacklogWarning, e.thresholds.HintsBacklogCritical); alert != nil {
+				evalResult.Alerts = append(evalResult.Alerts, alert)
+			}
+		}
+	}
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("node evaluation completed")
+
+	return evalResult
+}
+
+// evaluateNodeStatus evaluates cassandra_up.
+// cassandra_up = 0 (DN) -> Critical
+func (e *CassandraEvaluator) evaluateNodeStatus(
+	result *model.CassandraInspectionResult,
+) *model.CassandraAlert {
+	mv := result.GetMetric("cassandra_up")
+	if mv == nil || mv.IsNA {
+		return nil // Metric not collected, skip
+	}
+
+	if !result.Up {
+		return e.createAlert(result.GetIdentifier(), "cassandra_up", 0, model.AlertLevelCritical)
+	}
+
+	return nil
+}
+
+// evaluateDownEndpoints evaluates the number of peers this node sees as DN.
+// Any DN peer -> Warning (the DN node itself raises the critical alert when it is inspected).
+func (e *CassandraEvaluator) evaluateDownEndpoints(
+	result *model.CassandraInspectionResult,
+) *model.CassandraAlert {
+	if !result.HasDownEndpoints() || result.DownEndpoints == 0 {
+		return nil
+	}
+
+	return e.createAlert(result.GetIdentifier(), "cassandra_down_endpoints",
+		float64(result.DownEndpoints), model.AlertLevelWarning)
+}
+
+// evaluateThreshold evaluates a "higher is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *CassandraEvaluator) evaluateThreshold(
+	result *model.CassandraInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.CassandraAlert {
+	return e.alertAt(result, metricName, value, config.ThresholdPair{Warning: warning, Critical: critical})
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *CassandraEvaluator) alertAt(
+	result *model.CassandraInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.CassandraAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *CassandraEvaluator) determineInstanceStatus(
+	alerts []*model.CassandraAlert,
+) model.CassandraInstanceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.CassandraStatusCritical
+	}
+	if hasWarning {
+		return model.CassandraStatusWarning
+	}
+	return model.CassandraStatusNormal
+}
+
+// createAlert creates a CassandraAlert with formatted message.
+func (e *CassandraEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.CassandraAlert {
+	displayName := metricName
+	if def, exists := e.metricDefs[metricName]; exists {
+		displayName = def.GetDisplayName()
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.CassandraAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(metricName, currentValue, level),
+	}
+}
+
+// formatValue formats metric value for display.
+func (e *CassandraEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case "cassandra_up":
+		if value == 0 {
+			return model.CassandraNodeStateDown
+		}
+		return model.CassandraNodeStateUp
+	case "cassandra_heap_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "cassandra_down_endpoints", "cassandra_pending_compactions",
+		"cassandra_dropped_mutations", "cassandra_hints_backlog":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *CassandraEvaluator) generateAlertMessage(
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case "cassandra_up":
+		return "Cassandra 节点状态为 DN (cassandra_up=0)"
+	case "cassandra_down_endpoints":
+		return fmt.Sprintf("当前节点视角下集群中有 %.0f 个节点处于 DN 状态", currentValue)
+	case "cassandra_pending_compactions":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("待执行 Compaction 任务数为 %.0f，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.PendingCompactionsCritical)
+		}
+		return fmt.Sprintf("待执行 Compaction 任务数为 %.0f，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.PendingCompactionsWarning)
+	case "cassandra_dropped_mutations":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("近 5 分钟丢弃 Mutation %.0f 个，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.DroppedMutationsCritical)
+		}
+		return fmt.Sprintf("近 5 分钟丢弃 Mutation %.0f 个，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.DroppedMutationsWarning)
+	case "cassandra_heap_usage":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("JVM 堆内存使用率为 %.1f%%，已超过严重阈值 %.1f%%",
+				currentValue, e.thresholds.HeapUsageCritical)
+		}
+		return fmt.Sprintf("JVM 堆内存使用率为 %.1f%%，已超过警告阈值 %.1f%%",
+			currentValue, e.thresholds.HeapUsageWarning)
+	case "cassandra_hints_backlog":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("Hints 积压为 %.0f，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.HintsBacklogCritical)
+		}
+		return fmt.Sprintf("Hints 积压为 %.0f，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.HintsBacklogWarning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric.
+func (e *CassandraEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case "cassandra_up":
+		return 1, 1
+	case "cassandra_down_endpoints":
+		return 1, 0
+	case "cassandra_pending_compactions":
+		return e.thresholds.PendingCompactionsWarning, e.thresholds.PendingCompactionsCritical
+	case "cassandra_dropped_mutations":
+		return e.thresholds.DroppedMutationsWarning, e.thresholds.DroppedMutationsCritical
+	case "cassandra_heap_usage":
+		return e.thresholds.HeapUsageWarning, e.thresholds.HeapUsageCritical
+	case "cassandra_hints_backlog":
+		return e.thresholds.HintsBacklogWarning, e.thresholds.HintsBacklogCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *CassandraEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{
+		"cassandra_pending_compactions", "cassandra_dropped_mutations", "cassandra_heap_usage", "cassandra_hints_backlog",
+	})
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestCassandraEvaluator creates a Cassandra evaluator with default thresholds for testing.
+func createTestCassandraEvaluator() *CassandraEvaluator {
+	thresholds := &config.CassandraThresholds{
+		PendingCompactionsWarning:  100,
+		PendingCompactionsCritical: 500,
+		DroppedMutationsWarning:    1,
+		DroppedMutationsCritical:   100,
+		HeapUsageWarning:           80,
+		HeapUsageCritical:          90,
+		HintsBacklogWarning:        100,
+		HintsBacklogCritical:       1000,
+	}
+	metrics := []*model.CassandraMetricDefinition{
+		{Name: "cassandra_up", DisplayName: "节点状态"},
+		{Name: "cassandra_down_endpoints", DisplayName: "DN 节点数"},
+		{Name: "cassandra_pending_compactions", DisplayName: "待执行 Compaction"},
+		{Name: "cassandra_heap_usage", DisplayName: "堆内存使用率"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewCassandraEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// createTestCassandraResult creates a test Cassandra inspection result for a UN node.
+func createTestCassandraResult() *model.CassandraInspectionResult {
+	result := model.NewCassandraInspectionResult(model.NewCassandraInstance("cass-node-01", 9042))
+	result.Up = true
+	result.SetMetric(&model.CassandraMetricValue{Name: "cassandra_up", RawValue: 1})
+	return result
+}
+
+// =============================================================================
+// Node Status Tests
+// =============================================================================
+
+func TestCassandraEvaluator_DownNode(t *testing.T) {
+	evaluator := createTestCassandraEvaluator()
+	result := model.NewCassandraInspectionResult(model.NewCassandraInstance("cass-node-02", 9042))
+	result.SetMetric(&model.CassandraMetricValue{Name: "cassandra_up", RawValue: 0})
+	result.PendingCompactions = 1000
+	result.HeapUsagePercent = 99
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.CassandraStatusCritical {
+		t.Errorf("expected critical status for DN node, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 1 {
+		t.Fatalf("expected only the node status alert, got %d alerts", len(evalResult.Alerts))
+	}
+	alert := evalResult.Alerts[0]
+	if alert.MetricName != "cassandra_up" {
+		t.Errorf("expected cassandra_up alert, got %s", alert.MetricName)
+	}
+	if alert.FormattedValue != model.CassandraNodeStateDown {
+		t.Errorf("expected formatted value %s, got %s", model.CassandraNodeStateDown, alert.FormattedValue)
+	}
+}
+
+func TestCassandraEvaluator_DownEndpoints(t *testing.T) {
+	evaluator := createTestCassandraEvaluator()
+	result := createTestCassandraResult()
+	result.DownEndpoints = 2
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.CassandraStatusWarning {
+		t.Errorf("expected warning status, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(evalResult.Alerts))
+	}
+	if !strings.Contains(evalResult.Alerts[0].Message, "2 个节点") {
+		t.Errorf("expected message to mention down node count, got: %s", evalResult.Alerts[0].Message)
+	}
+}
+
+// =============================================================================
+// Threshold Tests
+// =============================================================================
+
+func TestCassandraEvaluator_EvaluateThresholds(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(r *model.CassandraInspectionResult)
+		metricName    string
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{"all not collected", func(r *model.CassandraInspectionResult) {}, "", "", false},
+		{"compactions normal", func(r *model.CassandraInspectionResult) { r.PendingCompactions = 10 }, "", "", false},
+		{"compactions warning", func(r *model.CassandraInspectionResult) { r.PendingCompactions = 150 }, "cassandra_pending_compactions", model.AlertLevelWarning, true},
+		{"compactions critical", func(r *model.CassandraInspectionResult) { r.PendingCompactions = 600 }, "cassandra_pending_compactions", model.AlertLevelCritical, true},
+		{"dropped mutations normal", func(r *model.CassandraInspectionResult) { r.DroppedMutations = 0 }, "", "", false},
+		{"dropped mutations warning", func(r *model.CassandraInspectionResult) { r.DroppedMutations = 5 }, "cassandra_dropped_mutations", model.AlertLevelWarning, true},
+		{"heap critical", func(r *model.CassandraInspectionResult) { r.HeapUsagePercent = 95 }, "cassandra_heap_usage", model.AlertLevelCritical, true},
+		{"hints warning", func(r *model.CassandraInspectionResult) { r.HintsBacklog = 200 }, "cassandra_hints_backlog", model.AlertLevelWarning, true},
+	}
+
+	evaluator := createTestCassandraEvaluator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestCassandraResult()
+			tt.setup(result)
+
+			evalResult := evaluator.Evaluate(result)
+			if !tt.expectAlert {
+				if len(evalResult.Alerts) != 0 {
+					t.Errorf("expected no alert, got %d", len(evalResult.Alerts))
+				}
+				return
+			}
+			if len(evalResult.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(evalResult.Alerts))
+			}
+			alert := evalResult.Alerts[0]
+			if alert.MetricName != tt.metricName {
+				t.Errorf("expected metric %s, got %s", tt.metricName, alert.MetricName)
+			}
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+		})
+	}
+}
+
+func TestCassandraEvaluator_ThresholdsDisabled(t *testing.T) {
+	evaluator := NewCassandraEvaluator(&config.CassandraThresholds{}, nil, nil, zerolog.Nop())
+	result := createTestCassandraResult()
+	result.PendingCompactions = 10000
+	result.DroppedMutations = 10000
+	result.HeapUsagePercent = 99
+	result.HintsBacklog = 10000
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.CassandraStatusNormal {
+		t.Errorf("expected normal status with disabled thresholds, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 0 {
+		t.Errorf("expected no alerts, got %d", len(evalResult.Alerts))
+	}
+}
@@ -0,0 +1,235 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// CassandraInspector orchestrates the complete Cassandra inspection workflow, coordinating
+// instance discovery, data collection, threshold evaluation, and result aggregation.
+type CassandraInspector struct {
+	collector *CassandraCollector
+	evaluator *CassandraEvaluator
+	config    *config.Config
+	timezone  *time.Location
+	version   string
+	logger    zerolog.Logger
+}
+
+// CassandraInspectorOption is a functional option for configuring a CassandraInspector.
+type CassandraInspectorOption func(*CassandraInspector)
+
+// NewCassandraInspector creates a new CassandraInspector with the given dependencies.
+//
+// Parameters:
+//   - cfg: Complete configuration including Cassandra inspection config
+//   - collector: Cassandra data collector
+//   - evaluator: Threshold evaluator
+//   - logger: Structured logger
+//   - opts: Optional configuration via functional options
+//
+// Returns:
+//   - *CassandraInspector: Configured inspector instance
+//   - error: Timezone loading error or validation failure
+func NewCassandraInspector(
+	cfg *config.Config,
+	collector *CassandraCollector,
+	evaluator *CassandraEvaluator,
+	logger zerolog.Logger,
+	opts ...CassandraInspectorOption,
+) (*CassandraInspector, error) {
+	// Validate required parameters
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if collector == nil {
+		return nil, fmt.Errorf("collector cannot be nil")
+	}
+	if evaluator == nil {
+		return nil, fmt.Errorf("evaluator cannot be nil")
+	}
+
+	// Determine timezone (from config or use default)
+	tzName := defaultTimezone
+	if cfg.Report.Timezone != "" {
+		tzName = cfg.Report.Timezone
+	}
+
+	// Load timezone
+	loc, err := time.LoadLocation(tzName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s: %w", tzName, err)
+	}
+
+	i := &CassandraInspector{
+		collector: collector,
+		evaluator: evaluator,
+		config:    cfg,
+		timezone:  loc,
+		version:   "dev",
+		logger:    logger.With().Str("component", "cassandra_inspector").Logger(),
+	}
+
+	// Apply functional options
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
+}
+
+// WithCassandraVersion sets the tool version to include in the inspection result.
+func WithCassandraVersion(version string) CassandraInspectorOption {
+	return func(i *CassandraInspector) {
+		i.version = version
+	}
+}
+
+// GetTimezone returns the configured timezone.
+func (i *CassandraInspector) GetTimezone() *time.Location {
+	return i.timezone
+}
+
+// GetVersion returns the configured version.
+func (i *CassandraInspector) GetVersion() string {
+	return i.version
+}
+
+// Inspect executes the complete Cassandra inspection workflow:
+// 1. Discovers Cassandra nodes
+// 2. Collects metrics for all instances
+// 3. Evaluates thresholds and generates alerts
+// 4. Aggregates results into CassandraInspectionResults
+//
+// Returns:
+//   - *model.CassandraInspectionResults: Complete inspection result with summary
+//   - error: Fatal errors that prevent inspection (discovery/config loading failures)
+func (i *CassandraInspector) Inspect(ctx context.Context) (*model.CassandraInspectionResults, error) {
+	// Step 1: Record start time (Asia/Shanghai)
+	startTime := time.Now().In(i.timezone)
+	i.logger.Info().
+		Time("start_time", startTime).
+		Str("timezone", i.timezone.String()).
+		Msg("starting Cassandra inspection")
+
+	// Step 2: Create result container
+	result := model.NewCassandraInspectionResults(startTime)
+	result.Version = i.version
+
+	// Step 3: Discover instances
+	i.logger.Debug().Msg("step 1: discovering Cassandra nodes")
+	instances, err := i.collector.DiscoverInstances(ctx)
+	if err != nil {
+		i.logger.Error().Err(err).Msg("instance discovery failed")
+		return nil, fmt.Errorf("instance discovery failed: %w", err)
+	}
+
+	// Step 4: Handle empty instance list (graceful degradation)
+	if len(instances) == 0 {
+		i.logger.Warn().Msg("no Cassandra nodes found, completing inspection with empty result")
+		endTime := time.Now().In(i.timezone)
+		result.Finalize(endTime)
+		return result, nil
+	}
+
+	i.logger.Info().Int("instance_count", len(instances)).Msg("discovered Cassandra nodes")
+
+	// Step 5: Load metric definitions (use collector's internal metrics)
+	i.logger.Debug().Msg("step 2: loading Cassandra metric definitions")
+	metrics := i.collector.GetMetrics()
+	if len(metrics) == 0 {
+		i.logger.Error().Msg("no Cassandra metrics defined")
+		return nil, fmt.Errorf("no Cassandra metrics defined")
+	}
+
+	i.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("step 3: collecting metrics")
+
+	resultsMap, err := i.collector.CollectMetrics(ctx, instances, metrics)
+	if err != nil {
+		i.logger.Error().Err(err).Msg("metrics collection failed")
+		return nil, fmt.Errorf("metrics collection failed: %w", err)
+	}
+
+	// Step 6: Evaluate thresholds
+	i.logger.Debug().
+		Int("results_count", len(resultsMap)).
+		Msg("step 4: evaluating thresholds")
+
+	_ = i.evaluator.EvaluateAll(resultsMap)
+
+	// Step 7: Build results
+	i.logger.Debug().Msg("step 5: building inspection results")
+	i.buildInspectionResults(result, resultsMap)
+
+	// Step 8: Finalize (calculate Duration, Summary, AlertSummary)
+	endTime := time.Now().In(i.timezone)
+	result.Finalize(endTime)
+
+	i.logger.Info().
+		Int("total_instances", result.Summary.TotalInstances).
+		Int("normal_instances", result.Summary.NormalInstances).
+		Int("warning_instances", result.Summary.WarningInstances).
+		Int("critical_instances", result.Summary.CriticalInstances).
+		Int("failed_instances", result.Summary.FailedInstances).
+		Int("down_nodes", result.Summary.DownNodes).
+		Int("total_alerts", result.AlertSummary.TotalAlerts).
+		Dur("duration", result.Duration).
+		Msg("Cassandra inspection completed")
+
+	// Step 9: Log critical alerts if any
+	if result.HasCritical() {
+		i.logger.Warn().
+			Int("critical_count", result.Summary.CriticalInstances).
+			Int("critical_alerts", result.AlertSummary.CriticalCount).
+			Msg("Cassandra inspection found critical issues")
+	}
+
+	return result, nil
+}
+
+// buildInspectionResults merges collection results into CassandraInspectionResults.
+func (i *CassandraInspector) buildInspectionResults(
+	result *model.CassandraInspectionResults,
+	resultsMap map[string]*model.CassandraInspectionResult,
+) {
+	// Iterate through all instance results
+	for _, inspResult := range resultsMap {
+		if inspResult == nil {
+			continue
+		}
+
+		// Convert timestamp to configured timezone
+		inspResult.CollectedAt = inspResult.CollectedAt.In(i.timezone)
+
+		// Add to result container (automatically aggregates alerts)
+		result.AddResult(inspResult)
+	}
+
+	i.logger.Debug().
+		Int("total_results", len(result.Results)).
+		Int("total_alerts", len(result.Alerts)).
+		Msg("inspection results merged")
+}
+
+// IsEnabled returns true if Cassandra inspection is enabled in the configuration.
+func (i *CassandraInspector) IsEnabled() bool {
+	return i.config != nil && i.config.Cassandra.Enabled
+}
+
+// GetConfig returns the Cassandra inspection configuration.
+func (i *CassandraInspector) GetConfig() *config.CassandraInspectionConfig {
+	if i.config == nil {
+		return nil
+	}
+	return &i.config.Cassandra
+}
@@ -0,0 +1,557 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// Cloud metric fields. Provider-specific metric definitions are normalized into
+// these fields so RDS / Redis / SLB resources of all providers are evaluated alike.
+const (
+	cloudCPUUsageField          = "cpu_usage"
+	cloudMemoryUsageField       = "memory_usage"
+	cloudConnectionUsageField   = "connection_usage"
+	cloudDiskUsageField         = "disk_usage"
+	cloudActiveConnectionsField = "active_connections"
+	cloudUnhealthyBackendsField = "unhealthy_backends"
+)
+
+// cloudDiscoveryFields lists the fields whose series are treated as cloud resources.
+var cloudDiscoveryFields = map[string]bool{
+	cloudCPUUsageField:          true,
+	cloudActiveConnectionsField: true,
+}
+
+// =============================================================================
+// Cloud Collector
+// =============================================================================
+
+// CloudCollector is the data collection service for managed cloud resources.
+// It reads Aliyun CloudMonitor and AWS CloudWatch metrics that Categraf stores in
+// VictoriaMetrics. Resources are identified by provider, resource type and resource ID.
+type CloudCollector struct {
+	vmClient       vm.MetricsSource
+	config         *config.CloudInspectionConfig
+	metrics        []*model.CloudMetricDefinition
+	metricDefs     map[string]*model.CloudMetricDefinition
+	instanceFilter *CloudInstanceFilter
+	logger         zerolog.Logger
+}
+
+// CloudInstanceFilter defines filtering criteria for cloud resources.
+type CloudInstanceFilter struct {
+	ResourcePatterns []string // Resource ID or name patterns (glob, e.g., "rm-*")
+	Providers        []string // Cloud providers (aliyun, aws)
+	ResourceTypes    []string // Resource types (rds, redis, slb)
+	Regions          []string // Regions (e.g., cn-hangzhou)
+}
+
+// NewCloudCollector creates a new CloudCollector instance.
+func NewCloudCollector(
+	cfg *config.CloudInspectionConfig,
+	vmClient vm.MetricsSource,
+	metrics []*model.CloudMetricDefinition,
+	logger zerolog.Logger,
+) *CloudCollector {
+	c := &CloudCollector{
+		vmClient: vmClient,
+		config:   cfg,
+		metrics:  metrics,
+		logger:   logger.With().Str("component", "cloud-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.CloudMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	// Build instance filter from config
+	c.instanceFilter = c.buildInstanceFilter()
+
+	return c
+}
+
+// buildInstanceFilter converts config.CloudFilter to CloudInstanceFilter.
+func (c *CloudCollector) buildInstanceFilter() *CloudInstanceFilter {
+	if c.config == nil {
+		return nil
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.ResourcePatterns) == 0 &&
+		len(filter.Providers) == 0 &&
+		len(filter.ResourceTypes) == 0 &&
+		len(filter.Regions) == 0 {
+		return nil
+	}
+
+	return &CloudInstanceFilter{
+		ResourcePatterns: filter.ResourcePatterns,
+		Providers:        filter.Providers,
+		ResourceTypes:    filter.ResourceTypes,
+		Regions:          filter.Regions,
+	}
+}
+
+// GetConfig returns the cloud inspection configuration.
+func (c *CloudCollector) GetConfig() *config.CloudInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *CloudCollector) GetMetrics() []*model.CloudMetricDefinition {
+	return c.metrics
+}
+
+// GetInstanceFilter returns the instance filter.
+func (c *CloudCollector) GetInstanceFilter() *CloudInstanceFilter {
+	return c.instanceFilter
+}
+
+// IsEmpty returns true if the instance filter has no filtering criteria.
+func (f *CloudInstanceFilter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.ResourcePatterns) == 0 &&
+		len(f.Providers) == 0 &&
+		len(f.ResourceTypes) == 0 &&
+		len(f.Regions) == 0
+}
+
+// Matches returns true if the resource passes the filter.
+// Providers, resource types and regions are compared case-insensitively;
+// resource patterns match either the resource ID or the resource name.
+func (f *CloudInstanceFilter) Matches(resource *model.CloudResource) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	if resource == nil {
+		return false
+	}
+
+	if len(f.Providers) > 0 && !containsFold(f.Providers, resource.Provider) {
+		return false
+	}
+	if len(f.ResourceTypes) > 0 && !containsFold(f.ResourceTypes, resource.ResourceType) {
+		return false
+	}
+	if len(f.Regions) > 0 && !containsFold(f.Regions, resource.Region) {
+		return false
+	}
+	if len(f.ResourcePatterns) > 0 {
+		for _, pattern := range f.ResourcePatterns {
+			if matchPattern(resource.ResourceID, pattern) ||
+				(resource.Name != "" && matchPattern(resource.Name, pattern)) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// =============================================================================
+// 云资源发现
+// =============================================================================
+
+// DiscoverInstances discovers all cloud resources.
+// Resources are the union of the series returned by the cpu_usage and
+// active_connections metrics of every provider and resource type.
+func (c *CloudCollector) DiscoverInstances(ctx context.Context) ([]*model.CloudResource, error) {
+	c.logger.Info().Msg("starting cloud resource discovery")
+
+	instanceMap := make(map[string]*model.CloudResource)
+	var order []string
+	queried := 0
+
+	for _, metric := range c.metrics {
+		if !cloudDiscoveryFields[metric.Field] || metric.IsPending() {
+			continue
+		}
+
+		results, err := c.vmClient.QueryResults(ctx, metric.Query)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("failed to query discovery metric, continuing with others")
+			continue
+		}
+		queried++
+
+		for _, result := range results {
+			resource := c.resourceFromLabels(metric, result.Labels)
+			if resource == nil {
+				c.logger.Warn().
+					Str("metric", metric.Name).
+					Str("id_label", metric.IDLabel).
+					Interface("labels", result.Labels).
+					Msg("missing resource id label")
+				continue
+			}
+
+			if existing, exists := instanceMap[resource.Key()]; exists {
+				fillCloudResource(existing, resource)
+				continue
+			}
+
+			if !c.instanceFilter.Matches(resource) {
+				c.logger.Debug().Str("resource", resource.Key()).Msg("cloud resource filtered out")
+				continue
+			}
+
+			instanceMap[resource.Key()] = resource
+			order = append(order, resource.Key())
+		}
+	}
+
+	if queried == 0 {
+		return nil, fmt.Errorf("failed to query any cloud discovery metric")
+	}
+
+	instances := make([]*model.CloudResource, 0, len(order))
+	for _, key := range order {
+		instances = append(instances, instanceMap[key])
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Msg("cloud resource discovery completed")
+
+	return instances, nil
+}
+
+// resourceFromLabels builds the cloud resource a series belongs to.
+// Returns nil if the resource ID label is missing.
+func (c *CloudCollector) resourceFromLabels(metric *model.CloudMetricDefinition, labels map[string]string) *model.CloudResource {
+	resourceID := labels[metric.IDLabel]
+	if resourceID == "" {
+		return nil
+	}
+
+	resource := model.NewCloudResource(metric.Provider, metric.ResourceType, resourceID)
+	if metric.NameLabel != "" {
+		resource.Name = labels[metric.NameLabel]
+	}
+	if metric.RegionLabel != "" {
+		resource.Region = labels[metric.RegionLabel]
+	}
+	if metric.SpecLabel != "" {
+		resource.Spec = labels[metric.SpecLabel]
+	}
+	return resource
+}
+
+// fillCloudResource copies the name, region and spec reported by another series
+// when the resource does not have them yet.
+func fillCloudResource(resource, from *model.CloudResource) {
+	if resource.Name == "" {
+		resource.Name = from.Name
+	}
+	if resource.Region == "" {
+		resource.Region = from.Region
+	}
+	if resource.Spec == "" {
+		resource.Spec = from.Spec
+	}
+}
+
+// =============================================================================
+// 云资源指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all cloud resources.
+//
+// Flow:
+//  1. Initialize result objects for each resource
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values from metrics
+//  6. Return results map (key = provider/resource_type/resource_id)
+//
+// Single metric failure does not abort the entire collection.
+func (c *CloudCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.CloudResource,
+	metrics []*model.CloudMetricDefinition,
+) (map[string]*model.CloudInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting cloud metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by resource key)
+	resultsMap := make(map[string]*model.CloudInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Key()] = model.NewCloudInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.CloudMetricDefinition
+	var activeMetrics []*model.CloudMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("cloud metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for pending metrics on the resources of the
+// same provider and resource type.
+func (c *CloudCollector) setPendingMetrics(
+	resultsMap map[string]*model.CloudInspectionResult,
+	pendingMetrics []*model.CloudMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending cloud metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			if !cloudMetricApplies(metric, result.Instance) {
+				continue
+			}
+			result.SetMetric(&model.CloudMetricValue{
+				Name:           metric.Field,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// cloudMetricApplies returns true if the metric definition covers the resource's
+// provider and resource type.
+func cloudMetricApplies(metric *model.CloudMetricDefinition, resource *model.CloudResource) bool {
+	return resource != nil &&
+		strings.EqualFold(metric.Provider, resource.Provider) &&
+		strings.EqualFold(metric.ResourceType, resource.ResourceType)
+}
+
+// collectMetricConcurrent collects a single metric for all resources (concurrent-safe).
+// Values are stored under the metric's normalized field; when several series match one
+// resource (SLB listeners, ELB target groups) the largest value is kept.
+func (c *CloudCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.CloudMetricDefinition,
+	resultsMap map[string]*model.CloudInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting cloud metric (concurrent)")
+
+	// Query VictoriaMetrics
+	results, err := c.vmClient.QueryResults(ctx, metric.Query)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		resourceID := result.Labels[metric.IDLabel]
+		if resourceID == "" {
+			continue
+		}
+		inspResult := resultsMap[model.CloudResourceKey(metric.Provider, metric.ResourceType, resourceID)]
+		if inspResult == nil {
+			continue
+		}
+
+		value := result.Value
+		if existing := inspResult.GetMetric(metric.Field); existing != nil && !existing.IsNA {
+			value = math.Max(existing.RawValue, value)
+		}
+
+		mv := &model.CloudMetricValue{
+			Name:      metric.Field,
+			RawValue:  value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields.
+// NaN/Inf values are treated as not collected.
+func (c *CloudCollector) extractFieldsFromMetrics(resultsMap map[string]*model.CloudInspectionResult) {
+	for _, result := range resultsMap {
+		fields := map[string]*float64{
+			cloudCPUUsageField:          &result.CPUUsage,
+			cloudMemoryUsageField:       &result.MemoryUsage,
+			cloudConnectionUsageField:   &result.ConnectionUsage,
+			cloudDiskUsageField:         &result.DiskUsage,
+			cloudActiveConnectionsField: &result.ActiveConnections,
+			cloudUnhealthyBackendsField: &result.UnhealthyBackends,
+		}
+		for name, field := range fields {
+			mv := result.GetMetric(name)
+			if mv == nil || mv.IsNA || math.IsNaN(mv.RawValue) || math.IsInf(mv.RawValue, 0) {
+				continue
+			}
+			*field = mv.RawValue
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
+
+// =============================================================================
+// 成本统计：窗口内 CPU 峰值采集
+// =============================================================================
+
+// CollectPeakCPU queries the CPU usage peak of every resource over window and stores it
+// in PeakCPUUsage. It wraps each active cpu_usage metric in a max_over_time subquery;
+// a failed query is logged and leaves the affected resources without a peak.
+func (c *CloudCollector) CollectPeakCPU(
+	ctx context.Context,
+	resultsMap map[string]*model.CloudInspectionResult,
+	window time.Duration,
+) {
+	for _, metric := range c.metrics {
+		if metric.Field != cloudCPUUsageField || metric.IsPending() {
+			continue
+		}
+
+		query := peakCPUQuery(metric.Query, window)
+		results, err := c.vmClient.QueryResults(ctx, query)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("failed to query CPU peak, continuing with others")
+			continue
+		}
+
+		for _, result := range results {
+			resourceID := result.Labels[metric.IDLabel]
+			if resourceID == "" || math.IsNaN(result.Value) || math.IsInf(result.Value, 0) {
+				continue
+			}
+			inspResult := resultsMap[model.CloudResourceKey(metric.Provider, metric.ResourceType, resourceID)]
+			if inspResult == nil {
+				continue
+			}
+			inspResult.PeakCPUUsage = math.Max(inspResult.PeakCPUUsage, result.Value)
+		}
+	}
+
+	c.logger.Debug().
+		Dur("window", window).
+		Msg("cloud CPU peak collection completed")
+}
+
+// peakCPUQuery builds the PromQL subquery returning the maximum of query over window,
+// e.g. max_over_time((aliyun_acs_rds_dashboard_cpu_usage_average)[720h:]).
+func peakCPUQuery(query string, window time.Duration) string {
+	return fmt.Sprintf("max_over_time((%s)[%s:])", query, formatPromDuration(window))
+}
+
+// formatPromDuration formats a duration as a PromQL duration in whole seconds, minutes or hours.
+func formatPromDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", int64(d/time.Hour))
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", int64(d/time.Minute))
+	default:
+		return fmt.Sprintf("%ds", int64(d/time.Second))
+	}
+}
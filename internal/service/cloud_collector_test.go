@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// createTestCloudCollector creates a CloudCollector querying a fake VM server.
+func createTestCloudCollector(t *testing.T, series map[string][]fakeSeries, cfg *config.CloudInspectionConfig, metrics []*model.CloudMetricDefinition) *CloudCollector {
+	t.Helper()
+	vmServer := newFakeVMServer(t, series)
+	return NewCloudCollector(cfg, newFakeVMClient(vmServer.URL), metrics, zerolog.Nop())
+}
+
+// createTestCloudMetricDefs returns Aliyun RDS and AWS ELB metrics, querying the fake VM
+// server by query name.
+func createTestCloudMetricDefs() []*model.CloudMetricDefinition {
+	rds := func(name, field string) *model.CloudMetricDefinition {
+		return &model.CloudMetricDefinition{
+			Name: name, Query: name, Provider: "aliyun", ResourceType: "rds", Field: field,
+			IDLabel: "instanceId", NameLabel: "instanceName", RegionLabel: "regionId",
+		}
+	}
+	elb := func(name, field string) *model.CloudMetricDefinition {
+		return &model.CloudMetricDefinition{
+			Name: name, Query: name, Provider: "aws", ResourceType: "slb", Field: field,
+			IDLabel: "load_balancer", RegionLabel: "region",
+		}
+	}
+	return []*model.CloudMetricDefinition{
+		rds("rds_cpu", "cpu_usage"),
+		rds("rds_memory", "memory_usage"),
+		elb("elb_active_connections", "active_connections"),
+		elb("elb_unhealthy_hosts", "unhealthy_backends"),
+	}
+}
+
+func TestCloudCollector_DiscoverInstances(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"rds_cpu": {
+			{map[string]string{"instanceId": "rm-001", "regionId": "cn-hangzhou"}, 20},
+			{map[string]string{"instanceId": "rm-001", "instanceName": "订单库"}, 20},    // Same resource, fills the name
+			{map[string]string{"instanceId": "rm-002", "regionId": "cn-beijing"}, 20}, // Filtered out by region
+			{map[string]string{"regionId": "cn-hangzhou"}, 20},                        // No resource ID
+		},
+		"rds_memory": {
+			{map[string]string{"instanceId": "rm-003", "regionId": "cn-hangzhou"}, 50}, // Not a discovery field
+		},
+		"elb_active_connections": {
+			{map[string]string{"load_balancer": "app/web/1", "region": "cn-hangzhou"}, 100},
+		},
+	}
+	cfg := &config.CloudInspectionConfig{InstanceFilter: config.CloudFilter{Regions: []string{"CN-HANGZHOU"}}}
+	collector := createTestCloudCollector(t, series, cfg, createTestCloudMetricDefs())
+
+	instances, err := collector.DiscoverInstances(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverInstances() error = %v", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("DiscoverInstances() returned %d resources, want 2: %+v", len(instances), instances)
+	}
+	if rds := instances[0]; rds.Key() != "aliyun/rds/rm-001" || rds.Name != "订单库" || rds.Region != "cn-hangzhou" {
+		t.Errorf("first resource = %+v, want rm-001 with the name of its second series", rds)
+	}
+	if elb := instances[1]; elb.Key() != "aws/slb/app/web/1" {
+		t.Errorf("second resource = %+v, want the ELB", elb)
+	}
+}
+
+func TestCloudCollector_DiscoverInstances_QueryError(t *testing.T) {
+	collector := NewCloudCollector(nil, newFakeVMClient("http://127.0.0.1:1"), createTestCloudMetricDefs(), zerolog.Nop())
+	if _, err := collector.DiscoverInstances(context.Background()); err == nil {
+		t.Error("DiscoverInstances() should fail when no discovery metric can be queried")
+	}
+}
+
+func TestCloudCollector_CollectMetrics(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"rds_cpu": {
+			{map[string]string{"instanceId": "rm-001"}, 35},
+			{map[string]string{"instanceId": "rm-009"}, 99}, // Not discovered
+		},
+		"elb_active_connections": {
+			{map[string]string{"load_balancer": "app/web/1"}, 100},
+		},
+		"elb_unhealthy_hosts": {
+			// One series per target group: the largest is kept
+			{map[string]string{"load_balancer": "app/web/1", "target_group": "tg-a"}, 0},
+			{map[string]string{"load_balancer": "app/web/1", "target_group": "tg-b"}, 2},
+		},
+	}
+	metrics := createTestCloudMetricDefs()
+	metrics[1].Status = "pending"
+	collector := createTestCloudCollector(t, series, nil, metrics)
+
+	rds := model.NewCloudResource("aliyun", "rds", "rm-001")
+	elb := model.NewCloudResource("aws", "slb", "app/web/1")
+	results, err := collector.CollectMetrics(context.Background(), []*model.CloudResource{rds, elb}, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("CollectMetrics() returned %d results, want only the discovered resources", len(results))
+	}
+
+	rdsResult := results[rds.Key()]
+	if rdsResult.CPUUsage != 35 || rdsResult.MemoryUsage != -1 {
+		t.Errorf("RDS = %v CPU, %v memory, want 35 and -1", rdsResult.CPUUsage, rdsResult.MemoryUsage)
+	}
+	if mv := rdsResult.GetMetric("memory_usage"); mv == nil || !mv.IsNA {
+		t.Errorf("pending metric = %+v, want N/A", mv)
+	}
+
+	elbResult := results[elb.Key()]
+	if elbResult.ActiveConnections != 100 || elbResult.UnhealthyBackends != 2 {
+		t.Errorf("ELB = %v connections, %v unhealthy backends, want 100 and 2", elbResult.ActiveConnections, elbResult.UnhealthyBackends)
+	}
+	if mv := elbResult.GetMetric("memory_usage"); mv != nil {
+		t.Errorf("pending RDS metric should not be set on the ELB: %+v", mv)
+	}
+}
+
+func TestCloudCollector_CollectPeakCPU(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"max_over_time((rds_cpu)[24h:])": {
+			{map[string]string{"instanceId": "rm-001"}, 88},
+		},
+	}
+	collector := createTestCloudCollector(t, series, nil, createTestCloudMetricDefs())
+
+	rds := model.NewCloudResource("aliyun", "rds", "rm-001")
+	elb := model.NewCloudResource("aws", "slb", "app/web/1")
+	resultsMap := map[string]*model.CloudInspectionResult{
+		rds.Key(): model.NewCloudInspectionResult(rds),
+		elb.Key(): model.NewCloudInspectionResult(elb),
+	}
+	collector.CollectPeakCPU(context.Background(), resultsMap, 24*time.Hour)
+
+	if peak := resultsMap[rds.Key()].PeakCPUUsage; peak != 88 {
+		t.Errorf("RDS PeakCPUUsage = %v, want 88", peak)
+	}
+	if peak := resultsMap[elb.Key()].PeakCPUUsage; peak != -1 {
+		t.Errorf("ELB PeakCPUUsage = %v, want -1 without CPU metric", peak)
+	}
+}
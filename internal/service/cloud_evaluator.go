@@ -0,0 +1,267 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Cloud Evaluator
+// =============================================================================
+
+// CloudEvaluationResult represents the evaluation result for a single cloud resource.
+type CloudEvaluationResult struct {
+	Identifier string                    `json:"identifier"` // 云资源标识符
+	Status     model.CloudInstanceStatus `json:"status"`     // 云资源整体状态
+	Alerts     []*model.CloudAlert       `json:"alerts"`     // 告警列表
+}
+
+// CloudEvaluator evaluates cloud resource metrics against thresholds.
+// Alerts are raised per normalized field, so they are named after the field
+// (cpu_usage, unhealthy_backends, ...) rather than the provider-specific metric.
+type CloudEvaluator struct {
+	thresholds   *config.CloudThresholds // 阈值配置
+	displayNames map[string]string       // 归一化字段显示名称（取第一个定义该字段的指标）
+	timezone     *time.Location          // 时区
+	logger       zerolog.Logger          // 日志器
+}
+
+// NewCloudEvaluator creates a new CloudEvaluator with the given threshold configuration.
+func NewCloudEvaluator(
+	thresholds *config.CloudThresholds,
+	metrics []*model.CloudMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *CloudEvaluator {
+	displayNames := make(map[string]string)
+	for _, m := range metrics {
+		if _, exists := displayNames[m.Field]; !exists && m.Field != "" {
+			displayNames[m.Field] = m.GetDisplayName()
+		}
+	}
+
+	return &CloudEvaluator{
+		thresholds:   thresholds,
+		displayNames: displayNames,
+		timezone:     timezone,
+		logger:       logger.With().Str("component", "cloud_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all cloud resources and returns the complete evaluation results.
+func (e *CloudEvaluator) EvaluateAll(
+	results map[string]*model.CloudInspectionResult,
+) []*CloudEvaluationResult {
+	evalResults := make([]*CloudEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("cloud evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single cloud resource against configured thresholds.
+// Unhealthy SLB backends are critical from the first occurrence.
+func (e *CloudEvaluator) Evaluate(
+	result *model.CloudInspectionResult,
+) *CloudEvaluationResult {
+	evalResult := &CloudEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.CloudStatusNormal,
+		Alerts:     make([]*model.CloudAlert, 0),
+	}
+
+	// Skip failed instances
+	if result.Error != "" {
+		evalResult.Status = model.CloudStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed cloud resource")
+		return evalResult
+	}
+
+	// 1. Evaluate utilization fields (higher is worse)
+	usages := []struct {
+		field     string
+		collected bool
+		value     float64
+	}{
+		{cloudCPUUsageField, result.HasCPUUsage(), result.CPUUsage},
+		{cloudMemoryUsageField, result.HasMemoryUsage(), result.MemoryUsage},
+		{cloudConnectionUsageField, result.HasConnectionUsage(), result.ConnectionUsage},
+		{cloudDiskUsageField, result.HasDiskUsage(), result.DiskUsage},
+	}
+	for _, u := range usages {
+		if !u.collected {
+			continue
+		}
+		warning, critical := e.getThresholds(u.field)
+		if alert := e.evaluateThreshold(result, u.field, u.value, warning, critical); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+	}
+
+	// 2. Evaluate SLB backends (any unhealthy backend -> Critical)
+	if result.HasUnhealthyBackends() {
+		evalResult.Alerts = append(evalResult.Alerts,
+			e.createAlert(result.GetIdentifier(), cloudUnhealthyBackendsField, result.UnhealthyBackends, model.AlertLevelCritical))
+	}
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("cloud resource evaluation completed")
+
+	return evalResult
+}
+
+// evaluateThreshold evaluates a "higher is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *CloudEvaluator) evaluateThreshold(
+	result *model.CloudInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.CloudAlert {
+	return e.alertAt(result, metricName, value, config.ThresholdPair{Warning: warning, Critical: critical})
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *CloudEvaluator) alertAt(
+	result *model.CloudInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.CloudAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *CloudEvaluator) determineInstanceStatus(
+	alerts []*model.CloudAlert,
+) model.CloudInstanceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.CloudStatusCritical
+	}
+	if hasWarning {
+		return model.CloudStatusWarning
+	}
+	return model.CloudStatusNormal
+}
+
+// createAlert creates a CloudAlert with formatted message.
+func (e *CloudEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.CloudAlert {
+	displayName := metricName
+	if name, exists := e.displayNames[metricName]; exists {
+		displayName = name
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.CloudAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(displayName, metricName, currentValue, level),
+	}
+}
+
+// formatValue formats metric value for display.
+func (e *CloudEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case cloudCPUUsageField, cloudMemoryUsageField, cloudConnectionUsageField, cloudDiskUsageField:
+		return fmt.Sprintf("%.1f%%", value)
+	case cloudActiveConnectionsField, cloudUnhealthyBackendsField:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *CloudEvaluator) generateAlertMessage(
+	displayName string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case cloudUnhealthyBackendsField:
+		return fmt.Sprintf("%.0f 个后端服务器健康检查失败，流量可能无法正常转发", currentValue)
+	case cloudCPUUsageField, cloudMemoryUsageField, cloudConnectionUsageField, cloudDiskUsageField:
+		warning, critical := e.getThresholds(metricName)
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("%s %.1f%%，已超过严重阈值 %.0f%%", displayName, currentValue, critical)
+		}
+		return fmt.Sprintf("%s %.1f%%，已超过警告阈值 %.0f%%", displayName, currentValue, warning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric field.
+// Unhealthy backends are critical from the first occurrence.
+func (e *CloudEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case cloudCPUUsageField:
+		return e.thresholds.CPUUsageWarning, e.thresholds.CPUUsageCritical
+	case cloudMemoryUsageField:
+		return e.thresholds.MemoryUsageWarning, e.thresholds.MemoryUsageCritical
+	case cloudConnectionUsageField:
+		return e.thresholds.ConnectionUsageWarning, e.thresholds.ConnectionUsageCritical
+	case cloudDiskUsageField:
+		return e.thresholds.DiskUsageWarning, e.thresholds.DiskUsageCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *CloudEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{cloudCPUUsageField, cloudMemoryUsageField, cloudConnectionUsageField, cloudDiskUsageField})
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestCloudEvaluator creates a cloud evaluator with default thresholds for testing.
+func createTestCloudEvaluator() *CloudEvaluator {
+	thresholds := &config.CloudThresholds{
+		CPUUsageWarning:         70,
+		CPUUsageCritical:        90,
+		MemoryUsageWarning:      80,
+		MemoryUsageCritical:     90,
+		ConnectionUsageWarning:  70,
+		ConnectionUsageCritical: 90,
+		DiskUsageWarning:        80,
+		DiskUsageCritical:       90,
+	}
+	metrics := []*model.CloudMetricDefinition{
+		{Name: "aliyun_rds_cpu_usage", DisplayName: "CPU 使用率", Field: "cpu_usage"},
+		{Name: "aws_rds_cpu_usage", DisplayName: "AWS CPU", Field: "cpu_usage"},
+		{Name: "aliyun_rds_connection_usage", DisplayName: "连接数使用率", Field: "connection_usage"},
+		{Name: "aliyun_slb_unhealthy_backends", DisplayName: "不健康后端数", Field: "unhealthy_backends"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewCloudEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// =============================================================================
+// Collector Helper Tests
+// =============================================================================
+
+func TestCloudInstanceFilter_Matches(t *testing.T) {
+	rds := model.NewCloudResource("aliyun", "rds", "rm-bp1abc")
+	rds.Name = "order-db"
+	rds.Region = "cn-hangzhou"
+	elb := model.NewCloudResource("aws", "slb", "app/prod-alb/123")
+
+	tests := []struct {
+		name     string
+		filter   *CloudInstanceFilter
+		resource *model.CloudResource
+		want     bool
+	}{
+		{"nil filter", nil, rds, true},
+		{"provider case-insensitive", &CloudInstanceFilter{Providers: []string{"Aliyun"}}, rds, true},
+		{"provider mismatch", &CloudInstanceFilter{Providers: []string{"aws"}}, rds, false},
+		{"resource type", &CloudInstanceFilter{ResourceTypes: []string{"slb"}}, elb, true},
+		{"region mismatch", &CloudInstanceFilter{Regions: []string{"cn-shanghai"}}, rds, false},
+		{"pattern by id", &CloudInstanceFilter{ResourcePatterns: []string{"rm-*"}}, rds, true},
+		{"pattern by name", &CloudInstanceFilter{ResourcePatterns: []string{"order-*"}}, rds, true},
+		{"pattern mismatch", &CloudInstanceFilter{ResourcePatterns: []string{"r-*"}}, rds, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.resource); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloudCollector_ResourceFromLabels(t *testing.T) {
+	c := NewCloudCollector(&config.CloudInspectionConfig{Enabled: true}, nil, nil, zerolog.Nop())
+	metric := &model.CloudMetricDefinition{
+		Provider: "aws", ResourceType: "rds", Field: "cpu_usage",
+		IDLabel: "db_instance_identifier", RegionLabel: "region",
+	}
+
+	resource := c.resourceFromLabels(metric, map[string]string{"db_instance_identifier": "prod-db-01", "region": "us-east-1"})
+	if resource == nil || resource.Key() != "aws/rds/prod-db-01" || resource.Region != "us-east-1" {
+		t.Fatalf("unexpected resource: %+v", resource)
+	}
+	if c.resourceFromLabels(metric, map[string]string{"region": "us-east-1"}) != nil {
+		t.Error("series without the id label should be skipped")
+	}
+}
+
+// =============================================================================
+// Evaluator Tests
+// =============================================================================
+
+func TestCloudEvaluator_Healthy(t *testing.T) {
+	e := createTestCloudEvaluator()
+	result := model.NewCloudInspectionResult(model.NewCloudResource("aliyun", "rds", "rm-bp1abc"))
+	result.CPUUsage = 30
+	result.ConnectionUsage = 10
+
+	eval := e.Evaluate(result)
+	if eval.Status != model.CloudStatusNormal || len(eval.Alerts) != 0 {
+		t.Errorf("expected normal status without alerts, got %s with %d alerts", eval.Status, len(eval.Alerts))
+	}
+}
+
+func TestCloudEvaluator_Thresholds(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *model.CloudInspectionResult)
+		metric string
+		want   model.AlertLevel
+	}{
+		{"cpu warning", func(r *model.CloudInspectionResult) { r.CPUUsage = 75 }, cloudCPUUsageField, model.AlertLevelWarning},
+		{"cpu critical", func(r *model.CloudInspectionResult) { r.CPUUsage = 95 }, cloudCPUUsageField, model.AlertLevelCritical},
+		{"connection warning", func(r *model.CloudInspectionResult) { r.ConnectionUsage = 72 }, cloudConnectionUsageField, model.AlertLevelWarning},
+		{"disk critical", func(r *model.CloudInspectionResult) { r.DiskUsage = 92 }, cloudDiskUsageField, model.AlertLevelCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := createTestCloudEvaluator()
+			result := model.NewCloudInspectionResult(model.NewCloudResource("aliyun", "rds", "rm-bp1abc"))
+			tt.modify(result)
+
+			eval := e.Evaluate(result)
+			if len(eval.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(eval.Alerts))
+			}
+			if eval.Alerts[0].MetricName != tt.metric || eval.Alerts[0].Level != tt.want {
+				t.Errorf("expected %s %s alert, got %s %s", tt.want, tt.metric, eval.Alerts[0].Level, eval.Alerts[0].MetricName)
+			}
+		})
+	}
+}
+
+func TestCloudEvaluator_UnhealthyBackends(t *testing.T) {
+	e := createTestCloudEvaluator()
+	result := model.NewCloudInspectionResult(model.NewCloudResource("aliyun", "slb", "lb-bp1xyz"))
+	result.ActiveConnections = 1200
+	result.UnhealthyBackends = 2
+
+	eval := e.Evaluate(result)
+
+	if eval.Status != model.CloudStatusCritical {
+		t.Errorf("expected critical status, got %s", eval.Status)
+	}
+	if len(eval.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(eval.Alerts))
+	}
+	alert := eval.Alerts[0]
+	if alert.MetricName != cloudUnhealthyBackendsField || !strings.Contains(alert.Message, "2 个后端服务器") {
+		t.Errorf("unexpected backend alert: %+v", alert)
+	}
+	if alert.MetricDisplayName != "不健康后端数" {
+		t.Errorf("display name should come from the first definition of the field, got %q", alert.MetricDisplayName)
+	}
+}
+
+func TestPeakCPUQuery(t *testing.T) {
+	tests := []struct {
+		window time.Duration
+		want   string
+	}{
+		{720 * time.Hour, "max_over_time((cpu)[720h:])"},
+		{90 * time.Minute, "max_over_time((cpu)[90m:])"},
+		{45 * time.Second, "max_over_time((cpu)[45s:])"},
+	}
+
+	for _, tt := range tests {
+		if got := peakCPUQuery("cpu", tt.window); got != tt.want {
+			t.Errorf("peakCPUQuery(%v) = %q, want %q", tt.window, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// CloudInspector orchestrates the complete cloud inspection workflow, coordinating
+// instance discovery, data collection, threshold evaluation, and result aggregation.
+type CloudInspector struct {
+	collector *CloudCollector
+	evaluator *CloudEvaluator
+	config    *config.Config
+	timezone  *time.Location
+	version   string
+	logger    zerolog.Logger
+}
+
+// CloudInspectorOption is a functional option for configuring a CloudInspector.
+type CloudInspectorOption func(*CloudInspector)
+
+// NewCloudInspector creates a new CloudInspector with the given dependencies.
+//
+// Parameters:
+//   - cfg: Complete configuration including cloud inspection config
+//   - collector: Cloud data collector
+//   - evaluator: Threshold evaluator
+//   - logger: Structured logger
+//   - opts: Optional configuration via functional options
+//
+// Returns:
+//   - *CloudInspector: Configured inspector instance
+//   - error: Timezone loading error or validation failure
+func NewCloudInspector(
+	cfg *config.Config,
+	collector *CloudCollector,
+	evaluator *CloudEvaluator,
+	logger zerolog.Logger,
+	opts ...CloudInspectorOption,
+) (*CloudInspector, error) {
+	// Validate required parameters
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if collector == nil {
+		return nil, fmt.Errorf("collector cannot be nil")
+	}
+	if evaluator == nil {
+		return nil, fmt.Errorf("evaluator cannot be nil")
+	}
+
+	// Determine timezone (from config or use default)
+	tzName := defaultTimezone
+	if cfg.Report.Timezone != "" {
+		tzName = cfg.Report.Timezone
+	}
+
+	// Load timezone
+	loc, err := time.LoadLocation(tzName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s: %w", tzName, err)
+	}
+
+	i := &CloudInspector{
+		collector: collector,
+		evaluator: evaluator,
+		config:    cfg,
+		timezone:  loc,
+		version:   "dev",
+		logger:    logger.With().Str("component", "cloud_inspector").Logger(),
+	}
+
+	// Apply functional options
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
+}
+
+// WithCloudVersion sets the tool version to include in the inspection result.
+func WithCloudVersion(version string) CloudInspectorOption {
+	return func(i *CloudInspector) {
+		i.version = version
+	}
+}
+
+// GetTimezone returns the configured timezone.
+func (i *CloudInspector) GetTimezone() *time.Location {
+	return i.timezone
+}
+
+// GetVersion returns the configured version.
+func (i *CloudInspector) GetVersion() string {
+	return i.version
+}
+
+// Inspect executes the complete cloud inspection workflow:
+// 1. Discovers cloud resources
+// 2. Collects metrics for all instances
+// 3. Evaluates thresholds and generates alerts
+// 4. Aggregates results into CloudInspectionResults
+//
+// Returns:
+//   - *model.CloudInspectionResults: Complete inspection result with summary
+//   - error: Fatal errors that prevent inspection (discovery/config loading failures)
+func (i *CloudInspector) Inspect(ctx context.Context) (*model.CloudInspectionResults, error) {
+	// Step 1: Record start time (Asia/Shanghai)
+	startTime := time.Now().In(i.timezone)
+	i.logger.Info().
+		Time("start_time", startTime).
+		Str("timezone", i.timezone.String()).
+		Msg("starting cloud inspection")
+
+	// Step 2: Create result container
+	result := model.NewCloudInspectionResults(startTime)
+	result.Version = i.version
+
+	// Step 3: Discover instances
+	i.logger.Debug().Msg("step 1: discovering cloud resources")
+	instances, err := i.collector.DiscoverInstances(ctx)
+	if err != nil {
+		i.logger.Error().Err(err).Msg("instance discovery failed")
+		return nil, fmt.Errorf("instance discovery failed: %w", err)
+	}
+
+	// Step 4: Handle empty instance list (graceful degradation)
+	if len(instances) == 0 {
+		i.logger.Warn().Msg("no cloud resources found, completing inspection with empty result")
+		endTime := time.Now().In(i.timezone)
+		result.Finalize(endTime)
+		return result, nil
+	}
+
+	i.logger.Info().Int("instance_count", len(instances)).Msg("discovered cloud resources")
+
+	// Step 5: Load metric definitions (use collector's internal metrics)
+	i.logger.Debug().Msg("step 2: loading cloud metric definitions")
+	metrics := i.collector.GetMetrics()
+	if len(metrics) == 0 {
+		i.logger.Error().Msg("no cloud metrics defined")
+		return nil, fmt.Errorf("no cloud metrics defined")
+	}
+
+	i.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("step 3: collecting metrics")
+
+	resultsMap, err := i.collector.CollectMetrics(ctx, instances, metrics)
+	if err != nil {
+		i.logger.Error().Err(err).Msg("metrics collection failed")
+		return nil, fmt.Errorf("metrics collection failed: %w", err)
+	}
+
+	// Step 6: Evaluate thresholds
+	i.logger.Debug().
+		Int("results_count", len(resultsMap)).
+		Msg("step 4: evaluating thresholds")
+
+	_ = i.evaluator.EvaluateAll(resultsMap)
+
+	// Collect the CPU peak used by the optional cost summary
+	costSummary := i.config.Cloud.CostSummary
+	if costSummary.Enabled {
+		i.logger.Debug().Dur("window", costSummary.Window).Msg("collecting CPU peak for cost summary")
+		i.collector.CollectPeakCPU(ctx, resultsMap, costSummary.Window)
+	}
+
+	// Step 7: Build results
+	i.logger.Debug().Msg("step 5: building inspection results")
+	i.buildInspectionResults(result, resultsMap)
+
+	// Step 8: Finalize (calculate Duration, Summary, AlertSummary)
+	endTime := time.Now().In(i.timezone)
+	result.Finalize(endTime)
+	if costSummary.Enabled {
+		result.CostSummary = model.NewCloudCostSummary(result.Results, costSummary.Window, costSummary.IdleCPUThreshold)
+		i.logger.Info().
+			Int("asset_groups", len(result.CostSummary.Groups)).
+			Int("underutilized", len(result.CostSummary.Underutilized)).
+			Msg("cloud cost summary built")
+	}
+
+	i.logger.Info().
+		Int("total_instances", result.Summary.TotalInstances).
+		Int("normal_instances", result.Summary.NormalInstances).
+		Int("warning_instances", result.Summary.WarningInstances).
+		Int("critical_instances", result.Summary.CriticalInstances).
+		Int("failed_instances", result.Summary.FailedInstances).
+		Int("rds_instances", result.Summary.RDSInstances).
+		Int("redis_instances", result.Summary.RedisInstances).
+		Int("slb_instances", result.Summary.SLBInstances).
+		Int("total_alerts", result.AlertSummary.TotalAlerts).
+		Dur("duration", result.Duration).
+		Msg("cloud inspection completed")
+
+	// Step 9: Log critical alerts if any
+	if result.HasCritical() {
+		i.logger.Warn().
+			Int("critical_count", result.Summary.CriticalInstances).
+			Int("critical_alerts", result.AlertSummary.CriticalCount).
+			Msg("cloud inspection found critical issues")
+	}
+
+	return result, nil
+}
+
+// buildInspectionResults merges collection results into CloudInspectionResults.
+func (i *CloudInspector) buildInspectionResults(
+	result *model.CloudInspectionResults,
+	resultsMap map[string]*model.CloudInspectionResult,
+) {
+	// Iterate through all instance results
+	for _, inspResult := range resultsMap {
+		if inspResult == nil {
+			continue
+		}
+
+		// Convert timestamp to configured timezone
+		inspResult.CollectedAt = inspResult.CollectedAt.In(i.timezone)
+
+		// Add to result container (automatically aggregates alerts)
+		result.AddResult(inspResult)
+	}
+
+	i.logger.Debug().
+		Int("total_results", len(result.Results)).
+		Int("total_alerts", len(result.Alerts)).
+		Msg("inspection results merged")
+}
+
+// IsEnabled returns true if cloud inspection is enabled in the configuration.
+func (i *CloudInspector) IsEnabled() bool {
+	return i.config != nil && i.config.Cloud.Enabled
+}
+
+// GetConfig returns the cloud inspection configuration.
+func (i *CloudInspector) GetConfig() *config.CloudInspectionConfig {
+	if i.config == nil {
+		return nil
+	}
+	return &i.config.Cloud
+}
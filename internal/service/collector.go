@@ -11,7 +11,9 @@ import (
 	"github.com/rs/zerolog"
 	"golang.org/x/sync/errgroup"
 
+	"inspection-tool/internal/client/cmdb"
 	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/ssh"
 	"inspection-tool/internal/client/vm"
 	"inspection-tool/internal/config"
 	"inspection-tool/internal/model"
@@ -29,25 +31,54 @@ type CollectionResult struct {
 	HostMetrics map[string]*model.HostMetrics // 按主机名分组的指标数据
 	FailedHosts []FailedHost                  // 采集失败的主机
 	CollectedAt time.Time                     // 采集时间
+
+	SourceConflicts []*model.SourceConflict // 多个采集器上报不同值的主机指标
+	ActiveAlerts    []*model.ActiveAlert    // N9E 中巡检主机正在触发的告警
 }
 
 // Collector is the data collection service that integrates N9E and VM clients.
 type Collector struct {
 	n9eClient  *n9e.Client
-	vmClient   *vm.Client
+	vmClient   vm.MetricsSource
 	config     *config.Config
 	metrics    []*model.MetricDefinition
 	hostFilter *vm.HostFilter
+	exclude    *config.HostPatterns     // Hosts dropped from the N9E hosts (nil: none)
+	groupTree  *model.BusinessGroupTree // Business groups synchronized from N9E (nil: busigroup label only)
+	cmdb       cmdb.Source              // CMDB enriching the host metadata (nil: not configured)
+	sshRunner  ssh.Runner               // SSH fallback filling the missing host metrics (nil: disabled)
+	checkpoint MetricCheckpoint         // Query results saved by an interrupted collection (nil: disabled)
 	logger     zerolog.Logger
 }
 
+// CollectorOption is a functional option for configuring the Collector.
+type CollectorOption func(*Collector)
+
+// WithBusinessGroupTree groups the hosts by the business groups synchronized from N9E, taken
+// from the group IDs of each host. Hosts without a known group fall back to the busigroup
+// label of their series.
+func WithBusinessGroupTree(tree *model.BusinessGroupTree) CollectorOption {
+	return func(c *Collector) {
+		c.groupTree = tree
+	}
+}
+
+// WithCMDB enriches the host metadata with the owner, application, location and maintenance
+// status recorded in the CMDB. A nil source leaves the hosts unchanged.
+func WithCMDB(source cmdb.Source) CollectorOption {
+	return func(c *Collector) {
+		c.cmdb = source
+	}
+}
+
 // NewCollector creates a new Collector instance.
 func NewCollector(
 	cfg *config.Config,
 	n9eClient *n9e.Client,
-	vmClient *vm.Client,
+	vmClient vm.MetricsSource,
 	metrics []*model.MetricDefinition,
 	logger zerolog.Logger,
+	opts ...CollectorOption,
 ) *Collector {
 	c := &Collector{
 		n9eClient: n9eClient,
@@ -56,9 +87,16 @@ func NewCollector(
 		metrics:   metrics,
 		logger:    logger.With().Str("component", "collector").Logger(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
 
 	// Build VM host filter from config
 	c.hostFilter = c.buildVMHostFilter()
+	if cfg != nil {
+		// Patterns are validated when the configuration is loaded
+		c.exclude, _ = config.CompileHostPatterns(cfg.Inspection.HostFilter.Exclude)
+	}
 
 	return c
 }
@@ -103,12 +141,18 @@ func (c *Collector) CollectAll(ctx context.Context) (*CollectionResult, error) {
 	}
 
 	// Step 2: Collect metrics from VictoriaMetrics
-	hostMetrics, err := c.CollectMetrics(ctx, hosts, c.metrics)
+	hostMetrics, conflicts, err := c.collectMetrics(ctx, hosts, c.metrics)
 	if err != nil {
 		return nil, fmt.Errorf("failed to collect metrics: %w", err)
 	}
 
-	// Step 3: Identify hosts that have no metrics (potential failures)
+	// Step 2b: Fill the metrics missing from the backend over SSH (optional)
+	c.fillFromSSH(ctx, hosts, hostMetrics)
+
+	// Step 3: Collect the alerts firing in N9E for the inspected hosts
+	activeAlerts := c.CollectActiveAlerts(ctx, hosts)
+
+	// Step 4: Identify hosts that have no metrics (potential failures)
 	var failedHosts []FailedHost
 	for _, host := range hosts {
 		if hm, exists := hostMetrics[host.Hostname]; !exists || len(hm.Metrics) == 0 {
@@ -123,13 +167,17 @@ func (c *Collector) CollectAll(ctx context.Context) (*CollectionResult, error) {
 		Int("total_hosts", len(hosts)).
 		Int("hosts_with_metrics", len(hostMetrics)).
 		Int("failed_hosts", len(failedHosts)).
+		Int("source_conflicts", len(conflicts)).
+		Int("active_alerts", len(activeAlerts)).
 		Msg("data collection completed")
 
 	return &CollectionResult{
-		Hosts:       hosts,
-		HostMetrics: hostMetrics,
-		FailedHosts: failedHosts,
-		CollectedAt: collectedAt,
+		Hosts:           hosts,
+		HostMetrics:     hostMetrics,
+		FailedHosts:     failedHosts,
+		CollectedAt:     collectedAt,
+		SourceConflicts: conflicts,
+		ActiveAlerts:    activeAlerts,
 	}, nil
 }
 
@@ -143,16 +191,120 @@ func (c *Collector) CollectHostMetas(ctx context.Context) ([]*model.HostMeta, er
 		return nil, fmt.Errorf("N9E API error: %w", err)
 	}
 
+	hosts = c.excludeHosts(hosts)
+
+	if c.groupTree != nil {
+		for _, host := range hosts {
+			host.BusinessGroup = c.groupTree.GroupName(host.GroupIDs)
+		}
+	}
+	c.enrichFromCMDB(ctx, hosts)
+
 	c.logger.Info().Int("count", len(hosts)).Msg("collected host metas successfully")
 	return hosts, nil
 }
 
+// excludeHosts drops the hosts whose hostname, ident or IP matches an exclusion pattern.
+func (c *Collector) excludeHosts(hosts []*model.HostMeta) []*model.HostMeta {
+	if c.exclude.Len() == 0 {
+		return hosts
+	}
+	kept := make([]*model.HostMeta, 0, len(hosts))
+	var excluded []string
+	for _, host := range hosts {
+		if c.exclude.Match(host.Hostname, host.Ident, host.IP) {
+			excluded = append(excluded, host.Hostname)
+			continue
+		}
+		kept = append(kept, host)
+	}
+	if len(excluded) > 0 {
+		c.logger.Info().Strs("hosts", excluded).Msg("excluded hosts by inspection.host_filter.exclude")
+	}
+	return kept
+}
+
+// enrichFromCMDB copies the CMDB attributes to the hosts. The CMDB is supplementary, so a
+// failed request only logs a warning and leaves the hosts unchanged.
+func (c *Collector) enrichFromCMDB(ctx context.Context, hosts []*model.HostMeta) {
+	if c.cmdb == nil {
+		return
+	}
+	records, err := c.cmdb.Records(ctx)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("failed to get hosts from CMDB, skipping enrichment")
+		return
+	}
+	matched := cmdb.Enrich(hosts, records)
+	c.logger.Info().
+		Int("records", len(records)).
+		Int("matched_hosts", matched).
+		Int("unmatched_hosts", len(hosts)-matched).
+		Msg("enriched host metas from CMDB")
+}
+
+// CollectActiveAlerts retrieves the alerts firing in N9E and keeps those of the given hosts,
+// matched by ident or by the hostname cleaned from it. Active alerts are supplementary, so a
+// failed request only logs a warning and returns none.
+func (c *Collector) CollectActiveAlerts(ctx context.Context, hosts []*model.HostMeta) []*model.ActiveAlert {
+	if c.n9eClient == nil || c.config == nil || !c.config.Datasources.N9E.ActiveAlerts {
+		return nil
+	}
+
+	events, err := c.n9eClient.GetActiveAlerts(ctx)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("failed to get active alerts from N9E, skipping")
+		return nil
+	}
+
+	hostnames := make(map[string]string, len(hosts)*2)
+	for _, host := range hosts {
+		hostnames[host.Hostname] = host.Hostname
+		if host.Ident != "" {
+			hostnames[host.Ident] = host.Hostname
+		}
+	}
+
+	var alerts []*model.ActiveAlert
+	for i := range events {
+		event := &events[i]
+		if event.TargetIdent == "" {
+			continue // Not a host alert
+		}
+		hostname, ok := hostnames[event.TargetIdent]
+		if !ok {
+			hostname, ok = hostnames[model.CleanIdent(event.TargetIdent)]
+		}
+		if !ok {
+			continue
+		}
+		alerts = append(alerts, event.ToActiveAlert(hostname))
+	}
+
+	c.logger.Info().
+		Int("events", len(events)).
+		Int("host_alerts", len(alerts)).
+		Msg("collected active alerts successfully")
+	return alerts
+}
+
 // CollectMetrics retrieves metric data from VictoriaMetrics for all hosts.
 func (c *Collector) CollectMetrics(
 	ctx context.Context,
 	hosts []*model.HostMeta,
 	metrics []*model.MetricDefinition,
 ) (map[string]*model.HostMetrics, error) {
+	hostMetricsMap, _, err := c.collectMetrics(ctx, hosts, metrics)
+	return hostMetricsMap, err
+}
+
+// collectMetrics retrieves metric data from VictoriaMetrics for all hosts and returns the
+// conflicts between collection sources found on the way.
+func (c *Collector) collectMetrics(
+	ctx context.Context,
+	hosts []*model.HostMeta,
+	metrics []*model.MetricDefinition,
+) (map[string]*model.HostMetrics, []*model.SourceConflict, error) {
 	c.logger.Debug().
 		Int("host_count", len(hosts)).
 		Int("metric_count", len(metrics)).
@@ -179,6 +331,9 @@ func (c *Collector) CollectMetrics(
 	// Set N/A for pending metrics
 	c.setPendingMetrics(hostMetricsMap, pendingMetrics)
 
+	// Resolve series to hosts by the configured identity label chain and source preference
+	resolver := newHostResolver(c.config.Inspection, hosts, c.logger)
+
 	// Collect active metrics concurrently using errgroup
 	g, ctx := errgroup.WithContext(ctx)
 	concurrency := c.config.Inspection.Concurrency
@@ -189,17 +344,20 @@ func (c *Collector) CollectMetrics(
 
 	var mu sync.Mutex // Protect hostMetricsMap concurrent writes
 
+	progress := progressFrom(ctx)
+	progress.Start(len(hosts), len(activeMetrics))
 	for _, metric := range activeMetrics {
 		metric := metric // Capture loop variable
 		g.Go(func() error {
 			var err error
 			if metric.HasExpandLabel() {
 				// Handle metrics that need to be expanded by label (e.g., disk by path)
-				err = c.collectExpandedMetricConcurrent(ctx, metric, hostMetricsMap, &mu)
+				err = c.collectExpandedMetricConcurrent(ctx, metric, hostMetricsMap, resolver, &mu)
 			} else {
 				// Handle regular metrics
-				err = c.collectSimpleMetricConcurrent(ctx, metric, hostMetricsMap, &mu)
+				err = c.collectSimpleMetricConcurrent(ctx, metric, hostMetricsMap, resolver, &mu)
 			}
+			progress.QueryDone()
 			if err != nil {
 				c.logger.Warn().
 					Err(err).
@@ -211,10 +369,21 @@ func (c *Collector) CollectMetrics(
 	}
 
 	if err := g.Wait(); err != nil {
-		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+		return nil, nil, fmt.Errorf("concurrent metric collection failed: %w", err)
 	}
 
-	return hostMetricsMap, nil
+	// Without a synchronized group, business groups come from the busigroup label of the
+	// collected series
+	for _, host := range hosts {
+		if host.BusinessGroup != "" {
+			continue
+		}
+		if group := resolver.businessGroup(host.Hostname); group != "" {
+			host.BusinessGroup = group
+		}
+	}
+
+	return hostMetricsMap, resolver.sourceConflicts(), nil
 }
 
 // collectSimpleMetric collects a single metric without label expansion.
@@ -222,6 +391,7 @@ func (c *Collector) collectSimpleMetric(
 	ctx context.Context,
 	metric *model.MetricDefinition,
 	hostMetricsMap map[string]*model.HostMetrics,
+	resolver *hostResolver,
 ) error {
 	c.logger.Debug().
 		Str("metric", metric.Name).
@@ -229,18 +399,16 @@ func (c *Collector) collectSimpleMetric(
 		Msg("collecting simple metric")
 
 	// Execute query with optional host filter
-	results, err := c.vmClient.QueryByIdentWithFilter(ctx, metric.Query, c.hostFilter)
+	results, err := c.queryResults(ctx, metric)
 	if err != nil {
 		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
 	}
 
 	// Map results to hosts
 	matchedCount := 0
-	for ident, result := range results {
-		// Try to match by hostname (clean ident)
-		hostname := model.CleanIdent(ident)
-		if hostMetrics, exists := hostMetricsMap[hostname]; exists {
-			mv := model.NewMetricValue(metric.Name, result.Value)
+	for _, match := range resolver.match(metric.Name, "", results) {
+		if hostMetrics, exists := hostMetricsMap[match.Hostname]; exists {
+			mv := model.NewMetricValue(metric.Name, match.Result.Value)
 			mv.Timestamp = time.Now().Unix()
 			hostMetrics.SetMetric(mv)
 			matchedCount++
@@ -256,12 +424,43 @@ func (c *Collector) collectSimpleMetric(
 	return nil
 }
 
+// queryResults returns the results of the query of a metric, from the checkpoint when an
+// interrupted collection saved them, else queried with the host filter and saved to the
+// checkpoint.
+func (c *Collector) queryResults(ctx context.Context, metric *model.MetricDefinition) ([]vm.QueryResult, error) {
+	if c.checkpoint == nil {
+		return c.queryMetric(ctx, metric)
+	}
+	if results, ok := c.checkpoint.Results(metric); ok {
+		c.logger.Debug().Str("metric", metric.Name).Msg("metric results restored from checkpoint")
+		return results, nil
+	}
+	results, err := c.queryMetric(ctx, metric)
+	if err == nil {
+		c.checkpoint.Save(metric, results)
+	}
+	return results, err
+}
+
+// queryMetric executes the query of a metric with the host filter. Metrics marked export are
+// streamed through the export API when the backend supports it (VictoriaMetrics), so metrics
+// with tens of thousands of series are not buffered as one query response.
+func (c *Collector) queryMetric(ctx context.Context, metric *model.MetricDefinition) ([]vm.QueryResult, error) {
+	if metric.Export {
+		if exporter, ok := c.vmClient.(vm.SeriesExporter); ok {
+			return exporter.ExportResultsWithFilter(ctx, metric.Query, c.hostFilter)
+		}
+	}
+	return c.vmClient.QueryResultsWithFilter(ctx, metric.Query, c.hostFilter)
+}
+
 // collectExpandedMetric collects a metric that should be expanded by a label.
 // For example, disk metrics expanded by "path" label.
 func (c *Collector) collectExpandedMetric(
 	ctx context.Context,
 	metric *model.MetricDefinition,
 	hostMetricsMap map[string]*model.HostMetrics,
+	resolver *hostResolver,
 ) error {
 	c.logger.Debug().
 		Str("metric", metric.Name).
@@ -270,7 +469,7 @@ func (c *Collector) collectExpandedMetric(
 		Msg("collecting expanded metric")
 
 	// Execute query - need raw results to access labels
-	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, c.hostFilter)
+	results, err := c.queryResults(ctx, metric)
 	if err != nil {
 		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
 	}
@@ -279,11 +478,8 @@ func (c *Collector) collectExpandedMetric(
 	hostMaxValues := make(map[string]float64)
 	hostExpandedMetrics := make(map[string][]*model.MetricValue)
 
-	for _, result := range results {
-		hostname := model.CleanIdent(result.Ident)
-		if hostname == "" {
-			continue
-		}
+	for _, match := range resolver.match(metric.Name, metric.ExpandByLabel, results) {
+		hostname, result := match.Hostname, match.Result
 
 		// Check if host exists in our map
 		if _, exists := hostMetricsMap[hostname]; !exists {
@@ -397,6 +593,7 @@ func (c *Collector) collectSimpleMetricConcurrent(
 	ctx context.Context,
 	metric *model.MetricDefinition,
 	hostMetricsMap map[string]*model.HostMetrics,
+	resolver *hostResolver,
 	mu *sync.Mutex,
 ) error {
 	c.logger.Debug().
@@ -405,7 +602,7 @@ func (c *Collector) collectSimpleMetricConcurrent(
 		Msg("collecting simple metric (concurrent)")
 
 	// Execute query with optional host filter
-	results, err := c.vmClient.QueryByIdentWithFilter(ctx, metric.Query, c.hostFilter)
+	results, err := c.queryResults(ctx, metric)
 	if err != nil {
 		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
 	}
@@ -416,11 +613,9 @@ func (c *Collector) collectSimpleMetricConcurrent(
 
 	// Map results to hosts
 	matchedCount := 0
-	for ident, result := range results {
-		// Try to match by hostname (clean ident)
-		hostname := model.CleanIdent(ident)
-		if hostMetrics, exists := hostMetricsMap[hostname]; exists {
-			mv := model.NewMetricValue(metric.Name, result.Value)
+	for _, match := range resolver.match(metric.Name, "", results) {
+		if hostMetrics, exists := hostMetricsMap[match.Hostname]; exists {
+			mv := model.NewMetricValue(metric.Name, match.Result.Value)
 			mv.Timestamp = time.Now().Unix()
 			hostMetrics.SetMetric(mv)
 			matchedCount++
@@ -442,6 +637,7 @@ func (c *Collector) collectExpandedMetricConcurrent(
 	ctx context.Context,
 	metric *model.MetricDefinition,
 	hostMetricsMap map[string]*model.HostMetrics,
+	resolver *hostResolver,
 	mu *sync.Mutex,
 ) error {
 	c.logger.Debug().
@@ -451,7 +647,7 @@ func (c *Collector) collectExpandedMetricConcurrent(
 		Msg("collecting expanded metric (concurrent)")
 
 	// Execute query - need raw results to access labels
-	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, c.hostFilter)
+	results, err := c.queryResults(ctx, metric)
 	if err != nil {
 		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
 	}
@@ -460,11 +656,8 @@ func (c *Collector) collectExpandedMetricConcurrent(
 	hostMaxValues := make(map[string]float64)
 	hostExpandedMetrics := make(map[string][]*model.MetricValue)
 
-	for _, result := range results {
-		hostname := model.CleanIdent(result.Ident)
-		if hostname == "" {
-			continue
-		}
+	for _, match := range resolver.match(metric.Name, metric.ExpandByLabel, results) {
+		hostname, result := match.Hostname, match.Result
 
 		// Get the expansion label value (e.g., path)
 		labelValue := result.Labels[metric.ExpandByLabel]
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -648,6 +649,191 @@ func TestCollector_CollectAll_Success(t *testing.T) {
 	}
 }
 
+func TestCollector_CollectHostMetas_BusinessGroupTree(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"dat": {
+				"list": [
+					{"ident": "test-host-1", "group_ids": [3, 1], "extend_info": "{}"},
+					{"ident": "test-host-2", "extend_info": "{}"}
+				],
+				"total": 2
+			},
+			"err": ""
+		}`))
+	})
+	defer n9eServer.Close()
+
+	tree := model.NewBusinessGroupTree([]model.BusinessGroup{
+		{ID: 1, Name: "电商/支付", Label: "payment"},
+		{ID: 3, Name: "电商/订单"},
+	})
+	collector := NewCollector(createTestConfig(), createN9EClient(n9eServer.URL), nil, nil, zerolog.Nop(), WithBusinessGroupTree(tree))
+
+	hosts, err := collector.CollectHostMetas(context.Background())
+	if err != nil {
+		t.Fatalf("CollectHostMetas failed: %v", err)
+	}
+	if hosts[0].BusinessGroup != "电商/支付" {
+		t.Errorf("Expected business group '电商/支付', got '%s'", hosts[0].BusinessGroup)
+	}
+	if hosts[1].BusinessGroup != "" {
+		t.Errorf("Expected no business group for a host without group, got '%s'", hosts[1].BusinessGroup)
+	}
+}
+
+func TestCollector_CollectHostMetas_Exclude(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"dat": {
+				"list": [
+					{"ident": "web-01", "host_ip": "192.168.1.10", "extend_info": "{}"},
+					{"ident": "web-02", "host_ip": "192.168.1.11", "extend_info": "{}"},
+					{"ident": "test-db-01", "host_ip": "192.168.1.20", "extend_info": "{}"},
+					{"ident": "db-01", "host_ip": "192.168.2.30", "extend_info": "{}"}
+				],
+				"total": 4
+			},
+			"err": ""
+		}`))
+	})
+	defer n9eServer.Close()
+
+	cfg := createTestConfig()
+	cfg.Inspection.HostFilter.Exclude = []string{"test-*", "/^web-0[2-9]$/", "192.168.2.*"}
+	collector := NewCollector(cfg, createN9EClient(n9eServer.URL), nil, nil, zerolog.Nop())
+
+	hosts, err := collector.CollectHostMetas(context.Background())
+	if err != nil {
+		t.Fatalf("CollectHostMetas failed: %v", err)
+	}
+	if len(hosts) != 1 || hosts[0].Hostname != "web-01" {
+		t.Errorf("Expected only web-01 after exclusion, got %d hosts", len(hosts))
+	}
+}
+
+func TestCollector_CollectHostMetas_CMDB(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"dat": {
+				"list": [
+					{"ident": "test-host-1", "extend_info": "{}"},
+					{"ident": "test-host-2", "extend_info": "{}"}
+				],
+				"total": 2
+			},
+			"err": ""
+		}`))
+	})
+	defer n9eServer.Close()
+
+	t.Run("enriched", func(t *testing.T) {
+		source := cmdbSourceFunc(func(context.Context) ([]model.CMDBRecord, error) {
+			return []model.CMDBRecord{{Hostname: "test-host-1", Owner: "alice", Application: "shop", Location: "IDC-A", Maintenance: true}}, nil
+		})
+		collector := NewCollector(createTestConfig(), createN9EClient(n9eServer.URL), nil, nil, zerolog.Nop(), WithCMDB(source))
+
+		hosts, err := collector.CollectHostMetas(context.Background())
+		if err != nil {
+			t.Fatalf("CollectHostMetas failed: %v", err)
+		}
+		if h := hosts[0]; h.Owner != "alice" || h.Application != "shop" || h.Location != "IDC-A" || !h.Maintenance {
+			t.Errorf("Expected CMDB attributes on test-host-1, got %+v", h)
+		}
+		if hosts[1].Owner != "" {
+			t.Errorf("Expected no CMDB attributes on test-host-2, got owner '%s'", hosts[1].Owner)
+		}
+	})
+
+	t.Run("CMDB error ignored", func(t *testing.T) {
+		source := cmdbSourceFunc(func(context.Context) ([]model.CMDBRecord, error) {
+			return nil, errors.New("CMDB unreachable")
+		})
+		collector := NewCollector(createTestConfig(), createN9EClient(n9eServer.URL), nil, nil, zerolog.Nop(), WithCMDB(source))
+
+		hosts, err := collector.CollectHostMetas(context.Background())
+		if err != nil {
+			t.Fatalf("CollectHostMetas should not fail on a CMDB error: %v", err)
+		}
+		if len(hosts) != 2 {
+			t.Errorf("Expected 2 hosts, got %d", len(hosts))
+		}
+	})
+}
+
+// cmdbSourceFunc adapts a function to the cmdb.Source interface.
+type cmdbSourceFunc func(ctx context.Context) ([]model.CMDBRecord, error)
+
+func (f cmdbSourceFunc) Records(ctx context.Context) ([]model.CMDBRecord, error) {
+	return f(ctx)
+}
+
+func TestCollector_CollectActiveAlerts(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/n9e/alert-cur-events/list" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"dat": {
+				"list": [
+					{"rule_name": "CPU使用率过高", "severity": 1, "target_ident": "test-host-1@192.168.1.100", "trigger_time": 1702483200},
+					{"rule_name": "内存使用率过高", "severity": 2, "target_ident": "test-host-2"},
+					{"rule_name": "其他主机告警", "severity": 2, "target_ident": "other-host"},
+					{"rule_name": "MySQL 连接数过高", "severity": 2, "target_ident": ""}
+				],
+				"total": 4
+			},
+			"err": ""
+		}`))
+	})
+	defer n9eServer.Close()
+
+	cfg := createTestConfig()
+	cfg.Datasources.N9E.ActiveAlerts = true
+	collector := NewCollector(cfg, createN9EClient(n9eServer.URL), nil, nil, zerolog.Nop())
+
+	hosts := []*model.HostMeta{
+		{Ident: "test-host-1@192.168.1.100", Hostname: "test-host-1"},
+		{Ident: "test-host-2", Hostname: "test-host-2"},
+	}
+	alerts := collector.CollectActiveAlerts(context.Background(), hosts)
+	if len(alerts) != 2 {
+		t.Fatalf("Expected 2 host alerts, got %d", len(alerts))
+	}
+	if alerts[0].Hostname != "test-host-1" || alerts[0].Level != model.AlertLevelCritical || alerts[0].TriggerTime.Unix() != 1702483200 {
+		t.Errorf("Unexpected first alert: %+v", alerts[0])
+	}
+	if alerts[1].Hostname != "test-host-2" || alerts[1].Level != model.AlertLevelWarning {
+		t.Errorf("Unexpected second alert: %+v", alerts[1])
+	}
+
+	// Disabled: the API is not queried
+	cfg.Datasources.N9E.ActiveAlerts = false
+	if got := collector.CollectActiveAlerts(context.Background(), hosts); got != nil {
+		t.Errorf("Expected no alerts when disabled, got %d", len(got))
+	}
+}
+
+func TestCollector_CollectActiveAlerts_Failure(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	defer n9eServer.Close()
+
+	cfg := createTestConfig()
+	cfg.Datasources.N9E.ActiveAlerts = true
+	collector := NewCollector(cfg, createN9EClient(n9eServer.URL), nil, nil, zerolog.Nop())
+
+	hosts := []*model.HostMeta{{Ident: "test-host-1", Hostname: "test-host-1"}}
+	if got := collector.CollectActiveAlerts(context.Background(), hosts); got != nil {
+		t.Errorf("Expected no alerts on API failure, got %d", len(got))
+	}
+}
+
 func TestCollector_CollectAll_NoHosts(t *testing.T) {
 	// Setup N9E server that returns empty list
 	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -911,6 +1097,52 @@ func TestCollector_ExpandedMetric_DiskByPath(t *testing.T) {
 	}
 }
 
+func TestCollector_ExportedMetric(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	defer n9eServer.Close()
+
+	vmServer := setupVMTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/export" {
+			t.Errorf("expected the export API, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("match[]"); got != "disk_used_percent" {
+			t.Errorf("match[] = %q, want disk_used_percent", got)
+		}
+		w.Write([]byte(`{"metric":{"ident":"host1","path":"/"},"values":[35,40],"timestamps":[1702483185000,1702483200000]}
+{"metric":{"ident":"host1","path":"/var"},"values":[60],"timestamps":[1702483200000]}
+`))
+	})
+	defer vmServer.Close()
+
+	cfg := createTestConfig()
+	n9eClient := createN9EClient(n9eServer.URL)
+	vmClient := createVMClient(vmServer.URL)
+
+	metrics := []*model.MetricDefinition{
+		{
+			Name:          "disk_usage",
+			Query:         "disk_used_percent",
+			ExpandByLabel: "path",
+			Aggregate:     model.AggregateMax,
+			Export:        true,
+		},
+	}
+
+	collector := NewCollector(cfg, n9eClient, vmClient, metrics, zerolog.Nop())
+	hostMetrics, err := collector.CollectMetrics(context.Background(), []*model.HostMeta{{Hostname: "host1"}}, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics failed: %v", err)
+	}
+
+	hm := hostMetrics["host1"]
+	if mv := hm.GetMetric("disk_usage:/"); mv == nil || mv.RawValue != 40.0 {
+		t.Errorf("disk_usage:/ = %+v, want the latest exported value 40", mv)
+	}
+	if mv := hm.GetMetric("disk_usage_max"); mv == nil || mv.RawValue != 60.0 {
+		t.Errorf("disk_usage_max = %+v, want 60", mv)
+	}
+}
+
 // =============================================================================
 // Context Cancellation Tests
 // =============================================================================
@@ -1306,3 +1538,126 @@ func TestCollector_CollectMetrics_ContextCancel_Concurrent(t *testing.T) {
 	// The important thing is that it completes quickly
 	_ = err // We don't check the error since individual failures return nil
 }
+
+// =============================================================================
+// Checkpoint Tests
+// =============================================================================
+
+// mapMetricCheckpoint is a MetricCheckpoint keeping the results by metric name and query.
+type mapMetricCheckpoint struct {
+	mu      sync.Mutex
+	results map[string][]vm.QueryResult
+}
+
+func (c *mapMetricCheckpoint) Results(metric *model.MetricDefinition) ([]vm.QueryResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results, ok := c.results[metric.Name+" "+metric.Query]
+	return results, ok
+}
+
+func (c *mapMetricCheckpoint) Save(metric *model.MetricDefinition, results []vm.QueryResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[metric.Name+" "+metric.Query] = results
+}
+
+func TestCollector_MetricCheckpoint(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	defer n9eServer.Close()
+
+	var mu sync.Mutex
+	var queries []string
+	vmServer := setupVMTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("query"))
+		mu.Unlock()
+		resp := map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{
+				"resultType": "vector",
+				"result": []map[string]interface{}{
+					{"metric": map[string]string{"ident": "host1"}, "value": []interface{}{1702483200.0, "62.3"}},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer vmServer.Close()
+
+	metrics := []*model.MetricDefinition{
+		{Name: "cpu_usage", Query: "cpu_usage_active"},
+		{Name: "memory_usage", Query: "100 - mem_available_percent"},
+	}
+
+	// cpu_usage was collected by the interrupted run
+	checkpoint := &mapMetricCheckpoint{results: map[string][]vm.QueryResult{
+		"cpu_usage cpu_usage_active": {{Ident: "host1", Value: 45.5, Labels: map[string]string{"ident": "host1"}}},
+	}}
+	collector := NewCollector(createTestConfig(), createN9EClient(n9eServer.URL), createVMClient(vmServer.URL), metrics, zerolog.Nop(),
+		WithMetricCheckpoint(checkpoint))
+	hostMetrics, err := collector.CollectMetrics(context.Background(), []*model.HostMeta{{Hostname: "host1"}}, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics failed: %v", err)
+	}
+
+	if len(queries) != 1 || queries[0] != "100 - mem_available_percent" {
+		t.Errorf("queries = %v, want only the metric missing from the checkpoint", queries)
+	}
+	hm := hostMetrics["host1"]
+	if mv := hm.GetMetric("cpu_usage"); mv == nil || mv.RawValue != 45.5 {
+		t.Errorf("cpu_usage = %+v, want the checkpoint value 45.5", mv)
+	}
+	if mv := hm.GetMetric("memory_usage"); mv == nil || mv.RawValue != 62.3 {
+		t.Errorf("memory_usage = %+v, want the queried value 62.3", mv)
+	}
+	if _, ok := checkpoint.Results(metrics[1]); !ok {
+		t.Error("the queried metric should be saved to the checkpoint")
+	}
+}
+
+// countingProgress is a Progress counting the queries of a collection.
+type countingProgress struct {
+	mu               sync.Mutex
+	targets, queries int
+	done             int
+}
+
+func (p *countingProgress) Start(targets, queries int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.targets, p.queries = targets, queries
+}
+
+func (p *countingProgress) QueryDone() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+}
+
+func TestCollector_Progress(t *testing.T) {
+	n9eServer := setupN9ETestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	defer n9eServer.Close()
+	vmServer := setupVMTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError) // Failed queries complete as well
+	})
+	defer vmServer.Close()
+
+	metrics := createTestMetrics() // 3 active metrics, 1 pending
+	collector := NewCollector(createTestConfig(), createN9EClient(n9eServer.URL), createVMClient(vmServer.URL), metrics, zerolog.Nop())
+	hosts := []*model.HostMeta{{Hostname: "host1"}, {Hostname: "host2"}}
+
+	progress := &countingProgress{}
+	if _, err := collector.CollectMetrics(ContextWithProgress(context.Background(), progress), hosts, metrics); err != nil {
+		t.Fatalf("CollectMetrics failed: %v", err)
+	}
+	if progress.targets != 2 || progress.queries != 3 || progress.done != 3 {
+		t.Errorf("progress = %d hosts, %d/%d queries, want 2 hosts, 3/3 queries", progress.targets, progress.done, progress.queries)
+	}
+
+	// Without progress attached, the collection runs as usual
+	if _, err := collector.CollectMetrics(context.Background(), hosts, metrics); err != nil {
+		t.Fatalf("CollectMetrics without progress failed: %v", err)
+	}
+}
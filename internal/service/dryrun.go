@@ -0,0 +1,107 @@
+package service
+
+import (
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/model"
+)
+
+// PlannedQuery is a query a collector would send, as printed by a dry run.
+type PlannedQuery struct {
+	Metric string // 指标名称
+	Query  string // 注入过滤条件后的查询表达式
+}
+
+// pendingDefinition is implemented by all metric and check definitions.
+type pendingDefinition interface {
+	IsPending() bool
+}
+
+// plannedQueries returns the queries of the active definitions with the filter injected.
+// Pending definitions and definitions without a query are skipped.
+func plannedQueries[T pendingDefinition](defs []T, filter *vm.HostFilter, fields func(T) (name, query string)) []PlannedQuery {
+	planned := make([]PlannedQuery, 0, len(defs))
+	for _, def := range defs {
+		if def.IsPending() {
+			continue
+		}
+		name, query := fields(def)
+		if query == "" {
+			continue
+		}
+		planned = append(planned, PlannedQuery{Metric: name, Query: vm.ApplyHostFilter(query, filter)})
+	}
+	return planned
+}
+
+// PlannedQueries returns the host metric queries with the host filter injected, without
+// querying the backend.
+func (c *Collector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.hostFilter, func(m *model.MetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the MySQL metric queries with the instance filter injected.
+func (c *MySQLCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.MySQLMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the Redis metric queries with the instance filter injected.
+func (c *RedisCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.RedisMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the Nginx metric queries with the instance filter injected.
+func (c *NginxCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.NginxMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the Tomcat metric queries with the instance filter injected.
+func (c *TomcatCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.TomcatMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the Cassandra metric queries with the instance filter injected.
+func (c *CassandraCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.CassandraMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the monitoring system metric queries. They are not filtered.
+func (c *MonitoringCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, nil, func(m *model.MonitoringMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the shared storage metric queries with the instance filter injected.
+func (c *StorageCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.StorageMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the log check queries (LogQL or LogsQL), sent as configured.
+func (c *LogCheckCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.checks, nil, func(m *model.LogCheckDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the LVS metric queries with the instance filter injected.
+func (c *LVSCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.LVSMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the Windows metric queries with the instance filter injected.
+func (c *WindowsCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.WindowsMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the AD metric queries with the instance filter injected.
+func (c *ADCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, c.instanceFilter.ToVMHostFilter(), func(m *model.ADMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the cloud metric queries. Cloud resources are filtered after the
+// query, so the queries are sent unchanged.
+func (c *CloudCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, nil, func(m *model.CloudMetricDefinition) (string, string) { return m.Name, m.Query })
+}
+
+// PlannedQueries returns the firewall metric queries. Firewall devices are filtered after
+// the query, so the queries are sent unchanged.
+func (c *FirewallCollector) PlannedQueries() []PlannedQuery {
+	return plannedQueries(c.metrics, nil, func(m *model.FirewallMetricDefinition) (string, string) { return m.Name, m.Query })
+}
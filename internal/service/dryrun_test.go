@@ -0,0 +1,53 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+func TestCollector_PlannedQueries(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Inspection.HostFilter.BusinessGroups = []string{"prod"}
+	cfg.Inspection.HostFilter.Tags = map[string]string{"env": "prod"}
+	metrics := []*model.MetricDefinition{
+		{Name: "cpu_usage", Query: "cpu_usage_active"},
+		{Name: "memory_usage", Query: "100 - mem_available_percent"},
+		{Name: "ntp_check", Query: "ntp_offset_ms", Status: "pending"},
+		{Name: "empty"},
+	}
+
+	got := NewCollector(cfg, nil, nil, metrics, zerolog.Nop()).PlannedQueries()
+
+	want := []PlannedQuery{
+		{Metric: "cpu_usage", Query: `cpu_usage_active{busigroup=~"prod", env="prod"}`},
+		{Metric: "memory_usage", Query: `100 - mem_available_percent{busigroup=~"prod", env="prod"}`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PlannedQueries() = %+v, want %+v", got, want)
+	}
+}
+
+func TestModuleCollectors_PlannedQueries(t *testing.T) {
+	mysqlCfg := &config.MySQLInspectionConfig{
+		InstanceFilter: config.MySQLFilter{BusinessGroups: []string{"db"}},
+	}
+	mysql := NewMySQLCollector(mysqlCfg, nil, []*model.MySQLMetricDefinition{
+		{Name: "mysql_up", Query: "mysql_up"},
+	}, zerolog.Nop())
+	if got := mysql.PlannedQueries(); len(got) != 1 || got[0].Query != `mysql_up{busigroup=~"db"}` {
+		t.Errorf("MySQL PlannedQueries() = %+v, want the instance filter injected", got)
+	}
+
+	// Without an instance filter the query is sent unchanged
+	redis := NewRedisCollector(&config.RedisInspectionConfig{}, nil, []*model.RedisMetricDefinition{
+		{Name: "redis_up", Query: "redis_up"},
+	}, zerolog.Nop())
+	if got := redis.PlannedQueries(); len(got) != 1 || got[0].Query != "redis_up" {
+		t.Errorf("Redis PlannedQueries() = %+v, want the query unchanged", got)
+	}
+}
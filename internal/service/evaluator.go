@@ -13,11 +13,12 @@ import (
 
 // metricThresholdMap maps metric names to their corresponding threshold config field names.
 var metricThresholdMap = map[string]string{
-	"cpu_usage":         "cpu_usage",
-	"memory_usage":      "memory_usage",
-	"disk_usage_max":    "disk_usage", // 磁盘使用聚合最大值用于告警判断
-	"processes_zombies": "zombie_processes",
-	"load_per_core":     "load_per_core",
+	"cpu_usage":                "cpu_usage",
+	"memory_usage":             "memory_usage",
+	"disk_usage_max":           "disk_usage", // 磁盘使用聚合最大值用于告警判断
+	"processes_zombies":        "zombie_processes",
+	"load_per_core":            "load_per_core",
+	"memory_available_percent": "memory_available_percent", // 下限告警
 }
 
 // HostEvaluationResult contains the evaluation result for a single host.
@@ -131,6 +132,19 @@ func (e *Evaluator) evaluateMetric(hostname, metricName string, value *model.Met
 	// Format the metric value for display
 	value.FormattedValue = e.formatMetricValue(metricName, value.RawValue)
 
+	// Values outside the plausible range (e.g. 4500% CPU after a counter reset) are
+	// collection errors: flag them as anomalous instead of raising bogus alerts
+	if def := e.getMetricDefinition(metricName); def != nil && !def.ValidRange.Contains(value.RawValue) {
+		value.Status = model.MetricStatusAnomaly
+		value.FormattedValue = fmt.Sprintf("%s (数据异常)", value.FormattedValue)
+		e.logger.Warn().
+			Str("hostname", hostname).
+			Str("metric", metricName).
+			Float64("value", value.RawValue).
+			Msg("metric value out of valid range, flagged as anomaly")
+		return nil
+	}
+
 	// Skip expanded metrics (e.g., disk_usage:/home) - only evaluate aggregated metrics
 	if strings.Contains(metricName, ":") {
 		// Expanded metrics are for display only, don't trigger alerts
@@ -140,14 +154,14 @@ func (e *Evaluator) evaluateMetric(hostname, metricName string, value *model.Met
 		if threshold == nil {
 			threshold = e.getThreshold(baseName)
 		}
-		if threshold != nil {
+		if threshold != nil && !threshold.IsDisabled() {
 			e.setMetricStatus(value, threshold)
 		}
 		return nil
 	}
 
 	threshold := e.getThreshold(metricName)
-	if threshold == nil {
+	if threshold == nil || threshold.IsDisabled() {
 		// No threshold configured for this metric, skip evaluation
 		value.Status = model.MetricStatusNormal
 		return nil
@@ -181,24 +195,21 @@ func (e *Evaluator) evaluateMetric(hostname, metricName string, value *model.Met
 
 // setMetricStatus sets the Status field of a MetricValue based on threshold evaluation.
 func (e *Evaluator) setMetricStatus(value *model.MetricValue, threshold *config.ThresholdPair) {
-	if value.RawValue >= threshold.Critical {
+	switch e.evaluateThreshold(value.RawValue, threshold) {
+	case model.AlertLevelCritical:
 		value.Status = model.MetricStatusCritical
-	} else if value.RawValue >= threshold.Warning {
+	case model.AlertLevelWarning:
 		value.Status = model.MetricStatusWarning
-	} else {
+	default:
 		value.Status = model.MetricStatusNormal
 	}
 }
 
 // evaluateThreshold compares a value against warning and critical thresholds.
+// For "below" thresholds the comparison is inverted (lower values are worse); a threshold
+// of 0 disables its level.
 func (e *Evaluator) evaluateThreshold(value float64, threshold *config.ThresholdPair) model.AlertLevel {
-	if value >= threshold.Critical {
-		return model.AlertLevelCritical
-	}
-	if value >= threshold.Warning {
-		return model.AlertLevelWarning
-	}
-	return model.AlertLevelNormal
+	return thresholdLevel(value, *threshold)
 }
 
 // getThreshold retrieves the threshold configuration for a metric name.
@@ -224,13 +235,30 @@ func (e *Evaluator) getThreshold(metricName string) *config.ThresholdPair {
 		return &e.thresholds.ZombieProcesses
 	case "load_per_core":
 		return &e.thresholds.LoadPerCore
+	case "memory_available_percent":
+		return &e.thresholds.MemoryAvailablePercent
 	default:
 		return nil
 	}
 }
 
-// getMetricDisplayName retrieves the display name for a metric from definitions.
-func (e *Evaluator) getMetricDisplayName(metricName string) string {
+// MetricThreshold returns the thresholds alerting on a metric definition, or nil when the
+// metric has none or they are disabled. Expanded metrics alert on their aggregated maximum.
+func (e *Evaluator) MetricThreshold(def *model.MetricDefinition) *config.ThresholdPair {
+	threshold := e.getThreshold(def.Name)
+	if def.ExpandByLabel != "" {
+		if aggregated := e.getThreshold(def.Name + "_max"); aggregated != nil {
+			threshold = aggregated
+		}
+	}
+	if threshold == nil || threshold.IsDisabled() {
+		return nil
+	}
+	return threshold
+}
+
+// getMetricDefinition retrieves the definition of a metric, or nil if not found.
+func (e *Evaluator) getMetricDefinition(metricName string) *model.MetricDefinition {
 	// Handle expanded metrics (e.g., disk_usage:/home → disk_usage)
 	baseName := metricName
 	if idx := strings.Index(metricName, ":"); idx > 0 {
@@ -241,7 +269,12 @@ func (e *Evaluator) getMetricDisplayName(metricName string) string {
 		baseName = strings.TrimSuffix(baseName, "_max")
 	}
 
-	if def, ok := e.metricDefs[baseName]; ok {
+	return e.metricDefs[baseName]
+}
+
+// getMetricDisplayName retrieves the display name for a metric from definitions.
+func (e *Evaluator) getMetricDisplayName(metricName string) string {
+	if def := e.getMetricDefinition(metricName); def != nil {
 		return def.DisplayName
 	}
 
@@ -259,26 +292,23 @@ func (e *Evaluator) buildAlertMessage(metricName string, value float64, level mo
 		thresholdValue = threshold.Critical
 	}
 
+	// Below thresholds read as a lower bound
+	thresholdLabel := "阈值"
+	if threshold.IsBelow() {
+		thresholdLabel = "下限阈值"
+	}
+
 	// Format the message based on metric type
 	unit := e.getMetricUnit(metricName)
 	if unit == "%" {
-		return fmt.Sprintf("%s %s: %.1f%% (阈值: %.1f%%)", displayName, levelStr, value, thresholdValue)
+		return fmt.Sprintf("%s %s: %.1f%% (%s: %.1f%%)", displayName, levelStr, value, thresholdLabel, thresholdValue)
 	}
-	return fmt.Sprintf("%s %s: %.2f (阈值: %.2f)", displayName, levelStr, value, thresholdValue)
+	return fmt.Sprintf("%s %s: %.2f (%s: %.2f)", displayName, levelStr, value, thresholdLabel, thresholdValue)
 }
 
 // getMetricUnit retrieves the unit for a metric from definitions.
 func (e *Evaluator) getMetricUnit(metricName string) string {
-	// Handle expanded metrics
-	baseName := metricName
-	if idx := strings.Index(metricName, ":"); idx > 0 {
-		baseName = metricName[:idx]
-	}
-	if strings.HasSuffix(baseName, "_max") {
-		baseName = strings.TrimSuffix(baseName, "_max")
-	}
-
-	if def, ok := e.metricDefs[baseName]; ok {
+	if def := e.getMetricDefinition(metricName); def != nil {
 		return def.Unit
 	}
 	return ""
@@ -317,18 +347,9 @@ func (e *Evaluator) determineHostStatus(alerts []*model.Alert) model.HostStatus
 
 // formatMetricValue formats a raw metric value based on the metric definition.
 func (e *Evaluator) formatMetricValue(metricName string, value float64) string {
-	// Get base metric name (strip expanded suffix like ":/" or "_max")
-	baseName := metricName
-	if idx := strings.Index(metricName, ":"); idx > 0 {
-		baseName = metricName[:idx]
-	}
-	if strings.HasSuffix(baseName, "_max") {
-		baseName = strings.TrimSuffix(baseName, "_max")
-	}
-
 	// Get metric definition for format info
-	def, ok := e.metricDefs[baseName]
-	if !ok {
+	def := e.getMetricDefinition(metricName)
+	if def == nil {
 		// Fallback to default number format
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -278,6 +279,150 @@ func TestEvaluator_MemoryUsage_Thresholds(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// 下限阈值（direction: below）评估测试
+// =============================================================================
+
+func TestEvaluator_MemoryAvailablePercent_BelowThresholds(t *testing.T) {
+	thresholds := createTestThresholds()
+	thresholds.MemoryAvailablePercent = config.ThresholdPair{
+		Warning:   20,
+		Critical:  10,
+		Direction: config.ThresholdDirectionBelow,
+	}
+	defs := append(createTestMetricDefs(), &model.MetricDefinition{
+		Name: "memory_available_percent", DisplayName: "可用内存比例", Unit: "%",
+	})
+	evaluator := NewEvaluator(thresholds, defs, zerolog.Nop())
+
+	tests := []struct {
+		name           string
+		value          float64
+		expectedStatus model.HostStatus
+		expectedLevel  model.AlertLevel
+	}{
+		{"Normal", 50.0, model.HostStatusNormal, model.AlertLevelNormal},
+		{"Warning", 15.0, model.HostStatusWarning, model.AlertLevelWarning},
+		{"Warning at threshold", 20.0, model.HostStatusWarning, model.AlertLevelWarning},
+		{"Critical", 5.0, model.HostStatusCritical, model.AlertLevelCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := model.NewHostMetrics("server-01")
+			metrics.SetMetric(&model.MetricValue{
+				Name:     "memory_available_percent",
+				RawValue: tt.value,
+			})
+
+			result := evaluator.EvaluateHost("server-01", metrics)
+
+			if result.Status != tt.expectedStatus {
+				t.Errorf("expected status %s, got %s", tt.expectedStatus, result.Status)
+			}
+
+			if tt.expectedLevel == model.AlertLevelNormal {
+				if len(result.Alerts) != 0 {
+					t.Errorf("expected 0 alerts for normal, got %d", len(result.Alerts))
+				}
+				return
+			}
+			if len(result.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(result.Alerts))
+			}
+			alert := result.Alerts[0]
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+			if !strings.Contains(alert.Message, "下限阈值") {
+				t.Errorf("expected lower-bound message, got %q", alert.Message)
+			}
+		})
+	}
+}
+
+func TestEvaluator_ValidRange_FlagsAnomaly(t *testing.T) {
+	zero, hundred := 0.0, 100.0
+	defs := createTestMetricDefs()
+	for _, def := range defs {
+		if def.Unit == "%" {
+			def.ValidRange = &model.ValueRange{Min: &zero, Max: &hundred}
+		}
+	}
+	defs = append(defs, &model.MetricDefinition{Name: "cpu_cores", DisplayName: "CPU 核心数"})
+	evaluator := NewEvaluator(createTestThresholds(), defs, zerolog.Nop())
+
+	metrics := model.NewHostMetrics("server-01")
+	metrics.SetMetric(model.NewMetricValue("cpu_usage", 4500))
+	metrics.SetMetric(model.NewMetricValue("memory_usage", 95))
+	metrics.SetMetric(model.NewMetricValue("disk_usage:/data", -3))
+	metrics.SetMetric(model.NewMetricValue("cpu_cores", 4500))
+
+	result := evaluator.EvaluateHost("server-01", metrics)
+
+	// Only the in-range critical memory usage alerts
+	if len(result.Alerts) != 1 || result.Alerts[0].MetricName != "memory_usage" {
+		t.Fatalf("expected only the memory_usage alert, got %v", result.Alerts)
+	}
+	if result.Status != model.HostStatusCritical {
+		t.Errorf("expected status critical, got %s", result.Status)
+	}
+
+	cpu := metrics.GetMetric("cpu_usage")
+	if cpu.Status != model.MetricStatusAnomaly {
+		t.Errorf("expected cpu_usage status anomaly, got %s", cpu.Status)
+	}
+	if cpu.FormattedValue != "4500.0% (数据异常)" {
+		t.Errorf("expected flagged formatted value, got %q", cpu.FormattedValue)
+	}
+	if disk := metrics.GetMetric("disk_usage:/data"); disk.Status != model.MetricStatusAnomaly {
+		t.Errorf("expected expanded disk_usage status anomaly, got %s", disk.Status)
+	}
+	// Metrics without a valid range are never flagged
+	if cores := metrics.GetMetric("cpu_cores"); cores.Status == model.MetricStatusAnomaly {
+		t.Error("expected cpu_cores without valid_range not to be flagged")
+	}
+}
+
+func TestEvaluator_MemoryAvailablePercent_DisabledByDefault(t *testing.T) {
+	evaluator := createTestEvaluator()
+
+	metrics := model.NewHostMetrics("server-01")
+	metrics.SetMetric(&model.MetricValue{
+		Name:     "memory_available_percent",
+		RawValue: 1.0,
+	})
+
+	result := evaluator.EvaluateHost("server-01", metrics)
+
+	if len(result.Alerts) != 0 {
+		t.Errorf("expected no alerts for disabled threshold, got %d", len(result.Alerts))
+	}
+	if got := metrics.Metrics["memory_available_percent"].Status; got != model.MetricStatusNormal {
+		t.Errorf("expected normal status, got %s", got)
+	}
+}
+
+func TestEvaluator_MetricThreshold(t *testing.T) {
+	evaluator := createTestEvaluator()
+
+	tests := []struct {
+		def  *model.MetricDefinition
+		want *config.ThresholdPair
+	}{
+		{&model.MetricDefinition{Name: "cpu_usage"}, &config.ThresholdPair{Warning: 70, Critical: 90}},
+		{&model.MetricDefinition{Name: "disk_usage", ExpandByLabel: "path"}, &config.ThresholdPair{Warning: 70, Critical: 90}},
+		{&model.MetricDefinition{Name: "memory_available_percent"}, nil}, // Disabled
+		{&model.MetricDefinition{Name: "uptime"}, nil},
+	}
+	for _, tt := range tests {
+		got := evaluator.MetricThreshold(tt.def)
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("MetricThreshold(%s) = %+v, want %+v", tt.def.Name, got, tt.want)
+		}
+	}
+}
+
 // =============================================================================
 // 磁盘评估测试
 // =============================================================================
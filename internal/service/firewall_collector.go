@@ -0,0 +1,491 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// Firewall metric fields. Profile-specific metric definitions are normalized into
+// these fields so devices of all vendors are evaluated alike.
+const (
+	firewallCPUUsageField        = model.FirewallFieldCPUUsage
+	firewallMemoryUsageField     = model.FirewallFieldMemoryUsage
+	firewallSessionUsageField    = model.FirewallFieldSessionUsage
+	firewallSessionsField        = model.FirewallFieldSessions
+	firewallVPNTunnelStatusField = model.FirewallFieldVPNTunnelStatus
+	firewallHAStatusField        = model.FirewallFieldHAStatus
+)
+
+// firewallDiscoveryFields lists the fields whose series are treated as firewall devices.
+var firewallDiscoveryFields = map[string]bool{
+	firewallCPUUsageField: true,
+	firewallSessionsField: true,
+}
+
+// =============================================================================
+// Firewall Collector
+// =============================================================================
+
+// FirewallCollector is the data collection service for firewall / VPN appliances.
+// It reads the SNMP metrics snmp_exporter exposes for each device and maps them onto
+// normalized fields through the device profiles of the metric definitions.
+// Devices are identified by profile and device address.
+type FirewallCollector struct {
+	vmClient       vm.MetricsSource
+	config         *config.FirewallInspectionConfig
+	metrics        []*model.FirewallMetricDefinition
+	metricDefs     map[string]*model.FirewallMetricDefinition
+	instanceFilter *FirewallInstanceFilter
+	logger         zerolog.Logger
+}
+
+// FirewallInstanceFilter defines filtering criteria for firewall devices.
+type FirewallInstanceFilter struct {
+	DevicePatterns []string // Device address or name patterns (glob, e.g., "10.0.1.*")
+	Profiles       []string // Device profiles (fortigate, paloalto, cisco_asa)
+}
+
+// NewFirewallCollector creates a new FirewallCollector instance.
+func NewFirewallCollector(
+	cfg *config.FirewallInspectionConfig,
+	vmClient vm.MetricsSource,
+	metrics []*model.FirewallMetricDefinition,
+	logger zerolog.Logger,
+) *FirewallCollector {
+	c := &FirewallCollector{
+		vmClient: vmClient,
+		config:   cfg,
+		metrics:  metrics,
+		logger:   logger.With().Str("component", "firewall-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.FirewallMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	// Build instance filter from config
+	c.instanceFilter = c.buildInstanceFilter()
+
+	return c
+}
+
+// buildInstanceFilter converts config.FirewallFilter to FirewallInstanceFilter.
+func (c *FirewallCollector) buildInstanceFilter() *FirewallInstanceFilter {
+	if c.config == nil {
+		return nil
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.DevicePatterns) == 0 && len(filter.Profiles) == 0 {
+		return nil
+	}
+
+	return &FirewallInstanceFilter{
+		DevicePatterns: filter.DevicePatterns,
+		Profiles:       filter.Profiles,
+	}
+}
+
+// GetConfig returns the firewall inspection configuration.
+func (c *FirewallCollector) GetConfig() *config.FirewallInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *FirewallCollector) GetMetrics() []*model.FirewallMetricDefinition {
+	return c.metrics
+}
+
+// GetInstanceFilter returns the instance filter.
+func (c *FirewallCollector) GetInstanceFilter() *FirewallInstanceFilter {
+	return c.instanceFilter
+}
+
+// IsEmpty returns true if the instance filter has no filtering criteria.
+func (f *FirewallInstanceFilter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.DevicePatterns) == 0 && len(f.Profiles) == 0
+}
+
+// Matches returns true if the device passes the filter.
+// Profiles are compared case-insensitively; device patterns match either the
+// device address or the device name.
+func (f *FirewallInstanceFilter) Matches(device *model.FirewallDevice) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	if device == nil {
+		return false
+	}
+
+	if len(f.Profiles) > 0 && !containsFold(f.Profiles, device.Profile) {
+		return false
+	}
+	if len(f.DevicePatterns) > 0 {
+		for _, pattern := range f.DevicePatterns {
+			if matchPattern(device.Address, pattern) ||
+				(device.Name != "" && matchPattern(device.Name, pattern)) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// =============================================================================
+// 防火墙设备发现
+// =============================================================================
+
+// DiscoverInstances discovers all firewall devices.
+// Devices are the union of the series returned by the cpu_usage and sessions
+// metrics of every profile.
+func (c *FirewallCollector) DiscoverInstances(ctx context.Context) ([]*model.FirewallDevice, error) {
+	c.logger.Info().Msg("starting firewall device discovery")
+
+	instanceMap := make(map[string]*model.FirewallDevice)
+	var order []string
+	queried := 0
+
+	for _, metric := range c.metrics {
+		if !firewallDiscoveryFields[metric.Field] || metric.IsPending() {
+			continue
+		}
+
+		results, err := c.vmClient.QueryResults(ctx, metric.Query)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("failed to query discovery metric, continuing with others")
+			continue
+		}
+		queried++
+
+		for _, result := range results {
+			device := c.deviceFromLabels(metric, result.Labels)
+			if device == nil {
+				c.logger.Warn().
+					Str("metric", metric.Name).
+					Str("device_label", metric.DeviceLabel).
+					Interface("labels", result.Labels).
+					Msg("missing device label")
+				continue
+			}
+
+			if existing, exists := instanceMap[device.Key()]; exists {
+				if existing.Name == "" {
+					existing.Name = device.Name
+				}
+				continue
+			}
+
+			if !c.instanceFilter.Matches(device) {
+				c.logger.Debug().Str("device", device.Key()).Msg("firewall device filtered out")
+				continue
+			}
+
+			instanceMap[device.Key()] = device
+			order = append(order, device.Key())
+		}
+	}
+
+	if queried == 0 {
+		return nil, fmt.Errorf("failed to query any firewall discovery metric")
+	}
+
+	instances := make([]*model.FirewallDevice, 0, len(order))
+	for _, key := range order {
+		instances = append(instances, instanceMap[key])
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Msg("firewall device discovery completed")
+
+	return instances, nil
+}
+
+// deviceFromLabels builds the firewall device a series belongs to.
+// Returns nil if the device label is missing.
+func (c *FirewallCollector) deviceFromLabels(metric *model.FirewallMetricDefinition, labels map[string]string) *model.FirewallDevice {
+	address := labels[metric.DeviceLabel]
+	if address == "" {
+		return nil
+	}
+
+	device := model.NewFirewallDevice(metric.Profile, address)
+	if metric.NameLabel != "" {
+		device.Name = labels[metric.NameLabel]
+	}
+	return device
+}
+
+// =============================================================================
+// 防火墙设备指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all firewall devices.
+//
+// Flow:
+//  1. Initialize result objects for each device
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values and VPN tunnels from metrics
+//  6. Return results map (key = profile/address)
+//
+// Single metric failure does not abort the entire collection.
+func (c *FirewallCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.FirewallDevice,
+	metrics []*model.FirewallMetricDefinition,
+) (map[string]*model.FirewallInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting firewall metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by device key)
+	resultsMap := make(map[string]*model.FirewallInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Key()] = model.NewFirewallInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.FirewallMetricDefinition
+	var activeMetrics []*model.FirewallMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values and VPN tunnels from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("firewall metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for pending metrics on the devices of the same profile.
+func (c *FirewallCollector) setPendingMetrics(
+	resultsMap map[string]*model.FirewallInspectionResult,
+	pendingMetrics []*model.FirewallMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending firewall metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			if result.Device == nil || !strings.EqualFold(metric.Profile, result.Device.Profile) {
+				continue
+			}
+			result.SetMetric(&model.FirewallMetricValue{
+				Name:           metric.Field,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// firewallMetricKey returns the key a series is stored under: the normalized field,
+// suffixed with the tunnel name for VPN tunnel status (e.g. "vpn_tunnel_status:to-branch").
+// Returns "" for a tunnel series without tunnel name.
+func firewallMetricKey(metric *model.FirewallMetricDefinition, labels map[string]string) string {
+	if metric.Field != firewallVPNTunnelStatusField {
+		return metric.Field
+	}
+	tunnel := labels[metric.TunnelLabel]
+	if tunnel == "" {
+		return ""
+	}
+	return metric.Field + ":" + tunnel
+}
+
+// collectMetricConcurrent collects a single metric for all devices (concurrent-safe).
+// Values are stored under the metric key; when several series match one key the
+// largest value is kept, except for VPN tunnel and HA status where the smallest
+// (down / abnormal) wins.
+func (c *FirewallCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.FirewallMetricDefinition,
+	resultsMap map[string]*model.FirewallInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting firewall metric (concurrent)")
+
+	// Query VictoriaMetrics
+	results, err := c.vmClient.QueryResults(ctx, metric.Query)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		address := result.Labels[metric.DeviceLabel]
+		if address == "" {
+			continue
+		}
+		inspResult := resultsMap[model.FirewallDeviceKey(metric.Profile, address)]
+		if inspResult == nil {
+			continue
+		}
+		key := firewallMetricKey(metric, result.Labels)
+		if key == "" {
+			continue
+		}
+
+		value := result.Value
+		if existing := inspResult.GetMetric(key); existing != nil && !existing.IsNA {
+			if metric.Field == firewallVPNTunnelStatusField || metric.Field == firewallHAStatusField {
+				value = math.Min(existing.RawValue, value)
+			} else {
+				value = math.Max(existing.RawValue, value)
+			}
+		}
+
+		mv := &model.FirewallMetricValue{
+			Name:      key,
+			RawValue:  value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields and
+// builds the VPN tunnel list, sorted by tunnel name.
+// NaN/Inf values are treated as not collected.
+func (c *FirewallCollector) extractFieldsFromMetrics(resultsMap map[string]*model.FirewallInspectionResult) {
+	for _, result := range resultsMap {
+		fields := map[string]*float64{
+			firewallCPUUsageField:     &result.CPUUsage,
+			firewallMemoryUsageField:  &result.MemoryUsage,
+			firewallSessionUsageField: &result.SessionUsage,
+			firewallSessionsField:     &result.Sessions,
+			firewallHAStatusField:     &result.HAStatus,
+		}
+		for name, field := range fields {
+			mv := result.GetMetric(name)
+			if mv == nil || mv.IsNA || math.IsNaN(mv.RawValue) || math.IsInf(mv.RawValue, 0) {
+				continue
+			}
+			*field = mv.RawValue
+		}
+		if mv := result.GetMetric(firewallHAStatusField); mv != nil && !mv.IsNA {
+			result.HAState = mv.StringValue
+		}
+
+		prefix := firewallVPNTunnelStatusField + ":"
+		var tunnels []string
+		for name := range result.Metrics {
+			if strings.HasPrefix(name, prefix) {
+				tunnels = append(tunnels, name)
+			}
+		}
+		sort.Strings(tunnels)
+		for _, name := range tunnels {
+			mv := result.GetMetric(name)
+			if math.IsNaN(mv.RawValue) {
+				continue
+			}
+			result.AddTunnel(&model.FirewallTunnel{
+				Name: strings.TrimPrefix(name, prefix),
+				Up:   mv.RawValue >= 1,
+			})
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// createTestFirewallCollector creates a FirewallCollector querying a fake VM server.
+func createTestFirewallCollector(t *testing.T, series map[string][]fakeSeries, cfg *config.FirewallInspectionConfig, metrics []*model.FirewallMetricDefinition) *FirewallCollector {
+	t.Helper()
+	vmServer := newFakeVMServer(t, series)
+	return NewFirewallCollector(cfg, newFakeVMClient(vmServer.URL), metrics, zerolog.Nop())
+}
+
+// createTestFirewallMetricDefs returns FortiGate and Palo Alto metrics, querying the fake
+// VM server by query name.
+func createTestFirewallMetricDefs() []*model.FirewallMetricDefinition {
+	metric := func(profile, name, field string) *model.FirewallMetricDefinition {
+		return &model.FirewallMetricDefinition{
+			Name: name, Query: name, Profile: profile, Field: field, DeviceLabel: "instance",
+		}
+	}
+	tunnel := metric("fortigate", "fg_vpn", "vpn_tunnel_status")
+	tunnel.TunnelLabel = "fgVpnTunEntPhase1Name"
+	ha := metric("paloalto", "pan_ha", "ha_status")
+	ha.LabelExtract = []string{"panSysHAState"}
+	return []*model.FirewallMetricDefinition{
+		metric("fortigate", "fg_cpu", "cpu_usage"),
+		tunnel,
+		metric("paloalto", "pan_sessions", "sessions"),
+		metric("paloalto", "pan_session_usage", "session_usage"),
+		ha,
+	}
+}
+
+func TestFirewallCollector_DiscoverInstances(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"fg_cpu": {
+			{map[string]string{"instance": "10.0.1.1"}, 20},
+			{map[string]string{"instance": "10.0.9.1"}, 20}, // Filtered out by pattern
+			{map[string]string{"job": "snmp"}, 20},          // No device label
+		},
+		"pan_session_usage": {
+			{map[string]string{"instance": "10.0.3.1"}, 50}, // Not a discovery field
+		},
+		"pan_sessions": {
+			{map[string]string{"instance": "10.0.1.2"}, 1200},
+		},
+	}
+	cfg := &config.FirewallInspectionConfig{InstanceFilter: config.FirewallFilter{DevicePatterns: []string{"10.0.1.*"}}}
+	collector := createTestFirewallCollector(t, series, cfg, createTestFirewallMetricDefs())
+
+	devices, err := collector.DiscoverInstances(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverInstances() error = %v", err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("DiscoverInstances() returned %d devices, want 2: %+v", len(devices), devices)
+	}
+	if devices[0].Key() != "fortigate/10.0.1.1" || devices[1].Key() != "paloalto/10.0.1.2" {
+		t.Errorf("devices = %s, %s, want the FortiGate and the Palo Alto", devices[0].Key(), devices[1].Key())
+	}
+}
+
+func TestFirewallCollector_DiscoverInstances_QueryError(t *testing.T) {
+	collector := NewFirewallCollector(nil, newFakeVMClient("http://127.0.0.1:1"), createTestFirewallMetricDefs(), zerolog.Nop())
+	if _, err := collector.DiscoverInstances(context.Background()); err == nil {
+		t.Error("DiscoverInstances() should fail when no discovery metric can be queried")
+	}
+}
+
+func TestFirewallCollector_CollectMetrics(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"fg_cpu": {
+			{map[string]string{"instance": "10.0.1.1"}, 35},
+		},
+		"fg_vpn": {
+			{map[string]string{"instance": "10.0.1.1", "fgVpnTunEntPhase1Name": "to-dc"}, 1},
+			// Two phase 2 selectors of one tunnel: a down selector wins
+			{map[string]string{"instance": "10.0.1.1", "fgVpnTunEntPhase1Name": "to-branch"}, 1},
+			{map[string]string{"instance": "10.0.1.1", "fgVpnTunEntPhase1Name": "to-branch"}, 0},
+			{map[string]string{"instance": "10.0.1.1"}, 0}, // No tunnel name
+		},
+		"pan_sessions": {
+			{map[string]string{"instance": "10.0.3.1"}, 1200},
+		},
+		"pan_ha": {
+			{map[string]string{"instance": "10.0.3.1", "panSysHAState": "suspended"}, 0},
+		},
+	}
+	metrics := createTestFirewallMetricDefs()
+	metrics[3].Status = "pending"
+	collector := createTestFirewallCollector(t, series, nil, metrics)
+
+	fortigate := model.NewFirewallDevice("fortigate", "10.0.1.1")
+	paloalto := model.NewFirewallDevice("paloalto", "10.0.3.1")
+	results, err := collector.CollectMetrics(context.Background(), []*model.FirewallDevice{fortigate, paloalto}, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+
+	fg := results[fortigate.Key()]
+	if fg.CPUUsage != 35 || fg.HAStatus != -1 {
+		t.Errorf("FortiGate = %v CPU, %v HA, want 35 and -1", fg.CPUUsage, fg.HAStatus)
+	}
+	if len(fg.Tunnels) != 2 || fg.TunnelsDown != 1 {
+		t.Fatalf("FortiGate tunnels = %+v, want 2 with 1 down", fg.Tunnels)
+	}
+	if fg.Tunnels[0].Name != "to-branch" || fg.Tunnels[0].Up || !fg.Tunnels[1].Up {
+		t.Errorf("tunnels = %+v, %+v, want to-branch down and to-dc up", fg.Tunnels[0], fg.Tunnels[1])
+	}
+	if mv := fg.GetMetric("session_usage"); mv != nil {
+		t.Errorf("pending Palo Alto metric should not be set on the FortiGate: %+v", mv)
+	}
+
+	pan := results[paloalto.Key()]
+	if pan.Sessions != 1200 || pan.SessionUsage != -1 {
+		t.Errorf("Palo Alto = %v sessions, %v session usage, want 1200 and -1", pan.Sessions, pan.SessionUsage)
+	}
+	if mv := pan.GetMetric("session_usage"); mv == nil || !mv.IsNA {
+		t.Errorf("pending metric = %+v, want N/A", mv)
+	}
+	if !pan.IsHAAbnormal() || pan.HAState != "suspended" {
+		t.Errorf("Palo Alto HA = %v (%q), want abnormal suspended", pan.HAStatus, pan.HAState)
+	}
+}
@@ -0,0 +1,270 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Firewall Evaluator
+// =============================================================================
+
+// FirewallEvaluationResult represents the evaluation result for a single firewall device.
+type FirewallEvaluationResult struct {
+	Identifier string                     `json:"identifier"` // 设备标识符
+	Status     model.FirewallDeviceStatus `json:"status"`     // 设备整体状态
+	Alerts     []*model.FirewallAlert     `json:"alerts"`     // 告警列表
+}
+
+// FirewallEvaluator evaluates firewall device metrics against thresholds.
+// Alerts are raised per normalized field, so they are named after the field
+// (cpu_usage, vpn_tunnel_status, ...) rather than the profile-specific metric.
+type FirewallEvaluator struct {
+	thresholds   *config.FirewallThresholds // 阈值配置
+	displayNames map[string]string          // 归一化字段显示名称（取第一个定义该字段的指标）
+	timezone     *time.Location             // 时区
+	logger       zerolog.Logger             // 日志器
+}
+
+// NewFirewallEvaluator creates a new FirewallEvaluator with the given threshold configuration.
+func NewFirewallEvaluator(
+	thresholds *config.FirewallThresholds,
+	metrics []*model.FirewallMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *FirewallEvaluator {
+	displayNames := make(map[string]string)
+	for _, m := range metrics {
+		if _, exists := displayNames[m.Field]; !exists && m.Field != "" {
+			displayNames[m.Field] = m.GetDisplayName()
+		}
+	}
+
+	return &FirewallEvaluator{
+		thresholds:   thresholds,
+		displayNames: displayNames,
+		timezone:     timezone,
+		logger:       logger.With().Str("component", "firewall_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all firewall devices and returns the complete evaluation results.
+func (e *FirewallEvaluator) EvaluateAll(
+	results map[string]*model.FirewallInspectionResult,
+) []*FirewallEvaluationResult {
+	evalResults := make([]*FirewallEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("firewall evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single firewall device against configured thresholds.
+// Down VPN tunnels and an abnormal HA state are critical from the first occurrence.
+func (e *FirewallEvaluator) Evaluate(
+	result *model.FirewallInspectionResult,
+) *FirewallEvaluationResult {
+	evalResult := &FirewallEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.FirewallStatusNormal,
+		Alerts:     make([]*model.FirewallAlert, 0),
+	}
+
+	// Skip failed devices
+	if result.Error != "" {
+		evalResult.Status = model.FirewallStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed firewall device")
+		return evalResult
+	}
+
+	// 1. Evaluate utilization fields (higher is worse)
+	usages := []struct {
+		field     string
+		collected bool
+		value     float64
+	}{
+		{firewallCPUUsageField, result.HasCPUUsage(), result.CPUUsage},
+		{firewallMemoryUsageField, result.HasMemoryUsage(), result.MemoryUsage},
+		{firewallSessionUsageField, result.HasSessionUsage(), result.SessionUsage},
+	}
+	for _, u := range usages {
+		if !u.collected {
+			continue
+		}
+		warning, critical := e.getThresholds(u.field)
+		if alert := e.alertAt(result, u.field, u.value, config.ThresholdPair{Warning: warning, Critical: critical}); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+	}
+
+	// 2. Evaluate VPN tunnels (any down tunnel -> Critical)
+	if result.TunnelsDown > 0 {
+		alert := e.createAlert(result.GetIdentifier(), firewallVPNTunnelStatusField, float64(result.TunnelsDown), model.AlertLevelCritical)
+		alert.Message = fmt.Sprintf("%d 条 VPN 隧道断开：%s", result.TunnelsDown, strings.Join(result.DownTunnelNames(), ", "))
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+
+	// 3. Evaluate HA status (abnormal -> Critical)
+	if result.IsHAAbnormal() {
+		alert := e.createAlert(result.GetIdentifier(), firewallHAStatusField, result.HAStatus, model.AlertLevelCritical)
+		if result.HAState != "" {
+			alert.FormattedValue = result.HAState
+			alert.Message = fmt.Sprintf("%s异常（%s），主备切换可能无法正常进行", alert.MetricDisplayName, result.HAState)
+		}
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("firewall device evaluation completed")
+
+	return evalResult
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *FirewallEvaluator) alertAt(
+	result *model.FirewallInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.FirewallAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *FirewallEvaluator) determineInstanceStatus(
+	alerts []*model.FirewallAlert,
+) model.FirewallDeviceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.FirewallStatusCritical
+	}
+	if hasWarning {
+		return model.FirewallStatusWarning
+	}
+	return model.FirewallStatusNormal
+}
+
+// createAlert creates a FirewallAlert with formatted message.
+func (e *FirewallEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.FirewallAlert {
+	displayName := metricName
+	if name, exists := e.displayNames[metricName]; exists {
+		displayName = name
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.FirewallAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(displayName, metricName, currentValue, level),
+	}
+}
+
+// formatValue formats metric value for display.
+func (e *FirewallEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case firewallCPUUsageField, firewallMemoryUsageField, firewallSessionUsageField:
+		return fmt.Sprintf("%.1f%%", value)
+	case firewallSessionsField, firewallVPNTunnelStatusField:
+		return fmt.Sprintf("%.0f", value)
+	case firewallHAStatusField:
+		return "异常"
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *FirewallEvaluator) generateAlertMessage(
+	displayName string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case firewallVPNTunnelStatusField:
+		return fmt.Sprintf("%.0f 条 VPN 隧道断开", currentValue)
+	case firewallHAStatusField:
+		return fmt.Sprintf("%s异常，主备切换可能无法正常进行", displayName)
+	case firewallCPUUsageField, firewallMemoryUsageField, firewallSessionUsageField:
+		warning, critical := e.getThresholds(metricName)
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("%s %.1f%%，已超过严重阈值 %.0f%%", displayName, currentValue, critical)
+		}
+		return fmt.Sprintf("%s %.1f%%，已超过警告阈值 %.0f%%", displayName, currentValue, warning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric field.
+// Down VPN tunnels and an abnormal HA state are critical from the first occurrence.
+func (e *FirewallEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case firewallCPUUsageField:
+		return e.thresholds.CPUUsageWarning, e.thresholds.CPUUsageCritical
+	case firewallMemoryUsageField:
+		return e.thresholds.MemoryUsageWarning, e.thresholds.MemoryUsageCritical
+	case firewallSessionUsageField:
+		return e.thresholds.SessionUsageWarning, e.thresholds.SessionUsageCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *FirewallEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{firewallCPUUsageField, firewallMemoryUsageField, firewallSessionUsageField})
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestFirewallEvaluator creates a firewall evaluator with default thresholds for testing.
+func createTestFirewallEvaluator() *FirewallEvaluator {
+	thresholds := &config.FirewallThresholds{
+		CPUUsageWarning:      70,
+		CPUUsageCritical:     90,
+		MemoryUsageWarning:   80,
+		MemoryUsageCritical:  90,
+		SessionUsageWarning:  70,
+		SessionUsageCritical: 90,
+	}
+	metrics := []*model.FirewallMetricDefinition{
+		{Name: "fortigate_cpu_usage", DisplayName: "CPU 使用率", Field: "cpu_usage"},
+		{Name: "paloalto_session_usage", DisplayName: "会话表使用率", Field: "session_usage"},
+		{Name: "fortigate_vpn_tunnel_status", DisplayName: "VPN 隧道状态", Field: "vpn_tunnel_status"},
+		{Name: "paloalto_ha_status", DisplayName: "HA 状态", Field: "ha_status"},
+		{Name: "cisco_asa_ha_status", DisplayName: "Failover 状态", Field: "ha_status"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewFirewallEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// =============================================================================
+// Collector Helper Tests
+// =============================================================================
+
+func TestFirewallInstanceFilter_Matches(t *testing.T) {
+	fortigate := model.NewFirewallDevice("fortigate", "10.0.1.1")
+	fortigate.Name = "fw-hq-01"
+	asa := model.NewFirewallDevice("cisco_asa", "10.0.2.1")
+
+	tests := []struct {
+		name   string
+		filter *FirewallInstanceFilter
+		device *model.FirewallDevice
+		want   bool
+	}{
+		{"nil filter", nil, fortigate, true},
+		{"profile case-insensitive", &FirewallInstanceFilter{Profiles: []string{"FortiGate"}}, fortigate, true},
+		{"profile mismatch", &FirewallInstanceFilter{Profiles: []string{"paloalto"}}, asa, false},
+		{"pattern by address", &FirewallInstanceFilter{DevicePatterns: []string{"10.0.1.*"}}, fortigate, true},
+		{"pattern by name", &FirewallInstanceFilter{DevicePatterns: []string{"fw-hq-*"}}, fortigate, true},
+		{"pattern mismatch", &FirewallInstanceFilter{DevicePatterns: []string{"10.0.1.*"}}, asa, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.device); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirewallMetricKey(t *testing.T) {
+	tunnel := &model.FirewallMetricDefinition{Field: "vpn_tunnel_status", TunnelLabel: "fgVpnTunEntPhase1Name"}
+	if got := firewallMetricKey(tunnel, map[string]string{"fgVpnTunEntPhase1Name": "to-branch"}); got != "vpn_tunnel_status:to-branch" {
+		t.Errorf("tunnel key = %q, want vpn_tunnel_status:to-branch", got)
+	}
+	if got := firewallMetricKey(tunnel, map[string]string{}); got != "" {
+		t.Errorf("tunnel key without tunnel name = %q, want empty", got)
+	}
+	if got := firewallMetricKey(&model.FirewallMetricDefinition{Field: "cpu_usage"}, nil); got != "cpu_usage" {
+		t.Errorf("field key = %q, want cpu_usage", got)
+	}
+}
+
+// =============================================================================
+// Evaluator Tests
+// =============================================================================
+
+func TestFirewallEvaluator_Healthy(t *testing.T) {
+	e := createTestFirewallEvaluator()
+	result := model.NewFirewallInspectionResult(model.NewFirewallDevice("fortigate", "10.0.1.1"))
+	result.CPUUsage = 30
+	result.HAStatus = 1
+	result.AddTunnel(&model.FirewallTunnel{Name: "to-branch", Up: true})
+
+	eval := e.Evaluate(result)
+	if eval.Status != model.FirewallStatusNormal || len(eval.Alerts) != 0 {
+		t.Errorf("expected normal status without alerts, got %s with %d alerts", eval.Status, len(eval.Alerts))
+	}
+}
+
+func TestFirewallEvaluator_Thresholds(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *model.FirewallInspectionResult)
+		metric string
+		want   model.AlertLevel
+	}{
+		{"cpu warning", func(r *model.FirewallInspectionResult) { r.CPUUsage = 75 }, firewallCPUUsageField, model.AlertLevelWarning},
+		{"memory critical", func(r *model.FirewallInspectionResult) { r.MemoryUsage = 95 }, firewallMemoryUsageField, model.AlertLevelCritical},
+		{"session warning", func(r *model.FirewallInspectionResult) { r.SessionUsage = 72 }, firewallSessionUsageField, model.AlertLevelWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := createTestFirewallEvaluator()
+			result := model.NewFirewallInspectionResult(model.NewFirewallDevice("paloalto", "10.0.3.1"))
+			tt.modify(result)
+
+			eval := e.Evaluate(result)
+			if len(eval.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(eval.Alerts))
+			}
+			if eval.Alerts[0].MetricName != tt.metric || eval.Alerts[0].Level != tt.want {
+				t.Errorf("expected %s %s alert, got %s %s", tt.want, tt.metric, eval.Alerts[0].Level, eval.Alerts[0].MetricName)
+			}
+		})
+	}
+}
+
+func TestFirewallEvaluator_TunnelsDown(t *testing.T) {
+	e := createTestFirewallEvaluator()
+	result := model.NewFirewallInspectionResult(model.NewFirewallDevice("fortigate", "10.0.1.1"))
+	result.AddTunnel(&model.FirewallTunnel{Name: "to-branch-a", Up: false})
+	result.AddTunnel(&model.FirewallTunnel{Name: "to-branch-b", Up: true})
+	result.AddTunnel(&model.FirewallTunnel{Name: "to-dc", Up: false})
+
+	eval := e.Evaluate(result)
+
+	if eval.Status != model.FirewallStatusCritical {
+		t.Errorf("expected critical status, got %s", eval.Status)
+	}
+	if len(eval.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(eval.Alerts))
+	}
+	alert := eval.Alerts[0]
+	if alert.MetricName != firewallVPNTunnelStatusField || alert.CurrentValue != 2 {
+		t.Errorf("unexpected tunnel alert: %+v", alert)
+	}
+	if !strings.Contains(alert.Message, "to-branch-a, to-dc") {
+		t.Errorf("tunnel alert should name the down tunnels, got %q", alert.Message)
+	}
+}
+
+func TestFirewallEvaluator_HAAbnormal(t *testing.T) {
+	e := createTestFirewallEvaluator()
+	result := model.NewFirewallInspectionResult(model.NewFirewallDevice("paloalto", "10.0.3.1"))
+	result.HAStatus = 0
+	result.HAState = "suspended"
+
+	eval := e.Evaluate(result)
+
+	if eval.Status != model.FirewallStatusCritical || len(eval.Alerts) != 1 {
+		t.Fatalf("expected 1 critical alert, got %s with %d alerts", eval.Status, len(eval.Alerts))
+	}
+	alert := eval.Alerts[0]
+	if alert.FormattedValue != "suspended" || !strings.Contains(alert.Message, "HA 状态异常（suspended）") {
+		t.Errorf("unexpected HA alert: %+v", alert)
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// defaultIdentityLabels is the identity resolution chain used when none is configured.
+var defaultIdentityLabels = []string{"ident", "host", "instance"}
+
+// hostResolver maps VictoriaMetrics series to inspected hosts, keeping one identity and one
+// collection source per host.
+// Each identity label in the chain is first matched against the host names; when no label
+// names a known host, the label values are matched against the host IPs, so exporters that
+// only label by IP or instance (e.g. "10.0.0.1:9100") still land on the right host.
+type hostResolver struct {
+	labels []string
+	names  map[string]bool
+	ips    map[string]string // IP -> hostname; "" when several hosts share the IP
+	logger zerolog.Logger
+
+	sources   *sourceSelector // nil: keep the series of every source
+	mu        sync.Mutex      // Protects conflicts and groups, as metrics are matched concurrently
+	conflicts []*model.SourceConflict
+	groups    map[string]string // hostname -> business group (busigroup label of its series)
+}
+
+// hostMatch is a query result resolved to an inspected host.
+type hostMatch struct {
+	Hostname string
+	Result   vm.QueryResult
+}
+
+// newHostResolver creates a resolver for hosts using the identity label chain and source
+// preference of cfg. IPs shared by several hosts are ambiguous and are never used for matching.
+func newHostResolver(cfg config.InspectionConfig, hosts []*model.HostMeta, logger zerolog.Logger) *hostResolver {
+	labels := cfg.IdentityLabels
+	if len(labels) == 0 {
+		labels = defaultIdentityLabels
+	}
+
+	r := &hostResolver{
+		labels:  labels,
+		names:   make(map[string]bool, len(hosts)),
+		ips:     make(map[string]string, len(hosts)),
+		logger:  logger,
+		sources: newSourceSelector(cfg.SourcePreference),
+		groups:  make(map[string]string),
+	}
+
+	for _, host := range hosts {
+		r.names[host.Hostname] = true
+	}
+	for _, host := range hosts {
+		if host.IP == "" || r.names[host.IP] {
+			continue
+		}
+		if other, exists := r.ips[host.IP]; exists && other != host.Hostname {
+			if other != "" {
+				logger.Warn().
+					Str("ip", host.IP).
+					Str("hostname", host.Hostname).
+					Str("other_hostname", other).
+					Msg("hosts share the same IP, IP identity matching disabled for it")
+			}
+			r.ips[host.IP] = ""
+			continue
+		}
+		r.ips[host.IP] = host.Hostname
+	}
+
+	return r
+}
+
+// resolve returns the host a series belongs to, the identity key it was matched by
+// ("label=value") and the priority of that key (lower is preferred).
+// It returns an empty hostname when the series matches no inspected host.
+func (r *hostResolver) resolve(labels map[string]string) (hostname, key string, priority int) {
+	for i, label := range r.labels {
+		value := labels[label]
+		if value == "" {
+			continue
+		}
+		if name := model.CleanIdent(value); r.names[name] {
+			return name, label + "=" + value, i
+		}
+	}
+
+	for i, label := range r.labels {
+		ip := identityIP(labels[label])
+		if ip == "" {
+			continue
+		}
+		if name := r.ips[ip]; name != "" {
+			return name, label + "=" + labels[label], len(r.labels) + i
+		}
+	}
+
+	return "", "", 0
+}
+
+// match resolves results to hosts. When the series of one host were matched under different
+// identity keys (e.g. one exporter labels by ident and another only by instance), only the
+// series of the preferred key are kept and the collision is logged, so the host is not
+// counted twice. With a source preference, only the series of each host's preferred
+// collection source are kept; differing values of other sources are recorded as conflicts
+// per host and expandLabel value.
+func (r *hostResolver) match(metric, expandLabel string, results []vm.QueryResult) []hostMatch {
+	type resolved struct {
+		hostname string
+		key      string
+		priority int
+	}
+
+	resolvedResults := make([]resolved, len(results))
+	bestKeys := make(map[string]resolved)
+	for i, result := range results {
+		hostname, key, priority := r.resolve(result.Labels)
+		resolvedResults[i] = resolved{hostname: hostname, key: key, priority: priority}
+		if hostname == "" {
+			continue
+		}
+		if best, exists := bestKeys[hostname]; !exists || priority < best.priority {
+			bestKeys[hostname] = resolvedResults[i]
+		}
+	}
+
+	matches := make([]hostMatch, 0, len(results))
+	collisions := make(map[string]bool)
+	for i, result := range results {
+		res := resolvedResults[i]
+		if res.hostname == "" {
+			continue
+		}
+		if best := bestKeys[res.hostname]; res.key != best.key {
+			if !collisions[res.key] {
+				collisions[res.key] = true
+				r.logger.Warn().
+					Str("metric", metric).
+					Str("hostname", res.hostname).
+					Str("key", best.key).
+					Str("duplicate_key", res.key).
+					Msg("host identity collision, ignoring series of the duplicate key")
+			}
+			continue
+		}
+		matches = append(matches, hostMatch{Hostname: res.hostname, Result: result})
+	}
+
+	matches, conflicts := r.sources.selectSources(metric, expandLabel, matches)
+	r.recordBusinessGroups(matches)
+	if len(conflicts) > 0 {
+		for _, conflict := range conflicts {
+			r.logger.Warn().
+				Str("hostname", conflict.Hostname).
+				Str("metric", conflict.Metric).
+				Str("source", conflict.Source).
+				Interface("values", conflict.Values).
+				Msg("collection sources report different values, using preferred source")
+		}
+		r.mu.Lock()
+		r.conflicts = append(r.conflicts, conflicts...)
+		r.mu.Unlock()
+	}
+
+	return matches
+}
+
+// recordBusinessGroups records the business group of the matched hosts, taken from the first
+// series carrying one (queries aggregating the label away leave it unset).
+func (r *hostResolver) recordBusinessGroups(matches []hostMatch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range matches {
+		if group := m.Result.Labels[model.BusinessGroupLabel]; group != "" && r.groups[m.Hostname] == "" {
+			r.groups[m.Hostname] = group
+		}
+	}
+}
+
+// businessGroup returns the business group recorded for hostname ("" when unknown).
+func (r *hostResolver) businessGroup(hostname string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.groups[hostname]
+}
+
+// sourceConflicts returns the source conflicts recorded so far, ordered by host and metric.
+func (r *hostResolver) sourceConflicts() []*model.SourceConflict {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conflicts := append([]*model.SourceConflict(nil), r.conflicts...)
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Hostname != conflicts[j].Hostname {
+			return conflicts[i].Hostname < conflicts[j].Hostname
+		}
+		return conflicts[i].Metric < conflicts[j].Metric
+	})
+	return conflicts
+}
+
+// identityIP extracts the IP address from an identity label value such as "10.0.0.1",
+// "10.0.0.1:9100" or "hostname@10.0.0.1". It returns "" when the value holds no IP.
+func identityIP(value string) string {
+	if idx := strings.LastIndex(value, "@"); idx >= 0 {
+		value = value[idx+1:]
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	if net.ParseIP(value) == nil {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+func TestHostResolver_Resolve(t *testing.T) {
+	hosts := []*model.HostMeta{
+		{Hostname: "host-1", IP: "10.0.0.1"},
+		{Hostname: "host-2", IP: "10.0.0.2"},
+		{Hostname: "host-3", IP: "10.0.0.2"}, // shares its IP with host-2
+	}
+	r := newHostResolver(config.InspectionConfig{}, hosts, zerolog.Nop())
+
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected string
+		key      string
+	}{
+		{"ident", map[string]string{"ident": "host-1"}, "host-1", "ident=host-1"},
+		{"ident with IP suffix", map[string]string{"ident": "host-1@10.0.0.1"}, "host-1", "ident=host-1@10.0.0.1"},
+		{"unknown ident falls back to host label", map[string]string{"ident": "other", "host": "host-2"}, "host-2", "host=host-2"},
+		{"instance IP with port", map[string]string{"instance": "10.0.0.1:9100"}, "host-1", "instance=10.0.0.1:9100"},
+		{"host name preferred over IP", map[string]string{"instance": "10.0.0.1:9100", "host": "host-2"}, "host-2", "host=host-2"},
+		{"shared IP is ambiguous", map[string]string{"instance": "10.0.0.2:9100"}, "", ""},
+		{"unknown host", map[string]string{"instance": "10.9.9.9:9100"}, "", ""},
+		{"no labels", map[string]string{}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostname, key, _ := r.resolve(tt.labels)
+			if hostname != tt.expected || key != tt.key {
+				t.Errorf("resolve() = (%q, %q), want (%q, %q)", hostname, key, tt.expected, tt.key)
+			}
+		})
+	}
+}
+
+func TestHostResolver_CustomLabels(t *testing.T) {
+	r := newHostResolver(config.InspectionConfig{IdentityLabels: []string{"node"}}, []*model.HostMeta{{Hostname: "host-1", IP: "10.0.0.1"}}, zerolog.Nop())
+
+	if hostname, _, _ := r.resolve(map[string]string{"ident": "host-1"}); hostname != "" {
+		t.Errorf("labels outside the chain should be ignored, got %q", hostname)
+	}
+	if hostname, _, _ := r.resolve(map[string]string{"node": "10.0.0.1"}); hostname != "host-1" {
+		t.Errorf("resolve(node=10.0.0.1) = %q, want host-1", hostname)
+	}
+}
+
+func TestHostResolver_MatchCollision(t *testing.T) {
+	r := newHostResolver(config.InspectionConfig{}, []*model.HostMeta{{Hostname: "host-1", IP: "10.0.0.1"}}, zerolog.Nop())
+
+	results := []vm.QueryResult{
+		{Value: 30, Labels: map[string]string{"instance": "10.0.0.1:9100", "path": "/"}},
+		{Value: 40, Labels: map[string]string{"ident": "host-1", "path": "/"}},
+		{Value: 50, Labels: map[string]string{"ident": "host-1", "path": "/data"}},
+		{Value: 60, Labels: map[string]string{"ident": "unknown"}},
+	}
+
+	matches := r.match("disk_usage", "path", results)
+	if len(matches) != 2 {
+		t.Fatalf("match() returned %d matches, want 2: %+v", len(matches), matches)
+	}
+	for _, m := range matches {
+		if m.Hostname != "host-1" || m.Result.Labels["ident"] != "host-1" {
+			t.Errorf("unexpected match %+v, the ident series should win over the instance series", m)
+		}
+	}
+}
+
+func TestHostResolver_BusinessGroups(t *testing.T) {
+	r := newHostResolver(config.InspectionConfig{}, []*model.HostMeta{{Hostname: "host-1"}, {Hostname: "host-2"}}, zerolog.Nop())
+
+	r.match("cpu_usage", "", []vm.QueryResult{
+		{Value: 30, Labels: map[string]string{"ident": "host-1"}},
+		{Value: 40, Labels: map[string]string{"ident": "host-2", "busigroup": "payment"}},
+	})
+	r.match("memory_usage", "", []vm.QueryResult{
+		{Value: 50, Labels: map[string]string{"ident": "host-1", "busigroup": "order"}},
+		{Value: 60, Labels: map[string]string{"ident": "host-2", "busigroup": "other"}},
+	})
+
+	if got := r.businessGroup("host-1"); got != "order" {
+		t.Errorf("businessGroup(host-1) = %q, want order", got)
+	}
+	if got := r.businessGroup("host-2"); got != "payment" {
+		t.Errorf("businessGroup(host-2) = %q, want the first recorded group payment", got)
+	}
+	if got := r.businessGroup("unknown"); got != "" {
+		t.Errorf("businessGroup(unknown) = %q, want empty", got)
+	}
+}
+
+func TestIdentityIP(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.1":        "10.0.0.1",
+		"10.0.0.1:9100":   "10.0.0.1",
+		"host-1@10.0.0.1": "10.0.0.1",
+		"[::1]:9100":      "::1",
+		"host-1":          "",
+		"host-1:9100":     "",
+		"":                "",
+	}
+	for value, expected := range tests {
+		if got := identityIP(value); got != expected {
+			t.Errorf("identityIP(%q) = %q, want %q", value, got, expected)
+		}
+	}
+}
@@ -110,6 +110,14 @@ func (i *Inspector) Run(ctx context.Context) (*model.InspectionResult, error) {
 	// Step 3: Build inspection result by merging collection and evaluation results
 	i.logger.Debug().Msg("step 3: building inspection result")
 	i.buildInspectionResult(result, collectionResult, evalResult)
+	result.SourceConflicts = collectionResult.SourceConflicts
+	for _, alert := range collectionResult.ActiveAlerts {
+		if !alert.TriggerTime.IsZero() {
+			alert.TriggerTime = alert.TriggerTime.In(i.timezone)
+		}
+	}
+	model.CorrelateActiveAlerts(collectionResult.ActiveAlerts, result.Alerts)
+	result.ActiveAlerts = collectionResult.ActiveAlerts
 
 	// Step 4: Finalize result (calculate summaries)
 	endTime := time.Now().In(i.timezone)
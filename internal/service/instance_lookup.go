@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/n9e"
+)
+
+// hostnameLabels are the series labels naming the host that reported a metric, in order of
+// preference: the Categraf agent hostname, the N9E ident, then the plain host label.
+var hostnameLabels = []string{"agent_hostname", "ident", "host"}
+
+// labelHostname returns the hostname of a series from its labels, "" when none is set.
+// The collectors of host-based modules (Nginx, Tomcat, Cassandra, storage, LVS, Windows,
+// AD) identify their instances by it.
+func labelHostname(labels map[string]string) string {
+	for _, key := range hostnameLabels {
+		if val := labels[key]; val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// n9eHostIP returns the IP address N9E registered for hostname.
+// Returns "N/A" when the client is not configured, the lookup fails or the host has no IP.
+func n9eHostIP(ctx context.Context, client *n9e.Client, hostname string, logger zerolog.Logger) string {
+	if client == nil {
+		return "N/A"
+	}
+
+	hostMeta, err := client.GetHostMetaByIdent(ctx, hostname)
+	if err != nil {
+		logger.Debug().
+			Err(err).
+			Str("hostname", hostname).
+			Msg("failed to get host meta from N9E")
+		return "N/A"
+	}
+
+	if hostMeta == nil || hostMeta.IP == "" {
+		return "N/A"
+	}
+
+	return hostMeta.IP
+}
+
+// matchAnyPattern reports whether value matches at least one of patterns.
+// No pattern matches every value, so an unset filter keeps all instances.
+func matchAnyPattern(value string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if matchPattern(value, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchPattern checks if a value matches a pattern with wildcard support.
+// Supports wildcard '*' which matches any sequence of characters.
+// Examples:
+//   - "GX-NM-*" matches "GX-NM-MNS-NGX-01"
+//   - "*-NGX-*" matches "GX-NM-MNS-NGX-01"
+//   - "*" matches all values
+func matchPattern(value, pattern string) bool {
+	// Exact match optimization
+	if value == pattern {
+		return true
+	}
+
+	// No wildcard
+	if !strings.Contains(pattern, "*") {
+		return false
+	}
+
+	// Convert to regex
+	regexPattern := regexp.QuoteMeta(pattern)
+	regexPattern = strings.ReplaceAll(regexPattern, "\\*", ".*")
+	regexPattern = "^" + regexPattern + "$"
+
+	re, err := regexp.Compile(regexPattern)
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(value)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// fakeSeries is a series answered by the fake VictoriaMetrics server.
+type fakeSeries struct {
+	labels map[string]string
+	value  float64
+}
+
+// newFakeVMServer returns a VictoriaMetrics server answering each instant query with the
+// series registered for it, and an empty result for other queries. Queries are matched as
+// sent, so collectors under test must not add host filters.
+func newFakeVMServer(t *testing.T, series map[string][]fakeSeries) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+
+		result := make([]map[string]interface{}, 0)
+		for _, s := range series[r.FormValue("query")] {
+			result = append(result, map[string]interface{}{
+				"metric": s.labels,
+				"value":  []interface{}{time.Now().Unix(), strconv.FormatFloat(s.value, 'f', -1, 64)},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data":   map[string]interface{}{"resultType": "vector", "result": result},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newFakeN9EServer returns an N9E server registering the given hostname -> IP addresses;
+// other hosts are unknown.
+func newFakeN9EServer(t *testing.T, ips map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ident := strings.TrimPrefix(r.URL.Path, "/api/n9e/target/")
+		ip, ok := ips[ident]
+		if !ok || ident == r.URL.Path {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"dat": map[string]interface{}{"ident": ident, "host_ip": ip},
+			"err": "",
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newFakeVMClient returns a VictoriaMetrics client of a fake server, without retries.
+func newFakeVMClient(serverURL string) *vm.Client {
+	return vm.NewClient(&config.VictoriaMetricsConfig{Endpoint: serverURL}, &config.RetryConfig{MaxRetries: 0}, zerolog.Nop())
+}
+
+// newFakeN9EClient returns an N9E client of a fake server, without retries.
+func newFakeN9EClient(serverURL string) *n9e.Client {
+	return n9e.NewClient(&config.N9EConfig{Endpoint: serverURL, Token: "test-token"}, &config.RetryConfig{MaxRetries: 0}, zerolog.Nop())
+}
+
+// =============================================================================
+// Shared Instance Lookup Tests
+// =============================================================================
+
+func TestLabelHostname(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{"agent hostname first", map[string]string{"agent_hostname": "a", "ident": "b", "host": "c"}, "a"},
+		{"ident fallback", map[string]string{"ident": "b", "host": "c"}, "b"},
+		{"host fallback", map[string]string{"host": "c"}, "c"},
+		{"empty values skipped", map[string]string{"agent_hostname": "", "ident": "b"}, "b"},
+		{"none", map[string]string{"instance": "10.0.0.1:9100"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labelHostname(tt.labels); got != tt.want {
+				t.Errorf("labelHostname(%v) = %q, want %q", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAnyPattern(t *testing.T) {
+	tests := []struct {
+		value    string
+		patterns []string
+		want     bool
+	}{
+		{"GX-DC-01", nil, true},
+		{"GX-DC-01", []string{"GX-DC-*"}, true},
+		{"GX-DC-01", []string{"SH-*", "*-01"}, true},
+		{"GX-DC-01", []string{"SH-*"}, false},
+	}
+	for _, tt := range tests {
+		if got := matchAnyPattern(tt.value, tt.patterns); got != tt.want {
+			t.Errorf("matchAnyPattern(%q, %v) = %v, want %v", tt.value, tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestN9EHostIP(t *testing.T) {
+	server := newFakeN9EServer(t, map[string]string{"host-1": "10.0.0.1", "host-no-ip": ""})
+	client := newFakeN9EClient(server.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		client   *n9e.Client
+		hostname string
+		want     string
+	}{
+		{"registered host", client, "host-1", "10.0.0.1"},
+		{"host without IP", client, "host-no-ip", "N/A"},
+		{"unknown host", client, "host-2", "N/A"},
+		{"no client", nil, "host-1", "N/A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n9eHostIP(ctx, tt.client, tt.hostname, zerolog.Nop()); got != tt.want {
+				t.Errorf("n9eHostIP(%q) = %q, want %q", tt.hostname, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/logs"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// logCheckUnknownGroup is used when a grouped count has no value for the group field.
+const logCheckUnknownGroup = "未知"
+
+// LogCounter counts log entries matching a query on a log backend.
+// It is implemented by logs.Client and can be mocked in tests.
+type LogCounter interface {
+	QueryCounts(ctx context.Context, query, groupBy string, window time.Duration, end time.Time) ([]logs.LogCount, error)
+}
+
+// =============================================================================
+// Log Check Collector
+// =============================================================================
+
+// LogCheckCollector runs log-based checks (LogQL on Loki, LogsQL on VictoriaLogs)
+// and counts the matching entries over the configured window. Grouped checks
+// produce one result per group value (e.g. per service).
+type LogCheckCollector struct {
+	client LogCounter
+	config *config.LogChecksInspectionConfig
+	checks []*model.LogCheckDefinition
+	logger zerolog.Logger
+}
+
+// NewLogCheckCollector creates a new LogCheckCollector instance.
+func NewLogCheckCollector(
+	cfg *config.LogChecksInspectionConfig,
+	client LogCounter,
+	checks []*model.LogCheckDefinition,
+	logger zerolog.Logger,
+) *LogCheckCollector {
+	return &LogCheckCollector{
+		client: client,
+		config: cfg,
+		checks: checks,
+		logger: logger.With().Str("component", "log-check-collector").Logger(),
+	}
+}
+
+// GetChecks returns the log check definitions.
+func (c *LogCheckCollector) GetChecks() []*model.LogCheckDefinition {
+	return c.checks
+}
+
+// GetWindow returns the counting window, defaulting to one hour.
+func (c *LogCheckCollector) GetWindow() time.Duration {
+	if c.config == nil || c.config.Window <= 0 {
+		return time.Hour
+	}
+	return c.config.Window
+}
+
+// Collect runs all active checks concurrently over the window ending at end.
+// Pending checks are skipped. A failed query yields a failed result for the
+// check instead of aborting the collection.
+func (c *LogCheckCollector) Collect(
+	ctx context.Context,
+	end time.Time,
+) (map[string]*model.LogCheckInspectionResult, error) {
+	window := c.GetWindow()
+	resultsMap := make(map[string]*model.LogCheckInspectionResult)
+
+	var activeChecks []*model.LogCheckDefinition
+	for _, check := range c.checks {
+		if check.IsPending() {
+			c.logger.Debug().Str("check", check.Name).Msg("skipping pending log check")
+			continue
+		}
+		activeChecks = append(activeChecks, check)
+	}
+
+	if len(activeChecks) == 0 {
+		c.logger.Warn().Msg("no active log checks to run")
+		return resultsMap, nil
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 5 // Log backends are more expensive to query than VictoriaMetrics
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(0, len(activeChecks)) // The hosts and services are known from the results
+	for _, check := range activeChecks {
+		check := check // Capture loop variable
+		g.Go(func() error {
+			results := c.collectCheck(ctx, check, window, end)
+			progress.QueryDone()
+			mu.Lock()
+			for _, r := range results {
+				resultsMap[r.GetIdentifier()] = r
+			}
+			mu.Unlock()
+			return nil // Single check failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent log check collection failed: %w", err)
+	}
+
+	c.logger.Info().
+		Int("active_checks", len(activeChecks)).
+		Int("results", len(resultsMap)).
+		Dur("window", window).
+		Msg("log checks collection completed")
+
+	return resultsMap, nil
+}
+
+// collectCheck runs a single check and converts its counts into results.
+func (c *LogCheckCollector) collectCheck(
+	ctx context.Context,
+	check *model.LogCheckDefinition,
+	window time.Duration,
+	end time.Time,
+) []*model.LogCheckInspectionResult {
+	collectedAt := time.Now()
+
+	counts, err := c.client.QueryCounts(ctx, check.Query, check.GroupBy, window, end)
+	if err != nil {
+		c.logger.Warn().
+			Err(err).
+			Str("check", check.Name).
+			Msg("failed to run log check, continuing with others")
+
+		result := c.newResult(check, "")
+		result.Error = fmt.Sprintf("日志查询失败: %v", err)
+		result.Status = model.LogCheckStatusFailed
+		result.CollectedAt = collectedAt
+		return []*model.LogCheckInspectionResult{result}
+	}
+
+	// An ungrouped check with no matches returns an empty vector
+	if check.GroupBy == "" {
+		result := c.newResult(check, "")
+		result.Count = 0
+		for _, count := range counts {
+			result.Count += count.Count
+		}
+		result.CollectedAt = collectedAt
+		return []*model.LogCheckInspectionResult{result}
+	}
+
+	results := make([]*model.LogCheckInspectionResult, 0, len(counts))
+	for _, count := range counts {
+		group := count.Labels[check.GroupBy]
+		if group == "" {
+			group = logCheckUnknownGroup
+		}
+		result := c.newResult(check, group)
+		result.Count = count.Count
+		result.CollectedAt = collectedAt
+		results = append(results, result)
+	}
+
+	c.logger.Debug().
+		Str("check", check.Name).
+		Int("group_count", len(results)).
+		Msg("log check collected")
+
+	return results
+}
+
+// newResult creates an inspection result for a check (or one group of it) with its thresholds.
+func (c *LogCheckCollector) newResult(check *model.LogCheckDefinition, group string) *model.LogCheckInspectionResult {
+	instance := model.NewLogCheckInstance(check.Name, check.GetDisplayName(), group)
+	instance.SetThresholds(check.Warning, check.Critical)
+	return model.NewLogCheckInspectionResult(instance)
+}
@@ -0,0 +1,154 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Log Check Evaluator
+// =============================================================================
+
+// LogCheckEvaluationResult represents the evaluation result for a single log check (group).
+type LogCheckEvaluationResult struct {
+	Identifier string                 `json:"identifier"` // 检查项标识符
+	Status     model.LogCheckStatus   `json:"status"`     // 检查项状态
+	Alerts     []*model.LogCheckAlert `json:"alerts"`     // 告警列表
+}
+
+// LogCheckEvaluator evaluates log check counts against the thresholds of each check.
+// Unlike the metric-based modules, thresholds come from the check definitions rather
+// than from the configuration file, since every check matches a different pattern.
+type LogCheckEvaluator struct {
+	window   time.Duration  // 统计时间窗口（用于告警消息）
+	timezone *time.Location // 时区
+	logger   zerolog.Logger // 日志器
+}
+
+// NewLogCheckEvaluator creates a new LogCheckEvaluator.
+func NewLogCheckEvaluator(
+	window time.Duration,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *LogCheckEvaluator {
+	return &LogCheckEvaluator{
+		window:   window,
+		timezone: timezone,
+		logger:   logger.With().Str("component", "log_check_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all log check results and returns the complete evaluation results.
+func (e *LogCheckEvaluator) EvaluateAll(
+	results map[string]*model.LogCheckInspectionResult,
+) []*LogCheckEvaluationResult {
+	evalResults := make([]*LogCheckEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("log check evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single log check result against its thresholds.
+// A threshold of 0 disables that level.
+func (e *LogCheckEvaluator) Evaluate(
+	result *model.LogCheckInspectionResult,
+) *LogCheckEvaluationResult {
+	evalResult := &LogCheckEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.LogCheckStatusNormal,
+		Alerts:     make([]*model.LogCheckAlert, 0),
+	}
+
+	// Skip failed checks
+	if result.Error != "" || !result.HasCount() {
+		evalResult.Status = model.LogCheckStatusFailed
+		result.Status = evalResult.Status
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed log check")
+		return evalResult
+	}
+
+	instance := result.Instance
+	switch {
+	case instance.Critical > 0 && result.Count >= instance.Critical:
+		evalResult.Alerts = append(evalResult.Alerts, e.createAlert(result, model.AlertLevelCritical))
+		evalResult.Status = model.LogCheckStatusCritical
+	case instance.Warning > 0 && result.Count >= instance.Warning:
+		evalResult.Alerts = append(evalResult.Alerts, e.createAlert(result, model.AlertLevelWarning))
+		evalResult.Status = model.LogCheckStatusWarning
+	}
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Float64("count", result.Count).
+		Msg("log check evaluation completed")
+
+	return evalResult
+}
+
+// createAlert creates a LogCheckAlert with formatted message.
+func (e *LogCheckEvaluator) createAlert(
+	result *model.LogCheckInspectionResult,
+	level model.AlertLevel,
+) *model.LogCheckAlert {
+	instance := result.Instance
+	alert := model.NewLogCheckAlert(instance.Identifier, instance.CheckName, result.Count, level)
+	alert.MetricDisplayName = instance.DisplayName
+	alert.FormattedValue = fmt.Sprintf("%.0f", result.Count)
+	alert.WarningThreshold = instance.Warning
+	alert.CriticalThreshold = instance.Critical
+	alert.Message = e.generateAlertMessage(result, level)
+	return alert
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *LogCheckEvaluator) generateAlertMessage(
+	result *model.LogCheckInspectionResult,
+	level model.AlertLevel,
+) string {
+	instance := result.Instance
+	subject := instance.DisplayName
+	if instance.Group != "" {
+		subject = fmt.Sprintf("%s [%s]", instance.DisplayName, instance.Group)
+	}
+
+	if level == model.AlertLevelCritical {
+		return fmt.Sprintf("近 %s %s匹配日志 %.0f 条，已超过严重阈值 %.0f",
+			formatLogCheckWindow(e.window), subject, result.Count, instance.Critical)
+	}
+	return fmt.Sprintf("近 %s %s匹配日志 %.0f 条，已超过警告阈值 %.0f",
+		formatLogCheckWindow(e.window), subject, result.Count, instance.Warning)
+}
+
+// formatLogCheckWindow formats the counting window in Chinese, e.g. "1 小时" or "30 分钟".
+func formatLogCheckWindow(window time.Duration) string {
+	switch {
+	case window <= 0:
+		return "1 小时"
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%d 小时", int64(window/time.Hour))
+	case window%time.Minute == 0:
+		return fmt.Sprintf("%d 分钟", int64(window/time.Minute))
+	default:
+		return window.String()
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/logs"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// mockLogCounter returns canned counts per query.
+type mockLogCounter struct {
+	counts map[string][]logs.LogCount
+	errs   map[string]error
+}
+
+func (m *mockLogCounter) QueryCounts(ctx context.Context, query, groupBy string, window time.Duration, end time.Time) ([]logs.LogCount, error) {
+	if err := m.errs[query]; err != nil {
+		return nil, err
+	}
+	return m.counts[query], nil
+}
+
+// createTestLogCheckResult creates a log check result with the given count and thresholds.
+func createTestLogCheckResult(group string, count, warning, critical float64) *model.LogCheckInspectionResult {
+	instance := model.NewLogCheckInstance("error_logs", "错误日志数", group)
+	instance.SetThresholds(warning, critical)
+	result := model.NewLogCheckInspectionResult(instance)
+	result.Count = count
+	return result
+}
+
+// =============================================================================
+// Collector Tests
+// =============================================================================
+
+func TestLogCheckCollector_Collect(t *testing.T) {
+	client := &mockLogCounter{
+		counts: map[string][]logs.LogCount{
+			`{env="prod"} |= "ERROR"`: {
+				{Labels: map[string]string{"app": "order"}, Count: 120},
+				{Labels: map[string]string{"app": "user"}, Count: 3},
+			},
+		},
+		errs: map[string]error{
+			`{env="prod"} |= "timeout"`: errors.New("backend unavailable"),
+		},
+	}
+	checks := []*model.LogCheckDefinition{
+		{Name: "error_logs", DisplayName: "错误日志数", Query: `{env="prod"} |= "ERROR"`, GroupBy: "app", Warning: 10, Critical: 100},
+		{Name: "oom_errors", DisplayName: "内存溢出", Query: `{env="prod"} |= "OutOfMemoryError"`, Critical: 1},
+		{Name: "timeouts", DisplayName: "超时", Query: `{env="prod"} |= "timeout"`, Warning: 1},
+		{Name: "slow_sql", DisplayName: "慢 SQL", Status: "pending"},
+	}
+	collector := NewLogCheckCollector(&config.LogChecksInspectionConfig{Window: time.Hour}, client, checks, zerolog.Nop())
+
+	results, err := collector.Collect(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results (2 groups, 1 ungrouped, 1 failed), got %d", len(results))
+	}
+	if r := results["error_logs/order"]; r == nil || r.Count != 120 || r.Instance.Critical != 100 {
+		t.Errorf("unexpected grouped result: %+v", r)
+	}
+	if r := results["oom_errors"]; r == nil || r.Count != 0 {
+		t.Errorf("expected ungrouped check without matches to count 0, got %+v", r)
+	}
+	if r := results["timeouts"]; r == nil || r.Status != model.LogCheckStatusFailed || r.Error == "" {
+		t.Errorf("expected failed result for query error, got %+v", r)
+	}
+	if _, ok := results["slow_sql"]; ok {
+		t.Error("pending check should be skipped")
+	}
+}
+
+// =============================================================================
+// Evaluator Tests
+// =============================================================================
+
+func TestLogCheckEvaluator_Evaluate(t *testing.T) {
+	tests := []struct {
+		name           string
+		count          float64
+		warning        float64
+		critical       float64
+		expectedStatus model.LogCheckStatus
+		expectedLevel  model.AlertLevel
+	}{
+		{"below warning", 5, 10, 100, model.LogCheckStatusNormal, ""},
+		{"warning", 10, 10, 100, model.LogCheckStatusWarning, model.AlertLevelWarning},
+		{"critical", 150, 10, 100, model.LogCheckStatusCritical, model.AlertLevelCritical},
+		{"critical only", 1, 0, 1, model.LogCheckStatusCritical, model.AlertLevelCritical},
+		{"thresholds disabled", 10000, 0, 0, model.LogCheckStatusNormal, ""},
+		{"not collected", -1, 10, 100, model.LogCheckStatusFailed, ""},
+	}
+
+	evaluator := NewLogCheckEvaluator(30*time.Minute, nil, zerolog.Nop())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestLogCheckResult("order", tt.count, tt.warning, tt.critical)
+
+			evalResult := evaluator.Evaluate(result)
+
+			if evalResult.Status != tt.expectedStatus {
+				t.Errorf("expected status %s, got %s", tt.expectedStatus, evalResult.Status)
+			}
+			if tt.expectedLevel == "" {
+				if len(evalResult.Alerts) != 0 {
+					t.Errorf("expected no alert, got %d", len(evalResult.Alerts))
+				}
+				return
+			}
+			if len(evalResult.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(evalResult.Alerts))
+			}
+			alert := evalResult.Alerts[0]
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+			if alert.Identifier != "error_logs/order" || alert.MetricName != "error_logs" {
+				t.Errorf("unexpected alert identity: %s %s", alert.Identifier, alert.MetricName)
+			}
+			if !strings.Contains(alert.Message, "[order]") || !strings.Contains(alert.Message, "30 分钟") {
+				t.Errorf("expected message to mention group and window, got %q", alert.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// LogCheckInspector orchestrates the complete log checks inspection workflow, coordinating
+// log queries, threshold evaluation, and result aggregation.
+type LogCheckInspector struct {
+	collector *LogCheckCollector
+	evaluator *LogCheckEvaluator
+	config    *config.Config
+	timezone  *time.Location
+	version   string
+	logger    zerolog.Logger
+}
+
+// LogCheckInspectorOption is a functional option for configuring a LogCheckInspector.
+type LogCheckInspectorOption func(*LogCheckInspector)
+
+// NewLogCheckInspector creates a new LogCheckInspector with the given dependencies.
+//
+// Parameters:
+//   - cfg: Complete configuration including log checks inspection config
+//   - collector: Log check collector
+//   - evaluator: Threshold evaluator
+//   - logger: Structured logger
+//   - opts: Optional configuration via functional options
+//
+// Returns:
+//   - *LogCheckInspector: Configured inspector instance
+//   - error: Timezone loading error or validation failure
+func NewLogCheckInspector(
+	cfg *config.Config,
+	collector *LogCheckCollector,
+	evaluator *LogCheckEvaluator,
+	logger zerolog.Logger,
+	opts ...LogCheckInspectorOption,
+) (*LogCheckInspector, error) {
+	// Validate required parameters
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if collector == nil {
+		return nil, fmt.Errorf("collector cannot be nil")
+	}
+	if evaluator == nil {
+		return nil, fmt.Errorf("evaluator cannot be nil")
+	}
+
+	// Determine timezone (from config or use default)
+	tzName := defaultTimezone
+	if cfg.Report.Timezone != "" {
+		tzName = cfg.Report.Timezone
+	}
+
+	// Load timezone
+	loc, err := time.LoadLocation(tzName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s: %w", tzName, err)
+	}
+
+	i := &LogCheckInspector{
+		collector: collector,
+		evaluator: evaluator,
+		config:    cfg,
+		timezone:  loc,
+		version:   "dev",
+		logger:    logger.With().Str("component", "log_check_inspector").Logger(),
+	}
+
+	// Apply functional options
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
+}
+
+// WithLogCheckVersion sets the tool version to include in the inspection result.
+func WithLogCheckVersion(version string) LogCheckInspectorOption {
+	return func(i *LogCheckInspector) {
+		i.version = version
+	}
+}
+
+// GetTimezone returns the configured timezone.
+func (i *LogCheckInspector) GetTimezone() *time.Location {
+	return i.timezone
+}
+
+// GetVersion returns the configured version.
+func (i *LogCheckInspector) GetVersion() string {
+	return i.version
+}
+
+// Inspect executes the complete log checks inspection workflow:
+// 1. Runs all active log checks over the configured window
+// 2. Evaluates the matched counts against each check's thresholds
+// 3. Aggregates results into LogCheckInspectionResults
+//
+// Returns:
+//   - *model.LogCheckInspectionResults: Complete inspection result with summary
+//   - error: Fatal errors that prevent inspection
+func (i *LogCheckInspector) Inspect(ctx context.Context) (*model.LogCheckInspectionResults, error) {
+	// Step 1: Record start time (Asia/Shanghai)
+	startTime := time.Now().In(i.timezone)
+	i.logger.Info().
+		Time("start_time", startTime).
+		Str("timezone", i.timezone.String()).
+		Dur("window", i.collector.GetWindow()).
+		Msg("starting log checks inspection")
+
+	// Step 2: Create result container
+	result := model.NewLogCheckInspectionResults(startTime)
+	result.Version = i.version
+
+	// Step 3: Run log checks
+	i.logger.Debug().
+		Int("check_count", len(i.collector.GetChecks())).
+		Msg("step 1: running log checks")
+
+	resultsMap, err := i.collector.Collect(ctx, startTime)
+	if err != nil {
+		i.logger.Error().Err(err).Msg("log checks collection failed")
+		return nil, fmt.Errorf("log checks collection failed: %w", err)
+	}
+
+	// Step 4: Evaluate thresholds
+	i.logger.Debug().
+		Int("results_count", len(resultsMap)).
+		Msg("step 2: evaluating thresholds")
+
+	_ = i.evaluator.EvaluateAll(resultsMap)
+
+	// Step 5: Build results
+	i.logger.Debug().Msg("step 3: building inspection results")
+	i.buildInspectionResults(result, resultsMap)
+
+	// Step 6: Finalize (calculate Duration, Summary, AlertSummary)
+	endTime := time.Now().In(i.timezone)
+	result.Finalize(endTime)
+
+	i.logger.Info().
+		Int("total_instances", result.Summary.TotalInstances).
+		Int("normal_instances", result.Summary.NormalInstances).
+		Int("warning_instances", result.Summary.WarningInstances).
+		Int("critical_instances", result.Summary.CriticalInstances).
+		Int("failed_instances", result.Summary.FailedInstances).
+		Int("matched_entries", result.Summary.MatchedEntries).
+		Int("total_alerts", result.AlertSummary.TotalAlerts).
+		Dur("duration", result.Duration).
+		Msg("log checks inspection completed")
+
+	// Step 7: Log critical alerts if any
+	if result.HasCritical() {
+		i.logger.Warn().
+			Int("critical_count", result.Summary.CriticalInstances).
+			Int("critical_alerts", result.AlertSummary.CriticalCount).
+			Msg("log checks inspection found critical issues")
+	}
+
+	return result, nil
+}
+
+// buildInspectionResults merges collection results into LogCheckInspectionResults.
+func (i *LogCheckInspector) buildInspectionResults(
+	result *model.LogCheckInspectionResults,
+	resultsMap map[string]*model.LogCheckInspectionResult,
+) {
+	// Iterate through all check results in identifier order, so that groups of a check stay together
+	identifiers := make([]string, 0, len(resultsMap))
+	for identifier := range resultsMap {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+
+	for _, identifier := range identifiers {
+		inspResult := resultsMap[identifier]
+		if inspResult == nil {
+			continue
+		}
+
+		// Convert timestamp to configured timezone
+		inspResult.CollectedAt = inspResult.CollectedAt.In(i.timezone)
+
+		// Add to result container (automatically aggregates alerts)
+		result.AddResult(inspResult)
+	}
+
+	i.logger.Debug().
+		Int("total_results", len(result.Results)).
+		Int("total_alerts", len(result.Alerts)).
+		Msg("inspection results merged")
+}
+
+// IsEnabled returns true if log checks inspection is enabled in the configuration.
+func (i *LogCheckInspector) IsEnabled() bool {
+	return i.config != nil && i.config.LogChecks.Enabled
+}
+
+// GetConfig returns the log checks inspection configuration.
+func (i *LogCheckInspector) GetConfig() *config.LogChecksInspectionConfig {
+	if i.config == nil {
+		return nil
+	}
+	return &i.config.LogChecks
+}
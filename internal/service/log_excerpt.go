@@ -0,0 +1,92 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/logs"
+	"inspection-tool/internal/config"
+)
+
+// LogQuerier queries recent log lines from a log backend.
+// It is implemented by logs.Client and can be mocked in tests.
+type LogQuerier interface {
+	QueryLines(ctx context.Context, query string, limit int, start, end time.Time) ([]logs.LogLine, error)
+}
+
+// LogQueryVars holds the instance fields available to log excerpt query templates.
+type LogQueryVars struct {
+	Hostname  string
+	IP        string
+	Port      int
+	Container string
+	LogPath   string
+}
+
+// LogExcerptFetcher fetches the last log lines of an instance so that critical
+// alerts carry the relevant log context without logging in to the host.
+type LogExcerptFetcher struct {
+	client LogQuerier
+	logger zerolog.Logger
+}
+
+// NewLogExcerptFetcher creates a new LogExcerptFetcher backed by the given log client.
+func NewLogExcerptFetcher(client LogQuerier, logger zerolog.Logger) *LogExcerptFetcher {
+	return &LogExcerptFetcher{
+		client: client,
+		logger: logger.With().Str("component", "log_excerpt").Logger(),
+	}
+}
+
+// Fetch renders the excerpt query for the instance and returns up to cfg.Lines
+// formatted log lines from the last cfg.Lookback, oldest first.
+func (f *LogExcerptFetcher) Fetch(ctx context.Context, cfg *config.LogExcerptConfig, vars LogQueryVars) ([]string, error) {
+	query, err := renderLogQuery(cfg.Query, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := cfg.Lookback
+	if lookback <= 0 {
+		lookback = time.Hour
+	}
+	end := time.Now()
+
+	lines, err := f.client.QueryLines(ctx, query, cfg.Lines, end.Add(-lookback), end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs for %s: %w", vars.Hostname, err)
+	}
+
+	excerpt := make([]string, 0, len(lines))
+	for _, line := range lines {
+		excerpt = append(excerpt, line.String())
+	}
+
+	f.logger.Debug().
+		Str("hostname", vars.Hostname).
+		Str("query", query).
+		Int("line_count", len(excerpt)).
+		Msg("log excerpt fetched")
+
+	return excerpt, nil
+}
+
+// renderLogQuery renders a log query template with the instance fields.
+func renderLogQuery(queryTemplate string, vars LogQueryVars) (string, error) {
+	tmpl, err := template.New("log_query").Option("missingkey=error").Parse(queryTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid log query template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render log query: %w", err)
+	}
+	return buf.String(), nil
+}
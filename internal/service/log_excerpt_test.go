@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"inspection-tool/internal/client/logs"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// mockLogQuerier records the last query and returns canned lines.
+type mockLogQuerier struct {
+	query string
+	limit int
+	lines []logs.LogLine
+	err   error
+}
+
+func (m *mockLogQuerier) QueryLines(ctx context.Context, query string, limit int, start, end time.Time) ([]logs.LogLine, error) {
+	m.query = query
+	m.limit = limit
+	return m.lines, m.err
+}
+
+func TestRenderLogQuery(t *testing.T) {
+	query, err := renderLogQuery(`{host="{{.Hostname}}", filename="{{.LogPath}}"} |= "error"`, LogQueryVars{
+		Hostname: "web-01",
+		LogPath:  "/var/log/nginx/error.log",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{host="web-01", filename="/var/log/nginx/error.log"} |= "error"`, query)
+
+	_, err = renderLogQuery(`{host="{{.Unknown}}"}`, LogQueryVars{})
+	assert.Error(t, err)
+}
+
+func TestLogExcerptFetcher_Fetch(t *testing.T) {
+	querier := &mockLogQuerier{
+		lines: []logs.LogLine{
+			{Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local), Line: "connect() failed"},
+		},
+	}
+	fetcher := NewLogExcerptFetcher(querier, zerolog.Nop())
+
+	excerpt, err := fetcher.Fetch(context.Background(), &config.LogExcerptConfig{
+		Query: `{host="{{.Hostname}}"}`,
+		Lines: 5,
+	}, LogQueryVars{Hostname: "web-01"})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{host="web-01"}`, querier.query)
+	assert.Equal(t, 5, querier.limit)
+	assert.Equal(t, []string{"2024-01-01 10:00:00 connect() failed"}, excerpt)
+}
+
+func TestNginxInspector_AttachLogExcerpts(t *testing.T) {
+	querier := &mockLogQuerier{
+		lines: []logs.LogLine{{Timestamp: time.Now(), Line: "upstream timed out"}},
+	}
+	cfg := &config.Config{
+		Nginx: config.NginxInspectionConfig{
+			LogExcerpt: config.LogExcerptConfig{Enabled: true, Query: `{host="{{.Hostname}}"}`, Lines: 10},
+		},
+	}
+	inspector := &NginxInspector{
+		config:     cfg,
+		logFetcher: NewLogExcerptFetcher(querier, zerolog.Nop()),
+		logger:     zerolog.Nop(),
+	}
+
+	critical := model.NewNginxInspectionResult(model.NewNginxInstance("web-01", 80))
+	criticalAlert := &model.NginxAlert{Identifier: "web-01:80", Level: model.AlertLevelCritical}
+	warningAlert := &model.NginxAlert{Identifier: "web-01:80", Level: model.AlertLevelWarning}
+	critical.Alerts = []*model.NginxAlert{criticalAlert, warningAlert}
+
+	warningOnly := model.NewNginxInspectionResult(model.NewNginxInstance("web-02", 80))
+	otherWarning := &model.NginxAlert{Identifier: "web-02:80", Level: model.AlertLevelWarning}
+	warningOnly.Alerts = []*model.NginxAlert{otherWarning}
+
+	inspector.attachLogExcerpts(context.Background(), map[string]*model.NginxInspectionResult{
+		"web-01:80": critical,
+		"web-02:80": warningOnly,
+	})
+
+	require.Len(t, criticalAlert.LogExcerpt, 1)
+	assert.Contains(t, criticalAlert.LogExcerpt[0], "upstream timed out")
+	assert.Empty(t, warningAlert.LogExcerpt, "warning alerts should not get excerpts")
+	assert.Empty(t, otherWarning.LogExcerpt)
+	assert.Equal(t, `{host="web-01"}`, querier.query, "only instances with critical alerts are queried")
+}
+
+func TestNginxInspector_AttachLogExcerpts_QueryError(t *testing.T) {
+	querier := &mockLogQuerier{err: errors.New("connection refused")}
+	cfg := &config.Config{
+		Nginx: config.NginxInspectionConfig{
+			LogExcerpt: config.LogExcerptConfig{Enabled: true, Query: `{host="{{.Hostname}}"}`, Lines: 10},
+		},
+	}
+	inspector := &NginxInspector{
+		config:     cfg,
+		logFetcher: NewLogExcerptFetcher(querier, zerolog.Nop()),
+		logger:     zerolog.Nop(),
+	}
+
+	result := model.NewNginxInspectionResult(model.NewNginxInstance("web-01", 80))
+	alert := &model.NginxAlert{Identifier: "web-01:80", Level: model.AlertLevelCritical}
+	result.Alerts = []*model.NginxAlert{alert}
+
+	inspector.attachLogExcerpts(context.Background(), map[string]*model.NginxInspectionResult{"web-01:80": result})
+
+	assert.Empty(t, alert.LogExcerpt)
+}
@@ -0,0 +1,497 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// Per-real-server metrics; each series describes one real server of a virtual service.
+const (
+	lvsBackendWeightMetric              = "lvs_backend_weight"
+	lvsBackendActiveConnectionsMetric   = "lvs_backend_active_connections"
+	lvsBackendInactiveConnectionsMetric = "lvs_backend_inactive_connections"
+)
+
+// Metrics derived from the virtual service structure after collection.
+const (
+	lvsActiveConnectionsMetric = "lvs_active_connections"
+	lvsZeroActiveMetric        = "lvs_zero_active_services"
+	lvsSyncDaemonMetric        = "lvs_sync_daemon_running"
+)
+
+// lvsDiscoveryMetrics lists the metrics whose hosts are treated as LVS directors.
+var lvsDiscoveryMetrics = map[string]bool{
+	lvsBackendWeightMetric: true,
+	lvsSyncDaemonMetric:    true,
+}
+
+// =============================================================================
+// LVS Collector
+// =============================================================================
+
+// LVSCollector is the data collection service for LVS/IPVS load balancers.
+// It integrates with VictoriaMetrics to collect virtual service, real server and sync daemon
+// metrics and N9E to obtain host IP addresses. Hosts are identified by hostname.
+type LVSCollector struct {
+	vmClient       vm.MetricsSource
+	n9eClient      *n9e.Client // 用于获取 IP 地址
+	config         *config.LVSInspectionConfig
+	metrics        []*model.LVSMetricDefinition
+	metricDefs     map[string]*model.LVSMetricDefinition
+	instanceFilter *LVSInstanceFilter
+	logger         zerolog.Logger
+}
+
+// LVSInstanceFilter defines filtering criteria for LVS directors.
+type LVSInstanceFilter struct {
+	HostnamePatterns []string          // Hostname patterns (glob, e.g., "GX-LVS-*")
+	BusinessGroups   []string          // Business groups (OR relation)
+	Tags             map[string]string // Tags (AND relation)
+}
+
+// NewLVSCollector creates a new LVSCollector instance.
+func NewLVSCollector(
+	cfg *config.LVSInspectionConfig,
+	vmClient vm.MetricsSource,
+	n9eClient *n9e.Client,
+	metrics []*model.LVSMetricDefinition,
+	logger zerolog.Logger,
+) *LVSCollector {
+	c := &LVSCollector{
+		vmClient:  vmClient,
+		n9eClient: n9eClient,
+		config:    cfg,
+		metrics:   metrics,
+		logger:    logger.With().Str("component", "lvs-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.LVSMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	// Build instance filter from config
+	c.instanceFilter = c.buildInstanceFilter()
+
+	return c
+}
+
+// buildInstanceFilter converts config.LVSFilter to LVSInstanceFilter.
+func (c *LVSCollector) buildInstanceFilter() *LVSInstanceFilter {
+	if c.config == nil {
+		return nil
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.HostnamePatterns) == 0 &&
+		len(filter.BusinessGroups) == 0 &&
+		len(filter.Tags) == 0 {
+		return nil
+	}
+
+	return &LVSInstanceFilter{
+		HostnamePatterns: filter.HostnamePatterns,
+		BusinessGroups:   filter.BusinessGroups,
+		Tags:             filter.Tags,
+	}
+}
+
+// GetConfig returns the LVS inspection configuration.
+func (c *LVSCollector) GetConfig() *config.LVSInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *LVSCollector) GetMetrics() []*model.LVSMetricDefinition {
+	return c.metrics
+}
+
+// GetInstanceFilter returns the instance filter.
+func (c *LVSCollector) GetInstanceFilter() *LVSInstanceFilter {
+	return c.instanceFilter
+}
+
+// IsEmpty returns true if the instance filter has no filtering criteria.
+func (f *LVSInstanceFilter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.HostnamePatterns) == 0 &&
+		len(f.BusinessGroups) == 0 &&
+		len(f.Tags) == 0
+}
+
+// ToVMHostFilter converts LVSInstanceFilter to vm.HostFilter.
+// Note: HostnamePatterns are not supported in vm.HostFilter and are
+// handled separately in the DiscoverInstances method.
+func (f *LVSInstanceFilter) ToVMHostFilter() *vm.HostFilter {
+	if f == nil || f.IsEmpty() {
+		return nil
+	}
+
+	if len(f.BusinessGroups) == 0 && len(f.Tags) == 0 {
+		return nil
+	}
+
+	return &vm.HostFilter{
+		BusinessGroups: f.BusinessGroups,
+		Tags:           f.Tags,
+	}
+}
+
+// =============================================================================
+// LVS 调度器发现
+// =============================================================================
+
+// DiscoverInstances discovers all LVS directors.
+// Directors are the union of the hosts returned by the real server weight and
+// sync daemon metrics.
+// IP addresses are retrieved from N9E API.
+func (c *LVSCollector) DiscoverInstances(ctx context.Context) ([]*model.LVSInstance, error) {
+	c.logger.Info().Msg("starting LVS director discovery")
+
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	instanceMap := make(map[string]*model.LVSInstance)
+	var order []string
+	queried := 0
+
+	for _, metric := range c.metrics {
+		if !lvsDiscoveryMetrics[metric.Name] || metric.IsPending() {
+			continue
+		}
+
+		results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("failed to query discovery metric, continuing with others")
+			continue
+		}
+		queried++
+
+		for _, result := range results {
+			hostname := labelHostname(result.Labels)
+			if hostname == "" {
+				c.logger.Warn().Interface("labels", result.Labels).Msg("missing hostname labels")
+				continue
+			}
+
+			if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
+				c.logger.Debug().Str("hostname", hostname).Msg("hostname filtered out")
+				continue
+			}
+
+			instance, exists := instanceMap[hostname]
+			if !exists {
+				instance = model.NewLVSInstance(hostname)
+				instanceMap[hostname] = instance
+				order = append(order, hostname)
+			}
+		}
+	}
+
+	if queried == 0 {
+		return nil, fmt.Errorf("failed to query any LVS discovery metric")
+	}
+
+	instances := make([]*model.LVSInstance, 0, len(order))
+	for _, hostname := range order {
+		instance := instanceMap[hostname]
+		instance.SetIP(n9eHostIP(ctx, c.n9eClient, hostname, c.logger))
+		instances = append(instances, instance)
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Msg("LVS director discovery completed")
+
+	return instances, nil
+}
+
+// =============================================================================
+// LVS 指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all LVS directors.
+//
+// Flow:
+//  1. Initialize result objects for each host
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values from metrics
+//  6. Return results map (key = identifier)
+//
+// Single metric failure does not abort the entire collection.
+func (c *LVSCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.LVSInstance,
+	metrics []*model.LVSMetricDefinition,
+) (map[string]*model.LVSInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting LVS metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by identifier)
+	resultsMap := make(map[string]*model.LVSInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Identifier] = model.NewLVSInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.LVSMetricDefinition
+	var activeMetrics []*model.LVSMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("LVS metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for all pending metrics on all hosts.
+func (c *LVSCollector) setPendingMetrics(
+	resultsMap map[string]*model.LVSInspectionResult,
+	pendingMetrics []*model.LVSMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending LVS metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			result.SetMetric(&model.LVSMetricValue{
+				Name:           metric.Name,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// collectMetricConcurrent collects a single metric for all hosts (concurrent-safe).
+// Series of the real server metrics are added to the virtual services instead of metric values.
+func (c *LVSCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.LVSMetricDefinition,
+	resultsMap map[string]*model.LVSInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting LVS metric (concurrent)")
+
+	// Query VictoriaMetrics
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		hostname := labelHostname(result.Labels)
+		if hostname == "" {
+			continue
+		}
+
+		inspResult, ok := resultsMap[hostname]
+		if !ok {
+			continue
+		}
+
+		// Each series of a real server metric describes one real server
+		if isLVSBackendMetric(metric.Name) {
+			setLVSBackendValue(inspResult, metric.Name, result.Labels, result.Value)
+			matchedCount++
+			continue
+		}
+
+		mv := &model.LVSMetricValue{
+			Name:      metric.Name,
+			RawValue:  result.Value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields.
+// Virtual services are summarized into the lvs_active_connections and
+// lvs_zero_active_services metrics so that they appear in the raw data sheet.
+// NaN/Inf values are treated as not collected.
+func (c *LVSCollector) extractFieldsFromMetrics(resultsMap map[string]*model.LVSInspectionResult) {
+	for _, result := range resultsMap {
+		if len(result.VirtualServices) > 0 {
+			sortLVSVirtualServices(result.VirtualServices)
+
+			var active float64
+			for _, vs := range result.VirtualServices {
+				active += vs.ActiveConnections()
+			}
+			result.ActiveConnections = active
+			zeroActive := result.ZeroActiveServiceNames()
+			result.ZeroActiveServices = len(zeroActive)
+
+			result.SetMetric(&model.LVSMetricValue{
+				Name:      lvsActiveConnectionsMetric,
+				RawValue:  active,
+				Timestamp: time.Now().Unix(),
+			})
+			result.SetMetric(&model.LVSMetricValue{
+				Name:        lvsZeroActiveMetric,
+				RawValue:    float64(len(zeroActive)),
+				StringValue: strings.Join(zeroActive, ", "),
+				Timestamp:   time.Now().Unix(),
+			})
+		}
+
+		if mv := result.GetMetric(lvsSyncDaemonMetric); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.SyncDaemonRunning = mv.RawValue
+			result.SyncDaemonState = mv.StringValue
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
+
+// isLVSBackendMetric returns true for the per-real-server metrics.
+func isLVSBackendMetric(name string) bool {
+	switch name {
+	case lvsBackendWeightMetric, lvsBackendActiveConnectionsMetric, lvsBackendInactiveConnectionsMetric:
+		return true
+	default:
+		return false
+	}
+}
+
+// setLVSBackendValue stores a real server metric value on the matching virtual service,
+// identified from the node_exporter ipvs labels. Firewall-mark services are keyed by mark.
+func setLVSBackendValue(result *model.LVSInspectionResult, metricName string, labels map[string]string, value float64) {
+	protocol := strings.ToUpper(labels["proto"])
+	address := net.JoinHostPort(labels["local_address"], labels["local_port"])
+	if mark := labels["local_mark"]; mark != "" {
+		protocol = "FWM"
+		address = mark
+	}
+
+	vs := result.GetVirtualService(protocol, address)
+	rs := vs.GetRealServer(net.JoinHostPort(labels["remote_address"], labels["remote_port"]))
+
+	switch metricName {
+	case lvsBackendWeightMetric:
+		rs.Weight = value
+	case lvsBackendActiveConnectionsMetric:
+		rs.ActiveConnections = value
+	case lvsBackendInactiveConnectionsMetric:
+		rs.InactiveConnections = value
+	}
+}
+
+// sortLVSVirtualServices sorts virtual services by address and their real servers by address,
+// so report output is stable across runs.
+func sortLVSVirtualServices(services []*model.LVSVirtualService) {
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Address != services[j].Address {
+			return services[i].Address < services[j].Address
+		}
+		return services[i].Protocol < services[j].Protocol
+	})
+	for _, vs := range services {
+		sort.Slice(vs.RealServers, func(i, j int) bool {
+			return vs.RealServers[i].Address < vs.RealServers[j].Address
+		})
+	}
+}
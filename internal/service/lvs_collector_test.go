@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// createTestLVSCollector creates an LVSCollector querying fake VM and N9E servers.
+func createTestLVSCollector(t *testing.T, series map[string][]fakeSeries, ips map[string]string, cfg *config.LVSInspectionConfig, metrics []*model.LVSMetricDefinition) *LVSCollector {
+	t.Helper()
+	vmServer := newFakeVMServer(t, series)
+	n9eServer := newFakeN9EServer(t, ips)
+	return NewLVSCollector(cfg, newFakeVMClient(vmServer.URL), newFakeN9EClient(n9eServer.URL), metrics, zerolog.Nop())
+}
+
+// lvsBackendLabels returns the node_exporter ipvs labels of a TCP real server on host.
+func lvsBackendLabels(host, vip, rip string) map[string]string {
+	return map[string]string{
+		"agent_hostname": host,
+		"proto":          "tcp",
+		"local_address":  vip,
+		"local_port":     "80",
+		"remote_address": rip,
+		"remote_port":    "8080",
+	}
+}
+
+func TestLVSCollector_DiscoverInstances(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"node_ipvs_backend_weight": {
+			{lvsBackendLabels("GX-LVS-01", "10.0.0.100", "10.0.1.1"), 1},
+			{lvsBackendLabels("GX-LVS-01", "10.0.0.100", "10.0.1.2"), 1}, // Same director
+			{lvsBackendLabels("SH-LVS-01", "10.0.0.100", "10.0.1.1"), 1}, // Filtered out
+			{map[string]string{"proto": "tcp"}, 1},                       // No hostname
+		},
+		"node_ipvs_sync_daemon": {
+			{map[string]string{"ident": "GX-LVS-02", "state": "backup"}, 1}, // Director without services
+		},
+	}
+	metrics := []*model.LVSMetricDefinition{
+		{Name: "lvs_backend_weight", Query: "node_ipvs_backend_weight"},
+		{Name: "lvs_sync_daemon_running", Query: "node_ipvs_sync_daemon"},
+		{Name: "lvs_backend_active_connections", Query: "node_ipvs_backend_connections_active"}, // Not a discovery metric
+	}
+	cfg := &config.LVSInspectionConfig{InstanceFilter: config.LVSFilter{HostnamePatterns: []string{"GX-LVS-*"}}}
+	collector := createTestLVSCollector(t, series, map[string]string{"GX-LVS-01": "10.0.0.1"}, cfg, metrics)
+
+	instances, err := collector.DiscoverInstances(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverInstances() error = %v", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("DiscoverInstances() returned %d directors, want 2: %+v", len(instances), instances)
+	}
+	if instances[0].Hostname != "GX-LVS-01" || instances[0].IP != "10.0.0.1" {
+		t.Errorf("first director = %+v, want GX-LVS-01 with its N9E IP", instances[0])
+	}
+	if instances[1].Hostname != "GX-LVS-02" || instances[1].IP != "N/A" {
+		t.Errorf("second director = %+v, want GX-LVS-02 without N9E IP", instances[1])
+	}
+}
+
+func TestLVSCollector_DiscoverInstances_QueryError(t *testing.T) {
+	metrics := []*model.LVSMetricDefinition{{Name: "lvs_backend_weight", Query: "node_ipvs_backend_weight"}}
+	collector := NewLVSCollector(nil, newFakeVMClient("http://127.0.0.1:1"), nil, metrics, zerolog.Nop())
+	if _, err := collector.DiscoverInstances(context.Background()); err == nil {
+		t.Error("DiscoverInstances() should fail when no discovery metric can be queried")
+	}
+}
+
+func TestLVSCollector_CollectMetrics(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"node_ipvs_backend_weight": {
+			{lvsBackendLabels("GX-LVS-01", "10.0.0.100", "10.0.1.2"), 1},
+			{lvsBackendLabels("GX-LVS-01", "10.0.0.100", "10.0.1.1"), 1},
+			{lvsBackendLabels("GX-LVS-01", "10.0.0.101", "10.0.1.3"), 0}, // Drained: no active real server
+			{lvsBackendLabels("GX-LVS-09", "10.0.0.100", "10.0.1.1"), 1}, // Not discovered
+		},
+		"node_ipvs_backend_connections_active": {
+			{lvsBackendLabels("GX-LVS-01", "10.0.0.100", "10.0.1.1"), 10},
+			{lvsBackendLabels("GX-LVS-01", "10.0.0.100", "10.0.1.2"), 5},
+		},
+		"node_ipvs_sync_daemon": {
+			{map[string]string{"agent_hostname": "GX-LVS-01", "state": "master"}, 1},
+		},
+	}
+	metrics := []*model.LVSMetricDefinition{
+		{Name: "lvs_backend_weight", Query: "node_ipvs_backend_weight"},
+		{Name: "lvs_backend_active_connections", Query: "node_ipvs_backend_connections_active"},
+		{Name: "lvs_sync_daemon_running", Query: "node_ipvs_sync_daemon", LabelExtract: []string{"state"}},
+		{Name: "lvs_backend_inactive_connections", Status: "pending"},
+	}
+	collector := createTestLVSCollector(t, series, nil, nil, metrics)
+
+	results, err := collector.CollectMetrics(context.Background(), []*model.LVSInstance{model.NewLVSInstance("GX-LVS-01")}, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("CollectMetrics() returned %d results, want only the discovered director", len(results))
+	}
+
+	result := results["GX-LVS-01"]
+	if len(result.VirtualServices) != 2 || result.RealServerCount() != 3 {
+		t.Fatalf("virtual services = %+v, want 2 services with 3 real servers", result.VirtualServices)
+	}
+	vs := result.VirtualServices[0]
+	if vs.String() != "TCP 10.0.0.100:80" || vs.RealServers[0].Address != "10.0.1.1:8080" || vs.RealServers[0].ActiveConnections != 10 {
+		t.Errorf("first virtual service = %s %+v, want sorted real servers with their connections", vs, vs.RealServers[0])
+	}
+	if result.ActiveConnections != 15 {
+		t.Errorf("ActiveConnections = %v, want 15", result.ActiveConnections)
+	}
+	if result.ZeroActiveServices != 1 {
+		t.Errorf("ZeroActiveServices = %d, want 1", result.ZeroActiveServices)
+	}
+	if mv := result.GetMetric("lvs_zero_active_services"); mv == nil || mv.StringValue != "TCP 10.0.0.101:80" {
+		t.Errorf("lvs_zero_active_services metric = %+v", mv)
+	}
+	if result.SyncDaemonRunning != 1 || result.SyncDaemonState != "master" {
+		t.Errorf("sync daemon = %v %q, want 1 master", result.SyncDaemonRunning, result.SyncDaemonState)
+	}
+	if mv := result.GetMetric("lvs_backend_inactive_connections"); mv == nil || !mv.IsNA {
+		t.Errorf("pending metric = %+v, want N/A", mv)
+	}
+}
@@ -0,0 +1,286 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// LVS Evaluator
+// =============================================================================
+
+// LVSEvaluationResult represents the evaluation result for a single LVS director.
+type LVSEvaluationResult struct {
+	Identifier string                  `json:"identifier"` // 调度器标识符
+	Status     model.LVSInstanceStatus `json:"status"`     // 调度器整体状态
+	Alerts     []*model.LVSAlert       `json:"alerts"`     // 告警列表
+}
+
+// LVSEvaluator evaluates LVS metrics against thresholds.
+type LVSEvaluator struct {
+	thresholds *config.LVSThresholds                 // 阈值配置
+	metricDefs map[string]*model.LVSMetricDefinition // 指标定义映射（用于获取显示名称）
+	timezone   *time.Location                        // 时区
+	logger     zerolog.Logger                        // 日志器
+}
+
+// NewLVSEvaluator creates a new LVSEvaluator with the given threshold configuration.
+func NewLVSEvaluator(
+	thresholds *config.LVSThresholds,
+	metrics []*model.LVSMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *LVSEvaluator {
+	metricDefs := make(map[string]*model.LVSMetricDefinition)
+	for _, m := range metrics {
+		metricDefs[m.Name] = m
+	}
+
+	return &LVSEvaluator{
+		thresholds: thresholds,
+		metricDefs: metricDefs,
+		timezone:   timezone,
+		logger:     logger.With().Str("component", "lvs_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all LVS directors and returns the complete evaluation results.
+func (e *LVSEvaluator) EvaluateAll(
+	results map[string]*model.LVSInspectionResult,
+) []*LVSEvaluationResult {
+	evalResults := make([]*LVSEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("LVS evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single LVS director against configured thresholds.
+// Every virtual service without an active real server raises its own critical alert
+// so that each affected service is listed.
+func (e *LVSEvaluator) Evaluate(
+	result *model.LVSInspectionResult,
+) *LVSEvaluationResult {
+	evalResult := &LVSEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.LVSStatusNormal,
+		Alerts:     make([]*model.LVSAlert, 0),
+	}
+
+	// Skip failed instances
+	if result.Error != "" {
+		evalResult.Status = model.LVSStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed director")
+		return evalResult
+	}
+
+	// 1. Evaluate virtual services (no active real server -> Critical)
+	evalResult.Alerts = append(evalResult.Alerts, e.evaluateVirtualServices(result)...)
+
+	// 2. Evaluate total active connections
+	if result.HasActiveConnections() {
+		if alert := e.evaluateThreshold(result, lvsActiveConnectionsMetric, result.ActiveConnections,
+			e.thresholds.ActiveConnectionsWarning, e.thresholds.ActiveConnectionsCritical); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+	}
+
+	// 3. Evaluate connection sync daemon (not running -> Warning)
+	if e.thresholds.AlertOnSyncDaemonStopped && result.HasSyncDaemon() && result.SyncDaemonRunning == 0 {
+		evalResult.Alerts = append(evalResult.Alerts,
+			e.createAlert(result.GetIdentifier(), lvsSyncDaemonMetric, 0, model.AlertLevelWarning))
+	}
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("director evaluation completed")
+
+	return evalResult
+}
+
+// evaluateVirtualServices creates a critical alert for each virtual service whose
+// real servers all have weight 0.
+func (e *LVSEvaluator) evaluateVirtualServices(
+	result *model.LVSInspectionResult,
+) []*model.LVSAlert {
+	var alerts []*model.LVSAlert
+
+	for _, vs := range result.VirtualServices {
+		if vs.ActiveRealServers() > 0 {
+			continue
+		}
+		alert := e.createAlert(result.GetIdentifier(), lvsZeroActiveMetric, 0, model.AlertLevelCritical)
+		alert.Message = fmt.Sprintf("虚拟服务 %s 无可用真实服务器（共 %d 台，权重均为 0），新连接将被拒绝",
+			vs.String(), len(vs.RealServers))
+		alerts = append(alerts, alert)
+	}
+
+	return alerts
+}
+
+// evaluateThreshold evaluates a "higher is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *LVSEvaluator) evaluateThreshold(
+	result *model.LVSInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.LVSAlert {
+	return e.alertAt(result, metricName, value, config.ThresholdPair{Warning: warning, Critical: critical})
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *LVSEvaluator) alertAt(
+	result *model.LVSInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.LVSAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *LVSEvaluator) determineInstanceStatus(
+	alerts []*model.LVSAlert,
+) model.LVSInstanceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.LVSStatusCritical
+	}
+	if hasWarning {
+		return model.LVSStatusWarning
+	}
+	return model.LVSStatusNormal
+}
+
+// createAlert creates an LVSAlert with formatted message.
+func (e *LVSEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.LVSAlert {
+	displayName := metricName
+	if def, exists := e.metricDefs[metricName]; exists {
+		displayName = def.GetDisplayName()
+	} else if name, ok := lvsDerivedDisplayNames[metricName]; ok {
+		displayName = name
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.LVSAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(metricName, currentValue, level),
+	}
+}
+
+// lvsDerivedDisplayNames holds display names of metrics derived by the collector,
+// which have no definition in the metrics file.
+var lvsDerivedDisplayNames = map[string]string{
+	lvsActiveConnectionsMetric: "活跃连接总数",
+	lvsZeroActiveMetric:        "无可用真实服务器",
+}
+
+// formatValue formats metric value for display.
+func (e *LVSEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case lvsSyncDaemonMetric:
+		if value != 0 {
+			return "运行"
+		}
+		return "未运行"
+	case lvsZeroActiveMetric:
+		return fmt.Sprintf("%.0f 台可用", value)
+	case lvsActiveConnectionsMetric:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *LVSEvaluator) generateAlertMessage(
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case lvsZeroActiveMetric:
+		return "虚拟服务无可用真实服务器"
+	case lvsSyncDaemonMetric:
+		return "IPVS 连接同步守护进程未运行，主备切换后已建立的连接将中断"
+	case lvsActiveConnectionsMetric:
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("活跃连接总数 %.0f，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.ActiveConnectionsCritical)
+		}
+		return fmt.Sprintf("活跃连接总数 %.0f，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.ActiveConnectionsWarning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric.
+// A virtual service with no active real server is critical from the first occurrence.
+func (e *LVSEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case lvsZeroActiveMetric:
+		return 0, 0
+	case lvsActiveConnectionsMetric:
+		return e.thresholds.ActiveConnectionsWarning, e.thresholds.ActiveConnectionsCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *LVSEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{lvsActiveConnectionsMetric})
+}
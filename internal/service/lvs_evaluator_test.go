@@ -0,0 +1,161 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestLVSEvaluator creates an LVS evaluator with default thresholds for testing.
+func createTestLVSEvaluator() *LVSEvaluator {
+	thresholds := &config.LVSThresholds{
+		ActiveConnectionsWarning:  50000,
+		ActiveConnectionsCritical: 100000,
+		AlertOnSyncDaemonStopped:  true,
+	}
+	metrics := []*model.LVSMetricDefinition{
+		{Name: "lvs_backend_weight", DisplayName: "真实服务器权重"},
+		{Name: "lvs_sync_daemon_running", DisplayName: "连接同步守护进程"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewLVSEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// createTestLVSResult creates a test LVS inspection result with one healthy virtual service.
+func createTestLVSResult() *model.LVSInspectionResult {
+	result := model.NewLVSInspectionResult(model.NewLVSInstance("lvs-01"))
+	setLVSBackendValue(result, lvsBackendWeightMetric, lvsTestLabels("80", "10.0.1.1"), 1)
+	setLVSBackendValue(result, lvsBackendWeightMetric, lvsTestLabels("80", "10.0.1.2"), 1)
+	return result
+}
+
+// lvsTestLabels returns node_exporter ipvs labels for a TCP real server behind VIP 10.0.0.100.
+func lvsTestLabels(port, realServer string) map[string]string {
+	return map[string]string{
+		"local_address":  "10.0.0.100",
+		"local_port":     port,
+		"proto":          "TCP",
+		"remote_address": realServer,
+		"remote_port":    port,
+	}
+}
+
+// =============================================================================
+// Collector Helper Tests
+// =============================================================================
+
+func TestSetLVSBackendValue(t *testing.T) {
+	result := createTestLVSResult()
+	setLVSBackendValue(result, lvsBackendActiveConnectionsMetric, lvsTestLabels("80", "10.0.1.1"), 120)
+	setLVSBackendValue(result, lvsBackendWeightMetric, map[string]string{
+		"local_mark": "100", "proto": "FWM", "remote_address": "10.0.2.1", "remote_port": "0",
+	}, 0)
+
+	if len(result.VirtualServices) != 2 {
+		t.Fatalf("expected 2 virtual services, got %d", len(result.VirtualServices))
+	}
+	vs := result.VirtualServices[0]
+	if vs.String() != "TCP 10.0.0.100:80" {
+		t.Errorf("unexpected virtual service %q", vs.String())
+	}
+	if len(vs.RealServers) != 2 || vs.ActiveRealServers() != 2 || vs.ActiveConnections() != 120 {
+		t.Errorf("unexpected real servers: %d servers, %d active, %.0f connections",
+			len(vs.RealServers), vs.ActiveRealServers(), vs.ActiveConnections())
+	}
+	if fwm := result.VirtualServices[1]; fwm.String() != "FWM 100" {
+		t.Errorf("expected firewall mark service, got %q", fwm.String())
+	}
+}
+
+// =============================================================================
+// Virtual Service Tests
+// =============================================================================
+
+func TestLVSEvaluator_ZeroActiveRealServers(t *testing.T) {
+	evaluator := createTestLVSEvaluator()
+	result := createTestLVSResult()
+	setLVSBackendValue(result, lvsBackendWeightMetric, lvsTestLabels("443", "10.0.1.1"), 0)
+	setLVSBackendValue(result, lvsBackendWeightMetric, lvsTestLabels("443", "10.0.1.2"), 0)
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.LVSStatusCritical {
+		t.Errorf("expected critical status, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(evalResult.Alerts))
+	}
+	alert := evalResult.Alerts[0]
+	if alert.Level != model.AlertLevelCritical || !strings.Contains(alert.Message, "TCP 10.0.0.100:443") {
+		t.Errorf("unexpected alert: level=%s message=%q", alert.Level, alert.Message)
+	}
+}
+
+// =============================================================================
+// Threshold Tests
+// =============================================================================
+
+func TestLVSEvaluator_EvaluateThresholds(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(r *model.LVSInspectionResult)
+		metricName    string
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{"all healthy", func(r *model.LVSInspectionResult) {}, "", "", false},
+		{"connections normal", func(r *model.LVSInspectionResult) { r.ActiveConnections = 1000 }, "", "", false},
+		{"connections warning", func(r *model.LVSInspectionResult) { r.ActiveConnections = 60000 }, "lvs_active_connections", model.AlertLevelWarning, true},
+		{"connections critical", func(r *model.LVSInspectionResult) { r.ActiveConnections = 150000 }, "lvs_active_connections", model.AlertLevelCritical, true},
+		{"sync daemon running", func(r *model.LVSInspectionResult) { r.SyncDaemonRunning = 1 }, "", "", false},
+		{"sync daemon stopped", func(r *model.LVSInspectionResult) { r.SyncDaemonRunning = 0 }, "lvs_sync_daemon_running", model.AlertLevelWarning, true},
+	}
+
+	evaluator := createTestLVSEvaluator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestLVSResult()
+			tt.setup(result)
+
+			evalResult := evaluator.Evaluate(result)
+			if !tt.expectAlert {
+				if len(evalResult.Alerts) != 0 {
+					t.Errorf("expected no alert, got %d", len(evalResult.Alerts))
+				}
+				return
+			}
+			if len(evalResult.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(evalResult.Alerts))
+			}
+			alert := evalResult.Alerts[0]
+			if alert.MetricName != tt.metricName {
+				t.Errorf("expected metric %s, got %s", tt.metricName, alert.MetricName)
+			}
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+		})
+	}
+}
+
+func TestLVSEvaluator_SyncDaemonAlertDisabled(t *testing.T) {
+	evaluator := NewLVSEvaluator(&config.LVSThresholds{}, nil, nil, zerolog.Nop())
+	result := createTestLVSResult()
+	result.SyncDaemonRunning = 0
+	result.ActiveConnections = 1000000
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.LVSStatusNormal {
+		t.Errorf("expected normal status with disabled thresholds, got %s", evalResult.Status)
+	}
+}
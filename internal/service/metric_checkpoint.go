@@ -0,0 +1,26 @@
+package service
+
+import (
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/model"
+)
+
+// MetricCheckpoint keeps the query results of the host metrics a collection completed, so
+// that an interrupted collection resumes without querying them again. Implementations must
+// be safe for concurrent use: the metrics are collected concurrently.
+type MetricCheckpoint interface {
+	// Results returns the saved query results of a metric; ok is false when the metric was
+	// not collected with the same query.
+	Results(metric *model.MetricDefinition) (results []vm.QueryResult, ok bool)
+	// Save records the query results of a collected metric.
+	Save(metric *model.MetricDefinition, results []vm.QueryResult)
+}
+
+// WithMetricCheckpoint takes the results of the metrics saved in the checkpoint instead of
+// querying them, and saves the results of the metrics queried. A nil checkpoint queries
+// every metric.
+func WithMetricCheckpoint(checkpoint MetricCheckpoint) CollectorOption {
+	return func(c *Collector) {
+		c.checkpoint = checkpoint
+	}
+}
@@ -0,0 +1,390 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// defaultMonitoringUpQuery is used for component discovery when the metrics file
+// does not define monitoring_up.
+const defaultMonitoringUpQuery = `up{job=~"victoriametrics|vmstorage|vmselect|vminsert|vmagent|influxdb"}`
+
+// =============================================================================
+// Monitoring Collector
+// =============================================================================
+
+// MonitoringCollector is the data collection service for the monitoring stack itself.
+// It queries VictoriaMetrics for the self-monitoring metrics exposed by
+// VictoriaMetrics components and InfluxDB. Components are identified by their
+// scrape target "instance" label rather than by host, since several components
+// usually run on the same host.
+type MonitoringCollector struct {
+	vmClient   vm.MetricsSource
+	config     *config.MonitoringInspectionConfig
+	metrics    []*model.MonitoringMetricDefinition
+	metricDefs map[string]*model.MonitoringMetricDefinition
+	logger     zerolog.Logger
+}
+
+// NewMonitoringCollector creates a new MonitoringCollector instance.
+func NewMonitoringCollector(
+	cfg *config.MonitoringInspectionConfig,
+	vmClient vm.MetricsSource,
+	metrics []*model.MonitoringMetricDefinition,
+	logger zerolog.Logger,
+) *MonitoringCollector {
+	c := &MonitoringCollector{
+		vmClient: vmClient,
+		config:   cfg,
+		metrics:  metrics,
+		logger:   logger.With().Str("component", "monitoring-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.MonitoringMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	return c
+}
+
+// GetConfig returns the monitoring inspection configuration.
+func (c *MonitoringCollector) GetConfig() *config.MonitoringInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *MonitoringCollector) GetMetrics() []*model.MonitoringMetricDefinition {
+	return c.metrics
+}
+
+// =============================================================================
+// 监控组件发现
+// =============================================================================
+
+// DiscoverInstances discovers all monitoring components by querying the monitoring_up metric.
+// Components reporting up=0 are kept so that DOWN components appear in the report.
+// The job label is used as the component type and the version is taken from monitoring_version.
+func (c *MonitoringCollector) DiscoverInstances(ctx context.Context) ([]*model.MonitoringInstance, error) {
+	c.logger.Info().Msg("starting monitoring component discovery")
+
+	// Step 1: Query monitoring_up to get all components (UP and DOWN)
+	upQuery := defaultMonitoringUpQuery
+	if def, ok := c.metricDefs["monitoring_up"]; ok && !def.IsPending() {
+		upQuery = def.Query
+	}
+	results, err := c.vmClient.QueryResults(ctx, upQuery)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("failed to query monitoring_up metric")
+		return nil, fmt.Errorf("failed to query monitoring_up: %w", err)
+	}
+
+	c.logger.Debug().Int("raw_results", len(results)).Msg("received monitoring_up query results")
+
+	// Step 2: Query monitoring_version to get component versions
+	versionMap := make(map[string]string)
+	if def, ok := c.metricDefs["monitoring_version"]; ok && !def.IsPending() {
+		versionResults, err := c.vmClient.QueryResults(ctx, def.Query)
+		if err != nil {
+			c.logger.Warn().Err(err).Msg("failed to query monitoring_version, continuing without versions")
+		}
+		for _, result := range versionResults {
+			if instance := result.Labels["instance"]; instance != "" && result.Labels["version"] != "" {
+				versionMap[instance] = result.Labels["version"]
+			}
+		}
+	}
+
+	// Step 3: Extract instances and apply filters
+	var instances []*model.MonitoringInstance
+	seenIdentifiers := make(map[string]bool)
+
+	for _, result := range results {
+		identifier := result.Labels["instance"]
+		if identifier == "" {
+			c.logger.Warn().Interface("labels", result.Labels).Msg("missing instance label")
+			continue
+		}
+
+		job := result.Labels["job"]
+		if !c.matchesFilter(identifier, job) {
+			c.logger.Debug().Str("instance", identifier).Str("job", job).Msg("component filtered out")
+			continue
+		}
+
+		if seenIdentifiers[identifier] {
+			c.logger.Debug().Str("instance", identifier).Msg("skipping duplicate component")
+			continue
+		}
+
+		hostname, port := c.splitInstance(identifier, result.Labels)
+		instance := model.NewMonitoringInstance(identifier, hostname, port)
+		instance.SetComponent(job)
+		instance.SetVersion(versionMap[identifier])
+
+		instances = append(instances, instance)
+		seenIdentifiers[identifier] = true
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Int("filtered_out", len(results)-len(instances)).
+		Msg("monitoring component discovery completed")
+
+	return instances, nil
+}
+
+// splitInstance derives hostname and port from the instance label.
+// Hostname labels (agent_hostname > ident > host) take precedence over the instance address.
+func (c *MonitoringCollector) splitInstance(identifier string, labels map[string]string) (string, int) {
+	hostname := identifier
+	port := 0
+	if host, portStr, err := net.SplitHostPort(identifier); err == nil {
+		hostname = host
+		port = parsePortLabel(portStr)
+	}
+
+	for _, key := range []string{"agent_hostname", "ident", "host"} {
+		if val := labels[key]; val != "" {
+			return val, port
+		}
+	}
+	return hostname, port
+}
+
+// matchesFilter checks if a component matches the configured instance patterns and jobs.
+// Returns true if no criteria configured or the component matches all configured criteria.
+func (c *MonitoringCollector) matchesFilter(instance, job string) bool {
+	if c.config == nil {
+		return true
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.Jobs) > 0 {
+		matched := false
+		for _, j := range filter.Jobs {
+			if strings.EqualFold(j, job) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return matchAnyPattern(instance, filter.InstancePatterns)
+}
+
+// =============================================================================
+// 监控组件指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all monitoring components.
+//
+// Flow:
+//  1. Initialize result objects for each component
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values from metrics
+//  6. Return results map (key = identifier)
+//
+// Single metric failure does not abort the entire collection.
+func (c *MonitoringCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.MonitoringInstance,
+	metrics []*model.MonitoringMetricDefinition,
+) (map[string]*model.MonitoringInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting monitoring metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by identifier)
+	resultsMap := make(map[string]*model.MonitoringInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Identifier] = model.NewMonitoringInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.MonitoringMetricDefinition
+	var activeMetrics []*model.MonitoringMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("monitoring metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for all pending metrics on all components.
+func (c *MonitoringCollector) setPendingMetrics(
+	resultsMap map[string]*model.MonitoringInspectionResult,
+	pendingMetrics []*model.MonitoringMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending monitoring metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			result.SetMetric(&model.MonitoringMetricValue{
+				Name:           metric.Name,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// collectMetricConcurrent collects a single metric for all components (concurrent-safe).
+// Metrics with label_extract additionally store the joined label values as StringValue.
+func (c *MonitoringCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.MonitoringMetricDefinition,
+	resultsMap map[string]*model.MonitoringInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting monitoring metric (concurrent)")
+
+	// Query VictoriaMetrics
+	results, err := c.vmClient.QueryResults(ctx, metric.Query)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		inspResult, ok := resultsMap[result.Labels["instance"]]
+		if !ok {
+			continue
+		}
+
+		mv := &model.MonitoringMetricValue{
+			Name:      metric.Name,
+			RawValue:  result.Value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields.
+// NaN/Inf values (e.g. no data in the rate window) are treated as not collected.
+func (c *MonitoringCollector) extractFieldsFromMetrics(resultsMap map[string]*model.MonitoringInspectionResult) {
+	for _, result := range resultsMap {
+		// monitoring_up -> Up (UP/DOWN)
+		if mv := result.GetMetric("monitoring_up"); mv != nil && !mv.IsNA {
+			result.Up = mv.RawValue == 1
+		}
+
+		if mv := result.GetMetric("monitoring_ingestion_rate"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.IngestionRate = mv.RawValue
+		}
+		if mv := result.GetMetric("monitoring_slow_queries"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.SlowQueries = mv.RawValue
+		}
+		if mv := result.GetMetric("monitoring_merge_backlog"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.MergeBacklog = mv.RawValue
+		}
+		if mv := result.GetMetric("monitoring_disk_usage"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.DiskUsagePercent = mv.RawValue
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
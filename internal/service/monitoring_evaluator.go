@@ -0,0 +1,315 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Monitoring Evaluator
+// =============================================================================
+
+// MonitoringEvaluationResult represents the evaluation result for a single monitoring component.
+type MonitoringEvaluationResult struct {
+	Identifier string                         `json:"identifier"` // 组件标识符
+	Status     model.MonitoringInstanceStatus `json:"status"`     // 组件整体状态
+	Alerts     []*model.MonitoringAlert       `json:"alerts"`     // 告警列表
+}
+
+// MonitoringEvaluator evaluates monitoring stack metrics against thresholds.
+type MonitoringEvaluator struct {
+	thresholds *config.MonitoringThresholds                 // 阈值配置
+	metricDefs map[string]*model.MonitoringMetricDefinition // 指标定义映射（用于获取显示名称）
+	timezone   *time.Location                               // 时区
+	logger     zerolog.Logger                               // 日志器
+}
+
+// NewMonitoringEvaluator creates a new MonitoringEvaluator with the given threshold configuration.
+func NewMonitoringEvaluator(
+	thresholds *config.MonitoringThresholds,
+	metrics []*model.MonitoringMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *MonitoringEvaluator {
+	metricDefs := make(map[string]*model.MonitoringMetricDefinition)
+	for _, m := range metrics {
+		metricDefs[m.Name] = m
+	}
+
+	return &MonitoringEvaluator{
+		thresholds: thresholds,
+		metricDefs: metricDefs,
+		timezone:   timezone,
+		logger:     logger.With().Str("component", "monitoring_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all monitoring components and returns the complete evaluation results.
+func (e *MonitoringEvaluator) EvaluateAll(
+	results map[string]*model.MonitoringInspectionResult,
+) []*MonitoringEvaluationResult {
+	evalResults := make([]*MonitoringEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("monitoring evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single monitoring component against configured thresholds.
+// A DOWN component only raises the target status alert, since its other metrics are stale.
+func (e *MonitoringEvaluator) Evaluate(
+	result *model.MonitoringInspectionResult,
+) *MonitoringEvaluationResult {
+	evalResult := &MonitoringEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.MonitoringStatusNormal,
+		Alerts:     make([]*model.MonitoringAlert, 0),
+	}
+
+	// Skip failed instances
+	if result.Error != "" {
+		evalResult.Status = model.MonitoringStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed component")
+		return evalResult
+	}
+
+	// 1. Evaluate target status (UP/DOWN)
+	if alert := e.evaluateTargetStatus(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	} else {
+		// 2. Evaluate ingestion, slow queries, merge backlog and disk usage
+		if alert := e.evaluateIngestionRate(result); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+		if result.HasSlowQueries() {
+			if alert := e.evaluateThreshold(result, "monitoring_slow_queries", result.SlowQueries,
+				e.thresholds.SlowQueriesWarning, e.thresholds.SlowQueriesCritical); alert != nil {
+				evalResult.Alerts = append(evalResult.Alerts, alert)
+			}
+		}
+		if result.HasMergeBacklog() {
+			if alert := e.evaluateThreshold(result, "monitoring_merge_backlog", result.MergeBacklog,
+				e.thresholds.MergeBacklogWarning, e.thresholds.MergeBacklogCritical); alert != nil {
+				evalResult.Alerts = append(evalResult.Alerts, alert)
+			}
+		}
+		if result.HasDiskUsage() {
+			if alert := e.evaluateThreshold(result, "monitoring_disk_usage", result.DiskUsagePercent,
+				e.thresholds.DiskUsageWarning, e.thresholds.DiskUsageCritical); alert != nil {
+				evalResult.Alerts = append(evalResult.Alerts, alert)
+			}
+		}
+	}
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("component evaluation completed")
+
+	return evalResult
+}
+
+// evaluateTargetStatus evaluates monitoring_up.
+// monitoring_up = 0 (DOWN) -> Critical
+func (e *MonitoringEvaluator) evaluateTargetStatus(
+	result *model.MonitoringInspectionResult,
+) *model.MonitoringAlert {
+	mv := result.GetMetric("monitoring_up")
+	if mv == nil || mv.IsNA {
+		return nil // Metric not collected, skip
+	}
+
+	if !result.Up {
+		return e.createAlert(result.GetIdentifier(), "monitoring_up", 0, model.AlertLevelCritical)
+	}
+
+	return nil
+}
+
+// evaluateIngestionRate evaluates the ingestion rate of components that write data.
+// Ingestion rate = 0 -> Warning (only when collected, so query-only components are skipped).
+func (e *MonitoringEvaluator) evaluateIngestionRate(
+	result *model.MonitoringInspectionResult,
+) *model.MonitoringAlert {
+	if !e.thresholds.AlertOnZeroIngestion || !result.HasIngestionRate() || result.IngestionRate > 0 {
+		return nil
+	}
+
+	return e.createAlert(result.GetIdentifier(), "monitoring_ingestion_rate", 0, model.AlertLevelWarning)
+}
+
+// evaluateThreshold evaluates a "higher is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *MonitoringEvaluator) evaluateThreshold(
+	result *model.MonitoringInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.MonitoringAlert {
+	return e.alertAt(result, metricName, value, config.ThresholdPair{Warning: warning, Critical: critical})
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *MonitoringEvaluator) alertAt(
+	result *model.MonitoringInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.MonitoringAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *MonitoringEvaluator) determineInstanceStatus(
+	alerts []*model.MonitoringAlert,
+) model.MonitoringInstanceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.MonitoringStatusCritical
+	}
+	if hasWarning {
+		return model.MonitoringStatusWarning
+	}
+	return model.MonitoringStatusNormal
+}
+
+// createAlert creates a MonitoringAlert with formatted message.
+func (e *MonitoringEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.MonitoringAlert {
+	displayName := metricName
+	if def, exists := e.metricDefs[metricName]; exists {
+		displayName = def.GetDisplayName()
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.MonitoringAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(metricName, currentValue, level),
+	}
+}
+
+// formatValue formats metric value for display.
+func (e *MonitoringEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case "monitoring_up":
+		if value == 0 {
+			return model.MonitoringTargetStateDown
+		}
+		return model.MonitoringTargetStateUp
+	case "monitoring_disk_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "monitoring_ingestion_rate":
+		return fmt.Sprintf("%.0f 行/秒", value)
+	case "monitoring_slow_queries", "monitoring_merge_backlog":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *MonitoringEvaluator) generateAlertMessage(
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case "monitoring_up":
+		return "监控组件采集目标状态为 DOWN (up=0)"
+	case "monitoring_ingestion_rate":
+		return "近 5 分钟写入速率为 0，数据写入可能已中断"
+	case "monitoring_slow_queries":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("近 5 分钟慢查询 %.0f 次，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.SlowQueriesCritical)
+		}
+		return fmt.Sprintf("近 5 分钟慢查询 %.0f 次，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.SlowQueriesWarning)
+	case "monitoring_merge_backlog":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("待合并行数为 %.0f，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.MergeBacklogCritical)
+		}
+		return fmt.Sprintf("待合并行数为 %.0f，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.MergeBacklogWarning)
+	case "monitoring_disk_usage":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("存储磁盘使用率为 %.1f%%，已超过严重阈值 %.1f%%",
+				currentValue, e.thresholds.DiskUsageCritical)
+		}
+		return fmt.Sprintf("存储磁盘使用率为 %.1f%%，已超过警告阈值 %.1f%%",
+			currentValue, e.thresholds.DiskUsageWarning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric.
+func (e *MonitoringEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case "monitoring_up":
+		return 1, 1
+	case "monitoring_slow_queries":
+		return e.thresholds.SlowQueriesWarning, e.thresholds.SlowQueriesCritical
+	case "monitoring_merge_backlog":
+		return e.thresholds.MergeBacklogWarning, e.thresholds.MergeBacklogCritical
+	case "monitoring_disk_usage":
+		return e.thresholds.DiskUsageWarning, e.thresholds.DiskUsageCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *MonitoringEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{"monitoring_slow_queries", "monitoring_merge_backlog", "monitoring_disk_usage"})
+}
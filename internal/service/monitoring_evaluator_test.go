@@ -0,0 +1,133 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestMonitoringEvaluator creates a monitoring evaluator with default thresholds for testing.
+func createTestMonitoringEvaluator() *MonitoringEvaluator {
+	thresholds := &config.MonitoringThresholds{
+		SlowQueriesWarning:   10,
+		SlowQueriesCritical:  100,
+		MergeBacklogWarning:  1000000,
+		MergeBacklogCritical: 10000000,
+		DiskUsageWarning:     80,
+		DiskUsageCritical:    90,
+		AlertOnZeroIngestion: true,
+	}
+	metrics := []*model.MonitoringMetricDefinition{
+		{Name: "monitoring_up", DisplayName: "采集状态"},
+		{Name: "monitoring_ingestion_rate", DisplayName: "写入速率"},
+		{Name: "monitoring_disk_usage", DisplayName: "存储磁盘使用率"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewMonitoringEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// createTestMonitoringResult creates a test monitoring inspection result for an UP component.
+func createTestMonitoringResult() *model.MonitoringInspectionResult {
+	result := model.NewMonitoringInspectionResult(model.NewMonitoringInstance("10.0.0.1:8482", "10.0.0.1", 8482))
+	result.Up = true
+	result.SetMetric(&model.MonitoringMetricValue{Name: "monitoring_up", RawValue: 1})
+	return result
+}
+
+// =============================================================================
+// Target Status Tests
+// =============================================================================
+
+func TestMonitoringEvaluator_DownTarget(t *testing.T) {
+	evaluator := createTestMonitoringEvaluator()
+	result := model.NewMonitoringInspectionResult(model.NewMonitoringInstance("10.0.0.2:8481", "10.0.0.2", 8481))
+	result.SetMetric(&model.MonitoringMetricValue{Name: "monitoring_up", RawValue: 0})
+	result.IngestionRate = 0
+	result.DiskUsagePercent = 99
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.MonitoringStatusCritical {
+		t.Errorf("expected critical status for DOWN component, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 1 {
+		t.Fatalf("expected only the target status alert, got %d alerts", len(evalResult.Alerts))
+	}
+	if evalResult.Alerts[0].FormattedValue != model.MonitoringTargetStateDown {
+		t.Errorf("expected formatted value %s, got %s", model.MonitoringTargetStateDown, evalResult.Alerts[0].FormattedValue)
+	}
+}
+
+// =============================================================================
+// Threshold Tests
+// =============================================================================
+
+func TestMonitoringEvaluator_EvaluateThresholds(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(r *model.MonitoringInspectionResult)
+		metricName    string
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{"all not collected", func(r *model.MonitoringInspectionResult) {}, "", "", false},
+		{"ingestion normal", func(r *model.MonitoringInspectionResult) { r.IngestionRate = 5000 }, "", "", false},
+		{"ingestion stopped", func(r *model.MonitoringInspectionResult) { r.IngestionRate = 0 }, "monitoring_ingestion_rate", model.AlertLevelWarning, true},
+		{"slow queries warning", func(r *model.MonitoringInspectionResult) { r.SlowQueries = 20 }, "monitoring_slow_queries", model.AlertLevelWarning, true},
+		{"merge backlog critical", func(r *model.MonitoringInspectionResult) { r.MergeBacklog = 2e7 }, "monitoring_merge_backlog", model.AlertLevelCritical, true},
+		{"disk usage normal", func(r *model.MonitoringInspectionResult) { r.DiskUsagePercent = 50 }, "", "", false},
+		{"disk usage critical", func(r *model.MonitoringInspectionResult) { r.DiskUsagePercent = 95 }, "monitoring_disk_usage", model.AlertLevelCritical, true},
+	}
+
+	evaluator := createTestMonitoringEvaluator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestMonitoringResult()
+			tt.setup(result)
+
+			evalResult := evaluator.Evaluate(result)
+			if !tt.expectAlert {
+				if len(evalResult.Alerts) != 0 {
+					t.Errorf("expected no alert, got %d", len(evalResult.Alerts))
+				}
+				return
+			}
+			if len(evalResult.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(evalResult.Alerts))
+			}
+			alert := evalResult.Alerts[0]
+			if alert.MetricName != tt.metricName {
+				t.Errorf("expected metric %s, got %s", tt.metricName, alert.MetricName)
+			}
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+		})
+	}
+}
+
+func TestMonitoringEvaluator_ThresholdsDisabled(t *testing.T) {
+	evaluator := NewMonitoringEvaluator(&config.MonitoringThresholds{}, nil, nil, zerolog.Nop())
+	result := createTestMonitoringResult()
+	result.IngestionRate = 0
+	result.SlowQueries = 10000
+	result.MergeBacklog = 1e9
+	result.DiskUsagePercent = 99
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.MonitoringStatusNormal {
+		t.Errorf("expected normal status with disabled thresholds, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 0 {
+		t.Errorf("expected no alerts, got %d", len(evalResult.Alerts))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,10 +18,16 @@ import (
 	"inspection-tool/internal/model"
 )
 
+// MGR member metrics return one series per group member instead of one per instance.
+const (
+	mysqlMGRMembersMetric      = "mgr_members"
+	mysqlMGRMemberQueuedMetric = "mgr_member_transactions_queued"
+)
+
 // MySQLCollector is the data collection service for MySQL instances.
 // It integrates with VictoriaMetrics to collect MySQL monitoring metrics.
 type MySQLCollector struct {
-	vmClient       *vm.Client
+	vmClient       vm.MetricsSource
 	config         *config.MySQLInspectionConfig
 	metrics        []*model.MySQLMetricDefinition
 	instanceFilter *MySQLInstanceFilter
@@ -38,7 +45,7 @@ type MySQLInstanceFilter struct {
 // NewMySQLCollector creates a new MySQLCollector instance.
 func NewMySQLCollector(
 	cfg *config.MySQLInspectionConfig,
-	vmClient *vm.Client,
+	vmClient vm.MetricsSource,
 	metrics []*model.MySQLMetricDefinition,
 	logger zerolog.Logger,
 ) *MySQLCollector {
@@ -369,6 +376,14 @@ func (c *MySQLCollector) collectMetricConcurrent(
 			continue
 		}
 
+		// MGR member series are kept per member instead of as a single metric value
+		if metric.Name == mysqlMGRMembersMetric || metric.Name == mysqlMGRMemberQueuedMetric {
+			if inspResult, ok := resultsMap[address]; ok && setMGRMemberValue(inspResult, metric.Name, result.Labels, result.Value) {
+				matchedCount++
+			}
+			continue
+		}
+
 		// Add metric value to result
 		if inspResult, ok := resultsMap[address]; ok {
 			mv := &model.MySQLMetricValue{
@@ -391,6 +406,30 @@ func (c *MySQLCollector) collectMetricConcurrent(
 	return nil
 }
 
+// setMGRMemberValue records one MGR group member series on the instance that reported it.
+// Returns false for series without a member_id label.
+func setMGRMemberValue(result *model.MySQLInspectionResult, metricName string, labels map[string]string, value float64) bool {
+	memberID := labels["member_id"]
+	if memberID == "" {
+		return false
+	}
+
+	member := result.GetMGRMember(memberID)
+	switch metricName {
+	case mysqlMGRMembersMetric:
+		member.Host = labels["member_host"]
+		member.Port, _ = strconv.Atoi(labels["member_port"])
+		member.State = strings.ToUpper(labels["member_state"])
+		member.Role = model.ParseMySQLMGRRole(labels["member_role"])
+		if groupName := labels["group_name"]; groupName != "" {
+			result.MGRGroupName = groupName
+		}
+	case mysqlMGRMemberQueuedMetric:
+		member.TransactionsQueued = int(value)
+	}
+	return true
+}
+
 // collectLabelExtractMetric collects metrics that extract values from labels.
 // This handles special metrics like mysql_version (extracts "version" label)
 // and mgr_role_primary (extracts "member_id" label for Server ID).
@@ -529,10 +568,13 @@ func (c *MySQLCollector) CollectMetrics(
 
 	var mu sync.Mutex // Protects resultsMap from concurrent writes
 
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(filteredMetrics))
 	for _, metric := range filteredMetrics {
 		metric := metric // Capture loop variable
 		g.Go(func() error {
 			err := c.collectMetricConcurrent(ctx, metric, instances, resultsMap, &mu)
+			progress.QueryDone()
 			if err != nil {
 				c.logger.Warn().
 					Err(err).
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -1123,3 +1124,89 @@ func TestCollectMetrics_ClusterModeFiltering(t *testing.T) {
 		})
 	}
 }
+
+// TestCollectMetrics_MGRMembers tests per-member MGR series collection and grouping
+func TestCollectMetrics_MGRMembers(t *testing.T) {
+	server := setupMySQLVMTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+
+		w.Header().Set("Content-Type", "application/json")
+		timestamp := time.Now().Unix()
+
+		// Both instances report the same two-member group
+		var series []string
+		for _, address := range []string{"172.18.182.91:3306", "172.18.182.92:3306"} {
+			if contains(query, "mysql_innodb_cluster_mgr_member_info") {
+				series = append(series,
+					fmt.Sprintf(`{"metric": {"address": %q, "group_name": "g1", "member_id": "uuid-1", "member_host": "172.18.182.91", "member_port": "3306", "member_state": "ONLINE", "member_role": "PRIMARY"}, "value": [%d, "1"]}`, address, timestamp),
+					fmt.Sprintf(`{"metric": {"address": %q, "group_name": "g1", "member_id": "uuid-2", "member_host": "172.18.182.92", "member_port": "3306", "member_state": "RECOVERING", "member_role": "SECONDARY"}, "value": [%d, "1"]}`, address, timestamp),
+				)
+			} else if contains(query, "mysql_innodb_cluster_mgr_member_transactions_in_queue") {
+				series = append(series,
+					fmt.Sprintf(`{"metric": {"address": %q, "member_id": "uuid-2"}, "value": [%d, "7"]}`, address, timestamp),
+				)
+			}
+		}
+
+		jsonResp := fmt.Sprintf(`{"status": "success", "data": {"resultType": "vector", "result": [%s]}}`, strings.Join(series, ","))
+		w.Write([]byte(jsonResp))
+	})
+	defer server.Close()
+
+	cfg := &config.MySQLInspectionConfig{
+		ClusterMode: "mgr",
+	}
+
+	collector := createTestMySQLCollector(server.URL, cfg)
+
+	instances := []*model.MySQLInstance{
+		model.NewMySQLInstanceWithClusterMode("172.18.182.91:3306", model.ClusterModeMGR),
+		model.NewMySQLInstanceWithClusterMode("172.18.182.92:3306", model.ClusterModeMGR),
+	}
+
+	metrics := []*model.MySQLMetricDefinition{
+		{Name: "mgr_members", Query: "mysql_innodb_cluster_mgr_member_info", Category: "mgr", ClusterMode: "mgr"},
+		{Name: "mgr_member_transactions_queued", Query: "mysql_innodb_cluster_mgr_member_transactions_in_queue", Category: "mgr", ClusterMode: "mgr"},
+	}
+
+	ctx := context.Background()
+	results, err := collector.CollectMetrics(ctx, instances, metrics)
+
+	if err != nil {
+		t.Fatalf("CollectMetrics failed: %v", err)
+	}
+
+	result := results["172.18.182.91:3306"]
+	if len(result.MGRMembers) != 2 {
+		t.Fatalf("MGRMembers = %d, want 2", len(result.MGRMembers))
+	}
+	if result.MGRGroupName != "g1" {
+		t.Errorf("MGRGroupName = %q, want \"g1\"", result.MGRGroupName)
+	}
+	// Member series are not stored as single metric values
+	if result.GetMetric("mgr_members") != nil {
+		t.Error("mgr_members should not be stored as a metric value")
+	}
+
+	inspection := model.NewMySQLInspectionResults(time.Now())
+	for _, instance := range instances {
+		inspection.AddResult(results[instance.Address])
+	}
+
+	clusters := inspection.MGRClusters()
+	if len(clusters) != 1 {
+		t.Fatalf("MGRClusters = %d, want 1", len(clusters))
+	}
+	cluster := clusters[0]
+	if cluster.Name != "g1" || len(cluster.Members) != 2 || cluster.OnlineMembers() != 1 {
+		t.Errorf("unexpected cluster: name=%q members=%d online=%d", cluster.Name, len(cluster.Members), cluster.OnlineMembers())
+	}
+
+	primary, secondary := cluster.Members[0], cluster.Members[1]
+	if !primary.Role.IsPrimary() || primary.Address() != "172.18.182.91:3306" || primary.HasTransactionsQueued() {
+		t.Errorf("unexpected primary member: %+v", primary)
+	}
+	if secondary.State != model.MGRMemberStateRecovering || secondary.TransactionsQueued != 7 {
+		t.Errorf("unexpected secondary member: %+v", secondary)
+	}
+}
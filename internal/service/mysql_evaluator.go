@@ -85,6 +85,33 @@ func (e *MySQLEvaluator) Evaluate(
 		evalResult.Alerts = append(evalResult.Alerts, alert)
 	}
 
+	// 评估慢查询速率（阈值为 0 时跳过）
+	if alert := e.evaluateSlowQueries(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+
+	// 评估运行线程数（阈值为 0 时跳过）
+	if alert := e.evaluateThreadsRunning(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+
+	// 评估 InnoDB 健康指标（阈值为 0 时跳过）
+	if alert := e.evaluateBufferPoolHitRatio(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateRowLockWaits(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateDeadlocks(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateHistoryListLength(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+
+	// 评估字符串/枚举期望值检查
+	evalResult.Alerts = append(evalResult.Alerts, e.evaluateValueChecks(result)...)
+
 	// 如果是 MGR 模式，评估 MGR 指标
 	if result.Instance.ClusterMode.IsMGR() {
 		// 评估 MGR 成员数
@@ -142,6 +169,180 @@ func (e *MySQLEvaluator) evaluateConnectionUsage(
 	return nil // 正常，无告警
 }
 
+// evaluateSlowQueries evaluates the slow queries rate (per second).
+// Returns nil if both thresholds are 0 (disabled).
+func (e *MySQLEvaluator) evaluateSlowQueries(
+	result *model.MySQLInspectionResult,
+) *model.MySQLAlert {
+	rate := result.SlowQueriesPerSecond
+	warning := e.thresholds.SlowQueriesWarning
+	critical := e.thresholds.SlowQueriesCritical
+
+	if critical > 0 && rate >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"slow_queries",
+			rate,
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && rate >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"slow_queries",
+			rate,
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
+// evaluateThreadsRunning evaluates the number of running threads.
+// Returns nil if both thresholds are 0 (disabled).
+func (e *MySQLEvaluator) evaluateThreadsRunning(
+	result *model.MySQLInspectionResult,
+) *model.MySQLAlert {
+	running := result.ThreadsRunning
+	warning := e.thresholds.ThreadsRunningWarning
+	critical := e.thresholds.ThreadsRunningCritical
+
+	if critical > 0 && running >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"threads_running",
+			float64(running),
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && running >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"threads_running",
+			float64(running),
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
+// evaluateBufferPoolHitRatio evaluates the InnoDB buffer pool hit ratio.
+// Lower is worse: a ratio at or below a threshold alerts. Returns nil if the metric was not collected or both thresholds are 0 (disabled).
+func (e *MySQLEvaluator) evaluateBufferPoolHitRatio(
+	result *model.MySQLInspectionResult,
+) *model.MySQLAlert {
+	// 未采集到数据时不评估，避免命中率为 0 误报
+	if m := result.GetMetric("innodb_buffer_pool_hit_ratio"); m == nil || m.IsNA {
+		return nil
+	}
+
+	ratio := result.InnoDBBufferPoolHitRatio
+	threshold := belowThreshold(e.thresholds.InnoDBBufferPoolHitRatioWarning, e.thresholds.InnoDBBufferPoolHitRatioCritical)
+	if level := thresholdLevel(ratio, threshold); level != model.AlertLevelNormal {
+		return e.createAlert(
+			result.GetAddress(),
+			"innodb_buffer_pool_hit_ratio",
+			ratio,
+			level,
+		)
+	}
+
+	return nil
+}
+
+// evaluateRowLockWaits evaluates the InnoDB row lock waits rate (per second).
+// Returns nil if both thresholds are 0 (disabled).
+func (e *MySQLEvaluator) evaluateRowLockWaits(
+	result *model.MySQLInspectionResult,
+) *model.MySQLAlert {
+	rate := result.InnoDBRowLockWaitsPerSecond
+	warning := e.thresholds.InnoDBRowLockWaitsWarning
+	critical := e.thresholds.InnoDBRowLockWaitsCritical
+
+	if critical > 0 && rate >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"innodb_row_lock_waits",
+			rate,
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && rate >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"innodb_row_lock_waits",
+			rate,
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
+// evaluateDeadlocks evaluates the number of InnoDB deadlocks in the last hour.
+// Returns nil if both thresholds are 0 (disabled).
+func (e *MySQLEvaluator) evaluateDeadlocks(
+	result *model.MySQLInspectionResult,
+) *model.MySQLAlert {
+	deadlocks := result.InnoDBDeadlocks
+	warning := e.thresholds.InnoDBDeadlocksWarning
+	critical := e.thresholds.InnoDBDeadlocksCritical
+
+	if critical > 0 && deadlocks >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"innodb_deadlocks",
+			float64(deadlocks),
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && deadlocks >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"innodb_deadlocks",
+			float64(deadlocks),
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
+// evaluateHistoryListLength evaluates the InnoDB undo history list length.
+// Returns nil if both thresholds are 0 (disabled).
+func (e *MySQLEvaluator) evaluateHistoryListLength(
+	result *model.MySQLInspectionResult,
+) *model.MySQLAlert {
+	length := result.InnoDBHistoryListLength
+	warning := e.thresholds.InnoDBHistoryListLengthWarning
+	critical := e.thresholds.InnoDBHistoryListLengthCritical
+
+	if critical > 0 && length >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"innodb_history_list_length",
+			float64(length),
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && length >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"innodb_history_list_length",
+			float64(length),
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
 // evaluateMGRMemberCount evaluates MGR cluster member count.
 func (e *MySQLEvaluator) evaluateMGRMemberCount(
 	result *model.MySQLInspectionResult,
@@ -189,6 +390,49 @@ func (e *MySQLEvaluator) evaluateMGRStateOnline(
 	return nil
 }
 
+// evaluateValueChecks evaluates configured expected-value checks against
+// string-valued metrics. Missing or N/A metrics are skipped.
+func (e *MySQLEvaluator) evaluateValueChecks(
+	result *model.MySQLInspectionResult,
+) []*model.MySQLAlert {
+	alerts := make([]*model.MySQLAlert, 0)
+
+	for i := range e.thresholds.ValueChecks {
+		check := &e.thresholds.ValueChecks[i]
+
+		mv := result.GetMetric(check.Metric)
+		if mv == nil || mv.IsNA || mv.StringValue == "" {
+			e.logger.Debug().
+				Str("address", result.GetAddress()).
+				Str("metric", check.Metric).
+				Msg("skipping value check: metric value not available")
+			continue
+		}
+
+		if check.Matches(mv.StringValue) {
+			continue
+		}
+
+		displayName := check.Metric
+		if def, exists := e.metricDefs[check.Metric]; exists {
+			displayName = def.GetDisplayName()
+		}
+
+		alerts = append(alerts, &model.MySQLAlert{
+			Address:           result.GetAddress(),
+			MetricName:        check.Metric,
+			MetricDisplayName: displayName,
+			CurrentValue:      mv.RawValue,
+			FormattedValue:    mv.StringValue,
+			Level:             valueCheckLevel(check),
+			Message:           valueCheckMessage(displayName, mv.StringValue, check),
+			ExpectedValue:     check.Expected(),
+		})
+	}
+
+	return alerts
+}
+
 // determineInstanceStatus determines the overall instance status based on alerts.
 // 状态聚合优先级：Critical > Warning > Normal
 func (e *MySQLEvaluator) determineInstanceStatus(
@@ -257,11 +501,18 @@ func (e *MySQLEvaluator) formatValue(value float64, metricName string) string {
 		return fmt.Sprintf("%.1f%%", value)
 	case "mgr_member_count":
 		return fmt.Sprintf("%d", int(value))
+	case "slow_queries":
+		return fmt.Sprintf("%.2f/s", value)
+	case "threads_running":
+		return fmt.Sprintf("%d", int(value))
+	case "innodb_buffer_pool_hit_ratio":
+		return fmt.Sprintf("%.2f%%", value)
+	case "innodb_row_lock_waits":
+		return fmt.Sprintf("%.2f/s", value)
+	case "innodb_deadlocks", "innodb_history_list_length":
+		return fmt.Sprintf("%d", int(value))
 	case "mgr_state_online":
-		if value == 0 {
-			return "离线"
-		}
-		return "在线"
+		return model.FormatBool("mgr_state_online", value != 0)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -298,6 +549,54 @@ func (e *MySQLEvaluator) generateAlertMessage(
 	case "mgr_state_online":
 		return "MGR 节点离线（mgr_state_online = 0）"
 
+	case "slow_queries":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("慢查询速率为 %.2f/s，已超过严重阈值 %.2f/s",
+				currentValue, criticalThreshold)
+		}
+		return fmt.Sprintf("慢查询速率为 %.2f/s，已超过警告阈值 %.2f/s",
+			currentValue, warningThreshold)
+
+	case "threads_running":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("运行线程数为 %d，已超过严重阈值 %d",
+				int(currentValue), int(criticalThreshold))
+		}
+		return fmt.Sprintf("运行线程数为 %d，已超过警告阈值 %d",
+			int(currentValue), int(warningThreshold))
+
+	case "innodb_buffer_pool_hit_ratio":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("InnoDB 缓冲池命中率为 %.2f%%，已低于严重阈值 %.2f%%",
+				currentValue, criticalThreshold)
+		}
+		return fmt.Sprintf("InnoDB 缓冲池命中率为 %.2f%%，已低于警告阈值 %.2f%%",
+			currentValue, warningThreshold)
+
+	case "innodb_row_lock_waits":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("InnoDB 行锁等待速率为 %.2f/s，已超过严重阈值 %.2f/s",
+				currentValue, criticalThreshold)
+		}
+		return fmt.Sprintf("InnoDB 行锁等待速率为 %.2f/s，已超过警告阈值 %.2f/s",
+			currentValue, warningThreshold)
+
+	case "innodb_deadlocks":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("最近 1 小时 InnoDB 死锁 %d 次，已超过严重阈值 %d",
+				int(currentValue), int(criticalThreshold))
+		}
+		return fmt.Sprintf("最近 1 小时 InnoDB 死锁 %d 次，已超过警告阈值 %d",
+			int(currentValue), int(warningThreshold))
+
+	case "innodb_history_list_length":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("InnoDB History List 长度为 %d，已超过严重阈值 %d，可能存在长事务",
+				int(currentValue), int(criticalThreshold))
+		}
+		return fmt.Sprintf("InnoDB History List 长度为 %d，已超过警告阈值 %d，可能存在长事务",
+			int(currentValue), int(warningThreshold))
+
 	default:
 		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
 	}
@@ -311,9 +610,34 @@ func (e *MySQLEvaluator) getThresholds(metricName string) (warning float64, crit
 	case "mgr_member_count":
 		expected := float64(e.thresholds.MGRMemberCountExpected)
 		return expected - 1, expected - 2 // 警告: expected-1, 严重: expected-2
+	case "slow_queries":
+		return e.thresholds.SlowQueriesWarning, e.thresholds.SlowQueriesCritical
+	case "threads_running":
+		return float64(e.thresholds.ThreadsRunningWarning), float64(e.thresholds.ThreadsRunningCritical)
+	case "innodb_buffer_pool_hit_ratio":
+		return e.thresholds.InnoDBBufferPoolHitRatioWarning, e.thresholds.InnoDBBufferPoolHitRatioCritical
+	case "innodb_row_lock_waits":
+		return e.thresholds.InnoDBRowLockWaitsWarning, e.thresholds.InnoDBRowLockWaitsCritical
+	case "innodb_deadlocks":
+		return float64(e.thresholds.InnoDBDeadlocksWarning), float64(e.thresholds.InnoDBDeadlocksCritical)
+	case "innodb_history_list_length":
+		return float64(e.thresholds.InnoDBHistoryListLengthWarning), float64(e.thresholds.InnoDBHistoryListLengthCritical)
 	case "mgr_state_online":
 		return 1, 1 // 离线即严重，无警告阈值
 	default:
 		return 0, 0
 	}
 }
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *MySQLEvaluator) Thresholds() []ModuleThreshold {
+	metrics := []string{
+		"connection_usage", "slow_queries", "threads_running", "innodb_buffer_pool_hit_ratio",
+		"innodb_row_lock_waits", "innodb_deadlocks", "innodb_history_list_length",
+	}
+	// The member count is checked against the expected size of the group, when one is set
+	if e.thresholds.MGRMemberCountExpected > 0 {
+		metrics = append(metrics, "mgr_member_count")
+	}
+	return moduleThresholds(e.getThresholds, metrics, "innodb_buffer_pool_hit_ratio", "mgr_member_count")
+}
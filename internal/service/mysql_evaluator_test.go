@@ -1,6 +1,8 @@
 package service
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +22,19 @@ func createTestMySQLThresholds() *config.MySQLThresholds {
 		ConnectionUsageWarning:  70,
 		ConnectionUsageCritical: 90,
 		MGRMemberCountExpected:  3,
+		SlowQueriesWarning:      1,
+		SlowQueriesCritical:     5,
+		ThreadsRunningWarning:   32,
+		ThreadsRunningCritical:  64,
+
+		InnoDBBufferPoolHitRatioWarning:  99,
+		InnoDBBufferPoolHitRatioCritical: 95,
+		InnoDBRowLockWaitsWarning:        10,
+		InnoDBRowLockWaitsCritical:       50,
+		InnoDBDeadlocksWarning:           1,
+		InnoDBDeadlocksCritical:          10,
+		InnoDBHistoryListLengthWarning:   100000,
+		InnoDBHistoryListLengthCritical:  1000000,
 	}
 }
 
@@ -29,6 +44,12 @@ func createTestMySQLMetricDefs() []*model.MySQLMetricDefinition {
 		{Name: "connection_usage", DisplayName: "连接使用率"},
 		{Name: "mgr_member_count", DisplayName: "MGR 成员数"},
 		{Name: "mgr_state_online", DisplayName: "MGR 在线状态"},
+		{Name: "slow_queries", DisplayName: "慢查询速率"},
+		{Name: "threads_running", DisplayName: "运行线程数"},
+		{Name: "innodb_buffer_pool_hit_ratio", DisplayName: "缓冲池命中率"},
+		{Name: "innodb_row_lock_waits", DisplayName: "行锁等待速率"},
+		{Name: "innodb_deadlocks", DisplayName: "死锁数"},
+		{Name: "innodb_history_list_length", DisplayName: "History List 长度"},
 		{Name: "mysql_up", DisplayName: "连接状态"},
 	}
 }
@@ -156,6 +177,232 @@ func TestEvaluateConnectionUsage(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// TestEvaluateSlowQueries - 慢查询速率评估测试
+// =============================================================================
+
+func TestEvaluateSlowQueries(t *testing.T) {
+	evaluator := createTestMySQLEvaluator()
+
+	tests := []struct {
+		name          string
+		rate          float64
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{name: "0.5/s - Normal", rate: 0.5, expectAlert: false},
+		{name: "Exactly 1/s - Warning", rate: 1, expectedLevel: model.AlertLevelWarning, expectAlert: true},
+		{name: "3/s - Warning", rate: 3, expectedLevel: model.AlertLevelWarning, expectAlert: true},
+		{name: "8/s - Critical", rate: 8, expectedLevel: model.AlertLevelCritical, expectAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+			result.SlowQueriesPerSecond = tt.rate
+
+			alert := evaluator.evaluateSlowQueries(result)
+
+			if tt.expectAlert {
+				if alert == nil {
+					t.Fatal("expected alert but got nil")
+				}
+				if alert.Level != tt.expectedLevel {
+					t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+				}
+				if alert.MetricName != "slow_queries" {
+					t.Errorf("expected metric name 'slow_queries', got %s", alert.MetricName)
+				}
+			} else if alert != nil {
+				t.Errorf("expected no alert but got level %s", alert.Level)
+			}
+		})
+	}
+}
+
+func TestEvaluateSlowQueries_Disabled(t *testing.T) {
+	thresholds := createTestMySQLThresholds()
+	thresholds.SlowQueriesWarning = 0
+	thresholds.SlowQueriesCritical = 0
+	evaluator := NewMySQLEvaluator(thresholds, createTestMySQLMetricDefs(), zerolog.Nop())
+
+	result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+	result.SlowQueriesPerSecond = 100
+
+	if alert := evaluator.evaluateSlowQueries(result); alert != nil {
+		t.Errorf("expected no alert when thresholds disabled, got level %s", alert.Level)
+	}
+}
+
+// =============================================================================
+// TestEvaluateThreadsRunning - 运行线程数评估测试
+// =============================================================================
+
+func TestEvaluateThreadsRunning(t *testing.T) {
+	evaluator := createTestMySQLEvaluator()
+
+	tests := []struct {
+		name          string
+		running       int
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{name: "8 threads - Normal", running: 8, expectAlert: false},
+		{name: "Exactly 32 - Warning", running: 32, expectedLevel: model.AlertLevelWarning, expectAlert: true},
+		{name: "Exactly 64 - Critical", running: 64, expectedLevel: model.AlertLevelCritical, expectAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+			result.ThreadsRunning = tt.running
+
+			alert := evaluator.evaluateThreadsRunning(result)
+
+			if tt.expectAlert {
+				if alert == nil {
+					t.Fatal("expected alert but got nil")
+				}
+				if alert.Level != tt.expectedLevel {
+					t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+				}
+				if alert.FormattedValue != fmt.Sprintf("%d", tt.running) {
+					t.Errorf("unexpected formatted value %s", alert.FormattedValue)
+				}
+			} else if alert != nil {
+				t.Errorf("expected no alert but got level %s", alert.Level)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// TestEvaluateInnoDB - InnoDB 健康指标评估测试
+// =============================================================================
+
+func TestEvaluateBufferPoolHitRatio(t *testing.T) {
+	evaluator := createTestMySQLEvaluator()
+
+	tests := []struct {
+		name          string
+		ratio         float64
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{name: "99.9% - Normal", ratio: 99.9, expectAlert: false},
+		{name: "Exactly 99% - Warning", ratio: 99, expectedLevel: model.AlertLevelWarning, expectAlert: true}, // Below thresholds include the threshold
+		{name: "Exactly 95% - Critical", ratio: 95, expectedLevel: model.AlertLevelCritical, expectAlert: true},
+		{name: "97% - Warning", ratio: 97, expectedLevel: model.AlertLevelWarning, expectAlert: true},
+		{name: "80% - Critical", ratio: 80, expectedLevel: model.AlertLevelCritical, expectAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+			result.SetMetric(&model.MySQLMetricValue{Name: "innodb_buffer_pool_hit_ratio", RawValue: tt.ratio})
+			result.InnoDBBufferPoolHitRatio = tt.ratio
+
+			alert := evaluator.evaluateBufferPoolHitRatio(result)
+
+			if tt.expectAlert {
+				if alert == nil {
+					t.Fatal("expected alert but got nil")
+				}
+				if alert.Level != tt.expectedLevel {
+					t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+				}
+				if !strings.Contains(alert.Message, "低于") {
+					t.Errorf("expected lower-bound message, got %q", alert.Message)
+				}
+			} else if alert != nil {
+				t.Errorf("expected no alert but got level %s", alert.Level)
+			}
+		})
+	}
+}
+
+func TestEvaluateBufferPoolHitRatio_NotCollected(t *testing.T) {
+	evaluator := createTestMySQLEvaluator()
+
+	// 未采集到指标时命中率为 0，不应告警
+	result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+
+	if alert := evaluator.evaluateBufferPoolHitRatio(result); alert != nil {
+		t.Errorf("expected no alert when metric not collected, got level %s", alert.Level)
+	}
+}
+
+func TestEvaluateRowLockWaits(t *testing.T) {
+	evaluator := createTestMySQLEvaluator()
+
+	result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+	result.InnoDBRowLockWaitsPerSecond = 12.5
+
+	alert := evaluator.evaluateRowLockWaits(result)
+	if alert == nil {
+		t.Fatal("expected alert but got nil")
+	}
+	if alert.Level != model.AlertLevelWarning {
+		t.Errorf("expected level warning, got %s", alert.Level)
+	}
+	if alert.FormattedValue != "12.50/s" {
+		t.Errorf("unexpected formatted value %s", alert.FormattedValue)
+	}
+}
+
+func TestEvaluateDeadlocks(t *testing.T) {
+	evaluator := createTestMySQLEvaluator()
+
+	tests := []struct {
+		name          string
+		deadlocks     int
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{name: "0 - Normal", deadlocks: 0, expectAlert: false},
+		{name: "1 - Warning", deadlocks: 1, expectedLevel: model.AlertLevelWarning, expectAlert: true},
+		{name: "10 - Critical", deadlocks: 10, expectedLevel: model.AlertLevelCritical, expectAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+			result.InnoDBDeadlocks = tt.deadlocks
+
+			alert := evaluator.evaluateDeadlocks(result)
+
+			if tt.expectAlert {
+				if alert == nil {
+					t.Fatal("expected alert but got nil")
+				}
+				if alert.Level != tt.expectedLevel {
+					t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+				}
+			} else if alert != nil {
+				t.Errorf("expected no alert but got level %s", alert.Level)
+			}
+		})
+	}
+}
+
+func TestEvaluateHistoryListLength(t *testing.T) {
+	evaluator := createTestMySQLEvaluator()
+
+	result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+	result.InnoDBHistoryListLength = 2000000
+
+	alert := evaluator.evaluateHistoryListLength(result)
+	if alert == nil {
+		t.Fatal("expected alert but got nil")
+	}
+	if alert.Level != model.AlertLevelCritical {
+		t.Errorf("expected level critical, got %s", alert.Level)
+	}
+	if alert.CriticalThreshold != 1000000 {
+		t.Errorf("expected critical threshold 1000000, got %v", alert.CriticalThreshold)
+	}
+}
+
 // =============================================================================
 // TestEvaluateMGRMemberCount - MGR 成员数评估测试
 // =============================================================================
@@ -536,3 +783,88 @@ func TestFormatValue(t *testing.T) {
 		})
 	}
 }
+
+// =============================================================================
+// 字符串/枚举期望值检查测试
+// =============================================================================
+
+func TestMySQLEvaluator_ValueChecks(t *testing.T) {
+	thresholds := createTestMySQLThresholds()
+	thresholds.ValueChecks = []config.ValueCheck{
+		{Metric: "mysql_version", Operator: config.ValueCheckPrefix, Values: []string{"8.0"}},
+	}
+	defs := append(createTestMySQLMetricDefs(), &model.MySQLMetricDefinition{Name: "mysql_version", DisplayName: "数据库版本"})
+	evaluator := NewMySQLEvaluator(thresholds, defs, zerolog.Nop())
+
+	tests := []struct {
+		name          string
+		version       string
+		expectAlert   bool
+		expectedLevel model.AlertLevel
+	}{
+		{"matching prefix", "8.0.39", false, ""},
+		{"mismatching prefix", "5.7.44", true, model.AlertLevelWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMGR)
+			result.SetMetric(&model.MySQLMetricValue{Name: "mysql_version", RawValue: 1, StringValue: tt.version})
+
+			alerts := evaluator.evaluateValueChecks(result)
+			if !tt.expectAlert {
+				if len(alerts) != 0 {
+					t.Errorf("expected no alerts, got %d", len(alerts))
+				}
+				return
+			}
+			if len(alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(alerts))
+			}
+			alert := alerts[0]
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+			if alert.FormattedValue != tt.version {
+				t.Errorf("expected formatted value %s, got %s", tt.version, alert.FormattedValue)
+			}
+			if alert.ExpectedValue != "8.0*" {
+				t.Errorf("expected ExpectedValue 8.0*, got %s", alert.ExpectedValue)
+			}
+			if !strings.Contains(alert.Message, "数据库版本为 5.7.44") {
+				t.Errorf("unexpected message: %s", alert.Message)
+			}
+		})
+	}
+}
+
+func TestMySQLEvaluator_ValueChecks_CriticalLevelAndMissingMetric(t *testing.T) {
+	thresholds := createTestMySQLThresholds()
+	thresholds.ValueChecks = []config.ValueCheck{
+		{Metric: "slow_query_log_file", Operator: config.ValueCheckEquals, Values: []string{"/data/mysql/slow.log"}, Level: "critical"},
+		{Metric: "mysql_version", Operator: config.ValueCheckPrefix, Values: []string{"8.0"}},
+	}
+	evaluator := NewMySQLEvaluator(thresholds, createTestMySQLMetricDefs(), zerolog.Nop())
+
+	result := createTestMySQLInspectionResult("172.18.182.91:3306", model.ClusterModeMasterSlave)
+	result.SetMetric(&model.MySQLMetricValue{Name: "slow_query_log_file", StringValue: "/tmp/slow.log"})
+	// mysql_version not collected: check is skipped
+
+	evalResult := evaluator.Evaluate(result)
+
+	var valueAlerts []*model.MySQLAlert
+	for _, a := range evalResult.Alerts {
+		if a.ExpectedValue != "" {
+			valueAlerts = append(valueAlerts, a)
+		}
+	}
+	if len(valueAlerts) != 1 {
+		t.Fatalf("expected 1 value check alert, got %d", len(valueAlerts))
+	}
+	if valueAlerts[0].Level != model.AlertLevelCritical {
+		t.Errorf("expected critical level, got %s", valueAlerts[0].Level)
+	}
+	if evalResult.Status != model.MySQLStatusCritical {
+		t.Errorf("expected critical status, got %s", evalResult.Status)
+	}
+}
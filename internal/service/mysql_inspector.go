@@ -171,6 +171,30 @@ func (i *MySQLInspector) Inspect(ctx context.Context) (*model.MySQLInspectionRes
 		if currConnMetric := inspResult.GetMetric("current_connections"); currConnMetric != nil {
 			inspResult.CurrentConnections = int(currConnMetric.RawValue)
 		}
+		if qpsMetric := inspResult.GetMetric("qps"); qpsMetric != nil {
+			inspResult.QPS = qpsMetric.RawValue
+		}
+		if tpsMetric := inspResult.GetMetric("tps"); tpsMetric != nil {
+			inspResult.TPS = tpsMetric.RawValue
+		}
+		if slowMetric := inspResult.GetMetric("slow_queries"); slowMetric != nil {
+			inspResult.SlowQueriesPerSecond = slowMetric.RawValue
+		}
+		if threadsMetric := inspResult.GetMetric("threads_running"); threadsMetric != nil {
+			inspResult.ThreadsRunning = int(threadsMetric.RawValue)
+		}
+		if hitRatioMetric := inspResult.GetMetric("innodb_buffer_pool_hit_ratio"); hitRatioMetric != nil {
+			inspResult.InnoDBBufferPoolHitRatio = hitRatioMetric.RawValue
+		}
+		if lockWaitsMetric := inspResult.GetMetric("innodb_row_lock_waits"); lockWaitsMetric != nil {
+			inspResult.InnoDBRowLockWaitsPerSecond = lockWaitsMetric.RawValue
+		}
+		if deadlocksMetric := inspResult.GetMetric("innodb_deadlocks"); deadlocksMetric != nil {
+			inspResult.InnoDBDeadlocks = int(deadlocksMetric.RawValue)
+		}
+		if historyMetric := inspResult.GetMetric("innodb_history_list_length"); historyMetric != nil {
+			inspResult.InnoDBHistoryListLength = int(historyMetric.RawValue)
+		}
 		if mgrCountMetric := inspResult.GetMetric("mgr_member_count"); mgrCountMetric != nil {
 			inspResult.MGRMemberCount = int(mgrCountMetric.RawValue)
 		}
@@ -4,7 +4,7 @@ package service
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,7 +23,7 @@ import (
 // It integrates with VictoriaMetrics to collect Nginx monitoring metrics
 // and N9E to obtain host IP addresses.
 type NginxCollector struct {
-	vmClient       *vm.Client
+	vmClient       vm.MetricsSource
 	n9eClient      *n9e.Client // 用于获取 IP 地址
 	config         *config.NginxInspectionConfig
 	metrics        []*model.NginxMetricDefinition
@@ -43,7 +43,7 @@ type NginxInstanceFilter struct {
 // NewNginxCollector creates a new NginxCollector instance.
 func NewNginxCollector(
 	cfg *config.NginxInspectionConfig,
-	vmClient *vm.Client,
+	vmClient vm.MetricsSource,
 	n9eClient *n9e.Client,
 	metrics []*model.NginxMetricDefinition,
 	logger zerolog.Logger,
@@ -163,18 +163,14 @@ func (c *NginxCollector) DiscoverInstances(ctx context.Context) ([]*model.NginxI
 
 	for _, result := range results {
 		// 3.1 Extract hostname
-		hostname := result.Labels["agent_hostname"]
+		hostname := labelHostname(result.Labels)
 		if hostname == "" {
-			// Fallback to ident label if agent_hostname is missing
-			hostname = result.Labels["ident"]
-			if hostname == "" {
-				c.logger.Warn().Interface("labels", result.Labels).Msg("missing both agent_hostname and ident labels")
-				continue
-			}
+			c.logger.Warn().Interface("labels", result.Labels).Msg("missing hostname labels")
+			continue
 		}
 
 		// 3.2 Apply hostname pattern filter
-		if !c.matchesHostnamePatterns(hostname) {
+		if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
 			c.logger.Debug().Str("hostname", hostname).Msg("hostname filtered out")
 			continue
 		}
@@ -230,7 +226,7 @@ func (c *NginxCollector) DiscoverInstances(ctx context.Context) ([]*model.NginxI
 		}
 
 		// 3.8 Get IP address from N9E API
-		ip := c.getIPFromN9E(ctx, hostname)
+		ip := n9eHostIP(ctx, c.n9eClient, hostname, c.logger)
 		instance.SetIP(ip)
 
 		instances = append(instances, instance)
@@ -257,11 +253,7 @@ func (c *NginxCollector) getContainerMap(ctx context.Context, vmFilter *vm.HostF
 	}
 
 	for _, result := range results {
-		// First try agent_hostname, fallback to ident
-		hostname := result.Labels["agent_hostname"]
-		if hostname == "" {
-			hostname = result.Labels["ident"]
-		}
+		hostname := labelHostname(result.Labels)
 		container := result.Labels["container"]
 		if hostname != "" && container != "" {
 			containerMap[hostname] = container
@@ -271,75 +263,6 @@ func (c *NginxCollector) getContainerMap(ctx context.Context, vmFilter *vm.HostF
 	return containerMap
 }
 
-// getIPFromN9E retrieves the IP address for a hostname from N9E API.
-// Returns "N/A" if the hostname is not found or an error occurs.
-func (c *NginxCollector) getIPFromN9E(ctx context.Context, hostname string) string {
-	if c.n9eClient == nil {
-		return "N/A"
-	}
-
-	hostMeta, err := c.n9eClient.GetHostMetaByIdent(ctx, hostname)
-	if err != nil {
-		c.logger.Debug().
-			Err(err).
-			Str("hostname", hostname).
-			Msg("failed to get host meta from N9E")
-		return "N/A"
-	}
-
-	if hostMeta == nil || hostMeta.IP == "" {
-		return "N/A"
-	}
-
-	return hostMeta.IP
-}
-
-// matchesHostnamePatterns checks if a hostname matches any configured patterns.
-// Returns true if no patterns configured or hostname matches at least one pattern.
-func (c *NginxCollector) matchesHostnamePatterns(hostname string) bool {
-	if c.instanceFilter == nil || len(c.instanceFilter.HostnamePatterns) == 0 {
-		return true
-	}
-
-	for _, pattern := range c.instanceFilter.HostnamePatterns {
-		if matchHostnamePattern(hostname, pattern) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// matchHostnamePattern checks if a hostname matches a pattern with wildcard support.
-// Supports wildcard '*' which matches any sequence of characters.
-// Examples:
-//   - "GX-NM-*" matches "GX-NM-MNS-NGX-01"
-//   - "*-NGX-*" matches "GX-NM-MNS-NGX-01"
-//   - "*" matches all hostnames
-func matchHostnamePattern(hostname, pattern string) bool {
-	// Exact match optimization
-	if hostname == pattern {
-		return true
-	}
-
-	// No wildcard
-	if !strings.Contains(pattern, "*") {
-		return false
-	}
-
-	// Convert to regex
-	regexPattern := regexp.QuoteMeta(pattern)
-	regexPattern = strings.ReplaceAll(regexPattern, "\\*", ".*")
-	regexPattern = "^" + regexPattern + "$"
-
-	re, err := regexp.Compile(regexPattern)
-	if err != nil {
-		return false
-	}
-
-	return re.MatchString(hostname)
-}
-
 // =============================================================================
 // Nginx 指标采集
 // =============================================================================
@@ -397,10 +320,13 @@ func (c *NginxCollector) CollectMetrics(
 
 	var mu sync.Mutex // Protects resultsMap from concurrent writes
 
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
 	for _, metric := range activeMetrics {
 		metric := metric // Capture loop variable
 		g.Go(func() error {
 			err := c.collectMetricConcurrent(ctx, metric, instances, resultsMap, &mu)
+			progress.QueryDone()
 			if err != nil {
 				c.logger.Warn().
 					Err(err).
@@ -491,17 +417,13 @@ func (c *NginxCollector) collectMetricConcurrent(
 
 	matchedCount := 0
 	for _, result := range results {
-		// First try agent_hostname, fallback to ident
-		hostname := result.Labels["agent_hostname"]
-		if hostname == "" {
-			hostname = result.Labels["ident"]
-		}
+		hostname := labelHostname(result.Labels)
 		if hostname == "" {
 			continue
 		}
 
 		// Apply hostname pattern filtering (post-filter)
-		if !c.matchesHostnamePatterns(hostname) {
+		if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
 			continue
 		}
 
@@ -565,17 +487,13 @@ func (c *NginxCollector) collectLabelExtractMetric(
 
 	matchedCount := 0
 	for _, result := range results {
-		// First try agent_hostname, fallback to ident
-		hostname := result.Labels["agent_hostname"]
-		if hostname == "" {
-			hostname = result.Labels["ident"]
-		}
+		hostname := labelHostname(result.Labels)
 		if hostname == "" {
 			continue
 		}
 
 		// Apply hostname pattern filtering
-		if !c.matchesHostnamePatterns(hostname) {
+		if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
 			continue
 		}
 
@@ -723,17 +641,13 @@ func (c *NginxCollector) CollectUpstreamStatus(
 
 	// Step 4: Process status results
 	for _, result := range statusResults {
-		// First try agent_hostname, fallback to ident
-		hostname := result.Labels["agent_hostname"]
-		if hostname == "" {
-			hostname = result.Labels["ident"]
-		}
+		hostname := labelHostname(result.Labels)
 		if hostname == "" {
 			continue
 		}
 
 		// Apply hostname filter
-		if !c.matchesHostnamePatterns(hostname) {
+		if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
 			continue
 		}
 
@@ -774,17 +688,72 @@ func (c *NginxCollector) CollectUpstreamStatus(
 	return nil
 }
 
+// CollectUpstream5xxRates collects per-upstream 5xx response rates from VictoriaMetrics.
+// Uses the query of the nginx_upstream_5xx_rate metric definition; the metric is
+// skipped when it is not defined or marked as pending.
+func (c *NginxCollector) CollectUpstream5xxRates(
+	ctx context.Context,
+	resultsMap map[string]*model.NginxInspectionResult,
+) error {
+	metric, exists := c.metricDefs["nginx_upstream_5xx_rate"]
+	if !exists || metric.IsPending() || metric.Query == "" {
+		c.logger.Debug().Msg("nginx_upstream_5xx_rate not configured, skipping upstream 5xx collection")
+		return nil
+	}
+
+	c.logger.Debug().Msg("collecting Nginx upstream 5xx rates")
+
+	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, c.instanceFilter.ToVMHostFilter())
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("failed to query nginx_upstream_5xx_rate")
+		return nil // Non-fatal: upstream traffic metrics are optional
+	}
+
+	hostnameToIdentifier := make(map[string]string)
+	for identifier, result := range resultsMap {
+		if result.Instance != nil {
+			hostnameToIdentifier[result.Instance.Hostname] = identifier
+		}
+	}
+
+	for _, result := range results {
+		hostname := labelHostname(result.Labels)
+		upstreamName := result.Labels["upstream"]
+		if hostname == "" || upstreamName == "" {
+			continue
+		}
+
+		if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
+			continue
+		}
+
+		identifier, exists := hostnameToIdentifier[hostname]
+		if !exists {
+			continue
+		}
+
+		// NaN occurs when an upstream received no requests in the window
+		if math.IsNaN(result.Value) || math.IsInf(result.Value, 0) {
+			continue
+		}
+
+		resultsMap[identifier].SetUpstream5xxRate(upstreamName, result.Value)
+	}
+
+	c.logger.Info().
+		Int("rate_results", len(results)).
+		Msg("Nginx upstream 5xx rate collection completed")
+
+	return nil
+}
+
 // buildUpstreamValueMap builds a map from upstream results.
 // Key format: "hostname:upstream:backend"
 func (c *NginxCollector) buildUpstreamValueMap(results []vm.QueryResult) map[string]float64 {
 	valueMap := make(map[string]float64)
 
 	for _, result := range results {
-		// First try agent_hostname, fallback to ident
-		hostname := result.Labels["agent_hostname"]
-		if hostname == "" {
-			hostname = result.Labels["ident"]
-		}
+		hostname := labelHostname(result.Labels)
 		upstreamName := result.Labels["upstream"]
 		backendAddr := result.Labels["name"]
 
@@ -447,11 +447,11 @@ func TestNginxCollector_DiscoverInstances_DuplicateInstances(t *testing.T) {
 }
 
 // =============================================================================
-// matchHostnamePattern Tests
+// matchPattern Tests
 // =============================================================================
 
-// TestMatchHostnamePattern tests hostname pattern matching with wildcards.
-func TestMatchHostnamePattern(t *testing.T) {
+// TestMatchPattern tests hostname pattern matching with wildcards.
+func TestMatchPattern(t *testing.T) {
 	tests := []struct {
 		name     string
 		hostname string
@@ -473,17 +473,17 @@ func TestMatchHostnamePattern(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := matchHostnamePattern(tt.hostname, tt.pattern)
+			result := matchPattern(tt.hostname, tt.pattern)
 			if result != tt.expected {
-				t.Errorf("matchHostnamePattern(%q, %q) = %v, want %v",
+				t.Errorf("matchPattern(%q, %q) = %v, want %v",
 					tt.hostname, tt.pattern, result, tt.expected)
 			}
 		})
 	}
 }
 
-// TestMatchHostnamePattern_EdgeCases tests edge cases for hostname pattern matching.
-func TestMatchHostnamePattern_EdgeCases(t *testing.T) {
+// TestMatchPattern_EdgeCases tests edge cases for hostname pattern matching.
+func TestMatchPattern_EdgeCases(t *testing.T) {
 	tests := []struct {
 		name     string
 		hostname string
@@ -500,9 +500,9 @@ func TestMatchHostnamePattern_EdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := matchHostnamePattern(tt.hostname, tt.pattern)
+			result := matchPattern(tt.hostname, tt.pattern)
 			if result != tt.expected {
-				t.Errorf("matchHostnamePattern(%q, %q) = %v, want %v",
+				t.Errorf("matchPattern(%q, %q) = %v, want %v",
 					tt.hostname, tt.pattern, result, tt.expected)
 			}
 		})
@@ -933,6 +933,81 @@ func TestNginxCollector_CollectUpstreamStatus_NoUpstream(t *testing.T) {
 	}
 }
 
+// TestNginxCollector_CollectUpstream5xxRates tests per-upstream 5xx rate collection.
+func TestNginxCollector_CollectUpstream5xxRates(t *testing.T) {
+	server := setupNginxVMTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		timestamp := time.Now().Unix()
+		w.Write([]byte(fmt.Sprintf(`{
+			"status": "success",
+			"data": {
+				"resultType": "vector",
+				"result": [
+					{"metric": {"agent_hostname": "GX-NM-NGX-01", "upstream": "backend"}, "value": [%d, "2.5"]},
+					{"metric": {"agent_hostname": "GX-NM-NGX-01", "upstream": "idle"}, "value": [%d, "NaN"]},
+					{"metric": {"agent_hostname": "GX-NM-NGX-99", "upstream": "backend"}, "value": [%d, "50"]}
+				]
+			}
+		}`, timestamp, timestamp, timestamp)))
+	})
+	defer server.Close()
+
+	cfg := &config.NginxInspectionConfig{
+		Enabled: true,
+	}
+	metrics := append(createTestNginxMetricDefs(), &model.NginxMetricDefinition{
+		Name:     "nginx_upstream_5xx_rate",
+		Query:    "nginx_upstream_5xx_rate_test",
+		Category: "upstream",
+	})
+	collector := createTestNginxCollector(server.URL, cfg, metrics)
+
+	instance := model.NewNginxInstance("GX-NM-NGX-01", 80)
+	resultsMap := map[string]*model.NginxInspectionResult{
+		"GX-NM-NGX-01:80": model.NewNginxInspectionResult(instance),
+	}
+
+	if err := collector.CollectUpstream5xxRates(context.Background(), resultsMap); err != nil {
+		t.Fatalf("CollectUpstream5xxRates failed: %v", err)
+	}
+
+	rates := resultsMap["GX-NM-NGX-01:80"].Upstream5xxRates
+	if len(rates) != 1 {
+		t.Fatalf("expected 1 upstream rate (NaN skipped), got %d", len(rates))
+	}
+	if rates["backend"] != 2.5 {
+		t.Errorf("expected backend 5xx rate 2.5, got %f", rates["backend"])
+	}
+}
+
+// TestNginxCollector_CollectUpstream5xxRates_NotConfigured tests that collection is skipped without a definition.
+func TestNginxCollector_CollectUpstream5xxRates_NotConfigured(t *testing.T) {
+	queried := false
+	server := setupNginxVMTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queried = true
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": []}}`))
+	})
+	defer server.Close()
+
+	cfg := &config.NginxInspectionConfig{
+		Enabled: true,
+	}
+	collector := createTestNginxCollector(server.URL, cfg, createTestNginxMetricDefs())
+
+	instance := model.NewNginxInstance("GX-NM-NGX-01", 80)
+	resultsMap := map[string]*model.NginxInspectionResult{
+		"GX-NM-NGX-01:80": model.NewNginxInspectionResult(instance),
+	}
+
+	if err := collector.CollectUpstream5xxRates(context.Background(), resultsMap); err != nil {
+		t.Fatalf("CollectUpstream5xxRates failed: %v", err)
+	}
+	if queried {
+		t.Error("expected no query when nginx_upstream_5xx_rate is not defined")
+	}
+}
+
 // =============================================================================
 // NginxInstanceFilter Tests
 // =============================================================================
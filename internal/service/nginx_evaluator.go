@@ -214,27 +214,16 @@ func (e *NginxEvaluator) evaluateLastErrorTime(
 	}
 
 	now := time.Now().Unix()
-	minutesSinceError := (now - timestamp) / 60
+	minutesSinceError := float64((now - timestamp) / 60)
 
-	// 严重：10 分钟内有错误
-	criticalMinutes := int64(e.thresholds.LastErrorCriticalMinutes)
-	if minutesSinceError <= criticalMinutes {
+	// 严重：10 分钟内有错误；警告：60 分钟内有错误
+	threshold := belowThreshold(float64(e.thresholds.LastErrorWarningMinutes), float64(e.thresholds.LastErrorCriticalMinutes))
+	if level := thresholdLevel(minutesSinceError, threshold); level != model.AlertLevelNormal {
 		return e.createAlert(
 			result.GetIdentifier(),
 			"last_error_time",
-			float64(minutesSinceError),
-			model.AlertLevelCritical,
-		)
-	}
-
-	// 警告：60 分钟内有错误
-	warningMinutes := int64(e.thresholds.LastErrorWarningMinutes)
-	if minutesSinceError <= warningMinutes {
-		return e.createAlert(
-			result.GetIdentifier(),
-			"last_error_time",
-			float64(minutesSinceError),
-			model.AlertLevelWarning,
+			minutesSinceError,
+			level,
 		)
 	}
 
@@ -328,9 +317,37 @@ func (e *NginxEvaluator) evaluateUpstreamStatus(
 		}
 	}
 
+	// Upstream 组内全部后端异常
+	for _, summary := range result.GetUpstreamSummaries() {
+		if summary.AllDown() {
+			alerts = append(alerts, e.createUpstreamAllDownAlert(result.GetIdentifier(), summary))
+		}
+	}
+
 	return alerts
 }
 
+// createUpstreamAllDownAlert creates an alert for an upstream whose backends are all unhealthy.
+func (e *NginxEvaluator) createUpstreamAllDownAlert(
+	identifier string,
+	summary model.NginxUpstreamSummary,
+) *model.NginxAlert {
+	message := fmt.Sprintf("Upstream %s 全部 %d 个后端均异常，该组无可用后端",
+		summary.UpstreamName, summary.TotalBackends)
+
+	return &model.NginxAlert{
+		Identifier:        identifier,
+		MetricName:        "upstream_all_down",
+		MetricDisplayName: "Upstream 可用后端",
+		CurrentValue:      float64(summary.HealthyBackends),
+		FormattedValue:    fmt.Sprintf("%d/%d", summary.HealthyBackends, summary.TotalBackends),
+		WarningThreshold:  1,
+		CriticalThreshold: 1,
+		Level:             model.AlertLevelCritical,
+		Message:           message,
+	}
+}
+
 // createUpstreamAlert creates an alert for an unhealthy upstream backend.
 func (e *NginxEvaluator) createUpstreamAlert(
 	identifier string,
@@ -528,3 +545,8 @@ func (e *NginxEvaluator) getThresholds(metricName string) (warning float64, crit
 		return 0, 0
 	}
 }
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *NginxEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{"connection_usage", "last_error_time"}, "last_error_time")
+}
@@ -586,12 +586,15 @@ func TestEvaluateUpstreamStatus(t *testing.T) {
 		result.UpstreamStatus = []model.NginxUpstreamStatus{
 			{UpstreamName: "backend", BackendAddress: "172.18.182.91:8080", Status: false, FallCount: 5},
 			{UpstreamName: "backend", BackendAddress: "172.18.182.92:8080", Status: false, FallCount: 3},
+			{UpstreamName: "api", BackendAddress: "172.18.182.93:8080", Status: false, FallCount: 2},
+			{UpstreamName: "api", BackendAddress: "172.18.182.94:8080", Status: true},
 		}
 
 		alerts := evaluator.evaluateUpstreamStatus(result)
 
-		if len(alerts) != 2 {
-			t.Errorf("expected 2 alerts, got %d", len(alerts))
+		// 3 backend alerts + 1 all-down alert for "backend"
+		if len(alerts) != 4 {
+			t.Errorf("expected 4 alerts, got %d", len(alerts))
 		}
 		for _, alert := range alerts {
 			if alert.Level != model.AlertLevelCritical {
@@ -600,6 +603,32 @@ func TestEvaluateUpstreamStatus(t *testing.T) {
 		}
 	})
 
+	t.Run("All backends of an upstream down - All-down alert", func(t *testing.T) {
+		result := createTestNginxInspectionResult("GX-NM-NGX-01", 80)
+		result.UpstreamStatus = []model.NginxUpstreamStatus{
+			{UpstreamName: "backend", BackendAddress: "172.18.182.91:8080", Status: false},
+			{UpstreamName: "backend", BackendAddress: "172.18.182.92:8080", Status: false},
+		}
+
+		alerts := evaluator.evaluateUpstreamStatus(result)
+
+		var allDown *model.NginxAlert
+		for _, alert := range alerts {
+			if alert.MetricName == "upstream_all_down" {
+				allDown = alert
+			}
+		}
+		if allDown == nil {
+			t.Fatal("expected upstream_all_down alert")
+		}
+		if allDown.FormattedValue != "0/2" {
+			t.Errorf("expected formatted value 0/2, got %s", allDown.FormattedValue)
+		}
+		if !contains(allDown.Message, "backend") {
+			t.Errorf("expected message to contain upstream name, got: %s", allDown.Message)
+		}
+	})
+
 	t.Run("No upstream configured - No alerts", func(t *testing.T) {
 		result := createTestNginxInspectionResult("GX-NM-NGX-01", 80)
 		result.UpstreamStatus = []model.NginxUpstreamStatus{}
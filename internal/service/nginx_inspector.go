@@ -15,12 +15,13 @@ import (
 // NginxInspector orchestrates the complete Nginx inspection workflow, coordinating
 // instance discovery, data collection, threshold evaluation, and result aggregation.
 type NginxInspector struct {
-	collector *NginxCollector
-	evaluator *NginxEvaluator
-	config    *config.Config
-	timezone  *time.Location
-	version   string
-	logger    zerolog.Logger
+	collector  *NginxCollector
+	evaluator  *NginxEvaluator
+	logFetcher *LogExcerptFetcher // 可选，为严重告警附加日志摘录
+	config     *config.Config
+	timezone   *time.Location
+	version    string
+	logger     zerolog.Logger
 }
 
 // NginxInspectorOption is a functional option for configuring a NginxInspector.
@@ -92,6 +93,14 @@ func WithNginxVersion(version string) NginxInspectorOption {
 	}
 }
 
+// WithNginxLogExcerptFetcher enables log excerpt collection for instances with critical alerts.
+// Excerpts are only fetched when nginx.log_excerpt.enabled is set.
+func WithNginxLogExcerptFetcher(fetcher *LogExcerptFetcher) NginxInspectorOption {
+	return func(i *NginxInspector) {
+		i.logFetcher = fetcher
+	}
+}
+
 // GetTimezone returns the configured timezone.
 func (i *NginxInspector) GetTimezone() *time.Location {
 	return i.timezone
@@ -168,10 +177,14 @@ func (i *NginxInspector) Inspect(ctx context.Context) (*model.NginxInspectionRes
 		i.logger.Warn().Err(err).Msg("upstream status collection failed, continuing with other metrics")
 		// Upstream 采集失败不是致命错误，继续处理
 	}
+	if err := i.collector.CollectUpstream5xxRates(ctx, metricsResults); err != nil {
+		i.logger.Warn().Err(err).Msg("upstream 5xx rate collection failed, continuing with other metrics")
+	}
 
 	// Step 8: 评估阈值并生成告警
 	i.logger.Debug().Msg("step 5: evaluating Nginx metrics against thresholds")
 	evalResults := i.evaluator.EvaluateAll(metricsResults)
+	i.attachLogExcerpts(ctx, metricsResults)
 
 	// Step 9: 整理结果
 	i.logger.Debug().Msg("step 6: organizing Nginx inspection results")
@@ -220,4 +233,44 @@ func (i *NginxInspector) GetConfig() *config.NginxInspectionConfig {
 		return nil
 	}
 	return &i.config.Nginx
-}
\ No newline at end of file
+}
+
+// attachLogExcerpts fetches log excerpts for instances with critical alerts and
+// attaches them to those alerts. Query failures are logged and do not fail the inspection.
+func (i *NginxInspector) attachLogExcerpts(ctx context.Context, results map[string]*model.NginxInspectionResult) {
+	if i.logFetcher == nil || !i.config.Nginx.LogExcerpt.Enabled {
+		return
+	}
+
+	for _, r := range results {
+		if r == nil || r.Instance == nil {
+			continue
+		}
+
+		var critical []*model.NginxAlert
+		for _, alert := range r.Alerts {
+			if alert.IsCritical() {
+				critical = append(critical, alert)
+			}
+		}
+		if len(critical) == 0 {
+			continue
+		}
+
+		excerpt, err := i.logFetcher.Fetch(ctx, &i.config.Nginx.LogExcerpt, LogQueryVars{
+			Hostname:  r.Instance.Hostname,
+			IP:        r.Instance.IP,
+			Port:      r.Instance.Port,
+			Container: r.Instance.Container,
+			LogPath:   r.Instance.ErrorLogPath,
+		})
+		if err != nil {
+			i.logger.Warn().Err(err).Str("identifier", r.GetIdentifier()).Msg("failed to fetch log excerpt")
+			continue
+		}
+
+		for _, alert := range critical {
+			alert.LogExcerpt = excerpt
+		}
+	}
+}
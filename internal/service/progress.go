@@ -0,0 +1,38 @@
+package service
+
+import "context"
+
+// Progress receives the progress of the collection of a module, shown as progress bars on
+// the console. Each metric is queried over all the hosts or instances of the module at
+// once, so the collection advances one metric query at a time. Implementations must be
+// safe for concurrent use: the metrics are collected concurrently.
+type Progress interface {
+	// Start is called when the metric queries start, with the number of hosts or instances
+	// they cover (0 when unknown before the queries) and the number of queries.
+	Start(targets, queries int)
+	// QueryDone is called when a metric query completed, successfully or not.
+	QueryDone()
+}
+
+type progressKey struct{}
+
+// ContextWithProgress returns a context whose module collection reports its progress to
+// progress. The progress is attached to the context of one inspection rather than to the
+// collectors, which are created before the console knows which module runs when.
+func ContextWithProgress(ctx context.Context, progress Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+// progressFrom returns the progress attached to ctx, one discarding the progress when none is.
+func progressFrom(ctx context.Context) Progress {
+	if progress, ok := ctx.Value(progressKey{}).(Progress); ok {
+		return progress
+	}
+	return noProgress{}
+}
+
+// noProgress discards the progress of a collection.
+type noProgress struct{}
+
+func (noProgress) Start(int, int) {}
+func (noProgress) QueryDone()     {}
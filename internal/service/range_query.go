@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// ApplyRangeFunctions returns the host metrics with the query of each metric having a
+// range_function wrapped in its *_over_time subquery over the inspection window, so the
+// collector, the raw data sheet and the provenance sheet all show the query actually run.
+// Definitions are copied, not modified. Without a window the metrics are returned unchanged.
+func ApplyRangeFunctions(metrics []*model.MetricDefinition, inspection *config.InspectionConfig) []*model.MetricDefinition {
+	if inspection == nil || inspection.RangeWindow <= 0 {
+		return metrics
+	}
+
+	result := make([]*model.MetricDefinition, 0, len(metrics))
+	for _, metric := range metrics {
+		if metric == nil || metric.RangeFunction == "" || metric.IsPending() {
+			result = append(result, metric)
+			continue
+		}
+		ranged := *metric
+		ranged.Query = rangeQuery(metric.Query, metric.RangeFunction, inspection.RangeWindow, inspection.RangeEnd)
+		result = append(result, &ranged)
+	}
+	return result
+}
+
+// rangeQuery builds the PromQL subquery aggregating query over window, e.g.
+// quantile_over_time(0.95, (cpu_usage_active)[24h:]). A non-zero end pins the window with
+// the @ modifier, so a past window gives the same result whenever the query runs.
+func rangeQuery(query string, fn model.RangeFunction, window time.Duration, end time.Time) string {
+	subquery := fmt.Sprintf("(%s)[%s:]", query, formatPromDuration(window))
+	if !end.IsZero() {
+		subquery += fmt.Sprintf(" @ %d", end.Unix())
+	}
+
+	switch fn {
+	case model.RangeFunctionAvg:
+		return fmt.Sprintf("avg_over_time(%s)", subquery)
+	case model.RangeFunctionMax:
+		return fmt.Sprintf("max_over_time(%s)", subquery)
+	case model.RangeFunctionP95:
+		return fmt.Sprintf("quantile_over_time(0.95, %s)", subquery)
+	default:
+		return query
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+func TestApplyRangeFunctions(t *testing.T) {
+	metrics := []*model.MetricDefinition{
+		{Name: "cpu_usage", Query: "cpu_usage_active", RangeFunction: model.RangeFunctionP95},
+		{Name: "memory_usage", Query: "mem_used_percent", RangeFunction: model.RangeFunctionAvg},
+		{Name: "disk_usage", Query: "disk_used_percent", RangeFunction: model.RangeFunctionMax},
+		{Name: "uptime", Query: "system_uptime"},
+		{Name: "ntp_offset", RangeFunction: model.RangeFunctionMax, Status: "pending"},
+	}
+
+	got := ApplyRangeFunctions(metrics, &config.InspectionConfig{RangeWindow: 24 * time.Hour})
+	want := []string{
+		"quantile_over_time(0.95, (cpu_usage_active)[24h:])",
+		"avg_over_time((mem_used_percent)[24h:])",
+		"max_over_time((disk_used_percent)[24h:])",
+		"system_uptime",
+		"",
+	}
+	for i, metric := range got {
+		if metric.Query != want[i] {
+			t.Errorf("%s: query = %q, want %q", metric.Name, metric.Query, want[i])
+		}
+	}
+
+	// The loaded definitions are left unchanged
+	if metrics[0].Query != "cpu_usage_active" {
+		t.Errorf("original query modified: %q", metrics[0].Query)
+	}
+	if got[3] != metrics[3] {
+		t.Error("metrics without range function should be returned as is")
+	}
+}
+
+func TestApplyRangeFunctions_End(t *testing.T) {
+	end := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	metrics := []*model.MetricDefinition{
+		{Name: "cpu_usage", Query: "cpu_usage_active", RangeFunction: model.RangeFunctionMax},
+	}
+
+	got := ApplyRangeFunctions(metrics, &config.InspectionConfig{RangeWindow: 90 * time.Minute, RangeEnd: end})
+	if want := "max_over_time((cpu_usage_active)[90m:] @ 1790841600)"; got[0].Query != want {
+		t.Errorf("query = %q, want %q", got[0].Query, want)
+	}
+}
+
+func TestApplyRangeFunctions_NoWindow(t *testing.T) {
+	metrics := []*model.MetricDefinition{
+		{Name: "cpu_usage", Query: "cpu_usage_active", RangeFunction: model.RangeFunctionMax},
+	}
+
+	got := ApplyRangeFunctions(metrics, &config.InspectionConfig{})
+	if got[0].Query != "cpu_usage_active" {
+		t.Errorf("query = %q, want the instant query", got[0].Query)
+	}
+}
@@ -18,7 +18,7 @@ import (
 // RedisCollector is the data collection service for Redis instances.
 // It integrates with VictoriaMetrics to collect Redis monitoring metrics.
 type RedisCollector struct {
-	vmClient       *vm.Client
+	vmClient       vm.MetricsSource
 	config         *config.RedisInspectionConfig
 	metrics        []*model.RedisMetricDefinition
 	instanceFilter *RedisInstanceFilter
@@ -35,7 +35,7 @@ type RedisInstanceFilter struct {
 // NewRedisCollector creates a new Redis collector.
 func NewRedisCollector(
 	cfg *config.RedisInspectionConfig,
-	vmClient *vm.Client,
+	vmClient vm.MetricsSource,
 	metrics []*model.RedisMetricDefinition,
 	logger zerolog.Logger,
 ) *RedisCollector {
@@ -321,6 +321,9 @@ func (c *RedisCollector) collectMetricConcurrent(
 				Timestamp: time.Now().Unix(),
 				Labels:    result.Labels,
 			}
+			if metric.LabelExtract != "" {
+				mv.StringValue = result.Labels[metric.LabelExtract]
+			}
 			inspResult.SetMetric(mv)
 			matchedCount++
 		}
@@ -485,6 +488,46 @@ func (c *RedisCollector) populateResultFields(result *model.RedisInspectionResul
 		result.Uptime = int64(m.RawValue)
 	}
 
+	// redis_used_memory -> UsedMemory
+	if m := result.GetMetric("redis_used_memory"); m != nil && !m.IsNA {
+		result.UsedMemory = int64(m.RawValue)
+	}
+
+	// redis_maxmemory -> MaxMemory
+	if m := result.GetMetric("redis_maxmemory"); m != nil && !m.IsNA {
+		result.MaxMemory = int64(m.RawValue)
+	}
+
+	// redis_mem_fragmentation_ratio -> MemFragmentationRatio
+	if m := result.GetMetric("redis_mem_fragmentation_ratio"); m != nil && !m.IsNA {
+		result.MemFragmentationRatio = m.RawValue
+	}
+
+	// redis_evicted_keys -> EvictedKeysPerSecond
+	if m := result.GetMetric("redis_evicted_keys"); m != nil && !m.IsNA {
+		result.EvictedKeysPerSecond = m.RawValue
+	}
+
+	// redis_expired_keys -> ExpiredKeysPerSecond
+	if m := result.GetMetric("redis_expired_keys"); m != nil && !m.IsNA {
+		result.ExpiredKeysPerSecond = m.RawValue
+	}
+
+	// redis_keyspace_hits + redis_keyspace_misses -> KeyspaceHitRatio
+	// Only calculated when both are present and there was at least one lookup
+	hits := result.GetMetric("redis_keyspace_hits")
+	misses := result.GetMetric("redis_keyspace_misses")
+	if hits != nil && !hits.IsNA && misses != nil && !misses.IsNA {
+		if total := hits.RawValue + misses.RawValue; total > 0 {
+			result.KeyspaceHitRatio = hits.RawValue / total * 100
+			result.SetMetric(&model.RedisMetricValue{
+				Name:      "redis_keyspace_hit_ratio",
+				RawValue:  result.KeyspaceHitRatio,
+				Timestamp: hits.Timestamp,
+			})
+		}
+	}
+
 	// Set collected timestamp
 	result.CollectedAt = time.Now()
 }
@@ -543,10 +586,13 @@ func (c *RedisCollector) CollectMetrics(
 
 	var mu sync.Mutex // Protects resultsMap from concurrent writes
 
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
 	for _, metric := range activeMetrics {
 		metric := metric // Capture loop variable
 		g.Go(func() error {
 			err := c.collectMetricConcurrent(ctx, metric, instances, resultsMap, &mu)
+			progress.QueryDone()
 			if err != nil {
 				c.logger.Warn().
 					Err(err).
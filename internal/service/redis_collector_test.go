@@ -1541,3 +1541,48 @@ func TestRedisCollector_populateResultFields(t *testing.T) {
 		t.Error("expected CollectedAt to be set")
 	}
 }
+
+// TestRedisCollector_populateResultFields_Memory tests memory and keyspace field population.
+func TestRedisCollector_populateResultFields_Memory(t *testing.T) {
+	collector := &RedisCollector{
+		logger: zerolog.Nop(),
+	}
+
+	instance := model.NewRedisInstanceWithRole("192.18.102.2:7000", model.RedisRoleMaster)
+	result := model.NewRedisInspectionResult(instance)
+
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_used_memory", RawValue: 512})
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_maxmemory", RawValue: 1024})
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_mem_fragmentation_ratio", RawValue: 1.3})
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_evicted_keys", RawValue: 2})
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_expired_keys", RawValue: 8})
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_keyspace_hits", RawValue: 90})
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_keyspace_misses", RawValue: 10})
+
+	collector.populateResultFields(result)
+
+	if result.GetMemoryUsagePercent() != 50 {
+		t.Errorf("expected memory usage 50%%, got %.1f", result.GetMemoryUsagePercent())
+	}
+	if result.MemFragmentationRatio != 1.3 {
+		t.Errorf("expected fragmentation ratio 1.3, got %.2f", result.MemFragmentationRatio)
+	}
+	if result.EvictedKeysPerSecond != 2 || result.ExpiredKeysPerSecond != 8 {
+		t.Errorf("unexpected evicted/expired rates: %.2f/%.2f", result.EvictedKeysPerSecond, result.ExpiredKeysPerSecond)
+	}
+	if !result.HasKeyspaceHitRatio() {
+		t.Fatal("expected keyspace hit ratio to be calculated")
+	}
+	if result.KeyspaceHitRatio != 90 {
+		t.Errorf("expected keyspace hit ratio 90, got %.1f", result.KeyspaceHitRatio)
+	}
+
+	// No lookups in the window: ratio is not calculated
+	idle := model.NewRedisInspectionResult(model.NewRedisInstanceWithRole("192.18.102.2:7001", model.RedisRoleSlave))
+	idle.SetMetric(&model.RedisMetricValue{Name: "redis_keyspace_hits", RawValue: 0})
+	idle.SetMetric(&model.RedisMetricValue{Name: "redis_keyspace_misses", RawValue: 0})
+	collector.populateResultFields(idle)
+	if idle.HasKeyspaceHitRatio() {
+		t.Error("expected keyspace hit ratio to be absent when there are no lookups")
+	}
+}
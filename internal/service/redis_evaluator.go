@@ -85,6 +85,23 @@ func (e *RedisEvaluator) Evaluate(
 		evalResult.Alerts = append(evalResult.Alerts, alert)
 	}
 
+	// 评估内存与 Keyspace 指标（所有节点，阈值为 0 时跳过）
+	if alert := e.evaluateMemoryUsage(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateFragmentationRatio(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateEvictedKeys(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateKeyspaceHitRatio(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+
+	// 评估字符串/枚举期望值检查
+	evalResult.Alerts = append(evalResult.Alerts, e.evaluateValueChecks(result)...)
+
 	// 仅 slave 节点的评估
 	if result.Instance != nil && result.Instance.Role.IsSlave() {
 		// 评估主从链接状态
@@ -142,6 +159,124 @@ func (e *RedisEvaluator) evaluateConnectionUsage(
 	return nil // 正常，无告警
 }
 
+// evaluateMemoryUsage evaluates used_memory as a percentage of maxmemory.
+// Returns nil if maxmemory is not set (unlimited) or both thresholds are 0 (disabled).
+func (e *RedisEvaluator) evaluateMemoryUsage(
+	result *model.RedisInspectionResult,
+) *model.RedisAlert {
+	if result.MaxMemory == 0 {
+		return nil
+	}
+
+	usage := result.GetMemoryUsagePercent()
+	warning := e.thresholds.MemoryUsageWarning
+	critical := e.thresholds.MemoryUsageCritical
+
+	if critical > 0 && usage >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"memory_usage",
+			usage,
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && usage >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"memory_usage",
+			usage,
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
+// evaluateFragmentationRatio evaluates the memory fragmentation ratio.
+// Returns nil if both thresholds are 0 (disabled).
+func (e *RedisEvaluator) evaluateFragmentationRatio(
+	result *model.RedisInspectionResult,
+) *model.RedisAlert {
+	ratio := result.MemFragmentationRatio
+	warning := e.thresholds.FragmentationRatioWarning
+	critical := e.thresholds.FragmentationRatioCritical
+
+	if critical > 0 && ratio >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"fragmentation_ratio",
+			ratio,
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && ratio >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"fragmentation_ratio",
+			ratio,
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
+// evaluateEvictedKeys evaluates the key eviction rate (per second).
+// Returns nil if both thresholds are 0 (disabled).
+func (e *RedisEvaluator) evaluateEvictedKeys(
+	result *model.RedisInspectionResult,
+) *model.RedisAlert {
+	rate := result.EvictedKeysPerSecond
+	warning := e.thresholds.EvictedKeysWarning
+	critical := e.thresholds.EvictedKeysCritical
+
+	if critical > 0 && rate >= critical {
+		return e.createAlert(
+			result.GetAddress(),
+			"evicted_keys",
+			rate,
+			model.AlertLevelCritical,
+		)
+	}
+
+	if warning > 0 && rate >= warning {
+		return e.createAlert(
+			result.GetAddress(),
+			"evicted_keys",
+			rate,
+			model.AlertLevelWarning,
+		)
+	}
+
+	return nil
+}
+
+// evaluateKeyspaceHitRatio evaluates the keyspace hit ratio. Lower is worse: a ratio at or
+// below a threshold alerts.
+// Returns nil if the ratio was not calculated or both thresholds are 0 (disabled).
+func (e *RedisEvaluator) evaluateKeyspaceHitRatio(
+	result *model.RedisInspectionResult,
+) *model.RedisAlert {
+	if !result.HasKeyspaceHitRatio() {
+		return nil
+	}
+
+	ratio := result.KeyspaceHitRatio
+	threshold := belowThreshold(e.thresholds.KeyspaceHitRatioWarning, e.thresholds.KeyspaceHitRatioCritical)
+	if level := thresholdLevel(ratio, threshold); level != model.AlertLevelNormal {
+		return e.createAlert(
+			result.GetAddress(),
+			"keyspace_hit_ratio",
+			ratio,
+			level,
+		)
+	}
+
+	return nil
+}
+
 // evaluateMasterLinkStatus evaluates master-slave link status (slave nodes only).
 func (e *RedisEvaluator) evaluateMasterLinkStatus(
 	result *model.RedisInspectionResult,
@@ -188,6 +323,49 @@ func (e *RedisEvaluator) evaluateReplicationLag(
 	return nil
 }
 
+// evaluateValueChecks evaluates configured expected-value checks against
+// string-valued metrics. Missing or N/A metrics are skipped.
+func (e *RedisEvaluator) evaluateValueChecks(
+	result *model.RedisInspectionResult,
+) []*model.RedisAlert {
+	alerts := make([]*model.RedisAlert, 0)
+
+	for i := range e.thresholds.ValueChecks {
+		check := &e.thresholds.ValueChecks[i]
+
+		mv := result.GetMetric(check.Metric)
+		if mv == nil || mv.IsNA || mv.StringValue == "" {
+			e.logger.Debug().
+				Str("address", result.GetAddress()).
+				Str("metric", check.Metric).
+				Msg("skipping value check: metric value not available")
+			continue
+		}
+
+		if check.Matches(mv.StringValue) {
+			continue
+		}
+
+		displayName := check.Metric
+		if def, exists := e.metricDefs[check.Metric]; exists {
+			displayName = def.GetDisplayName()
+		}
+
+		alerts = append(alerts, &model.RedisAlert{
+			Address:           result.GetAddress(),
+			MetricName:        check.Metric,
+			MetricDisplayName: displayName,
+			CurrentValue:      mv.RawValue,
+			FormattedValue:    mv.StringValue,
+			Level:             valueCheckLevel(check),
+			Message:           valueCheckMessage(displayName, mv.StringValue, check),
+			ExpectedValue:     check.Expected(),
+		})
+	}
+
+	return alerts
+}
+
 // determineInstanceStatus determines the overall instance status based on alerts.
 // 状态聚合优先级：Critical > Warning > Normal
 func (e *RedisEvaluator) determineInstanceStatus(
@@ -267,10 +445,13 @@ func (e *RedisEvaluator) formatValue(value float64, metricName string) string {
 		}
 		return fmt.Sprintf("%.0f B", value)
 	case "master_link_status":
-		if value == 0 {
-			return "断开"
-		}
-		return "正常"
+		return model.FormatBool("redis_master_link_status", value != 0)
+	case "memory_usage", "keyspace_hit_ratio":
+		return fmt.Sprintf("%.1f%%", value)
+	case "fragmentation_ratio":
+		return fmt.Sprintf("%.2f", value)
+	case "evicted_keys":
+		return fmt.Sprintf("%.2f/s", value)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -305,6 +486,38 @@ func (e *RedisEvaluator) generateAlertMessage(
 	case "master_link_status":
 		return "主从链接断开（master_link_status = 0）"
 
+	case "memory_usage":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("内存使用率为 %.1f%%，已超过严重阈值 %.1f%%",
+				currentValue, criticalThreshold)
+		}
+		return fmt.Sprintf("内存使用率为 %.1f%%，已超过警告阈值 %.1f%%",
+			currentValue, warningThreshold)
+
+	case "fragmentation_ratio":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("内存碎片率为 %.2f，已超过严重阈值 %.2f",
+				currentValue, criticalThreshold)
+		}
+		return fmt.Sprintf("内存碎片率为 %.2f，已超过警告阈值 %.2f",
+			currentValue, warningThreshold)
+
+	case "evicted_keys":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("Key 驱逐速率为 %.2f/s，已超过严重阈值 %.2f/s",
+				currentValue, criticalThreshold)
+		}
+		return fmt.Sprintf("Key 驱逐速率为 %.2f/s，已超过警告阈值 %.2f/s",
+			currentValue, warningThreshold)
+
+	case "keyspace_hit_ratio":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("Keyspace 命中率为 %.1f%%，已低于严重阈值 %.1f%%",
+				currentValue, criticalThreshold)
+		}
+		return fmt.Sprintf("Keyspace 命中率为 %.1f%%，已低于警告阈值 %.1f%%",
+			currentValue, warningThreshold)
+
 	default:
 		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
 	}
@@ -319,7 +532,22 @@ func (e *RedisEvaluator) getThresholds(metricName string) (warning float64, crit
 		return float64(e.thresholds.ReplicationLagWarning), float64(e.thresholds.ReplicationLagCritical)
 	case "master_link_status":
 		return 1, 1 // 断开即严重，无警告阈值
+	case "memory_usage":
+		return e.thresholds.MemoryUsageWarning, e.thresholds.MemoryUsageCritical
+	case "fragmentation_ratio":
+		return e.thresholds.FragmentationRatioWarning, e.thresholds.FragmentationRatioCritical
+	case "evicted_keys":
+		return e.thresholds.EvictedKeysWarning, e.thresholds.EvictedKeysCritical
+	case "keyspace_hit_ratio":
+		return e.thresholds.KeyspaceHitRatioWarning, e.thresholds.KeyspaceHitRatioCritical
 	default:
 		return 0, 0
 	}
 }
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *RedisEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds,
+		[]string{"connection_usage", "replication_lag", "memory_usage", "fragmentation_ratio", "evicted_keys", "keyspace_hit_ratio"},
+		"keyspace_hit_ratio")
+}
@@ -265,6 +265,134 @@ func TestRedisEvaluator_Evaluate_MasterLinkStatus_Critical(t *testing.T) {
 	assert.Contains(t, evalResult.Alerts[0].Message, "主从链接断开")
 }
 
+// =============================================================================
+// Memory and Keyspace Evaluation Tests
+// =============================================================================
+
+// createTestRedisMemoryEvaluator creates a RedisEvaluator with memory and keyspace thresholds enabled.
+func createTestRedisMemoryEvaluator() *RedisEvaluator {
+	thresholds := &config.RedisThresholds{
+		ConnectionUsageWarning:     70,
+		ConnectionUsageCritical:    90,
+		ReplicationLagWarning:      1048576,
+		ReplicationLagCritical:     10485760,
+		MemoryUsageWarning:         70,
+		MemoryUsageCritical:        90,
+		FragmentationRatioWarning:  1.5,
+		FragmentationRatioCritical: 2.0,
+		EvictedKeysWarning:         1,
+		EvictedKeysCritical:        100,
+		KeyspaceHitRatioWarning:    90,
+		KeyspaceHitRatioCritical:   80,
+	}
+	return NewRedisEvaluator(thresholds, nil, zerolog.Nop())
+}
+
+func TestRedisEvaluator_Evaluate_MemoryUsage(t *testing.T) {
+	tests := []struct {
+		name          string
+		used          int64
+		max           int64
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{"normal", 500, 1000, "", false},
+		{"warning", 750, 1000, model.AlertLevelWarning, true},
+		{"critical", 950, 1000, model.AlertLevelCritical, true},
+		{"unlimited_maxmemory", 950, 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator := createTestRedisMemoryEvaluator()
+			result := createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+			result.UsedMemory = tt.used
+			result.MaxMemory = tt.max
+
+			alert := evaluator.evaluateMemoryUsage(result)
+			if !tt.expectAlert {
+				assert.Nil(t, alert)
+				return
+			}
+			require.NotNil(t, alert)
+			assert.Equal(t, "memory_usage", alert.MetricName)
+			assert.Equal(t, tt.expectedLevel, alert.Level)
+		})
+	}
+}
+
+func TestRedisEvaluator_Evaluate_FragmentationRatio(t *testing.T) {
+	evaluator := createTestRedisMemoryEvaluator()
+	result := createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+
+	result.MemFragmentationRatio = 1.2
+	assert.Nil(t, evaluator.evaluateFragmentationRatio(result))
+
+	result.MemFragmentationRatio = 1.6
+	alert := evaluator.evaluateFragmentationRatio(result)
+	require.NotNil(t, alert)
+	assert.Equal(t, model.AlertLevelWarning, alert.Level)
+
+	result.MemFragmentationRatio = 2.5
+	alert = evaluator.evaluateFragmentationRatio(result)
+	require.NotNil(t, alert)
+	assert.Equal(t, model.AlertLevelCritical, alert.Level)
+	assert.Contains(t, alert.Message, "2.50")
+}
+
+func TestRedisEvaluator_Evaluate_EvictedKeys(t *testing.T) {
+	evaluator := createTestRedisMemoryEvaluator()
+	result := createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+
+	result.EvictedKeysPerSecond = 0
+	assert.Nil(t, evaluator.evaluateEvictedKeys(result))
+
+	result.EvictedKeysPerSecond = 5
+	alert := evaluator.evaluateEvictedKeys(result)
+	require.NotNil(t, alert)
+	assert.Equal(t, model.AlertLevelWarning, alert.Level)
+	assert.Equal(t, "5.00/s", alert.FormattedValue)
+}
+
+func TestRedisEvaluator_Evaluate_KeyspaceHitRatio(t *testing.T) {
+	evaluator := createTestRedisMemoryEvaluator()
+	result := createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+
+	// Not calculated: no alert even though the field is 0
+	assert.Nil(t, evaluator.evaluateKeyspaceHitRatio(result))
+
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_keyspace_hit_ratio", RawValue: 95})
+	result.KeyspaceHitRatio = 95
+	assert.Nil(t, evaluator.evaluateKeyspaceHitRatio(result))
+
+	result.KeyspaceHitRatio = 85
+	alert := evaluator.evaluateKeyspaceHitRatio(result)
+	require.NotNil(t, alert)
+	assert.Equal(t, model.AlertLevelWarning, alert.Level)
+	assert.Contains(t, alert.Message, "低于警告阈值")
+
+	result.KeyspaceHitRatio = 50
+	alert = evaluator.evaluateKeyspaceHitRatio(result)
+	require.NotNil(t, alert)
+	assert.Equal(t, model.AlertLevelCritical, alert.Level)
+}
+
+func TestRedisEvaluator_Evaluate_MemoryThresholdsDisabled(t *testing.T) {
+	evaluator := createTestRedisEvaluator() // memory thresholds all 0
+	result := createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+	result.UsedMemory = 990
+	result.MaxMemory = 1000
+	result.MemFragmentationRatio = 5
+	result.EvictedKeysPerSecond = 1000
+	result.KeyspaceHitRatio = 1
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_keyspace_hit_ratio", RawValue: 1})
+
+	evalResult := evaluator.Evaluate(result)
+
+	assert.Equal(t, model.RedisStatusNormal, evalResult.Status)
+	assert.Empty(t, evalResult.Alerts)
+}
+
 // =============================================================================
 // Skip Master Metrics Tests
 // =============================================================================
@@ -654,3 +782,46 @@ func TestRedisEvaluator_Evaluate_InPlaceUpdate(t *testing.T) {
 	assert.Equal(t, model.RedisStatusCritical, result.Status)
 	assert.Len(t, result.Alerts, 1)
 }
+
+// =============================================================================
+// Value Check Tests
+// =============================================================================
+
+func TestRedisEvaluator_Evaluate_ValueChecks(t *testing.T) {
+	thresholds := &config.RedisThresholds{
+		ConnectionUsageWarning:  70,
+		ConnectionUsageCritical: 90,
+		ValueChecks: []config.ValueCheck{
+			{Metric: "redis_maxmemory_policy", Operator: config.ValueCheckIn, Values: []string{"allkeys-lru", "volatile-lru"}},
+		},
+	}
+	metrics := []*model.RedisMetricDefinition{
+		{Name: "redis_maxmemory_policy", DisplayName: "内存淘汰策略"},
+	}
+	evaluator := NewRedisEvaluator(thresholds, metrics, zerolog.Nop())
+
+	// Not collected: skipped
+	result := createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+	evalResult := evaluator.Evaluate(result)
+	assert.Empty(t, evalResult.Alerts)
+
+	// Matching policy
+	result = createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_maxmemory_policy", StringValue: "allkeys-lru"})
+	evalResult = evaluator.Evaluate(result)
+	assert.Empty(t, evalResult.Alerts)
+
+	// Unexpected policy
+	result = createTestRedisResult("192.18.102.2:7000", model.RedisRoleMaster, 10, 1000)
+	result.SetMetric(&model.RedisMetricValue{Name: "redis_maxmemory_policy", StringValue: "noeviction"})
+	evalResult = evaluator.Evaluate(result)
+
+	assert.Equal(t, model.RedisStatusWarning, evalResult.Status)
+	require.Len(t, evalResult.Alerts, 1)
+	alert := evalResult.Alerts[0]
+	assert.Equal(t, "redis_maxmemory_policy", alert.MetricName)
+	assert.Equal(t, "内存淘汰策略", alert.MetricDisplayName)
+	assert.Equal(t, "noeviction", alert.FormattedValue)
+	assert.Equal(t, "allkeys-lru | volatile-lru", alert.ExpectedValue)
+	assert.Equal(t, "内存淘汰策略为 noeviction，不符合期望值 allkeys-lru | volatile-lru", alert.Message)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"math"
+	"path"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// sourceConflictTolerance is the relative difference up to which the values of two collection
+// sources count as equal, since agents sample and compute usage slightly differently.
+const sourceConflictTolerance = 0.01
+
+// sourceSelector keeps one collection source per host and metric when several agents
+// (e.g. node_exporter and categraf) report the same metric for a host.
+type sourceSelector struct {
+	label string
+	order []string
+	rules []config.SourcePreferenceRule
+}
+
+// newSourceSelector creates a selector from the source preference config.
+// It returns nil when no source label is configured, which keeps every series.
+func newSourceSelector(pref config.SourcePreference) *sourceSelector {
+	if pref.Label == "" {
+		return nil
+	}
+	return &sourceSelector{
+		label: pref.Label,
+		order: pref.Order,
+		rules: pref.Rules,
+	}
+}
+
+// rank returns the preference of source for hostname (lower is preferred): its position in
+// the order of the first rule matching the host, or in the default order. Unlisted sources
+// rank last.
+func (s *sourceSelector) rank(hostname, source string) int {
+	order := s.order
+	for _, rule := range s.rules {
+		if matchHostPattern(rule.Hosts, hostname) {
+			order = rule.Order
+			break
+		}
+	}
+	for i, o := range order {
+		if o == source {
+			return i
+		}
+	}
+	return len(order)
+}
+
+// preferred reports whether source a is preferred over source b for hostname.
+// Sources of equal rank are ordered by name, so the choice does not depend on series order.
+func (s *sourceSelector) preferred(hostname, a, b string) bool {
+	ra, rb := s.rank(hostname, a), s.rank(hostname, b)
+	if ra != rb {
+		return ra < rb
+	}
+	return a < b
+}
+
+// selectSources keeps the series of the preferred source of each host and returns the
+// conflicts: series of one host (and expandLabel value) whose sources report different values.
+func (s *sourceSelector) selectSources(metric, expandLabel string, matches []hostMatch) ([]hostMatch, []*model.SourceConflict) {
+	if s == nil {
+		return matches, nil
+	}
+
+	type seriesKey struct {
+		hostname, labelValue string
+	}
+
+	best := make(map[string]string)
+	values := make(map[seriesKey]map[string]float64)
+	var keys []seriesKey
+	for _, m := range matches {
+		source := m.Result.Labels[s.label]
+		if current, exists := best[m.Hostname]; !exists || s.preferred(m.Hostname, source, current) {
+			best[m.Hostname] = source
+		}
+
+		key := seriesKey{hostname: m.Hostname}
+		if expandLabel != "" {
+			key.labelValue = m.Result.Labels[expandLabel]
+		}
+		if values[key] == nil {
+			values[key] = make(map[string]float64)
+			keys = append(keys, key)
+		}
+		values[key][source] = m.Result.Value
+	}
+
+	selected := make([]hostMatch, 0, len(matches))
+	for _, m := range matches {
+		if m.Result.Labels[s.label] == best[m.Hostname] {
+			selected = append(selected, m)
+		}
+	}
+
+	var conflicts []*model.SourceConflict
+	for _, key := range keys {
+		bySource := values[key]
+		chosen, ok := bySource[best[key.hostname]]
+		if !ok || len(bySource) < 2 {
+			continue
+		}
+		conflicting := false
+		for _, v := range bySource {
+			if !valuesAgree(chosen, v) {
+				conflicting = true
+				break
+			}
+		}
+		if !conflicting {
+			continue
+		}
+
+		name := metric
+		if expandLabel != "" {
+			name = metric + ":" + key.labelValue
+		}
+		conflicts = append(conflicts, &model.SourceConflict{
+			Hostname: key.hostname,
+			Metric:   name,
+			Source:   best[key.hostname],
+			Values:   bySource,
+		})
+	}
+
+	return selected, conflicts
+}
+
+// valuesAgree reports whether a and b differ by at most sourceConflictTolerance relatively.
+func valuesAgree(a, b float64) bool {
+	scale := math.Max(math.Abs(a), math.Abs(b))
+	return math.Abs(a-b) <= sourceConflictTolerance*math.Max(scale, 1)
+}
+
+// matchHostPattern reports whether hostname matches one of the wildcard patterns.
+func matchHostPattern(patterns []string, hostname string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, hostname); ok {
+			return true
+		}
+	}
+	return false
+}
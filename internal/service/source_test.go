@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+func TestSourceSelector_Rank(t *testing.T) {
+	s := newSourceSelector(config.SourcePreference{
+		Label: "job",
+		Order: []string{"categraf", "node-exporter"},
+		Rules: []config.SourcePreferenceRule{{Hosts: []string{"db-*"}, Order: []string{"node-exporter"}}},
+	})
+
+	if !s.preferred("web-1", "categraf", "node-exporter") {
+		t.Error("categraf should be preferred by the default order")
+	}
+	if !s.preferred("db-1", "node-exporter", "categraf") {
+		t.Error("node-exporter should be preferred by the db-* rule")
+	}
+	if !s.preferred("web-1", "node-exporter", "telegraf") {
+		t.Error("listed sources should be preferred over unlisted ones")
+	}
+	if newSourceSelector(config.SourcePreference{}) != nil {
+		t.Error("selector without a label should be nil")
+	}
+}
+
+func TestHostResolver_MatchSourcePreference(t *testing.T) {
+	cfg := config.InspectionConfig{
+		SourcePreference: config.SourcePreference{Label: "job", Order: []string{"categraf", "node-exporter"}},
+	}
+	hosts := []*model.HostMeta{{Hostname: "host-1"}, {Hostname: "host-2"}}
+	r := newHostResolver(cfg, hosts, zerolog.Nop())
+
+	results := []vm.QueryResult{
+		{Value: 51, Labels: map[string]string{"ident": "host-1", "job": "node-exporter", "path": "/"}},
+		{Value: 45, Labels: map[string]string{"ident": "host-1", "job": "categraf", "path": "/"}},
+		{Value: 70, Labels: map[string]string{"ident": "host-1", "job": "node-exporter", "path": "/data"}},
+		{Value: 70.2, Labels: map[string]string{"ident": "host-1", "job": "categraf", "path": "/data"}},
+		{Value: 30, Labels: map[string]string{"ident": "host-2", "job": "node-exporter", "path": "/"}},
+	}
+
+	matches := r.match("disk_usage", "path", results)
+	if len(matches) != 3 {
+		t.Fatalf("match() returned %d matches, want 3: %+v", len(matches), matches)
+	}
+	for _, m := range matches {
+		if m.Hostname == "host-1" && m.Result.Labels["job"] != "categraf" {
+			t.Errorf("host-1 should only keep categraf series, got %+v", m.Result.Labels)
+		}
+	}
+
+	// "/" differs (45 vs 51); "/data" agrees within tolerance; host-2 has a single source
+	conflicts := r.sourceConflicts()
+	if len(conflicts) != 1 {
+		t.Fatalf("sourceConflicts() = %d conflicts, want 1: %+v", len(conflicts), conflicts)
+	}
+	c := conflicts[0]
+	if c.Hostname != "host-1" || c.Metric != "disk_usage:/" || c.Source != "categraf" || c.Values["node-exporter"] != 51 {
+		t.Errorf("unexpected conflict %+v", c)
+	}
+}
+
+func TestHostResolver_MatchWithoutSourcePreference(t *testing.T) {
+	r := newHostResolver(config.InspectionConfig{}, []*model.HostMeta{{Hostname: "host-1"}}, zerolog.Nop())
+
+	results := []vm.QueryResult{
+		{Value: 51, Labels: map[string]string{"ident": "host-1", "job": "node-exporter"}},
+		{Value: 45, Labels: map[string]string{"ident": "host-1", "job": "categraf"}},
+	}
+	if matches := r.match("cpu_usage", "", results); len(matches) != 2 {
+		t.Errorf("match() without source preference should keep every series, got %d", len(matches))
+	}
+	if conflicts := r.sourceConflicts(); len(conflicts) != 0 {
+		t.Errorf("sourceConflicts() = %+v, want none", conflicts)
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/ssh"
+	"inspection-tool/internal/model"
+)
+
+// WithSSHFallback fills the host metrics missing from the metrics backend, and the pending
+// metrics it cannot provide (e.g. NTP check), by running the whitelisted SSH checks of
+// inspection.ssh_fallback on the hosts. A nil runner disables the fallback.
+func WithSSHFallback(runner ssh.Runner) CollectorOption {
+	return func(c *Collector) {
+		c.sshRunner = runner
+	}
+}
+
+// fillFromSSH runs, on each host, the SSH checks of which at least one metric is missing or
+// N/A, and sets the missing metrics from their output. Metrics collected from the backend are
+// never overwritten. The fallback is supplementary: a failed check only logs a warning.
+func (c *Collector) fillFromSSH(ctx context.Context, hosts []*model.HostMeta, hostMetricsMap map[string]*model.HostMetrics) {
+	if c.sshRunner == nil || c.config == nil {
+		return
+	}
+	cfg := c.config.Inspection.SSHFallback
+
+	checks := cfg.Checks
+	if len(checks) == 0 {
+		checks = ssh.DefaultChecks
+	}
+	passwordUser := cfg.PasswordUser
+	if passwordUser == "" {
+		passwordUser = cfg.User
+	}
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 10 // Default concurrency
+	}
+
+	defs := make(map[string]*model.MetricDefinition, len(c.metrics))
+	for _, metric := range c.metrics {
+		defs[metric.Name] = metric
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	var hostsFilled, checksFailed atomic.Int64
+	for _, host := range hosts {
+		hostMetrics := hostMetricsMap[host.Hostname]
+		if hostMetrics == nil {
+			continue
+		}
+
+		// Checks to run and the metrics each of them may fill on this host
+		missing := make(map[string]bool)
+		var hostChecks []string
+		for _, name := range checks {
+			needed := false
+			for _, metric := range ssh.CheckMetrics(name) {
+				if _, ok := defs[metric]; ok && !hasMetricValue(hostMetrics, metric) {
+					missing[metric] = true
+					needed = true
+				}
+			}
+			if needed {
+				hostChecks = append(hostChecks, name)
+			}
+		}
+		if len(hostChecks) == 0 {
+			continue
+		}
+
+		address := host.IP
+		if address == "" {
+			address = host.Hostname
+		}
+		g.Go(func() error {
+			var values []*model.MetricValue
+			for _, name := range hostChecks {
+				checkValues, err := ssh.RunCheck(gctx, c.sshRunner, address, name, passwordUser)
+				if err != nil {
+					checksFailed.Add(1)
+					c.logger.Warn().
+						Err(err).
+						Str("hostname", host.Hostname).
+						Str("check", name).
+						Msg("SSH fallback check failed, metrics left as N/A")
+					continue
+				}
+				values = append(values, checkValues...)
+			}
+
+			filled := 0
+			for _, mv := range sshMetricValues(values, defs, missing) {
+				mv.Timestamp = time.Now().Unix()
+				hostMetrics.SetMetric(mv)
+				filled++
+			}
+			if filled > 0 {
+				hostsFilled.Add(1)
+			}
+			return nil // A host failure does not abort the other hosts
+		})
+	}
+	_ = g.Wait()
+
+	c.logger.Info().
+		Int64("hosts_filled", hostsFilled.Load()).
+		Int64("failed_checks", checksFailed.Load()).
+		Msg("SSH fallback collection completed")
+}
+
+// sshMetricValues keeps the check values of the missing metrics. Values expanded by label
+// (disk_usage:/path) are filtered to physical disks like the backend series, keep only the
+// expansion label and give the aggregated maximum of metrics aggregated by max.
+func sshMetricValues(
+	values []*model.MetricValue,
+	defs map[string]*model.MetricDefinition,
+	missing map[string]bool,
+) []*model.MetricValue {
+	var kept []*model.MetricValue
+	maxValues := make(map[string]float64)
+	for _, mv := range values {
+		base, _, expanded := strings.Cut(mv.Name, ":")
+		if !missing[base] {
+			continue
+		}
+		def := defs[base]
+		if !expanded {
+			kept = append(kept, mv)
+			continue
+		}
+		if !def.HasExpandLabel() {
+			continue
+		}
+
+		labelValue := mv.Labels[def.ExpandByLabel]
+		if def.ExpandByLabel == "path" {
+			device, fstype := mv.Labels["device"], mv.Labels["fstype"]
+			if (device != "" && !isPhysicalBlockDevice(device, fstype)) || !isPhysicalDiskPath(labelValue) {
+				continue
+			}
+		}
+		mv.Labels = map[string]string{def.ExpandByLabel: labelValue}
+		kept = append(kept, mv)
+
+		if def.Aggregate == model.AggregateMax {
+			if current, ok := maxValues[base]; !ok || mv.RawValue > current {
+				maxValues[base] = mv.RawValue
+			}
+		}
+	}
+	for base, maxValue := range maxValues {
+		kept = append(kept, model.NewMetricValue(fmt.Sprintf("%s_max", base), maxValue))
+	}
+	return kept
+}
+
+// hasMetricValue reports whether the host has a value of the metric, directly or expanded by
+// label (disk_usage:/home); N/A values do not count.
+func hasMetricValue(hostMetrics *model.HostMetrics, metric string) bool {
+	for name, mv := range hostMetrics.Metrics {
+		if mv == nil || mv.IsNA {
+			continue
+		}
+		if name == metric || strings.HasPrefix(name, metric+":") {
+			return true
+		}
+	}
+	return false
+}
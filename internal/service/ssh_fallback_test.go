@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/model"
+)
+
+// sshRunnerFunc adapts a function to the ssh.Runner interface.
+type sshRunnerFunc func(ctx context.Context, host, command string) (string, error)
+
+func (f sshRunnerFunc) Run(ctx context.Context, host, command string) (string, error) {
+	return f(ctx, host, command)
+}
+
+func TestCollector_FillFromSSH(t *testing.T) {
+	metrics := []*model.MetricDefinition{
+		{Name: "disk_usage", Query: "disk_used_percent", ExpandByLabel: "path", Aggregate: model.AggregateMax},
+		{Name: "disk_total", Query: "disk_total", ExpandByLabel: "path"},
+		{Name: "uptime", Query: "system_uptime"},
+		{Name: "load_1m", Query: "system_load1"},
+		{Name: "ntp_check", Status: "pending"},
+		{Name: "password_expiry", Status: "pending"},
+	}
+
+	var mu sync.Mutex
+	commands := make(map[string][]string)
+	runner := sshRunnerFunc(func(_ context.Context, host, command string) (string, error) {
+		mu.Lock()
+		commands[host] = append(commands[host], strings.Fields(command)[1])
+		mu.Unlock()
+		switch {
+		case strings.Contains(command, "df"):
+			return "Filesystem Type 1024-blocks Used Available Capacity Mounted on\n" +
+				"/dev/sda1 xfs 1000 600 400 60% /\n" +
+				"/dev/sdb1 ext4 1000 900 100 90% /data\n" +
+				"tmpfs tmpfs 1000 0 1000 0% /run\n", nil
+		case strings.Contains(command, "/proc/uptime"):
+			return "3600.5 100.0\n0.50 0.40 0.30 1/100 1234\n", nil
+		case strings.Contains(command, "chronyc"):
+			return "System time     : 0.002000000 seconds fast of NTP time\nLeap status     : Normal\n", nil
+		case strings.Contains(command, "chage"):
+			if host == "10.0.0.2" {
+				return "", errors.New("connection refused")
+			}
+			return "Password expires : never\n", nil
+		}
+		t.Errorf("unexpected command %q", command)
+		return "", nil
+	})
+
+	cfg := createTestConfig()
+	cfg.Inspection.SSHFallback.User = "inspect"
+	collector := NewCollector(cfg, nil, nil, metrics, zerolog.Nop(), WithSSHFallback(runner))
+
+	hosts := []*model.HostMeta{
+		{Hostname: "agentless", IP: "10.0.0.1"},
+		{Hostname: "monitored", IP: "10.0.0.2"},
+	}
+	hostMetrics := map[string]*model.HostMetrics{
+		"agentless": model.NewHostMetrics("agentless"),
+		"monitored": model.NewHostMetrics("monitored"),
+	}
+	collector.setPendingMetrics(hostMetrics, metrics[4:])
+	monitored := hostMetrics["monitored"]
+	monitored.SetMetric(model.NewMetricValue("disk_usage:/", 42))
+	monitored.SetMetric(model.NewMetricValue("disk_total:/", 1024))
+	monitored.SetMetric(model.NewMetricValue("uptime", 7200))
+	monitored.SetMetric(model.NewMetricValue("load_1m", 1.5))
+
+	collector.fillFromSSH(context.Background(), hosts, hostMetrics)
+
+	agentless := hostMetrics["agentless"]
+	for name, want := range map[string]float64{
+		"disk_usage:/":     60,
+		"disk_usage:/data": 90,
+		"disk_usage_max":   90,
+		"disk_total:/":     1000 * 1024,
+		"uptime":           3600.5,
+		"load_1m":          0.5,
+		"ntp_check":        2,
+	} {
+		if mv := agentless.GetMetric(name); mv == nil || mv.IsNA || mv.RawValue != want {
+			t.Errorf("agentless %s = %+v, want %v", name, mv, want)
+		}
+	}
+	if mv := agentless.GetMetric("disk_usage:/run"); mv != nil {
+		t.Errorf("disk_usage:/run = %+v, want tmpfs skipped", mv)
+	}
+	if mv := agentless.GetMetric("disk_usage:/"); len(mv.Labels) != 1 || mv.Labels["path"] != "/" {
+		t.Errorf("disk_usage:/ labels = %v, want only the path", mv.Labels)
+	}
+	if mv := agentless.GetMetric("load_5m"); mv != nil {
+		t.Errorf("load_5m = %+v, want metrics without definition not set", mv)
+	}
+	if mv := agentless.GetMetric("password_expiry"); mv == nil || !mv.IsNA {
+		t.Errorf("password_expiry = %+v, want N/A for a password that never expires", mv)
+	}
+
+	// Backend metrics are kept; only the pending metrics are checked over SSH
+	if got := strings.Join(commands["10.0.0.2"], ","); got != "chronyc,chage" {
+		t.Errorf("commands on the monitored host = %q, want chronyc,chage", got)
+	}
+	if mv := monitored.GetMetric("disk_usage:/"); mv.RawValue != 42 {
+		t.Errorf("monitored disk_usage:/ = %v, want the backend value kept", mv.RawValue)
+	}
+	if mv := monitored.GetMetric("ntp_check"); mv == nil || mv.IsNA || mv.RawValue != 2 {
+		t.Errorf("monitored ntp_check = %+v, want filled over SSH", mv)
+	}
+	if mv := monitored.GetMetric("password_expiry"); mv == nil || !mv.IsNA {
+		t.Errorf("monitored password_expiry = %+v, want N/A after a failed check", mv)
+	}
+}
+
+func TestCollector_FillFromSSH_Disabled(t *testing.T) {
+	metrics := []*model.MetricDefinition{{Name: "ntp_check", Status: "pending"}}
+	hostMetrics := map[string]*model.HostMetrics{"host": model.NewHostMetrics("host")}
+	collector := NewCollector(createTestConfig(), nil, nil, metrics, zerolog.Nop())
+	collector.setPendingMetrics(hostMetrics, metrics)
+
+	collector.fillFromSSH(context.Background(), []*model.HostMeta{{Hostname: "host"}}, hostMetrics)
+
+	if mv := hostMetrics["host"].GetMetric("ntp_check"); mv == nil || !mv.IsNA {
+		t.Errorf("ntp_check = %+v, want N/A without SSH fallback", mv)
+	}
+}
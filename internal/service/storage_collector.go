@@ -0,0 +1,439 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// storageMountMetric is the per-mount metric; each series describes one mount point.
+const storageMountMetric = "storage_mount_status"
+
+// storageRoles maps metrics used for host discovery to the role they indicate.
+var storageRoles = map[string]string{
+	storageMountMetric:                   "挂载客户端",
+	"storage_gluster_peers_disconnected": "GlusterFS 节点",
+	"storage_gluster_bricks_offline":     "GlusterFS 节点",
+	"storage_nfs_rpc_errors":             "NFS 服务端",
+}
+
+// =============================================================================
+// Storage Collector
+// =============================================================================
+
+// StorageCollector is the data collection service for shared storage (NFS/GlusterFS).
+// It integrates with VictoriaMetrics to collect mount, GlusterFS and NFS server
+// metrics and N9E to obtain host IP addresses. Hosts are identified by hostname.
+type StorageCollector struct {
+	vmClient       vm.MetricsSource
+	n9eClient      *n9e.Client // 用于获取 IP 地址
+	config         *config.StorageInspectionConfig
+	metrics        []*model.StorageMetricDefinition
+	metricDefs     map[string]*model.StorageMetricDefinition
+	instanceFilter *StorageInstanceFilter
+	logger         zerolog.Logger
+}
+
+// StorageInstanceFilter defines filtering criteria for shared storage hosts.
+type StorageInstanceFilter struct {
+	HostnamePatterns []string          // Hostname patterns (glob, e.g., "GX-NFS-*")
+	BusinessGroups   []string          // Business groups (OR relation)
+	Tags             map[string]string // Tags (AND relation)
+}
+
+// NewStorageCollector creates a new StorageCollector instance.
+func NewStorageCollector(
+	cfg *config.StorageInspectionConfig,
+	vmClient vm.MetricsSource,
+	n9eClient *n9e.Client,
+	metrics []*model.StorageMetricDefinition,
+	logger zerolog.Logger,
+) *StorageCollector {
+	c := &StorageCollector{
+		vmClient:  vmClient,
+		n9eClient: n9eClient,
+		config:    cfg,
+		metrics:   metrics,
+		logger:    logger.With().Str("component", "storage-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.StorageMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	// Build instance filter from config
+	c.instanceFilter = c.buildInstanceFilter()
+
+	return c
+}
+
+// buildInstanceFilter converts config.StorageFilter to StorageInstanceFilter.
+func (c *StorageCollector) buildInstanceFilter() *StorageInstanceFilter {
+	if c.config == nil {
+		return nil
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.HostnamePatterns) == 0 &&
+		len(filter.BusinessGroups) == 0 &&
+		len(filter.Tags) == 0 {
+		return nil
+	}
+
+	return &StorageInstanceFilter{
+		HostnamePatterns: filter.HostnamePatterns,
+		BusinessGroups:   filter.BusinessGroups,
+		Tags:             filter.Tags,
+	}
+}
+
+// GetConfig returns the shared storage inspection configuration.
+func (c *StorageCollector) GetConfig() *config.StorageInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *StorageCollector) GetMetrics() []*model.StorageMetricDefinition {
+	return c.metrics
+}
+
+// GetInstanceFilter returns the instance filter.
+func (c *StorageCollector) GetInstanceFilter() *StorageInstanceFilter {
+	return c.instanceFilter
+}
+
+// IsEmpty returns true if the instance filter has no filtering criteria.
+func (f *StorageInstanceFilter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.HostnamePatterns) == 0 &&
+		len(f.BusinessGroups) == 0 &&
+		len(f.Tags) == 0
+}
+
+// ToVMHostFilter converts StorageInstanceFilter to vm.HostFilter.
+// Note: HostnamePatterns are not supported in vm.HostFilter and are
+// handled separately in the DiscoverInstances method.
+func (f *StorageInstanceFilter) ToVMHostFilter() *vm.HostFilter {
+	if f == nil || f.IsEmpty() {
+		return nil
+	}
+
+	if len(f.BusinessGroups) == 0 && len(f.Tags) == 0 {
+		return nil
+	}
+
+	return &vm.HostFilter{
+		BusinessGroups: f.BusinessGroups,
+		Tags:           f.Tags,
+	}
+}
+
+// =============================================================================
+// 共享存储主机发现
+// =============================================================================
+
+// DiscoverInstances discovers all shared storage hosts.
+// Hosts are the union of those returned by the mount, GlusterFS and NFS server
+// metrics; each metric also adds the corresponding role to the host.
+// IP addresses are retrieved from N9E API.
+func (c *StorageCollector) DiscoverInstances(ctx context.Context) ([]*model.StorageInstance, error) {
+	c.logger.Info().Msg("starting shared storage host discovery")
+
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	instanceMap := make(map[string]*model.StorageInstance)
+	var order []string
+	queried := 0
+
+	for _, metric := range c.metrics {
+		role, ok := storageRoles[metric.Name]
+		if !ok || metric.IsPending() {
+			continue
+		}
+
+		results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("failed to query discovery metric, continuing with others")
+			continue
+		}
+		queried++
+
+		for _, result := range results {
+			hostname := labelHostname(result.Labels)
+			if hostname == "" {
+				c.logger.Warn().Interface("labels", result.Labels).Msg("missing hostname labels")
+				continue
+			}
+
+			if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
+				c.logger.Debug().Str("hostname", hostname).Msg("hostname filtered out")
+				continue
+			}
+
+			instance, exists := instanceMap[hostname]
+			if !exists {
+				instance = model.NewStorageInstance(hostname)
+				instanceMap[hostname] = instance
+				order = append(order, hostname)
+			}
+			instance.AddRole(role)
+		}
+	}
+
+	if queried == 0 {
+		return nil, fmt.Errorf("failed to query any shared storage discovery metric")
+	}
+
+	instances := make([]*model.StorageInstance, 0, len(order))
+	for _, hostname := range order {
+		instance := instanceMap[hostname]
+		instance.SetIP(n9eHostIP(ctx, c.n9eClient, hostname, c.logger))
+		instances = append(instances, instance)
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Msg("shared storage host discovery completed")
+
+	return instances, nil
+}
+
+// =============================================================================
+// 共享存储指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all shared storage hosts.
+//
+// Flow:
+//  1. Initialize result objects for each host
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values from metrics
+//  6. Return results map (key = identifier)
+//
+// Single metric failure does not abort the entire collection.
+func (c *StorageCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.StorageInstance,
+	metrics []*model.StorageMetricDefinition,
+) (map[string]*model.StorageInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting Storage metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by identifier)
+	resultsMap := make(map[string]*model.StorageInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Identifier] = model.NewStorageInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.StorageMetricDefinition
+	var activeMetrics []*model.StorageMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("Storage metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for all pending metrics on all hosts.
+func (c *StorageCollector) setPendingMetrics(
+	resultsMap map[string]*model.StorageInspectionResult,
+	pendingMetrics []*model.StorageMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending Storage metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			result.SetMetric(&model.StorageMetricValue{
+				Name:           metric.Name,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// collectMetricConcurrent collects a single metric for all hosts (concurrent-safe).
+// Series of the mount metric are added as mount points instead of metric values.
+func (c *StorageCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.StorageMetricDefinition,
+	resultsMap map[string]*model.StorageInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting Storage metric (concurrent)")
+
+	// Query VictoriaMetrics
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		hostname := labelHostname(result.Labels)
+		if hostname == "" {
+			continue
+		}
+
+		inspResult, ok := resultsMap[hostname]
+		if !ok {
+			continue
+		}
+
+		// Each series of the mount metric is a separate mount point
+		if metric.Name == storageMountMetric {
+			inspResult.AddMount(&model.StorageMount{
+				MountPoint: result.Labels["mountpoint"],
+				FSType:     result.Labels["fstype"],
+				Device:     result.Labels["device"],
+				Stale:      result.Value != 0,
+			})
+			matchedCount++
+			continue
+		}
+
+		mv := &model.StorageMetricValue{
+			Name:      metric.Name,
+			RawValue:  result.Value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields.
+// Mount points are summarized into the storage_mount_status metric (value = stale count).
+// NaN/Inf values (e.g. no data in the rate window) are treated as not collected.
+func (c *StorageCollector) extractFieldsFromMetrics(resultsMap map[string]*model.StorageInspectionResult) {
+	for _, result := range resultsMap {
+		if len(result.Mounts) > 0 {
+			sort.Slice(result.Mounts, func(i, j int) bool {
+				return result.Mounts[i].MountPoint < result.Mounts[j].MountPoint
+			})
+			stale := result.StaleMountPoints()
+			result.StaleMounts = len(stale)
+			result.SetMetric(&model.StorageMetricValue{
+				Name:        storageMountMetric,
+				RawValue:    float64(len(stale)),
+				StringValue: strings.Join(stale, ", "),
+				Timestamp:   time.Now().Unix(),
+			})
+		}
+
+		if mv := result.GetMetric("storage_gluster_peers_disconnected"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.GlusterPeersDisconnected = mv.RawValue
+		}
+		if mv := result.GetMetric("storage_gluster_bricks_offline"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.GlusterBricksOffline = mv.RawValue
+		}
+		if mv := result.GetMetric("storage_nfs_rpc_errors"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.NFSRPCErrors = mv.RawValue
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// createTestStorageCollector creates a StorageCollector querying fake VM and N9E servers.
+func createTestStorageCollector(t *testing.T, series map[string][]fakeSeries, ips map[string]string, cfg *config.StorageInspectionConfig, metrics []*model.StorageMetricDefinition) *StorageCollector {
+	t.Helper()
+	vmServer := newFakeVMServer(t, series)
+	n9eServer := newFakeN9EServer(t, ips)
+	return NewStorageCollector(cfg, newFakeVMClient(vmServer.URL), newFakeN9EClient(n9eServer.URL), metrics, zerolog.Nop())
+}
+
+// createTestStorageMetricDefs returns the storage metrics, querying the fake VM server by metric name.
+func createTestStorageMetricDefs() []*model.StorageMetricDefinition {
+	return []*model.StorageMetricDefinition{
+		{Name: "storage_mount_status", Query: "storage_mount_status"},
+		{Name: "storage_gluster_peers_disconnected", Query: "storage_gluster_peers_disconnected"},
+		{Name: "storage_gluster_bricks_offline", Query: "storage_gluster_bricks_offline"},
+		{Name: "storage_nfs_rpc_errors", Query: "storage_nfs_rpc_errors"},
+	}
+}
+
+// storageMountLabels returns the labels of an NFS mount point on host.
+func storageMountLabels(host, mountpoint string) map[string]string {
+	return map[string]string{"agent_hostname": host, "mountpoint": mountpoint, "fstype": "nfs4", "device": "nfs01:/export"}
+}
+
+func TestStorageCollector_DiscoverInstances(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"storage_mount_status": {
+			{storageMountLabels("GX-APP-01", "/data"), 0},
+			{storageMountLabels("GX-APP-01", "/backup"), 0}, // Same host
+			{storageMountLabels("SH-APP-01", "/data"), 0},   // Filtered out
+			{map[string]string{"mountpoint": "/data"}, 0},   // No hostname
+			{map[string]string{"ident": "GX-GFS-01"}, 0},    // Gluster node mounting its volume
+		},
+		"storage_gluster_peers_disconnected": {
+			{map[string]string{"agent_hostname": "GX-GFS-01"}, 0},
+		},
+		"storage_nfs_rpc_errors": {
+			{map[string]string{"agent_hostname": "GX-NFS-01"}, 0},
+		},
+	}
+	cfg := &config.StorageInspectionConfig{InstanceFilter: config.StorageFilter{HostnamePatterns: []string{"GX-*"}}}
+	collector := createTestStorageCollector(t, series, map[string]string{"GX-NFS-01": "10.0.0.5"}, cfg, createTestStorageMetricDefs())
+
+	instances, err := collector.DiscoverInstances(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverInstances() error = %v", err)
+	}
+	if len(instances) != 3 {
+		t.Fatalf("DiscoverInstances() returned %d hosts, want 3: %+v", len(instances), instances)
+	}
+
+	byHost := make(map[string]*model.StorageInstance, len(instances))
+	for _, instance := range instances {
+		byHost[instance.Hostname] = instance
+	}
+	if roles := byHost["GX-APP-01"].Roles; !slices.Equal(roles, []string{"挂载客户端"}) {
+		t.Errorf("GX-APP-01 roles = %v", roles)
+	}
+	if roles := byHost["GX-GFS-01"].Roles; !slices.Equal(roles, []string{"挂载客户端", "GlusterFS 节点"}) {
+		t.Errorf("GX-GFS-01 roles = %v, want both roles once", roles)
+	}
+	if nfs := byHost["GX-NFS-01"]; nfs == nil || nfs.IP != "10.0.0.5" || byHost["GX-APP-01"].IP != "N/A" {
+		t.Errorf("IPs = %+v, want the N9E IP of GX-NFS-01 only", instances)
+	}
+}
+
+func TestStorageCollector_DiscoverInstances_QueryError(t *testing.T) {
+	collector := NewStorageCollector(nil, newFakeVMClient("http://127.0.0.1:1"), nil, createTestStorageMetricDefs(), zerolog.Nop())
+	if _, err := collector.DiscoverInstances(context.Background()); err == nil {
+		t.Error("DiscoverInstances() should fail when no discovery metric can be queried")
+	}
+}
+
+func TestStorageCollector_CollectMetrics(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"storage_mount_status": {
+			{storageMountLabels("GX-APP-01", "/data"), 1}, // Stale
+			{storageMountLabels("GX-APP-01", "/backup"), 0},
+			{storageMountLabels("GX-APP-09", "/data"), 1}, // Not discovered
+		},
+		"storage_gluster_peers_disconnected": {
+			{map[string]string{"agent_hostname": "GX-GFS-01"}, 1},
+		},
+	}
+	metrics := createTestStorageMetricDefs()
+	metrics[3] = &model.StorageMetricDefinition{Name: "storage_nfs_rpc_errors", Status: "pending"}
+	collector := createTestStorageCollector(t, series, nil, nil, metrics)
+
+	instances := []*model.StorageInstance{model.NewStorageInstance("GX-APP-01"), model.NewStorageInstance("GX-GFS-01")}
+	results, err := collector.CollectMetrics(context.Background(), instances, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("CollectMetrics() returned %d results, want only the discovered hosts", len(results))
+	}
+
+	app := results["GX-APP-01"]
+	if len(app.Mounts) != 2 || app.Mounts[0].MountPoint != "/backup" || app.Mounts[1].FSType != "nfs4" {
+		t.Errorf("mounts = %+v, want both mount points sorted", app.Mounts)
+	}
+	if app.StaleMounts != 1 {
+		t.Errorf("StaleMounts = %d, want 1", app.StaleMounts)
+	}
+	if mv := app.GetMetric("storage_mount_status"); mv == nil || mv.RawValue != 1 || mv.StringValue != "/data" {
+		t.Errorf("storage_mount_status metric = %+v, want the stale mount point", mv)
+	}
+	if app.GlusterPeersDisconnected != -1 {
+		t.Errorf("GlusterPeersDisconnected = %v, want -1 without series", app.GlusterPeersDisconnected)
+	}
+
+	gluster := results["GX-GFS-01"]
+	if gluster.GlusterPeersDisconnected != 1 || gluster.NFSRPCErrors != -1 {
+		t.Errorf("gluster = %v peers disconnected, %v RPC errors, want 1 and -1", gluster.GlusterPeersDisconnected, gluster.NFSRPCErrors)
+	}
+	if mv := gluster.GetMetric("storage_nfs_rpc_errors"); mv == nil || !mv.IsNA {
+		t.Errorf("pending metric = %+v, want N/A", mv)
+	}
+}
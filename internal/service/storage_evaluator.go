@@ -0,0 +1,278 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Storage Evaluator
+// =============================================================================
+
+// StorageEvaluationResult represents the evaluation result for a single shared storage host.
+type StorageEvaluationResult struct {
+	Identifier string                      `json:"identifier"` // 主机标识符
+	Status     model.StorageInstanceStatus `json:"status"`     // 主机整体状态
+	Alerts     []*model.StorageAlert       `json:"alerts"`     // 告警列表
+}
+
+// StorageEvaluator evaluates shared storage metrics against thresholds.
+type StorageEvaluator struct {
+	thresholds *config.StorageThresholds                 // 阈值配置
+	metricDefs map[string]*model.StorageMetricDefinition // 指标定义映射（用于获取显示名称）
+	timezone   *time.Location                            // 时区
+	logger     zerolog.Logger                            // 日志器
+}
+
+// NewStorageEvaluator creates a new StorageEvaluator with the given threshold configuration.
+func NewStorageEvaluator(
+	thresholds *config.StorageThresholds,
+	metrics []*model.StorageMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *StorageEvaluator {
+	metricDefs := make(map[string]*model.StorageMetricDefinition)
+	for _, m := range metrics {
+		metricDefs[m.Name] = m
+	}
+
+	return &StorageEvaluator{
+		thresholds: thresholds,
+		metricDefs: metricDefs,
+		timezone:   timezone,
+		logger:     logger.With().Str("component", "storage_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all shared storage hosts and returns the complete evaluation results.
+func (e *StorageEvaluator) EvaluateAll(
+	results map[string]*model.StorageInspectionResult,
+) []*StorageEvaluationResult {
+	evalResults := make([]*StorageEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("storage evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single shared storage host against configured thresholds.
+// Every stale mount raises its own critical alert so that each mount point is listed.
+func (e *StorageEvaluator) Evaluate(
+	result *model.StorageInspectionResult,
+) *StorageEvaluationResult {
+	evalResult := &StorageEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.StorageStatusNormal,
+		Alerts:     make([]*model.StorageAlert, 0),
+	}
+
+	// Skip failed instances
+	if result.Error != "" {
+		evalResult.Status = model.StorageStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed host")
+		return evalResult
+	}
+
+	// 1. Evaluate mount availability (stale mount -> Critical)
+	evalResult.Alerts = append(evalResult.Alerts, e.evaluateMounts(result)...)
+
+	// 2. Evaluate GlusterFS peer and brick status (any disconnected/offline -> Critical)
+	if result.HasGlusterPeers() && result.GlusterPeersDisconnected > 0 {
+		evalResult.Alerts = append(evalResult.Alerts,
+			e.createAlert(result.GetIdentifier(), "storage_gluster_peers_disconnected", result.GlusterPeersDisconnected, model.AlertLevelCritical))
+	}
+	if result.HasGlusterBricks() && result.GlusterBricksOffline > 0 {
+		evalResult.Alerts = append(evalResult.Alerts,
+			e.createAlert(result.GetIdentifier(), "storage_gluster_bricks_offline", result.GlusterBricksOffline, model.AlertLevelCritical))
+	}
+
+	// 3. Evaluate NFS server RPC errors
+	if result.HasNFSRPCErrors() {
+		if alert := e.evaluateThreshold(result, "storage_nfs_rpc_errors", result.NFSRPCErrors,
+			e.thresholds.NFSRPCErrorsWarning, e.thresholds.NFSRPCErrorsCritical); alert != nil {
+			evalResult.Alerts = append(evalResult.Alerts, alert)
+		}
+	}
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("host evaluation completed")
+
+	return evalResult
+}
+
+// evaluateMounts creates a critical alert for each stale mount point.
+func (e *StorageEvaluator) evaluateMounts(
+	result *model.StorageInspectionResult,
+) []*model.StorageAlert {
+	var alerts []*model.StorageAlert
+
+	for _, mount := range result.Mounts {
+		if !mount.Stale {
+			continue
+		}
+		alert := e.createAlert(result.GetIdentifier(), storageMountMetric, 1, model.AlertLevelCritical)
+		alert.Message = fmt.Sprintf("挂载点 %s 已失效（stale），访问将挂起或报错", mount.String())
+		alerts = append(alerts, alert)
+	}
+
+	return alerts
+}
+
+// evaluateThreshold evaluates a "higher is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *StorageEvaluator) evaluateThreshold(
+	result *model.StorageInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.StorageAlert {
+	return e.alertAt(result, metricName, value, config.ThresholdPair{Warning: warning, Critical: critical})
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *StorageEvaluator) alertAt(
+	result *model.StorageInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.StorageAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *StorageEvaluator) determineInstanceStatus(
+	alerts []*model.StorageAlert,
+) model.StorageInstanceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.StorageStatusCritical
+	}
+	if hasWarning {
+		return model.StorageStatusWarning
+	}
+	return model.StorageStatusNormal
+}
+
+// createAlert creates a StorageAlert with formatted message.
+func (e *StorageEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.StorageAlert {
+	displayName := metricName
+	if def, exists := e.metricDefs[metricName]; exists {
+		displayName = def.GetDisplayName()
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.StorageAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(metricName, currentValue, level),
+	}
+}
+
+// formatValue formats metric value for display.
+func (e *StorageEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case storageMountMetric:
+		if value != 0 {
+			return "失效"
+		}
+		return "正常"
+	case "storage_gluster_peers_disconnected", "storage_gluster_bricks_offline", "storage_nfs_rpc_errors":
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *StorageEvaluator) generateAlertMessage(
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case storageMountMetric:
+		return "共享存储挂载点已失效（stale）"
+	case "storage_gluster_peers_disconnected":
+		return fmt.Sprintf("GlusterFS 有 %.0f 个 peer 处于 Disconnected 状态", currentValue)
+	case "storage_gluster_bricks_offline":
+		return fmt.Sprintf("GlusterFS 有 %.0f 个 brick 离线，卷冗余已降级", currentValue)
+	case "storage_nfs_rpc_errors":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("近 5 分钟 NFS RPC 错误 %.0f 次，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.NFSRPCErrorsCritical)
+		}
+		return fmt.Sprintf("近 5 分钟 NFS RPC 错误 %.0f 次，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.NFSRPCErrorsWarning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric.
+// Stale mounts, disconnected peers and offline bricks are critical from the first occurrence.
+func (e *StorageEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case storageMountMetric, "storage_gluster_peers_disconnected", "storage_gluster_bricks_offline":
+		return 1, 1
+	case "storage_nfs_rpc_errors":
+		return e.thresholds.NFSRPCErrorsWarning, e.thresholds.NFSRPCErrorsCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *StorageEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{"storage_nfs_rpc_errors"})
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestStorageEvaluator creates a shared storage evaluator with default thresholds for testing.
+func createTestStorageEvaluator() *StorageEvaluator {
+	thresholds := &config.StorageThresholds{
+		NFSRPCErrorsWarning:  1,
+		NFSRPCErrorsCritical: 100,
+	}
+	metrics := []*model.StorageMetricDefinition{
+		{Name: "storage_mount_status", DisplayName: "挂载状态"},
+		{Name: "storage_gluster_bricks_offline", DisplayName: "GlusterFS 离线 Brick 数"},
+		{Name: "storage_nfs_rpc_errors", DisplayName: "NFS RPC 错误数"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewStorageEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// createTestStorageResult creates a test shared storage inspection result with one healthy mount.
+func createTestStorageResult() *model.StorageInspectionResult {
+	result := model.NewStorageInspectionResult(model.NewStorageInstance("app-01"))
+	result.AddMount(&model.StorageMount{MountPoint: "/data", FSType: "nfs4", Device: "10.0.0.1:/export/data"})
+	return result
+}
+
+// =============================================================================
+// Mount Tests
+// =============================================================================
+
+func TestStorageEvaluator_StaleMounts(t *testing.T) {
+	evaluator := createTestStorageEvaluator()
+	result := createTestStorageResult()
+	result.AddMount(&model.StorageMount{MountPoint: "/backup", FSType: "nfs4", Device: "10.0.0.1:/export/backup", Stale: true})
+	result.AddMount(&model.StorageMount{MountPoint: "/share", FSType: "fuse.glusterfs", Device: "gfs-01:/vol0", Stale: true})
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.StorageStatusCritical {
+		t.Errorf("expected critical status for stale mounts, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 2 {
+		t.Fatalf("expected one alert per stale mount, got %d alerts", len(evalResult.Alerts))
+	}
+	for i, mountPoint := range []string{"/backup", "/share"} {
+		alert := evalResult.Alerts[i]
+		if alert.Level != model.AlertLevelCritical {
+			t.Errorf("expected critical level for %s, got %s", mountPoint, alert.Level)
+		}
+		if !strings.Contains(alert.Message, mountPoint) {
+			t.Errorf("expected message to mention %s, got %q", mountPoint, alert.Message)
+		}
+	}
+}
+
+// =============================================================================
+// Threshold Tests
+// =============================================================================
+
+func TestStorageEvaluator_EvaluateThresholds(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(r *model.StorageInspectionResult)
+		metricName    string
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{"all not collected", func(r *model.StorageInspectionResult) {}, "", "", false},
+		{"gluster healthy", func(r *model.StorageInspectionResult) {
+			r.GlusterPeersDisconnected = 0
+			r.GlusterBricksOffline = 0
+		}, "", "", false},
+		{"gluster peer disconnected", func(r *model.StorageInspectionResult) { r.GlusterPeersDisconnected = 1 }, "storage_gluster_peers_disconnected", model.AlertLevelCritical, true},
+		{"gluster brick offline", func(r *model.StorageInspectionResult) { r.GlusterBricksOffline = 2 }, "storage_gluster_bricks_offline", model.AlertLevelCritical, true},
+		{"nfs rpc errors normal", func(r *model.StorageInspectionResult) { r.NFSRPCErrors = 0 }, "", "", false},
+		{"nfs rpc errors warning", func(r *model.StorageInspectionResult) { r.NFSRPCErrors = 5 }, "storage_nfs_rpc_errors", model.AlertLevelWarning, true},
+		{"nfs rpc errors critical", func(r *model.StorageInspectionResult) { r.NFSRPCErrors = 500 }, "storage_nfs_rpc_errors", model.AlertLevelCritical, true},
+	}
+
+	evaluator := createTestStorageEvaluator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestStorageResult()
+			tt.setup(result)
+
+			evalResult := evaluator.Evaluate(result)
+			if !tt.expectAlert {
+				if len(evalResult.Alerts) != 0 {
+					t.Errorf("expected no alert, got %d", len(evalResult.Alerts))
+				}
+				return
+			}
+			if len(evalResult.Alerts) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(evalResult.Alerts))
+			}
+			alert := evalResult.Alerts[0]
+			if alert.MetricName != tt.metricName {
+				t.Errorf("expected metric %s, got %s", tt.metricName, alert.MetricName)
+			}
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+		})
+	}
+}
+
+func TestStorageEvaluator_ThresholdsDisabled(t *testing.T) {
+	evaluator := NewStorageEvaluator(&config.StorageThresholds{}, nil, nil, zerolog.Nop())
+	result := createTestStorageResult()
+	result.NFSRPCErrors = 10000
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.StorageStatusNormal {
+		t.Errorf("expected normal status with disabled thresholds, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 0 {
+		t.Errorf("expected no alerts, got %d", len(evalResult.Alerts))
+	}
+}
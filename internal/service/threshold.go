@@ -0,0 +1,65 @@
+package service
+
+import (
+	"slices"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// thresholdLevel returns the alert level of value against a pair of thresholds, in the
+// direction of the pair: "above" pairs alert when the value rises to a threshold, "below"
+// pairs when it falls to one. A threshold of 0 disables its level. The host and module
+// evaluators share it, so that both read thresholds the same way.
+func thresholdLevel(value float64, threshold config.ThresholdPair) model.AlertLevel {
+	reached := func(limit float64) bool {
+		if limit == 0 {
+			return false
+		}
+		if threshold.IsBelow() {
+			return value <= limit
+		}
+		return value >= limit
+	}
+
+	switch {
+	case reached(threshold.Critical):
+		return model.AlertLevelCritical
+	case reached(threshold.Warning):
+		return model.AlertLevelWarning
+	default:
+		return model.AlertLevelNormal
+	}
+}
+
+// belowThreshold returns the "lower is worse" pair of a module's warning and critical thresholds.
+func belowThreshold(warning, critical float64) config.ThresholdPair {
+	return config.ThresholdPair{Warning: warning, Critical: critical, Direction: config.ThresholdDirectionBelow}
+}
+
+// ModuleThreshold is a configured threshold pair of a module evaluator and the metric it
+// alerts on. The metric is the evaluator's metric name, which for derived values (usage
+// ratios, lags) is not the name of a metric definition.
+type ModuleThreshold struct {
+	Metric string
+	config.ThresholdPair
+}
+
+// moduleThresholds returns the enabled threshold pairs of metrics as read by get. The metrics
+// listed in below alert on falling values. State checks that alert on any occurrence have no
+// configurable thresholds and are left out by the callers.
+func moduleThresholds(get func(string) (float64, float64), metrics []string, below ...string) []ModuleThreshold {
+	var thresholds []ModuleThreshold
+	for _, metric := range metrics {
+		warning, critical := get(metric)
+		pair := config.ThresholdPair{Warning: warning, Critical: critical}
+		if slices.Contains(below, metric) {
+			pair = belowThreshold(warning, critical)
+		}
+		if pair.IsDisabled() {
+			continue
+		}
+		thresholds = append(thresholds, ModuleThreshold{Metric: metric, ThresholdPair: pair})
+	}
+	return thresholds
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+func TestThresholdLevel(t *testing.T) {
+	above := config.ThresholdPair{Warning: 70, Critical: 90}
+	below := belowThreshold(20, 10)
+
+	tests := []struct {
+		name      string
+		value     float64
+		threshold config.ThresholdPair
+		want      model.AlertLevel
+	}{
+		{"above normal", 69.9, above, model.AlertLevelNormal},
+		{"above at warning", 70, above, model.AlertLevelWarning},
+		{"above at critical", 90, above, model.AlertLevelCritical},
+		{"below normal", 20.1, below, model.AlertLevelNormal},
+		{"below at warning", 20, below, model.AlertLevelWarning},
+		{"below at critical", 10, below, model.AlertLevelCritical},
+		{"below zero value", 0, below, model.AlertLevelCritical},
+		{"above warning disabled", 80, config.ThresholdPair{Critical: 90}, model.AlertLevelNormal},
+		{"above critical only", 95, config.ThresholdPair{Critical: 90}, model.AlertLevelCritical},
+		{"below warning disabled", 15, belowThreshold(0, 10), model.AlertLevelNormal},
+		{"below critical only", 5, belowThreshold(0, 10), model.AlertLevelCritical},
+		{"below critical disabled", 0, belowThreshold(20, 0), model.AlertLevelWarning},
+		{"below equal thresholds", 10, belowThreshold(10, 10), model.AlertLevelCritical},
+		{"disabled", 100, config.ThresholdPair{}, model.AlertLevelNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thresholdLevel(tt.value, tt.threshold); got != tt.want {
+				t.Errorf("thresholdLevel(%v, %+v) = %s, want %s", tt.value, tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModuleThresholds(t *testing.T) {
+	get := func(metric string) (float64, float64) {
+		switch metric {
+		case "usage":
+			return 70, 90
+		case "hit_ratio":
+			return 95, 90
+		default:
+			return 0, 0
+		}
+	}
+	got := moduleThresholds(get, []string{"usage", "disabled", "hit_ratio"}, "hit_ratio")
+	want := []ModuleThreshold{
+		{Metric: "usage", ThresholdPair: config.ThresholdPair{Warning: 70, Critical: 90}},
+		{Metric: "hit_ratio", ThresholdPair: belowThreshold(95, 90)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("moduleThresholds() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMySQLEvaluatorThresholds(t *testing.T) {
+	thresholds := &config.MySQLThresholds{ConnectionUsageWarning: 70, ConnectionUsageCritical: 90}
+	evaluator := NewMySQLEvaluator(thresholds, nil, zerolog.Nop())
+	if got := evaluator.Thresholds(); len(got) != 1 || got[0].Metric != "connection_usage" {
+		t.Errorf("Thresholds() = %+v, want connection_usage only", got)
+	}
+
+	// The expected member count of the group alerts on fewer members
+	thresholds.MGRMemberCountExpected = 3
+	got := evaluator.Thresholds()
+	last := got[len(got)-1]
+	if last.Metric != "mgr_member_count" || !last.IsBelow() || last.Warning != 2 || last.Critical != 1 {
+		t.Errorf("Thresholds() = %+v, want mgr_member_count below 2 / 1", got)
+	}
+}
@@ -4,7 +4,7 @@ package service
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,7 +27,7 @@ import (
 // It integrates with VictoriaMetrics to collect Tomcat monitoring metrics
 // and N9E to obtain host IP addresses.
 type TomcatCollector struct {
-	vmClient       *vm.Client
+	vmClient       vm.MetricsSource
 	n9eClient      *n9e.Client // 用于获取 IP 地址
 	config         *config.TomcatInspectionConfig
 	metrics        []*model.TomcatMetricDefinition
@@ -48,7 +48,7 @@ type TomcatInstanceFilter struct {
 // NewTomcatCollector creates a new TomcatCollector instance.
 func NewTomcatCollector(
 	cfg *config.TomcatInspectionConfig,
-	vmClient *vm.Client,
+	vmClient vm.MetricsSource,
 	n9eClient *n9e.Client,
 	metrics []*model.TomcatMetricDefinition,
 	logger zerolog.Logger,
@@ -181,14 +181,14 @@ func (c *TomcatCollector) DiscoverInstances(ctx context.Context) ([]*model.Tomca
 
 	for _, result := range results {
 		// 5.1 Extract hostname
-		hostname := c.extractHostname(result.Labels)
+		hostname := labelHostname(result.Labels)
 		if hostname == "" {
 			c.logger.Warn().Interface("labels", result.Labels).Msg("missing hostname labels")
 			continue
 		}
 
 		// 5.2 Apply hostname pattern filter
-		if !c.matchesHostnamePatterns(hostname) {
+		if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
 			c.logger.Debug().Str("hostname", hostname).Msg("hostname filtered out")
 			continue
 		}
@@ -197,7 +197,7 @@ func (c *TomcatCollector) DiscoverInstances(ctx context.Context) ([]*model.Tomca
 		container := containerMap[hostname]
 
 		// 5.4 Apply container pattern filter (if container exists)
-		if container != "" && !c.matchesContainerPatterns(container) {
+		if container != "" && c.instanceFilter != nil && !matchAnyPattern(container, c.instanceFilter.ContainerPatterns) {
 			c.logger.Debug().
 				Str("hostname", hostname).
 				Str("container", container).
@@ -236,7 +236,7 @@ func (c *TomcatCollector) DiscoverInstances(ctx context.Context) ([]*model.Tomca
 		c.populateInstanceFromInfo(instance, infoMap, hostname)
 
 		// 5.9 Get IP from N9E
-		ip := c.getIPFromN9E(ctx, hostname)
+		ip := n9eHostIP(ctx, c.n9eClient, hostname, c.logger)
 		instance.SetIP(ip)
 
 		instances = append(instances, instance)
@@ -262,7 +262,7 @@ func (c *TomcatCollector) buildContainerMap(ctx context.Context, vmFilter *vm.Ho
 	}
 
 	for _, result := range results {
-		hostname := c.extractHostname(result.Labels)
+		hostname := labelHostname(result.Labels)
 		container := result.Labels["container"]
 		if hostname != "" && container != "" {
 			containerMap[hostname] = container
@@ -277,7 +277,7 @@ func (c *TomcatCollector) buildInfoMap(results []vm.QueryResult) map[string]map[
 	infoMap := make(map[string]map[string]string)
 
 	for _, result := range results {
-		hostname := c.extractHostname(result.Labels)
+		hostname := labelHostname(result.Labels)
 		if hostname != "" {
 			infoMap[hostname] = result.Labels
 		}
@@ -286,17 +286,6 @@ func (c *TomcatCollector) buildInfoMap(results []vm.QueryResult) map[string]map[
 	return infoMap
 }
 
-// extractHostname extracts hostname from metric labels.
-// Tries: agent_hostname > ident > host
-func (c *TomcatCollector) extractHostname(labels map[string]string) string {
-	for _, key := range []string{"agent_hostname", "ident", "host"} {
-		if val := labels[key]; val != "" {
-			return val
-		}
-	}
-	return ""
-}
-
 // extractPort extracts port from tomcat_info labels.
 func (c *TomcatCollector) extractPort(infoMap map[string]map[string]string, hostname string) int {
 	if info, ok := infoMap[hostname]; ok {
@@ -337,87 +326,6 @@ func (c *TomcatCollector) populateInstanceFromInfo(
 	}
 }
 
-// getIPFromN9E retrieves the IP address for a hostname from N9E API.
-// Returns "N/A" if the hostname is not found or an error occurs.
-func (c *TomcatCollector) getIPFromN9E(ctx context.Context, hostname string) string {
-	if c.n9eClient == nil {
-		return "N/A"
-	}
-
-	hostMeta, err := c.n9eClient.GetHostMetaByIdent(ctx, hostname)
-	if err != nil {
-		c.logger.Debug().
-			Err(err).
-			Str("hostname", hostname).
-			Msg("failed to get host meta from N9E")
-		return "N/A"
-	}
-
-	if hostMeta == nil || hostMeta.IP == "" {
-		return "N/A"
-	}
-
-	return hostMeta.IP
-}
-
-// matchesHostnamePatterns checks if a hostname matches any configured patterns.
-// Returns true if no patterns configured or hostname matches at least one pattern.
-func (c *TomcatCollector) matchesHostnamePatterns(hostname string) bool {
-	if c.instanceFilter == nil || len(c.instanceFilter.HostnamePatterns) == 0 {
-		return true
-	}
-
-	for _, pattern := range c.instanceFilter.HostnamePatterns {
-		if matchPattern(hostname, pattern) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// matchesContainerPatterns checks if a container name matches any configured patterns.
-// Returns true if no patterns configured or container matches at least one pattern.
-func (c *TomcatCollector) matchesContainerPatterns(container string) bool {
-	if c.instanceFilter == nil || len(c.instanceFilter.ContainerPatterns) == 0 {
-		return true
-	}
-
-	for _, pattern := range c.instanceFilter.ContainerPatterns {
-		if matchPattern(container, pattern) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// matchPattern checks if a value matches a pattern with wildcard support.
-// Supports wildcard '*' which matches any sequence of characters.
-func matchPattern(value, pattern string) bool {
-	// Exact match optimization
-	if value == pattern {
-		return true
-	}
-
-	// No wildcard
-	if !strings.Contains(pattern, "*") {
-		return false
-	}
-
-	// Convert to regex
-	regexPattern := regexp.QuoteMeta(pattern)
-	regexPattern = strings.ReplaceAll(regexPattern, "\\*", ".*")
-	regexPattern = "^" + regexPattern + "$"
-
-	re, err := regexp.Compile(regexPattern)
-	if err != nil {
-		return false
-	}
-
-	return re.MatchString(value)
-}
-
 // =============================================================================
 // Tomcat 指标采集
 // =============================================================================
@@ -476,10 +384,13 @@ func (c *TomcatCollector) CollectMetrics(
 
 	var mu sync.Mutex // Protects resultsMap from concurrent writes
 
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
 	for _, metric := range activeMetrics {
 		metric := metric // Capture loop variable
 		g.Go(func() error {
 			err := c.collectMetricConcurrent(ctx, metric, instances, resultsMap, &mu)
+			progress.QueryDone()
 			if err != nil {
 				c.logger.Warn().
 					Err(err).
@@ -678,10 +589,7 @@ func (c *TomcatCollector) extractIdentifier(
 	labels map[string]string,
 	identifierMap map[string]*model.TomcatInstance,
 ) string {
-	hostname := labels["agent_hostname"]
-	if hostname == "" {
-		hostname = labels["ident"]
-	}
+	hostname := labelHostname(labels)
 	if hostname == "" {
 		return ""
 	}
@@ -737,6 +645,17 @@ func (c *TomcatCollector) extractFieldsFromMetrics(resultsMap map[string]*model.
 			result.NonRootUser = mv.RawValue == 1
 		}
 
+		// JVM metrics from JMX exporter (NaN/Inf means no data in the window)
+		if mv := result.GetMetric("tomcat_jvm_heap_usage"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.HeapUsagePercent = mv.RawValue
+		}
+		if mv := result.GetMetric("tomcat_gc_pause_avg_ms"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.GCPauseAvgMs = mv.RawValue
+		}
+		if mv := result.GetMetric("tomcat_thread_pool_usage"); mv != nil && !mv.IsNA && !math.IsNaN(mv.RawValue) && !math.IsInf(mv.RawValue, 0) {
+			result.ThreadPoolUsagePercent = mv.RawValue
+		}
+
 		// Set collected time
 		result.CollectedAt = time.Now()
 	}
@@ -103,6 +103,17 @@ func (e *TomcatEvaluator) Evaluate(
 		evalResult.Alerts = append(evalResult.Alerts, alert)
 	}
 
+	// 4. Evaluate JVM heap, GC pause and thread pool (skipped when not collected or disabled)
+	if alert := e.evaluateHeapUsage(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateGCPause(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+	if alert := e.evaluateThreadPoolUsage(result); alert != nil {
+		evalResult.Alerts = append(evalResult.Alerts, alert)
+	}
+
 	// Aggregate status
 	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
 
@@ -188,28 +199,86 @@ func (e *TomcatEvaluator) evaluateLastErrorTime(
 	}
 
 	now := time.Now().Unix()
-	minutesSinceError := (now - timestamp) / 60
+	minutesSinceError := float64((now - timestamp) / 60)
 
-	// Critical: error within 10 minutes
-	criticalMinutes := int64(e.thresholds.LastErrorCriticalMinutes)
-	if minutesSinceError <= criticalMinutes {
+	// Critical: error within 10 minutes; warning: error within 60 minutes
+	threshold := belowThreshold(float64(e.thresholds.LastErrorWarningMinutes), float64(e.thresholds.LastErrorCriticalMinutes))
+	if level := thresholdLevel(minutesSinceError, threshold); level != model.AlertLevelNormal {
 		return e.createAlert(
 			result.GetIdentifier(),
 			"tomcat_last_error_timestamp",
-			float64(minutesSinceError),
-			model.AlertLevelCritical,
+			minutesSinceError,
+			level,
 		)
 	}
 
-	// Warning: error within 60 minutes
-	warningMinutes := int64(e.thresholds.LastErrorWarningMinutes)
-	if minutesSinceError <= warningMinutes {
-		return e.createAlert(
-			result.GetIdentifier(),
-			"tomcat_last_error_timestamp",
-			float64(minutesSinceError),
-			model.AlertLevelWarning,
-		)
+	return nil
+}
+
+// evaluateHeapUsage evaluates JVM heap usage percentage.
+// Returns nil if heap usage was not collected or both thresholds are 0 (disabled).
+func (e *TomcatEvaluator) evaluateHeapUsage(
+	result *model.TomcatInspectionResult,
+) *model.TomcatAlert {
+	if !result.HasHeapUsage() {
+		return nil
+	}
+
+	usage := result.HeapUsagePercent
+	warning := e.thresholds.HeapUsageWarning
+	critical := e.thresholds.HeapUsageCritical
+
+	if critical > 0 && usage >= critical {
+		return e.createAlert(result.GetIdentifier(), "tomcat_jvm_heap_usage", usage, model.AlertLevelCritical)
+	}
+	if warning > 0 && usage >= warning {
+		return e.createAlert(result.GetIdentifier(), "tomcat_jvm_heap_usage", usage, model.AlertLevelWarning)
+	}
+
+	return nil
+}
+
+// evaluateGCPause evaluates the average GC pause time (milliseconds).
+// Returns nil if GC pause was not collected or both thresholds are 0 (disabled).
+func (e *TomcatEvaluator) evaluateGCPause(
+	result *model.TomcatInspectionResult,
+) *model.TomcatAlert {
+	if !result.HasGCPause() {
+		return nil
+	}
+
+	pause := result.GCPauseAvgMs
+	warning := e.thresholds.GCPauseWarningMs
+	critical := e.thresholds.GCPauseCriticalMs
+
+	if critical > 0 && pause >= critical {
+		return e.createAlert(result.GetIdentifier(), "tomcat_gc_pause_avg_ms", pause, model.AlertLevelCritical)
+	}
+	if warning > 0 && pause >= warning {
+		return e.createAlert(result.GetIdentifier(), "tomcat_gc_pause_avg_ms", pause, model.AlertLevelWarning)
+	}
+
+	return nil
+}
+
+// evaluateThreadPoolUsage evaluates executor thread pool saturation (busy / max threads).
+// Returns nil if thread pool usage was not collected or both thresholds are 0 (disabled).
+func (e *TomcatEvaluator) evaluateThreadPoolUsage(
+	result *model.TomcatInspectionResult,
+) *model.TomcatAlert {
+	if !result.HasThreadPoolUsage() {
+		return nil
+	}
+
+	usage := result.ThreadPoolUsagePercent
+	warning := e.thresholds.ThreadPoolUsageWarning
+	critical := e.thresholds.ThreadPoolUsageCritical
+
+	if critical > 0 && usage >= critical {
+		return e.createAlert(result.GetIdentifier(), "tomcat_thread_pool_usage", usage, model.AlertLevelCritical)
+	}
+	if warning > 0 && usage >= warning {
+		return e.createAlert(result.GetIdentifier(), "tomcat_thread_pool_usage", usage, model.AlertLevelWarning)
 	}
 
 	return nil
@@ -290,6 +359,10 @@ func (e *TomcatEvaluator) formatValue(value float64, metricName string) string {
 		return "普通用户"
 	case "tomcat_last_error_timestamp":
 		return fmt.Sprintf("%.0f 分钟前", value)
+	case "tomcat_jvm_heap_usage", "tomcat_thread_pool_usage":
+		return fmt.Sprintf("%.1f%%", value)
+	case "tomcat_gc_pause_avg_ms":
+		return fmt.Sprintf("%.0f ms", value)
 	default:
 		return fmt.Sprintf("%.2f", value)
 	}
@@ -314,6 +387,27 @@ func (e *TomcatEvaluator) generateAlertMessage(
 		}
 		return fmt.Sprintf("最近 %d 分钟内有错误日志（警告阈值: %d 分钟）",
 			minutes, e.thresholds.LastErrorWarningMinutes)
+	case "tomcat_jvm_heap_usage":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("JVM 堆内存使用率为 %.1f%%，已超过严重阈值 %.1f%%",
+				currentValue, e.thresholds.HeapUsageCritical)
+		}
+		return fmt.Sprintf("JVM 堆内存使用率为 %.1f%%，已超过警告阈值 %.1f%%",
+			currentValue, e.thresholds.HeapUsageWarning)
+	case "tomcat_gc_pause_avg_ms":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("平均 GC 暂停时间为 %.0f ms，已超过严重阈值 %.0f ms",
+				currentValue, e.thresholds.GCPauseCriticalMs)
+		}
+		return fmt.Sprintf("平均 GC 暂停时间为 %.0f ms，已超过警告阈值 %.0f ms",
+			currentValue, e.thresholds.GCPauseWarningMs)
+	case "tomcat_thread_pool_usage":
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("线程池饱和度为 %.1f%%，已超过严重阈值 %.1f%%",
+				currentValue, e.thresholds.ThreadPoolUsageCritical)
+		}
+		return fmt.Sprintf("线程池饱和度为 %.1f%%，已超过警告阈值 %.1f%%",
+			currentValue, e.thresholds.ThreadPoolUsageWarning)
 	default:
 		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
 	}
@@ -330,7 +424,20 @@ func (e *TomcatEvaluator) getThresholds(metricName string) (warning float64, cri
 		return 1, 1
 	case "tomcat_non_root_user":
 		return 1, 1
+	case "tomcat_jvm_heap_usage":
+		return e.thresholds.HeapUsageWarning, e.thresholds.HeapUsageCritical
+	case "tomcat_gc_pause_avg_ms":
+		return e.thresholds.GCPauseWarningMs, e.thresholds.GCPauseCriticalMs
+	case "tomcat_thread_pool_usage":
+		return e.thresholds.ThreadPoolUsageWarning, e.thresholds.ThreadPoolUsageCritical
 	default:
 		return 0, 0
 	}
 }
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *TomcatEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds,
+		[]string{"tomcat_jvm_heap_usage", "tomcat_gc_pause_avg_ms", "tomcat_thread_pool_usage", "tomcat_last_error_timestamp"},
+		"tomcat_last_error_timestamp")
+}
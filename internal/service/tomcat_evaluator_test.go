@@ -0,0 +1,144 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestTomcatEvaluator creates a Tomcat evaluator with JVM thresholds for testing.
+func createTestTomcatEvaluator() *TomcatEvaluator {
+	thresholds := &config.TomcatThresholds{
+		LastErrorWarningMinutes:  60,
+		LastErrorCriticalMinutes: 10,
+		HeapUsageWarning:         80,
+		HeapUsageCritical:        90,
+		GCPauseWarningMs:         200,
+		GCPauseCriticalMs:        500,
+		ThreadPoolUsageWarning:   80,
+		ThreadPoolUsageCritical:  95,
+	}
+	metrics := []*model.TomcatMetricDefinition{
+		{Name: "tomcat_jvm_heap_usage", DisplayName: "堆内存使用率"},
+		{Name: "tomcat_gc_pause_avg_ms", DisplayName: "平均 GC 暂停时间"},
+		{Name: "tomcat_thread_pool_usage", DisplayName: "线程池饱和度"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewTomcatEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// createTestTomcatResult creates a test Tomcat inspection result.
+func createTestTomcatResult() *model.TomcatInspectionResult {
+	result := model.NewTomcatInspectionResult(model.NewTomcatInstance("GX-MFUI-01", 18001))
+	result.Up = true
+	return result
+}
+
+// =============================================================================
+// JVM Metric Tests
+// =============================================================================
+
+func TestTomcatEvaluator_EvaluateHeapUsage(t *testing.T) {
+	tests := []struct {
+		name          string
+		usage         float64
+		expectedLevel model.AlertLevel
+		expectAlert   bool
+	}{
+		{"not collected", -1, "", false},
+		{"normal", 50, "", false},
+		{"warning", 85, model.AlertLevelWarning, true},
+		{"critical", 95, model.AlertLevelCritical, true},
+	}
+
+	evaluator := createTestTomcatEvaluator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createTestTomcatResult()
+			result.HeapUsagePercent = tt.usage
+
+			alert := evaluator.evaluateHeapUsage(result)
+			if !tt.expectAlert {
+				if alert != nil {
+					t.Errorf("expected no alert, got level %s", alert.Level)
+				}
+				return
+			}
+			if alert == nil {
+				t.Fatal("expected alert but got nil")
+			}
+			if alert.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, alert.Level)
+			}
+			if alert.MetricName != "tomcat_jvm_heap_usage" {
+				t.Errorf("expected metric name tomcat_jvm_heap_usage, got %s", alert.MetricName)
+			}
+		})
+	}
+}
+
+func TestTomcatEvaluator_EvaluateGCPause(t *testing.T) {
+	evaluator := createTestTomcatEvaluator()
+	result := createTestTomcatResult()
+
+	result.GCPauseAvgMs = 50
+	if alert := evaluator.evaluateGCPause(result); alert != nil {
+		t.Errorf("expected no alert for 50ms, got %s", alert.Level)
+	}
+
+	result.GCPauseAvgMs = 300
+	alert := evaluator.evaluateGCPause(result)
+	if alert == nil || alert.Level != model.AlertLevelWarning {
+		t.Fatalf("expected warning alert for 300ms, got %v", alert)
+	}
+	if alert.FormattedValue != "300 ms" {
+		t.Errorf("expected formatted value '300 ms', got %s", alert.FormattedValue)
+	}
+	if alert.WarningThreshold != 200 || alert.CriticalThreshold != 500 {
+		t.Errorf("unexpected thresholds: %v / %v", alert.WarningThreshold, alert.CriticalThreshold)
+	}
+
+	result.GCPauseAvgMs = 800
+	if alert := evaluator.evaluateGCPause(result); alert == nil || alert.Level != model.AlertLevelCritical {
+		t.Errorf("expected critical alert for 800ms, got %v", alert)
+	}
+}
+
+func TestTomcatEvaluator_EvaluateThreadPoolUsage(t *testing.T) {
+	evaluator := createTestTomcatEvaluator()
+	result := createTestTomcatResult()
+
+	result.ThreadPoolUsagePercent = 96
+	alert := evaluator.evaluateThreadPoolUsage(result)
+	if alert == nil || alert.Level != model.AlertLevelCritical {
+		t.Fatalf("expected critical alert for 96%%, got %v", alert)
+	}
+	if !contains(alert.Message, "线程池饱和度") {
+		t.Errorf("expected message to mention thread pool, got: %s", alert.Message)
+	}
+}
+
+func TestTomcatEvaluator_JVMThresholdsDisabled(t *testing.T) {
+	evaluator := NewTomcatEvaluator(&config.TomcatThresholds{}, nil, nil, zerolog.Nop())
+	result := createTestTomcatResult()
+	result.HeapUsagePercent = 99
+	result.GCPauseAvgMs = 5000
+	result.ThreadPoolUsagePercent = 100
+
+	evalResult := evaluator.Evaluate(result)
+
+	if evalResult.Status != model.TomcatStatusNormal {
+		t.Errorf("expected normal status with disabled thresholds, got %s", evalResult.Status)
+	}
+	if len(evalResult.Alerts) != 0 {
+		t.Errorf("expected no alerts, got %d", len(evalResult.Alerts))
+	}
+}
@@ -15,12 +15,13 @@ import (
 // TomcatInspector orchestrates the complete Tomcat inspection workflow, coordinating
 // instance discovery, data collection, threshold evaluation, and result aggregation.
 type TomcatInspector struct {
-	collector *TomcatCollector
-	evaluator *TomcatEvaluator
-	config    *config.Config
-	timezone  *time.Location
-	version   string
-	logger    zerolog.Logger
+	collector  *TomcatCollector
+	evaluator  *TomcatEvaluator
+	logFetcher *LogExcerptFetcher // 可选，为严重告警附加日志摘录
+	config     *config.Config
+	timezone   *time.Location
+	version    string
+	logger     zerolog.Logger
 }
 
 // TomcatInspectorOption is a functional option for configuring a TomcatInspector.
@@ -92,6 +93,14 @@ func WithTomcatVersion(version string) TomcatInspectorOption {
 	}
 }
 
+// WithTomcatLogExcerptFetcher enables log excerpt collection for instances with critical alerts.
+// Excerpts are only fetched when tomcat.log_excerpt.enabled is set.
+func WithTomcatLogExcerptFetcher(fetcher *LogExcerptFetcher) TomcatInspectorOption {
+	return func(i *TomcatInspector) {
+		i.logFetcher = fetcher
+	}
+}
+
 // GetTimezone returns the configured timezone.
 func (i *TomcatInspector) GetTimezone() *time.Location {
 	return i.timezone
@@ -166,6 +175,7 @@ func (i *TomcatInspector) Inspect(ctx context.Context) (*model.TomcatInspectionR
 		Msg("step 4: evaluating thresholds")
 
 	_ = i.evaluator.EvaluateAll(resultsMap)
+	i.attachLogExcerpts(ctx, resultsMap)
 
 	// Step 7: Build results
 	i.logger.Debug().Msg("step 5: building inspection results")
@@ -232,3 +242,43 @@ func (i *TomcatInspector) GetConfig() *config.TomcatInspectionConfig {
 	}
 	return &i.config.Tomcat
 }
+
+// attachLogExcerpts fetches log excerpts for instances with critical alerts and
+// attaches them to those alerts. Query failures are logged and do not fail the inspection.
+func (i *TomcatInspector) attachLogExcerpts(ctx context.Context, results map[string]*model.TomcatInspectionResult) {
+	if i.logFetcher == nil || !i.config.Tomcat.LogExcerpt.Enabled {
+		return
+	}
+
+	for _, r := range results {
+		if r == nil || r.Instance == nil {
+			continue
+		}
+
+		var critical []*model.TomcatAlert
+		for _, alert := range r.Alerts {
+			if alert.IsCritical() {
+				critical = append(critical, alert)
+			}
+		}
+		if len(critical) == 0 {
+			continue
+		}
+
+		excerpt, err := i.logFetcher.Fetch(ctx, &i.config.Tomcat.LogExcerpt, LogQueryVars{
+			Hostname:  r.Instance.Hostname,
+			IP:        r.Instance.IP,
+			Port:      r.Instance.Port,
+			Container: r.Instance.Container,
+			LogPath:   r.Instance.LogPath,
+		})
+		if err != nil {
+			i.logger.Warn().Err(err).Str("identifier", r.GetIdentifier()).Msg("failed to fetch log excerpt")
+			continue
+		}
+
+		for _, alert := range critical {
+			alert.LogExcerpt = excerpt
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"fmt"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// valueCheckLevel returns the alert level raised when check does not match.
+func valueCheckLevel(check *config.ValueCheck) model.AlertLevel {
+	if check.Level == string(model.AlertLevelCritical) {
+		return model.AlertLevelCritical
+	}
+	return model.AlertLevelWarning
+}
+
+// valueCheckMessage builds the alert message for a failed expected-value check.
+func valueCheckMessage(displayName, actual string, check *config.ValueCheck) string {
+	return fmt.Sprintf("%s为 %s，不符合期望值 %s", displayName, actual, check.Expected())
+}
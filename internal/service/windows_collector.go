@@ -0,0 +1,510 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"inspection-tool/internal/client/n9e"
+	"inspection-tool/internal/client/vm"
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// Per-object metrics; each series describes one service or IIS application pool.
+const (
+	windowsServiceStateMetric   = "windows_service_state"
+	windowsAppPoolStateMetric   = "windows_iis_app_pool_state"
+	windowsRequestsQueuedMetric = "windows_iis_requests_queued"
+)
+
+// Metrics derived from the service and application pool states after collection.
+const (
+	windowsStoppedServicesMetric = "windows_stopped_services"
+	windowsStoppedAppPoolsMetric = "windows_stopped_app_pools"
+)
+
+// windowsDiscoveryMetrics lists the metrics whose hosts are treated as Windows hosts.
+var windowsDiscoveryMetrics = map[string]bool{
+	windowsServiceStateMetric: true,
+	windowsAppPoolStateMetric: true,
+}
+
+// =============================================================================
+// Windows Collector
+// =============================================================================
+
+// WindowsCollector is the data collection service for Windows hosts.
+// It integrates with VictoriaMetrics to collect service and IIS application pool
+// metrics and N9E to obtain host IP addresses. Hosts are identified by hostname.
+type WindowsCollector struct {
+	vmClient       vm.MetricsSource
+	n9eClient      *n9e.Client // 用于获取 IP 地址
+	config         *config.WindowsInspectionConfig
+	metrics        []*model.WindowsMetricDefinition
+	metricDefs     map[string]*model.WindowsMetricDefinition
+	instanceFilter *WindowsInstanceFilter
+	services       map[string]bool // 服务白名单（小写）
+	logger         zerolog.Logger
+}
+
+// WindowsInstanceFilter defines filtering criteria for Windows hosts.
+type WindowsInstanceFilter struct {
+	HostnamePatterns []string          // Hostname patterns (glob, e.g., "GX-WIN-*")
+	BusinessGroups   []string          // Business groups (OR relation)
+	Tags             map[string]string // Tags (AND relation)
+}
+
+// NewWindowsCollector creates a new WindowsCollector instance.
+func NewWindowsCollector(
+	cfg *config.WindowsInspectionConfig,
+	vmClient vm.MetricsSource,
+	n9eClient *n9e.Client,
+	metrics []*model.WindowsMetricDefinition,
+	logger zerolog.Logger,
+) *WindowsCollector {
+	c := &WindowsCollector{
+		vmClient:  vmClient,
+		n9eClient: n9eClient,
+		config:    cfg,
+		metrics:   metrics,
+		logger:    logger.With().Str("component", "windows-collector").Logger(),
+	}
+
+	// Build metric definitions map for fast lookup
+	c.metricDefs = make(map[string]*model.WindowsMetricDefinition, len(metrics))
+	for _, m := range metrics {
+		c.metricDefs[m.Name] = m
+	}
+
+	// Build instance filter from config
+	c.instanceFilter = c.buildInstanceFilter()
+
+	// Build service allowlist (windows_exporter reports lowercase service names)
+	c.services = make(map[string]bool)
+	if cfg != nil {
+		for _, name := range cfg.Services {
+			c.services[strings.ToLower(name)] = true
+		}
+	}
+
+	return c
+}
+
+// buildInstanceFilter converts config.WindowsFilter to WindowsInstanceFilter.
+func (c *WindowsCollector) buildInstanceFilter() *WindowsInstanceFilter {
+	if c.config == nil {
+		return nil
+	}
+
+	filter := c.config.InstanceFilter
+	if len(filter.HostnamePatterns) == 0 &&
+		len(filter.BusinessGroups) == 0 &&
+		len(filter.Tags) == 0 {
+		return nil
+	}
+
+	return &WindowsInstanceFilter{
+		HostnamePatterns: filter.HostnamePatterns,
+		BusinessGroups:   filter.BusinessGroups,
+		Tags:             filter.Tags,
+	}
+}
+
+// GetConfig returns the Windows inspection configuration.
+func (c *WindowsCollector) GetConfig() *config.WindowsInspectionConfig {
+	return c.config
+}
+
+// GetMetrics returns the list of metric definitions.
+func (c *WindowsCollector) GetMetrics() []*model.WindowsMetricDefinition {
+	return c.metrics
+}
+
+// GetInstanceFilter returns the instance filter.
+func (c *WindowsCollector) GetInstanceFilter() *WindowsInstanceFilter {
+	return c.instanceFilter
+}
+
+// IsEmpty returns true if the instance filter has no filtering criteria.
+func (f *WindowsInstanceFilter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.HostnamePatterns) == 0 &&
+		len(f.BusinessGroups) == 0 &&
+		len(f.Tags) == 0
+}
+
+// ToVMHostFilter converts WindowsInstanceFilter to vm.HostFilter.
+// Note: HostnamePatterns are not supported in vm.HostFilter and are
+// handled separately in the DiscoverInstances method.
+func (f *WindowsInstanceFilter) ToVMHostFilter() *vm.HostFilter {
+	if f == nil || f.IsEmpty() {
+		return nil
+	}
+
+	if len(f.BusinessGroups) == 0 && len(f.Tags) == 0 {
+		return nil
+	}
+
+	return &vm.HostFilter{
+		BusinessGroups: f.BusinessGroups,
+		Tags:           f.Tags,
+	}
+}
+
+// =============================================================================
+// Windows 主机发现
+// =============================================================================
+
+// DiscoverInstances discovers all Windows hosts.
+// Hosts are the union of the hosts returned by the service state and
+// IIS application pool state metrics.
+// IP addresses are retrieved from N9E API.
+func (c *WindowsCollector) DiscoverInstances(ctx context.Context) ([]*model.WindowsInstance, error) {
+	c.logger.Info().Msg("starting Windows host discovery")
+
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	instanceMap := make(map[string]*model.WindowsInstance)
+	var order []string
+	queried := 0
+
+	for _, metric := range c.metrics {
+		if !windowsDiscoveryMetrics[metric.Name] || metric.IsPending() {
+			continue
+		}
+
+		results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+		if err != nil {
+			c.logger.Warn().Err(err).Str("metric", metric.Name).Msg("failed to query discovery metric, continuing with others")
+			continue
+		}
+		queried++
+
+		for _, result := range results {
+			hostname := labelHostname(result.Labels)
+			if hostname == "" {
+				c.logger.Warn().Interface("labels", result.Labels).Msg("missing hostname labels")
+				continue
+			}
+
+			if c.instanceFilter != nil && !matchAnyPattern(hostname, c.instanceFilter.HostnamePatterns) {
+				c.logger.Debug().Str("hostname", hostname).Msg("hostname filtered out")
+				continue
+			}
+
+			instance, exists := instanceMap[hostname]
+			if !exists {
+				instance = model.NewWindowsInstance(hostname)
+				instanceMap[hostname] = instance
+				order = append(order, hostname)
+			}
+		}
+	}
+
+	if queried == 0 {
+		return nil, fmt.Errorf("failed to query any Windows discovery metric")
+	}
+
+	instances := make([]*model.WindowsInstance, 0, len(order))
+	for _, hostname := range order {
+		instance := instanceMap[hostname]
+		instance.SetIP(n9eHostIP(ctx, c.n9eClient, hostname, c.logger))
+		instances = append(instances, instance)
+	}
+
+	c.logger.Info().
+		Int("discovered", len(instances)).
+		Msg("Windows host discovery completed")
+
+	return instances, nil
+}
+
+// =============================================================================
+// Windows 指标采集
+// =============================================================================
+
+// CollectMetrics retrieves metric data from VictoriaMetrics for all Windows hosts.
+//
+// Flow:
+//  1. Initialize result objects for each host
+//  2. Separate pending and active metrics
+//  3. Set N/A for pending metrics
+//  4. Concurrently collect active metrics (errgroup + concurrency limit)
+//  5. Extract field values from metrics
+//  6. Return results map (key = identifier)
+//
+// Single metric failure does not abort the entire collection.
+func (c *WindowsCollector) CollectMetrics(
+	ctx context.Context,
+	instances []*model.WindowsInstance,
+	metrics []*model.WindowsMetricDefinition,
+) (map[string]*model.WindowsInspectionResult, error) {
+	c.logger.Debug().
+		Int("instance_count", len(instances)).
+		Int("metric_count", len(metrics)).
+		Msg("collecting Windows metrics from VictoriaMetrics")
+
+	// Step 1: Initialize results map (indexed by identifier)
+	resultsMap := make(map[string]*model.WindowsInspectionResult, len(instances))
+	for _, instance := range instances {
+		resultsMap[instance.Identifier] = model.NewWindowsInspectionResult(instance)
+	}
+
+	// Step 2: Separate pending and active metrics
+	var pendingMetrics []*model.WindowsMetricDefinition
+	var activeMetrics []*model.WindowsMetricDefinition
+
+	for _, metric := range metrics {
+		if metric.IsPending() {
+			pendingMetrics = append(pendingMetrics, metric)
+		} else {
+			activeMetrics = append(activeMetrics, metric)
+		}
+	}
+
+	// Step 3: Set N/A for pending metrics
+	c.setPendingMetrics(resultsMap, pendingMetrics)
+
+	if len(activeMetrics) == 0 {
+		c.logger.Warn().Msg("no active metrics to collect")
+		return resultsMap, nil
+	}
+
+	// Step 4: Concurrently collect active metrics
+	g, ctx := errgroup.WithContext(ctx)
+	concurrency := 20 // Default concurrency
+	g.SetLimit(concurrency)
+
+	var mu sync.Mutex // Protects resultsMap from concurrent writes
+
+	progress := progressFrom(ctx)
+	progress.Start(len(instances), len(activeMetrics))
+	for _, metric := range activeMetrics {
+		metric := metric // Capture loop variable
+		g.Go(func() error {
+			err := c.collectMetricConcurrent(ctx, metric, resultsMap, &mu)
+			progress.QueryDone()
+			if err != nil {
+				c.logger.Warn().
+					Err(err).
+					Str("metric", metric.Name).
+					Msg("failed to collect metric, continuing with others")
+			}
+			return nil // Single metric failure does not abort
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("concurrent metric collection failed: %w", err)
+	}
+
+	// Step 5: Extract field values from metrics
+	c.extractFieldsFromMetrics(resultsMap)
+
+	c.logger.Info().
+		Int("instances", len(instances)).
+		Int("active_metrics", len(activeMetrics)).
+		Int("pending_metrics", len(pendingMetrics)).
+		Msg("Windows metrics collection completed")
+
+	return resultsMap, nil
+}
+
+// setPendingMetrics sets N/A values for all pending metrics on all hosts.
+func (c *WindowsCollector) setPendingMetrics(
+	resultsMap map[string]*model.WindowsInspectionResult,
+	pendingMetrics []*model.WindowsMetricDefinition,
+) {
+	if len(pendingMetrics) == 0 {
+		return
+	}
+
+	c.logger.Debug().
+		Int("pending_count", len(pendingMetrics)).
+		Msg("setting N/A for pending Windows metrics")
+
+	for _, metric := range pendingMetrics {
+		for _, result := range resultsMap {
+			result.SetMetric(&model.WindowsMetricValue{
+				Name:           metric.Name,
+				RawValue:       0,
+				FormattedValue: "N/A",
+				IsNA:           true,
+			})
+		}
+	}
+}
+
+// collectMetricConcurrent collects a single metric for all hosts (concurrent-safe).
+// Series of the service and application pool metrics are added to the host's services
+// and application pools instead of metric values.
+func (c *WindowsCollector) collectMetricConcurrent(
+	ctx context.Context,
+	metric *model.WindowsMetricDefinition,
+	resultsMap map[string]*model.WindowsInspectionResult,
+	mu *sync.Mutex,
+) error {
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Str("query", metric.Query).
+		Msg("collecting Windows metric (concurrent)")
+
+	// Query VictoriaMetrics
+	vmFilter := c.instanceFilter.ToVMHostFilter()
+	results, err := c.vmClient.QueryResultsWithFilter(ctx, metric.Query, vmFilter)
+	if err != nil {
+		return fmt.Errorf("query failed for %s: %w", metric.Name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	matchedCount := 0
+	for _, result := range results {
+		hostname := labelHostname(result.Labels)
+		if hostname == "" {
+			continue
+		}
+
+		inspResult, ok := resultsMap[hostname]
+		if !ok {
+			continue
+		}
+
+		// Each series of a per-object metric describes one service or application pool
+		if isWindowsObjectMetric(metric.Name) {
+			if c.setWindowsObjectValue(inspResult, metric.Name, result.Labels, result.Value) {
+				matchedCount++
+			}
+			continue
+		}
+
+		mv := &model.WindowsMetricValue{
+			Name:      metric.Name,
+			RawValue:  result.Value,
+			Timestamp: time.Now().Unix(),
+			Labels:    result.Labels,
+		}
+		if metric.HasLabelExtract() {
+			var values []string
+			for _, label := range metric.LabelExtract {
+				if val := result.Labels[label]; val != "" {
+					values = append(values, val)
+				}
+			}
+			mv.StringValue = strings.Join(values, ", ")
+		}
+		inspResult.SetMetric(mv)
+		matchedCount++
+	}
+
+	c.logger.Debug().
+		Str("metric", metric.Name).
+		Int("matched", matchedCount).
+		Msg("metric collection completed")
+
+	return nil
+}
+
+// extractFieldsFromMetrics extracts metric values to result struct fields.
+// Services and application pools are summarized into the windows_stopped_services,
+// windows_stopped_app_pools and windows_iis_requests_queued metrics so that they
+// appear in the raw data sheet.
+func (c *WindowsCollector) extractFieldsFromMetrics(resultsMap map[string]*model.WindowsInspectionResult) {
+	for _, result := range resultsMap {
+		if len(result.Services) > 0 {
+			sort.Slice(result.Services, func(i, j int) bool {
+				return result.Services[i].Name < result.Services[j].Name
+			})
+
+			stopped := result.StoppedServiceNames()
+			result.StoppedServices = len(stopped)
+			result.SetMetric(&model.WindowsMetricValue{
+				Name:        windowsStoppedServicesMetric,
+				RawValue:    float64(len(stopped)),
+				StringValue: strings.Join(stopped, ", "),
+				Timestamp:   time.Now().Unix(),
+			})
+		}
+
+		if len(result.AppPools) > 0 {
+			sort.Slice(result.AppPools, func(i, j int) bool {
+				return result.AppPools[i].Name < result.AppPools[j].Name
+			})
+
+			stopped := result.StoppedAppPoolNames()
+			result.StoppedAppPools = len(stopped)
+			result.SetMetric(&model.WindowsMetricValue{
+				Name:        windowsStoppedAppPoolsMetric,
+				RawValue:    float64(len(stopped)),
+				StringValue: strings.Join(stopped, ", "),
+				Timestamp:   time.Now().Unix(),
+			})
+
+			queued, collected := 0.0, false
+			for _, pool := range result.AppPools {
+				if pool.HasRequestsQueued() {
+					queued += pool.RequestsQueued
+					collected = true
+				}
+			}
+			if collected {
+				result.RequestsQueued = queued
+				result.SetMetric(&model.WindowsMetricValue{
+					Name:      windowsRequestsQueuedMetric,
+					RawValue:  queued,
+					Timestamp: time.Now().Unix(),
+				})
+			}
+		}
+
+		// Set collected time
+		result.CollectedAt = time.Now()
+	}
+}
+
+// isWindowsObjectMetric returns true for the per-service and per-application-pool metrics.
+func isWindowsObjectMetric(name string) bool {
+	switch name {
+	case windowsServiceStateMetric, windowsAppPoolStateMetric, windowsRequestsQueuedMetric:
+		return true
+	default:
+		return false
+	}
+}
+
+// setWindowsObjectValue stores a service or application pool metric value on the host,
+// identified from the windows_exporter name/state and app labels.
+// State series with value 0 (states the object is not in), services outside the allowlist
+// and NaN/Inf values are ignored; returns false if the series was skipped.
+func (c *WindowsCollector) setWindowsObjectValue(result *model.WindowsInspectionResult, metricName string, labels map[string]string, value float64) bool {
+	switch metricName {
+	case windowsServiceStateMetric:
+		name := labels["name"]
+		if name == "" || value != 1 || !c.services[strings.ToLower(name)] {
+			return false
+		}
+		result.GetService(name).State = labels["state"]
+	case windowsAppPoolStateMetric:
+		if labels["app"] == "" || value != 1 {
+			return false
+		}
+		result.GetAppPool(labels["app"]).State = labels["state"]
+	case windowsRequestsQueuedMetric:
+		if labels["app"] == "" || math.IsNaN(value) || math.IsInf(value, 0) {
+			return false
+		}
+		result.GetAppPool(labels["app"]).RequestsQueued = value
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// createTestWindowsVMCollector creates a WindowsCollector querying fake VM and N9E servers.
+func createTestWindowsVMCollector(t *testing.T, series map[string][]fakeSeries, ips map[string]string, cfg *config.WindowsInspectionConfig, metrics []*model.WindowsMetricDefinition) *WindowsCollector {
+	t.Helper()
+	vmServer := newFakeVMServer(t, series)
+	n9eServer := newFakeN9EServer(t, ips)
+	return NewWindowsCollector(cfg, newFakeVMClient(vmServer.URL), newFakeN9EClient(n9eServer.URL), metrics, zerolog.Nop())
+}
+
+// windowsStateLabels returns the windows_exporter labels of an object in state on host,
+// keyed by the name label of services or the app label of application pools.
+func windowsStateLabels(host, key, name, state string) map[string]string {
+	return map[string]string{"agent_hostname": host, key: name, "state": state}
+}
+
+func TestWindowsCollector_DiscoverInstances(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"windows_service_state": {
+			{windowsStateLabels("GX-WIN-01", "name", "w32time", "running"), 1},
+			{windowsStateLabels("GX-WIN-01", "name", "w32time", "stopped"), 0}, // Same host
+			{windowsStateLabels("SH-WIN-01", "name", "w32time", "running"), 1}, // Filtered out
+			{map[string]string{"name": "w32time"}, 1},                          // No hostname
+		},
+		"windows_iis_app_pool_state": {
+			{windowsStateLabels("GX-WIN-02", "app", "DefaultAppPool", "Running"), 1},
+		},
+	}
+	metrics := []*model.WindowsMetricDefinition{
+		{Name: "windows_service_state", Query: "windows_service_state"},
+		{Name: "windows_iis_app_pool_state", Query: "windows_iis_app_pool_state"},
+	}
+	cfg := &config.WindowsInspectionConfig{InstanceFilter: config.WindowsFilter{HostnamePatterns: []string{"GX-WIN-*"}}}
+	collector := createTestWindowsVMCollector(t, series, map[string]string{"GX-WIN-02": "10.0.0.2"}, cfg, metrics)
+
+	instances, err := collector.DiscoverInstances(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverInstances() error = %v", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("DiscoverInstances() returned %d hosts, want 2: %+v", len(instances), instances)
+	}
+	if instances[0].Hostname != "GX-WIN-01" || instances[0].IP != "N/A" {
+		t.Errorf("first host = %+v, want GX-WIN-01 without N9E IP", instances[0])
+	}
+	if instances[1].Hostname != "GX-WIN-02" || instances[1].IP != "10.0.0.2" {
+		t.Errorf("second host = %+v, want GX-WIN-02 with its N9E IP", instances[1])
+	}
+}
+
+func TestWindowsCollector_DiscoverInstances_QueryError(t *testing.T) {
+	metrics := []*model.WindowsMetricDefinition{{Name: "windows_service_state", Query: "windows_service_state"}}
+	collector := NewWindowsCollector(nil, newFakeVMClient("http://127.0.0.1:1"), nil, metrics, zerolog.Nop())
+	if _, err := collector.DiscoverInstances(context.Background()); err == nil {
+		t.Error("DiscoverInstances() should fail when no discovery metric can be queried")
+	}
+}
+
+func TestWindowsCollector_CollectMetrics(t *testing.T) {
+	series := map[string][]fakeSeries{
+		"windows_service_state": {
+			{windowsStateLabels("GX-WIN-01", "name", "w32time", "running"), 1},
+			{windowsStateLabels("GX-WIN-01", "name", "w32time", "stopped"), 0},
+			{windowsStateLabels("GX-WIN-01", "name", "spooler", "stopped"), 1},
+			{windowsStateLabels("GX-WIN-01", "name", "wuauserv", "stopped"), 1}, // Not allowlisted
+		},
+		"windows_iis_app_pool_state": {
+			{windowsStateLabels("GX-WIN-01", "app", "DefaultAppPool", "Running"), 1},
+			{windowsStateLabels("GX-WIN-01", "app", "LegacyPool", "Stopped"), 1},
+		},
+		"windows_iis_requests_queued": {
+			{map[string]string{"agent_hostname": "GX-WIN-01", "app": "DefaultAppPool"}, 3},
+			{map[string]string{"agent_hostname": "GX-WIN-01", "app": "LegacyPool"}, 2},
+		},
+		"windows_os_info": {
+			{map[string]string{"agent_hostname": "GX-WIN-01", "product": "Windows Server 2019"}, 1},
+		},
+	}
+	metrics := []*model.WindowsMetricDefinition{
+		{Name: "windows_service_state", Query: "windows_service_state"},
+		{Name: "windows_iis_app_pool_state", Query: "windows_iis_app_pool_state"},
+		{Name: "windows_iis_requests_queued", Query: "windows_iis_requests_queued"},
+		{Name: "windows_os_info", Query: "windows_os_info", LabelExtract: []string{"product"}},
+		{Name: "windows_uptime", Status: "pending"},
+	}
+	cfg := &config.WindowsInspectionConfig{Services: []string{"W32Time", "Spooler"}}
+	collector := createTestWindowsVMCollector(t, series, nil, cfg, metrics)
+
+	results, err := collector.CollectMetrics(context.Background(), []*model.WindowsInstance{model.NewWindowsInstance("GX-WIN-01")}, metrics)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+
+	result := results["GX-WIN-01"]
+	if len(result.Services) != 2 || result.Services[0].Name != "spooler" || result.Services[1].State != "running" {
+		t.Errorf("services = %+v, want the sorted allowlisted services in their current state", result.Services)
+	}
+	if result.StoppedServices != 1 {
+		t.Errorf("StoppedServices = %d, want 1", result.StoppedServices)
+	}
+	if mv := result.GetMetric("windows_stopped_services"); mv == nil || mv.StringValue != "spooler" {
+		t.Errorf("windows_stopped_services metric = %+v", mv)
+	}
+	if result.StoppedAppPools != 1 || result.RequestsQueued != 5 {
+		t.Errorf("app pools = %d stopped, %v queued, want 1 and 5", result.StoppedAppPools, result.RequestsQueued)
+	}
+	if mv := result.GetMetric("windows_os_info"); mv == nil || mv.StringValue != "Windows Server 2019" {
+		t.Errorf("windows_os_info metric = %+v, want the extracted product label", mv)
+	}
+	if mv := result.GetMetric("windows_uptime"); mv == nil || !mv.IsNA {
+		t.Errorf("pending metric = %+v, want N/A", mv)
+	}
+}
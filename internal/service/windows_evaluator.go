@@ -0,0 +1,293 @@
+// Package service provides business logic services for the inspection tool.
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Windows Evaluator
+// =============================================================================
+
+// WindowsEvaluationResult represents the evaluation result for a single Windows host.
+type WindowsEvaluationResult struct {
+	Identifier string                      `json:"identifier"` // 主机标识符
+	Status     model.WindowsInstanceStatus `json:"status"`     // 主机整体状态
+	Alerts     []*model.WindowsAlert       `json:"alerts"`     // 告警列表
+}
+
+// WindowsEvaluator evaluates Windows metrics against thresholds.
+type WindowsEvaluator struct {
+	thresholds *config.WindowsThresholds                 // 阈值配置
+	metricDefs map[string]*model.WindowsMetricDefinition // 指标定义映射（用于获取显示名称）
+	timezone   *time.Location                            // 时区
+	logger     zerolog.Logger                            // 日志器
+}
+
+// NewWindowsEvaluator creates a new WindowsEvaluator with the given threshold configuration.
+func NewWindowsEvaluator(
+	thresholds *config.WindowsThresholds,
+	metrics []*model.WindowsMetricDefinition,
+	timezone *time.Location,
+	logger zerolog.Logger,
+) *WindowsEvaluator {
+	metricDefs := make(map[string]*model.WindowsMetricDefinition)
+	for _, m := range metrics {
+		metricDefs[m.Name] = m
+	}
+
+	return &WindowsEvaluator{
+		thresholds: thresholds,
+		metricDefs: metricDefs,
+		timezone:   timezone,
+		logger:     logger.With().Str("component", "windows_evaluator").Logger(),
+	}
+}
+
+// EvaluateAll evaluates all Windows hosts and returns the complete evaluation results.
+func (e *WindowsEvaluator) EvaluateAll(
+	results map[string]*model.WindowsInspectionResult,
+) []*WindowsEvaluationResult {
+	evalResults := make([]*WindowsEvaluationResult, 0, len(results))
+
+	for _, result := range results {
+		evalResults = append(evalResults, e.Evaluate(result))
+	}
+
+	e.logger.Info().
+		Int("total_instances", len(evalResults)).
+		Msg("Windows evaluation completed")
+
+	return evalResults
+}
+
+// Evaluate evaluates a single Windows host against configured thresholds.
+// Every stopped service and application pool raises its own critical alert
+// so that each affected object is listed.
+func (e *WindowsEvaluator) Evaluate(
+	result *model.WindowsInspectionResult,
+) *WindowsEvaluationResult {
+	evalResult := &WindowsEvaluationResult{
+		Identifier: result.GetIdentifier(),
+		Status:     model.WindowsStatusNormal,
+		Alerts:     make([]*model.WindowsAlert, 0),
+	}
+
+	// Skip failed instances
+	if result.Error != "" {
+		evalResult.Status = model.WindowsStatusFailed
+		e.logger.Debug().
+			Str("identifier", result.GetIdentifier()).
+			Str("error", result.Error).
+			Msg("skipping evaluation for failed host")
+		return evalResult
+	}
+
+	// 1. Evaluate allowlisted services (not running -> Critical)
+	evalResult.Alerts = append(evalResult.Alerts, e.evaluateServices(result)...)
+
+	// 2. Evaluate IIS application pools (not Running -> Critical, request queue -> thresholds)
+	evalResult.Alerts = append(evalResult.Alerts, e.evaluateAppPools(result)...)
+
+	// Aggregate status
+	evalResult.Status = e.determineInstanceStatus(evalResult.Alerts)
+
+	// Update original result
+	result.Status = evalResult.Status
+	result.Alerts = evalResult.Alerts
+
+	e.logger.Debug().
+		Str("identifier", result.GetIdentifier()).
+		Str("status", string(evalResult.Status)).
+		Int("alert_count", len(evalResult.Alerts)).
+		Msg("host evaluation completed")
+
+	return evalResult
+}
+
+// evaluateServices creates a critical alert for each allowlisted service that is not running.
+func (e *WindowsEvaluator) evaluateServices(
+	result *model.WindowsInspectionResult,
+) []*model.WindowsAlert {
+	var alerts []*model.WindowsAlert
+
+	for _, svc := range result.Services {
+		if svc.IsRunning() {
+			continue
+		}
+		alert := e.createAlert(result.GetIdentifier(), windowsStoppedServicesMetric, 0, model.AlertLevelCritical)
+		alert.FormattedValue = svc.State
+		alert.Message = fmt.Sprintf("服务 %s 未运行（当前状态: %s）", svc.Name, svc.State)
+		alerts = append(alerts, alert)
+	}
+
+	return alerts
+}
+
+// evaluateAppPools creates a critical alert for each IIS application pool that is not Running
+// and checks the request queue length of each pool against the thresholds.
+func (e *WindowsEvaluator) evaluateAppPools(
+	result *model.WindowsInspectionResult,
+) []*model.WindowsAlert {
+	var alerts []*model.WindowsAlert
+
+	for _, pool := range result.AppPools {
+		if pool.HasState() && !pool.IsRunning() {
+			alert := e.createAlert(result.GetIdentifier(), windowsStoppedAppPoolsMetric, 0, model.AlertLevelCritical)
+			alert.FormattedValue = pool.State
+			alert.Message = fmt.Sprintf("IIS 应用程序池 %s 未运行（当前状态: %s），站点将返回 503", pool.Name, pool.State)
+			alerts = append(alerts, alert)
+		}
+
+		if pool.HasRequestsQueued() {
+			if alert := e.evaluateThreshold(result, windowsRequestsQueuedMetric, pool.RequestsQueued,
+				e.thresholds.RequestsQueuedWarning, e.thresholds.RequestsQueuedCritical); alert != nil {
+				alert.Message = fmt.Sprintf("应用程序池 %s %s", pool.Name, alert.Message)
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	return alerts
+}
+
+// evaluateThreshold evaluates a "higher is worse" metric against its thresholds.
+// A threshold of 0 disables that level.
+func (e *WindowsEvaluator) evaluateThreshold(
+	result *model.WindowsInspectionResult,
+	metricName string,
+	value, warning, critical float64,
+) *model.WindowsAlert {
+	return e.alertAt(result, metricName, value, config.ThresholdPair{Warning: warning, Critical: critical})
+}
+
+// alertAt returns the alert of a metric reaching threshold, or nil.
+func (e *WindowsEvaluator) alertAt(
+	result *model.WindowsInspectionResult,
+	metricName string,
+	value float64,
+	threshold config.ThresholdPair,
+) *model.WindowsAlert {
+	level := thresholdLevel(value, threshold)
+	if level == model.AlertLevelNormal {
+		return nil
+	}
+	return e.createAlert(result.GetIdentifier(), metricName, value, level)
+}
+
+// determineInstanceStatus determines overall status based on alerts.
+// Priority: Critical > Warning > Normal
+func (e *WindowsEvaluator) determineInstanceStatus(
+	alerts []*model.WindowsAlert,
+) model.WindowsInstanceStatus {
+	hasCritical := false
+	hasWarning := false
+
+	for _, alert := range alerts {
+		if alert.Level == model.AlertLevelCritical {
+			hasCritical = true
+		} else if alert.Level == model.AlertLevelWarning {
+			hasWarning = true
+		}
+	}
+
+	if hasCritical {
+		return model.WindowsStatusCritical
+	}
+	if hasWarning {
+		return model.WindowsStatusWarning
+	}
+	return model.WindowsStatusNormal
+}
+
+// createAlert creates a WindowsAlert with formatted message.
+func (e *WindowsEvaluator) createAlert(
+	identifier string,
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) *model.WindowsAlert {
+	displayName := metricName
+	if def, exists := e.metricDefs[metricName]; exists {
+		displayName = def.GetDisplayName()
+	} else if name, ok := windowsDerivedDisplayNames[metricName]; ok {
+		displayName = name
+	}
+
+	warningThreshold, criticalThreshold := e.getThresholds(metricName)
+
+	return &model.WindowsAlert{
+		Identifier:        identifier,
+		MetricName:        metricName,
+		MetricDisplayName: displayName,
+		CurrentValue:      currentValue,
+		FormattedValue:    e.formatValue(currentValue, metricName),
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		Level:             level,
+		Message:           e.generateAlertMessage(metricName, currentValue, level),
+	}
+}
+
+// windowsDerivedDisplayNames holds display names of metrics derived by the collector,
+// which have no definition in the metrics file.
+var windowsDerivedDisplayNames = map[string]string{
+	windowsStoppedServicesMetric: "服务未运行",
+	windowsStoppedAppPoolsMetric: "应用程序池未运行",
+}
+
+// formatValue formats metric value for display.
+// Service and application pool alerts replace it with the reported state.
+func (e *WindowsEvaluator) formatValue(value float64, metricName string) string {
+	switch metricName {
+	case windowsRequestsQueuedMetric:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// generateAlertMessage generates human-readable alert message.
+func (e *WindowsEvaluator) generateAlertMessage(
+	metricName string,
+	currentValue float64,
+	level model.AlertLevel,
+) string {
+	switch metricName {
+	case windowsStoppedServicesMetric:
+		return "服务未运行"
+	case windowsStoppedAppPoolsMetric:
+		return "IIS 应用程序池未运行"
+	case windowsRequestsQueuedMetric:
+		if level == model.AlertLevelCritical {
+			return fmt.Sprintf("请求队列长度 %.0f，已超过严重阈值 %.0f",
+				currentValue, e.thresholds.RequestsQueuedCritical)
+		}
+		return fmt.Sprintf("请求队列长度 %.0f，已超过警告阈值 %.0f",
+			currentValue, e.thresholds.RequestsQueuedWarning)
+	default:
+		return fmt.Sprintf("%s 指标异常，当前值: %.2f", metricName, currentValue)
+	}
+}
+
+// getThresholds returns warning and critical thresholds for a metric.
+// A stopped service or application pool is critical from the first occurrence.
+func (e *WindowsEvaluator) getThresholds(metricName string) (warning float64, critical float64) {
+	switch metricName {
+	case windowsRequestsQueuedMetric:
+		return e.thresholds.RequestsQueuedWarning, e.thresholds.RequestsQueuedCritical
+	default:
+		return 0, 0
+	}
+}
+
+// Thresholds returns the configured threshold pairs the evaluator alerts on.
+func (e *WindowsEvaluator) Thresholds() []ModuleThreshold {
+	return moduleThresholds(e.getThresholds, []string{windowsRequestsQueuedMetric})
+}
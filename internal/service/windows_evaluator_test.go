@@ -0,0 +1,153 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"inspection-tool/internal/config"
+	"inspection-tool/internal/model"
+)
+
+// =============================================================================
+// Test Helper Functions
+// =============================================================================
+
+// createTestWindowsEvaluator creates a Windows evaluator with default thresholds for testing.
+func createTestWindowsEvaluator() *WindowsEvaluator {
+	thresholds := &config.WindowsThresholds{
+		RequestsQueuedWarning:  100,
+		RequestsQueuedCritical: 1000,
+	}
+	metrics := []*model.WindowsMetricDefinition{
+		{Name: "windows_service_state", DisplayName: "服务状态"},
+		{Name: "windows_iis_requests_queued", DisplayName: "请求队列长度"},
+	}
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	return NewWindowsEvaluator(thresholds, metrics, tz, zerolog.Nop())
+}
+
+// createTestWindowsCollector creates a Windows collector with the W3SVC/WAS allowlist.
+func createTestWindowsCollector() *WindowsCollector {
+	cfg := &config.WindowsInspectionConfig{Enabled: true, Services: []string{"W3SVC", "WAS"}}
+	return NewWindowsCollector(cfg, nil, nil, nil, zerolog.Nop())
+}
+
+// =============================================================================
+// Collector Helper Tests
+// =============================================================================
+
+func TestWindowsCollector_SetObjectValue(t *testing.T) {
+	c := createTestWindowsCollector()
+	result := model.NewWindowsInspectionResult(model.NewWindowsInstance("win-01"))
+
+	// Allowlisted services are matched case-insensitively; others are ignored
+	if !c.setWindowsObjectValue(result, windowsServiceStateMetric, map[string]string{"name": "w3svc", "state": "running"}, 1) {
+		t.Error("w3svc should be collected")
+	}
+	if c.setWindowsObjectValue(result, windowsServiceStateMetric, map[string]string{"name": "spooler", "state": "stopped"}, 1) {
+		t.Error("spooler is not allowlisted and should be skipped")
+	}
+	// Series for states the service is not in are skipped
+	if c.setWindowsObjectValue(result, windowsServiceStateMetric, map[string]string{"name": "was", "state": "running"}, 0) {
+		t.Error("state series with value 0 should be skipped")
+	}
+	c.setWindowsObjectValue(result, windowsServiceStateMetric, map[string]string{"name": "was", "state": "stopped"}, 1)
+
+	c.setWindowsObjectValue(result, windowsAppPoolStateMetric, map[string]string{"app": "DefaultAppPool", "state": "Running"}, 1)
+	c.setWindowsObjectValue(result, windowsRequestsQueuedMetric, map[string]string{"app": "DefaultAppPool"}, 12)
+	c.setWindowsObjectValue(result, windowsAppPoolStateMetric, map[string]string{"app": "api", "state": "Stopped"}, 1)
+
+	c.extractFieldsFromMetrics(map[string]*model.WindowsInspectionResult{"win-01": result})
+
+	if len(result.Services) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(result.Services))
+	}
+	if result.StoppedServices != 1 || result.StoppedServiceNames()[0] != "was" {
+		t.Errorf("expected was to be the only stopped service, got %v", result.StoppedServiceNames())
+	}
+	if result.StoppedAppPools != 1 || result.StoppedAppPoolNames()[0] != "api" {
+		t.Errorf("expected api to be the only stopped app pool, got %v", result.StoppedAppPoolNames())
+	}
+	if result.RequestsQueued != 12 {
+		t.Errorf("expected 12 requests queued, got %v", result.RequestsQueued)
+	}
+	if mv := result.GetMetric(windowsStoppedServicesMetric); mv == nil || mv.StringValue != "was" {
+		t.Errorf("expected stopped services metric with was, got %+v", mv)
+	}
+}
+
+// =============================================================================
+// Evaluator Tests
+// =============================================================================
+
+func TestWindowsEvaluator_StoppedServicesAndAppPools(t *testing.T) {
+	e := createTestWindowsEvaluator()
+	result := model.NewWindowsInspectionResult(model.NewWindowsInstance("win-01"))
+	result.GetService("w3svc").State = "running"
+	result.GetService("was").State = "stopped"
+	result.GetAppPool("DefaultAppPool").State = "Running"
+	result.GetAppPool("api").State = "Stopped"
+
+	eval := e.Evaluate(result)
+
+	if eval.Status != model.WindowsStatusCritical {
+		t.Errorf("expected critical status, got %s", eval.Status)
+	}
+	if len(eval.Alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(eval.Alerts))
+	}
+	if eval.Alerts[0].MetricName != windowsStoppedServicesMetric || !strings.Contains(eval.Alerts[0].Message, "was") {
+		t.Errorf("unexpected service alert: %+v", eval.Alerts[0])
+	}
+	if eval.Alerts[0].FormattedValue != "stopped" {
+		t.Errorf("service alert should show the reported state, got %q", eval.Alerts[0].FormattedValue)
+	}
+	if eval.Alerts[1].MetricName != windowsStoppedAppPoolsMetric || !strings.Contains(eval.Alerts[1].Message, "api") {
+		t.Errorf("unexpected app pool alert: %+v", eval.Alerts[1])
+	}
+}
+
+func TestWindowsEvaluator_RequestsQueued(t *testing.T) {
+	tests := []struct {
+		name   string
+		queued float64
+		want   model.WindowsInstanceStatus
+	}{
+		{"below warning", 10, model.WindowsStatusNormal},
+		{"warning", 100, model.WindowsStatusWarning},
+		{"critical", 1500, model.WindowsStatusCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := createTestWindowsEvaluator()
+			result := model.NewWindowsInspectionResult(model.NewWindowsInstance("win-01"))
+			pool := result.GetAppPool("DefaultAppPool")
+			pool.State = "Running"
+			pool.RequestsQueued = tt.queued
+
+			eval := e.Evaluate(result)
+			if eval.Status != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, eval.Status)
+			}
+			if tt.want != model.WindowsStatusNormal && !strings.Contains(eval.Alerts[0].Message, "DefaultAppPool") {
+				t.Errorf("alert message should name the app pool, got %q", eval.Alerts[0].Message)
+			}
+		})
+	}
+}
+
+func TestWindowsEvaluator_AppPoolStateNotCollected(t *testing.T) {
+	e := createTestWindowsEvaluator()
+	result := model.NewWindowsInspectionResult(model.NewWindowsInstance("win-01"))
+	// Only the request queue was reported for this pool
+	result.GetAppPool("DefaultAppPool").RequestsQueued = 0
+
+	eval := e.Evaluate(result)
+	if eval.Status != model.WindowsStatusNormal || len(eval.Alerts) != 0 {
+		t.Errorf("app pool without state should not alert, got %s with %d alerts", eval.Status, len(eval.Alerts))
+	}
+}
@@ -0,0 +1,70 @@
+// Package testfixtures builds realistic inspection results for tests: hosts and MySQL, Redis,
+// Nginx and Tomcat instances with their metrics and threshold alerts. Results are assembled
+// with the model constructors, so instance statuses, alert lists and summaries stay consistent
+// whatever the test adds. Report writers, new modules and integrations consuming the models
+// share these fixtures instead of hand-writing result structs.
+package testfixtures
+
+import (
+	"fmt"
+	"time"
+
+	"inspection-tool/internal/model"
+)
+
+// Thresholds of all fixture alerts. Alerted metrics are percentages: a value at or above
+// CriticalThreshold raises a critical alert, any other alerted value a warning.
+const (
+	WarningThreshold  = 70.0
+	CriticalThreshold = 90.0
+)
+
+const (
+	// Duration is the inspection duration of all fixture results.
+	Duration = 5 * time.Second
+	// Version is the tool version of all fixture results.
+	Version = "1.0.0-test"
+)
+
+// InspectionTime is the inspection time of all fixture results (2025-12-13 10:00 Asia/Shanghai).
+var InspectionTime = time.Date(2025, 12, 13, 10, 0, 0, 0, time.FixedZone("CST", 8*60*60))
+
+// displayNames holds the display names of the fixture metrics.
+var displayNames = map[string]string{
+	"cpu_usage":                "CPU利用率",
+	"memory_usage":             "内存利用率",
+	"disk_usage_max":           "磁盘最大利用率",
+	"connection_usage":         "连接使用率",
+	"tomcat_jvm_heap_usage":    "JVM 堆内存使用率",
+	"tomcat_thread_pool_usage": "线程池使用率",
+}
+
+// DisplayName returns the display name of a fixture metric, or the metric name when unknown.
+func DisplayName(metricName string) string {
+	if name, ok := displayNames[metricName]; ok {
+		return name
+	}
+	return metricName
+}
+
+// alertLevel returns the alert level of an alerted value.
+func alertLevel(value float64) model.AlertLevel {
+	if value >= CriticalThreshold {
+		return model.AlertLevelCritical
+	}
+	return model.AlertLevelWarning
+}
+
+// alertMessage returns the alert message of an alerted value,
+// e.g. "CPU利用率 75.0% 超过警告阈值 70.0%".
+func alertMessage(metricName string, value float64) string {
+	if alertLevel(value) == model.AlertLevelCritical {
+		return fmt.Sprintf("%s %s 超过严重阈值 %s", DisplayName(metricName), percent(value), percent(CriticalThreshold))
+	}
+	return fmt.Sprintf("%s %s 超过警告阈值 %s", DisplayName(metricName), percent(value), percent(WarningThreshold))
+}
+
+// percent formats a percentage value, e.g. "75.0%".
+func percent(value float64) string {
+	return fmt.Sprintf("%.1f%%", value)
+}
@@ -0,0 +1,60 @@
+package testfixtures
+
+import (
+	"testing"
+
+	"inspection-tool/internal/model"
+)
+
+func TestDefaultInspectionResult(t *testing.T) {
+	result := DefaultInspectionResult()
+
+	if s := result.Summary; s.TotalHosts != 3 || s.NormalHosts != 1 || s.WarningHosts != 1 || s.CriticalHosts != 1 {
+		t.Errorf("summary = %+v, want 1 normal, 1 warning and 1 critical host", s)
+	}
+	if s := result.AlertSummary; s.TotalAlerts != 2 || s.WarningCount != 1 || s.CriticalCount != 1 {
+		t.Errorf("alert summary = %+v, want 1 warning and 1 critical alert", s)
+	}
+	if result.Duration != Duration || result.Version != Version {
+		t.Errorf("duration / version = %v / %q", result.Duration, result.Version)
+	}
+
+	alert := result.Alerts[0]
+	if alert.Hostname != "host-2" || alert.Level != model.AlertLevelWarning || alert.Message != "CPU利用率 75.0% 超过警告阈值 70.0%" {
+		t.Errorf("first alert = %+v, want the host-2 CPU warning", alert)
+	}
+	if metric := result.Hosts[2].GetMetric("memory_usage"); metric.RawValue != 95 || metric.Status != model.MetricStatusCritical {
+		t.Errorf("host-3 memory = %+v, want the critical alerted value", metric)
+	}
+}
+
+func TestAddHostAlert_KeepsLabels(t *testing.T) {
+	host := NewHost("host-1", "192.168.1.1")
+	alert := AddHostAlert(host, "disk_usage:/", 92)
+
+	if alert.Level != model.AlertLevelCritical || host.Status != model.HostStatusCritical {
+		t.Errorf("alert level / host status = %s / %s, want critical", alert.Level, host.Status)
+	}
+	if metric := host.GetMetric("disk_usage:/"); metric.Labels["path"] != "/" {
+		t.Errorf("disk metric labels = %v, want the mount point kept", metric.Labels)
+	}
+}
+
+func TestDefaultModuleResults(t *testing.T) {
+	if s := DefaultMySQLInspectionResults().Summary; s.TotalInstances != 3 || s.WarningInstances != 1 || s.CriticalInstances != 1 {
+		t.Errorf("MySQL summary = %+v", s)
+	}
+	if s := DefaultRedisInspectionResults().Summary; s.TotalInstances != 2 || s.WarningInstances != 1 {
+		t.Errorf("Redis summary = %+v", s)
+	}
+	nginx := DefaultNginxInspectionResults()
+	if s := nginx.Summary; s.TotalInstances != 2 || s.CriticalInstances != 1 {
+		t.Errorf("Nginx summary = %+v", s)
+	}
+	if alert := nginx.Alerts[0]; alert.Identifier != "web-2:80" || alert.Level != model.AlertLevelCritical {
+		t.Errorf("Nginx alert = %+v, want the web-2 critical alert", alert)
+	}
+	if s := DefaultTomcatInspectionResults().Summary; s.TotalInstances != 2 || s.WarningInstances != 1 {
+		t.Errorf("Tomcat summary = %+v", s)
+	}
+}
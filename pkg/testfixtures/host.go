@@ -0,0 +1,84 @@
+package testfixtures
+
+import (
+	"inspection-tool/internal/model"
+)
+
+// NewHost returns a Linux host with normal CPU, memory and disk usage.
+func NewHost(hostname, ip string) *model.HostResult {
+	host := model.NewHostResult(&model.HostMeta{
+		Hostname:      hostname,
+		IP:            ip,
+		OS:            "Linux",
+		OSVersion:     "CentOS 7.9",
+		KernelVersion: "3.10.0-1160.el7",
+		CPUCores:      4,
+		MemoryTotal:   16 * 1024 * 1024 * 1024,
+	})
+	host.CollectedAt = InspectionTime
+	SetHostMetric(host, "cpu_usage", 35.0, nil)
+	SetHostMetric(host, "memory_usage", 50.0, nil)
+	SetHostMetric(host, "disk_usage_max", 45.0, nil)
+	SetHostMetric(host, "disk_usage:/", 45.0, map[string]string{"path": "/"})
+	return host
+}
+
+// AddHostAlert sets a host metric to value and raises the matching alert on the host,
+// updating the host status. It returns the alert.
+func AddHostAlert(host *model.HostResult, metricName string, value float64) *model.Alert {
+	alert := model.NewAlert(host.Hostname, metricName, value, alertLevel(value))
+	alert.MetricDisplayName = DisplayName(metricName)
+	alert.FormattedValue = percent(value)
+	alert.WarningThreshold = WarningThreshold
+	alert.CriticalThreshold = CriticalThreshold
+	alert.Message = alertMessage(metricName, value)
+
+	var labels map[string]string
+	if metric := host.GetMetric(metricName); metric != nil {
+		labels = metric.Labels
+	}
+	metric := SetHostMetric(host, metricName, value, labels)
+	metric.Status = model.MetricStatusWarning
+	if alert.IsCritical() {
+		metric.Status = model.MetricStatusCritical
+	}
+	host.AddAlert(alert)
+	return alert
+}
+
+// NewInspectionResult returns the host inspection result of hosts, with their alerts and
+// summaries.
+func NewInspectionResult(hosts ...*model.HostResult) *model.InspectionResult {
+	result := model.NewInspectionResult(InspectionTime)
+	for _, host := range hosts {
+		result.AddHost(host)
+	}
+	result.Finalize(InspectionTime.Add(Duration))
+	result.Version = Version
+	return result
+}
+
+// DefaultInspectionResult returns three hosts: host-1 is normal, host-2 has a CPU warning
+// and host-3 a critical memory alert.
+func DefaultInspectionResult() *model.InspectionResult {
+	host2 := NewHost("host-2", "192.168.1.2")
+	AddHostAlert(host2, "cpu_usage", 75.0)
+	host3 := NewHost("host-3", "192.168.1.3")
+	AddHostAlert(host3, "memory_usage", 95.0)
+	return NewInspectionResult(NewHost("host-1", "192.168.1.1"), host2, host3)
+}
+
+// SetHostMetric sets a normal percentage metric on host and returns it. Labels are those of
+// the metric, such as the path of a per-partition disk metric.
+func SetHostMetric(host *model.HostResult, name string, value float64, labels map[string]string) *model.MetricValue {
+	metric := &model.MetricValue{
+		Name:           name,
+		RawValue:       value,
+		FormattedValue: percent(value),
+		Status:         model.MetricStatusNormal,
+		Labels:         labels,
+		Timestamp:      InspectionTime.Unix(),
+	}
+	host.SetMetric(metric)
+	return metric
+}
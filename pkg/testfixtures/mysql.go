@@ -0,0 +1,61 @@
+package testfixtures
+
+import (
+	"inspection-tool/internal/model"
+)
+
+// NewMySQLResult returns a normal MySQL 8.0 MGR member listening on address ("IP:Port").
+func NewMySQLResult(address string) *model.MySQLInspectionResult {
+	instance := model.NewMySQLInstanceWithClusterMode(address, model.ClusterModeMGR)
+	instance.SetVersion("8.0.39", "8.0.39")
+	result := model.NewMySQLInspectionResult(instance)
+	result.ConnectionStatus = true
+	result.MaxConnections = 1000
+	result.CurrentConnections = 100
+	result.QPS = 1520.5
+	result.TPS = 210.3
+	result.ThreadsRunning = 4
+	result.BinlogEnabled = true
+	result.MGRMemberCount = 3
+	result.MGRStateOnline = true
+	result.Uptime = 30 * 24 * 3600
+	result.CollectedAt = InspectionTime
+	return result
+}
+
+// AddMySQLAlert raises an alert of a percentage metric with value on the instance,
+// updating its status. It returns the alert.
+func AddMySQLAlert(result *model.MySQLInspectionResult, metricName string, value float64) *model.MySQLAlert {
+	alert := model.NewMySQLAlert(result.GetAddress(), metricName, value, alertLevel(value))
+	alert.MetricDisplayName = DisplayName(metricName)
+	alert.FormattedValue = percent(value)
+	alert.WarningThreshold = WarningThreshold
+	alert.CriticalThreshold = CriticalThreshold
+	alert.Message = alertMessage(metricName, value)
+	result.AddAlert(alert)
+	return alert
+}
+
+// NewMySQLInspectionResults returns the MySQL inspection results of the instances, with
+// their alerts and summaries.
+func NewMySQLInspectionResults(results ...*model.MySQLInspectionResult) *model.MySQLInspectionResults {
+	inspection := model.NewMySQLInspectionResults(InspectionTime)
+	for _, result := range results {
+		inspection.AddResult(result)
+	}
+	inspection.Finalize(InspectionTime.Add(Duration))
+	inspection.Version = Version
+	return inspection
+}
+
+// DefaultMySQLInspectionResults returns three MGR members: 172.18.182.91 is normal,
+// 172.18.182.92 has a connection usage warning and 172.18.182.93 a critical one.
+func DefaultMySQLInspectionResults() *model.MySQLInspectionResults {
+	warning := NewMySQLResult("172.18.182.92:3306")
+	warning.CurrentConnections = 800
+	AddMySQLAlert(warning, "connection_usage", 80.0)
+	critical := NewMySQLResult("172.18.182.93:3306")
+	critical.CurrentConnections = 950
+	AddMySQLAlert(critical, "connection_usage", 95.0)
+	return NewMySQLInspectionResults(NewMySQLResult("172.18.182.91:3306"), warning, critical)
+}
@@ -0,0 +1,60 @@
+package testfixtures
+
+import (
+	"inspection-tool/internal/model"
+)
+
+// NewNginxResult returns a normal Nginx 1.24 binary deployment on hostname.
+func NewNginxResult(hostname, ip string, port int) *model.NginxInspectionResult {
+	instance := model.NewNginxInstance(hostname, port)
+	instance.IP = ip
+	instance.Version = "1.24.0"
+	instance.InstallPath = "/usr/local/nginx"
+	instance.ErrorLogPath = "/usr/local/nginx/logs/error.log"
+	result := model.NewNginxInspectionResult(instance)
+	result.Up = true
+	result.ActiveConnections = 400
+	result.WorkerProcesses = 4
+	result.WorkerConnections = 1024
+	result.CalculateConnectionUsagePercent()
+	result.ErrorPage4xxConfigured = true
+	result.ErrorPage5xxConfigured = true
+	result.NonRootUser = true
+	result.CollectedAt = InspectionTime
+	return result
+}
+
+// AddNginxAlert raises an alert of a percentage metric with value on the instance,
+// updating its status. It returns the alert.
+func AddNginxAlert(result *model.NginxInspectionResult, metricName string, value float64) *model.NginxAlert {
+	alert := model.NewNginxAlert(result.GetIdentifier(), metricName, value, alertLevel(value))
+	alert.MetricDisplayName = DisplayName(metricName)
+	alert.FormattedValue = percent(value)
+	alert.WarningThreshold = WarningThreshold
+	alert.CriticalThreshold = CriticalThreshold
+	alert.Message = alertMessage(metricName, value)
+	result.AddAlert(alert)
+	return alert
+}
+
+// NewNginxInspectionResults returns the Nginx inspection results of the instances, with
+// their alerts and summaries.
+func NewNginxInspectionResults(results ...*model.NginxInspectionResult) *model.NginxInspectionResults {
+	inspection := model.NewNginxInspectionResults(InspectionTime)
+	for _, result := range results {
+		inspection.AddResult(result)
+	}
+	inspection.Finalize(InspectionTime.Add(Duration))
+	inspection.Version = Version
+	return inspection
+}
+
+// DefaultNginxInspectionResults returns two instances: web-1 is normal and web-2 has a
+// critical connection usage alert.
+func DefaultNginxInspectionResults() *model.NginxInspectionResults {
+	critical := NewNginxResult("web-2", "192.168.2.2", 80)
+	critical.ActiveConnections = 3900
+	critical.CalculateConnectionUsagePercent()
+	AddNginxAlert(critical, "connection_usage", critical.ConnectionUsagePercent)
+	return NewNginxInspectionResults(NewNginxResult("web-1", "192.168.2.1", 80), critical)
+}
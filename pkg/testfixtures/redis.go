@@ -0,0 +1,64 @@
+package testfixtures
+
+import (
+	"inspection-tool/internal/model"
+)
+
+// NewRedisResult returns a normal Redis 7.0 cluster node listening on address ("IP:Port").
+func NewRedisResult(address string, role model.RedisRole) *model.RedisInspectionResult {
+	instance := model.NewRedisInstanceWithRole(address, role)
+	instance.SetVersion("7.0.15")
+	instance.SetClusterEnabled(true)
+	result := model.NewRedisInspectionResult(instance)
+	result.ConnectionStatus = true
+	result.ClusterEnabled = true
+	result.ClusterState = "ok"
+	result.MaxClients = 10000
+	result.ConnectedClients = 200
+	result.UsedMemory = 1024 * 1024 * 1024
+	result.MaxMemory = 4 * 1024 * 1024 * 1024
+	result.MemFragmentationRatio = 1.1
+	result.KeyspaceHitRatio = 98.5
+	result.Uptime = 30 * 24 * 3600
+	if role == model.RedisRoleMaster {
+		result.ConnectedSlaves = 1
+	} else {
+		result.MasterLinkStatus = true
+	}
+	result.CollectedAt = InspectionTime
+	return result
+}
+
+// AddRedisAlert raises an alert of a percentage metric with value on the instance,
+// updating its status. It returns the alert.
+func AddRedisAlert(result *model.RedisInspectionResult, metricName string, value float64) *model.RedisAlert {
+	alert := model.NewRedisAlert(result.GetAddress(), metricName, value, alertLevel(value))
+	alert.MetricDisplayName = DisplayName(metricName)
+	alert.FormattedValue = percent(value)
+	alert.WarningThreshold = WarningThreshold
+	alert.CriticalThreshold = CriticalThreshold
+	alert.Message = alertMessage(metricName, value)
+	result.AddAlert(alert)
+	return alert
+}
+
+// NewRedisInspectionResults returns the Redis inspection results of the instances, with
+// their alerts and summaries.
+func NewRedisInspectionResults(results ...*model.RedisInspectionResult) *model.RedisInspectionResults {
+	inspection := model.NewRedisInspectionResults(InspectionTime)
+	for _, result := range results {
+		inspection.AddResult(result)
+	}
+	inspection.Finalize(InspectionTime.Add(Duration))
+	inspection.Version = Version
+	return inspection
+}
+
+// DefaultRedisInspectionResults returns a master and its replica on 192.18.102.2: the master
+// is normal and the replica has a memory usage warning.
+func DefaultRedisInspectionResults() *model.RedisInspectionResults {
+	replica := NewRedisResult("192.18.102.2:7001", model.RedisRoleSlave)
+	replica.MasterPort = 7000
+	AddRedisAlert(replica, "memory_usage", 80.0)
+	return NewRedisInspectionResults(NewRedisResult("192.18.102.2:7000", model.RedisRoleMaster), replica)
+}
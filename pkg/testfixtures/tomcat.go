@@ -0,0 +1,65 @@
+package testfixtures
+
+import (
+	"inspection-tool/internal/model"
+)
+
+// NewTomcatResult returns a normal Tomcat 9 binary deployment on hostname.
+func NewTomcatResult(hostname, ip string, port int) *model.TomcatInspectionResult {
+	instance := model.NewTomcatInstance(hostname, port)
+	instance.IP = ip
+	instance.Version = "9.0.85"
+	instance.InstallPath = "/opt/tomcat"
+	instance.LogPath = "/opt/tomcat/logs"
+	result := model.NewTomcatInspectionResult(instance)
+	result.Up = true
+	result.Connections = 120
+	result.UptimeSeconds = 30 * 24 * 3600
+	result.UptimeFormatted = "30天0小时"
+	result.NonRootUser = true
+	result.HeapUsagePercent = 45.0
+	result.GCPauseAvgMs = 12.5
+	result.ThreadPoolUsagePercent = 30.0
+	result.CollectedAt = InspectionTime
+	return result
+}
+
+// AddTomcatAlert raises an alert of a percentage metric with value on the instance,
+// updating its status (the model leaves Tomcat statuses to the evaluator). It returns the
+// alert.
+func AddTomcatAlert(result *model.TomcatInspectionResult, metricName string, value float64) *model.TomcatAlert {
+	alert := model.NewTomcatAlert(result.GetIdentifier(), metricName, value, alertLevel(value))
+	alert.MetricDisplayName = DisplayName(metricName)
+	alert.FormattedValue = percent(value)
+	alert.WarningThreshold = WarningThreshold
+	alert.CriticalThreshold = CriticalThreshold
+	alert.Message = alertMessage(metricName, value)
+	result.AddAlert(alert)
+	if alert.IsCritical() {
+		result.Status = model.TomcatStatusCritical
+	} else if result.Status != model.TomcatStatusCritical {
+		result.Status = model.TomcatStatusWarning
+	}
+	return alert
+}
+
+// NewTomcatInspectionResults returns the Tomcat inspection results of the instances, with
+// their alerts and summaries.
+func NewTomcatInspectionResults(results ...*model.TomcatInspectionResult) *model.TomcatInspectionResults {
+	inspection := model.NewTomcatInspectionResults(InspectionTime)
+	for _, result := range results {
+		inspection.AddResult(result)
+	}
+	inspection.Finalize(InspectionTime.Add(Duration))
+	inspection.Version = Version
+	return inspection
+}
+
+// DefaultTomcatInspectionResults returns two instances: app-1 is normal and app-2 has a
+// JVM heap usage warning.
+func DefaultTomcatInspectionResults() *model.TomcatInspectionResults {
+	warning := NewTomcatResult("app-2", "192.168.3.2", 8080)
+	warning.HeapUsagePercent = 85.0
+	AddTomcatAlert(warning, "tomcat_jvm_heap_usage", warning.HeapUsagePercent)
+	return NewTomcatInspectionResults(NewTomcatResult("app-1", "192.168.3.1", 8080), warning)
+}